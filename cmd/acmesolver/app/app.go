@@ -29,6 +29,8 @@ import (
 
 func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 	s := new(solver.HTTP01Solver)
+	var challenges []string
+	var shutdownDrainPeriod time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "acmesolver",
@@ -38,11 +40,30 @@ func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 			rootCtx = logf.NewContext(rootCtx, logf.Log, "acmesolver")
 			log := logf.FromContext(rootCtx)
 
+			for _, c := range challenges {
+				ch, err := solver.ParseChallenge(c)
+				if err != nil {
+					return err
+				}
+				s.Challenges = append(s.Challenges, ch)
+			}
+
 			completedCh := make(chan struct{})
 			go func() {
 				defer close(completedCh)
 				<-stopCh
-				// allow a timeout for graceful shutdown
+
+				if shutdownDrainPeriod > 0 {
+					// keep serving challenges while load balancers move
+					// traffic to a replacement solver
+					log.Info("draining acmesolver server", "period", shutdownDrainPeriod)
+					s.Drain()
+					time.Sleep(shutdownDrainPeriod)
+				}
+
+				// allow a timeout for graceful shutdown; this must match the
+				// shutdown timeout assumed by the controller when setting the
+				// termination grace period of solver pods
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 
@@ -65,6 +86,16 @@ func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 	cmd.Flags().StringVar(&s.Domain, "domain", "", "the domain name to verify")
 	cmd.Flags().StringVar(&s.Token, "token", "", "the challenge token to verify against")
 	cmd.Flags().StringVar(&s.Key, "key", "", "the challenge key to respond with")
+	cmd.Flags().StringArrayVar(&challenges, "challenge", nil, "an additional challenge to respond to, in the form "+
+		"'domain,token,key'. May be specified multiple times")
+	cmd.Flags().StringVar(&s.ChallengesFile, "challenges-file", "", "a file listing additional challenges to respond to, "+
+		"one per line in the form 'domain,token,key'. The file is re-read on each request")
+	cmd.Flags().DurationVar(&s.ReadTimeout, "read-timeout", 0, "the maximum duration for reading an entire request, "+
+		"including its body. Zero means no timeout")
+	cmd.Flags().DurationVar(&s.ReadHeaderTimeout, "read-header-timeout", 0, "the maximum duration for reading the headers "+
+		"of a request. Zero means the read timeout is used")
+	cmd.Flags().DurationVar(&shutdownDrainPeriod, "shutdown-drain-period", 0, "the period for which challenges continue to be "+
+		"served, while health checks fail, before the server shuts down")
 
 	return cmd
 }
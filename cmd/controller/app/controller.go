@@ -25,7 +25,12 @@ import (
 	"os"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/go-logr/logr"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
@@ -36,9 +41,12 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/controller/app/options"
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
+	"github.com/cert-manager/cert-manager/internal/controller/eventexport"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificateauthorities"
 	"github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -66,9 +74,55 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		return err
 	}
 
+	if ctx.EventExporter != nil {
+		g.Go(func() error {
+			log.V(logf.InfoLevel).Info("starting event exporter", "sink", opts.EventExportSink)
+			ctx.EventExporter.Run(rootCtx)
+			return nil
+		})
+	}
+
+	if len(opts.LogLevelsConfigMap) > 0 {
+		namespace, name, err := opts.LogLevelsConfigMapRef()
+		if err != nil {
+			return err
+		}
+		startLogLevelsWatcher(rootCtx, log, ctx.Client, namespace, name)
+	}
+
+	if ctx.IssuancePause.Paused() {
+		log.V(logf.InfoLevel).Info("issuance is paused, new ACME orders will not be submitted")
+	}
+	if len(opts.IssuancePauseConfigMap) > 0 {
+		namespace, name, err := opts.IssuancePauseConfigMapRef()
+		if err != nil {
+			return err
+		}
+		startIssuancePauseWatcher(rootCtx, log, ctx.Client, ctx.IssuancePause, namespace, name)
+	}
+
+	if opts.SelfTest {
+		g.Go(func() error {
+			runSelfTest(rootCtx, log, ctx)
+			return nil
+		})
+	}
+
 	enabledControllers := opts.EnabledControllers()
 	log.Info(fmt.Sprintf("enabled controllers: %s", enabledControllers.List()))
 
+	if opts.EnableResourceStateMetrics {
+		ctx.Metrics.SetupResourceStateCollector(
+			ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
+			ctx.SharedInformerFactory.Acme().V1().Orders().Lister(),
+			ctx.SharedInformerFactory.Acme().V1().Challenges().Lister(),
+		)
+	}
+
+	if opts.EnableChallengeQueueEndpoint {
+		ctx.Metrics.SetupChallengeQueue()
+	}
+
 	// Start metrics server
 	metricsLn, err := net.Listen("tcp", opts.MetricsListenAddress)
 	if err != nil {
@@ -183,8 +237,9 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 			continue
 		}
 
-		// don't run clusterissuers controller if scoped to a single namespace
-		if ctx.Namespace != "" && n == clusterissuers.ControllerName {
+		// don't run controllers of cluster scoped resources if scoped to a
+		// single namespace
+		if ctx.Namespace != "" && (n == clusterissuers.ControllerName || n == certificateauthorities.ControllerName) {
 			log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to a single namespace")
 			continue
 		}
@@ -261,25 +316,50 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		return nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceLimitsMemory: %w", err)
 	}
 
+	var secretAccessServiceAccountNamespace, secretAccessServiceAccountName string
+	if len(opts.SecretAccessClusterRole) > 0 {
+		secretAccessServiceAccountNamespace, secretAccessServiceAccountName, err = opts.SecretAccessServiceAccountRef()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	eventExporter, err := buildEventExporter(log, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
 		Kubeconfig:         opts.Kubeconfig,
+		KubeContext:        opts.KubeContext,
 		KubernetesAPIQPS:   opts.KubernetesAPIQPS,
 		KubernetesAPIBurst: opts.KubernetesAPIBurst,
 		APIServerHost:      opts.APIServerHost,
 
-		Namespace: opts.Namespace,
+		Namespace:   opts.Namespace,
+		OwnedBy:     opts.OwnedBy,
+		IssuerClass: opts.IssuerClass,
+
+		IssuancePause: controller.NewIssuancePause(opts.IssuancePaused),
 
 		Clock:   clock.RealClock{},
 		Metrics: metrics.New(log, clock.RealClock{}),
 
+		EventExporter: eventExporter,
+
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
 			HTTP01SolverResourceRequestMemory: http01SolverResourceRequestMemory,
 			HTTP01SolverResourceLimitsCPU:     http01SolverResourceLimitsCPU,
 			HTTP01SolverResourceLimitsMemory:  http01SolverResourceLimitsMemory,
 			HTTP01SolverImage:                 opts.ACMEHTTP01SolverImage,
+
+			HTTP01SolverRunAsNonRoot:           opts.ACMEHTTP01SolverRunAsNonRoot,
+			HTTP01SolverSeccompProfileType:     corev1.SeccompProfileType(opts.ACMEHTTP01SolverSeccompProfile),
+			HTTP01SolverReadOnlyRootFilesystem: opts.ACMEHTTP01SolverReadOnlyRootFilesystem,
+
 			// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 			HTTP01SolverNameservers: opts.ACMEHTTP01SolverNameservers,
 
@@ -288,15 +368,32 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			DNS01CheckAuthoritative: !opts.DNS01RecursiveNameserversOnly,
 
 			AccountRegistry: acmeAccountRegistry,
+
+			UserAgentClusterID: opts.UserAgentClusterID,
+			RedactUserAgent:    opts.RedactUserAgent,
+
+			HTTPClient: accounts.HTTPClientOptions{
+				Timeout:   opts.ACMEHTTPTimeout,
+				KeepAlive: opts.ACMEHTTPKeepAlive,
+				ProxyURL:  opts.ACMEHTTPProxy,
+			},
+
+			OrderDiagnosticsTTL:           opts.ACMEOrderDiagnosticsTTL,
+			AuthorizationFetchParallelism: opts.ACMEAuthorizationFetchParallelism,
+
+			DirectoryMetaCache: acmecl.NewDirectoryMetaCache(opts.ACMEDirectoryCacheTTL),
 		},
 
 		SchedulerOptions: controller.SchedulerOptions{
 			MaxConcurrentChallenges: opts.MaxConcurrentChallenges,
+			Policy:                  opts.ChallengeSchedulingPolicy,
+			Workers:                 opts.ChallengeWorkers,
 		},
 
 		IssuerOptions: controller.IssuerOptions{
 			ClusterIssuerAmbientCredentials: opts.ClusterIssuerAmbientCredentials,
 			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
+			KubernetesCSRAllowedSignerNames: opts.KubernetesCSRAllowedSignerNames,
 			ClusterResourceNamespace:        opts.ClusterResourceNamespace,
 		},
 
@@ -311,6 +408,13 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
 			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
 		},
+
+		SecretAccessOptions: controller.SecretAccessOptions{
+			ClusterRoleName:         opts.SecretAccessClusterRole,
+			ServiceAccountNamespace: secretAccessServiceAccountNamespace,
+			ServiceAccountName:      secretAccessServiceAccountName,
+			NamespaceSelector:       opts.SecretAccessNamespaceSelector,
+		},
 	})
 	if err != nil {
 		return nil, err
@@ -362,3 +466,38 @@ func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, l
 
 	return nil
 }
+
+// buildEventExporter returns the exporter for the configured event export
+// sink, or nil if Events should not be exported.
+func buildEventExporter(log logr.Logger, opts *options.ControllerOptions) (*eventexport.Exporter, error) {
+	var sink eventexport.Sink
+	switch opts.EventExportSink {
+	case "":
+		return nil, nil
+	case eventexport.SinkWebhook:
+		sink = eventexport.NewWebhookSink(opts.EventExportURL, &http.Client{Timeout: 10 * time.Second})
+	case eventexport.SinkSlack:
+		sink = eventexport.NewSlackSink(opts.EventExportURL, &http.Client{Timeout: 10 * time.Second})
+	case eventexport.SinkEventBridge:
+		config := aws.NewConfig()
+		if len(opts.EventExportEventBridgeRegion) > 0 {
+			config = config.WithRegion(opts.EventExportEventBridgeRegion)
+		}
+		sess, err := session.NewSessionWithOptions(session.Options{
+			Config:            *config,
+			SharedConfigState: session.SharedConfigEnable,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating aws session for the event exporter: %w", err)
+		}
+		sink = eventexport.NewEventBridgeSink(eventbridge.New(sess), opts.EventExportEventBridgeBus)
+	default:
+		return nil, fmt.Errorf("unknown event export sink %q", opts.EventExportSink)
+	}
+
+	return eventexport.New(log, sink, eventexport.Options{
+		Reasons:       opts.EventExportReasons,
+		BatchSize:     opts.EventExportBatchSize,
+		BatchInterval: opts.EventExportBatchInterval,
+	}), nil
+}
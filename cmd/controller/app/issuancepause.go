@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// issuancePausedKey is the key of the issuance pause ConfigMap which pauses
+// issuance while set to "true".
+const issuancePausedKey = "paused"
+
+// startIssuancePauseWatcher watches the named ConfigMap and pauses issuance
+// while its 'paused' key is set to "true". Deleting the ConfigMap resumes
+// issuance, unless it was paused when the controller was started.
+func startIssuancePauseWatcher(ctx context.Context, log logr.Logger, client kubernetes.Interface, pause *controller.IssuancePause, namespace, name string) {
+	log = log.WithName("issuance-pause").WithValues("configmap", namespace+"/"+name)
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 10*time.Hour,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+	informer := factory.Core().V1().ConfigMaps().Informer()
+
+	var current bool
+	set := func(paused bool) {
+		if current == paused {
+			return
+		}
+		current = paused
+		pause.SetPaused(paused)
+		log.V(logf.InfoLevel).Info("updated issuance pause", "paused", paused, "effective", pause.Paused())
+	}
+	apply := func(obj interface{}) {
+		cm, ok := obj.(*corev1.ConfigMap)
+		if !ok {
+			return
+		}
+		set(cm.Data[issuancePausedKey] == "true")
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    apply,
+		UpdateFunc: func(_, obj interface{}) { apply(obj) },
+		DeleteFunc: func(interface{}) { set(false) },
+	})
+
+	factory.Start(ctx.Done())
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// startLogLevelsWatcher watches the named ConfigMap and configures the
+// verbosity of the controllers' loggers from its data whenever it changes.
+// Each key of the ConfigMap is the name of a controller, e.g. 'orders', and
+// each value the log level to use for it. Deleting the ConfigMap restores
+// the global log level for all controllers.
+func startLogLevelsWatcher(ctx context.Context, log logr.Logger, client kubernetes.Interface, namespace, name string) {
+	log = log.WithName("log-levels").WithValues("configmap", namespace+"/"+name)
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 10*time.Hour,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+	informer := factory.Core().V1().ConfigMaps().Informer()
+
+	apply := func(obj interface{}) {
+		cm, ok := obj.(*corev1.ConfigMap)
+		if !ok {
+			return
+		}
+		levels := make(map[string]int, len(cm.Data))
+		for controller, value := range cm.Data {
+			level, err := strconv.Atoi(value)
+			if err != nil || level < 0 {
+				log.Error(err, "ignoring invalid log level", "controller", controller, "level", value)
+				continue
+			}
+			levels[controller] = level
+		}
+		logf.SetLevels(levels)
+		log.V(logf.InfoLevel).Info("updated controller log levels", "levels", levels)
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    apply,
+		UpdateFunc: func(_, obj interface{}) { apply(obj) },
+		DeleteFunc: func(interface{}) {
+			logf.SetLevels(nil)
+			log.V(logf.InfoLevel).Info("reset controller log levels")
+		},
+	})
+
+	factory.Start(ctx.Done())
+}
@@ -20,32 +20,43 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
 
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
+	"github.com/cert-manager/cert-manager/internal/controller/eventexport"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cm "github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/controller"
 	challengescontroller "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
 	shimgatewaycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
 	shimingresscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/ingresses"
+	certificateauthoritiescontroller "github.com/cert-manager/cert-manager/pkg/controller/certificateauthorities"
 	cracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/acme"
 	crapprovercontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/approver"
 	crcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/ca"
+	crkubernetescsrcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/kubernetescsr"
 	crselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/venafi"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/keymanager"
 	certificatesmetricscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificates/metrics"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/ocspstapling"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/readiness"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revisionmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/trigger"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/verification"
 	csracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/acme"
 	csrcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/ca"
 	csrselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/selfsigned"
@@ -53,6 +64,7 @@ import (
 	csrvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/venafi"
 	clusterissuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	issuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/issuers"
+	secretaccesscontroller "github.com/cert-manager/cert-manager/pkg/controller/secretaccess"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -61,6 +73,7 @@ import (
 type ControllerOptions struct {
 	APIServerHost      string
 	Kubeconfig         string
+	KubeContext        string
 	KubernetesAPIQPS   float32
 	KubernetesAPIBurst int
 
@@ -80,12 +93,42 @@ type ControllerOptions struct {
 	ACMEHTTP01SolverResourceRequestMemory string
 	ACMEHTTP01SolverResourceLimitsCPU     string
 	ACMEHTTP01SolverResourceLimitsMemory  string
+	// ACMEHTTP01SolverRunAsNonRoot, ACMEHTTP01SolverSeccompProfile and
+	// ACMEHTTP01SolverReadOnlyRootFilesystem configure the security context
+	// of all HTTP01 solver pods.
+	ACMEHTTP01SolverRunAsNonRoot           bool
+	ACMEHTTP01SolverSeccompProfile         string
+	ACMEHTTP01SolverReadOnlyRootFilesystem bool
 	// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 	ACMEHTTP01SolverNameservers []string
 
+	// ACMEHTTPTimeout, ACMEHTTPKeepAlive and ACMEHTTPProxy configure the HTTP
+	// client used to communicate with ACME servers. They can be overridden
+	// by the httpClient field of an ACME issuer.
+	ACMEHTTPTimeout   time.Duration
+	ACMEHTTPKeepAlive time.Duration
+	ACMEHTTPProxy     string
+
+	// ACMEOrderDiagnosticsTTL is how long the diagnostics of a failed Order
+	// are retained for. If zero, no diagnostics are retained.
+	ACMEOrderDiagnosticsTTL time.Duration
+
+	// ACMEDirectoryCacheTTL is how long the metadata of an ACME server's
+	// directory is cached for before it is fetched again.
+	ACMEDirectoryCacheTTL time.Duration
+
+	// ACMEAuthorizationFetchParallelism is the maximum number of
+	// authorizations of a new Order which are fetched from the ACME server
+	// at the same time.
+	ACMEAuthorizationFetchParallelism int
+
 	ClusterIssuerAmbientCredentials bool
 	IssuerAmbientCredentials        bool
 
+	// KubernetesCSRAllowedSignerNames is the list of Kubernetes signer names
+	// which KubernetesCSR issuers may request certificates from.
+	KubernetesCSRAllowedSignerNames []string
+
 	// Default issuer/certificates details consumed by ingress-shim
 	DefaultIssuerName                 string
 	DefaultIssuerKind                 string
@@ -101,10 +144,46 @@ type ControllerOptions struct {
 	EnableCertificateOwnerRef bool
 
 	MaxConcurrentChallenges int
+	// ChallengeSchedulingPolicy determines the order in which challenges are
+	// scheduled for processing.
+	ChallengeSchedulingPolicy string
+	// ChallengeWorkers is the number of challenges that are processed
+	// concurrently by the challenges controller.
+	ChallengeWorkers int
 
 	// The host and port address, separated by a ':', that the Prometheus server
 	// should expose metrics on.
 	MetricsListenAddress string
+	// EnableResourceStateMetrics determines whether a time series describing
+	// the state of each Certificate, Order and Challenge should be exposed.
+	EnableResourceStateMetrics bool
+	// EnableChallengeQueueEndpoint determines whether the challenge
+	// scheduler's queue should be served on the metrics server.
+	EnableChallengeQueueEndpoint bool
+
+	// SelfTest determines whether the reachability of the ACME directories,
+	// recursive nameservers and DNS provider APIs used by issuers is checked
+	// and logged at startup.
+	SelfTest bool
+
+	// EventExportSink is the kind of external system that Events are
+	// exported to. If empty, Events are not exported.
+	EventExportSink string
+	// EventExportURL is the URL that the webhook and slack sinks post to.
+	EventExportURL string
+	// EventExportEventBridgeBus and EventExportEventBridgeRegion identify the
+	// event bus that the eventbridge sink puts Events on.
+	EventExportEventBridgeBus    string
+	EventExportEventBridgeRegion string
+	// EventExportReasons limits the exported Events to those with one of the
+	// given reasons.
+	EventExportReasons []string
+	// EventExportBatchSize and EventExportBatchInterval control how many
+	// Events are sent at once, and how long they are held back to fill a
+	// batch.
+	EventExportBatchSize     int
+	EventExportBatchInterval time.Duration
+
 	// PprofAddress is the address on which Go profiler will run. Should be
 	// in form <host>:<port>.
 	PprofAddress string
@@ -120,6 +199,44 @@ type ControllerOptions struct {
 	// CertificateRequest -> Order. Slice of string literals that are
 	// treated as prefixes for annotation keys.
 	CopiedAnnotationPrefixes []string
+
+	// UserAgentClusterID is included in the User Agent sent to ACME servers
+	// and DNS providers, to allow requests to be correlated.
+	UserAgentClusterID string
+	// RedactUserAgent removes version, platform and cluster information from
+	// the User Agent sent to ACME servers and DNS providers.
+	RedactUserAgent bool
+
+	// OwnedBy identifies this cert-manager instance. If set, the resources
+	// created by the controllers are labelled with it, and resources without
+	// the label are not modified.
+	OwnedBy string
+
+	// IssuerClass is the value of the cert-manager.io/class annotation of the
+	// resources which this instance processes.
+	IssuerClass string
+
+	// LogLevelsConfigMap is the <namespace>/<name> of a ConfigMap which
+	// configures the log level of individual controllers at runtime.
+	LogLevelsConfigMap string
+
+	// IssuancePaused pauses the submission of new ACME orders.
+	IssuancePaused bool
+
+	// IssuancePauseConfigMap is the <namespace>/<name> of a ConfigMap which
+	// pauses the submission of new ACME orders at runtime.
+	IssuancePauseConfigMap string
+
+	// SecretAccessClusterRole is the name of a ClusterRole granting access to
+	// Secrets, which is bound to SecretAccessServiceAccount in each namespace
+	// using a RoleBinding. If set, the secret-access controller is enabled.
+	SecretAccessClusterRole string
+	// SecretAccessServiceAccount is the ServiceAccount, in the form
+	// <namespace>/<name>, that SecretAccessClusterRole is bound to.
+	SecretAccessServiceAccount string
+	// SecretAccessNamespaceSelector is a label selector limiting the
+	// namespaces in which SecretAccessClusterRole is bound.
+	SecretAccessNamespaceSelector string
 }
 
 const (
@@ -141,12 +258,28 @@ const (
 
 	defaultDNS01RecursiveNameserversOnly = false
 
-	defaultMaxConcurrentChallenges = 60
+	defaultMaxConcurrentChallenges   = 60
+	defaultChallengeSchedulingPolicy = "FIFO"
+	defaultChallengeWorkers          = 20
 
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
+	defaultEnableResourceStateMetrics     = false
+	defaultEnableChallengeQueueEndpoint   = false
+	defaultSelfTest                       = false
 
 	// default time period to wait between checking DNS01 and HTTP01 challenge propagation
 	defaultDNS01CheckRetryPeriod = 10 * time.Second
+
+	defaultEventExportEventBridgeBus = "default"
+	defaultEventExportBatchSize      = 20
+	defaultEventExportBatchInterval  = 30 * time.Second
+
+	defaultACMEHTTPTimeout   = 90 * time.Second
+	defaultACMEHTTPKeepAlive = 30 * time.Second
+
+	defaultACMEDirectoryCacheTTL = time.Hour
+
+	defaultACMEAuthorizationFetchParallelism = 5
 )
 
 var (
@@ -156,11 +289,16 @@ var (
 	defaultACMEHTTP01SolverResourceLimitsCPU     = "100m"
 	defaultACMEHTTP01SolverResourceLimitsMemory  = "64Mi"
 
+	defaultACMEHTTP01SolverRunAsNonRoot           = true
+	defaultACMEHTTP01SolverSeccompProfile         = string(corev1.SeccompProfileTypeRuntimeDefault)
+	defaultACMEHTTP01SolverReadOnlyRootFilesystem = false
+
 	defaultAutoCertificateAnnotations = []string{"kubernetes.io/tls-acme"}
 
 	allControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		certificateauthoritiescontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		shimgatewaycontroller.ControllerName,
@@ -172,6 +310,7 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		crkubernetescsrcontroller.CRControllerName,
 		// certificate controllers
 		trigger.ControllerName,
 		issuing.ControllerName,
@@ -179,11 +318,15 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		verification.ControllerName,
+		ocspstapling.ControllerName,
+		secretaccesscontroller.ControllerName,
 	}
 
 	defaultEnabledControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		certificateauthoritiescontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		orderscontroller.ControllerName,
@@ -201,6 +344,31 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		verification.ControllerName,
+		ocspstapling.ControllerName,
+	}
+
+	// controllerGroups are names which may be given to --controllers to
+	// enable or disable a set of related controllers at once.
+	controllerGroups = map[string][]string{
+		"certificates": {
+			trigger.ControllerName,
+			issuing.ControllerName,
+			keymanager.ControllerName,
+			requestmanager.ControllerName,
+			readiness.ControllerName,
+			revisionmanager.ControllerName,
+			verification.ControllerName,
+			ocspstapling.ControllerName,
+		},
+		"certificaterequests": {
+			cracmecontroller.CRControllerName,
+			crapprovercontroller.ControllerName,
+			crcacontroller.CRControllerName,
+			crselfsignedcontroller.CRControllerName,
+			crvaultcontroller.CRControllerName,
+			crvenaficontroller.CRControllerName,
+		},
 	}
 
 	experimentalCertificateSigningRequestControllers = []string{
@@ -244,6 +412,13 @@ func NewControllerOptions() *ControllerOptions {
 		DNS01RecursiveNameserversOnly:     defaultDNS01RecursiveNameserversOnly,
 		EnableCertificateOwnerRef:         defaultEnableCertificateOwnerRef,
 		MetricsListenAddress:              defaultPrometheusMetricsServerAddress,
+		EnableResourceStateMetrics:        defaultEnableResourceStateMetrics,
+		EnableChallengeQueueEndpoint:      defaultEnableChallengeQueueEndpoint,
+		SelfTest:                          defaultSelfTest,
+		ChallengeSchedulingPolicy:         defaultChallengeSchedulingPolicy,
+		EventExportEventBridgeBus:         defaultEventExportEventBridgeBus,
+		EventExportBatchSize:              defaultEventExportBatchSize,
+		EventExportBatchInterval:          defaultEventExportBatchInterval,
 		DNS01CheckRetryPeriod:             defaultDNS01CheckRetryPeriod,
 		EnablePprof:                       cmdutil.DefaultEnableProfiling,
 		PprofAddress:                      cmdutil.DefaultProfilerAddr,
@@ -255,7 +430,12 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Optional apiserver host address to connect to. If not specified, autoconfiguration "+
 		"will be attempted.")
 	fs.StringVar(&s.Kubeconfig, "kubeconfig", defaultKubeconfig, ""+
-		"Paths to a kubeconfig. Only required if out-of-cluster.")
+		"Paths to a kubeconfig. Only required if out-of-cluster. If running out-of-cluster and neither "+
+		"this nor --master is set, the kubeconfig is loaded from the KUBECONFIG environment variable or "+
+		"the default location in the home directory.")
+	fs.StringVar(&s.KubeContext, "kube-context", "", ""+
+		"The name of the kubeconfig context to use. If not specified, the current context of the kubeconfig is used. "+
+		"Setting this flag makes the controller run out-of-cluster.")
 	fs.Float32Var(&s.KubernetesAPIQPS, "kube-api-qps", defaultKubernetesAPIQPS, "indicates the maximum queries-per-second requests to the Kubernetes apiserver")
 	fs.IntVar(&s.KubernetesAPIBurst, "kube-api-burst", defaultKubernetesAPIBurst, "the maximum burst queries-per-second of requests sent to the Kubernetes apiserver")
 	fs.StringVar(&s.ClusterResourceNamespace, "cluster-resource-namespace", defaultClusterResourceNamespace, ""+
@@ -287,7 +467,10 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"A list of controllers to enable. '--controllers=*' enables all "+
 		"on-by-default controllers, '--controllers=foo' enables just the controller "+
 		"named 'foo', '--controllers=*,-foo' disables the controller named "+
-		"'foo'.\nAll controllers: %s",
+		"'foo'. The names 'certificates' and 'certificaterequests' may be used to "+
+		"refer to all certificates or certificaterequests controllers, for example "+
+		"'--controllers=orders,challenges' runs only the ACME orders and challenges "+
+		"controllers.\nAll controllers: %s",
 		strings.Join(allControllers, ", ")))
 
 	// HTTP-01 solver pod configuration via flags is a now deprecated
@@ -311,6 +494,39 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.ACMEHTTP01SolverResourceLimitsMemory, "acme-http01-solver-resource-limits-memory", defaultACMEHTTP01SolverResourceLimitsMemory, ""+
 		"Defines the resource limits Memory size when spawning new ACME HTTP01 challenge solver pods.")
 
+	fs.BoolVar(&s.ACMEHTTP01SolverRunAsNonRoot, "acme-http01-solver-run-as-non-root", defaultACMEHTTP01SolverRunAsNonRoot, ""+
+		"Whether ACME HTTP01 challenge solver pods are required to run as a non-root user.")
+
+	fs.StringVar(&s.ACMEHTTP01SolverSeccompProfile, "acme-http01-solver-seccomp-profile", defaultACMEHTTP01SolverSeccompProfile, ""+
+		"The type of the seccomp profile of ACME HTTP01 challenge solver pods. One of RuntimeDefault, "+
+		"Unconfined, or empty to not set a seccomp profile.")
+
+	fs.BoolVar(&s.ACMEHTTP01SolverReadOnlyRootFilesystem, "acme-http01-solver-read-only-root-filesystem", defaultACMEHTTP01SolverReadOnlyRootFilesystem, ""+
+		"Whether the root filesystem of the container of ACME HTTP01 challenge solver pods is mounted read-only.")
+
+	fs.DurationVar(&s.ACMEHTTPTimeout, "acme-http-timeout", defaultACMEHTTPTimeout, ""+
+		"The maximum duration of an HTTP request to an ACME server. Can be overridden by the "+
+		"spec.acme.httpClient.timeout field of an issuer.")
+	fs.DurationVar(&s.ACMEHTTPKeepAlive, "acme-http-keep-alive", defaultACMEHTTPKeepAlive, ""+
+		"The interval between keep-alive probes of connections to ACME servers. A negative value disables "+
+		"keep-alive probes. Can be overridden by the spec.acme.httpClient.keepAlive field of an issuer.")
+	fs.StringVar(&s.ACMEHTTPProxy, "acme-http-proxy", "", ""+
+		"The URL of the proxy through which requests to ACME servers are sent. If empty, the proxy is taken "+
+		"from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. Can be overridden by the "+
+		"spec.acme.httpClient.proxyURL field of an issuer.")
+	fs.DurationVar(&s.ACMEOrderDiagnosticsTTL, "acme-order-diagnostics-ttl", 0, ""+
+		"If set, when an Order fails its state, the state of its Challenges including their last self check "+
+		"error, and the problems reported by the ACME server are stored compressed in a ConfigMap named "+
+		"'<order>-diagnostics' next to the Order, so that failures can be investigated after the solvers have "+
+		"been cleaned up. The ConfigMap is deleted once this duration has passed since the Order failed, or "+
+		"when the Order is deleted.")
+	fs.DurationVar(&s.ACMEDirectoryCacheTTL, "acme-directory-cache-ttl", defaultACMEDirectoryCacheTTL, ""+
+		"How long the metadata advertised in the directory of an ACME server, such as its CAA identities, "+
+		"terms of service and certificate profiles, is cached for before it is fetched again.")
+	fs.IntVar(&s.ACMEAuthorizationFetchParallelism, "acme-authorization-fetch-parallelism", defaultACMEAuthorizationFetchParallelism, ""+
+		"The maximum number of authorizations of a new Order which are fetched from the ACME server at the "+
+		"same time. Higher values reduce the time taken to set up Orders for certificates with many DNS names.")
+
 	fs.StringSliceVar(&s.ACMEHTTP01SolverNameservers, "acme-http01-solver-nameservers",
 		[]string{}, "A list of comma separated dns server endpoints used for "+
 			"ACME HTTP01 check requests. This should be a list containing host and "+
@@ -319,11 +535,16 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&s.ClusterIssuerAmbientCredentials, "cluster-issuer-ambient-credentials", defaultClusterIssuerAmbientCredentials, ""+
 		"Whether a cluster-issuer may make use of ambient credentials for issuers. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the ClusterIssuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
-		"AWS - All sources the Go SDK defaults to, notably including any EC2 IAM roles available via instance metadata.")
+		"AWS - All sources the Go SDK defaults to, notably including any EC2 IAM roles available via instance metadata. "+
+		"Defaults to false when running out-of-cluster.")
 	fs.BoolVar(&s.IssuerAmbientCredentials, "issuer-ambient-credentials", defaultIssuerAmbientCredentials, ""+
 		"Whether an issuer may make use of ambient credentials. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the Issuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
 		"AWS - All sources the Go SDK defaults to, notably including any EC2 IAM roles available via instance metadata.")
+	fs.StringSliceVar(&s.KubernetesCSRAllowedSignerNames, "kubernetes-csr-allowed-signer-names", []string{}, ""+
+		"A list of comma separated Kubernetes signer names which KubernetesCSR issuers may request certificates from. "+
+		"Any user able to create an Issuer can request certificates from these signers, so only signers which are safe to expose to them should be listed. "+
+		"KubernetesCSR issuers are not ready when their signer is not in this list.")
 	fs.StringSliceVar(&s.DefaultAutoCertificateAnnotations, "auto-certificate-annotations", defaultAutoCertificateAnnotations, ""+
 		"The annotation consumed by the ingress-shim controller to indicate a ingress is requesting a certificate")
 
@@ -355,18 +576,122 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+	fs.StringVar(&s.ChallengeSchedulingPolicy, "challenge-scheduling-policy", defaultChallengeSchedulingPolicy, ""+
+		"The order in which challenges are scheduled for processing. Either 'FIFO', which schedules challenges "+
+		"in the order they were created, or 'ZoneFair', which shares the --max-concurrent-challenges limit "+
+		"fairly between the registered domains of the challenges so that a single domain with many pending "+
+		"challenges does not delay the others.")
+	fs.IntVar(&s.ChallengeWorkers, "challenge-workers", defaultChallengeWorkers, ""+
+		"The number of challenges that are processed concurrently, for example when presenting challenges "+
+		"or checking their propagation. Certificates with many DNS names are issued faster with more workers, "+
+		"at the cost of more concurrent requests to DNS providers and the ACME server.")
 	fs.DurationVar(&s.DNS01CheckRetryPeriod, "dns01-check-retry-period", defaultDNS01CheckRetryPeriod, ""+
 		"The duration the controller should wait between a propagation check. Despite the name, this flag is used to configure the wait period for both DNS01 and HTTP01 challenge propagation checks. For DNS01 challenges the propagation check verifies that a TXT record with the challenge token has been created. For HTTP01 challenges the propagation check verifies that the challenge token is served at the challenge URL."+
 		"This should be a valid duration string, for example 180s or 1h")
 
+	fs.StringVar(&s.UserAgentClusterID, "user-agent-cluster-id", "", ""+
+		"An identifier for this cluster which is included in the User-Agent sent to ACME servers and DNS "+
+		"provider APIs, allowing their support teams to correlate requests made by this installation.")
+	fs.BoolVar(&s.RedactUserAgent, "redact-user-agent", false, ""+
+		"If true, the User-Agent sent to ACME servers and DNS provider APIs is reduced to 'cert-manager', "+
+		"omitting the version, platform and any --user-agent-cluster-id.")
+
+	fs.StringVar(&s.OwnedBy, "owned-by", "", ""+
+		"An identifier for this cert-manager instance. If set, the Orders, Challenges, ACME HTTP01 solver "+
+		"resources and Secrets created by this instance are labelled '"+cm.GroupName+"/owned-by=<value>' and with "+
+		"the UID of the resource controlling them in '"+cm.GroupName+"/controller-uid', and "+
+		"this instance will not modify any of those resources which do not carry the label with the same value. "+
+		"This prevents interference between multiple cert-manager instances in one cluster. Existing resources "+
+		"must be labelled before enabling this option.")
+	fs.StringVar(&s.IssuerClass, "issuer-class", "", ""+
+		"The class of this cert-manager instance. Only Issuers, ClusterIssuers, Certificates, Ingresses and "+
+		"Gateways annotated '"+cm.GroupName+"/class=<value>', and CertificateRequests referencing those issuers, "+
+		"are processed. If empty, only resources without the annotation are processed. This allows multiple "+
+		"cert-manager instances to run in one cluster without processing each other's resources.")
+	fs.StringVar(&s.LogLevelsConfigMap, "log-levels-configmap", "", ""+
+		"A ConfigMap, in the form <namespace>/<name>, which is watched to change the log level of individual "+
+		"controllers without restarting. Each key is the name of a controller, e.g. 'orders', and each value the "+
+		"log level to use for that controller instead of -v. Deleting the ConfigMap restores the -v log level.")
+	fs.BoolVar(&s.IssuancePaused, "issuance-paused", false, ""+
+		"Pause issuance cluster wide. New Orders are not submitted to ACME servers until issuance is resumed, "+
+		"while all resources continue to be reconciled and their status reported. Orders already submitted "+
+		"are completed.")
+	fs.StringVar(&s.IssuancePauseConfigMap, "issuance-pause-configmap", "", ""+
+		"A ConfigMap, in the form <namespace>/<name>, which is watched to pause issuance without restarting. "+
+		"Issuance is paused, as with --issuance-paused, while the ConfigMap has the key 'paused' set to 'true'.")
+
+	fs.StringVar(&s.SecretAccessClusterRole, "secret-access-cluster-role", "", ""+
+		"If set, the named ClusterRole is bound to the ServiceAccount given by --secret-access-service-account "+
+		"in every namespace using a RoleBinding, and the "+secretaccesscontroller.ControllerName+" controller is enabled. "+
+		"This allows access to Secrets to be granted per namespace rather than cluster wide: Secrets are "+
+		"only listed and watched in the selected namespaces.")
+	fs.StringVar(&s.SecretAccessServiceAccount, "secret-access-service-account", "", ""+
+		"The ServiceAccount, in the form <namespace>/<name>, that the --secret-access-cluster-role is bound to.")
+	fs.StringVar(&s.SecretAccessNamespaceSelector, "secret-access-namespace-selector", "", ""+
+		"A label selector limiting the namespaces in which the --secret-access-cluster-role is bound. "+
+		"The cluster resource namespace is always selected. If empty, all namespaces are selected.")
+
 	fs.StringVar(&s.MetricsListenAddress, "metrics-listen-address", defaultPrometheusMetricsServerAddress, ""+
 		"The host and port that the metrics endpoint should listen on.")
+	fs.BoolVar(&s.EnableResourceStateMetrics, "enable-resource-state-metrics", defaultEnableResourceStateMetrics, ""+
+		"Whether to expose a time series for every Certificate, Order and Challenge with labels "+
+		"describing its current condition and state, similar to kube-state-metrics. This can "+
+		"produce a large number of series in clusters with many resources.")
+	fs.BoolVar(&s.EnableChallengeQueueEndpoint, "enable-challenge-queue-endpoint", defaultEnableChallengeQueueEndpoint, ""+
+		"Whether to serve the Challenges waiting to be scheduled, along with the reason they are waiting, "+
+		"as JSON on the /challenges/queue path of the metrics server.")
+	fs.BoolVar(&s.SelfTest, "self-test", defaultSelfTest, ""+
+		"Whether to check that the ACME directories, recursive nameservers and DNS provider APIs used by "+
+		"issuers are reachable when the controller starts, logging an error for each that is not. "+
+		"'cmctl check environment' performs the same checks on demand.")
+
+	fs.StringVar(&s.EventExportSink, "event-export-sink", "", ""+
+		"The external system that the Events recorded by the controller are exported to, so that they "+
+		"are retained for longer than the cluster retains Events. One of 'webhook', 'slack' or 'eventbridge'. "+
+		"If empty, Events are not exported.")
+	fs.StringVar(&s.EventExportURL, "event-export-url", "", ""+
+		"The URL that the webhook and slack event export sinks post to. The webhook sink posts a JSON "+
+		"object with an 'events' list, the slack sink expects a Slack incoming webhook URL.")
+	fs.StringVar(&s.EventExportEventBridgeBus, "event-export-eventbridge-bus", defaultEventExportEventBridgeBus, ""+
+		"The name or ARN of the Amazon EventBridge event bus that the eventbridge event export sink puts Events on. "+
+		"Credentials are taken from the environment of the controller.")
+	fs.StringVar(&s.EventExportEventBridgeRegion, "event-export-eventbridge-region", "", ""+
+		"The AWS region of the EventBridge event bus. If empty, the region is taken from the environment of the controller.")
+	fs.StringSliceVar(&s.EventExportReasons, "event-export-reasons", nil, ""+
+		"If set, only Events with one of the given reasons are exported, e.g. 'Failed,ErrInitIssuer'.")
+	fs.IntVar(&s.EventExportBatchSize, "event-export-batch-size", defaultEventExportBatchSize, ""+
+		"The maximum number of Events exported at once.")
+	fs.DurationVar(&s.EventExportBatchInterval, "event-export-batch-interval", defaultEventExportBatchInterval, ""+
+		"The maximum time an Event is held back to be exported together with later Events.")
+
 	fs.BoolVar(&s.EnablePprof, "enable-profiling", cmdutil.DefaultEnableProfiling, ""+
 		"Enable profiling for controller.")
 	fs.StringVar(&s.PprofAddress, "profiler-address", cmdutil.DefaultProfilerAddr,
 		"The host and port that Go profiler should listen on, i.e localhost:6060. Ensure that profiler is not exposed on a public address. Profiler will be served at /debug/pprof.")
 }
 
+// InCluster returns whether the controller connects to the API server using
+// the in-cluster configuration of its Pod.
+func (o *ControllerOptions) InCluster() bool {
+	return controller.ContextOptions{
+		APIServerHost: o.APIServerHost,
+		Kubeconfig:    o.Kubeconfig,
+		KubeContext:   o.KubeContext,
+	}.InCluster()
+}
+
+// ApplyOutOfClusterDefaults disables the use of ambient credentials by
+// ClusterIssuers when running out-of-cluster, unless explicitly enabled. The
+// ambient credentials of a controller running out-of-cluster are those of the
+// machine it runs on, such as a developer workstation, rather than those
+// granted to the controller in the cluster.
+func (o *ControllerOptions) ApplyOutOfClusterDefaults(fs *pflag.FlagSet) {
+	if o.InCluster() || fs.Changed("cluster-issuer-ambient-credentials") {
+		return
+	}
+	o.ClusterIssuerAmbientCredentials = false
+}
+
 func (o *ControllerOptions) Validate() error {
 	if len(o.DefaultIssuerKind) == 0 {
 		return errors.New("the --default-issuer-kind flag must not be empty")
@@ -392,6 +717,102 @@ func (o *ControllerOptions) Validate() error {
 		}
 	}
 
+	switch corev1.SeccompProfileType(o.ACMEHTTP01SolverSeccompProfile) {
+	case "", corev1.SeccompProfileTypeRuntimeDefault, corev1.SeccompProfileTypeUnconfined:
+	default:
+		return fmt.Errorf("invalid value for --acme-http01-solver-seccomp-profile: %q must be one of RuntimeDefault, Unconfined or empty", o.ACMEHTTP01SolverSeccompProfile)
+	}
+
+	switch o.ChallengeSchedulingPolicy {
+	case "FIFO", "ZoneFair":
+	default:
+		return fmt.Errorf("invalid value for --challenge-scheduling-policy: %q must be one of FIFO or ZoneFair", o.ChallengeSchedulingPolicy)
+	}
+
+	if o.ChallengeWorkers <= 0 {
+		return fmt.Errorf("invalid value for --challenge-workers: %v must be higher than 0", o.ChallengeWorkers)
+	}
+
+	if o.ACMEOrderDiagnosticsTTL < 0 {
+		return fmt.Errorf("invalid value for --acme-order-diagnostics-ttl: %v must not be negative", o.ACMEOrderDiagnosticsTTL)
+	}
+
+	if o.ACMEDirectoryCacheTTL <= 0 {
+		return fmt.Errorf("invalid value for --acme-directory-cache-ttl: %v must be higher than 0", o.ACMEDirectoryCacheTTL)
+	}
+
+	if o.ACMEAuthorizationFetchParallelism <= 0 {
+		return fmt.Errorf("invalid value for --acme-authorization-fetch-parallelism: %v must be higher than 0", o.ACMEAuthorizationFetchParallelism)
+	}
+
+	if o.ACMEHTTPTimeout <= 0 {
+		return fmt.Errorf("invalid value for --acme-http-timeout: %v must be higher than 0", o.ACMEHTTPTimeout)
+	}
+
+	if len(o.ACMEHTTPProxy) > 0 {
+		if _, err := url.Parse(o.ACMEHTTPProxy); err != nil {
+			return fmt.Errorf("invalid value for --acme-http-proxy: %v", err)
+		}
+	}
+
+	switch o.EventExportSink {
+	case "":
+	case eventexport.SinkWebhook, eventexport.SinkSlack:
+		if len(o.EventExportURL) == 0 {
+			return fmt.Errorf("the --event-export-url flag must be set for the %s event export sink", o.EventExportSink)
+		}
+		if _, err := url.Parse(o.EventExportURL); err != nil {
+			return fmt.Errorf("invalid value for --event-export-url: %v", err)
+		}
+	case eventexport.SinkEventBridge:
+		if len(o.EventExportEventBridgeBus) == 0 {
+			return errors.New("the --event-export-eventbridge-bus flag must not be empty")
+		}
+	default:
+		return fmt.Errorf("invalid value for --event-export-sink: %q must be one of webhook, slack or eventbridge", o.EventExportSink)
+	}
+
+	if o.EventExportBatchSize <= 0 {
+		return fmt.Errorf("invalid value for --event-export-batch-size: %v must be higher than 0", o.EventExportBatchSize)
+	}
+
+	if o.EventExportBatchInterval <= 0 {
+		return fmt.Errorf("invalid value for --event-export-batch-interval: %v must be higher than 0", o.EventExportBatchInterval)
+	}
+
+	if len(o.OwnedBy) > 0 {
+		if errs := validation.IsValidLabelValue(o.OwnedBy); len(errs) > 0 {
+			return fmt.Errorf("invalid value for --owned-by: %s", strings.Join(errs, ", "))
+		}
+	}
+
+	if len(o.IssuerClass) > 0 {
+		if errs := validation.IsValidLabelValue(o.IssuerClass); len(errs) > 0 {
+			return fmt.Errorf("invalid value for --issuer-class: %s", strings.Join(errs, ", "))
+		}
+	}
+
+	if len(o.LogLevelsConfigMap) > 0 {
+		if _, _, err := o.LogLevelsConfigMapRef(); err != nil {
+			return err
+		}
+	}
+
+	if len(o.IssuancePauseConfigMap) > 0 {
+		if _, _, err := o.IssuancePauseConfigMapRef(); err != nil {
+			return err
+		}
+	}
+
+	if len(o.SecretAccessClusterRole) > 0 {
+		if _, _, err := o.SecretAccessServiceAccountRef(); err != nil {
+			return err
+		}
+		if _, err := labels.Parse(o.SecretAccessNamespaceSelector); err != nil {
+			return fmt.Errorf("invalid value for --secret-access-namespace-selector: %v", err)
+		}
+	}
+
 	errs := []error{}
 	allControllersSet := sets.NewString(allControllers...)
 	for _, controller := range o.controllers {
@@ -400,6 +821,9 @@ func (o *ControllerOptions) Validate() error {
 		}
 
 		controller = strings.TrimPrefix(controller, "-")
+		if _, ok := controllerGroups[controller]; ok {
+			continue
+		}
 		if !allControllersSet.Has(controller) {
 			errs = append(errs, fmt.Errorf("%q is not in the list of known controllers", controller))
 		}
@@ -412,6 +836,15 @@ func (o *ControllerOptions) Validate() error {
 	return nil
 }
 
+// expandControllerGroup returns the controllers in the named controller
+// group, or just the given name if it is not a group.
+func expandControllerGroup(name string) []string {
+	if group, ok := controllerGroups[name]; ok {
+		return group
+	}
+	return []string{name}
+}
+
 func (o *ControllerOptions) EnabledControllers() sets.String {
 	var disabled []string
 	enabled := sets.NewString()
@@ -421,14 +854,19 @@ func (o *ControllerOptions) EnabledControllers() sets.String {
 		case controller == "*":
 			enabled = enabled.Insert(defaultEnabledControllers...)
 		case strings.HasPrefix(controller, "-"):
-			disabled = append(disabled, strings.TrimPrefix(controller, "-"))
+			disabled = append(disabled, expandControllerGroup(strings.TrimPrefix(controller, "-"))...)
 		default:
-			enabled = enabled.Insert(controller)
+			enabled = enabled.Insert(expandControllerGroup(controller)...)
 		}
 	}
 
 	enabled = enabled.Delete(disabled...)
 
+	if len(o.SecretAccessClusterRole) > 0 {
+		logf.Log.Info("enabling the secret-access controller", "clusterrole", o.SecretAccessClusterRole)
+		enabled = enabled.Insert(secretaccesscontroller.ControllerName)
+	}
+
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalCertificateSigningRequestControllers) {
 		logf.Log.Info("enabling all experimental certificatesigningrequest controllers")
 		enabled = enabled.Insert(experimentalCertificateSigningRequestControllers...)
@@ -441,3 +879,33 @@ func (o *ControllerOptions) EnabledControllers() sets.String {
 
 	return enabled
 }
+
+// SecretAccessServiceAccountRef returns the namespace and name of the
+// ServiceAccount given by --secret-access-service-account.
+func (o *ControllerOptions) SecretAccessServiceAccountRef() (string, string, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(o.SecretAccessServiceAccount)
+	if err != nil || len(namespace) == 0 || len(name) == 0 {
+		return "", "", fmt.Errorf("invalid value for --secret-access-service-account: %q must be of the form <namespace>/<name>", o.SecretAccessServiceAccount)
+	}
+	return namespace, name, nil
+}
+
+// LogLevelsConfigMapRef returns the namespace and name of the
+// LogLevelsConfigMap.
+func (o *ControllerOptions) LogLevelsConfigMapRef() (string, string, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(o.LogLevelsConfigMap)
+	if err != nil || len(namespace) == 0 || len(name) == 0 {
+		return "", "", fmt.Errorf("invalid value for --log-levels-configmap: %q must be of the form <namespace>/<name>", o.LogLevelsConfigMap)
+	}
+	return namespace, name, nil
+}
+
+// IssuancePauseConfigMapRef returns the namespace and name of the
+// IssuancePauseConfigMap.
+func (o *ControllerOptions) IssuancePauseConfigMapRef() (string, string, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(o.IssuancePauseConfigMap)
+	if err != nil || len(namespace) == 0 || len(name) == 0 {
+		return "", "", fmt.Errorf("invalid value for --issuance-pause-configmap: %q must be of the form <namespace>/<name>", o.IssuancePauseConfigMap)
+	}
+	return namespace, name, nil
+}
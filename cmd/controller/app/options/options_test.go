@@ -19,6 +19,7 @@ package options
 import (
 	"testing"
 
+	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -47,6 +48,14 @@ func TestEnabledControllers(t *testing.T) {
 			controllers: []string{"*", "-clusterissuers", "-issuers"},
 			expEnabled:  sets.NewString(defaultEnabledControllers...).Delete("clusterissuers", "issuers"),
 		},
+		"if a controller group is enabled, return all controllers in the group": {
+			controllers: []string{"certificates", "orders"},
+			expEnabled:  sets.NewString(controllerGroups["certificates"]...).Insert("orders"),
+		},
+		"if all controllers enabled, a group disabled, return all default controllers without the group": {
+			controllers: []string{"*", "-certificaterequests"},
+			expEnabled:  sets.NewString(defaultEnabledControllers...).Delete(controllerGroups["certificaterequests"]...),
+		},
 	}
 
 	for name, test := range tests {
@@ -63,3 +72,46 @@ func TestEnabledControllers(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyOutOfClusterDefaults(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		expAmbient bool
+	}{
+		"in-cluster, ambient credentials are used by default": {
+			expAmbient: true,
+		},
+		"out-of-cluster, ambient credentials are not used by default": {
+			args:       []string{"--kubeconfig=/tmp/kubeconfig"},
+			expAmbient: false,
+		},
+		"out-of-cluster using a kubeconfig context, ambient credentials are not used by default": {
+			args:       []string{"--kube-context=management"},
+			expAmbient: false,
+		},
+		"out-of-cluster, ambient credentials are used if explicitly enabled": {
+			args:       []string{"--master=https://localhost:6443", "--cluster-issuer-ambient-credentials=true"},
+			expAmbient: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+			t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+
+			o := NewControllerOptions()
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			o.AddFlags(fs)
+			if err := fs.Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+
+			o.ApplyOutOfClusterDefaults(fs)
+			if o.ClusterIssuerAmbientCredentials != test.expAmbient {
+				t.Errorf("got unexpected ClusterIssuerAmbientCredentials, exp=%t got=%t",
+					test.expAmbient, o.ClusterIssuerAmbientCredentials)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/envchecker"
+)
+
+// selfTestTimeout is the timeout of each check of the startup self test.
+const selfTestTimeout = 10 * time.Second
+
+// runSelfTest checks that the ACME directories, recursive nameservers and
+// DNS provider APIs used by the issuers the controller manages are
+// reachable, and logs the outcome of each check. Failures are only logged,
+// as they are often transient.
+func runSelfTest(ctx context.Context, log logr.Logger, cmctx *controller.Context) {
+	log = log.WithName("self-test")
+
+	var issuers []cmapi.GenericIssuer
+	issuerList, err := cmctx.CMClient.CertmanagerV1().Issuers(cmctx.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Error(err, "error listing Issuers")
+		return
+	}
+	for i := range issuerList.Items {
+		issuers = append(issuers, &issuerList.Items[i])
+	}
+	if cmctx.Namespace == "" {
+		clusterIssuerList, err := cmctx.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Error(err, "error listing ClusterIssuers")
+			return
+		}
+		for i := range clusterIssuerList.Items {
+			issuers = append(issuers, &clusterIssuerList.Items[i])
+		}
+	}
+
+	targets := envchecker.TargetsForIssuers(issuers)
+	targets.Nameservers = cmctx.ACMEOptions.DNS01Nameservers
+	report := envchecker.New(selfTestTimeout).Check(ctx, targets)
+	for _, result := range report {
+		if result.Err != nil {
+			log.Error(result.Err, "prerequisite is not met", "kind", result.Kind, "target", result.Target)
+			continue
+		}
+		log.V(logf.InfoLevel).Info("prerequisite is met", "kind", result.Kind, "target", result.Target)
+	}
+	if !report.Failed() {
+		log.V(logf.InfoLevel).Info("the environment meets all prerequisites")
+	}
+}
@@ -33,6 +33,7 @@ import (
 	_ "github.com/cert-manager/cert-manager/pkg/controller/issuers"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/acme"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	_ "github.com/cert-manager/cert-manager/pkg/issuer/kubernetescsr"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/selfsigned"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/vault"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/venafi"
@@ -68,6 +69,7 @@ It will ensure certificates are valid and up to date periodically, and attempt
 to renew certificates at an appropriate time before expiry.`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
+			o.ControllerOptions.ApplyOutOfClusterDefaults(cmd.Flags())
 			if err := o.Validate(args); err != nil {
 				return fmt.Errorf("error validating options: %s", err)
 			}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/cert-manager/cert-manager/pkg/util/envchecker"
+)
+
+// Options is a struct to support check environment command
+type Options struct {
+	// ACMEServers are ACME directories checked in addition to those of the
+	// Issuers and ClusterIssuers in the cluster.
+	ACMEServers []string
+
+	// Nameservers are the recursive nameservers checked.
+	Nameservers []string
+
+	// Timeout of each HTTP request
+	Timeout time.Duration
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+var checkEnvironmentDesc = templates.LongDesc(i18n.T(`
+Check that the environment meets the prerequisites of the Issuers and
+ClusterIssuers in the cluster.
+
+This check verifies that the ACME directories of ACME issuers, the recursive
+nameservers used to check DNS01 challenges, and the APIs of the DNS01 providers
+configured on ACME issuers can be reached. Run it from the network cert-manager
+runs in, for example in a pod, as it reports reachability from where it runs.`))
+
+var checkEnvironmentExample = templates.Examples(i18n.T(`
+# Check the environment of the issuers in the cluster.
+cmctl check environment
+
+# Check an ACME server before creating an issuer for it, using specific nameservers.
+cmctl check environment --acme-server https://acme-v02.api.letsencrypt.org/directory --nameservers 8.8.8.8:53,1.1.1.1:53`))
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCheckEnvironment returns a cobra command for checking the environment
+// prerequisites of cert-manager issuers.
+func NewCmdCheckEnvironment(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "environment",
+		Short:   "Check if the environment meets the prerequisites of the issuers",
+		Long:    checkEnvironmentDesc,
+		Example: checkEnvironmentExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(ctx)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringSliceVar(&o.ACMEServers, "acme-server", nil, "URLs of ACME directories to check in addition to those of the issuers in the cluster")
+	cmd.Flags().StringSliceVar(&o.Nameservers, "nameservers", dnsutil.RecursiveNameservers, "Recursive nameservers to check, in the form host:port")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 10*time.Second, "Timeout of each check")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Run executes check environment command
+func (o *Options) Run(ctx context.Context) error {
+	issuers, err := o.listIssuers(ctx)
+	if err != nil {
+		return err
+	}
+
+	targets := envchecker.TargetsForIssuers(issuers)
+	targets.Nameservers = o.Nameservers
+	seen := map[string]bool{}
+	for _, directory := range targets.ACMEDirectories {
+		seen[directory] = true
+	}
+	for _, server := range o.ACMEServers {
+		if !seen[server] {
+			targets.ACMEDirectories = append(targets.ACMEDirectories, server)
+			seen[server] = true
+		}
+	}
+
+	report := envchecker.New(o.Timeout).Check(ctx, targets)
+	report.Print(o.Out)
+
+	if report.Failed() {
+		return errors.New("the environment does not meet all prerequisites")
+	}
+	fmt.Fprintln(o.Out, "The environment meets all prerequisites")
+	return nil
+}
+
+// listIssuers returns the Issuers in all namespaces and the ClusterIssuers.
+func (o *Options) listIssuers(ctx context.Context) ([]cmapi.GenericIssuer, error) {
+	var issuers []cmapi.GenericIssuer
+
+	issuerList, err := o.CMClient.CertmanagerV1().Issuers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing Issuers: %w", err)
+	}
+	for i := range issuerList.Items {
+		issuers = append(issuers, &issuerList.Items[i])
+	}
+
+	clusterIssuerList, err := o.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ClusterIssuers: %w", err)
+	}
+	for i := range clusterIssuerList.Items {
+		issuers = append(issuers, &clusterIssuerList.Items[i])
+	}
+
+	return issuers, nil
+}
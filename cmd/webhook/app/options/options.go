@@ -71,6 +71,8 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 	fs.StringVar(&c.TLSConfig.Dynamic.SecretNamespace, "dynamic-serving-ca-secret-namespace", c.TLSConfig.Dynamic.SecretNamespace, "namespace of the secret used to store the CA that signs serving certificates")
 	fs.StringVar(&c.TLSConfig.Dynamic.SecretName, "dynamic-serving-ca-secret-name", c.TLSConfig.Dynamic.SecretName, "name of the secret used to store the CA that signs serving certificates certificates")
 	fs.StringSliceVar(&c.TLSConfig.Dynamic.DNSNames, "dynamic-serving-dns-names", c.TLSConfig.Dynamic.DNSNames, "DNS names that should be present on certificates generated by the dynamic serving CA")
+	fs.DurationVar(&c.TLSConfig.Dynamic.CAPropagationDelay.Duration, "dynamic-serving-ca-propagation-delay", c.TLSConfig.Dynamic.CAPropagationDelay.Duration, "amount of time a new CA is present in the CA bundle before it is used to sign serving certificates, "+
+		"which gives the cainjector time to update caBundle fields when the dynamic serving CA is rotated. Defaults to 10m")
 
 	fs.StringVar(&c.KubeConfig, "kubeconfig", c.KubeConfig, "optional path to the kubeconfig used to connect to the apiserver. If not specified, in-cluster-config will be used")
 	fs.StringVar(&c.APIServerHost, "api-server-host", c.APIServerHost, ""+
@@ -74,6 +74,11 @@ type OrderSpec struct {
 	// Duration is the duration for the not after date for the requested certificate.
 	// this is set on order creation as pe the ACME spec.
 	Duration *metav1.Duration
+
+	// Profile is the name of the certificate profile requested from the ACME
+	// server when the order is created, for ACME servers implementing the
+	// ACME profiles extension.
+	Profile string
 }
 
 type OrderStatus struct {
@@ -109,6 +114,16 @@ type OrderStatus struct {
 	// FailureTime stores the time that this order failed.
 	// This is used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// RetryAfter is the earliest time at which the ACME server will be
+	// contacted again for this order, after it asked cert-manager to back off
+	// because a rate limit was exceeded or it was unavailable.
+	RetryAfter *metav1.Time
+
+	// Problem is the problem document returned by the ACME server when the
+	// Order failed, including the subproblems of the individual identifiers
+	// which could not be validated or issued for.
+	Problem *ACMEProblem
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
@@ -121,6 +136,11 @@ type ACMEAuthorization struct {
 	// Identifier is the DNS name to be validated as part of this authorization
 	Identifier string
 
+	// IdentifierType is the type of the identifier to be validated as part of
+	// this authorization, either 'dns' or 'ip'. For 'ip' identifiers, the
+	// identifier field holds the IP address being validated.
+	IdentifierType ACMEIdentifierType
+
 	// Wildcard will be true if this authorization is for a wildcard DNS name.
 	// If this is true, the identifier will be the *non-wildcard* version of
 	// the DNS name.
@@ -144,6 +164,10 @@ type ACMEAuthorization struct {
 	// name and an appropriate Challenge resource will be created to perform
 	// the ACME challenge process.
 	Challenges []ACMEChallenge
+
+	// Problem is the error reported by the ACME server for this
+	// authorization, if validating its identifier failed.
+	Problem *ACMEProblem
 }
 
 // Challenge specifies a challenge offered by the ACME server for an Order.
@@ -166,6 +190,41 @@ type ACMEChallenge struct {
 	Type string
 }
 
+// ACMEProblem is a problem document returned by the ACME server, as
+// described in RFC 8555 section 6.7.
+type ACMEProblem struct {
+	// Type is a URI identifying the type of the problem, for example
+	// 'urn:ietf:params:acme:error:dns'.
+	Type string
+
+	// Detail is a human readable explanation of the problem.
+	Detail string
+
+	// Status is the HTTP status code of the response the problem was
+	// returned in, if any.
+	Status int
+
+	// Subproblems contains the problems of the individual identifiers that
+	// caused this problem, if the ACME server reported them.
+	Subproblems []ACMESubproblem
+}
+
+// ACMESubproblem is a problem reported by the ACME server for an individual
+// identifier of an Order.
+type ACMESubproblem struct {
+	// Type is a URI identifying the type of the problem.
+	Type string
+
+	// Detail is a human readable explanation of the problem.
+	Detail string
+
+	// Identifier is the DNS name or IP address the problem applies to.
+	Identifier string
+
+	// IdentifierType is the type of the identifier, either 'dns' or 'ip'.
+	IdentifierType ACMEIdentifierType
+}
+
 // State represents the state of an ACME resource, such as an Order.
 // The possible options here map to the corresponding values in the
 // ACME specification.
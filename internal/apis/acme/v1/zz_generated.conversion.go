@@ -28,10 +28,10 @@ import (
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	metav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
-	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	pkgapismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	pkgapismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -104,6 +104,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01HostPort)(nil), (*acme.ACMEChallengeSolverHTTP01HostPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(a.(*v1.ACMEChallengeSolverHTTP01HostPort), b.(*acme.ACMEChallengeSolverHTTP01HostPort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01HostPort)(nil), (*v1.ACMEChallengeSolverHTTP01HostPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1_ACMEChallengeSolverHTTP01HostPort(a.(*acme.ACMEChallengeSolverHTTP01HostPort), b.(*v1.ACMEChallengeSolverHTTP01HostPort), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01Ingress)(nil), (*acme.ACMEChallengeSolverHTTP01Ingress)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(a.(*v1.ACMEChallengeSolverHTTP01Ingress), b.(*acme.ACMEChallengeSolverHTTP01Ingress), scope)
 	}); err != nil {
@@ -164,6 +174,96 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01IstioVirtualService)(nil), (*acme.ACMEChallengeSolverHTTP01IstioVirtualService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(a.(*v1.ACMEChallengeSolverHTTP01IstioVirtualService), b.(*acme.ACMEChallengeSolverHTTP01IstioVirtualService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01IstioVirtualService)(nil), (*v1.ACMEChallengeSolverHTTP01IstioVirtualService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1_ACMEChallengeSolverHTTP01IstioVirtualService(a.(*acme.ACMEChallengeSolverHTTP01IstioVirtualService), b.(*v1.ACMEChallengeSolverHTTP01IstioVirtualService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), (*acme.ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(a.(*v1.ACMEChallengeSolverHTTP01OpenShiftRoute), b.(*acme.ACMEChallengeSolverHTTP01OpenShiftRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), (*v1.ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1_ACMEChallengeSolverHTTP01OpenShiftRoute(a.(*acme.ACMEChallengeSolverHTTP01OpenShiftRoute), b.(*v1.ACMEChallengeSolverHTTP01OpenShiftRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), (*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(a.(*v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck), b.(*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), (*v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(a.(*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck), b.(*v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01SelfCheck)(nil), (*acme.ACMEChallengeSolverHTTP01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(a.(*v1.ACMEChallengeSolverHTTP01SelfCheck), b.(*acme.ACMEChallengeSolverHTTP01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01SelfCheck)(nil), (*v1.ACMEChallengeSolverHTTP01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1_ACMEChallengeSolverHTTP01SelfCheck(a.(*acme.ACMEChallengeSolverHTTP01SelfCheck), b.(*v1.ACMEChallengeSolverHTTP01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01Server)(nil), (*acme.ACMEChallengeSolverHTTP01Server)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(a.(*v1.ACMEChallengeSolverHTTP01Server), b.(*acme.ACMEChallengeSolverHTTP01Server), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01Server)(nil), (*v1.ACMEChallengeSolverHTTP01Server)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01Server_To_v1_ACMEChallengeSolverHTTP01Server(a.(*acme.ACMEChallengeSolverHTTP01Server), b.(*v1.ACMEChallengeSolverHTTP01Server), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), (*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(a.(*v1.ACMEChallengeSolverHTTP01ServiceObjectMeta), b.(*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), (*v1.ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta(a.(*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta), b.(*v1.ACMEChallengeSolverHTTP01ServiceObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01ServiceReference)(nil), (*acme.ACMEChallengeSolverHTTP01ServiceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(a.(*v1.ACMEChallengeSolverHTTP01ServiceReference), b.(*acme.ACMEChallengeSolverHTTP01ServiceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ServiceReference)(nil), (*v1.ACMEChallengeSolverHTTP01ServiceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1_ACMEChallengeSolverHTTP01ServiceReference(a.(*acme.ACMEChallengeSolverHTTP01ServiceReference), b.(*v1.ACMEChallengeSolverHTTP01ServiceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01ServiceTemplate)(nil), (*acme.ACMEChallengeSolverHTTP01ServiceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(a.(*v1.ACMEChallengeSolverHTTP01ServiceTemplate), b.(*acme.ACMEChallengeSolverHTTP01ServiceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ServiceTemplate)(nil), (*v1.ACMEChallengeSolverHTTP01ServiceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1_ACMEChallengeSolverHTTP01ServiceTemplate(a.(*acme.ACMEChallengeSolverHTTP01ServiceTemplate), b.(*v1.ACMEChallengeSolverHTTP01ServiceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEDNS01SelfCheck)(nil), (*acme.ACMEDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(a.(*v1.ACMEDNS01SelfCheck), b.(*acme.ACMEDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEDNS01SelfCheck)(nil), (*v1.ACMEDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEDNS01SelfCheck_To_v1_ACMEDNS01SelfCheck(a.(*acme.ACMEDNS01SelfCheck), b.(*v1.ACMEDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*v1.ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -174,6 +274,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEHTTPClient)(nil), (*acme.ACMEHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEHTTPClient_To_acme_ACMEHTTPClient(a.(*v1.ACMEHTTPClient), b.(*acme.ACMEHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEHTTPClient)(nil), (*v1.ACMEHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEHTTPClient_To_v1_ACMEHTTPClient(a.(*acme.ACMEHTTPClient), b.(*v1.ACMEHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderAcmeDNS)(nil), (*acme.ACMEIssuerDNS01ProviderAcmeDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01ProviderAcmeDNS(a.(*v1.ACMEIssuerDNS01ProviderAcmeDNS), b.(*acme.ACMEIssuerDNS01ProviderAcmeDNS), scope)
 	}); err != nil {
@@ -274,6 +384,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEPreAuthorization)(nil), (*acme.ACMEPreAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(a.(*v1.ACMEPreAuthorization), b.(*acme.ACMEPreAuthorization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEPreAuthorization)(nil), (*v1.ACMEPreAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEPreAuthorization_To_v1_ACMEPreAuthorization(a.(*acme.ACMEPreAuthorization), b.(*v1.ACMEPreAuthorization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEProblem)(nil), (*acme.ACMEProblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEProblem_To_acme_ACMEProblem(a.(*v1.ACMEProblem), b.(*acme.ACMEProblem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEProblem)(nil), (*v1.ACMEProblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEProblem_To_v1_ACMEProblem(a.(*acme.ACMEProblem), b.(*v1.ACMEProblem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMERateLimitBudget)(nil), (*acme.ACMERateLimitBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(a.(*v1.ACMERateLimitBudget), b.(*acme.ACMERateLimitBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMERateLimitBudget)(nil), (*v1.ACMERateLimitBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMERateLimitBudget_To_v1_ACMERateLimitBudget(a.(*acme.ACMERateLimitBudget), b.(*v1.ACMERateLimitBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMERateLimitBudgetStatus)(nil), (*acme.ACMERateLimitBudgetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(a.(*v1.ACMERateLimitBudgetStatus), b.(*acme.ACMERateLimitBudgetStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMERateLimitBudgetStatus)(nil), (*v1.ACMERateLimitBudgetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMERateLimitBudgetStatus_To_v1_ACMERateLimitBudgetStatus(a.(*acme.ACMERateLimitBudgetStatus), b.(*v1.ACMERateLimitBudgetStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMESubproblem)(nil), (*acme.ACMESubproblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMESubproblem_To_acme_ACMESubproblem(a.(*v1.ACMESubproblem), b.(*acme.ACMESubproblem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMESubproblem)(nil), (*v1.ACMESubproblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMESubproblem_To_v1_ACMESubproblem(a.(*acme.ACMESubproblem), b.(*v1.ACMESubproblem), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.AzureManagedIdentity)(nil), (*acme.AzureManagedIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_AzureManagedIdentity_To_acme_AzureManagedIdentity(a.(*v1.AzureManagedIdentity), b.(*acme.AzureManagedIdentity), scope)
 	}); err != nil {
@@ -304,6 +464,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ChallengeCondition)(nil), (*acme.ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ChallengeCondition_To_acme_ChallengeCondition(a.(*v1.ChallengeCondition), b.(*acme.ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeCondition)(nil), (*v1.ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeCondition_To_v1_ChallengeCondition(a.(*acme.ChallengeCondition), b.(*v1.ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ChallengeList)(nil), (*acme.ChallengeList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ChallengeList_To_acme_ChallengeList(a.(*v1.ChallengeList), b.(*acme.ChallengeList), scope)
 	}); err != nil {
@@ -374,6 +544,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.Route53AssumeRole)(nil), (*acme.Route53AssumeRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Route53AssumeRole_To_acme_Route53AssumeRole(a.(*v1.Route53AssumeRole), b.(*acme.Route53AssumeRole), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.Route53AssumeRole)(nil), (*v1.Route53AssumeRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_Route53AssumeRole_To_v1_Route53AssumeRole(a.(*acme.Route53AssumeRole), b.(*v1.Route53AssumeRole), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*acme.ACMEIssuer)(nil), (*v1.ACMEIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_acme_ACMEIssuer_To_v1_ACMEIssuer(a.(*acme.ACMEIssuer), b.(*v1.ACMEIssuer), scope)
 	}); err != nil {
@@ -390,9 +570,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 func autoConvert_v1_ACMEAuthorization_To_acme_ACMEAuthorization(in *v1.ACMEAuthorization, out *acme.ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
+	out.IdentifierType = acme.ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = acme.State(in.InitialState)
 	out.Challenges = *(*[]acme.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.Problem = (*acme.ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -404,9 +586,11 @@ func Convert_v1_ACMEAuthorization_To_acme_ACMEAuthorization(in *v1.ACMEAuthoriza
 func autoConvert_acme_ACMEAuthorization_To_v1_ACMEAuthorization(in *acme.ACMEAuthorization, out *v1.ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
+	out.IdentifierType = v1.ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = v1.State(in.InitialState)
 	out.Challenges = *(*[]v1.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.Problem = (*v1.ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -481,6 +665,11 @@ func Convert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACMECha
 
 func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1.ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.TTL = (*int)(unsafe.Pointer(in.TTL))
+	out.ZoneMap = *(*map[string]string)(unsafe.Pointer(&in.ZoneMap))
+	out.CleanupPolicy = acme.DNS01CleanupPolicy(in.CleanupPolicy)
+	out.RecursiveNameservers = *(*[]string)(unsafe.Pointer(&in.RecursiveNameservers))
+	out.PropagationDelay = (*apismetav1.Duration)(unsafe.Pointer(in.PropagationDelay))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -564,6 +753,11 @@ func Convert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *v1.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = v1.CNAMEStrategy(in.CNAMEStrategy)
+	out.TTL = (*int)(unsafe.Pointer(in.TTL))
+	out.ZoneMap = *(*map[string]string)(unsafe.Pointer(&in.ZoneMap))
+	out.CleanupPolicy = v1.DNS01CleanupPolicy(in.CleanupPolicy)
+	out.RecursiveNameservers = *(*[]string)(unsafe.Pointer(&in.RecursiveNameservers))
+	out.PropagationDelay = (*apismetav1.Duration)(unsafe.Pointer(in.PropagationDelay))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(v1.ACMEIssuerDNS01ProviderAkamai)
@@ -648,6 +842,12 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *ac
 func autoConvert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *v1.ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.IstioVirtualService = (*acme.ACMEChallengeSolverHTTP01IstioVirtualService)(unsafe.Pointer(in.IstioVirtualService))
+	out.OpenShiftRoute = (*acme.ACMEChallengeSolverHTTP01OpenShiftRoute)(unsafe.Pointer(in.OpenShiftRoute))
+	out.HostPort = (*acme.ACMEChallengeSolverHTTP01HostPort)(unsafe.Pointer(in.HostPort))
+	out.ProxiedSelfCheck = (*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(unsafe.Pointer(in.ProxiedSelfCheck))
+	out.SelfCheck = (*acme.ACMEChallengeSolverHTTP01SelfCheck)(unsafe.Pointer(in.SelfCheck))
+	out.Server = (*acme.ACMEChallengeSolverHTTP01Server)(unsafe.Pointer(in.Server))
 	return nil
 }
 
@@ -659,6 +859,12 @@ func Convert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *v1.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*v1.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.IstioVirtualService = (*v1.ACMEChallengeSolverHTTP01IstioVirtualService)(unsafe.Pointer(in.IstioVirtualService))
+	out.OpenShiftRoute = (*v1.ACMEChallengeSolverHTTP01OpenShiftRoute)(unsafe.Pointer(in.OpenShiftRoute))
+	out.HostPort = (*v1.ACMEChallengeSolverHTTP01HostPort)(unsafe.Pointer(in.HostPort))
+	out.ProxiedSelfCheck = (*v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(unsafe.Pointer(in.ProxiedSelfCheck))
+	out.SelfCheck = (*v1.ACMEChallengeSolverHTTP01SelfCheck)(unsafe.Pointer(in.SelfCheck))
+	out.Server = (*v1.ACMEChallengeSolverHTTP01Server)(unsafe.Pointer(in.Server))
 	return nil
 }
 
@@ -691,12 +897,33 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeS
 	return autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(in *v1.ACMEChallengeSolverHTTP01HostPort, out *acme.ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(in *v1.ACMEChallengeSolverHTTP01HostPort, out *acme.ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1_ACMEChallengeSolverHTTP01HostPort(in *acme.ACMEChallengeSolverHTTP01HostPort, out *v1.ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1_ACMEChallengeSolverHTTP01HostPort is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1_ACMEChallengeSolverHTTP01HostPort(in *acme.ACMEChallengeSolverHTTP01HostPort, out *v1.ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1_ACMEChallengeSolverHTTP01HostPort(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *v1.ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.ServiceTemplate = (*acme.ACMEChallengeSolverHTTP01ServiceTemplate)(unsafe.Pointer(in.ServiceTemplate))
 	return nil
 }
 
@@ -711,6 +938,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1_ACMEChallengeSolver
 	out.Name = in.Name
 	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*v1.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.ServiceTemplate = (*v1.ACMEChallengeSolverHTTP01ServiceTemplate)(unsafe.Pointer(in.ServiceTemplate))
 	return nil
 }
 
@@ -845,6 +1073,220 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSo
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(in *v1.ACMEChallengeSolverHTTP01IstioVirtualService, out *acme.ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Gateways = *(*[]string)(unsafe.Pointer(&in.Gateways))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(in *v1.ACMEChallengeSolverHTTP01IstioVirtualService, out *acme.ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1_ACMEChallengeSolverHTTP01IstioVirtualService(in *acme.ACMEChallengeSolverHTTP01IstioVirtualService, out *v1.ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Gateways = *(*[]string)(unsafe.Pointer(&in.Gateways))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1_ACMEChallengeSolverHTTP01IstioVirtualService is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1_ACMEChallengeSolverHTTP01IstioVirtualService(in *acme.ACMEChallengeSolverHTTP01IstioVirtualService, out *v1.ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1_ACMEChallengeSolverHTTP01IstioVirtualService(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(in *v1.ACMEChallengeSolverHTTP01OpenShiftRoute, out *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(in *v1.ACMEChallengeSolverHTTP01OpenShiftRoute, out *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1_ACMEChallengeSolverHTTP01OpenShiftRoute(in *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, out *v1.ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1_ACMEChallengeSolverHTTP01OpenShiftRoute is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1_ACMEChallengeSolverHTTP01OpenShiftRoute(in *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, out *v1.ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1_ACMEChallengeSolverHTTP01OpenShiftRoute(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	out.Strategy = acme.HTTP01ProxiedSelfCheckStrategy(in.Strategy)
+	out.Service = (*acme.ACMEChallengeSolverHTTP01ServiceReference)(unsafe.Pointer(in.Service))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	out.Strategy = v1.HTTP01ProxiedSelfCheckStrategy(in.Strategy)
+	out.Service = (*v1.ACMEChallengeSolverHTTP01ServiceReference)(unsafe.Pointer(in.Service))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *v1.ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(in *v1.ACMEChallengeSolverHTTP01SelfCheck, out *acme.ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.ProxyURL = in.ProxyURL
+	out.Host = in.Host
+	out.IPFamily = acme.HTTP01SelfCheckIPFamily(in.IPFamily)
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(in *v1.ACMEChallengeSolverHTTP01SelfCheck, out *acme.ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1_ACMEChallengeSolverHTTP01SelfCheck(in *acme.ACMEChallengeSolverHTTP01SelfCheck, out *v1.ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.ProxyURL = in.ProxyURL
+	out.Host = in.Host
+	out.IPFamily = v1.HTTP01SelfCheckIPFamily(in.IPFamily)
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1_ACMEChallengeSolverHTTP01SelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1_ACMEChallengeSolverHTTP01SelfCheck(in *acme.ACMEChallengeSolverHTTP01SelfCheck, out *v1.ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1_ACMEChallengeSolverHTTP01SelfCheck(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(in *v1.ACMEChallengeSolverHTTP01Server, out *acme.ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	out.ListenPort = in.ListenPort
+	out.ReadTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadTimeout))
+	out.ReadHeaderTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadHeaderTimeout))
+	out.ShutdownDrainPeriod = (*apismetav1.Duration)(unsafe.Pointer(in.ShutdownDrainPeriod))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(in *v1.ACMEChallengeSolverHTTP01Server, out *acme.ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01Server_To_v1_ACMEChallengeSolverHTTP01Server(in *acme.ACMEChallengeSolverHTTP01Server, out *v1.ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	out.ListenPort = in.ListenPort
+	out.ReadTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadTimeout))
+	out.ReadHeaderTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadHeaderTimeout))
+	out.ShutdownDrainPeriod = (*apismetav1.Duration)(unsafe.Pointer(in.ShutdownDrainPeriod))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01Server_To_v1_ACMEChallengeSolverHTTP01Server is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01Server_To_v1_ACMEChallengeSolverHTTP01Server(in *acme.ACMEChallengeSolverHTTP01Server, out *v1.ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01Server_To_v1_ACMEChallengeSolverHTTP01Server(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *v1.ACMEChallengeSolverHTTP01ServiceObjectMeta, out *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *v1.ACMEChallengeSolverHTTP01ServiceObjectMeta, out *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, out *v1.ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, out *v1.ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(in *v1.ACMEChallengeSolverHTTP01ServiceReference, out *acme.ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(in *v1.ACMEChallengeSolverHTTP01ServiceReference, out *acme.ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1_ACMEChallengeSolverHTTP01ServiceReference(in *acme.ACMEChallengeSolverHTTP01ServiceReference, out *v1.ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1_ACMEChallengeSolverHTTP01ServiceReference is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1_ACMEChallengeSolverHTTP01ServiceReference(in *acme.ACMEChallengeSolverHTTP01ServiceReference, out *v1.ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1_ACMEChallengeSolverHTTP01ServiceReference(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(in *v1.ACMEChallengeSolverHTTP01ServiceTemplate, out *acme.ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	if err := Convert_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(&in.ACMEChallengeSolverHTTP01ServiceObjectMeta, &out.ACMEChallengeSolverHTTP01ServiceObjectMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(in *v1.ACMEChallengeSolverHTTP01ServiceTemplate, out *acme.ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1_ACMEChallengeSolverHTTP01ServiceTemplate(in *acme.ACMEChallengeSolverHTTP01ServiceTemplate, out *v1.ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	if err := Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1_ACMEChallengeSolverHTTP01ServiceObjectMeta(&in.ACMEChallengeSolverHTTP01ServiceObjectMeta, &out.ACMEChallengeSolverHTTP01ServiceObjectMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1_ACMEChallengeSolverHTTP01ServiceTemplate is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1_ACMEChallengeSolverHTTP01ServiceTemplate(in *acme.ACMEChallengeSolverHTTP01ServiceTemplate, out *v1.ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1_ACMEChallengeSolverHTTP01ServiceTemplate(in, out, s)
+}
+
+func autoConvert_v1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(in *v1.ACMEDNS01SelfCheck, out *acme.ACMEDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = acme.DNS01SelfCheckNameservers(in.Nameservers)
+	out.PollInterval = (*apismetav1.Duration)(unsafe.Pointer(in.PollInterval))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck is an autogenerated conversion function.
+func Convert_v1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(in *v1.ACMEDNS01SelfCheck, out *acme.ACMEDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_v1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEDNS01SelfCheck_To_v1_ACMEDNS01SelfCheck(in *acme.ACMEDNS01SelfCheck, out *v1.ACMEDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = v1.DNS01SelfCheckNameservers(in.Nameservers)
+	out.PollInterval = (*apismetav1.Duration)(unsafe.Pointer(in.PollInterval))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_acme_ACMEDNS01SelfCheck_To_v1_ACMEDNS01SelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEDNS01SelfCheck_To_v1_ACMEDNS01SelfCheck(in *acme.ACMEDNS01SelfCheck, out *v1.ACMEDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEDNS01SelfCheck_To_v1_ACMEDNS01SelfCheck(in, out, s)
+}
+
 func autoConvert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *v1.ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
@@ -873,11 +1315,37 @@ func Convert_acme_ACMEExternalAccountBinding_To_v1_ACMEExternalAccountBinding(in
 	return autoConvert_acme_ACMEExternalAccountBinding_To_v1_ACMEExternalAccountBinding(in, out, s)
 }
 
+func autoConvert_v1_ACMEHTTPClient_To_acme_ACMEHTTPClient(in *v1.ACMEHTTPClient, out *acme.ACMEHTTPClient, s conversion.Scope) error {
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.KeepAlive = (*apismetav1.Duration)(unsafe.Pointer(in.KeepAlive))
+	out.ProxyURL = in.ProxyURL
+	return nil
+}
+
+// Convert_v1_ACMEHTTPClient_To_acme_ACMEHTTPClient is an autogenerated conversion function.
+func Convert_v1_ACMEHTTPClient_To_acme_ACMEHTTPClient(in *v1.ACMEHTTPClient, out *acme.ACMEHTTPClient, s conversion.Scope) error {
+	return autoConvert_v1_ACMEHTTPClient_To_acme_ACMEHTTPClient(in, out, s)
+}
+
+func autoConvert_acme_ACMEHTTPClient_To_v1_ACMEHTTPClient(in *acme.ACMEHTTPClient, out *v1.ACMEHTTPClient, s conversion.Scope) error {
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.KeepAlive = (*apismetav1.Duration)(unsafe.Pointer(in.KeepAlive))
+	out.ProxyURL = in.ProxyURL
+	return nil
+}
+
+// Convert_acme_ACMEHTTPClient_To_v1_ACMEHTTPClient is an autogenerated conversion function.
+func Convert_acme_ACMEHTTPClient_To_v1_ACMEHTTPClient(in *acme.ACMEHTTPClient, out *v1.ACMEHTTPClient, s conversion.Scope) error {
+	return autoConvert_acme_ACMEHTTPClient_To_v1_ACMEHTTPClient(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.ACMEIssuer, s conversion.Scope) error {
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
+	out.HTTPClient = (*acme.ACMEHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(acme.ACMEExternalAccountBinding)
@@ -902,7 +1370,15 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.OnlyReturnExistingAccount = in.OnlyReturnExistingAccount
+	out.DeactivateAccountOnDeletion = in.DeactivateAccountOnDeletion
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PreAuthorizedDNSNames = *(*[]string)(unsafe.Pointer(&in.PreAuthorizedDNSNames))
+	out.MaxConcurrentChallenges = (*int)(unsafe.Pointer(in.MaxConcurrentChallenges))
+	out.MaxIdentifiersPerOrder = (*int)(unsafe.Pointer(in.MaxIdentifiersPerOrder))
+	out.ProcessingOrderRecheckInterval = (*apismetav1.Duration)(unsafe.Pointer(in.ProcessingOrderRecheckInterval))
+	out.DNS01SelfCheck = (*acme.ACMEDNS01SelfCheck)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.RateLimitBudget = (*acme.ACMERateLimitBudget)(unsafe.Pointer(in.RateLimitBudget))
 	return nil
 }
 
@@ -910,7 +1386,9 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
+	out.HTTPClient = (*v1.ACMEHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(v1.ACMEExternalAccountBinding)
@@ -935,7 +1413,15 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.OnlyReturnExistingAccount = in.OnlyReturnExistingAccount
+	out.DeactivateAccountOnDeletion = in.DeactivateAccountOnDeletion
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PreAuthorizedDNSNames = *(*[]string)(unsafe.Pointer(&in.PreAuthorizedDNSNames))
+	out.MaxConcurrentChallenges = (*int)(unsafe.Pointer(in.MaxConcurrentChallenges))
+	out.MaxIdentifiersPerOrder = (*int)(unsafe.Pointer(in.MaxIdentifiersPerOrder))
+	out.ProcessingOrderRecheckInterval = (*apismetav1.Duration)(unsafe.Pointer(in.ProcessingOrderRecheckInterval))
+	out.DNS01SelfCheck = (*v1.ACMEDNS01SelfCheck)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.RateLimitBudget = (*v1.ACMERateLimitBudget)(unsafe.Pointer(in.RateLimitBudget))
 	return nil
 }
 
@@ -944,6 +1430,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01Provid
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -957,6 +1444,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAcmeDNS_To_v1_ACMEIssuerDNS01Provid
 	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -1032,7 +1520,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01Provi
 	out.ClientID = in.ClientID
 	if in.ClientSecret != nil {
 		in, out := &in.ClientSecret, &out.ClientSecret
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1065,6 +1553,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1076,7 +1565,7 @@ func Convert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderC
 func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01ProviderCloudDNS(in *acme.ACMEIssuerDNS01ProviderCloudDNS, out *v1.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1085,6 +1574,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1113,6 +1603,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01Pro
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.ZoneIDs))
 	return nil
 }
 
@@ -1125,7 +1616,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01Pro
 	out.Email = in.Email
 	if in.APIKey != nil {
 		in, out := &in.APIKey, &out.APIKey
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1134,13 +1625,14 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01Pro
 	}
 	if in.APIToken != nil {
 		in, out := &in.APIToken, &out.APIToken
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.ZoneIDs))
 	return nil
 }
 
@@ -1218,7 +1710,11 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01Provid
 		return err
 	}
 	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	out.RoleChain = *(*[]acme.Route53AssumeRole)(unsafe.Pointer(&in.RoleChain))
 	out.HostedZoneID = in.HostedZoneID
+	out.HostedZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.HostedZoneIDs))
 	out.Region = in.Region
 	return nil
 }
@@ -1232,7 +1728,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01Provid
 	out.AccessKeyID = in.AccessKeyID
 	if in.SecretAccessKeyID != nil {
 		in, out := &in.SecretAccessKeyID, &out.SecretAccessKeyID
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1243,7 +1739,11 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01Provid
 		return err
 	}
 	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	out.RoleChain = *(*[]v1.Route53AssumeRole)(unsafe.Pointer(&in.RoleChain))
 	out.HostedZoneID = in.HostedZoneID
+	out.HostedZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.HostedZoneIDs))
 	out.Region = in.Region
 	return nil
 }
@@ -1280,6 +1780,12 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1_ACMEIssuerDNS01ProviderWe
 func autoConvert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.AccountKeyRotation = in.AccountKeyRotation
+	out.PreAuthorizations = *(*[]acme.ACMEPreAuthorization)(unsafe.Pointer(&in.PreAuthorizations))
+	out.CAAIdentities = *(*[]string)(unsafe.Pointer(&in.CAAIdentities))
+	out.TermsOfService = in.TermsOfService
+	out.RateLimitBudgets = *(*[]acme.ACMERateLimitBudgetStatus)(unsafe.Pointer(&in.RateLimitBudgets))
 	return nil
 }
 
@@ -1291,6 +1797,12 @@ func Convert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatu
 func autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *v1.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.AccountKeyRotation = in.AccountKeyRotation
+	out.PreAuthorizations = *(*[]v1.ACMEPreAuthorization)(unsafe.Pointer(&in.PreAuthorizations))
+	out.CAAIdentities = *(*[]string)(unsafe.Pointer(&in.CAAIdentities))
+	out.TermsOfService = in.TermsOfService
+	out.RateLimitBudgets = *(*[]v1.ACMERateLimitBudgetStatus)(unsafe.Pointer(&in.RateLimitBudgets))
 	return nil
 }
 
@@ -1299,6 +1811,134 @@ func Convert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssuerSta
 	return autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in, out, s)
 }
 
+func autoConvert_v1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(in *v1.ACMEPreAuthorization, out *acme.ACMEPreAuthorization, s conversion.Scope) error {
+	out.DNSName = in.DNSName
+	out.URL = in.URL
+	out.State = acme.State(in.State)
+	out.Expires = (*apismetav1.Time)(unsafe.Pointer(in.Expires))
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_v1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization is an autogenerated conversion function.
+func Convert_v1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(in *v1.ACMEPreAuthorization, out *acme.ACMEPreAuthorization, s conversion.Scope) error {
+	return autoConvert_v1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(in, out, s)
+}
+
+func autoConvert_acme_ACMEPreAuthorization_To_v1_ACMEPreAuthorization(in *acme.ACMEPreAuthorization, out *v1.ACMEPreAuthorization, s conversion.Scope) error {
+	out.DNSName = in.DNSName
+	out.URL = in.URL
+	out.State = v1.State(in.State)
+	out.Expires = (*apismetav1.Time)(unsafe.Pointer(in.Expires))
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_acme_ACMEPreAuthorization_To_v1_ACMEPreAuthorization is an autogenerated conversion function.
+func Convert_acme_ACMEPreAuthorization_To_v1_ACMEPreAuthorization(in *acme.ACMEPreAuthorization, out *v1.ACMEPreAuthorization, s conversion.Scope) error {
+	return autoConvert_acme_ACMEPreAuthorization_To_v1_ACMEPreAuthorization(in, out, s)
+}
+
+func autoConvert_v1_ACMEProblem_To_acme_ACMEProblem(in *v1.ACMEProblem, out *acme.ACMEProblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Status = in.Status
+	out.Subproblems = *(*[]acme.ACMESubproblem)(unsafe.Pointer(&in.Subproblems))
+	return nil
+}
+
+// Convert_v1_ACMEProblem_To_acme_ACMEProblem is an autogenerated conversion function.
+func Convert_v1_ACMEProblem_To_acme_ACMEProblem(in *v1.ACMEProblem, out *acme.ACMEProblem, s conversion.Scope) error {
+	return autoConvert_v1_ACMEProblem_To_acme_ACMEProblem(in, out, s)
+}
+
+func autoConvert_acme_ACMEProblem_To_v1_ACMEProblem(in *acme.ACMEProblem, out *v1.ACMEProblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Status = in.Status
+	out.Subproblems = *(*[]v1.ACMESubproblem)(unsafe.Pointer(&in.Subproblems))
+	return nil
+}
+
+// Convert_acme_ACMEProblem_To_v1_ACMEProblem is an autogenerated conversion function.
+func Convert_acme_ACMEProblem_To_v1_ACMEProblem(in *acme.ACMEProblem, out *v1.ACMEProblem, s conversion.Scope) error {
+	return autoConvert_acme_ACMEProblem_To_v1_ACMEProblem(in, out, s)
+}
+
+func autoConvert_v1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(in *v1.ACMERateLimitBudget, out *acme.ACMERateLimitBudget, s conversion.Scope) error {
+	out.CertificatesPerRegisteredDomain = in.CertificatesPerRegisteredDomain
+	out.Window = (*apismetav1.Duration)(unsafe.Pointer(in.Window))
+	out.RenewalDelayThreshold = in.RenewalDelayThreshold
+	return nil
+}
+
+// Convert_v1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget is an autogenerated conversion function.
+func Convert_v1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(in *v1.ACMERateLimitBudget, out *acme.ACMERateLimitBudget, s conversion.Scope) error {
+	return autoConvert_v1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(in, out, s)
+}
+
+func autoConvert_acme_ACMERateLimitBudget_To_v1_ACMERateLimitBudget(in *acme.ACMERateLimitBudget, out *v1.ACMERateLimitBudget, s conversion.Scope) error {
+	out.CertificatesPerRegisteredDomain = in.CertificatesPerRegisteredDomain
+	out.Window = (*apismetav1.Duration)(unsafe.Pointer(in.Window))
+	out.RenewalDelayThreshold = in.RenewalDelayThreshold
+	return nil
+}
+
+// Convert_acme_ACMERateLimitBudget_To_v1_ACMERateLimitBudget is an autogenerated conversion function.
+func Convert_acme_ACMERateLimitBudget_To_v1_ACMERateLimitBudget(in *acme.ACMERateLimitBudget, out *v1.ACMERateLimitBudget, s conversion.Scope) error {
+	return autoConvert_acme_ACMERateLimitBudget_To_v1_ACMERateLimitBudget(in, out, s)
+}
+
+func autoConvert_v1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(in *v1.ACMERateLimitBudgetStatus, out *acme.ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	out.RegisteredDomain = in.RegisteredDomain
+	out.Issued = in.Issued
+	out.Remaining = in.Remaining
+	return nil
+}
+
+// Convert_v1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus is an autogenerated conversion function.
+func Convert_v1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(in *v1.ACMERateLimitBudgetStatus, out *acme.ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	return autoConvert_v1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMERateLimitBudgetStatus_To_v1_ACMERateLimitBudgetStatus(in *acme.ACMERateLimitBudgetStatus, out *v1.ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	out.RegisteredDomain = in.RegisteredDomain
+	out.Issued = in.Issued
+	out.Remaining = in.Remaining
+	return nil
+}
+
+// Convert_acme_ACMERateLimitBudgetStatus_To_v1_ACMERateLimitBudgetStatus is an autogenerated conversion function.
+func Convert_acme_ACMERateLimitBudgetStatus_To_v1_ACMERateLimitBudgetStatus(in *acme.ACMERateLimitBudgetStatus, out *v1.ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMERateLimitBudgetStatus_To_v1_ACMERateLimitBudgetStatus(in, out, s)
+}
+
+func autoConvert_v1_ACMESubproblem_To_acme_ACMESubproblem(in *v1.ACMESubproblem, out *acme.ACMESubproblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Identifier = in.Identifier
+	out.IdentifierType = acme.ACMEIdentifierType(in.IdentifierType)
+	return nil
+}
+
+// Convert_v1_ACMESubproblem_To_acme_ACMESubproblem is an autogenerated conversion function.
+func Convert_v1_ACMESubproblem_To_acme_ACMESubproblem(in *v1.ACMESubproblem, out *acme.ACMESubproblem, s conversion.Scope) error {
+	return autoConvert_v1_ACMESubproblem_To_acme_ACMESubproblem(in, out, s)
+}
+
+func autoConvert_acme_ACMESubproblem_To_v1_ACMESubproblem(in *acme.ACMESubproblem, out *v1.ACMESubproblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Identifier = in.Identifier
+	out.IdentifierType = v1.ACMEIdentifierType(in.IdentifierType)
+	return nil
+}
+
+// Convert_acme_ACMESubproblem_To_v1_ACMESubproblem is an autogenerated conversion function.
+func Convert_acme_ACMESubproblem_To_v1_ACMESubproblem(in *acme.ACMESubproblem, out *v1.ACMESubproblem, s conversion.Scope) error {
+	return autoConvert_acme_ACMESubproblem_To_v1_ACMESubproblem(in, out, s)
+}
+
 func autoConvert_v1_AzureManagedIdentity_To_acme_AzureManagedIdentity(in *v1.AzureManagedIdentity, out *acme.AzureManagedIdentity, s conversion.Scope) error {
 	out.ClientID = in.ClientID
 	out.ResourceID = in.ResourceID
@@ -1377,6 +2017,34 @@ func Convert_acme_Challenge_To_v1_Challenge(in *acme.Challenge, out *v1.Challeng
 	return autoConvert_acme_Challenge_To_v1_Challenge(in, out, s)
 }
 
+func autoConvert_v1_ChallengeCondition_To_acme_ChallengeCondition(in *v1.ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	out.Type = acme.ChallengeConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1_ChallengeCondition_To_acme_ChallengeCondition is an autogenerated conversion function.
+func Convert_v1_ChallengeCondition_To_acme_ChallengeCondition(in *v1.ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_v1_ChallengeCondition_To_acme_ChallengeCondition(in, out, s)
+}
+
+func autoConvert_acme_ChallengeCondition_To_v1_ChallengeCondition(in *acme.ChallengeCondition, out *v1.ChallengeCondition, s conversion.Scope) error {
+	out.Type = v1.ChallengeConditionType(in.Type)
+	out.Status = pkgapismetav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_acme_ChallengeCondition_To_v1_ChallengeCondition is an autogenerated conversion function.
+func Convert_acme_ChallengeCondition_To_v1_ChallengeCondition(in *acme.ChallengeCondition, out *v1.ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeCondition_To_v1_ChallengeCondition(in, out, s)
+}
+
 func autoConvert_v1_ChallengeList_To_acme_ChallengeList(in *v1.ChallengeList, out *acme.ChallengeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1423,6 +2091,7 @@ func autoConvert_v1_ChallengeSpec_To_acme_ChallengeSpec(in *v1.ChallengeSpec, ou
 	out.URL = in.URL
 	out.AuthorizationURL = in.AuthorizationURL
 	out.DNSName = in.DNSName
+	out.IdentifierType = acme.ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = in.Wildcard
 	out.Type = acme.ACMEChallengeType(in.Type)
 	out.Token = in.Token
@@ -1445,6 +2114,7 @@ func autoConvert_acme_ChallengeSpec_To_v1_ChallengeSpec(in *acme.ChallengeSpec,
 	out.URL = in.URL
 	out.AuthorizationURL = in.AuthorizationURL
 	out.DNSName = in.DNSName
+	out.IdentifierType = v1.ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = in.Wildcard
 	out.Type = v1.ACMEChallengeType(in.Type)
 	out.Token = in.Token
@@ -1466,8 +2136,14 @@ func Convert_acme_ChallengeSpec_To_v1_ChallengeSpec(in *acme.ChallengeSpec, out
 func autoConvert_v1_ChallengeStatus_To_acme_ChallengeStatus(in *v1.ChallengeStatus, out *acme.ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*apismetav1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.Step = acme.ChallengeStep(in.Step)
+	out.Problem = (*acme.ACMEProblem)(unsafe.Pointer(in.Problem))
+	out.FailedCleanUpAttempts = in.FailedCleanUpAttempts
+	out.CNAMETarget = in.CNAMETarget
+	out.Conditions = *(*[]acme.ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1479,8 +2155,14 @@ func Convert_v1_ChallengeStatus_To_acme_ChallengeStatus(in *v1.ChallengeStatus,
 func autoConvert_acme_ChallengeStatus_To_v1_ChallengeStatus(in *acme.ChallengeStatus, out *v1.ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*apismetav1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = v1.State(in.State)
+	out.Step = v1.ChallengeStep(in.Step)
+	out.Problem = (*v1.ACMEProblem)(unsafe.Pointer(in.Problem))
+	out.FailedCleanUpAttempts = in.FailedCleanUpAttempts
+	out.CNAMETarget = in.CNAMETarget
+	out.Conditions = *(*[]v1.ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1571,7 +2253,8 @@ func autoConvert_v1_OrderSpec_To_acme_OrderSpec(in *v1.OrderSpec, out *acme.Orde
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -1588,7 +2271,8 @@ func autoConvert_acme_OrderSpec_To_v1_OrderSpec(in *acme.OrderSpec, out *v1.Orde
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -1604,7 +2288,9 @@ func autoConvert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acm
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*apismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.Problem = (*acme.ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -1620,7 +2306,9 @@ func autoConvert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v
 	out.State = v1.State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*apismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.Problem = (*v1.ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -1628,3 +2316,27 @@ func autoConvert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v
 func Convert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v1.OrderStatus, s conversion.Scope) error {
 	return autoConvert_acme_OrderStatus_To_v1_OrderStatus(in, out, s)
 }
+
+func autoConvert_v1_Route53AssumeRole_To_acme_Route53AssumeRole(in *v1.Route53AssumeRole, out *acme.Route53AssumeRole, s conversion.Scope) error {
+	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	return nil
+}
+
+// Convert_v1_Route53AssumeRole_To_acme_Route53AssumeRole is an autogenerated conversion function.
+func Convert_v1_Route53AssumeRole_To_acme_Route53AssumeRole(in *v1.Route53AssumeRole, out *acme.Route53AssumeRole, s conversion.Scope) error {
+	return autoConvert_v1_Route53AssumeRole_To_acme_Route53AssumeRole(in, out, s)
+}
+
+func autoConvert_acme_Route53AssumeRole_To_v1_Route53AssumeRole(in *acme.Route53AssumeRole, out *v1.Route53AssumeRole, s conversion.Scope) error {
+	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	return nil
+}
+
+// Convert_acme_Route53AssumeRole_To_v1_Route53AssumeRole is an autogenerated conversion function.
+func Convert_acme_Route53AssumeRole_To_v1_Route53AssumeRole(in *acme.Route53AssumeRole, out *v1.Route53AssumeRole, s conversion.Scope) error {
+	return autoConvert_acme_Route53AssumeRole_To_v1_Route53AssumeRole(in, out, s)
+}
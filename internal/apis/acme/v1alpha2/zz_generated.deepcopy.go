@@ -22,10 +22,10 @@ limitations under the License.
 package v1alpha2
 
 import (
-	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	apisv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
@@ -43,6 +43,11 @@ func (in *ACMEAuthorization) DeepCopyInto(out *ACMEAuthorization) {
 		*out = make([]ACMEChallenge, len(*in))
 		copy(*out, *in)
 	}
+	if in.Problem != nil {
+		in, out := &in.Problem, &out.Problem
+		*out = new(ACMEProblem)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -106,6 +111,28 @@ func (in *ACMEChallengeSolver) DeepCopy() *ACMEChallengeSolver {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolverDNS01) DeepCopyInto(out *ACMEChallengeSolverDNS01) {
 	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int)
+		**out = **in
+	}
+	if in.ZoneMap != nil {
+		in, out := &in.ZoneMap, &out.ZoneMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RecursiveNameservers != nil {
+		in, out := &in.RecursiveNameservers, &out.RecursiveNameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PropagationDelay != nil {
+		in, out := &in.PropagationDelay, &out.PropagationDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(ACMEIssuerDNS01ProviderAkamai)
@@ -177,6 +204,36 @@ func (in *ACMEChallengeSolverHTTP01) DeepCopyInto(out *ACMEChallengeSolverHTTP01
 		*out = new(ACMEChallengeSolverHTTP01GatewayHTTPRoute)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IstioVirtualService != nil {
+		in, out := &in.IstioVirtualService, &out.IstioVirtualService
+		*out = new(ACMEChallengeSolverHTTP01IstioVirtualService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenShiftRoute != nil {
+		in, out := &in.OpenShiftRoute, &out.OpenShiftRoute
+		*out = new(ACMEChallengeSolverHTTP01OpenShiftRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostPort != nil {
+		in, out := &in.HostPort, &out.HostPort
+		*out = new(ACMEChallengeSolverHTTP01HostPort)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxiedSelfCheck != nil {
+		in, out := &in.ProxiedSelfCheck, &out.ProxiedSelfCheck
+		*out = new(ACMEChallengeSolverHTTP01ProxiedSelfCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelfCheck != nil {
+		in, out := &in.SelfCheck, &out.SelfCheck
+		*out = new(ACMEChallengeSolverHTTP01SelfCheck)
+		**out = **in
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(ACMEChallengeSolverHTTP01Server)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -220,6 +277,27 @@ func (in *ACMEChallengeSolverHTTP01GatewayHTTPRoute) DeepCopy() *ACMEChallengeSo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01HostPort) DeepCopyInto(out *ACMEChallengeSolverHTTP01HostPort) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(ACMEChallengeSolverHTTP01IngressPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01HostPort.
+func (in *ACMEChallengeSolverHTTP01HostPort) DeepCopy() *ACMEChallengeSolverHTTP01HostPort {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01HostPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolverHTTP01Ingress) DeepCopyInto(out *ACMEChallengeSolverHTTP01Ingress) {
 	*out = *in
@@ -238,6 +316,11 @@ func (in *ACMEChallengeSolverHTTP01Ingress) DeepCopyInto(out *ACMEChallengeSolve
 		*out = new(ACMEChallengeSolverHTTP01IngressTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceTemplate != nil {
+		in, out := &in.ServiceTemplate, &out.ServiceTemplate
+		*out = new(ACMEChallengeSolverHTTP01ServiceTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -381,6 +464,214 @@ func (in *ACMEChallengeSolverHTTP01IngressTemplate) DeepCopy() *ACMEChallengeSol
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01IstioVirtualService) DeepCopyInto(out *ACMEChallengeSolverHTTP01IstioVirtualService) {
+	*out = *in
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01IstioVirtualService.
+func (in *ACMEChallengeSolverHTTP01IstioVirtualService) DeepCopy() *ACMEChallengeSolverHTTP01IstioVirtualService {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01IstioVirtualService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01OpenShiftRoute) DeepCopyInto(out *ACMEChallengeSolverHTTP01OpenShiftRoute) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01OpenShiftRoute.
+func (in *ACMEChallengeSolverHTTP01OpenShiftRoute) DeepCopy() *ACMEChallengeSolverHTTP01OpenShiftRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01OpenShiftRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01ProxiedSelfCheck) DeepCopyInto(out *ACMEChallengeSolverHTTP01ProxiedSelfCheck) {
+	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ACMEChallengeSolverHTTP01ServiceReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01ProxiedSelfCheck.
+func (in *ACMEChallengeSolverHTTP01ProxiedSelfCheck) DeepCopy() *ACMEChallengeSolverHTTP01ProxiedSelfCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01ProxiedSelfCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01SelfCheck) DeepCopyInto(out *ACMEChallengeSolverHTTP01SelfCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01SelfCheck.
+func (in *ACMEChallengeSolverHTTP01SelfCheck) DeepCopy() *ACMEChallengeSolverHTTP01SelfCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01SelfCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01Server) DeepCopyInto(out *ACMEChallengeSolverHTTP01Server) {
+	*out = *in
+	if in.ReadTimeout != nil {
+		in, out := &in.ReadTimeout, &out.ReadTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReadHeaderTimeout != nil {
+		in, out := &in.ReadHeaderTimeout, &out.ReadHeaderTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ShutdownDrainPeriod != nil {
+		in, out := &in.ShutdownDrainPeriod, &out.ShutdownDrainPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01Server.
+func (in *ACMEChallengeSolverHTTP01Server) DeepCopy() *ACMEChallengeSolverHTTP01Server {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01Server)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01ServiceObjectMeta) DeepCopyInto(out *ACMEChallengeSolverHTTP01ServiceObjectMeta) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01ServiceObjectMeta.
+func (in *ACMEChallengeSolverHTTP01ServiceObjectMeta) DeepCopy() *ACMEChallengeSolverHTTP01ServiceObjectMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01ServiceObjectMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01ServiceReference) DeepCopyInto(out *ACMEChallengeSolverHTTP01ServiceReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01ServiceReference.
+func (in *ACMEChallengeSolverHTTP01ServiceReference) DeepCopy() *ACMEChallengeSolverHTTP01ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01ServiceTemplate) DeepCopyInto(out *ACMEChallengeSolverHTTP01ServiceTemplate) {
+	*out = *in
+	in.ACMEChallengeSolverHTTP01ServiceObjectMeta.DeepCopyInto(&out.ACMEChallengeSolverHTTP01ServiceObjectMeta)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01ServiceTemplate.
+func (in *ACMEChallengeSolverHTTP01ServiceTemplate) DeepCopy() *ACMEChallengeSolverHTTP01ServiceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01ServiceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEDNS01SelfCheck) DeepCopyInto(out *ACMEDNS01SelfCheck) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEDNS01SelfCheck.
+func (in *ACMEDNS01SelfCheck) DeepCopy() *ACMEDNS01SelfCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEDNS01SelfCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEExternalAccountBinding) DeepCopyInto(out *ACMEExternalAccountBinding) {
 	*out = *in
@@ -398,9 +689,40 @@ func (in *ACMEExternalAccountBinding) DeepCopy() *ACMEExternalAccountBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEHTTPClient) DeepCopyInto(out *ACMEHTTPClient) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KeepAlive != nil {
+		in, out := &in.KeepAlive, &out.KeepAlive
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEHTTPClient.
+func (in *ACMEHTTPClient) DeepCopy() *ACMEHTTPClient {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEHTTPClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 	*out = *in
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(ACMEHTTPClient)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(ACMEExternalAccountBinding)
@@ -414,6 +736,36 @@ func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreAuthorizedDNSNames != nil {
+		in, out := &in.PreAuthorizedDNSNames, &out.PreAuthorizedDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrentChallenges != nil {
+		in, out := &in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxIdentifiersPerOrder != nil {
+		in, out := &in.MaxIdentifiersPerOrder, &out.MaxIdentifiersPerOrder
+		*out = new(int)
+		**out = **in
+	}
+	if in.ProcessingOrderRecheckInterval != nil {
+		in, out := &in.ProcessingOrderRecheckInterval, &out.ProcessingOrderRecheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DNS01SelfCheck != nil {
+		in, out := &in.DNS01SelfCheck, &out.DNS01SelfCheck
+		*out = new(ACMEDNS01SelfCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimitBudget != nil {
+		in, out := &in.RateLimitBudget, &out.RateLimitBudget
+		*out = new(ACMERateLimitBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -468,7 +820,7 @@ func (in *ACMEIssuerDNS01ProviderAzureDNS) DeepCopyInto(out *ACMEIssuerDNS01Prov
 	*out = *in
 	if in.ClientSecret != nil {
 		in, out := &in.ClientSecret, &out.ClientSecret
-		*out = new(metav1.SecretKeySelector)
+		*out = new(apismetav1.SecretKeySelector)
 		**out = **in
 	}
 	if in.ManagedIdentity != nil {
@@ -494,7 +846,7 @@ func (in *ACMEIssuerDNS01ProviderCloudDNS) DeepCopyInto(out *ACMEIssuerDNS01Prov
 	*out = *in
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(metav1.SecretKeySelector)
+		*out = new(apismetav1.SecretKeySelector)
 		**out = **in
 	}
 	return
@@ -515,14 +867,21 @@ func (in *ACMEIssuerDNS01ProviderCloudflare) DeepCopyInto(out *ACMEIssuerDNS01Pr
 	*out = *in
 	if in.APIKey != nil {
 		in, out := &in.APIKey, &out.APIKey
-		*out = new(metav1.SecretKeySelector)
+		*out = new(apismetav1.SecretKeySelector)
 		**out = **in
 	}
 	if in.APIToken != nil {
 		in, out := &in.APIToken, &out.APIToken
-		*out = new(metav1.SecretKeySelector)
+		*out = new(apismetav1.SecretKeySelector)
 		**out = **in
 	}
+	if in.ZoneIDs != nil {
+		in, out := &in.ZoneIDs, &out.ZoneIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -575,10 +934,31 @@ func (in *ACMEIssuerDNS01ProviderRoute53) DeepCopyInto(out *ACMEIssuerDNS01Provi
 	*out = *in
 	if in.SecretAccessKeyID != nil {
 		in, out := &in.SecretAccessKeyID, &out.SecretAccessKeyID
-		*out = new(metav1.SecretKeySelector)
+		*out = new(apismetav1.SecretKeySelector)
 		**out = **in
 	}
 	out.SecretAccessKey = in.SecretAccessKey
+	if in.SessionTags != nil {
+		in, out := &in.SessionTags, &out.SessionTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RoleChain != nil {
+		in, out := &in.RoleChain, &out.RoleChain
+		*out = make([]Route53AssumeRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostedZoneIDs != nil {
+		in, out := &in.HostedZoneIDs, &out.HostedZoneIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -616,6 +996,23 @@ func (in *ACMEIssuerDNS01ProviderWebhook) DeepCopy() *ACMEIssuerDNS01ProviderWeb
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerStatus) DeepCopyInto(out *ACMEIssuerStatus) {
 	*out = *in
+	if in.PreAuthorizations != nil {
+		in, out := &in.PreAuthorizations, &out.PreAuthorizations
+		*out = make([]ACMEPreAuthorization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CAAIdentities != nil {
+		in, out := &in.CAAIdentities, &out.CAAIdentities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RateLimitBudgets != nil {
+		in, out := &in.RateLimitBudgets, &out.RateLimitBudgets
+		*out = make([]ACMERateLimitBudgetStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -629,6 +1026,100 @@ func (in *ACMEIssuerStatus) DeepCopy() *ACMEIssuerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEPreAuthorization) DeepCopyInto(out *ACMEPreAuthorization) {
+	*out = *in
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEPreAuthorization.
+func (in *ACMEPreAuthorization) DeepCopy() *ACMEPreAuthorization {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEPreAuthorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEProblem) DeepCopyInto(out *ACMEProblem) {
+	*out = *in
+	if in.Subproblems != nil {
+		in, out := &in.Subproblems, &out.Subproblems
+		*out = make([]ACMESubproblem, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEProblem.
+func (in *ACMEProblem) DeepCopy() *ACMEProblem {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEProblem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMERateLimitBudget) DeepCopyInto(out *ACMERateLimitBudget) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMERateLimitBudget.
+func (in *ACMERateLimitBudget) DeepCopy() *ACMERateLimitBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMERateLimitBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMERateLimitBudgetStatus) DeepCopyInto(out *ACMERateLimitBudgetStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMERateLimitBudgetStatus.
+func (in *ACMERateLimitBudgetStatus) DeepCopy() *ACMERateLimitBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMERateLimitBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMESubproblem) DeepCopyInto(out *ACMESubproblem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMESubproblem.
+func (in *ACMESubproblem) DeepCopy() *ACMESubproblem {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMESubproblem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureManagedIdentity) DeepCopyInto(out *AzureManagedIdentity) {
 	*out = *in
@@ -684,7 +1175,7 @@ func (in *Challenge) DeepCopyInto(out *Challenge) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -706,6 +1197,26 @@ func (in *Challenge) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChallengeCondition) DeepCopyInto(out *ChallengeCondition) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChallengeCondition.
+func (in *ChallengeCondition) DeepCopy() *ChallengeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ChallengeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChallengeList) DeepCopyInto(out *ChallengeList) {
 	*out = *in
@@ -760,6 +1271,22 @@ func (in *ChallengeSpec) DeepCopy() *ChallengeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChallengeStatus) DeepCopyInto(out *ChallengeStatus) {
 	*out = *in
+	if in.PresentedTime != nil {
+		in, out := &in.PresentedTime, &out.PresentedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Problem != nil {
+		in, out := &in.Problem, &out.Problem
+		*out = new(ACMEProblem)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ChallengeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -855,7 +1382,7 @@ func (in *OrderSpec) DeepCopyInto(out *OrderSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
-		*out = new(apismetav1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	return
@@ -890,6 +1417,15 @@ func (in *OrderStatus) DeepCopyInto(out *OrderStatus) {
 		in, out := &in.FailureTime, &out.FailureTime
 		*out = (*in).DeepCopy()
 	}
+	if in.RetryAfter != nil {
+		in, out := &in.RetryAfter, &out.RetryAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.Problem != nil {
+		in, out := &in.Problem, &out.Problem
+		*out = new(ACMEProblem)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -902,3 +1438,26 @@ func (in *OrderStatus) DeepCopy() *OrderStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route53AssumeRole) DeepCopyInto(out *Route53AssumeRole) {
+	*out = *in
+	if in.SessionTags != nil {
+		in, out := &in.SessionTags, &out.SessionTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53AssumeRole.
+func (in *Route53AssumeRole) DeepCopy() *Route53AssumeRole {
+	if in == nil {
+		return nil
+	}
+	out := new(Route53AssumeRole)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -80,6 +80,12 @@ type OrderSpec struct {
 	// this is set on order creation as pe the ACME spec.
 	// +optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Profile is the name of the certificate profile requested from the ACME
+	// server when the order is created, for ACME servers implementing the
+	// ACME profiles extension.
+	// +optional
+	Profile string `json:"profile,omitempty"`
 }
 
 type OrderStatus struct {
@@ -122,6 +128,18 @@ type OrderStatus struct {
 	// This is used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// RetryAfter is the earliest time at which the ACME server will be
+	// contacted again for this order, after it asked cert-manager to back off
+	// because a rate limit was exceeded or it was unavailable.
+	// +optional
+	RetryAfter *metav1.Time `json:"retryAfter,omitempty"`
+
+	// Problem is the problem document returned by the ACME server when the
+	// Order failed, including the subproblems of the individual identifiers
+	// which could not be validated or issued for.
+	// +optional
+	Problem *ACMEProblem `json:"problem,omitempty"`
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
@@ -135,6 +153,12 @@ type ACMEAuthorization struct {
 	// +optional
 	Identifier string `json:"identifier,omitempty"`
 
+	// IdentifierType is the type of the identifier to be validated as part of
+	// this authorization, either 'dns' or 'ip'. For 'ip' identifiers, the
+	// identifier field holds the IP address being validated.
+	// +optional
+	IdentifierType ACMEIdentifierType `json:"identifierType,omitempty"`
+
 	// Wildcard will be true if this authorization is for a wildcard DNS name.
 	// If this is true, the identifier will be the *non-wildcard* version of
 	// the DNS name.
@@ -160,6 +184,11 @@ type ACMEAuthorization struct {
 	// the ACME challenge process.
 	// +optional
 	Challenges []ACMEChallenge `json:"challenges,omitempty"`
+
+	// Problem is the error reported by the ACME server for this
+	// authorization, if validating its identifier failed.
+	// +optional
+	Problem *ACMEProblem `json:"problem,omitempty"`
 }
 
 // Challenge specifies a challenge offered by the ACME server for an Order.
@@ -182,6 +211,49 @@ type ACMEChallenge struct {
 	Type string `json:"type"`
 }
 
+// ACMEProblem is a problem document returned by the ACME server, as
+// described in RFC 8555 section 6.7.
+type ACMEProblem struct {
+	// Type is a URI identifying the type of the problem, for example
+	// 'urn:ietf:params:acme:error:dns'.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Detail is a human readable explanation of the problem.
+	// +optional
+	Detail string `json:"detail,omitempty"`
+
+	// Status is the HTTP status code of the response the problem was
+	// returned in, if any.
+	// +optional
+	Status int `json:"status,omitempty"`
+
+	// Subproblems contains the problems of the individual identifiers that
+	// caused this problem, if the ACME server reported them.
+	// +optional
+	Subproblems []ACMESubproblem `json:"subproblems,omitempty"`
+}
+
+// ACMESubproblem is a problem reported by the ACME server for an individual
+// identifier of an Order.
+type ACMESubproblem struct {
+	// Type is a URI identifying the type of the problem.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Detail is a human readable explanation of the problem.
+	// +optional
+	Detail string `json:"detail,omitempty"`
+
+	// Identifier is the DNS name or IP address the problem applies to.
+	// +optional
+	Identifier string `json:"identifier,omitempty"`
+
+	// IdentifierType is the type of the identifier, either 'dns' or 'ip'.
+	// +optional
+	IdentifierType ACMEIdentifierType `json:"identifierType,omitempty"`
+}
+
 // State represents the state of an ACME resource, such as an Order.
 // The possible options here map to the corresponding values in the
 // ACME specification.
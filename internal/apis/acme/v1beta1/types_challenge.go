@@ -66,6 +66,12 @@ type ChallengeSpec struct {
 	// non-wildcard domain, e.g. for `*.example.com`, it must be `example.com`.
 	DNSName string `json:"dnsName"`
 
+	// identifierType is the type of the identifier that this challenge is for.
+	// If set to 'ip', the dnsName field holds the IP address being validated.
+	// If not set, the identifier is a DNS name.
+	// +optional
+	IdentifierType ACMEIdentifierType `json:"identifierType,omitempty"`
+
 	// wildcard will be true if this challenge is for a wildcard identifier,
 	// for example '*.example.com'.
 	// +optional
@@ -114,6 +120,19 @@ const (
 	ACMEChallengeTypeDNS01 ACMEChallengeType = "DNS-01"
 )
 
+// The type of an ACME identifier. Only dns and ip are supported.
+// +kubebuilder:validation:Enum=dns;ip
+type ACMEIdentifierType string
+
+const (
+	// ACMEIdentifierTypeDNS denotes an identifier for a DNS name.
+	ACMEIdentifierTypeDNS ACMEIdentifierType = "dns"
+
+	// ACMEIdentifierTypeIP denotes an identifier for an IP address.
+	// More info: https://datatracker.ietf.org/doc/html/rfc8738
+	ACMEIdentifierTypeIP ACMEIdentifierType = "ip"
+)
+
 type ChallengeStatus struct {
 	// Used to denote whether this challenge should be processed or not.
 	// This field will only be set to true by the 'scheduling' component.
@@ -133,6 +152,11 @@ type ChallengeStatus struct {
 	// +optional
 	Presented bool `json:"presented"`
 
+	// PresentedTime is the time at which the challenge values were last
+	// presented using the challenge mechanism.
+	// +optional
+	PresentedTime *metav1.Time `json:"presentedTime,omitempty"`
+
 	// Contains human readable information on why the Challenge is in the
 	// current state.
 	// +optional
@@ -142,4 +166,114 @@ type ChallengeStatus struct {
 	// If not set, the state of the challenge is unknown.
 	// +optional
 	State State `json:"state,omitempty"`
+
+	// Step is the last step of solving the challenge which has been
+	// completed. The challenges controller resumes solving the challenge from
+	// the following step, so that challenge values are not presented, and the
+	// challenge is not accepted with the ACME server, more than once.
+	// If not set, no step has been completed yet.
+	// +optional
+	Step ChallengeStep `json:"step,omitempty"`
+
+	// Problem is the error reported by the ACME server when validating the
+	// challenge failed.
+	// +optional
+	Problem *ACMEProblem `json:"problem,omitempty"`
+
+	// The number of consecutive times cleaning up the presented challenge
+	// values has failed. cert-manager stops retrying, and reports the records
+	// which must be deleted manually, once this reaches a limit.
+	// +optional
+	FailedCleanUpAttempts int `json:"failedCleanUpAttempts,omitempty"`
+
+	// CNAMETarget is the domain name which the `_acme-challenge` record of
+	// the DNS name being validated must be a CNAME record for, for the
+	// challenge to be solved. It is only set for DNS01 challenges solved
+	// using an acme-dns server.
+	// +optional
+	CNAMETarget string `json:"cnameTarget,omitempty"`
+
+	// List of status conditions to indicate the status of the
+	// challenge. Known condition types are `Scheduled`, `Presented` and
+	// `SelfCheckSucceeded`.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []ChallengeCondition `json:"conditions,omitempty"`
 }
+
+// ChallengeCondition contains condition information for a Challenge.
+type ChallengeCondition struct {
+	// Type of the condition, known values are (`Scheduled`, `Presented`,
+	// `SelfCheckSucceeded`).
+	Type ChallengeConditionType `json:"type"`
+
+	// Status of the condition, one of (`True`, `False`, `Unknown`).
+	Status cmmeta.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ChallengeConditionType represents a Challenge condition value.
+type ChallengeConditionType string
+
+const (
+	// ChallengeConditionScheduled indicates whether a challenge has been
+	// scheduled for processing. Challenges which are waiting to be scheduled
+	// have this condition set to False, with a reason describing why the
+	// challenge is waiting, such as the limit on the number of challenges
+	// processed at a time having been reached.
+	ChallengeConditionScheduled ChallengeConditionType = "Scheduled"
+
+	// ChallengeConditionPresented indicates whether the challenge values are
+	// currently presented by the challenge's solver.
+	ChallengeConditionPresented ChallengeConditionType = "Presented"
+
+	// ChallengeConditionSelfCheckSucceeded indicates whether the self check
+	// of the presented challenge values has passed. While the values are
+	// still propagating this condition is False with the reason
+	// `PropagationDelay` or `AwaitingPropagation`, which is expected and not
+	// a failure. Only the reason `SelfCheckTimeout` means the self check has
+	// failed.
+	ChallengeConditionSelfCheckSucceeded ChallengeConditionType = "SelfCheckSucceeded"
+)
+
+// ChallengeStep is a step of solving a Challenge. The steps are completed in
+// the order Created, Presented, SelfChecked, Accepted and Validated.
+// +kubebuilder:validation:Enum=Created;Presented;SelfChecked;Accepted;Validated
+type ChallengeStep string
+
+const (
+	// ChallengeStepCreated is completed once the state of the challenge has
+	// been retrieved from the ACME server.
+	ChallengeStepCreated ChallengeStep = "Created"
+
+	// ChallengeStepPresented is completed once the challenge values have
+	// been presented.
+	ChallengeStepPresented ChallengeStep = "Presented"
+
+	// ChallengeStepSelfChecked is completed once the self check of the
+	// presented challenge values has passed.
+	ChallengeStepSelfChecked ChallengeStep = "SelfChecked"
+
+	// ChallengeStepAccepted is completed once the challenge has been accepted
+	// with the ACME server, which then validates it.
+	ChallengeStepAccepted ChallengeStep = "Accepted"
+
+	// ChallengeStepValidated is completed once the ACME server has finished
+	// validating the challenge's authorization.
+	ChallengeStepValidated ChallengeStep = "Validated"
+)
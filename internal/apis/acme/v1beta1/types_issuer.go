@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -48,12 +49,23 @@ type ACMEIssuer struct {
 	// endpoint.
 	// For example, for Let's Encrypt's DST crosssign you would use:
 	// "DST Root CA X3" or "ISRG Root X1" for the newer Let's Encrypt root CA.
-	// This value picks the first certificate bundle in the ACME alternative
-	// chains that has a certificate with this value as its issuer's CN
+	// This value picks the default certificate bundle, or else the first of
+	// the ACME alternative chains, whose root has this value as its CN. The
+	// root of a bundle is the issuer of its top-most certificate.
 	// +optional
 	// +kubebuilder:validation:MaxLength=64
 	PreferredChain string `json:"preferredChain"`
 
+	// Profile is the name of the certificate profile to request when creating
+	// orders, for ACME servers implementing the ACME profiles extension, for
+	// example "shortlived" or "tlsserver" for Let's Encrypt.
+	// The profile must be advertised by the ACME server, otherwise Orders
+	// will fail. It may be overridden for a single Certificate using the
+	// `acme.cert-manager.io/profile` annotation.
+	// If not set, the ACME server's default profile is used.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
 	// Enables or disables validation of the ACME server TLS certificate.
 	// If true, requests to the ACME server will not have their TLS certificate
 	// validated (i.e. insecure connections will be allowed).
@@ -64,6 +76,13 @@ type ACMEIssuer struct {
 	// +optional
 	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
 
+	// HTTPClient configures the HTTP client used to communicate with the ACME
+	// server. Options which are not set default to the values of the
+	// controller's --acme-http-timeout, --acme-http-keep-alive and
+	// --acme-http-proxy flags.
+	// +optional
+	HTTPClient *ACMEHTTPClient `json:"httpClient,omitempty"`
+
 	// ExternalAccountBinding is a reference to a CA external account of the ACME
 	// server.
 	// If set, upon registration cert-manager will attempt to associate the given
@@ -95,6 +114,25 @@ type ACMEIssuer struct {
 	// +optional
 	DisableAccountKeyGeneration bool `json:"disableAccountKeyGeneration,omitempty"`
 
+	// Enables using only an existing ACME account.
+	// If true, cert-manager looks up the account registered with the ACME
+	// server for the private key using the onlyReturnExisting option
+	// described in RFC 8555 section 7.3.1, and never registers a new account.
+	// This can be used to adopt an account whose private key was imported
+	// without accidentally creating a new account if the key is wrong.
+	// Defaults to false.
+	// +optional
+	OnlyReturnExistingAccount bool `json:"onlyReturnExistingAccount,omitempty"`
+
+	// Enables deactivating the ACME account when the Issuer is deleted.
+	// If true, cert-manager adds a finalizer to the Issuer and deactivates
+	// the account registered with the ACME server before the Issuer is
+	// removed. A deactivated account cannot be used again, so this should
+	// not be enabled for accounts which are shared with other Issuers.
+	// Defaults to false.
+	// +optional
+	DeactivateAccountOnDeletion bool `json:"deactivateAccountOnDeletion,omitempty"`
+
 	// Enables requesting a Not After date on certificates that matches the
 	// duration of the certificate. This is not supported by all ACME servers
 	// like Let's Encrypt. If set to true when the ACME server does not support
@@ -102,6 +140,58 @@ type ACMEIssuer struct {
 	// Defaults to false.
 	// +optional
 	EnableDurationFeature bool `json:"enableDurationFeature,omitempty"`
+
+	// PreAuthorizedDNSNames is a list of DNS names for which the ACME account
+	// is pre-authorized once it has been registered, before any Certificate
+	// requests them, using the ACME server's newAuthz endpoint as described in
+	// RFC 8555 section 7.4.1. The authorizations are solved using the
+	// Issuer's solvers, and their state is recorded in the Issuer's status.
+	// Pre-authorization is not supported by all ACME servers like Let's
+	// Encrypt.
+	// +optional
+	PreAuthorizedDNSNames []string `json:"preAuthorizedDNSNames,omitempty"`
+
+	// MaxConcurrentChallenges is the maximum number of Challenges for this
+	// issuer that are processed at the same time. This limit applies in
+	// addition to the controller's --max-concurrent-challenges flag, and can
+	// be used to stay within the rate limits of the DNS provider or ACME
+	// server used by the issuer. If not set, only the controller's limit
+	// applies.
+	// +optional
+	MaxConcurrentChallenges *int `json:"maxConcurrentChallenges,omitempty"`
+
+	// MaxIdentifiersPerOrder is the maximum number of DNS names and IP
+	// addresses the ACME server accepts in a single order. ACME servers do
+	// not advertise this limit in their directory, Let's Encrypt for example
+	// accepts at most 100. If set, CertificateRequests with more identifiers
+	// fail with an error naming the limit rather than being rejected by the
+	// ACME server.
+	// +optional
+	MaxIdentifiersPerOrder *int `json:"maxIdentifiersPerOrder,omitempty"`
+
+	// ProcessingOrderRecheckInterval enables support for ACME servers which
+	// keep finalized Orders in the 'processing' state for hours or days, for
+	// example because issuance requires manual approval. If set, cert-manager
+	// does not wait for the certificate whilst finalizing an Order, and
+	// instead re-checks Orders which are still processing at this interval
+	// until the ACME server has issued the certificate. It must be at least
+	// one minute. If not set, cert-manager waits for the certificate whilst
+	// finalizing the Order.
+	// +optional
+	ProcessingOrderRecheckInterval *metav1.Duration `json:"processingOrderRecheckInterval,omitempty"`
+
+	// DNS01SelfCheck configures how cert-manager checks that the records
+	// solving DNS01 challenges have propagated before asking the ACME server
+	// to validate them. If not set, the controller's flags apply.
+	// +optional
+	DNS01SelfCheck *ACMEDNS01SelfCheck `json:"dns01SelfCheck,omitempty"`
+
+	// RateLimitBudget configures the tracking of the certificates issued by
+	// this issuer for each registered domain against the rate limit of the
+	// ACME server. If not set, the limits of well-known ACME servers such as
+	// Let's Encrypt are tracked.
+	// +optional
+	RateLimitBudget *ACMERateLimitBudget `json:"rateLimitBudget,omitempty"`
 }
 
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
@@ -126,6 +216,29 @@ type ACMEExternalAccountBinding struct {
 	KeyAlgorithm HMACKeyAlgorithm `json:"keyAlgorithm,omitempty"`
 }
 
+// ACMEHTTPClient configures the HTTP client used to communicate with an ACME
+// server.
+type ACMEHTTPClient struct {
+	// Timeout is the maximum duration of a single request to the ACME
+	// server, including reading its response.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// KeepAlive is the interval between keep-alive probes of the
+	// connections to the ACME server. A negative duration disables
+	// keep-alive probes.
+	// +optional
+	KeepAlive *metav1.Duration `json:"keepAlive,omitempty"`
+
+	// ProxyURL is the URL of the proxy through which requests to the ACME
+	// server are sent, e.g. 'http://proxy.example.com:3128'. The http, https
+	// and socks5 schemes are supported. If not set, the proxy is taken from
+	// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the
+	// controller.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+}
+
 // HMACKeyAlgorithm is the name of a key algorithm used for HMAC encryption
 // +kubebuilder:validation:Enum=HS256;HS384;HS512
 type HMACKeyAlgorithm string
@@ -212,6 +325,168 @@ type ACMEChallengeSolverHTTP01 struct {
 	// This solver is experimental, and fields / behaviour may change in the future.
 	// +optional
 	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute `json:"gatewayHTTPRoute,omitempty"`
+
+	// The Istio VirtualService solver will solve challenges by creating an
+	// Istio VirtualService, bound to existing Istio Gateways, which routes
+	// requests for '/.well-known/acme-challenge/XYZ' to the challenge solver
+	// pods. It can be used in clusters which use Istio for ingress traffic
+	// instead of an ingress controller.
+	// +optional
+	IstioVirtualService *ACMEChallengeSolverHTTP01IstioVirtualService `json:"istioVirtualService,omitempty"`
+
+	// The OpenShift Route solver will solve challenges by creating an
+	// OpenShift Route which routes requests for
+	// '/.well-known/acme-challenge/XYZ' to the challenge solver pods. It can
+	// be used in OpenShift clusters, whose router does not serve Ingresses
+	// which are not converted to Routes.
+	// +optional
+	OpenShiftRoute *ACMEChallengeSolverHTTP01OpenShiftRoute `json:"openShiftRoute,omitempty"`
+
+	// The host port solver will solve challenges by running the challenge
+	// solver pods with host port 80 mapped to the solver, without creating
+	// a Service or an Ingress. It can be used in bare-metal clusters without
+	// an ingress controller, in which port 80 is routed directly to nodes.
+	// +optional
+	HostPort *ACMEChallengeSolverHTTP01HostPort `json:"hostPort,omitempty"`
+
+	// ProxiedSelfCheck configures how cert-manager performs its self check
+	// when the DNS name being validated is found to be proxied by Cloudflare
+	// (an 'orange-cloud' record). For proxied names the self check reaches
+	// the Cloudflare edge rather than the cluster, and commonly fails even
+	// though the ACME server would be able to complete validation.
+	// If not specified, proxied names are checked in the same way as any
+	// other name.
+	// +optional
+	ProxiedSelfCheck *ACMEChallengeSolverHTTP01ProxiedSelfCheck `json:"proxiedSelfCheck,omitempty"`
+
+	// SelfCheck configures the self check cert-manager performs before asking
+	// the ACME server to validate the challenge. It can be used when pods
+	// cannot reach the cluster's external ingress, which would otherwise cause
+	// the self check to fail indefinitely.
+	// +optional
+	SelfCheck *ACMEChallengeSolverHTTP01SelfCheck `json:"selfCheck,omitempty"`
+
+	// Server configures the HTTP server run by the solver pods which serve
+	// the challenge. If not specified, the server listens on port 8089 and
+	// shuts down immediately when its pod is terminated.
+	// +optional
+	Server *ACMEChallengeSolverHTTP01Server `json:"server,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01Server configures the HTTP server run by HTTP01
+// solver pods.
+type ACMEChallengeSolverHTTP01Server struct {
+	// ListenPort is the container port the solver listens on, for clusters
+	// where the default port 8089 is reserved. The solver Service continues
+	// to expose port 8089. Defaults to 8089.
+	// +optional
+	ListenPort int32 `json:"listenPort,omitempty"`
+
+	// ReadTimeout is the maximum duration for reading an entire request,
+	// including its body. If not specified, there is no timeout.
+	// +optional
+	ReadTimeout *metav1.Duration `json:"readTimeout,omitempty"`
+
+	// ReadHeaderTimeout is the maximum duration for reading the headers of
+	// a request. If not specified, ReadTimeout is used.
+	// +optional
+	ReadHeaderTimeout *metav1.Duration `json:"readHeaderTimeout,omitempty"`
+
+	// ShutdownDrainPeriod is the period for which a terminating solver pod
+	// continues to serve challenges while reporting itself as unhealthy,
+	// allowing load balancers to move traffic to a replacement pod before
+	// the server shuts down. If not specified, the server shuts down
+	// immediately.
+	// +optional
+	ShutdownDrainPeriod *metav1.Duration `json:"shutdownDrainPeriod,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01SelfCheck configures the HTTP01 self check.
+type ACMEChallengeSolverHTTP01SelfCheck struct {
+	// Disabled skips the self check, relying on the ACME server to validate
+	// the challenge.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// ProxyURL is the URL of an HTTP proxy the self check request is sent
+	// through, e.g. 'http://proxy.example.com:3128'. If not specified, the
+	// proxy configured in the controller's environment is used.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// Host overrides the Host header and the TLS server name (SNI) of the
+	// self check request. If not specified, the DNS name being validated is
+	// used.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// IPFamily is the IP family the self check connects over. 'DualStack'
+	// resolves both A and AAAA records and, like Let's Encrypt, prefers
+	// IPv6 addresses, falling back to IPv4 if they cannot be connected to.
+	// 'IPv4' and 'IPv6' only connect over the given family. Defaults to
+	// 'DualStack'.
+	// +optional
+	IPFamily HTTP01SelfCheckIPFamily `json:"ipFamily,omitempty"`
+}
+
+// HTTP01SelfCheckIPFamily is the IP family the HTTP01 self check connects
+// over.
+// +kubebuilder:validation:Enum=DualStack;IPv4;IPv6
+type HTTP01SelfCheckIPFamily string
+
+const (
+	// HTTP01SelfCheckIPFamilyDualStack prefers IPv6 and falls back to IPv4.
+	HTTP01SelfCheckIPFamilyDualStack HTTP01SelfCheckIPFamily = "DualStack"
+
+	// HTTP01SelfCheckIPFamilyIPv4 only connects over IPv4.
+	HTTP01SelfCheckIPFamilyIPv4 HTTP01SelfCheckIPFamily = "IPv4"
+
+	// HTTP01SelfCheckIPFamilyIPv6 only connects over IPv6.
+	HTTP01SelfCheckIPFamilyIPv6 HTTP01SelfCheckIPFamily = "IPv6"
+)
+
+// ACMEChallengeSolverHTTP01ProxiedSelfCheck configures the HTTP01 self check
+// for DNS names which are proxied by Cloudflare.
+type ACMEChallengeSolverHTTP01ProxiedSelfCheck struct {
+	// Strategy is the self check strategy used for proxied DNS names.
+	// 'Service' performs the self check against the ingress controller's
+	// Service given in 'service', bypassing the proxy.
+	// 'TrustCA' skips the self check and relies on the ACME server to
+	// validate the challenge.
+	Strategy HTTP01ProxiedSelfCheckStrategy `json:"strategy"`
+
+	// Service is the Service of the ingress controller that serves HTTP01
+	// challenge requests. Required when strategy is 'Service'.
+	// +optional
+	Service *ACMEChallengeSolverHTTP01ServiceReference `json:"service,omitempty"`
+}
+
+// HTTP01ProxiedSelfCheckStrategy is the strategy used to self check HTTP01
+// challenges for DNS names which are proxied by Cloudflare.
+// +kubebuilder:validation:Enum=Service;TrustCA
+type HTTP01ProxiedSelfCheckStrategy string
+
+const (
+	// HTTP01ProxiedSelfCheckStrategyService performs the self check against
+	// the ingress controller's Service directly.
+	HTTP01ProxiedSelfCheckStrategyService HTTP01ProxiedSelfCheckStrategy = "Service"
+
+	// HTTP01ProxiedSelfCheckStrategyTrustCA skips the self check.
+	HTTP01ProxiedSelfCheckStrategyTrustCA HTTP01ProxiedSelfCheckStrategy = "TrustCA"
+)
+
+// ACMEChallengeSolverHTTP01ServiceReference is a reference to a port of a
+// Kubernetes Service.
+type ACMEChallengeSolverHTTP01ServiceReference struct {
+	// Name of the Service.
+	Name string `json:"name"`
+
+	// Namespace of the Service.
+	Namespace string `json:"namespace"`
+
+	// Port of the Service which serves HTTP requests. Defaults to 80.
+	// +optional
+	Port int32 `json:"port,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -243,6 +518,11 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	// ingress used for HTTP01 challenges.
 	// +optional
 	IngressTemplate *ACMEChallengeSolverHTTP01IngressTemplate `json:"ingressTemplate,omitempty"`
+
+	// Optional service template used to configure the ACME challenge solver
+	// service used for HTTP01 challenges.
+	// +optional
+	ServiceTemplate *ACMEChallengeSolverHTTP01ServiceTemplate `json:"serviceTemplate,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
@@ -263,6 +543,56 @@ type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
 	ParentRefs []gwapi.ParentReference `json:"parentRefs,omitempty"`
 }
 
+// The ACMEChallengeSolverHTTP01IstioVirtualService solver will create Istio
+// VirtualServices bound to existing Istio Gateways, routing to an ACME
+// challenge solver pod.
+type ACMEChallengeSolverHTTP01IstioVirtualService struct {
+	// Optional service type for Kubernetes solver service. Supported values
+	// are NodePort or ClusterIP. If unset, defaults to NodePort.
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Gateways are the Istio Gateways which the VirtualService is bound to,
+	// in the form `<namespace>/<name>`, or `<name>` for a Gateway in the
+	// namespace of the challenge. The Gateways must accept HTTP traffic on
+	// port 80 for the DNS names being validated.
+	Gateways []string `json:"gateways"`
+
+	// Custom labels that will be applied to VirtualServices created by
+	// cert-manager while solving HTTP-01 challenges.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// The ACMEChallengeSolverHTTP01OpenShiftRoute solver will create OpenShift
+// Routes, routing to an ACME challenge solver pod.
+type ACMEChallengeSolverHTTP01OpenShiftRoute struct {
+	// Optional service type for Kubernetes solver service. Supported values
+	// are NodePort or ClusterIP. If unset, defaults to NodePort.
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Custom labels that will be applied to Routes created by cert-manager
+	// while solving HTTP-01 challenges. Labels can be used to select the
+	// router shard which admits the Routes.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// The ACMEChallengeSolverHTTP01HostPort solver runs the challenge solver
+// pods with host port 80 mapped to the solver. The pods must run on the
+// nodes to which the ACME server's requests for the DNS names being validated
+// are routed, which can be selected with the pod template. As only one pod may
+// use the host port on a node at a time, challenges are solved one at a time
+// on each node.
+type ACMEChallengeSolverHTTP01HostPort struct {
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for HTTP01 challenges, for example to select the nodes which they
+	// run on.
+	// +optional
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
+}
+
 type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 	// ObjectMeta overrides for the pod used to solve HTTP01 challenges.
 	// Only the 'labels' and 'annotations' fields may be set.
@@ -332,6 +662,25 @@ type ACMEChallengeSolverHTTP01IngressObjectMeta struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+type ACMEChallengeSolverHTTP01ServiceTemplate struct {
+	// ObjectMeta overrides for the service used to solve HTTP01 challenges.
+	// Only the 'labels' and 'annotations' fields may be set.
+	// If labels or annotations overlap with in-built values, the values here
+	// will override the in-built values.
+	// +optional
+	ACMEChallengeSolverHTTP01ServiceObjectMeta `json:"metadata"`
+}
+
+type ACMEChallengeSolverHTTP01ServiceObjectMeta struct {
+	// Annotations that should be added to the created ACME HTTP01 solver service.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels that should be added to the created ACME HTTP01 solver service.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
 // Used to configure a DNS01 challenge provider to be used when solving DNS01
 // challenges.
 // Only one DNS provider may be configured per solver.
@@ -341,6 +690,57 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	CNAMEStrategy CNAMEStrategy `json:"cnameStrategy,omitempty"`
 
+	// TTL is the time to live, in seconds, of the TXT records created to solve
+	// DNS01 challenges. It is also used as the time to wait after the record
+	// has propagated before asking the ACME server to validate the challenge.
+	// Not all DNS providers support setting the TTL, in which case the
+	// provider's default is used.
+	// +optional
+	TTL *int `json:"ttl,omitempty"`
+
+	// ZoneMap overrides the detection of the DNS zone in which the TXT records
+	// solving DNS01 challenges are created, which otherwise looks up the SOA
+	// records of the challenged domain. Each key is a domain and its value the
+	// zone containing the domain and its subdomains, e.g.
+	// 'internal.corp.example.com: corp.example.com'. If several keys match a
+	// domain, the longest one is used.
+	// This is useful for delegated internal zones and DNS APIs behind a proxy,
+	// for which the zone found using SOA records is not the zone managed by the
+	// DNS provider.
+	// +optional
+	ZoneMap map[string]string `json:"zoneMap,omitempty"`
+
+	// CleanupPolicy configures when TXT records created to solve DNS01
+	// challenges are removed.
+	// Defaults to `Deferred`, where records are removed once the Challenge
+	// has reached a final state, such as `valid` or `invalid`, and that state
+	// has been saved, which is usually within seconds of the ACME server
+	// validating the challenge. Records of a Challenge deleted before then
+	// are removed by its finalizer.
+	// If set to `Immediate`, records are removed as soon as the ACME server
+	// reports the challenge as valid, before its state has been saved,
+	// limiting the amount of time validation records are visible in public
+	// DNS. If this fails, removal is retried as for `Deferred`.
+	// +optional
+	CleanupPolicy DNS01CleanupPolicy `json:"cleanupPolicy,omitempty"`
+
+	// RecursiveNameservers is a list of "host:port" addresses of the
+	// recursive nameservers used to follow CNAMEs when CNAMEStrategy is
+	// Follow and to check that the TXT records solving DNS01 challenges have
+	// propagated, overriding the controller's
+	// --dns01-recursive-nameservers flag for this solver.
+	// This is useful in split-horizon environments where different zones
+	// must be resolved using different nameservers.
+	// +optional
+	RecursiveNameservers []string `json:"recursiveNameservers,omitempty"`
+
+	// PropagationDelay is the minimum time to wait after presenting a DNS01
+	// challenge record before checking that it has propagated. This avoids
+	// propagation checks which are bound to fail against DNS providers which
+	// are slow to publish records, e.g. '120s' for some anycast providers.
+	// +optional
+	PropagationDelay *metav1.Duration `json:"propagationDelay,omitempty"`
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	// +optional
 	Akamai *ACMEIssuerDNS01ProviderAkamai `json:"akamai,omitempty"`
@@ -400,6 +800,91 @@ const (
 	FollowStrategy = "Follow"
 )
 
+// DNS01CleanupPolicy configures when TXT records presented for DNS01
+// challenges are removed.
+// +kubebuilder:validation:Enum=Deferred;Immediate
+type DNS01CleanupPolicy string
+
+const (
+	// DNS01CleanupPolicyDeferred removes records once the final state of the
+	// Challenge has been saved, or by its finalizer if the Challenge is
+	// deleted before then.
+	DNS01CleanupPolicyDeferred DNS01CleanupPolicy = "Deferred"
+
+	// DNS01CleanupPolicyImmediate removes records as soon as the ACME server
+	// reports the challenge as valid.
+	DNS01CleanupPolicyImmediate DNS01CleanupPolicy = "Immediate"
+)
+
+// ACMERateLimitBudget configures the tracking of the certificates issued for
+// each registered domain against the rate limit of an ACME server.
+type ACMERateLimitBudget struct {
+	// CertificatesPerRegisteredDomain is the number of certificates the ACME
+	// server issues for a registered domain, such as example.com for
+	// www.example.com, within the window. If not set, the limit of the ACME
+	// server is used if it is well-known, otherwise no budget is tracked.
+	// +optional
+	CertificatesPerRegisteredDomain int `json:"certificatesPerRegisteredDomain,omitempty"`
+
+	// Window is the period over which issued certificates count against the
+	// limit. Defaults to the window of the ACME server if it is well-known,
+	// or to 168h otherwise.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// RenewalDelayThreshold enables delaying renewals whilst the budget of a
+	// registered domain is nearly exhausted. The renewal of a Certificate is
+	// delayed whilst the remaining budget of any of its registered domains is
+	// at most this number, but for no longer than half of the time between
+	// its renewal time and its expiry. The first issuance of a Certificate,
+	// and re-issuances for any other reason than renewal, are not delayed.
+	// Defaults to 0, which disables delaying renewals.
+	// +optional
+	RenewalDelayThreshold int `json:"renewalDelayThreshold,omitempty"`
+}
+
+// ACMEDNS01SelfCheck configures how the propagation of the records solving
+// DNS01 challenges is checked.
+type ACMEDNS01SelfCheck struct {
+	// Nameservers selects the nameservers queried for the records.
+	// If set to `Authoritative`, the authoritative nameservers of the zone
+	// are queried directly, skipping the caches of recursive nameservers,
+	// which may hold stale answers for as long as the TTL of the records.
+	// If set to `Recursive`, only recursive nameservers are queried.
+	// Defaults to the mode set by the controller's
+	// --dns01-recursive-nameservers-only flag.
+	// +optional
+	Nameservers DNS01SelfCheckNameservers `json:"nameservers,omitempty"`
+
+	// PollInterval is the time waited between two checks.
+	// Defaults to the controller's --dns01-check-retry-period flag.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// Timeout is the time after which a Challenge whose records have still
+	// not propagated is marked as errored, so that its Order is retried.
+	// It is measured from the time the Challenge was scheduled for
+	// processing. If not set, the records are checked until they have
+	// propagated.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DNS01SelfCheckNameservers selects the nameservers queried to check the
+// propagation of the records solving DNS01 challenges.
+// +kubebuilder:validation:Enum=Authoritative;Recursive
+type DNS01SelfCheckNameservers string
+
+const (
+	// DNS01SelfCheckNameserversAuthoritative queries the authoritative
+	// nameservers of the zone.
+	DNS01SelfCheckNameserversAuthoritative DNS01SelfCheckNameservers = "Authoritative"
+
+	// DNS01SelfCheckNameserversRecursive queries the recursive nameservers
+	// only.
+	DNS01SelfCheckNameserversRecursive DNS01SelfCheckNameservers = "Recursive"
+)
+
 // ACMEIssuerDNS01ProviderAkamai is a structure containing the DNS
 // configuration for Akamai DNS—Zone Record Management API
 type ACMEIssuerDNS01ProviderAkamai struct {
@@ -414,13 +899,25 @@ type ACMEIssuerDNS01ProviderAkamai struct {
 type ACMEIssuerDNS01ProviderCloudDNS struct {
 	// +optional
 	ServiceAccount *cmmeta.SecretKeySelector `json:"serviceAccountSecretRef,omitempty"`
-	Project        string                    `json:"project"`
+
+	// Project is the Google Cloud project hosting the Cloud DNS zone, which
+	// may differ from the project of the credentials used.
+	// If left empty the project of the credentials is used.
+	// +optional
+	Project string `json:"project,omitempty"`
 
 	// HostedZoneName is an optional field that tells cert-manager in which
 	// Cloud DNS zone the challenge record has to be created.
 	// If left empty cert-manager will automatically choose a zone.
 	// +optional
 	HostedZoneName string `json:"hostedZoneName,omitempty"`
+
+	// ImpersonateServiceAccount is the email address of a Google service
+	// account to impersonate when managing the challenge records. The
+	// credentials used, either from serviceAccountSecretRef or ambient such as
+	// GKE Workload Identity, must be allowed to create tokens for it.
+	// +optional
+	ImpersonateServiceAccount string `json:"impersonateServiceAccount,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderCloudflare is a structure containing the DNS
@@ -440,6 +937,15 @@ type ACMEIssuerDNS01ProviderCloudflare struct {
 	// API token used to authenticate with Cloudflare.
 	// +optional
 	APIToken *cmmeta.SecretKeySelector `json:"apiTokenSecretRef,omitempty"`
+
+	// ZoneIDs maps DNS zones to the IDs of the Cloudflare zones managing
+	// them. The Cloudflare zone of a domain is the one mapped to the longest
+	// DNS zone the domain is part of, which skips looking up the zone by name.
+	// This is required when using an API token scoped to zones which is not
+	// permitted to list them. The Cloudflare zone of domains which are not
+	// part of any of the DNS zones is looked up.
+	// +optional
+	ZoneIDs map[string]string `json:"zoneIDs,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderDigitalOcean is a structure containing the DNS
@@ -471,18 +977,59 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 	// +optional
 	Role string `json:"role,omitempty"`
 
+	// ExternalID is passed when assuming Role, for roles whose trust policy
+	// requires an external ID.
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// SessionTags are passed as session tags when assuming Role.
+	// +optional
+	SessionTags map[string]string `json:"sessionTags,omitempty"`
+
+	// RoleChain is a list of roles assumed in turn after Role, each using the
+	// credentials of the previously assumed role. The credentials of the last
+	// role are used to manage records, which allows managing hosted zones owned
+	// by an account that can only be reached through an intermediate role.
+	// +optional
+	RoleChain []Route53AssumeRole `json:"roleChain,omitempty"`
+
 	// If set, the provider will manage only this zone in Route53 and will not do an lookup using the route53:ListHostedZonesByName api call.
 	// +optional
 	HostedZoneID string `json:"hostedZoneID,omitempty"`
 
+	// HostedZoneIDs maps DNS zones to the IDs of the Route53 hosted zones
+	// managing them. The hosted zone of a domain is the one mapped to the
+	// longest DNS zone the domain is part of, which skips looking up the
+	// hosted zone using the route53:ListHostedZonesByName api call. This
+	// avoids selecting the wrong hosted zone in accounts with private or
+	// overlapping hosted zones. The hosted zone of domains which are not part
+	// of any of the DNS zones is looked up. Ignored if HostedZoneID is set.
+	// +optional
+	HostedZoneIDs map[string]string `json:"hostedZoneIDs,omitempty"`
+
 	// Always set the region when using AccessKeyID and SecretAccessKey
 	Region string `json:"region"`
 }
 
+// Route53AssumeRole is a role assumed by the Route53 provider.
+type Route53AssumeRole struct {
+	// Role is the ARN of the role to assume.
+	Role string `json:"role"`
+
+	// ExternalID is passed when assuming the role, for roles whose trust
+	// policy requires an external ID.
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// SessionTags are passed as session tags when assuming the role.
+	// +optional
+	SessionTags map[string]string `json:"sessionTags,omitempty"`
+}
+
 // ACMEIssuerDNS01ProviderAzureDNS is a structure containing the
 // configuration for Azure DNS
 type ACMEIssuerDNS01ProviderAzureDNS struct {
-	// if both this and ClientSecret are left unset MSI will be used
+	// if both this and ClientSecret are left unset, workload identity will be used if the controller has a federated token (AZURE_FEDERATED_TOKEN_FILE), and MSI otherwise
 	// +optional
 	ClientID string `json:"clientID,omitempty"`
 
@@ -504,7 +1051,7 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 	// +optional
 	HostedZoneName string `json:"hostedZoneName,omitempty"`
 
-	// name of the Azure environment (default AzurePublicCloud)
+	// name of the Azure environment the DNS zone belongs to, which selects the Azure Resource Manager and Azure Active Directory endpoints that are used (default AzurePublicCloud). AzureUSGovernment is accepted as an alias of AzureUSGovernmentCloud
 	// +optional
 	Environment AzureDNSEnvironment `json:"environment,omitempty"`
 
@@ -514,7 +1061,7 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 }
 
 type AzureManagedIdentity struct {
-	// client ID of the managed identity, can not be used at the same time as resourceID
+	// client ID of the managed identity, can not be used at the same time as resourceID. When authenticating with Azure Workload Identity, this defaults to the AZURE_CLIENT_ID environment variable of the cert-manager controller
 	// +optional
 	ClientID string `json:"clientID,omitempty"`
 
@@ -523,7 +1070,7 @@ type AzureManagedIdentity struct {
 	ResourceID string `json:"resourceID,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=AzurePublicCloud;AzureChinaCloud;AzureGermanCloud;AzureUSGovernmentCloud
+// +kubebuilder:validation:Enum=AzurePublicCloud;AzureChinaCloud;AzureGermanCloud;AzureUSGovernmentCloud;AzureUSGovernment
 type AzureDNSEnvironment string
 
 const (
@@ -531,6 +1078,10 @@ const (
 	AzureChinaCloud        AzureDNSEnvironment = "AzureChinaCloud"
 	AzureGermanCloud       AzureDNSEnvironment = "AzureGermanCloud"
 	AzureUSGovernmentCloud AzureDNSEnvironment = "AzureUSGovernmentCloud"
+
+	// AzureUSGovernment is the name of the Azure US Government cloud used by
+	// the Azure CLI, accepted as an alias of AzureUSGovernmentCloud.
+	AzureUSGovernment AzureDNSEnvironment = "AzureUSGovernment"
 )
 
 // ACMEIssuerDNS01ProviderAcmeDNS is a structure containing the
@@ -539,6 +1090,14 @@ type ACMEIssuerDNS01ProviderAcmeDNS struct {
 	Host string `json:"host"`
 
 	AccountSecret cmmeta.SecretKeySelector `json:"accountSecretRef"`
+
+	// AutoRegister registers a new account with the acme-dns server for each
+	// domain which has no account in the account Secret, and adds its
+	// credentials to the Secret. The CNAME record which must be created for
+	// the domain is reported in the `cnameTarget` field of the Challenge's
+	// status.
+	// +optional
+	AutoRegister bool `json:"autoRegister,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderRFC2136 is a structure containing the
@@ -605,4 +1164,76 @@ type ACMEIssuerStatus struct {
 	// associated with the  Issuer
 	// +optional
 	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
+
+	// LastPrivateKeyHash is a hash of the public key of the private key
+	// associated with the latest registered ACME account. It is used to detect
+	// when the account private key of the Issuer has changed, in which case
+	// the account URI is looked up again.
+	// +optional
+	LastPrivateKeyHash string `json:"lastPrivateKeyHash,omitempty"`
+
+	// AccountKeyRotation is the value of the
+	// acme.cert-manager.io/account-key-rotation annotation when the private
+	// key of the ACME account was last rotated.
+	// +optional
+	AccountKeyRotation string `json:"accountKeyRotation,omitempty"`
+
+	// PreAuthorizations is the state of the authorizations of the DNS names
+	// listed in preAuthorizedDNSNames.
+	// +optional
+	PreAuthorizations []ACMEPreAuthorization `json:"preAuthorizations,omitempty"`
+
+	// CAAIdentities are the hostnames the ACME server advertises in its
+	// directory as referring to itself in CAA records. The issuer domain of
+	// CAA records of DNS names issued for must be one of them.
+	// +optional
+	CAAIdentities []string `json:"caaIdentities,omitempty"`
+
+	// TermsOfService is the URL of the ACME server's current terms of service,
+	// as advertised in its directory.
+	// +optional
+	TermsOfService string `json:"termsOfService,omitempty"`
+
+	// RateLimitBudgets is the rate limit budget of each registered domain
+	// this issuer has recently issued certificates for.
+	// +optional
+	RateLimitBudgets []ACMERateLimitBudgetStatus `json:"rateLimitBudgets,omitempty"`
+}
+
+// ACMEPreAuthorization is the state of an authorization requested for one of
+// the preAuthorizedDNSNames of an ACME Issuer.
+type ACMEPreAuthorization struct {
+	// DNSName is the DNS name which is pre-authorized.
+	DNSName string `json:"dnsName"`
+
+	// URL is the URL of the Authorization on the ACME server.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// State is the last known state of the Authorization.
+	// +optional
+	State State `json:"state,omitempty"`
+
+	// Expires is the time at which the Authorization expires.
+	// +optional
+	Expires *metav1.Time `json:"expires,omitempty"`
+
+	// Reason contains human readable information on why the Authorization
+	// could not be requested or is in a failed state.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ACMERateLimitBudgetStatus is the rate limit budget of a registered domain.
+type ACMERateLimitBudgetStatus struct {
+	// RegisteredDomain is the registered domain, such as example.com.
+	RegisteredDomain string `json:"registeredDomain"`
+
+	// Issued is the number of certificates issued for the registered domain
+	// by this issuer within the window.
+	Issued int `json:"issued"`
+
+	// Remaining is the number of certificates which can still be issued for
+	// the registered domain within the window.
+	Remaining int `json:"remaining"`
 }
@@ -27,10 +27,10 @@ import (
 	acme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	metav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
-	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	pkgapismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	pkgapismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -103,6 +103,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01HostPort)(nil), (*acme.ACMEChallengeSolverHTTP01HostPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(a.(*ACMEChallengeSolverHTTP01HostPort), b.(*acme.ACMEChallengeSolverHTTP01HostPort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01HostPort)(nil), (*ACMEChallengeSolverHTTP01HostPort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1beta1_ACMEChallengeSolverHTTP01HostPort(a.(*acme.ACMEChallengeSolverHTTP01HostPort), b.(*ACMEChallengeSolverHTTP01HostPort), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01Ingress)(nil), (*acme.ACMEChallengeSolverHTTP01Ingress)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(a.(*ACMEChallengeSolverHTTP01Ingress), b.(*acme.ACMEChallengeSolverHTTP01Ingress), scope)
 	}); err != nil {
@@ -163,6 +173,96 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01IstioVirtualService)(nil), (*acme.ACMEChallengeSolverHTTP01IstioVirtualService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(a.(*ACMEChallengeSolverHTTP01IstioVirtualService), b.(*acme.ACMEChallengeSolverHTTP01IstioVirtualService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01IstioVirtualService)(nil), (*ACMEChallengeSolverHTTP01IstioVirtualService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService(a.(*acme.ACMEChallengeSolverHTTP01IstioVirtualService), b.(*ACMEChallengeSolverHTTP01IstioVirtualService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), (*acme.ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(a.(*ACMEChallengeSolverHTTP01OpenShiftRoute), b.(*acme.ACMEChallengeSolverHTTP01OpenShiftRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), (*ACMEChallengeSolverHTTP01OpenShiftRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute(a.(*acme.ACMEChallengeSolverHTTP01OpenShiftRoute), b.(*ACMEChallengeSolverHTTP01OpenShiftRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), (*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(a.(*ACMEChallengeSolverHTTP01ProxiedSelfCheck), b.(*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), (*ACMEChallengeSolverHTTP01ProxiedSelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(a.(*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck), b.(*ACMEChallengeSolverHTTP01ProxiedSelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01SelfCheck)(nil), (*acme.ACMEChallengeSolverHTTP01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(a.(*ACMEChallengeSolverHTTP01SelfCheck), b.(*acme.ACMEChallengeSolverHTTP01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01SelfCheck)(nil), (*ACMEChallengeSolverHTTP01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01SelfCheck(a.(*acme.ACMEChallengeSolverHTTP01SelfCheck), b.(*ACMEChallengeSolverHTTP01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01Server)(nil), (*acme.ACMEChallengeSolverHTTP01Server)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(a.(*ACMEChallengeSolverHTTP01Server), b.(*acme.ACMEChallengeSolverHTTP01Server), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01Server)(nil), (*ACMEChallengeSolverHTTP01Server)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01Server_To_v1beta1_ACMEChallengeSolverHTTP01Server(a.(*acme.ACMEChallengeSolverHTTP01Server), b.(*ACMEChallengeSolverHTTP01Server), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), (*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(a.(*ACMEChallengeSolverHTTP01ServiceObjectMeta), b.(*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), (*ACMEChallengeSolverHTTP01ServiceObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta(a.(*acme.ACMEChallengeSolverHTTP01ServiceObjectMeta), b.(*ACMEChallengeSolverHTTP01ServiceObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01ServiceReference)(nil), (*acme.ACMEChallengeSolverHTTP01ServiceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(a.(*ACMEChallengeSolverHTTP01ServiceReference), b.(*acme.ACMEChallengeSolverHTTP01ServiceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ServiceReference)(nil), (*ACMEChallengeSolverHTTP01ServiceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1beta1_ACMEChallengeSolverHTTP01ServiceReference(a.(*acme.ACMEChallengeSolverHTTP01ServiceReference), b.(*ACMEChallengeSolverHTTP01ServiceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01ServiceTemplate)(nil), (*acme.ACMEChallengeSolverHTTP01ServiceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(a.(*ACMEChallengeSolverHTTP01ServiceTemplate), b.(*acme.ACMEChallengeSolverHTTP01ServiceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01ServiceTemplate)(nil), (*ACMEChallengeSolverHTTP01ServiceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate(a.(*acme.ACMEChallengeSolverHTTP01ServiceTemplate), b.(*ACMEChallengeSolverHTTP01ServiceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEDNS01SelfCheck)(nil), (*acme.ACMEDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(a.(*ACMEDNS01SelfCheck), b.(*acme.ACMEDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEDNS01SelfCheck)(nil), (*ACMEDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEDNS01SelfCheck_To_v1beta1_ACMEDNS01SelfCheck(a.(*acme.ACMEDNS01SelfCheck), b.(*ACMEDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -173,6 +273,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEHTTPClient)(nil), (*acme.ACMEHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEHTTPClient_To_acme_ACMEHTTPClient(a.(*ACMEHTTPClient), b.(*acme.ACMEHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEHTTPClient)(nil), (*ACMEHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEHTTPClient_To_v1beta1_ACMEHTTPClient(a.(*acme.ACMEHTTPClient), b.(*ACMEHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderAcmeDNS)(nil), (*acme.ACMEIssuerDNS01ProviderAcmeDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01ProviderAcmeDNS(a.(*ACMEIssuerDNS01ProviderAcmeDNS), b.(*acme.ACMEIssuerDNS01ProviderAcmeDNS), scope)
 	}); err != nil {
@@ -273,6 +383,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEPreAuthorization)(nil), (*acme.ACMEPreAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(a.(*ACMEPreAuthorization), b.(*acme.ACMEPreAuthorization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEPreAuthorization)(nil), (*ACMEPreAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEPreAuthorization_To_v1beta1_ACMEPreAuthorization(a.(*acme.ACMEPreAuthorization), b.(*ACMEPreAuthorization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEProblem)(nil), (*acme.ACMEProblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEProblem_To_acme_ACMEProblem(a.(*ACMEProblem), b.(*acme.ACMEProblem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEProblem)(nil), (*ACMEProblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEProblem_To_v1beta1_ACMEProblem(a.(*acme.ACMEProblem), b.(*ACMEProblem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMERateLimitBudget)(nil), (*acme.ACMERateLimitBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(a.(*ACMERateLimitBudget), b.(*acme.ACMERateLimitBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMERateLimitBudget)(nil), (*ACMERateLimitBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMERateLimitBudget_To_v1beta1_ACMERateLimitBudget(a.(*acme.ACMERateLimitBudget), b.(*ACMERateLimitBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMERateLimitBudgetStatus)(nil), (*acme.ACMERateLimitBudgetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(a.(*ACMERateLimitBudgetStatus), b.(*acme.ACMERateLimitBudgetStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMERateLimitBudgetStatus)(nil), (*ACMERateLimitBudgetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMERateLimitBudgetStatus_To_v1beta1_ACMERateLimitBudgetStatus(a.(*acme.ACMERateLimitBudgetStatus), b.(*ACMERateLimitBudgetStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMESubproblem)(nil), (*acme.ACMESubproblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMESubproblem_To_acme_ACMESubproblem(a.(*ACMESubproblem), b.(*acme.ACMESubproblem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMESubproblem)(nil), (*ACMESubproblem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMESubproblem_To_v1beta1_ACMESubproblem(a.(*acme.ACMESubproblem), b.(*ACMESubproblem), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AzureManagedIdentity)(nil), (*acme.AzureManagedIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AzureManagedIdentity_To_acme_AzureManagedIdentity(a.(*AzureManagedIdentity), b.(*acme.AzureManagedIdentity), scope)
 	}); err != nil {
@@ -303,6 +463,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ChallengeCondition)(nil), (*acme.ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ChallengeCondition_To_acme_ChallengeCondition(a.(*ChallengeCondition), b.(*acme.ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeCondition)(nil), (*ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeCondition_To_v1beta1_ChallengeCondition(a.(*acme.ChallengeCondition), b.(*ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ChallengeList)(nil), (*acme.ChallengeList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ChallengeList_To_acme_ChallengeList(a.(*ChallengeList), b.(*acme.ChallengeList), scope)
 	}); err != nil {
@@ -373,6 +543,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Route53AssumeRole)(nil), (*acme.Route53AssumeRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Route53AssumeRole_To_acme_Route53AssumeRole(a.(*Route53AssumeRole), b.(*acme.Route53AssumeRole), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.Route53AssumeRole)(nil), (*Route53AssumeRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_Route53AssumeRole_To_v1beta1_Route53AssumeRole(a.(*acme.Route53AssumeRole), b.(*Route53AssumeRole), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*acme.ACMEIssuer)(nil), (*ACMEIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(a.(*acme.ACMEIssuer), b.(*ACMEIssuer), scope)
 	}); err != nil {
@@ -389,9 +569,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 func autoConvert_v1beta1_ACMEAuthorization_To_acme_ACMEAuthorization(in *ACMEAuthorization, out *acme.ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
+	out.IdentifierType = acme.ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = acme.State(in.InitialState)
 	out.Challenges = *(*[]acme.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.Problem = (*acme.ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -403,9 +585,11 @@ func Convert_v1beta1_ACMEAuthorization_To_acme_ACMEAuthorization(in *ACMEAuthori
 func autoConvert_acme_ACMEAuthorization_To_v1beta1_ACMEAuthorization(in *acme.ACMEAuthorization, out *ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
+	out.IdentifierType = ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = State(in.InitialState)
 	out.Challenges = *(*[]ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.Problem = (*ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -480,6 +664,11 @@ func Convert_acme_ACMEChallengeSolver_To_v1beta1_ACMEChallengeSolver(in *acme.AC
 
 func autoConvert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.TTL = (*int)(unsafe.Pointer(in.TTL))
+	out.ZoneMap = *(*map[string]string)(unsafe.Pointer(&in.ZoneMap))
+	out.CleanupPolicy = acme.DNS01CleanupPolicy(in.CleanupPolicy)
+	out.RecursiveNameservers = *(*[]string)(unsafe.Pointer(&in.RecursiveNameservers))
+	out.PropagationDelay = (*apismetav1.Duration)(unsafe.Pointer(in.PropagationDelay))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -563,6 +752,11 @@ func Convert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(i
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = CNAMEStrategy(in.CNAMEStrategy)
+	out.TTL = (*int)(unsafe.Pointer(in.TTL))
+	out.ZoneMap = *(*map[string]string)(unsafe.Pointer(&in.ZoneMap))
+	out.CleanupPolicy = DNS01CleanupPolicy(in.CleanupPolicy)
+	out.RecursiveNameservers = *(*[]string)(unsafe.Pointer(&in.RecursiveNameservers))
+	out.PropagationDelay = (*apismetav1.Duration)(unsafe.Pointer(in.PropagationDelay))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(ACMEIssuerDNS01ProviderAkamai)
@@ -647,6 +841,12 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS01(i
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.IstioVirtualService = (*acme.ACMEChallengeSolverHTTP01IstioVirtualService)(unsafe.Pointer(in.IstioVirtualService))
+	out.OpenShiftRoute = (*acme.ACMEChallengeSolverHTTP01OpenShiftRoute)(unsafe.Pointer(in.OpenShiftRoute))
+	out.HostPort = (*acme.ACMEChallengeSolverHTTP01HostPort)(unsafe.Pointer(in.HostPort))
+	out.ProxiedSelfCheck = (*acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck)(unsafe.Pointer(in.ProxiedSelfCheck))
+	out.SelfCheck = (*acme.ACMEChallengeSolverHTTP01SelfCheck)(unsafe.Pointer(in.SelfCheck))
+	out.Server = (*acme.ACMEChallengeSolverHTTP01Server)(unsafe.Pointer(in.Server))
 	return nil
 }
 
@@ -658,6 +858,12 @@ func Convert_v1beta1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.IstioVirtualService = (*ACMEChallengeSolverHTTP01IstioVirtualService)(unsafe.Pointer(in.IstioVirtualService))
+	out.OpenShiftRoute = (*ACMEChallengeSolverHTTP01OpenShiftRoute)(unsafe.Pointer(in.OpenShiftRoute))
+	out.HostPort = (*ACMEChallengeSolverHTTP01HostPort)(unsafe.Pointer(in.HostPort))
+	out.ProxiedSelfCheck = (*ACMEChallengeSolverHTTP01ProxiedSelfCheck)(unsafe.Pointer(in.ProxiedSelfCheck))
+	out.SelfCheck = (*ACMEChallengeSolverHTTP01SelfCheck)(unsafe.Pointer(in.SelfCheck))
+	out.Server = (*ACMEChallengeSolverHTTP01Server)(unsafe.Pointer(in.Server))
 	return nil
 }
 
@@ -690,12 +896,33 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1beta1_ACMEChall
 	return autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1beta1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(in *ACMEChallengeSolverHTTP01HostPort, out *acme.ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(in *ACMEChallengeSolverHTTP01HostPort, out *acme.ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01HostPort_To_acme_ACMEChallengeSolverHTTP01HostPort(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1beta1_ACMEChallengeSolverHTTP01HostPort(in *acme.ACMEChallengeSolverHTTP01HostPort, out *ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1beta1_ACMEChallengeSolverHTTP01HostPort is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1beta1_ACMEChallengeSolverHTTP01HostPort(in *acme.ACMEChallengeSolverHTTP01HostPort, out *ACMEChallengeSolverHTTP01HostPort, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01HostPort_To_v1beta1_ACMEChallengeSolverHTTP01HostPort(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.ServiceTemplate = (*acme.ACMEChallengeSolverHTTP01ServiceTemplate)(unsafe.Pointer(in.ServiceTemplate))
 	return nil
 }
 
@@ -710,6 +937,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1beta1_ACMEChallengeS
 	out.Name = in.Name
 	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.ServiceTemplate = (*ACMEChallengeSolverHTTP01ServiceTemplate)(unsafe.Pointer(in.ServiceTemplate))
 	return nil
 }
 
@@ -844,6 +1072,220 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChalle
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(in *ACMEChallengeSolverHTTP01IstioVirtualService, out *acme.ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.Gateways = *(*[]string)(unsafe.Pointer(&in.Gateways))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(in *ACMEChallengeSolverHTTP01IstioVirtualService, out *acme.ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService_To_acme_ACMEChallengeSolverHTTP01IstioVirtualService(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService(in *acme.ACMEChallengeSolverHTTP01IstioVirtualService, out *ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.Gateways = *(*[]string)(unsafe.Pointer(&in.Gateways))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService(in *acme.ACMEChallengeSolverHTTP01IstioVirtualService, out *ACMEChallengeSolverHTTP01IstioVirtualService, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01IstioVirtualService_To_v1beta1_ACMEChallengeSolverHTTP01IstioVirtualService(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(in *ACMEChallengeSolverHTTP01OpenShiftRoute, out *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(in *ACMEChallengeSolverHTTP01OpenShiftRoute, out *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute_To_acme_ACMEChallengeSolverHTTP01OpenShiftRoute(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute(in *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, out *ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute(in *acme.ACMEChallengeSolverHTTP01OpenShiftRoute, out *ACMEChallengeSolverHTTP01OpenShiftRoute, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01OpenShiftRoute_To_v1beta1_ACMEChallengeSolverHTTP01OpenShiftRoute(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	out.Strategy = acme.HTTP01ProxiedSelfCheckStrategy(in.Strategy)
+	out.Service = (*acme.ACMEChallengeSolverHTTP01ServiceReference)(unsafe.Pointer(in.Service))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	out.Strategy = HTTP01ProxiedSelfCheckStrategy(in.Strategy)
+	out.Service = (*ACMEChallengeSolverHTTP01ServiceReference)(unsafe.Pointer(in.Service))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in *acme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, out *ACMEChallengeSolverHTTP01ProxiedSelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ProxiedSelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01ProxiedSelfCheck(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(in *ACMEChallengeSolverHTTP01SelfCheck, out *acme.ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.ProxyURL = in.ProxyURL
+	out.Host = in.Host
+	out.IPFamily = acme.HTTP01SelfCheckIPFamily(in.IPFamily)
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(in *ACMEChallengeSolverHTTP01SelfCheck, out *acme.ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01SelfCheck_To_acme_ACMEChallengeSolverHTTP01SelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01SelfCheck(in *acme.ACMEChallengeSolverHTTP01SelfCheck, out *ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	out.Disabled = in.Disabled
+	out.ProxyURL = in.ProxyURL
+	out.Host = in.Host
+	out.IPFamily = HTTP01SelfCheckIPFamily(in.IPFamily)
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01SelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01SelfCheck(in *acme.ACMEChallengeSolverHTTP01SelfCheck, out *ACMEChallengeSolverHTTP01SelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01SelfCheck_To_v1beta1_ACMEChallengeSolverHTTP01SelfCheck(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(in *ACMEChallengeSolverHTTP01Server, out *acme.ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	out.ListenPort = in.ListenPort
+	out.ReadTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadTimeout))
+	out.ReadHeaderTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadHeaderTimeout))
+	out.ShutdownDrainPeriod = (*apismetav1.Duration)(unsafe.Pointer(in.ShutdownDrainPeriod))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(in *ACMEChallengeSolverHTTP01Server, out *acme.ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01Server_To_acme_ACMEChallengeSolverHTTP01Server(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01Server_To_v1beta1_ACMEChallengeSolverHTTP01Server(in *acme.ACMEChallengeSolverHTTP01Server, out *ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	out.ListenPort = in.ListenPort
+	out.ReadTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadTimeout))
+	out.ReadHeaderTimeout = (*apismetav1.Duration)(unsafe.Pointer(in.ReadHeaderTimeout))
+	out.ShutdownDrainPeriod = (*apismetav1.Duration)(unsafe.Pointer(in.ShutdownDrainPeriod))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01Server_To_v1beta1_ACMEChallengeSolverHTTP01Server is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01Server_To_v1beta1_ACMEChallengeSolverHTTP01Server(in *acme.ACMEChallengeSolverHTTP01Server, out *ACMEChallengeSolverHTTP01Server, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01Server_To_v1beta1_ACMEChallengeSolverHTTP01Server(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *ACMEChallengeSolverHTTP01ServiceObjectMeta, out *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *ACMEChallengeSolverHTTP01ServiceObjectMeta, out *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, out *ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta(in *acme.ACMEChallengeSolverHTTP01ServiceObjectMeta, out *ACMEChallengeSolverHTTP01ServiceObjectMeta, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(in *ACMEChallengeSolverHTTP01ServiceReference, out *acme.ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(in *ACMEChallengeSolverHTTP01ServiceReference, out *acme.ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01ServiceReference_To_acme_ACMEChallengeSolverHTTP01ServiceReference(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1beta1_ACMEChallengeSolverHTTP01ServiceReference(in *acme.ACMEChallengeSolverHTTP01ServiceReference, out *ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1beta1_ACMEChallengeSolverHTTP01ServiceReference is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1beta1_ACMEChallengeSolverHTTP01ServiceReference(in *acme.ACMEChallengeSolverHTTP01ServiceReference, out *ACMEChallengeSolverHTTP01ServiceReference, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ServiceReference_To_v1beta1_ACMEChallengeSolverHTTP01ServiceReference(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(in *ACMEChallengeSolverHTTP01ServiceTemplate, out *acme.ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	if err := Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta(&in.ACMEChallengeSolverHTTP01ServiceObjectMeta, &out.ACMEChallengeSolverHTTP01ServiceObjectMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(in *ACMEChallengeSolverHTTP01ServiceTemplate, out *acme.ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate_To_acme_ACMEChallengeSolverHTTP01ServiceTemplate(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate(in *acme.ACMEChallengeSolverHTTP01ServiceTemplate, out *ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	if err := Convert_acme_ACMEChallengeSolverHTTP01ServiceObjectMeta_To_v1beta1_ACMEChallengeSolverHTTP01ServiceObjectMeta(&in.ACMEChallengeSolverHTTP01ServiceObjectMeta, &out.ACMEChallengeSolverHTTP01ServiceObjectMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate(in *acme.ACMEChallengeSolverHTTP01ServiceTemplate, out *ACMEChallengeSolverHTTP01ServiceTemplate, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01ServiceTemplate_To_v1beta1_ACMEChallengeSolverHTTP01ServiceTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(in *ACMEDNS01SelfCheck, out *acme.ACMEDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = acme.DNS01SelfCheckNameservers(in.Nameservers)
+	out.PollInterval = (*apismetav1.Duration)(unsafe.Pointer(in.PollInterval))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1beta1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck is an autogenerated conversion function.
+func Convert_v1beta1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(in *ACMEDNS01SelfCheck, out *acme.ACMEDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEDNS01SelfCheck_To_acme_ACMEDNS01SelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEDNS01SelfCheck_To_v1beta1_ACMEDNS01SelfCheck(in *acme.ACMEDNS01SelfCheck, out *ACMEDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = DNS01SelfCheckNameservers(in.Nameservers)
+	out.PollInterval = (*apismetav1.Duration)(unsafe.Pointer(in.PollInterval))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_acme_ACMEDNS01SelfCheck_To_v1beta1_ACMEDNS01SelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEDNS01SelfCheck_To_v1beta1_ACMEDNS01SelfCheck(in *acme.ACMEDNS01SelfCheck, out *ACMEDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEDNS01SelfCheck_To_v1beta1_ACMEDNS01SelfCheck(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
@@ -872,11 +1314,37 @@ func Convert_acme_ACMEExternalAccountBinding_To_v1beta1_ACMEExternalAccountBindi
 	return autoConvert_acme_ACMEExternalAccountBinding_To_v1beta1_ACMEExternalAccountBinding(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEHTTPClient_To_acme_ACMEHTTPClient(in *ACMEHTTPClient, out *acme.ACMEHTTPClient, s conversion.Scope) error {
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.KeepAlive = (*apismetav1.Duration)(unsafe.Pointer(in.KeepAlive))
+	out.ProxyURL = in.ProxyURL
+	return nil
+}
+
+// Convert_v1beta1_ACMEHTTPClient_To_acme_ACMEHTTPClient is an autogenerated conversion function.
+func Convert_v1beta1_ACMEHTTPClient_To_acme_ACMEHTTPClient(in *ACMEHTTPClient, out *acme.ACMEHTTPClient, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEHTTPClient_To_acme_ACMEHTTPClient(in, out, s)
+}
+
+func autoConvert_acme_ACMEHTTPClient_To_v1beta1_ACMEHTTPClient(in *acme.ACMEHTTPClient, out *ACMEHTTPClient, s conversion.Scope) error {
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.KeepAlive = (*apismetav1.Duration)(unsafe.Pointer(in.KeepAlive))
+	out.ProxyURL = in.ProxyURL
+	return nil
+}
+
+// Convert_acme_ACMEHTTPClient_To_v1beta1_ACMEHTTPClient is an autogenerated conversion function.
+func Convert_acme_ACMEHTTPClient_To_v1beta1_ACMEHTTPClient(in *acme.ACMEHTTPClient, out *ACMEHTTPClient, s conversion.Scope) error {
+	return autoConvert_acme_ACMEHTTPClient_To_v1beta1_ACMEHTTPClient(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acme.ACMEIssuer, s conversion.Scope) error {
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
+	out.HTTPClient = (*acme.ACMEHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(acme.ACMEExternalAccountBinding)
@@ -901,7 +1369,15 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acme
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.OnlyReturnExistingAccount = in.OnlyReturnExistingAccount
+	out.DeactivateAccountOnDeletion = in.DeactivateAccountOnDeletion
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PreAuthorizedDNSNames = *(*[]string)(unsafe.Pointer(&in.PreAuthorizedDNSNames))
+	out.MaxConcurrentChallenges = (*int)(unsafe.Pointer(in.MaxConcurrentChallenges))
+	out.MaxIdentifiersPerOrder = (*int)(unsafe.Pointer(in.MaxIdentifiersPerOrder))
+	out.ProcessingOrderRecheckInterval = (*apismetav1.Duration)(unsafe.Pointer(in.ProcessingOrderRecheckInterval))
+	out.DNS01SelfCheck = (*acme.ACMEDNS01SelfCheck)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.RateLimitBudget = (*acme.ACMERateLimitBudget)(unsafe.Pointer(in.RateLimitBudget))
 	return nil
 }
 
@@ -909,7 +1385,9 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.Profile = in.Profile
 	out.SkipTLSVerify = in.SkipTLSVerify
+	out.HTTPClient = (*ACMEHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(ACMEExternalAccountBinding)
@@ -934,7 +1412,15 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.OnlyReturnExistingAccount = in.OnlyReturnExistingAccount
+	out.DeactivateAccountOnDeletion = in.DeactivateAccountOnDeletion
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PreAuthorizedDNSNames = *(*[]string)(unsafe.Pointer(&in.PreAuthorizedDNSNames))
+	out.MaxConcurrentChallenges = (*int)(unsafe.Pointer(in.MaxConcurrentChallenges))
+	out.MaxIdentifiersPerOrder = (*int)(unsafe.Pointer(in.MaxIdentifiersPerOrder))
+	out.ProcessingOrderRecheckInterval = (*apismetav1.Duration)(unsafe.Pointer(in.ProcessingOrderRecheckInterval))
+	out.DNS01SelfCheck = (*ACMEDNS01SelfCheck)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.RateLimitBudget = (*ACMERateLimitBudget)(unsafe.Pointer(in.RateLimitBudget))
 	return nil
 }
 
@@ -943,6 +1429,7 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01P
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -956,6 +1443,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAcmeDNS_To_v1beta1_ACMEIssuerDNS01P
 	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
+	out.AutoRegister = in.AutoRegister
 	return nil
 }
 
@@ -1031,7 +1519,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1beta1_ACMEIssuerDNS01
 	out.ClientID = in.ClientID
 	if in.ClientSecret != nil {
 		in, out := &in.ClientSecret, &out.ClientSecret
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1064,6 +1552,7 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1075,7 +1564,7 @@ func Convert_v1beta1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01Prov
 func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNS(in *acme.ACMEIssuerDNS01ProviderCloudDNS, out *ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1084,6 +1573,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1beta1_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1112,6 +1602,7 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.ZoneIDs))
 	return nil
 }
 
@@ -1124,7 +1615,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1beta1_ACMEIssuerDNS
 	out.Email = in.Email
 	if in.APIKey != nil {
 		in, out := &in.APIKey, &out.APIKey
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1133,13 +1624,14 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1beta1_ACMEIssuerDNS
 	}
 	if in.APIToken != nil {
 		in, out := &in.APIToken, &out.APIToken
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.ZoneIDs))
 	return nil
 }
 
@@ -1217,7 +1709,11 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01P
 		return err
 	}
 	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	out.RoleChain = *(*[]acme.Route53AssumeRole)(unsafe.Pointer(&in.RoleChain))
 	out.HostedZoneID = in.HostedZoneID
+	out.HostedZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.HostedZoneIDs))
 	out.Region = in.Region
 	return nil
 }
@@ -1231,7 +1727,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01P
 	out.AccessKeyID = in.AccessKeyID
 	if in.SecretAccessKeyID != nil {
 		in, out := &in.SecretAccessKeyID, &out.SecretAccessKeyID
-		*out = new(apismetav1.SecretKeySelector)
+		*out = new(pkgapismetav1.SecretKeySelector)
 		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
@@ -1242,7 +1738,11 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01P
 		return err
 	}
 	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	out.RoleChain = *(*[]Route53AssumeRole)(unsafe.Pointer(&in.RoleChain))
 	out.HostedZoneID = in.HostedZoneID
+	out.HostedZoneIDs = *(*map[string]string)(unsafe.Pointer(&in.HostedZoneIDs))
 	out.Region = in.Region
 	return nil
 }
@@ -1279,6 +1779,12 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1beta1_ACMEIssuerDNS01Provi
 func autoConvert_v1beta1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.AccountKeyRotation = in.AccountKeyRotation
+	out.PreAuthorizations = *(*[]acme.ACMEPreAuthorization)(unsafe.Pointer(&in.PreAuthorizations))
+	out.CAAIdentities = *(*[]string)(unsafe.Pointer(&in.CAAIdentities))
+	out.TermsOfService = in.TermsOfService
+	out.RateLimitBudgets = *(*[]acme.ACMERateLimitBudgetStatus)(unsafe.Pointer(&in.RateLimitBudgets))
 	return nil
 }
 
@@ -1290,6 +1796,12 @@ func Convert_v1beta1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerSta
 func autoConvert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.AccountKeyRotation = in.AccountKeyRotation
+	out.PreAuthorizations = *(*[]ACMEPreAuthorization)(unsafe.Pointer(&in.PreAuthorizations))
+	out.CAAIdentities = *(*[]string)(unsafe.Pointer(&in.CAAIdentities))
+	out.TermsOfService = in.TermsOfService
+	out.RateLimitBudgets = *(*[]ACMERateLimitBudgetStatus)(unsafe.Pointer(&in.RateLimitBudgets))
 	return nil
 }
 
@@ -1298,6 +1810,134 @@ func Convert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in *acme.ACMEIssu
 	return autoConvert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(in *ACMEPreAuthorization, out *acme.ACMEPreAuthorization, s conversion.Scope) error {
+	out.DNSName = in.DNSName
+	out.URL = in.URL
+	out.State = acme.State(in.State)
+	out.Expires = (*apismetav1.Time)(unsafe.Pointer(in.Expires))
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_v1beta1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization is an autogenerated conversion function.
+func Convert_v1beta1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(in *ACMEPreAuthorization, out *acme.ACMEPreAuthorization, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEPreAuthorization_To_acme_ACMEPreAuthorization(in, out, s)
+}
+
+func autoConvert_acme_ACMEPreAuthorization_To_v1beta1_ACMEPreAuthorization(in *acme.ACMEPreAuthorization, out *ACMEPreAuthorization, s conversion.Scope) error {
+	out.DNSName = in.DNSName
+	out.URL = in.URL
+	out.State = State(in.State)
+	out.Expires = (*apismetav1.Time)(unsafe.Pointer(in.Expires))
+	out.Reason = in.Reason
+	return nil
+}
+
+// Convert_acme_ACMEPreAuthorization_To_v1beta1_ACMEPreAuthorization is an autogenerated conversion function.
+func Convert_acme_ACMEPreAuthorization_To_v1beta1_ACMEPreAuthorization(in *acme.ACMEPreAuthorization, out *ACMEPreAuthorization, s conversion.Scope) error {
+	return autoConvert_acme_ACMEPreAuthorization_To_v1beta1_ACMEPreAuthorization(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEProblem_To_acme_ACMEProblem(in *ACMEProblem, out *acme.ACMEProblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Status = in.Status
+	out.Subproblems = *(*[]acme.ACMESubproblem)(unsafe.Pointer(&in.Subproblems))
+	return nil
+}
+
+// Convert_v1beta1_ACMEProblem_To_acme_ACMEProblem is an autogenerated conversion function.
+func Convert_v1beta1_ACMEProblem_To_acme_ACMEProblem(in *ACMEProblem, out *acme.ACMEProblem, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEProblem_To_acme_ACMEProblem(in, out, s)
+}
+
+func autoConvert_acme_ACMEProblem_To_v1beta1_ACMEProblem(in *acme.ACMEProblem, out *ACMEProblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Status = in.Status
+	out.Subproblems = *(*[]ACMESubproblem)(unsafe.Pointer(&in.Subproblems))
+	return nil
+}
+
+// Convert_acme_ACMEProblem_To_v1beta1_ACMEProblem is an autogenerated conversion function.
+func Convert_acme_ACMEProblem_To_v1beta1_ACMEProblem(in *acme.ACMEProblem, out *ACMEProblem, s conversion.Scope) error {
+	return autoConvert_acme_ACMEProblem_To_v1beta1_ACMEProblem(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(in *ACMERateLimitBudget, out *acme.ACMERateLimitBudget, s conversion.Scope) error {
+	out.CertificatesPerRegisteredDomain = in.CertificatesPerRegisteredDomain
+	out.Window = (*apismetav1.Duration)(unsafe.Pointer(in.Window))
+	out.RenewalDelayThreshold = in.RenewalDelayThreshold
+	return nil
+}
+
+// Convert_v1beta1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget is an autogenerated conversion function.
+func Convert_v1beta1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(in *ACMERateLimitBudget, out *acme.ACMERateLimitBudget, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMERateLimitBudget_To_acme_ACMERateLimitBudget(in, out, s)
+}
+
+func autoConvert_acme_ACMERateLimitBudget_To_v1beta1_ACMERateLimitBudget(in *acme.ACMERateLimitBudget, out *ACMERateLimitBudget, s conversion.Scope) error {
+	out.CertificatesPerRegisteredDomain = in.CertificatesPerRegisteredDomain
+	out.Window = (*apismetav1.Duration)(unsafe.Pointer(in.Window))
+	out.RenewalDelayThreshold = in.RenewalDelayThreshold
+	return nil
+}
+
+// Convert_acme_ACMERateLimitBudget_To_v1beta1_ACMERateLimitBudget is an autogenerated conversion function.
+func Convert_acme_ACMERateLimitBudget_To_v1beta1_ACMERateLimitBudget(in *acme.ACMERateLimitBudget, out *ACMERateLimitBudget, s conversion.Scope) error {
+	return autoConvert_acme_ACMERateLimitBudget_To_v1beta1_ACMERateLimitBudget(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(in *ACMERateLimitBudgetStatus, out *acme.ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	out.RegisteredDomain = in.RegisteredDomain
+	out.Issued = in.Issued
+	out.Remaining = in.Remaining
+	return nil
+}
+
+// Convert_v1beta1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus is an autogenerated conversion function.
+func Convert_v1beta1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(in *ACMERateLimitBudgetStatus, out *acme.ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMERateLimitBudgetStatus_To_acme_ACMERateLimitBudgetStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMERateLimitBudgetStatus_To_v1beta1_ACMERateLimitBudgetStatus(in *acme.ACMERateLimitBudgetStatus, out *ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	out.RegisteredDomain = in.RegisteredDomain
+	out.Issued = in.Issued
+	out.Remaining = in.Remaining
+	return nil
+}
+
+// Convert_acme_ACMERateLimitBudgetStatus_To_v1beta1_ACMERateLimitBudgetStatus is an autogenerated conversion function.
+func Convert_acme_ACMERateLimitBudgetStatus_To_v1beta1_ACMERateLimitBudgetStatus(in *acme.ACMERateLimitBudgetStatus, out *ACMERateLimitBudgetStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMERateLimitBudgetStatus_To_v1beta1_ACMERateLimitBudgetStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMESubproblem_To_acme_ACMESubproblem(in *ACMESubproblem, out *acme.ACMESubproblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Identifier = in.Identifier
+	out.IdentifierType = acme.ACMEIdentifierType(in.IdentifierType)
+	return nil
+}
+
+// Convert_v1beta1_ACMESubproblem_To_acme_ACMESubproblem is an autogenerated conversion function.
+func Convert_v1beta1_ACMESubproblem_To_acme_ACMESubproblem(in *ACMESubproblem, out *acme.ACMESubproblem, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMESubproblem_To_acme_ACMESubproblem(in, out, s)
+}
+
+func autoConvert_acme_ACMESubproblem_To_v1beta1_ACMESubproblem(in *acme.ACMESubproblem, out *ACMESubproblem, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Detail = in.Detail
+	out.Identifier = in.Identifier
+	out.IdentifierType = ACMEIdentifierType(in.IdentifierType)
+	return nil
+}
+
+// Convert_acme_ACMESubproblem_To_v1beta1_ACMESubproblem is an autogenerated conversion function.
+func Convert_acme_ACMESubproblem_To_v1beta1_ACMESubproblem(in *acme.ACMESubproblem, out *ACMESubproblem, s conversion.Scope) error {
+	return autoConvert_acme_ACMESubproblem_To_v1beta1_ACMESubproblem(in, out, s)
+}
+
 func autoConvert_v1beta1_AzureManagedIdentity_To_acme_AzureManagedIdentity(in *AzureManagedIdentity, out *acme.AzureManagedIdentity, s conversion.Scope) error {
 	out.ClientID = in.ClientID
 	out.ResourceID = in.ResourceID
@@ -1376,6 +2016,34 @@ func Convert_acme_Challenge_To_v1beta1_Challenge(in *acme.Challenge, out *Challe
 	return autoConvert_acme_Challenge_To_v1beta1_Challenge(in, out, s)
 }
 
+func autoConvert_v1beta1_ChallengeCondition_To_acme_ChallengeCondition(in *ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	out.Type = acme.ChallengeConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1beta1_ChallengeCondition_To_acme_ChallengeCondition is an autogenerated conversion function.
+func Convert_v1beta1_ChallengeCondition_To_acme_ChallengeCondition(in *ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_v1beta1_ChallengeCondition_To_acme_ChallengeCondition(in, out, s)
+}
+
+func autoConvert_acme_ChallengeCondition_To_v1beta1_ChallengeCondition(in *acme.ChallengeCondition, out *ChallengeCondition, s conversion.Scope) error {
+	out.Type = ChallengeConditionType(in.Type)
+	out.Status = pkgapismetav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_acme_ChallengeCondition_To_v1beta1_ChallengeCondition is an autogenerated conversion function.
+func Convert_acme_ChallengeCondition_To_v1beta1_ChallengeCondition(in *acme.ChallengeCondition, out *ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeCondition_To_v1beta1_ChallengeCondition(in, out, s)
+}
+
 func autoConvert_v1beta1_ChallengeList_To_acme_ChallengeList(in *ChallengeList, out *acme.ChallengeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1422,6 +2090,7 @@ func autoConvert_v1beta1_ChallengeSpec_To_acme_ChallengeSpec(in *ChallengeSpec,
 	out.URL = in.URL
 	out.AuthorizationURL = in.AuthorizationURL
 	out.DNSName = in.DNSName
+	out.IdentifierType = acme.ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = in.Wildcard
 	out.Type = acme.ACMEChallengeType(in.Type)
 	out.Token = in.Token
@@ -1444,6 +2113,7 @@ func autoConvert_acme_ChallengeSpec_To_v1beta1_ChallengeSpec(in *acme.ChallengeS
 	out.URL = in.URL
 	out.AuthorizationURL = in.AuthorizationURL
 	out.DNSName = in.DNSName
+	out.IdentifierType = ACMEIdentifierType(in.IdentifierType)
 	out.Wildcard = in.Wildcard
 	out.Type = ACMEChallengeType(in.Type)
 	out.Token = in.Token
@@ -1465,8 +2135,14 @@ func Convert_acme_ChallengeSpec_To_v1beta1_ChallengeSpec(in *acme.ChallengeSpec,
 func autoConvert_v1beta1_ChallengeStatus_To_acme_ChallengeStatus(in *ChallengeStatus, out *acme.ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*apismetav1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.Step = acme.ChallengeStep(in.Step)
+	out.Problem = (*acme.ACMEProblem)(unsafe.Pointer(in.Problem))
+	out.FailedCleanUpAttempts = in.FailedCleanUpAttempts
+	out.CNAMETarget = in.CNAMETarget
+	out.Conditions = *(*[]acme.ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1478,8 +2154,14 @@ func Convert_v1beta1_ChallengeStatus_To_acme_ChallengeStatus(in *ChallengeStatus
 func autoConvert_acme_ChallengeStatus_To_v1beta1_ChallengeStatus(in *acme.ChallengeStatus, out *ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*apismetav1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = State(in.State)
+	out.Step = ChallengeStep(in.Step)
+	out.Problem = (*ACMEProblem)(unsafe.Pointer(in.Problem))
+	out.FailedCleanUpAttempts = in.FailedCleanUpAttempts
+	out.CNAMETarget = in.CNAMETarget
+	out.Conditions = *(*[]ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1570,7 +2252,8 @@ func autoConvert_v1beta1_OrderSpec_To_acme_OrderSpec(in *OrderSpec, out *acme.Or
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -1587,7 +2270,8 @@ func autoConvert_acme_OrderSpec_To_v1beta1_OrderSpec(in *acme.OrderSpec, out *Or
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Profile = in.Profile
 	return nil
 }
 
@@ -1603,7 +2287,9 @@ func autoConvert_v1beta1_OrderStatus_To_acme_OrderStatus(in *OrderStatus, out *a
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*apismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.Problem = (*acme.ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -1619,7 +2305,9 @@ func autoConvert_acme_OrderStatus_To_v1beta1_OrderStatus(in *acme.OrderStatus, o
 	out.State = State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*apismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.Problem = (*ACMEProblem)(unsafe.Pointer(in.Problem))
 	return nil
 }
 
@@ -1627,3 +2315,27 @@ func autoConvert_acme_OrderStatus_To_v1beta1_OrderStatus(in *acme.OrderStatus, o
 func Convert_acme_OrderStatus_To_v1beta1_OrderStatus(in *acme.OrderStatus, out *OrderStatus, s conversion.Scope) error {
 	return autoConvert_acme_OrderStatus_To_v1beta1_OrderStatus(in, out, s)
 }
+
+func autoConvert_v1beta1_Route53AssumeRole_To_acme_Route53AssumeRole(in *Route53AssumeRole, out *acme.Route53AssumeRole, s conversion.Scope) error {
+	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	return nil
+}
+
+// Convert_v1beta1_Route53AssumeRole_To_acme_Route53AssumeRole is an autogenerated conversion function.
+func Convert_v1beta1_Route53AssumeRole_To_acme_Route53AssumeRole(in *Route53AssumeRole, out *acme.Route53AssumeRole, s conversion.Scope) error {
+	return autoConvert_v1beta1_Route53AssumeRole_To_acme_Route53AssumeRole(in, out, s)
+}
+
+func autoConvert_acme_Route53AssumeRole_To_v1beta1_Route53AssumeRole(in *acme.Route53AssumeRole, out *Route53AssumeRole, s conversion.Scope) error {
+	out.Role = in.Role
+	out.ExternalID = in.ExternalID
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
+	return nil
+}
+
+// Convert_acme_Route53AssumeRole_To_v1beta1_Route53AssumeRole is an autogenerated conversion function.
+func Convert_acme_Route53AssumeRole_To_v1beta1_Route53AssumeRole(in *acme.Route53AssumeRole, out *Route53AssumeRole, s conversion.Scope) error {
+	return autoConvert_acme_Route53AssumeRole_To_v1beta1_Route53AssumeRole(in, out, s)
+}
@@ -145,7 +145,7 @@ type CertificateSpec struct {
 	// with the given name in the same namespace as the Certificate will be used.
 	// If the `kind` field is set to `ClusterIssuer`, a ClusterIssuer with the
 	// provided name will be used.
-	// The `name` field in this stanza is required at all times.
+	// The `name` field in this stanza is required unless `observeOnly` is set.
 	IssuerRef cmmeta.ObjectReference
 
 	// IsCA will mark this Certificate as valid for certificate signing.
@@ -178,6 +178,77 @@ type CertificateSpec struct {
 	// `--feature-gates=AdditionalCertificateOutputFormats=true` option on both
 	// the controller and webhook components.
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat
+
+	// AdditionalTrustedCAs references Secrets and ConfigMaps in the
+	// Certificate's namespace containing PEM encoded CA certificates. The
+	// certificates are appended, in order, to the issuing CA and written to
+	// the `trust.pem` key of the Certificate's target Secret, so that
+	// applications can mount their serving certificate and the CAs they must
+	// trust together. This is an Alpha Feature and is only enabled with the
+	// `--feature-gates=AdditionalTrustedCAs=true` option on both the
+	// controller and webhook components.
+	AdditionalTrustedCAs []CertificateAdditionalTrustedCA
+
+	// Canary configures migration of this Certificate to a new issuer. When
+	// set and `issuerRef` is changed to reference a different issuer than the
+	// one which issued the current certificate, the candidate certificate from
+	// the new issuer is first stored in the shadow Secret named by
+	// `canary.secretName` while the existing Secret is left untouched. The
+	// candidate is only promoted to `secretName` once a verification hook has
+	// annotated the shadow Secret with `cert-manager.io/canary-verified`, set
+	// to the value of its `cert-manager.io/canary-request` annotation.
+	Canary *CertificateCanary
+
+	// Chain configures post-issuance transforms of the signed certificate
+	// chain stored in the `tls.crt` key of the Certificate's target Secret.
+	Chain *CertificateChainOptions
+
+	// RenewalWindows restricts the renewal of the certificate as it nears
+	// expiry to recurring maintenance windows. If the renewal time falls
+	// outside of all windows, renewal is deferred until the next window
+	// opens, unless the certificate is due for urgent renewal before then.
+	// Re-issuance for any other reason, such as a change to the Certificate's
+	// spec, is never deferred.
+	RenewalWindows *CertificateRenewalWindows
+
+	// Verification configures checks which are run against each newly
+	// issued certificate once it has been stored in the target Secret. A
+	// failed check is reported by the `VerificationFailed` condition.
+	Verification *CertificateVerification
+
+	// AdditionalPrivateKey configures a second private key, using a different
+	// key algorithm to the Certificate's private key, for which a certificate
+	// with the same subject and names is issued and renewed alongside the
+	// primary certificate. The key and certificate are stored in the
+	// `tls-additional.key` and `tls-additional.crt` keys of the Certificate's
+	// target Secret, so that web servers can serve both an RSA and an ECDSA
+	// certificate.
+	AdditionalPrivateKey *CertificateAdditionalPrivateKey
+
+	// OCSPStapling configures fetching the OCSP response for the issued
+	// certificate from the OCSP responder named in the certificate, and
+	// storing it in the `ocsp.der` key of the Certificate's target Secret.
+	// The response is refreshed on a schedule, so that servers which staple
+	// OCSP responses can read it from the Secret instead of fetching it
+	// themselves.
+	OCSPStapling *CertificateOCSPStapling
+
+	// RenewalDisabled disables the automatic renewal of the certificate, for
+	// certificates which are renewed manually or by an external system. The
+	// certificate is still issued when its Secret does not contain one, or
+	// when the spec changes, but is not re-issued as it nears expiry or once
+	// it has expired. The `ExpiryWarning` condition is set on the Certificate
+	// once the certificate expires within 30, 14 or 7 days.
+	RenewalDisabled bool
+
+	// ObserveOnly makes the Certificate observe the certificate stored in the
+	// `secretName` Secret without ever issuing one, for certificates which
+	// are issued outside of cert-manager. The Secret is never modified, and
+	// the details of the stored certificate are reported in
+	// `status.observed`. Only `secretName` is required, all other fields
+	// which configure issuance are ignored. The `ExpiryWarning` condition is
+	// set as for Certificates with `renewalDisabled` set.
+	ObserveOnly bool
 }
 
 // CertificatePrivateKey contains configuration options for private keys
@@ -192,9 +263,16 @@ type CertificatePrivateKey struct {
 	// to await user intervention.
 	// If set to `Always`, a private key matching the specified requirements
 	// will be generated whenever a re-issuance occurs.
+	// If set to `Scheduled`, the private key is reused across re-issuances
+	// like with `Never`, but a new private key is generated on the schedule
+	// defined in `rotationSchedule`.
 	// Default is `Never` for backward compatibility.
 	RotationPolicy PrivateKeyRotationPolicy
 
+	// RotationSchedule defines when the private key is rotated if
+	// `rotationPolicy` is set to `Scheduled`, in which case it is required.
+	RotationSchedule *PrivateKeyRotationSchedule
+
 	// The private key cryptography standards (PKCS) encoding for this
 	// certificate's private key to be encoded in.
 	// If provided, allowed values are `PKCS1` and `PKCS8` standing for PKCS#1
@@ -251,6 +329,104 @@ type CertificateAdditionalOutputFormat struct {
 	Type CertificateOutputFormatType
 }
 
+// CertificateAdditionalTrustedCA is a source of PEM encoded CA certificates to
+// be written to the `trust.pem` key of a Certificate's target Secret.
+// Exactly one of Secret or ConfigMap must be specified.
+type CertificateAdditionalTrustedCA struct {
+	// Secret selects a key of a Secret in the Certificate's namespace.
+	Secret *cmmeta.SecretKeySelector
+
+	// ConfigMap selects a key of a ConfigMap in the Certificate's namespace.
+	ConfigMap *ConfigMapKeySelector
+}
+
+// ConfigMapKeySelector is a reference to a specific 'key' within a ConfigMap
+// resource.
+type ConfigMapKeySelector struct {
+	// The name of the ConfigMap resource being referred to.
+	cmmeta.LocalObjectReference
+
+	// The key of the entry in the ConfigMap resource's `data` field to be
+	// used.
+	Key string
+}
+
+// CertificateCanary configures issuing candidate certificates from a new
+// issuer into a shadow Secret, before they are promoted to the Certificate's
+// target Secret.
+type CertificateCanary struct {
+	// SecretName is the name of the shadow Secret resource that candidate
+	// certificates will be written to. It must be different from the
+	// Certificate's `secretName`.
+	SecretName string
+}
+
+// CertificateChainOptions configures how the signed certificate chain is
+// stored in the Certificate's target Secret.
+type CertificateChainOptions struct {
+	// MaxDepth is the maximum number of certificates, including the leaf
+	// certificate, stored in the `tls.crt` key of the Secret. Certificates are
+	// removed from the root end of the chain returned by the issuer, for
+	// example to drop a cross-signed root certificate which some TLS
+	// implementations fail to handle. The chain is only trimmed if the
+	// remaining certificates can still be verified using the removed
+	// certificates or the issuing CA, otherwise the full chain is stored.
+	MaxDepth int32
+}
+
+// CertificateVerification configures the post-issuance verification of a
+// Certificate. At least one of httpProbe or job must be set; if both are set,
+// both must pass.
+type CertificateVerification struct {
+	// HTTPProbe verifies that a TLS endpoint is serving the issued
+	// certificate.
+	HTTPProbe *CertificateHTTPProbe
+
+	// Job verifies the issued certificate by running a Job, which must
+	// complete successfully.
+	Job *CertificateVerificationJob
+
+	// Timeout is the time the checks may take to pass after a certificate
+	// has been issued, before its verification is considered to have
+	// failed. Defaults to 5 minutes.
+	Timeout *metav1.Duration
+
+	// RollbackOnFailure restores the most recently verified certificate and
+	// private key to the target Secret if the verification of a newly issued
+	// certificate fails. The verified certificate is kept in a Secret named
+	// after the target Secret with the suffix `-verified`.
+	RollbackOnFailure bool
+}
+
+// CertificateHTTPProbe configures verifying that an HTTPS endpoint is serving
+// the issued certificate.
+type CertificateHTTPProbe struct {
+	// URL is the HTTPS URL which is requested, for example
+	// `https://example.com:8443/healthz`. The probe passes if the leaf
+	// certificate presented by the server is the issued certificate; the
+	// response status is not checked. The host of the URL is used as the
+	// TLS server name.
+	URL string
+}
+
+// CertificateVerificationJob configures a Job which verifies the issued
+// certificate. The target Secret is mounted read-only into the Job's
+// container at `/etc/cert-manager/certificate`.
+type CertificateVerificationJob struct {
+	// Image is the container image to run.
+	Image string
+
+	// Command overrides the entrypoint of the container image.
+	Command []string
+
+	// Args are the arguments passed to the entrypoint.
+	Args []string
+
+	// ServiceAccountName is the name of the ServiceAccount the Job runs as.
+	// If not set, the default ServiceAccount of the namespace is used.
+	ServiceAccountName string
+}
+
 // Denotes how private keys should be generated or sourced when a Certificate
 // is being issued.
 type PrivateKeyRotationPolicy string
@@ -265,8 +441,86 @@ var (
 	// RotationPolicyAlways means a private key matching the specified
 	// requirements will be generated whenever a re-issuance occurs.
 	RotationPolicyAlways PrivateKeyRotationPolicy = "Always"
+
+	// RotationPolicyScheduled means the private key is reused across
+	// re-issuances, but a new private key is generated on a schedule.
+	RotationPolicyScheduled PrivateKeyRotationPolicy = "Scheduled"
 )
 
+// PrivateKeyRotationSchedule defines when the private key of a Certificate
+// is rotated. Exactly one of schedule or interval must be set.
+type PrivateKeyRotationSchedule struct {
+	// Schedule is a cron expression of five fields (minute, hour, day of
+	// month, month and day of week) denoting when the private key is
+	// rotated, e.g. "0 9 1 */3 *" to rotate it quarterly.
+	Schedule string
+
+	// TimeZone is the name of the IANA time zone in which the schedule is
+	// evaluated, e.g. "Europe/London". Defaults to UTC.
+	TimeZone string
+
+	// Interval is the time between two rotations of the private key, e.g.
+	// "2160h". It must be at least 1h.
+	Interval *metav1.Duration
+
+	// AdvanceNotice is how long before a rotation of the private key an
+	// event announcing it is emitted on the Certificate, e.g. to leave time
+	// to publish the new key for pinning or DANE. Defaults to 7 days.
+	AdvanceNotice *metav1.Duration
+}
+
+// CertificateAdditionalPrivateKey configures the additional private key of a
+// Certificate.
+type CertificateAdditionalPrivateKey struct {
+	// Algorithm is the private key algorithm of the additional private key.
+	// Allowed values are `RSA` or `ECDSA`, and it must differ from the algorithm of
+	// the Certificate's private key.
+	Algorithm PrivateKeyAlgorithm
+
+	// Size is the key bit size of the additional private key. The allowed
+	// values and defaults are the same as for the Certificate's private key.
+	Size int
+}
+
+// CertificateOCSPStapling configures the fetching of OCSP responses for a
+// Certificate.
+type CertificateOCSPStapling struct {
+	// RefreshInterval is how often the OCSP response is fetched again, at
+	// least 1 minute. If not set, it is fetched again once half of the
+	// response's validity period has elapsed. The response is always fetched
+	// again before its next update time has passed.
+	RefreshInterval *metav1.Duration
+}
+
+// CertificateRenewalWindows configures the maintenance windows during which a
+// Certificate may be renewed.
+type CertificateRenewalWindows struct {
+	// Windows during which renewal is allowed. Renewal is allowed while any of
+	// the windows is open.
+	Windows []CertificateRenewalWindow
+
+	// TimeZone is the name of the IANA time zone in which the schedules of the
+	// windows are evaluated, e.g. "Europe/London". Defaults to UTC.
+	TimeZone string
+
+	// UrgentRenewBefore is how long before its expiry the certificate is
+	// renewed regardless of the windows. Defaults to half of the time between
+	// the renewal time of the certificate and its expiry.
+	UrgentRenewBefore *metav1.Duration
+}
+
+// CertificateRenewalWindow is a recurring window of time.
+type CertificateRenewalWindow struct {
+	// Schedule is a cron expression of five fields (minute, hour, day of
+	// month, month and day of week) denoting when the window opens, e.g.
+	// "0 22 * * MON-FRI" to open the window at 22:00 on weekdays.
+	Schedule string
+
+	// Duration is how long the window stays open once opened, at most 7 days,
+	// e.g. "8h".
+	Duration metav1.Duration
+}
+
 // X509Subject Full X509 name specification
 type X509Subject struct {
 	// Organizations to be used on the Certificate.
@@ -342,6 +596,16 @@ type CertificateStatus struct {
 	// 1 hour has elapsed from this time.
 	LastFailureTime *metav1.Time
 
+	// LastSuccessTime is the time as recorded by the Certificate controller
+	// of the most recent successful issuance of a certificate for this
+	// Certificate resource.
+	LastSuccessTime *metav1.Time
+
+	// LastError is a short description of the most recent failure to issue
+	// a certificate for this Certificate resource, truncated to 256
+	// characters. It is cleared upon a successful issuance.
+	LastError string
+
 	// The time after which the certificate stored in the secret named
 	// by this resource in spec.secretName is valid.
 	NotBefore *metav1.Time
@@ -355,6 +619,11 @@ type CertificateStatus struct {
 	// If not set, no upcoming renewal is scheduled.
 	RenewalTime *metav1.Time
 
+	// PrivateKeyRotationTime is the time from which the private key of the
+	// certificate is next rotated, if `spec.privateKey.rotationPolicy` is set
+	// to `Scheduled`. It is computed whenever a new private key is stored.
+	PrivateKeyRotationTime *metav1.Time
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -385,6 +654,46 @@ type CertificateStatus struct {
 	// delay till the next issuance will be calculated using formula
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// The revision of the certificate which the `VerificationFailed`
+	// condition reports on. Only set if `spec.verification` is set.
+	VerificationRevision *int
+
+	// Observed contains the details of the certificate stored in the
+	// `secretName` Secret. Only set if `spec.observeOnly` is set.
+	Observed *CertificateObservation
+
+	// The value of the `cert-manager.io/issuance-request` annotation for
+	// which an issuance was last triggered. External controllers can compare
+	// it to the value they set to know that their request was acknowledged.
+	LastIssuanceRequest string
+}
+
+// CertificateObservation contains the details of a certificate observed by
+// a Certificate with `spec.observeOnly` set.
+type CertificateObservation struct {
+	// CommonName is the common name of the certificate's subject.
+	CommonName string
+
+	// DNSNames are the DNS subject alternative names of the certificate.
+	DNSNames []string
+
+	// IPAddresses are the IP address subject alternative names of the
+	// certificate.
+	IPAddresses []string
+
+	// URIs are the URI subject alternative names of the certificate.
+	URIs []string
+
+	// EmailAddresses are the email address subject alternative names of the
+	// certificate.
+	EmailAddresses []string
+
+	// Issuer is the distinguished name of the certificate's issuer.
+	Issuer string
+
+	// SerialNumber is the serial number of the certificate, in hexadecimal.
+	SerialNumber string
 }
 
 // CertificateCondition contains condition information for an Certificate.
@@ -444,6 +753,27 @@ const (
 	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// CertificateConditionVerificationFailed is set on Certificates with
+	// `spec.verification` configured. It is `Unknown` while the certificate
+	// of the current revision is being verified, `True` if its verification
+	// failed and `False` once it has passed.
+	// While it is `True`, re-issuance is backed off as after a failed
+	// issuance, unless the Certificate's spec has changed.
+	CertificateConditionVerificationFailed CertificateConditionType = "VerificationFailed"
+
+	// CertificateConditionIssuanceRequested is set on Certificates with the
+	// `cert-manager.io/issuance-request` annotation. It is `True` while the
+	// issuance requested by the annotation value recorded in
+	// `status.lastIssuanceRequest` is in progress, and `False` once it has
+	// completed, with the reason `Issued` or `Failed`.
+	CertificateConditionIssuanceRequested CertificateConditionType = "IssuanceRequested"
+
+	// CertificateConditionExpiryWarning is set on Certificates with
+	// `spec.renewalDisabled` or `spec.observeOnly` set. It is `True` once the
+	// certificate expires within 30, 14 or 7 days, with the reason naming the
+	// threshold, and `False` otherwise.
+	CertificateConditionExpiryWarning CertificateConditionType = "ExpiryWarning"
 )
 
 // CertificateSecretTemplate defines the default labels and annotations
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A CertificateAuthority provisions a chain of certificate authorities,
+// consisting of a self-signed root and any number of intermediates, and
+// exposes the last CA of the chain as a ClusterIssuer.
+// The Certificates, Secrets and ClusterIssuers making up the chain are
+// created in the cluster resource namespace and owned by the
+// CertificateAuthority.
+type CertificateAuthority struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the CertificateAuthority resource.
+	Spec CertificateAuthoritySpec
+
+	// Status of the CertificateAuthority. This is set and managed automatically.
+	Status CertificateAuthorityStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateAuthorityList is a list of CertificateAuthorities
+type CertificateAuthorityList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []CertificateAuthority
+}
+
+// CertificateAuthoritySpec describes the chain of certificate authorities to
+// provision.
+type CertificateAuthoritySpec struct {
+	// Root is the self-signed root certificate authority of the chain.
+	Root CertificateAuthorityCA
+
+	// Intermediates is the list of intermediate certificate authorities of the
+	// chain, ordered from the one issued by the root to the one backing the
+	// ClusterIssuer.
+	// If empty, the ClusterIssuer issues certificates from the root directly.
+	Intermediates []CertificateAuthorityCA
+
+	// IssuerName is the name of the ClusterIssuer issuing certificates from
+	// the last certificate authority of the chain.
+	// Defaults to the name of the CertificateAuthority.
+	IssuerName string
+}
+
+// CertificateAuthorityCA describes a certificate authority of the chain.
+type CertificateAuthorityCA struct {
+	// CommonName is the common name of the certificate authority.
+	CommonName string
+
+	// Full X509 name specification of the certificate authority.
+	Subject *X509Subject
+
+	// The requested 'duration' (i.e. lifetime) of the certificate authority.
+	// If unset, the default duration of Certificates is used.
+	Duration *metav1.Duration
+
+	// How long before the currently issued certificate's expiry
+	// cert-manager should renew the certificate authority.
+	RenewBefore *metav1.Duration
+
+	// Options to control private keys used for the certificate authority.
+	PrivateKey *CertificatePrivateKey
+}
+
+// CertificateAuthorityStatus defines the observed state of the
+// CertificateAuthority.
+type CertificateAuthorityStatus struct {
+	// List of status conditions to indicate the status of the
+	// CertificateAuthority.
+	// Known condition types are `Ready`.
+	Conditions []CertificateAuthorityCondition
+}
+
+// CertificateAuthorityCondition contains condition information for a
+// CertificateAuthority.
+type CertificateAuthorityCondition struct {
+	// Type of the condition, known values are (`Ready`).
+	Type CertificateAuthorityConditionType
+
+	// Status of the condition, one of (`True`, `False`, `Unknown`).
+	Status cmmeta.ConditionStatus
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	LastTransitionTime *metav1.Time
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	Reason string
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	Message string
+
+	// If set, this represents the .metadata.generation that the condition was
+	// set based upon.
+	ObservedGeneration int64
+}
+
+// CertificateAuthorityConditionType represents a CertificateAuthority
+// condition value.
+type CertificateAuthorityConditionType string
+
+const (
+	// CertificateAuthorityConditionReady indicates that every certificate
+	// authority of the chain has been issued and that the ClusterIssuer is
+	// ready to issue certificates.
+	CertificateAuthorityConditionReady CertificateAuthorityConditionType = "Ready"
+)
@@ -129,6 +129,12 @@ type CertificateRequestStatus struct {
 	// If not set, the CA is assumed to be unknown/not available.
 	CA []byte
 
+	// The PEM encoded x509 certificate chain resulting from additionally
+	// signing the certificate signing request with the cross-signing CA
+	// keypair of the issuer.
+	// This is only set by CA issuers with a cross-signing keypair.
+	CrossSignedCertificate []byte
+
 	// FailureTime stores the time that this CertificateRequest failed. This is
 	// used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
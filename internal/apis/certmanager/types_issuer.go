@@ -82,6 +82,27 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig
+
+	// TLS configures the TLS connections the issuer makes to the servers it
+	// communicates with. Of the DNS01 providers, only Azure DNS, Cloudflare,
+	// DigitalOcean and Route53 support configuring TLS.
+	TLS *IssuerTLSConfig
+}
+
+// IssuerTLSConfig configures the TLS connections an issuer makes to the
+// servers it communicates with, such as ACME servers, Vault, Venafi and the
+// APIs of DNS01 providers.
+type IssuerTLSConfig struct {
+	// MinVersion is the minimum TLS version of connections, one of
+	// VersionTLS10, VersionTLS11, VersionTLS12 or VersionTLS13. Defaults to
+	// the minimum version of the Go standard library, which is TLS 1.2.
+	MinVersion string
+
+	// CipherSuites are the names of the cipher suites allowed for TLS 1.2 and
+	// lower versions, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. The cipher
+	// suites of TLS 1.3 are not configurable. Defaults to the cipher suites
+	// of the Go standard library.
+	CipherSuites []string
 }
 
 // IssuerConfig is a generic wrapper around custom issuer types
@@ -106,6 +127,11 @@ type IssuerConfig struct {
 	// Venafi configures this issuer to sign certificates using a Venafi TPP
 	// or Venafi Cloud policy zone.
 	Venafi *VenafiIssuer
+
+	// KubernetesCSR configures this issuer to sign certificates using the
+	// Kubernetes certificates.k8s.io CertificateSigningRequest API, with a
+	// signer which is already running in the cluster.
+	KubernetesCSR *KubernetesCSRIssuer
 }
 
 // VenafiIssuer configures an issuer to sign certificates using a Venafi TPP
@@ -144,6 +170,15 @@ type VenafiTPP struct {
 	// If not specified, the connection will be verified using the cert-manager
 	// system root certificates.
 	CABundle []byte
+
+	// ServiceGeneratedCSR configures the issuer to have the TPP instance
+	// generate the private key and CSR for each certificate, as required by
+	// policies which forbid private keys from being generated by clients.
+	// The subject and subject alternative names of the CertificateRequest's
+	// CSR are used for the request, and the private key returned by TPP is
+	// stored in the Certificate's Secret in place of the key generated by
+	// cert-manager.
+	ServiceGeneratedCSR bool
 }
 
 // VenafiCloud defines connection configuration details for Venafi Cloud
@@ -165,6 +200,20 @@ type SelfSignedIssuer struct {
 	CRLDistributionPoints []string
 }
 
+// KubernetesCSRIssuer configures an issuer to sign certificates by creating a
+// Kubernetes CertificateSigningRequest for a signer which is already running
+// in the cluster, such as the signing controller of a managed control plane.
+type KubernetesCSRIssuer struct {
+	// SignerName is the name of the signer which the Kubernetes
+	// CertificateSigningRequests will be requested from, for example
+	// `kubernetes.io/kube-apiserver-client`. The signer must be allowed by
+	// the --kubernetes-csr-allowed-signer-names flag of the cert-manager
+	// controller.
+	// cert-manager does not approve the CertificateSigningRequests it
+	// creates, they must be approved by another party.
+	SignerName string
+}
+
 // VaultIssuer configures an issuer to sign certificates using a HashiCorp Vault
 // PKI backend.
 type VaultIssuer struct {
@@ -176,6 +225,7 @@ type VaultIssuer struct {
 
 	// Path is the mount path of the Vault PKI backend's `sign` endpoint, e.g:
 	// "my_pki_mount/sign/my-role-name".
+	// If `mode` is `Issue`, this is the path of its `issue` endpoint instead.
 	Path string
 
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
@@ -187,8 +237,38 @@ type VaultIssuer struct {
 	// parameter is ignored for plain HTTP protocol connection. If not set the
 	// system root certificates are used to validate the TLS connection.
 	CABundle []byte
+
+	// Mode configures how certificates are obtained from Vault.
+	// Defaults to `Sign`, where the CSR of each CertificateRequest is signed
+	// by Vault and `path` must be a `sign` endpoint.
+	// If set to `Issue`, Vault generates the private key of each certificate
+	// and `path` must be an `issue` endpoint, e.g:
+	// "my_pki_mount/issue/my-role-name". The subject and subject alternative
+	// names of the CertificateRequest's CSR are used for the request, and
+	// the private key returned by Vault is stored in the Certificate's Secret
+	// in place of the key generated by cert-manager.
+	Mode VaultIssuerMode
+
+	// ResponseWrapTTL, if set, requests Vault to wrap the response of each
+	// sign or issue call in a single-use response wrapping token with the
+	// given time to live, which is unwrapped by cert-manager straight away.
+	// The issued certificate, and the private key in `Issue` mode, are then
+	// never part of a response which could be read by another client.
+	ResponseWrapTTL *metav1.Duration
 }
 
+// VaultIssuerMode configures how certificates are obtained from Vault.
+type VaultIssuerMode string
+
+const (
+	// VaultIssuerModeSign has Vault sign the CSR of each CertificateRequest.
+	VaultIssuerModeSign VaultIssuerMode = "Sign"
+
+	// VaultIssuerModeIssue has Vault generate the private key of each
+	// certificate.
+	VaultIssuerModeIssue VaultIssuerMode = "Issue"
+)
+
 // VaultAuth is configuration used to authenticate with a Vault server.
 // Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
 type VaultAuth struct {
@@ -262,6 +342,35 @@ type CAIssuer struct {
 	// certificate will be issued with no OCSP servers set. For example, an
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	OCSPServers []string
+
+	// PKCS12 configures the Issuer to read its signing keypair from a
+	// password-protected PKCS#12 bundle stored in the `keystore.p12` entry of
+	// the `secretName` Secret, instead of the `tls.crt` and `tls.key` entries.
+	// Any CA certificates in the bundle are appended to the certificate chain.
+	PKCS12 *CAPKCS12Keypair
+
+	// RotationOverlap is the period after the signing CA certificate of the
+	// Issuer has been rotated during which issued certificates include both
+	// the previous and the new CA certificate in `ca.crt`, so that clients
+	// trusting the bundle keep working whilst certificates signed by either
+	// CA are in use. If not set, only the current CA certificate is included.
+	RotationOverlap *metav1.Duration
+
+	// CrossSigningSecretName is the name of a Secret containing a second CA
+	// keypair, in its `tls.crt` and `tls.key` entries, which additionally
+	// signs the certificates issued by this Issuer. The cross-signed
+	// certificate and its chain are stored in the `tls-cross-signed.crt`
+	// entry of the Certificate's Secret, so that clients trusting either
+	// root can verify the certificate during a migration between roots.
+	CrossSigningSecretName string
+}
+
+// CAPKCS12Keypair configures a CA Issuer to read its signing keypair from a
+// password-protected PKCS#12 bundle.
+type CAPKCS12Keypair struct {
+	// PasswordSecretRef is a reference to a key in a Secret resource
+	// containing the password used to decrypt the PKCS#12 bundle.
+	PasswordSecretRef cmmeta.SecretKeySelector
 }
 
 // IssuerStatus contains status information about an Issuer
@@ -274,6 +383,28 @@ type IssuerStatus struct {
 	// This field should only be set if the Issuer is configured to use an ACME
 	// server to issue certificates.
 	ACME *cmacme.ACMEIssuerStatus
+
+	// CA specific status options.
+	// This field is only set if the Issuer is configured to use a CA keypair
+	// with a rotation overlap.
+	CA *CAIssuerStatus
+}
+
+// CAIssuerStatus records the signing CA certificates of a CA Issuer, so
+// that the previous CA certificate can be included in `ca.crt` after the
+// signing keypair has been rotated.
+type CAIssuerStatus struct {
+	// Certificate is the PEM encoded CA certificate of the signing keypair
+	// currently used by the Issuer.
+	Certificate []byte
+
+	// PreviousCertificate is the PEM encoded CA certificate of the signing
+	// keypair used by the Issuer before it was last rotated.
+	PreviousCertificate []byte
+
+	// LastRotationTime is the time at which the Issuer observed that its
+	// signing keypair was last rotated.
+	LastRotationTime *metav1.Time
 }
 
 // IssuerCondition contains condition information for an Issuer.
@@ -314,3 +445,24 @@ const (
 	// should prevent attempts to sign certificates.
 	IssuerConditionReady IssuerConditionType = "Ready"
 )
+
+// Reasons set on the Ready condition of an Issuer which is not ready because
+// of a problem with the credentials it uses to connect to its external
+// service, or to the DNS providers of its ACME DNS01 solvers.
+const (
+	// IssuerReasonSecretNotFound is used when a Secret referenced by the
+	// Issuer for its credentials does not exist.
+	IssuerReasonSecretNotFound = "SecretNotFound"
+
+	// IssuerReasonPermissionDenied is used when the credentials of the
+	// Issuer were accepted, but are not permitted to perform an operation.
+	IssuerReasonPermissionDenied = "PermissionDenied"
+
+	// IssuerReasonInvalidCredentials is used when the credentials of the
+	// Issuer were rejected by the external service.
+	IssuerReasonInvalidCredentials = "InvalidCredentials"
+
+	// IssuerReasonEndpointUnreachable is used when the external service of
+	// the Issuer could not be connected to.
+	IssuerReasonEndpointUnreachable = "EndpointUnreachable"
+)
@@ -28,11 +28,11 @@ import (
 	acmev1 "github.com/cert-manager/cert-manager/internal/apis/acme/v1"
 	certmanager "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
-	internalapismetav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
+	metav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	apisacmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgapismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -54,6 +54,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAIssuerStatus)(nil), (*certmanager.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(a.(*v1.CAIssuerStatus), b.(*certmanager.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerStatus)(nil), (*v1.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(a.(*certmanager.CAIssuerStatus), b.(*v1.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAPKCS12Keypair)(nil), (*certmanager.CAPKCS12Keypair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(a.(*v1.CAPKCS12Keypair), b.(*certmanager.CAPKCS12Keypair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS12Keypair)(nil), (*v1.CAPKCS12Keypair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS12Keypair_To_v1_CAPKCS12Keypair(a.(*certmanager.CAPKCS12Keypair), b.(*v1.CAPKCS12Keypair), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Certificate_To_certmanager_Certificate(a.(*v1.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -74,6 +94,106 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAdditionalPrivateKey)(nil), (*certmanager.CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(a.(*v1.CertificateAdditionalPrivateKey), b.(*certmanager.CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalPrivateKey)(nil), (*v1.CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(a.(*certmanager.CertificateAdditionalPrivateKey), b.(*v1.CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAdditionalTrustedCA)(nil), (*certmanager.CertificateAdditionalTrustedCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(a.(*v1.CertificateAdditionalTrustedCA), b.(*certmanager.CertificateAdditionalTrustedCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalTrustedCA)(nil), (*v1.CertificateAdditionalTrustedCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalTrustedCA_To_v1_CertificateAdditionalTrustedCA(a.(*certmanager.CertificateAdditionalTrustedCA), b.(*v1.CertificateAdditionalTrustedCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAuthority)(nil), (*certmanager.CertificateAuthority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAuthority_To_certmanager_CertificateAuthority(a.(*v1.CertificateAuthority), b.(*certmanager.CertificateAuthority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAuthority)(nil), (*v1.CertificateAuthority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAuthority_To_v1_CertificateAuthority(a.(*certmanager.CertificateAuthority), b.(*v1.CertificateAuthority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAuthorityCA)(nil), (*certmanager.CertificateAuthorityCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAuthorityCA_To_certmanager_CertificateAuthorityCA(a.(*v1.CertificateAuthorityCA), b.(*certmanager.CertificateAuthorityCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAuthorityCA)(nil), (*v1.CertificateAuthorityCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAuthorityCA_To_v1_CertificateAuthorityCA(a.(*certmanager.CertificateAuthorityCA), b.(*v1.CertificateAuthorityCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAuthorityCondition)(nil), (*certmanager.CertificateAuthorityCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAuthorityCondition_To_certmanager_CertificateAuthorityCondition(a.(*v1.CertificateAuthorityCondition), b.(*certmanager.CertificateAuthorityCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAuthorityCondition)(nil), (*v1.CertificateAuthorityCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAuthorityCondition_To_v1_CertificateAuthorityCondition(a.(*certmanager.CertificateAuthorityCondition), b.(*v1.CertificateAuthorityCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAuthorityList)(nil), (*certmanager.CertificateAuthorityList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAuthorityList_To_certmanager_CertificateAuthorityList(a.(*v1.CertificateAuthorityList), b.(*certmanager.CertificateAuthorityList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAuthorityList)(nil), (*v1.CertificateAuthorityList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAuthorityList_To_v1_CertificateAuthorityList(a.(*certmanager.CertificateAuthorityList), b.(*v1.CertificateAuthorityList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAuthoritySpec)(nil), (*certmanager.CertificateAuthoritySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAuthoritySpec_To_certmanager_CertificateAuthoritySpec(a.(*v1.CertificateAuthoritySpec), b.(*certmanager.CertificateAuthoritySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAuthoritySpec)(nil), (*v1.CertificateAuthoritySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAuthoritySpec_To_v1_CertificateAuthoritySpec(a.(*certmanager.CertificateAuthoritySpec), b.(*v1.CertificateAuthoritySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAuthorityStatus)(nil), (*certmanager.CertificateAuthorityStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAuthorityStatus_To_certmanager_CertificateAuthorityStatus(a.(*v1.CertificateAuthorityStatus), b.(*certmanager.CertificateAuthorityStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAuthorityStatus)(nil), (*v1.CertificateAuthorityStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAuthorityStatus_To_v1_CertificateAuthorityStatus(a.(*certmanager.CertificateAuthorityStatus), b.(*v1.CertificateAuthorityStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateCanary)(nil), (*certmanager.CertificateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateCanary_To_certmanager_CertificateCanary(a.(*v1.CertificateCanary), b.(*certmanager.CertificateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateCanary)(nil), (*v1.CertificateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateCanary_To_v1_CertificateCanary(a.(*certmanager.CertificateCanary), b.(*v1.CertificateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateChainOptions)(nil), (*certmanager.CertificateChainOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateChainOptions_To_certmanager_CertificateChainOptions(a.(*v1.CertificateChainOptions), b.(*certmanager.CertificateChainOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateChainOptions)(nil), (*v1.CertificateChainOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateChainOptions_To_v1_CertificateChainOptions(a.(*certmanager.CertificateChainOptions), b.(*v1.CertificateChainOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(a.(*v1.CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -84,6 +204,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateHTTPProbe)(nil), (*certmanager.CertificateHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(a.(*v1.CertificateHTTPProbe), b.(*certmanager.CertificateHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateHTTPProbe)(nil), (*v1.CertificateHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateHTTPProbe_To_v1_CertificateHTTPProbe(a.(*certmanager.CertificateHTTPProbe), b.(*v1.CertificateHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*v1.CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -104,6 +234,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateOCSPStapling)(nil), (*certmanager.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(a.(*v1.CertificateOCSPStapling), b.(*certmanager.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateOCSPStapling)(nil), (*v1.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(a.(*certmanager.CertificateOCSPStapling), b.(*v1.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateObservation)(nil), (*certmanager.CertificateObservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateObservation_To_certmanager_CertificateObservation(a.(*v1.CertificateObservation), b.(*certmanager.CertificateObservation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateObservation)(nil), (*v1.CertificateObservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateObservation_To_v1_CertificateObservation(a.(*certmanager.CertificateObservation), b.(*v1.CertificateObservation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*v1.CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
@@ -114,6 +264,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRenewalWindow)(nil), (*certmanager.CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(a.(*v1.CertificateRenewalWindow), b.(*certmanager.CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalWindow)(nil), (*v1.CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalWindow_To_v1_CertificateRenewalWindow(a.(*certmanager.CertificateRenewalWindow), b.(*v1.CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRenewalWindows)(nil), (*certmanager.CertificateRenewalWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(a.(*v1.CertificateRenewalWindows), b.(*certmanager.CertificateRenewalWindows), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalWindows)(nil), (*v1.CertificateRenewalWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalWindows_To_v1_CertificateRenewalWindows(a.(*certmanager.CertificateRenewalWindows), b.(*v1.CertificateRenewalWindows), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -184,6 +354,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateVerification)(nil), (*certmanager.CertificateVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateVerification_To_certmanager_CertificateVerification(a.(*v1.CertificateVerification), b.(*certmanager.CertificateVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateVerification)(nil), (*v1.CertificateVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateVerification_To_v1_CertificateVerification(a.(*certmanager.CertificateVerification), b.(*v1.CertificateVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateVerificationJob)(nil), (*certmanager.CertificateVerificationJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(a.(*v1.CertificateVerificationJob), b.(*certmanager.CertificateVerificationJob), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateVerificationJob)(nil), (*v1.CertificateVerificationJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateVerificationJob_To_v1_CertificateVerificationJob(a.(*certmanager.CertificateVerificationJob), b.(*v1.CertificateVerificationJob), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ClusterIssuer)(nil), (*certmanager.ClusterIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ClusterIssuer_To_certmanager_ClusterIssuer(a.(*v1.ClusterIssuer), b.(*certmanager.ClusterIssuer), scope)
 	}); err != nil {
@@ -204,6 +394,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ConfigMapKeySelector)(nil), (*certmanager.ConfigMapKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(a.(*v1.ConfigMapKeySelector), b.(*certmanager.ConfigMapKeySelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ConfigMapKeySelector)(nil), (*v1.ConfigMapKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(a.(*certmanager.ConfigMapKeySelector), b.(*v1.ConfigMapKeySelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Issuer_To_certmanager_Issuer(a.(*v1.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -264,6 +464,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerTLSConfig)(nil), (*certmanager.IssuerTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(a.(*v1.IssuerTLSConfig), b.(*certmanager.IssuerTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerTLSConfig)(nil), (*v1.IssuerTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerTLSConfig_To_v1_IssuerTLSConfig(a.(*certmanager.IssuerTLSConfig), b.(*v1.IssuerTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.JKSKeystore)(nil), (*certmanager.JKSKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_JKSKeystore_To_certmanager_JKSKeystore(a.(*v1.JKSKeystore), b.(*certmanager.JKSKeystore), scope)
 	}); err != nil {
@@ -274,6 +484,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.KubernetesCSRIssuer)(nil), (*certmanager.KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(a.(*v1.KubernetesCSRIssuer), b.(*certmanager.KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.KubernetesCSRIssuer)(nil), (*v1.KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_KubernetesCSRIssuer_To_v1_KubernetesCSRIssuer(a.(*certmanager.KubernetesCSRIssuer), b.(*v1.KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -284,6 +504,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.PrivateKeyRotationSchedule)(nil), (*certmanager.PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(a.(*v1.PrivateKeyRotationSchedule), b.(*certmanager.PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PrivateKeyRotationSchedule)(nil), (*v1.PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(a.(*certmanager.PrivateKeyRotationSchedule), b.(*v1.PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -391,6 +621,17 @@ func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certm
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(certmanager.CAPKCS12Keypair)
+		if err := Convert_v1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS12 = nil
+	}
+	out.RotationOverlap = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RotationOverlap))
+	out.CrossSigningSecretName = in.CrossSigningSecretName
 	return nil
 }
 
@@ -403,6 +644,17 @@ func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, o
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(v1.CAPKCS12Keypair)
+		if err := Convert_certmanager_CAPKCS12Keypair_To_v1_CAPKCS12Keypair(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS12 = nil
+	}
+	out.RotationOverlap = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RotationOverlap))
+	out.CrossSigningSecretName = in.CrossSigningSecretName
 	return nil
 }
 
@@ -411,6 +663,54 @@ func Convert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *
 	return autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *v1.CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
+	out.PreviousCertificate = *(*[]byte)(unsafe.Pointer(&in.PreviousCertificate))
+	out.LastRotationTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastRotationTime))
+	return nil
+}
+
+// Convert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus is an autogenerated conversion function.
+func Convert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *v1.CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *v1.CAIssuerStatus, s conversion.Scope) error {
+	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
+	out.PreviousCertificate = *(*[]byte)(unsafe.Pointer(&in.PreviousCertificate))
+	out.LastRotationTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastRotationTime))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *v1.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_v1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in *v1.CAPKCS12Keypair, out *certmanager.CAPKCS12Keypair, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair is an autogenerated conversion function.
+func Convert_v1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in *v1.CAPKCS12Keypair, out *certmanager.CAPKCS12Keypair, s conversion.Scope) error {
+	return autoConvert_v1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS12Keypair_To_v1_CAPKCS12Keypair(in *certmanager.CAPKCS12Keypair, out *v1.CAPKCS12Keypair, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS12Keypair_To_v1_CAPKCS12Keypair is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS12Keypair_To_v1_CAPKCS12Keypair(in *certmanager.CAPKCS12Keypair, out *v1.CAPKCS12Keypair, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS12Keypair_To_v1_CAPKCS12Keypair(in, out, s)
+}
+
 func autoConvert_v1_Certificate_To_certmanager_Certificate(in *v1.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -463,10 +763,306 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAddi
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *v1.CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *v1.CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *v1.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *v1.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(in, out, s)
+}
+
+func autoConvert_v1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in *v1.CertificateAdditionalTrustedCA, out *certmanager.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(certmanager.ConfigMapKeySelector)
+		if err := Convert_v1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMap = nil
+	}
+	return nil
+}
+
+// Convert_v1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA is an autogenerated conversion function.
+func Convert_v1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in *v1.CertificateAdditionalTrustedCA, out *certmanager.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalTrustedCA_To_v1_CertificateAdditionalTrustedCA(in *certmanager.CertificateAdditionalTrustedCA, out *v1.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		if err := Convert_certmanager_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMap = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalTrustedCA_To_v1_CertificateAdditionalTrustedCA is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalTrustedCA_To_v1_CertificateAdditionalTrustedCA(in *certmanager.CertificateAdditionalTrustedCA, out *v1.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalTrustedCA_To_v1_CertificateAdditionalTrustedCA(in, out, s)
+}
+
+func autoConvert_v1_CertificateAuthority_To_certmanager_CertificateAuthority(in *v1.CertificateAuthority, out *certmanager.CertificateAuthority, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_CertificateAuthoritySpec_To_certmanager_CertificateAuthoritySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_CertificateAuthorityStatus_To_certmanager_CertificateAuthorityStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateAuthority_To_certmanager_CertificateAuthority is an autogenerated conversion function.
+func Convert_v1_CertificateAuthority_To_certmanager_CertificateAuthority(in *v1.CertificateAuthority, out *certmanager.CertificateAuthority, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAuthority_To_certmanager_CertificateAuthority(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAuthority_To_v1_CertificateAuthority(in *certmanager.CertificateAuthority, out *v1.CertificateAuthority, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_CertificateAuthoritySpec_To_v1_CertificateAuthoritySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_CertificateAuthorityStatus_To_v1_CertificateAuthorityStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateAuthority_To_v1_CertificateAuthority is an autogenerated conversion function.
+func Convert_certmanager_CertificateAuthority_To_v1_CertificateAuthority(in *certmanager.CertificateAuthority, out *v1.CertificateAuthority, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAuthority_To_v1_CertificateAuthority(in, out, s)
+}
+
+func autoConvert_v1_CertificateAuthorityCA_To_certmanager_CertificateAuthorityCA(in *v1.CertificateAuthorityCA, out *certmanager.CertificateAuthorityCA, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.Subject = (*certmanager.X509Subject)(unsafe.Pointer(in.Subject))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_v1_CertificateAuthorityCA_To_certmanager_CertificateAuthorityCA is an autogenerated conversion function.
+func Convert_v1_CertificateAuthorityCA_To_certmanager_CertificateAuthorityCA(in *v1.CertificateAuthorityCA, out *certmanager.CertificateAuthorityCA, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAuthorityCA_To_certmanager_CertificateAuthorityCA(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAuthorityCA_To_v1_CertificateAuthorityCA(in *certmanager.CertificateAuthorityCA, out *v1.CertificateAuthorityCA, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.Subject = (*v1.X509Subject)(unsafe.Pointer(in.Subject))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.PrivateKey = (*v1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_certmanager_CertificateAuthorityCA_To_v1_CertificateAuthorityCA is an autogenerated conversion function.
+func Convert_certmanager_CertificateAuthorityCA_To_v1_CertificateAuthorityCA(in *certmanager.CertificateAuthorityCA, out *v1.CertificateAuthorityCA, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAuthorityCA_To_v1_CertificateAuthorityCA(in, out, s)
+}
+
+func autoConvert_v1_CertificateAuthorityCondition_To_certmanager_CertificateAuthorityCondition(in *v1.CertificateAuthorityCondition, out *certmanager.CertificateAuthorityCondition, s conversion.Scope) error {
+	out.Type = certmanager.CertificateAuthorityConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_v1_CertificateAuthorityCondition_To_certmanager_CertificateAuthorityCondition is an autogenerated conversion function.
+func Convert_v1_CertificateAuthorityCondition_To_certmanager_CertificateAuthorityCondition(in *v1.CertificateAuthorityCondition, out *certmanager.CertificateAuthorityCondition, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAuthorityCondition_To_certmanager_CertificateAuthorityCondition(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAuthorityCondition_To_v1_CertificateAuthorityCondition(in *certmanager.CertificateAuthorityCondition, out *v1.CertificateAuthorityCondition, s conversion.Scope) error {
+	out.Type = v1.CertificateAuthorityConditionType(in.Type)
+	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_certmanager_CertificateAuthorityCondition_To_v1_CertificateAuthorityCondition is an autogenerated conversion function.
+func Convert_certmanager_CertificateAuthorityCondition_To_v1_CertificateAuthorityCondition(in *certmanager.CertificateAuthorityCondition, out *v1.CertificateAuthorityCondition, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAuthorityCondition_To_v1_CertificateAuthorityCondition(in, out, s)
+}
+
+func autoConvert_v1_CertificateAuthorityList_To_certmanager_CertificateAuthorityList(in *v1.CertificateAuthorityList, out *certmanager.CertificateAuthorityList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.CertificateAuthority, len(*in))
+		for i := range *in {
+			if err := Convert_v1_CertificateAuthority_To_certmanager_CertificateAuthority(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_CertificateAuthorityList_To_certmanager_CertificateAuthorityList is an autogenerated conversion function.
+func Convert_v1_CertificateAuthorityList_To_certmanager_CertificateAuthorityList(in *v1.CertificateAuthorityList, out *certmanager.CertificateAuthorityList, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAuthorityList_To_certmanager_CertificateAuthorityList(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAuthorityList_To_v1_CertificateAuthorityList(in *certmanager.CertificateAuthorityList, out *v1.CertificateAuthorityList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.CertificateAuthority, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateAuthority_To_v1_CertificateAuthority(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateAuthorityList_To_v1_CertificateAuthorityList is an autogenerated conversion function.
+func Convert_certmanager_CertificateAuthorityList_To_v1_CertificateAuthorityList(in *certmanager.CertificateAuthorityList, out *v1.CertificateAuthorityList, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAuthorityList_To_v1_CertificateAuthorityList(in, out, s)
+}
+
+func autoConvert_v1_CertificateAuthoritySpec_To_certmanager_CertificateAuthoritySpec(in *v1.CertificateAuthoritySpec, out *certmanager.CertificateAuthoritySpec, s conversion.Scope) error {
+	if err := Convert_v1_CertificateAuthorityCA_To_certmanager_CertificateAuthorityCA(&in.Root, &out.Root, s); err != nil {
+		return err
+	}
+	out.Intermediates = *(*[]certmanager.CertificateAuthorityCA)(unsafe.Pointer(&in.Intermediates))
+	out.IssuerName = in.IssuerName
+	return nil
+}
+
+// Convert_v1_CertificateAuthoritySpec_To_certmanager_CertificateAuthoritySpec is an autogenerated conversion function.
+func Convert_v1_CertificateAuthoritySpec_To_certmanager_CertificateAuthoritySpec(in *v1.CertificateAuthoritySpec, out *certmanager.CertificateAuthoritySpec, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAuthoritySpec_To_certmanager_CertificateAuthoritySpec(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAuthoritySpec_To_v1_CertificateAuthoritySpec(in *certmanager.CertificateAuthoritySpec, out *v1.CertificateAuthoritySpec, s conversion.Scope) error {
+	if err := Convert_certmanager_CertificateAuthorityCA_To_v1_CertificateAuthorityCA(&in.Root, &out.Root, s); err != nil {
+		return err
+	}
+	out.Intermediates = *(*[]v1.CertificateAuthorityCA)(unsafe.Pointer(&in.Intermediates))
+	out.IssuerName = in.IssuerName
+	return nil
+}
+
+// Convert_certmanager_CertificateAuthoritySpec_To_v1_CertificateAuthoritySpec is an autogenerated conversion function.
+func Convert_certmanager_CertificateAuthoritySpec_To_v1_CertificateAuthoritySpec(in *certmanager.CertificateAuthoritySpec, out *v1.CertificateAuthoritySpec, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAuthoritySpec_To_v1_CertificateAuthoritySpec(in, out, s)
+}
+
+func autoConvert_v1_CertificateAuthorityStatus_To_certmanager_CertificateAuthorityStatus(in *v1.CertificateAuthorityStatus, out *certmanager.CertificateAuthorityStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]certmanager.CertificateAuthorityCondition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1_CertificateAuthorityStatus_To_certmanager_CertificateAuthorityStatus is an autogenerated conversion function.
+func Convert_v1_CertificateAuthorityStatus_To_certmanager_CertificateAuthorityStatus(in *v1.CertificateAuthorityStatus, out *certmanager.CertificateAuthorityStatus, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAuthorityStatus_To_certmanager_CertificateAuthorityStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAuthorityStatus_To_v1_CertificateAuthorityStatus(in *certmanager.CertificateAuthorityStatus, out *v1.CertificateAuthorityStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]v1.CertificateAuthorityCondition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_certmanager_CertificateAuthorityStatus_To_v1_CertificateAuthorityStatus is an autogenerated conversion function.
+func Convert_certmanager_CertificateAuthorityStatus_To_v1_CertificateAuthorityStatus(in *certmanager.CertificateAuthorityStatus, out *v1.CertificateAuthorityStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAuthorityStatus_To_v1_CertificateAuthorityStatus(in, out, s)
+}
+
+func autoConvert_v1_CertificateCanary_To_certmanager_CertificateCanary(in *v1.CertificateCanary, out *certmanager.CertificateCanary, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_v1_CertificateCanary_To_certmanager_CertificateCanary is an autogenerated conversion function.
+func Convert_v1_CertificateCanary_To_certmanager_CertificateCanary(in *v1.CertificateCanary, out *certmanager.CertificateCanary, s conversion.Scope) error {
+	return autoConvert_v1_CertificateCanary_To_certmanager_CertificateCanary(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateCanary_To_v1_CertificateCanary(in *certmanager.CertificateCanary, out *v1.CertificateCanary, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_certmanager_CertificateCanary_To_v1_CertificateCanary is an autogenerated conversion function.
+func Convert_certmanager_CertificateCanary_To_v1_CertificateCanary(in *certmanager.CertificateCanary, out *v1.CertificateCanary, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateCanary_To_v1_CertificateCanary(in, out, s)
+}
+
+func autoConvert_v1_CertificateChainOptions_To_certmanager_CertificateChainOptions(in *v1.CertificateChainOptions, out *certmanager.CertificateChainOptions, s conversion.Scope) error {
+	out.MaxDepth = in.MaxDepth
+	return nil
+}
+
+// Convert_v1_CertificateChainOptions_To_certmanager_CertificateChainOptions is an autogenerated conversion function.
+func Convert_v1_CertificateChainOptions_To_certmanager_CertificateChainOptions(in *v1.CertificateChainOptions, out *certmanager.CertificateChainOptions, s conversion.Scope) error {
+	return autoConvert_v1_CertificateChainOptions_To_certmanager_CertificateChainOptions(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateChainOptions_To_v1_CertificateChainOptions(in *certmanager.CertificateChainOptions, out *v1.CertificateChainOptions, s conversion.Scope) error {
+	out.MaxDepth = in.MaxDepth
+	return nil
+}
+
+// Convert_certmanager_CertificateChainOptions_To_v1_CertificateChainOptions is an autogenerated conversion function.
+func Convert_certmanager_CertificateChainOptions_To_v1_CertificateChainOptions(in *certmanager.CertificateChainOptions, out *v1.CertificateChainOptions, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateChainOptions_To_v1_CertificateChainOptions(in, out, s)
+}
+
 func autoConvert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -481,7 +1077,7 @@ func Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.
 func autoConvert_certmanager_CertificateCondition_To_v1_CertificateCondition(in *certmanager.CertificateCondition, out *v1.CertificateCondition, s conversion.Scope) error {
 	out.Type = v1.CertificateConditionType(in.Type)
 	out.Status = apismetav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -493,6 +1089,26 @@ func Convert_certmanager_CertificateCondition_To_v1_CertificateCondition(in *cer
 	return autoConvert_certmanager_CertificateCondition_To_v1_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in *v1.CertificateHTTPProbe, out *certmanager.CertificateHTTPProbe, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe is an autogenerated conversion function.
+func Convert_v1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in *v1.CertificateHTTPProbe, out *certmanager.CertificateHTTPProbe, s conversion.Scope) error {
+	return autoConvert_v1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateHTTPProbe_To_v1_CertificateHTTPProbe(in *certmanager.CertificateHTTPProbe, out *v1.CertificateHTTPProbe, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_certmanager_CertificateHTTPProbe_To_v1_CertificateHTTPProbe is an autogenerated conversion function.
+func Convert_certmanager_CertificateHTTPProbe_To_v1_CertificateHTTPProbe(in *certmanager.CertificateHTTPProbe, out *v1.CertificateHTTPProbe, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateHTTPProbe_To_v1_CertificateHTTPProbe(in, out, s)
+}
+
 func autoConvert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(in *v1.CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -589,8 +1205,61 @@ func Convert_certmanager_CertificateList_To_v1_CertificateList(in *certmanager.C
 	return autoConvert_certmanager_CertificateList_To_v1_CertificateList(in, out, s)
 }
 
+func autoConvert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	out.RefreshInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RefreshInterval))
+	return nil
+}
+
+// Convert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1.CertificateOCSPStapling, s conversion.Scope) error {
+	out.RefreshInterval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RefreshInterval))
+	return nil
+}
+
+// Convert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_v1_CertificateObservation_To_certmanager_CertificateObservation(in *v1.CertificateObservation, out *certmanager.CertificateObservation, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.Issuer = in.Issuer
+	out.SerialNumber = in.SerialNumber
+	return nil
+}
+
+// Convert_v1_CertificateObservation_To_certmanager_CertificateObservation is an autogenerated conversion function.
+func Convert_v1_CertificateObservation_To_certmanager_CertificateObservation(in *v1.CertificateObservation, out *certmanager.CertificateObservation, s conversion.Scope) error {
+	return autoConvert_v1_CertificateObservation_To_certmanager_CertificateObservation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateObservation_To_v1_CertificateObservation(in *certmanager.CertificateObservation, out *v1.CertificateObservation, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.Issuer = in.Issuer
+	out.SerialNumber = in.SerialNumber
+	return nil
+}
+
+// Convert_certmanager_CertificateObservation_To_v1_CertificateObservation is an autogenerated conversion function.
+func Convert_certmanager_CertificateObservation_To_v1_CertificateObservation(in *certmanager.CertificateObservation, out *v1.CertificateObservation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateObservation_To_v1_CertificateObservation(in, out, s)
+}
+
 func autoConvert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*certmanager.PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -604,6 +1273,7 @@ func Convert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v
 
 func autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *v1.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = v1.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*v1.PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	out.Encoding = v1.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -615,6 +1285,52 @@ func Convert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *c
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *v1.CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_v1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_v1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *v1.CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalWindow_To_v1_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *v1.CertificateRenewalWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalWindow_To_v1_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalWindow_To_v1_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *v1.CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalWindow_To_v1_CertificateRenewalWindow(in, out, s)
+}
+
+func autoConvert_v1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in *v1.CertificateRenewalWindows, out *certmanager.CertificateRenewalWindows, s conversion.Scope) error {
+	out.Windows = *(*[]certmanager.CertificateRenewalWindow)(unsafe.Pointer(&in.Windows))
+	out.TimeZone = in.TimeZone
+	out.UrgentRenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.UrgentRenewBefore))
+	return nil
+}
+
+// Convert_v1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows is an autogenerated conversion function.
+func Convert_v1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in *v1.CertificateRenewalWindows, out *certmanager.CertificateRenewalWindows, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalWindows_To_v1_CertificateRenewalWindows(in *certmanager.CertificateRenewalWindows, out *v1.CertificateRenewalWindows, s conversion.Scope) error {
+	out.Windows = *(*[]v1.CertificateRenewalWindow)(unsafe.Pointer(&in.Windows))
+	out.TimeZone = in.TimeZone
+	out.UrgentRenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.UrgentRenewBefore))
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalWindows_To_v1_CertificateRenewalWindows is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalWindows_To_v1_CertificateRenewalWindows(in *certmanager.CertificateRenewalWindows, out *v1.CertificateRenewalWindows, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalWindows_To_v1_CertificateRenewalWindows(in, out, s)
+}
+
 func autoConvert_v1_CertificateRequest_To_certmanager_CertificateRequest(in *v1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -650,7 +1366,7 @@ func Convert_certmanager_CertificateRequest_To_v1_CertificateRequest(in *certman
 func autoConvert_v1_CertificateRequestCondition_To_certmanager_CertificateRequestCondition(in *v1.CertificateRequestCondition, out *certmanager.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateRequestConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -664,7 +1380,7 @@ func Convert_v1_CertificateRequestCondition_To_certmanager_CertificateRequestCon
 func autoConvert_certmanager_CertificateRequestCondition_To_v1_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *v1.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = v1.CertificateRequestConditionType(in.Type)
 	out.Status = apismetav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -718,8 +1434,8 @@ func Convert_certmanager_CertificateRequestList_To_v1_CertificateRequestList(in
 }
 
 func autoConvert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *v1.CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -738,8 +1454,8 @@ func Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in
 }
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *v1.CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -761,7 +1477,8 @@ func autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestSt
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.CrossSignedCertificate = *(*[]byte)(unsafe.Pointer(&in.CrossSignedCertificate))
+	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -774,7 +1491,8 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestSt
 	out.Conditions = *(*[]v1.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.CrossSignedCertificate = *(*[]byte)(unsafe.Pointer(&in.CrossSignedCertificate))
+	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -809,8 +1527,8 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.Subject = (*certmanager.X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URIs requires manual conversion: does not exist in peer-type
@@ -826,7 +1544,7 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	} else {
 		out.Keystores = nil
 	}
-	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
@@ -835,6 +1553,25 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = make([]certmanager.CertificateAdditionalTrustedCA, len(*in))
+		for i := range *in {
+			if err := Convert_v1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalTrustedCAs = nil
+	}
+	out.Canary = (*certmanager.CertificateCanary)(unsafe.Pointer(in.Canary))
+	out.Chain = (*certmanager.CertificateChainOptions)(unsafe.Pointer(in.Chain))
+	out.RenewalWindows = (*certmanager.CertificateRenewalWindows)(unsafe.Pointer(in.RenewalWindows))
+	out.Verification = (*certmanager.CertificateVerification)(unsafe.Pointer(in.Verification))
+	out.AdditionalPrivateKey = (*certmanager.CertificateAdditionalPrivateKey)(unsafe.Pointer(in.AdditionalPrivateKey))
+	out.OCSPStapling = (*certmanager.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	out.RenewalDisabled = in.RenewalDisabled
+	out.ObserveOnly = in.ObserveOnly
 	return nil
 }
 
@@ -842,8 +1579,8 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.Subject = (*v1.X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URISANs requires manual conversion: does not exist in peer-type
@@ -859,7 +1596,7 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	} else {
 		out.Keystores = nil
 	}
-	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
@@ -868,18 +1605,43 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = make([]v1.CertificateAdditionalTrustedCA, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateAdditionalTrustedCA_To_v1_CertificateAdditionalTrustedCA(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalTrustedCAs = nil
+	}
+	out.Canary = (*v1.CertificateCanary)(unsafe.Pointer(in.Canary))
+	out.Chain = (*v1.CertificateChainOptions)(unsafe.Pointer(in.Chain))
+	out.RenewalWindows = (*v1.CertificateRenewalWindows)(unsafe.Pointer(in.RenewalWindows))
+	out.Verification = (*v1.CertificateVerification)(unsafe.Pointer(in.Verification))
+	out.AdditionalPrivateKey = (*v1.CertificateAdditionalPrivateKey)(unsafe.Pointer(in.AdditionalPrivateKey))
+	out.OCSPStapling = (*v1.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	out.RenewalDisabled = in.RenewalDisabled
+	out.ObserveOnly = in.ObserveOnly
 	return nil
 }
 
 func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*metav1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.LastSuccessTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastSuccessTime))
+	out.LastError = in.LastError
+	out.NotBefore = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.PrivateKeyRotationTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.PrivateKeyRotationTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.VerificationRevision = (*int)(unsafe.Pointer(in.VerificationRevision))
+	out.Observed = (*certmanager.CertificateObservation)(unsafe.Pointer(in.Observed))
+	out.LastIssuanceRequest = in.LastIssuanceRequest
 	return nil
 }
 
@@ -890,13 +1652,19 @@ func Convert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Certif
 
 func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certmanager.CertificateStatus, out *v1.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1.CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*metav1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.LastSuccessTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastSuccessTime))
+	out.LastError = in.LastError
+	out.NotBefore = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.PrivateKeyRotationTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.PrivateKeyRotationTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.VerificationRevision = (*int)(unsafe.Pointer(in.VerificationRevision))
+	out.Observed = (*v1.CertificateObservation)(unsafe.Pointer(in.Observed))
+	out.LastIssuanceRequest = in.LastIssuanceRequest
 	return nil
 }
 
@@ -905,6 +1673,58 @@ func Convert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certmanag
 	return autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in, out, s)
 }
 
+func autoConvert_v1_CertificateVerification_To_certmanager_CertificateVerification(in *v1.CertificateVerification, out *certmanager.CertificateVerification, s conversion.Scope) error {
+	out.HTTPProbe = (*certmanager.CertificateHTTPProbe)(unsafe.Pointer(in.HTTPProbe))
+	out.Job = (*certmanager.CertificateVerificationJob)(unsafe.Pointer(in.Job))
+	out.Timeout = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RollbackOnFailure = in.RollbackOnFailure
+	return nil
+}
+
+// Convert_v1_CertificateVerification_To_certmanager_CertificateVerification is an autogenerated conversion function.
+func Convert_v1_CertificateVerification_To_certmanager_CertificateVerification(in *v1.CertificateVerification, out *certmanager.CertificateVerification, s conversion.Scope) error {
+	return autoConvert_v1_CertificateVerification_To_certmanager_CertificateVerification(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateVerification_To_v1_CertificateVerification(in *certmanager.CertificateVerification, out *v1.CertificateVerification, s conversion.Scope) error {
+	out.HTTPProbe = (*v1.CertificateHTTPProbe)(unsafe.Pointer(in.HTTPProbe))
+	out.Job = (*v1.CertificateVerificationJob)(unsafe.Pointer(in.Job))
+	out.Timeout = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RollbackOnFailure = in.RollbackOnFailure
+	return nil
+}
+
+// Convert_certmanager_CertificateVerification_To_v1_CertificateVerification is an autogenerated conversion function.
+func Convert_certmanager_CertificateVerification_To_v1_CertificateVerification(in *certmanager.CertificateVerification, out *v1.CertificateVerification, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateVerification_To_v1_CertificateVerification(in, out, s)
+}
+
+func autoConvert_v1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in *v1.CertificateVerificationJob, out *certmanager.CertificateVerificationJob, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_v1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob is an autogenerated conversion function.
+func Convert_v1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in *v1.CertificateVerificationJob, out *certmanager.CertificateVerificationJob, s conversion.Scope) error {
+	return autoConvert_v1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateVerificationJob_To_v1_CertificateVerificationJob(in *certmanager.CertificateVerificationJob, out *v1.CertificateVerificationJob, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_certmanager_CertificateVerificationJob_To_v1_CertificateVerificationJob is an autogenerated conversion function.
+func Convert_certmanager_CertificateVerificationJob_To_v1_CertificateVerificationJob(in *certmanager.CertificateVerificationJob, out *v1.CertificateVerificationJob, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateVerificationJob_To_v1_CertificateVerificationJob(in, out, s)
+}
+
 func autoConvert_v1_ClusterIssuer_To_certmanager_ClusterIssuer(in *v1.ClusterIssuer, out *certmanager.ClusterIssuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -979,6 +1799,32 @@ func Convert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in *certmanag
 	return autoConvert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in *v1.ConfigMapKeySelector, out *certmanager.ConfigMapKeySelector, s conversion.Scope) error {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.LocalObjectReference, &out.LocalObjectReference, s); err != nil {
+		return err
+	}
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector is an autogenerated conversion function.
+func Convert_v1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in *v1.ConfigMapKeySelector, out *certmanager.ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_v1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in, out, s)
+}
+
+func autoConvert_certmanager_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(in *certmanager.ConfigMapKeySelector, out *v1.ConfigMapKeySelector, s conversion.Scope) error {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.LocalObjectReference, &out.LocalObjectReference, s); err != nil {
+		return err
+	}
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_ConfigMapKeySelector_To_v1_ConfigMapKeySelector is an autogenerated conversion function.
+func Convert_certmanager_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(in *certmanager.ConfigMapKeySelector, out *v1.ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_certmanager_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(in, out, s)
+}
+
 func autoConvert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1014,7 +1860,7 @@ func Convert_certmanager_Issuer_To_v1_Issuer(in *certmanager.Issuer, out *v1.Iss
 func autoConvert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1029,7 +1875,7 @@ func Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCond
 func autoConvert_certmanager_IssuerCondition_To_v1_IssuerCondition(in *certmanager.IssuerCondition, out *v1.IssuerCondition, s conversion.Scope) error {
 	out.Type = v1.IssuerConditionType(in.Type)
 	out.Status = apismetav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1051,7 +1897,15 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1071,6 +1925,7 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.Venafi = nil
 	}
+	out.KubernetesCSR = (*certmanager.KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -1089,7 +1944,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*v1.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1.CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(v1.VaultIssuer)
@@ -1109,6 +1972,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.Venafi = nil
 	}
+	out.KubernetesCSR = (*v1.KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -1163,6 +2027,7 @@ func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out
 	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.TLS = (*certmanager.IssuerTLSConfig)(unsafe.Pointer(in.TLS))
 	return nil
 }
 
@@ -1175,6 +2040,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerS
 	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.TLS = (*v1.IssuerTLSConfig)(unsafe.Pointer(in.TLS))
 	return nil
 }
 
@@ -1186,6 +2052,7 @@ func Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec,
 func autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*certmanager.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1197,6 +2064,7 @@ func Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, ou
 func autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*apisacmev1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*v1.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1205,9 +2073,31 @@ func Convert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerS
 	return autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in, out, s)
 }
 
+func autoConvert_v1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in *v1.IssuerTLSConfig, out *certmanager.IssuerTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_v1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig is an autogenerated conversion function.
+func Convert_v1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in *v1.IssuerTLSConfig, out *certmanager.IssuerTLSConfig, s conversion.Scope) error {
+	return autoConvert_v1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerTLSConfig_To_v1_IssuerTLSConfig(in *certmanager.IssuerTLSConfig, out *v1.IssuerTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_certmanager_IssuerTLSConfig_To_v1_IssuerTLSConfig is an autogenerated conversion function.
+func Convert_certmanager_IssuerTLSConfig_To_v1_IssuerTLSConfig(in *certmanager.IssuerTLSConfig, out *v1.IssuerTLSConfig, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerTLSConfig_To_v1_IssuerTLSConfig(in, out, s)
+}
+
 func autoConvert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1220,7 +2110,7 @@ func Convert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *
 
 func autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeystore, out *v1.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1231,9 +2121,29 @@ func Convert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeysto
 	return autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *v1.KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	return nil
+}
+
+// Convert_v1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_v1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *v1.KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_v1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_KubernetesCSRIssuer_To_v1_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *v1.KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	return nil
+}
+
+// Convert_certmanager_KubernetesCSRIssuer_To_v1_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_certmanager_KubernetesCSRIssuer_To_v1_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *v1.KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_KubernetesCSRIssuer_To_v1_KubernetesCSRIssuer(in, out, s)
+}
+
 func autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1246,7 +2156,7 @@ func Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keysto
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1257,6 +2167,32 @@ func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKC
 	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *v1.PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	out.Interval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Interval))
+	out.AdvanceNotice = (*pkgapismetav1.Duration)(unsafe.Pointer(in.AdvanceNotice))
+	return nil
+}
+
+// Convert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *v1.PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in, out, s)
+}
+
+func autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *v1.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	out.Interval = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Interval))
+	out.AdvanceNotice = (*pkgapismetav1.Duration)(unsafe.Pointer(in.AdvanceNotice))
+	return nil
+}
+
+// Convert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *v1.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(in, out, s)
+}
+
 func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1280,7 +2216,7 @@ func Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager
 func autoConvert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1294,7 +2230,7 @@ func Convert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole, ou
 func autoConvert_certmanager_VaultAppRole_To_v1_VaultAppRole(in *certmanager.VaultAppRole, out *v1.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1309,7 +2245,7 @@ func autoConvert_v1_VaultAuth_To_certmanager_VaultAuth(in *v1.VaultAuth, out *ce
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1345,7 +2281,7 @@ func autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(apismetav1.SecretKeySelector)
-		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1385,6 +2321,8 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Mode = certmanager.VaultIssuerMode(in.Mode)
+	out.ResponseWrapTTL = (*pkgapismetav1.Duration)(unsafe.Pointer(in.ResponseWrapTTL))
 	return nil
 }
 
@@ -1401,6 +2339,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Mode = v1.VaultIssuerMode(in.Mode)
+	out.ResponseWrapTTL = (*pkgapismetav1.Duration)(unsafe.Pointer(in.ResponseWrapTTL))
 	return nil
 }
 
@@ -1411,7 +2351,7 @@ func Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.VaultIssu
 
 func autoConvert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1425,7 +2365,7 @@ func Convert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1.Va
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *v1.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1439,7 +2379,7 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1452,7 +2392,7 @@ func Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *
 
 func autoConvert_certmanager_VenafiCloud_To_v1_VenafiCloud(in *certmanager.VenafiCloud, out *v1.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1521,10 +2461,11 @@ func Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.VenafiI
 
 func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ServiceGeneratedCSR = in.ServiceGeneratedCSR
 	return nil
 }
 
@@ -1535,10 +2476,11 @@ func Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certma
 
 func autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP, out *v1.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ServiceGeneratedCSR = in.ServiceGeneratedCSR
 	return nil
 }
 
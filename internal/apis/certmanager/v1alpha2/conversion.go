@@ -125,3 +125,33 @@ func Convert_certmanager_CertificateRequestSpec_To_v1alpha2_CertificateRequestSp
 	out.CSRPEM = in.Request
 	return nil
 }
+
+func Convert_v1alpha2_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	if err := autoConvert_v1alpha2_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in, out, s); err != nil {
+		return err
+	}
+
+	switch in.Algorithm {
+	case ECDSAKeyAlgorithm:
+		out.Algorithm = certmanager.ECDSAKeyAlgorithm
+	case RSAKeyAlgorithm:
+		out.Algorithm = certmanager.RSAKeyAlgorithm
+	}
+
+	return nil
+}
+
+func Convert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha2_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	if err := autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha2_CertificateAdditionalPrivateKey(in, out, s); err != nil {
+		return err
+	}
+
+	switch in.Algorithm {
+	case certmanager.ECDSAKeyAlgorithm:
+		out.Algorithm = ECDSAKeyAlgorithm
+	case certmanager.RSAKeyAlgorithm:
+		out.Algorithm = RSAKeyAlgorithm
+	}
+
+	return nil
+}
@@ -92,6 +92,18 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// IssuanceRequestAnnotationKey is an annotation that can be added to
+	// Certificate resources by users or external controllers to request the
+	// issuance of a new certificate. Its value is opaque: an issuance is
+	// triggered each time it is set to a value which differs from
+	// `status.lastIssuanceRequest`, even if the current certificate is still
+	// valid and re-issuance is backing off after failures. The request is
+	// acknowledged by setting `status.lastIssuanceRequest` to the value and
+	// the `IssuanceRequested` condition to `True`. That condition becomes
+	// `False` once the requested issuance has completed, with the reason
+	// `Issued` if it succeeded or `Failed` if it did not.
+	IssuanceRequestAnnotationKey = "cert-manager.io/issuance-request"
 )
 
 // Common/known resource kinds.
@@ -149,6 +149,13 @@ type CertificateRequestStatus struct {
 	// +optional
 	CA []byte `json:"ca,omitempty"`
 
+	// The PEM encoded x509 certificate chain resulting from additionally
+	// signing the certificate signing request with the cross-signing CA
+	// keypair of the issuer.
+	// This is only set by CA issuers with a cross-signing keypair.
+	// +optional
+	CrossSignedCertificate []byte `json:"crossSignedCertificate,omitempty"`
+
 	// FailureTime stores the time that this CertificateRequest failed. This is
 	// used to influence garbage collection and back-off.
 	// +optional
@@ -28,9 +28,9 @@ import (
 	acmev1alpha3 "github.com/cert-manager/cert-manager/internal/apis/acme/v1alpha3"
 	certmanager "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
-	apismetav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
+	v1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -52,6 +52,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CAIssuerStatus)(nil), (*certmanager.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(a.(*CAIssuerStatus), b.(*certmanager.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerStatus)(nil), (*CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(a.(*certmanager.CAIssuerStatus), b.(*CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CAPKCS12Keypair)(nil), (*certmanager.CAPKCS12Keypair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(a.(*CAPKCS12Keypair), b.(*certmanager.CAPKCS12Keypair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS12Keypair)(nil), (*CAPKCS12Keypair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS12Keypair_To_v1alpha3_CAPKCS12Keypair(a.(*certmanager.CAPKCS12Keypair), b.(*CAPKCS12Keypair), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Certificate_To_certmanager_Certificate(a.(*Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -72,6 +92,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateAdditionalTrustedCA)(nil), (*certmanager.CertificateAdditionalTrustedCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(a.(*CertificateAdditionalTrustedCA), b.(*certmanager.CertificateAdditionalTrustedCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalTrustedCA)(nil), (*CertificateAdditionalTrustedCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalTrustedCA_To_v1alpha3_CertificateAdditionalTrustedCA(a.(*certmanager.CertificateAdditionalTrustedCA), b.(*CertificateAdditionalTrustedCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateCanary)(nil), (*certmanager.CertificateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateCanary_To_certmanager_CertificateCanary(a.(*CertificateCanary), b.(*certmanager.CertificateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateCanary)(nil), (*CertificateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateCanary_To_v1alpha3_CertificateCanary(a.(*certmanager.CertificateCanary), b.(*CertificateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateChainOptions)(nil), (*certmanager.CertificateChainOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateChainOptions_To_certmanager_CertificateChainOptions(a.(*CertificateChainOptions), b.(*certmanager.CertificateChainOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateChainOptions)(nil), (*CertificateChainOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateChainOptions_To_v1alpha3_CertificateChainOptions(a.(*certmanager.CertificateChainOptions), b.(*CertificateChainOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(a.(*CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -82,6 +132,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateHTTPProbe)(nil), (*certmanager.CertificateHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(a.(*CertificateHTTPProbe), b.(*certmanager.CertificateHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateHTTPProbe)(nil), (*CertificateHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateHTTPProbe_To_v1alpha3_CertificateHTTPProbe(a.(*certmanager.CertificateHTTPProbe), b.(*CertificateHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -102,11 +162,51 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateOCSPStapling)(nil), (*certmanager.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(a.(*CertificateOCSPStapling), b.(*certmanager.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateOCSPStapling)(nil), (*CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(a.(*certmanager.CertificateOCSPStapling), b.(*CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateObservation)(nil), (*certmanager.CertificateObservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateObservation_To_certmanager_CertificateObservation(a.(*CertificateObservation), b.(*certmanager.CertificateObservation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateObservation)(nil), (*CertificateObservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateObservation_To_v1alpha3_CertificateObservation(a.(*certmanager.CertificateObservation), b.(*CertificateObservation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateRenewalWindow)(nil), (*certmanager.CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(a.(*CertificateRenewalWindow), b.(*certmanager.CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalWindow)(nil), (*CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalWindow_To_v1alpha3_CertificateRenewalWindow(a.(*certmanager.CertificateRenewalWindow), b.(*CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateRenewalWindows)(nil), (*certmanager.CertificateRenewalWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(a.(*CertificateRenewalWindows), b.(*certmanager.CertificateRenewalWindows), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalWindows)(nil), (*CertificateRenewalWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalWindows_To_v1alpha3_CertificateRenewalWindows(a.(*certmanager.CertificateRenewalWindows), b.(*CertificateRenewalWindows), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(a.(*CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -167,6 +267,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateVerification)(nil), (*certmanager.CertificateVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateVerification_To_certmanager_CertificateVerification(a.(*CertificateVerification), b.(*certmanager.CertificateVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateVerification)(nil), (*CertificateVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateVerification_To_v1alpha3_CertificateVerification(a.(*certmanager.CertificateVerification), b.(*CertificateVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateVerificationJob)(nil), (*certmanager.CertificateVerificationJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(a.(*CertificateVerificationJob), b.(*certmanager.CertificateVerificationJob), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateVerificationJob)(nil), (*CertificateVerificationJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateVerificationJob_To_v1alpha3_CertificateVerificationJob(a.(*certmanager.CertificateVerificationJob), b.(*CertificateVerificationJob), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterIssuer)(nil), (*certmanager.ClusterIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ClusterIssuer_To_certmanager_ClusterIssuer(a.(*ClusterIssuer), b.(*certmanager.ClusterIssuer), scope)
 	}); err != nil {
@@ -187,6 +307,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ConfigMapKeySelector)(nil), (*certmanager.ConfigMapKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(a.(*ConfigMapKeySelector), b.(*certmanager.ConfigMapKeySelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ConfigMapKeySelector)(nil), (*ConfigMapKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ConfigMapKeySelector_To_v1alpha3_ConfigMapKeySelector(a.(*certmanager.ConfigMapKeySelector), b.(*ConfigMapKeySelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Issuer_To_certmanager_Issuer(a.(*Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -247,6 +377,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerTLSConfig)(nil), (*certmanager.IssuerTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(a.(*IssuerTLSConfig), b.(*certmanager.IssuerTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerTLSConfig)(nil), (*IssuerTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerTLSConfig_To_v1alpha3_IssuerTLSConfig(a.(*certmanager.IssuerTLSConfig), b.(*IssuerTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*JKSKeystore)(nil), (*certmanager.JKSKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_JKSKeystore_To_certmanager_JKSKeystore(a.(*JKSKeystore), b.(*certmanager.JKSKeystore), scope)
 	}); err != nil {
@@ -257,6 +397,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KubernetesCSRIssuer)(nil), (*certmanager.KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(a.(*KubernetesCSRIssuer), b.(*certmanager.KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.KubernetesCSRIssuer)(nil), (*KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_KubernetesCSRIssuer_To_v1alpha3_KubernetesCSRIssuer(a.(*certmanager.KubernetesCSRIssuer), b.(*KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -267,6 +417,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PrivateKeyRotationSchedule)(nil), (*certmanager.PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(a.(*PrivateKeyRotationSchedule), b.(*certmanager.PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PrivateKeyRotationSchedule)(nil), (*PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PrivateKeyRotationSchedule_To_v1alpha3_PrivateKeyRotationSchedule(a.(*certmanager.PrivateKeyRotationSchedule), b.(*PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -352,6 +512,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*certmanager.CertificateAdditionalPrivateKey)(nil), (*CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha3_CertificateAdditionalPrivateKey(a.(*certmanager.CertificateAdditionalPrivateKey), b.(*CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*certmanager.CertificatePrivateKey)(nil), (*CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_certmanager_CertificatePrivateKey_To_v1alpha3_CertificatePrivateKey(a.(*certmanager.CertificatePrivateKey), b.(*CertificatePrivateKey), scope)
 	}); err != nil {
@@ -372,6 +537,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*CertificateAdditionalPrivateKey)(nil), (*certmanager.CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(a.(*CertificateAdditionalPrivateKey), b.(*certmanager.CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*CertificateRequestSpec)(nil), (*certmanager.CertificateRequestSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(a.(*CertificateRequestSpec), b.(*certmanager.CertificateRequestSpec), scope)
 	}); err != nil {
@@ -389,6 +559,17 @@ func autoConvert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *ce
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(certmanager.CAPKCS12Keypair)
+		if err := Convert_v1alpha3_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS12 = nil
+	}
+	out.RotationOverlap = (*apismetav1.Duration)(unsafe.Pointer(in.RotationOverlap))
+	out.CrossSigningSecretName = in.CrossSigningSecretName
 	return nil
 }
 
@@ -401,6 +582,17 @@ func autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIss
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(CAPKCS12Keypair)
+		if err := Convert_certmanager_CAPKCS12Keypair_To_v1alpha3_CAPKCS12Keypair(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS12 = nil
+	}
+	out.RotationOverlap = (*apismetav1.Duration)(unsafe.Pointer(in.RotationOverlap))
+	out.CrossSigningSecretName = in.CrossSigningSecretName
 	return nil
 }
 
@@ -409,6 +601,54 @@ func Convert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
+	out.PreviousCertificate = *(*[]byte)(unsafe.Pointer(&in.PreviousCertificate))
+	out.LastRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.LastRotationTime))
+	return nil
+}
+
+// Convert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus is an autogenerated conversion function.
+func Convert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *CAIssuerStatus, s conversion.Scope) error {
+	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
+	out.PreviousCertificate = *(*[]byte)(unsafe.Pointer(&in.PreviousCertificate))
+	out.LastRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.LastRotationTime))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_v1alpha3_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in *CAPKCS12Keypair, out *certmanager.CAPKCS12Keypair, s conversion.Scope) error {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair is an autogenerated conversion function.
+func Convert_v1alpha3_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in *CAPKCS12Keypair, out *certmanager.CAPKCS12Keypair, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS12Keypair_To_v1alpha3_CAPKCS12Keypair(in *certmanager.CAPKCS12Keypair, out *CAPKCS12Keypair, s conversion.Scope) error {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS12Keypair_To_v1alpha3_CAPKCS12Keypair is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS12Keypair_To_v1alpha3_CAPKCS12Keypair(in *certmanager.CAPKCS12Keypair, out *CAPKCS12Keypair, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS12Keypair_To_v1alpha3_CAPKCS12Keypair(in, out, s)
+}
+
 func autoConvert_v1alpha3_Certificate_To_certmanager_Certificate(in *Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -461,10 +701,116 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha3_Certifica
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha3_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+func autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha3_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = KeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+func autoConvert_v1alpha3_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in *CertificateAdditionalTrustedCA, out *certmanager.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(meta.SecretKeySelector)
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(certmanager.ConfigMapKeySelector)
+		if err := Convert_v1alpha3_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMap = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in *CertificateAdditionalTrustedCA, out *certmanager.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalTrustedCA_To_v1alpha3_CertificateAdditionalTrustedCA(in *certmanager.CertificateAdditionalTrustedCA, out *CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(metav1.SecretKeySelector)
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapKeySelector)
+		if err := Convert_certmanager_ConfigMapKeySelector_To_v1alpha3_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMap = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalTrustedCA_To_v1alpha3_CertificateAdditionalTrustedCA is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalTrustedCA_To_v1alpha3_CertificateAdditionalTrustedCA(in *certmanager.CertificateAdditionalTrustedCA, out *CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalTrustedCA_To_v1alpha3_CertificateAdditionalTrustedCA(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateCanary_To_certmanager_CertificateCanary(in *CertificateCanary, out *certmanager.CertificateCanary, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_v1alpha3_CertificateCanary_To_certmanager_CertificateCanary is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateCanary_To_certmanager_CertificateCanary(in *CertificateCanary, out *certmanager.CertificateCanary, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateCanary_To_certmanager_CertificateCanary(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateCanary_To_v1alpha3_CertificateCanary(in *certmanager.CertificateCanary, out *CertificateCanary, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_certmanager_CertificateCanary_To_v1alpha3_CertificateCanary is an autogenerated conversion function.
+func Convert_certmanager_CertificateCanary_To_v1alpha3_CertificateCanary(in *certmanager.CertificateCanary, out *CertificateCanary, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateCanary_To_v1alpha3_CertificateCanary(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateChainOptions_To_certmanager_CertificateChainOptions(in *CertificateChainOptions, out *certmanager.CertificateChainOptions, s conversion.Scope) error {
+	out.MaxDepth = in.MaxDepth
+	return nil
+}
+
+// Convert_v1alpha3_CertificateChainOptions_To_certmanager_CertificateChainOptions is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateChainOptions_To_certmanager_CertificateChainOptions(in *CertificateChainOptions, out *certmanager.CertificateChainOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateChainOptions_To_certmanager_CertificateChainOptions(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateChainOptions_To_v1alpha3_CertificateChainOptions(in *certmanager.CertificateChainOptions, out *CertificateChainOptions, s conversion.Scope) error {
+	out.MaxDepth = in.MaxDepth
+	return nil
+}
+
+// Convert_certmanager_CertificateChainOptions_To_v1alpha3_CertificateChainOptions is an autogenerated conversion function.
+func Convert_certmanager_CertificateChainOptions_To_v1alpha3_CertificateChainOptions(in *certmanager.CertificateChainOptions, out *CertificateChainOptions, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateChainOptions_To_v1alpha3_CertificateChainOptions(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(in *CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -479,7 +825,7 @@ func Convert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(i
 func autoConvert_certmanager_CertificateCondition_To_v1alpha3_CertificateCondition(in *certmanager.CertificateCondition, out *CertificateCondition, s conversion.Scope) error {
 	out.Type = CertificateConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -491,6 +837,26 @@ func Convert_certmanager_CertificateCondition_To_v1alpha3_CertificateCondition(i
 	return autoConvert_certmanager_CertificateCondition_To_v1alpha3_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in *CertificateHTTPProbe, out *certmanager.CertificateHTTPProbe, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1alpha3_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in *CertificateHTTPProbe, out *certmanager.CertificateHTTPProbe, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateHTTPProbe_To_v1alpha3_CertificateHTTPProbe(in *certmanager.CertificateHTTPProbe, out *CertificateHTTPProbe, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_certmanager_CertificateHTTPProbe_To_v1alpha3_CertificateHTTPProbe is an autogenerated conversion function.
+func Convert_certmanager_CertificateHTTPProbe_To_v1alpha3_CertificateHTTPProbe(in *certmanager.CertificateHTTPProbe, out *CertificateHTTPProbe, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateHTTPProbe_To_v1alpha3_CertificateHTTPProbe(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateKeystores_To_certmanager_CertificateKeystores(in *CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -587,8 +953,61 @@ func Convert_certmanager_CertificateList_To_v1alpha3_CertificateList(in *certman
 	return autoConvert_certmanager_CertificateList_To_v1alpha3_CertificateList(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	out.RefreshInterval = (*apismetav1.Duration)(unsafe.Pointer(in.RefreshInterval))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *CertificateOCSPStapling, s conversion.Scope) error {
+	out.RefreshInterval = (*apismetav1.Duration)(unsafe.Pointer(in.RefreshInterval))
+	return nil
+}
+
+// Convert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateObservation_To_certmanager_CertificateObservation(in *CertificateObservation, out *certmanager.CertificateObservation, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.Issuer = in.Issuer
+	out.SerialNumber = in.SerialNumber
+	return nil
+}
+
+// Convert_v1alpha3_CertificateObservation_To_certmanager_CertificateObservation is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateObservation_To_certmanager_CertificateObservation(in *CertificateObservation, out *certmanager.CertificateObservation, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateObservation_To_certmanager_CertificateObservation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateObservation_To_v1alpha3_CertificateObservation(in *certmanager.CertificateObservation, out *CertificateObservation, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.Issuer = in.Issuer
+	out.SerialNumber = in.SerialNumber
+	return nil
+}
+
+// Convert_certmanager_CertificateObservation_To_v1alpha3_CertificateObservation is an autogenerated conversion function.
+func Convert_certmanager_CertificateObservation_To_v1alpha3_CertificateObservation(in *certmanager.CertificateObservation, out *CertificateObservation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateObservation_To_v1alpha3_CertificateObservation(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*certmanager.PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	return nil
 }
 
@@ -599,12 +1018,59 @@ func Convert_v1alpha3_CertificatePrivateKey_To_certmanager_CertificatePrivateKey
 
 func autoConvert_certmanager_CertificatePrivateKey_To_v1alpha3_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	// WARNING: in.Encoding requires manual conversion: does not exist in peer-type
 	// WARNING: in.Algorithm requires manual conversion: does not exist in peer-type
 	// WARNING: in.Size requires manual conversion: does not exist in peer-type
 	return nil
 }
 
+func autoConvert_v1alpha3_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_v1alpha3_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalWindow_To_v1alpha3_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *CertificateRenewalWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalWindow_To_v1alpha3_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalWindow_To_v1alpha3_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalWindow_To_v1alpha3_CertificateRenewalWindow(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in *CertificateRenewalWindows, out *certmanager.CertificateRenewalWindows, s conversion.Scope) error {
+	out.Windows = *(*[]certmanager.CertificateRenewalWindow)(unsafe.Pointer(&in.Windows))
+	out.TimeZone = in.TimeZone
+	out.UrgentRenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.UrgentRenewBefore))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in *CertificateRenewalWindows, out *certmanager.CertificateRenewalWindows, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalWindows_To_v1alpha3_CertificateRenewalWindows(in *certmanager.CertificateRenewalWindows, out *CertificateRenewalWindows, s conversion.Scope) error {
+	out.Windows = *(*[]CertificateRenewalWindow)(unsafe.Pointer(&in.Windows))
+	out.TimeZone = in.TimeZone
+	out.UrgentRenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.UrgentRenewBefore))
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalWindows_To_v1alpha3_CertificateRenewalWindows is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalWindows_To_v1alpha3_CertificateRenewalWindows(in *certmanager.CertificateRenewalWindows, out *CertificateRenewalWindows, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalWindows_To_v1alpha3_CertificateRenewalWindows(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(in *CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -640,7 +1106,7 @@ func Convert_certmanager_CertificateRequest_To_v1alpha3_CertificateRequest(in *c
 func autoConvert_v1alpha3_CertificateRequestCondition_To_certmanager_CertificateRequestCondition(in *CertificateRequestCondition, out *certmanager.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateRequestConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -654,7 +1120,7 @@ func Convert_v1alpha3_CertificateRequestCondition_To_certmanager_CertificateRequ
 func autoConvert_certmanager_CertificateRequestCondition_To_v1alpha3_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = CertificateRequestConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -708,8 +1174,8 @@ func Convert_certmanager_CertificateRequestList_To_v1alpha3_CertificateRequestLi
 }
 
 func autoConvert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := v1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	// WARNING: in.CSRPEM requires manual conversion: does not exist in peer-type
@@ -723,8 +1189,8 @@ func autoConvert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateReque
 }
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1alpha3_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := v1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	// WARNING: in.Request requires manual conversion: does not exist in peer-type
@@ -741,7 +1207,8 @@ func autoConvert_v1alpha3_CertificateRequestStatus_To_certmanager_CertificateReq
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.CrossSignedCertificate = *(*[]byte)(unsafe.Pointer(&in.CrossSignedCertificate))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -754,7 +1221,8 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1alpha3_CertificateReq
 	out.Conditions = *(*[]CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.CrossSignedCertificate = *(*[]byte)(unsafe.Pointer(&in.CrossSignedCertificate))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -797,8 +1265,8 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	}
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
@@ -814,7 +1282,7 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := v1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
@@ -834,6 +1302,33 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = make([]certmanager.CertificateAdditionalTrustedCA, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalTrustedCAs = nil
+	}
+	out.Canary = (*certmanager.CertificateCanary)(unsafe.Pointer(in.Canary))
+	out.Chain = (*certmanager.CertificateChainOptions)(unsafe.Pointer(in.Chain))
+	out.RenewalWindows = (*certmanager.CertificateRenewalWindows)(unsafe.Pointer(in.RenewalWindows))
+	out.Verification = (*certmanager.CertificateVerification)(unsafe.Pointer(in.Verification))
+	if in.AdditionalPrivateKey != nil {
+		in, out := &in.AdditionalPrivateKey, &out.AdditionalPrivateKey
+		*out = new(certmanager.CertificateAdditionalPrivateKey)
+		if err := Convert_v1alpha3_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalPrivateKey = nil
+	}
+	out.OCSPStapling = (*certmanager.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	out.RenewalDisabled = in.RenewalDisabled
+	out.ObserveOnly = in.ObserveOnly
 	return nil
 }
 
@@ -849,8 +1344,8 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	}
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
@@ -866,7 +1361,7 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := v1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
@@ -883,18 +1378,51 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = make([]CertificateAdditionalTrustedCA, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateAdditionalTrustedCA_To_v1alpha3_CertificateAdditionalTrustedCA(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalTrustedCAs = nil
+	}
+	out.Canary = (*CertificateCanary)(unsafe.Pointer(in.Canary))
+	out.Chain = (*CertificateChainOptions)(unsafe.Pointer(in.Chain))
+	out.RenewalWindows = (*CertificateRenewalWindows)(unsafe.Pointer(in.RenewalWindows))
+	out.Verification = (*CertificateVerification)(unsafe.Pointer(in.Verification))
+	if in.AdditionalPrivateKey != nil {
+		in, out := &in.AdditionalPrivateKey, &out.AdditionalPrivateKey
+		*out = new(CertificateAdditionalPrivateKey)
+		if err := Convert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha3_CertificateAdditionalPrivateKey(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalPrivateKey = nil
+	}
+	out.OCSPStapling = (*CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	out.RenewalDisabled = in.RenewalDisabled
+	out.ObserveOnly = in.ObserveOnly
 	return nil
 }
 
 func autoConvert_v1alpha3_CertificateStatus_To_certmanager_CertificateStatus(in *CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*apismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.LastSuccessTime = (*apismetav1.Time)(unsafe.Pointer(in.LastSuccessTime))
+	out.LastError = in.LastError
+	out.NotBefore = (*apismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*apismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*apismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.PrivateKeyRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.PrivateKeyRotationTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.VerificationRevision = (*int)(unsafe.Pointer(in.VerificationRevision))
+	out.Observed = (*certmanager.CertificateObservation)(unsafe.Pointer(in.Observed))
+	out.LastIssuanceRequest = in.LastIssuanceRequest
 	return nil
 }
 
@@ -905,13 +1433,19 @@ func Convert_v1alpha3_CertificateStatus_To_certmanager_CertificateStatus(in *Cer
 
 func autoConvert_certmanager_CertificateStatus_To_v1alpha3_CertificateStatus(in *certmanager.CertificateStatus, out *CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*apismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.LastSuccessTime = (*apismetav1.Time)(unsafe.Pointer(in.LastSuccessTime))
+	out.LastError = in.LastError
+	out.NotBefore = (*apismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*apismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*apismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.PrivateKeyRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.PrivateKeyRotationTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.VerificationRevision = (*int)(unsafe.Pointer(in.VerificationRevision))
+	out.Observed = (*CertificateObservation)(unsafe.Pointer(in.Observed))
+	out.LastIssuanceRequest = in.LastIssuanceRequest
 	return nil
 }
 
@@ -920,6 +1454,58 @@ func Convert_certmanager_CertificateStatus_To_v1alpha3_CertificateStatus(in *cer
 	return autoConvert_certmanager_CertificateStatus_To_v1alpha3_CertificateStatus(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateVerification_To_certmanager_CertificateVerification(in *CertificateVerification, out *certmanager.CertificateVerification, s conversion.Scope) error {
+	out.HTTPProbe = (*certmanager.CertificateHTTPProbe)(unsafe.Pointer(in.HTTPProbe))
+	out.Job = (*certmanager.CertificateVerificationJob)(unsafe.Pointer(in.Job))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RollbackOnFailure = in.RollbackOnFailure
+	return nil
+}
+
+// Convert_v1alpha3_CertificateVerification_To_certmanager_CertificateVerification is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateVerification_To_certmanager_CertificateVerification(in *CertificateVerification, out *certmanager.CertificateVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateVerification_To_certmanager_CertificateVerification(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateVerification_To_v1alpha3_CertificateVerification(in *certmanager.CertificateVerification, out *CertificateVerification, s conversion.Scope) error {
+	out.HTTPProbe = (*CertificateHTTPProbe)(unsafe.Pointer(in.HTTPProbe))
+	out.Job = (*CertificateVerificationJob)(unsafe.Pointer(in.Job))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RollbackOnFailure = in.RollbackOnFailure
+	return nil
+}
+
+// Convert_certmanager_CertificateVerification_To_v1alpha3_CertificateVerification is an autogenerated conversion function.
+func Convert_certmanager_CertificateVerification_To_v1alpha3_CertificateVerification(in *certmanager.CertificateVerification, out *CertificateVerification, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateVerification_To_v1alpha3_CertificateVerification(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in *CertificateVerificationJob, out *certmanager.CertificateVerificationJob, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_v1alpha3_CertificateVerificationJob_To_certmanager_CertificateVerificationJob is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in *CertificateVerificationJob, out *certmanager.CertificateVerificationJob, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateVerificationJob_To_v1alpha3_CertificateVerificationJob(in *certmanager.CertificateVerificationJob, out *CertificateVerificationJob, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_certmanager_CertificateVerificationJob_To_v1alpha3_CertificateVerificationJob is an autogenerated conversion function.
+func Convert_certmanager_CertificateVerificationJob_To_v1alpha3_CertificateVerificationJob(in *certmanager.CertificateVerificationJob, out *CertificateVerificationJob, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateVerificationJob_To_v1alpha3_CertificateVerificationJob(in, out, s)
+}
+
 func autoConvert_v1alpha3_ClusterIssuer_To_certmanager_ClusterIssuer(in *ClusterIssuer, out *certmanager.ClusterIssuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -994,6 +1580,32 @@ func Convert_certmanager_ClusterIssuerList_To_v1alpha3_ClusterIssuerList(in *cer
 	return autoConvert_certmanager_ClusterIssuerList_To_v1alpha3_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha3_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in *ConfigMapKeySelector, out *certmanager.ConfigMapKeySelector, s conversion.Scope) error {
+	if err := v1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.LocalObjectReference, &out.LocalObjectReference, s); err != nil {
+		return err
+	}
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1alpha3_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector is an autogenerated conversion function.
+func Convert_v1alpha3_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in *ConfigMapKeySelector, out *certmanager.ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in, out, s)
+}
+
+func autoConvert_certmanager_ConfigMapKeySelector_To_v1alpha3_ConfigMapKeySelector(in *certmanager.ConfigMapKeySelector, out *ConfigMapKeySelector, s conversion.Scope) error {
+	if err := v1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.LocalObjectReference, &out.LocalObjectReference, s); err != nil {
+		return err
+	}
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_ConfigMapKeySelector_To_v1alpha3_ConfigMapKeySelector is an autogenerated conversion function.
+func Convert_certmanager_ConfigMapKeySelector_To_v1alpha3_ConfigMapKeySelector(in *certmanager.ConfigMapKeySelector, out *ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_certmanager_ConfigMapKeySelector_To_v1alpha3_ConfigMapKeySelector(in, out, s)
+}
+
 func autoConvert_v1alpha3_Issuer_To_certmanager_Issuer(in *Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1029,7 +1641,7 @@ func Convert_certmanager_Issuer_To_v1alpha3_Issuer(in *certmanager.Issuer, out *
 func autoConvert_v1alpha3_IssuerCondition_To_certmanager_IssuerCondition(in *IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1044,7 +1656,7 @@ func Convert_v1alpha3_IssuerCondition_To_certmanager_IssuerCondition(in *IssuerC
 func autoConvert_certmanager_IssuerCondition_To_v1alpha3_IssuerCondition(in *certmanager.IssuerCondition, out *IssuerCondition, s conversion.Scope) error {
 	out.Type = IssuerConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1066,7 +1678,15 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1086,6 +1706,7 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.Venafi = nil
 	}
+	out.KubernetesCSR = (*certmanager.KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -1104,7 +1725,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(VaultIssuer)
@@ -1124,6 +1753,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	} else {
 		out.Venafi = nil
 	}
+	out.KubernetesCSR = (*KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -1178,6 +1808,7 @@ func autoConvert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, o
 	if err := Convert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.TLS = (*certmanager.IssuerTLSConfig)(unsafe.Pointer(in.TLS))
 	return nil
 }
 
@@ -1190,6 +1821,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.I
 	if err := Convert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.TLS = (*IssuerTLSConfig)(unsafe.Pointer(in.TLS))
 	return nil
 }
 
@@ -1201,6 +1833,7 @@ func Convert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.Issue
 func autoConvert_v1alpha3_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*certmanager.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1212,6 +1845,7 @@ func Convert_v1alpha3_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus,
 func autoConvert_certmanager_IssuerStatus_To_v1alpha3_IssuerStatus(in *certmanager.IssuerStatus, out *IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1alpha3.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1220,9 +1854,31 @@ func Convert_certmanager_IssuerStatus_To_v1alpha3_IssuerStatus(in *certmanager.I
 	return autoConvert_certmanager_IssuerStatus_To_v1alpha3_IssuerStatus(in, out, s)
 }
 
+func autoConvert_v1alpha3_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in *IssuerTLSConfig, out *certmanager.IssuerTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_v1alpha3_IssuerTLSConfig_To_certmanager_IssuerTLSConfig is an autogenerated conversion function.
+func Convert_v1alpha3_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in *IssuerTLSConfig, out *certmanager.IssuerTLSConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerTLSConfig_To_v1alpha3_IssuerTLSConfig(in *certmanager.IssuerTLSConfig, out *IssuerTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_certmanager_IssuerTLSConfig_To_v1alpha3_IssuerTLSConfig is an autogenerated conversion function.
+func Convert_certmanager_IssuerTLSConfig_To_v1alpha3_IssuerTLSConfig(in *certmanager.IssuerTLSConfig, out *IssuerTLSConfig, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerTLSConfig_To_v1alpha3_IssuerTLSConfig(in, out, s)
+}
+
 func autoConvert_v1alpha3_JKSKeystore_To_certmanager_JKSKeystore(in *JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1235,7 +1891,7 @@ func Convert_v1alpha3_JKSKeystore_To_certmanager_JKSKeystore(in *JKSKeystore, ou
 
 func autoConvert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in *certmanager.JKSKeystore, out *JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1246,9 +1902,29 @@ func Convert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in *certmanager.JKS
 	return autoConvert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1alpha3_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	return nil
+}
+
+// Convert_v1alpha3_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_v1alpha3_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha3_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_KubernetesCSRIssuer_To_v1alpha3_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	return nil
+}
+
+// Convert_certmanager_KubernetesCSRIssuer_To_v1alpha3_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_certmanager_KubernetesCSRIssuer_To_v1alpha3_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_KubernetesCSRIssuer_To_v1alpha3_KubernetesCSRIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1261,7 +1937,7 @@ func Convert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Key
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1272,6 +1948,32 @@ func Convert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in *certmanag
 	return autoConvert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1alpha3_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	out.Interval = (*apismetav1.Duration)(unsafe.Pointer(in.Interval))
+	out.AdvanceNotice = (*apismetav1.Duration)(unsafe.Pointer(in.AdvanceNotice))
+	return nil
+}
+
+// Convert_v1alpha3_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_v1alpha3_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in, out, s)
+}
+
+func autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1alpha3_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	out.Interval = (*apismetav1.Duration)(unsafe.Pointer(in.Interval))
+	out.AdvanceNotice = (*apismetav1.Duration)(unsafe.Pointer(in.AdvanceNotice))
+	return nil
+}
+
+// Convert_certmanager_PrivateKeyRotationSchedule_To_v1alpha3_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_certmanager_PrivateKeyRotationSchedule_To_v1alpha3_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1alpha3_PrivateKeyRotationSchedule(in, out, s)
+}
+
 func autoConvert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1295,7 +1997,7 @@ func Convert_certmanager_SelfSignedIssuer_To_v1alpha3_SelfSignedIssuer(in *certm
 func autoConvert_v1alpha3_VaultAppRole_To_certmanager_VaultAppRole(in *VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1309,7 +2011,7 @@ func Convert_v1alpha3_VaultAppRole_To_certmanager_VaultAppRole(in *VaultAppRole,
 func autoConvert_certmanager_VaultAppRole_To_v1alpha3_VaultAppRole(in *certmanager.VaultAppRole, out *VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1324,7 +2026,7 @@ func autoConvert_v1alpha3_VaultAuth_To_certmanager_VaultAuth(in *VaultAuth, out
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1360,7 +2062,7 @@ func autoConvert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in *certmanager.Vau
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(metav1.SecretKeySelector)
-		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1400,6 +2102,8 @@ func autoConvert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Mode = certmanager.VaultIssuerMode(in.Mode)
+	out.ResponseWrapTTL = (*apismetav1.Duration)(unsafe.Pointer(in.ResponseWrapTTL))
 	return nil
 }
 
@@ -1416,6 +2120,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Mode = VaultIssuerMode(in.Mode)
+	out.ResponseWrapTTL = (*apismetav1.Duration)(unsafe.Pointer(in.ResponseWrapTTL))
 	return nil
 }
 
@@ -1426,7 +2132,7 @@ func Convert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager.Vau
 
 func autoConvert_v1alpha3_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1440,7 +2146,7 @@ func Convert_v1alpha3_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha3_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1454,7 +2160,7 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1alpha3_VaultKubernetesAuth(in
 
 func autoConvert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1467,7 +2173,7 @@ func Convert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, ou
 
 func autoConvert_certmanager_VenafiCloud_To_v1alpha3_VenafiCloud(in *certmanager.VenafiCloud, out *VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1536,10 +2242,11 @@ func Convert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in *certmanager.V
 
 func autoConvert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := v1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ServiceGeneratedCSR = in.ServiceGeneratedCSR
 	return nil
 }
 
@@ -1550,10 +2257,11 @@ func Convert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *cer
 
 func autoConvert_certmanager_VenafiTPP_To_v1alpha3_VenafiTPP(in *certmanager.VenafiTPP, out *VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := v1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ServiceGeneratedCSR = in.ServiceGeneratedCSR
 	return nil
 }
 
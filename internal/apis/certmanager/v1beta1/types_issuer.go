@@ -89,6 +89,30 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig `json:",inline"`
+
+	// TLS configures the TLS connections the issuer makes to the servers it
+	// communicates with. Of the DNS01 providers, only Azure DNS, Cloudflare,
+	// DigitalOcean and Route53 support configuring TLS.
+	// +optional
+	TLS *IssuerTLSConfig `json:"tls,omitempty"`
+}
+
+// IssuerTLSConfig configures the TLS connections an issuer makes to the
+// servers it communicates with, such as ACME servers, Vault, Venafi and the
+// APIs of DNS01 providers.
+type IssuerTLSConfig struct {
+	// MinVersion is the minimum TLS version of connections, one of
+	// VersionTLS10, VersionTLS11, VersionTLS12 or VersionTLS13. Defaults to
+	// the minimum version of the Go standard library, which is TLS 1.2.
+	// +optional
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites are the names of the cipher suites allowed for TLS 1.2 and
+	// lower versions, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. The cipher
+	// suites of TLS 1.3 are not configurable. Defaults to the cipher suites
+	// of the Go standard library.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
 }
 
 // The configuration for the issuer.
@@ -119,6 +143,12 @@ type IssuerConfig struct {
 	// or Venafi Cloud policy zone.
 	// +optional
 	Venafi *VenafiIssuer `json:"venafi,omitempty"`
+
+	// KubernetesCSR configures this issuer to sign certificates using the
+	// Kubernetes certificates.k8s.io CertificateSigningRequest API, with a
+	// signer which is already running in the cluster.
+	// +optional
+	KubernetesCSR *KubernetesCSRIssuer `json:"kubernetesCSR,omitempty"`
 }
 
 // Configures an issuer to sign certificates using a Venafi TPP
@@ -160,6 +190,16 @@ type VenafiTPP struct {
 	// system root certificates.
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
+
+	// ServiceGeneratedCSR configures the issuer to have the TPP instance
+	// generate the private key and CSR for each certificate, as required by
+	// policies which forbid private keys from being generated by clients.
+	// The subject and subject alternative names of the CertificateRequest's
+	// CSR are used for the request, and the private key returned by TPP is
+	// stored in the Certificate's Secret in place of the key generated by
+	// cert-manager.
+	// +optional
+	ServiceGeneratedCSR bool `json:"serviceGeneratedCSR,omitempty"`
 }
 
 // VenafiCloud defines connection configuration details for Venafi Cloud
@@ -183,6 +223,20 @@ type SelfSignedIssuer struct {
 	CRLDistributionPoints []string `json:"crlDistributionPoints,omitempty"`
 }
 
+// Configures an issuer to sign certificates by creating a Kubernetes
+// CertificateSigningRequest for a signer which is already running in the
+// cluster, such as the signing controller of a managed control plane.
+type KubernetesCSRIssuer struct {
+	// SignerName is the name of the signer which the Kubernetes
+	// CertificateSigningRequests will be requested from, for example
+	// `kubernetes.io/kube-apiserver-client`. The signer must be allowed by
+	// the --kubernetes-csr-allowed-signer-names flag of the cert-manager
+	// controller.
+	// cert-manager does not approve the CertificateSigningRequests it
+	// creates, they must be approved by another party.
+	SignerName string `json:"signerName"`
+}
+
 // Configures an issuer to sign certificates using a HashiCorp Vault
 // PKI backend.
 type VaultIssuer struct {
@@ -194,6 +248,7 @@ type VaultIssuer struct {
 
 	// Path is the mount path of the Vault PKI backend's `sign` endpoint, e.g:
 	// "my_pki_mount/sign/my-role-name".
+	// If `mode` is `Issue`, this is the path of its `issue` endpoint instead.
 	Path string `json:"path"`
 
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
@@ -207,8 +262,41 @@ type VaultIssuer struct {
 	// system root certificates are used to validate the TLS connection.
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Mode configures how certificates are obtained from Vault.
+	// Defaults to `Sign`, where the CSR of each CertificateRequest is signed
+	// by Vault and `path` must be a `sign` endpoint.
+	// If set to `Issue`, Vault generates the private key of each certificate
+	// and `path` must be an `issue` endpoint, e.g:
+	// "my_pki_mount/issue/my-role-name". The subject and subject alternative
+	// names of the CertificateRequest's CSR are used for the request, and
+	// the private key returned by Vault is stored in the Certificate's Secret
+	// in place of the key generated by cert-manager.
+	// +optional
+	Mode VaultIssuerMode `json:"mode,omitempty"`
+
+	// ResponseWrapTTL, if set, requests Vault to wrap the response of each
+	// sign or issue call in a single-use response wrapping token with the
+	// given time to live, which is unwrapped by cert-manager straight away.
+	// The issued certificate, and the private key in `Issue` mode, are then
+	// never part of a response which could be read by another client.
+	// +optional
+	ResponseWrapTTL *metav1.Duration `json:"responseWrapTTL,omitempty"`
 }
 
+// VaultIssuerMode configures how certificates are obtained from Vault.
+// +kubebuilder:validation:Enum=Sign;Issue
+type VaultIssuerMode string
+
+const (
+	// VaultIssuerModeSign has Vault sign the CSR of each CertificateRequest.
+	VaultIssuerModeSign VaultIssuerMode = "Sign"
+
+	// VaultIssuerModeIssue has Vault generate the private key of each
+	// certificate.
+	VaultIssuerModeIssue VaultIssuerMode = "Issue"
+)
+
 // Configuration used to authenticate with a Vault server.
 // Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
 type VaultAuth struct {
@@ -283,6 +371,38 @@ type CAIssuer struct {
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	// +optional
 	OCSPServers []string `json:"ocspServers,omitempty"`
+
+	// PKCS12 configures the Issuer to read its signing keypair from a
+	// password-protected PKCS#12 bundle stored in the `keystore.p12` entry of
+	// the `secretName` Secret, instead of the `tls.crt` and `tls.key` entries.
+	// Any CA certificates in the bundle are appended to the certificate chain.
+	// +optional
+	PKCS12 *CAPKCS12Keypair `json:"pkcs12,omitempty"`
+
+	// RotationOverlap is the period after the signing CA certificate of the
+	// Issuer has been rotated during which issued certificates include both
+	// the previous and the new CA certificate in `ca.crt`, so that clients
+	// trusting the bundle keep working whilst certificates signed by either
+	// CA are in use. If not set, only the current CA certificate is included.
+	// +optional
+	RotationOverlap *metav1.Duration `json:"rotationOverlap,omitempty"`
+
+	// CrossSigningSecretName is the name of a Secret containing a second CA
+	// keypair, in its `tls.crt` and `tls.key` entries, which additionally
+	// signs the certificates issued by this Issuer. The cross-signed
+	// certificate and its chain are stored in the `tls-cross-signed.crt`
+	// entry of the Certificate's Secret, so that clients trusting either
+	// root can verify the certificate during a migration between roots.
+	// +optional
+	CrossSigningSecretName string `json:"crossSigningSecretName,omitempty"`
+}
+
+// CAPKCS12Keypair configures a CA Issuer to read its signing keypair from a
+// password-protected PKCS#12 bundle.
+type CAPKCS12Keypair struct {
+	// PasswordSecretRef is a reference to a key in a Secret resource
+	// containing the password used to decrypt the PKCS#12 bundle.
+	PasswordSecretRef cmmeta.SecretKeySelector `json:"passwordSecretRef"`
 }
 
 // IssuerStatus contains status information about an Issuer
@@ -299,6 +419,32 @@ type IssuerStatus struct {
 	// server to issue certificates.
 	// +optional
 	ACME *cmacme.ACMEIssuerStatus `json:"acme,omitempty"`
+
+	// CA specific status options.
+	// This field is only set if the Issuer is configured to use a CA keypair
+	// with a rotation overlap.
+	// +optional
+	CA *CAIssuerStatus `json:"ca,omitempty"`
+}
+
+// CAIssuerStatus records the signing CA certificates of a CA Issuer, so
+// that the previous CA certificate can be included in `ca.crt` after the
+// signing keypair has been rotated.
+type CAIssuerStatus struct {
+	// Certificate is the PEM encoded CA certificate of the signing keypair
+	// currently used by the Issuer.
+	// +optional
+	Certificate []byte `json:"certificate,omitempty"`
+
+	// PreviousCertificate is the PEM encoded CA certificate of the signing
+	// keypair used by the Issuer before it was last rotated.
+	// +optional
+	PreviousCertificate []byte `json:"previousCertificate,omitempty"`
+
+	// LastRotationTime is the time at which the Issuer observed that its
+	// signing keypair was last rotated.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
 }
 
 // IssuerCondition contains condition information for an Issuer.
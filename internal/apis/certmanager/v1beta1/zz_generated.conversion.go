@@ -28,9 +28,9 @@ import (
 	acmev1beta1 "github.com/cert-manager/cert-manager/internal/apis/acme/v1beta1"
 	certmanager "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
-	apismetav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
+	v1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -52,6 +52,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CAIssuerStatus)(nil), (*certmanager.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CAIssuerStatus_To_certmanager_CAIssuerStatus(a.(*CAIssuerStatus), b.(*certmanager.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerStatus)(nil), (*CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerStatus_To_v1beta1_CAIssuerStatus(a.(*certmanager.CAIssuerStatus), b.(*CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CAPKCS12Keypair)(nil), (*certmanager.CAPKCS12Keypair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(a.(*CAPKCS12Keypair), b.(*certmanager.CAPKCS12Keypair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS12Keypair)(nil), (*CAPKCS12Keypair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS12Keypair_To_v1beta1_CAPKCS12Keypair(a.(*certmanager.CAPKCS12Keypair), b.(*CAPKCS12Keypair), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Certificate_To_certmanager_Certificate(a.(*Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -72,6 +92,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateAdditionalPrivateKey)(nil), (*certmanager.CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(a.(*CertificateAdditionalPrivateKey), b.(*certmanager.CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalPrivateKey)(nil), (*CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalPrivateKey_To_v1beta1_CertificateAdditionalPrivateKey(a.(*certmanager.CertificateAdditionalPrivateKey), b.(*CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateAdditionalTrustedCA)(nil), (*certmanager.CertificateAdditionalTrustedCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(a.(*CertificateAdditionalTrustedCA), b.(*certmanager.CertificateAdditionalTrustedCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalTrustedCA)(nil), (*CertificateAdditionalTrustedCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalTrustedCA_To_v1beta1_CertificateAdditionalTrustedCA(a.(*certmanager.CertificateAdditionalTrustedCA), b.(*CertificateAdditionalTrustedCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateCanary)(nil), (*certmanager.CertificateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateCanary_To_certmanager_CertificateCanary(a.(*CertificateCanary), b.(*certmanager.CertificateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateCanary)(nil), (*CertificateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateCanary_To_v1beta1_CertificateCanary(a.(*certmanager.CertificateCanary), b.(*CertificateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateChainOptions)(nil), (*certmanager.CertificateChainOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateChainOptions_To_certmanager_CertificateChainOptions(a.(*CertificateChainOptions), b.(*certmanager.CertificateChainOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateChainOptions)(nil), (*CertificateChainOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateChainOptions_To_v1beta1_CertificateChainOptions(a.(*certmanager.CertificateChainOptions), b.(*CertificateChainOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateCondition_To_certmanager_CertificateCondition(a.(*CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -82,6 +142,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateHTTPProbe)(nil), (*certmanager.CertificateHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(a.(*CertificateHTTPProbe), b.(*certmanager.CertificateHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateHTTPProbe)(nil), (*CertificateHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateHTTPProbe_To_v1beta1_CertificateHTTPProbe(a.(*certmanager.CertificateHTTPProbe), b.(*CertificateHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -102,6 +172,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateOCSPStapling)(nil), (*certmanager.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(a.(*CertificateOCSPStapling), b.(*certmanager.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateOCSPStapling)(nil), (*CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(a.(*certmanager.CertificateOCSPStapling), b.(*CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateObservation)(nil), (*certmanager.CertificateObservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateObservation_To_certmanager_CertificateObservation(a.(*CertificateObservation), b.(*certmanager.CertificateObservation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateObservation)(nil), (*CertificateObservation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateObservation_To_v1beta1_CertificateObservation(a.(*certmanager.CertificateObservation), b.(*CertificateObservation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
@@ -112,6 +202,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateRenewalWindow)(nil), (*certmanager.CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(a.(*CertificateRenewalWindow), b.(*certmanager.CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalWindow)(nil), (*CertificateRenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalWindow_To_v1beta1_CertificateRenewalWindow(a.(*certmanager.CertificateRenewalWindow), b.(*CertificateRenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateRenewalWindows)(nil), (*certmanager.CertificateRenewalWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(a.(*CertificateRenewalWindows), b.(*certmanager.CertificateRenewalWindows), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalWindows)(nil), (*CertificateRenewalWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalWindows_To_v1beta1_CertificateRenewalWindows(a.(*certmanager.CertificateRenewalWindows), b.(*CertificateRenewalWindows), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateRequest_To_certmanager_CertificateRequest(a.(*CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -192,6 +302,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateVerification)(nil), (*certmanager.CertificateVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateVerification_To_certmanager_CertificateVerification(a.(*CertificateVerification), b.(*certmanager.CertificateVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateVerification)(nil), (*CertificateVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateVerification_To_v1beta1_CertificateVerification(a.(*certmanager.CertificateVerification), b.(*CertificateVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateVerificationJob)(nil), (*certmanager.CertificateVerificationJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(a.(*CertificateVerificationJob), b.(*certmanager.CertificateVerificationJob), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateVerificationJob)(nil), (*CertificateVerificationJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateVerificationJob_To_v1beta1_CertificateVerificationJob(a.(*certmanager.CertificateVerificationJob), b.(*CertificateVerificationJob), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterIssuer)(nil), (*certmanager.ClusterIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ClusterIssuer_To_certmanager_ClusterIssuer(a.(*ClusterIssuer), b.(*certmanager.ClusterIssuer), scope)
 	}); err != nil {
@@ -212,6 +342,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ConfigMapKeySelector)(nil), (*certmanager.ConfigMapKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(a.(*ConfigMapKeySelector), b.(*certmanager.ConfigMapKeySelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ConfigMapKeySelector)(nil), (*ConfigMapKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ConfigMapKeySelector_To_v1beta1_ConfigMapKeySelector(a.(*certmanager.ConfigMapKeySelector), b.(*ConfigMapKeySelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Issuer_To_certmanager_Issuer(a.(*Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -272,6 +412,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerTLSConfig)(nil), (*certmanager.IssuerTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(a.(*IssuerTLSConfig), b.(*certmanager.IssuerTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerTLSConfig)(nil), (*IssuerTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerTLSConfig_To_v1beta1_IssuerTLSConfig(a.(*certmanager.IssuerTLSConfig), b.(*IssuerTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*JKSKeystore)(nil), (*certmanager.JKSKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_JKSKeystore_To_certmanager_JKSKeystore(a.(*JKSKeystore), b.(*certmanager.JKSKeystore), scope)
 	}); err != nil {
@@ -282,6 +432,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KubernetesCSRIssuer)(nil), (*certmanager.KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(a.(*KubernetesCSRIssuer), b.(*certmanager.KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.KubernetesCSRIssuer)(nil), (*KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_KubernetesCSRIssuer_To_v1beta1_KubernetesCSRIssuer(a.(*certmanager.KubernetesCSRIssuer), b.(*KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -292,6 +452,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PrivateKeyRotationSchedule)(nil), (*certmanager.PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(a.(*PrivateKeyRotationSchedule), b.(*certmanager.PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PrivateKeyRotationSchedule)(nil), (*PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PrivateKeyRotationSchedule_To_v1beta1_PrivateKeyRotationSchedule(a.(*certmanager.PrivateKeyRotationSchedule), b.(*PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -389,6 +559,17 @@ func autoConvert_v1beta1_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *cer
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(certmanager.CAPKCS12Keypair)
+		if err := Convert_v1beta1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS12 = nil
+	}
+	out.RotationOverlap = (*apismetav1.Duration)(unsafe.Pointer(in.RotationOverlap))
+	out.CrossSigningSecretName = in.CrossSigningSecretName
 	return nil
 }
 
@@ -401,6 +582,17 @@ func autoConvert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in *certmanager.CAIssu
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(CAPKCS12Keypair)
+		if err := Convert_certmanager_CAPKCS12Keypair_To_v1beta1_CAPKCS12Keypair(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS12 = nil
+	}
+	out.RotationOverlap = (*apismetav1.Duration)(unsafe.Pointer(in.RotationOverlap))
+	out.CrossSigningSecretName = in.CrossSigningSecretName
 	return nil
 }
 
@@ -409,6 +601,54 @@ func Convert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1beta1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
+	out.PreviousCertificate = *(*[]byte)(unsafe.Pointer(&in.PreviousCertificate))
+	out.LastRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.LastRotationTime))
+	return nil
+}
+
+// Convert_v1beta1_CAIssuerStatus_To_certmanager_CAIssuerStatus is an autogenerated conversion function.
+func Convert_v1beta1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerStatus_To_v1beta1_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *CAIssuerStatus, s conversion.Scope) error {
+	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
+	out.PreviousCertificate = *(*[]byte)(unsafe.Pointer(&in.PreviousCertificate))
+	out.LastRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.LastRotationTime))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerStatus_To_v1beta1_CAIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerStatus_To_v1beta1_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerStatus_To_v1beta1_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in *CAPKCS12Keypair, out *certmanager.CAPKCS12Keypair, s conversion.Scope) error {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair is an autogenerated conversion function.
+func Convert_v1beta1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in *CAPKCS12Keypair, out *certmanager.CAPKCS12Keypair, s conversion.Scope) error {
+	return autoConvert_v1beta1_CAPKCS12Keypair_To_certmanager_CAPKCS12Keypair(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS12Keypair_To_v1beta1_CAPKCS12Keypair(in *certmanager.CAPKCS12Keypair, out *CAPKCS12Keypair, s conversion.Scope) error {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS12Keypair_To_v1beta1_CAPKCS12Keypair is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS12Keypair_To_v1beta1_CAPKCS12Keypair(in *certmanager.CAPKCS12Keypair, out *CAPKCS12Keypair, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS12Keypair_To_v1beta1_CAPKCS12Keypair(in, out, s)
+}
+
 func autoConvert_v1beta1_Certificate_To_certmanager_Certificate(in *Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -461,10 +701,126 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1beta1_Certificat
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1beta1_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1beta1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_v1beta1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1beta1_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalPrivateKey_To_v1beta1_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalPrivateKey_To_v1beta1_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1beta1_CertificateAdditionalPrivateKey(in, out, s)
+}
+
+func autoConvert_v1beta1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in *CertificateAdditionalTrustedCA, out *certmanager.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(meta.SecretKeySelector)
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(certmanager.ConfigMapKeySelector)
+		if err := Convert_v1beta1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMap = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA is an autogenerated conversion function.
+func Convert_v1beta1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in *CertificateAdditionalTrustedCA, out *certmanager.CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalTrustedCA_To_v1beta1_CertificateAdditionalTrustedCA(in *certmanager.CertificateAdditionalTrustedCA, out *CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(metav1.SecretKeySelector)
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapKeySelector)
+		if err := Convert_certmanager_ConfigMapKeySelector_To_v1beta1_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMap = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalTrustedCA_To_v1beta1_CertificateAdditionalTrustedCA is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalTrustedCA_To_v1beta1_CertificateAdditionalTrustedCA(in *certmanager.CertificateAdditionalTrustedCA, out *CertificateAdditionalTrustedCA, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalTrustedCA_To_v1beta1_CertificateAdditionalTrustedCA(in, out, s)
+}
+
+func autoConvert_v1beta1_CertificateCanary_To_certmanager_CertificateCanary(in *CertificateCanary, out *certmanager.CertificateCanary, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_v1beta1_CertificateCanary_To_certmanager_CertificateCanary is an autogenerated conversion function.
+func Convert_v1beta1_CertificateCanary_To_certmanager_CertificateCanary(in *CertificateCanary, out *certmanager.CertificateCanary, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateCanary_To_certmanager_CertificateCanary(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateCanary_To_v1beta1_CertificateCanary(in *certmanager.CertificateCanary, out *CertificateCanary, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_certmanager_CertificateCanary_To_v1beta1_CertificateCanary is an autogenerated conversion function.
+func Convert_certmanager_CertificateCanary_To_v1beta1_CertificateCanary(in *certmanager.CertificateCanary, out *CertificateCanary, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateCanary_To_v1beta1_CertificateCanary(in, out, s)
+}
+
+func autoConvert_v1beta1_CertificateChainOptions_To_certmanager_CertificateChainOptions(in *CertificateChainOptions, out *certmanager.CertificateChainOptions, s conversion.Scope) error {
+	out.MaxDepth = in.MaxDepth
+	return nil
+}
+
+// Convert_v1beta1_CertificateChainOptions_To_certmanager_CertificateChainOptions is an autogenerated conversion function.
+func Convert_v1beta1_CertificateChainOptions_To_certmanager_CertificateChainOptions(in *CertificateChainOptions, out *certmanager.CertificateChainOptions, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateChainOptions_To_certmanager_CertificateChainOptions(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateChainOptions_To_v1beta1_CertificateChainOptions(in *certmanager.CertificateChainOptions, out *CertificateChainOptions, s conversion.Scope) error {
+	out.MaxDepth = in.MaxDepth
+	return nil
+}
+
+// Convert_certmanager_CertificateChainOptions_To_v1beta1_CertificateChainOptions is an autogenerated conversion function.
+func Convert_certmanager_CertificateChainOptions_To_v1beta1_CertificateChainOptions(in *certmanager.CertificateChainOptions, out *CertificateChainOptions, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateChainOptions_To_v1beta1_CertificateChainOptions(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateCondition_To_certmanager_CertificateCondition(in *CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -479,7 +835,7 @@ func Convert_v1beta1_CertificateCondition_To_certmanager_CertificateCondition(in
 func autoConvert_certmanager_CertificateCondition_To_v1beta1_CertificateCondition(in *certmanager.CertificateCondition, out *CertificateCondition, s conversion.Scope) error {
 	out.Type = CertificateConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -491,6 +847,26 @@ func Convert_certmanager_CertificateCondition_To_v1beta1_CertificateCondition(in
 	return autoConvert_certmanager_CertificateCondition_To_v1beta1_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in *CertificateHTTPProbe, out *certmanager.CertificateHTTPProbe, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1beta1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe is an autogenerated conversion function.
+func Convert_v1beta1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in *CertificateHTTPProbe, out *certmanager.CertificateHTTPProbe, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateHTTPProbe_To_certmanager_CertificateHTTPProbe(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateHTTPProbe_To_v1beta1_CertificateHTTPProbe(in *certmanager.CertificateHTTPProbe, out *CertificateHTTPProbe, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_certmanager_CertificateHTTPProbe_To_v1beta1_CertificateHTTPProbe is an autogenerated conversion function.
+func Convert_certmanager_CertificateHTTPProbe_To_v1beta1_CertificateHTTPProbe(in *certmanager.CertificateHTTPProbe, out *CertificateHTTPProbe, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateHTTPProbe_To_v1beta1_CertificateHTTPProbe(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateKeystores_To_certmanager_CertificateKeystores(in *CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -587,8 +963,61 @@ func Convert_certmanager_CertificateList_To_v1beta1_CertificateList(in *certmana
 	return autoConvert_certmanager_CertificateList_To_v1beta1_CertificateList(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	out.RefreshInterval = (*apismetav1.Duration)(unsafe.Pointer(in.RefreshInterval))
+	return nil
+}
+
+// Convert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *CertificateOCSPStapling, s conversion.Scope) error {
+	out.RefreshInterval = (*apismetav1.Duration)(unsafe.Pointer(in.RefreshInterval))
+	return nil
+}
+
+// Convert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_v1beta1_CertificateObservation_To_certmanager_CertificateObservation(in *CertificateObservation, out *certmanager.CertificateObservation, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.Issuer = in.Issuer
+	out.SerialNumber = in.SerialNumber
+	return nil
+}
+
+// Convert_v1beta1_CertificateObservation_To_certmanager_CertificateObservation is an autogenerated conversion function.
+func Convert_v1beta1_CertificateObservation_To_certmanager_CertificateObservation(in *CertificateObservation, out *certmanager.CertificateObservation, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateObservation_To_certmanager_CertificateObservation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateObservation_To_v1beta1_CertificateObservation(in *certmanager.CertificateObservation, out *CertificateObservation, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.Issuer = in.Issuer
+	out.SerialNumber = in.SerialNumber
+	return nil
+}
+
+// Convert_certmanager_CertificateObservation_To_v1beta1_CertificateObservation is an autogenerated conversion function.
+func Convert_certmanager_CertificateObservation_To_v1beta1_CertificateObservation(in *certmanager.CertificateObservation, out *CertificateObservation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateObservation_To_v1beta1_CertificateObservation(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*certmanager.PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -602,6 +1031,7 @@ func Convert_v1beta1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(
 
 func autoConvert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	out.Encoding = PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -613,6 +1043,52 @@ func Convert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_v1beta1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_v1beta1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in *CertificateRenewalWindow, out *certmanager.CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateRenewalWindow_To_certmanager_CertificateRenewalWindow(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalWindow_To_v1beta1_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *CertificateRenewalWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalWindow_To_v1beta1_CertificateRenewalWindow is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalWindow_To_v1beta1_CertificateRenewalWindow(in *certmanager.CertificateRenewalWindow, out *CertificateRenewalWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalWindow_To_v1beta1_CertificateRenewalWindow(in, out, s)
+}
+
+func autoConvert_v1beta1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in *CertificateRenewalWindows, out *certmanager.CertificateRenewalWindows, s conversion.Scope) error {
+	out.Windows = *(*[]certmanager.CertificateRenewalWindow)(unsafe.Pointer(&in.Windows))
+	out.TimeZone = in.TimeZone
+	out.UrgentRenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.UrgentRenewBefore))
+	return nil
+}
+
+// Convert_v1beta1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows is an autogenerated conversion function.
+func Convert_v1beta1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in *CertificateRenewalWindows, out *certmanager.CertificateRenewalWindows, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateRenewalWindows_To_certmanager_CertificateRenewalWindows(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalWindows_To_v1beta1_CertificateRenewalWindows(in *certmanager.CertificateRenewalWindows, out *CertificateRenewalWindows, s conversion.Scope) error {
+	out.Windows = *(*[]CertificateRenewalWindow)(unsafe.Pointer(&in.Windows))
+	out.TimeZone = in.TimeZone
+	out.UrgentRenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.UrgentRenewBefore))
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalWindows_To_v1beta1_CertificateRenewalWindows is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalWindows_To_v1beta1_CertificateRenewalWindows(in *certmanager.CertificateRenewalWindows, out *CertificateRenewalWindows, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalWindows_To_v1beta1_CertificateRenewalWindows(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateRequest_To_certmanager_CertificateRequest(in *CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -648,7 +1124,7 @@ func Convert_certmanager_CertificateRequest_To_v1beta1_CertificateRequest(in *ce
 func autoConvert_v1beta1_CertificateRequestCondition_To_certmanager_CertificateRequestCondition(in *CertificateRequestCondition, out *certmanager.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateRequestConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -662,7 +1138,7 @@ func Convert_v1beta1_CertificateRequestCondition_To_certmanager_CertificateReque
 func autoConvert_certmanager_CertificateRequestCondition_To_v1beta1_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = CertificateRequestConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -716,8 +1192,8 @@ func Convert_certmanager_CertificateRequestList_To_v1beta1_CertificateRequestLis
 }
 
 func autoConvert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := v1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -736,8 +1212,8 @@ func Convert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateRequestSpe
 }
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1beta1_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := v1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -759,7 +1235,8 @@ func autoConvert_v1beta1_CertificateRequestStatus_To_certmanager_CertificateRequ
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.CrossSignedCertificate = *(*[]byte)(unsafe.Pointer(&in.CrossSignedCertificate))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -772,7 +1249,8 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1beta1_CertificateRequ
 	out.Conditions = *(*[]CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.CrossSignedCertificate = *(*[]byte)(unsafe.Pointer(&in.CrossSignedCertificate))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -807,8 +1285,8 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *Cert
 	out.Subject = (*certmanager.X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
@@ -824,7 +1302,7 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *Cert
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := v1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
@@ -833,6 +1311,25 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *Cert
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = make([]certmanager.CertificateAdditionalTrustedCA, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_CertificateAdditionalTrustedCA_To_certmanager_CertificateAdditionalTrustedCA(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalTrustedCAs = nil
+	}
+	out.Canary = (*certmanager.CertificateCanary)(unsafe.Pointer(in.Canary))
+	out.Chain = (*certmanager.CertificateChainOptions)(unsafe.Pointer(in.Chain))
+	out.RenewalWindows = (*certmanager.CertificateRenewalWindows)(unsafe.Pointer(in.RenewalWindows))
+	out.Verification = (*certmanager.CertificateVerification)(unsafe.Pointer(in.Verification))
+	out.AdditionalPrivateKey = (*certmanager.CertificateAdditionalPrivateKey)(unsafe.Pointer(in.AdditionalPrivateKey))
+	out.OCSPStapling = (*certmanager.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	out.RenewalDisabled = in.RenewalDisabled
+	out.ObserveOnly = in.ObserveOnly
 	return nil
 }
 
@@ -845,8 +1342,8 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	out.Subject = (*X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
@@ -862,7 +1359,7 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := v1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
@@ -871,6 +1368,25 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.AdditionalTrustedCAs != nil {
+		in, out := &in.AdditionalTrustedCAs, &out.AdditionalTrustedCAs
+		*out = make([]CertificateAdditionalTrustedCA, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateAdditionalTrustedCA_To_v1beta1_CertificateAdditionalTrustedCA(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalTrustedCAs = nil
+	}
+	out.Canary = (*CertificateCanary)(unsafe.Pointer(in.Canary))
+	out.Chain = (*CertificateChainOptions)(unsafe.Pointer(in.Chain))
+	out.RenewalWindows = (*CertificateRenewalWindows)(unsafe.Pointer(in.RenewalWindows))
+	out.Verification = (*CertificateVerification)(unsafe.Pointer(in.Verification))
+	out.AdditionalPrivateKey = (*CertificateAdditionalPrivateKey)(unsafe.Pointer(in.AdditionalPrivateKey))
+	out.OCSPStapling = (*CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	out.RenewalDisabled = in.RenewalDisabled
+	out.ObserveOnly = in.ObserveOnly
 	return nil
 }
 
@@ -881,13 +1397,19 @@ func Convert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *certmana
 
 func autoConvert_v1beta1_CertificateStatus_To_certmanager_CertificateStatus(in *CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*apismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.LastSuccessTime = (*apismetav1.Time)(unsafe.Pointer(in.LastSuccessTime))
+	out.LastError = in.LastError
+	out.NotBefore = (*apismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*apismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*apismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.PrivateKeyRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.PrivateKeyRotationTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.VerificationRevision = (*int)(unsafe.Pointer(in.VerificationRevision))
+	out.Observed = (*certmanager.CertificateObservation)(unsafe.Pointer(in.Observed))
+	out.LastIssuanceRequest = in.LastIssuanceRequest
 	return nil
 }
 
@@ -898,13 +1420,19 @@ func Convert_v1beta1_CertificateStatus_To_certmanager_CertificateStatus(in *Cert
 
 func autoConvert_certmanager_CertificateStatus_To_v1beta1_CertificateStatus(in *certmanager.CertificateStatus, out *CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*apismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.LastSuccessTime = (*apismetav1.Time)(unsafe.Pointer(in.LastSuccessTime))
+	out.LastError = in.LastError
+	out.NotBefore = (*apismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*apismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*apismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.PrivateKeyRotationTime = (*apismetav1.Time)(unsafe.Pointer(in.PrivateKeyRotationTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.VerificationRevision = (*int)(unsafe.Pointer(in.VerificationRevision))
+	out.Observed = (*CertificateObservation)(unsafe.Pointer(in.Observed))
+	out.LastIssuanceRequest = in.LastIssuanceRequest
 	return nil
 }
 
@@ -913,6 +1441,58 @@ func Convert_certmanager_CertificateStatus_To_v1beta1_CertificateStatus(in *cert
 	return autoConvert_certmanager_CertificateStatus_To_v1beta1_CertificateStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateVerification_To_certmanager_CertificateVerification(in *CertificateVerification, out *certmanager.CertificateVerification, s conversion.Scope) error {
+	out.HTTPProbe = (*certmanager.CertificateHTTPProbe)(unsafe.Pointer(in.HTTPProbe))
+	out.Job = (*certmanager.CertificateVerificationJob)(unsafe.Pointer(in.Job))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RollbackOnFailure = in.RollbackOnFailure
+	return nil
+}
+
+// Convert_v1beta1_CertificateVerification_To_certmanager_CertificateVerification is an autogenerated conversion function.
+func Convert_v1beta1_CertificateVerification_To_certmanager_CertificateVerification(in *CertificateVerification, out *certmanager.CertificateVerification, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateVerification_To_certmanager_CertificateVerification(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateVerification_To_v1beta1_CertificateVerification(in *certmanager.CertificateVerification, out *CertificateVerification, s conversion.Scope) error {
+	out.HTTPProbe = (*CertificateHTTPProbe)(unsafe.Pointer(in.HTTPProbe))
+	out.Job = (*CertificateVerificationJob)(unsafe.Pointer(in.Job))
+	out.Timeout = (*apismetav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RollbackOnFailure = in.RollbackOnFailure
+	return nil
+}
+
+// Convert_certmanager_CertificateVerification_To_v1beta1_CertificateVerification is an autogenerated conversion function.
+func Convert_certmanager_CertificateVerification_To_v1beta1_CertificateVerification(in *certmanager.CertificateVerification, out *CertificateVerification, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateVerification_To_v1beta1_CertificateVerification(in, out, s)
+}
+
+func autoConvert_v1beta1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in *CertificateVerificationJob, out *certmanager.CertificateVerificationJob, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_v1beta1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob is an autogenerated conversion function.
+func Convert_v1beta1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in *CertificateVerificationJob, out *certmanager.CertificateVerificationJob, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateVerificationJob_To_certmanager_CertificateVerificationJob(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateVerificationJob_To_v1beta1_CertificateVerificationJob(in *certmanager.CertificateVerificationJob, out *CertificateVerificationJob, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_certmanager_CertificateVerificationJob_To_v1beta1_CertificateVerificationJob is an autogenerated conversion function.
+func Convert_certmanager_CertificateVerificationJob_To_v1beta1_CertificateVerificationJob(in *certmanager.CertificateVerificationJob, out *CertificateVerificationJob, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateVerificationJob_To_v1beta1_CertificateVerificationJob(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterIssuer_To_certmanager_ClusterIssuer(in *ClusterIssuer, out *certmanager.ClusterIssuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -987,6 +1567,32 @@ func Convert_certmanager_ClusterIssuerList_To_v1beta1_ClusterIssuerList(in *cert
 	return autoConvert_certmanager_ClusterIssuerList_To_v1beta1_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1beta1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in *ConfigMapKeySelector, out *certmanager.ConfigMapKeySelector, s conversion.Scope) error {
+	if err := v1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.LocalObjectReference, &out.LocalObjectReference, s); err != nil {
+		return err
+	}
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector is an autogenerated conversion function.
+func Convert_v1beta1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in *ConfigMapKeySelector, out *certmanager.ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConfigMapKeySelector_To_certmanager_ConfigMapKeySelector(in, out, s)
+}
+
+func autoConvert_certmanager_ConfigMapKeySelector_To_v1beta1_ConfigMapKeySelector(in *certmanager.ConfigMapKeySelector, out *ConfigMapKeySelector, s conversion.Scope) error {
+	if err := v1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.LocalObjectReference, &out.LocalObjectReference, s); err != nil {
+		return err
+	}
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_ConfigMapKeySelector_To_v1beta1_ConfigMapKeySelector is an autogenerated conversion function.
+func Convert_certmanager_ConfigMapKeySelector_To_v1beta1_ConfigMapKeySelector(in *certmanager.ConfigMapKeySelector, out *ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_certmanager_ConfigMapKeySelector_To_v1beta1_ConfigMapKeySelector(in, out, s)
+}
+
 func autoConvert_v1beta1_Issuer_To_certmanager_Issuer(in *Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1022,7 +1628,7 @@ func Convert_certmanager_Issuer_To_v1beta1_Issuer(in *certmanager.Issuer, out *I
 func autoConvert_v1beta1_IssuerCondition_To_certmanager_IssuerCondition(in *IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1037,7 +1643,7 @@ func Convert_v1beta1_IssuerCondition_To_certmanager_IssuerCondition(in *IssuerCo
 func autoConvert_certmanager_IssuerCondition_To_v1beta1_IssuerCondition(in *certmanager.IssuerCondition, out *IssuerCondition, s conversion.Scope) error {
 	out.Type = IssuerConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1059,7 +1665,15 @@ func autoConvert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerConf
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1beta1_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1079,6 +1693,7 @@ func autoConvert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerConf
 	} else {
 		out.Venafi = nil
 	}
+	out.KubernetesCSR = (*certmanager.KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -1097,7 +1712,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanage
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1beta1_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(VaultIssuer)
@@ -1117,6 +1740,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanage
 	} else {
 		out.Venafi = nil
 	}
+	out.KubernetesCSR = (*KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -1171,6 +1795,7 @@ func autoConvert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, ou
 	if err := Convert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.TLS = (*certmanager.IssuerTLSConfig)(unsafe.Pointer(in.TLS))
 	return nil
 }
 
@@ -1183,6 +1808,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1beta1_IssuerSpec(in *certmanager.Is
 	if err := Convert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.TLS = (*IssuerTLSConfig)(unsafe.Pointer(in.TLS))
 	return nil
 }
 
@@ -1194,6 +1820,7 @@ func Convert_certmanager_IssuerSpec_To_v1beta1_IssuerSpec(in *certmanager.Issuer
 func autoConvert_v1beta1_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*certmanager.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1205,6 +1832,7 @@ func Convert_v1beta1_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus,
 func autoConvert_certmanager_IssuerStatus_To_v1beta1_IssuerStatus(in *certmanager.IssuerStatus, out *IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1beta1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1213,9 +1841,31 @@ func Convert_certmanager_IssuerStatus_To_v1beta1_IssuerStatus(in *certmanager.Is
 	return autoConvert_certmanager_IssuerStatus_To_v1beta1_IssuerStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in *IssuerTLSConfig, out *certmanager.IssuerTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_v1beta1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig is an autogenerated conversion function.
+func Convert_v1beta1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in *IssuerTLSConfig, out *certmanager.IssuerTLSConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_IssuerTLSConfig_To_certmanager_IssuerTLSConfig(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerTLSConfig_To_v1beta1_IssuerTLSConfig(in *certmanager.IssuerTLSConfig, out *IssuerTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_certmanager_IssuerTLSConfig_To_v1beta1_IssuerTLSConfig is an autogenerated conversion function.
+func Convert_certmanager_IssuerTLSConfig_To_v1beta1_IssuerTLSConfig(in *certmanager.IssuerTLSConfig, out *IssuerTLSConfig, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerTLSConfig_To_v1beta1_IssuerTLSConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_JKSKeystore_To_certmanager_JKSKeystore(in *JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1228,7 +1878,7 @@ func Convert_v1beta1_JKSKeystore_To_certmanager_JKSKeystore(in *JKSKeystore, out
 
 func autoConvert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in *certmanager.JKSKeystore, out *JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1239,9 +1889,29 @@ func Convert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in *certmanager.JKSK
 	return autoConvert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1beta1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	return nil
+}
+
+// Convert_v1beta1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_v1beta1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_v1beta1_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_KubernetesCSRIssuer_To_v1beta1_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	return nil
+}
+
+// Convert_certmanager_KubernetesCSRIssuer_To_v1beta1_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_certmanager_KubernetesCSRIssuer_To_v1beta1_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_KubernetesCSRIssuer_To_v1beta1_KubernetesCSRIssuer(in, out, s)
+}
+
 func autoConvert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1254,7 +1924,7 @@ func Convert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Keys
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1265,6 +1935,32 @@ func Convert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in *certmanage
 	return autoConvert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1beta1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	out.Interval = (*apismetav1.Duration)(unsafe.Pointer(in.Interval))
+	out.AdvanceNotice = (*apismetav1.Duration)(unsafe.Pointer(in.AdvanceNotice))
+	return nil
+}
+
+// Convert_v1beta1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_v1beta1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_v1beta1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in, out, s)
+}
+
+func autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1beta1_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	out.Interval = (*apismetav1.Duration)(unsafe.Pointer(in.Interval))
+	out.AdvanceNotice = (*apismetav1.Duration)(unsafe.Pointer(in.AdvanceNotice))
+	return nil
+}
+
+// Convert_certmanager_PrivateKeyRotationSchedule_To_v1beta1_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_certmanager_PrivateKeyRotationSchedule_To_v1beta1_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1beta1_PrivateKeyRotationSchedule(in, out, s)
+}
+
 func autoConvert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1288,7 +1984,7 @@ func Convert_certmanager_SelfSignedIssuer_To_v1beta1_SelfSignedIssuer(in *certma
 func autoConvert_v1beta1_VaultAppRole_To_certmanager_VaultAppRole(in *VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1302,7 +1998,7 @@ func Convert_v1beta1_VaultAppRole_To_certmanager_VaultAppRole(in *VaultAppRole,
 func autoConvert_certmanager_VaultAppRole_To_v1beta1_VaultAppRole(in *certmanager.VaultAppRole, out *VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1317,7 +2013,7 @@ func autoConvert_v1beta1_VaultAuth_To_certmanager_VaultAuth(in *VaultAuth, out *
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1353,7 +2049,7 @@ func autoConvert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in *certmanager.Vaul
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(metav1.SecretKeySelector)
-		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1393,6 +2089,8 @@ func autoConvert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer,
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Mode = certmanager.VaultIssuerMode(in.Mode)
+	out.ResponseWrapTTL = (*apismetav1.Duration)(unsafe.Pointer(in.ResponseWrapTTL))
 	return nil
 }
 
@@ -1409,6 +2107,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Mode = VaultIssuerMode(in.Mode)
+	out.ResponseWrapTTL = (*apismetav1.Duration)(unsafe.Pointer(in.ResponseWrapTTL))
 	return nil
 }
 
@@ -1419,7 +2119,7 @@ func Convert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.Vaul
 
 func autoConvert_v1beta1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1433,7 +2133,7 @@ func Convert_v1beta1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1447,7 +2147,7 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in *
 
 func autoConvert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1460,7 +2160,7 @@ func Convert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out
 
 func autoConvert_certmanager_VenafiCloud_To_v1beta1_VenafiCloud(in *certmanager.VenafiCloud, out *VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1529,10 +2229,11 @@ func Convert_certmanager_VenafiIssuer_To_v1beta1_VenafiIssuer(in *certmanager.Ve
 
 func autoConvert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := v1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ServiceGeneratedCSR = in.ServiceGeneratedCSR
 	return nil
 }
 
@@ -1543,10 +2244,11 @@ func Convert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *cert
 
 func autoConvert_certmanager_VenafiTPP_To_v1beta1_VenafiTPP(in *certmanager.VenafiTPP, out *VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := v1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ServiceGeneratedCSR = in.ServiceGeneratedCSR
 	return nil
 }
 
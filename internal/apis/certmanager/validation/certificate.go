@@ -20,7 +20,10 @@ import (
 	"fmt"
 	"net"
 	"net/mail"
+	"net/url"
+	"reflect"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
@@ -34,6 +37,7 @@ import (
 	"github.com/cert-manager/cert-manager/internal/webhook/feature"
 	"github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/cron"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -46,6 +50,12 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, field.Required(fldPath.Child("secretName"), "must be specified"))
 	}
 
+	// Observe-only Certificates are never issued, so the fields which
+	// configure issuance are ignored.
+	if crt.ObserveOnly {
+		return el
+	}
+
 	el = append(el, validateIssuerRef(crt.IssuerRef, fldPath)...)
 
 	var commonName = crt.CommonName
@@ -101,6 +111,10 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, field.TooLong(fldPath.Child("commonName"), crt.CommonName, 64))
 	}
 
+	if len(crt.DNSNames) > 0 {
+		el = append(el, validateDNSNames(crt, fldPath)...)
+	}
+
 	if len(crt.IPAddresses) > 0 {
 		el = append(el, validateIPAddresses(crt, fldPath)...)
 	}
@@ -110,20 +124,11 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	}
 
 	if crt.PrivateKey != nil {
-		switch crt.PrivateKey.Algorithm {
-		case "", internalcmapi.RSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && (crt.PrivateKey.Size < 2048 || crt.PrivateKey.Size > 8192) {
-				el = append(el, field.Invalid(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
-			}
-		case internalcmapi.ECDSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && crt.PrivateKey.Size != 256 && crt.PrivateKey.Size != 384 && crt.PrivateKey.Size != 521 {
-				el = append(el, field.NotSupported(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, []string{"256", "384", "521"}))
-			}
-		case internalcmapi.Ed25519KeyAlgorithm:
-			break
-		default:
-			el = append(el, field.Invalid(fldPath.Child("privateKey", "algorithm"), crt.PrivateKey.Algorithm, "must be either empty or one of rsa or ecdsa"))
-		}
+		el = append(el, validatePrivateKey(crt.PrivateKey, fldPath.Child("privateKey"))...)
+	}
+
+	if crt.AdditionalPrivateKey != nil {
+		el = append(el, validateAdditionalPrivateKey(crt, fldPath.Child("additionalPrivateKey"))...)
 	}
 
 	if crt.Duration != nil || crt.RenewBefore != nil {
@@ -146,6 +151,27 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	}
 
 	el = append(el, validateAdditionalOutputFormats(crt, fldPath)...)
+	el = append(el, validateAdditionalTrustedCAs(crt, fldPath)...)
+
+	if crt.Canary != nil {
+		el = append(el, validateCanary(crt, fldPath)...)
+	}
+
+	if crt.Chain != nil && crt.Chain.MaxDepth < 1 {
+		el = append(el, field.Invalid(fldPath.Child("chain", "maxDepth"), crt.Chain.MaxDepth, "must be at least 1"))
+	}
+
+	if crt.RenewalWindows != nil {
+		el = append(el, validateRenewalWindows(crt.RenewalWindows, fldPath.Child("renewalWindows"))...)
+	}
+
+	if crt.Verification != nil {
+		el = append(el, validateVerification(crt.Verification, fldPath.Child("verification"))...)
+	}
+
+	if crt.OCSPStapling != nil && crt.OCSPStapling.RefreshInterval != nil && crt.OCSPStapling.RefreshInterval.Duration < time.Minute {
+		el = append(el, field.Invalid(fldPath.Child("ocspStapling", "refreshInterval"), crt.OCSPStapling.RefreshInterval.Duration, "must be at least 1m"))
+	}
 
 	return el
 }
@@ -157,11 +183,76 @@ func ValidateCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (f
 }
 
 func ValidateUpdateCertificate(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
-	crt := obj.(*internalcmapi.Certificate)
+	oldCrt, crt := oldObj.(*internalcmapi.Certificate), obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
+
+	// Certificates created before duplicate dnsNames were rejected can still
+	// be updated, as long as their dnsNames are left unchanged.
+	if reflect.DeepEqual(oldCrt.Spec.DNSNames, crt.Spec.DNSNames) {
+		allErrs = allErrs.Filter(func(err error) bool {
+			fieldErr, ok := err.(*field.Error)
+			return ok && fieldErr.Type == field.ErrorTypeDuplicate && strings.HasPrefix(fieldErr.Field, "spec.dnsNames[")
+		})
+	}
+
 	return allErrs, nil
 }
 
+func validatePrivateKey(pk *internalcmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch pk.Algorithm {
+	case "", internalcmapi.RSAKeyAlgorithm:
+		if pk.Size > 0 && (pk.Size < 2048 || pk.Size > 8192) {
+			el = append(el, field.Invalid(fldPath.Child("size"), pk.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
+		}
+	case internalcmapi.ECDSAKeyAlgorithm:
+		if pk.Size > 0 && pk.Size != 256 && pk.Size != 384 && pk.Size != 521 {
+			el = append(el, field.NotSupported(fldPath.Child("size"), pk.Size, []string{"256", "384", "521"}))
+		}
+	case internalcmapi.Ed25519KeyAlgorithm:
+		break
+	default:
+		el = append(el, field.Invalid(fldPath.Child("algorithm"), pk.Algorithm, "must be either empty or one of rsa or ecdsa"))
+	}
+
+	switch {
+	case pk.RotationPolicy == internalcmapi.RotationPolicyScheduled && pk.RotationSchedule == nil:
+		el = append(el, field.Required(fldPath.Child("rotationSchedule"), "must be specified when rotationPolicy is Scheduled"))
+	case pk.RotationPolicy != internalcmapi.RotationPolicyScheduled && pk.RotationSchedule != nil:
+		el = append(el, field.Forbidden(fldPath.Child("rotationSchedule"), "may only be specified when rotationPolicy is Scheduled"))
+	case pk.RotationSchedule != nil:
+		el = append(el, validatePrivateKeyRotationSchedule(pk.RotationSchedule, fldPath.Child("rotationSchedule"))...)
+	}
+	return el
+}
+
+// validateAdditionalPrivateKey ensures that the additional private key is an
+// RSA or ECDSA key, and that it uses the other of the two algorithms to the
+// Certificate's private key.
+func validateAdditionalPrivateKey(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	apk := crt.AdditionalPrivateKey
+	el := validatePrivateKey(&internalcmapi.CertificatePrivateKey{Algorithm: apk.Algorithm, Size: apk.Size}, fldPath)
+	if len(el) > 0 {
+		return el
+	}
+
+	algorithm := internalcmapi.RSAKeyAlgorithm
+	if crt.PrivateKey != nil && crt.PrivateKey.Algorithm != "" {
+		algorithm = crt.PrivateKey.Algorithm
+	}
+
+	switch {
+	case apk.Algorithm != internalcmapi.RSAKeyAlgorithm && apk.Algorithm != internalcmapi.ECDSAKeyAlgorithm:
+		el = append(el, field.NotSupported(fldPath.Child("algorithm"), apk.Algorithm, []string{string(internalcmapi.RSAKeyAlgorithm), string(internalcmapi.ECDSAKeyAlgorithm)}))
+	case algorithm != internalcmapi.RSAKeyAlgorithm && algorithm != internalcmapi.ECDSAKeyAlgorithm:
+		el = append(el, field.Invalid(fldPath.Child("algorithm"), apk.Algorithm, fmt.Sprintf("may only be set when the Certificate's private key algorithm is %s or %s", internalcmapi.RSAKeyAlgorithm, internalcmapi.ECDSAKeyAlgorithm)))
+	case apk.Algorithm == algorithm:
+		el = append(el, field.Invalid(fldPath.Child("algorithm"), apk.Algorithm, "must differ from the Certificate's private key algorithm"))
+	}
+
+	return el
+}
+
 func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -180,6 +271,27 @@ func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) fi
 	return el
 }
 
+// validateDNSNames ensures that the dnsNames can be normalized into the form
+// that is used in certificates and that no two names are the same once
+// normalized, e.g. 'Example.com.' and 'example.com'.
+func validateDNSNames(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	seen := sets.NewString()
+	for i, d := range a.DNSNames {
+		normalized, err := pki.NormalizeDNSName(d)
+		if err != nil {
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, err.Error()))
+			continue
+		}
+		if seen.Has(normalized) {
+			el = append(el, field.Duplicate(fldPath.Child("dnsNames").Index(i), d))
+			continue
+		}
+		seen.Insert(normalized)
+	}
+	return el
+}
+
 func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.IPAddresses) <= 0 {
 		return nil
@@ -282,3 +394,156 @@ func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath
 
 	return el
 }
+
+func validateAdditionalTrustedCAs(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalTrustedCAs) {
+		if len(crt.AdditionalTrustedCAs) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("additionalTrustedCAs"), "feature gate AdditionalTrustedCAs must be enabled"))
+		}
+		return el
+	}
+
+	for i, ca := range crt.AdditionalTrustedCAs {
+		caPath := fldPath.Child("additionalTrustedCAs").Index(i)
+		switch {
+		case ca.Secret != nil && ca.ConfigMap != nil:
+			el = append(el, field.Forbidden(caPath, "only one of secret or configMap may be specified"))
+		case ca.Secret != nil:
+			if len(ca.Secret.Name) == 0 {
+				el = append(el, field.Required(caPath.Child("secret", "name"), "secret name is required"))
+			}
+			if len(ca.Secret.Key) == 0 {
+				el = append(el, field.Required(caPath.Child("secret", "key"), "secret key is required"))
+			}
+		case ca.ConfigMap != nil:
+			if len(ca.ConfigMap.Name) == 0 {
+				el = append(el, field.Required(caPath.Child("configMap", "name"), "configMap name is required"))
+			}
+			if len(ca.ConfigMap.Key) == 0 {
+				el = append(el, field.Required(caPath.Child("configMap", "key"), "configMap key is required"))
+			}
+		default:
+			el = append(el, field.Required(caPath, "one of secret or configMap must be specified"))
+		}
+	}
+
+	return el
+}
+
+func validateCanary(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	secretNamePath := fldPath.Child("canary", "secretName")
+	switch {
+	case len(crt.Canary.SecretName) == 0:
+		el = append(el, field.Required(secretNamePath, "must be specified"))
+	case crt.Canary.SecretName == crt.SecretName:
+		el = append(el, field.Invalid(secretNamePath, crt.Canary.SecretName, "must be different from spec.secretName"))
+	default:
+		for _, msg := range apivalidation.NameIsDNSSubdomain(crt.Canary.SecretName, false) {
+			el = append(el, field.Invalid(secretNamePath, crt.Canary.SecretName, msg))
+		}
+	}
+
+	return el
+}
+
+// maxRenewalWindowDuration is the longest a renewal window may stay open.
+const maxRenewalWindowDuration = 7 * 24 * time.Hour
+
+func validateRenewalWindows(rw *internalcmapi.CertificateRenewalWindows, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(rw.Windows) == 0 {
+		el = append(el, field.Required(fldPath.Child("windows"), "at least one window must be specified"))
+	}
+	for i, w := range rw.Windows {
+		wPath := fldPath.Child("windows").Index(i)
+		if _, err := cron.Parse(w.Schedule); err != nil {
+			el = append(el, field.Invalid(wPath.Child("schedule"), w.Schedule, err.Error()))
+		}
+		if w.Duration.Duration <= 0 || w.Duration.Duration > maxRenewalWindowDuration {
+			el = append(el, field.Invalid(wPath.Child("duration"), w.Duration.Duration, fmt.Sprintf("must be greater than 0 and at most %s", maxRenewalWindowDuration)))
+		}
+	}
+
+	if len(rw.TimeZone) > 0 {
+		if _, err := time.LoadLocation(rw.TimeZone); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("timeZone"), rw.TimeZone, "must be a valid IANA time zone name"))
+		}
+	}
+
+	if rw.UrgentRenewBefore != nil && rw.UrgentRenewBefore.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("urgentRenewBefore"), rw.UrgentRenewBefore.Duration, "must be greater than 0"))
+	}
+
+	return el
+}
+
+const minPrivateKeyRotationInterval = time.Hour
+
+func validatePrivateKeyRotationSchedule(rs *internalcmapi.PrivateKeyRotationSchedule, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	switch {
+	case len(rs.Schedule) == 0 && rs.Interval == nil:
+		el = append(el, field.Required(fldPath, "one of schedule or interval must be specified"))
+	case len(rs.Schedule) > 0 && rs.Interval != nil:
+		el = append(el, field.Forbidden(fldPath, "only one of schedule or interval may be specified"))
+	}
+
+	if len(rs.Schedule) > 0 {
+		if _, err := cron.Parse(rs.Schedule); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("schedule"), rs.Schedule, err.Error()))
+		}
+	}
+
+	if rs.Interval != nil && rs.Interval.Duration < minPrivateKeyRotationInterval {
+		el = append(el, field.Invalid(fldPath.Child("interval"), rs.Interval.Duration, fmt.Sprintf("must be at least %s", minPrivateKeyRotationInterval)))
+	}
+
+	if len(rs.TimeZone) > 0 {
+		if _, err := time.LoadLocation(rs.TimeZone); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("timeZone"), rs.TimeZone, "must be a valid IANA time zone name"))
+		}
+	}
+
+	if rs.AdvanceNotice != nil && rs.AdvanceNotice.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("advanceNotice"), rs.AdvanceNotice.Duration, "must not be negative"))
+	}
+
+	return el
+}
+
+func validateVerification(v *internalcmapi.CertificateVerification, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if v.HTTPProbe == nil && v.Job == nil {
+		el = append(el, field.Required(fldPath, "at least one of httpProbe or job must be specified"))
+	}
+
+	if v.HTTPProbe != nil {
+		urlPath := fldPath.Child("httpProbe", "url")
+		u, err := url.Parse(v.HTTPProbe.URL)
+		switch {
+		case len(v.HTTPProbe.URL) == 0:
+			el = append(el, field.Required(urlPath, "must be specified"))
+		case err != nil:
+			el = append(el, field.Invalid(urlPath, v.HTTPProbe.URL, err.Error()))
+		case u.Scheme != "https" || len(u.Hostname()) == 0:
+			el = append(el, field.Invalid(urlPath, v.HTTPProbe.URL, "must be an https URL with a host"))
+		}
+	}
+
+	if v.Job != nil && len(v.Job.Image) == 0 {
+		el = append(el, field.Required(fldPath.Child("job", "image"), "must be specified"))
+	}
+
+	if v.Timeout != nil && v.Timeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("timeout"), v.Timeout.Duration, "must be greater than 0"))
+	}
+
+	return el
+}
@@ -76,6 +76,76 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 		},
+		"valid observe-only certificate without an issuerRef or names": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName:  "abc",
+					ObserveOnly: true,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid observe-only certificate without a secretName": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					ObserveOnly: true,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("secretName"), "must be specified"),
+			},
+		},
+		"valid certificate with chain maxDepth": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Chain:      &internalcmapi.CertificateChainOptions{MaxDepth: 2},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with chain maxDepth of zero": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Chain:      &internalcmapi.CertificateChainOptions{MaxDepth: 0},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("chain", "maxDepth"), int32(0), "must be at least 1"),
+			},
+		},
+		"valid certificate with ocspStapling": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					OCSPStapling: &internalcmapi.CertificateOCSPStapling{RefreshInterval: &metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with ocspStapling refreshInterval below 1m": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					OCSPStapling: &internalcmapi.CertificateOCSPStapling{RefreshInterval: &metav1.Duration{Duration: time.Second}},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ocspStapling", "refreshInterval"), time.Second, "must be at least 1m"),
+			},
+		},
 		"valid with blank issuerRef kind": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -366,6 +436,43 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("privateKey", "algorithm"), internalcmapi.PrivateKeyAlgorithm("blah"), "must be either empty or one of rsa or ecdsa"),
 			},
 		},
+		"valid certificate with internationalised dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"bücher.example.com", "*.bücher.example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"certificate with invalid internationalised dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"example.com", "-bücher.example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "-bücher.example.com", `invalid internationalised domain name "-bücher.example.com": idna: invalid label "-bücher"`),
+			},
+		},
+		"certificate with dnsNames which are duplicates once normalized": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"example.com", "Example.COM.", "bücher.example.com", "xn--bcher-kva.example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Duplicate(fldPath.Child("dnsNames").Index(1), "Example.COM."),
+				field.Duplicate(fldPath.Child("dnsNames").Index(3), "xn--bcher-kva.example.com"),
+			},
+		},
 		"valid certificate with ipAddresses": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -676,6 +783,49 @@ func TestValidateCertificate(t *testing.T) {
 	}
 }
 
+func TestValidateUpdateCertificate(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	withDNSNames := func(dnsNames ...string) *internalcmapi.Certificate {
+		return &internalcmapi.Certificate{
+			Spec: internalcmapi.CertificateSpec{
+				DNSNames:   dnsNames,
+				SecretName: "abc",
+				IssuerRef:  validIssuerRef,
+			},
+		}
+	}
+	scenarios := map[string]struct {
+		oldCrt, crt *internalcmapi.Certificate
+		errs        []*field.Error
+	}{
+		"existing duplicate dnsNames are allowed if they are unchanged": {
+			oldCrt: withDNSNames("example.com", "Example.COM."),
+			crt:    withDNSNames("example.com", "Example.COM."),
+		},
+		"duplicate dnsNames are rejected if the dnsNames are changed": {
+			oldCrt: withDNSNames("example.com"),
+			crt:    withDNSNames("example.com", "Example.COM."),
+			errs: []*field.Error{
+				field.Duplicate(fldPath.Child("dnsNames").Index(1), "Example.COM."),
+			},
+		},
+		"invalid dnsNames are rejected even if they are unchanged": {
+			oldCrt: withDNSNames("-bücher.example.com"),
+			crt:    withDNSNames("-bücher.example.com"),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(0), "-bücher.example.com", `invalid internationalised domain name "-bücher.example.com": idna: invalid label "-bücher"`),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs, warnings := ValidateUpdateCertificate(someAdmissionRequest, s.oldCrt, s.crt)
+			assert.ElementsMatch(t, errs, s.errs)
+			assert.Empty(t, warnings)
+		})
+	}
+}
+
 func TestValidateDuration(t *testing.T) {
 	usefulDurations := map[string]*metav1.Duration{
 		"one second":  {Duration: time.Second},
@@ -899,6 +1049,383 @@ func Test_validateAdditionalOutputFormats(t *testing.T) {
 	}
 }
 
+func Test_validateAdditionalTrustedCAs(t *testing.T) {
+	fldPath := field.NewPath("spec", "additionalTrustedCAs")
+	secretRef := func(name, key string) *cmmeta.SecretKeySelector {
+		return &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}, Key: key}
+	}
+	configMapRef := func(name, key string) *internalcmapi.ConfigMapKeySelector {
+		return &internalcmapi.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	tests := map[string]struct {
+		featureEnabled bool
+		cas            []internalcmapi.CertificateAdditionalTrustedCA
+		expErr         field.ErrorList
+	}{
+		"if feature disabled and no CAs defined, expect no error": {
+			featureEnabled: false,
+		},
+		"if feature disabled and a CA is defined, expect error": {
+			featureEnabled: false,
+			cas: []internalcmapi.CertificateAdditionalTrustedCA{
+				{Secret: secretRef("ca", "ca.crt")},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath, "feature gate AdditionalTrustedCAs must be enabled"),
+			},
+		},
+		"if feature enabled and valid secret and configMap references defined, expect no error": {
+			featureEnabled: true,
+			cas: []internalcmapi.CertificateAdditionalTrustedCA{
+				{Secret: secretRef("ca", "ca.crt")},
+				{ConfigMap: configMapRef("bundle", "ca.pem")},
+			},
+		},
+		"if feature enabled and neither secret nor configMap defined, expect error": {
+			featureEnabled: true,
+			cas:            []internalcmapi.CertificateAdditionalTrustedCA{{}},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Index(0), "one of secret or configMap must be specified"),
+			},
+		},
+		"if feature enabled and both secret and configMap defined, expect error": {
+			featureEnabled: true,
+			cas: []internalcmapi.CertificateAdditionalTrustedCA{
+				{Secret: secretRef("ca", "ca.crt"), ConfigMap: configMapRef("bundle", "ca.pem")},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath.Index(0), "only one of secret or configMap may be specified"),
+			},
+		},
+		"if feature enabled and references are missing a name or key, expect error": {
+			featureEnabled: true,
+			cas: []internalcmapi.CertificateAdditionalTrustedCA{
+				{Secret: secretRef("", "ca.crt")},
+				{ConfigMap: configMapRef("bundle", "")},
+			},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Index(0).Child("secret", "name"), "secret name is required"),
+				field.Required(fldPath.Index(1).Child("configMap", "key"), "configMap key is required"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.AdditionalTrustedCAs, test.featureEnabled)()
+			spec := &internalcmapi.CertificateSpec{AdditionalTrustedCAs: test.cas}
+			gotErr := validateAdditionalTrustedCAs(spec, field.NewPath("spec"))
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validateCanary(t *testing.T) {
+	fldPath := field.NewPath("spec", "canary", "secretName")
+
+	tests := map[string]struct {
+		canary *internalcmapi.CertificateCanary
+		expErr field.ErrorList
+	}{
+		"valid shadow secret name": {
+			canary: &internalcmapi.CertificateCanary{SecretName: "abc-canary"},
+		},
+		"missing shadow secret name": {
+			canary: &internalcmapi.CertificateCanary{},
+			expErr: field.ErrorList{
+				field.Required(fldPath, "must be specified"),
+			},
+		},
+		"shadow secret name matching spec.secretName": {
+			canary: &internalcmapi.CertificateCanary{SecretName: "abc"},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath, "abc", "must be different from spec.secretName"),
+			},
+		},
+		"invalid shadow secret name": {
+			canary: &internalcmapi.CertificateCanary{SecretName: "Abc_Canary"},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath, "Abc_Canary", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := &internalcmapi.CertificateSpec{SecretName: "abc", Canary: test.canary}
+			gotErr := validateCanary(spec, field.NewPath("spec"))
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validateAdditionalPrivateKey(t *testing.T) {
+	fldPath := field.NewPath("spec", "additionalPrivateKey")
+
+	tests := map[string]struct {
+		privateKey           *internalcmapi.CertificatePrivateKey
+		additionalPrivateKey *internalcmapi.CertificateAdditionalPrivateKey
+		expErr               field.ErrorList
+	}{
+		"ECDSA additional key for the default RSA key": {
+			additionalPrivateKey: &internalcmapi.CertificateAdditionalPrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 384},
+			expErr:               field.ErrorList{},
+		},
+		"RSA additional key for an ECDSA key": {
+			privateKey:           &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm},
+			additionalPrivateKey: &internalcmapi.CertificateAdditionalPrivateKey{Algorithm: internalcmapi.RSAKeyAlgorithm},
+			expErr:               field.ErrorList{},
+		},
+		"additional key with the same algorithm as the private key": {
+			privateKey:           &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.RSAKeyAlgorithm},
+			additionalPrivateKey: &internalcmapi.CertificateAdditionalPrivateKey{Algorithm: internalcmapi.RSAKeyAlgorithm, Size: 4096},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("algorithm"), internalcmapi.RSAKeyAlgorithm, "must differ from the Certificate's private key algorithm"),
+			},
+		},
+		"Ed25519 additional key": {
+			additionalPrivateKey: &internalcmapi.CertificateAdditionalPrivateKey{Algorithm: internalcmapi.Ed25519KeyAlgorithm},
+			expErr: field.ErrorList{
+				field.NotSupported(fldPath.Child("algorithm"), internalcmapi.Ed25519KeyAlgorithm, []string{"RSA", "ECDSA"}),
+			},
+		},
+		"additional key for an Ed25519 key": {
+			privateKey:           &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.Ed25519KeyAlgorithm},
+			additionalPrivateKey: &internalcmapi.CertificateAdditionalPrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("algorithm"), internalcmapi.ECDSAKeyAlgorithm, "may only be set when the Certificate's private key algorithm is RSA or ECDSA"),
+			},
+		},
+		"invalid additional key size": {
+			additionalPrivateKey: &internalcmapi.CertificateAdditionalPrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 2048},
+			expErr: field.ErrorList{
+				field.NotSupported(fldPath.Child("size"), 2048, []string{"256", "384", "521"}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := &internalcmapi.CertificateSpec{PrivateKey: test.privateKey, AdditionalPrivateKey: test.additionalPrivateKey}
+			gotErr := validateAdditionalPrivateKey(spec, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validateRenewalWindows(t *testing.T) {
+	fldPath := field.NewPath("spec", "renewalWindows")
+
+	tests := map[string]struct {
+		windows *internalcmapi.CertificateRenewalWindows
+		expErr  field.ErrorList
+	}{
+		"valid windows": {
+			windows: &internalcmapi.CertificateRenewalWindows{
+				Windows: []internalcmapi.CertificateRenewalWindow{
+					{Schedule: "0 22 * * MON-FRI", Duration: metav1.Duration{Duration: 8 * time.Hour}},
+					{Schedule: "0 0 * * SAT", Duration: metav1.Duration{Duration: 48 * time.Hour}},
+				},
+				TimeZone:          "Europe/London",
+				UrgentRenewBefore: &metav1.Duration{Duration: 72 * time.Hour},
+			},
+		},
+		"no windows": {
+			windows: &internalcmapi.CertificateRenewalWindows{},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("windows"), "at least one window must be specified"),
+			},
+		},
+		"invalid schedule and duration": {
+			windows: &internalcmapi.CertificateRenewalWindows{
+				Windows: []internalcmapi.CertificateRenewalWindow{
+					{Schedule: "0 22 * *", Duration: metav1.Duration{Duration: 8 * 24 * time.Hour}},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("windows").Index(0).Child("schedule"), "0 22 * *", `expected 5 fields in cron expression "0 22 * *", found 4`),
+				field.Invalid(fldPath.Child("windows").Index(0).Child("duration"), 8*24*time.Hour, "must be greater than 0 and at most 168h0m0s"),
+			},
+		},
+		"invalid time zone and urgentRenewBefore": {
+			windows: &internalcmapi.CertificateRenewalWindows{
+				Windows: []internalcmapi.CertificateRenewalWindow{
+					{Schedule: "@daily", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+				TimeZone:          "Mars/Olympus_Mons",
+				UrgentRenewBefore: &metav1.Duration{},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("windows").Index(0).Child("schedule"), "@daily", `expected 5 fields in cron expression "@daily", found 1`),
+				field.Invalid(fldPath.Child("timeZone"), "Mars/Olympus_Mons", "must be a valid IANA time zone name"),
+				field.Invalid(fldPath.Child("urgentRenewBefore"), time.Duration(0), "must be greater than 0"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateRenewalWindows(test.windows, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validatePrivateKeyRotationSchedule(t *testing.T) {
+	fldPath := field.NewPath("spec", "privateKey")
+
+	tests := map[string]struct {
+		privateKey *internalcmapi.CertificatePrivateKey
+		expErr     field.ErrorList
+	}{
+		"valid schedule": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{
+					Schedule:      "0 9 1 */3 *",
+					TimeZone:      "Europe/London",
+					AdvanceNotice: &metav1.Duration{Duration: 14 * 24 * time.Hour},
+				},
+			},
+			expErr: field.ErrorList{},
+		},
+		"valid interval": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{
+					Interval: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+				},
+			},
+			expErr: field.ErrorList{},
+		},
+		"missing rotationSchedule": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyScheduled,
+			},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("rotationSchedule"), "must be specified when rotationPolicy is Scheduled"),
+			},
+		},
+		"rotationSchedule without Scheduled rotationPolicy": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyAlways,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{
+					Interval: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath.Child("rotationSchedule"), "may only be specified when rotationPolicy is Scheduled"),
+			},
+		},
+		"neither schedule nor interval": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy:   internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{},
+			},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("rotationSchedule"), "one of schedule or interval must be specified"),
+			},
+		},
+		"both schedule and interval": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{
+					Schedule: "0 9 1 * *",
+					Interval: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath.Child("rotationSchedule"), "only one of schedule or interval may be specified"),
+			},
+		},
+		"invalid schedule, time zone and advanceNotice": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{
+					Schedule:      "0 9 1 *",
+					TimeZone:      "Mars/Olympus_Mons",
+					AdvanceNotice: &metav1.Duration{Duration: -time.Hour},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("rotationSchedule", "schedule"), "0 9 1 *", `expected 5 fields in cron expression "0 9 1 *", found 4`),
+				field.Invalid(fldPath.Child("rotationSchedule", "timeZone"), "Mars/Olympus_Mons", "must be a valid IANA time zone name"),
+				field.Invalid(fldPath.Child("rotationSchedule", "advanceNotice"), -time.Hour, "must not be negative"),
+			},
+		},
+		"interval too short": {
+			privateKey: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{
+					Interval: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("rotationSchedule", "interval"), time.Minute, "must be at least 1h0m0s"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validatePrivateKey(test.privateKey, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validateVerification(t *testing.T) {
+	fldPath := field.NewPath("spec", "verification")
+
+	tests := map[string]struct {
+		verification *internalcmapi.CertificateVerification
+		expErr       field.ErrorList
+	}{
+		"valid verification": {
+			verification: &internalcmapi.CertificateVerification{
+				HTTPProbe:         &internalcmapi.CertificateHTTPProbe{URL: "https://example.com:8443/healthz"},
+				Job:               &internalcmapi.CertificateVerificationJob{Image: "curlimages/curl"},
+				Timeout:           &metav1.Duration{Duration: 10 * time.Minute},
+				RollbackOnFailure: true,
+			},
+		},
+		"no checks": {
+			verification: &internalcmapi.CertificateVerification{RollbackOnFailure: true},
+			expErr: field.ErrorList{
+				field.Required(fldPath, "at least one of httpProbe or job must be specified"),
+			},
+		},
+		"missing URL and image": {
+			verification: &internalcmapi.CertificateVerification{
+				HTTPProbe: &internalcmapi.CertificateHTTPProbe{},
+				Job:       &internalcmapi.CertificateVerificationJob{},
+			},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("httpProbe", "url"), "must be specified"),
+				field.Required(fldPath.Child("job", "image"), "must be specified"),
+			},
+		},
+		"invalid URL and timeout": {
+			verification: &internalcmapi.CertificateVerification{
+				HTTPProbe: &internalcmapi.CertificateHTTPProbe{URL: "http://example.com"},
+				Timeout:   &metav1.Duration{},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("httpProbe", "url"), "http://example.com", "must be an https URL with a host"),
+				field.Invalid(fldPath.Child("timeout"), time.Duration(0), "must be greater than 0"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateVerification(test.verification, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
 func Test_validateLiteralSubject(t *testing.T) {
 	fldPath := field.NewPath("spec")
 	tests := map[string]struct {
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+// Validation functions for cert-manager CertificateAuthority types.
+
+func ValidateCertificateAuthority(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	ca := obj.(*cmapi.CertificateAuthority)
+	return ValidateCertificateAuthoritySpec(&ca.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateCertificateAuthority(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	ca := obj.(*cmapi.CertificateAuthority)
+	return ValidateCertificateAuthoritySpec(&ca.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateCertificateAuthoritySpec(spec *cmapi.CertificateAuthoritySpec, fldPath *field.Path) field.ErrorList {
+	el := validateCertificateAuthorityCA(&spec.Root, fldPath.Child("root"))
+	for i := range spec.Intermediates {
+		el = append(el, validateCertificateAuthorityCA(&spec.Intermediates[i], fldPath.Child("intermediates").Index(i))...)
+	}
+	if spec.IssuerName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.IssuerName) {
+			el = append(el, field.Invalid(fldPath.Child("issuerName"), spec.IssuerName, msg))
+		}
+	}
+	return el
+}
+
+func validateCertificateAuthorityCA(ca *cmapi.CertificateAuthorityCA, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if ca.CommonName == "" {
+		el = append(el, field.Required(fldPath.Child("commonName"), ""))
+	} else if len(ca.CommonName) > 64 {
+		el = append(el, field.TooLong(fldPath.Child("commonName"), ca.CommonName, 64))
+	}
+	if ca.PrivateKey != nil {
+		el = append(el, validatePrivateKey(ca.PrivateKey, fldPath.Child("privateKey"))...)
+	}
+	if ca.Duration != nil || ca.RenewBefore != nil {
+		el = append(el, ValidateDuration(&cmapi.CertificateSpec{Duration: ca.Duration, RenewBefore: ca.RenewBefore}, fldPath)...)
+	}
+	return el
+}
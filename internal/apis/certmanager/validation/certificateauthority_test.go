@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+func TestValidateCertificateAuthority(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	longCommonName := strings.Repeat("a", 65)
+
+	scenarios := map[string]struct {
+		spec      *cmapi.CertificateAuthoritySpec
+		expectedE field.ErrorList
+	}{
+		"root only": {
+			spec: &cmapi.CertificateAuthoritySpec{
+				Root: cmapi.CertificateAuthorityCA{CommonName: "root"},
+			},
+			expectedE: field.ErrorList{},
+		},
+		"root and intermediates with an issuer name": {
+			spec: &cmapi.CertificateAuthoritySpec{
+				Root: cmapi.CertificateAuthorityCA{
+					CommonName: "root",
+					Duration:   &metav1.Duration{Duration: 10 * 365 * 24 * time.Hour},
+					PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384},
+				},
+				Intermediates: []cmapi.CertificateAuthorityCA{{CommonName: "intermediate"}},
+				IssuerName:    "internal-ca",
+			},
+			expectedE: field.ErrorList{},
+		},
+		"missing and too long common names": {
+			spec: &cmapi.CertificateAuthoritySpec{
+				Intermediates: []cmapi.CertificateAuthorityCA{{CommonName: longCommonName}},
+			},
+			expectedE: field.ErrorList{
+				field.Required(fldPath.Child("root", "commonName"), ""),
+				field.TooLong(fldPath.Child("intermediates").Index(0).Child("commonName"), longCommonName, 64),
+			},
+		},
+		"invalid private key and renewBefore": {
+			spec: &cmapi.CertificateAuthoritySpec{
+				Root: cmapi.CertificateAuthorityCA{
+					CommonName:  "root",
+					Duration:    &metav1.Duration{Duration: time.Hour * 24},
+					RenewBefore: &metav1.Duration{Duration: time.Hour * 48},
+					PrivateKey:  &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: 1024},
+				},
+			},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("root", "privateKey", "size"), 1024, "must be between 2048 & 8192 for rsa keyAlgorithm"),
+				field.Invalid(fldPath.Child("root", "renewBefore"), time.Hour*48, "certificate duration 24h0m0s must be greater than renewBefore 48h0m0s"),
+			},
+		},
+		"invalid issuer name": {
+			spec: &cmapi.CertificateAuthoritySpec{
+				Root:       cmapi.CertificateAuthorityCA{CommonName: "root"},
+				IssuerName: "Internal_CA",
+			},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerName"), "Internal_CA", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			gotE, gotW := ValidateCertificateAuthority(nil, &cmapi.CertificateAuthority{Spec: *s.spec})
+			if len(gotW) != 0 {
+				t.Errorf("Expected no warnings but got %v", gotW)
+			}
+			if !reflect.DeepEqual(gotE, s.expectedE) {
+				t.Errorf("Expected errors %v but got %v", s.expectedE, gotE)
+			}
+		})
+	}
+}
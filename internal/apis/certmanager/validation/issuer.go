@@ -19,12 +19,16 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	cliflag "k8s.io/component-base/cli/flag"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
@@ -48,7 +52,30 @@ func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.O
 }
 
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	if iss.TLS != nil {
+		el = append(el, ValidateIssuerTLSConfig(iss.TLS, fldPath.Child("tls"))...)
+	}
+	return el, warnings
+}
+
+// ValidateIssuerTLSConfig validates the TLS configuration of the outbound
+// connections of an issuer.
+func ValidateIssuerTLSConfig(cfg *certmanager.IssuerTLSConfig, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(cfg.MinVersion) > 0 {
+		if _, err := cliflag.TLSVersion(cfg.MinVersion); err != nil {
+			el = append(el, field.NotSupported(fldPath.Child("minVersion"), cfg.MinVersion, cliflag.TLSPossibleVersions()))
+		}
+	}
+	for i, suite := range cfg.CipherSuites {
+		if _, err := cliflag.TLSCipherSuites([]string{suite}); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("cipherSuites").Index(i), suite, "unknown cipher suite"))
+		}
+	}
+
+	return el
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, []string) {
@@ -96,6 +123,14 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (f
 			el = append(el, ValidateVenafiIssuerConfig(iss.Venafi, fldPath.Child("venafi"))...)
 		}
 	}
+	if iss.KubernetesCSR != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("kubernetesCSR"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateKubernetesCSRIssuerConfig(iss.KubernetesCSR, fldPath.Child("kubernetesCSR"))...)
+		}
+	}
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
@@ -126,13 +161,79 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		}
 	}
 
+	if iss.HTTPClient != nil {
+		el = append(el, ValidateACMEHTTPClient(iss.HTTPClient, fldPath.Child("httpClient"))...)
+	}
+
 	for i, sol := range iss.Solvers {
 		el = append(el, ValidateACMEIssuerChallengeSolverConfig(&sol, fldPath.Child("solvers").Index(i))...)
 	}
 
+	for i, dnsName := range iss.PreAuthorizedDNSNames {
+		fldPath := fldPath.Child("preAuthorizedDNSNames").Index(i)
+		switch {
+		case len(dnsName) == 0:
+			el = append(el, field.Required(fldPath, "DNS name must not be empty"))
+		// RFC 8555 section 7.4.1 does not allow pre-authorizing wildcard
+		// domain names.
+		case strings.HasPrefix(dnsName, "*"):
+			el = append(el, field.Invalid(fldPath, dnsName, "wildcard DNS names cannot be pre-authorized"))
+		}
+	}
+
+	if iss.MaxConcurrentChallenges != nil && *iss.MaxConcurrentChallenges < 1 {
+		el = append(el, field.Invalid(fldPath.Child("maxConcurrentChallenges"), *iss.MaxConcurrentChallenges, "must be at least 1"))
+	}
+
+	if iss.MaxIdentifiersPerOrder != nil && *iss.MaxIdentifiersPerOrder < 1 {
+		el = append(el, field.Invalid(fldPath.Child("maxIdentifiersPerOrder"), *iss.MaxIdentifiersPerOrder, "must be at least 1"))
+	}
+
+	if iss.ProcessingOrderRecheckInterval != nil && iss.ProcessingOrderRecheckInterval.Duration < time.Minute {
+		el = append(el, field.Invalid(fldPath.Child("processingOrderRecheckInterval"), iss.ProcessingOrderRecheckInterval.Duration, "must be at least 1m"))
+	}
+
+	if iss.DNS01SelfCheck != nil {
+		el = append(el, ValidateACMEDNS01SelfCheck(iss.DNS01SelfCheck, fldPath.Child("dns01SelfCheck"))...)
+	}
+
+	if iss.RateLimitBudget != nil {
+		el = append(el, ValidateACMERateLimitBudget(iss.RateLimitBudget, fldPath.Child("rateLimitBudget"))...)
+	}
+
 	return el, warnings
 }
 
+func ValidateACMEHTTPClient(cl *cmacme.ACMEHTTPClient, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if cl.Timeout != nil && cl.Timeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("timeout"), cl.Timeout.Duration.String(), "must be greater than zero"))
+	}
+
+	if len(cl.ProxyURL) > 0 {
+		el = append(el, validateProxyURL(cl.ProxyURL, fldPath.Child("proxyURL"))...)
+	}
+
+	return el
+}
+
+func validateProxyURL(proxyURL string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	u, err := url.Parse(proxyURL)
+	switch {
+	case err != nil:
+		el = append(el, field.Invalid(fldPath, proxyURL, err.Error()))
+	case u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5":
+		el = append(el, field.Invalid(fldPath, proxyURL, "scheme must be one of http, https or socks5"))
+	case len(u.Host) == 0:
+		el = append(el, field.Invalid(fldPath, proxyURL, "must include a host"))
+	}
+
+	return el
+}
+
 func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -168,12 +269,102 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 		numDefined++
 		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(http01.GatewayHTTPRoute, fldPath.Child("gateway"))...)
 	}
+	if http01.IstioVirtualService != nil {
+		numDefined++
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01IstioVirtualServiceConfig(http01.IstioVirtualService, fldPath.Child("istioVirtualService"))...)
+	}
+	if http01.OpenShiftRoute != nil {
+		numDefined++
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01OpenShiftRouteConfig(http01.OpenShiftRoute, fldPath.Child("openShiftRoute"))...)
+	}
+	if http01.HostPort != nil {
+		numDefined++
+	}
 	if numDefined == 0 {
 		el = append(el, field.Required(fldPath, "no HTTP01 solver type configured"))
 	}
 	if numDefined > 1 {
 		el = append(el, field.Required(fldPath, "only 1 HTTP01 solver type may be configured"))
 	}
+	if http01.ProxiedSelfCheck != nil {
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01ProxiedSelfCheck(http01.ProxiedSelfCheck, fldPath.Child("proxiedSelfCheck"))...)
+	}
+	if http01.SelfCheck != nil {
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01SelfCheck(http01.SelfCheck, fldPath.Child("selfCheck"))...)
+	}
+	if http01.Server != nil {
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01Server(http01.Server, fldPath.Child("server"))...)
+	}
+
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverHTTP01Server(server *cmacme.ACMEChallengeSolverHTTP01Server, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if server.ListenPort < 0 || server.ListenPort > 65535 {
+		el = append(el, field.Invalid(fldPath.Child("listenPort"), server.ListenPort, "must be a valid port number"))
+	}
+	if server.ReadTimeout != nil && server.ReadTimeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("readTimeout"), server.ReadTimeout.Duration, "must be greater than 0"))
+	}
+	if server.ReadHeaderTimeout != nil && server.ReadHeaderTimeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("readHeaderTimeout"), server.ReadHeaderTimeout.Duration, "must be greater than 0"))
+	}
+	if server.ShutdownDrainPeriod != nil && server.ShutdownDrainPeriod.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("shutdownDrainPeriod"), server.ShutdownDrainPeriod.Duration, "must not be negative"))
+	}
+
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverHTTP01SelfCheck(check *cmacme.ACMEChallengeSolverHTTP01SelfCheck, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if check.Disabled {
+		if len(check.ProxyURL) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("proxyURL"), "proxyURL may not be specified when the self check is disabled"))
+		}
+		if len(check.Host) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("host"), "host may not be specified when the self check is disabled"))
+		}
+		return el
+	}
+	if len(check.ProxyURL) > 0 {
+		el = append(el, validateProxyURL(check.ProxyURL, fldPath.Child("proxyURL"))...)
+	}
+
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverHTTP01ProxiedSelfCheck(check *cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch check.Strategy {
+	case cmacme.HTTP01ProxiedSelfCheckStrategyService:
+		if check.Service == nil {
+			el = append(el, field.Required(fldPath.Child("service"), "service is required when strategy is Service"))
+			break
+		}
+		if len(check.Service.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("service", "name"), ""))
+		}
+		if len(check.Service.Namespace) == 0 {
+			el = append(el, field.Required(fldPath.Child("service", "namespace"), ""))
+		}
+		if check.Service.Port < 0 || check.Service.Port > 65535 {
+			el = append(el, field.Invalid(fldPath.Child("service", "port"), check.Service.Port, "must be a valid port number"))
+		}
+	case cmacme.HTTP01ProxiedSelfCheckStrategyTrustCA:
+		if check.Service != nil {
+			el = append(el, field.Forbidden(fldPath.Child("service"), "service may only be specified when strategy is Service"))
+		}
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("strategy"), check.Strategy, []string{
+			string(cmacme.HTTP01ProxiedSelfCheckStrategyService),
+			string(cmacme.HTTP01ProxiedSelfCheckStrategyTrustCA),
+		}))
+	}
 
 	return el
 }
@@ -207,6 +398,37 @@ func ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(gateway *cmacme.ACMECh
 	return el
 }
 
+func ValidateACMEIssuerChallengeSolverHTTP01IstioVirtualServiceConfig(vs *cmacme.ACMEChallengeSolverHTTP01IstioVirtualService, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch vs.ServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("serviceType"), vs.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
+	}
+	if len(vs.Gateways) == 0 {
+		el = append(el, field.Required(fldPath.Child("gateways"), `at least 1 gateway is required`))
+	}
+	for i, gateway := range vs.Gateways {
+		parts := strings.Split(gateway, "/")
+		if len(parts) > 2 || len(parts[0]) == 0 || len(parts[len(parts)-1]) == 0 {
+			el = append(el, field.Invalid(fldPath.Child("gateways").Index(i), gateway, `must be in the form "<namespace>/<name>" or "<name>"`))
+		}
+	}
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverHTTP01OpenShiftRouteConfig(route *cmacme.ACMEChallengeSolverHTTP01OpenShiftRoute, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch route.ServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("serviceType"), route.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
+	}
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.SecretName) == 0 {
@@ -217,6 +439,15 @@ func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) fiel
 			el = append(el, field.Invalid(fldPath.Child("ocspServer").Index(i), ocspURL, "must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org"))
 		}
 	}
+	if iss.PKCS12 != nil {
+		el = append(el, ValidateSecretKeySelector(&iss.PKCS12.PasswordSecretRef, fldPath.Child("pkcs12", "passwordSecretRef"))...)
+	}
+	if iss.RotationOverlap != nil && iss.RotationOverlap.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("rotationOverlap"), iss.RotationOverlap.Duration, "must be greater than 0"))
+	}
+	if len(iss.CrossSigningSecretName) > 0 && iss.CrossSigningSecretName == iss.SecretName {
+		el = append(el, field.Invalid(fldPath.Child("crossSigningSecretName"), iss.CrossSigningSecretName, "must differ from secretName"))
+	}
 	return el
 }
 
@@ -224,6 +455,14 @@ func ValidateSelfSignedIssuerConfig(iss *certmanager.SelfSignedIssuer, fldPath *
 	return nil
 }
 
+func ValidateKubernetesCSRIssuerConfig(iss *certmanager.KubernetesCSRIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.SignerName) == 0 {
+		el = append(el, field.Required(fldPath.Child("signerName"), "signer name is a required field"))
+	}
+	return el
+}
+
 func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.Server) == 0 {
@@ -243,6 +482,16 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		}
 	}
 
+	switch iss.Mode {
+	case "", certmanager.VaultIssuerModeSign, certmanager.VaultIssuerModeIssue:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("mode"), iss.Mode, []string{string(certmanager.VaultIssuerModeSign), string(certmanager.VaultIssuerModeIssue)}))
+	}
+
+	if iss.ResponseWrapTTL != nil && iss.ResponseWrapTTL.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("responseWrapTTL"), iss.ResponseWrapTTL.Duration, "must be a positive duration"))
+	}
+
 	return el
 	// TODO: add validation for Vault authentication types
 }
@@ -282,6 +531,49 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 	return el
 }
 
+// ValidateACMEDNS01SelfCheck validates the configuration of the checks of
+// the propagation of DNS01 challenge records.
+func ValidateACMEDNS01SelfCheck(sc *cmacme.ACMEDNS01SelfCheck, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch sc.Nameservers {
+	case "", cmacme.DNS01SelfCheckNameserversAuthoritative, cmacme.DNS01SelfCheckNameserversRecursive:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("nameservers"), sc.Nameservers, []string{string(cmacme.DNS01SelfCheckNameserversAuthoritative), string(cmacme.DNS01SelfCheckNameserversRecursive)}))
+	}
+	if sc.PollInterval != nil && sc.PollInterval.Duration < time.Second {
+		el = append(el, field.Invalid(fldPath.Child("pollInterval"), sc.PollInterval.Duration, "must be at least 1s"))
+	}
+	if sc.Timeout != nil && sc.Timeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("timeout"), sc.Timeout.Duration, "must be greater than 0"))
+	}
+
+	return el
+}
+
+// ValidateACMERateLimitBudget validates the configuration of the tracking of
+// the rate limit budget of an ACME issuer.
+func ValidateACMERateLimitBudget(b *cmacme.ACMERateLimitBudget, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if b.CertificatesPerRegisteredDomain < 0 {
+		el = append(el, field.Invalid(fldPath.Child("certificatesPerRegisteredDomain"), b.CertificatesPerRegisteredDomain, "must not be negative"))
+	}
+	if b.Window != nil && b.Window.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("window"), b.Window.Duration, "must be greater than 0"))
+	}
+	if b.RenewalDelayThreshold < 0 {
+		el = append(el, field.Invalid(fldPath.Child("renewalDelayThreshold"), b.RenewalDelayThreshold, "must not be negative"))
+	}
+
+	return el
+}
+
+// maxDNS01RecordTTL is the maximum TTL, in seconds, of the TXT records created
+// to solve DNS01 challenges. Challenges are only accepted once the TTL has
+// passed after the record has propagated.
+const maxDNS01RecordTTL = 3600
+
 // This list must be kept in sync with pkg/issuer/acme/dns/rfc2136/rfc2136.go
 var supportedTSIGAlgorithms = []string{
 	"HMACMD5",
@@ -303,6 +595,48 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, field.Invalid(fldPath.Child("cnameStrategy"), p.CNAMEStrategy, fmt.Sprintf("must be one of %q or %q", cmacme.NoneStrategy, cmacme.FollowStrategy)))
 		}
 	}
+	if p.TTL != nil {
+		switch {
+		case *p.TTL <= 0:
+			el = append(el, field.Invalid(fldPath.Child("ttl"), *p.TTL, "must be greater than 0"))
+		case *p.TTL > maxDNS01RecordTTL:
+			el = append(el, field.Invalid(fldPath.Child("ttl"), *p.TTL, fmt.Sprintf("must be no more than %d", maxDNS01RecordTTL)))
+		}
+	}
+	for domain, zone := range p.ZoneMap {
+		domainPath := fldPath.Child("zoneMap").Key(domain)
+		if len(domain) == 0 {
+			el = append(el, field.Invalid(domainPath, domain, "domain must not be empty"))
+			continue
+		}
+		if len(zone) == 0 {
+			el = append(el, field.Required(domainPath, "zone must not be empty"))
+			continue
+		}
+		// The zone must contain the domain, i.e. be the domain itself or one
+		// of its parent domains.
+		d := strings.ToLower(strings.TrimSuffix(domain, "."))
+		z := strings.ToLower(strings.TrimSuffix(zone, "."))
+		if d != z && !strings.HasSuffix(d, "."+z) {
+			el = append(el, field.Invalid(domainPath, zone, fmt.Sprintf("zone must be %q or one of its parent domains", domain)))
+		}
+	}
+	if len(p.CleanupPolicy) > 0 {
+		switch p.CleanupPolicy {
+		case cmacme.DNS01CleanupPolicyDeferred:
+		case cmacme.DNS01CleanupPolicyImmediate:
+		default:
+			el = append(el, field.Invalid(fldPath.Child("cleanupPolicy"), p.CleanupPolicy, fmt.Sprintf("must be one of %q or %q", cmacme.DNS01CleanupPolicyDeferred, cmacme.DNS01CleanupPolicyImmediate)))
+		}
+	}
+	for i, server := range p.RecursiveNameservers {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("recursiveNameservers").Index(i), server, "must be a host:port address"))
+		}
+	}
+	if p.PropagationDelay != nil && p.PropagationDelay.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("propagationDelay"), p.PropagationDelay.Duration, "must not be negative"))
+	}
 	numProviders := 0
 	if p.Akamai != nil {
 		numProviders++
@@ -351,10 +685,10 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 				el = append(el, field.Required(fldPath.Child("azureDNS", "resourceGroupName"), ""))
 			}
 			switch p.AzureDNS.Environment {
-			case "", cmacme.AzurePublicCloud, cmacme.AzureChinaCloud, cmacme.AzureGermanCloud, cmacme.AzureUSGovernmentCloud:
+			case "", cmacme.AzurePublicCloud, cmacme.AzureChinaCloud, cmacme.AzureGermanCloud, cmacme.AzureUSGovernmentCloud, cmacme.AzureUSGovernment:
 			default:
 				el = append(el, field.Invalid(fldPath.Child("azureDNS", "environment"), p.AzureDNS.Environment,
-					fmt.Sprintf("must be either empty or one of %s, %s, %s or %s", cmacme.AzurePublicCloud, cmacme.AzureChinaCloud, cmacme.AzureGermanCloud, cmacme.AzureUSGovernmentCloud)))
+					fmt.Sprintf("must be either empty or one of %s, %s, %s, %s or %s", cmacme.AzurePublicCloud, cmacme.AzureChinaCloud, cmacme.AzureGermanCloud, cmacme.AzureUSGovernmentCloud, cmacme.AzureUSGovernment)))
 			}
 		}
 	}
@@ -368,9 +702,6 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if p.CloudDNS.ServiceAccount != nil {
 				el = append(el, ValidateSecretKeySelector(p.CloudDNS.ServiceAccount, fldPath.Child("cloudDNS", "serviceAccountSecretRef"))...)
 			}
-			if len(p.CloudDNS.Project) == 0 {
-				el = append(el, field.Required(fldPath.Child("cloudDNS", "project"), ""))
-			}
 		}
 	}
 	if p.Cloudflare != nil {
@@ -393,6 +724,11 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if len(p.Cloudflare.Email) == 0 && p.Cloudflare.APIKey != nil {
 				el = append(el, field.Required(fldPath.Child("cloudflare", "email"), ""))
 			}
+			for zone, id := range p.Cloudflare.ZoneIDs {
+				if len(zone) == 0 || len(id) == 0 {
+					el = append(el, field.Invalid(fldPath.Child("cloudflare", "zoneIDs"), zone, "DNS zones and zone IDs must not be empty"))
+				}
+			}
 		}
 	}
 	if p.Route53 != nil {
@@ -414,6 +750,19 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if p.Route53.SecretAccessKeyID != nil {
 				el = append(el, ValidateSecretKeySelector(p.Route53.SecretAccessKeyID, fldPath.Child("route53", "accessKeyIDSecretRef"))...)
 			}
+			if len(p.Route53.Role) == 0 && (len(p.Route53.ExternalID) > 0 || len(p.Route53.SessionTags) > 0) {
+				el = append(el, field.Required(fldPath.Child("route53", "role"), "role must be specified when externalID or sessionTags are set"))
+			}
+			for i, r := range p.Route53.RoleChain {
+				if len(r.Role) == 0 {
+					el = append(el, field.Required(fldPath.Child("route53", "roleChain").Index(i).Child("role"), ""))
+				}
+			}
+			for zone, id := range p.Route53.HostedZoneIDs {
+				if len(zone) == 0 || len(id) == 0 {
+					el = append(el, field.Invalid(fldPath.Child("route53", "hostedZoneIDs"), zone, "DNS zones and hosted zone IDs must not be empty"))
+				}
+			}
 		}
 	}
 	if p.AcmeDNS != nil {
@@ -19,11 +19,15 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/utils/pointer"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
@@ -87,6 +91,26 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
 			},
 		},
+		"vault issuer in issue mode with response wrapping": {
+			spec: &cmapi.VaultIssuer{
+				Server:          "something",
+				Path:            "pki/issue/role",
+				Mode:            cmapi.VaultIssuerModeIssue,
+				ResponseWrapTTL: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		"vault issuer with unknown mode and non-positive response wrap ttl": {
+			spec: &cmapi.VaultIssuer{
+				Server:          "something",
+				Path:            "a/b/c",
+				Mode:            "Generate",
+				ResponseWrapTTL: &metav1.Duration{},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("mode"), cmapi.VaultIssuerMode("Generate"), []string{"Sign", "Issue"}),
+				field.Invalid(fldPath.Child("responseWrapTTL"), time.Duration(0), "must be a positive duration"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -149,6 +173,133 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				},
 			},
 		},
+		"acme issuer with valid pre-authorized DNS names": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                 "valid-email",
+				Server:                "valid-server",
+				PrivateKey:            validSecretKeyRef,
+				PreAuthorizedDNSNames: []string{"example.com", "www.example.com"},
+			},
+		},
+		"acme issuer with empty and wildcard pre-authorized DNS names": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                 "valid-email",
+				Server:                "valid-server",
+				PrivateKey:            validSecretKeyRef,
+				PreAuthorizedDNSNames: []string{"", "*.example.com"},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("preAuthorizedDNSNames").Index(0), "DNS name must not be empty"),
+				field.Invalid(fldPath.Child("preAuthorizedDNSNames").Index(1), "*.example.com", "wildcard DNS names cannot be pre-authorized"),
+			},
+		},
+		"acme issuer with a valid maxConcurrentChallenges": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                   "valid-email",
+				Server:                  "valid-server",
+				PrivateKey:              validSecretKeyRef,
+				MaxConcurrentChallenges: pointer.Int(5),
+			},
+		},
+		"acme issuer with a maxConcurrentChallenges of zero": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                   "valid-email",
+				Server:                  "valid-server",
+				PrivateKey:              validSecretKeyRef,
+				MaxConcurrentChallenges: pointer.Int(0),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("maxConcurrentChallenges"), 0, "must be at least 1"),
+			},
+		},
+		"acme issuer with a maxIdentifiersPerOrder of zero": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                  "valid-email",
+				Server:                 "valid-server",
+				PrivateKey:             validSecretKeyRef,
+				MaxIdentifiersPerOrder: pointer.Int(0),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("maxIdentifiersPerOrder"), 0, "must be at least 1"),
+			},
+		},
+		"acme issuer with a valid processingOrderRecheckInterval": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                          "valid-email",
+				Server:                         "valid-server",
+				PrivateKey:                     validSecretKeyRef,
+				ProcessingOrderRecheckInterval: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		"acme issuer with a processingOrderRecheckInterval of less than a minute": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                          "valid-email",
+				Server:                         "valid-server",
+				PrivateKey:                     validSecretKeyRef,
+				ProcessingOrderRecheckInterval: &metav1.Duration{Duration: time.Second * 10},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("processingOrderRecheckInterval"), time.Second*10, "must be at least 1m"),
+			},
+		},
+		"acme issuer with a valid dns01SelfCheck": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				DNS01SelfCheck: &cmacme.ACMEDNS01SelfCheck{
+					Nameservers:  cmacme.DNS01SelfCheckNameserversAuthoritative,
+					PollInterval: &metav1.Duration{Duration: time.Second * 5},
+					Timeout:      &metav1.Duration{Duration: time.Minute * 10},
+				},
+			},
+		},
+		"acme issuer with an invalid dns01SelfCheck": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				DNS01SelfCheck: &cmacme.ACMEDNS01SelfCheck{
+					Nameservers:  "Cached",
+					PollInterval: &metav1.Duration{Duration: time.Millisecond * 100},
+					Timeout:      &metav1.Duration{},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("dns01SelfCheck", "nameservers"), cmacme.DNS01SelfCheckNameservers("Cached"), []string{"Authoritative", "Recursive"}),
+				field.Invalid(fldPath.Child("dns01SelfCheck", "pollInterval"), time.Millisecond*100, "must be at least 1s"),
+				field.Invalid(fldPath.Child("dns01SelfCheck", "timeout"), time.Duration(0), "must be greater than 0"),
+			},
+		},
+		"acme issuer with a valid rateLimitBudget": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				RateLimitBudget: &cmacme.ACMERateLimitBudget{
+					CertificatesPerRegisteredDomain: 50,
+					Window:                          &metav1.Duration{Duration: time.Hour * 168},
+					RenewalDelayThreshold:           5,
+				},
+			},
+		},
+		"acme issuer with an invalid rateLimitBudget": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				RateLimitBudget: &cmacme.ACMERateLimitBudget{
+					CertificatesPerRegisteredDomain: -1,
+					Window:                          &metav1.Duration{},
+					RenewalDelayThreshold:           -1,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("rateLimitBudget", "certificatesPerRegisteredDomain"), -1, "must not be negative"),
+				field.Invalid(fldPath.Child("rateLimitBudget", "window"), time.Duration(0), "must be greater than 0"),
+				field.Invalid(fldPath.Child("rateLimitBudget", "renewalDelayThreshold"), -1, "must not be negative"),
+			},
+		},
 		"acme solver with external account binding missing required fields": {
 			spec: &cmacme.ACMEIssuer{
 				Email:                  "valid-email",
@@ -276,6 +427,142 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				),
 			},
 		},
+		"acme solver with valid http01 istio virtual service config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							IstioVirtualService: &cmacme.ACMEChallengeSolverHTTP01IstioVirtualService{
+								Gateways: []string{"istio-system/ingressgateway", "gateway"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"acme solver with invalid http01 istio virtual service config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							IstioVirtualService: &cmacme.ACMEChallengeSolverHTTP01IstioVirtualService{
+								Gateways: []string{"istio-system/", "a/b/c"},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(
+					fldPath.Child("solvers").Index(0).Child("http01", "istioVirtualService", "gateways").Index(0),
+					"istio-system/", `must be in the form "<namespace>/<name>" or "<name>"`,
+				),
+				field.Invalid(
+					fldPath.Child("solvers").Index(0).Child("http01", "istioVirtualService", "gateways").Index(1),
+					"a/b/c", `must be in the form "<namespace>/<name>" or "<name>"`,
+				),
+			},
+		},
+		"acme solver with no gateways in http01 istio virtual service config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							IstioVirtualService: &cmacme.ACMEChallengeSolverHTTP01IstioVirtualService{},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(
+					fldPath.Child("solvers").Index(0).Child("http01", "istioVirtualService", "gateways"),
+					"at least 1 gateway is required",
+				),
+			},
+		},
+		"acme solver with valid http01 openshift route config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							OpenShiftRoute: &cmacme.ACMEChallengeSolverHTTP01OpenShiftRoute{
+								ServiceType: corev1.ServiceTypeClusterIP,
+								Labels:      map[string]string{"router": "public"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"acme solver with invalid service type in http01 openshift route config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							OpenShiftRoute: &cmacme.ACMEChallengeSolverHTTP01OpenShiftRoute{
+								ServiceType: corev1.ServiceTypeLoadBalancer,
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(
+					fldPath.Child("solvers").Index(0).Child("http01", "openShiftRoute", "serviceType"),
+					corev1.ServiceTypeLoadBalancer, `must be empty, "ClusterIP" or "NodePort"`,
+				),
+			},
+		},
+		"acme solver with valid http01 host port config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							HostPort: &cmacme.ACMEChallengeSolverHTTP01HostPort{},
+						},
+					},
+				},
+			},
+		},
+		"acme solver with http01 host port and ingress configs": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress:  &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+							HostPort: &cmacme.ACMEChallengeSolverHTTP01HostPort{},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(
+					fldPath.Child("solvers").Index(0).Child("http01"),
+					"only 1 HTTP01 solver type may be configured",
+				),
+			},
+		},
 		"acme solver with multiple http01 solver configs": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -387,6 +674,57 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				},
 			},
 		},
+		"acme issuer with valid httpClient": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				HTTPClient: &cmacme.ACMEHTTPClient{
+					Timeout:   &metav1.Duration{Duration: time.Minute},
+					KeepAlive: &metav1.Duration{Duration: -1},
+					ProxyURL:  "socks5://proxy.example.com:1080",
+				},
+			},
+		},
+		"acme issuer with invalid httpClient timeout": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				HTTPClient: &cmacme.ACMEHTTPClient{
+					Timeout: &metav1.Duration{},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("httpClient", "timeout"), "0s", "must be greater than zero"),
+			},
+		},
+		"acme issuer with unsupported httpClient proxyURL scheme": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				HTTPClient: &cmacme.ACMEHTTPClient{
+					ProxyURL: "ftp://proxy.example.com",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("httpClient", "proxyURL"), "ftp://proxy.example.com", "scheme must be one of http, https or socks5"),
+			},
+		},
+		"acme issuer with httpClient proxyURL without a host": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				HTTPClient: &cmacme.ACMEHTTPClient{
+					ProxyURL: "http://",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("httpClient", "proxyURL"), "http://", "must include a host"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -431,6 +769,84 @@ func TestValidateIssuerSpec(t *testing.T) {
 			},
 			errs: []*field.Error{field.Required(fldPath.Child("ca", "secretName"), "")},
 		},
+		"valid ca issuer with a pkcs12 keypair": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS12: &cmapi.CAPKCS12Keypair{
+							PasswordSecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "password"},
+								Key:                  "password",
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with a pkcs12 keypair without a password secret": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS12:     &cmapi.CAPKCS12Keypair{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "pkcs12", "passwordSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("ca", "pkcs12", "passwordSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"valid ca issuer with a rotation overlap": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:      "valid",
+						RotationOverlap: &metav1.Duration{Duration: time.Hour * 24},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with a negative rotation overlap": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:      "valid",
+						RotationOverlap: &metav1.Duration{Duration: -time.Hour},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "rotationOverlap"), -time.Hour, "must be greater than 0"),
+			},
+		},
+		"valid ca issuer with a cross-signing secret": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:             "valid",
+						CrossSigningSecretName: "cross",
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer cross-signing with its own secret": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:             "valid",
+						CrossSigningSecretName: "valid",
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "crossSigningSecretName"), "valid", "must differ from secretName"),
+			},
+		},
 		"valid self signed issuer": {
 			spec: &cmapi.IssuerSpec{
 				IssuerConfig: cmapi.IssuerConfig{
@@ -455,6 +871,26 @@ func TestValidateIssuerSpec(t *testing.T) {
 			},
 			errs: []*field.Error{},
 		},
+		"valid kubernetes csr issuer": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					KubernetesCSR: &cmapi.KubernetesCSRIssuer{
+						SignerName: "example.com/signer",
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"kubernetes csr issuer without signer name specified": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					KubernetesCSR: &cmapi.KubernetesCSRIssuer{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("kubernetesCSR", "signerName"), "signer name is a required field"),
+			},
+		},
 		"missing issuer config": {
 			spec: &cmapi.IssuerSpec{
 				IssuerConfig: cmapi.IssuerConfig{},
@@ -500,6 +936,37 @@ func TestValidateIssuerSpec(t *testing.T) {
 				field.Invalid(fldPath.Child("ca", "ocspServer").Index(0), "", `must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org`),
 			},
 		},
+		"valid tls config": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+				},
+				TLS: &cmapi.IssuerTLSConfig{
+					MinVersion:   "VersionTLS12",
+					CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid tls config": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+				},
+				TLS: &cmapi.IssuerTLSConfig{
+					MinVersion:   "VersionSSL30",
+					CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_NOT_A_SUITE"},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("tls", "minVersion"), "VersionSSL30", cliflag.TLSPossibleVersions()),
+				field.Invalid(fldPath.Child("tls", "cipherSuites").Index(1), "TLS_NOT_A_SUITE", "unknown cipher suite"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -580,6 +1047,109 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				field.Invalid(fldPath.Child("ingress", "serviceType"), corev1.ServiceType("InvalidServiceType"), `must be empty, "ClusterIP" or "NodePort"`),
 			},
 		},
+		"proxied self check using an ingress controller service": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				ProxiedSelfCheck: &cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck{
+					Strategy: cmacme.HTTP01ProxiedSelfCheckStrategyService,
+					Service: &cmacme.ACMEChallengeSolverHTTP01ServiceReference{
+						Name:      "ingress-nginx-controller",
+						Namespace: "ingress-nginx",
+					},
+				},
+			},
+		},
+		"proxied self check trusting the CA": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				ProxiedSelfCheck: &cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck{
+					Strategy: cmacme.HTTP01ProxiedSelfCheckStrategyTrustCA,
+				},
+			},
+		},
+		"proxied self check using a service without a service reference": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				ProxiedSelfCheck: &cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck{
+					Strategy: cmacme.HTTP01ProxiedSelfCheckStrategyService,
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("proxiedSelfCheck", "service"), "service is required when strategy is Service"),
+			},
+		},
+		"proxied self check with an unknown strategy": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				ProxiedSelfCheck: &cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck{
+					Strategy: "Unknown",
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("proxiedSelfCheck", "strategy"), cmacme.HTTP01ProxiedSelfCheckStrategy("Unknown"), []string{"Service", "TrustCA"}),
+			},
+		},
+		"self check through a proxy with a custom host": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				SelfCheck: &cmacme.ACMEChallengeSolverHTTP01SelfCheck{
+					ProxyURL: "http://proxy.example.com:3128",
+					Host:     "example.com",
+				},
+			},
+		},
+		"disabled self check": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress:   &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				SelfCheck: &cmacme.ACMEChallengeSolverHTTP01SelfCheck{Disabled: true},
+			},
+		},
+		"self check with an invalid proxy URL": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				SelfCheck: &cmacme.ACMEChallengeSolverHTTP01SelfCheck{
+					ProxyURL: "ftp://proxy.example.com",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("selfCheck", "proxyURL"), "ftp://proxy.example.com", "scheme must be one of http, https or socks5"),
+			},
+		},
+		"disabled self check with a proxy URL": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				SelfCheck: &cmacme.ACMEChallengeSolverHTTP01SelfCheck{
+					Disabled: true,
+					ProxyURL: "http://proxy.example.com:3128",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("selfCheck", "proxyURL"), "proxyURL may not be specified when the self check is disabled"),
+			},
+		},
+		"solver server with a custom port and timeouts": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				Server: &cmacme.ACMEChallengeSolverHTTP01Server{
+					ListenPort:          8080,
+					ReadTimeout:         &metav1.Duration{Duration: 10 * time.Second},
+					ShutdownDrainPeriod: &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+		},
+		"solver server with an invalid port and timeout": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+				Server: &cmacme.ACMEChallengeSolverHTTP01Server{
+					ListenPort:        70000,
+					ReadHeaderTimeout: &metav1.Duration{},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("server", "listenPort"), int32(70000), "must be a valid port number"),
+				field.Invalid(fldPath.Child("server", "readHeaderTimeout"), time.Duration(0), "must be greater than 0"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -604,15 +1174,12 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 		cfg  *cmacme.ACMEChallengeSolverDNS01
 		errs []*field.Error
 	}{
-		"missing clouddns project": {
+		"clouddns project not set should be allowed to use the project of the credentials": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
 					ServiceAccount: &validSecretKeyRef,
 				},
 			},
-			errs: []*field.Error{
-				field.Required(fldPath.Child("cloudDNS", "project"), ""),
-			},
 		},
 		"missing clouddns service account key": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
@@ -649,6 +1216,14 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				},
 			},
 		},
+		"clouddns with a service account to impersonate": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project:                   "valid",
+					ImpersonateServiceAccount: "dns01-solver@valid.iam.gserviceaccount.com",
+				},
+			},
+		},
 		"missing cloudflare api key fields": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
@@ -761,6 +1336,79 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("route53", "accessKeyIDSecretRef", "key"), "secret key is required"),
 			},
 		},
+		"route53 role chain": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:      "valid",
+					Role:        "arn:aws:iam::111111111111:role/intermediate",
+					ExternalID:  "external-id",
+					SessionTags: map[string]string{"team": "platform"},
+					RoleChain: []cmacme.Route53AssumeRole{
+						{Role: "arn:aws:iam::222222222222:role/dns", ExternalID: "other-external-id"},
+					},
+				},
+			},
+		},
+		"route53 externalID without role": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:     "valid",
+					ExternalID: "external-id",
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("route53", "role"), "role must be specified when externalID or sessionTags are set"),
+			},
+		},
+		"cloudflare zone IDs with an api token": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+					APIToken: &validSecretKeyRef,
+					ZoneIDs:  map[string]string{"example.com": "zone-1"},
+				},
+			},
+		},
+		"cloudflare zone IDs with an empty DNS zone": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+					APIToken: &validSecretKeyRef,
+					ZoneIDs:  map[string]string{"": "zone-1"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("cloudflare", "zoneIDs"), "", "DNS zones and zone IDs must not be empty"),
+			},
+		},
+		"route53 hosted zone IDs": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:        "valid",
+					HostedZoneIDs: map[string]string{"example.com": "Z1", "internal.example.com": "Z2"},
+				},
+			},
+		},
+		"route53 hosted zone IDs with an empty ID": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:        "valid",
+					HostedZoneIDs: map[string]string{"example.com": ""},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("route53", "hostedZoneIDs"), "example.com", "DNS zones and hosted zone IDs must not be empty"),
+			},
+		},
+		"route53 role chain entry missing role": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:    "valid",
+					RoleChain: []cmacme.Route53AssumeRole{{ExternalID: "external-id"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("route53", "roleChain").Index(0).Child("role"), ""),
+			},
+		},
 		"missing provider config": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{},
 			errs: []*field.Error{
@@ -786,7 +1434,7 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("azureDNS", "subscriptionID"), ""),
 				field.Required(fldPath.Child("azureDNS", "resourceGroupName"), ""),
 				field.Invalid(fldPath.Child("azureDNS", "environment"), cmacme.AzureDNSEnvironment("an env"),
-					"must be either empty or one of AzurePublicCloud, AzureChinaCloud, AzureGermanCloud or AzureUSGovernmentCloud"),
+					"must be either empty or one of AzurePublicCloud, AzureChinaCloud, AzureGermanCloud, AzureUSGovernmentCloud or AzureUSGovernment"),
 			},
 		},
 		"invalid azuredns missing clientSecret and tenantID": {
@@ -1169,6 +1817,106 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Forbidden(fldPath.Child("cloudflare"), "may not specify more than one provider type"),
 			},
 		},
+		"valid ttl and cleanup policy": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				TTL:           pointer.Int(30),
+				CleanupPolicy: cmacme.DNS01CleanupPolicyImmediate,
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+		},
+		"invalid ttl and cleanup policy": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				TTL:           pointer.Int(0),
+				CleanupPolicy: "Sometimes",
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ttl"), 0, "must be greater than 0"),
+				field.Invalid(fldPath.Child("cleanupPolicy"), cmacme.DNS01CleanupPolicy("Sometimes"), `must be one of "Deferred" or "Immediate"`),
+			},
+		},
+		"ttl longer than the maximum": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				TTL: pointer.Int(86400),
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ttl"), 86400, "must be no more than 3600"),
+			},
+		},
+		"valid zone map": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ZoneMap: map[string]string{
+					"internal.corp.example.com": "corp.example.com",
+					"example.org":               "Example.org.",
+				},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+		},
+		"zone map with a zone which does not contain its domain": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ZoneMap: map[string]string{
+					"internal.corp.example.com": "other.example.com",
+				},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("zoneMap").Key("internal.corp.example.com"), "other.example.com", `zone must be "internal.corp.example.com" or one of its parent domains`),
+			},
+		},
+		"zone map with an empty zone": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ZoneMap: map[string]string{
+					"internal.corp.example.com": "",
+				},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("zoneMap").Key("internal.corp.example.com"), "zone must not be empty"),
+			},
+		},
+		"negative propagation delay": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				PropagationDelay: &metav1.Duration{Duration: -time.Second},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("propagationDelay"), -time.Second, "must not be negative"),
+			},
+		},
+		"valid recursive nameservers": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RecursiveNameservers: []string{"10.0.0.53:53", "[2001:db8::53]:53"},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+		},
+		"recursive nameserver without a port": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RecursiveNameservers: []string{"10.0.0.53:53", "10.0.0.54"},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("recursiveNameservers").Index(1), "10.0.0.54", "must be a host:port address"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
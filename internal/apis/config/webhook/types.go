@@ -111,6 +111,11 @@ type DynamicServingConfig struct {
 
 	// DNSNames that must be present on serving certificates signed by the CA.
 	DNSNames []string
+
+	// CAPropagationDelay is the amount of time a new CA is present in the CA
+	// bundle stored in the Secret before it is used to sign serving
+	// certificates, when the CA is rotated.
+	CAPropagationDelay metav1.Duration
 }
 
 // FilesystemServingConfig enables using a certificate and private key found on the local filesystem.
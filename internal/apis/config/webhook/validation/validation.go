@@ -46,6 +46,9 @@ func ValidateWebhookConfiguration(cfg *config.WebhookConfiguration) error {
 			if len(cfg.TLSConfig.Dynamic.DNSNames) == 0 {
 				allErrors = append(allErrors, fmt.Errorf("invalid configuration: tlsConfig.dynamic.dnsNames (--dynamic-serving-dns-names) must be specified when using dynamic TLS config"))
 			}
+			if cfg.TLSConfig.Dynamic.CAPropagationDelay.Duration < 0 {
+				allErrors = append(allErrors, fmt.Errorf("invalid configuration: tlsConfig.dynamic.caPropagationDelay (--dynamic-serving-ca-propagation-delay) must not be negative"))
+			}
 		}
 	}
 	if cfg.HealthzPort == nil {
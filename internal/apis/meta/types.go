@@ -72,4 +72,12 @@ type SecretKeySelector struct {
 const (
 	// Used as a data key in Secret resources to store a CA certificate.
 	TLSCAKey = "ca.crt"
+
+	// Used as a data key in Secret resources to store a certificate chain
+	// cross-signed by a second CA.
+	CrossSignedCertKey = "tls-cross-signed.crt"
+
+	// Used as a data key in Secret resources to store a bundle of CA
+	// certificates that the holder of the Secret should trust.
+	TrustBundleKey = "trust.pem"
 )
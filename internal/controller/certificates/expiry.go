@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// NotExpiringReason is the reason of a False ExpiryWarning condition.
+	NotExpiringReason = "NotExpiring"
+)
+
+// ExpiryWarningThreshold is a time before the expiry of a certificate, once
+// which an ExpiryWarning is raised for certificates which are not renewed
+// automatically.
+type ExpiryWarningThreshold struct {
+	// Days is the number of days before expiry.
+	Days int
+	// Reason is the reason of the ExpiryWarning condition.
+	Reason string
+}
+
+// ExpiryWarningThresholds are the thresholds of the ExpiryWarning condition,
+// from the shortest to the longest.
+var ExpiryWarningThresholds = []ExpiryWarningThreshold{
+	{Days: 7, Reason: "ExpiresWithin7Days"},
+	{Days: 14, Reason: "ExpiresWithin14Days"},
+	{Days: 30, Reason: "ExpiresWithin30Days"},
+}
+
+func (t ExpiryWarningThreshold) before(notAfter time.Time) time.Time {
+	return notAfter.Add(-time.Duration(t.Days) * 24 * time.Hour)
+}
+
+// ExpiryWarningCondition returns the ExpiryWarning condition of a certificate
+// which expires at notAfter, and the time at which the condition changes
+// next. The returned time is zero if the condition will not change again.
+func ExpiryWarningCondition(now, notAfter time.Time) (cmapi.CertificateCondition, time.Time) {
+	for i, threshold := range ExpiryWarningThresholds {
+		if now.Before(threshold.before(notAfter)) {
+			continue
+		}
+		var next time.Time
+		if i > 0 {
+			next = ExpiryWarningThresholds[i-1].before(notAfter)
+		}
+		return cmapi.CertificateCondition{
+			Type:    cmapi.CertificateConditionExpiryWarning,
+			Status:  cmmeta.ConditionTrue,
+			Reason:  threshold.Reason,
+			Message: fmt.Sprintf("Certificate expires on %s and is not renewed automatically as renewal is disabled", notAfter.Format(time.RFC1123)),
+		}, next
+	}
+
+	longest := ExpiryWarningThresholds[len(ExpiryWarningThresholds)-1]
+	return cmapi.CertificateCondition{
+		Type:    cmapi.CertificateConditionExpiryWarning,
+		Status:  cmmeta.ConditionFalse,
+		Reason:  NotExpiringReason,
+		Message: fmt.Sprintf("Certificate does not expire within %d days", longest.Days),
+	}, longest.before(notAfter)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestExpiryWarningCondition(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	const day = 24 * time.Hour
+
+	tests := map[string]struct {
+		notAfter  time.Time
+		expStatus cmmeta.ConditionStatus
+		expReason string
+		expNext   time.Time
+	}{
+		"not expiring within 30 days": {
+			notAfter:  now.Add(60 * day),
+			expStatus: cmmeta.ConditionFalse,
+			expReason: NotExpiringReason,
+			expNext:   now.Add(30 * day),
+		},
+		"expiring within 30 days": {
+			notAfter:  now.Add(20 * day),
+			expStatus: cmmeta.ConditionTrue,
+			expReason: "ExpiresWithin30Days",
+			expNext:   now.Add(6 * day),
+		},
+		"expiring in exactly 14 days": {
+			notAfter:  now.Add(14 * day),
+			expStatus: cmmeta.ConditionTrue,
+			expReason: "ExpiresWithin14Days",
+			expNext:   now.Add(7 * day),
+		},
+		"expiring within 7 days": {
+			notAfter:  now.Add(day),
+			expStatus: cmmeta.ConditionTrue,
+			expReason: "ExpiresWithin7Days",
+		},
+		"expired": {
+			notAfter:  now.Add(-day),
+			expStatus: cmmeta.ConditionTrue,
+			expReason: "ExpiresWithin7Days",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cond, next := ExpiryWarningCondition(now, test.notAfter)
+			assert.Equal(t, test.expStatus, cond.Status)
+			assert.Equal(t, test.expReason, cond.Reason)
+			assert.Equal(t, test.expNext, next)
+		})
+	}
+}
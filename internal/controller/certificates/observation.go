@@ -0,0 +1,38 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto/x509"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Observation returns the details of the given certificate which are
+// reported in the status of a Certificate with `spec.observeOnly` set.
+func Observation(cert *x509.Certificate) *cmapi.CertificateObservation {
+	return &cmapi.CertificateObservation{
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		IPAddresses:    pki.IPAddressesToString(cert.IPAddresses),
+		URIs:           pki.URLsToString(cert.URIs),
+		EmailAddresses: cert.EmailAddresses,
+		Issuer:         cert.Issuer.String(),
+		SerialNumber:   cert.SerialNumber.Text(16),
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestObservation(t *testing.T) {
+	uri, err := url.Parse("spiffe://cluster.local/ns/sandbox/sa/default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "example.com"},
+		Issuer:         pkix.Name{CommonName: "Example CA", Organization: []string{"Example"}},
+		SerialNumber:   big.NewInt(0xbeef),
+		DNSNames:       []string{"example.com", "www.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{uri},
+		EmailAddresses: []string{"admin@example.com"},
+	}
+
+	assert.Equal(t, &cmapi.CertificateObservation{
+		CommonName:     "example.com",
+		DNSNames:       []string{"example.com", "www.example.com"},
+		IPAddresses:    []string{"10.0.0.1"},
+		URIs:           []string{"spiffe://cluster.local/ns/sandbox/sa/default"},
+		EmailAddresses: []string{"admin@example.com"},
+		Issuer:         "CN=Example CA,O=Example",
+		SerialNumber:   "beef",
+	}, Observation(cert))
+}
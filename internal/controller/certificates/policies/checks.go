@@ -34,8 +34,12 @@ import (
 	"sigs.k8s.io/structured-merge-diff/v4/value"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -73,6 +77,21 @@ func SecretPublicKeysDiffer(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// SecretCACertificateInvalid returns a policy violation if the Secret
+// contains CA data which cannot be decoded as PEM encoded certificates.
+// Secrets without CA data are not violations, since not all issuers return a
+// CA certificate.
+func SecretCACertificateInvalid(input Input) (string, string, bool) {
+	caData := input.Secret.Data[cmmeta.TLSCAKey]
+	if len(caData) == 0 {
+		return "", "", false
+	}
+	if _, err := pki.DecodeX509CertificateChainBytes(caData); err != nil {
+		return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid CA certificate: %v", err), true
+	}
+	return "", "", false
+}
+
 func SecretPrivateKeyMatchesSpec(input Input) (string, string, bool) {
 	if input.Secret.Data == nil || len(input.Secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
 		return SecretMismatch, "Existing issued Secret does not contain private key data", true
@@ -94,6 +113,38 @@ func SecretPrivateKeyMatchesSpec(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// SecretAdditionalPrivateKeyMismatch returns a policy violation if the
+// Certificate has an additional private key, but the Secret does not contain a
+// valid additional key pair matching the Certificate's spec.
+func SecretAdditionalPrivateKeyMismatch(input Input) (string, string, bool) {
+	acrt := certificates.AdditionalPrivateKeyCertificate(input.Certificate)
+	if acrt == nil {
+		return "", "", false
+	}
+
+	pkData := input.Secret.Data[cmmeta.AdditionalTLSPrivateKeyKey]
+	certData := input.Secret.Data[cmmeta.AdditionalTLSCertKey]
+	if len(pkData) == 0 || len(certData) == 0 {
+		return AdditionalPrivateKeyMismatch, "Issuing certificate as Secret does not contain an additional key pair", true
+	}
+	if _, err := tls.X509KeyPair(certData, pkData); err != nil {
+		return AdditionalPrivateKeyMismatch, fmt.Sprintf("Issuing certificate as Secret contains an invalid additional key pair: %v", err), true
+	}
+
+	pk, err := pki.DecodePrivateKeyBytes(pkData)
+	if err != nil {
+		return AdditionalPrivateKeyMismatch, fmt.Sprintf("Existing issued Secret contains invalid additional private key data: %v", err), true
+	}
+	violations, err := certificates.PrivateKeyMatchesSpec(pk, acrt.Spec)
+	if err != nil {
+		return AdditionalPrivateKeyMismatch, fmt.Sprintf("Failed to check additional private key is up to date: %v", err), true
+	}
+	if len(violations) > 0 {
+		return AdditionalPrivateKeyMismatch, fmt.Sprintf("Existing additional private key is not up to date for spec: %v", violations), true
+	}
+	return "", "", false
+}
+
 func SecretIssuerAnnotationsNotUpToDate(input Input) (string, string, bool) {
 	name := input.Secret.Annotations[cmapi.IssuerNameAnnotationKey]
 	kind := input.Secret.Annotations[cmapi.IssuerKindAnnotationKey]
@@ -155,6 +206,11 @@ func currentSecretValidForSpec(input Input) (string, string, bool) {
 func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 
 	return func(input Input) (string, string, bool) {
+		// Certificates with renewal disabled are not renewed as they near
+		// expiry, nor once they have expired.
+		if input.Certificate.Spec.RenewalDisabled {
+			return "", "", false
+		}
 
 		// Determine if the certificate is nearing expiry solely by looking at
 		// the actual cert, if it exists. We assume that at this point we have
@@ -171,6 +227,7 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		crt := input.Certificate
 		renewalTime := certificates.RenewalTime(notBefore.Time, notAfter.Time, crt.Spec.RenewBefore)
+		renewalTime = certificates.RenewalTimeInWindows(renewalTime, notAfter.Time, crt.Spec.RenewalWindows)
 
 		renewIn := renewalTime.Time.Sub(c.Now())
 		if renewIn > 0 {
@@ -182,6 +239,56 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 	}
 }
 
+// PrivateKeyRotationDue returns a policy function that triggers re-issuance
+// once the scheduled rotation time of the private key of a Certificate with
+// the Scheduled rotation policy has passed. The keymanager controller then
+// generates a new private key for the issuance.
+func PrivateKeyRotationDue(c clock.Clock) Func {
+	return func(input Input) (string, string, bool) {
+		crt := input.Certificate
+		// Certificates with renewal disabled are not re-issued to rotate
+		// their private key either.
+		if crt.Spec.RenewalDisabled || crt.Spec.PrivateKey == nil || crt.Spec.PrivateKey.RotationPolicy != cmapi.RotationPolicyScheduled {
+			return "", "", false
+		}
+
+		rotationTime := crt.Status.PrivateKeyRotationTime
+		if rotationTime == nil || c.Now().Before(rotationTime.Time) {
+			return "", "", false
+		}
+
+		return PrivateKeyRotation, fmt.Sprintf("Rotating private key as its rotation was scheduled at %s", rotationTime), true
+	}
+}
+
+// CurrentRevisionFailedVerification returns a policy violation if the
+// certificate of the current revision of a Certificate has failed its
+// verification, and the Certificate's spec has not changed since it was
+// requested. Re-issuing the Certificate would then most likely result in a
+// certificate which fails its verification again.
+func CurrentRevisionFailedVerification(input Input) (string, string, bool) {
+	crt := input.Certificate
+	if crt.Spec.Verification == nil || crt.Status.Revision == nil ||
+		crt.Status.VerificationRevision == nil || *crt.Status.VerificationRevision != *crt.Status.Revision {
+		return "", "", false
+	}
+
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionVerificationFailed)
+	if cond == nil || cond.Status != cmmeta.ConditionTrue {
+		return "", "", false
+	}
+
+	if input.CurrentRevisionRequest != nil {
+		// A certificate issued for the changed spec may pass its verification.
+		violations, err := certificates.RequestMatchesSpec(input.CurrentRevisionRequest, crt.Spec)
+		if err == nil && len(violations) > 0 {
+			return "", "", false
+		}
+	}
+
+	return VerificationFailed, fmt.Sprintf("The certificate of revision %d failed its verification", *crt.Status.Revision), true
+}
+
 // CurrentCertificateHasExpired is used exclusively to check if the current
 // issued certificate has actually expired rather than just nearing expiry.
 func CurrentCertificateHasExpired(c clock.Clock) Func {
@@ -401,6 +508,32 @@ func SecretAdditionalOutputFormatsDataMismatch(input Input) (string, string, boo
 	return "", "", false
 }
 
+// SecretCertificateChainNotTrimmed validates that the certificate chain
+// stored in the Secret has been trimmed to the Certificate's chain maxDepth.
+// Returns true (violation) if the chain is longer than maxDepth and can be
+// trimmed. Chains which cannot be trimmed while remaining verifiable are not
+// considered a violation, since these are stored in full.
+func SecretCertificateChainNotTrimmed(input Input) (string, string, bool) {
+	if input.Certificate.Spec.Chain == nil {
+		return "", "", false
+	}
+
+	maxDepth := int(input.Certificate.Spec.Chain.MaxDepth)
+	chain := input.Secret.Data[corev1.TLSCertKey]
+	certs, err := pki.DecodeX509CertificateChainBytes(chain)
+	if err != nil || maxDepth < 1 || len(certs) <= maxDepth {
+		return "", "", false
+	}
+
+	// The chain is stored in full if it cannot be trimmed, so this is not a
+	// violation which re-applying the Secret data would resolve.
+	if _, err := internalcertificates.TrimCertificateChain(chain, input.Secret.Data[cmmeta.TLSCAKey], maxDepth); err != nil {
+		return "", "", false
+	}
+
+	return CertificateChainMismatch, "Certificate chain in Secret is longer than chain.maxDepth", true
+}
+
 // SecretAdditionalOutputFormatsOwnerMismatch validates that the field manager
 // owns the correct Certificate's AdditionalOutputFormats in the Secret.
 // Returns true (violation) if:
@@ -465,6 +598,42 @@ func SecretAdditionalOutputFormatsOwnerMismatch(fieldManager string) Func {
 	}
 }
 
+// SecretTrustBundleOwnerMismatch validates that the field manager doesn't own
+// a trust bundle in the Secret which is no longer configured on the
+// Certificate. Returns true (violation) if the `trust.pem` key is owned by the
+// field manager, but the Certificate has no additional trusted CAs, or the
+// AdditionalTrustedCAs feature is disabled. Re-applying the Secret data then
+// removes the stale key.
+// A violation with the reason `ManagedFieldsParseError` should be considered a
+// non re-triable error.
+func SecretTrustBundleOwnerMismatch(fieldManager string) Func {
+	return func(input Input) (string, string, bool) {
+		if utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalTrustedCAs) && len(input.Certificate.Spec.AdditionalTrustedCAs) > 0 {
+			return "", "", false
+		}
+
+		for _, managedField := range input.Secret.ManagedFields {
+			if managedField.Manager != fieldManager || managedField.FieldsV1 == nil {
+				continue
+			}
+
+			var fieldset fieldpath.Set
+			if err := fieldset.FromJSON(bytes.NewReader(managedField.FieldsV1.Raw)); err != nil {
+				return ManagedFieldsParseError, fmt.Sprintf("failed to decode managed fields on Secret: %s", err), true
+			}
+
+			if fieldset.Has(fieldpath.Path{
+				{FieldName: pointer.String("data")},
+				{FieldName: pointer.String(cmmeta.TrustBundleKey)},
+			}) {
+				return TrustBundleMismatch, "Secret contains a trust bundle but the Certificate has no additional trusted CAs", true
+			}
+		}
+
+		return "", "", false
+	}
+}
+
 // SecretOwnerReferenceManagedFieldMismatch validates that the Secret has an
 // owner reference to the Certificate if enabled. Returns true (violation) if:
 // * the Secret doesn't have an owner reference and is expecting one
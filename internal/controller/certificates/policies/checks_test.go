@@ -17,21 +17,28 @@ limitations under the License.
 package policies
 
 import (
+	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Runs a full set of tests against the trigger 'policy chain' once it is
@@ -135,6 +142,37 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Issuing certificate as Secret contains an invalid key-pair: tls: private key does not match public key",
 			reissue: true,
 		},
+		"trigger issuance as Secret is missing the additional key pair": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something",
+				AdditionalPrivateKey: &cmapi.CertificateAdditionalPrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			}},
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+			reason:  AdditionalPrivateKeyMismatch,
+			message: "Issuing certificate as Secret does not contain an additional key pair",
+			reissue: true,
+		},
+		"trigger issuance as Secret contains corrupt CA certificate data": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something", CommonName: "example.com"}},
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+					cmmeta.TLSCAKey: []byte("invalid"),
+				},
+			},
+			reason:  InvalidCertificate,
+			message: "Issuing certificate as Secret contains an invalid CA certificate: error decoding certificate PEM block",
+			reissue: true,
+		},
 		"trigger issuance as Secret has old/incorrect 'issuer name' annotation": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				SecretName: "something",
@@ -421,6 +459,37 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Renewing certificate as renewal was scheduled at 0000-12-31 23:59:00 +0000 UTC",
 			reissue: true,
 		},
+		"does not trigger renewal of an expired certificate if renewal is disabled": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					RenewalDisabled: true,
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+						clock.Now().Add(time.Minute*-30),
+						// expired 1 minute ago
+						clock.Now().Add(time.Minute*-1),
+					),
+				},
+			},
+		},
 		"does not trigger renewal if the x509 cert has been re-issued, but Certificate's renewal time has not been updated yet": {
 			certificate: &cmapi.Certificate{
 				Spec: cmapi.CertificateSpec{
@@ -455,6 +524,46 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				},
 			},
 		},
+		"does not trigger renewal if renewalTime is in the past but outside of all renewal windows": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					RenewBefore: &metav1.Duration{Duration: time.Minute * 40},
+					RenewalWindows: &cmapi.CertificateRenewalWindows{
+						Windows: []cmapi.CertificateRenewalWindow{
+							{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+						},
+					},
+				},
+				Status: cmapi.CertificateStatus{
+					RenewalTime: &metav1.Time{Time: clock.Now().Add(time.Minute * 10)},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+						clock.Now().Add(time.Minute*-30),
+						// expires in 30 minutes time, renewal deferred until
+						// the urgent deadline in 10 minutes time
+						clock.Now().Add(time.Minute*30),
+					),
+				},
+			},
+		},
 		"does not trigger renewal if renewal time is in 1 minute": {
 			certificate: &cmapi.Certificate{
 				Spec: cmapi.CertificateSpec{
@@ -1297,6 +1406,189 @@ func Test_SecretAdditionalOutputFormatsDataMismatch(t *testing.T) {
 	}
 }
 
+func Test_SecretCertificateChainNotTrimmed(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	caA := testcrypto.MustCreateCert(t, pk, gen.Certificate("ca-a", gen.SetCertificateCommonName("ca-a"), gen.SetCertificateIsCA(true)))
+	caB := testcrypto.MustCreateCert(t, pk, gen.Certificate("ca-b", gen.SetCertificateCommonName("ca-b"), gen.SetCertificateIsCA(true)))
+
+	// A leaf issued by an intermediate, which is issued by a root.
+	rootPK := testcrypto.MustCreatePEMPrivateKey(t)
+	rootTmpl, err := pki.GenerateTemplate(gen.Certificate("root", gen.SetCertificateCommonName("root"), gen.SetCertificateIsCA(true)))
+	require.NoError(t, err)
+	root := mustSignPEM(t, rootTmpl, rootTmpl, rootPK, rootPK)
+	intermediatePK := testcrypto.MustCreatePEMPrivateKey(t)
+	intermediateTmpl, err := pki.GenerateTemplate(gen.Certificate("intermediate", gen.SetCertificateCommonName("intermediate"), gen.SetCertificateIsCA(true)))
+	require.NoError(t, err)
+	intermediate := mustSignPEM(t, intermediateTmpl, rootTmpl, intermediatePK, rootPK)
+	leafTmpl, err := pki.GenerateTemplate(gen.Certificate("leaf", gen.SetCertificateCommonName("leaf")))
+	require.NoError(t, err)
+	leaf := mustSignPEM(t, leafTmpl, intermediateTmpl, testcrypto.MustCreatePEMPrivateKey(t), intermediatePK)
+
+	certWithMaxDepth := func(depth int32) *cmapi.Certificate {
+		return &cmapi.Certificate{Spec: cmapi.CertificateSpec{Chain: &cmapi.CertificateChainOptions{MaxDepth: depth}}}
+	}
+	secretWithChain := func(chain ...[]byte) *corev1.Secret {
+		var data []byte
+		for _, c := range chain {
+			data = append(data, c...)
+		}
+		return &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: data}}
+	}
+
+	tests := map[string]struct {
+		input        Input
+		expViolation bool
+	}{
+		"if chain options are not set, should return false": {
+			input: Input{Certificate: &cmapi.Certificate{}, Secret: secretWithChain(caA, caA)},
+		},
+		"if the chain is not longer than maxDepth, should return false": {
+			input: Input{Certificate: certWithMaxDepth(2), Secret: secretWithChain(caA, caA)},
+		},
+		"if the chain is longer than maxDepth and can be trimmed, should return true": {
+			input:        Input{Certificate: certWithMaxDepth(1), Secret: secretWithChain(caA, caA)},
+			expViolation: true,
+		},
+		"if the chain is longer than maxDepth but cannot be trimmed, should return false": {
+			input: Input{Certificate: certWithMaxDepth(1), Secret: secretWithChain(caA, caB)},
+		},
+		"if the full chain is stored and maxDepth excludes the root, should return true": {
+			input:        Input{Certificate: certWithMaxDepth(2), Secret: secretWithChain(leaf, intermediate, root)},
+			expViolation: true,
+		},
+		"if the full chain is stored and maxDepth only includes the leaf, should return true": {
+			input:        Input{Certificate: certWithMaxDepth(1), Secret: secretWithChain(leaf, intermediate, root)},
+			expViolation: true,
+		},
+		"if the chain has been trimmed to maxDepth, should return false": {
+			input: Input{Certificate: certWithMaxDepth(2), Secret: secretWithChain(leaf, intermediate)},
+		},
+		"if the full chain is not longer than maxDepth, should return false": {
+			input: Input{Certificate: certWithMaxDepth(3), Secret: secretWithChain(leaf, intermediate, root)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretCertificateChainNotTrimmed(test.input)
+			assert.Equal(t, test.expViolation, gotViolation)
+			if test.expViolation {
+				assert.Equal(t, CertificateChainMismatch, gotReason)
+				assert.Equal(t, "Certificate chain in Secret is longer than chain.maxDepth", gotMessage)
+			}
+		})
+	}
+}
+
+func Test_PrivateKeyRotationDue(t *testing.T) {
+	now := time.Now()
+	clock := fakeclock.NewFakeClock(now)
+	rotationTime := metav1.NewTime(now.Add(-time.Minute))
+
+	scheduledCert := func(rotationTime *metav1.Time, mods ...gen.CertificateModifier) *cmapi.Certificate {
+		crt := gen.Certificate("test",
+			gen.SetCertificatePrivateKeyRotationSchedule(cmapi.PrivateKeyRotationSchedule{
+				Interval: &metav1.Duration{Duration: time.Hour * 24 * 90},
+			}),
+		)
+		crt.Status.PrivateKeyRotationTime = rotationTime
+		return gen.CertificateFrom(crt, mods...)
+	}
+
+	tests := map[string]struct {
+		certificate  *cmapi.Certificate
+		expViolation bool
+	}{
+		"if the rotation policy is not Scheduled, should return false": {
+			certificate: gen.Certificate("test", gen.SetCertificatePrivateKeyRotationTime(rotationTime)),
+		},
+		"if no rotation is scheduled, should return false": {
+			certificate: scheduledCert(nil),
+		},
+		"if the rotation time is in the future, should return false": {
+			certificate: scheduledCert(&metav1.Time{Time: now.Add(time.Minute)}),
+		},
+		"if renewal is disabled, should return false": {
+			certificate: scheduledCert(&rotationTime, gen.SetCertificateRenewalDisabled(true)),
+		},
+		"if the rotation time has passed, should return true": {
+			certificate:  scheduledCert(&rotationTime),
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := PrivateKeyRotationDue(clock)(Input{Certificate: test.certificate})
+			assert.Equal(t, test.expViolation, gotViolation)
+			if test.expViolation {
+				assert.Equal(t, PrivateKeyRotation, gotReason)
+				assert.Equal(t, fmt.Sprintf("Rotating private key as its rotation was scheduled at %s", &rotationTime), gotMessage)
+			}
+		})
+	}
+}
+
+func Test_CurrentRevisionFailedVerification(t *testing.T) {
+	bundle := testcrypto.MustCreateCryptoBundle(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com")), &fakeclock.FakeClock{})
+
+	failedCert := func(mods ...gen.CertificateModifier) *cmapi.Certificate {
+		return gen.CertificateFrom(bundle.Certificate, append([]gen.CertificateModifier{
+			gen.SetCertificateVerification(cmapi.CertificateVerification{
+				HTTPProbe: &cmapi.CertificateHTTPProbe{URL: "https://example.com"},
+			}),
+			gen.SetCertificateRevision(2),
+			gen.SetCertificateVerificationRevision(2),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionVerificationFailed,
+				Status: cmmeta.ConditionTrue,
+			}),
+		}, mods...)...)
+	}
+
+	tests := map[string]struct {
+		certificate  *cmapi.Certificate
+		request      *cmapi.CertificateRequest
+		expViolation bool
+	}{
+		"if the certificate is not verified, should return false": {
+			certificate: gen.CertificateFrom(bundle.Certificate, gen.SetCertificateRevision(2)),
+		},
+		"if the certificate of the current revision is being verified, should return false": {
+			certificate: failedCert(gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionVerificationFailed,
+				Status: cmmeta.ConditionUnknown,
+			})),
+		},
+		"if the certificate of a previous revision failed its verification, should return false": {
+			certificate: failedCert(gen.SetCertificateRevision(3)),
+		},
+		"if the spec changed since the current revision was requested, should return false": {
+			certificate: failedCert(gen.SetCertificateDNSNames("example.org")),
+			request:     bundle.CertificateRequest,
+		},
+		"if the certificate of the current revision failed its verification, should return true": {
+			certificate:  failedCert(),
+			request:      bundle.CertificateRequest,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := CurrentRevisionFailedVerification(Input{
+				Certificate:            test.certificate,
+				CurrentRevisionRequest: test.request,
+			})
+			assert.Equal(t, test.expViolation, gotViolation)
+			if test.expViolation {
+				assert.Equal(t, VerificationFailed, gotReason)
+				assert.Equal(t, "The certificate of revision 2 failed its verification", gotMessage)
+			}
+		})
+	}
+}
+
 func Test_SecretAdditionalOutputFormatsOwnerMismatch(t *testing.T) {
 	const fieldManager = "cert-manager-test"
 
@@ -2164,3 +2456,79 @@ func Test_SecretOwnerReferenceValueMismatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_SecretTrustBundleOwnerMismatch(t *testing.T) {
+	const fieldManager = "cert-manager-test"
+
+	ownedTrustBundle := []metav1.ManagedFieldsEntry{
+		{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+			Raw: []byte(`{"f:data": {".": {}, "f:trust.pem": {}}}`),
+		}},
+	}
+	trustedCAs := []cmapi.CertificateAdditionalTrustedCA{
+		{Secret: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca"}, Key: "ca.crt"}},
+	}
+
+	tests := map[string]struct {
+		featureEnabled bool
+		trustedCAs     []cmapi.CertificateAdditionalTrustedCA
+		managedFields  []metav1.ManagedFieldsEntry
+		expViolation   bool
+	}{
+		"if no trusted CAs and no trust bundle is owned, should return false": {
+			featureEnabled: true,
+		},
+		"if trusted CAs and the trust bundle is owned, should return false": {
+			featureEnabled: true,
+			trustedCAs:     trustedCAs,
+			managedFields:  ownedTrustBundle,
+		},
+		"if no trusted CAs and the trust bundle is owned by another manager, should return false": {
+			featureEnabled: true,
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "not-cert-manager", FieldsV1: &metav1.FieldsV1{
+					Raw: []byte(`{"f:data": {".": {}, "f:trust.pem": {}}}`),
+				}},
+			},
+		},
+		"if no trusted CAs and the trust bundle is owned, should return true": {
+			featureEnabled: true,
+			managedFields:  ownedTrustBundle,
+			expViolation:   true,
+		},
+		"if trusted CAs but the feature is disabled and the trust bundle is owned, should return true": {
+			featureEnabled: false,
+			trustedCAs:     trustedCAs,
+			managedFields:  ownedTrustBundle,
+			expViolation:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.AdditionalTrustedCAs, test.featureEnabled)()
+
+			reason, _, violation := SecretTrustBundleOwnerMismatch(fieldManager)(Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{AdditionalTrustedCAs: test.trustedCAs}},
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ManagedFields: test.managedFields}},
+			})
+			assert.Equal(t, test.expViolation, violation)
+			if test.expViolation {
+				assert.Equal(t, TrustBundleMismatch, reason)
+			}
+		})
+	}
+}
+
+// mustSignPEM returns the PEM encoded certificate for the private key pkData
+// of tmpl, signed by the private key issuerPKData of issuerTmpl.
+func mustSignPEM(t *testing.T, tmpl, issuerTmpl *x509.Certificate, pkData, issuerPKData []byte) []byte {
+	pk, err := pki.DecodePrivateKeyBytes(pkData)
+	require.NoError(t, err)
+	issuerPK, err := pki.DecodePrivateKeyBytes(issuerPKData)
+	require.NoError(t, err)
+	tmpl.PublicKey = pk.Public()
+	certPEM, _, err := pki.SignCertificate(tmpl, issuerTmpl, pk.Public(), issuerPK)
+	require.NoError(t, err)
+	return certPEM
+}
@@ -32,6 +32,10 @@ const (
 	// SecretMismatch is a policy violation reason for a scenario where Secret's
 	// private key does not match spec.
 	SecretMismatch string = "SecretMismatch"
+	// AdditionalPrivateKeyMismatch is a policy violation reason for a
+	// scenario where Secret's additional key pair is missing, invalid or does
+	// not match spec.
+	AdditionalPrivateKeyMismatch string = "AdditionalPrivateKeyMismatch"
 	// IncorrectIssuer is a policy violation reason for a scenario where
 	// Certificate has been issued by incorrect Issuer.
 	IncorrectIssuer string = "IncorrectIssuer"
@@ -41,6 +45,14 @@ const (
 	// Renewing is a policy violation reason for a scenario where
 	// Certificate's renewal time is now or in past.
 	Renewing string = "Renewing"
+	// PrivateKeyRotation is a policy violation reason for a scenario where
+	// the scheduled rotation time of the Certificate's private key is now or
+	// in past.
+	PrivateKeyRotation string = "PrivateKeyRotation"
+	// VerificationFailed is a policy violation reason for a scenario where
+	// the certificate of the Certificate's current revision has failed its
+	// verification.
+	VerificationFailed string = "VerificationFailed"
 	// Expired is a policy violation reason for a scenario where Certificate has
 	// expired.
 	Expired string = "Expired"
@@ -59,4 +71,12 @@ const (
 	// a missing owner reference to the Certificate, or has an owner reference it
 	// shouldn't have.
 	SecretOwnerRefMismatch string = "SecretOwnerRefMismatch"
+	// CertificateChainMismatch is a policy violation whereby the certificate
+	// chain stored in the Secret is longer than the Certificate's chain
+	// maxDepth, and can be trimmed.
+	CertificateChainMismatch string = "CertificateChainMismatch"
+	// TrustBundleMismatch is a policy violation whereby the Secret contains a
+	// trust bundle written by cert-manager although the Certificate no longer
+	// has any additional trusted CAs.
+	TrustBundleMismatch string = "TrustBundleMismatch"
 )
@@ -268,6 +268,9 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 		if err != nil {
 			return Input{}, err
 		}
+		// CertificateRequests for the Certificate's additional private key are
+		// not taken into account.
+		reqs, _ = certificates.SplitAdditionalPrivateKeyRequests(reqs)
 		switch {
 		case len(reqs) > 1:
 			return Input{}, fmt.Errorf("multiple CertificateRequests were found for the 'current' revision %v, issuance is skipped until there are no more duplicates", *crt.Status.Revision)
@@ -295,6 +298,7 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 	if err != nil {
 		return Input{}, err
 	}
+	reqs, _ = certificates.SplitAdditionalPrivateKeyRequests(reqs)
 	switch {
 	case len(reqs) > 1:
 		// This error feels worthless: we know that the "duplicate certificate
@@ -70,13 +70,25 @@ func NewTriggerPolicyChain(c clock.Clock) Chain {
 		SecretDoesNotExist,
 		SecretIsMissingData,
 		SecretPublicKeysDiffer,
+		SecretCACertificateInvalid,
 		SecretPrivateKeyMatchesSpec,
+		SecretAdditionalPrivateKeyMismatch,
 		SecretIssuerAnnotationsNotUpToDate,
 		CurrentCertificateRequestNotValidForSpec,
+		PrivateKeyRotationDue(c),
 		CurrentCertificateNearingExpiry(c),
 	}
 }
 
+// NewTriggerBackoffPolicyChain includes policy checks which, if return true,
+// should cause the re-issuance of a Certificate to be backed off, even if the
+// trigger policy chain requires it.
+func NewTriggerBackoffPolicyChain() Chain {
+	return Chain{
+		CurrentRevisionFailedVerification,
+	}
+}
+
 // NewReadinessPolicyChain includes readiness policy checks, which if return
 // true, would cause a Certificate to be marked as not ready.
 func NewReadinessPolicyChain(c clock.Clock) Chain {
@@ -89,6 +101,18 @@ func NewReadinessPolicyChain(c clock.Clock) Chain {
 	}
 }
 
+// NewObserverReadinessPolicyChain includes readiness policy checks for
+// Certificates with `spec.observeOnly` set, which are never issued by
+// cert-manager and so have no CertificateRequests to check.
+func NewObserverReadinessPolicyChain(c clock.Clock) Chain {
+	return Chain{
+		SecretDoesNotExist,
+		SecretIsMissingData,
+		SecretPublicKeysDiffer,
+		CurrentCertificateHasExpired(c),
+	}
+}
+
 // NewSecretPostIssuancePolicyChain includes policy checks that are to be
 // performed _after_ issuance has been successful, testing for the presence and
 // correctness of metadata and output formats of Certificate's Secrets.
@@ -98,11 +122,25 @@ func NewSecretPostIssuancePolicyChain(ownerRefEnabled bool, fieldManager string)
 		SecretTemplateMismatchesSecretManagedFields(fieldManager),
 		SecretAdditionalOutputFormatsDataMismatch,
 		SecretAdditionalOutputFormatsOwnerMismatch(fieldManager),
+		SecretCertificateChainNotTrimmed,
+		SecretTrustBundleOwnerMismatch(fieldManager),
 		SecretOwnerReferenceManagedFieldMismatch(ownerRefEnabled, fieldManager),
 		SecretOwnerReferenceValueMismatch(ownerRefEnabled),
 	}
 }
 
+// NewSecretIntegrityPolicyChain includes policy checks which, if return true,
+// indicate that the Secret of a Certificate has been deleted or that its data
+// has been corrupted.
+func NewSecretIntegrityPolicyChain() Chain {
+	return Chain{
+		SecretDoesNotExist,
+		SecretIsMissingData,
+		SecretPublicKeysDiffer,
+		SecretCACertificateInvalid,
+	}
+}
+
 // NewTemporaryCertificatePolicyChain includes policy checks for ensuing a
 // temporary certificate is valid.
 func NewTemporaryCertificatePolicyChain() Chain {
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"strings"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -64,3 +65,56 @@ func OutputFormatDER(privateKey []byte) []byte {
 func OutputFormatCombinedPEM(privateKey, certificate []byte) []byte {
 	return bytes.Join([][]byte{privateKey, certificate}, []byte("\n"))
 }
+
+// TrimCertificateChain returns the PEM encoded certificate chain with at most
+// maxDepth certificates, removing certificates from the end of the chain. The
+// chain is returned unchanged if it is not longer than maxDepth.
+// An error is returned if the trimmed chain cannot be verified using the
+// removed certificates, or the certificates in the given CA, as trust anchors.
+func TrimCertificateChain(chain, ca []byte, maxDepth int) ([]byte, error) {
+	certs, err := utilpki.DecodeX509CertificateChainBytes(chain)
+	if err != nil {
+		return nil, err
+	}
+	if maxDepth < 1 || len(certs) <= maxDepth {
+		return chain, nil
+	}
+
+	kept, removed := certs[:maxDepth], certs[maxDepth:]
+
+	roots := x509.NewCertPool()
+	for _, cert := range removed {
+		roots.AddCert(cert)
+	}
+	if len(ca) > 0 {
+		if caCerts, err := utilpki.DecodeX509CertificateChainBytes(ca); err == nil {
+			for _, cert := range caCerts {
+				roots.AddCert(cert)
+			}
+		}
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range kept[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	// Verify at the time the leaf was issued so that the result does not
+	// depend on when the chain is trimmed.
+	if _, err := kept[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   kept[0].NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("trimmed certificate chain cannot be verified: %w", err)
+	}
+
+	var trimmed bytes.Buffer
+	for _, cert := range kept {
+		if err := pem.Encode(&trimmed, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return nil, err
+		}
+	}
+
+	return trimmed.Bytes(), nil
+}
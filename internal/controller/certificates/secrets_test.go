@@ -17,11 +17,19 @@ limitations under the License.
 package certificates
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -161,3 +169,94 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_TrimCertificateChain(t *testing.T) {
+	now := time.Now()
+	serial := int64(0)
+	mustCreateCert := func(cn string, isCA bool, issuer *x509.Certificate, issuerKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		serial++
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             now,
+			NotAfter:              now.Add(time.Hour),
+			IsCA:                  isCA,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		}
+		if issuer == nil {
+			issuer, issuerKey = tmpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, key.Public(), issuerKey)
+		assert.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		assert.NoError(t, err)
+		return cert, key
+	}
+	encode := func(certs ...*x509.Certificate) []byte {
+		var buf bytes.Buffer
+		for _, cert := range certs {
+			assert.NoError(t, pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+		}
+		return buf.Bytes()
+	}
+
+	root, rootKey := mustCreateCert("root", true, nil, nil)
+	intermediate, intermediateKey := mustCreateCert("intermediate", true, root, rootKey)
+	leaf, _ := mustCreateCert("leaf", false, intermediate, intermediateKey)
+	unrelated, _ := mustCreateCert("unrelated", true, nil, nil)
+
+	tests := map[string]struct {
+		chain    []byte
+		ca       []byte
+		maxDepth int
+		expChain []byte
+		expErr   bool
+	}{
+		"if the chain is not longer than the maximum depth, expect it unchanged": {
+			chain:    encode(leaf, intermediate, root),
+			maxDepth: 3,
+			expChain: encode(leaf, intermediate, root),
+		},
+		"if the chain is longer than the maximum depth, expect the root end to be removed": {
+			chain:    encode(leaf, intermediate, root),
+			maxDepth: 2,
+			expChain: encode(leaf, intermediate),
+		},
+		"if trimmed to the leaf, expect the leaf only": {
+			chain:    encode(leaf, intermediate, root),
+			maxDepth: 1,
+			expChain: encode(leaf),
+		},
+		"if the trimmed chain can only be verified by the CA, expect it to be trimmed": {
+			chain:    encode(leaf, intermediate, unrelated),
+			ca:       encode(root),
+			maxDepth: 2,
+			expChain: encode(leaf, intermediate),
+		},
+		"if the trimmed chain cannot be verified, expect error": {
+			chain:    encode(leaf, intermediate, unrelated),
+			maxDepth: 2,
+			expErr:   true,
+		},
+		"if the chain cannot be decoded, expect error": {
+			chain:    []byte("garbage"),
+			maxDepth: 1,
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain, err := TrimCertificateChain(test.chain, test.ca, test.maxDepth)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, string(test.expChain), string(chain))
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventexport forwards the Events recorded by the cert-manager
+// controller to an external system. Kubernetes only retains Events for a
+// short time, so exporting them allows failures which happen while nobody is
+// watching, such as an overnight renewal, to be noticed.
+package eventexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// Event is the representation of a Kubernetes Event sent to a Sink.
+type Event struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+
+	// InvolvedObject is the object that the Event is about.
+	InvolvedObject ObjectReference `json:"involvedObject"`
+
+	// Source is the component which recorded the Event.
+	Source string `json:"source"`
+
+	Count          int32     `json:"count"`
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+	LastTimestamp  time.Time `json:"lastTimestamp"`
+}
+
+// ObjectReference identifies the object that an Event is about.
+type ObjectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Sink sends batches of Events to an external system.
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+}
+
+// Options configure an Exporter.
+type Options struct {
+	// Reasons limits the exported Events to those with one of the given
+	// reasons. If empty, all Events are exported.
+	Reasons []string
+
+	// BatchSize is the maximum number of Events sent to the Sink at once.
+	BatchSize int
+
+	// BatchInterval is the maximum time an Event is held back waiting for
+	// more Events to fill a batch.
+	BatchInterval time.Duration
+}
+
+// Exporter forwards Events to a Sink in batches. Exporting is best effort:
+// Events are dropped if the Sink cannot keep up or fails to send them.
+type Exporter struct {
+	log  logr.Logger
+	sink Sink
+
+	reasons       sets.String
+	batchSize     int
+	batchInterval time.Duration
+
+	events chan Event
+}
+
+// defaultBatchInterval is used if Options.BatchInterval is not set.
+const defaultBatchInterval = 30 * time.Second
+
+// bufferedBatches is the number of full batches that may be waiting to be
+// sent before further Events are dropped.
+const bufferedBatches = 10
+
+// New returns an Exporter which sends Events to the given Sink once Run has
+// been called.
+func New(log logr.Logger, sink Sink, opts Options) *Exporter {
+	batchSize := opts.BatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	batchInterval := opts.BatchInterval
+	if batchInterval <= 0 {
+		batchInterval = defaultBatchInterval
+	}
+	return &Exporter{
+		log:           log.WithName("event-exporter"),
+		sink:          sink,
+		reasons:       sets.NewString(opts.Reasons...),
+		batchSize:     batchSize,
+		batchInterval: batchInterval,
+		events:        make(chan Event, batchSize*bufferedBatches),
+	}
+}
+
+// Export queues the given Event to be sent to the Sink if its reason is
+// selected. It never blocks, so that it can be used as the event handler of
+// an event broadcaster.
+func (e *Exporter) Export(event *corev1.Event) {
+	if e.reasons.Len() > 0 && !e.reasons.Has(event.Reason) {
+		return
+	}
+
+	select {
+	case e.events <- newEvent(event):
+	default:
+		e.log.V(logf.WarnLevel).Info("dropping event as the export buffer is full", "reason", event.Reason,
+			"kind", event.InvolvedObject.Kind, "namespace", event.InvolvedObject.Namespace, "name", event.InvolvedObject.Name)
+	}
+}
+
+// Run sends the queued Events to the Sink until the given context is
+// cancelled. Any Events queued at that point are sent before returning.
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.batchInterval)
+	defer ticker.Stop()
+
+	var batch []Event
+	for {
+		select {
+		case <-ctx.Done():
+			// allow a timeout for sending the remaining events
+			sendCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			for {
+				select {
+				case event := <-e.events:
+					batch = e.add(sendCtx, batch, event)
+				default:
+					e.send(sendCtx, batch)
+					return
+				}
+			}
+		case event := <-e.events:
+			batch = e.add(ctx, batch, event)
+		case <-ticker.C:
+			e.send(ctx, batch)
+			batch = nil
+		}
+	}
+}
+
+// add appends the Event to the batch, sending the batch if it is full.
+func (e *Exporter) add(ctx context.Context, batch []Event, event Event) []Event {
+	batch = append(batch, event)
+	if len(batch) < e.batchSize {
+		return batch
+	}
+	e.send(ctx, batch)
+	return nil
+}
+
+func (e *Exporter) send(ctx context.Context, batch []Event) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := e.sink.Send(ctx, batch); err != nil {
+		e.log.Error(err, "failed to export events", "count", len(batch))
+	}
+}
+
+func newEvent(event *corev1.Event) Event {
+	return Event{
+		Type:    event.Type,
+		Reason:  event.Reason,
+		Message: event.Message,
+		InvolvedObject: ObjectReference{
+			APIVersion: event.InvolvedObject.APIVersion,
+			Kind:       event.InvolvedObject.Kind,
+			Namespace:  event.InvolvedObject.Namespace,
+			Name:       event.InvolvedObject.Name,
+		},
+		Source:         event.Source.Component,
+		Count:          event.Count,
+		FirstTimestamp: event.FirstTimestamp.Time,
+		LastTimestamp:  event.LastTimestamp.Time,
+	}
+}
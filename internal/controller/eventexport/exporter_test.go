@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexport
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type fakeSink struct {
+	lock    sync.Mutex
+	batches [][]string
+}
+
+func (s *fakeSink) Send(_ context.Context, events []Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var reasons []string
+	for _, event := range events {
+		reasons = append(reasons, event.Reason)
+	}
+	s.batches = append(s.batches, reasons)
+	return nil
+}
+
+func (s *fakeSink) sent() [][]string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.batches
+}
+
+func TestExporter(t *testing.T) {
+	tests := map[string]struct {
+		opts       Options
+		reasons    []string
+		expBatches [][]string
+	}{
+		"events are sent in batches of the batch size": {
+			opts:       Options{BatchSize: 2, BatchInterval: time.Hour},
+			reasons:    []string{"Issuing", "Generated", "Requested", "Issuing", "Failed"},
+			expBatches: [][]string{{"Issuing", "Generated"}, {"Requested", "Issuing"}, {"Failed"}},
+		},
+		"only events with the selected reasons are sent": {
+			opts:       Options{BatchSize: 10, BatchInterval: time.Hour, Reasons: []string{"Failed", "ErrInitIssuer"}},
+			reasons:    []string{"Issuing", "Failed", "Issuing", "ErrInitIssuer"},
+			expBatches: [][]string{{"Failed", "ErrInitIssuer"}},
+		},
+		"nothing is sent if no events are selected": {
+			opts:    Options{BatchSize: 10, BatchInterval: time.Hour, Reasons: []string{"Failed"}},
+			reasons: []string{"Issuing"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sink := &fakeSink{}
+			e := New(logtesting.NewTestLogger(t), sink, test.opts)
+			for _, reason := range test.reasons {
+				e.Export(&corev1.Event{Reason: reason})
+			}
+
+			// the remaining events are sent once the context is cancelled
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			e.Run(ctx)
+
+			assert.Equal(t, test.expBatches, sink.sent())
+		})
+	}
+}
+
+func TestExporterBatchInterval(t *testing.T) {
+	sink := &fakeSink{}
+	e := New(logtesting.NewTestLogger(t), sink, Options{BatchSize: 10, BatchInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	e.Export(&corev1.Event{Reason: "Failed"})
+	assert.Eventually(t, func() bool {
+		return len(sink.sent()) == 1
+	}, 5*time.Second, 10*time.Millisecond, "expected a partial batch to be sent after the batch interval")
+}
+
+func TestExporterDropsEventsWhenFull(t *testing.T) {
+	sink := &fakeSink{}
+	e := New(logtesting.NewTestLogger(t), sink, Options{BatchSize: 1, BatchInterval: time.Hour})
+	for i := 0; i < bufferedBatches+5; i++ {
+		e.Export(&corev1.Event{Reason: "Failed"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e.Run(ctx)
+
+	assert.Len(t, sink.sent(), bufferedBatches)
+}
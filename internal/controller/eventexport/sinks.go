@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+)
+
+const (
+	// SinkWebhook posts batches of Events as JSON to a URL.
+	SinkWebhook = "webhook"
+	// SinkSlack posts batches of Events to a Slack incoming webhook.
+	SinkSlack = "slack"
+	// SinkEventBridge puts Events on an Amazon EventBridge event bus.
+	SinkEventBridge = "eventbridge"
+)
+
+// WebhookPayload is the request body posted by the webhook sink.
+type WebhookPayload struct {
+	Events []Event `json:"events"`
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a Sink which posts each batch of Events as a
+// WebhookPayload to the given URL.
+func NewWebhookSink(url string, client *http.Client) Sink {
+	return &webhookSink{url: url, client: client}
+}
+
+func (s *webhookSink) Send(ctx context.Context, events []Event) error {
+	return postJSON(ctx, s.client, s.url, WebhookPayload{Events: events})
+}
+
+type slackSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackSink returns a Sink which posts each batch of Events as a single
+// message to the given Slack incoming webhook URL.
+func NewSlackSink(url string, client *http.Client) Sink {
+	return &slackSink{url: url, client: client}
+}
+
+func (s *slackSink) Send(ctx context.Context, events []Event) error {
+	var text strings.Builder
+	for i, event := range events {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		obj := event.InvolvedObject
+		name := obj.Name
+		if len(obj.Namespace) > 0 {
+			name = obj.Namespace + "/" + obj.Name
+		}
+		fmt.Fprintf(&text, "*%s %s* %s %s: %s", event.Type, event.Reason, obj.Kind, name, event.Message)
+	}
+
+	return postJSON(ctx, s.client, s.url, map[string]string{"text": text.String()})
+}
+
+// eventBridgeMaxEntries is the maximum number of entries accepted by a single
+// PutEvents call.
+const eventBridgeMaxEntries = 10
+
+type eventBridgeSink struct {
+	client  eventbridgeiface.EventBridgeAPI
+	busName string
+}
+
+// NewEventBridgeSink returns a Sink which puts each Event on the given
+// EventBridge event bus, with the source "cert-manager" and the detail type
+// "cert-manager Event".
+func NewEventBridgeSink(client eventbridgeiface.EventBridgeAPI, busName string) Sink {
+	return &eventBridgeSink{client: client, busName: busName}
+}
+
+func (s *eventBridgeSink) Send(ctx context.Context, events []Event) error {
+	for len(events) > 0 {
+		n := len(events)
+		if n > eventBridgeMaxEntries {
+			n = eventBridgeMaxEntries
+		}
+
+		entries := make([]*eventbridge.PutEventsRequestEntry, 0, n)
+		for _, event := range events[:n] {
+			detail, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, &eventbridge.PutEventsRequestEntry{
+				EventBusName: aws.String(s.busName),
+				Source:       aws.String("cert-manager"),
+				DetailType:   aws.String("cert-manager Event"),
+				Detail:       aws.String(string(detail)),
+			})
+		}
+
+		out, err := s.client.PutEventsWithContext(ctx, &eventbridge.PutEventsInput{Entries: entries})
+		if err != nil {
+			return err
+		}
+		if failed := aws.Int64Value(out.FailedEntryCount); failed > 0 {
+			return fmt.Errorf("eventbridge rejected %d of %d events", failed, len(entries))
+		}
+
+		events = events[n:]
+	}
+
+	return nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %q from %s", resp.Status, req.URL.Host)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvents = []Event{
+	{
+		Type:           "Warning",
+		Reason:         "Failed",
+		Message:        "The certificate request has failed to complete",
+		InvolvedObject: ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Namespace: "ns", Name: "crt"},
+	},
+	{
+		Type:           "Warning",
+		Reason:         "ErrInitIssuer",
+		Message:        "Error initializing issuer",
+		InvolvedObject: ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer", Name: "issuer"},
+	},
+}
+
+func TestWebhookSinks(t *testing.T) {
+	tests := map[string]struct {
+		newSink func(url string, client *http.Client) Sink
+		status  int
+		expBody string
+		expErr  bool
+	}{
+		"webhook sink posts the events": {
+			newSink: NewWebhookSink,
+			status:  http.StatusOK,
+			expBody: mustMarshal(t, WebhookPayload{Events: testEvents}),
+		},
+		"slack sink posts a message with a line per event": {
+			newSink: NewSlackSink,
+			status:  http.StatusOK,
+			expBody: mustMarshal(t, map[string]string{"text": "*Warning Failed* Certificate ns/crt: The certificate request has failed to complete\n" +
+				"*Warning ErrInitIssuer* ClusterIssuer issuer: Error initializing issuer"}),
+		},
+		"an error status is returned as an error": {
+			newSink: NewWebhookSink,
+			status:  http.StatusInternalServerError,
+			expBody: mustMarshal(t, WebhookPayload{Events: testEvents}),
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body json.RawMessage
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.JSONEq(t, test.expBody, string(body))
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			err := test.newSink(server.URL, server.Client()).Send(context.Background(), testEvents)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
+type fakeEventBridge struct {
+	eventbridgeiface.EventBridgeAPI
+	inputs []*eventbridge.PutEventsInput
+}
+
+func (f *fakeEventBridge) PutEventsWithContext(_ aws.Context, input *eventbridge.PutEventsInput, _ ...request.Option) (*eventbridge.PutEventsOutput, error) {
+	f.inputs = append(f.inputs, input)
+	return &eventbridge.PutEventsOutput{FailedEntryCount: aws.Int64(0)}, nil
+}
+
+func TestEventBridgeSink(t *testing.T) {
+	var events []Event
+	for i := 0; i < eventBridgeMaxEntries+1; i++ {
+		events = append(events, testEvents[0])
+	}
+
+	client := &fakeEventBridge{}
+	require.NoError(t, NewEventBridgeSink(client, "bus").Send(context.Background(), events))
+
+	require.Len(t, client.inputs, 2, "expected the events to be split into calls of at most 10 entries")
+	assert.Len(t, client.inputs[0].Entries, eventBridgeMaxEntries)
+	assert.Len(t, client.inputs[1].Entries, 1)
+
+	entry := client.inputs[1].Entries[0]
+	assert.Equal(t, "bus", aws.StringValue(entry.EventBusName))
+	assert.Equal(t, "cert-manager", aws.StringValue(entry.Source))
+	assert.JSONEq(t, mustMarshal(t, testEvents[0]), aws.StringValue(entry.Detail))
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -58,6 +58,36 @@ const (
 	// This feature gate must be used together with LiteralCertificateSubject webhook feature gate.
 	// See https://github.com/cert-manager/cert-manager/issues/3203 and https://github.com/cert-manager/cert-manager/issues/4424 for context.
 	LiteralCertificateSubject featuregate.Feature = "LiteralCertificateSubject"
+
+	// Alpha: v1.9
+	//
+	// AdditionalTrustedCAs enables appending the CAs referenced in a
+	// Certificate's `additionalTrustedCAs` field to the `trust.pem` key of the
+	// issued Secret.
+	// This feature gate must be used together with the AdditionalTrustedCAs webhook feature gate.
+	AdditionalTrustedCAs featuregate.Feature = "AdditionalTrustedCAs"
+
+	// Alpha: v1.9
+	//
+	// ACMEOrderLongPolling enables waiting for pending ACME Orders to become
+	// ready by long-polling the ACME server, rather than re-checking them at
+	// a fixed interval, if the ACME server advertises support for it.
+	ACMEOrderLongPolling featuregate.Feature = "ACMEOrderLongPolling"
+
+	// Alpha: v1.9
+	//
+	// HTTP01ConsolidatedSolving enables serving the HTTP-01 challenges of an
+	// Order which use the same Ingress solver from a single solver Pod,
+	// Service and Ingress, rather than creating them for each Challenge.
+	HTTP01ConsolidatedSolving featuregate.Feature = "HTTP01ConsolidatedSolving"
+
+	// Alpha: v1.9
+	//
+	// ACMEOrderCoalescing enables CertificateRequests to share an in-flight
+	// ACME Order for the same identifiers and public key, rather than
+	// creating a duplicate Order, which reduces the number of duplicate
+	// certificates requested from the ACME server.
+	ACMEOrderCoalescing featuregate.Feature = "ACMEOrderCoalescing"
 )
 
 func init() {
@@ -74,4 +104,8 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	AdditionalCertificateOutputFormats:               {Default: false, PreRelease: featuregate.Alpha},
 	ServerSideApply:                                  {Default: false, PreRelease: featuregate.Alpha},
 	LiteralCertificateSubject:                        {Default: false, PreRelease: featuregate.Alpha},
+	AdditionalTrustedCAs:                             {Default: false, PreRelease: featuregate.Alpha},
+	ACMEOrderLongPolling:                             {Default: false, PreRelease: featuregate.Alpha},
+	HTTP01ConsolidatedSolving:                        {Default: false, PreRelease: featuregate.Alpha},
+	ACMEOrderCoalescing:                              {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,508 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretaccess provides a Secret informer for controllers which are
+// only granted access to Secrets in selected namespaces.
+package secretaccess
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var errReadOnly = errors.New("the cache of a namespaced informer cannot be modified")
+
+// NamespaceSelection selects the namespaces in which access to Secrets is
+// granted. The cluster resource namespace is always selected so that
+// ClusterIssuers continue to function.
+type NamespaceSelection struct {
+	Selector                 labels.Selector
+	ClusterResourceNamespace string
+}
+
+// Selected returns true if access to Secrets is granted in the namespace with
+// the given name and labels.
+func (s NamespaceSelection) Selected(namespace string, nsLabels map[string]string) bool {
+	if namespace == s.ClusterResourceNamespace {
+		return true
+	}
+	return s.Selector.Matches(labels.Set(nsLabels))
+}
+
+// NewInformerFactory wraps factory so that its Secret informer lists and
+// watches Secrets in each selected namespace, rather than cluster wide. All
+// other informers are returned by factory.
+func NewInformerFactory(factory kubeinformers.SharedInformerFactory, client kubernetes.Interface, resync time.Duration, selection NamespaceSelection) kubeinformers.SharedInformerFactory {
+	f := &informerFactory{
+		SharedInformerFactory: factory,
+		client:                client,
+		resync:                resync,
+		selection:             selection,
+	}
+	f.core = &coreGroup{Interface: factory.Core(), factory: f}
+	return f
+}
+
+type informerFactory struct {
+	kubeinformers.SharedInformerFactory
+
+	client    kubernetes.Interface
+	resync    time.Duration
+	selection NamespaceSelection
+	core      *coreGroup
+
+	lock    sync.Mutex
+	secrets *namespacedInformer
+}
+
+func (f *informerFactory) Core() coreinformers.Interface {
+	return f.core
+}
+
+// Start starts the informers of the wrapped factory, and the Secret informer
+// if it has been requested.
+func (f *informerFactory) Start(stopCh <-chan struct{}) {
+	f.SharedInformerFactory.Start(stopCh)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.secrets != nil {
+		go f.secrets.Run(stopCh)
+	}
+}
+
+func (f *informerFactory) secretInformer() *namespacedInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.secrets == nil {
+		f.secrets = newNamespacedInformer(
+			f.SharedInformerFactory.Core().V1().Namespaces().Informer(),
+			f.selection,
+			func(namespace string, indexers cache.Indexers) cache.SharedIndexInformer {
+				return corev1informers.NewSecretInformer(f.client, namespace, f.resync, indexers)
+			},
+		)
+	}
+	return f.secrets
+}
+
+type coreGroup struct {
+	coreinformers.Interface
+	factory *informerFactory
+}
+
+func (g *coreGroup) V1() corev1informers.Interface {
+	return &coreV1{Interface: g.Interface.V1(), factory: g.factory}
+}
+
+type coreV1 struct {
+	corev1informers.Interface
+	factory *informerFactory
+}
+
+func (v *coreV1) Secrets() corev1informers.SecretInformer {
+	return &secretInformer{informer: v.factory.secretInformer()}
+}
+
+type secretInformer struct {
+	informer *namespacedInformer
+}
+
+func (s *secretInformer) Informer() cache.SharedIndexInformer {
+	return s.informer
+}
+
+func (s *secretInformer) Lister() corev1listers.SecretLister {
+	return corev1listers.NewSecretLister(s.informer.GetIndexer())
+}
+
+// namespacedInformer is a SharedIndexInformer which runs an informer in each
+// selected namespace. Informers are started and stopped as namespaces are
+// created, deleted and labelled. Handlers are notified of the deletion of the
+// objects in a namespace which is no longer selected.
+type namespacedInformer struct {
+	namespaces  cache.SharedIndexInformer
+	selection   NamespaceSelection
+	newInformer func(namespace string, indexers cache.Indexers) cache.SharedIndexInformer
+
+	lock             sync.RWMutex
+	stopCh           <-chan struct{}
+	informers        map[string]*namespaceInformer
+	handlers         []handlerRegistration
+	indexers         cache.Indexers
+	watchErrHandler  cache.WatchErrorHandler
+	transform        cache.TransformFunc
+	namespacesSynced bool
+}
+
+type namespaceInformer struct {
+	cache.SharedIndexInformer
+	stop chan struct{}
+}
+
+type handlerRegistration struct {
+	handler cache.ResourceEventHandler
+	resync  time.Duration
+}
+
+var _ cache.SharedIndexInformer = &namespacedInformer{}
+
+func newNamespacedInformer(namespaces cache.SharedIndexInformer, selection NamespaceSelection, newInformer func(string, cache.Indexers) cache.SharedIndexInformer) *namespacedInformer {
+	i := &namespacedInformer{
+		namespaces:  namespaces,
+		selection:   selection,
+		newInformer: newInformer,
+		informers:   make(map[string]*namespaceInformer),
+		indexers:    cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	}
+	namespaces.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { i.syncNamespace(obj) },
+		UpdateFunc: func(_, obj interface{}) { i.syncNamespace(obj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if ns, ok := obj.(*corev1.Namespace); ok {
+				i.lock.Lock()
+				notify := i.stopInformer(ns.Name)
+				i.lock.Unlock()
+				notify()
+			}
+		},
+	})
+	return i
+}
+
+// Run starts an informer in each selected namespace, and blocks until stopCh
+// is closed. It returns immediately if the informer is already running.
+func (i *namespacedInformer) Run(stopCh <-chan struct{}) {
+	i.lock.Lock()
+	if i.stopCh != nil {
+		i.lock.Unlock()
+		return
+	}
+	i.stopCh = stopCh
+	i.lock.Unlock()
+
+	if !cache.WaitForCacheSync(stopCh, i.namespaces.HasSynced) {
+		return
+	}
+	for _, obj := range i.namespaces.GetStore().List() {
+		i.syncNamespace(obj)
+	}
+	i.lock.Lock()
+	i.namespacesSynced = true
+	i.lock.Unlock()
+
+	<-stopCh
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for name, inf := range i.informers {
+		close(inf.stop)
+		delete(i.informers, name)
+	}
+}
+
+// syncNamespace starts the informer of a selected namespace and stops the
+// informer of a namespace which is no longer selected.
+func (i *namespacedInformer) syncNamespace(obj interface{}) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+
+	i.lock.Lock()
+	if i.stopCh == nil {
+		// The informers are started once Run is called.
+		i.lock.Unlock()
+		return
+	}
+
+	_, running := i.informers[ns.Name]
+	selected := ns.DeletionTimestamp == nil && i.selection.Selected(ns.Name, ns.Labels)
+	switch {
+	case selected && !running:
+		inf := &namespaceInformer{
+			SharedIndexInformer: i.newInformer(ns.Name, i.indexers),
+			stop:                make(chan struct{}),
+		}
+		if i.watchErrHandler != nil {
+			_ = inf.SetWatchErrorHandler(i.watchErrHandler)
+		}
+		if i.transform != nil {
+			_ = inf.SetTransform(i.transform)
+		}
+		for _, h := range i.handlers {
+			inf.AddEventHandlerWithResyncPeriod(h.handler, h.resync)
+		}
+		i.informers[ns.Name] = inf
+		go inf.Run(inf.stop)
+
+	case !selected && running:
+		notify := i.stopInformer(ns.Name)
+		i.lock.Unlock()
+		notify()
+		return
+	}
+	i.lock.Unlock()
+}
+
+// stopInformer stops the informer of the given namespace. It must be called
+// with the lock held, and returns a function notifying the handlers of the
+// deletion of the objects the informer had cached, which must be called once
+// the lock has been released.
+func (i *namespacedInformer) stopInformer(namespace string) func() {
+	inf, ok := i.informers[namespace]
+	if !ok {
+		return func() {}
+	}
+	close(inf.stop)
+	delete(i.informers, namespace)
+
+	objs := inf.GetStore().List()
+	handlers := append([]handlerRegistration(nil), i.handlers...)
+	return func() {
+		for _, obj := range objs {
+			for _, h := range handlers {
+				h.handler.OnDelete(obj)
+			}
+		}
+	}
+}
+
+func (i *namespacedInformer) AddEventHandler(handler cache.ResourceEventHandler) {
+	i.AddEventHandlerWithResyncPeriod(handler, 0)
+}
+
+func (i *namespacedInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resync time.Duration) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.handlers = append(i.handlers, handlerRegistration{handler: handler, resync: resync})
+	for _, inf := range i.informers {
+		inf.AddEventHandlerWithResyncPeriod(handler, resync)
+	}
+}
+
+func (i *namespacedInformer) GetStore() cache.Store {
+	return i.GetIndexer()
+}
+
+func (i *namespacedInformer) GetIndexer() cache.Indexer {
+	return &namespacedIndexer{informer: i}
+}
+
+func (i *namespacedInformer) GetController() cache.Controller {
+	return i
+}
+
+// HasSynced returns true once the informers of all selected namespaces have
+// synced.
+func (i *namespacedInformer) HasSynced() bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	if !i.namespacesSynced {
+		return false
+	}
+	for _, inf := range i.informers {
+		if !inf.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// LastSyncResourceVersion returns an empty string, since the informers of
+// each namespace are synced independently.
+func (i *namespacedInformer) LastSyncResourceVersion() string {
+	return ""
+}
+
+func (i *namespacedInformer) SetWatchErrorHandler(handler cache.WatchErrorHandler) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.stopCh != nil {
+		return errors.New("informer has already started")
+	}
+	i.watchErrHandler = handler
+	return nil
+}
+
+func (i *namespacedInformer) SetTransform(transform cache.TransformFunc) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.stopCh != nil {
+		return errors.New("informer has already started")
+	}
+	i.transform = transform
+	return nil
+}
+
+func (i *namespacedInformer) AddIndexers(indexers cache.Indexers) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.stopCh != nil {
+		return errors.New("informer has already started")
+	}
+	for name, fn := range indexers {
+		if _, ok := i.indexers[name]; ok {
+			return errors.New("indexer conflict: " + name)
+		}
+		i.indexers[name] = fn
+	}
+	return nil
+}
+
+// informerFor returns the informer of the given namespace, or nil if the
+// namespace is not selected.
+func (i *namespacedInformer) informerFor(namespace string) cache.SharedIndexInformer {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	if inf, ok := i.informers[namespace]; ok {
+		return inf
+	}
+	return nil
+}
+
+func (i *namespacedInformer) all() []cache.SharedIndexInformer {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	infs := make([]cache.SharedIndexInformer, 0, len(i.informers))
+	for _, inf := range i.informers {
+		infs = append(infs, inf)
+	}
+	return infs
+}
+
+// namespacedIndexer is a read only view of the caches of the informers of
+// all selected namespaces.
+type namespacedIndexer struct {
+	informer *namespacedInformer
+}
+
+func (n *namespacedIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return n.GetByKey(key)
+}
+
+func (n *namespacedIndexer) GetByKey(key string) (interface{}, bool, error) {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	inf := n.informer.informerFor(namespace)
+	if inf == nil {
+		return nil, false, nil
+	}
+	return inf.GetIndexer().GetByKey(key)
+}
+
+func (n *namespacedIndexer) List() []interface{} {
+	var objs []interface{}
+	for _, inf := range n.informer.all() {
+		objs = append(objs, inf.GetIndexer().List()...)
+	}
+	return objs
+}
+
+func (n *namespacedIndexer) ListKeys() []string {
+	var keys []string
+	for _, inf := range n.informer.all() {
+		keys = append(keys, inf.GetIndexer().ListKeys()...)
+	}
+	return keys
+}
+
+func (n *namespacedIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	if indexName == cache.NamespaceIndex {
+		inf := n.informer.informerFor(indexedValue)
+		if inf == nil {
+			return nil, nil
+		}
+		return inf.GetIndexer().ByIndex(indexName, indexedValue)
+	}
+
+	var objs []interface{}
+	for _, inf := range n.informer.all() {
+		o, err := inf.GetIndexer().ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, o...)
+	}
+	return objs, nil
+}
+
+func (n *namespacedIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	var objs []interface{}
+	for _, inf := range n.informer.all() {
+		o, err := inf.GetIndexer().Index(indexName, obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, o...)
+	}
+	return objs, nil
+}
+
+func (n *namespacedIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	var keys []string
+	for _, inf := range n.informer.all() {
+		k, err := inf.GetIndexer().IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k...)
+	}
+	return keys, nil
+}
+
+func (n *namespacedIndexer) ListIndexFuncValues(indexName string) []string {
+	var values []string
+	for _, inf := range n.informer.all() {
+		values = append(values, inf.GetIndexer().ListIndexFuncValues(indexName)...)
+	}
+	return values
+}
+
+func (n *namespacedIndexer) GetIndexers() cache.Indexers {
+	n.informer.lock.RLock()
+	defer n.informer.lock.RUnlock()
+	return n.informer.indexers
+}
+
+func (n *namespacedIndexer) AddIndexers(indexers cache.Indexers) error {
+	return n.informer.AddIndexers(indexers)
+}
+
+func (n *namespacedIndexer) Add(interface{}) error               { return errReadOnly }
+func (n *namespacedIndexer) Update(interface{}) error            { return errReadOnly }
+func (n *namespacedIndexer) Delete(interface{}) error            { return errReadOnly }
+func (n *namespacedIndexer) Replace([]interface{}, string) error { return errReadOnly }
+func (n *namespacedIndexer) Resync() error                       { return nil }
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretaccess
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestInformerFactory(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "selected", Labels: map[string]string{"secrets": "allowed"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "selected", Name: "a"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "b"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "c"}},
+	)
+	selector, err := labels.Parse("secrets=allowed")
+	require.NoError(t, err)
+
+	factory := NewInformerFactory(kubeinformers.NewSharedInformerFactory(client, 0), client, 0, NamespaceSelection{
+		Selector:                 selector,
+		ClusterResourceNamespace: "cert-manager",
+	})
+	secrets := factory.Core().V1().Secrets()
+
+	var lock sync.Mutex
+	deleted := map[string]bool{}
+	secrets.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			lock.Lock()
+			defer lock.Unlock()
+			deleted[obj.(*corev1.Secret).Name] = true
+		},
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+	require.True(t, cache.WaitForCacheSync(stopCh, secrets.Informer().HasSynced))
+
+	lister := secrets.Lister()
+	_, err = lister.Secrets("selected").Get("a")
+	assert.NoError(t, err, "Secrets in selected namespaces should be cached")
+	_, err = lister.Secrets("cert-manager").Get("c")
+	assert.NoError(t, err, "Secrets in the cluster resource namespace should be cached")
+	_, err = lister.Secrets("other").Get("b")
+	assert.True(t, apierrors.IsNotFound(err), "Secrets in other namespaces must not be cached")
+
+	all, err := lister.List(labels.Everything())
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// Selecting a namespace starts caching its Secrets.
+	_, err = client.CoreV1().Namespaces().Update(context.Background(),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"secrets": "allowed"}}}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.NoError(t, wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		_, err := lister.Secrets("other").Get("b")
+		return err == nil, nil
+	}))
+
+	// Deselecting a namespace stops caching its Secrets, which are reported
+	// as deleted.
+	_, err = client.CoreV1().Namespaces().Update(context.Background(),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "selected"}}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.NoError(t, wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		_, err := lister.Secrets("selected").Get("a")
+		return apierrors.IsNotFound(err), nil
+	}))
+	lock.Lock()
+	assert.True(t, deleted["a"])
+	lock.Unlock()
+}
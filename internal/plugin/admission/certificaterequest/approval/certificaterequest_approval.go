@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
@@ -48,6 +50,11 @@ import (
 
 const PluginName = "CertificateRequestApproval"
 
+// missingResourceCacheTTL is how long a GroupKind which is not served by the
+// apiserver is remembered as missing, so that bulk approvals of
+// CertificateRequests referencing it do not each perform discovery queries.
+const missingResourceCacheTTL = 10 * time.Second
+
 type certificateRequestApproval struct {
 	*admission.Handler
 
@@ -57,7 +64,12 @@ type certificateRequestApproval struct {
 	// resourceCache stores the associated APIResource for a given GroupKind
 	// to making multiple queries to the API server for every approval.
 	resourceCache map[schema.GroupKind]metav1.APIResource
-	mutex         sync.RWMutex
+	// missingResourceCache stores the time until which a GroupKind is known
+	// not to be served by the apiserver.
+	missingResourceCache map[schema.GroupKind]time.Time
+	mutex                sync.RWMutex
+
+	clock clock.Clock
 }
 
 var _ admission.ValidationInterface = &certificateRequestApproval{}
@@ -72,8 +84,10 @@ func Register(plugins *admission.Plugins) {
 
 func NewPlugin() admission.Interface {
 	return &certificateRequestApproval{
-		Handler:       admission.NewHandler(admissionv1.Update),
-		resourceCache: map[schema.GroupKind]metav1.APIResource{},
+		Handler:              admission.NewHandler(admissionv1.Update),
+		resourceCache:        map[schema.GroupKind]metav1.APIResource{},
+		missingResourceCache: map[schema.GroupKind]time.Time{},
+		clock:                clock.RealClock{},
 	}
 }
 
@@ -134,14 +148,15 @@ func approvalConditionsHaveChanged(oldCR, cr *certmanager.CertificateRequest) bo
 // 'resource' may be nil even if err is also nil.
 func (c *certificateRequestApproval) apiResourceForGroupKind(groupKind schema.GroupKind) (resource *metav1.APIResource, err error) {
 	// fast path if resource is in the cache already
-	if resource := c.readAPIResourceFromCache(groupKind); resource != nil {
+	resource, missing := c.readAPIResourceFromCache(groupKind)
+	if resource != nil {
 		return resource, nil
 	}
+	if missing {
+		return nil, errNoResourceExists
+	}
 
 	// otherwise, query the apiserver
-	// TODO: we should enhance caching here to avoid performing discovery queries
-	//       many times if many CertificateRequest resources exist that reference
-	//       a resource that doesn't exist
 	groups, err := c.discovery.ServerGroups()
 	if err != nil {
 		return nil, err
@@ -172,22 +187,45 @@ func (c *certificateRequestApproval) apiResourceForGroupKind(groupKind schema.Gr
 		}
 	}
 
+	c.cacheMissingAPIResource(groupKind)
 	return nil, errNoResourceExists
 }
 
-func (c *certificateRequestApproval) readAPIResourceFromCache(groupKind schema.GroupKind) *metav1.APIResource {
+// readAPIResourceFromCache returns the cached APIResource for the GroupKind,
+// or whether the GroupKind was recently found not to be served.
+func (c *certificateRequestApproval) readAPIResourceFromCache(groupKind schema.GroupKind) (*metav1.APIResource, bool) {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 	if resource, ok := c.resourceCache[groupKind]; ok {
-		return &resource
+		return &resource, false
 	}
-	return nil
+	if until, ok := c.missingResourceCache[groupKind]; ok && c.clock.Now().Before(until) {
+		return nil, true
+	}
+	return nil, false
 }
 
 func (c *certificateRequestApproval) cacheAPIResource(groupKind schema.GroupKind, resource metav1.APIResource) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	c.resourceCache[groupKind] = resource
+	delete(c.missingResourceCache, groupKind)
+}
+
+// cacheMissingAPIResource remembers that the GroupKind is not served by the
+// apiserver. The GroupKinds are taken from the signer names of
+// CertificateRequests, so expired entries are pruned to stop the cache
+// growing without bound.
+func (c *certificateRequestApproval) cacheMissingAPIResource(groupKind schema.GroupKind) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.clock.Now()
+	for gk, until := range c.missingResourceCache {
+		if !now.Before(until) {
+			delete(c.missingResourceCache, gk)
+		}
+	}
+	c.missingResourceCache[groupKind] = now.Add(missingResourceCacheTTL)
 }
 
 var errNoResourceExists = fmt.Errorf("no resource registered")
@@ -20,13 +20,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	authnv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/client-go/discovery"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	"github.com/cert-manager/cert-manager/internal/apis/meta"
@@ -313,6 +316,126 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestAPIResourceForGroupKindCachesMissingResources(t *testing.T) {
+	var calls int
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	a := NewPlugin().(*certificateRequestApproval)
+	a.clock = fakeClock
+	a.discovery = discoveryfake.NewDiscovery().
+		WithServerGroups(func() (*metav1.APIGroupList, error) {
+			calls++
+			return &metav1.APIGroupList{}, nil
+		})
+
+	groupKind := schema.GroupKind{Group: "example.io", Kind: "Issuer"}
+	for i := 0; i < 3; i++ {
+		if _, err := a.apiResourceForGroupKind(groupKind); err != errNoResourceExists {
+			t.Fatalf("expected errNoResourceExists, got: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected a single discovery query while the missing resource is cached, got %d", calls)
+	}
+
+	fakeClock.Step(missingResourceCacheTTL)
+	if _, err := a.apiResourceForGroupKind(groupKind); err != errNoResourceExists {
+		t.Fatalf("expected errNoResourceExists, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected discovery to be queried again once the cache expired, got %d queries", calls)
+	}
+
+	fakeClock.Step(missingResourceCacheTTL)
+	otherGroupKind := schema.GroupKind{Group: "other.example.io", Kind: "Issuer"}
+	if _, err := a.apiResourceForGroupKind(otherGroupKind); err != errNoResourceExists {
+		t.Fatalf("expected errNoResourceExists, got: %v", err)
+	}
+	if _, ok := a.missingResourceCache[groupKind]; ok {
+		t.Errorf("expected the expired entry for %s to be pruned", groupKind)
+	}
+	if len(a.missingResourceCache) != 1 {
+		t.Errorf("expected a single cached missing resource, got %d", len(a.missingResourceCache))
+	}
+}
+
+func TestValidateCachesSignerResourceLookups(t *testing.T) {
+	req := admissionv1.AdmissionRequest{
+		UserInfo:  authnv1.UserInfo{Username: "user-1"},
+		Operation: admissionv1.Update,
+		RequestResource: &metav1.GroupVersionResource{
+			Group:    "cert-manager.io",
+			Resource: "certificaterequests",
+		},
+		RequestSubResource: "status",
+	}
+	newCRs := func(group string) (*certmanager.CertificateRequest, *certmanager.CertificateRequest) {
+		oldCR := &certmanager.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns"},
+			Spec: certmanager.CertificateRequestSpec{
+				IssuerRef: meta.ObjectReference{Name: "my-issuer", Kind: "Issuer", Group: group},
+			},
+		}
+		newCR := oldCR.DeepCopy()
+		newCR.Status.Conditions = []certmanager.CertificateRequestCondition{
+			{Type: certmanager.CertificateRequestConditionApproved, Status: meta.ConditionTrue},
+		}
+		return oldCR, newCR
+	}
+
+	var groupsCalls, resourcesCalls int
+	a := NewPlugin().(*certificateRequestApproval)
+	a.clock = fakeclock.NewFakeClock(time.Now())
+	a.authorizer = &fakeAuthorizer{
+		t:           t,
+		verb:        "approve",
+		allowedName: "issuers.example.io/testns.my-issuer",
+		decision:    authorizer.DecisionAllow,
+	}
+	a.discovery = discoveryfake.NewDiscovery().
+		WithServerGroups(func() (*metav1.APIGroupList, error) {
+			groupsCalls++
+			return &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "example.io",
+						Versions: []metav1.GroupVersionForDiscovery{
+							{GroupVersion: "example.io/a-version", Version: "a-version"},
+						},
+					},
+				},
+			}, nil
+		}).
+		WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
+			resourcesCalls++
+			return &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{
+					{Name: "issuers", Namespaced: true, Kind: "Issuer"},
+				},
+			}, nil
+		})
+
+	oldCR, newCR := newCRs("example.io")
+	for i := 0; i < 3; i++ {
+		if _, err := a.Validate(context.TODO(), req, oldCR, newCR); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if groupsCalls != 1 || resourcesCalls != 1 {
+		t.Errorf("expected a single discovery query for repeated approvals of a served signer, got %d group and %d resource queries", groupsCalls, resourcesCalls)
+	}
+
+	oldCR, newCR = newCRs("missing.example.io")
+	expErr := field.Forbidden(field.NewPath("spec.issuerRef"),
+		"referenced signer resource does not exist: {my-issuer Issuer missing.example.io}")
+	for i := 0; i < 3; i++ {
+		_, err := a.Validate(context.TODO(), req, oldCR, newCR)
+		compareErrors(t, expErr, err)
+	}
+	if groupsCalls != 2 {
+		t.Errorf("expected a single discovery query for repeated approvals of a missing signer, got %d", groupsCalls-1)
+	}
+}
+
 type fakeAuthorizer struct {
 	t           *testing.T
 	verb        string
@@ -52,6 +52,7 @@ var certificateGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificates
 var certificateRequestGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificaterequests")
 var issuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("issuers")
 var clusterIssuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("clusterissuers")
+var certificateAuthorityGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificateauthorities")
 var orderGVR = acmev1.SchemeGroupVersion.WithResource("orders")
 var challengeGVR = acmev1.SchemeGroupVersion.WithResource("challenges")
 
@@ -68,12 +69,13 @@ func newValidationPair(create validateCreateFunc, update validateUpdateFunc) val
 }
 
 var validationMapping = map[schema.GroupVersionResource]validationPair{
-	certificateGVR:        newValidationPair(cmvalidation.ValidateCertificate, cmvalidation.ValidateUpdateCertificate),
-	certificateRequestGVR: newValidationPair(cmvalidation.ValidateCertificateRequest, cmvalidation.ValidateUpdateCertificateRequest),
-	issuerGVR:             newValidationPair(cmvalidation.ValidateIssuer, cmvalidation.ValidateUpdateIssuer),
-	clusterIssuerGVR:      newValidationPair(cmvalidation.ValidateClusterIssuer, cmvalidation.ValidateUpdateClusterIssuer),
-	orderGVR:              newValidationPair(acmevalidation.ValidateOrder, acmevalidation.ValidateOrderUpdate),
-	challengeGVR:          newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
+	certificateGVR:          newValidationPair(cmvalidation.ValidateCertificate, cmvalidation.ValidateUpdateCertificate),
+	certificateRequestGVR:   newValidationPair(cmvalidation.ValidateCertificateRequest, cmvalidation.ValidateUpdateCertificateRequest),
+	issuerGVR:               newValidationPair(cmvalidation.ValidateIssuer, cmvalidation.ValidateUpdateIssuer),
+	clusterIssuerGVR:        newValidationPair(cmvalidation.ValidateClusterIssuer, cmvalidation.ValidateUpdateClusterIssuer),
+	certificateAuthorityGVR: newValidationPair(cmvalidation.ValidateCertificateAuthority, cmvalidation.ValidateUpdateCertificateAuthority),
+	orderGVR:                newValidationPair(acmevalidation.ValidateOrder, acmevalidation.ValidateOrderUpdate),
+	challengeGVR:            newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
 }
 
 func NewPlugin() admission.Interface {
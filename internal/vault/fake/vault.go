@@ -30,6 +30,7 @@ import (
 type Vault struct {
 	NewFn                           func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)
 	SignFn                          func([]byte, time.Duration) ([]byte, []byte, error)
+	IssueFn                         func([]byte, time.Duration) ([]byte, []byte, []byte, error)
 	IsVaultInitializedAndUnsealedFn func() error
 }
 
@@ -39,6 +40,9 @@ func New() *Vault {
 		SignFn: func([]byte, time.Duration) ([]byte, []byte, error) {
 			return nil, nil, nil
 		},
+		IssueFn: func([]byte, time.Duration) ([]byte, []byte, []byte, error) {
+			return nil, nil, nil, nil
+		},
 		IsVaultInitializedAndUnsealedFn: func() error {
 			return nil
 		},
@@ -64,6 +68,19 @@ func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
 	return v
 }
 
+// Issue implements `vault.Interface`.
+func (v *Vault) Issue(csrPEM []byte, duration time.Duration) ([]byte, []byte, []byte, error) {
+	return v.IssueFn(csrPEM, duration)
+}
+
+// WithIssue sets the fake Vault's Issue function.
+func (v *Vault) WithIssue(certPEM, caPEM, keyPEM []byte, err error) *Vault {
+	v.IssueFn = func([]byte, time.Duration) ([]byte, []byte, []byte, error) {
+		return certPEM, caPEM, keyPEM, err
+	}
+	return v
+}
+
 // WithNew sets the fake Vault's New function.
 func (v *Vault) WithNew(f func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)) *Vault {
 	v.NewFn = f
@@ -31,7 +31,9 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/tlsconfig"
 )
 
 var _ Interface = &Vault{}
@@ -47,6 +49,7 @@ type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister
 // TODO: Sys() is duplicated here and in Client interface
 type Interface interface {
 	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
+	Issue(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, keyPEM []byte, err error)
 	Sys() *vault.Sys
 	IsVaultInitializedAndUnsealed() error
 }
@@ -102,22 +105,81 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.Gen
 
 // Sign will connect to a Vault instance to sign a certificate signing request.
 func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, err error) {
-	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	parameters, err := certificateParameters(csrPEM, duration)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
+	parameters["csr"] = string(csrPEM)
 
-	parameters := map[string]string{
+	vaultResult, err := v.requestCertificate(parameters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
+	}
+
+	// The sign endpoint never returns a private key. If one is returned the
+	// configured path is an issue endpoint, and the certificate does not
+	// belong to the key of the CSR.
+	if _, ok := vaultResult.Data["private_key"]; ok {
+		return nil, nil, errors.New("vault returned a private key for the certificate, the path of the issuer must be a sign endpoint unless its mode is Issue")
+	}
+
+	return extractCertificatesFromVaultCertificateSecret(vaultResult)
+}
+
+// Issue will connect to a Vault instance to issue a certificate, along with a
+// private key generated by Vault, for the subject of a certificate signing
+// request.
+func (v *Vault) Issue(csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, key []byte, err error) {
+	parameters, err := certificateParameters(csrPEM, duration)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode CSR for issuing: %s", err)
+	}
+
+	vaultResult, err := v.requestCertificate(parameters)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to issue certificate by vault: %s", err)
+	}
+
+	keyPEM, ok := vaultResult.Data["private_key"].(string)
+	if !ok || len(keyPEM) == 0 {
+		return nil, nil, nil, errors.New("vault did not return a private key for the certificate, the path of the issuer must be an issue endpoint")
+	}
+	// The private key is returned on its own, and is not parsed along with
+	// the certificates so that it cannot appear in their parsing errors.
+	delete(vaultResult.Data, "private_key")
+	delete(vaultResult.Data, "private_key_type")
+
+	cert, ca, err = extractCertificatesFromVaultCertificateSecret(vaultResult)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return cert, ca, []byte(keyPEM), nil
+}
+
+// certificateParameters returns the parameters of a sign or issue request for
+// the subject and subject alternative names of the given CSR.
+func certificateParameters(csrPEM []byte, duration time.Duration) (map[string]string, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
 		"common_name": csr.Subject.CommonName,
 		"alt_names":   strings.Join(csr.DNSNames, ","),
 		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
 		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
 		"ttl":         duration.String(),
-		"csr":         string(csrPEM),
 
 		"exclude_cn_from_sans": "true",
-	}
+	}, nil
+}
 
+// requestCertificate sends a sign or issue request to the path of the issuer
+// and returns the certificate bundle of the response. If response wrapping is
+// configured, the wrapped response is unwrapped first.
+func (v *Vault) requestCertificate(parameters map[string]string) (*certutil.Secret, error) {
 	vaultIssuer := v.issuer.GetSpec().Vault
 	url := path.Join("/v1", vaultIssuer.Path)
 
@@ -125,24 +187,72 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 
 	v.addVaultNamespaceToRequest(request)
 
+	if vaultIssuer.ResponseWrapTTL != nil {
+		request.WrapTTL = vaultIssuer.ResponseWrapTTL.Duration.String()
+	}
+
 	if err := request.SetJSONBody(parameters); err != nil {
-		return nil, nil, fmt.Errorf("failed to build vault request: %s", err)
+		return nil, fmt.Errorf("failed to build vault request: %s", err)
 	}
 
 	resp, err := v.client.RawRequest(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	vaultResult := certutil.Secret{}
-	err = resp.DecodeJSON(&vaultResult)
+	if vaultIssuer.ResponseWrapTTL == nil {
+		vaultResult := certutil.Secret{}
+		if err := resp.DecodeJSON(&vaultResult); err != nil {
+			return nil, fmt.Errorf("failed to decode response returned by vault: %s", err)
+		}
+
+		return &vaultResult, nil
+	}
+
+	wrapped := vault.Secret{}
+	if err := resp.DecodeJSON(&wrapped); err != nil {
+		return nil, fmt.Errorf("failed to decode wrapped response returned by vault: %s", err)
+	}
+
+	if wrapped.WrapInfo == nil || len(wrapped.WrapInfo.Token) == 0 {
+		return nil, errors.New("vault did not wrap the response")
+	}
+
+	// Vault records the path of the request which created a wrapped response.
+	// A different path means the wrapping token was not created by this
+	// request, and must not be trusted.
+	if strings.Trim(wrapped.WrapInfo.CreationPath, "/") != strings.Trim(vaultIssuer.Path, "/") {
+		return nil, fmt.Errorf("wrapped response was created by unexpected path %q", wrapped.WrapInfo.CreationPath)
+	}
+
+	return v.unwrap(wrapped.WrapInfo.Token)
+}
+
+// unwrap exchanges a single-use response wrapping token for the certificate
+// bundle it wraps.
+func (v *Vault) unwrap(wrappingToken string) (*certutil.Secret, error) {
+	url := path.Join("/v1", "sys", "wrapping", "unwrap")
+
+	request := v.client.NewRequest("POST", url)
+	request.ClientToken = wrappingToken
+
+	v.addVaultNamespaceToRequest(request)
+
+	resp, err := v.client.RawRequest(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to decode response returned by vault: %s", err)
+		return nil, fmt.Errorf("failed to unwrap response: %s", err)
 	}
 
-	return extractCertificatesFromVaultCertificateSecret(&vaultResult)
+	defer resp.Body.Close()
+
+	vaultResult := certutil.Secret{}
+	if err := resp.DecodeJSON(&vaultResult); err != nil {
+		return nil, fmt.Errorf("failed to decode unwrapped response returned by vault: %s", err)
+	}
+
+	return &vaultResult, nil
 }
 
 func (v *Vault) setToken(client Client) error {
@@ -172,7 +282,7 @@ func (v *Vault) setToken(client Client) error {
 	if kubernetesAuth != nil {
 		token, err := v.requestTokenWithKubernetesAuth(client, kubernetesAuth)
 		if err != nil {
-			return fmt.Errorf("error reading Kubernetes service account token from %s: %s", kubernetesAuth.SecretRef.Name, err.Error())
+			return fmt.Errorf("error reading Kubernetes service account token from %s: %w", kubernetesAuth.SecretRef.Name, err)
 		}
 		client.SetToken(token)
 		return nil
@@ -185,6 +295,11 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
 
+	tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+	if err := tlsconfig.Apply(tlsConfig, v.issuer.GetSpec().TLS); err != nil {
+		return nil, fmt.Errorf("error configuring Vault TLS: %w", err)
+	}
+
 	certs := v.issuer.GetSpec().Vault.CABundle
 	if len(certs) == 0 {
 		return cfg, nil
@@ -196,7 +311,7 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 		return nil, fmt.Errorf("error loading Vault CA bundle")
 	}
 
-	cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = caCertPool
+	tlsConfig.RootCAs = caCertPool
 
 	return cfg, nil
 }
@@ -272,7 +387,7 @@ func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1.VaultAppRo
 
 	resp, err := client.RawRequest(request)
 	if err != nil {
-		return "", fmt.Errorf("error logging in to Vault server: %s", err.Error())
+		return "", loginError("error logging in to Vault server", err)
 	}
 
 	defer resp.Body.Close()
@@ -333,7 +448,7 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 
 	resp, err := client.RawRequest(request)
 	if err != nil {
-		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
+		return "", loginError("error calling Vault server", err)
 	}
 
 	defer resp.Body.Close()
@@ -351,6 +466,27 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 	return token, nil
 }
 
+// loginError wraps an error returned by Vault when logging in with an auth
+// method. If Vault rejected the login, the error records whether the
+// credentials were invalid or not permitted to log in with the role.
+func loginError(msg string, err error) error {
+	err = fmt.Errorf("%s: %w", msg, err)
+
+	var respErr *vault.ResponseError
+	if !errors.As(err, &respErr) {
+		return err
+	}
+
+	switch {
+	case respErr.StatusCode == http.StatusForbidden:
+		return cmerrors.NewCredentialsError(v1.IssuerReasonPermissionDenied, err)
+	case respErr.StatusCode >= 400 && respErr.StatusCode < 500:
+		return cmerrors.NewCredentialsError(v1.IssuerReasonInvalidCredentials, err)
+	}
+
+	return err
+}
+
 func (v *Vault) Sys() *vault.Sys {
 	return v.client.Sys()
 }
@@ -21,6 +21,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -37,10 +38,12 @@ import (
 	"github.com/hashicorp/vault/sdk/helper/certutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	vaultfake "github.com/cert-manager/cert-manager/internal/vault/fake"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/cert-manager/cert-manager/test/unit/listers"
@@ -331,6 +334,204 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestIssue(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	keyPEM := pki.EncodePKCS1PrivateKey(privatekey)
+
+	issued := signedCertificateSecret(testIntermediateCa)
+	issued.Data["private_key"] = string(keyPEM)
+	issued.Data["private_key_type"] = "rsa"
+	issuedData, err := jsonutil.EncodeJSON(issued)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedData, err := bundlePEM(testIntermediateCa)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issueIssuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/issue/role", Mode: cmapi.VaultIssuerModeIssue}),
+	)
+
+	tests := map[string]struct {
+		fakeClient *vaultfake.Client
+
+		expectedErr  error
+		expectedCert string
+		expectedCA   string
+		expectedKey  string
+	}{
+		"a response with a private key should return the certificate and the private key": {
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+				Response: &http.Response{Body: io.NopCloser(bytes.NewReader(issuedData))},
+			}, nil),
+			expectedCert: testLeafCertificate + testIntermediateCa,
+			expectedCA:   testIntermediateCa,
+			expectedKey:  string(keyPEM),
+		},
+		"a response without a private key should error": {
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+				Response: &http.Response{Body: io.NopCloser(bytes.NewReader(signedData))},
+			}, nil),
+			expectedErr: errors.New("vault did not return a private key for the certificate, the path of the issuer must be an issue endpoint"),
+		},
+		"a failed request should error": {
+			fakeClient:  vaultfake.NewFakeClient().WithRawRequest(nil, errors.New("request failed")),
+			expectedErr: errors.New("failed to issue certificate by vault: request failed"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer:    issueIssuer,
+				client:    test.fakeClient,
+			}
+
+			cert, ca, key, err := v.Issue(csrPEM, time.Minute)
+			if !errorsEqual(test.expectedErr, err) {
+				t.Fatalf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+			if _, ok := test.fakeClient.NewRequestS.Obj.(map[string]string)["csr"]; ok {
+				t.Errorf("unexpected csr parameter in issue request")
+			}
+			if test.expectedCert != string(cert) {
+				t.Errorf("unexpected certificate, exp=%q got=%q", test.expectedCert, cert)
+			}
+			if test.expectedCA != string(ca) {
+				t.Errorf("unexpected ca, exp=%q got=%q", test.expectedCA, ca)
+			}
+			if test.expectedKey != string(key) {
+				t.Errorf("unexpected private key returned")
+			}
+		})
+	}
+}
+
+func TestSignWithIssueEndpoint(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	issued := signedCertificateSecret(testIntermediateCa)
+	issued.Data["private_key"] = "a private key"
+	issuedData, err := jsonutil.EncodeJSON(issued)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := &Vault{
+		namespace: "test-namespace",
+		issuer:    gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/issue/role"})),
+		client: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+			Response: &http.Response{Body: io.NopCloser(bytes.NewReader(issuedData))},
+		}, nil),
+	}
+
+	_, _, err = v.Sign(csrPEM, time.Minute)
+	expectedErr := errors.New("vault returned a private key for the certificate, the path of the issuer must be a sign endpoint unless its mode is Issue")
+	if !errorsEqual(expectedErr, err) {
+		t.Fatalf("unexpected error, exp=%v got=%v", expectedErr, err)
+	}
+}
+
+func TestSignWithResponseWrapping(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	bundleData, err := bundlePEM(testIntermediateCa)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrappedResponse := func(creationPath string) []byte {
+		data, err := jsonutil.EncodeJSON(&vault.Secret{
+			WrapInfo: &vault.SecretWrapInfo{
+				Token:        "wrapping-token",
+				TTL:          60,
+				CreationPath: creationPath,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := map[string]struct {
+		responses [][]byte
+
+		expectedErr  error
+		expectedCert string
+	}{
+		"a wrapped response should be unwrapped with the wrapping token": {
+			responses:    [][]byte{wrappedResponse("pki/sign/role"), bundleData},
+			expectedCert: testLeafCertificate + testIntermediateCa,
+		},
+		"a response which was not wrapped should error": {
+			responses:   [][]byte{bundleData},
+			expectedErr: errors.New("failed to sign certificate by vault: vault did not wrap the response"),
+		},
+		"a wrapped response created by another path should error": {
+			responses:   [][]byte{wrappedResponse("secret/data/other")},
+			expectedErr: errors.New(`failed to sign certificate by vault: wrapped response was created by unexpected path "secret/data/other"`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			client := vaultfake.NewFakeClient()
+			client.RawRequestFn = func(r *vault.Request) (*vault.Response, error) {
+				if calls >= len(test.responses) {
+					t.Fatalf("unexpected request %d", calls)
+				}
+				switch calls {
+				case 0:
+					if r.WrapTTL != "1m0s" {
+						t.Errorf("unexpected wrap ttl, exp=%q got=%q", "1m0s", r.WrapTTL)
+					}
+				case 1:
+					if r.ClientToken != "wrapping-token" {
+						t.Errorf("expected unwrap request to use the wrapping token, got=%q", r.ClientToken)
+					}
+				}
+				resp := &vault.Response{Response: &http.Response{Body: io.NopCloser(bytes.NewReader(test.responses[calls]))}}
+				calls++
+				return resp, nil
+			}
+
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer: gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{
+					Path:            "pki/sign/role",
+					ResponseWrapTTL: &metav1.Duration{Duration: time.Minute},
+				})),
+				client: client,
+			}
+
+			cert, _, err := v.Sign(csrPEM, time.Minute)
+			if !errorsEqual(test.expectedErr, err) {
+				t.Fatalf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+			if test.expectedCert != string(cert) {
+				t.Errorf("unexpected certificate, exp=%q got=%q", test.expectedCert, cert)
+			}
+		})
+	}
+}
+
+func errorsEqual(exp, got error) bool {
+	if exp == nil || got == nil {
+		return exp == got
+	}
+	return exp.Error() == got.Error()
+}
+
 type testExtractCertificatesFromVaultCertT struct {
 	secret       *certutil.Secret
 	expectedCert string
@@ -927,6 +1128,27 @@ func TestNewConfig(t *testing.T) {
 				return nil
 			},
 		},
+
+		"the issuer's TLS configuration should be applied": {
+			issuer: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{Vault: &cmapi.VaultIssuer{}},
+					TLS: &cmapi.IssuerTLSConfig{
+						MinVersion:   "VersionTLS13",
+						CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+					},
+				},
+			},
+			expectedErr: nil,
+			checkFunc: func(cfg *vault.Config) error {
+				tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+				if tlsConfig.MinVersion != tls.VersionTLS13 || len(tlsConfig.CipherSuites) != 1 {
+					return fmt.Errorf("got unexpected TLS configuration, min version=%x cipher suites=%v",
+						tlsConfig.MinVersion, tlsConfig.CipherSuites)
+				}
+				return nil
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -1081,3 +1303,39 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginError(t *testing.T) {
+	tests := map[string]struct {
+		err            error
+		expectedReason string
+	}{
+		"a request that could not be sent has no credentials reason": {
+			err:            errors.New("request failed"),
+			expectedReason: "",
+		},
+		"a forbidden response means the login is not permitted": {
+			err:            &vault.ResponseError{StatusCode: http.StatusForbidden, Errors: []string{"permission denied"}},
+			expectedReason: cmapi.IssuerReasonPermissionDenied,
+		},
+		"a bad request response means the credentials are invalid": {
+			err:            &vault.ResponseError{StatusCode: http.StatusBadRequest, Errors: []string{"invalid role or secret ID"}},
+			expectedReason: cmapi.IssuerReasonInvalidCredentials,
+		},
+		"a server error has no credentials reason": {
+			err:            &vault.ResponseError{StatusCode: http.StatusInternalServerError},
+			expectedReason: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := loginError("error logging in to Vault server", test.err)
+			if !strings.HasPrefix(err.Error(), "error logging in to Vault server: ") {
+				t.Errorf("unexpected error message %q", err.Error())
+			}
+			if reason := cmerrors.CredentialsErrorReason(err); reason != test.expectedReason {
+				t.Errorf("expected reason %q, got %q", test.expectedReason, reason)
+			}
+		})
+	}
+}
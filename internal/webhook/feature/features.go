@@ -43,6 +43,13 @@ const (
 	// This feature gate must be used together with LiteralCertificateSubject webhook feature gate.
 	// See https://github.com/cert-manager/cert-manager/issues/3203 and https://github.com/cert-manager/cert-manager/issues/4424 for context.
 	LiteralCertificateSubject featuregate.Feature = "LiteralCertificateSubject"
+
+	// Alpha: v1.9
+	//
+	// AdditionalTrustedCAs enables the `additionalTrustedCAs` field on
+	// Certificates.
+	// This feature gate must be used together with the AdditionalTrustedCAs controller feature gate.
+	AdditionalTrustedCAs featuregate.Feature = "AdditionalTrustedCAs"
 )
 
 func init() {
@@ -59,4 +66,5 @@ func init() {
 var webhookFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AdditionalCertificateOutputFormats: {Default: false, PreRelease: featuregate.Alpha},
 	LiteralCertificateSubject:          {Default: false, PreRelease: featuregate.Alpha},
+	AdditionalTrustedCAs:               {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -130,9 +130,10 @@ func buildCertificateSource(log logr.Logger, tlsConfig config.TLSConfig, restCfg
 		return &tls.DynamicSource{
 			DNSNames: tlsConfig.Dynamic.DNSNames,
 			Authority: &authority.DynamicAuthority{
-				SecretNamespace: tlsConfig.Dynamic.SecretNamespace,
-				SecretName:      tlsConfig.Dynamic.SecretName,
-				RESTConfig:      restCfg,
+				SecretNamespace:  tlsConfig.Dynamic.SecretNamespace,
+				SecretName:       tlsConfig.Dynamic.SecretName,
+				PropagationDelay: tlsConfig.Dynamic.CAPropagationDelay.Duration,
+				RESTConfig:       restCfg,
 			},
 		}
 	default:
@@ -0,0 +1,85 @@
+#!/usr/bin/env bash
+
+# Copyright 2022 The cert-manager Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# This script starts the DNS provider emulators used by the tests in
+# test/integration/*_dns01, runs the tests against them and removes the
+# emulators again. The Azure DNS tests use an in-process mock and run without
+# any emulator.
+#
+# Usage: dns01-provider-test.sh <go> <ctr>
+
+go=$1
+ctr=$2
+
+localstack_image=${LOCALSTACK_IMAGE:-docker.io/localstack/localstack:1.2.0}
+powerdns_image=${POWERDNS_IMAGE:-docker.io/powerdns/pdns-auth-47:4.7.2}
+localstack_port=${LOCALSTACK_PORT:-4566}
+powerdns_port=${POWERDNS_PORT:-5353}
+
+zone=example.com
+tsig_key_name=cert-manager
+tsig_secret=IwBTJx9wrDp4Y1RyC3H0gA==
+
+localstack=cert-manager-dns01-localstack
+powerdns=cert-manager-dns01-powerdns
+
+cleanup() {
+	$ctr rm --force "$localstack" "$powerdns" >/dev/null 2>&1 || true
+}
+trap cleanup EXIT
+cleanup
+
+$ctr run --detach --name "$localstack" \
+	--publish "127.0.0.1:${localstack_port}:4566" \
+	--env SERVICES=route53 \
+	"$localstack_image" >/dev/null
+
+$ctr run --detach --name "$powerdns" \
+	--publish "127.0.0.1:${powerdns_port}:53/udp" \
+	--publish "127.0.0.1:${powerdns_port}:53/tcp" \
+	"$powerdns_image" \
+	--dnsupdate=yes --allow-dnsupdate-from=0.0.0.0/0 >/dev/null
+
+echo "Waiting for localstack to become ready"
+for _ in $(seq 60); do
+	if curl --silent --fail "http://127.0.0.1:${localstack_port}/_localstack/health" | grep --quiet '"route53": "\(available\|running\)"'; then
+		break
+	fi
+	sleep 2
+done
+
+echo "Configuring the ${zone} zone in PowerDNS"
+for _ in $(seq 30); do
+	if $ctr exec "$powerdns" pdnsutil list-all-zones >/dev/null 2>&1; then
+		break
+	fi
+	sleep 1
+done
+$ctr exec "$powerdns" pdnsutil create-zone "$zone" "ns1.${zone}"
+$ctr exec "$powerdns" pdnsutil import-tsig-key "$tsig_key_name" hmac-md5 "$tsig_secret"
+$ctr exec "$powerdns" pdnsutil set-meta "$zone" TSIG-ALLOW-DNSUPDATE "$tsig_key_name"
+$ctr exec "$powerdns" pdnsutil set-meta "$zone" ALLOW-DNSUPDATE-FROM 0.0.0.0/0 ::/0
+
+ROUTE53_ENDPOINT="http://127.0.0.1:${localstack_port}" \
+PDNS_NAMESERVER="127.0.0.1:${powerdns_port}" \
+PDNS_ZONE="${zone}." \
+PDNS_TSIG_KEY_NAME="$tsig_key_name" \
+PDNS_TSIG_SECRET="$tsig_secret" \
+	$go test -count=1 ./test/integration/azuredns_dns01/... ./test/integration/powerdns_dns01/... ./test/integration/route53_dns01/... ./test/integration/rfc2136_dns01/...
@@ -137,6 +137,18 @@ Details:
   Note that if you use GINKGO_FOCUS or --ginkgo.focus, Ginkgo's parallelism will
   be turned off in order to see the logs streamed (instead of waiting until test
   ends before being able to see the logs).
+
+${bold}RUNNING THE ISSUER CONFORMANCE SUITE AGAINST YOUR OWN ISSUER${end}
+
+  The issuer conformance suite runs the YAML test cases found in
+  test/e2e/suite/conformance/issuers/cases against an existing issuer:
+
+    ${bold}$(basename "$0") --ginkgo.focus '\[Conformance\] Issuers with issuer type Configured' \\
+      --suite.conformance-issuer-name=my-issuer \\
+      --suite.conformance-domain-suffix=example.com \\
+      --suite.conformance-unsupported-features=IPAddresses,URISANs${end}
+
+  Use --suite.conformance-cases-dir to run your own test cases instead.
 EOF
   exit 0
 }
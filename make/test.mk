@@ -57,6 +57,16 @@ setup-integration-tests: test/integration/versionchecker/testdata/test_manifests
 integration-test: setup-integration-tests | $(NEEDS_GOTESTSUM) $(NEEDS_ETCD) $(NEEDS_KUBECTL) $(NEEDS_KUBE-APISERVER) $(NEEDS_GO)
 	$(GOTESTSUM) ./test/...
 
+.PHONY: dns01-provider-test
+## Run the DNS01 provider integration tests against local emulators of the
+## providers' APIs: localstack for Route 53 and a PowerDNS server for RFC2136.
+## Requires a container runtime; the emulators are removed once the tests
+## have completed.
+##
+## @category Development
+dns01-provider-test: | $(NEEDS_GO)
+	make/dns01-provider-test.sh $(GO) $(CTR)
+
 .PHONY: e2e
 ## Run the end-to-end tests. Before running this, you need to run:
 ##
@@ -19,17 +19,20 @@ package accounts
 import (
 	"crypto/rsa"
 	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"time"
 
-	acmeapi "golang.org/x/crypto/acme"
-
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	"github.com/cert-manager/cert-manager/pkg/acme/client/middleware"
 	acmeutil "github.com/cert-manager/cert-manager/pkg/acme/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/tlsconfig"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 const (
@@ -37,6 +40,9 @@ const (
 	// Note that there may be other timeouts - e.g. dial timeouts or TLS handshake timeouts - which will be smaller than this. This
 	// timeout is the overall timeout for the entire request.
 	defaultACMEHTTPTimeout = time.Second * 90
+
+	// defaultACMEHTTPKeepAlive sets the default interval between keep-alive probes of connections to the ACME server.
+	defaultACMEHTTPKeepAlive = time.Second * 30
 )
 
 // NewClientFunc is a function type for building a new ACME client.
@@ -46,37 +52,136 @@ var _ NewClientFunc = NewClient
 
 // NewClient is an implementation of NewClientFunc that returns a real ACME client.
 func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string) acmecl.Interface {
-	return middleware.NewLogger(&acmeapi.Client{
+	return newClient(client, config, privateKey, "", userAgent)
+}
+
+// newClient returns a real ACME client. If accountURL is not empty, it is
+// used as the key ID of requests signed by the client instead of looking up
+// the account registered for privateKey with the ACME server.
+func newClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, accountURL string, userAgent string) acmecl.Interface {
+	return middleware.NewLogger(acmecl.NewClient(&acmeapi.Client{
 		Key:          privateKey,
+		KID:          acmeapi.KeyID(accountURL),
 		HTTPClient:   client,
 		DirectoryURL: config.Server,
 		UserAgent:    userAgent,
 		RetryBackoff: acmeutil.RetryBackoff,
-	})
+	}))
+}
+
+// PrivateKeyHash returns a hash identifying the given ACME account private
+// key, which is the JWK thumbprint of its public key as defined in RFC 7638.
+func PrivateKeyHash(privateKey *rsa.PrivateKey) string {
+	// Computing the thumbprint of an RSA key cannot fail
+	hash, _ := acmeapi.JWKThumbprint(privateKey.Public())
+	return hash
+}
+
+// HTTPClientOptions configures the HTTP client used to communicate with an
+// ACME server. They are set from the controller's flags and may be
+// overridden per issuer by the issuer's httpClient field.
+type HTTPClientOptions struct {
+	// Timeout is the maximum duration of a single request. A zero Timeout
+	// uses the default of 90 seconds.
+	Timeout time.Duration
+
+	// KeepAlive is the interval between keep-alive probes of connections.
+	// A zero KeepAlive uses the default of 30 seconds, and a negative one
+	// disables keep-alive probes.
+	KeepAlive time.Duration
+
+	// ProxyURL is the URL of the proxy through which requests are sent. If
+	// empty, the proxy is taken from the environment.
+	ProxyURL string
+
+	// TLS configures the minimum version and cipher suites of connections.
+	// It is only set per issuer, from the issuer's tls field.
+	TLS *cmapi.IssuerTLSConfig
+}
+
+// ForIssuer returns the options to use for the given ACME issuer, which are
+// the options set in its httpClient field and otherwise those of o.
+func (o HTTPClientOptions) ForIssuer(spec *cmacme.ACMEIssuer) HTTPClientOptions {
+	if spec == nil || spec.HTTPClient == nil {
+		return o
+	}
+	if spec.HTTPClient.Timeout != nil {
+		o.Timeout = spec.HTTPClient.Timeout.Duration
+	}
+	if spec.HTTPClient.KeepAlive != nil {
+		o.KeepAlive = spec.HTTPClient.KeepAlive.Duration
+	}
+	if spec.HTTPClient.ProxyURL != "" {
+		o.ProxyURL = spec.HTTPClient.ProxyURL
+	}
+	return o
+}
+
+// WithTLS returns the options with the TLS configuration of an issuer.
+func (o HTTPClientOptions) WithTLS(issuerTLS *cmapi.IssuerTLSConfig) HTTPClientOptions {
+	o.TLS = issuerTLS
+	return o
 }
 
 // BuildHTTPClient returns a instrumented HTTP client to be used by the ACME
 // client.
 // For the time being, we construct a new HTTP client on each invocation.
-// This is because we need to set the 'skipTLSVerify' flag on the HTTP client
-// itself.
+// This is because we need to set the 'skipTLSVerify' flag and the issuer's
+// HTTP client options on the HTTP client itself.
 // In future, we may change to having two global HTTP clients - one that ignores
 // TLS connection errors, and the other that does not.
-func BuildHTTPClient(metrics *metrics.Metrics, skipTLSVerify bool) *http.Client {
+func BuildHTTPClient(metrics *metrics.Metrics, skipTLSVerify bool, opts HTTPClientOptions) *http.Client {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultACMEHTTPTimeout
+	}
+	keepAlive := opts.KeepAlive
+	if keepAlive == 0 {
+		keepAlive = defaultACMEHTTPKeepAlive
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: skipTLSVerify}
+	tlsErr := tlsconfig.Apply(tlsConfig, opts.TLS)
+
+	transport := &http.Transport{
+		Proxy: proxyFunc(opts.ProxyURL),
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: keepAlive,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if tlsErr != nil {
+		// An invalid TLS configuration fails every request, rather than
+		// silently connecting with the default configuration.
+		transport.Proxy = func(*http.Request) (*url.URL, error) {
+			return nil, fmt.Errorf("invalid ACME TLS configuration: %w", tlsErr)
+		}
+	}
+
 	return acmecl.NewInstrumentedClient(metrics,
 		&http.Client{
-			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-				DialContext: (&net.Dialer{
-					Timeout:   30 * time.Second,
-					KeepAlive: 30 * time.Second,
-				}).DialContext,
-				TLSClientConfig:       &tls.Config{InsecureSkipVerify: skipTLSVerify},
-				MaxIdleConns:          100,
-				IdleConnTimeout:       90 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				ExpectContinueTimeout: 1 * time.Second,
-			},
-			Timeout: defaultACMEHTTPTimeout,
+			Transport: transport,
+			Timeout:   timeout,
 		})
 }
+
+// proxyFunc returns the Proxy function of an http.Transport which sends
+// requests through the given proxy, or the proxy configured in the
+// environment if proxyURL is empty. An invalid proxy URL fails every request,
+// rather than silently connecting without the proxy.
+func proxyFunc(proxyURL string) func(*http.Request) (*url.URL, error) {
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return func(*http.Request) (*url.URL, error) {
+			return nil, fmt.Errorf("invalid ACME HTTP proxy URL %q: %w", proxyURL, err)
+		}
+	}
+	return http.ProxyURL(u)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestHTTPClientOptionsForIssuer(t *testing.T) {
+	defaults := HTTPClientOptions{
+		Timeout:   time.Minute,
+		KeepAlive: time.Second,
+		ProxyURL:  "http://default.example.com",
+	}
+
+	tests := map[string]struct {
+		spec *cmacme.ACMEIssuer
+		want HTTPClientOptions
+	}{
+		"no httpClient uses the defaults": {
+			spec: &cmacme.ACMEIssuer{},
+			want: defaults,
+		},
+		"empty httpClient uses the defaults": {
+			spec: &cmacme.ACMEIssuer{HTTPClient: &cmacme.ACMEHTTPClient{}},
+			want: defaults,
+		},
+		"httpClient overrides the defaults": {
+			spec: &cmacme.ACMEIssuer{HTTPClient: &cmacme.ACMEHTTPClient{
+				Timeout:   &metav1.Duration{Duration: time.Hour},
+				KeepAlive: &metav1.Duration{Duration: -1},
+				ProxyURL:  "socks5://issuer.example.com:1080",
+			}},
+			want: HTTPClientOptions{
+				Timeout:   time.Hour,
+				KeepAlive: -1,
+				ProxyURL:  "socks5://issuer.example.com:1080",
+			},
+		},
+		"httpClient overrides only the fields that are set": {
+			spec: &cmacme.ACMEIssuer{HTTPClient: &cmacme.ACMEHTTPClient{
+				Timeout: &metav1.Duration{Duration: time.Hour},
+			}},
+			want: HTTPClientOptions{
+				Timeout:   time.Hour,
+				KeepAlive: time.Second,
+				ProxyURL:  "http://default.example.com",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := defaults.ForIssuer(test.spec); got != test.want {
+				t.Errorf("unexpected options, exp=%+v got=%+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPClient(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://acme.example.com/directory", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := BuildHTTPClient(nil, false, HTTPClientOptions{ProxyURL: "http://proxy.example.com:3128"})
+	if cl.Timeout != defaultACMEHTTPTimeout {
+		t.Errorf("unexpected timeout, exp=%s got=%s", defaultACMEHTTPTimeout, cl.Timeout)
+	}
+
+	proxy, err := proxyFunc("http://proxy.example.com:3128")(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if proxy.String() != "http://proxy.example.com:3128" {
+		t.Errorf("unexpected proxy, exp=http://proxy.example.com:3128 got=%s", proxy)
+	}
+
+	if _, err := proxyFunc("http://[::1")(req); err == nil {
+		t.Errorf("expected an error for an invalid proxy URL")
+	}
+}
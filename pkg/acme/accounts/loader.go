@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+)
+
+// LoadClientFunc is a function type for loading the ACME client of an
+// Issuer into a registry.
+type LoadClientFunc func(ctx context.Context, issuer cmapi.GenericIssuer) error
+
+// ClientLoader registers ACME clients for Issuers whose ACME account has
+// already been registered by the issuers controllers.
+// The registry is populated by the issuers controllers when they set up an
+// Issuer. When the issuers controllers are not running in the same process,
+// for example when the orders and challenges controllers are deployed on
+// their own using --controllers, the ClientLoader is used to build the
+// client from the Issuer's status and account private key instead.
+type ClientLoader struct {
+	registry                 Registry
+	secretLister             corelisters.SecretLister
+	clusterResourceNamespace string
+	metrics                  *metrics.Metrics
+	httpClientOptions        HTTPClientOptions
+	userAgent                string
+
+	lock sync.Mutex
+	// a map of an issuer's 'uid' to the client it was loaded for
+	loaded map[string]loadedClient
+}
+
+// loadedFrom contains the state of an Issuer and its account private key
+// that a client was loaded from. The client is rebuilt if any of it changes,
+// for example because the private key was rotated or the Issuer was
+// registered with another ACME server.
+type loadedFrom struct {
+	generation         int64
+	accountURI         string
+	lastPrivateKeyHash string
+	privateKeyHash     string
+}
+
+// loadedClient records what a registered client was loaded from.
+type loadedClient struct {
+	loadedFrom
+
+	// secretResourceVersion is the resource version of the account private
+	// key Secret the private key was last read from. The private key is only
+	// parsed again once the Secret has changed.
+	secretResourceVersion string
+}
+
+// NewClientLoader returns a new ClientLoader which adds the clients it loads
+// to the given registry.
+func NewClientLoader(
+	registry Registry,
+	secretLister corelisters.SecretLister,
+	clusterResourceNamespace string,
+	metrics *metrics.Metrics,
+	httpClientOptions HTTPClientOptions,
+	userAgent string,
+) *ClientLoader {
+	return &ClientLoader{
+		registry:                 registry,
+		secretLister:             secretLister,
+		clusterResourceNamespace: clusterResourceNamespace,
+		metrics:                  metrics,
+		httpClientOptions:        httpClientOptions,
+		userAgent:                userAgent,
+		loaded:                   make(map[string]loadedClient),
+	}
+}
+
+// LoadClient ensures a client is registered for the given Issuer if it is a
+// Ready ACME Issuer with a registered account. Nothing is registered, and no
+// error returned, if the Issuer is not ready, since the issuers controllers
+// are then still setting up its account.
+// The client is rebuilt if the Issuer's spec, its account or its account
+// private key changed since it was last loaded. A registered client is kept
+// if the account private key cannot be read.
+func (l *ClientLoader) LoadClient(ctx context.Context, issuer cmapi.GenericIssuer) error {
+	spec := issuer.GetSpec().ACME
+	status := issuer.GetStatus().ACMEStatus()
+	if spec == nil || len(status.URI) == 0 || !apiutil.IssuerHasCondition(issuer, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return nil
+	}
+
+	ns := issuer.GetObjectMeta().Namespace
+	if ns == "" {
+		ns = l.clusterResourceNamespace
+	}
+
+	uid := string(issuer.GetUID())
+	log := logf.FromContext(ctx)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	previous, loaded := l.loaded[uid]
+	_, err := l.registry.GetClient(uid)
+	registered := err == nil
+
+	sel := acme.PrivateKeySelector(spec.PrivateKey)
+	secret, err := l.secretLister.Secrets(ns).Get(sel.Name)
+	if err != nil {
+		if registered {
+			log.V(logf.DebugLevel).Info("keeping the registered ACME client as its account private key could not be read", "error", err.Error())
+			return nil
+		}
+		return fmt.Errorf("failed to load ACME account private key: %w", err)
+	}
+
+	state := loadedFrom{
+		generation:         issuer.GetObjectMeta().Generation,
+		accountURI:         status.URI,
+		lastPrivateKeyHash: status.LastPrivateKeyHash,
+		privateKeyHash:     previous.privateKeyHash,
+	}
+	if registered && loaded && previous.secretResourceVersion == secret.ResourceVersion && previous.loadedFrom == state {
+		return nil
+	}
+
+	pk, _, err := kube.ParseTLSKeyFromSecret(secret, sel.Key)
+	if err != nil {
+		if registered {
+			log.V(logf.DebugLevel).Info("keeping the registered ACME client as its account private key is invalid", "error", err.Error())
+			return nil
+		}
+		return fmt.Errorf("failed to load ACME account private key: %w", err)
+	}
+	rsaPk, ok := pk.(*rsa.PrivateKey)
+	if !ok {
+		if registered {
+			log.V(logf.DebugLevel).Info("keeping the registered ACME client as its account private key is not an RSA key")
+			return nil
+		}
+		return fmt.Errorf("ACME account private key in Secret %s/%s is not an RSA key", ns, sel.Name)
+	}
+
+	state.privateKeyHash = PrivateKeyHash(rsaPk)
+	if registered && loaded && previous.loadedFrom == state {
+		// The Secret changed, but not the private key.
+		l.loaded[uid] = loadedClient{loadedFrom: state, secretResourceVersion: secret.ResourceVersion}
+		return nil
+	}
+
+	// The account URI can be used as the key ID of the client if it was
+	// registered for the current private key.
+	if status.LastPrivateKeyHash == PrivateKeyHash(rsaPk) {
+		l.registry.SetAccountURL(spec.Server, rsaPk, status.URI)
+	}
+
+	// The registry only replaces a client if its server, TLS verification,
+	// private key or account changed, so remove the client loaded from a
+	// previous state to pick up any other change, such as to the Issuer's
+	// HTTP client options.
+	if loaded && previous.loadedFrom != state {
+		l.registry.RemoveClient(uid)
+	}
+
+	log.V(logf.DebugLevel).Info("loading ACME client for issuer from its account private key")
+	l.registry.AddClient(BuildHTTPClient(l.metrics, spec.SkipTLSVerify, l.httpClientOptions.ForIssuer(spec).WithTLS(issuer.GetSpec().TLS)), uid, *spec, rsaPk, l.userAgent)
+	l.loaded[uid] = loadedClient{loadedFrom: state, secretResourceVersion: secret.ResourceVersion}
+
+	return nil
+}
+
+// IssuerDeleted removes the client of a deleted Issuer or ClusterIssuer from
+// the registry. It is registered as the DeleteFunc of the Issuer and
+// ClusterIssuer informers of the controllers using the ClientLoader.
+func (l *ClientLoader) IssuerDeleted(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	issuer, ok := obj.(cmapi.GenericIssuer)
+	if !ok {
+		return
+	}
+
+	uid := string(issuer.GetUID())
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.registry.RemoveClient(uid)
+	delete(l.loaded, uid)
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"context"
+	"crypto/rsa"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestClientLoader_LoadClient(t *testing.T) {
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyPEM, err := pki.EncodeECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keySecret := func(name string, key []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name},
+			Data:       map[string][]byte{corev1.TLSPrivateKeyKey: key},
+		}
+	}
+	ready := gen.AddIssuerCondition(cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+	issuer := func(mods ...gen.IssuerModifier) *cmapi.Issuer {
+		iss := gen.Issuer("test",
+			append([]gen.IssuerModifier{
+				gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerACMEURL("https://acme.example.com/directory"),
+				gen.SetIssuerACMEPrivKeyRef("account-key"),
+			}, mods...)...)
+		iss.UID = types.UID("test-uid")
+		return iss
+	}
+
+	tests := map[string]struct {
+		issuer        *cmapi.Issuer
+		secrets       []*corev1.Secret
+		expErr        bool
+		expRegistered bool
+		expAccountURL string
+	}{
+		"do nothing if the issuer is not an ACME issuer": {
+			issuer: gen.Issuer("test", gen.SetIssuerNamespace("testns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}), ready),
+		},
+		"do nothing if the issuer is not ready": {
+			issuer:  issuer(gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1")),
+			secrets: []*corev1.Secret{keySecret("account-key", pki.EncodePKCS1PrivateKey(rsaKey))},
+		},
+		"do nothing if the issuer has no registered account": {
+			issuer:  issuer(ready),
+			secrets: []*corev1.Secret{keySecret("account-key", pki.EncodePKCS1PrivateKey(rsaKey))},
+		},
+		"register a client for a ready issuer with a registered account": {
+			issuer:        issuer(ready, gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1")),
+			secrets:       []*corev1.Secret{keySecret("account-key", pki.EncodePKCS1PrivateKey(rsaKey))},
+			expRegistered: true,
+		},
+		"use the account URI as the key ID if it was registered for the current private key": {
+			issuer: issuer(ready,
+				gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1"),
+				gen.SetIssuerACMELastPrivateKeyHash(PrivateKeyHash(rsaKey))),
+			secrets:       []*corev1.Secret{keySecret("account-key", pki.EncodePKCS1PrivateKey(rsaKey))},
+			expRegistered: true,
+			expAccountURL: "https://acme.example.com/acct/1",
+		},
+		"look up the account if the account URI was registered for a different private key": {
+			issuer: issuer(ready,
+				gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1"),
+				gen.SetIssuerACMELastPrivateKeyHash("other")),
+			secrets:       []*corev1.Secret{keySecret("account-key", pki.EncodePKCS1PrivateKey(rsaKey))},
+			expRegistered: true,
+		},
+		"return an error if the account private key does not exist": {
+			issuer: issuer(ready, gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1")),
+			expErr: true,
+		},
+		"return an error if the account private key is not an RSA key": {
+			issuer:  issuer(ready, gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1")),
+			secrets: []*corev1.Secret{keySecret("account-key", ecKeyPEM)},
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, s := range test.secrets {
+				if err := indexer.Add(s); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r := NewDefaultRegistry()
+			l := NewClientLoader(r, corelisters.NewSecretLister(indexer), "kube-system", metrics.New(logf.Log, clock.RealClock{}), HTTPClientOptions{}, "cert-manager-test")
+
+			err := l.LoadClient(context.Background(), test.issuer)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+
+			_, err = r.GetClient(string(test.issuer.UID))
+			if registered := err == nil; registered != test.expRegistered {
+				t.Errorf("unexpected client registration, exp=%t got=%t", test.expRegistered, registered)
+			}
+			if got := r.(*registry).clients[string(test.issuer.UID)].accountURL; got != test.expAccountURL {
+				t.Errorf("unexpected account URL, exp=%q got=%q", test.expAccountURL, got)
+			}
+		})
+	}
+}
+
+func TestClientLoader_LoadClientRebuildsClient(t *testing.T) {
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotatedKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keySecret := func(resourceVersion string, key *rsa.PrivateKey) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "account-key", ResourceVersion: resourceVersion},
+			Data:       map[string][]byte{corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(key)},
+		}
+	}
+	issuer := func(accountURL string, key *rsa.PrivateKey) *cmapi.Issuer {
+		iss := gen.Issuer("test",
+			gen.SetIssuerNamespace("testns"),
+			gen.SetIssuerACMEURL("https://acme.example.com/directory"),
+			gen.SetIssuerACMEPrivKeyRef("account-key"),
+			gen.SetIssuerACMEAccountURL(accountURL),
+			gen.SetIssuerACMELastPrivateKeyHash(PrivateKeyHash(key)),
+			gen.AddIssuerCondition(cmapi.IssuerCondition{
+				Type:   cmapi.IssuerConditionReady,
+				Status: cmmeta.ConditionTrue,
+			}))
+		iss.UID = types.UID("test-uid")
+		return iss
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	r := NewDefaultRegistry()
+	l := NewClientLoader(r, corelisters.NewSecretLister(indexer), "kube-system", metrics.New(logf.Log, clock.RealClock{}), HTTPClientOptions{}, "cert-manager-test")
+
+	load := func(iss *cmapi.Issuer, secret *corev1.Secret) clientWithMeta {
+		t.Helper()
+		if err := indexer.Update(secret); err != nil {
+			t.Fatal(err)
+		}
+		if err := l.LoadClient(context.Background(), iss); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cl, ok := r.(*registry).clients[string(iss.UID)]
+		if !ok {
+			t.Fatal("expected a client to be registered")
+		}
+		return cl
+	}
+
+	initial := load(issuer("https://acme.example.com/acct/1", rsaKey), keySecret("1", rsaKey))
+	if initial.accountURL != "https://acme.example.com/acct/1" {
+		t.Errorf("unexpected account URL, exp=%q got=%q", "https://acme.example.com/acct/1", initial.accountURL)
+	}
+
+	unchanged := load(issuer("https://acme.example.com/acct/1", rsaKey), keySecret("1", rsaKey))
+	if unchanged.Interface != initial.Interface {
+		t.Error("expected the client to be kept if nothing changed")
+	}
+
+	resynced := load(issuer("https://acme.example.com/acct/1", rsaKey), keySecret("2", rsaKey))
+	if resynced.Interface != initial.Interface {
+		t.Error("expected the client to be kept if the Secret changed but not the private key")
+	}
+
+	// The account private key Secret cannot be read.
+	if err := indexer.Delete(keySecret("2", rsaKey)); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.LoadClient(context.Background(), issuer("https://acme.example.com/acct/1", rsaKey)); err != nil {
+		t.Errorf("expected no error if a client is registered, got err=%v", err)
+	}
+	if kept := r.(*registry).clients["test-uid"]; kept.Interface != initial.Interface {
+		t.Error("expected the client to be kept if the Secret cannot be read")
+	}
+
+	// The private key is rotated and the issuers controller registers an
+	// account for it.
+	rotated := load(issuer("https://acme.example.com/acct/2", rotatedKey), keySecret("3", rotatedKey))
+	if rotated.Interface == initial.Interface {
+		t.Error("expected the client to be rebuilt after the private key was rotated")
+	}
+	if rotated.accountURL != "https://acme.example.com/acct/2" {
+		t.Errorf("unexpected account URL, exp=%q got=%q", "https://acme.example.com/acct/2", rotated.accountURL)
+	}
+	if rotated.publicKey == initial.publicKey {
+		t.Error("expected the client to use the rotated private key")
+	}
+
+	l.IssuerDeleted(cache.DeletedFinalStateUnknown{Key: "testns/test", Obj: issuer("https://acme.example.com/acct/2", rotatedKey)})
+	if _, err := r.GetClient("test-uid"); err != ErrNotFound {
+		t.Errorf("expected the client of the deleted issuer to be removed, got err=%v", err)
+	}
+}
@@ -41,6 +41,13 @@ type Registry interface {
 	// resource that constructed it.
 	RemoveClient(uid string)
 
+	// SetAccountURL records the URL of the account registered with the ACME
+	// server at serverURL for the given private key. Clients subsequently
+	// added for the same server and private key use it as their key ID,
+	// rather than looking up the account with the ACME server on first use.
+	// An empty accountURL forgets the recorded URL.
+	SetAccountURL(serverURL string, privateKey *rsa.PrivateKey, accountURL string)
+
 	Getter
 }
 
@@ -61,7 +68,8 @@ type Getter interface {
 // NewDefaultRegistry returns a new default instantiation of a client registry.
 func NewDefaultRegistry() Registry {
 	return &registry{
-		clients: make(map[string]clientWithMeta),
+		clients:     make(map[string]clientWithMeta),
+		accountURLs: make(map[accountKey]string),
 	}
 }
 
@@ -71,6 +79,17 @@ type registry struct {
 
 	// a map of an issuer's 'uid' to an ACME client with metadata
 	clients map[string]clientWithMeta
+
+	// a map of an ACME server URL and account private key to the URL of
+	// the account registered with that key
+	accountURLs map[accountKey]string
+}
+
+// accountKey identifies the account registered with an ACME server for a
+// private key.
+type accountKey struct {
+	serverURL      string
+	privateKeyHash string
 }
 
 // stableOptions contains data about an ACME client that can be used to compare
@@ -82,13 +101,14 @@ type stableOptions struct {
 	issuerUID     string
 	publicKey     string
 	exponent      int
+	accountURL    string
 }
 
 func (c stableOptions) equalTo(c2 stableOptions) bool {
 	return c == c2
 }
 
-func newStableOptions(uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) stableOptions {
+func newStableOptions(uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, accountURL string) stableOptions {
 	// Encoding a big.Int cannot fail
 	publicNBytes, _ := privateKey.PublicKey.N.GobEncode()
 	return stableOptions{
@@ -97,6 +117,7 @@ func newStableOptions(uid string, config cmacme.ACMEIssuer, privateKey *rsa.Priv
 		issuerUID:     uid,
 		publicKey:     string(publicNBytes),
 		exponent:      privateKey.PublicKey.E,
+		accountURL:    accountURL,
 	}
 }
 
@@ -126,7 +147,8 @@ func (r *registry) ensureClient(client *http.Client, uid string, config cmacme.A
 	// which could itself cause a race
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	newOpts := newStableOptions(uid, config, privateKey)
+	accountURL := r.accountURLs[accountKey{serverURL: config.Server, privateKeyHash: PrivateKeyHash(privateKey)}]
+	newOpts := newStableOptions(uid, config, privateKey, accountURL)
 	// fast-path if there is nothing to do
 	if meta, ok := r.clients[uid]; ok && meta.equalTo(newOpts) {
 		return
@@ -134,11 +156,24 @@ func (r *registry) ensureClient(client *http.Client, uid string, config cmacme.A
 	// create a new client if one is not registered or if the
 	// 'metadata' does not match
 	r.clients[uid] = clientWithMeta{
-		Interface:     NewClient(client, config, privateKey, userAgent),
+		Interface:     newClient(client, config, privateKey, accountURL, userAgent),
 		stableOptions: newOpts,
 	}
 }
 
+// SetAccountURL records the URL of the account registered with the ACME
+// server at serverURL for the given private key.
+func (r *registry) SetAccountURL(serverURL string, privateKey *rsa.PrivateKey, accountURL string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := accountKey{serverURL: serverURL, privateKeyHash: PrivateKeyHash(privateKey)}
+	if accountURL == "" {
+		delete(r.accountURLs, key)
+		return
+	}
+	r.accountURLs[key] = accountURL
+}
+
 // GetClient will fetch a registered client using the UID of the Issuer
 // resources that constructed it.
 // If no client is found, ErrNotFound will be returned.
@@ -145,3 +145,41 @@ func TestRegistry_AddClient_UpdatesExistingWhenPrivateKeyChanges(t *testing.T) {
 		t.Errorf("expected ListClients to have 1 item but it has %d", len(l))
 	}
 }
+
+func TestRegistry_SetAccountURL(t *testing.T) {
+	r := NewDefaultRegistry().(*registry)
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk2, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.SetAccountURL("https://acme.example.com", pk, "https://acme.example.com/acct/1")
+
+	// Clients for the same server and private key use the account URL
+	r.AddClient(http.DefaultClient, "abc", cmacme.ACMEIssuer{Server: "https://acme.example.com"}, pk, "cert-manager-test")
+	if got := r.clients["abc"].accountURL; got != "https://acme.example.com/acct/1" {
+		t.Errorf("expected client to use the recorded account URL but got %q", got)
+	}
+
+	// Clients for a different private key or server do not
+	r.AddClient(http.DefaultClient, "def", cmacme.ACMEIssuer{Server: "https://acme.example.com"}, pk2, "cert-manager-test")
+	if got := r.clients["def"].accountURL; got != "" {
+		t.Errorf("expected client for a different private key to look up its account but got %q", got)
+	}
+	r.AddClient(http.DefaultClient, "ghi", cmacme.ACMEIssuer{Server: "https://other.example.com"}, pk, "cert-manager-test")
+	if got := r.clients["ghi"].accountURL; got != "" {
+		t.Errorf("expected client for a different server to look up its account but got %q", got)
+	}
+
+	// Forgetting the account URL replaces the client with one which looks
+	// up the account
+	r.SetAccountURL("https://acme.example.com", pk, "")
+	r.AddClient(http.DefaultClient, "abc", cmacme.ACMEIssuer{Server: "https://acme.example.com"}, pk, "cert-manager-test")
+	if got := r.clients["abc"].accountURL; got != "" {
+		t.Errorf("expected client to look up its account after the account URL was forgotten but got %q", got)
+	}
+}
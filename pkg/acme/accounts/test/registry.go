@@ -30,10 +30,11 @@ var _ accounts.Registry = &FakeRegistry{}
 
 // FakeRegistry implements the accounts.Registry interface using stub functions
 type FakeRegistry struct {
-	AddClientFunc    func(uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string)
-	RemoveClientFunc func(uid string)
-	GetClientFunc    func(uid string) (acmecl.Interface, error)
-	ListClientsFunc  func() map[string]acmecl.Interface
+	AddClientFunc     func(uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string)
+	RemoveClientFunc  func(uid string)
+	SetAccountURLFunc func(serverURL string, privateKey *rsa.PrivateKey, accountURL string)
+	GetClientFunc     func(uid string) (acmecl.Interface, error)
+	ListClientsFunc   func() map[string]acmecl.Interface
 }
 
 func (f *FakeRegistry) AddClient(client *http.Client, uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string) {
@@ -44,6 +45,12 @@ func (f *FakeRegistry) RemoveClient(uid string) {
 	f.RemoveClientFunc(uid)
 }
 
+func (f *FakeRegistry) SetAccountURL(serverURL string, privateKey *rsa.PrivateKey, accountURL string) {
+	if f.SetAccountURLFunc != nil {
+		f.SetAccountURLFunc(serverURL, privateKey, accountURL)
+	}
+}
+
 func (f *FakeRegistry) GetClient(uid string) (acmecl.Interface, error) {
 	return f.GetClientFunc(uid)
 }
@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+// ErrPreAuthorizationNotSupported is returned by Authorize if the ACME server
+// does not advertise a newAuthz endpoint in its directory.
+var ErrPreAuthorizationNotSupported = errors.New("acme: the ACME server does not support pre-authorization")
+
+// Authorize requests a new authorization for the given DNS name using the
+// ACME server's newAuthz endpoint, as described in RFC 8555 section 7.4.1.
+// Unlike acme.Client.Authorize it fails with ErrPreAuthorizationNotSupported
+// rather than posting to an empty URL if the endpoint is not advertised.
+func (c *Client) Authorize(ctx context.Context, domain string) (*acme.Authorization, error) {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dir.AuthzURL == "" {
+		return nil, ErrPreAuthorizationNotSupported
+	}
+
+	return c.Client.Authorize(ctx, domain)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+func TestAuthorize(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		noNewAuthz bool
+		expErr     error
+	}{
+		"authorization is requested": {},
+		"ACME server without a newAuthz endpoint": {
+			noNewAuthz: true,
+			expErr:     ErrPreAuthorizationNotSupported,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/directory":
+					newAuthz := server.URL + "/new-authz"
+					if test.noNewAuthz {
+						newAuthz = ""
+					}
+					fmt.Fprintf(w, `{"newNonce": %q, "newOrder": %q, "newAuthz": %q}`, server.URL+"/new-nonce", server.URL+"/new-order", newAuthz)
+				case "/new-nonce":
+					w.Header().Set("Replay-Nonce", "nonce")
+				case "/new-authz":
+					var outer struct {
+						Payload string `json:"payload"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&outer); err != nil {
+						t.Errorf("failed to decode request: %v", err)
+					}
+					var payload struct {
+						Identifier struct {
+							Type, Value string
+						}
+					}
+					decodeSegment(t, outer.Payload, &payload)
+					if payload.Identifier.Type != "dns" || payload.Identifier.Value != "example.com" {
+						t.Errorf("unexpected identifier: %+v", payload.Identifier)
+					}
+
+					w.Header().Set("Location", server.URL+"/authz/1")
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"status": "pending", "identifier": {"type": "dns", "value": "example.com"}}`)
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			cl := NewClient(&acme.Client{
+				Key:          key,
+				HTTPClient:   server.Client(),
+				DirectoryURL: server.URL + "/directory",
+				KID:          "https://acme.example.com/acct/1",
+			})
+
+			authz, err := cl.Authorize(context.Background(), "example.com")
+			if err != test.expErr {
+				t.Fatalf("unexpected error, exp=%v got=%v", test.expErr, err)
+			}
+			if test.expErr != nil {
+				return
+			}
+			if authz.URI != server.URL+"/authz/1" || authz.Status != acme.StatusPending {
+				t.Errorf("unexpected authorization: %+v", authz)
+			}
+		})
+	}
+}
+
+func decodeSegment(t *testing.T, segment string, v interface{}) {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatal(err)
+	}
+}
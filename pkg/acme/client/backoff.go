@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+const (
+	// RateLimitedProblemType is the type of the problem document returned by
+	// ACME servers when a rate limit has been exceeded.
+	RateLimitedProblemType = "urn:ietf:params:acme:error:rateLimited"
+
+	// DefaultRateLimitBackoff is how long to back off for when an ACME server
+	// reports that a rate limit has been exceeded without sending a
+	// Retry-After header.
+	DefaultRateLimitBackoff = 5 * time.Minute
+)
+
+// Backoff describes when a request rejected by an ACME server may be retried.
+type Backoff struct {
+	// RetryAfter is the earliest time at which the request may be retried.
+	RetryAfter time.Time
+
+	// RateLimited is true if the request was rejected because a rate limit
+	// was exceeded.
+	RateLimited bool
+}
+
+// IsRateLimited returns true if err is an ACME error reporting that a rate
+// limit has been exceeded, either with a 429 response or a rateLimited
+// problem document.
+func IsRateLimited(err error) bool {
+	var acmeErr *acme.Error
+	if !errors.As(err, &acmeErr) {
+		return false
+	}
+	return acmeErr.StatusCode == http.StatusTooManyRequests || acmeErr.ProblemType == RateLimitedProblemType
+}
+
+// BackoffForError returns how long to back off for before retrying a request
+// which failed with err, or nil if the ACME server did not ask to back off.
+// The server asks to back off either by reporting that a rate limit has been
+// exceeded, or by sending a Retry-After header with a 503 response.
+func BackoffForError(err error, now time.Time) *Backoff {
+	var acmeErr *acme.Error
+	if !errors.As(err, &acmeErr) {
+		return nil
+	}
+
+	retryAfter, hasRetryAfter := parseRetryAfter(acmeErr.Header.Get("Retry-After"), now)
+	switch {
+	case IsRateLimited(acmeErr):
+		if !hasRetryAfter {
+			retryAfter = now.Add(DefaultRateLimitBackoff)
+		}
+		return &Backoff{RetryAfter: retryAfter, RateLimited: true}
+	case acmeErr.StatusCode == http.StatusServiceUnavailable && hasRetryAfter:
+		return &Backoff{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date. Times in the past are returned as now.
+func parseRetryAfter(v string, now time.Time) (time.Time, bool) {
+	if v == "" {
+		return time.Time{}, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return now.Add(time.Duration(secs) * time.Second), true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if t.Before(now) {
+		return now, true
+	}
+	return t, true
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+func TestBackoffForError(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	header := func(retryAfter string) http.Header {
+		return http.Header{"Retry-After": []string{retryAfter}}
+	}
+
+	tests := map[string]struct {
+		err error
+		exp *Backoff
+	}{
+		"not an ACME error": {
+			err: errors.New("connection refused"),
+		},
+		"ACME error which does not ask to back off": {
+			err: &acme.Error{StatusCode: http.StatusForbidden, Header: header("60")},
+		},
+		"429 with Retry-After in seconds": {
+			err: &acme.Error{StatusCode: http.StatusTooManyRequests, Header: header("120")},
+			exp: &Backoff{RetryAfter: now.Add(2 * time.Minute), RateLimited: true},
+		},
+		"wrapped 429 with Retry-After as an HTTP date": {
+			err: fmt.Errorf("error creating order: %w", &acme.Error{StatusCode: http.StatusTooManyRequests, Header: header("Wed, 01 Jun 2022 11:00:00 GMT")}),
+			exp: &Backoff{RetryAfter: now.Add(time.Hour), RateLimited: true},
+		},
+		"rateLimited problem without Retry-After": {
+			err: &acme.Error{StatusCode: http.StatusBadRequest, ProblemType: RateLimitedProblemType},
+			exp: &Backoff{RetryAfter: now.Add(DefaultRateLimitBackoff), RateLimited: true},
+		},
+		"rateLimited problem with Retry-After in the past": {
+			err: &acme.Error{StatusCode: http.StatusTooManyRequests, Header: header("Wed, 01 Jun 2022 09:00:00 GMT")},
+			exp: &Backoff{RetryAfter: now, RateLimited: true},
+		},
+		"503 with Retry-After": {
+			err: &acme.Error{StatusCode: http.StatusServiceUnavailable, Header: header("30")},
+			exp: &Backoff{RetryAfter: now.Add(30 * time.Second)},
+		},
+		"503 without Retry-After": {
+			err: &acme.Error{StatusCode: http.StatusServiceUnavailable},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, BackoffForError(test.err, now))
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// maxDirectorySize is the maximum size of an ACME directory that will be read.
+const maxDirectorySize = 1 << 20
+
+const (
+	// CAAIdentitiesMetaField is the field of the ACME directory's "meta"
+	// object listing the hostnames the ACME server recognises as referring
+	// to itself in CAA records, as defined in RFC 8555 section 7.1.1.
+	CAAIdentitiesMetaField = "caaIdentities"
+
+	// TermsOfServiceMetaField is the field of the ACME directory's "meta"
+	// object holding the URL of the ACME server's current terms of service.
+	TermsOfServiceMetaField = "termsOfService"
+
+	// OrderLongPollingMetaField is the field of the ACME directory's "meta"
+	// object with which an ACME server advertises that it holds requests for
+	// the status of an order open until the status changes, returning a
+	// Retry-After header when it does not.
+	// This is not part of RFC 8555. Clients of servers which do not advertise
+	// it poll orders at a fixed interval instead.
+	OrderLongPollingMetaField = "orderLongPolling"
+)
+
+// DirectoryMeta holds the fields of an ACME directory's "meta" object which
+// are used by cert-manager.
+type DirectoryMeta struct {
+	// Profiles are the certificate profiles advertised by the ACME server,
+	// as a map of profile name to description. It is nil if the ACME server
+	// does not implement the profiles extension.
+	Profiles map[string]string
+
+	// CAAIdentities are the hostnames the ACME server recognises as
+	// referring to itself in the issuer domain of CAA records.
+	CAAIdentities []string
+
+	// TermsOfService is the URL of the ACME server's current terms of
+	// service.
+	TermsOfService string
+
+	// OrderLongPolling is true if the ACME server supports long-polling
+	// orders.
+	OrderLongPolling bool
+}
+
+// FetchDirectoryMeta fetches the ACME directory at the given URL and returns
+// the fields of its "meta" object which are used by cert-manager.
+func FetchDirectoryMeta(ctx context.Context, httpClient *http.Client, directoryURL string) (*DirectoryMeta, error) {
+	meta, err := fetchDirectoryMeta(ctx, httpClient, directoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	dirMeta := &DirectoryMeta{}
+	if raw, ok := meta[ProfilesMetaField]; ok {
+		if err := json.Unmarshal(raw, &dirMeta.Profiles); err != nil {
+			return nil, fmt.Errorf("invalid value for ACME directory meta field %q: %w", ProfilesMetaField, err)
+		}
+	}
+	if raw, ok := meta[CAAIdentitiesMetaField]; ok {
+		if err := json.Unmarshal(raw, &dirMeta.CAAIdentities); err != nil {
+			return nil, fmt.Errorf("invalid value for ACME directory meta field %q: %w", CAAIdentitiesMetaField, err)
+		}
+	}
+	if raw, ok := meta[TermsOfServiceMetaField]; ok {
+		if err := json.Unmarshal(raw, &dirMeta.TermsOfService); err != nil {
+			return nil, fmt.Errorf("invalid value for ACME directory meta field %q: %w", TermsOfServiceMetaField, err)
+		}
+	}
+	if raw, ok := meta[OrderLongPollingMetaField]; ok {
+		if err := json.Unmarshal(raw, &dirMeta.OrderLongPolling); err != nil {
+			return nil, fmt.Errorf("invalid value for ACME directory meta field %q: %w", OrderLongPollingMetaField, err)
+		}
+	}
+
+	return dirMeta, nil
+}
+
+// fetchDirectoryMeta fetches the ACME directory at the given URL and returns
+// the fields of its "meta" object, which is where ACME servers advertise
+// support for extensions to RFC 8555.
+func fetchDirectoryMeta(ctx context.Context, httpClient *http.Client, directoryURL string) (map[string]json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, directoryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching ACME directory", resp.StatusCode)
+	}
+
+	var dir struct {
+		Meta map[string]json.RawMessage `json:"meta"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDirectorySize)).Decode(&dir); err != nil {
+		return nil, fmt.Errorf("failed to decode ACME directory: %w", err)
+	}
+
+	return dir.Meta, nil
+}
+
+// DirectoryMetaCache caches the metadata of ACME directories by directory
+// URL, so that it is not fetched from the ACME server every time an issuer
+// or order is synced. Entries expire after a TTL so that changes to the
+// directory, such as new terms of service, are picked up. Failures to fetch
+// a directory are not cached.
+type DirectoryMetaCache struct {
+	ttl   time.Duration
+	clock clock.Clock
+
+	// fetch is used to fetch the ACME directory, and can be replaced in tests.
+	fetch func(ctx context.Context, httpClient *http.Client, directoryURL string) (*DirectoryMeta, error)
+
+	lock    sync.Mutex
+	entries map[string]directoryMetaEntry
+}
+
+type directoryMetaEntry struct {
+	meta    *DirectoryMeta
+	expires time.Time
+}
+
+// NewDirectoryMetaCache returns a DirectoryMetaCache whose entries expire
+// after the given TTL.
+func NewDirectoryMetaCache(ttl time.Duration) *DirectoryMetaCache {
+	return &DirectoryMetaCache{
+		ttl:     ttl,
+		clock:   clock.RealClock{},
+		fetch:   FetchDirectoryMeta,
+		entries: make(map[string]directoryMetaEntry),
+	}
+}
+
+// Get returns the metadata of the ACME directory at the given URL, fetching
+// it with httpClient if it is not cached or its cache entry has expired.
+func (c *DirectoryMetaCache) Get(ctx context.Context, httpClient *http.Client, directoryURL string) (*DirectoryMeta, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[directoryURL]
+	c.lock.Unlock()
+	if ok && c.clock.Now().Before(entry.expires) {
+		return entry.meta, nil
+	}
+
+	meta, err := c.fetch(ctx, httpClient, directoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.entries[directoryURL] = directoryMetaEntry{meta: meta, expires: c.clock.Now().Add(c.ttl)}
+	c.lock.Unlock()
+
+	return meta, nil
+}
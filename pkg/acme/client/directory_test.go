@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestFetchDirectoryMeta(t *testing.T) {
+	tests := map[string]struct {
+		directory string
+		expMeta   *DirectoryMeta
+		expErr    bool
+	}{
+		"directory without any known meta fields": {
+			directory: `{"meta": {"website": "https://acme.example.com"}}`,
+			expMeta:   &DirectoryMeta{},
+		},
+		"directory advertising CAA identities and terms of service": {
+			directory: `{"meta": {"caaIdentities": ["acme.example.com"], "termsOfService": "https://acme.example.com/tos"}}`,
+			expMeta: &DirectoryMeta{
+				CAAIdentities:  []string{"acme.example.com"},
+				TermsOfService: "https://acme.example.com/tos",
+			},
+		},
+		"directory advertising profiles": {
+			directory: `{"meta": {"profiles": {"classic": "The default profile", "shortlived": "Certificates valid for 6 days"}}}`,
+			expMeta: &DirectoryMeta{
+				Profiles: map[string]string{"classic": "The default profile", "shortlived": "Certificates valid for 6 days"},
+			},
+		},
+		"directory advertising long-polling": {
+			directory: `{"meta": {"orderLongPolling": true}}`,
+			expMeta:   &DirectoryMeta{OrderLongPolling: true},
+		},
+		"invalid long-polling meta field": {
+			directory: `{"meta": {"orderLongPolling": "yes"}}`,
+			expErr:    true,
+		},
+		"invalid directory": {
+			directory: `not json`,
+			expErr:    true,
+		},
+		"invalid profiles meta field": {
+			directory: `{"meta": {"profiles": ["classic"]}}`,
+			expErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(test.directory))
+			}))
+			defer server.Close()
+
+			meta, err := FetchDirectoryMeta(context.Background(), server.Client(), server.URL)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if !reflect.DeepEqual(meta, test.expMeta) {
+				t.Errorf("unexpected directory meta, exp=%+v got=%+v", test.expMeta, meta)
+			}
+		})
+	}
+}
+
+func TestDirectoryMetaCache(t *testing.T) {
+	var fetches int
+	var fail bool
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	cache := NewDirectoryMetaCache(time.Hour)
+	cache.clock = fakeClock
+	cache.fetch = func(_ context.Context, _ *http.Client, directoryURL string) (*DirectoryMeta, error) {
+		fetches++
+		if fail {
+			return nil, errors.New("unavailable")
+		}
+		return &DirectoryMeta{TermsOfService: directoryURL + "/tos"}, nil
+	}
+
+	get := func(directoryURL string) {
+		t.Helper()
+		meta, err := cache.Get(context.Background(), nil, directoryURL)
+		if fail != (err != nil) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err == nil && meta.TermsOfService != directoryURL+"/tos" {
+			t.Errorf("unexpected directory meta %+v", meta)
+		}
+	}
+
+	get("https://a.example.com")
+	get("https://a.example.com")
+	get("https://b.example.com")
+	if fetches != 2 {
+		t.Errorf("expected each directory to be fetched once, got %d fetches", fetches)
+	}
+
+	fakeClock.Step(time.Hour)
+	fail = true
+	get("https://a.example.com")
+	get("https://a.example.com")
+	if fetches != 4 {
+		t.Errorf("expected expired entries and failures not to be cached, got %d fetches", fetches)
+	}
+}
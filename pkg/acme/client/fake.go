@@ -18,9 +18,10 @@ package client
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 
-	"golang.org/x/crypto/acme"
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 // TODO: expand this out one day to be backed by the pebble wfe package
@@ -29,6 +30,7 @@ import (
 // FakeACME implements Interface and can be used as a mock acme.Client in tests.
 type FakeACME struct {
 	FakeAuthorizeOrder          func(ctx context.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error)
+	FakeAuthorize               func(ctx context.Context, domain string) (*acme.Authorization, error)
 	FakeGetOrder                func(ctx context.Context, url string) (*acme.Order, error)
 	FakeFetchCert               func(ctx context.Context, url string, bundle bool) ([][]byte, error)
 	FakeListCertAlternates      func(ctx context.Context, url string) ([]string, error)
@@ -44,6 +46,8 @@ type FakeACME struct {
 	FakeDNS01ChallengeRecord    func(token string) (string, error)
 	FakeDiscover                func(ctx context.Context) (acme.Directory, error)
 	FakeUpdateReg               func(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	FakeAccountKeyRollover      func(ctx context.Context, newKey crypto.Signer) error
+	FakeDeactivateReg           func(ctx context.Context) error
 }
 
 var _ Interface = &FakeACME{}
@@ -55,6 +59,13 @@ func (f *FakeACME) AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ..
 	return nil, fmt.Errorf("AuthorizeOrder not implemented")
 }
 
+func (f *FakeACME) Authorize(ctx context.Context, domain string) (*acme.Authorization, error) {
+	if f.FakeAuthorize != nil {
+		return f.FakeAuthorize(ctx, domain)
+	}
+	return nil, fmt.Errorf("Authorize not implemented")
+}
+
 func (f *FakeACME) GetOrder(ctx context.Context, url string) (*acme.Order, error) {
 	if f.FakeGetOrder != nil {
 		return f.FakeGetOrder(ctx, url)
@@ -155,6 +166,20 @@ func (f *FakeACME) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Accoun
 	return nil, fmt.Errorf("UpdateReg not implemented")
 }
 
+func (f *FakeACME) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	if f.FakeAccountKeyRollover != nil {
+		return f.FakeAccountKeyRollover(ctx, newKey)
+	}
+	return fmt.Errorf("AccountKeyRollover not implemented")
+}
+
+func (f *FakeACME) DeactivateReg(ctx context.Context) error {
+	if f.FakeDeactivateReg != nil {
+		return f.FakeDeactivateReg(ctx)
+	}
+	return fmt.Errorf("DeactivateReg not implemented")
+}
+
 func (f *FakeACME) ListCertAlternates(ctx context.Context, url string) ([]string, error) {
 	if f.FakeListCertAlternates != nil {
 		return f.FakeListCertAlternates(ctx, url)
@@ -18,10 +18,10 @@ package client
 
 import (
 	"context"
+	"crypto"
 
 	acmeutil "github.com/cert-manager/cert-manager/pkg/acme/util"
-
-	"golang.org/x/crypto/acme"
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 // Interface is an Automatic Certificate Management Environment (ACME) client
@@ -31,6 +31,7 @@ import (
 // and RFC 8555 (https://tools.ietf.org/html/rfc8555).
 type Interface interface {
 	AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error)
+	Authorize(ctx context.Context, domain string) (*acme.Authorization, error)
 	GetOrder(ctx context.Context, url string) (*acme.Order, error)
 	FetchCert(ctx context.Context, url string, bundle bool) ([][]byte, error)
 	ListCertAlternates(ctx context.Context, url string) ([]string, error)
@@ -46,8 +47,23 @@ type Interface interface {
 	DNS01ChallengeRecord(token string) (string, error)
 	Discover(ctx context.Context) (acme.Directory, error)
 	UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error
+	DeactivateReg(ctx context.Context) error
+}
+
+// Client wraps an acme.Client to change the behaviour of methods which do
+// not fit cert-manager's use of the ACME server.
+type Client struct {
+	*acme.Client
+}
+
+// NewClient returns a Client wrapping the given acme.Client.
+func NewClient(cl *acme.Client) *Client {
+	return &Client{Client: cl}
 }
 
-var _ Interface = &acme.Client{
-	RetryBackoff: acmeutil.RetryBackoff,
+var _ Interface = &Client{
+	Client: &acme.Client{
+		RetryBackoff: acmeutil.RetryBackoff,
+	},
 }
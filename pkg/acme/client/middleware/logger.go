@@ -18,12 +18,13 @@ package middleware
 
 import (
 	"context"
+	"crypto"
 
 	"github.com/go-logr/logr"
-	"golang.org/x/crypto/acme"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/client"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 func NewLogger(baseCl client.Interface) client.Interface {
@@ -47,6 +48,12 @@ func (l *Logger) AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ...a
 	return l.baseCl.AuthorizeOrder(ctx, id, opt...)
 }
 
+func (l *Logger) Authorize(ctx context.Context, domain string) (*acme.Authorization, error) {
+	l.log.V(logf.TraceLevel).Info("Calling Authorize")
+
+	return l.baseCl.Authorize(ctx, domain)
+}
+
 func (l *Logger) GetOrder(ctx context.Context, url string) (*acme.Order, error) {
 	l.log.V(logf.TraceLevel).Info("Calling GetOrder")
 
@@ -136,3 +143,15 @@ func (l *Logger) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account,
 
 	return l.baseCl.UpdateReg(ctx, a)
 }
+
+func (l *Logger) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	l.log.V(logf.TraceLevel).Info("Calling AccountKeyRollover")
+
+	return l.baseCl.AccountKeyRollover(ctx, newKey)
+}
+
+func (l *Logger) DeactivateReg(ctx context.Context) error {
+	l.log.V(logf.TraceLevel).Info("Calling DeactivateReg")
+
+	return l.baseCl.DeactivateReg(ctx)
+}
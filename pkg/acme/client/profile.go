@@ -0,0 +1,42 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// ProfilesMetaField is the field of the ACME directory's "meta" object in
+// which an ACME server implementing the ACME profiles extension advertises
+// the certificate profiles it supports, as a map of profile name to a human
+// readable description.
+// A profile is selected by setting the "profile" field of a newOrder request,
+// see acme.WithOrderProfile.
+const ProfilesMetaField = "profiles"
+
+// SupportedProfiles fetches the ACME directory at the given URL and returns
+// the certificate profiles advertised by the ACME server. A nil map is
+// returned if the ACME server does not implement the profiles extension.
+func SupportedProfiles(ctx context.Context, httpClient *http.Client, directoryURL string) (map[string]string, error) {
+	meta, err := FetchDirectoryMeta(ctx, httpClient, directoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return meta.Profiles, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSupportedProfiles(t *testing.T) {
+	tests := map[string]struct {
+		directory   string
+		expProfiles map[string]string
+		expErr      bool
+	}{
+		"directory without the profiles meta field": {
+			directory: `{"meta": {"termsOfService": "https://acme.example.com/tos"}}`,
+		},
+		"directory advertising profiles": {
+			directory:   `{"meta": {"profiles": {"classic": "The default profile", "shortlived": "Six day certificates"}}}`,
+			expProfiles: map[string]string{"classic": "The default profile", "shortlived": "Six day certificates"},
+		},
+		"invalid profiles meta field": {
+			directory: `{"meta": {"profiles": ["classic"]}}`,
+			expErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(test.directory))
+			}))
+			defer server.Close()
+
+			profiles, err := SupportedProfiles(context.Background(), server.Client(), server.URL)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if !reflect.DeepEqual(profiles, test.expProfiles) {
+				t.Errorf("unexpected profiles, exp=%v got=%v", test.expProfiles, profiles)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // for ES256 and RS256
+	_ "crypto/sha512" // for ES384 and ES512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// jsonWebSignature is a JWS in the flattened JSON serialization, which is
+// the only serialization ACME clients use.
+type jsonWebSignature struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// protectedHeader is the protected header of a JWS sent by an ACME client.
+type protectedHeader struct {
+	Algorithm string          `json:"alg"`
+	JWK       json.RawMessage `json:"jwk"`
+	KeyID     string          `json:"kid"`
+	Nonce     string          `json:"nonce"`
+	URL       string          `json:"url"`
+}
+
+// jsonWebKey is the public JSON Web Key of an RSA or EC account key, see
+// RFC 7518 section 6.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// decodeJWS decodes the protected header and payload of a JWS, without
+// verifying its signature.
+func decodeJWS(body []byte) (*jsonWebSignature, *protectedHeader, []byte, error) {
+	var jws jsonWebSignature
+	if err := json.Unmarshal(body, &jws); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid JWS: %w", err)
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid JWS protected header: %w", err)
+	}
+	var header protectedHeader
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid JWS protected header: %w", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid JWS payload: %w", err)
+	}
+	return &jws, &header, payload, nil
+}
+
+// verify checks the signature of the JWS was made by the given key, using
+// the algorithm in its protected header.
+func (jws *jsonWebSignature) verify(alg string, pub crypto.PublicKey) error {
+	sig, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid JWS signature: %w", err)
+	}
+
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "ES384":
+		hash = crypto.SHA384
+	case "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported JWS algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write([]byte(jws.Protected + "." + jws.Payload))
+	digest := h.Sum(nil)
+
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		if alg != "RS256" {
+			return fmt.Errorf("JWS algorithm %q cannot be used with an RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hash, digest, sig); err != nil {
+			return errors.New("invalid JWS signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if alg == "RS256" || len(sig) != 2*size {
+			return fmt.Errorf("JWS algorithm %q cannot be used with an EC key on curve %s", alg, pub.Curve.Params().Name)
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid JWS signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", pub)
+	}
+}
+
+// publicKey decodes an RSA or EC JSON Web Key.
+func publicKey(raw json.RawMessage) (crypto.PublicKey, error) {
+	var jwk jsonWebKey
+	if err := json.Unmarshal(raw, &jwk); err != nil {
+		return nil, fmt.Errorf("invalid JWK: %w", err)
+	}
+
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("invalid JWK parameter %q", s)
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported JWK curve %q", jwk.Crv)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("invalid JWK: point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWK key type %q", jwk.Kty)
+	}
+}
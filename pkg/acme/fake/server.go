@@ -0,0 +1,1058 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake implements an in-process ACME (RFC 8555) server, which ACME
+// clients, external issuers and challenge solvers can be integration tested
+// against without running a separate ACME server.
+// The server uses the same endpoints as Pebble, Let's Encrypt's ACME test
+// server, and like Pebble it requires a fresh nonce for every request.
+// Whether a challenge is valid is decided by a hook, which by default
+// accepts every challenge.
+package fake
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+// The paths of the endpoints of the server, which are those used by Pebble.
+const (
+	DirectoryPath   = "/dir"
+	noncePath       = "/nonce-plz"
+	newAccountPath  = "/sign-me-up"
+	accountPath     = "/my-account/"
+	newOrderPath    = "/order-plz"
+	orderPath       = "/my-order/"
+	finalizePath    = "/finalize-order/"
+	authzPath       = "/authZ/"
+	challengePath   = "/chalZ/"
+	certPath        = "/certZ/"
+	revokeCertPath  = "/revoke-cert"
+	keyRolloverPath = "/rollover-account-key"
+)
+
+// DefaultCertificateLifetime is the lifetime of the certificates issued by
+// the server if Options.CertificateLifetime is not set.
+const DefaultCertificateLifetime = time.Hour * 24 * 90
+
+// orderLifetime is the time after which pending orders and authorizations
+// are reported to expire.
+const orderLifetime = time.Hour * 24 * 7
+
+// Challenge is a challenge which a client has asked the server to validate.
+type Challenge struct {
+	// Type is the type of the challenge, e.g. "http-01" or "dns-01".
+	Type string
+	// Identifier is the identifier being authorized. For wildcard
+	// identifiers this does not include the "*." prefix.
+	Identifier acme.AuthzID
+	// Wildcard is true if the authorization is for a wildcard identifier.
+	Wildcard bool
+	// Token is the token of the challenge.
+	Token string
+	// KeyAuthorization is the token joined with the thumbprint of the
+	// account key, which is served by HTTP01 solvers.
+	KeyAuthorization string
+}
+
+// DNS01Value returns the value of the TXT record which solves the
+// challenge if it is a DNS01 challenge.
+func (c *Challenge) DNS01Value() string {
+	b := sha256.Sum256([]byte(c.KeyAuthorization))
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// ValidateFunc decides whether a challenge has been solved. It returns nil if
+// it has, or an error describing why it has not.
+type ValidateFunc func(ctx context.Context, ch *Challenge) error
+
+// Options configure the behaviour of the server.
+type Options struct {
+	// ValidateChallenge is called when a client responds to a challenge.
+	// If it returns an error, the challenge, its authorization and its order
+	// become invalid. If nil, every challenge is valid.
+	ValidateChallenge ValidateFunc
+
+	// ChallengeTypes are the types of challenges offered for each
+	// authorization. Defaults to "http-01" and "dns-01". Only "dns-01"
+	// challenges are offered for wildcard identifiers, and no "dns-01"
+	// challenges for IP address identifiers.
+	ChallengeTypes []string
+
+	// TermsOfService is the URL of the terms of service advertised in the
+	// directory. If set, clients must agree to them to create an account.
+	TermsOfService string
+
+	// CertificateLifetime is the lifetime of issued certificates. Defaults
+	// to DefaultCertificateLifetime.
+	CertificateLifetime time.Duration
+}
+
+// Server is an in-process ACME server listening on a local TLS port.
+type Server struct {
+	opts   Options
+	server *httptest.Server
+
+	caKey  crypto.Signer
+	caCert *x509.Certificate
+
+	mu           sync.Mutex
+	lastID       int
+	nonces       map[string]bool
+	accounts     map[string]*account
+	orders       map[string]*order
+	authzs       map[string]*authorization
+	challenges   map[string]*challenge
+	certificates map[string]*certificate
+}
+
+type account struct {
+	url        string
+	key        crypto.PublicKey
+	thumbprint string
+	status     string
+	contact    []string
+}
+
+type order struct {
+	url         string
+	finalizeURL string
+	certURL     string
+	account     *account
+	status      string
+	expires     time.Time
+	identifiers []acme.AuthzID
+	authzs      []*authorization
+	problem     *problem
+}
+
+type authorization struct {
+	url        string
+	order      *order
+	identifier acme.AuthzID
+	wildcard   bool
+	status     string
+	expires    time.Time
+	challenges []*challenge
+}
+
+type challenge struct {
+	url       string
+	authz     *authorization
+	typ       string
+	token     string
+	status    string
+	validated time.Time
+	problem   *problem
+}
+
+type certificate struct {
+	account *account
+	cert    *x509.Certificate
+	chain   []byte
+	revoked bool
+}
+
+// problem is an ACME problem document, see RFC 8555 section 6.7.
+type problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func newProblem(status int, typ, format string, args ...interface{}) *problem {
+	return &problem{
+		Type:   "urn:ietf:params:acme:error:" + typ,
+		Detail: fmt.Sprintf(format, args...),
+		Status: status,
+	}
+}
+
+// NewServer starts an ACME server. It must be closed with Close once it is
+// no longer needed.
+func NewServer(opts Options) (*Server, error) {
+	if len(opts.ChallengeTypes) == 0 {
+		opts.ChallengeTypes = []string{"http-01", "dns-01"}
+	}
+	if opts.CertificateLifetime == 0 {
+		opts.CertificateLifetime = DefaultCertificateLifetime
+	}
+
+	caKey, caCert, err := newCA()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		opts:         opts,
+		caKey:        caKey,
+		caCert:       caCert,
+		nonces:       make(map[string]bool),
+		accounts:     make(map[string]*account),
+		orders:       make(map[string]*order),
+		authzs:       make(map[string]*authorization),
+		challenges:   make(map[string]*challenge),
+		certificates: make(map[string]*certificate),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(DirectoryPath, s.handleDirectory)
+	mux.HandleFunc(noncePath, s.handleNonce)
+	mux.HandleFunc(newAccountPath, s.post(s.handleNewAccount))
+	mux.HandleFunc(accountPath, s.post(s.handleAccount))
+	mux.HandleFunc(newOrderPath, s.post(s.handleNewOrder))
+	mux.HandleFunc(orderPath, s.post(s.handleOrder))
+	mux.HandleFunc(finalizePath, s.post(s.handleFinalize))
+	mux.HandleFunc(authzPath, s.post(s.handleAuthorization))
+	mux.HandleFunc(challengePath, s.post(s.handleChallenge))
+	mux.HandleFunc(certPath, s.post(s.handleCertificate))
+	mux.HandleFunc(revokeCertPath, s.post(s.handleRevokeCert))
+	mux.HandleFunc(keyRolloverPath, s.post(s.handleKeyRollover))
+	s.server = httptest.NewTLSServer(mux)
+
+	return s, nil
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// DirectoryURL returns the URL of the ACME directory of the server.
+func (s *Server) DirectoryURL() string {
+	return s.server.URL + DirectoryPath
+}
+
+// HTTPClient returns an HTTP client which trusts the TLS certificate of the
+// server.
+func (s *Server) HTTPClient() *http.Client {
+	return s.server.Client()
+}
+
+// Roots returns a pool containing the CA certificate which issued
+// certificates chain to.
+func (s *Server) Roots() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.caCert)
+	return pool
+}
+
+// InvalidateNonces invalidates all nonces handed out so far, so that the
+// next request of every client fails with a badNonce error, which clients
+// are expected to retry.
+func (s *Server) InvalidateNonces() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nonces = make(map[string]bool)
+}
+
+func newCA() (crypto.Signer, *x509.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "cert-manager fake ACME root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24 * 365 * 10),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, cert, nil
+}
+
+func randomSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// newURL returns a new URL for a resource under the given path. It must be
+// called with s.mu held.
+func (s *Server) newURL(path string) string {
+	s.lastID++
+	return s.server.URL + path + strconv.Itoa(s.lastID)
+}
+
+// newNonce returns a new nonce which may be used for a single request.
+func (s *Server) newNonce() string {
+	nonce := randomToken()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nonces[nonce] = true
+	return nonce
+}
+
+func (s *Server) writeHeaders(w http.ResponseWriter) {
+	w.Header().Set("Replay-Nonce", s.newNonce())
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Add("Link", fmt.Sprintf("<%s>;rel=\"index\"", s.DirectoryURL()))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeProblem(w http.ResponseWriter, p *problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+func (s *Server) handleDirectory(w http.ResponseWriter, r *http.Request) {
+	meta := map[string]interface{}{
+		"externalAccountRequired": false,
+	}
+	if s.opts.TermsOfService != "" {
+		meta["termsOfService"] = s.opts.TermsOfService
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"newNonce":   s.server.URL + noncePath,
+		"newAccount": s.server.URL + newAccountPath,
+		"newOrder":   s.server.URL + newOrderPath,
+		"revokeCert": s.server.URL + revokeCertPath,
+		"keyChange":  s.server.URL + keyRolloverPath,
+		"meta":       meta,
+	})
+}
+
+func (s *Server) handleNonce(w http.ResponseWriter, r *http.Request) {
+	s.writeHeaders(w)
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeProblem(w, newProblem(http.StatusMethodNotAllowed, "malformed", "method %s not allowed", r.Method))
+	}
+}
+
+// request is a verified JWS request to the server.
+type request struct {
+	url     string
+	header  *protectedHeader
+	payload []byte
+	// account is the account which signed the request. It is nil for
+	// requests signed with a JWK.
+	account *account
+	// key is the key which signed the request.
+	key crypto.PublicKey
+}
+
+// postAsGet returns true if the request is a POST-as-GET request, which has
+// an empty payload.
+func (req *request) postAsGet() bool {
+	return len(req.payload) == 0
+}
+
+type postHandler func(w http.ResponseWriter, req *request) *problem
+
+// post returns a handler for requests to the endpoint which are POSTed
+// JWS, which verifies the JWS before calling h with s.mu held.
+func (s *Server) post(h postHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.writeHeaders(w)
+		if r.Method != http.MethodPost {
+			writeProblem(w, newProblem(http.StatusMethodNotAllowed, "malformed", "method %s not allowed", r.Method))
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/jose+json" {
+			writeProblem(w, newProblem(http.StatusUnsupportedMediaType, "malformed", "unsupported content type %q", ct))
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			writeProblem(w, newProblem(http.StatusBadRequest, "malformed", "error reading request: %v", err))
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		req, p := s.verify(r, body)
+		if p == nil {
+			p = h(w, req)
+		}
+		if p != nil {
+			writeProblem(w, p)
+		}
+	}
+}
+
+// verify checks the nonce, URL and signature of a JWS request. It must be
+// called with s.mu held.
+func (s *Server) verify(r *http.Request, body []byte) (*request, *problem) {
+	jws, header, payload, err := decodeJWS(body)
+	if err != nil {
+		return nil, newProblem(http.StatusBadRequest, "malformed", "%v", err)
+	}
+
+	if !s.nonces[header.Nonce] {
+		return nil, newProblem(http.StatusBadRequest, "badNonce", "JWS has an invalid anti-replay nonce: %q", header.Nonce)
+	}
+	delete(s.nonces, header.Nonce)
+
+	url := s.server.URL + r.URL.Path
+	if header.URL != url {
+		return nil, newProblem(http.StatusBadRequest, "malformed", "JWS header URL %q does not match the request URL %q", header.URL, url)
+	}
+
+	req := &request{url: url, header: header, payload: payload}
+	switch {
+	case header.KeyID != "" && len(header.JWK) > 0:
+		return nil, newProblem(http.StatusBadRequest, "malformed", "JWS header must not contain both a jwk and a kid")
+	case header.KeyID != "":
+		acct, ok := s.accounts[header.KeyID]
+		if !ok {
+			return nil, newProblem(http.StatusBadRequest, "accountDoesNotExist", "account %q does not exist", header.KeyID)
+		}
+		if acct.status != acme.StatusValid {
+			return nil, newProblem(http.StatusForbidden, "unauthorized", "account %q is %s", acct.url, acct.status)
+		}
+		req.account = acct
+		req.key = acct.key
+	case len(header.JWK) > 0:
+		if r.URL.Path != newAccountPath && r.URL.Path != revokeCertPath {
+			return nil, newProblem(http.StatusBadRequest, "malformed", "requests to %s must be signed using a kid", r.URL.Path)
+		}
+		req.key, err = publicKey(header.JWK)
+		if err != nil {
+			return nil, newProblem(http.StatusBadRequest, "badPublicKey", "%v", err)
+		}
+	default:
+		return nil, newProblem(http.StatusBadRequest, "malformed", "JWS header must contain a jwk or a kid")
+	}
+
+	if err := jws.verify(header.Algorithm, req.key); err != nil {
+		return nil, newProblem(http.StatusBadRequest, "malformed", "%v", err)
+	}
+
+	return req, nil
+}
+
+func (s *Server) handleNewAccount(w http.ResponseWriter, req *request) *problem {
+	var payload struct {
+		Contact              []string `json:"contact"`
+		TermsOfServiceAgreed bool     `json:"termsOfServiceAgreed"`
+		OnlyReturnExisting   bool     `json:"onlyReturnExisting"`
+	}
+	if err := json.Unmarshal(req.payload, &payload); err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "invalid new account request: %v", err)
+	}
+
+	thumbprint, err := acme.JWKThumbprint(req.key)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "badPublicKey", "%v", err)
+	}
+	for _, acct := range s.accounts {
+		if acct.thumbprint == thumbprint {
+			w.Header().Set("Location", acct.url)
+			writeJSON(w, http.StatusOK, acct.wire())
+			return nil
+		}
+	}
+
+	if payload.OnlyReturnExisting {
+		return newProblem(http.StatusBadRequest, "accountDoesNotExist", "no account exists with the provided key")
+	}
+	if s.opts.TermsOfService != "" && !payload.TermsOfServiceAgreed {
+		return newProblem(http.StatusForbidden, "userActionRequired", "the terms of service at %s must be agreed to", s.opts.TermsOfService)
+	}
+
+	acct := &account{
+		url:        s.newURL(accountPath),
+		key:        req.key,
+		thumbprint: thumbprint,
+		status:     acme.StatusValid,
+		contact:    payload.Contact,
+	}
+	s.accounts[acct.url] = acct
+
+	w.Header().Set("Location", acct.url)
+	writeJSON(w, http.StatusCreated, acct.wire())
+	return nil
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, req *request) *problem {
+	if req.account.url != req.url {
+		return newProblem(http.StatusForbidden, "unauthorized", "account %q may not modify account %q", req.account.url, req.url)
+	}
+
+	if !req.postAsGet() {
+		var payload struct {
+			Contact []string `json:"contact"`
+			Status  string   `json:"status"`
+		}
+		if err := json.Unmarshal(req.payload, &payload); err != nil {
+			return newProblem(http.StatusBadRequest, "malformed", "invalid account update: %v", err)
+		}
+		switch payload.Status {
+		case "":
+		case acme.StatusDeactivated:
+			req.account.status = acme.StatusDeactivated
+		default:
+			return newProblem(http.StatusBadRequest, "malformed", "account status may only be set to %q", acme.StatusDeactivated)
+		}
+		if payload.Contact != nil {
+			req.account.contact = payload.Contact
+		}
+	}
+
+	writeJSON(w, http.StatusOK, req.account.wire())
+	return nil
+}
+
+func (s *Server) handleKeyRollover(w http.ResponseWriter, req *request) *problem {
+	inner, header, payload, err := decodeJWS(req.payload)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "invalid inner JWS: %v", err)
+	}
+	if header.URL != req.url {
+		return newProblem(http.StatusBadRequest, "malformed", "inner JWS header URL %q does not match the request URL %q", header.URL, req.url)
+	}
+	if len(header.JWK) == 0 || header.KeyID != "" {
+		return newProblem(http.StatusBadRequest, "malformed", "inner JWS must be signed using a jwk")
+	}
+	newKey, err := publicKey(header.JWK)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "badPublicKey", "%v", err)
+	}
+	if err := inner.verify(header.Algorithm, newKey); err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "inner JWS: %v", err)
+	}
+
+	var keyChange struct {
+		Account string          `json:"account"`
+		OldKey  json.RawMessage `json:"oldKey"`
+	}
+	if err := json.Unmarshal(payload, &keyChange); err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "invalid key change request: %v", err)
+	}
+	if keyChange.Account != req.account.url {
+		return newProblem(http.StatusBadRequest, "malformed", "key change request is for account %q, not %q", keyChange.Account, req.account.url)
+	}
+	oldKey, err := publicKey(keyChange.OldKey)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "badPublicKey", "%v", err)
+	}
+	if oldThumbprint, err := acme.JWKThumbprint(oldKey); err != nil || oldThumbprint != req.account.thumbprint {
+		return newProblem(http.StatusBadRequest, "malformed", "old key does not match the current key of the account")
+	}
+
+	thumbprint, err := acme.JWKThumbprint(newKey)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "badPublicKey", "%v", err)
+	}
+	for _, acct := range s.accounts {
+		if acct.thumbprint == thumbprint {
+			w.Header().Set("Location", acct.url)
+			return newProblem(http.StatusConflict, "malformed", "the new key is already used by account %q", acct.url)
+		}
+	}
+
+	req.account.key = newKey
+	req.account.thumbprint = thumbprint
+	writeJSON(w, http.StatusOK, req.account.wire())
+	return nil
+}
+
+func (s *Server) handleNewOrder(w http.ResponseWriter, req *request) *problem {
+	var payload struct {
+		Identifiers []wireIdentifier `json:"identifiers"`
+	}
+	if err := json.Unmarshal(req.payload, &payload); err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "invalid new order request: %v", err)
+	}
+	if len(payload.Identifiers) == 0 {
+		return newProblem(http.StatusBadRequest, "malformed", "an order must contain at least one identifier")
+	}
+
+	o := &order{
+		url:         s.newURL(orderPath),
+		finalizeURL: s.newURL(finalizePath),
+		account:     req.account,
+		status:      acme.StatusPending,
+		expires:     time.Now().Add(orderLifetime),
+	}
+	for _, id := range payload.Identifiers {
+		if p := validateIdentifier(id); p != nil {
+			return p
+		}
+		o.identifiers = append(o.identifiers, acme.AuthzID{Type: id.Type, Value: id.Value})
+		o.authzs = append(o.authzs, s.newAuthorization(o, id))
+	}
+
+	s.orders[o.url] = o
+	for _, authz := range o.authzs {
+		s.authzs[authz.url] = authz
+		for _, ch := range authz.challenges {
+			s.challenges[ch.url] = ch
+		}
+	}
+
+	w.Header().Set("Location", o.url)
+	writeJSON(w, http.StatusCreated, o.wire())
+	return nil
+}
+
+func validateIdentifier(id wireIdentifier) *problem {
+	switch id.Type {
+	case "dns":
+		name := strings.TrimPrefix(id.Value, "*.")
+		if name == "" || strings.Contains(name, "*") || net.ParseIP(name) != nil {
+			return newProblem(http.StatusBadRequest, "rejectedIdentifier", "invalid DNS identifier %q", id.Value)
+		}
+	case "ip":
+		if net.ParseIP(id.Value) == nil {
+			return newProblem(http.StatusBadRequest, "rejectedIdentifier", "invalid IP address identifier %q", id.Value)
+		}
+	default:
+		return newProblem(http.StatusBadRequest, "unsupportedIdentifier", "unsupported identifier type %q", id.Type)
+	}
+	return nil
+}
+
+// newAuthorization returns a new pending authorization for the identifier.
+// It must be called with s.mu held.
+func (s *Server) newAuthorization(o *order, id wireIdentifier) *authorization {
+	authz := &authorization{
+		url:        s.newURL(authzPath),
+		order:      o,
+		identifier: acme.AuthzID{Type: id.Type, Value: strings.TrimPrefix(id.Value, "*.")},
+		wildcard:   strings.HasPrefix(id.Value, "*."),
+		status:     acme.StatusPending,
+		expires:    o.expires,
+	}
+	for _, typ := range s.opts.ChallengeTypes {
+		if authz.wildcard && typ != "dns-01" {
+			continue
+		}
+		if id.Type == "ip" && typ == "dns-01" {
+			continue
+		}
+		authz.challenges = append(authz.challenges, &challenge{
+			url:    s.newURL(challengePath),
+			authz:  authz,
+			typ:    typ,
+			token:  randomToken(),
+			status: acme.StatusPending,
+		})
+	}
+	return authz
+}
+
+func (s *Server) handleOrder(w http.ResponseWriter, req *request) *problem {
+	o, ok := s.orders[req.url]
+	if !ok {
+		return newProblem(http.StatusNotFound, "malformed", "order %q does not exist", req.url)
+	}
+	if o.account != req.account {
+		return newProblem(http.StatusForbidden, "unauthorized", "order %q belongs to another account", req.url)
+	}
+	writeJSON(w, http.StatusOK, o.wire())
+	return nil
+}
+
+func (s *Server) handleAuthorization(w http.ResponseWriter, req *request) *problem {
+	authz, ok := s.authzs[req.url]
+	if !ok {
+		return newProblem(http.StatusNotFound, "malformed", "authorization %q does not exist", req.url)
+	}
+	if authz.order.account != req.account {
+		return newProblem(http.StatusForbidden, "unauthorized", "authorization %q belongs to another account", req.url)
+	}
+	writeJSON(w, http.StatusOK, authz.wire())
+	return nil
+}
+
+func (s *Server) handleChallenge(w http.ResponseWriter, req *request) *problem {
+	ch, ok := s.challenges[req.url]
+	if !ok {
+		return newProblem(http.StatusNotFound, "malformed", "challenge %q does not exist", req.url)
+	}
+	if ch.authz.order.account != req.account {
+		return newProblem(http.StatusForbidden, "unauthorized", "challenge %q belongs to another account", req.url)
+	}
+
+	// Responding to a challenge which is no longer pending, or to a
+	// challenge of an authorization which is no longer pending, has no
+	// effect.
+	if !req.postAsGet() && ch.status == acme.StatusPending && ch.authz.status == acme.StatusPending {
+		s.validate(ch, req.account)
+	}
+
+	w.Header().Add("Link", fmt.Sprintf("<%s>;rel=\"up\"", ch.authz.url))
+	writeJSON(w, http.StatusOK, ch.wire())
+	return nil
+}
+
+// validate validates the challenge using the ValidateChallenge hook, and
+// updates the challenge, its authorization and order with the result. It
+// must be called with s.mu held, which is released while the hook runs.
+func (s *Server) validate(ch *challenge, acct *account) {
+	ch.status = acme.StatusProcessing
+
+	err := error(nil)
+	if s.opts.ValidateChallenge != nil {
+		req := &Challenge{
+			Type:             ch.typ,
+			Identifier:       ch.authz.identifier,
+			Wildcard:         ch.authz.wildcard,
+			Token:            ch.token,
+			KeyAuthorization: ch.token + "." + acct.thumbprint,
+		}
+		s.mu.Unlock()
+		err = s.opts.ValidateChallenge(context.Background(), req)
+		s.mu.Lock()
+	}
+
+	ch.validated = time.Now()
+	if err != nil {
+		ch.status = acme.StatusInvalid
+		ch.problem = newProblem(http.StatusForbidden, "unauthorized", "%v", err)
+		ch.authz.status = acme.StatusInvalid
+		ch.authz.order.status = acme.StatusInvalid
+		ch.authz.order.problem = ch.problem
+		return
+	}
+
+	ch.status = acme.StatusValid
+	ch.authz.status = acme.StatusValid
+
+	o := ch.authz.order
+	if o.status != acme.StatusPending {
+		return
+	}
+	for _, authz := range o.authzs {
+		if authz.status != acme.StatusValid {
+			return
+		}
+	}
+	o.status = acme.StatusReady
+}
+
+func (s *Server) handleFinalize(w http.ResponseWriter, req *request) *problem {
+	var o *order
+	for _, candidate := range s.orders {
+		if candidate.finalizeURL == req.url {
+			o = candidate
+			break
+		}
+	}
+	if o == nil {
+		return newProblem(http.StatusNotFound, "malformed", "order for %q does not exist", req.url)
+	}
+	if o.account != req.account {
+		return newProblem(http.StatusForbidden, "unauthorized", "order %q belongs to another account", o.url)
+	}
+	if o.status != acme.StatusReady {
+		return newProblem(http.StatusForbidden, "orderNotReady", "order %q is %s, not %s", o.url, o.status, acme.StatusReady)
+	}
+
+	var payload struct {
+		CSR string `json:"csr"`
+	}
+	if err := json.Unmarshal(req.payload, &payload); err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "invalid finalize request: %v", err)
+	}
+	der, err := base64.RawURLEncoding.DecodeString(payload.CSR)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "badCSR", "invalid CSR encoding: %v", err)
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "badCSR", "invalid CSR: %v", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return newProblem(http.StatusBadRequest, "badCSR", "invalid CSR signature: %v", err)
+	}
+	if p := checkCSRIdentifiers(csr, o.identifiers); p != nil {
+		return p
+	}
+
+	cert, chain, err := s.issue(csr)
+	if err != nil {
+		return newProblem(http.StatusInternalServerError, "serverInternal", "error issuing certificate: %v", err)
+	}
+	o.certURL = s.newURL(certPath)
+	o.status = acme.StatusValid
+	s.certificates[o.certURL] = &certificate{account: o.account, cert: cert, chain: chain}
+
+	w.Header().Set("Location", o.url)
+	writeJSON(w, http.StatusOK, o.wire())
+	return nil
+}
+
+// checkCSRIdentifiers checks the CSR requests exactly the identifiers of the
+// order. The common name, if any, must be one of the identifiers.
+func checkCSRIdentifiers(csr *x509.CertificateRequest, identifiers []acme.AuthzID) *problem {
+	want := make([]string, 0, len(identifiers))
+	wantSet := make(map[string]bool, len(identifiers))
+	for _, id := range identifiers {
+		want = append(want, id.Value)
+		wantSet[id.Value] = true
+	}
+	got := append([]string{}, csr.DNSNames...)
+	for _, ip := range csr.IPAddresses {
+		got = append(got, ip.String())
+	}
+	sort.Strings(want)
+	sort.Strings(got)
+	if strings.Join(want, ",") != strings.Join(got, ",") {
+		return newProblem(http.StatusBadRequest, "badCSR", "CSR identifiers %v do not match the order identifiers %v", got, want)
+	}
+	if cn := csr.Subject.CommonName; cn != "" && !wantSet[cn] {
+		return newProblem(http.StatusBadRequest, "badCSR", "CSR common name %q is not one of the order identifiers", cn)
+	}
+	return nil
+}
+
+// issue signs a certificate for the CSR, and returns it together with the
+// PEM chain of the certificate and the CA certificate.
+func (s *Server) issue(csr *x509.CertificateRequest) (*x509.Certificate, []byte, error) {
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: csr.Subject.CommonName},
+		DNSNames:     csr.DNSNames,
+		IPAddresses:  csr.IPAddresses,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(s.opts.CertificateLifetime),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, s.caCert, csr.PublicKey, s.caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCert.Raw})...)
+	return cert, chain, nil
+}
+
+func (s *Server) handleCertificate(w http.ResponseWriter, req *request) *problem {
+	cert, ok := s.certificates[req.url]
+	if !ok {
+		return newProblem(http.StatusNotFound, "malformed", "certificate %q does not exist", req.url)
+	}
+	if cert.account != req.account {
+		return newProblem(http.StatusForbidden, "unauthorized", "certificate %q belongs to another account", req.url)
+	}
+	w.Header().Set("Content-Type", "application/pem-certificate-chain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(cert.chain)
+	return nil
+}
+
+func (s *Server) handleRevokeCert(w http.ResponseWriter, req *request) *problem {
+	var payload struct {
+		Certificate string `json:"certificate"`
+	}
+	if err := json.Unmarshal(req.payload, &payload); err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "invalid revocation request: %v", err)
+	}
+	der, err := base64.RawURLEncoding.DecodeString(payload.Certificate)
+	if err != nil {
+		return newProblem(http.StatusBadRequest, "malformed", "invalid certificate encoding: %v", err)
+	}
+
+	var cert *certificate
+	for _, candidate := range s.certificates {
+		if string(candidate.cert.Raw) == string(der) {
+			cert = candidate
+			break
+		}
+	}
+	if cert == nil {
+		return newProblem(http.StatusNotFound, "malformed", "the certificate was not issued by this server")
+	}
+
+	// A certificate may be revoked by the account which requested it, or
+	// by the holder of its private key.
+	authorized := req.account != nil && req.account == cert.account
+	if req.account == nil {
+		keyThumbprint, err := acme.JWKThumbprint(req.key)
+		certThumbprint, certErr := acme.JWKThumbprint(cert.cert.PublicKey)
+		authorized = err == nil && certErr == nil && keyThumbprint == certThumbprint
+	}
+	if !authorized {
+		return newProblem(http.StatusForbidden, "unauthorized", "the request is not authorized to revoke the certificate")
+	}
+	if cert.revoked {
+		return newProblem(http.StatusBadRequest, "alreadyRevoked", "the certificate has already been revoked")
+	}
+
+	cert.revoked = true
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// Revoked returns true if the certificate with the given DER encoding has
+// been revoked.
+func (s *Server) Revoked(der []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cert := range s.certificates {
+		if string(cert.cert.Raw) == string(der) {
+			return cert.revoked
+		}
+	}
+	return false
+}
+
+type wireIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type wireAccount struct {
+	Status  string   `json:"status"`
+	Contact []string `json:"contact,omitempty"`
+}
+
+func (a *account) wire() wireAccount {
+	return wireAccount{Status: a.status, Contact: a.contact}
+}
+
+type wireOrder struct {
+	Status         string           `json:"status"`
+	Expires        time.Time        `json:"expires"`
+	Identifiers    []wireIdentifier `json:"identifiers"`
+	Authorizations []string         `json:"authorizations"`
+	Finalize       string           `json:"finalize"`
+	Certificate    string           `json:"certificate,omitempty"`
+	Error          *problem         `json:"error,omitempty"`
+}
+
+func (o *order) wire() wireOrder {
+	w := wireOrder{
+		Status:      o.status,
+		Expires:     o.expires,
+		Finalize:    o.finalizeURL,
+		Certificate: o.certURL,
+		Error:       o.problem,
+	}
+	for _, id := range o.identifiers {
+		w.Identifiers = append(w.Identifiers, wireIdentifier{Type: id.Type, Value: id.Value})
+	}
+	for _, authz := range o.authzs {
+		w.Authorizations = append(w.Authorizations, authz.url)
+	}
+	return w
+}
+
+type wireAuthorization struct {
+	Status     string          `json:"status"`
+	Expires    time.Time       `json:"expires"`
+	Identifier wireIdentifier  `json:"identifier"`
+	Wildcard   bool            `json:"wildcard,omitempty"`
+	Challenges []wireChallenge `json:"challenges"`
+}
+
+func (a *authorization) wire() wireAuthorization {
+	w := wireAuthorization{
+		Status:     a.status,
+		Expires:    a.expires,
+		Identifier: wireIdentifier{Type: a.identifier.Type, Value: a.identifier.Value},
+		Wildcard:   a.wildcard,
+	}
+	for _, ch := range a.challenges {
+		w.Challenges = append(w.Challenges, ch.wire())
+	}
+	return w
+}
+
+type wireChallenge struct {
+	Type      string     `json:"type"`
+	URL       string     `json:"url"`
+	Status    string     `json:"status"`
+	Token     string     `json:"token"`
+	Validated *time.Time `json:"validated,omitempty"`
+	Error     *problem   `json:"error,omitempty"`
+}
+
+func (c *challenge) wire() wireChallenge {
+	w := wireChallenge{
+		Type:   c.typ,
+		URL:    c.url,
+		Status: c.status,
+		Token:  c.token,
+		Error:  c.problem,
+	}
+	if !c.validated.IsZero() {
+		w.Validated = &c.validated
+	}
+	return w
+}
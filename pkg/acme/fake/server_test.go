@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	"github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+func newTestClient(t *testing.T, srv *Server) *acmecl.Client {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	cl := acmecl.NewClient(&acme.Client{
+		Key:          key,
+		HTTPClient:   srv.HTTPClient(),
+		DirectoryURL: srv.DirectoryURL(),
+	})
+	_, err = cl.Register(context.Background(), &acme.Account{}, acme.AcceptTOS)
+	require.NoError(t, err)
+	return cl
+}
+
+func newTestCSR(t *testing.T, names ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: names[0]},
+		DNSNames: names,
+	}, key)
+	require.NoError(t, err)
+	return csr
+}
+
+// authorize accepts the challenge of the given type of every authorization
+// of the order, and waits for the order to be ready.
+func authorize(ctx context.Context, t *testing.T, cl *acmecl.Client, order *acme.Order, typ string) (*acme.Order, error) {
+	for _, url := range order.AuthzURLs {
+		authz, err := cl.GetAuthorization(ctx, url)
+		require.NoError(t, err)
+		var ch *acme.Challenge
+		for _, c := range authz.Challenges {
+			if c.Type == typ {
+				ch = c
+			}
+		}
+		require.NotNil(t, ch, "authorization has no %s challenge", typ)
+		_, err = cl.Accept(ctx, ch)
+		require.NoError(t, err)
+		if _, err := cl.WaitAuthorization(ctx, url); err != nil {
+			return nil, err
+		}
+	}
+	return cl.WaitOrder(ctx, order.URI)
+}
+
+func TestServerIssuesCertificates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var validated []*Challenge
+	srv, err := NewServer(Options{
+		TermsOfService: "https://acme.example.com/terms",
+		ValidateChallenge: func(_ context.Context, ch *Challenge) error {
+			validated = append(validated, ch)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	cl := newTestClient(t, srv)
+
+	order, err := cl.AuthorizeOrder(ctx, acme.DomainIDs("example.com", "*.example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, acme.StatusPending, order.Status)
+	require.Len(t, order.AuthzURLs, 2)
+
+	wildcard, err := cl.GetAuthorization(ctx, order.AuthzURLs[1])
+	require.NoError(t, err)
+	assert.True(t, wildcard.Wildcard)
+	assert.Equal(t, "example.com", wildcard.Identifier.Value)
+	require.Len(t, wildcard.Challenges, 1)
+	assert.Equal(t, "dns-01", wildcard.Challenges[0].Type)
+
+	// The CSR cannot be finalized before the order is ready.
+	_, _, err = cl.CreateOrderCert(ctx, order.FinalizeURL, newTestCSR(t, "example.com", "*.example.com"), true)
+	assertProblem(t, err, "orderNotReady")
+
+	order, err = authorize(ctx, t, cl, order, "dns-01")
+	require.NoError(t, err)
+	assert.Equal(t, acme.StatusReady, order.Status)
+
+	require.Len(t, validated, 2)
+	thumbprint, err := acme.JWKThumbprint(cl.Key.Public())
+	require.NoError(t, err)
+	assert.Equal(t, validated[0].Token+"."+thumbprint, validated[0].KeyAuthorization)
+	dns01Value, err := cl.DNS01ChallengeRecord(validated[0].Token)
+	require.NoError(t, err)
+	assert.Equal(t, dns01Value, validated[0].DNS01Value())
+
+	// The CSR must request exactly the identifiers of the order.
+	_, _, err = cl.CreateOrderCert(ctx, order.FinalizeURL, newTestCSR(t, "example.com"), true)
+	assertProblem(t, err, "badCSR")
+
+	chain, _, err := cl.CreateOrderCert(ctx, order.FinalizeURL, newTestCSR(t, "example.com", "*.example.com"), true)
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+
+	cert, err := x509.ParseCertificate(chain[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "*.example.com"}, cert.DNSNames)
+	_, err = cert.Verify(x509.VerifyOptions{DNSName: "www.example.com", Roots: srv.Roots()})
+	assert.NoError(t, err)
+
+	require.NoError(t, cl.RevokeCert(ctx, nil, chain[0], acme.CRLReasonUnspecified))
+	assert.True(t, srv.Revoked(chain[0]))
+	// Clients treat the alreadyRevoked error as success.
+	assert.NoError(t, cl.RevokeCert(ctx, nil, chain[0], acme.CRLReasonUnspecified))
+}
+
+func TestServerInvalidChallenge(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	srv, err := NewServer(Options{
+		ValidateChallenge: func(_ context.Context, ch *Challenge) error {
+			return errors.New("key authorization not served")
+		},
+	})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	cl := newTestClient(t, srv)
+
+	order, err := cl.AuthorizeOrder(ctx, acme.DomainIDs("example.com"))
+	require.NoError(t, err)
+
+	_, err = authorize(ctx, t, cl, order, "http-01")
+	var authzErr *acme.AuthorizationError
+	require.True(t, errors.As(err, &authzErr), "expected an authorization error, got %v", err)
+	require.Len(t, authzErr.Errors, 1)
+	assertProblem(t, authzErr.Errors[0], "unauthorized")
+
+	order, err = cl.GetOrder(ctx, order.URI)
+	require.NoError(t, err)
+	assert.Equal(t, acme.StatusInvalid, order.Status)
+}
+
+func TestServerRejectsStaleNonces(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	srv, err := NewServer(Options{})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	cl := newTestClient(t, srv)
+
+	// Clients are expected to retry requests rejected because of a stale
+	// nonce once with a fresh nonce.
+	srv.InvalidateNonces()
+	order, err := cl.AuthorizeOrder(ctx, acme.DomainIDs("example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, acme.StatusPending, order.Status)
+}
+
+func TestServerAccountKeyRollover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	srv, err := NewServer(Options{})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	cl := newTestClient(t, srv)
+	acct, err := cl.GetReg(ctx, "")
+	require.NoError(t, err)
+
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	require.NoError(t, cl.AccountKeyRollover(ctx, newKey))
+
+	// The account can only be found using the new key.
+	cl.Key = newKey
+	rolled, err := cl.GetReg(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, acct.URI, rolled.URI)
+}
+
+func assertProblem(t *testing.T, err error, typ string) {
+	t.Helper()
+	var acmeErr *acme.Error
+	if assert.True(t, errors.As(err, &acmeErr), "expected an ACME error, got %v", err) {
+		assert.Equal(t, "urn:ietf:params:acme:error:"+typ, acmeErr.ProblemType)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit tracks the certificates issued by ACME issuers for each
+// registered domain against the rate limits of ACME servers, such as the
+// Let's Encrypt limit on the number of certificates per registered domain.
+package ratelimit
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// DefaultWindow is the window of limits whose window is not configured.
+const DefaultWindow = 7 * 24 * time.Hour
+
+// Limit is a limit on the number of certificates issued for a registered
+// domain within a window.
+type Limit struct {
+	// Certificates is the number of certificates issued for a registered
+	// domain within the window.
+	Certificates int
+	// Window is the period over which issued certificates count against the
+	// limit.
+	Window time.Duration
+}
+
+// knownLimits are the limits of well-known ACME servers, keyed by the URL of
+// their directory.
+var knownLimits = map[string]Limit{
+	"https://acme-v02.api.letsencrypt.org/directory":         {Certificates: 50, Window: DefaultWindow},
+	"https://acme-staging-v02.api.letsencrypt.org/directory": {Certificates: 30000, Window: DefaultWindow},
+}
+
+// LimitForIssuer returns the limit tracked for an ACME issuer, which is the
+// limit configured on the issuer or otherwise the limit of its ACME server if
+// it is well-known. It returns false if no limit is tracked for the issuer.
+func LimitForIssuer(acme *cmacme.ACMEIssuer) (Limit, bool) {
+	limit, known := knownLimits[acme.Server]
+	if acme.RateLimitBudget != nil {
+		if acme.RateLimitBudget.CertificatesPerRegisteredDomain > 0 {
+			limit.Certificates = acme.RateLimitBudget.CertificatesPerRegisteredDomain
+			known = true
+		}
+		if acme.RateLimitBudget.Window != nil {
+			limit.Window = acme.RateLimitBudget.Window.Duration
+		}
+	}
+	if !known {
+		return Limit{}, false
+	}
+	if limit.Window <= 0 {
+		limit.Window = DefaultWindow
+	}
+	return limit, true
+}
+
+// RegisteredDomain returns the registered domain of a DNS name, which is the
+// public suffix of the name plus one label, e.g. example.com for
+// *.www.example.com.
+func RegisteredDomain(dnsName string) (string, error) {
+	dnsName = strings.TrimPrefix(strings.TrimSuffix(dnsName, "."), "*.")
+	return publicsuffix.EffectiveTLDPlusOne(strings.ToLower(dnsName))
+}
+
+// RegisteredDomains returns the sorted registered domains of the given DNS
+// names. Names which have no registered domain, such as public suffixes, are
+// skipped.
+func RegisteredDomains(dnsNames []string) []string {
+	seen := map[string]bool{}
+	var domains []string
+	for _, dnsName := range dnsNames {
+		domain, err := RegisteredDomain(dnsName)
+		if err != nil || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// Issuance is a certificate issued by an ACME issuer.
+type Issuance struct {
+	// Time is the time the certificate was issued.
+	Time time.Time
+	// DNSNames are the DNS names of the certificate.
+	DNSNames []string
+}
+
+// Budgets returns the budget of each registered domain which certificates
+// were issued for within the window of the limit ending at now, sorted by
+// registered domain. A certificate counts once against each of its
+// registered domains.
+func Budgets(limit Limit, issuances []Issuance, now time.Time) []cmacme.ACMERateLimitBudgetStatus {
+	start := now.Add(-limit.Window)
+	issued := map[string]int{}
+	for _, issuance := range issuances {
+		if issuance.Time.Before(start) || issuance.Time.After(now) {
+			continue
+		}
+		for _, domain := range RegisteredDomains(issuance.DNSNames) {
+			issued[domain]++
+		}
+	}
+
+	budgets := make([]cmacme.ACMERateLimitBudgetStatus, 0, len(issued))
+	for domain, n := range issued {
+		remaining := limit.Certificates - n
+		if remaining < 0 {
+			remaining = 0
+		}
+		budgets = append(budgets, cmacme.ACMERateLimitBudgetStatus{
+			RegisteredDomain: domain,
+			Issued:           n,
+			Remaining:        remaining,
+		})
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].RegisteredDomain < budgets[j].RegisteredDomain
+	})
+	return budgets
+}
+
+// Remaining returns the smallest remaining budget of the registered domains
+// of the given DNS names, as recorded in the status of an issuer, and the
+// registered domain it belongs to. The full limit remains for registered
+// domains which have no recorded budget.
+func Remaining(limit Limit, budgets []cmacme.ACMERateLimitBudgetStatus, dnsNames []string) (int, string) {
+	remaining, domain := limit.Certificates, ""
+	for _, d := range RegisteredDomains(dnsNames) {
+		for _, budget := range budgets {
+			if budget.RegisteredDomain == d && budget.Remaining < remaining {
+				remaining, domain = budget.Remaining, d
+			}
+		}
+	}
+	return remaining, domain
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestLimitForIssuer(t *testing.T) {
+	tests := map[string]struct {
+		acme       *cmacme.ACMEIssuer
+		expected   Limit
+		expectedOK bool
+	}{
+		"well-known ACME server": {
+			acme:       &cmacme.ACMEIssuer{Server: "https://acme-v02.api.letsencrypt.org/directory"},
+			expected:   Limit{Certificates: 50, Window: DefaultWindow},
+			expectedOK: true,
+		},
+		"well-known ACME server with a configured window": {
+			acme: &cmacme.ACMEIssuer{
+				Server:          "https://acme-v02.api.letsencrypt.org/directory",
+				RateLimitBudget: &cmacme.ACMERateLimitBudget{Window: &metav1.Duration{Duration: time.Hour}},
+			},
+			expected:   Limit{Certificates: 50, Window: time.Hour},
+			expectedOK: true,
+		},
+		"unknown ACME server": {
+			acme:       &cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"},
+			expectedOK: false,
+		},
+		"unknown ACME server with a configured limit": {
+			acme: &cmacme.ACMEIssuer{
+				Server:          "https://acme.example.com/directory",
+				RateLimitBudget: &cmacme.ACMERateLimitBudget{CertificatesPerRegisteredDomain: 10},
+			},
+			expected:   Limit{Certificates: 10, Window: DefaultWindow},
+			expectedOK: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			limit, ok := LimitForIssuer(test.acme)
+			if ok != test.expectedOK || limit != test.expected {
+				t.Errorf("expected %+v, %t, got %+v, %t", test.expected, test.expectedOK, limit, ok)
+			}
+		})
+	}
+}
+
+func TestBudgets(t *testing.T) {
+	now := time.Now()
+	limit := Limit{Certificates: 3, Window: time.Hour}
+	issuances := []Issuance{
+		{Time: now.Add(-time.Minute), DNSNames: []string{"example.com", "*.example.com", "www.example.co.uk"}},
+		{Time: now.Add(-time.Minute * 30), DNSNames: []string{"a.example.com"}},
+		{Time: now.Add(-time.Minute * 40), DNSNames: []string{"b.example.com"}},
+		{Time: now.Add(-time.Minute * 50), DNSNames: []string{"c.example.com"}},
+		// issuances outside of the window do not count
+		{Time: now.Add(-time.Hour * 2), DNSNames: []string{"example.org"}},
+		// public suffixes have no registered domain
+		{Time: now.Add(-time.Minute), DNSNames: []string{"co.uk"}},
+	}
+
+	expected := []cmacme.ACMERateLimitBudgetStatus{
+		{RegisteredDomain: "example.co.uk", Issued: 1, Remaining: 2},
+		{RegisteredDomain: "example.com", Issued: 4, Remaining: 0},
+	}
+	budgets := Budgets(limit, issuances, now)
+	if !reflect.DeepEqual(expected, budgets) {
+		t.Fatalf("expected budgets %+v, got %+v", expected, budgets)
+	}
+
+	remaining, domain := Remaining(limit, budgets, []string{"www.example.co.uk", "www.example.com"})
+	if remaining != 0 || domain != "example.com" {
+		t.Errorf("expected no budget to remain for example.com, got %d for %q", remaining, domain)
+	}
+	remaining, domain = Remaining(limit, budgets, []string{"example.net"})
+	if remaining != 3 || domain != "" {
+		t.Errorf("expected the full budget to remain for example.net, got %d for %q", remaining, domain)
+	}
+}
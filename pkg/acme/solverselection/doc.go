@@ -0,0 +1,40 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package solverselection implements the selection of the ACME challenge
+// solver, out of those configured on an ACME Issuer, that is used to solve
+// the challenge for an identifier of an ACME order.
+//
+// A solver is eligible for an identifier if the ACME server offers a
+// challenge of the solver's type, and if all of the solver's selectors
+// (matchLabels, dnsNames and dnsZones) match. A solver without a selector
+// matches every identifier.
+//
+// Out of the eligible solvers, the most specific one is selected. Solvers
+// are ranked by, in order of precedence:
+//
+//  1. whether one of the solver's dnsNames matches the identifier,
+//  2. the number of labels of the longest of the solver's dnsZones matching
+//     the identifier,
+//  3. the number of the solver's matchLabels.
+//
+// If several eligible solvers have the same rank, the one listed first is
+// selected.
+//
+// This package is used by the orders controller, and may be used by other
+// components which need to determine which solver the orders controller
+// would select.
+package solverselection
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solverselection
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// ErrNoSolver is returned by Select if none of the configured solvers can be
+// used for the identifier.
+var ErrNoSolver = errors.New("no configured challenge solvers can be used for this challenge")
+
+// Request describes the identifier for which a solver is selected.
+type Request struct {
+	// ObjectMeta is the metadata of the resource, usually an Order, for
+	// which the solver is selected. Its labels are compared with the
+	// solvers' matchLabels selectors.
+	ObjectMeta metav1.ObjectMeta
+
+	// DNSName is the DNS name being authorized, without any wildcard prefix.
+	DNSName string
+
+	// Wildcard is true if the authorization is for the wildcard of DNSName.
+	Wildcard bool
+
+	// ChallengeTypes are the types of the challenges offered by the ACME
+	// server for the identifier.
+	ChallengeTypes []cmacme.ACMEChallengeType
+}
+
+// Selection is the result of selecting a solver.
+type Selection struct {
+	// Index is the index of the selected solver in the list of solvers.
+	Index int
+
+	// Solver is a copy of the selected solver.
+	Solver cmacme.ACMEChallengeSolver
+
+	// Type is the type of challenge to be solved by the selected solver.
+	Type cmacme.ACMEChallengeType
+}
+
+// rank is the specificity of a solver's match. See the package documentation
+// for how solvers are ranked.
+type rank struct {
+	dnsNames bool
+	dnsZones int
+	labels   int
+}
+
+// moreSpecificThan returns true if r is strictly more specific than o.
+func (r rank) moreSpecificThan(o rank) bool {
+	if r.dnsNames != o.dnsNames {
+		return r.dnsNames
+	}
+	if r.dnsZones != o.dnsZones {
+		return r.dnsZones > o.dnsZones
+	}
+	return r.labels > o.labels
+}
+
+// Select returns the most specific of the given solvers that can be used to
+// solve a challenge for the identifier described by the request. ErrNoSolver
+// is returned if none of the solvers can be used.
+func Select(ctx context.Context, solvers []cmacme.ACMEChallengeSolver, req Request) (*Selection, error) {
+	dbg := logf.FromContext(ctx, "solverselection").V(logf.DebugLevel)
+
+	domainToFind := req.DNSName
+	if req.Wildcard {
+		domainToFind = "*." + domainToFind
+	}
+
+	var selected *Selection
+	var selectedRank rank
+	for i, solver := range solvers {
+		dbg := dbg.WithValues("solver_index", i)
+
+		chType, ok := solverType(solver, req.ChallengeTypes)
+		if !ok {
+			dbg.Info("cannot use solver as the ACME authorization does not allow solvers of this type")
+			continue
+		}
+
+		var r rank
+		if solver.Selector != nil {
+			labelsMatch, numLabelsMatch := selectors.Labels(*solver.Selector).Matches(req.ObjectMeta, domainToFind)
+			dnsNamesMatch, numDNSNamesMatch := selectors.DNSNames(*solver.Selector).Matches(req.ObjectMeta, domainToFind)
+			dnsZonesMatch, numDNSZonesMatch := selectors.DNSZones(*solver.Selector).Matches(req.ObjectMeta, domainToFind)
+			if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch {
+				dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch)
+				continue
+			}
+
+			// Multiple dnsName matches do not count as extra weight, since
+			// dnsNames are matched exactly.
+			r = rank{
+				dnsNames: numDNSNamesMatch > 0,
+				dnsZones: numDNSZonesMatch,
+				labels:   numLabelsMatch,
+			}
+		}
+
+		if selected != nil && !r.moreSpecificThan(selectedRank) {
+			dbg.Info("not selecting solver as a previously selected solver is just as or more specific", "selected_solver_index", selected.Index)
+			continue
+		}
+
+		dbg.Info("selecting solver", "dnsnames_match", r.dnsNames, "dnszone_labels_matched", r.dnsZones, "labels_matched", r.labels)
+		selected = &Selection{
+			Index:  i,
+			Solver: *solver.DeepCopy(),
+			Type:   chType,
+		}
+		selectedRank = r
+	}
+
+	if selected == nil {
+		return nil, ErrNoSolver
+	}
+
+	return selected, nil
+}
+
+// solverType returns the type of challenge that the solver can solve, if the
+// ACME server offers a challenge of that type.
+func solverType(solver cmacme.ACMEChallengeSolver, offered []cmacme.ACMEChallengeType) (cmacme.ACMEChallengeType, bool) {
+	for _, t := range offered {
+		switch {
+		case t == cmacme.ACMEChallengeTypeHTTP01 && solver.HTTP01 != nil:
+			return t, true
+		case t == cmacme.ACMEChallengeTypeDNS01 && solver.DNS01 != nil:
+			return t, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solverselection
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+var (
+	bothTypes = []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeHTTP01, cmacme.ACMEChallengeTypeDNS01}
+)
+
+func http01Solver(sel *cmacme.CertificateDNSNameSelector) cmacme.ACMEChallengeSolver {
+	return cmacme.ACMEChallengeSolver{Selector: sel, HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}}
+}
+
+func dns01Solver(sel *cmacme.CertificateDNSNameSelector) cmacme.ACMEChallengeSolver {
+	return cmacme.ACMEChallengeSolver{Selector: sel, DNS01: &cmacme.ACMEChallengeSolverDNS01{}}
+}
+
+func TestSelect(t *testing.T) {
+	labels := map[string]string{"team": "a", "env": "prod"}
+
+	tests := map[string]struct {
+		solvers  []cmacme.ACMEChallengeSolver
+		req      Request
+		expIndex int
+		expType  cmacme.ACMEChallengeType
+		expErr   error
+	}{
+		"no solvers": {
+			req:    Request{DNSName: "example.com", ChallengeTypes: bothTypes},
+			expErr: ErrNoSolver,
+		},
+		"no solver of an offered type": {
+			solvers: []cmacme.ACMEChallengeSolver{dns01Solver(nil)},
+			req:     Request{DNSName: "example.com", ChallengeTypes: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeHTTP01}},
+			expErr:  ErrNoSolver,
+		},
+		"first solver without a selector": {
+			solvers: []cmacme.ACMEChallengeSolver{http01Solver(nil), dns01Solver(nil)},
+			req:     Request{DNSName: "example.com", ChallengeTypes: bothTypes},
+			expType: cmacme.ACMEChallengeTypeHTTP01,
+		},
+		"skip solvers of types not offered": {
+			solvers:  []cmacme.ACMEChallengeSolver{http01Solver(nil), dns01Solver(nil)},
+			req:      Request{DNSName: "example.com", ChallengeTypes: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01}},
+			expIndex: 1,
+			expType:  cmacme.ACMEChallengeTypeDNS01,
+		},
+		"skip solvers whose selector does not match": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01Solver(&cmacme.CertificateDNSNameSelector{DNSNames: []string{"other.com"}}),
+				dns01Solver(nil),
+			},
+			req:      Request{DNSName: "example.com", ChallengeTypes: bothTypes},
+			expIndex: 1,
+			expType:  cmacme.ACMEChallengeTypeDNS01,
+		},
+		"dnsNames are more specific than dnsZones": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01Solver(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"www.example.com"}}),
+				dns01Solver(&cmacme.CertificateDNSNameSelector{DNSNames: []string{"www.example.com"}}),
+			},
+			req:      Request{DNSName: "www.example.com", ChallengeTypes: bothTypes},
+			expIndex: 1,
+			expType:  cmacme.ACMEChallengeTypeDNS01,
+		},
+		"longer dnsZones are more specific than matchLabels": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01Solver(&cmacme.CertificateDNSNameSelector{MatchLabels: labels}),
+				http01Solver(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}),
+				dns01Solver(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"sub.example.com"}}),
+			},
+			req:      Request{ObjectMeta: metav1.ObjectMeta{Labels: labels}, DNSName: "www.sub.example.com", ChallengeTypes: bothTypes},
+			expIndex: 2,
+			expType:  cmacme.ACMEChallengeTypeDNS01,
+		},
+		"more matchLabels are more specific": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01Solver(&cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"team": "a"}}),
+				dns01Solver(&cmacme.CertificateDNSNameSelector{MatchLabels: labels}),
+			},
+			req:      Request{ObjectMeta: metav1.ObjectMeta{Labels: labels}, DNSName: "example.com", ChallengeTypes: bothTypes},
+			expIndex: 1,
+			expType:  cmacme.ACMEChallengeTypeDNS01,
+		},
+		"first of equally specific solvers": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01Solver(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}),
+				dns01Solver(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}),
+			},
+			req:     Request{DNSName: "example.com", ChallengeTypes: bothTypes},
+			expType: cmacme.ACMEChallengeTypeHTTP01,
+		},
+		"wildcard identifiers match dnsNames with the wildcard prefix": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01Solver(&cmacme.CertificateDNSNameSelector{DNSNames: []string{"example.com"}}),
+				dns01Solver(&cmacme.CertificateDNSNameSelector{DNSNames: []string{"*.example.com"}}),
+			},
+			req:      Request{DNSName: "example.com", Wildcard: true, ChallengeTypes: bothTypes},
+			expIndex: 1,
+			expType:  cmacme.ACMEChallengeTypeDNS01,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			selection, err := Select(context.Background(), test.solvers, test.req)
+			if test.expErr != nil {
+				assert.Equal(t, test.expErr, err)
+				assert.Nil(t, selection)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expIndex, selection.Index)
+			assert.Equal(t, test.solvers[test.expIndex], selection.Solver)
+			assert.Equal(t, test.expType, selection.Type)
+		})
+	}
+}
+
+var (
+	propertyLabels = []string{"a", "b", "c"}
+	propertyDNS    = []string{"example.com", "www.example.com", "sub.example.com", "www.sub.example.com", "example.org"}
+)
+
+// randomSolver returns a solver with a selector built from a small set of
+// values, so that solvers frequently match and tie with each other.
+func randomSolver(r *rand.Rand) cmacme.ACMEChallengeSolver {
+	var sel *cmacme.CertificateDNSNameSelector
+	if r.Intn(4) > 0 {
+		sel = &cmacme.CertificateDNSNameSelector{}
+		for _, l := range propertyLabels {
+			if r.Intn(3) == 0 {
+				if sel.MatchLabels == nil {
+					sel.MatchLabels = make(map[string]string)
+				}
+				sel.MatchLabels[l] = "true"
+			}
+		}
+		for _, d := range propertyDNS {
+			if r.Intn(5) == 0 {
+				sel.DNSNames = append(sel.DNSNames, d)
+			}
+			if r.Intn(5) == 0 {
+				sel.DNSZones = append(sel.DNSZones, d)
+			}
+		}
+	}
+	if r.Intn(2) == 0 {
+		return http01Solver(sel)
+	}
+	return dns01Solver(sel)
+}
+
+func randomRequest(r *rand.Rand) Request {
+	req := Request{
+		ObjectMeta: metav1.ObjectMeta{Labels: make(map[string]string)},
+		DNSName:    propertyDNS[r.Intn(len(propertyDNS))],
+	}
+	for _, l := range propertyLabels {
+		if r.Intn(2) == 0 {
+			req.ObjectMeta.Labels[l] = "true"
+		}
+	}
+	for _, t := range bothTypes {
+		if r.Intn(4) > 0 {
+			req.ChallengeTypes = append(req.ChallengeTypes, t)
+		}
+	}
+	return req
+}
+
+// expectedRank independently computes whether the solver is eligible for the
+// request, and its rank, from the rules in the package documentation.
+func expectedRank(solver cmacme.ACMEChallengeSolver, req Request) (rank, bool) {
+	offered := false
+	for _, t := range req.ChallengeTypes {
+		if (t == cmacme.ACMEChallengeTypeHTTP01 && solver.HTTP01 != nil) || (t == cmacme.ACMEChallengeTypeDNS01 && solver.DNS01 != nil) {
+			offered = true
+		}
+	}
+	if !offered {
+		return rank{}, false
+	}
+	sel := solver.Selector
+	if sel == nil {
+		return rank{}, true
+	}
+
+	var r rank
+	for k, v := range sel.MatchLabels {
+		if req.ObjectMeta.Labels[k] != v {
+			return rank{}, false
+		}
+		r.labels++
+	}
+	for _, d := range sel.DNSNames {
+		if d == req.DNSName {
+			r.dnsNames = true
+		}
+	}
+	if len(sel.DNSNames) > 0 && !r.dnsNames {
+		return rank{}, false
+	}
+	for _, z := range sel.DNSZones {
+		if (req.DNSName == z || strings.HasSuffix(req.DNSName, "."+z)) && strings.Count(z, ".")+1 > r.dnsZones {
+			r.dnsZones = strings.Count(z, ".") + 1
+		}
+	}
+	if len(sel.DNSZones) > 0 && r.dnsZones == 0 {
+		return rank{}, false
+	}
+	return r, true
+}
+
+// TestSelect_properties checks that Select always selects the first of the
+// most specific eligible solvers, and that the selection is stable when
+// solvers which could not have been selected are added or removed.
+func TestSelect_properties(t *testing.T) {
+	const iterations = 5000
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < iterations; i++ {
+		solvers := make([]cmacme.ACMEChallengeSolver, r.Intn(6))
+		for j := range solvers {
+			solvers[j] = randomSolver(r)
+		}
+		req := randomRequest(r)
+
+		expIndex := -1
+		var expRank rank
+		for j, s := range solvers {
+			sr, ok := expectedRank(s, req)
+			if ok && (expIndex == -1 || sr.moreSpecificThan(expRank)) {
+				expIndex, expRank = j, sr
+			}
+		}
+
+		selection, err := Select(context.Background(), solvers, req)
+		if expIndex == -1 {
+			if err != ErrNoSolver {
+				t.Fatalf("iteration %d: expected ErrNoSolver, got selection=%v err=%v", i, selection, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("iteration %d: unexpected error: %v", i, err)
+		}
+		if selection.Index != expIndex {
+			t.Fatalf("iteration %d: expected solver %d to be selected, got %d\nsolvers=%+v\nrequest=%+v", i, expIndex, selection.Index, solvers, req)
+		}
+
+		// Appending a solver without a selector never changes the selection.
+		appended, err := Select(context.Background(), append(solvers[:len(solvers):len(solvers)], http01Solver(nil), dns01Solver(nil)), req)
+		if err != nil || appended.Index != expIndex {
+			t.Fatalf("iteration %d: appending a solver without a selector changed the selection from %d to %+v (err=%v)", i, expIndex, appended, err)
+		}
+
+		// Removing a solver listed before the selected one changes only the
+		// index of the selection, unless the removed solver was just as
+		// specific.
+		if expIndex > 0 {
+			removed, err := Select(context.Background(), solvers[1:], req)
+			require.NoError(t, err)
+			if sr, ok := expectedRank(solvers[0], req); !ok || expRank.moreSpecificThan(sr) {
+				if removed.Index != expIndex-1 {
+					t.Fatalf("iteration %d: removing a less specific solver changed the selection", i)
+				}
+			}
+		}
+	}
+}
+
+// TestSelect_fuzz checks that Select does not panic on arbitrary input, and
+// that any solver it selects can solve one of the offered challenge types.
+func TestSelect_fuzz(t *testing.T) {
+	const iterations = 1000
+	f := fuzz.New().NilChance(0.3).NumElements(0, 4)
+
+	for i := 0; i < iterations; i++ {
+		var solvers []cmacme.ACMEChallengeSolver
+		var req Request
+		f.Fuzz(&solvers)
+		f.Fuzz(&req.ObjectMeta.Labels)
+		f.Fuzz(&req.DNSName)
+		f.Fuzz(&req.Wildcard)
+		req.ChallengeTypes = bothTypes[:i%3]
+
+		selection, err := Select(context.Background(), solvers, req)
+		if err != nil {
+			assert.Equal(t, ErrNoSolver, err)
+			continue
+		}
+		assert.Equal(t, solvers[selection.Index], selection.Solver)
+		assert.Contains(t, req.ChallengeTypes, selection.Type)
+	}
+}
@@ -17,10 +17,15 @@ limitations under the License.
 package acme
 
 import (
+	"errors"
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 // IsFinalState will return true if the given ACME State is a 'final' state.
@@ -43,6 +48,53 @@ func IsFailureState(s cmacme.State) bool {
 	return false
 }
 
+// CheckMaxIdentifiers returns an error naming the limit if an Order with the
+// given spec has more identifiers than the issuer's ACME server accepts in a
+// single order, as configured by the issuer's maxIdentifiersPerOrder field.
+func CheckMaxIdentifiers(spec cmacme.OrderSpec, issuer *cmacme.ACMEIssuer) error {
+	if issuer == nil || issuer.MaxIdentifiersPerOrder == nil {
+		return nil
+	}
+
+	dnsNames := sets.NewString(spec.DNSNames...)
+	if spec.CommonName != "" {
+		dnsNames.Insert(spec.CommonName)
+	}
+	identifiers := dnsNames.Len() + sets.NewString(spec.IPAddresses...).Len()
+	if max := *issuer.MaxIdentifiersPerOrder; identifiers > max {
+		return fmt.Errorf("the request has %d DNS names and IP addresses but the issuer's ACME server accepts at most %d per order (spec.acme.maxIdentifiersPerOrder), split them across multiple Certificates", identifiers, max)
+	}
+	return nil
+}
+
+// ProblemFromError returns the problem document of the given ACME error,
+// including its subproblems, so that it can be stored on the status of an
+// Order or Challenge. It returns nil if err is not an ACME error.
+func ProblemFromError(err error) *cmacme.ACMEProblem {
+	var acmeErr *acmeapi.Error
+	if !errors.As(err, &acmeErr) {
+		return nil
+	}
+
+	problem := &cmacme.ACMEProblem{
+		Type:   acmeErr.ProblemType,
+		Detail: acmeErr.Detail,
+		Status: acmeErr.StatusCode,
+	}
+	for _, sp := range acmeErr.Subproblems {
+		subproblem := cmacme.ACMESubproblem{
+			Type:   sp.Type,
+			Detail: sp.Detail,
+		}
+		if sp.Identifier != nil {
+			subproblem.Identifier = sp.Identifier.Value
+			subproblem.IdentifierType = cmacme.ACMEIdentifierType(sp.Identifier.Type)
+		}
+		problem.Subproblems = append(problem.Subproblems, subproblem)
+	}
+	return problem
+}
+
 // PrivateKeySelector will default the SecretKeySelector with a default secret key
 // if one is not already specified.
 func PrivateKeySelector(sel cmmeta.SecretKeySelector) cmmeta.SecretKeySelector {
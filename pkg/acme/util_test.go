@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+func TestProblemFromError(t *testing.T) {
+	acmeErr := &acmeapi.Error{
+		StatusCode:  400,
+		ProblemType: "urn:ietf:params:acme:error:rejectedIdentifier",
+		Detail:      "Some identifiers were rejected",
+		Subproblems: []acmeapi.Subproblem{
+			{
+				Type:       "urn:ietf:params:acme:error:rejectedIdentifier",
+				Detail:     "Policy forbids issuing for name",
+				Identifier: &acmeapi.AuthzID{Type: "dns", Value: "example.com"},
+			},
+			{
+				Type:   "urn:ietf:params:acme:error:malformed",
+				Detail: "Invalid identifier",
+			},
+		},
+	}
+	expected := &cmacme.ACMEProblem{
+		Type:   "urn:ietf:params:acme:error:rejectedIdentifier",
+		Detail: "Some identifiers were rejected",
+		Status: 400,
+		Subproblems: []cmacme.ACMESubproblem{
+			{
+				Type:           "urn:ietf:params:acme:error:rejectedIdentifier",
+				Detail:         "Policy forbids issuing for name",
+				Identifier:     "example.com",
+				IdentifierType: cmacme.ACMEIdentifierTypeDNS,
+			},
+			{
+				Type:   "urn:ietf:params:acme:error:malformed",
+				Detail: "Invalid identifier",
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		err      error
+		expected *cmacme.ACMEProblem
+	}{
+		"nil error": {
+			err:      nil,
+			expected: nil,
+		},
+		"non ACME error": {
+			err:      errors.New("some error"),
+			expected: nil,
+		},
+		"ACME error": {
+			err:      acmeErr,
+			expected: expected,
+		},
+		"wrapped ACME error": {
+			err:      fmt.Errorf("error finalizing order: %w", acmeErr),
+			expected: expected,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if problem := ProblemFromError(test.err); !reflect.DeepEqual(problem, test.expected) {
+				t.Errorf("expected problem %#v, got %#v", test.expected, problem)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -372,3 +373,89 @@ func CertificateRequestIsDenied(cr *cmapi.CertificateRequest) bool {
 
 	return false
 }
+
+// GetChallengeCondition returns the condition of the given type of the
+// Challenge, or nil if the Challenge does not have the condition.
+func GetChallengeCondition(ch *cmacme.Challenge, conditionType cmacme.ChallengeConditionType) *cmacme.ChallengeCondition {
+	for _, cond := range ch.Status.Conditions {
+		if cond.Type == conditionType {
+			return &cond
+		}
+	}
+	return nil
+}
+
+// SetChallengeCondition will set a 'condition' on the given Challenge.
+//   - If no condition of the same type already exists, the condition will be
+//     inserted with the LastTransitionTime set to the current time.
+//   - If a condition of the same type and state already exists, the condition
+//     will be updated but the LastTransitionTime will not be modified.
+//   - If a condition of the same type and different state already exists, the
+//     condition will be updated with the LastTransitionTime set to the current
+//     time.
+func SetChallengeCondition(ch *cmacme.Challenge, conditionType cmacme.ChallengeConditionType, status cmmeta.ConditionStatus, reason, message string) {
+	newCondition := cmacme.ChallengeCondition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+
+	nowTime := metav1.NewTime(Clock.Now())
+	newCondition.LastTransitionTime = &nowTime
+
+	for idx, cond := range ch.Status.Conditions {
+		if cond.Type != conditionType {
+			continue
+		}
+
+		if cond.Status == status {
+			newCondition.LastTransitionTime = cond.LastTransitionTime
+		}
+
+		ch.Status.Conditions[idx] = newCondition
+		return
+	}
+
+	ch.Status.Conditions = append(ch.Status.Conditions, newCondition)
+}
+
+// SetCertificateAuthorityCondition will set a 'condition' on the given
+// CertificateAuthority.
+//   - If no condition of the same type already exists, the condition will be
+//     inserted with the LastTransitionTime set to the current time.
+//   - If a condition of the same type and state already exists, the condition
+//     will be updated but the LastTransitionTime will not be modified.
+//   - If a condition of the same type and different state already exists, the
+//     condition will be updated with the LastTransitionTime set to the current
+//     time.
+func SetCertificateAuthorityCondition(ca *cmapi.CertificateAuthority, observedGeneration int64, conditionType cmapi.CertificateAuthorityConditionType,
+	status cmmeta.ConditionStatus, reason, message string) {
+	newCondition := cmapi.CertificateAuthorityCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: observedGeneration,
+	}
+
+	nowTime := metav1.NewTime(Clock.Now())
+	newCondition.LastTransitionTime = &nowTime
+
+	for idx, cond := range ca.Status.Conditions {
+		if cond.Type != conditionType {
+			continue
+		}
+
+		// If this update doesn't contain a state transition, we don't update the
+		// conditions LastTransitionTime to Now()
+		if cond.Status == status {
+			newCondition.LastTransitionTime = cond.LastTransitionTime
+		}
+
+		ca.Status.Conditions[idx] = newCondition
+		return
+	}
+
+	ca.Status.Conditions = append(ca.Status.Conditions, newCondition)
+}
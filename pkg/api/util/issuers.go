@@ -34,6 +34,9 @@ const (
 	IssuerSelfSigned string = "selfsigned"
 	// IssuerVenafi uses Venafi Trust Protection Platform and Venafi Cloud
 	IssuerVenafi string = "venafi"
+	// IssuerKubernetesCSR signs using the Kubernetes CertificateSigningRequest
+	// API
+	IssuerKubernetesCSR string = "kubernetescsr"
 )
 
 // NameForIssuer determines the name of the Issuer implementation given an
@@ -50,6 +53,8 @@ func NameForIssuer(i cmapi.GenericIssuer) (string, error) {
 		return IssuerSelfSigned, nil
 	case i.GetSpec().Venafi != nil:
 		return IssuerVenafi, nil
+	case i.GetSpec().KubernetesCSR != nil:
+		return IssuerKubernetesCSR, nil
 	}
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.GetObjectMeta().Namespace, i.GetObjectMeta().Name)
 }
@@ -18,4 +18,9 @@ package v1
 
 const (
 	ACMEFinalizer = "finalizer.acme.cert-manager.io"
+
+	// ACMEAccountDeactivationFinalizer is added to ACME Issuers and
+	// ClusterIssuers which have deactivateAccountOnDeletion set, so that
+	// their ACME account can be deactivated before they are removed.
+	ACMEAccountDeactivationFinalizer = "acme.cert-manager.io/deactivate-account"
 )
@@ -34,6 +34,26 @@ const (
 	// solver for each ingress class.
 	ACMECertificateHTTP01IngressClassOverride = "acme.cert-manager.io/http01-override-ingress-class"
 
+	// ACMECertificateProfileOverride is an annotation to override the
+	// certificate profile requested from the ACME server.
+	// If this annotation is specified on a Certificate or CertificateRequest
+	// resource, the profile given here is requested instead of the profile
+	// configured on the ACME issuer.
+	ACMECertificateProfileOverride = "acme.cert-manager.io/profile"
+
+	// ForceCleanUpAnnotationKey can be set to "true" on a Challenge to make
+	// cert-manager give up cleaning up the presented challenge values, for
+	// example when the DNS provider credentials have been revoked. The
+	// records which must then be deleted manually are reported in an Event.
+	ForceCleanUpAnnotationKey = "acme.cert-manager.io/force-cleanup"
+
+	// AccountKeyRotationAnnotationKey can be set on an ACME Issuer or
+	// ClusterIssuer to rotate the private key of its ACME account using the
+	// ACME server's keyChange endpoint. The key is rotated whenever the value
+	// of the annotation changes, e.g. to the current date, and the handled
+	// value is recorded in status.acme.accountKeyRotation.
+	AccountKeyRotationAnnotationKey = "acme.cert-manager.io/account-key-rotation"
+
 	// IngressEditInPlaceAnnotationKey is used to toggle the use of ingressClass instead
 	// of ingress on the created Certificate resource
 	IngressEditInPlaceAnnotationKey = "acme.cert-manager.io/http01-edit-in-place"
@@ -49,6 +69,11 @@ const (
 	// SolverIdentificationLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the "true" if the Pod is an HTTP-01 solver.
 	SolverIdentificationLabelKey = "acme.cert-manager.io/http01-solver"
+
+	// SolverGroupLabelKey is added to the labels of a Pod, Service and Ingress
+	// shared by the HTTP-01 challenges of an Order which use the same solver.
+	// Its value will be a hash identifying the Order and solver.
+	SolverGroupLabelKey = "acme.cert-manager.io/http01-solver-group"
 )
 
 const (
@@ -55,6 +55,22 @@ const (
 	// as a 'next private key' Secret resource.
 	IsNextPrivateKeySecretLabelKey = "cert-manager.io/next-private-key"
 
+	// Label key used to denote the cert-manager instance which created a
+	// resource, when the controller is run with --owned-by. Instances run
+	// with --owned-by will not modify resources which do not carry this label
+	// with their own value.
+	OwnedByLabelKey = "cert-manager.io/owned-by"
+
+	// Label key used to record the UID of the resource controlling a
+	// resource created by a cert-manager instance run with --owned-by.
+	ControllerUIDLabelKey = "cert-manager.io/controller-uid"
+
+	// Annotation key used to assign an Issuer, ClusterIssuer, Certificate,
+	// Ingress or Gateway to the cert-manager instance run with the matching
+	// --issuer-class. Resources without this annotation are only processed by
+	// instances run without --issuer-class.
+	IssuerClassAnnotationKey = "cert-manager.io/class"
+
 	// Annotation key used to limit the number of CertificateRequests to be kept for a Certificate.
 	// Minimum value is 1.
 	// If unset all CertificateRequests will be kept.
@@ -86,6 +102,16 @@ const (
 	// Annotation key used to set the PrivateKeyRotationPolicy for a Certificate.
 	// If unset a policy `Never` will be used.
 	PrivateKeyRotationPolicyAnnotationKey = "cert-manager.io/private-key-rotation-policy"
+
+	// Annotation key set on a Certificate's canary shadow Secret to the name
+	// of the CertificateRequest which issued the candidate certificate.
+	CanaryRequestAnnotationKey = "cert-manager.io/canary-request"
+
+	// Annotation key set on a Certificate's canary shadow Secret by a
+	// verification hook once the candidate certificate has been verified. The
+	// value must match the value of the CanaryRequestAnnotationKey annotation
+	// for the candidate to be promoted.
+	CanaryVerifiedAnnotationKey = "cert-manager.io/canary-verified"
 )
 
 const (
@@ -115,8 +141,30 @@ const (
 	// issuer type to self-sign certificates.
 	CertificateRequestPrivateKeyAnnotationKey = "cert-manager.io/private-key-secret-name"
 
+	// Annotation added to CertificateRequest resources by issuers which
+	// generate the private key of the certificate themselves, rather than
+	// signing the public key of the CSR, to denote the name of a Secret
+	// resource in the same namespace containing the private key of the issued
+	// certificate under the 'tls.key' key.
+	// If present, this private key is stored in the Certificate's Secret
+	// instead of the private key generated by cert-manager.
+	CertificateRequestIssuerPrivateKeyAnnotationKey = "cert-manager.io/issuer-private-key-secret-name"
+
 	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// Annotation added with the value "true" to the CertificateRequest
+	// resources created for a Certificate's additional private key. The
+	// private key is stored in the Secret named by the
+	// 'cert-manager.io/private-key-secret-name' annotation under the
+	// 'tls-additional.key' key.
+	CertificateRequestAdditionalPrivateKeyAnnotationKey = "cert-manager.io/additional-private-key"
+
+	// Annotation added to the Kubernetes CertificateSigningRequests created by
+	// the KubernetesCSR issuer to denote the namespace and name of the
+	// CertificateRequest they were created for, in the form
+	// `<namespace>/<name>`.
+	CertificateRequestSourceAnnotationKey = "cert-manager.io/certificaterequest"
 )
 
 const (
@@ -126,14 +174,27 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// IssuanceRequestAnnotationKey is an annotation that can be added to
+	// Certificate resources by users or external controllers to request the
+	// issuance of a new certificate. Its value is opaque: an issuance is
+	// triggered each time it is set to a value which differs from
+	// `status.lastIssuanceRequest`, even if the current certificate is still
+	// valid and re-issuance is backing off after failures. The request is
+	// acknowledged by setting `status.lastIssuanceRequest` to the value and
+	// the `IssuanceRequested` condition to `True`. That condition becomes
+	// `False` once the requested issuance has completed, with the reason
+	// `Issued` if it succeeded or `Failed` if it did not.
+	IssuanceRequestAnnotationKey = "cert-manager.io/issuance-request"
 )
 
 // Common/known resource kinds.
 const (
-	ClusterIssuerKind      = "ClusterIssuer"
-	IssuerKind             = "Issuer"
-	CertificateKind        = "Certificate"
-	CertificateRequestKind = "CertificateRequest"
+	ClusterIssuerKind        = "ClusterIssuer"
+	IssuerKind               = "Issuer"
+	CertificateKind          = "Certificate"
+	CertificateRequestKind   = "CertificateRequest"
+	CertificateAuthorityKind = "CertificateAuthority"
 )
 
 const (
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+
+// A CertificateAuthority provisions a chain of certificate authorities,
+// consisting of a self-signed root and any number of intermediates, and
+// exposes the last CA of the chain as a ClusterIssuer.
+// The Certificates, Secrets and ClusterIssuers making up the chain are
+// created in the cluster resource namespace and owned by the
+// CertificateAuthority.
+type CertificateAuthority struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the CertificateAuthority resource.
+	Spec CertificateAuthoritySpec `json:"spec"`
+
+	// Status of the CertificateAuthority. This is set and managed automatically.
+	// +optional
+	Status CertificateAuthorityStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateAuthorityList is a list of CertificateAuthorities
+type CertificateAuthorityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CertificateAuthority `json:"items"`
+}
+
+// CertificateAuthoritySpec describes the chain of certificate authorities to
+// provision.
+type CertificateAuthoritySpec struct {
+	// Root is the self-signed root certificate authority of the chain.
+	Root CertificateAuthorityCA `json:"root"`
+
+	// Intermediates is the list of intermediate certificate authorities of the
+	// chain, ordered from the one issued by the root to the one backing the
+	// ClusterIssuer.
+	// If empty, the ClusterIssuer issues certificates from the root directly.
+	// +optional
+	Intermediates []CertificateAuthorityCA `json:"intermediates,omitempty"`
+
+	// IssuerName is the name of the ClusterIssuer issuing certificates from
+	// the last certificate authority of the chain.
+	// Defaults to the name of the CertificateAuthority.
+	// +optional
+	IssuerName string `json:"issuerName,omitempty"`
+}
+
+// CertificateAuthorityCA describes a certificate authority of the chain.
+type CertificateAuthorityCA struct {
+	// CommonName is the common name of the certificate authority.
+	CommonName string `json:"commonName"`
+
+	// Full X509 name specification of the certificate authority.
+	// +optional
+	Subject *X509Subject `json:"subject,omitempty"`
+
+	// The requested 'duration' (i.e. lifetime) of the certificate authority.
+	// If unset, the default duration of Certificates is used.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// How long before the currently issued certificate's expiry
+	// cert-manager should renew the certificate authority.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Options to control private keys used for the certificate authority.
+	// +optional
+	PrivateKey *CertificatePrivateKey `json:"privateKey,omitempty"`
+}
+
+// CertificateAuthorityStatus defines the observed state of the
+// CertificateAuthority.
+type CertificateAuthorityStatus struct {
+	// List of status conditions to indicate the status of the
+	// CertificateAuthority.
+	// Known condition types are `Ready`.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []CertificateAuthorityCondition `json:"conditions,omitempty"`
+}
+
+// CertificateAuthorityCondition contains condition information for a
+// CertificateAuthority.
+type CertificateAuthorityCondition struct {
+	// Type of the condition, known values are (`Ready`).
+	Type CertificateAuthorityConditionType `json:"type"`
+
+	// Status of the condition, one of (`True`, `False`, `Unknown`).
+	Status cmmeta.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// If set, this represents the .metadata.generation that the condition was
+	// set based upon.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// CertificateAuthorityConditionType represents a CertificateAuthority
+// condition value.
+type CertificateAuthorityConditionType string
+
+const (
+	// CertificateAuthorityConditionReady indicates that every certificate
+	// authority of the chain has been issued and that the ClusterIssuer is
+	// ready to issue certificates.
+	CertificateAuthorityConditionReady CertificateAuthorityConditionType = "Ready"
+)
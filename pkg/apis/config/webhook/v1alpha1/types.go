@@ -95,6 +95,12 @@ type DynamicServingConfig struct {
 
 	// DNSNames that must be present on serving certificates signed by the CA.
 	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// CAPropagationDelay is the amount of time a new CA is present in the CA
+	// bundle stored in the Secret before it is used to sign serving
+	// certificates, when the CA is rotated.
+	// Defaults to 10m.
+	CAPropagationDelay metav1.Duration `json:"caPropagationDelay,omitempty"`
 }
 
 // FilesystemServingConfig enables using a certificate and private key found on the local filesystem.
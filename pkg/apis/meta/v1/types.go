@@ -76,4 +76,21 @@ type SecretKeySelector struct {
 const (
 	// Used as a data key in Secret resources to store a CA certificate.
 	TLSCAKey = "ca.crt"
+
+	// Used as a data key in Secret resources to store a certificate chain
+	// cross-signed by a second CA.
+	CrossSignedCertKey = "tls-cross-signed.crt"
+
+	// Used as data keys in Secret resources to store the additional private
+	// key of a Certificate and the certificate issued for it.
+	AdditionalTLSPrivateKeyKey = "tls-additional.key"
+	AdditionalTLSCertKey       = "tls-additional.crt"
+
+	// Used as a data key in Secret resources to store a bundle of CA
+	// certificates that the holder of the Secret should trust.
+	TrustBundleKey = "trust.pem"
+
+	// Used as a data key in Secret resources to store the DER encoded OCSP
+	// response for the certificate.
+	OCSPResponseKey = "ocsp.der"
 )
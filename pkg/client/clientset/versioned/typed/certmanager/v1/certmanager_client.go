@@ -29,6 +29,7 @@ import (
 type CertmanagerV1Interface interface {
 	RESTClient() rest.Interface
 	CertificatesGetter
+	CertificateAuthoritiesGetter
 	CertificateRequestsGetter
 	ClusterIssuersGetter
 	IssuersGetter
@@ -43,6 +44,10 @@ func (c *CertmanagerV1Client) Certificates(namespace string) CertificateInterfac
 	return newCertificates(c, namespace)
 }
 
+func (c *CertmanagerV1Client) CertificateAuthorities() CertificateAuthorityInterface {
+	return newCertificateAuthorities(c)
+}
+
 func (c *CertmanagerV1Client) CertificateRequests(namespace string) CertificateRequestInterface {
 	return newCertificateRequests(c, namespace)
 }
@@ -32,6 +32,10 @@ func (c *FakeCertmanagerV1) Certificates(namespace string) v1.CertificateInterfa
 	return &FakeCertificates{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) CertificateAuthorities() v1.CertificateAuthorityInterface {
+	return &FakeCertificateAuthorities{c}
+}
+
 func (c *FakeCertmanagerV1) CertificateRequests(namespace string) v1.CertificateRequestInterface {
 	return &FakeCertificateRequests{c, namespace}
 }
@@ -20,6 +20,8 @@ package v1
 
 type CertificateExpansion interface{}
 
+type CertificateAuthorityExpansion interface{}
+
 type CertificateRequestExpansion interface{}
 
 type ClusterIssuerExpansion interface{}
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// Certificates returns a CertificateInformer.
 	Certificates() CertificateInformer
+	// CertificateAuthorities returns a CertificateAuthorityInformer.
+	CertificateAuthorities() CertificateAuthorityInformer
 	// CertificateRequests returns a CertificateRequestInformer.
 	CertificateRequests() CertificateRequestInformer
 	// ClusterIssuers returns a ClusterIssuerInformer.
@@ -50,6 +52,11 @@ func (v *version) Certificates() CertificateInformer {
 	return &certificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CertificateAuthorities returns a CertificateAuthorityInformer.
+func (v *version) CertificateAuthorities() CertificateAuthorityInformer {
+	return &certificateAuthorityInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // CertificateRequests returns a CertificateRequestInformer.
 func (v *version) CertificateRequests() CertificateRequestInformer {
 	return &certificateRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
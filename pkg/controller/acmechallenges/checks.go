@@ -16,4 +16,78 @@ limitations under the License.
 
 package acmechallenges
 
-// no checks for the acme orders controller yet
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// secretChanged re-queues the DNS01 Challenges whose solver references the
+// given Secret, so that Challenges waiting to retry after their provider
+// failed with stale credentials pick up rotated credentials straight away.
+func (c *controller) secretChanged(obj interface{}) {
+	log := c.log.WithName("secretChanged")
+
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		log.Error(nil, "object was not a secret object")
+		return
+	}
+	log = logf.WithResource(log, secret)
+	challenges, err := c.challengesForSecret(secret)
+	if err != nil {
+		log.Error(err, "error looking up challenges observing secret")
+		return
+	}
+	for _, ch := range challenges {
+		key, err := cache.MetaNamespaceKeyFunc(ch)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// challengesForSecret returns the DNS01 Challenges which are not yet in a
+// final state and whose solver reads credentials from the given Secret.
+func (c *controller) challengesForSecret(secret *corev1.Secret) ([]*cmacme.Challenge, error) {
+	challenges, err := c.challengeLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing challenges: %w", err)
+	}
+
+	var affected []*cmacme.Challenge
+	for _, ch := range challenges {
+		if ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 || acme.IsFinalState(ch.Status.State) {
+			continue
+		}
+
+		// Secrets referenced by ClusterIssuer solvers are read from the
+		// cluster resource namespace.
+		resourceNamespace := ch.Namespace
+		if ch.Spec.IssuerRef.Kind == cmapi.ClusterIssuerKind {
+			resourceNamespace = c.clusterResourceNamespace
+		}
+		if resourceNamespace != secret.Namespace {
+			continue
+		}
+
+		for _, ref := range issuer.DNS01SecretRefs(ch.Spec.Solver.DNS01) {
+			if ref != nil && ref.Name == secret.Name {
+				affected = append(affected, ch)
+				break
+			}
+		}
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSecretChanged(t *testing.T) {
+	setCloudflareSolver := func(secretName string) gen.ChallengeModifier {
+		return func(ch *cmacme.Challenge) {
+			ch.Spec.Solver.DNS01 = &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+					APIToken: &cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: secretName},
+						Key:                  "api-token",
+					},
+				},
+			}
+		}
+	}
+	dns01Challenge := func(name string, mods ...gen.ChallengeModifier) *cmacme.Challenge {
+		mods = append([]gen.ChallengeModifier{
+			gen.SetChallengeNamespace("default"),
+			gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.IssuerKind}),
+			gen.SetChallengeState(cmacme.Pending),
+			setCloudflareSolver("cloudflare"),
+		}, mods...)
+		return gen.Challenge(name, mods...)
+	}
+
+	tests := map[string]struct {
+		secret       *corev1.Secret
+		challenges   []*cmacme.Challenge
+		expectedKeys []string
+	}{
+		"pending challenges referencing the secret are queued": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cloudflare"}},
+			challenges: []*cmacme.Challenge{
+				dns01Challenge("ch1"),
+				dns01Challenge("ch2", gen.SetChallengeState(cmacme.Processing)),
+				dns01Challenge("ch3", setCloudflareSolver("other")),
+			},
+			expectedKeys: []string{"default/ch1", "default/ch2"},
+		},
+		"challenges in a final state are not queued": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cloudflare"}},
+			challenges: []*cmacme.Challenge{
+				dns01Challenge("ch1", gen.SetChallengeState(cmacme.Valid)),
+				dns01Challenge("ch2", gen.SetChallengeState(cmacme.Errored)),
+			},
+		},
+		"challenges of issuers in other namespaces are not queued": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "cloudflare"}},
+			challenges: []*cmacme.Challenge{
+				dns01Challenge("ch1"),
+			},
+		},
+		"challenges of cluster issuers are queued for secrets in the cluster resource namespace": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "cloudflare"}},
+			challenges: []*cmacme.Challenge{
+				dns01Challenge("ch1", gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.ClusterIssuerKind})),
+				dns01Challenge("ch2"),
+			},
+			expectedKeys: []string{"default/ch1"},
+		},
+		"http01 challenges are not queued": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cloudflare"}},
+			challenges: []*cmacme.Challenge{
+				dns01Challenge("ch1", gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01)),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, ch := range test.challenges {
+				if err := indexer.Add(ch); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c := &controller{
+				challengeLister:          cmacmelisters.NewChallengeLister(indexer),
+				clusterResourceNamespace: "cert-manager",
+				queue:                    workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+				log:                      logf.Log,
+			}
+			defer c.queue.ShutDown()
+			c.secretChanged(test.secret)
+
+			var keys []string
+			for c.queue.Len() > 0 {
+				key, _ := c.queue.Get()
+				keys = append(keys, key.(string))
+				c.queue.Done(key)
+			}
+			sort.Strings(keys)
+			assert.Equal(t, test.expectedKeys, keys)
+		})
+	}
+}
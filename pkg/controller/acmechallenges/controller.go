@@ -23,12 +23,19 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -37,6 +44,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 type controller struct {
@@ -46,8 +54,14 @@ type controller struct {
 	// used to fetch ACME clients used in the controller
 	accountRegistry accounts.Getter
 
+	// loadClient registers the ACME client of an Issuer that is not present
+	// in the account registry, for example because the issuers controllers
+	// are not running in this process.
+	loadClient accounts.LoadClientFunc
+
 	// all the listers used by this controller
 	challengeLister     cmacmelisters.ChallengeLister
+	orderLister         cmacmelisters.OrderLister
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
 	secretLister        corelisters.SecretLister
@@ -57,6 +71,9 @@ type controller struct {
 	// This also allows for easy mocking of the different challenge mechanisms.
 	dnsSolver  solver
 	httpSolver solver
+	// solverSweeper deletes the HTTP01 solver resources left behind by
+	// challenges, for example if cert-manager exited while cleaning up.
+	solverSweeper sweeper
 	// scheduler marks challenges as Processing=true if they can be scheduled
 	// for processing. This job runs periodically every N seconds, so it cannot
 	// be constructed as a traditional controller.
@@ -65,6 +82,10 @@ type controller struct {
 	// used to record Events about resources to the API
 	recorder record.EventRecorder
 
+	// used to look up Orders missing from the cache and to delete orphaned
+	// Challenges
+	cmClient cmclient.Interface
+
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
 	queue workqueue.RateLimitingInterface
@@ -76,6 +97,25 @@ type controller struct {
 
 	DNS01CheckRetryPeriod time.Duration
 
+	// clock is used to determine whether the propagation checks of a
+	// Challenge have timed out
+	clock clock.Clock
+
+	// metrics is used to count Challenges whose clean up was abandoned
+	metrics *metrics.Metrics
+
+	// clusterResourceNamespace is the namespace Secrets referenced by
+	// ClusterIssuers are read from.
+	clusterResourceNamespace string
+
+	// ownedBy identifies this cert-manager instance. If set, only
+	// Challenges labelled with it are scheduled and synced.
+	ownedBy string
+
+	// issuerClass is the issuer class of this cert-manager instance. Only
+	// Challenges of issuers of this class are scheduled and synced.
+	issuerClass string
+
 	// objectUpdater implements the updateObject function which is used to save
 	// changes to the Challenge.Status and Challenge.Finalizers
 	objectUpdater
@@ -90,10 +130,12 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// obtain references to all the informers used by this controller
 	challengeInformer := ctx.SharedInformerFactory.Acme().V1().Challenges()
+	orderInformer := ctx.SharedInformerFactory.Acme().V1().Orders()
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
 	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
 	// we register these informers here so the HTTP01 solver has a synced
-	// cache when managing pod/service/ingress resources
+	// cache when managing configmap/pod/service/ingress resources
+	configMapInformer := ctx.KubeSharedInformerFactory.Core().V1().ConfigMaps()
 	podInformer := ctx.KubeSharedInformerFactory.Core().V1().Pods()
 	serviceInformer := ctx.KubeSharedInformerFactory.Core().V1().Services()
 	ingressInformer := ctx.KubeSharedInformerFactory.Networking().V1().Ingresses()
@@ -102,8 +144,10 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		challengeInformer.Informer().HasSynced,
+		orderInformer.Informer().HasSynced,
 		issuerInformer.Informer().HasSynced,
 		secretInformer.Informer().HasSynced,
+		configMapInformer.Informer().HasSynced,
 		podInformer.Informer().HasSynced,
 		serviceInformer.Informer().HasSynced,
 		ingressInformer.Informer().HasSynced,
@@ -116,6 +160,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// set all the references to the listers for used by the Sync function
 	c.challengeLister = challengeInformer.Lister()
+	c.orderLister = orderInformer.Lister()
 	c.issuerLister = issuerInformer.Lister()
 	c.secretLister = secretInformer.Lister()
 
@@ -129,17 +174,48 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// register handler functions
 	challengeInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	// DNS01 providers read their credentials on each attempt, so Challenges
+	// are retried as soon as the credentials they reference change.
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretChanged})
+	// Challenges are re-queued when their Order is deleted, so that those
+	// left behind are cleaned up.
+	orderInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: c.orderDeleted})
 
 	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
-	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
+	schedulerSelector := labels.Everything()
+	if ctx.OwnedBy != "" {
+		schedulerSelector = labels.SelectorFromSet(labels.Set{cmapi.OwnedByLabelKey: ctx.OwnedBy})
+	}
+	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, schedulerSelector, scheduler.Options{
+		MaxConcurrentChallenges: ctx.SchedulerOptions.MaxConcurrentChallenges,
+		Policy:                  scheduler.Policy(ctx.SchedulerOptions.Policy),
+		IssuerLimit:             c.issuerChallengeLimit,
+		Claimed:                 c.claimed,
+	})
 	c.recorder = ctx.Recorder
+	c.cmClient = ctx.CMClient
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
+	loader := accounts.NewClientLoader(
+		ctx.ACMEOptions.AccountRegistry,
+		c.secretLister,
+		ctx.IssuerOptions.ClusterResourceNamespace,
+		ctx.Metrics,
+		ctx.ACMEOptions.HTTPClient,
+		ctx.ExternalUserAgent,
+	)
+	c.loadClient = loader.LoadClient
+	// Clients loaded for Issuers are removed when the Issuer is deleted.
+	issuerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: loader.IssuerDeleted})
+	if ctx.Namespace == "" {
+		ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: loader.IssuerDeleted})
+	}
 
-	var err error
-	c.httpSolver, err = http.NewSolver(ctx)
+	httpSolver, err := http.NewSolver(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
+	c.httpSolver = httpSolver
+	c.solverSweeper = httpSolver
 	c.dnsSolver, err = dns.NewSolver(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -148,6 +224,11 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// read options from context
 	c.dns01Nameservers = ctx.ACMEOptions.DNS01Nameservers
 	c.DNS01CheckRetryPeriod = ctx.ACMEOptions.DNS01CheckRetryPeriod
+	c.clock = ctx.Clock
+	c.ownedBy = ctx.OwnedBy
+	c.issuerClass = ctx.IssuerClass
+	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+	c.metrics = ctx.Metrics
 
 	// Construct an objectUpdater which is used to save changes to the Challenge
 	// object, either using Update or using Patch + Server Side Apply.
@@ -156,6 +237,52 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	return c.queue, mustSync, nil
 }
 
+// issuerChallengeLimit returns the maximum number of concurrent challenges
+// configured on the ACME issuer of the given challenge, if any.
+func (c *controller) issuerChallengeLimit(ch *cmacme.Challenge) (int, bool) {
+	genericIssuer, err := c.helper.GetGenericIssuer(ch.Spec.IssuerRef, ch.Namespace)
+	if err != nil {
+		return 0, false
+	}
+	acme := genericIssuer.GetSpec().ACME
+	if acme == nil || acme.MaxConcurrentChallenges == nil {
+		return 0, false
+	}
+	return *acme.MaxConcurrentChallenges, true
+}
+
+// claimed returns false if the issuer of the challenge is assigned to another
+// cert-manager instance by its issuer class. Challenges whose issuer can not
+// be read are claimed, so that they can still be cleaned up.
+func (c *controller) claimed(ch *cmacme.Challenge) bool {
+	genericIssuer, err := c.helper.GetGenericIssuer(ch.Spec.IssuerRef, ch.Namespace)
+	if err != nil {
+		return true
+	}
+	return controllerpkg.IsClaimed(genericIssuer, c.issuerClass)
+}
+
+// sweeper deletes solver resources which are no longer needed by any
+// challenge.
+type sweeper interface {
+	SweepOrphaned(ctx context.Context) error
+}
+
+// solverSweepInterval is how often solver resources left behind by
+// challenges are looked for.
+const solverSweepInterval = time.Minute * 10
+
+// sweepSolverResources deletes solver resources which are no longer needed,
+// as they may be left behind if cert-manager exits while cleaning up a
+// challenge.
+func (c *controller) sweepSolverResources(ctx context.Context) {
+	log := logf.FromContext(ctx, "sweeper")
+
+	if err := c.solverSweeper.SweepOrphaned(ctx); err != nil {
+		log.Error(err, "error deleting orphaned solver resources")
+	}
+}
+
 // MaxChallengesPerSchedule is the maximum number of challenges that can be
 // scheduled with a single call to the scheduler.
 // This provides a very crude rate limit on how many challenges we will schedule
@@ -181,6 +308,7 @@ func (c *controller) runScheduler(ctx context.Context) {
 		log := logf.WithResource(log, chOriginal)
 		ch := chOriginal.DeepCopy()
 		ch.Status.Processing = true
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionScheduled, cmmeta.ConditionTrue, "Scheduled", "Challenge scheduled for processing")
 		if err := c.updateObject(ctx, chOriginal, ch); err != nil {
 			log.Error(err, "error scheduling challenge for processing")
 			return
@@ -191,6 +319,49 @@ func (c *controller) runScheduler(ctx context.Context) {
 	if len(toSchedule) > 0 {
 		log.V(logf.DebugLevel).Info("scheduled challenges for processing", "number_scheduled", len(toSchedule))
 	}
+
+	queue, err := c.scheduler.Queue(toSchedule)
+	if err != nil {
+		log.Error(err, "error determining the challenges waiting to be scheduled")
+		return
+	}
+	c.updateQueuedChallenges(ctx, queue)
+}
+
+// updateQueuedChallenges sets the Scheduled condition of the challenges
+// waiting to be scheduled to explain why they are waiting, and reports the
+// queue to the metrics server.
+func (c *controller) updateQueuedChallenges(ctx context.Context, queue []scheduler.QueuedChallenge) {
+	log := logf.FromContext(ctx, "scheduler")
+
+	items := make([]metrics.QueuedChallenge, 0, len(queue))
+	for _, item := range queue {
+		chOriginal := item.Challenge
+		items = append(items, metrics.QueuedChallenge{
+			Namespace:   chOriginal.Namespace,
+			Name:        chOriginal.Name,
+			DNSName:     chOriginal.Spec.DNSName,
+			Type:        string(chOriginal.Spec.Type),
+			Position:    item.Position,
+			Reason:      item.Reason,
+			Message:     item.Message,
+			QueuedSince: chOriginal.CreationTimestamp.Time,
+		})
+
+		// only update the condition if it has changed, to avoid writing to
+		// every queued challenge on each run of the scheduler
+		cond := apiutil.GetChallengeCondition(chOriginal, cmacme.ChallengeConditionScheduled)
+		if cond != nil && cond.Status == cmmeta.ConditionFalse && cond.Reason == item.Reason && cond.Message == item.Message {
+			continue
+		}
+		ch := chOriginal.DeepCopy()
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionScheduled, cmmeta.ConditionFalse, item.Reason, item.Message)
+		if err := c.updateObject(ctx, chOriginal, ch); err != nil {
+			logf.WithResource(log, chOriginal).Error(err, "error updating the scheduled condition of a queued challenge")
+		}
+	}
+
+	c.metrics.UpdateChallengeQueue(items)
 }
 
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
@@ -212,6 +383,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsOwnedBy(ch, c.ownedBy) {
+		logf.WithResource(log, ch).V(logf.DebugLevel).Info("skipping challenge not owned by this cert-manager instance", "owned_by", c.ownedBy)
+		return nil
+	}
+
+	if !c.claimed(ch) {
+		logf.WithResource(log, ch).V(logf.DebugLevel).Info("skipping challenge of an issuer with another issuer class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	ctx = logf.NewContext(ctx, logf.WithResource(log, ch))
 	return c.Sync(ctx, ch)
 }
@@ -226,6 +407,8 @@ func init() {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(c).
 			With(c.runScheduler, time.Second).
+			With(c.sweepSolverResources, solverSweepInterval).
+			Workers(func(ctx *controllerpkg.Context) int { return ctx.SchedulerOptions.Workers }).
 			Complete()
 	})
 }
@@ -19,11 +19,17 @@ package acmechallenges
 import (
 	"context"
 	"testing"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmechallenges/scheduler"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/require"
@@ -35,8 +41,31 @@ const (
 )
 
 func TestRunScheduler(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	fixedClockTime := metav1.NewTime(fixedClock.Now())
+
+	scheduledCondition := cmacme.ChallengeCondition{
+		Type:               cmacme.ChallengeConditionScheduled,
+		Status:             cmmeta.ConditionTrue,
+		LastTransitionTime: &fixedClockTime,
+		Reason:             "Scheduled",
+		Message:            "Challenge scheduled for processing",
+	}
+	queuedCondition := func(reason, message string) cmacme.ChallengeCondition {
+		return cmacme.ChallengeCondition{
+			Type:               cmacme.ChallengeConditionScheduled,
+			Status:             cmmeta.ConditionFalse,
+			LastTransitionTime: &fixedClockTime,
+			Reason:             reason,
+			Message:            message,
+		}
+	}
+	maxConcurrentCondition := queuedCondition(scheduler.ReasonMaxConcurrentChallenges,
+		"Waiting for a Challenge to complete, as at most 1 Challenges are processed at a time")
+
 	tests := map[string]struct {
 		maxConcurrentChallenges int
+		issuerClass             string
 		builder                 *testpkg.Builder
 	}{
 		"unscheduled challenges are scheduled": {
@@ -60,6 +89,7 @@ func TestRunScheduler(t *testing.T) {
 							gen.Challenge("ch1",
 								gen.SetChallengeDNSName("host1.example.com"),
 								gen.SetChallengeProcessing(true),
+								gen.SetChallengeStatusCondition(scheduledCondition),
 							))),
 					testpkg.NewAction(
 						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
@@ -68,6 +98,7 @@ func TestRunScheduler(t *testing.T) {
 							gen.Challenge("ch2",
 								gen.SetChallengeDNSName("host2.example.com"),
 								gen.SetChallengeProcessing(true),
+								gen.SetChallengeStatusCondition(scheduledCondition),
 							))),
 				},
 				ExpectedEvents: []string{
@@ -89,8 +120,18 @@ func TestRunScheduler(t *testing.T) {
 						gen.SetChallengeProcessing(false),
 					),
 				},
-				ExpectedActions: nil,
-				ExpectedEvents:  nil,
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(
+						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+							"status",
+							gen.DefaultTestNamespace,
+							gen.Challenge("ch2",
+								gen.SetChallengeDNSName("host2.example.com"),
+								gen.SetChallengeProcessing(false),
+								gen.SetChallengeStatusCondition(maxConcurrentCondition),
+							))),
+				},
+				ExpectedEvents: nil,
 			},
 		},
 		"challenges with the same domain are never scheduled together": {
@@ -106,6 +147,53 @@ func TestRunScheduler(t *testing.T) {
 						gen.SetChallengeProcessing(false),
 					),
 				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(
+						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+							"status",
+							gen.DefaultTestNamespace,
+							gen.Challenge("ch2",
+								gen.SetChallengeDNSName("host1.example.com"),
+								gen.SetChallengeProcessing(false),
+								gen.SetChallengeStatusCondition(queuedCondition(scheduler.ReasonConflictingChallenge,
+									"Waiting for Challenge default-unit-test-ns/ch1 for the same DNS name and challenge type to complete")),
+							))),
+				},
+				ExpectedEvents: nil,
+			},
+		},
+		"queued challenges with an up to date condition are not updated": {
+			maxConcurrentChallenges: 1,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.Challenge("ch1",
+						gen.SetChallengeDNSName("host1.example.com"),
+						gen.SetChallengeProcessing(true),
+					),
+					gen.Challenge("ch2",
+						gen.SetChallengeDNSName("host2.example.com"),
+						gen.SetChallengeProcessing(false),
+						gen.SetChallengeStatusCondition(maxConcurrentCondition),
+					),
+				},
+				ExpectedActions: nil,
+				ExpectedEvents:  nil,
+			},
+		},
+		"challenges of issuers with another issuer class are not scheduled": {
+			maxConcurrentChallenges: 2,
+			issuerClass:             "tenant-a",
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.Issuer("test-issuer", func(iss cmapi.GenericIssuer) {
+						iss.SetAnnotations(map[string]string{cmapi.IssuerClassAnnotationKey: "tenant-b"})
+					}),
+					gen.Challenge("ch1",
+						gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: "test-issuer"}),
+						gen.SetChallengeDNSName("host1.example.com"),
+						gen.SetChallengeProcessing(false),
+					),
+				},
 				ExpectedActions: nil,
 				ExpectedEvents:  nil,
 			},
@@ -129,8 +217,10 @@ func TestRunScheduler(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			test.builder.T = t
+			test.builder.Clock = fixedClock
 			test.builder.Init()
 			test.builder.Context.SchedulerOptions.MaxConcurrentChallenges = test.maxConcurrentChallenges
+			test.builder.Context.IssuerClass = test.issuerClass
 
 			defer test.builder.Stop()
 			c := &controller{}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const reasonOrphaned = "Orphaned"
+
+// orderDeleted re-queues the Challenges owned by a deleted Order, so that
+// they are cleaned up even if the garbage collector does not delete them.
+func (c *controller) orderDeleted(obj interface{}) {
+	log := c.log.WithName("orderDeleted")
+
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	order, ok := obj.(*cmacme.Order)
+	if !ok {
+		log.Error(nil, "object was not an order object")
+		return
+	}
+
+	challenges, err := c.challengeLister.Challenges(order.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing challenges")
+		return
+	}
+	for _, ch := range challenges {
+		if !metav1.IsControlledBy(ch, order) {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(ch)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// orphanedBy returns the name of the Order which controlled the Challenge if
+// that Order no longer exists, for example because the Challenge was
+// restored from a backup taken before the Order was deleted. Challenges
+// without a controlling Order are never considered orphaned. A missing Order
+// is confirmed with the API server, so that a lagging informer cache does not
+// cause a Challenge to be deleted.
+func (c *controller) orphanedBy(ctx context.Context, ch *cmacme.Challenge) (string, bool, error) {
+	ref := metav1.GetControllerOf(ch)
+	if ref == nil || ref.Kind != cmacme.OrderKind {
+		return "", false, nil
+	}
+
+	order, err := c.orderLister.Orders(ch.Namespace).Get(ref.Name)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return "", false, err
+	}
+	if err == nil && order.UID == ref.UID {
+		return "", false, nil
+	}
+
+	order, err = c.cmClient.AcmeV1().Orders(ch.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		return ref.Name, true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return ref.Name, order.UID != ref.UID, nil
+}
+
+// deleteOrphaned deletes a Challenge whose Order no longer exists. Any
+// presented challenge values are cleaned up by the finalizer of the
+// Challenge using the solver recorded on it, as for any other deleted
+// Challenge.
+func (c *controller) deleteOrphaned(ctx context.Context, ch *cmacme.Challenge, orderName string) error {
+	log := logf.FromContext(ctx)
+
+	log.Info("deleting challenge as the order which owns it no longer exists", "order", orderName)
+	c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonOrphaned, "Deleting challenge as the Order %q which owns it no longer exists", orderName)
+
+	err := c.cmClient.AcmeV1().Challenges(ch.Namespace).Delete(ctx, ch.Name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting orphaned challenge: %w", err)
+	}
+	return nil
+}
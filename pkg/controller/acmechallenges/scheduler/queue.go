@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+const (
+	// ReasonMaxConcurrentChallenges is used when a Challenge is waiting
+	// because the maximum number of Challenges are already processing.
+	ReasonMaxConcurrentChallenges = "MaxConcurrentChallenges"
+	// ReasonConflictingChallenge is used when a Challenge is waiting for
+	// another Challenge for the same DNS name and challenge type.
+	ReasonConflictingChallenge = "ConflictingChallenge"
+	// ReasonIssuerMaxConcurrentChallenges is used when a Challenge is waiting
+	// because the maximum number of Challenges of its issuer are already
+	// processing.
+	ReasonIssuerMaxConcurrentChallenges = "IssuerMaxConcurrentChallenges"
+	// ReasonQueued is used when a Challenge could be processed, but has not
+	// been picked by the scheduler yet.
+	ReasonQueued = "Queued"
+)
+
+// QueuedChallenge is a Challenge which is waiting to be scheduled for
+// processing, along with the reason it has not been scheduled yet.
+type QueuedChallenge struct {
+	Challenge *cmacme.Challenge
+	// Position is the position of the Challenge in the queue, starting at 1.
+	// Challenges are scheduled in the order of their position, so it is an
+	// estimate of when the Challenge will start processing.
+	Position int
+	Reason   string
+	Message  string
+}
+
+// Queue returns the Challenges which are waiting to be scheduled, in the
+// order in which they will be scheduled. The given Challenges are those just
+// returned by ScheduleN, which are treated as processing even if the lister
+// has not observed the update yet.
+func (s *Scheduler) Queue(scheduled []*cmacme.Challenge) ([]QueuedChallenge, error) {
+	allChallenges, err := s.listChallenges()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queue(allChallenges, scheduled), nil
+}
+
+func (s *Scheduler) queue(allChallenges, scheduled []*cmacme.Challenge) []QueuedChallenge {
+	isScheduled := make(map[string]bool, len(scheduled))
+	for _, ch := range scheduled {
+		isScheduled[ch.Namespace+"/"+ch.Name] = true
+	}
+
+	var inProgress, waiting []*cmacme.Challenge
+	for _, ch := range allChallenges {
+		switch {
+		case ch.Status.Processing || isScheduled[ch.Namespace+"/"+ch.Name]:
+			inProgress = append(inProgress, ch)
+		case !acme.IsFinalState(ch.Status.State):
+			waiting = append(waiting, ch)
+		}
+	}
+
+	s.sortChallenges(waiting, inProgress)
+
+	// simulate scheduling the queue, so that challenges which would be
+	// scheduled take up capacity from those behind them
+	processing := len(inProgress)
+	perIssuer := countByIssuer(inProgress)
+	queue := make([]QueuedChallenge, 0, len(waiting))
+	for i, ch := range waiting {
+		item := QueuedChallenge{Challenge: ch, Position: i + 1}
+		limit, limited := 0, false
+		if s.issuerLimit != nil {
+			limit, limited = s.issuerLimit(ch)
+		}
+		switch conflict := findConflict(ch, inProgress, waiting[:i]); {
+		case conflict != nil:
+			item.Reason = ReasonConflictingChallenge
+			item.Message = fmt.Sprintf("Waiting for Challenge %s/%s for the same DNS name and challenge type to complete", conflict.Namespace, conflict.Name)
+		case processing >= s.maxConcurrentChallenges:
+			item.Reason = ReasonMaxConcurrentChallenges
+			item.Message = fmt.Sprintf("Waiting for a Challenge to complete, as at most %d Challenges are processed at a time", s.maxConcurrentChallenges)
+		case limited && perIssuer[issuerKey(ch)] >= limit:
+			item.Reason = ReasonIssuerMaxConcurrentChallenges
+			item.Message = fmt.Sprintf("Waiting for a Challenge of the same issuer to complete, as at most %d of its Challenges are processed at a time", limit)
+		default:
+			item.Reason = ReasonQueued
+			item.Message = "Waiting to be scheduled"
+			processing++
+			perIssuer[issuerKey(ch)]++
+		}
+		queue = append(queue, item)
+	}
+
+	return queue
+}
+
+// findConflict returns the first processing Challenge, or otherwise the
+// first Challenge ahead in the queue, which cannot be processed at the same
+// time as the given Challenge.
+func findConflict(ch *cmacme.Challenge, inProgress, ahead []*cmacme.Challenge) *cmacme.Challenge {
+	for _, other := range inProgress {
+		if compareChallenges(ch, other) == 0 {
+			return other
+		}
+	}
+	for _, other := range ahead {
+		if compareChallenges(ch, other) == 0 {
+			return other
+		}
+	}
+	return nil
+}
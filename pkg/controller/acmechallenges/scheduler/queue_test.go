@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestQueue(t *testing.T) {
+	type expectedItem struct {
+		name     string
+		position int
+		reason   string
+	}
+
+	tests := map[string]struct {
+		challenges  []*cmacme.Challenge
+		scheduled   []string
+		max         int
+		issuerLimit IssuerLimitFunc
+		expected    []expectedItem
+	}{
+		"no challenges means an empty queue": {
+			max: 5,
+		},
+		"processing and final challenges are not queued": {
+			challenges: append(
+				ascendingChallengeN(1, gen.SetChallengeProcessing(true)),
+				gen.Challenge("done", gen.SetChallengeState(cmacme.Valid)),
+			),
+			max: 5,
+		},
+		"challenges are queued in order of creation": {
+			challenges: ascendingChallengeN(3),
+			max:        5,
+			expected: []expectedItem{
+				{"test-0", 1, ReasonQueued},
+				{"test-1", 2, ReasonQueued},
+				{"test-2", 3, ReasonQueued},
+			},
+		},
+		"just scheduled challenges are treated as processing": {
+			challenges: ascendingChallengeN(3),
+			scheduled:  []string{"test-0", "test-1"},
+			max:        2,
+			expected: []expectedItem{
+				{"test-2", 1, ReasonMaxConcurrentChallenges},
+			},
+		},
+		"challenges for the same DNS name and type as a processing challenge conflict": {
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("a", gen.SetChallengeDNSName("example.com"), gen.SetChallengeProcessing(true)),
+				gen.Challenge("b", gen.SetChallengeDNSName("example.com")),
+				gen.Challenge("c", gen.SetChallengeDNSName("example.org")),
+			},
+			max: 5,
+			expected: []expectedItem{
+				{"b", 1, ReasonConflictingChallenge},
+				{"c", 2, ReasonQueued},
+			},
+		},
+		"challenges for the same DNS name and type as a challenge ahead in the queue conflict": {
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("a", gen.SetChallengeDNSName("example.com"), withCreationTimestamp(1)),
+				gen.Challenge("b", gen.SetChallengeDNSName("example.com"), withCreationTimestamp(2)),
+				gen.Challenge("c", gen.SetChallengeDNSName("example.com"), withCreationTimestamp(3),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01)),
+			},
+			max: 5,
+			expected: []expectedItem{
+				{"a", 1, ReasonQueued},
+				{"b", 2, ReasonConflictingChallenge},
+				{"c", 3, ReasonQueued},
+			},
+		},
+		"challenges which would be scheduled take up capacity": {
+			challenges: ascendingChallengeN(3),
+			max:        2,
+			expected: []expectedItem{
+				{"test-0", 1, ReasonQueued},
+				{"test-1", 2, ReasonQueued},
+				{"test-2", 3, ReasonMaxConcurrentChallenges},
+			},
+		},
+		"challenges of an issuer at its limit wait for the issuer": {
+			challenges: ascendingChallengeN(2),
+			max:        5,
+			issuerLimit: func(*cmacme.Challenge) (int, bool) {
+				return 1, true
+			},
+			expected: []expectedItem{
+				{"test-0", 1, ReasonQueued},
+				{"test-1", 2, ReasonIssuerMaxConcurrentChallenges},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := New(context.Background(), nil, nil, Options{MaxConcurrentChallenges: test.max, IssuerLimit: test.issuerLimit})
+
+			var scheduled []*cmacme.Challenge
+			for _, ch := range test.challenges {
+				for _, name := range test.scheduled {
+					if ch.Name == name {
+						scheduled = append(scheduled, ch)
+					}
+				}
+			}
+
+			var actual []expectedItem
+			for _, item := range s.queue(test.challenges, scheduled) {
+				actual = append(actual, expectedItem{item.Challenge.Name, item.Position, item.Reason})
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
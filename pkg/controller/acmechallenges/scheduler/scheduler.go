@@ -19,29 +19,86 @@ package scheduler
 import (
 	"context"
 	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/net/publicsuffix"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// Policy determines the order in which the scheduler processes the
+// challenges that are waiting to be scheduled.
+type Policy string
+
+const (
+	// PolicyFIFO schedules challenges in the order in which they were
+	// created.
+	PolicyFIFO Policy = "FIFO"
+
+	// PolicyZoneFair shares the available capacity fairly between DNS zones,
+	// so that a large number of challenges for a single zone cannot delay
+	// the challenges for other zones. The zone of a challenge is its
+	// registered domain, e.g. example.com for www.example.com.
+	PolicyZoneFair Policy = "ZoneFair"
+)
+
+// IssuerLimitFunc returns the maximum number of challenges of the issuer of
+// the given challenge that may be processing at once, or false if the issuer
+// does not limit the number of its challenges.
+type IssuerLimitFunc func(ch *cmacme.Challenge) (int, bool)
+
+// Options configure the scheduler.
+type Options struct {
+	// MaxConcurrentChallenges is the maximum number of challenges that may
+	// be processing at once.
+	MaxConcurrentChallenges int
+
+	// Policy determines the order in which challenges are scheduled.
+	// Defaults to PolicyFIFO.
+	Policy Policy
+
+	// IssuerLimit is used to apply per-issuer limits on the number of
+	// challenges processing at once. Optional.
+	IssuerLimit IssuerLimitFunc
+
+	// Claimed returns false for challenges which are handled by another
+	// cert-manager instance, which are then ignored by the scheduler.
+	// Optional.
+	Claimed func(ch *cmacme.Challenge) bool
+}
+
 // Scheduler implements an ACME challenge scheduler that applies heuristics
 // to challenge resources in order to determine which challenges should be
 // processing at a given time.
 type Scheduler struct {
 	log                     logr.Logger
 	challengeLister         cmacmelisters.ChallengeLister
+	selector                labels.Selector
 	maxConcurrentChallenges int
+	policy                  Policy
+	issuerLimit             IssuerLimitFunc
+	claimed                 func(ch *cmacme.Challenge) bool
 }
 
-// New will construct a new instance of a scheduler
-func New(ctx context.Context, l cmacmelisters.ChallengeLister, maxConcurrentChallenges int) *Scheduler {
+// New will construct a new instance of a scheduler. Only the challenges
+// matching the selector are considered when scheduling.
+func New(ctx context.Context, l cmacmelisters.ChallengeLister, selector labels.Selector, opts Options) *Scheduler {
 	log := logs.FromContext(ctx, "challenge-scheduler")
-	return &Scheduler{log: log, challengeLister: l, maxConcurrentChallenges: maxConcurrentChallenges}
+	return &Scheduler{
+		log:                     log,
+		challengeLister:         l,
+		selector:                selector,
+		maxConcurrentChallenges: opts.MaxConcurrentChallenges,
+		policy:                  opts.Policy,
+		issuerLimit:             opts.IssuerLimit,
+		claimed:                 opts.Claimed,
+	}
 }
 
 // ScheduleN will return a maximum of N challenge resources that should be
@@ -50,7 +107,7 @@ func New(ctx context.Context, l cmacmelisters.ChallengeLister, maxConcurrentChal
 // scheduled.
 func (s *Scheduler) ScheduleN(n int) ([]*cmacme.Challenge, error) {
 	// Get a list of all challenges from the cache
-	allChallenges, err := s.challengeLister.List(labels.Everything())
+	allChallenges, err := s.listChallenges()
 	if err != nil {
 		return nil, err
 	}
@@ -58,17 +115,35 @@ func (s *Scheduler) ScheduleN(n int) ([]*cmacme.Challenge, error) {
 	return s.scheduleN(n, allChallenges)
 }
 
+// listChallenges returns the challenges matching the selector of the
+// scheduler which are claimed by this cert-manager instance.
+func (s *Scheduler) listChallenges() ([]*cmacme.Challenge, error) {
+	allChallenges, err := s.challengeLister.List(s.selector)
+	if err != nil || s.claimed == nil {
+		return allChallenges, err
+	}
+
+	claimed := allChallenges[:0:0]
+	for _, ch := range allChallenges {
+		if s.claimed(ch) {
+			claimed = append(claimed, ch)
+		}
+	}
+	return claimed, nil
+}
+
 func (s *Scheduler) scheduleN(n int, allChallenges []*cmacme.Challenge) ([]*cmacme.Challenge, error) {
 	// Determine the list of challenges that could feasibly be scheduled on
 	// this pass of the scheduler.
-	// This function returns a list of candidates sorted by creation timestamp.
-	candidates, inProgressChallengeCount, err := s.determineChallengeCandidates(allChallenges)
+	// This function returns a list of candidates sorted according to the
+	// scheduling policy.
+	candidates, inProgress, err := s.determineChallengeCandidates(allChallenges)
 	if err != nil {
 		return nil, err
 	}
 
 	numberToSelect := n
-	remainingNumberAllowedChallenges := s.maxConcurrentChallenges - inProgressChallengeCount
+	remainingNumberAllowedChallenges := s.maxConcurrentChallenges - len(inProgress)
 	if remainingNumberAllowedChallenges < 0 {
 		remainingNumberAllowedChallenges = 0
 	}
@@ -76,7 +151,7 @@ func (s *Scheduler) scheduleN(n int, allChallenges []*cmacme.Challenge) ([]*cmac
 		numberToSelect = remainingNumberAllowedChallenges
 	}
 
-	candidates, err = s.selectChallengesToSchedule(candidates, numberToSelect)
+	candidates, err = s.selectChallengesToSchedule(candidates, inProgress, numberToSelect)
 	if err != nil {
 		return nil, err
 	}
@@ -84,34 +159,50 @@ func (s *Scheduler) scheduleN(n int, allChallenges []*cmacme.Challenge) ([]*cmac
 	return candidates, nil
 }
 
-// selectChallengesToSchedule will apply some sorting heuristic to the allowed
-// challenge candidates and return a maximum of N challenges that should be
-// scheduled for processing.
-func (s *Scheduler) selectChallengesToSchedule(candidates []*cmacme.Challenge, n int) ([]*cmacme.Challenge, error) {
-	// Trim the candidates returned to 'n'
-	if len(candidates) > n {
-		candidates = candidates[:n]
+// selectChallengesToSchedule will return a maximum of N challenges from the
+// sorted candidates that should be scheduled for processing, skipping those
+// whose issuer is already processing its maximum number of challenges.
+func (s *Scheduler) selectChallengesToSchedule(candidates, inProgress []*cmacme.Challenge, n int) ([]*cmacme.Challenge, error) {
+	if s.issuerLimit == nil {
+		// Trim the candidates returned to 'n'
+		if len(candidates) > n {
+			candidates = candidates[:n]
+		}
+		return candidates, nil
 	}
-	return candidates, nil
+
+	perIssuer := countByIssuer(inProgress)
+	selected := []*cmacme.Challenge{}
+	for _, ch := range candidates {
+		if len(selected) >= n {
+			break
+		}
+		key := issuerKey(ch)
+		if limit, ok := s.issuerLimit(ch); ok && perIssuer[key] >= limit {
+			s.log.V(logs.DebugLevel).Info("issuer is processing its maximum number of challenges", "issuer", key, "max_concurrent", limit)
+			continue
+		}
+		perIssuer[key]++
+		selected = append(selected, ch)
+	}
+	return selected, nil
 }
 
 // determineChallengeCandidates will determine which, if any, challenges can
 // be scheduled given the current state of items to be scheduled and currently
-// processing.
-// The returned challenges will be sorted in ascending order based on timestamp
-// (i.e. the oldest challenge will be element zero).
-func (s *Scheduler) determineChallengeCandidates(allChallenges []*cmacme.Challenge) ([]*cmacme.Challenge, int, error) {
+// processing, and returns them along with the processing challenges.
+// The returned candidates will be sorted according to the scheduling policy.
+func (s *Scheduler) determineChallengeCandidates(allChallenges []*cmacme.Challenge) ([]*cmacme.Challenge, []*cmacme.Challenge, error) {
 	// consider the entire set of challenges for 'in progress', in case a challenge
 	// has processing=true whilst still being in a 'final' state
 	inProgress := processingChallenges(allChallenges)
-	inProgressChallengeCount := len(inProgress)
 
 	// Ensure we only run a max of MaxConcurrentChallenges at a time
 	// We perform this check here to avoid extra processing if we've already
 	// hit the maximum number of challenges.
-	if inProgressChallengeCount >= s.maxConcurrentChallenges {
+	if len(inProgress) >= s.maxConcurrentChallenges {
 		s.log.V(logs.DebugLevel).Info("hit maximum concurrent challenge limit. refusing to schedule more challenges.", "in_progress", len(inProgress), "max_concurrent", s.maxConcurrentChallenges)
-		return []*cmacme.Challenge{}, inProgressChallengeCount, nil
+		return []*cmacme.Challenge{}, inProgress, nil
 	}
 
 	// Calculate incomplete challenges
@@ -136,18 +227,79 @@ func (s *Scheduler) determineChallengeCandidates(allChallenges []*cmacme.Challen
 		return true
 	})
 
-	// Finally, sorted the challenges by timestamp to ensure a stable output
-	sortChallengesByTimestamp(candidates)
+	// Finally, sort the challenges according to the policy to ensure a
+	// stable output
+	s.sortChallenges(candidates, inProgress)
 
-	return candidates, inProgressChallengeCount, nil
+	return candidates, inProgress, nil
+}
+
+// sortChallenges sorts the challenges in the order in which they should be
+// scheduled according to the scheduling policy.
+func (s *Scheduler) sortChallenges(chs, inProgress []*cmacme.Challenge) {
+	sortChallengesByTimestamp(chs)
+	if s.policy != PolicyZoneFair {
+		return
+	}
+
+	// The rank of a challenge is the number of challenges for its zone which
+	// are processing or ahead of it, so that each zone gets a turn before
+	// any zone gets a second one.
+	perZone := make(map[string]int)
+	for _, ch := range inProgress {
+		perZone[challengeZone(ch)]++
+	}
+	rank := make(map[*cmacme.Challenge]int, len(chs))
+	for _, ch := range chs {
+		zone := challengeZone(ch)
+		rank[ch] = perZone[zone]
+		perZone[zone]++
+	}
+	sort.SliceStable(chs, func(i, j int) bool {
+		return rank[chs[i]] < rank[chs[j]]
+	})
 }
 
 func sortChallengesByTimestamp(chs []*cmacme.Challenge) {
 	sort.Slice(chs, func(i, j int) bool {
-		return chs[i].CreationTimestamp.Before(&chs[j].CreationTimestamp)
+		if !chs[i].CreationTimestamp.Equal(&chs[j].CreationTimestamp) {
+			return chs[i].CreationTimestamp.Before(&chs[j].CreationTimestamp)
+		}
+		if chs[i].Namespace != chs[j].Namespace {
+			return chs[i].Namespace < chs[j].Namespace
+		}
+		return chs[i].Name < chs[j].Name
 	})
 }
 
+// challengeZone returns the registered domain of the challenge's DNS name,
+// which is used as an approximation of its DNS zone.
+func challengeZone(ch *cmacme.Challenge) string {
+	zone, err := publicsuffix.EffectiveTLDPlusOne(strings.TrimSuffix(ch.Spec.DNSName, "."))
+	if err != nil {
+		return ch.Spec.DNSName
+	}
+	return zone
+}
+
+// issuerKey returns a key identifying the issuer of the challenge.
+func issuerKey(ch *cmacme.Challenge) string {
+	ref := ch.Spec.IssuerRef
+	if ref.Kind == cmapi.ClusterIssuerKind {
+		return ref.Kind + "/" + ref.Name
+	}
+	return cmapi.IssuerKind + "/" + ch.Namespace + "/" + ref.Name
+}
+
+// countByIssuer returns the number of the given challenges per issuer key.
+func countByIssuer(chs []*cmacme.Challenge) map[string]int {
+	counts := make(map[string]int)
+	for _, ch := range chs {
+		counts[issuerKey(ch)]++
+	}
+	return counts
+}
+
 // notProcessingChallenges will filter out challenges from the given slice
 // that have status.processing set to true.
 func notProcessingChallenges(chs []*cmacme.Challenge) []*cmacme.Challenge {
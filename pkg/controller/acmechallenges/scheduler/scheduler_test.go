@@ -23,11 +23,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/diff"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -319,7 +322,7 @@ func TestScheduleN(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			s := New(context.Background(), challengesInformer.Lister(), maxConcurrentChallenges)
+			s := New(context.Background(), challengesInformer.Lister(), labels.Everything(), Options{MaxConcurrentChallenges: maxConcurrentChallenges})
 
 			if test.expected == nil {
 				test.expected = []*cmacme.Challenge{}
@@ -337,3 +340,98 @@ func TestScheduleN(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleNOptions(t *testing.T) {
+	challenge := func(name, dnsName, issuer string, mods ...gen.ChallengeModifier) *cmacme.Challenge {
+		return gen.Challenge(name, append([]gen.ChallengeModifier{
+			gen.SetChallengeDNSName(dnsName),
+			gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: issuer, Kind: "Issuer"}),
+		}, mods...)...)
+	}
+	limitIssuer := func(name string, limit int) IssuerLimitFunc {
+		return func(ch *cmacme.Challenge) (int, bool) {
+			return limit, ch.Spec.IssuerRef.Name == name
+		}
+	}
+
+	tests := map[string]struct {
+		n           int
+		policy      Policy
+		issuerLimit IssuerLimitFunc
+		challenges  []*cmacme.Challenge
+		expected    []string
+	}{
+		"FIFO schedules in order of creation regardless of zone": {
+			n:      3,
+			policy: PolicyFIFO,
+			challenges: []*cmacme.Challenge{
+				challenge("a", "a.example.com", "issuer", withCreationTimestamp(1)),
+				challenge("b", "b.example.com", "issuer", withCreationTimestamp(2)),
+				challenge("c", "c.example.com", "issuer", withCreationTimestamp(3)),
+				challenge("d", "example.org", "issuer", withCreationTimestamp(4)),
+			},
+			expected: []string{"a", "b", "c"},
+		},
+		"ZoneFair gives each zone a turn before any zone gets a second one": {
+			n:      3,
+			policy: PolicyZoneFair,
+			challenges: []*cmacme.Challenge{
+				challenge("a", "a.example.com", "issuer", withCreationTimestamp(1)),
+				challenge("b", "b.example.com", "issuer", withCreationTimestamp(2)),
+				challenge("c", "c.example.com", "issuer", withCreationTimestamp(3)),
+				challenge("d", "example.org", "issuer", withCreationTimestamp(4)),
+			},
+			expected: []string{"a", "d", "b"},
+		},
+		"ZoneFair takes processing challenges into account": {
+			n:      2,
+			policy: PolicyZoneFair,
+			challenges: []*cmacme.Challenge{
+				challenge("processing", "example.com", "issuer", gen.SetChallengeProcessing(true)),
+				challenge("a", "a.example.com", "issuer", withCreationTimestamp(1)),
+				challenge("b", "b.example.com", "issuer", withCreationTimestamp(2)),
+				challenge("c", "www.example.org", "issuer", withCreationTimestamp(3)),
+			},
+			expected: []string{"c", "a"},
+		},
+		"issuer limit skips the challenges of an issuer at its limit": {
+			n:           3,
+			issuerLimit: limitIssuer("limited", 1),
+			challenges: []*cmacme.Challenge{
+				challenge("processing", "example.com", "limited", gen.SetChallengeProcessing(true)),
+				challenge("a", "a.example.com", "limited", withCreationTimestamp(1)),
+				challenge("b", "b.example.com", "other", withCreationTimestamp(2)),
+			},
+			expected: []string{"b"},
+		},
+		"issuer limit counts the challenges selected in the same pass": {
+			n:           3,
+			issuerLimit: limitIssuer("limited", 2),
+			challenges: []*cmacme.Challenge{
+				challenge("a", "a.example.com", "limited", withCreationTimestamp(1)),
+				challenge("b", "b.example.com", "limited", withCreationTimestamp(2)),
+				challenge("c", "c.example.com", "limited", withCreationTimestamp(3)),
+			},
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := New(context.Background(), nil, nil, Options{
+				MaxConcurrentChallenges: maxConcurrentChallenges,
+				Policy:                  test.policy,
+				IssuerLimit:             test.issuerLimit,
+			})
+
+			chs, err := s.scheduleN(test.n, test.challenges)
+			require.NoError(t, err)
+
+			var names []string
+			for _, ch := range chs {
+				names = append(names, ch.Name)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
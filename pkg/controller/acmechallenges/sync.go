@@ -20,28 +20,49 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
-	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 const (
-	reasonDomainVerified = "DomainVerified"
-	reasonCleanUpError   = "CleanUpError"
-	reasonPresentError   = "PresentError"
-	reasonPresented      = "Presented"
-	reasonFailed         = "Failed"
+	reasonDomainVerified   = "DomainVerified"
+	reasonCleanUpError     = "CleanUpError"
+	reasonCleanUpAbandoned = "CleanUpAbandoned"
+	reasonPresentError     = "PresentError"
+	reasonPresented        = "Presented"
+	reasonFailed           = "Failed"
+	reasonSelfCheckTimeout = cmacme.ChallengeReasonSelfCheckTimeout
+
+	reasonSelfCheckSucceeded = "SelfCheckSucceeded"
+	reasonCleanedUp          = "CleanedUp"
+
+	// maxCleanUpAttempts is the number of consecutive times cleaning up the
+	// presented challenge values may fail before cert-manager gives up, so
+	// that a permanently failing DNS provider (e.g. revoked credentials) does
+	// not keep the Challenge around forever.
+	maxCleanUpAttempts = 5
+
+	// defaultDNS01RecordTTL is how long to wait after a DNS01 challenge
+	// record has propagated before accepting the challenge, if its solver
+	// does not configure the TTL of the record.
+	defaultDNS01RecordTTL = 60 * time.Second
 )
 
 // solver solves ACME challenges by presenting the given token and key in an
@@ -78,6 +99,14 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		return c.handleFinalizer(ctx, ch)
 	}
 
+	orderName, orphaned, err := c.orphanedBy(ctx, ch)
+	if err != nil {
+		return fmt.Errorf("error checking whether the order of the challenge exists: %w", err)
+	}
+	if orphaned {
+		return c.deleteOrphaned(ctx, ch, orderName)
+	}
+
 	// bail out early on if processing=false, as this challenge has not been
 	// scheduled yet.
 	if !ch.Status.Processing {
@@ -101,21 +130,12 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 	// left for us to do here.
 	if acme.IsFinalState(ch.Status.State) {
 		if ch.Status.Presented {
-			solver, err := c.solverFor(ch.Spec.Type)
-			if err != nil {
-				log.Error(err, "error getting solver for challenge")
-				return err
-			}
-
-			err = solver.CleanUp(ctx, genericIssuer, ch)
-			if err != nil {
-				c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCleanUpError, "Error cleaning up challenge: %v", err)
-				ch.Status.Reason = err.Error()
-				log.Error(err, "error cleaning up challenge")
+			if err := c.cleanUp(ctx, ch); err != nil {
 				return err
 			}
 
 			ch.Status.Presented = false
+			apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionPresented, cmmeta.ConditionFalse, reasonCleanedUp, "Presented challenge values have been cleaned up")
 		}
 
 		ch.Status.Processing = false
@@ -123,6 +143,13 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		return nil
 	}
 
+	// The client is loaded on each sync, rather than only if it is not
+	// registered, so that it is rebuilt when the Issuer's account changes.
+	if c.loadClient != nil {
+		if err := c.loadClient(ctx, genericIssuer); err != nil {
+			return err
+		}
+	}
 	cl, err := c.accountRegistry.GetClient(string(genericIssuer.GetUID()))
 	if err != nil {
 		return err
@@ -138,6 +165,7 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		if ch.Status.State == "" {
 			return fmt.Errorf("could not determine acme challenge status. retrying after applying back-off")
 		}
+		ch.Status.Step = cmacme.ChallengeStepCreated
 
 		// the change in the challenges status will trigger a resync.
 		// this ensures our cache is consistent so we don't call Present twice
@@ -146,7 +174,22 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		return nil
 	}
 
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ValidateCAA) {
+	solver, err := c.solverFor(ch.Spec.Type)
+	if err != nil {
+		return err
+	}
+
+	// Solving the challenge is resumed from the step following the last one
+	// which was completed, so that a challenge which has already been
+	// accepted is not accepted again after a restart of the controller. Some
+	// ACME servers treat a second response to a challenge as a duplicate.
+	if ch.Status.Step == cmacme.ChallengeStepAccepted {
+		return c.waitForAuthorization(ctx, cl, genericIssuer, solver, ch)
+	}
+
+	// CAA records can only be published for DNS names, so they are not
+	// checked for IP address identifiers.
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ValidateCAA) && ch.Spec.IdentifierType != cmacme.ACMEIdentifierTypeIP {
 		// check for CAA records.
 		// CAA records are static, so we don't have to present anything
 		// before we check for them.
@@ -168,36 +211,54 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		}
 	}
 
-	solver, err := c.solverFor(ch.Spec.Type)
-	if err != nil {
-		return err
-	}
-
 	if !ch.Status.Presented {
 		err := solver.Present(ctx, genericIssuer, ch)
 		if err != nil {
 			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonPresentError, "Error presenting challenge: %v", err)
 			ch.Status.Reason = err.Error()
+			apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionPresented, cmmeta.ConditionFalse, reasonPresentError, err.Error())
 			return err
 		}
 
 		ch.Status.Presented = true
+		ch.Status.PresentedTime = &metav1.Time{Time: c.clock.Now()}
+		ch.Status.Step = cmacme.ChallengeStepPresented
 		c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonPresented, "Presented challenge using %s challenge mechanism", ch.Spec.Type)
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionPresented, cmmeta.ConditionTrue, reasonPresented, fmt.Sprintf("Presented challenge using %s challenge mechanism", ch.Spec.Type))
 	}
 
-	err = solver.Check(ctx, genericIssuer, ch)
-	if err != nil {
-		log.Error(err, "propagation check failed")
-		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
+	if ch.Status.Step != cmacme.ChallengeStepSelfChecked {
+		if delay := c.propagationDelayRemaining(ch); delay > 0 {
+			ch.Status.Reason = fmt.Sprintf("Waiting %s for the %s challenge to propagate before checking it", delay, ch.Spec.Type)
+			apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonPropagationDelay, ch.Status.Reason)
+			key, err := controllerpkg.KeyFunc(ch)
+			// This is an unexpected edge case and should never occur
+			if err != nil {
+				return err
+			}
+			c.queue.AddAfter(key, delay)
+			return nil
+		}
 
+		propagated, err := c.checkPropagation(ctx, genericIssuer, solver, ch)
+		if err != nil || !propagated {
+			return err
+		}
+		ch.Status.Step = cmacme.ChallengeStepSelfChecked
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionTrue, reasonSelfCheckSucceeded, "Presented challenge values have propagated")
+	}
+
+	// Wait for the TTL of the record to pass before accepting the challenge,
+	// so that resolvers caching the record no longer serve a stale value.
+	// The challenge is re-queued rather than waited for in the worker.
+	if delay := c.recordTTLRemaining(ch); delay > 0 {
+		ch.Status.Reason = fmt.Sprintf("Waiting %s for the TTL of the %s challenge record before accepting it", delay, ch.Spec.Type)
 		key, err := controllerpkg.KeyFunc(ch)
 		// This is an unexpected edge case and should never occur
 		if err != nil {
 			return err
 		}
-
-		c.queue.AddAfter(key, c.DNS01CheckRetryPeriod)
-
+		c.queue.AddAfter(key, delay)
 		return nil
 	}
 
@@ -206,9 +267,151 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		return err
 	}
 
+	// The Accepted step is persisted before waiting for the ACME server to
+	// validate the challenge. Updating the status triggers a resync, which
+	// then waits for the authorization.
+	return nil
+}
+
+// checkPropagation returns true if the presented challenge values have
+// propagated. Otherwise, the challenge is re-queued to be checked again, or
+// marked as errored once the self check has timed out.
+func (c *controller) checkPropagation(ctx context.Context, genericIssuer cmapi.GenericIssuer, solver solver, ch *cmacme.Challenge) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	err := solver.Check(ctx, genericIssuer, ch)
+	if err != nil {
+		// A failing self check is expected until the challenge values have
+		// propagated, so it is only reported as a failure once it times out.
+		log.V(logf.InfoLevel).Info("waiting for challenge to propagate", "reason", err.Error())
+		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonAwaitingPropagation, ch.Status.Reason)
+
+		selfCheck := dns01SelfCheck(genericIssuer, ch)
+		if c.selfCheckTimedOut(ch, selfCheck) {
+			// Marking the challenge as errored fails its Order, which will
+			// be retried with new challenges.
+			ch.Status.State = cmacme.Errored
+			ch.Status.Reason = fmt.Sprintf("Timed out after %s waiting for %s challenge propagation: %s", selfCheck.Timeout.Duration, ch.Spec.Type, err)
+			c.recorder.Event(ch, corev1.EventTypeWarning, reasonSelfCheckTimeout, ch.Status.Reason)
+			apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonSelfCheckTimeout, ch.Status.Reason)
+			return false, nil
+		}
+
+		key, err := controllerpkg.KeyFunc(ch)
+		// This is an unexpected edge case and should never occur
+		if err != nil {
+			return false, err
+		}
+
+		retryPeriod := c.DNS01CheckRetryPeriod
+		if selfCheck != nil && selfCheck.PollInterval != nil {
+			retryPeriod = selfCheck.PollInterval.Duration
+		}
+		c.queue.AddAfter(key, retryPeriod)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// waitForAuthorization waits for the ACME server to validate the
+// authorization of an accepted challenge, and cleans up the presented
+// challenge values straight away if the solver is configured to.
+func (c *controller) waitForAuthorization(ctx context.Context, cl acmecl.Interface, genericIssuer cmapi.GenericIssuer, solver solver, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx)
+
+	log.V(logf.DebugLevel).Info("waiting for authorization for domain")
+	authorization, err := cl.WaitAuthorization(ctx, ch.Spec.AuthorizationURL)
+	if err != nil {
+		log.Error(err, "error waiting for authorization")
+		return c.handleAuthorizationError(ch, err)
+	}
+
+	ch.Status.State = cmacme.State(authorization.Status)
+	ch.Status.Step = cmacme.ChallengeStepValidated
+	ch.Status.Reason = "Successfully authorized domain"
+	ch.Status.Problem = nil
+	c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonDomainVerified, "Domain %q verified with %q validation", ch.Spec.DNSName, ch.Spec.Type)
+
+	if ch.Status.State == cmacme.Valid && cleanupImmediately(ch) {
+		log.V(logf.DebugLevel).Info("cleaning up challenge immediately after validation")
+		if err := solver.CleanUp(ctx, genericIssuer, ch); err != nil {
+			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCleanUpError, "Error cleaning up challenge: %v", err)
+			log.Error(err, "error cleaning up challenge")
+			// the challenge is still presented, so clean up will be retried
+			// once the challenge has been finalized.
+			return nil
+		}
+
+		ch.Status.Presented = false
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionPresented, cmmeta.ConditionFalse, reasonCleanedUp, "Presented challenge values have been cleaned up")
+	}
+
 	return nil
 }
 
+// propagationDelayRemaining returns how much longer to wait before checking
+// that the challenge values have propagated, if the DNS01 solver of the
+// challenge configures a minimum propagation delay.
+func (c *controller) propagationDelayRemaining(ch *cmacme.Challenge) time.Duration {
+	dns01 := ch.Spec.Solver.DNS01
+	if ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 || dns01 == nil || dns01.PropagationDelay == nil || ch.Status.PresentedTime == nil {
+		return 0
+	}
+	return dns01.PropagationDelay.Duration - c.clock.Since(ch.Status.PresentedTime.Time)
+}
+
+// recordTTLRemaining returns how much longer to wait after the self check of
+// a DNS01 challenge has succeeded before accepting the challenge. This is the
+// TTL configured on the DNS01 solver of the challenge, or
+// defaultDNS01RecordTTL if the solver does not configure one.
+func (c *controller) recordTTLRemaining(ch *cmacme.Challenge) time.Duration {
+	if ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 {
+		return 0
+	}
+	cond := apiutil.GetChallengeCondition(ch, cmacme.ChallengeConditionSelfCheckSucceeded)
+	if cond == nil || cond.Status != cmmeta.ConditionTrue || cond.LastTransitionTime == nil {
+		return 0
+	}
+	ttl := defaultDNS01RecordTTL
+	if dns01 := ch.Spec.Solver.DNS01; dns01 != nil && dns01.TTL != nil {
+		ttl = time.Duration(*dns01.TTL) * time.Second
+	}
+	return ttl - c.clock.Since(cond.LastTransitionTime.Time)
+}
+
+// dns01SelfCheck returns the configuration of the propagation checks of the
+// issuer if the challenge is a DNS01 challenge, or nil otherwise.
+func dns01SelfCheck(issuer cmapi.GenericIssuer, ch *cmacme.Challenge) *cmacme.ACMEDNS01SelfCheck {
+	if ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 || issuer.GetSpec().ACME == nil {
+		return nil
+	}
+	return issuer.GetSpec().ACME.DNS01SelfCheck
+}
+
+// selfCheckTimedOut returns true if the timeout of the propagation checks has
+// passed since the challenge was scheduled for processing.
+func (c *controller) selfCheckTimedOut(ch *cmacme.Challenge, selfCheck *cmacme.ACMEDNS01SelfCheck) bool {
+	if selfCheck == nil || selfCheck.Timeout == nil {
+		return false
+	}
+	cond := apiutil.GetChallengeCondition(ch, cmacme.ChallengeConditionScheduled)
+	if cond == nil || cond.Status != cmmeta.ConditionTrue || cond.LastTransitionTime == nil {
+		return false
+	}
+	return c.clock.Since(cond.LastTransitionTime.Time) >= selfCheck.Timeout.Duration
+}
+
+// cleanupImmediately returns true if the records presented for the challenge
+// should be removed as soon as the challenge has been validated.
+func cleanupImmediately(ch *cmacme.Challenge) bool {
+	return ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 &&
+		ch.Spec.Solver.DNS01 != nil &&
+		ch.Spec.Solver.DNS01.CleanupPolicy == cmacme.DNS01CleanupPolicyImmediate
+}
+
 // handleError will handle ACME error types, updating the challenge resource
 // with any new information found whilst inspecting the error response.
 // This may include marking the challenge as expired.
@@ -255,35 +458,82 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 		return nil
 	}
 
-	defer func() {
-		// call Update to remove the metadata.finalizers entry
-		ch.Finalizers = ch.Finalizers[1:]
-	}()
+	// Challenges which were presented are always cleaned up, even if they
+	// are no longer being processed, so that deleting an Order or
+	// Certificate part way through validation does not leave records behind.
+	if ch.Status.Processing || ch.Status.Presented {
+		// The finalizer is kept, and cleaning up retried, until cleanUp
+		// either succeeds or gives up.
+		if err := c.cleanUp(ctx, ch); err != nil {
+			return err
+		}
+	}
 
-	if !ch.Status.Processing {
+	// call Update to remove the metadata.finalizers entry
+	ch.Finalizers = ch.Finalizers[1:]
+
+	return nil
+}
+
+// cleanUp removes the challenge values presented for the Challenge. It
+// returns an error if cleaning up failed and should be retried. cleanUp gives
+// up, and reports the records which must be deleted manually, if the
+// force-cleanup annotation is set, if the issuer no longer exists, or once
+// cleaning up has failed maxCleanUpAttempts times in a row.
+func (c *controller) cleanUp(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx)
+
+	if ch.Annotations[cmacme.ForceCleanUpAnnotationKey] == "true" {
+		c.abandonCleanUp(ch, fmt.Sprintf("the %s annotation is set", cmacme.ForceCleanUpAnnotationKey))
 		return nil
 	}
 
 	genericIssuer, err := c.helper.GetGenericIssuer(ch.Spec.IssuerRef, ch.Namespace)
-	if err != nil {
-		return fmt.Errorf("error reading (cluster)issuer %q: %v", ch.Spec.IssuerRef.Name, err)
+	if k8sErrors.IsNotFound(err) {
+		c.abandonCleanUp(ch, fmt.Sprintf("the referenced issuer %q no longer exists", ch.Spec.IssuerRef.Name))
+		return nil
 	}
-
-	solver, err := c.solverFor(ch.Spec.Type)
-	if err != nil {
-		log.Error(err, "error getting solver for challenge")
+	if err == nil {
+		var solver solver
+		solver, err = c.solverFor(ch.Spec.Type)
+		if err == nil {
+			err = solver.CleanUp(ctx, genericIssuer, ch)
+		}
+	}
+	if err == nil {
+		ch.Status.FailedCleanUpAttempts = 0
 		return nil
 	}
 
-	err = solver.CleanUp(ctx, genericIssuer, ch)
-	if err != nil {
-		c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCleanUpError, "Error cleaning up challenge: %v", err)
-		ch.Status.Reason = err.Error()
-		log.Error(err, "error cleaning up challenge")
+	ch.Status.FailedCleanUpAttempts++
+	ch.Status.Reason = err.Error()
+	c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCleanUpError, "Error cleaning up challenge: %v", err)
+	log.Error(err, "error cleaning up challenge", "attempts", ch.Status.FailedCleanUpAttempts)
+
+	if ch.Status.FailedCleanUpAttempts >= maxCleanUpAttempts {
+		c.abandonCleanUp(ch, fmt.Sprintf("it has failed %d times", ch.Status.FailedCleanUpAttempts))
 		return nil
 	}
 
-	return nil
+	return err
+}
+
+// abandonCleanUp records that the challenge values presented for the
+// Challenge will not be cleaned up by cert-manager, so that they can be
+// deleted manually.
+func (c *controller) abandonCleanUp(ch *cmacme.Challenge, reason string) {
+	c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCleanUpAbandoned,
+		"Giving up cleaning up challenge as %s, the following must be deleted manually: %s", reason, leftoverChallengeRecords(ch))
+	c.metrics.IncrementChallengeCleanUpAbandonedCount(string(ch.Spec.Type))
+}
+
+// leftoverChallengeRecords describes the records presented for the
+// Challenge.
+func leftoverChallengeRecords(ch *cmacme.Challenge) string {
+	if ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 {
+		return fmt.Sprintf("TXT record %q with value %q", "_acme-challenge."+ch.Spec.DNSName, ch.Spec.Key)
+	}
+	return fmt.Sprintf("HTTP01 solver Pods, Services and Ingresses for %q in namespace %q", ch.Spec.DNSName, ch.Namespace)
 }
 
 // syncChallengeStatus will communicate with the ACME server to retrieve the current
@@ -336,23 +586,23 @@ func (c *controller) syncChallengeStatus(ctx context.Context, cl acmecl.Interfac
 	// an account). We might be able to handle errors more gracefully using
 	// this info
 	ch.Status.Reason = ""
+	ch.Status.Problem = nil
 	if acmeChallenge.Error != nil {
 		if acmeErr, ok := acmeChallenge.Error.(*acmeapi.Error); ok {
 			ch.Status.Reason = acmeErr.Detail
 		} else {
 			ch.Status.Reason = acmeChallenge.Error.Error()
 		}
+		ch.Status.Problem = acme.ProblemFromError(acmeChallenge.Error)
 	}
 	ch.Status.State = cmState
 
 	return nil
 }
 
-// acceptChallenge will accept the challenge with the acme server and then wait
-// for the authorization to reach a 'final' state.
-// It will update the challenge's status to reflect the final state of the
-// challenge if it failed, or the final state of the challenge's authorization
-// if accepting the challenge succeeds.
+// acceptChallenge will accept the challenge with the acme server, and mark the
+// Accepted step as completed if it succeeds. It will update the challenge's
+// status to reflect the state of the challenge returned by the acme server.
 func (c *controller) acceptChallenge(ctx context.Context, cl acmecl.Interface, ch *cmacme.Challenge) error {
 	log := logf.FromContext(ctx, "acceptChallenge")
 
@@ -373,16 +623,8 @@ func (c *controller) acceptChallenge(ctx context.Context, cl acmecl.Interface, c
 		return handleError(ch, err)
 	}
 
-	log.V(logf.DebugLevel).Info("waiting for authorization for domain")
-	authorization, err := cl.WaitAuthorization(ctx, ch.Spec.AuthorizationURL)
-	if err != nil {
-		log.Error(err, "error waiting for authorization")
-		return c.handleAuthorizationError(ch, err)
-	}
-
-	ch.Status.State = cmacme.State(authorization.Status)
-	ch.Status.Reason = "Successfully authorized domain"
-	c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonDomainVerified, "Domain %q verified with %q validation", ch.Spec.DNSName, ch.Spec.Type)
+	ch.Status.Step = cmacme.ChallengeStepAccepted
+	ch.Status.Reason = "Waiting for the ACME server to validate the challenge"
 
 	return nil
 }
@@ -401,6 +643,14 @@ func (c *controller) handleAuthorizationError(ch *cmacme.Challenge, err error) e
 	//   if the returned state is 'invalid'
 	ch.Status.State = cmacme.Invalid
 	ch.Status.Reason = fmt.Sprintf("Error accepting authorization: %v", authErr)
+	// Record the validation error reported by the ACME server, so that the
+	// Order can report which of its identifiers failed.
+	for _, err := range authErr.Errors {
+		if problem := acme.ProblemFromError(err); problem != nil {
+			ch.Status.Problem = problem
+			break
+		}
+	}
 	c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonFailed, "Accepting challenge authorization failed: %v", authErr)
 
 	// return nil here, as accepting the challenge did not error, the challenge
@@ -21,11 +21,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
-	acmeapi "golang.org/x/crypto/acme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -35,6 +37,7 @@ import (
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 // Present the challenge value with the given solver.
@@ -89,9 +92,151 @@ func TestSyncHappyPath(t *testing.T) {
 	)
 	deletedChallenge := gen.ChallengeFrom(baseChallenge,
 		gen.SetChallengeDeletionTimestamp(metav1.Now()))
+	testOrder := gen.Order("testorder", gen.SetOrderUID("order-uid"))
+	orphanedChallenge := gen.ChallengeFrom(baseChallenge,
+		gen.SetChallengeOwnerReference(*metav1.NewControllerRef(testOrder, cmacme.SchemeGroupVersion.WithKind(cmacme.OrderKind))),
+	)
+
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	testIssuerDNS01SelfCheckTimeout := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{Nameserver: "127.0.0.1"},
+				},
+			},
+		},
+		DNS01SelfCheck: &cmacme.ACMEDNS01SelfCheck{
+			Timeout: &metav1.Duration{Duration: time.Minute * 10},
+		},
+	}))
+	scheduledAt := func(t time.Time) gen.ChallengeModifier {
+		return gen.SetChallengeStatusCondition(cmacme.ChallengeCondition{
+			Type:               cmacme.ChallengeConditionScheduled,
+			Status:             cmmeta.ConditionTrue,
+			LastTransitionTime: &metav1.Time{Time: t},
+			Reason:             "Scheduled",
+			Message:            "Challenge scheduled for processing",
+		})
+	}
+	conditionNow := func(typ cmacme.ChallengeConditionType, status cmmeta.ConditionStatus, reason, message string) gen.ChallengeModifier {
+		return gen.SetChallengeStatusCondition(cmacme.ChallengeCondition{
+			Type:               typ,
+			Status:             status,
+			LastTransitionTime: &metav1.Time{Time: fixedClock.Now()},
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+	cleanedUpNow := conditionNow(cmacme.ChallengeConditionPresented, cmmeta.ConditionFalse, "CleanedUp", "Presented challenge values have been cleaned up")
+	presentedDNS01Challenge := gen.ChallengeFrom(baseChallenge,
+		gen.SetChallengeProcessing(true),
+		gen.SetChallengeURL("testurl"),
+		gen.SetChallengeState(cmacme.Pending),
+		gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+		gen.SetChallengePresented(true),
+	)
+	delayedDNS01Challenge := gen.ChallengeFrom(presentedDNS01Challenge,
+		gen.SetChallengeSolver(cmacme.ACMEChallengeSolver{
+			DNS01: &cmacme.ACMEChallengeSolverDNS01{
+				PropagationDelay: &metav1.Duration{Duration: time.Minute * 2},
+			},
+		}),
+		gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-time.Second*30))),
+	)
+	failingDNS01Solver := &fakeSolver{
+		fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+			return fmt.Errorf("some error")
+		},
+	}
+
+	passingDNS01Solver := &fakeSolver{
+		fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+			return nil
+		},
+	}
+	ttlDNS01Challenge := gen.ChallengeFrom(presentedDNS01Challenge,
+		gen.SetChallengeDNSName("test.com"),
+		gen.SetChallengeSolver(cmacme.ACMEChallengeSolver{
+			DNS01: &cmacme.ACMEChallengeSolverDNS01{
+				TTL: pointer.Int(120),
+			},
+		}),
+	)
+	selfCheckedAt := func(t time.Time) gen.ChallengeModifier {
+		return gen.SetChallengeStatusCondition(cmacme.ChallengeCondition{
+			Type:               cmacme.ChallengeConditionSelfCheckSucceeded,
+			Status:             cmmeta.ConditionTrue,
+			LastTransitionTime: &metav1.Time{Time: t},
+			Reason:             "SelfCheckSucceeded",
+			Message:            "Presented challenge values have propagated",
+		})
+	}
+	acceptedDNS01Challenge := func(policy cmacme.DNS01CleanupPolicy) *cmacme.Challenge {
+		return gen.ChallengeFrom(presentedDNS01Challenge,
+			gen.SetChallengeDNSName("test.com"),
+			gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
+			gen.SetChallengeSolver(cmacme.ACMEChallengeSolver{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					CleanupPolicy: policy,
+				},
+			}),
+		)
+	}
+	validatedDNS01Challenge := func(policy cmacme.DNS01CleanupPolicy, mods ...gen.ChallengeModifier) *cmacme.Challenge {
+		return gen.ChallengeFrom(acceptedDNS01Challenge(policy), append([]gen.ChallengeModifier{
+			gen.SetChallengeState(cmacme.Valid),
+			gen.SetChallengeStep(cmacme.ChallengeStepValidated),
+			gen.SetChallengeReason("Successfully authorized domain"),
+		}, mods...)...)
+	}
+	validAuthorizationClient := &acmecl.FakeACME{
+		FakeWaitAuthorization: func(context.Context, string) (*acmeapi.Authorization, error) {
+			return &acmeapi.Authorization{Status: acmeapi.StatusValid}, nil
+		},
+	}
 
 	simulatedCleanupError := errors.New("simulated-cleanup-error")
 	tests := map[string]testT{
+		"cleanup if a presented challenge which is no longer processing is deleted": {
+			challenge: gen.ChallengeFrom(deletedChallenge,
+				gen.SetChallengePresented(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return simulatedCleanupError
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(deletedChallenge,
+						gen.SetChallengePresented(true),
+						gen.SetChallengeURL("testurl"),
+						gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					),
+					testIssuerHTTP01Enabled,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(
+						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+							"status",
+							gen.DefaultTestNamespace,
+							gen.ChallengeFrom(deletedChallenge,
+								gen.SetChallengePresented(true),
+								gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+								gen.SetChallengeURL("testurl"),
+								gen.SetChallengeReason(simulatedCleanupError.Error()),
+								gen.SetChallengeFailedCleanUpAttempts(1),
+							))),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf("Warning CleanUpError Error cleaning up challenge: %s", simulatedCleanupError),
+				},
+			},
+			expectErr: true,
+		},
 		"cleanup if the challenge is deleted and remove the finalizer": {
 			challenge: gen.ChallengeFrom(deletedChallenge,
 				gen.SetChallengeProcessing(true),
@@ -124,7 +269,7 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
-		"if the challenge is deleted and the cleanup fails, set the reason (and remove the finalizer, which is a bug)": {
+		"if the challenge is deleted and the cleanup fails, set the reason and keep the finalizer to retry": {
 			challenge: gen.ChallengeFrom(deletedChallenge,
 				gen.SetChallengeProcessing(true),
 				gen.SetChallengeURL("testurl"),
@@ -144,15 +289,60 @@ func TestSyncHappyPath(t *testing.T) {
 					),
 					testIssuerHTTP01Enabled,
 				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(
+						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+							"status",
+							gen.DefaultTestNamespace,
+							gen.ChallengeFrom(deletedChallenge,
+								gen.SetChallengeProcessing(true),
+								gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+								gen.SetChallengeURL("testurl"),
+								gen.SetChallengeReason(simulatedCleanupError.Error()),
+								gen.SetChallengeFailedCleanUpAttempts(1),
+							))),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf("Warning CleanUpError Error cleaning up challenge: %s", simulatedCleanupError),
+				},
+			},
+			expectErr: true,
+		},
+		"if the challenge is deleted and the cleanup has failed too many times, give up and remove the finalizer": {
+			challenge: gen.ChallengeFrom(deletedChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+				gen.SetChallengeDNSName("example.com"),
+				gen.SetChallengeFailedCleanUpAttempts(maxCleanUpAttempts-1),
+			),
+			dnsSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return simulatedCleanupError
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(deletedChallenge,
+						gen.SetChallengeProcessing(true),
+						gen.SetChallengeURL("testurl"),
+						gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+						gen.SetChallengeDNSName("example.com"),
+						gen.SetChallengeFailedCleanUpAttempts(maxCleanUpAttempts-1),
+					),
+					testIssuerHTTP01Enabled,
+				},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
 						gen.DefaultTestNamespace,
 						gen.ChallengeFrom(deletedChallenge,
 							gen.SetChallengeProcessing(true),
 							gen.SetChallengeURL("testurl"),
-							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+							gen.SetChallengeDNSName("example.com"),
 							gen.SetChallengeFinalizers([]string{}),
 							gen.SetChallengeReason(simulatedCleanupError.Error()),
+							gen.SetChallengeFailedCleanUpAttempts(maxCleanUpAttempts),
 						))),
 					testpkg.NewAction(
 						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
@@ -160,14 +350,59 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.DefaultTestNamespace,
 							gen.ChallengeFrom(deletedChallenge,
 								gen.SetChallengeProcessing(true),
-								gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 								gen.SetChallengeURL("testurl"),
+								gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+								gen.SetChallengeDNSName("example.com"),
 								gen.SetChallengeFinalizers([]string{}),
 								gen.SetChallengeReason(simulatedCleanupError.Error()),
+								gen.SetChallengeFailedCleanUpAttempts(maxCleanUpAttempts),
 							))),
 				},
 				ExpectedEvents: []string{
 					fmt.Sprintf("Warning CleanUpError Error cleaning up challenge: %s", simulatedCleanupError),
+					`Warning CleanUpAbandoned Giving up cleaning up challenge as it has failed 5 times, the following must be deleted manually: TXT record "_acme-challenge.example.com" with value ""`,
+				},
+			},
+		},
+		"if the challenge is deleted with the force-cleanup annotation, remove the finalizer without cleaning up": {
+			challenge: gen.ChallengeFrom(deletedChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengeDNSName("example.com"),
+				gen.SetChallengeAnnotations(map[string]string{cmacme.ForceCleanUpAnnotationKey: "true"}),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					t.Error("unexpected call to CleanUp")
+					return nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(deletedChallenge,
+						gen.SetChallengeProcessing(true),
+						gen.SetChallengeURL("testurl"),
+						gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+						gen.SetChallengeDNSName("example.com"),
+						gen.SetChallengeAnnotations(map[string]string{cmacme.ForceCleanUpAnnotationKey: "true"}),
+					),
+					testIssuerHTTP01Enabled,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(deletedChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeDNSName("example.com"),
+							gen.SetChallengeAnnotations(map[string]string{cmacme.ForceCleanUpAnnotationKey: "true"}),
+							gen.SetChallengeFinalizers([]string{}),
+						))),
+				},
+				ExpectedEvents: []string{
+					`Warning CleanUpAbandoned Giving up cleaning up challenge as the acme.cert-manager.io/force-cleanup annotation is set, the following must be deleted manually: HTTP01 solver Pods, Services and Ingresses for "example.com" in namespace "default-unit-test-ns"`,
 				},
 			},
 		},
@@ -235,6 +470,7 @@ func TestSyncHappyPath(t *testing.T) {
 								gen.SetChallengeProcessing(true),
 								gen.SetChallengeURL("testurl"),
 								gen.SetChallengeState(cmacme.Ready),
+								gen.SetChallengeStep(cmacme.ChallengeStepCreated),
 							))),
 				},
 			},
@@ -267,6 +503,7 @@ func TestSyncHappyPath(t *testing.T) {
 								gen.SetChallengeProcessing(true),
 								gen.SetChallengeURL("testurl"),
 								gen.SetChallengeState(cmacme.Pending),
+								gen.SetChallengeStep(cmacme.ChallengeStepCreated),
 							))),
 				},
 			},
@@ -296,6 +533,7 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 			builder: &testpkg.Builder{
+				Clock: fixedClock,
 				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
 					gen.SetChallengeProcessing(true),
 					gen.SetChallengeURL("testurl"),
@@ -311,8 +549,12 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeURL("testurl"),
 							gen.SetChallengeState(cmacme.Pending),
 							gen.SetChallengePresented(true),
+							gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now())),
+							gen.SetChallengeStep(cmacme.ChallengeStepPresented),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation: some error"),
+							conditionNow(cmacme.ChallengeConditionPresented, cmmeta.ConditionTrue, "Presented", "Presented challenge using HTTP-01 challenge mechanism"),
+							conditionNow(cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonAwaitingPropagation, "Waiting for HTTP-01 challenge propagation: some error"),
 						))),
 				},
 				ExpectedEvents: []string{
@@ -320,6 +562,97 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"wait for the propagation delay of the solver before checking a presented challenge": {
+			challenge:  delayedDNS01Challenge,
+			dnsSolver:  failingDNS01Solver,
+			acmeClient: &acmecl.FakeACME{},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					delayedDNS01Challenge,
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(delayedDNS01Challenge,
+							gen.SetChallengeReason("Waiting 1m30s for the DNS-01 challenge to propagate before checking it"),
+							conditionNow(cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonPropagationDelay, "Waiting 1m30s for the DNS-01 challenge to propagate before checking it"),
+						))),
+				},
+			},
+		},
+		"check a presented challenge once the propagation delay of the solver has passed": {
+			challenge:  gen.ChallengeFrom(delayedDNS01Challenge, gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-time.Minute*2)))),
+			dnsSolver:  failingDNS01Solver,
+			acmeClient: &acmecl.FakeACME{},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(delayedDNS01Challenge, gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-time.Minute*2)))),
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(delayedDNS01Challenge,
+							gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-time.Minute*2))),
+							gen.SetChallengeReason("Waiting for DNS-01 challenge propagation: some error"),
+							conditionNow(cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonAwaitingPropagation, "Waiting for DNS-01 challenge propagation: some error"),
+						))),
+				},
+			},
+		},
+		"keep waiting for the challenge to propagate before the self check timeout has passed": {
+			challenge:  gen.ChallengeFrom(presentedDNS01Challenge, scheduledAt(fixedClock.Now().Add(-time.Minute*5))),
+			dnsSolver:  failingDNS01Solver,
+			acmeClient: &acmecl.FakeACME{},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(presentedDNS01Challenge, scheduledAt(fixedClock.Now().Add(-time.Minute*5))),
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(presentedDNS01Challenge,
+							scheduledAt(fixedClock.Now().Add(-time.Minute*5)),
+							gen.SetChallengeReason("Waiting for DNS-01 challenge propagation: some error"),
+							conditionNow(cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonAwaitingPropagation, "Waiting for DNS-01 challenge propagation: some error"),
+						))),
+				},
+			},
+		},
+		"mark the challenge as errored once the self check timeout has passed": {
+			challenge:  gen.ChallengeFrom(presentedDNS01Challenge, scheduledAt(fixedClock.Now().Add(-time.Minute*10))),
+			dnsSolver:  failingDNS01Solver,
+			acmeClient: &acmecl.FakeACME{},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(presentedDNS01Challenge, scheduledAt(fixedClock.Now().Add(-time.Minute*10))),
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(presentedDNS01Challenge,
+							scheduledAt(fixedClock.Now().Add(-time.Minute*10)),
+							gen.SetChallengeState(cmacme.Errored),
+							gen.SetChallengeReason("Timed out after 10m0s waiting for DNS-01 challenge propagation: some error"),
+							conditionNow(cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionFalse, cmacme.ChallengeReasonSelfCheckTimeout, "Timed out after 10m0s waiting for DNS-01 challenge propagation: some error"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning SelfCheckTimeout Timed out after 10m0s waiting for DNS-01 challenge propagation: some error",
+				},
+			},
+		},
 		"accept the challenge if the self check is passing": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -337,6 +670,184 @@ func TestSyncHappyPath(t *testing.T) {
 					return nil
 				},
 			},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeDNSName("test.com"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeDNSName("test.com"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(true),
+							gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
+							gen.SetChallengeReason("Waiting for the ACME server to validate the challenge"),
+							conditionNow(cmacme.ChallengeConditionSelfCheckSucceeded, cmmeta.ConditionTrue, "SelfCheckSucceeded", "Presented challenge values have propagated"),
+						))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
+					return &acmeapi.Challenge{Status: acmeapi.StatusPending}, nil
+				},
+			},
+		},
+		"wait for the TTL of the record before accepting a DNS01 challenge whose self check passed": {
+			challenge:  ttlDNS01Challenge,
+			dnsSolver:  passingDNS01Solver,
+			acmeClient: &acmecl.FakeACME{},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					ttlDNS01Challenge,
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(ttlDNS01Challenge,
+							gen.SetChallengeStep(cmacme.ChallengeStepSelfChecked),
+							gen.SetChallengeReason("Waiting 2m0s for the TTL of the DNS-01 challenge record before accepting it"),
+							selfCheckedAt(fixedClock.Now()),
+						))),
+				},
+			},
+		},
+		"accept a DNS01 challenge once the TTL of its record has passed": {
+			challenge: gen.ChallengeFrom(ttlDNS01Challenge,
+				gen.SetChallengeStep(cmacme.ChallengeStepSelfChecked),
+				selfCheckedAt(fixedClock.Now().Add(-time.Minute*2)),
+			),
+			dnsSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(ttlDNS01Challenge,
+						gen.SetChallengeStep(cmacme.ChallengeStepSelfChecked),
+						selfCheckedAt(fixedClock.Now().Add(-time.Minute*2)),
+					),
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(ttlDNS01Challenge,
+							gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
+							gen.SetChallengeReason("Waiting for the ACME server to validate the challenge"),
+							selfCheckedAt(fixedClock.Now().Add(-time.Minute*2)),
+						))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
+					return &acmeapi.Challenge{Status: acmeapi.StatusPending}, nil
+				},
+			},
+		},
+		"clean up a DNS01 challenge as soon as it is validated if the cleanup policy is Immediate": {
+			challenge: acceptedDNS01Challenge(cmacme.DNS01CleanupPolicyImmediate),
+			dnsSolver: &fakeSolver{
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			acmeClient: validAuthorizationClient,
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					acceptedDNS01Challenge(cmacme.DNS01CleanupPolicyImmediate),
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						validatedDNS01Challenge(cmacme.DNS01CleanupPolicyImmediate,
+							gen.SetChallengePresented(false),
+							cleanedUpNow,
+						))),
+				},
+				ExpectedEvents: []string{
+					`Normal DomainVerified Domain "test.com" verified with "DNS-01" validation`,
+				},
+			},
+		},
+		"keep a DNS01 challenge presented if cleaning it up immediately fails": {
+			challenge: acceptedDNS01Challenge(cmacme.DNS01CleanupPolicyImmediate),
+			dnsSolver: &fakeSolver{
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return simulatedCleanupError
+				},
+			},
+			acmeClient: validAuthorizationClient,
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					acceptedDNS01Challenge(cmacme.DNS01CleanupPolicyImmediate),
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						validatedDNS01Challenge(cmacme.DNS01CleanupPolicyImmediate))),
+				},
+				ExpectedEvents: []string{
+					`Normal DomainVerified Domain "test.com" verified with "DNS-01" validation`,
+					fmt.Sprintf("Warning CleanUpError Error cleaning up challenge: %s", simulatedCleanupError),
+				},
+			},
+		},
+		"retain the records of a validated DNS01 challenge until it is finalized if the cleanup policy is Deferred": {
+			challenge: acceptedDNS01Challenge(cmacme.DNS01CleanupPolicyDeferred),
+			dnsSolver: &fakeSolver{
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					t.Error("unexpected call to CleanUp")
+					return nil
+				},
+			},
+			acmeClient: validAuthorizationClient,
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					acceptedDNS01Challenge(cmacme.DNS01CleanupPolicyDeferred),
+					testIssuerDNS01SelfCheckTimeout,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						validatedDNS01Challenge(cmacme.DNS01CleanupPolicyDeferred))),
+				},
+				ExpectedEvents: []string{
+					`Normal DomainVerified Domain "test.com" verified with "DNS-01" validation`,
+				},
+			},
+		},
+		"wait for the authorization of an accepted challenge without accepting it again": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeDNSName("test.com"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+				gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
+			),
+			httpSolver: &fakeSolver{},
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
 					gen.SetChallengeProcessing(true),
@@ -345,6 +856,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengeState(cmacme.Pending),
 					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 					gen.SetChallengePresented(true),
+					gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
@@ -357,6 +869,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Valid),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(true),
+							gen.SetChallengeStep(cmacme.ChallengeStepValidated),
 							gen.SetChallengeReason("Successfully authorized domain"),
 						))),
 				},
@@ -365,12 +878,6 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
-				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
-					// return something other than valid here so we can verify that
-					// the challenge.status.state is set to the *authorizations*
-					// status and not the challenges
-					return &acmeapi.Challenge{Status: acmeapi.StatusPending}, nil
-				},
 				FakeWaitAuthorization: func(context.Context, string) (*acmeapi.Authorization, error) {
 					return &acmeapi.Authorization{Status: acmeapi.StatusValid}, nil
 				},
@@ -383,11 +890,9 @@ func TestSyncHappyPath(t *testing.T) {
 				gen.SetChallengeState(cmacme.Pending),
 				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 				gen.SetChallengePresented(true),
+				gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
 			),
 			httpSolver: &fakeSolver{
-				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
-					return nil
-				},
 				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
 					return nil
 				},
@@ -399,6 +904,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengeState(cmacme.Pending),
 					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 					gen.SetChallengePresented(true),
+					gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
@@ -410,6 +916,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Invalid),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(true),
+							gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
 							gen.SetChallengeReason("Error accepting authorization: acme: authorization error for example.com: an error happened"),
 						))),
 				},
@@ -418,12 +925,6 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
-				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
-					// return something other than invalid here so we can verify that
-					// the challenge.status.state is set to the *authorizations*
-					// status and not the challenges
-					return &acmeapi.Challenge{Status: acmeapi.StatusPending}, nil
-				},
 				FakeWaitAuthorization: func(context.Context, string) (*acmeapi.Authorization, error) {
 					return nil, &acmeapi.AuthorizationError{
 						URI:        "http://testerroruri",
@@ -442,11 +943,9 @@ func TestSyncHappyPath(t *testing.T) {
 				gen.SetChallengeState(cmacme.Pending),
 				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 				gen.SetChallengePresented(true),
+				gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
 			),
 			httpSolver: &fakeSolver{
-				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
-					return nil
-				},
 				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
 					return nil
 				},
@@ -458,6 +957,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengeState(cmacme.Pending),
 					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 					gen.SetChallengePresented(true),
+					gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
@@ -469,7 +969,13 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Invalid),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(true),
+							gen.SetChallengeStep(cmacme.ChallengeStepAccepted),
 							gen.SetChallengeReason("Error accepting authorization: acme: authorization error for example.com: 400 fakeerror: this is a very detailed error"),
+							gen.SetChallengeProblem(&cmacme.ACMEProblem{
+								Type:   "fakeerror",
+								Detail: "this is a very detailed error",
+								Status: 400,
+							}),
 						))),
 				},
 				ExpectedEvents: []string{
@@ -477,12 +983,6 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
-				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
-					// return something other than invalid here so we can verify that
-					// the challenge.status.state is set to the *authorizations*
-					// status and not the challenges
-					return &acmeapi.Challenge{Status: acmeapi.StatusPending}, nil
-				},
 				FakeWaitAuthorization: func(context.Context, string) (*acmeapi.Authorization, error) {
 					return nil, &acmeapi.AuthorizationError{
 						URI:        "http://testerroruri",
@@ -512,6 +1012,7 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 			builder: &testpkg.Builder{
+				Clock: fixedClock,
 				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
 					gen.SetChallengeProcessing(true),
 					gen.SetChallengeURL("testurl"),
@@ -529,6 +1030,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Valid),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(false),
+							cleanedUpNow,
 						))),
 				},
 			},
@@ -547,6 +1049,7 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 			builder: &testpkg.Builder{
+				Clock: fixedClock,
 				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
 					gen.SetChallengeProcessing(true),
 					gen.SetChallengeURL("testurl"),
@@ -564,10 +1067,70 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Invalid),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(false),
+							cleanedUpNow,
 						))),
 				},
 			},
 		},
+		"delete a challenge whose order no longer exists": {
+			challenge: gen.ChallengeFrom(orphanedChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengePresented(true),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(orphanedChallenge,
+						gen.SetChallengeProcessing(true),
+						gen.SetChallengePresented(true),
+						gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					),
+					testIssuerHTTP01Enabled,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewGetAction(cmacme.SchemeGroupVersion.WithResource("orders"), gen.DefaultTestNamespace, "testorder")),
+					testpkg.NewAction(coretesting.NewDeleteAction(cmacme.SchemeGroupVersion.WithResource("challenges"), gen.DefaultTestNamespace, "testchal")),
+				},
+				ExpectedEvents: []string{
+					`Normal Orphaned Deleting challenge as the Order "testorder" which owns it no longer exists`,
+				},
+			},
+		},
+		"delete a challenge whose order has been replaced by another with the same name": {
+			challenge: gen.ChallengeFrom(orphanedChallenge,
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(orphanedChallenge,
+						gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					),
+					gen.OrderFrom(testOrder, gen.SetOrderUID("other-order-uid")),
+					testIssuerHTTP01Enabled,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewGetAction(cmacme.SchemeGroupVersion.WithResource("orders"), gen.DefaultTestNamespace, "testorder")),
+					testpkg.NewAction(coretesting.NewDeleteAction(cmacme.SchemeGroupVersion.WithResource("challenges"), gen.DefaultTestNamespace, "testchal")),
+				},
+				ExpectedEvents: []string{
+					`Normal Orphaned Deleting challenge as the Order "testorder" which owns it no longer exists`,
+				},
+			},
+		},
+		"do nothing if the order of an unscheduled challenge exists": {
+			challenge: gen.ChallengeFrom(orphanedChallenge,
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(orphanedChallenge,
+						gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					),
+					testOrder,
+					testIssuerHTTP01Enabled,
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -23,13 +23,18 @@ import (
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
@@ -38,6 +43,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 var keyFunc = controllerpkg.KeyFunc
@@ -49,6 +55,37 @@ type controller struct {
 	// used to fetch ACME clients used in the controller
 	accountRegistry accounts.Getter
 
+	// loadClient registers the ACME client of an Issuer that is not present
+	// in the account registry, for example because the issuers controllers
+	// are not running in this process.
+	loadClient accounts.LoadClientFunc
+
+	// orderLongPollingSupported reports whether pending Orders for an Issuer
+	// should be waited on by long-polling its ACME server. It is nil if the
+	// ACMEOrderLongPolling feature gate is disabled.
+	orderLongPollingSupported func(ctx context.Context, issuer cmapi.GenericIssuer) bool
+
+	// directoryMeta returns the metadata advertised in the directory of the
+	// ACME server of an Issuer, such as the certificate profiles it supports
+	// and the maximum number of identifiers per order, which Orders are
+	// checked against before they are created.
+	directoryMeta func(ctx context.Context, issuer cmapi.GenericIssuer) (*acmecl.DirectoryMeta, error)
+
+	// issuancePause reports whether issuance is paused, in which case new
+	// Orders are not submitted to the ACME server.
+	issuancePause *controllerpkg.IssuancePause
+
+	// diagnosticsTTL is how long the diagnostics of failed Orders are
+	// retained for in a ConfigMap. If zero, no diagnostics are retained.
+	diagnosticsTTL time.Duration
+	// kubeClient is used to manage the diagnostics ConfigMaps.
+	kubeClient kubernetes.Interface
+
+	// authzFetchParallelism is the maximum number of authorizations of an
+	// Order which are fetched at the same time. Values below 1 are treated
+	// as 1.
+	authzFetchParallelism int
+
 	// all the listers used by this controller
 	orderLister         cmacmelisters.OrderLister
 	challengeLister     cmacmelisters.ChallengeLister
@@ -66,6 +103,15 @@ type controller struct {
 	// fieldManager is the manager name used for the Apply operations on Secrets.
 	fieldManager string
 
+	// ownedBy identifies this cert-manager instance. If set, only Orders
+	// labelled with it are synced, and the Challenges created for them are
+	// labelled with it.
+	ownedBy string
+
+	// issuerClass is the issuer class of this cert-manager instance. Only
+	// Orders of issuers of this class are synced.
+	issuerClass string
+
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
 	queue workqueue.RateLimitingInterface
@@ -180,6 +226,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsOwnedBy(order, c.ownedBy) {
+		logf.WithResource(log, order).V(logf.DebugLevel).Info("skipping order not owned by this cert-manager instance", "owned_by", c.ownedBy)
+		return nil
+	}
+
+	if !c.claimed(order) {
+		logf.WithResource(log, order).V(logf.DebugLevel).Info("skipping order of an issuer with another issuer class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	ctx = logf.NewContext(ctx, logf.WithResource(log, order))
 	return c.Sync(ctx, order)
 }
@@ -224,6 +280,31 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		isNamespaced,
 		ctx.FieldManager,
 	)
+	loader := accounts.NewClientLoader(
+		ctx.ACMEOptions.AccountRegistry,
+		ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		ctx.IssuerOptions.ClusterResourceNamespace,
+		ctx.Metrics,
+		ctx.ACMEOptions.HTTPClient,
+		ctx.ExternalUserAgent,
+	)
+	ctrl.loadClient = loader.LoadClient
+	// Clients loaded for Issuers are removed when the Issuer is deleted.
+	ctx.SharedInformerFactory.Certmanager().V1().Issuers().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: loader.IssuerDeleted})
+	if !isNamespaced {
+		ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: loader.IssuerDeleted})
+	}
+	directoryMeta := newDirectoryMetaChecker(ctx.Metrics, ctx.ACMEOptions.HTTPClient, ctx.ACMEOptions.DirectoryMetaCache)
+	ctrl.directoryMeta = directoryMeta.directoryMeta
+	ctrl.ownedBy = ctx.OwnedBy
+	ctrl.issuerClass = ctx.IssuerClass
+	ctrl.issuancePause = ctx.IssuancePause
+	ctrl.diagnosticsTTL = ctx.ACMEOptions.OrderDiagnosticsTTL
+	ctrl.kubeClient = ctx.Client
+	ctrl.authzFetchParallelism = ctx.ACMEOptions.AuthorizationFetchParallelism
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ACMEOrderLongPolling) {
+		ctrl.orderLongPollingSupported = directoryMeta.orderLongPollingSupported
+	}
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -236,3 +317,14 @@ func init() {
 			Complete()
 	})
 }
+
+// claimed returns false if the issuer of the order is assigned to another
+// cert-manager instance by its issuer class. Orders whose issuer can not be
+// read are claimed, so that the error is reported when they are synced.
+func (c *controller) claimed(o *cmacme.Order) bool {
+	genericIssuer, err := c.helper.GetGenericIssuer(o.Spec.IssuerRef, o.Namespace)
+	if err != nil {
+		return true
+	}
+	return controllerpkg.IsClaimed(genericIssuer, c.issuerClass)
+}
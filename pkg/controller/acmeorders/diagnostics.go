@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// diagnosticsKey is the key of the diagnostics ConfigMap holding the
+	// gzip compressed JSON encoded orderDiagnostics.
+	diagnosticsKey = "diagnostics.json.gz"
+
+	// diagnosticsExpiryAnnotationKey is set on diagnostics ConfigMaps to the
+	// time after which they are deleted.
+	diagnosticsExpiryAnnotationKey = "acme.cert-manager.io/diagnostics-expiry"
+)
+
+// orderDiagnostics is the state of a failed Order and its Challenges, which is
+// retained so that the failure can be investigated after the Challenges have
+// been cleaned up.
+type orderDiagnostics struct {
+	Name           string                     `json:"name"`
+	URL            string                     `json:"url,omitempty"`
+	State          cmacme.State               `json:"state"`
+	Reason         string                     `json:"reason,omitempty"`
+	FailureTime    *metav1.Time               `json:"failureTime,omitempty"`
+	Authorizations []cmacme.ACMEAuthorization `json:"authorizations,omitempty"`
+	Challenges     []challengeDiagnostics     `json:"challenges,omitempty"`
+}
+
+// challengeDiagnostics is the state of a Challenge of a failed Order. Reason
+// holds the last self check error or the problem reported by the ACME server.
+type challengeDiagnostics struct {
+	Name       string                   `json:"name"`
+	DNSName    string                   `json:"dnsName"`
+	Wildcard   bool                     `json:"wildcard,omitempty"`
+	Type       cmacme.ACMEChallengeType `json:"type"`
+	URL        string                   `json:"url"`
+	State      cmacme.State             `json:"state,omitempty"`
+	Reason     string                   `json:"reason,omitempty"`
+	Presented  bool                     `json:"presented"`
+	Processing bool                     `json:"processing"`
+
+	// ExpectedRecord is the TXT record name, for DNS-01 challenges, or the URL,
+	// for HTTP-01 challenges, which the self check expected ExpectedValue at.
+	ExpectedRecord string `json:"expectedRecord,omitempty"`
+	ExpectedValue  string `json:"expectedValue,omitempty"`
+
+	Conditions []cmacme.ChallengeCondition `json:"conditions,omitempty"`
+}
+
+// diagnosticsConfigMapName returns the name of the ConfigMap holding the
+// diagnostics of the given Order.
+func diagnosticsConfigMapName(o *cmacme.Order) string {
+	return o.Name + "-diagnostics"
+}
+
+// syncDiagnostics stores the diagnostics of a failed Order in a ConfigMap
+// until diagnosticsTTL has passed since the Order failed, after which the
+// ConfigMap is deleted.
+func (c *controller) syncDiagnostics(ctx context.Context, o *cmacme.Order) error {
+	log := logf.FromContext(ctx)
+
+	if o.Status.FailureTime == nil {
+		return nil
+	}
+	name := diagnosticsConfigMapName(o)
+	expiry := o.Status.FailureTime.Add(c.diagnosticsTTL)
+	remaining := expiry.Sub(c.clock.Now())
+
+	if remaining <= 0 {
+		err := c.kubeClient.CoreV1().ConfigMaps(o.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err == nil {
+			log.V(logf.DebugLevel).Info("deleted expired Order diagnostics", "configmap", name)
+		}
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	_, err := c.kubeClient.CoreV1().ConfigMaps(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm, err := c.buildDiagnosticsConfigMap(o, expiry)
+		if err != nil {
+			return err
+		}
+		_, err = c.kubeClient.CoreV1().ConfigMaps(o.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		log.V(logf.DebugLevel).Info("stored failed Order diagnostics", "configmap", name)
+	} else if err != nil {
+		return err
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(o)
+	if err != nil {
+		log.Error(err, "failed to construct key for Order")
+		return nil
+	}
+	// Re-queue the Order to delete the diagnostics once they have expired.
+	c.scheduledWorkQueue.Add(key, remaining)
+	return nil
+}
+
+// buildDiagnosticsConfigMap builds the ConfigMap holding the diagnostics of
+// the given failed Order. It is owned by the Order so that it is deleted
+// along with it.
+func (c *controller) buildDiagnosticsConfigMap(o *cmacme.Order, expiry time.Time) (*corev1.ConfigMap, error) {
+	challenges, err := c.listOwnedChallenges(o)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeDiagnostics(buildOrderDiagnostics(o, challenges))
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      diagnosticsConfigMapName(o),
+			Namespace: o.Namespace,
+			Labels:    controllerpkg.AddOwnedByLabels(nil, c.ownedBy, o),
+			Annotations: map[string]string{
+				diagnosticsExpiryAnnotationKey: expiry.UTC().Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(o, orderGvk)},
+		},
+		BinaryData: map[string][]byte{
+			diagnosticsKey: data,
+		},
+	}, nil
+}
+
+func buildOrderDiagnostics(o *cmacme.Order, challenges []*cmacme.Challenge) *orderDiagnostics {
+	d := &orderDiagnostics{
+		Name:           o.Name,
+		URL:            o.Status.URL,
+		State:          o.Status.State,
+		Reason:         o.Status.Reason,
+		FailureTime:    o.Status.FailureTime,
+		Authorizations: o.Status.Authorizations,
+	}
+	for _, ch := range challenges {
+		cd := challengeDiagnostics{
+			Name:       ch.Name,
+			DNSName:    ch.Spec.DNSName,
+			Wildcard:   ch.Spec.Wildcard,
+			Type:       ch.Spec.Type,
+			URL:        ch.Spec.URL,
+			State:      ch.Status.State,
+			Reason:     ch.Status.Reason,
+			Presented:  ch.Status.Presented,
+			Processing: ch.Status.Processing,
+			Conditions: ch.Status.Conditions,
+		}
+		switch ch.Spec.Type {
+		case cmacme.ACMEChallengeTypeDNS01:
+			cd.ExpectedRecord = "_acme-challenge." + ch.Spec.DNSName
+			cd.ExpectedValue = ch.Spec.Key
+		case cmacme.ACMEChallengeTypeHTTP01:
+			cd.ExpectedRecord = fmt.Sprintf("http://%s/.well-known/acme-challenge/%s", ch.Spec.DNSName, ch.Spec.Token)
+			cd.ExpectedValue = ch.Spec.Key
+		}
+		d.Challenges = append(d.Challenges, cd)
+	}
+	return d
+}
+
+// encodeDiagnostics returns the gzip compressed JSON encoding of d.
+func encodeDiagnostics(d *orderDiagnostics) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(d); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestOrderDiagnostics(t *testing.T) {
+	order := gen.Order("testorder", gen.SetOrderStatus(cmacme.OrderStatus{
+		URL:    "http://testurl.com/abcde",
+		State:  cmacme.Invalid,
+		Reason: "Failed to finalize Order: 403 : urn:ietf:params:acme:error:unauthorized",
+	}))
+	challenges := []*cmacme.Challenge{
+		gen.Challenge("dns",
+			gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			gen.SetChallengeDNSName("example.com"),
+			gen.SetChallengeState(cmacme.Pending),
+			gen.SetChallengeReason("Waiting for DNS-01 challenge propagation: DNS record for \"example.com\" not yet propagated"),
+		),
+		gen.Challenge("http",
+			gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+			gen.SetChallengeDNSName("www.example.com"),
+			gen.SetChallengeToken("token"),
+			gen.SetChallengeState(cmacme.Invalid),
+		),
+	}
+	challenges[0].Spec.Key = "dnskey"
+	challenges[1].Spec.Key = "token.thumbprint"
+
+	data, err := encodeDiagnostics(buildOrderDiagnostics(order, challenges))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got orderDiagnostics
+	if err := json.NewDecoder(zr).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, orderDiagnostics{
+		Name:   "testorder",
+		URL:    "http://testurl.com/abcde",
+		State:  cmacme.Invalid,
+		Reason: "Failed to finalize Order: 403 : urn:ietf:params:acme:error:unauthorized",
+		Challenges: []challengeDiagnostics{
+			{
+				Name:           "dns",
+				DNSName:        "example.com",
+				Type:           cmacme.ACMEChallengeTypeDNS01,
+				State:          cmacme.Pending,
+				Reason:         "Waiting for DNS-01 challenge propagation: DNS record for \"example.com\" not yet propagated",
+				ExpectedRecord: "_acme-challenge.example.com",
+				ExpectedValue:  "dnskey",
+			},
+			{
+				Name:           "http",
+				DNSName:        "www.example.com",
+				Type:           cmacme.ACMEChallengeTypeHTTP01,
+				State:          cmacme.Invalid,
+				ExpectedRecord: "http://www.example.com/.well-known/acme-challenge/token",
+				ExpectedValue:  "token.thumbprint",
+			},
+		},
+	}, got)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// directoryMetaChecker looks up the metadata advertised in the directory of
+// the ACME server of an issuer, such as the certificate profiles it supports.
+// Results are cached per ACME directory URL by the shared directory metadata
+// cache, and failures to fetch the directory are not cached.
+type directoryMetaChecker struct {
+	metrics           *metrics.Metrics
+	httpClientOptions accounts.HTTPClientOptions
+
+	cache *acmecl.DirectoryMetaCache
+}
+
+func newDirectoryMetaChecker(metrics *metrics.Metrics, httpClientOptions accounts.HTTPClientOptions, cache *acmecl.DirectoryMetaCache) *directoryMetaChecker {
+	if cache == nil {
+		cache = acmecl.NewDirectoryMetaCache(defaultDirectoryMetaCacheTTL)
+	}
+	return &directoryMetaChecker{
+		metrics:           metrics,
+		httpClientOptions: httpClientOptions,
+		cache:             cache,
+	}
+}
+
+// defaultDirectoryMetaCacheTTL is the TTL of the directory metadata cache
+// used if none is shared with the controller.
+const defaultDirectoryMetaCacheTTL = time.Hour
+
+// directoryMeta returns the metadata advertised in the directory of the ACME
+// server of the given issuer.
+func (d *directoryMetaChecker) directoryMeta(ctx context.Context, issuer cmapi.GenericIssuer) (*acmecl.DirectoryMeta, error) {
+	spec := issuer.GetSpec().ACME
+	if spec == nil {
+		return &acmecl.DirectoryMeta{}, nil
+	}
+
+	return d.cache.Get(ctx, accounts.BuildHTTPClient(d.metrics, spec.SkipTLSVerify, d.httpClientOptions.ForIssuer(spec).WithTLS(issuer.GetSpec().TLS)), spec.Server)
+}
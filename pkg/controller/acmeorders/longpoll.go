@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+var (
+	// LongPollTimeout is the maximum time a request for the status of a
+	// pending Order is held open by the ACME server when long-polling.
+	LongPollTimeout time.Duration = time.Second * 10
+
+	// LongPollRequeuePeriod is the period after which a pending Order is
+	// re-queued to long-poll the ACME server again.
+	LongPollRequeuePeriod time.Duration = time.Second
+)
+
+// orderLongPollingSupported returns true if the ACME server of the given
+// issuer advertises support for long-polling orders. It returns false if the
+// ACME directory cannot be fetched, so that Orders are polled at a fixed
+// interval until the directory can be fetched again.
+func (d *directoryMetaChecker) orderLongPollingSupported(ctx context.Context, issuer cmapi.GenericIssuer) bool {
+	meta, err := d.directoryMeta(ctx, issuer)
+	if err != nil {
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("failed to determine whether the ACME server supports long-polling orders", "error", err)
+		return false
+	}
+
+	return meta.OrderLongPolling
+}
@@ -24,7 +24,7 @@ import (
 
 func DNSNames(sel cmacme.CertificateDNSNameSelector) Selector {
 	return &dnsNamesSelector{
-		allowedDNSNames: sel.DNSNames,
+		allowedDNSNames: normalizeAll(sel.DNSNames),
 	}
 }
 
@@ -37,6 +37,8 @@ func (s *dnsNamesSelector) Matches(meta metav1.ObjectMeta, dnsName string) (bool
 		return true, 0
 	}
 
+	dnsName = normalize(dnsName)
+
 	for _, d := range s.allowedDNSNames {
 		if dnsName == d {
 			return true, 1
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectors
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestDNSNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector cmacme.CertificateDNSNameSelector
+		meta     metav1.ObjectMeta
+		dnsName  string
+		matches  bool
+		score    int
+	}{
+		{
+			name:     "matching a domain with an empty selector",
+			selector: cmacme.CertificateDNSNameSelector{},
+			dnsName:  "www.example.com",
+			matches:  true,
+			score:    0,
+		},
+		{
+			name: "matching a domain",
+			selector: cmacme.CertificateDNSNameSelector{
+				DNSNames: []string{"www.example.com"},
+			},
+			dnsName: "www.example.com",
+			matches: true,
+			score:   1,
+		},
+		{
+			name: "not matching a different domain",
+			selector: cmacme.CertificateDNSNameSelector{
+				DNSNames: []string{"www.example.com"},
+			},
+			dnsName: "example.com",
+			matches: false,
+			score:   0,
+		},
+		{
+			name: "matching a domain in a different case with a trailing dot",
+			selector: cmacme.CertificateDNSNameSelector{
+				DNSNames: []string{"WWW.Example.com."},
+			},
+			dnsName: "www.example.COM",
+			matches: true,
+			score:   1,
+		},
+		{
+			name: "matching an internationalised domain by its punycode form",
+			selector: cmacme.CertificateDNSNameSelector{
+				DNSNames: []string{"xn--bcher-kva.example"},
+			},
+			dnsName: "bücher.example",
+			matches: true,
+			score:   1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testSelector(t, DNSNames(test.selector), test.meta, test.dnsName, test.matches, test.score)
+		})
+	}
+}
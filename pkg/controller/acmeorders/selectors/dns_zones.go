@@ -25,7 +25,7 @@ import (
 
 func DNSZones(sel cmacme.CertificateDNSNameSelector) Selector {
 	return &dnsZonesSelector{
-		allowedDNSZones: sel.DNSZones,
+		allowedDNSZones: normalizeAll(sel.DNSZones),
 	}
 }
 
@@ -38,6 +38,8 @@ func (s *dnsZonesSelector) Matches(meta metav1.ObjectMeta, dnsName string) (bool
 		return true, 0
 	}
 
+	dnsName = normalize(dnsName)
+
 	maxMatchingLabels := 0
 	for _, zone := range s.allowedDNSZones {
 		numMatchingLabels := dns.CompareDomainName(zone, dnsName)
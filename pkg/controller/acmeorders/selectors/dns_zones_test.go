@@ -58,6 +58,33 @@ func TestDNSZones(t *testing.T) {
 			matches: true,
 			score:   2,
 		},
+		{
+			name: "matching an internationalised domain in a punycode zone",
+			selector: cmacme.CertificateDNSNameSelector{
+				DNSZones: []string{"xn--bcher-kva.example"},
+			},
+			dnsName: "www.bücher.example",
+			matches: true,
+			score:   2,
+		},
+		{
+			name: "matching a punycode domain in an internationalised zone",
+			selector: cmacme.CertificateDNSNameSelector{
+				DNSZones: []string{"bücher.example"},
+			},
+			dnsName: "www.xn--bcher-kva.example",
+			matches: true,
+			score:   2,
+		},
+		{
+			name: "matching a domain with a trailing dot in a zone",
+			selector: cmacme.CertificateDNSNameSelector{
+				DNSZones: []string{"Example.com."},
+			},
+			dnsName: "www.example.COM",
+			matches: true,
+			score:   2,
+		},
 	}
 
 	for _, test := range tests {
@@ -18,6 +18,8 @@ package selectors
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // Selector determines whether a kubernetes object matches the
@@ -32,3 +34,26 @@ type Selector interface {
 	// where an empty selector matches all).
 	Matches(meta metav1.ObjectMeta, dnsName string) (bool, int)
 }
+
+// normalize returns the normalized form of a DNS name so that selectors
+// match regardless of e.g. the case of the name, a trailing dot or whether
+// an internationalised name is written in its Unicode or punycode form.
+// Names which cannot be normalized are returned unchanged.
+func normalize(name string) string {
+	normalized, err := pki.NormalizeDNSName(name)
+	if err != nil {
+		return name
+	}
+	return normalized
+}
+
+func normalizeAll(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	normalized := make([]string, len(names))
+	for i, name := range names {
+		normalized[i] = normalize(name)
+	}
+	return normalized
+}
@@ -21,10 +21,11 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
-	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -40,19 +41,44 @@ import (
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 const (
-	reasonSolver  = "Solver"
-	reasonCreated = "Created"
+	reasonSolver         = "Solver"
+	reasonCreated        = "Created"
+	reasonIssuancePaused = "IssuancePaused"
+	reasonBackoff        = "Backoff"
+	reasonProcessing     = "Processing"
+
+	// issuancePausedMessage is the reason of Orders which have not been
+	// submitted to the ACME server because issuance is paused.
+	issuancePausedMessage = "Issuance is paused, the Order will be submitted to the ACME server once issuance is resumed"
+
+	// processingOrderMessage is the reason of finalized Orders which the ACME
+	// server is still processing, and which are re-checked periodically.
+	processingOrderMessage = "The ACME server is processing the Order, it will be checked again periodically until the certificate has been issued"
 )
 
 var (
 	// RequeuePeriod is the default period after which an Order should be re-queued.
 	// It can be overriden in tests.
 	RequeuePeriod time.Duration = time.Second * 5
+
+	// IssuancePausedRequeuePeriod is the period after which an Order which
+	// has not been submitted because issuance is paused is re-queued to check
+	// whether issuance has been resumed.
+	IssuancePausedRequeuePeriod time.Duration = time.Minute
+
+	// ProcessingOrderWaitTimeout is the maximum time a worker waits for the
+	// ACME server to issue the certificate whilst finalizing an Order of an
+	// Issuer with a processingOrderRecheckInterval, after which the Order is
+	// re-checked at that interval instead.
+	// It can be overriden in tests.
+	ProcessingOrderWaitTimeout time.Duration = time.Second * 10
 )
 
 func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
@@ -77,28 +103,75 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		dbg.Info("updated Order resource status successfully")
 	}()
 
+	// If the ACME server asked to back off, retry once it allows instead of
+	// returning the error and retrying with the rate limiter's backoff.
+	defer func() {
+		if backoff := acmecl.BackoffForError(err, c.clock.Now()); backoff != nil {
+			log.V(logf.InfoLevel).Info("ACME server asked to back off, retrying later", "retryAfter", backoff.RetryAfter, "rateLimited", backoff.RateLimited, "error", err)
+			retryAfter := metav1.NewTime(backoff.RetryAfter)
+			o.Status.RetryAfter = &retryAfter
+			c.recorder.Eventf(o, corev1.EventTypeWarning, reasonBackoff, "ACME server asked to back off until %s: %v", backoff.RetryAfter.UTC().Format(time.RFC3339), err)
+			err = c.requeueAt(o, backoff.RetryAfter)
+		}
+	}()
+
 	genericIssuer, err := c.helper.GetGenericIssuer(o.Spec.IssuerRef, o.Namespace)
 	if err != nil {
 		return fmt.Errorf("error reading (cluster)issuer %q: %v", o.Spec.IssuerRef.Name, err)
 	}
+	// The client is loaded on each sync, rather than only if it is not
+	// registered, so that it is rebuilt when the Issuer's account changes.
+	if c.loadClient != nil {
+		if err := c.loadClient(ctx, genericIssuer); err != nil {
+			return err
+		}
+	}
 	cl, err := c.accountRegistry.GetClient(string(genericIssuer.GetUID()))
 	if err != nil {
 		return err
 	}
 
+	if o.Status.RetryAfter != nil && !acme.IsFailureState(o.Status.State) {
+		if c.clock.Now().Before(o.Status.RetryAfter.Time) {
+			dbg.Info("Not contacting the ACME server as it asked to back off", "retryAfter", o.Status.RetryAfter)
+			return c.requeueAt(o, o.Status.RetryAfter.Time)
+		}
+		o.Status.RetryAfter = nil
+	}
+
 	switch {
 	case acme.IsFailureState(o.Status.State):
 		log.V(logf.DebugLevel).Info("Doing nothing as Order is in a failed state")
-		// if the Order is failed there's nothing left for us to do, return nil
+		// if the Order is failed there's nothing left for us to do other than
+		// retaining its diagnostics, if enabled
+		if c.diagnosticsTTL > 0 {
+			return c.syncDiagnostics(ctx, o)
+		}
+		return nil
+	case o.Status.URL == "" && c.issuancePause.Paused():
+		log.V(logf.DebugLevel).Info("Not creating new ACME order as issuance is paused")
+		if o.Status.Reason != issuancePausedMessage {
+			c.recorder.Event(o, corev1.EventTypeNormal, reasonIssuancePaused, issuancePausedMessage)
+			o.Status.Reason = issuancePausedMessage
+		}
+		key, err := cache.MetaNamespaceKeyFunc(o)
+		if err != nil {
+			log.Error(err, "failed to construct key for Order")
+			return nil
+		}
+		c.scheduledWorkQueue.Add(key, IssuancePausedRequeuePeriod)
 		return nil
 	case o.Status.URL == "":
 		log.V(logf.DebugLevel).Info("Creating new ACME order as status.url is not set")
-		return c.createOrder(ctx, cl, o)
+		if o.Status.Reason == issuancePausedMessage {
+			o.Status.Reason = ""
+		}
+		return c.createOrder(ctx, cl, o, genericIssuer)
 	case o.Status.FinalizeURL == "":
 		log.V(logf.DebugLevel).Info("Updating Order status as status.finalizeURL is not set")
 		_, err := c.updateOrderStatus(ctx, cl, o)
 		if acmeErr, ok := err.(*acmeapi.Error); ok {
-			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+			if isPermanentACMEError(acmeErr) {
 				log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 				c.setOrderState(&o.Status, string(cmacme.Errored))
 				o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
@@ -118,6 +191,9 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		// if the Order is valid and the certificate data has been set, clean
 		// up any owned Challenge resources and do nothing
 		return c.deleteAllChallenges(ctx, o)
+	case o.Status.State == cmacme.Processing && processingOrderRecheckInterval(genericIssuer) > 0:
+		log.V(logf.DebugLevel).Info("Checking whether the ACME server has finished processing the Order")
+		return c.recheckProcessingOrder(ctx, cl, o, genericIssuer)
 	}
 
 	dbg.Info("Computing list of Challenge resources that need to exist to complete this Order")
@@ -168,7 +244,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	acmeOrder, err := getACMEOrder(ctx, cl, o)
 	// Order probably has been deleted, we cannot recover here.
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
-		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+		if isPermanentACMEError(acmeErr) {
 			log.Error(err, "failed to retrieve the ACME order (4xx error) marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
@@ -185,9 +261,10 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		//  Order as failed, we could just mark the Order as failed as there is
 		//  no way that we will attempt and continue the order anyway.
 		log.V(logf.DebugLevel).Info("Update Order status as at least one Challenge has failed")
+		setAuthorizationProblems(o, challenges)
 		_, err := c.updateOrderStatusFromACMEOrder(ctx, cl, o, acmeOrder)
 		if acmeErr, ok := err.(*acmeapi.Error); ok {
-			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+			if isPermanentACMEError(acmeErr) {
 				log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 				c.setOrderState(&o.Status, string(cmacme.Errored))
 				o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
@@ -199,6 +276,16 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	// anyChallengesFailed(challenges) == false is already implied by the above
 	// case, but explicitly check it in the following cases for if anything changes in future.
 
+	// If all of the Order's authorizations were already valid no Challenges
+	// are required, so the Order can be finalized as soon as the ACME server
+	// reports it as ready rather than on the next sync.
+	case len(requiredChallenges) == 0 && acmeOrder.Status == acmeapi.StatusReady:
+		log.V(logf.DebugLevel).Info("All authorizations are already valid, finalizing Order")
+		if _, err := c.updateOrderStatusFromACMEOrder(ctx, cl, o, acmeOrder); err != nil {
+			return err
+		}
+		return c.finalizeOrder(ctx, cl, o, genericIssuer)
+
 	// This is to avoid stuck Orders in edge cases where all the Challenges have
 	// been finalized, but the ACME server has not yet updated the ACME Order's
 	// status to valid. This is not an expected behaviour from an ACME server
@@ -209,6 +296,17 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		// This is probably not needed as at this point the Order's status
 		// should already be Pending, but set it anyway to be explicit.
 		c.setOrderState(&o.Status, string(cmacme.Pending))
+		requeuePeriod := RequeuePeriod
+		if c.orderLongPollingSupported != nil && c.orderLongPollingSupported(ctx, genericIssuer) {
+			updated, err := c.longPollOrder(ctx, cl, o)
+			if err != nil {
+				return err
+			}
+			if updated {
+				return nil
+			}
+			requeuePeriod = LongPollRequeuePeriod
+		}
 		key, err := cache.MetaNamespaceKeyFunc(o)
 		if err != nil {
 			log.Error(err, "failed to construct key for pending Order")
@@ -219,15 +317,16 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 			// as failed here.
 			return nil
 		}
-		// Re-queue the Order to be processed again after 5 seconds.
-		c.scheduledWorkQueue.Add(key, RequeuePeriod)
+		// Re-queue the Order to be processed again after 5 seconds, or
+		// straight away to long-poll the ACME server again.
+		c.scheduledWorkQueue.Add(key, requeuePeriod)
 		return nil
 
 	case !anyChallengesFailed(challenges) && allChallengesFinal(challenges):
 		log.V(logf.DebugLevel).Info("All challenges are in a final state, updating order state")
 		_, err := c.updateOrderStatusFromACMEOrder(ctx, cl, o, acmeOrder)
 		if acmeErr, ok := err.(*acmeapi.Error); ok {
-			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+			if isPermanentACMEError(acmeErr) {
 				log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 				c.setOrderState(&o.Status, string(cmacme.Errored))
 				o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
@@ -242,7 +341,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	return nil
 }
 
-func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) error {
+func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 
 	if o.Status.URL != "" {
@@ -257,27 +356,55 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	log.V(logf.DebugLevel).Info("build set of domains for Order", "domains", dnsIdentifierSet.List())
 
 	ipIdentifierSet := sets.NewString(o.Spec.IPAddresses...)
-	log.V(logf.DebugLevel).Info("build set of IPs for Order", "domains", dnsIdentifierSet.List())
+	log.V(logf.DebugLevel).Info("build set of IPs for Order", "ipAddresses", ipIdentifierSet.List())
 
 	authzIDs := acmeapi.DomainIDs(dnsIdentifierSet.List()...)
 	authzIDs = append(authzIDs, acmeapi.IPIDs(ipIdentifierSet.List()...)...)
 	// create a new order with the acme server
 
+	// Fail early rather than have the ACME server reject the order with a
+	// less helpful error.
+	if err := acme.CheckMaxIdentifiers(o.Spec, issuer.GetSpec().ACME); err != nil {
+		log.V(logf.InfoLevel).Info("Order has more identifiers than the ACME server accepts, marking Order as failed", "error", err)
+		c.setOrderState(&o.Status, string(cmacme.Errored))
+		o.Status.Reason = fmt.Sprintf("Failed to create Order: %v", err)
+		return nil
+	}
+
+	if c.directoryMeta != nil {
+		meta, err := c.directoryMeta(ctx, issuer)
+		if err != nil {
+			return fmt.Errorf("error fetching the ACME server's directory: %v", err)
+		}
+		if o.Spec.Profile != "" {
+			if _, ok := meta.Profiles[o.Spec.Profile]; !ok {
+				log.V(logf.InfoLevel).Info("ACME server does not advertise the requested profile, marking Order as failed", "profile", o.Spec.Profile)
+				c.setOrderState(&o.Status, string(cmacme.Errored))
+				o.Status.Reason = fmt.Sprintf("Failed to create Order: the ACME server does not support the profile %q", o.Spec.Profile)
+				return nil
+			}
+		}
+	}
+
 	var options []acmeapi.OrderOption
 	if o.Spec.Duration != nil {
 		options = append(options, acmeapi.WithOrderNotAfter(c.clock.Now().Add(o.Spec.Duration.Duration)))
 	}
+	if o.Spec.Profile != "" {
+		options = append(options, acmeapi.WithOrderProfile(o.Spec.Profile))
+	}
 	acmeOrder, err := cl.AuthorizeOrder(ctx, authzIDs, options...)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
-		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+		if isPermanentACMEError(acmeErr) {
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to create Order: %v", err)
+			o.Status.Problem = acme.ProblemFromError(err)
 			return nil
 		}
 	}
 	if err != nil {
-		return fmt.Errorf("error creating new order: %v", err)
+		return fmt.Errorf("error creating new order: %w", err)
 	}
 	log.V(logf.DebugLevel).Info("submitted Order to ACME server")
 
@@ -289,6 +416,35 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	return nil
 }
 
+// longPollOrder sends a single request for the status of the given pending
+// Order, which the ACME server holds open for up to LongPollTimeout until the
+// status changes, and updates the Order's status if it is no longer pending.
+// It returns false if the Order is still pending, in which case it should be
+// re-queued. The worker never sleeps between requests, so that it is not
+// blocked for longer than the request.
+func (c *controller) longPollOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (bool, error) {
+	log := logf.FromContext(ctx)
+	log.V(logf.DebugLevel).Info("Long-polling ACME server for the status of the order")
+
+	pollCtx, cancel := context.WithTimeout(ctx, LongPollTimeout)
+	defer cancel()
+
+	acmeOrder, err := getACMEOrder(pollCtx, cl, o)
+	switch {
+	case err != nil && ctx.Err() == nil:
+		log.V(logf.DebugLevel).Info("failed to long-poll ACME order, falling back to polling", "error", err)
+		return false, nil
+	case err != nil:
+		return false, err
+	case acmeOrder.Status == acmeapi.StatusPending:
+		log.V(logf.DebugLevel).Info("ACME order is still pending after long-polling")
+		return false, nil
+	}
+
+	_, err = c.updateOrderStatusFromACMEOrder(ctx, cl, o, acmeOrder)
+	return true, err
+}
+
 func (c *controller) updateOrderStatus(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
 	acmeOrder, err := getACMEOrder(ctx, cl, o)
 	if err != nil {
@@ -307,6 +463,9 @@ func (c *controller) updateOrderStatusFromACMEOrder(ctx context.Context, cl acme
 	}
 	o.Status.FinalizeURL = acmeOrder.FinalizeURL
 	c.setOrderState(&o.Status, acmeOrder.Status)
+	if acmeOrder.Error != nil {
+		o.Status.Problem = acme.ProblemFromError(acmeOrder.Error)
+	}
 	// once the 'authorizations' slice contains at least one item, it cannot be
 	// updated. If it does not contain any items, update it containing the list
 	// of authorizations returned on the Order.
@@ -342,6 +501,21 @@ func constructAuthorizations(o *acmeapi.Order) []cmacme.ACMEAuthorization {
 	return authzs
 }
 
+// setAuthorizationProblems records the problems reported by the ACME server
+// for the given failed Challenges on the Order's matching authorizations.
+func setAuthorizationProblems(o *cmacme.Order, chs []*cmacme.Challenge) {
+	for _, ch := range chs {
+		if ch.Status.Problem == nil {
+			continue
+		}
+		for i := range o.Status.Authorizations {
+			if o.Status.Authorizations[i].URL == ch.Spec.AuthorizationURL {
+				o.Status.Authorizations[i].Problem = ch.Status.Problem.DeepCopy()
+			}
+		}
+	}
+}
+
 func anyAuthorizationsMissingMetadata(o *cmacme.Order) bool {
 	for _, a := range o.Status.Authorizations {
 		if a.Identifier == "" {
@@ -351,39 +525,82 @@ func anyAuthorizationsMissingMetadata(o *cmacme.Order) bool {
 	return false
 }
 
+// fetchMetadataForAuthorizations fetches the authorizations of the Order which
+// have not been fetched yet from the ACME server, up to authzFetchParallelism
+// at a time. The authorizations which were fetched are recorded even if
+// fetching others failed, in which case the errors are returned together,
+// unless the ACME server asked to back off.
 func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmacme.Order, cl acmecl.Interface) error {
 	log := logf.FromContext(ctx)
+
+	parallelism := c.authzFetchParallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	errs := make([]error, len(o.Status.Authorizations))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
 	for i, authz := range o.Status.Authorizations {
 		// only fetch metadata for each authorization once
 		if authz.Identifier != "" {
 			continue
 		}
 
-		acmeAuthz, err := cl.GetAuthorization(ctx, authz.URL)
-		if acmeErr, ok := err.(*acmeapi.Error); ok {
-			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
-				log.Error(err, "failed to fetch authorization metadata from acme server")
-				c.setOrderState(&o.Status, string(cmacme.Errored))
-				o.Status.Reason = fmt.Sprintf("Failed to fetch authorization: %v", err)
-				return nil
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, authz cmacme.ACMEAuthorization) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			acmeAuthz, err := cl.GetAuthorization(ctx, authz.URL)
+			if err != nil {
+				errs[i] = err
+				return
 			}
+
+			authz.InitialState = cmacme.State(acmeAuthz.Status)
+			authz.Identifier = acmeAuthz.Identifier.Value
+			authz.IdentifierType = cmacme.ACMEIdentifierType(acmeAuthz.Identifier.Type)
+			authz.Wildcard = &acmeAuthz.Wildcard
+			authz.Challenges = make([]cmacme.ACMEChallenge, len(acmeAuthz.Challenges))
+			for j, acmech := range acmeAuthz.Challenges {
+				authz.Challenges[j].URL = acmech.URI
+				authz.Challenges[j].Token = acmech.Token
+				authz.Challenges[j].Type = acmech.Type
+			}
+			o.Status.Authorizations[i] = authz
+		}(i, authz)
+	}
+	wg.Wait()
+
+	var retryable []error
+	for _, err := range errs {
+		if err == nil {
+			continue
 		}
-		if err != nil {
-			return err
+		if acmeErr, ok := err.(*acmeapi.Error); ok && isPermanentACMEError(acmeErr) {
+			log.Error(err, "failed to fetch authorization metadata from acme server")
+			c.setOrderState(&o.Status, string(cmacme.Errored))
+			o.Status.Reason = fmt.Sprintf("Failed to fetch authorization: %v", err)
+			return nil
 		}
-
-		authz.InitialState = cmacme.State(acmeAuthz.Status)
-		authz.Identifier = acmeAuthz.Identifier.Value
-		authz.Wildcard = &acmeAuthz.Wildcard
-		authz.Challenges = make([]cmacme.ACMEChallenge, len(acmeAuthz.Challenges))
-		for i, acmech := range acmeAuthz.Challenges {
-			authz.Challenges[i].URL = acmech.URI
-			authz.Challenges[i].Token = acmech.Token
-			authz.Challenges[i].Type = acmech.Type
+		retryable = append(retryable, err)
+	}
+	if len(retryable) == 1 {
+		return retryable[0]
+	}
+	// An aggregate cannot be unwrapped to the ACME error, so return an error
+	// with which the ACME server asked to back off unchanged so that the
+	// backoff is respected.
+	for _, err := range retryable {
+		if acmecl.BackoffForError(err, c.clock.Now()) != nil {
+			return err
 		}
-		o.Status.Authorizations[i] = authz
 	}
-	return nil
+	return utilerrors.NewAggregate(retryable)
 }
 
 func (c *controller) anyRequiredChallengesDoNotExist(requiredChallenges []cmacme.Challenge) (bool, error) {
@@ -401,6 +618,7 @@ func (c *controller) anyRequiredChallengesDoNotExist(requiredChallenges []cmacme
 
 func (c *controller) createRequiredChallenges(ctx context.Context, o *cmacme.Order, requiredChallenges []cmacme.Challenge) error {
 	for _, ch := range requiredChallenges {
+		ch.Labels = controllerpkg.AddOwnedByLabels(ch.Labels, c.ownedBy, o)
 		_, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).Create(ctx, &ch, metav1.CreateOptions{})
 		if apierrors.IsAlreadyExists(err) {
 			continue
@@ -509,8 +727,19 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 		derBytes = block.Bytes
 	}
 
+	// ACME servers which issue certificates asynchronously may keep the
+	// order processing for hours or days, so only wait for a short time
+	// before re-checking the order periodically instead of blocking a worker.
+	finalizeCtx := ctx
+	recheckInterval := processingOrderRecheckInterval(issuer)
+	if recheckInterval > 0 {
+		var cancel context.CancelFunc
+		finalizeCtx, cancel = context.WithTimeout(ctx, ProcessingOrderWaitTimeout)
+		defer cancel()
+	}
+
 	// Call to CreateOrderCert finalizes the ACME order. This call can only be made once.
-	certSlice, certURL, err := cl.CreateOrderCert(ctx, o.Status.FinalizeURL, derBytes, true)
+	certSlice, certURL, err := cl.CreateOrderCert(finalizeCtx, o.Status.FinalizeURL, derBytes, true)
 
 	acmeErr, ok := err.(*acmeapi.Error)
 
@@ -523,7 +752,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 
 		acmeOrder, getOrderErr := getACMEOrder(ctx, cl, o)
 		acmeGetOrderErr, ok := getOrderErr.(*acmeapi.Error)
-		if ok && acmeGetOrderErr.StatusCode >= 400 && acmeGetOrderErr.StatusCode < 500 {
+		if ok && isPermanentACMEError(acmeGetOrderErr) {
 			log.Error(err, "failed to retrieve the ACME order (4xx error) marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
@@ -537,14 +766,20 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 			c.setOrderState(&o.Status, string(cmacme.Valid))
 			return c.syncCertificateDataWithOrder(ctx, cl, *acmeOrder, o, issuer)
 		}
+		if acmeOrder.Status == acmeapi.StatusProcessing && recheckInterval > 0 {
+			log.V(logf.DebugLevel).Info("an attempt was made to finalize an order that is already being processed. Re-checking the order periodically")
+			c.setOrderState(&o.Status, string(cmacme.Processing))
+			return c.scheduleProcessingOrderRecheck(o, recheckInterval)
+		}
 
 	}
 
 	// Any other ACME 4xx error means that the Order can be considered failed.
-	if ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+	if ok && isPermanentACMEError(acmeErr) {
 		log.Error(err, "failed to finalize Order resource due to bad request, marking Order as failed")
 		c.setOrderState(&o.Status, string(cmacme.Errored))
 		o.Status.Reason = fmt.Sprintf("Failed to finalize Order: %v", err)
+		o.Status.Problem = acme.ProblemFromError(err)
 		return nil
 	}
 
@@ -552,7 +787,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	// non-4xx error, ensure the order status is up-to-date.
 	_, errUpdate := c.updateOrderStatus(ctx, cl, o)
 	if acmeErr, ok := errUpdate.(*acmeapi.Error); ok {
-		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+		if isPermanentACMEError(acmeErr) {
 			log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", errUpdate)
@@ -560,30 +795,77 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 		}
 	}
 	if errUpdate != nil {
-		return fmt.Errorf("error syncing order status: %v", errUpdate)
+		return fmt.Errorf("error syncing order status: %w", errUpdate)
 	}
 	// Check for non-4xx errors from CreateOrderCert
 	if err != nil {
-		return fmt.Errorf("error finalizing order: %v", err)
+		if recheckInterval > 0 && o.Status.State == cmacme.Processing && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			log.V(logf.DebugLevel).Info("ACME server is still processing the order after it was finalized, re-checking it periodically")
+			return c.scheduleProcessingOrderRecheck(o, recheckInterval)
+		}
+		return fmt.Errorf("error finalizing order: %w", err)
 	}
 
 	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
-		preferredChain := issuer.GetSpec().ACME.PreferredChain
-		found, altChain, err := getAltCertChain(ctx, cl, certURL, preferredChain)
+		preferredChain, err := selectPreferredChain(ctx, cl, certURL, certSlice, issuer.GetSpec().ACME.PreferredChain)
 		if err != nil {
 			return fmt.Errorf("error retrieving alternate chain: %w", err)
 		}
-		if found {
-			return c.storeCertificateOnStatus(ctx, o, altChain)
-		}
-		// if no match is found we return to the actual cert
-		// it is a *preferred* chain after all
-		log.V(logf.DebugLevel).Info(fmt.Sprintf("Preferred chain %s not found, fall back to the default cert", preferredChain))
+		return c.storeCertificateOnStatus(ctx, o, preferredChain)
 	}
 
 	return c.storeCertificateOnStatus(ctx, o, certSlice)
 }
 
+// recheckProcessingOrder updates the status of a finalized Order which the
+// ACME server was processing, and stores the certificate once it has been
+// issued. Orders which are still processing are re-checked later.
+func (c *controller) recheckProcessingOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
+	log := logf.FromContext(ctx)
+
+	acmeOrder, err := c.updateOrderStatus(ctx, cl, o)
+	if acmeErr, ok := err.(*acmeapi.Error); ok {
+		if isPermanentACMEError(acmeErr) {
+			log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
+			c.setOrderState(&o.Status, string(cmacme.Errored))
+			o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if acmeOrder.Status == acmeapi.StatusProcessing {
+		return c.scheduleProcessingOrderRecheck(o, processingOrderRecheckInterval(issuer))
+	}
+	if o.Status.Reason == processingOrderMessage {
+		o.Status.Reason = ""
+	}
+	return c.syncCertificateDataWithOrder(ctx, cl, *acmeOrder, o, issuer)
+}
+
+// scheduleProcessingOrderRecheck re-queues an Order which the ACME server is
+// processing to be checked again after the given interval.
+func (c *controller) scheduleProcessingOrderRecheck(o *cmacme.Order, interval time.Duration) error {
+	if o.Status.Reason != processingOrderMessage {
+		c.recorder.Event(o, corev1.EventTypeNormal, reasonProcessing, processingOrderMessage)
+		o.Status.Reason = processingOrderMessage
+	}
+	return c.requeueAt(o, c.clock.Now().Add(interval))
+}
+
+// processingOrderRecheckInterval returns the interval at which Orders of the
+// given issuer which the ACME server is processing are re-checked, or zero
+// if the issuer does not configure one.
+func processingOrderRecheckInterval(issuer cmapi.GenericIssuer) time.Duration {
+	acmeSpec := issuer.GetSpec().ACME
+	if acmeSpec == nil || acmeSpec.ProcessingOrderRecheckInterval == nil {
+		return 0
+	}
+	return acmeSpec.ProcessingOrderRecheckInterval.Duration
+}
+
 func (c *controller) storeCertificateOnStatus(ctx context.Context, o *cmacme.Order, certs [][]byte) error {
 	log := logf.FromContext(ctx)
 	// encode the retrieved certificates (including the chain)
@@ -610,7 +892,7 @@ func (c *controller) syncCertificateData(ctx context.Context, cl acmecl.Interfac
 	log := logf.FromContext(ctx)
 	acmeOrder, err := c.updateOrderStatus(ctx, cl, o)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
-		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+		if isPermanentACMEError(acmeErr) {
 			log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
@@ -635,19 +917,9 @@ func (c *controller) syncCertificateDataWithOrder(ctx context.Context, cl acmecl
 		return nil
 	}
 
-	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
-		found, altCerts, err := getAltCertChain(ctx, cl, acmeOrder.CertURL, issuer.GetSpec().ACME.PreferredChain)
-		if err != nil {
-			return err
-		}
-		if found {
-			return c.storeCertificateOnStatus(ctx, o, altCerts)
-		}
-
-	}
 	certs, err := cl.FetchCert(ctx, acmeOrder.CertURL, true)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
-		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+		if isPermanentACMEError(acmeErr) {
 			log.Error(err, "failed to retrieve issued certificate from ACME server")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to retrieve signed certificate: %v", err)
@@ -658,6 +930,13 @@ func (c *controller) syncCertificateDataWithOrder(ctx context.Context, cl acmecl
 		return err
 	}
 
+	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
+		certs, err = selectPreferredChain(ctx, cl, acmeOrder.CertURL, certs, issuer.GetSpec().ACME.PreferredChain)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = c.storeCertificateOnStatus(ctx, o, certs)
 	if err != nil {
 		return err
@@ -683,6 +962,28 @@ func getACMEOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*a
 	return acmeOrder, nil
 }
 
+// selectPreferredChain returns the certificate chain issued by the root with
+// the common name preferredChain. The default chain is returned if it is
+// issued by that root, or if none of the alternate chains offered by the ACME
+// server are; it is a *preferred* chain after all.
+func selectPreferredChain(ctx context.Context, cl acmecl.Interface, certURL string, defaultChain [][]byte, preferredChain string) ([][]byte, error) {
+	log := logf.FromContext(ctx).WithValues("preferredChain", preferredChain)
+	if rootCN, err := chainRootCommonName(defaultChain); err == nil && rootCN == preferredChain {
+		log.V(logf.DebugLevel).Info("Default ACME bundle is issued by the preferred chain")
+		return defaultChain, nil
+	}
+
+	found, altChain, err := getAltCertChain(ctx, cl, certURL, preferredChain)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		log.V(logf.DebugLevel).Info("Preferred chain not found, fall back to the default cert")
+		return defaultChain, nil
+	}
+	return altChain, nil
+}
+
 func getAltCertChain(ctx context.Context, cl acmecl.Interface, certURL string, preferredChain string) (bool, [][]byte, error) {
 	log := logf.FromContext(ctx)
 	altURLs, err := cl.ListCertAlternates(ctx, certURL)
@@ -695,23 +996,33 @@ func getAltCertChain(ctx context.Context, cl acmecl.Interface, certURL string, p
 		if err != nil {
 			return false, nil, fmt.Errorf("error fetching alternate certificate chain from %s: %w", altURL, err)
 		}
-		// Loop over each cert in this alternative chain
-		for _, altCert := range altChain {
-			cert, err := x509.ParseCertificate(altCert)
-			if err != nil {
-				return false, nil, fmt.Errorf("error parsing alternate certificate chain: %w", err)
-			}
-			log.V(logf.DebugLevel).WithValues("Issuer CN", cert.Issuer.CommonName).Info("Found alternative ACME bundle")
-			if cert.Issuer.CommonName == preferredChain {
-				// if the issuer's CN matched the preferred chain it means this bundle is
-				// signed by the requested chain
-				log.V(logf.DebugLevel).WithValues("Issuer CN", cert.Issuer.CommonName).Info("Selecting alternative ACME bundle with a matching Common Name from %s", altURL)
-				return true, altChain, nil
-			}
+		// Only the root of the chain is compared, as intermediates may be
+		// issued by the preferred root in every chain, e.g. when the preferred
+		// root is itself cross-signed by another root.
+		rootCN, err := chainRootCommonName(altChain)
+		if err != nil {
+			return false, nil, fmt.Errorf("error parsing alternate certificate chain: %w", err)
+		}
+		log.V(logf.DebugLevel).WithValues("Issuer CN", rootCN).Info("Found alternative ACME bundle")
+		if rootCN == preferredChain {
+			log.V(logf.DebugLevel).WithValues("Issuer CN", rootCN, "url", altURL).Info("Selecting alternative ACME bundle with a matching Common Name")
+			return true, altChain, nil
 		}
 	}
 	return false, nil, nil
+}
 
+// chainRootCommonName returns the common name of the root of the given
+// certificate chain, which is the issuer of the top-most certificate.
+func chainRootCommonName(chain [][]byte) (string, error) {
+	if len(chain) == 0 {
+		return "", errors.New("certificate chain is empty")
+	}
+	cert, err := x509.ParseCertificate(chain[len(chain)-1])
+	if err != nil {
+		return "", err
+	}
+	return cert.Issuer.CommonName, nil
 }
 
 // updateOrApplyStatus will update the order status.
@@ -728,3 +1039,20 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, order *cmacme.Orde
 		return err
 	}
 }
+
+// isPermanentACMEError returns true if retrying the request which failed with
+// the given ACME error will not succeed, i.e. for 4xx errors. Requests which
+// were rate limited are retried once the ACME server allows.
+func isPermanentACMEError(acmeErr *acmeapi.Error) bool {
+	return acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 && !acmecl.IsRateLimited(acmeErr)
+}
+
+// requeueAt schedules the Order to be processed again at the given time.
+func (c *controller) requeueAt(o *cmacme.Order, t time.Time) error {
+	key, err := cache.MetaNamespaceKeyFunc(o)
+	if err != nil {
+		return err
+	}
+	c.scheduledWorkQueue.Add(key, t.Sub(c.clock.Now()))
+	return nil
+}
@@ -18,25 +18,37 @@ package acmeorders
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
-	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	schedulertest "github.com/cert-manager/cert-manager/pkg/scheduler/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 func TestSync(t *testing.T) {
@@ -66,6 +78,9 @@ func TestSync(t *testing.T) {
 			},
 		},
 	}))
+	testIssuerMaxIdentifiers := gen.IssuerFrom(testIssuerHTTP01TestCom, func(iss cmapi.GenericIssuer) {
+		iss.GetSpec().ACME.MaxIdentifiersPerOrder = pointer.Int(1)
+	})
 
 	testIssuerHTTP01TestComPreferredChain := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
 		PreferredChain: "ISRG Root X1",
@@ -81,6 +96,9 @@ func TestSync(t *testing.T) {
 		},
 	}))
 
+	testIssuerHTTP01TestComProcessingRecheck := testIssuerHTTP01TestCom.DeepCopy()
+	testIssuerHTTP01TestComProcessingRecheck.Spec.ACME.ProcessingOrderRecheckInterval = &metav1.Duration{Duration: time.Hour}
+
 	testOrder := gen.Order("testorder",
 		gen.SetOrderCommonName("test.com"),
 		gen.SetOrderIssuer(cmmeta.ObjectReference{
@@ -88,6 +106,12 @@ func TestSync(t *testing.T) {
 		}),
 	)
 
+	testOrderProfile := gen.OrderFrom(testOrder, func(o *cmacme.Order) {
+		o.Spec.Profile = "shortlived"
+	})
+
+	testOrderManyDNSNames := gen.OrderFrom(testOrder, gen.SetOrderDNSNames("test.com", "www.test.com"))
+
 	testOrderIP := gen.Order("testorder", gen.SetOrderIssuer(cmmeta.ObjectReference{Name: testIssuerHTTP01.Name}), gen.SetOrderIPAddresses("10.0.0.1"))
 
 	pendingStatus := cmacme.OrderStatus{
@@ -118,7 +142,11 @@ func TestSync(t *testing.T) {
 		FailureTime: &nowMetaTime,
 		URL:         "http://testurl.com/abcde",
 		FinalizeURL: "http://testurl.com/abcde/finalize",
-		Reason:      "Failed to finalize Order: 429 : some error",
+		Reason:      "Failed to finalize Order: 400 : some error",
+		Problem: &cmacme.ACMEProblem{
+			Detail: "some error",
+			Status: 400,
+		},
 		Authorizations: []cmacme.ACMEAuthorization{
 			{
 				URL:          "http://authzurl",
@@ -135,9 +163,14 @@ func TestSync(t *testing.T) {
 		},
 	}
 
-	acmeError429 := acmeapi.Error{
+	acmeError429WithRetryAfter := acmeapi.Error{
 		StatusCode: 429,
 		Detail:     "some error",
+		Header:     http.Header{"Retry-After": []string{"60"}},
+	}
+	acmeError400 := acmeapi.Error{
+		StatusCode: 400,
+		Detail:     "some error",
 	}
 	acmeError403 := acmeapi.Error{
 		StatusCode: 403,
@@ -151,6 +184,35 @@ func TestSync(t *testing.T) {
 	testOrderErrored := gen.OrderFrom(testOrder, gen.SetOrderStatus(erroredStatus))
 	testOrderErrored.Status.FailureTime = &nowMetaTime
 	testOrderErroredWithDetail := gen.OrderFrom(testOrderPending, gen.SetOrderStatus(erroredStatusWithDetail))
+	testOrderReadyRetryAfter := gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready))
+	testOrderReadyRetryAfter.Status.RetryAfter = &metav1.Time{Time: nowTime.Add(time.Minute)}
+	testOrderAuthorizationsMissingMetadata := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		State:       cmacme.Pending,
+		URL:         "http://testurl.com/abcde",
+		FinalizeURL: "http://testurl.com/abcde/finalize",
+		Authorizations: []cmacme.ACMEAuthorization{
+			{URL: "http://authzurl/1"},
+			{URL: "http://authzurl/2"},
+		},
+	}))
+	testOrderErroredExpiredDiagnostics := testOrderErrored.DeepCopy()
+	testOrderErroredExpiredDiagnostics.Status.FailureTime = &metav1.Time{Time: nowTime.Add(-2 * time.Hour)}
+
+	testDiagnostics, err := encodeDiagnostics(buildOrderDiagnostics(testOrderErrored, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testDiagnosticsConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testorder-diagnostics",
+			Namespace: testOrderErrored.Namespace,
+			Annotations: map[string]string{
+				diagnosticsExpiryAnnotationKey: nowTime.Add(time.Hour).UTC().Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(testOrderErrored, orderGvk)},
+		},
+		BinaryData: map[string][]byte{diagnosticsKey: testDiagnostics},
+	}
 	testOrderValid := testOrderPending.DeepCopy()
 	testOrderValid.Status.State = cmacme.Valid
 	// pem encoded word 'test'
@@ -160,6 +222,39 @@ dGVzdA==
 `)
 	testOrderReady := testOrderPending.DeepCopy()
 	testOrderReady.Status.State = cmacme.Ready
+	testOrderProcessing := testOrderPending.DeepCopy()
+	testOrderProcessing.Status.State = cmacme.Processing
+	testOrderProcessing.Status.Reason = processingOrderMessage
+	testOrderPendingAuthorizationValid := gen.OrderFrom(testOrder, gen.SetOrderStatus(
+		cmacme.OrderStatus{
+			State:       cmacme.Pending,
+			URL:         "http://testurl.com/abcde",
+			FinalizeURL: "http://testurl.com/abcde/finalize",
+			Authorizations: []cmacme.ACMEAuthorization{
+				{
+					URL:          "http://authzurl",
+					Identifier:   "test.com",
+					InitialState: cmacme.Valid,
+					Challenges: []cmacme.ACMEChallenge{
+						{
+							URL:   "http://chalurl",
+							Token: "token",
+							Type:  "http-01",
+						},
+					},
+				},
+			},
+		},
+	))
+	testACMEOrderPendingAuthorizationValid := &acmeapi.Order{
+		URI:         "http://testurl.com/abcde",
+		Status:      acmeapi.StatusPending,
+		FinalizeURL: "http://testurl.com/abcde/finalize",
+	}
+
+	// finalized records whether the ACME Order that is ready to be finalized
+	// has been, after which it is returned as valid.
+	finalized := false
 
 	testCert := []byte(`-----BEGIN CERTIFICATE-----
 MIIFjTCCA3WgAwIBAgIRANOxciY0IzLc9AUoUSrsnGowDQYJKoZIhvcNAQELBQAw
@@ -214,6 +309,13 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	testAuthorizationChallengeValid.Status.State = cmacme.Valid
 	testAuthorizationChallengeInvalid := testAuthorizationChallenge.DeepCopy()
 	testAuthorizationChallengeInvalid.Status.State = cmacme.Invalid
+	testChallengeProblem := &cmacme.ACMEProblem{
+		Type:   "urn:ietf:params:acme:error:unauthorized",
+		Detail: "Invalid response from http://test.com/.well-known/acme-challenge/token: 404",
+		Status: 403,
+	}
+	testAuthorizationChallengeInvalidWithProblem := testAuthorizationChallengeInvalid.DeepCopy()
+	testAuthorizationChallengeInvalidWithProblem.Status.Problem = testChallengeProblem
 
 	testACMEAuthorizationPending := &acmeapi.Authorization{
 		URI:    "http://authzurl",
@@ -250,9 +352,42 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	*testACMEOrderReady = *testACMEOrderPending
 	testACMEOrderReady.Status = acmeapi.StatusReady
 	// shallow copy
+	testACMEOrderProcessing := &acmeapi.Order{}
+	*testACMEOrderProcessing = *testACMEOrderPending
+	testACMEOrderProcessing.Status = acmeapi.StatusProcessing
+	// shallow copy
 	testACMEOrderInvalid := &acmeapi.Order{}
 	*testACMEOrderInvalid = *testACMEOrderPending
 	testACMEOrderInvalid.Status = acmeapi.StatusInvalid
+	testACMEOrderInvalidWithError := &acmeapi.Order{}
+	*testACMEOrderInvalidWithError = *testACMEOrderInvalid
+	testACMEOrderInvalidWithError.Error = &acmeapi.Error{
+		StatusCode:  403,
+		ProblemType: "urn:ietf:params:acme:error:unauthorized",
+		Detail:      "Some of the identifiers requested were rejected",
+		Subproblems: []acmeapi.Subproblem{
+			{
+				Type:       "urn:ietf:params:acme:error:unauthorized",
+				Detail:     "Invalid response from http://test.com/.well-known/acme-challenge/token: 404",
+				Identifier: &acmeapi.AuthzID{Type: "dns", Value: "test.com"},
+			},
+		},
+	}
+	testOrderInvalidWithProblem := testOrderInvalid.DeepCopy()
+	testOrderInvalidWithProblem.Status.Authorizations[0].Problem = testChallengeProblem
+	testOrderInvalidWithProblem.Status.Problem = &cmacme.ACMEProblem{
+		Type:   "urn:ietf:params:acme:error:unauthorized",
+		Detail: "Some of the identifiers requested were rejected",
+		Status: 403,
+		Subproblems: []cmacme.ACMESubproblem{
+			{
+				Type:           "urn:ietf:params:acme:error:unauthorized",
+				Detail:         "Invalid response from http://test.com/.well-known/acme-challenge/token: 404",
+				Identifier:     "test.com",
+				IdentifierType: cmacme.ACMEIdentifierTypeDNS,
+			},
+		},
+	}
 
 	tests := map[string]testT{
 		"create a new order with the acme server, set the order url on the status resource and return nil to avoid cache timing issues": {
@@ -291,6 +426,122 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"do not create a new order with the acme server if issuance is paused": {
+			order:          testOrder,
+			issuancePaused: true,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrder.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							Reason: issuancePausedMessage,
+						})))),
+				},
+				ExpectedEvents: []string{
+					"Normal IssuancePaused " + issuancePausedMessage,
+				},
+			},
+			acmeClient:     &acmecl.FakeACME{},
+			shouldSchedule: true,
+		},
+		"create a new order with the acme server once issuance is resumed": {
+			order: gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+				Reason: issuancePausedMessage,
+			})),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					return testACMEAuthorizationPending, nil
+				},
+			},
+		},
+		"create a new order requesting a profile advertised by the acme server": {
+			order:        testOrderProfile,
+			acmeProfiles: map[string]string{"shortlived": "Short-lived certificates"},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderProfile},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderProfile, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					if len(opt) != 1 || opt[0] != acmeapi.WithOrderProfile("shortlived") {
+						return nil, fmt.Errorf("Invalid order options: expected the shortlived profile got %v", opt)
+					}
+					return testACMEOrderPending, nil
+				},
+			},
+		},
+		"mark the order as errored if the acme server does not advertise the requested profile": {
+			order:        testOrderProfile,
+			acmeProfiles: map[string]string{"tlsserver": "TLS server certificates"},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderProfile},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderProfile, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Errored,
+							Reason:      `Failed to create Order: the ACME server does not support the profile "shortlived"`,
+							FailureTime: &nowMetaTime,
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{},
+		},
+		"mark the order as errored if it has more identifiers than the acme server accepts": {
+			order: testOrderManyDNSNames,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerMaxIdentifiers, testOrderManyDNSNames},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderManyDNSNames, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Errored,
+							Reason:      "Failed to create Order: the request has 2 DNS names and IP addresses but the issuer's ACME server accepts at most 1 per order (spec.acme.maxIdentifiersPerOrder), split them across multiple Certificates",
+							FailureTime: &nowMetaTime,
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{},
+		},
 		"create a new order with the acme server with an IP address": {
 			order: testOrderIP,
 			builder: &testpkg.Builder{
@@ -418,6 +669,50 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			},
 			shouldSchedule: true,
 		},
+		"long-poll the ACME server if the ACME Order is still pending and the server supports it": {
+			order:            testOrderPendingAuthorizationValid,
+			orderLongPolling: true,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPendingAuthorizationValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrder.Namespace, gen.OrderFrom(testOrderPendingAuthorizationValid, func(o *cmacme.Order) {
+							o.Status.State = cmacme.Ready
+						}))),
+				},
+			},
+			acmeClient: func() acmecl.Interface {
+				// The Order becomes ready while the second request, which
+				// long-polls the ACME server, is held open.
+				calls := 0
+				return &acmecl.FakeACME{
+					FakeGetOrder: func(ctx context.Context, url string) (*acmeapi.Order, error) {
+						calls++
+						if calls == 1 {
+							return testACMEOrderPendingAuthorizationValid, nil
+						}
+						o := *testACMEOrderPendingAuthorizationValid
+						o.Status = acmeapi.StatusReady
+						return &o, nil
+					},
+				}
+			}(),
+		},
+		"reschedule if the ACME Order is still pending after long-polling the ACME server": {
+			order:            testOrderPendingAuthorizationValid,
+			orderLongPolling: true,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPendingAuthorizationValid},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(ctx context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderPendingAuthorizationValid, nil
+				},
+			},
+			shouldSchedule: true,
+		},
 		"skip creating a Challenge for an already valid authorization": {
 			order: gen.OrderFrom(testOrder, gen.SetOrderStatus(
 				cmacme.OrderStatus{
@@ -483,6 +778,37 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"skip creating a Challenge for an already valid authorization, finalize immediately if the ACME Order is ready": {
+			order: testOrderPendingAuthorizationValid,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPendingAuthorizationValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrder.Namespace, gen.OrderFrom(testOrderPendingAuthorizationValid, func(o *cmacme.Order) {
+							o.Status.State = cmacme.Valid
+							o.Status.Certificate = testOrderValid.Status.Certificate
+						}))),
+				},
+				ExpectedEvents: []string{
+					"Normal Complete Order completed successfully",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					o := *testACMEOrderPendingAuthorizationValid
+					o.Status = acmeapi.StatusReady
+					if finalized {
+						o.Status = acmeapi.StatusValid
+					}
+					return &o, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					finalized = true
+					return [][]byte{[]byte("test")}, "http://testurl", nil
+				},
+			},
+		},
 		"do nothing if the challenge for test.com is still pending": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -561,7 +887,7 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 					return testACMEOrderReady, nil
 				},
 				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
-					return nil, "", &acmeError429
+					return nil, "", &acmeError400
 				},
 				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
 					// TODO: assert s = "token"
@@ -569,6 +895,64 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"call FinalizeOrder and back off until the time requested by the ACME server if finalize is rate limited": {
+			order: gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready)),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready))},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderErroredWithDetail.Namespace, testOrderReadyRetryAfter)),
+				},
+				ExpectedEvents: []string{
+					"Warning Backoff ACME server asked to back off until " + nowTime.Add(time.Minute).UTC().Format(time.RFC3339) + ": error finalizing order: 429 : some error",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", &acmeError429WithRetryAfter
+				},
+			},
+			shouldSchedule: true,
+		},
+		"back off until the time requested by the ACME server if fetching an authorization is rate limited": {
+			order: testOrderAuthorizationsMissingMetadata,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderAuthorizationsMissingMetadata},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderAuthorizationsMissingMetadata.Namespace,
+						gen.OrderFrom(testOrderAuthorizationsMissingMetadata, func(o *cmacme.Order) {
+							o.Status.RetryAfter = &metav1.Time{Time: nowTime.Add(time.Minute)}
+						}))),
+				},
+				ExpectedEvents: []string{
+					"Warning Backoff ACME server asked to back off until " + nowTime.Add(time.Minute).UTC().Format(time.RFC3339) + ": 429 : some error",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetAuthorization: func(_ context.Context, url string) (*acmeapi.Authorization, error) {
+					if url == "http://authzurl/2" {
+						return nil, &acmeError429WithRetryAfter
+					}
+					return nil, errors.New("connection reset")
+				},
+			},
+			shouldSchedule: true,
+		},
+		"do not contact the ACME server before the time it asked to back off until": {
+			order: testOrderReadyRetryAfter,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReadyRetryAfter},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			acmeClient:     &acmecl.FakeACME{},
+			shouldSchedule: true,
+		},
 		"call FinalizeOrder, return error if finalize fails with an unspecified error": {
 			order: gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready)),
 			builder: &testpkg.Builder{
@@ -589,6 +973,70 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			},
 			expectErr: true,
 		},
+		"call FinalizeOrder and re-check the order periodically if the ACME server is still processing it": {
+			order: testOrderReady,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestComProcessingRecheck, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderProcessing.Namespace, testOrderProcessing)),
+				},
+				ExpectedEvents: []string{
+					"Normal Processing " + processingOrderMessage,
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderProcessing, nil
+				},
+				FakeCreateOrderCert: func(ctx context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					if _, ok := ctx.Deadline(); !ok {
+						return nil, "", errors.New("expected the order to be finalized with a deadline")
+					}
+					return nil, "", fmt.Errorf("waiting for order: %w", context.DeadlineExceeded)
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+			shouldSchedule: true,
+		},
+		"re-check a processing order later if the ACME server is still processing it": {
+			order: testOrderProcessing,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestComProcessingRecheck, testOrderProcessing, testAuthorizationChallengeValid},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderProcessing, nil
+				},
+			},
+			shouldSchedule: true,
+		},
+		"re-check a processing order and store the certificate once the ACME server has issued it": {
+			order: testOrderProcessing,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestComProcessingRecheck, testOrderProcessing, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderValid.Namespace, testOrderValid)),
+				},
+				ExpectedEvents: []string{
+					"Normal Complete Order completed successfully",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderValid, nil
+				},
+				FakeFetchCert: func(_ context.Context, url string, bundle bool) ([][]byte, error) {
+					return [][]byte{[]byte("test")}, nil
+				},
+			},
+		},
 		"call FinalizeOrder, recover if finalize fails because order is already finalized": {
 			order: testOrderReady,
 			builder: &testpkg.Builder{
@@ -648,6 +1096,12 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 
 				},
 				FakeFetchCert: func(_ context.Context, url string, bundle bool) ([][]byte, error) {
+					if url == testACMEOrderValid.CertURL {
+						// the default chain is fetched
+						// first and is not issued by
+						// the preferred chain
+						return [][]byte{[]byte("test")}, nil
+					}
 					if url != "http://alturl" {
 						// This bit just ensures that we
 						// call it from the correct
@@ -735,6 +1189,25 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"record the problems reported by the ACME server if the challenge is 'failed'": {
+			order: testOrderPending,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending, testAuthorizationChallengeInvalidWithProblem},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderInvalidWithProblem.Namespace, testOrderInvalidWithProblem)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderInvalidWithError, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"should leave the order state as-is if the challenge is marked invalid but the acme order is pending": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -767,6 +1240,48 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			},
 			acmeClient: &acmecl.FakeACME{},
 		},
+		"store the diagnostics of a failed order in a configmap and re-queue it for their expiry": {
+			order:          testOrderErrored,
+			diagnosticsTTL: time.Hour,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderErrored},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewGetAction(corev1.SchemeGroupVersion.WithResource("configmaps"),
+						testOrderErrored.Namespace, "testorder-diagnostics")),
+					testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("configmaps"),
+						testOrderErrored.Namespace, testDiagnosticsConfigMap)),
+				},
+			},
+			acmeClient:     &acmecl.FakeACME{},
+			shouldSchedule: true,
+		},
+		"re-queue a failed order for the expiry of its existing diagnostics": {
+			order:          testOrderErrored,
+			diagnosticsTTL: time.Hour,
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{testDiagnosticsConfigMap},
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderErrored},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewGetAction(corev1.SchemeGroupVersion.WithResource("configmaps"),
+						testOrderErrored.Namespace, "testorder-diagnostics")),
+				},
+			},
+			acmeClient:     &acmecl.FakeACME{},
+			shouldSchedule: true,
+		},
+		"delete the diagnostics of a failed order once they have expired": {
+			order:          testOrderErroredExpiredDiagnostics,
+			diagnosticsTTL: time.Hour,
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{testDiagnosticsConfigMap},
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderErroredExpiredDiagnostics},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("configmaps"),
+						testOrderErrored.Namespace, "testorder-diagnostics")),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{},
+		},
 		"do nothing if the order is in errored state with no url or finalize url on status": {
 			order: testOrderErrored,
 			builder: &testpkg.Builder{
@@ -791,11 +1306,15 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 }
 
 type testT struct {
-	order          *cmacme.Order
-	builder        *testpkg.Builder
-	acmeClient     acmecl.Interface
-	shouldSchedule bool
-	expectErr      bool
+	order            *cmacme.Order
+	builder          *testpkg.Builder
+	acmeClient       acmecl.Interface
+	orderLongPolling bool
+	acmeProfiles     map[string]string
+	issuancePaused   bool
+	diagnosticsTTL   time.Duration
+	shouldSchedule   bool
+	expectErr        bool
 }
 
 func runTest(t *testing.T, test testT) {
@@ -822,6 +1341,18 @@ func runTest(t *testing.T, test testT) {
 		},
 	}
 	cw.scheduledWorkQueue = &fakeScheduler
+	if test.orderLongPolling {
+		cw.orderLongPollingSupported = func(context.Context, cmapi.GenericIssuer) bool {
+			return true
+		}
+	}
+
+	cw.issuancePause = controllerpkg.NewIssuancePause(test.issuancePaused)
+	cw.diagnosticsTTL = test.diagnosticsTTL
+
+	cw.directoryMeta = func(context.Context, cmapi.GenericIssuer) (*acmecl.DirectoryMeta, error) {
+		return &acmecl.DirectoryMeta{Profiles: test.acmeProfiles}, nil
+	}
 
 	test.builder.Start()
 
@@ -838,3 +1369,207 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestSelectPreferredChain(t *testing.T) {
+	// build the chains of a CA whose root "ISRG Root X1" is cross-signed by
+	// the older root "DST Root CA X3", in which the intermediate is issued by
+	// "ISRG Root X1" in both chains.
+	signCert := func(cn string, pub, issuerPK interface{}, issuer *x509.Certificate) ([]byte, *x509.Certificate) {
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		if issuer == nil {
+			issuer = tmpl
+		}
+		_, cert, err := pki.SignCertificate(tmpl, issuer, pub, issuerPK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert.Raw, cert
+	}
+	newKey := func() *ecdsa.PrivateKey {
+		pk, err := pki.GenerateECPrivateKey(256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pk
+	}
+	dstPK, isrgPK, intPK, leafPK := newKey(), newKey(), newKey(), newKey()
+	_, dstRoot := signCert("DST Root CA X3", dstPK.Public(), dstPK, nil)
+	_, isrgRoot := signCert("ISRG Root X1", isrgPK.Public(), isrgPK, nil)
+	isrgCross, _ := signCert("ISRG Root X1", isrgPK.Public(), dstPK, dstRoot)
+	intermediate, intCert := signCert("R3", intPK.Public(), isrgPK, isrgRoot)
+	leaf, _ := signCert("example.com", leafPK.Public(), intPK, intCert)
+
+	longChain := [][]byte{leaf, intermediate, isrgCross}
+	shortChain := [][]byte{leaf, intermediate}
+
+	tests := map[string]struct {
+		defaultChain   [][]byte
+		altChains      map[string][][]byte
+		preferredChain string
+		expChain       [][]byte
+		expErr         bool
+	}{
+		"the default chain is used if its root matches": {
+			defaultChain:   longChain,
+			preferredChain: "DST Root CA X3",
+			expChain:       longChain,
+		},
+		"an alternate chain is used if its root matches, even if an intermediate of the default chain matches": {
+			defaultChain:   longChain,
+			altChains:      map[string][][]byte{"http://alturl": shortChain},
+			preferredChain: "ISRG Root X1",
+			expChain:       shortChain,
+		},
+		"an alternate chain is used if its root matches the root cross-signing the default chain": {
+			defaultChain:   shortChain,
+			altChains:      map[string][][]byte{"http://alturl": longChain},
+			preferredChain: "DST Root CA X3",
+			expChain:       longChain,
+		},
+		"the default chain is used if no chain matches": {
+			defaultChain:   longChain,
+			altChains:      map[string][][]byte{"http://alturl": shortChain},
+			preferredChain: "Other Root",
+			expChain:       longChain,
+		},
+		"an error is returned if an alternate chain cannot be parsed": {
+			defaultChain:   longChain,
+			altChains:      map[string][][]byte{"http://alturl": {[]byte("test")}},
+			preferredChain: "ISRG Root X1",
+			expErr:         true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cl := &acmecl.FakeACME{
+				FakeListCertAlternates: func(_ context.Context, url string) ([]string, error) {
+					var urls []string
+					for u := range test.altChains {
+						urls = append(urls, u)
+					}
+					return urls, nil
+				},
+				FakeFetchCert: func(_ context.Context, url string, bundle bool) ([][]byte, error) {
+					chain, ok := test.altChains[url]
+					if !ok {
+						return nil, fmt.Errorf("unexpected url %q", url)
+					}
+					return chain, nil
+				},
+			}
+
+			chain, err := selectPreferredChain(context.Background(), cl, "http://certurl", test.defaultChain, test.preferredChain)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if !reflect.DeepEqual(chain, test.expChain) {
+				t.Errorf("unexpected chain, exp=%d certificates got=%d certificates", len(test.expChain), len(chain))
+			}
+		})
+	}
+}
+
+func TestFetchMetadataForAuthorizations(t *testing.T) {
+	const parallelism = 3
+	urls := []string{"http://authz/1", "http://authz/2", "http://authz/3", "http://authz/4", "http://authz/5", "http://authz/6"}
+
+	tests := map[string]struct {
+		failing    map[string]error
+		expState   cmacme.State
+		expErr     bool
+		expBackoff bool
+		expFilled  []bool
+	}{
+		"all authorizations are fetched": {
+			expFilled: []bool{true, true, true, true, true, true},
+		},
+		"authorizations which were fetched are recorded if fetching others fails": {
+			failing: map[string]error{
+				"http://authz/2": errors.New("connection reset"),
+				"http://authz/5": errors.New("connection reset"),
+			},
+			expErr:    true,
+			expFilled: []bool{true, false, true, true, false, true},
+		},
+		"an error with which the ACME server asked to back off is returned unchanged": {
+			failing: map[string]error{
+				"http://authz/2": errors.New("connection reset"),
+				"http://authz/4": &acmeapi.Error{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"60"}}},
+			},
+			expErr:     true,
+			expBackoff: true,
+			expFilled:  []bool{true, false, true, false, true, true},
+		},
+		"the order is errored if an authorization fails permanently": {
+			failing: map[string]error{
+				"http://authz/3": &acmeapi.Error{StatusCode: http.StatusForbidden, ProblemType: "urn:ietf:params:acme:error:unauthorized"},
+			},
+			expState:  cmacme.Errored,
+			expFilled: []bool{true, true, false, true, true, true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lock sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			cl := &acmecl.FakeACME{
+				FakeGetAuthorization: func(_ context.Context, url string) (*acmeapi.Authorization, error) {
+					lock.Lock()
+					inFlight++
+					if inFlight > maxInFlight {
+						maxInFlight = inFlight
+					}
+					lock.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					lock.Lock()
+					inFlight--
+					lock.Unlock()
+
+					if err := test.failing[url]; err != nil {
+						return nil, err
+					}
+					return &acmeapi.Authorization{
+						URI:        url,
+						Status:     acmeapi.StatusPending,
+						Identifier: acmeapi.AuthzID{Type: "dns", Value: url},
+					}, nil
+				},
+			}
+
+			o := gen.Order("test", gen.SetOrderStatus(cmacme.OrderStatus{
+				Authorizations: constructAuthorizations(&acmeapi.Order{AuthzURLs: urls}),
+			}))
+			c := &controller{authzFetchParallelism: parallelism, clock: fakeclock.NewFakeClock(time.Now())}
+			err := c.fetchMetadataForAuthorizations(context.Background(), o, cl)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if backoff := acmecl.BackoffForError(err, time.Now()); (backoff != nil) != test.expBackoff {
+				t.Errorf("expected backoff=%t, got %v for error %v", test.expBackoff, backoff, err)
+			}
+			if maxInFlight > parallelism {
+				t.Errorf("expected at most %d authorizations to be fetched at a time, got %d", parallelism, maxInFlight)
+			}
+			if o.Status.State != test.expState {
+				t.Errorf("expected order state %q, got %q", test.expState, o.Status.State)
+			}
+			for i, authz := range o.Status.Authorizations {
+				if filled := authz.Identifier != ""; filled != test.expFilled[i] {
+					t.Errorf("authorization %s: expected fetched=%t, got %t", authz.URL, test.expFilled[i], filled)
+				}
+			}
+		})
+	}
+}
@@ -24,10 +24,10 @@ import (
 
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	"github.com/cert-manager/cert-manager/pkg/acme/solverselection"
 	"github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -79,184 +79,52 @@ func buildChallenge(ctx context.Context, cl acmecl.Interface, issuer cmapi.Gener
 }
 
 func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order, authz cmacme.ACMEAuthorization) (*cmacme.ChallengeSpec, error) {
-	log := logf.FromContext(ctx, "challengeSpecForAuthorization")
-	dbg := log.V(logf.DebugLevel)
-
-	// 1. fetch solvers from issuer
-	solvers := issuer.GetSpec().ACME.Solvers
-
 	wc := false
 	if authz.Wildcard != nil {
 		wc = *authz.Wildcard
 	}
-	domainToFind := authz.Identifier
-	if wc {
-		domainToFind = "*." + domainToFind
-	}
-
-	var selectedSolver *cmacme.ACMEChallengeSolver
-	var selectedChallenge *cmacme.ACMEChallenge
-	selectedNumLabelsMatch := 0
-	selectedNumDNSNamesMatch := 0
-	selectedNumDNSZonesMatch := 0
-
-	challengeForSolver := func(solver *cmacme.ACMEChallengeSolver) *cmacme.ACMEChallenge {
-		for _, ch := range authz.Challenges {
-			switch {
-			case ch.Type == "http-01" && solver.HTTP01 != nil:
-				return &ch
-			case ch.Type == "dns-01" && solver.DNS01 != nil:
-				return &ch
-			}
-		}
-		return nil
-	}
-
-	// 2. filter solvers to only those that matchLabels
-	for _, cfg := range solvers {
-		acmech := challengeForSolver(&cfg)
-		if acmech == nil {
-			dbg.Info("cannot use solver as the ACME authorization does not allow solvers of this type")
-			continue
-		}
-
-		if cfg.Selector == nil {
-			if selectedSolver != nil {
-				dbg.Info("not selecting solver as previously selected solver has a just as or more specific selector")
-				continue
-			}
-			dbg.Info("selecting solver due to match all selector and no previously selected solver")
-			selectedSolver = cfg.DeepCopy()
-			selectedChallenge = acmech
-			continue
-		}
-
-		labelsMatch, numLabelsMatch := selectors.Labels(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsNamesMatch, numDNSNamesMatch := selectors.DNSNames(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsZonesMatch, numDNSZonesMatch := selectors.DNSZones(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-
-		if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch {
-			dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch)
-			continue
-		}
-
-		dbg.Info("selector matches")
-
-		selectSolver := func() {
-			selectedSolver = cfg.DeepCopy()
-			selectedChallenge = acmech
-			selectedNumLabelsMatch = numLabelsMatch
-			selectedNumDNSNamesMatch = numDNSNamesMatch
-			selectedNumDNSZonesMatch = numDNSZonesMatch
-		}
-
-		if selectedSolver == nil {
-			dbg.Info("selecting solver as there is no previously selected solver")
-			selectSolver()
-			continue
-		}
-
-		dbg.Info("determining whether this match is more significant than last")
-
-		// because we don't count multiple dnsName matches as extra 'weight'
-		// in the selection process, we normalize the numDNSNamesMatch vars
-		// to be either 1 or 0 (i.e. true or false)
-		selectedHasMatchingDNSNames := selectedNumDNSNamesMatch > 0
-		hasMatchingDNSNames := numDNSNamesMatch > 0
-
-		// dnsName selectors have the highest precedence, so check them first
-		switch {
-		case !selectedHasMatchingDNSNames && hasMatchingDNSNames:
-			dbg.Info("selecting solver as this solver has matching DNS names and the previous one does not")
-			selectSolver()
-			continue
-		case selectedHasMatchingDNSNames && !hasMatchingDNSNames:
-			dbg.Info("not selecting solver as the previous one has matching DNS names and this one does not")
-			continue
-		case !selectedHasMatchingDNSNames && !hasMatchingDNSNames:
-			dbg.Info("solver does not have any matching DNS names, checking dnsZones")
-			// check zones
-		case selectedHasMatchingDNSNames && hasMatchingDNSNames:
-			dbg.Info("both this solver and the previously selected one matches dnsNames, comparing zones")
-			if numDNSZonesMatch > selectedNumDNSZonesMatch {
-				dbg.Info("selecting solver as this one has a more specific dnsZone match than the previously selected one")
-				selectSolver()
-				continue
-			}
-			if selectedNumDNSZonesMatch > numDNSZonesMatch {
-				dbg.Info("not selecting this solver as the previously selected one has a more specific dnsZone match")
-				continue
-			}
-			dbg.Info("both this solver and the previously selected one match dnsZones, comparing labels")
-			// choose the one with the most labels
-			if numLabelsMatch > selectedNumLabelsMatch {
-				dbg.Info("selecting solver as this one has more labels than the previously selected one")
-				selectSolver()
-				continue
-			}
-			dbg.Info("not selecting this solver as previous one has either the same number of or more labels")
-			continue
-		}
-
-		selectedHasMatchingDNSZones := selectedNumDNSZonesMatch > 0
-		hasMatchingDNSZones := numDNSZonesMatch > 0
 
-		switch {
-		case !selectedHasMatchingDNSZones && hasMatchingDNSZones:
-			dbg.Info("selecting solver as this solver has matching DNS zones and the previous one does not")
-			selectSolver()
-			continue
-		case selectedHasMatchingDNSZones && !hasMatchingDNSZones:
-			dbg.Info("not selecting solver as the previous one has matching DNS zones and this one does not")
-			continue
-		case !selectedHasMatchingDNSZones && !hasMatchingDNSZones:
-			dbg.Info("solver does not have any matching DNS zones, checking labels")
-			// check labels
-		case selectedHasMatchingDNSZones && hasMatchingDNSZones:
-			dbg.Info("both this solver and the previously selected one matches dnsZones")
-			dbg.Info("comparing number of matching domain segments")
-			// choose the one with the most matching DNS zone segments
-			if numDNSZonesMatch > selectedNumDNSZonesMatch {
-				dbg.Info("selecting solver because this one has more matching DNS zone segments")
-				selectSolver()
-				continue
-			}
-			if selectedNumDNSZonesMatch > numDNSZonesMatch {
-				dbg.Info("not selecting solver because previous one has more matching DNS zone segments")
-				continue
-			}
-			// choose the one with the most labels
-			if numLabelsMatch > selectedNumLabelsMatch {
-				dbg.Info("selecting solver because this one has more labels than the previous one")
-				selectSolver()
+	// 1. determine the challenge types offered for the authorization,
+	//    ignoring any types not supported by cert-manager
+	//    IP address identifiers can only be validated using HTTP01 challenges
+	//    as they have no DNS zone in which to present a DNS01 record.
+	isIP := authz.IdentifierType == cmacme.ACMEIdentifierTypeIP
+	var offered []cmacme.ACMEChallengeType
+	for _, ch := range authz.Challenges {
+		if chType, err := challengeType(ch.Type); err == nil {
+			if isIP && chType != cmacme.ACMEChallengeTypeHTTP01 {
 				continue
 			}
-			dbg.Info("not selecting solver as this one's number of matching labels is equal to or less than the last one")
-			continue
-		}
-
-		if numLabelsMatch > selectedNumLabelsMatch {
-			dbg.Info("selecting solver as this one has more labels than the last one")
-			selectSolver()
-			continue
+			offered = append(offered, chType)
 		}
-
-		dbg.Info("not selecting solver as this one's number of matching labels is equal to or less than the last one (reached end of loop)")
-		// if we get here, the number of matches is less than or equal so we
-		// fallback to choosing the first in the list
-	}
-
-	if selectedSolver == nil || selectedChallenge == nil {
-		return nil, fmt.Errorf("no configured challenge solvers can be used for this challenge")
 	}
 
-	// It should never be possible for this case to be hit as earlier in this
-	// method we already assert that the challenge type is one of 'http-01'
-	// or 'dns-01'.
-	chType, err := challengeType(selectedChallenge.Type)
+	// 2. select the most specific of the issuer's solvers
+	selection, err := solverselection.Select(ctx, issuer.GetSpec().ACME.Solvers, solverselection.Request{
+		ObjectMeta:     o.ObjectMeta,
+		DNSName:        authz.Identifier,
+		Wildcard:       wc,
+		ChallengeTypes: offered,
+	})
 	if err != nil {
 		return nil, err
 	}
+	selectedSolver := &selection.Solver
+	chType := selection.Type
+
+	// 3. use the first challenge offered of the selected solver's type
+	var selectedChallenge *cmacme.ACMEChallenge
+	for i, ch := range authz.Challenges {
+		if t, err := challengeType(ch.Type); err == nil && t == chType {
+			selectedChallenge = &authz.Challenges[i]
+			break
+		}
+	}
+	// It should never be possible for this case to be hit as the solver was
+	// selected for one of the offered challenge types.
+	if selectedChallenge == nil {
+		return nil, fmt.Errorf("no challenge of type %q offered", chType)
+	}
 
 	key, err := keyForChallenge(cl, selectedChallenge.Token, chType)
 	if err != nil {
@@ -270,18 +138,24 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 	}
 
 	// 5. construct Challenge resource with spec.solver field set
-	return &cmacme.ChallengeSpec{
+	chSpec := &cmacme.ChallengeSpec{
 		AuthorizationURL: authz.URL,
 		Type:             chType,
 		URL:              selectedChallenge.URL,
 		DNSName:          authz.Identifier,
 		Token:            selectedChallenge.Token,
 		Key:              key,
-		// selectedSolver cannot be nil due to the check above.
-		Solver:    *selectedSolver,
-		Wildcard:  wc,
-		IssuerRef: o.Spec.IssuerRef,
-	}, nil
+		Solver:           *selectedSolver,
+		Wildcard:         wc,
+		IssuerRef:        o.Spec.IssuerRef,
+	}
+	// The identifier type is only set for IP addresses so that the names of
+	// Challenges for DNS names, which are computed from the spec, are unchanged.
+	if isIP {
+		chSpec.IdentifierType = cmacme.ACMEIdentifierTypeIP
+	}
+
+	return chSpec, nil
 }
 
 func challengeType(t string) (cmacme.ACMEChallengeType, error) {
@@ -371,6 +371,64 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  emptySelectorSolverDNS01,
 			},
 		},
+		"should use HTTP01 solver for IP address identifiers even if DNS01 is offered": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverHTTP01,
+								emptySelectorSolverDNS01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					IPAddresses: []string{"10.0.0.1"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier:     "10.0.0.1",
+				IdentifierType: cmacme.ACMEIdentifierTypeIP,
+				Challenges:     []cmacme.ACMEChallenge{*acmeChallengeDNS01, *acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:           cmacme.ACMEChallengeTypeHTTP01,
+				DNSName:        "10.0.0.1",
+				IdentifierType: cmacme.ACMEIdentifierTypeIP,
+				Token:          acmeChallengeHTTP01.Token,
+				Key:            "http01",
+				Solver:         emptySelectorSolverHTTP01,
+			},
+		},
+		"should return an error for IP address identifiers if only DNS01 solvers are configured": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverDNS01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					IPAddresses: []string{"10.0.0.1"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier:     "10.0.0.1",
+				IdentifierType: cmacme.ACMEIdentifierTypeIP,
+				Challenges:     []cmacme.ACMEChallenge{*acmeChallengeDNS01, *acmeChallengeHTTP01},
+			},
+			expectedError: true,
+		},
 		"should return an error if none match": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
@@ -45,6 +45,10 @@ type Builder struct {
 	// runDurationFuncs are a list of functions that will be called every
 	// 'duration'
 	runDurationFuncs []runDurationFunc
+
+	// workersFunc returns the number of workers the controller should be run
+	// with, overriding the number passed to Run if it is higher than zero.
+	workersFunc func(*Context) int
 }
 
 // New creates a basic Builder, setting the sync call to the one given
@@ -79,6 +83,15 @@ func (b *Builder) First(function func(context.Context)) *Builder {
 	return b
 }
 
+// Workers will set the number of items of the controller's queue that are
+// processed concurrently, overriding the number of workers passed to Run.
+// The function is called once the controller Context has been built, so that
+// the number can be read from the controller's options.
+func (b *Builder) Workers(function func(*Context) int) *Builder {
+	b.workersFunc = function
+	return b
+}
+
 func (b *Builder) Complete() (Interface, error) {
 	controllerctx, err := b.contextFactory.Build(b.name)
 	if err != nil {
@@ -95,5 +108,10 @@ func (b *Builder) Complete() (Interface, error) {
 		return nil, fmt.Errorf("error registering controller: %v", err)
 	}
 
-	return NewController(ctx, b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue), nil
+	ctrl := NewController(ctx, b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue)
+	if b.workersFunc != nil {
+		ctrl.(*controller).workers = b.workersFunc(controllerctx)
+	}
+
+	return ctrl, nil
 }
@@ -48,6 +48,9 @@ type controller struct {
 	gatewayLister gwlisters.GatewayLister
 	sync          shimhelper.SyncFn
 
+	// issuerClass is the class of the Gateways processed by this controller.
+	issuerClass string
+
 	// For testing purposes.
 	queue workqueue.RateLimitingInterface
 }
@@ -55,7 +58,8 @@ type controller struct {
 func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
 	c.gatewayLister = ctx.GWShared.Gateway().V1alpha2().Gateways().Lister()
 	log := logf.FromContext(ctx.RootContext, ControllerName)
-	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), ctx.IngressShimOptions, ctx.FieldManager)
+	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), nil, ctx.IngressShimOptions, ctx.FieldManager)
+	c.issuerClass = ctx.IssuerClass
 
 	// We don't need to requeue Gateways on "Deleted" events, since our Sync
 	// function does nothing when the Gateway lister returns "not found". But we
@@ -104,6 +108,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		return nil
+	}
+
 	return c.sync(ctx, crt)
 }
 
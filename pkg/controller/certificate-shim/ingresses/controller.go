@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	networkingv1 "k8s.io/api/networking/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	shimhelper "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -40,6 +42,9 @@ const (
 type controller struct {
 	ingressLister networkingv1listers.IngressLister
 	sync          shimhelper.SyncFn
+
+	// issuerClass is the class of the Ingresses processed by this controller.
+	issuerClass string
 }
 
 func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
@@ -49,7 +54,8 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.ingressLister = ingressInformer.Lister()
 
 	log := logf.FromContext(ctx.RootContext, ControllerName)
-	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, cmShared.Certmanager().V1().Certificates().Lister(), ctx.IngressShimOptions, ctx.FieldManager)
+	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, cmShared.Certmanager().V1().Certificates().Lister(), c.ingressLister, ctx.IngressShimOptions, ctx.FieldManager)
+	c.issuerClass = ctx.IssuerClass
 
 	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
 
@@ -64,8 +70,18 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// to do some cleanup, we would use a finalizer, and the cleanup logic would
 	// be triggered by the "Updated" event when the object gets marked for
 	// deletion.
-	ingressInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{
-		Queue: queue,
+	//
+	// The Ingress controlling the Certificate for a Secret which is also
+	// referenced by the Ingress is re-queued too, since the hosts of the
+	// Certificate are those of all the Ingresses referencing the Secret.
+	handleIngress := ingressHandler(queue, cmShared.Certmanager().V1().Certificates().Lister())
+	ingressInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handleIngress,
+		UpdateFunc: func(old, new interface{}) {
+			handleIngress(old)
+			handleIngress(new)
+		},
+		DeleteFunc: handleIngress,
 	})
 
 	// We still re-queue on "Add" because the workqueue will remove any
@@ -102,6 +118,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		return nil
+	}
+
 	return c.sync(ctx, crt)
 }
 
@@ -130,8 +150,15 @@ func certificateHandler(queue workqueue.RateLimitingInterface) func(obj interfac
 
 		ingress := metav1.GetControllerOf(cert)
 		if ingress == nil {
-			// No controller should care about orphans being deleted or
-			// updated.
+			// The controller of a Certificate shared by several Ingresses
+			// may have been deleted, in which case one of the remaining
+			// Ingresses takes it over. No controller should care about
+			// orphans being deleted or updated.
+			for _, ref := range cert.OwnerReferences {
+				if ref.Kind == "Ingress" {
+					queue.Add(cert.Namespace + "/" + ref.Name)
+				}
+			}
 			return
 		}
 
@@ -143,6 +170,32 @@ func certificateHandler(queue workqueue.RateLimitingInterface) func(obj interfac
 	}
 }
 
+// ingressHandler queues the Ingress, as well as the Ingresses controlling the
+// Certificates for the Secrets referenced in its TLS blocks.
+func ingressHandler(queue workqueue.RateLimitingInterface, certificateLister cmlisters.CertificateLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		ing, ok := obj.(*networkingv1.Ingress)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("not an Ingress object: %#v", obj))
+			return
+		}
+
+		queue.Add(ing.Namespace + "/" + ing.Name)
+		for _, tls := range ing.Spec.TLS {
+			cert, err := certificateLister.Certificates(ing.Namespace).Get(tls.SecretName)
+			if err != nil {
+				continue
+			}
+			if ref := metav1.GetControllerOf(cert); ref != nil && ref.Kind == "Ingress" && ref.Name != ing.Name {
+				queue.Add(ing.Namespace + "/" + ref.Name)
+			}
+		}
+	}
+}
+
 func init() {
 	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
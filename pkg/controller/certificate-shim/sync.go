@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/record"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
@@ -74,6 +76,7 @@ func SyncFnFor(
 	log logr.Logger,
 	cmClient clientset.Interface,
 	cmLister cmlisters.CertificateLister,
+	ingressLister networkingv1listers.IngressLister,
 	defaults controller.IngressShimOptions,
 	fieldManager string,
 ) SyncFn {
@@ -116,7 +119,7 @@ func SyncFnFor(
 			return nil
 		}
 
-		newCrts, updateCrts, err := buildCertificates(rec, log, cmLister, ingLike, issuerName, issuerKind, issuerGroup)
+		newCrts, updateCrts, err := buildCertificates(rec, log, cmLister, ingressLister, autoAnnotations, ingLike, issuerName, issuerKind, issuerGroup)
 		if err != nil {
 			return err
 		}
@@ -130,26 +133,7 @@ func SyncFnFor(
 		}
 
 		for _, crt := range updateCrts {
-
-			if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-				err = internalcertificates.Apply(ctx, cmClient, fieldManager, &cmapi.Certificate{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:            crt.Name,
-						Namespace:       crt.Namespace,
-						Labels:          crt.Labels,
-						OwnerReferences: crt.OwnerReferences,
-					},
-					Spec: cmapi.CertificateSpec{
-						DNSNames:   crt.Spec.DNSNames,
-						SecretName: crt.Spec.SecretName,
-						IssuerRef:  crt.Spec.IssuerRef,
-						Usages:     crt.Spec.Usages,
-					},
-				})
-			} else {
-				_, err = cmClient.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
-			}
-			if err != nil {
+			if err := updateCertificate(ctx, cmClient, fieldManager, crt); err != nil {
 				return err
 			}
 
@@ -163,6 +147,28 @@ func SyncFnFor(
 		unrequiredCertNames := findCertificatesToBeRemoved(certs, ingLike)
 
 		for _, certName := range unrequiredCertNames {
+			// A Certificate for a Secret which is still referenced by other
+			// Ingresses is handed over to them rather than deleted.
+			if ing, ok := ingLike.(*networkingv1.Ingress); ok && ingressLister != nil {
+				crt, err := cmLister.Certificates(ing.Namespace).Get(certName)
+				if err != nil {
+					return err
+				}
+				sharing, err := ingressesSharingSecret(ingressLister, ing, crt.Spec.SecretName, autoAnnotations)
+				if err != nil {
+					return err
+				}
+				if len(sharing) > 0 {
+					crt = crt.DeepCopy()
+					crt.OwnerReferences = ingressOwnerReferences(sharing)
+					if err := updateCertificate(ctx, cmClient, fieldManager, crt); err != nil {
+						return err
+					}
+					rec.Eventf(ingLikeObj, corev1.EventTypeNormal, reasonUpdateCertificate, "Successfully handed over Certificate %q to Ingress %q which also references its Secret", certName, sharing[0].Name)
+					continue
+				}
+			}
+
 			err = cmClient.CertmanagerV1().Certificates(ingLike.GetNamespace()).Delete(ctx, certName, metav1.DeleteOptions{})
 			if err != nil {
 				return err
@@ -174,6 +180,30 @@ func SyncFnFor(
 	}
 }
 
+// updateCertificate updates the Certificate created for an Ingress-like
+// object. If the ServerSideApply feature is enabled, the fields managed by
+// the certificate-shim will instead get applied.
+func updateCertificate(ctx context.Context, cmClient clientset.Interface, fieldManager string, crt *cmapi.Certificate) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		return internalcertificates.Apply(ctx, cmClient, fieldManager, &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            crt.Name,
+				Namespace:       crt.Namespace,
+				Labels:          crt.Labels,
+				OwnerReferences: crt.OwnerReferences,
+			},
+			Spec: cmapi.CertificateSpec{
+				DNSNames:   crt.Spec.DNSNames,
+				SecretName: crt.Spec.SecretName,
+				IssuerRef:  crt.Spec.IssuerRef,
+				Usages:     crt.Spec.Usages,
+			},
+		})
+	}
+	_, err := cmClient.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
 func validateIngressLike(ingLike metav1.Object) field.ErrorList {
 	switch o := ingLike.(type) {
 	case *networkingv1.Ingress:
@@ -286,6 +316,8 @@ func buildCertificates(
 	rec record.EventRecorder,
 	log logr.Logger,
 	cmLister cmlisters.CertificateLister,
+	ingressLister networkingv1listers.IngressLister,
+	autoAnnotations []string,
 	ingLike metav1.Object,
 	issuerName, issuerKind, issuerGroup string,
 ) (new, update []*cmapi.Certificate, _ error) {
@@ -347,13 +379,37 @@ func buildCertificates(
 		case *gwapi.Gateway:
 			controllerGVK = gatewayGVK
 		}
+		ownerRefs := []metav1.OwnerReference{*metav1.NewControllerRef(ingLike, controllerGVK)}
+
+		// When several Ingresses reference the same Secret, a single
+		// Certificate for the hosts of all of them is controlled by one of
+		// them, and the others are added as owners of the Certificate.
+		adopt := false
+		if ing, ok := ingLike.(*networkingv1.Ingress); ok && ingressLister != nil {
+			sharing, err := ingressesSharingSecret(ingressLister, ing, secretRef.Name, autoAnnotations)
+			if err != nil {
+				return nil, nil, err
+			}
+			controllerIng := controllingIngress(existingCrt, sharing)
+			if controllerIng == nil {
+				log.V(logf.InfoLevel).Info("certificate resource is not owned by an Ingress referencing its secret. refusing to update non-owned certificate resource for object", "secret", secretRef.Name)
+				continue
+			}
+			if controllerIng.UID != ing.UID {
+				log.V(logf.DebugLevel).Info("certificate resource is controlled by another Ingress referencing the same secret", "secret", secretRef.Name, "controller", controllerIng.Name)
+				continue
+			}
+			adopt = existingCrt != nil && metav1.GetControllerOf(existingCrt) == nil
+			hosts = hostsForSecret(sharing, secretRef.Name)
+			ownerRefs = ingressOwnerReferences(sharing)
+		}
 
 		crt := &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:            secretRef.Name,
 				Namespace:       secretRef.Namespace,
 				Labels:          ingLike.GetLabels(),
-				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ingLike, controllerGVK)},
+				OwnerReferences: ownerRefs,
 			},
 			Spec: cmapi.CertificateSpec{
 				DNSNames:   hosts,
@@ -379,23 +435,32 @@ func buildCertificates(
 			return nil, nil, err
 		}
 
+		// The Certificate is processed by the same cert-manager instance as
+		// the object it was created for.
+		if class, ok := ingLike.GetAnnotations()[cmapi.IssuerClassAnnotationKey]; ok {
+			if crt.Annotations == nil {
+				crt.Annotations = make(map[string]string)
+			}
+			crt.Annotations[cmapi.IssuerClassAnnotationKey] = class
+		}
+
 		// check if a Certificate for this TLS entry already exists, and if it
 		// does then skip this entry
 		if existingCrt != nil {
 			log := logf.WithRelatedResource(log, existingCrt)
 			log.V(logf.DebugLevel).Info("certificate already exists for this object, ensuring it is up to date")
 
-			if metav1.GetControllerOf(existingCrt) == nil {
+			if metav1.GetControllerOf(existingCrt) == nil && !adopt {
 				log.V(logf.InfoLevel).Info("certificate resource has no owner. refusing to update non-owned certificate resource for object")
 				continue
 			}
 
-			if !metav1.IsControlledBy(existingCrt, ingLike) {
+			if !metav1.IsControlledBy(existingCrt, ingLike) && !adopt {
 				log.V(logf.InfoLevel).Info("certificate resource is not owned by this object. refusing to update non-owned certificate resource for object")
 				continue
 			}
 
-			if !certNeedsUpdate(existingCrt, crt) {
+			if !certNeedsUpdate(existingCrt, crt) && reflect.DeepEqual(existingCrt.OwnerReferences, crt.OwnerReferences) {
 				log.V(logf.DebugLevel).Info("certificate resource is already up to date for object")
 				continue
 			}
@@ -404,6 +469,7 @@ func buildCertificates(
 
 			updateCrt.Spec = crt.Spec
 			updateCrt.Labels = crt.Labels
+			updateCrt.OwnerReferences = crt.OwnerReferences
 
 			setIssuerSpecificConfig(crt, ingLike)
 
@@ -416,6 +482,111 @@ func buildCertificates(
 	return newCrts, updateCrts, nil
 }
 
+// ingressesSharingSecret returns the Ingresses synced by the ingress-shim in
+// the namespace of the given Ingress which have a TLS block for the Secret,
+// including the given Ingress if it has one. The Ingresses are ordered by
+// creation time and then by name, so that the first one is the one which
+// controls a new Certificate for the Secret.
+func ingressesSharingSecret(ingressLister networkingv1listers.IngressLister, ing *networkingv1.Ingress, secretName string, autoAnnotations []string) ([]*networkingv1.Ingress, error) {
+	ingresses, err := ingressLister.Ingresses(ing.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var sharing []*networkingv1.Ingress
+	for _, other := range append(ingresses, ing) {
+		// The given Ingress may be more recent than the one in the lister.
+		if other.UID == ing.UID && other != ing {
+			continue
+		}
+		if other.DeletionTimestamp != nil || !hasShimAnnotation(other, autoAnnotations) || len(hostsForSecret([]*networkingv1.Ingress{other}, secretName)) == 0 {
+			continue
+		}
+		sharing = append(sharing, other)
+	}
+
+	sort.SliceStable(sharing, func(i, j int) bool {
+		if !sharing[i].CreationTimestamp.Equal(&sharing[j].CreationTimestamp) {
+			return sharing[i].CreationTimestamp.Before(&sharing[j].CreationTimestamp)
+		}
+		return sharing[i].Name < sharing[j].Name
+	})
+
+	return sharing, nil
+}
+
+// controllingIngress returns the Ingress which controls the Certificate for
+// a Secret shared by the given Ingresses. The current controller of an
+// existing Certificate keeps controlling it for as long as it references the
+// Secret, so that its issuer configuration does not change when other
+// Ingresses start referencing the Secret. Otherwise, the first Ingress
+// controls it. Nil is returned if an existing Certificate is not owned by
+// any of the Ingresses, in which case it must not be updated.
+func controllingIngress(existingCrt *cmapi.Certificate, sharing []*networkingv1.Ingress) *networkingv1.Ingress {
+	if len(sharing) == 0 {
+		return nil
+	}
+	if existingCrt == nil {
+		return sharing[0]
+	}
+
+	if ref := metav1.GetControllerOf(existingCrt); ref != nil {
+		for _, ing := range sharing {
+			if ing.UID == ref.UID {
+				return ing
+			}
+		}
+		return nil
+	}
+
+	// The controller of the Certificate has been deleted, but the
+	// Certificate is still owned by other Ingresses.
+	for _, ref := range existingCrt.OwnerReferences {
+		for _, ing := range sharing {
+			if ing.UID == ref.UID {
+				return sharing[0]
+			}
+		}
+	}
+	return nil
+}
+
+// hostsForSecret returns the hosts of the TLS blocks for the Secret of the
+// given Ingresses, without duplicates.
+func hostsForSecret(ingresses []*networkingv1.Ingress, secretName string) []string {
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, ing := range ingresses {
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName != secretName {
+				continue
+			}
+			for _, host := range tls.Hosts {
+				if !seen[host] {
+					seen[host] = true
+					hosts = append(hosts, host)
+				}
+			}
+		}
+	}
+	return hosts
+}
+
+// ingressOwnerReferences returns the owner references of a Certificate
+// shared by the given Ingresses, controlled by the first one.
+func ingressOwnerReferences(ingresses []*networkingv1.Ingress) []metav1.OwnerReference {
+	var refs []metav1.OwnerReference
+	for i, ing := range ingresses {
+		ref := *metav1.NewControllerRef(ing, ingressV1GVK)
+		if i > 0 {
+			isController := false
+			ref.Controller = &isController
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
 func findCertificatesToBeRemoved(certs []*cmapi.Certificate, ingLike metav1.Object) []string {
 	var toBeRemoved []string
 	for _, crt := range certs {
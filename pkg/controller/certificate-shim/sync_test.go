@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -78,6 +79,40 @@ func TestSync(t *testing.T) {
 		gen.SetIssuerACME(cmacme.ACMEIssuer{}))
 	acmeClusterIssuer := gen.ClusterIssuer("issuer-name",
 		gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+	// sharingIngress returns an Ingress with a TLS block for the
+	// "example-com-tls" Secret, created the given number of minutes ago.
+	sharingIngress := func(name string, minutesAgo int, hosts ...string) *networkingv1.Ingress {
+		ing := buildIngress(name, gen.DefaultTestNamespace, map[string]string{
+			cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+		})
+		ing.CreationTimestamp = metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(minutesAgo) * time.Minute))
+		ing.Spec.TLS = []networkingv1.IngressTLS{{Hosts: hosts, SecretName: "example-com-tls"}}
+		return ing
+	}
+	sharedCertificate := func(ownerReferences []metav1.OwnerReference, hosts ...string) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "example-com-tls",
+				Namespace:       gen.DefaultTestNamespace,
+				OwnerReferences: ownerReferences,
+			},
+			Spec: cmapi.CertificateSpec{
+				DNSNames:   hosts,
+				SecretName: "example-com-tls",
+				IssuerRef: cmmeta.ObjectReference{
+					Name: "issuer-name",
+					Kind: "ClusterIssuer",
+				},
+				Usages: cmapi.DefaultKeyUsages(),
+			},
+		}
+	}
+	ingressA := sharingIngress("ingress-a", 10, "example.com", "www.example.com")
+	ingressB := sharingIngress("ingress-b", 5, "www.example.com", "api.example.com")
+	ownerIngressB := *metav1.NewControllerRef(ingressB, ingressV1GVK)
+	ownerIngressB.Controller = pointer.Bool(false)
+	sharedOwnerReferences := append(buildIngressOwnerReferences("ingress-a", gen.DefaultTestNamespace), ownerIngressB)
+
 	type testT struct {
 		Name                string
 		IngressLike         metav1.Object
@@ -85,6 +120,7 @@ func TestSync(t *testing.T) {
 		IssuerLister        []runtime.Object
 		ClusterIssuerLister []runtime.Object
 		CertificateLister   []runtime.Object
+		IngressLister       []runtime.Object
 		DefaultIssuerName   string
 		DefaultIssuerKind   string
 		DefaultIssuerGroup  string
@@ -427,6 +463,52 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:   "the issuer class of the ingress should be copied to the Certificate",
+			Issuer: acmeClusterIssuer,
+			IngressLike: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+						cmapi.IssuerClassAnnotationKey:              "tenant-a",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1.IngressSpec{
+					TLS: []networkingv1.IngressTLS{
+						{
+							Hosts:      []string{"example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "example-com-tls",
+						Namespace: gen.DefaultTestNamespace,
+						Annotations: map[string]string{
+							cmapi.IssuerClassAnnotationKey: "tenant-a",
+						},
+						OwnerReferences: buildIngressOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+						Usages: cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+		},
 		{
 			Name:   "return a single DNS01 Certificate for an ingress with a single valid TLS entry",
 			Issuer: acmeClusterIssuer,
@@ -1460,6 +1542,56 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:                "create a single Certificate for the hosts of all the Ingresses referencing the same Secret, controlled by the oldest one",
+			Issuer:              acmeClusterIssuer,
+			IngressLike:         ingressA,
+			IngressLister:       []runtime.Object{ingressA, ingressB},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				sharedCertificate(sharedOwnerReferences, "example.com", "www.example.com", "api.example.com"),
+			},
+		},
+		{
+			Name:                "do not create a Certificate for a Secret also referenced by an older Ingress",
+			Issuer:              acmeClusterIssuer,
+			IngressLike:         ingressB,
+			IngressLister:       []runtime.Object{ingressA, ingressB},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+		},
+		{
+			Name:                "update the Certificate with the hosts of an Ingress which starts referencing the same Secret",
+			Issuer:              acmeClusterIssuer,
+			IngressLike:         ingressA,
+			IngressLister:       []runtime.Object{ingressA, ingressB},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			CertificateLister: []runtime.Object{
+				sharedCertificate(buildIngressOwnerReferences("ingress-a", gen.DefaultTestNamespace), "example.com", "www.example.com"),
+			},
+			ExpectedEvents: []string{`Normal UpdateCertificate Successfully updated Certificate "example-com-tls"`},
+			ExpectedUpdate: []*cmapi.Certificate{
+				sharedCertificate(sharedOwnerReferences, "example.com", "www.example.com", "api.example.com"),
+			},
+		},
+		{
+			Name:   "hand over the Certificate to another Ingress referencing the same Secret when the controlling Ingress stops referencing it",
+			Issuer: acmeClusterIssuer,
+			IngressLike: func() *networkingv1.Ingress {
+				ing := ingressA.DeepCopy()
+				ing.Spec.TLS = nil
+				return ing
+			}(),
+			IngressLister:       []runtime.Object{ingressA, ingressB},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			CertificateLister: []runtime.Object{
+				sharedCertificate(sharedOwnerReferences, "example.com", "www.example.com", "api.example.com"),
+			},
+			ExpectedEvents: []string{`Normal UpdateCertificate Successfully handed over Certificate "example-com-tls" to Ingress "ingress-b" which also references its Secret`},
+			ExpectedUpdate: []*cmapi.Certificate{
+				sharedCertificate(buildIngressOwnerReferences("ingress-b", gen.DefaultTestNamespace), "example.com", "www.example.com", "api.example.com"),
+			},
+		},
 	}
 
 	testGatewayShim := []testT{
@@ -2835,13 +2967,14 @@ func TestSync(t *testing.T) {
 			}
 			b := &testpkg.Builder{
 				T:                  t,
+				KubeObjects:        test.IngressLister,
 				CertManagerObjects: allCMObjects,
 				ExpectedActions:    expectedActions,
 				ExpectedEvents:     test.ExpectedEvents,
 			}
 			b.Init()
 			defer b.Stop()
-			sync := SyncFnFor(b.Recorder, logr.Discard(), b.CMClient, b.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), controller.IngressShimOptions{
+			sync := SyncFnFor(b.Recorder, logr.Discard(), b.CMClient, b.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), b.KubeSharedInformerFactory.Networking().V1().Ingresses().Lister(), controller.IngressShimOptions{
 				DefaultIssuerName:                 test.DefaultIssuerName,
 				DefaultIssuerKind:                 test.DefaultIssuerKind,
 				DefaultIssuerGroup:                test.DefaultIssuerGroup,
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificateauthorities
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+type controller struct {
+	certificateAuthorityLister cmlisters.CertificateAuthorityLister
+	clusterIssuerLister        cmlisters.ClusterIssuerLister
+	certificateLister          cmlisters.CertificateLister
+
+	// maintain a reference to the workqueue for this controller
+	// so the handleOwnedResource method can enqueue resources
+	queue workqueue.RateLimitingInterface
+
+	// logger to be used by this controller
+	log logr.Logger
+
+	// clientset used to update cert-manager API resources
+	cmClient cmclient.Interface
+
+	// used to record Events about resources to the API
+	recorder record.EventRecorder
+
+	// clusterResourceNamespace is the namespace the Certificates and Secrets
+	// of the certificate authorities are stored in, which is also where the
+	// CA ClusterIssuers read their Secrets from.
+	clusterResourceNamespace string
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	c.log = logf.FromContext(ctx.RootContext, ControllerName)
+
+	// create a queue used to queue up items to be processed
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateAuthorityInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateAuthorities()
+	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateAuthorityInformer.Informer().HasSynced,
+		clusterIssuerInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	// set all the references to the listers for used by the Sync function
+	c.certificateAuthorityLister = certificateAuthorityInformer.Lister()
+	c.clusterIssuerLister = clusterIssuerInformer.Lister()
+	c.certificateLister = certificateInformer.Lister()
+
+	// register handler functions
+	certificateAuthorityInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	// CertificateAuthorities are re-synced as the Certificates and
+	// ClusterIssuers making up their chain become ready.
+	handleOwnedResource := controllerpkg.HandleOwnedResourceNamespacedFunc(c.log, c.queue, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateAuthorityKind), func(_, name string) (interface{}, error) {
+		return c.certificateAuthorityLister.Get(name)
+	})
+	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: handleOwnedResource})
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: handleOwnedResource})
+
+	// instantiate additional helpers used by this controller
+	c.cmClient = ctx.CMClient
+	c.recorder = ctx.Recorder
+	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+
+	return c.queue, mustSync, nil
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(nil, "invalid resource key")
+		return nil
+	}
+
+	ca, err := c.certificateAuthorityLister.Get(name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Error(err, "certificateauthority in work queue no longer exists")
+			return nil
+		}
+
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, ca))
+	return c.Sync(ctx, ca)
+}
+
+const (
+	// ControllerName is the name of the CertificateAuthorities controller.
+	ControllerName = "certificateauthorities"
+)
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controller{}).
+			Complete()
+	})
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificateauthorities
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/errors"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/globals"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	reasonConflict            = "Conflict"
+	reasonCreateCertificate   = "CreateCertificate"
+	reasonUpdateCertificate   = "UpdateCertificate"
+	reasonDeleteCertificate   = "DeleteCertificate"
+	reasonCreateClusterIssuer = "CreateClusterIssuer"
+	reasonUpdateClusterIssuer = "UpdateClusterIssuer"
+	reasonDeleteClusterIssuer = "DeleteClusterIssuer"
+	reasonPending             = "Pending"
+	reasonReady               = "Ready"
+)
+
+// errNotOwned is returned when a resource of the chain already exists but is
+// not owned by the CertificateAuthority.
+type errNotOwned struct {
+	kind, name string
+}
+
+func (e errNotOwned) Error() string {
+	return fmt.Sprintf("%s %q already exists and is not owned by the CertificateAuthority", e.kind, e.name)
+}
+
+func (c *controller) Sync(ctx context.Context, ca *cmapi.CertificateAuthority) (err error) {
+	log := logf.FromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, globals.DefaultControllerContextTimeout)
+	defer cancel()
+
+	caCopy := ca.DeepCopy()
+	defer func() {
+		if saveErr := c.updateStatus(ctx, ca, caCopy); saveErr != nil {
+			err = errors.NewAggregate([]error{saveErr, err})
+		}
+	}()
+
+	issuers := buildClusterIssuers(caCopy)
+	certificates := buildCertificates(caCopy, c.clusterResourceNamespace)
+
+	for _, iss := range issuers {
+		if err := c.ensureClusterIssuer(ctx, caCopy, iss); err != nil {
+			return c.handleEnsureError(caCopy, err)
+		}
+	}
+	for _, crt := range certificates {
+		if err := c.ensureCertificate(ctx, caCopy, crt); err != nil {
+			return c.handleEnsureError(caCopy, err)
+		}
+	}
+
+	if err := c.deleteUnrequired(ctx, caCopy, issuers, certificates); err != nil {
+		return err
+	}
+
+	reason, message := reasonReady, fmt.Sprintf("ClusterIssuer %q is ready to issue certificates", issuers[len(issuers)-1].Name)
+	status := cmmeta.ConditionTrue
+	if pending := c.pendingResource(issuers[len(issuers)-1], certificates); pending != "" {
+		reason, message, status = reasonPending, fmt.Sprintf("Waiting for %s to be ready", pending), cmmeta.ConditionFalse
+	}
+	log.V(logf.DebugLevel).Info("certificate authority chain synced", "ready", status, "message", message)
+	apiutil.SetCertificateAuthorityCondition(caCopy, caCopy.Generation, cmapi.CertificateAuthorityConditionReady, status, reason, message)
+
+	return nil
+}
+
+// handleEnsureError marks the CertificateAuthority as not ready if a resource
+// of its chain is owned by something else, which is not retried until either
+// of them changes.
+func (c *controller) handleEnsureError(ca *cmapi.CertificateAuthority, err error) error {
+	if _, ok := err.(errNotOwned); !ok {
+		return err
+	}
+	c.recorder.Event(ca, corev1.EventTypeWarning, reasonConflict, err.Error())
+	apiutil.SetCertificateAuthorityCondition(ca, ca.Generation, cmapi.CertificateAuthorityConditionReady, cmmeta.ConditionFalse, reasonConflict, err.Error())
+	return nil
+}
+
+// pendingResource returns a description of the first resource of the chain
+// which is not ready yet, or an empty string if the final ClusterIssuer is
+// ready to issue certificates.
+func (c *controller) pendingResource(issuer *cmapi.ClusterIssuer, certificates []*cmapi.Certificate) string {
+	for _, crt := range certificates {
+		existing, err := c.certificateLister.Certificates(crt.Namespace).Get(crt.Name)
+		if err != nil || !apiutil.CertificateHasCondition(existing, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}) {
+			return fmt.Sprintf("Certificate %q", crt.Name)
+		}
+	}
+
+	existing, err := c.clusterIssuerLister.Get(issuer.Name)
+	if err != nil || !apiutil.IssuerHasCondition(existing, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return fmt.Sprintf("ClusterIssuer %q", issuer.Name)
+	}
+
+	return ""
+}
+
+func (c *controller) ensureClusterIssuer(ctx context.Context, ca *cmapi.CertificateAuthority, iss *cmapi.ClusterIssuer) error {
+	existing, err := c.clusterIssuerLister.Get(iss.Name)
+	if k8sErrors.IsNotFound(err) {
+		if _, err := c.cmClient.CertmanagerV1().ClusterIssuers().Create(ctx, iss, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		c.recorder.Eventf(ca, corev1.EventTypeNormal, reasonCreateClusterIssuer, "Created ClusterIssuer %q", iss.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(existing, ca) {
+		return errNotOwned{kind: cmapi.ClusterIssuerKind, name: iss.Name}
+	}
+	if apiequality.Semantic.DeepEqual(existing.Spec, iss.Spec) {
+		return nil
+	}
+
+	existing = existing.DeepCopy()
+	existing.Spec = iss.Spec
+	if _, err := c.cmClient.CertmanagerV1().ClusterIssuers().Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recorder.Eventf(ca, corev1.EventTypeNormal, reasonUpdateClusterIssuer, "Updated ClusterIssuer %q", iss.Name)
+	return nil
+}
+
+func (c *controller) ensureCertificate(ctx context.Context, ca *cmapi.CertificateAuthority, crt *cmapi.Certificate) error {
+	existing, err := c.certificateLister.Certificates(crt.Namespace).Get(crt.Name)
+	if k8sErrors.IsNotFound(err) {
+		if _, err := c.cmClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		c.recorder.Eventf(ca, corev1.EventTypeNormal, reasonCreateCertificate, "Created Certificate %q", crt.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(existing, ca) {
+		return errNotOwned{kind: cmapi.CertificateKind, name: crt.Name}
+	}
+	if apiequality.Semantic.DeepEqual(existing.Spec, crt.Spec) {
+		return nil
+	}
+
+	existing = existing.DeepCopy()
+	existing.Spec = crt.Spec
+	if _, err := c.cmClient.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recorder.Eventf(ca, corev1.EventTypeNormal, reasonUpdateCertificate, "Updated Certificate %q", crt.Name)
+	return nil
+}
+
+// deleteUnrequired deletes the Certificates and ClusterIssuers owned by the
+// CertificateAuthority which are no longer part of its chain, such as after
+// an intermediate has been removed.
+func (c *controller) deleteUnrequired(ctx context.Context, ca *cmapi.CertificateAuthority, issuers []*cmapi.ClusterIssuer, certificates []*cmapi.Certificate) error {
+	required := make(map[string]bool)
+	for _, iss := range issuers {
+		required[cmapi.ClusterIssuerKind+"/"+iss.Name] = true
+	}
+	for _, crt := range certificates {
+		required[cmapi.CertificateKind+"/"+crt.Name] = true
+	}
+
+	existingIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, iss := range existingIssuers {
+		if !metav1.IsControlledBy(iss, ca) || required[cmapi.ClusterIssuerKind+"/"+iss.Name] {
+			continue
+		}
+		if err := c.cmClient.CertmanagerV1().ClusterIssuers().Delete(ctx, iss.Name, metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		c.recorder.Eventf(ca, corev1.EventTypeNormal, reasonDeleteClusterIssuer, "Deleted unrequired ClusterIssuer %q", iss.Name)
+	}
+
+	existingCertificates, err := c.certificateLister.Certificates(c.clusterResourceNamespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, crt := range existingCertificates {
+		if !metav1.IsControlledBy(crt, ca) || required[cmapi.CertificateKind+"/"+crt.Name] {
+			continue
+		}
+		if err := c.cmClient.CertmanagerV1().Certificates(crt.Namespace).Delete(ctx, crt.Name, metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		c.recorder.Eventf(ca, corev1.EventTypeNormal, reasonDeleteCertificate, "Deleted unrequired Certificate %q", crt.Name)
+	}
+
+	return nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, old, new *cmapi.CertificateAuthority) error {
+	if apiequality.Semantic.DeepEqual(old.Status, new.Status) {
+		return nil
+	}
+	_, err := c.cmClient.CertmanagerV1().CertificateAuthorities().UpdateStatus(ctx, new, metav1.UpdateOptions{})
+	return err
+}
+
+// level is a certificate authority of the chain along with the names of the
+// Certificate storing it and of the CA ClusterIssuer backed by it.
+type level struct {
+	cmapi.CertificateAuthorityCA
+
+	certificateName string
+	issuerName      string
+}
+
+// levels returns the levels of the chain of the CertificateAuthority, starting
+// with the root. The ClusterIssuer of the last level is named after
+// spec.issuerName.
+func levels(ca *cmapi.CertificateAuthority) []level {
+	cas := append([]cmapi.CertificateAuthorityCA{ca.Spec.Root}, ca.Spec.Intermediates...)
+	levels := make([]level, len(cas))
+	for i, l := range cas {
+		name := ca.Name + "-root"
+		if i > 0 {
+			name = fmt.Sprintf("%s-intermediate-%d", ca.Name, i)
+		}
+		levels[i] = level{CertificateAuthorityCA: l, certificateName: name, issuerName: name}
+	}
+
+	issuerName := ca.Spec.IssuerName
+	if issuerName == "" {
+		issuerName = ca.Name
+	}
+	levels[len(levels)-1].issuerName = issuerName
+
+	return levels
+}
+
+// selfSignedIssuerName returns the name of the SelfSigned ClusterIssuer
+// issuing the root of the CertificateAuthority.
+func selfSignedIssuerName(ca *cmapi.CertificateAuthority) string {
+	return ca.Name + "-selfsigned"
+}
+
+// buildClusterIssuers returns the SelfSigned ClusterIssuer issuing the root of
+// the CertificateAuthority followed by the CA ClusterIssuer of each level of
+// its chain. The last ClusterIssuer is the one exposed to users.
+func buildClusterIssuers(ca *cmapi.CertificateAuthority) []*cmapi.ClusterIssuer {
+	issuers := []*cmapi.ClusterIssuer{
+		{
+			ObjectMeta: ownedObjectMeta(ca, selfSignedIssuerName(ca), ""),
+			Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+				SelfSigned: &cmapi.SelfSignedIssuer{},
+			}},
+		},
+	}
+	for _, l := range levels(ca) {
+		issuers = append(issuers, &cmapi.ClusterIssuer{
+			ObjectMeta: ownedObjectMeta(ca, l.issuerName, ""),
+			Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+				CA: &cmapi.CAIssuer{SecretName: l.certificateName},
+			}},
+		})
+	}
+	return issuers
+}
+
+// buildCertificates returns the Certificate of each level of the chain of the
+// CertificateAuthority, each issued by the ClusterIssuer of the level above
+// it.
+func buildCertificates(ca *cmapi.CertificateAuthority, namespace string) []*cmapi.Certificate {
+	var certificates []*cmapi.Certificate
+	issuerName := selfSignedIssuerName(ca)
+	for _, l := range levels(ca) {
+		certificates = append(certificates, &cmapi.Certificate{
+			ObjectMeta: ownedObjectMeta(ca, l.certificateName, namespace),
+			Spec: cmapi.CertificateSpec{
+				CommonName:  l.CommonName,
+				Subject:     l.Subject,
+				Duration:    l.Duration,
+				RenewBefore: l.RenewBefore,
+				PrivateKey:  l.PrivateKey,
+				IsCA:        true,
+				SecretName:  l.certificateName,
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  issuerName,
+					Kind:  cmapi.ClusterIssuerKind,
+					Group: certmanager.GroupName,
+				},
+			},
+		})
+		issuerName = l.issuerName
+	}
+	return certificates
+}
+
+func ownedObjectMeta(ca *cmapi.CertificateAuthority, name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       namespace,
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ca, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateAuthorityKind))},
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificateauthorities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func TestSync(t *testing.T) {
+	const namespace = "cert-manager"
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	issuersGVR := cmapi.SchemeGroupVersion.WithResource("clusterissuers")
+	certificatesGVR := cmapi.SchemeGroupVersion.WithResource("certificates")
+	caGVR := cmapi.SchemeGroupVersion.WithResource("certificateauthorities")
+
+	ca := &cmapi.CertificateAuthority{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "uid", Generation: 2},
+		Spec: cmapi.CertificateAuthoritySpec{
+			Root:          cmapi.CertificateAuthorityCA{CommonName: "Test Root CA"},
+			Intermediates: []cmapi.CertificateAuthorityCA{{CommonName: "Test Intermediate CA"}},
+		},
+	}
+	rootOnly := ca.DeepCopy()
+	rootOnly.Spec.Intermediates = nil
+
+	issuers := buildClusterIssuers(ca)
+	certificates := buildCertificates(ca, namespace)
+
+	withCondition := func(ca *cmapi.CertificateAuthority, status cmmeta.ConditionStatus, reason, message string) *cmapi.CertificateAuthority {
+		ca = ca.DeepCopy()
+		ca.Status.Conditions = []cmapi.CertificateAuthorityCondition{{
+			Type:               cmapi.CertificateAuthorityConditionReady,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &now,
+			ObservedGeneration: 2,
+		}}
+		return ca
+	}
+	readyCertificate := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		crt = crt.DeepCopy()
+		crt.Status.Conditions = []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}}
+		return crt
+	}
+	readyIssuer := func(iss *cmapi.ClusterIssuer) *cmapi.ClusterIssuer {
+		iss = iss.DeepCopy()
+		iss.Status.Conditions = []cmapi.IssuerCondition{{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}}
+		return iss
+	}
+
+	tests := map[string]struct {
+		ca              *cmapi.CertificateAuthority
+		existing        []runtime.Object
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"provisions the ClusterIssuers and Certificates of the chain": {
+			ca: ca,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(issuersGVR, "", issuers[0])),
+				testpkg.NewAction(coretesting.NewCreateAction(issuersGVR, "", issuers[1])),
+				testpkg.NewAction(coretesting.NewCreateAction(issuersGVR, "", issuers[2])),
+				testpkg.NewAction(coretesting.NewCreateAction(certificatesGVR, namespace, certificates[0])),
+				testpkg.NewAction(coretesting.NewCreateAction(certificatesGVR, namespace, certificates[1])),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(caGVR, "status", "",
+					withCondition(ca, cmmeta.ConditionFalse, reasonPending, `Waiting for Certificate "test-root" to be ready`))),
+			},
+			expectedEvents: []string{
+				`Normal CreateClusterIssuer Created ClusterIssuer "test-selfsigned"`,
+				`Normal CreateClusterIssuer Created ClusterIssuer "test-root"`,
+				`Normal CreateClusterIssuer Created ClusterIssuer "test"`,
+				`Normal CreateCertificate Created Certificate "test-root"`,
+				`Normal CreateCertificate Created Certificate "test-intermediate-1"`,
+			},
+		},
+		"is ready once the chain has been issued": {
+			ca: ca,
+			existing: []runtime.Object{
+				readyIssuer(issuers[0]), readyIssuer(issuers[1]), readyIssuer(issuers[2]),
+				readyCertificate(certificates[0]), readyCertificate(certificates[1]),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(caGVR, "status", "",
+					withCondition(ca, cmmeta.ConditionTrue, reasonReady, `ClusterIssuer "test" is ready to issue certificates`))),
+			},
+		},
+		"does not take over resources it does not own": {
+			ca: ca,
+			existing: []runtime.Object{
+				&cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "test-selfsigned"}},
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(caGVR, "status", "",
+					withCondition(ca, cmmeta.ConditionFalse, reasonConflict, `ClusterIssuer "test-selfsigned" already exists and is not owned by the CertificateAuthority`))),
+			},
+			expectedEvents: []string{
+				`Warning Conflict ClusterIssuer "test-selfsigned" already exists and is not owned by the CertificateAuthority`,
+			},
+		},
+		"removes the resources of an intermediate which is no longer part of the chain": {
+			ca: rootOnly,
+			existing: []runtime.Object{
+				readyIssuer(issuers[0]), readyIssuer(issuers[1]), readyIssuer(issuers[2]),
+				readyCertificate(certificates[0]), readyCertificate(certificates[1]),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(issuersGVR, "", readyIssuer(buildClusterIssuers(rootOnly)[1]))),
+				testpkg.NewAction(coretesting.NewDeleteAction(issuersGVR, "", "test-root")),
+				testpkg.NewAction(coretesting.NewDeleteAction(certificatesGVR, namespace, "test-intermediate-1")),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(caGVR, "status", "",
+					withCondition(rootOnly, cmmeta.ConditionTrue, reasonReady, `ClusterIssuer "test" is ready to issue certificates`))),
+			},
+			expectedEvents: []string{
+				`Normal UpdateClusterIssuer Updated ClusterIssuer "test"`,
+				`Normal DeleteClusterIssuer Deleted unrequired ClusterIssuer "test-root"`,
+				`Normal DeleteCertificate Deleted unrequired Certificate "test-intermediate-1"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now.Time),
+				CertManagerObjects: append([]runtime.Object{test.ca}, test.existing...),
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = namespace
+
+			c := &controller{}
+			if _, _, err := c.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := c.Sync(context.Background(), test.ca); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := builder.AllEventsCalled(); err != nil {
+				t.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
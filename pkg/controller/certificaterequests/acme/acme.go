@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
@@ -39,6 +40,7 @@ import (
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/go-logr/logr"
 )
@@ -63,6 +65,9 @@ type ACME struct {
 
 	// fieldManager is the manager name used for Create and Apply operations.
 	fieldManager string
+
+	// ownedBy identifies this cert-manager instance on the Orders it creates.
+	ownedBy string
 }
 
 func init() {
@@ -103,6 +108,7 @@ func NewACME(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		acmeClientV:   ctx.CMClient.AcmeV1(),
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		fieldManager:  ctx.FieldManager,
+		ownedBy:       ctx.OwnedBy,
 	}
 }
 
@@ -139,7 +145,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	}
 
 	// If we fail to build the order we have to hard fail.
-	expectedOrder, err := buildOrder(cr, csr, issuer.GetSpec().ACME.EnableDurationFeature)
+	expectedOrder, err := buildOrder(cr, csr, issuer.GetSpec().ACME.EnableDurationFeature, orderProfile(cr, issuer))
 	if err != nil {
 		message := "Failed to build order"
 
@@ -149,7 +155,44 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 		return nil, nil
 	}
 
+	// If the request has more identifiers than the ACME server accepts in an
+	// order we have to hard fail, naming the limit in the Certificate's
+	// status rather than having the ACME server reject the order.
+	if err := acme.CheckMaxIdentifiers(expectedOrder.Spec, issuer.GetSpec().ACME); err != nil {
+		message := "The CSR PEM requests more identifiers than the issuer's ACME server accepts in a single order"
+
+		a.reporter.Failed(cr, err, "TooManyIdentifiers", message)
+		log.V(logf.DebugLevel).Info(fmt.Sprintf("%s: %s", message, err))
+
+		return nil, nil
+	}
+	expectedOrder.Labels = controllerpkg.AddOwnedByLabels(expectedOrder.Labels, a.ownedBy, cr)
+
 	order, err := a.orderLister.Orders(expectedOrder.Namespace).Get(expectedOrder.Name)
+	if k8sErrors.IsNotFound(err) && utilfeature.DefaultFeatureGate.Enabled(feature.ACMEOrderCoalescing) {
+		// Rather than creating a duplicate Order, share an identical Order
+		// which is still in flight, if there is one.
+		shared, sharedErr := a.findSharedOrder(cr, csr, expectedOrder)
+		if sharedErr != nil {
+			message := fmt.Sprintf("Failed to list order resources in namespace %s", expectedOrder.Namespace)
+
+			a.reporter.Pending(cr, sharedErr, "OrderGetError", message)
+			log.Error(sharedErr, message)
+
+			return nil, sharedErr
+		}
+		if shared != nil {
+			order, err = a.shareOrder(ctx, cr, shared)
+			if err != nil {
+				message := fmt.Sprintf("Failed to share order resource %s/%s", shared.Namespace, shared.Name)
+
+				a.reporter.Pending(cr, err, "OrderSharingError", message)
+				log.Error(err, message)
+
+				return nil, err
+			}
+		}
+	}
 	if k8sErrors.IsNotFound(err) {
 		// Failing to create the order here is most likely network related.
 		// We should backoff and keep trying.
@@ -179,7 +222,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 
 		return nil, err
 	}
-	if !metav1.IsControlledBy(order, cr) {
+	if !metav1.IsControlledBy(order, cr) && !isSharedWith(order, cr) {
 		// TODO: improve this behaviour - this issue occurs because someone
 		//  else may create a CertificateRequest with a name that is equal to
 		//  the name of the request we are creating, due to our hash function
@@ -238,8 +281,18 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	}, nil
 }
 
+// orderProfile returns the ACME certificate profile to request for the
+// CertificateRequest. The profile annotation on the CertificateRequest, which
+// is copied from the Certificate, takes precedence over the issuer's profile.
+func orderProfile(cr *cmapi.CertificateRequest, issuer cmapi.GenericIssuer) string {
+	if profile := cr.Annotations[cmacme.ACMECertificateProfileOverride]; profile != "" {
+		return profile
+	}
+	return issuer.GetSpec().ACME.Profile
+}
+
 // Build order. If we error here it is a terminating failure.
-func buildOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, enableDurationFeature bool) (*cmacme.Order, error) {
+func buildOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, enableDurationFeature bool, profile string) (*cmacme.Order, error) {
 	var ipAddresses []string
 	for _, ip := range csr.IPAddresses {
 		ipAddresses = append(ipAddresses, ip.String())
@@ -256,6 +309,7 @@ func buildOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, enab
 		CommonName:  csr.Subject.CommonName,
 		DNSNames:    dnsNames,
 		IPAddresses: ipAddresses,
+		Profile:     profile,
 	}
 
 	if enableDurationFeature {
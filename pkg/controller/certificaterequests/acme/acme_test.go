@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
@@ -113,6 +114,10 @@ func TestSign(t *testing.T) {
 		}),
 	)
 
+	limitedIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerACME(cmacme.ACMEIssuer{MaxIdentifiersPerOrder: pointer.Int(2)}),
+	)
+
 	rootPK, err := pki.GenerateECPrivateKey(256)
 	if err != nil {
 		t.Fatal()
@@ -144,6 +149,7 @@ func TestSign(t *testing.T) {
 
 	csrPEM := generateCSR(t, sk, "example.com", "example.com", "foo.com")
 	csrPEMExampleNotPresent := generateCSR(t, sk, "example.com", "foo.com")
+	csrPEMManyIdentifiers := generateCSR(t, sk, "example.com", "example.com", "foo.com", "bar.com")
 
 	baseCRNotApproved := gen.CertificateRequest("test-cr",
 		gen.SetCertificateRequestCSR(csrPEM),
@@ -210,12 +216,12 @@ func TestSign(t *testing.T) {
 		t.Fatal(err)
 	}
 	ipBaseCR := gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestCSR(ipCSRPEM))
-	ipBaseOrder, err := buildOrder(ipBaseCR, ipCSR, baseIssuer.GetSpec().ACME.EnableDurationFeature)
+	ipBaseOrder, err := buildOrder(ipBaseCR, ipCSR, baseIssuer.GetSpec().ACME.EnableDurationFeature, "")
 	if err != nil {
 		t.Fatalf("failed to build order during testing: %s", err)
 	}
 
-	baseOrder, err := buildOrder(baseCR, csr, baseIssuer.GetSpec().ACME.EnableDurationFeature)
+	baseOrder, err := buildOrder(baseCR, csr, baseIssuer.GetSpec().ACME.EnableDurationFeature, "")
 	if err != nil {
 		t.Fatalf("failed to build order during testing: %s", err)
 	}
@@ -313,6 +319,36 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if the request has more identifiers than the issuer accepts per order then should hard fail": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSR(csrPEMManyIdentifiers),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), limitedIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning TooManyIdentifiers The CSR PEM requests more identifiers than the issuer's ACME server accepts in a single order: the request has 3 DNS names and IP addresses but the issuer's ACME server accepts at most 2 per order (spec.acme.maxIdentifiersPerOrder), split them across multiple Certificates`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSR(csrPEMManyIdentifiers),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The CSR PEM requests more identifiers than the issuer's ACME server accepts in a single order: the request has 3 DNS names and IP addresses but the issuer's ACME server accepts at most 2 per order (spec.acme.maxIdentifiersPerOrder), split them across multiple Certificates`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+
 		"if the common name is not present in the IP Addresses then should hard fail": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
 				gen.SetCertificateRequestCSR(generateCSR(t, sk, "10.0.0.1", "example.com")),
@@ -696,6 +732,7 @@ func Test_buildOrder(t *testing.T) {
 		cr                    *v1.CertificateRequest
 		csr                   *x509.CertificateRequest
 		enableDurationFeature bool
+		profile               string
 	}
 	tests := []struct {
 		name    string
@@ -736,10 +773,27 @@ func Test_buildOrder(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Building with a profile",
+			args: args{
+				cr:      cr,
+				csr:     csr,
+				profile: "shortlived",
+			},
+			want: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					Request:    csrPEM,
+					CommonName: "example.com",
+					DNSNames:   []string{"example.com"},
+					Profile:    "shortlived",
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := buildOrder(tt.args.cr, tt.args.csr, tt.args.enableDurationFeature)
+			got, err := buildOrder(tt.args.cr, tt.args.csr, tt.args.enableDurationFeature, tt.args.profile)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildOrder() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -756,7 +810,7 @@ func Test_buildOrder(t *testing.T) {
 		"test-comparison-that-is-at-the-fifty-two-character-l",
 		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
 		gen.SetCertificateRequestCSR(csrPEM))
-	orderOne, err := buildOrder(longCrOne, csr, false)
+	orderOne, err := buildOrder(longCrOne, csr, false, "")
 	if err != nil {
 		t.Errorf("buildOrder() received error %v", err)
 		return
@@ -768,7 +822,7 @@ func Test_buildOrder(t *testing.T) {
 			gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
 			gen.SetCertificateRequestCSR(csrPEM))
 
-		orderTwo, err := buildOrder(longCrTwo, csr, false)
+		orderTwo, err := buildOrder(longCrTwo, csr, false, "")
 		if err != nil {
 			t.Errorf("buildOrder() received error %v", err)
 			return
@@ -783,13 +837,13 @@ func Test_buildOrder(t *testing.T) {
 	})
 
 	t.Run("Builds two orders from the same long CRs to guarantee same name", func(t *testing.T) {
-		orderOne, err := buildOrder(longCrOne, csr, false)
+		orderOne, err := buildOrder(longCrOne, csr, false, "")
 		if err != nil {
 			t.Errorf("buildOrder() received error %v", err)
 			return
 		}
 
-		orderTwo, err := buildOrder(longCrOne, csr, false)
+		orderTwo, err := buildOrder(longCrOne, csr, false, "")
 		if err != nil {
 			t.Errorf("buildOrder() received error %v", err)
 			return
@@ -802,3 +856,46 @@ func Test_buildOrder(t *testing.T) {
 		}
 	})
 }
+
+func Test_orderProfile(t *testing.T) {
+	issuer := gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{Profile: "tlsserver"}))
+	issuerWithoutProfile := gen.Issuer("test", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+
+	tests := map[string]struct {
+		cr     *cmapi.CertificateRequest
+		issuer cmapi.GenericIssuer
+		want   string
+	}{
+		"no profile configured": {
+			cr:     gen.CertificateRequest("test"),
+			issuer: issuerWithoutProfile,
+			want:   "",
+		},
+		"profile configured on the issuer": {
+			cr:     gen.CertificateRequest("test"),
+			issuer: issuer,
+			want:   "tlsserver",
+		},
+		"profile annotation overrides the issuer": {
+			cr: gen.CertificateRequest("test", gen.SetCertificateRequestAnnotations(map[string]string{
+				cmacme.ACMECertificateProfileOverride: "shortlived",
+			})),
+			issuer: issuer,
+			want:   "shortlived",
+		},
+		"profile annotation without a profile on the issuer": {
+			cr: gen.CertificateRequest("test", gen.SetCertificateRequestAnnotations(map[string]string{
+				cmacme.ACMECertificateProfileOverride: "shortlived",
+			})),
+			issuer: issuerWithoutProfile,
+			want:   "shortlived",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := orderProfile(test.cr, test.issuer); got != test.want {
+				t.Errorf("orderProfile() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// findSharedOrder returns the Order the CertificateRequest shares, or an
+// Order it can share, or nil if there is none.
+//
+// An Order can be shared by CertificateRequests for the same issuer,
+// identifiers and public key, since the certificate it results in is valid
+// for all of them. Only Orders which are still in flight are shared: sharing
+// a completed Order would hand out a previously issued certificate, so that
+// renewals would never get a new one.
+func (a *ACME) findSharedOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, expectedOrder *cmacme.Order) (*cmacme.Order, error) {
+	orders, err := a.orderLister.Orders(expectedOrder.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var shareable *cmacme.Order
+	for _, order := range orders {
+		if isSharedWith(order, cr) {
+			return order, nil
+		}
+		if !canShareOrder(order, expectedOrder, csr) {
+			continue
+		}
+		// Pick the oldest Order so that all CertificateRequests sharing
+		// an Order agree on which one it is.
+		if shareable == nil || order.CreationTimestamp.Before(&shareable.CreationTimestamp) ||
+			(order.CreationTimestamp.Equal(&shareable.CreationTimestamp) && order.Name < shareable.Name) {
+			shareable = order
+		}
+	}
+
+	return shareable, nil
+}
+
+// canShareOrder returns true if the Order is in flight and would result in
+// the same certificate as the expected Order of the CertificateRequest.
+func canShareOrder(order, expectedOrder *cmacme.Order, csr *x509.CertificateRequest) bool {
+	if order.DeletionTimestamp != nil || acme.IsFinalState(order.Status.State) {
+		return false
+	}
+	if order.Labels[cmapi.OwnedByLabelKey] != expectedOrder.Labels[cmapi.OwnedByLabelKey] {
+		return false
+	}
+
+	spec, expectedSpec := order.Spec, expectedOrder.Spec
+	if spec.IssuerRef != expectedSpec.IssuerRef ||
+		spec.CommonName != expectedSpec.CommonName ||
+		spec.Profile != expectedSpec.Profile ||
+		!apiequality.Semantic.DeepEqual(spec.Duration, expectedSpec.Duration) ||
+		!util.EqualUnsorted(spec.DNSNames, expectedSpec.DNSNames) ||
+		!util.EqualUnsorted(spec.IPAddresses, expectedSpec.IPAddresses) {
+		return false
+	}
+
+	orderCSR, err := pki.DecodeX509CertificateRequestBytes(spec.Request)
+	if err != nil {
+		return false
+	}
+	equal, err := pki.PublicKeysEqual(orderCSR.PublicKey, csr.PublicKey)
+	return err == nil && equal
+}
+
+// shareOrder adds the CertificateRequest as an owner of the Order, so that
+// the Order is kept until all the CertificateRequests sharing it have been
+// deleted, and so that they are all resynced when the Order changes.
+func (a *ACME) shareOrder(ctx context.Context, cr *cmapi.CertificateRequest, order *cmacme.Order) (*cmacme.Order, error) {
+	if isSharedWith(order, cr) {
+		return order, nil
+	}
+
+	order = order.DeepCopy()
+	order.OwnerReferences = append(order.OwnerReferences, metav1.OwnerReference{
+		APIVersion: cmapi.SchemeGroupVersion.String(),
+		Kind:       cmapi.CertificateRequestKind,
+		Name:       cr.Name,
+		UID:        cr.UID,
+	})
+	order, err := a.acmeClientV.Orders(order.Namespace).Update(ctx, order, metav1.UpdateOptions{FieldManager: a.fieldManager})
+	if err != nil {
+		return nil, err
+	}
+
+	a.reporter.Pending(cr, nil, "OrderShared",
+		fmt.Sprintf("Sharing in-flight Order resource %s/%s for the same identifiers and public key", order.Namespace, order.Name))
+
+	return order, nil
+}
+
+// isSharedWith returns true if the CertificateRequest has been added as an
+// owner of the Order, without being its controller.
+func isSharedWith(order *cmacme.Order, cr *cmapi.CertificateRequest) bool {
+	for _, ref := range order.OwnerReferences {
+		if ref.UID == cr.UID && (ref.Controller == nil || !*ref.Controller) {
+			return true
+		}
+	}
+	return false
+}
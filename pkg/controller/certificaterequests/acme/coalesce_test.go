@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func Test_findSharedOrder(t *testing.T) {
+	sk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSK, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newCR := func(name string, csrPEM []byte) (*cmapi.CertificateRequest, *cmacme.Order) {
+		cr := gen.CertificateRequest(name,
+			gen.SetCertificateRequestNamespace("default"),
+			gen.SetCertificateRequestCSR(csrPEM),
+		)
+		cr.UID = types.UID("uid-" + name)
+		csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		order, err := buildOrder(cr, csr, false, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cr, order
+	}
+	cr, expectedOrder := newCR("cr", generateCSR(t, sk, "example.com", "example.com", "foo.com"))
+	// The CSR of the same identifiers in another order is not identical.
+	_, sameOrder := newCR("same", generateCSR(t, sk, "example.com", "foo.com", "example.com"))
+	_, otherKeyOrder := newCR("other-key", generateCSR(t, otherSK, "example.com", "example.com", "foo.com"))
+	_, otherNamesOrder := newCR("other-names", generateCSR(t, sk, "example.com", "example.com"))
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withState := func(order *cmacme.Order, state cmacme.State) *cmacme.Order {
+		return gen.OrderFrom(order, gen.SetOrderState(state))
+	}
+	now := time.Now()
+	newerOrder := gen.OrderFrom(sameOrder, gen.SetOrderState(cmacme.Pending), func(order *cmacme.Order) {
+		order.CreationTimestamp = metav1.NewTime(now)
+	})
+	olderOrder := gen.OrderFrom(newerOrder, func(order *cmacme.Order) {
+		order.Name = "older"
+		order.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+	})
+	sharedOrder := gen.OrderFrom(otherNamesOrder, gen.SetOrderState(cmacme.Valid), gen.SetOrderOwnerReference(metav1.OwnerReference{
+		APIVersion: cmapi.SchemeGroupVersion.String(),
+		Kind:       cmapi.CertificateRequestKind,
+		Name:       cr.Name,
+		UID:        cr.UID,
+	}))
+
+	tests := map[string]struct {
+		orders   []*cmacme.Order
+		expected *cmacme.Order
+	}{
+		"no Orders": {},
+		"an in-flight Order for the same identifiers and public key is shared": {
+			orders:   []*cmacme.Order{withState(sameOrder, cmacme.Pending)},
+			expected: withState(sameOrder, cmacme.Pending),
+		},
+		"a completed Order is not shared": {
+			orders: []*cmacme.Order{withState(sameOrder, cmacme.Valid)},
+		},
+		"a failed Order is not shared": {
+			orders: []*cmacme.Order{withState(sameOrder, cmacme.Errored)},
+		},
+		"an Order for another public key is not shared": {
+			orders: []*cmacme.Order{withState(otherKeyOrder, cmacme.Pending)},
+		},
+		"an Order for other identifiers is not shared": {
+			orders: []*cmacme.Order{withState(otherNamesOrder, cmacme.Pending)},
+		},
+		"the oldest Order is shared": {
+			orders:   []*cmacme.Order{newerOrder, olderOrder},
+			expected: olderOrder,
+		},
+		"an Order already shared with the CertificateRequest is returned whatever its state": {
+			orders:   []*cmacme.Order{withState(sameOrder, cmacme.Pending), sharedOrder},
+			expected: sharedOrder,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lister := testlisters.NewFakeOrderLister()
+			lister.OrdersFn = func(string) cmacmelisters.OrderNamespaceLister {
+				nsLister := testlisters.NewFakeOrderNamespaceLister()
+				nsLister.ListFn = func(labels.Selector) ([]*cmacme.Order, error) {
+					return test.orders, nil
+				}
+				return nsLister
+			}
+
+			got, err := (&ACME{orderLister: lister}).findSharedOrder(cr, csr, expectedOrder)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotName, expectedName string
+			if got != nil {
+				gotName = got.Name
+			}
+			if test.expected != nil {
+				expectedName = test.expected.Name
+			}
+			if gotName != expectedName {
+				t.Errorf("expected Order %q, got %q", expectedName, gotName)
+			}
+		})
+	}
+}
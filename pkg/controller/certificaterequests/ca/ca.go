@@ -17,13 +17,16 @@ limitations under the License.
 package ca
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/x509"
 	"fmt"
+	"time"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -49,6 +52,7 @@ type CA struct {
 	secretsLister corelisters.SecretLister
 
 	reporter *crutil.Reporter
+	clock    clock.Clock
 
 	// Used for testing to get reproducible resulting certificates
 	templateGenerator templateGenerator
@@ -69,6 +73,7 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		issuerOptions:     ctx.IssuerOptions,
 		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		clock:             ctx.Clock,
 		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
 		signingFn:         pki.SignCSRTemplate,
 	}
@@ -84,7 +89,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := kube.SecretCAKeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA)
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 
@@ -129,10 +134,74 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, err
 	}
 
+	resp := &issuerpkg.IssueResponse{
+		Certificate: bundle.ChainPEM,
+		CA:          withPreviousCA(issuerObj, bundle.CAPEM, c.clock.Now()),
+	}
+
+	if crossName := issuerObj.GetSpec().CA.CrossSigningSecretName; len(crossName) > 0 {
+		crossCerts, crossKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, crossName)
+		if k8sErrors.IsNotFound(err) {
+			message := fmt.Sprintf("Referenced cross-signing secret %s/%s not found", resourceNamespace, crossName)
+
+			c.reporter.Pending(cr, err, "SecretMissing", message)
+			log.Error(err, message)
+
+			return nil, nil
+		}
+
+		if cmerrors.IsInvalidData(err) {
+			message := fmt.Sprintf("Failed to parse cross-signing CA keypair from secret %s/%s", resourceNamespace, crossName)
+
+			c.reporter.Pending(cr, err, "SecretInvalidData", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+
+		if err != nil {
+			message := fmt.Sprintf("Failed to get cross-signing key pair from secret %s/%s", resourceNamespace, crossName)
+			c.reporter.Pending(cr, err, "SecretGetError", message)
+			log.Error(err, message)
+			return nil, err
+		}
+
+		crossBundle, err := c.signingFn(crossCerts, crossKey, template)
+		if err != nil {
+			message := "Error cross-signing certificate"
+			c.reporter.Failed(cr, err, "SigningError", message)
+			log.Error(err, message)
+			return nil, err
+		}
+
+		resp.CrossSignedCertificate = crossBundle.ChainPEM
+	}
+
 	log.V(logf.DebugLevel).Info("certificate issued")
 
-	return &issuerpkg.IssueResponse{
-		Certificate: bundle.ChainPEM,
-		CA:          bundle.CAPEM,
-	}, nil
+	return resp, nil
+}
+
+// withPreviousCA appends the CA certificate the issuer used before its
+// signing keypair was last rotated to caPEM, until the rotation overlap
+// configured on the issuer has passed.
+func withPreviousCA(issuerObj cmapi.GenericIssuer, caPEM []byte, now time.Time) []byte {
+	overlap := issuerObj.GetSpec().CA.RotationOverlap
+	status := issuerObj.GetStatus().CA
+	if overlap == nil || status == nil || len(status.Certificate) == 0 {
+		return caPEM
+	}
+
+	previous := status.PreviousCertificate
+	if !bytes.Equal(status.Certificate, caPEM) {
+		// The issuer has not yet observed that its signing keypair has been
+		// rotated, so the CA certificate in its status is the previous one.
+		previous = status.Certificate
+	} else if status.LastRotationTime == nil || !now.Before(status.LastRotationTime.Add(overlap.Duration)) {
+		return caPEM
+	}
+	if len(previous) == 0 {
+		return caPEM
+	}
+
+	return append(append([]byte{}, caPEM...), previous...)
 }
@@ -38,7 +38,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	fakeclock "k8s.io/utils/clock/testing"
+	"software.sslmate.com/src/go-pkcs12"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
@@ -480,6 +482,7 @@ func TestCA_Sign(t *testing.T) {
 		givenCAIssuer    cmapi.GenericIssuer
 		givenCR          *cmapi.CertificateRequest
 		assertSignedCert func(t *testing.T, got *x509.Certificate)
+		wantNilResp      bool
 		wantErr          string
 	}{
 		"when the CertificateRequest has the duration field set, it should appear as notAfter on the signed ca": {
@@ -578,6 +581,50 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, []string{"http://www.example.com/crl/test.crl"}, gotCA.CRLDistributionPoints)
 			},
 		},
+		"when the Issuer reads its keypair from a PKCS#12 bundle, it should be used to sign the certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(pkcs12SecretDataFor(t, rootPK, rootCert, "password"))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+				PKCS12: &cmapi.CAPKCS12Keypair{
+					PasswordSecretRef: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "secret-1"},
+						Key:                  "password",
+					},
+				},
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.NoError(t, got.CheckSignatureFrom(rootCert))
+			},
+		},
+		"when the Issuer reads its keypair from a PKCS#12 bundle with the wrong password, it should not sign the certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(pkcs12SecretDataFor(t, rootPK, rootCert, "password"))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+				PKCS12: &cmapi.CAPKCS12Keypair{
+					PasswordSecretRef: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "secret-1"},
+						Key:                  "wrong",
+					},
+				},
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			wantNilResp: true,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -590,6 +637,7 @@ func TestCA_Sign(t *testing.T) {
 					IssuerAmbientCredentials:        false,
 				},
 				reporter: util.NewReporter(fixedClock, rec),
+				clock:    fixedClock,
 				secretsLister: testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
 					testlisters.SetFakeSecretNamespaceListerGet(test.givenCASecret, nil),
 				),
@@ -600,6 +648,9 @@ func TestCA_Sign(t *testing.T) {
 			gotIssueResp, gotErr := c.Sign(context.Background(), test.givenCR, test.givenCAIssuer)
 			if test.wantErr != "" {
 				require.EqualError(t, gotErr, test.wantErr)
+			} else if test.wantNilResp {
+				require.NoError(t, gotErr)
+				require.Nil(t, gotIssueResp)
 			} else {
 				require.NoError(t, gotErr)
 
@@ -613,6 +664,109 @@ func TestCA_Sign(t *testing.T) {
 	}
 }
 
+func TestCA_SignCrossSigned(t *testing.T) {
+	rootPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	rootCert, _ := generateSelfSignedCACert(t, rootPK, "root")
+
+	crossPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	crossCert, _ := generateSelfSignedCACert(t, crossPK, "cross")
+
+	testpk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	testCSR := generateCSR(t, testpk, x509.ECDSAWithSHA256)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert)))))
+	require.NoError(t, indexer.Add(gen.SecretFrom(gen.Secret("secret-cross"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, crossPK, crossCert)))))
+
+	c := &CA{
+		reporter:          util.NewReporter(fixedClock, &testpkg.FakeRecorder{}),
+		clock:             fixedClock,
+		secretsLister:     clientcorev1.NewSecretLister(indexer),
+		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
+		signingFn:         pki.SignCSRTemplate,
+	}
+	cr := gen.CertificateRequest("cr-1",
+		gen.SetCertificateRequestNamespace("default"),
+		gen.SetCertificateRequestCSR(testCSR),
+	)
+
+	resp, err := c.Sign(context.Background(), cr, gen.Issuer("issuer-1", gen.SetIssuerNamespace("default"), gen.SetIssuerCA(cmapi.CAIssuer{
+		SecretName:             "secret-1",
+		CrossSigningSecretName: "secret-cross",
+	})))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	signed, err := pki.DecodeX509CertificateBytes(resp.Certificate)
+	require.NoError(t, err)
+	crossSigned, err := pki.DecodeX509CertificateBytes(resp.CrossSignedCertificate)
+	require.NoError(t, err)
+
+	assert.Equal(t, signed.Subject, crossSigned.Subject)
+	assert.Equal(t, signed.PublicKey, crossSigned.PublicKey)
+	assert.Equal(t, "cross", crossSigned.Issuer.CommonName)
+
+	// A missing cross-signing secret leaves the request pending.
+	resp, err = c.Sign(context.Background(), cr, gen.Issuer("issuer-1", gen.SetIssuerNamespace("default"), gen.SetIssuerCA(cmapi.CAIssuer{
+		SecretName:             "secret-1",
+		CrossSigningSecretName: "secret-missing",
+	})))
+	require.NoError(t, err)
+	require.Nil(t, resp)
+}
+
+func TestWithPreviousCA(t *testing.T) {
+	current := []byte("current\n")
+	previous := []byte("previous\n")
+	rotatedAt := metav1.NewTime(fixedClockStart.Add(-time.Hour))
+	overlap := &metav1.Duration{Duration: time.Hour * 2}
+
+	tests := map[string]struct {
+		overlap  *metav1.Duration
+		status   *cmapi.CAIssuerStatus
+		expected []byte
+	}{
+		"without a rotation overlap, only the current CA is returned": {
+			status:   &cmapi.CAIssuerStatus{Certificate: current, PreviousCertificate: previous, LastRotationTime: &rotatedAt},
+			expected: current,
+		},
+		"without a CA status, only the current CA is returned": {
+			overlap:  overlap,
+			expected: current,
+		},
+		"during the rotation overlap, the previous CA is appended": {
+			overlap:  overlap,
+			status:   &cmapi.CAIssuerStatus{Certificate: current, PreviousCertificate: previous, LastRotationTime: &rotatedAt},
+			expected: []byte("current\nprevious\n"),
+		},
+		"after the rotation overlap, only the current CA is returned": {
+			overlap:  &metav1.Duration{Duration: time.Minute * 30},
+			status:   &cmapi.CAIssuerStatus{Certificate: current, PreviousCertificate: previous, LastRotationTime: &rotatedAt},
+			expected: current,
+		},
+		"if the issuer has not observed the rotation yet, the CA in its status is appended": {
+			overlap:  overlap,
+			status:   &cmapi.CAIssuerStatus{Certificate: previous},
+			expected: []byte("current\nprevious\n"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName:      "secret-1",
+				RotationOverlap: test.overlap,
+			}))
+			issuer.Status.CA = test.status
+
+			assert.Equal(t, test.expected, withPreviousCA(issuer, current, fixedClockStart))
+		})
+	}
+}
+
 // Returns a map that is meant to be used for creating a certificate Secret
 // that contains the fields "tls.crt" and "tls.key".
 func secretDataFor(t *testing.T, caKey *ecdsa.PrivateKey, caCrt *x509.Certificate) (secretData map[string][]byte) {
@@ -630,3 +784,18 @@ func secretDataFor(t *testing.T, caKey *ecdsa.PrivateKey, caCrt *x509.Certificat
 		"tls.crt": caCrtPEM,
 	}
 }
+
+// Returns a map that is meant to be used for creating a certificate Secret
+// that contains a PKCS#12 bundle in the field "keystore.p12", encrypted with
+// the given password, which is stored in the field "password". The field
+// "wrong" holds an incorrect password.
+func pkcs12SecretDataFor(t *testing.T, caKey *ecdsa.PrivateKey, caCrt *x509.Certificate, password string) (secretData map[string][]byte) {
+	bundle, err := pkcs12.Encode(rand.Reader, caKey, caCrt, nil, password)
+	require.NoError(t, err)
+
+	return map[string][]byte{
+		"keystore.p12": bundle,
+		"password":     []byte(password),
+		"wrong":        []byte("wrong-password"),
+	}
+}
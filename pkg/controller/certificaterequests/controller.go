@@ -67,6 +67,10 @@ type Controller struct {
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
 
+	// issuerClass is the class of the issuers whose requests are processed
+	// by this controller.
+	issuerClass string
+
 	certificateRequestLister cmlisters.CertificateRequestLister
 
 	// we need to wait for Secrets to be synced to avoid a situation where CA issuer's Secret
@@ -184,6 +188,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.reporter = util.NewReporter(c.clock, c.recorder)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.issuerClass = ctx.IssuerClass
 
 	// Construct the issuer implementation with the built component context.
 	c.issuer = c.issuerConstructor(ctx)
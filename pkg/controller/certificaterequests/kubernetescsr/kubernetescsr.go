@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetescsr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	CRControllerName = "certificaterequests-issuer-kubernetescsr"
+
+	reasonCSRCreated = "CertificateSigningRequestCreated"
+	reasonCSRPending = "CertificateSigningRequestPending"
+
+	// minimumDuration is the shortest certificate duration which the
+	// Kubernetes CertificateSigningRequest API accepts as expirationSeconds.
+	minimumDuration = 10 * time.Minute
+)
+
+// KubernetesCSR signs CertificateRequests by creating a Kubernetes
+// CertificateSigningRequest for the signerName configured on the issuer, and
+// waiting for the signer to issue the certificate.
+type KubernetesCSR struct {
+	kubeClient    kubernetes.Interface
+	csrLister     certificateslisters.CertificateSigningRequestLister
+	issuerOptions controllerpkg.IssuerOptions
+
+	reporter *crutil.Reporter
+}
+
+func init() {
+	// create certificate request controller for the kubernetes csr issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(
+				apiutil.IssuerKubernetesCSR,
+				NewKubernetesCSR,
+
+				// Re-sync the CertificateRequest which a Kubernetes
+				// CertificateSigningRequest was created for whenever it is
+				// approved, denied or signed.
+				func(ctx *controllerpkg.Context, log logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
+					csrInformer := ctx.KubeSharedInformerFactory.Certificates().V1().CertificateSigningRequests().Informer()
+					csrInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+						WorkFunc: handleCertificateSigningRequestWorkFunc(log, queue),
+					})
+					return []cache.InformerSynced{csrInformer.HasSynced}, nil
+				},
+			)).
+			Complete()
+	})
+}
+
+func NewKubernetesCSR(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	return &KubernetesCSR{
+		kubeClient:    ctx.Client,
+		csrLister:     ctx.KubeSharedInformerFactory.Certificates().V1().CertificateSigningRequests().Lister(),
+		issuerOptions: ctx.IssuerOptions,
+		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
+	}
+}
+
+// Sign creates a Kubernetes CertificateSigningRequest for the
+// CertificateRequest if one does not exist yet, and returns the certificate
+// once it has been signed. The CertificateRequest is synced again whenever its
+// CertificateSigningRequest changes. cert-manager never approves the
+// CertificateSigningRequest, and deletes it once it has been completed.
+func (k *KubernetesCSR) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	spec := issuerObj.GetSpec().KubernetesCSR
+
+	if !k.issuerOptions.KubernetesCSRSignerAllowed(spec.SignerName) {
+		message := fmt.Sprintf("Signer %q is not allowed by the cert-manager controller", spec.SignerName)
+		k.reporter.Failed(cr, fmt.Errorf("signer %q is not in the allowed signer names", spec.SignerName), "SignerNotAllowed", message)
+		log.Error(nil, message)
+		return nil, nil
+	}
+
+	if cr.Spec.Duration != nil && cr.Spec.Duration.Duration < minimumDuration {
+		message := fmt.Sprintf("Requested duration %s is shorter than the minimum of %s supported by CertificateSigningRequests", cr.Spec.Duration.Duration, minimumDuration)
+		k.reporter.Failed(cr, fmt.Errorf("duration %s is too short", cr.Spec.Duration.Duration), "DurationTooShort", message)
+		log.Error(nil, message)
+		return nil, nil
+	}
+
+	name := certificateSigningRequestName(cr)
+	csr, err := k.csrLister.Get(name)
+	if k8sErrors.IsNotFound(err) {
+		csr = buildCertificateSigningRequest(cr, name, spec.SignerName)
+		if _, err := k.kubeClient.CertificatesV1().CertificateSigningRequests().Create(ctx, csr, metav1.CreateOptions{}); err != nil {
+			message := "Failed to create CertificateSigningRequest"
+			k.reporter.Pending(cr, err, "CertificateSigningRequestError", message)
+			log.Error(err, message)
+			return nil, err
+		}
+
+		message := fmt.Sprintf("Created CertificateSigningRequest %q for signer %q", name, spec.SignerName)
+		k.reporter.Pending(cr, nil, reasonCSRCreated, message)
+		log.V(logf.InfoLevel).Info(message)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if csr.Annotations[cmapi.CertificateRequestSourceAnnotationKey] != sourceAnnotationValue(cr) {
+		message := fmt.Sprintf("CertificateSigningRequest %q was not created for this CertificateRequest", name)
+		k.reporter.Failed(cr, fmt.Errorf("CertificateSigningRequest %q has unexpected %q annotation", name, cmapi.CertificateRequestSourceAnnotationKey), "CertificateSigningRequestConflict", message)
+		log.Error(nil, message)
+		return nil, nil
+	}
+
+	for _, cond := range csr.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		if cond.Type == certificatesv1.CertificateDenied || cond.Type == certificatesv1.CertificateFailed {
+			message := fmt.Sprintf("CertificateSigningRequest %q is %s", name, cond.Type)
+			k.reporter.Failed(cr, fmt.Errorf("%s: %s", cond.Reason, cond.Message), "CertificateSigningRequest"+string(cond.Type), message)
+			log.V(logf.InfoLevel).Info(message)
+			k.deleteCertificateSigningRequest(ctx, log, name)
+			return nil, nil
+		}
+	}
+
+	if len(csr.Status.Certificate) > 0 {
+		log.V(logf.InfoLevel).Info("certificate issued")
+		k.deleteCertificateSigningRequest(ctx, log, name)
+		return &issuer.IssueResponse{
+			Certificate: csr.Status.Certificate,
+		}, nil
+	}
+
+	k.reporter.Pending(cr, nil, reasonCSRPending, fmt.Sprintf("Waiting for CertificateSigningRequest %q to be signed by %q", name, spec.SignerName))
+	return nil, nil
+}
+
+// certificateSigningRequestName returns the name of the cluster scoped
+// CertificateSigningRequest for the CertificateRequest. The UID is used so
+// that a re-created CertificateRequest of the same name is not mistaken for
+// the one the existing CertificateSigningRequest was created for.
+func certificateSigningRequestName(cr *cmapi.CertificateRequest) string {
+	return "cert-manager-" + string(cr.UID)
+}
+
+func sourceAnnotationValue(cr *cmapi.CertificateRequest) string {
+	return cr.Namespace + "/" + cr.Name
+}
+
+func buildCertificateSigningRequest(cr *cmapi.CertificateRequest, name, signerName string) *certificatesv1.CertificateSigningRequest {
+	usages := cr.Spec.Usages
+	if len(usages) == 0 {
+		usages = cmapi.DefaultKeyUsages()
+	}
+	var kubeUsages []certificatesv1.KeyUsage
+	for _, u := range usages {
+		kubeUsages = append(kubeUsages, certificatesv1.KeyUsage(u))
+	}
+	if cr.Spec.IsCA {
+		kubeUsages = append(kubeUsages, certificatesv1.UsageCertSign)
+	}
+
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				cmapi.CertificateRequestSourceAnnotationKey: sourceAnnotationValue(cr),
+			},
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    cr.Spec.Request,
+			SignerName: signerName,
+			Usages:     kubeUsages,
+		},
+	}
+	if cr.Spec.Duration != nil {
+		seconds := int32(cr.Spec.Duration.Seconds())
+		csr.Spec.ExpirationSeconds = &seconds
+	}
+
+	return csr
+}
+
+// deleteCertificateSigningRequest deletes a completed
+// CertificateSigningRequest so that signed requests do not accumulate in the
+// cluster. Failures are only logged since the CertificateRequest has already
+// been completed.
+func (k *KubernetesCSR) deleteCertificateSigningRequest(ctx context.Context, log logr.Logger, name string) {
+	err := k.kubeClient.CertificatesV1().CertificateSigningRequests().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		log.Error(err, "failed to delete completed CertificateSigningRequest")
+	}
+}
+
+// handleCertificateSigningRequestWorkFunc returns a function which enqueues
+// the CertificateRequest referenced by the source annotation of a
+// CertificateSigningRequest.
+func handleCertificateSigningRequestWorkFunc(log logr.Logger, queue workqueue.RateLimitingInterface) func(obj interface{}) {
+	return func(obj interface{}) {
+		csr, ok := obj.(*certificatesv1.CertificateSigningRequest)
+		if !ok {
+			log.Error(nil, "object is not a CertificateSigningRequest", "object", obj)
+			return
+		}
+		if key, ok := csr.Annotations[cmapi.CertificateRequestSourceAnnotationKey]; ok {
+			queue.Add(key)
+		}
+	}
+}
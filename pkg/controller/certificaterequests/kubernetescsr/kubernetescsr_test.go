@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetescsr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSign(t *testing.T) {
+	csrResource := certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests")
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR([]byte("csr")),
+		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+		func(cr *cmapi.CertificateRequest) { cr.UID = "abc" },
+	)
+	baseIssuer := gen.Issuer("test-issuer", gen.SetIssuerKubernetesCSR(cmapi.KubernetesCSRIssuer{
+		SignerName: "example.com/signer",
+	}))
+	baseCSR := gen.CertificateSigningRequest("cert-manager-abc",
+		gen.SetCertificateSigningRequestRequest([]byte("csr")),
+		gen.SetCertificateSigningRequestSignerName("example.com/signer"),
+		gen.SetCertificateSigningRequestExpirationSeconds(3600),
+		gen.SetCertificateSigningRequestUsages([]certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment}),
+		gen.AddCertificateSigningRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestSourceAnnotationKey: gen.DefaultTestNamespace + "/test-cr",
+		}),
+		func(csr *certificatesv1.CertificateSigningRequest) { csr.Labels = nil },
+	)
+	approvedCondition := certificatesv1.CertificateSigningRequestCondition{
+		Type:   certificatesv1.CertificateApproved,
+		Status: corev1.ConditionTrue,
+		Reason: "Policy",
+	}
+
+	tests := map[string]struct {
+		issuer          cmapi.GenericIssuer
+		cr              *cmapi.CertificateRequest
+		csrs            []runtime.Object
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+		expectedReason  string
+		expectedResp    *issuer.IssueResponse
+	}{
+		"create a CertificateSigningRequest if one does not exist": {
+			issuer: baseIssuer,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(csrResource, "", baseCSR)),
+			},
+			expectedEvents: []string{`Normal CertificateSigningRequestCreated Created CertificateSigningRequest "cert-manager-abc" for signer "example.com/signer"`},
+			expectedReason: cmapi.CertificateRequestReasonPending,
+		},
+		"fail if the signer is not allowed": {
+			issuer: gen.Issuer("test-issuer", gen.SetIssuerKubernetesCSR(cmapi.KubernetesCSRIssuer{
+				SignerName: "kubernetes.io/kube-apiserver-client",
+			})),
+			expectedEvents: []string{`Warning SignerNotAllowed Signer "kubernetes.io/kube-apiserver-client" is not allowed by the cert-manager controller: signer "kubernetes.io/kube-apiserver-client" is not in the allowed signer names`},
+			expectedReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"fail if the requested duration is shorter than the CertificateSigningRequest minimum": {
+			issuer:         baseIssuer,
+			cr:             gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Minute})),
+			expectedEvents: []string{`Warning DurationTooShort Requested duration 1m0s is shorter than the minimum of 10m0s supported by CertificateSigningRequests: duration 1m0s is too short`},
+			expectedReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"wait for an unapproved CertificateSigningRequest without approving it": {
+			issuer:         baseIssuer,
+			csrs:           []runtime.Object{baseCSR},
+			expectedEvents: []string{`Normal CertificateSigningRequestPending Waiting for CertificateSigningRequest "cert-manager-abc" to be signed by "example.com/signer"`},
+			expectedReason: cmapi.CertificateRequestReasonPending,
+		},
+		"fail if the CertificateSigningRequest has been denied": {
+			issuer: baseIssuer,
+			csrs: []runtime.Object{gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+					Type:    certificatesv1.CertificateDenied,
+					Status:  corev1.ConditionTrue,
+					Reason:  "Policy",
+					Message: "not allowed",
+				}),
+			)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(csrResource, "", "cert-manager-abc")),
+			},
+			expectedEvents: []string{`Warning CertificateSigningRequestDenied CertificateSigningRequest "cert-manager-abc" is Denied: Policy: not allowed`},
+			expectedReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"fail if the CertificateSigningRequest was created for another CertificateRequest": {
+			issuer: baseIssuer,
+			csrs: []runtime.Object{gen.CertificateSigningRequestFrom(baseCSR,
+				gen.AddCertificateSigningRequestAnnotations(map[string]string{
+					cmapi.CertificateRequestSourceAnnotationKey: "other/test-cr",
+				}),
+			)},
+			expectedEvents: []string{`Warning CertificateSigningRequestConflict CertificateSigningRequest "cert-manager-abc" was not created for this CertificateRequest: CertificateSigningRequest "cert-manager-abc" has unexpected "cert-manager.io/certificaterequest" annotation`},
+			expectedReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"return the certificate and delete the CertificateSigningRequest once it has been signed": {
+			issuer: baseIssuer,
+			csrs: []runtime.Object{gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestStatusCondition(approvedCondition),
+				gen.SetCertificateSigningRequestCertificate([]byte("cert")),
+			)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(csrResource, "", "cert-manager-abc")),
+			},
+			expectedResp: &issuer.IssueResponse{Certificate: []byte("cert")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:               t,
+				KubeObjects:     test.csrs,
+				ExpectedActions: test.expectedActions,
+				ExpectedEvents:  test.expectedEvents,
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.KubernetesCSRAllowedSignerNames = []string{"example.com/signer"}
+			k := NewKubernetesCSR(builder.Context)
+			builder.Start()
+			defer builder.Stop()
+
+			cr := baseCR.DeepCopy()
+			if test.cr != nil {
+				cr = test.cr.DeepCopy()
+			}
+			resp, err := k.Sign(context.Background(), cr, test.issuer)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResp, resp)
+			if test.expectedReason != "" {
+				assert.True(t, apiutil.CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionReady,
+					Status: cmmeta.ConditionFalse,
+					Reason: test.expectedReason,
+				}))
+			}
+			builder.CheckAndFinish(err)
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
@@ -119,7 +120,14 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		return nil, nil
 	}
 
-	privatekey, err := kube.SecretTLSKey(ctx, s.secretsLister, cr.Namespace, secretName)
+	// CertificateRequests for a Certificate's additional private key are
+	// signed using the additional key stored alongside the private key.
+	keyName := corev1.TLSPrivateKeyKey
+	if cr.Annotations[cmapi.CertificateRequestAdditionalPrivateKeyAnnotationKey] == "true" {
+		keyName = cmmeta.AdditionalTLSPrivateKeyKey
+	}
+
+	privatekey, err := kube.SecretTLSKeyRef(ctx, s.secretsLister, cr.Namespace, secretName, keyName)
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", cr.Namespace, secretName)
 
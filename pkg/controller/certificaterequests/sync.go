@@ -33,6 +33,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -93,6 +94,13 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	}
 
 	log = logf.WithRelatedResource(log, issuerObj)
+
+	// The issuer belongs to another cert-manager instance, ignore
+	if !controllerpkg.IsClaimed(issuerObj, c.issuerClass) {
+		dbg.Info("issuer class does not match this controller, ignoring", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	dbg.Info("ensuring issuer type matches this controller")
 
 	issuerType, err := apiutil.NameForIssuer(issuerObj)
@@ -146,6 +154,7 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	// Update to status with the new given response.
 	crCopy.Status.Certificate = resp.Certificate
 	crCopy.Status.CA = resp.CA
+	crCopy.Status.CrossSignedCertificate = resp.CrossSignedCertificate
 
 	// invalid cert
 	_, err = pki.DecodeX509CertificateBytes(crCopy.Status.Certificate)
@@ -524,6 +524,18 @@ func TestSync(t *testing.T) {
 				ExpectedEvents:  []string{},
 			},
 		},
+		"exit nil and no action if the issuer has a different class (it belongs to another instance)": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR,
+					gen.IssuerFrom(baseIssuer, func(iss cmapi.GenericIssuer) {
+						iss.SetAnnotations(map[string]string{cmapi.IssuerClassAnnotationKey: "tenant-a"})
+					}),
+				},
+				ExpectedActions: []testpkg.Action{},
+				ExpectedEvents:  []string{},
+			},
+		},
 		"if the Certificate is already set in the status then return nil and no-op, regardless of condition": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
 				gen.SetCertificateRequestCertificate([]byte("a cert")),
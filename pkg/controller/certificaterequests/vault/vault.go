@@ -18,8 +18,13 @@ package vault
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	vaultinternal "github.com/cert-manager/cert-manager/internal/vault"
@@ -30,6 +35,7 @@ import (
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 const (
@@ -43,6 +49,11 @@ type Vault struct {
 	issuerOptions controllerpkg.IssuerOptions
 	secretsLister corelisters.SecretLister
 	reporter      *crutil.Reporter
+	kubeClient    kubernetes.Interface
+
+	// ownedBy identifies this cert-manager instance on the Secrets it
+	// creates to hold private keys generated by Vault.
+	ownedBy string
 
 	vaultClientBuilder vaultinternal.ClientBuilder
 }
@@ -62,6 +73,8 @@ func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		issuerOptions:      ctx.IssuerOptions,
 		secretsLister:      ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		kubeClient:         ctx.Client,
+		ownedBy:            ctx.OwnedBy,
 		vaultClientBuilder: vaultinternal.New,
 	}
 }
@@ -92,6 +105,11 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	}
 
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
+
+	if issuerObj.GetSpec().Vault.Mode == v1.VaultIssuerModeIssue {
+		return v.issue(ctx, client, cr, certDuration)
+	}
+
 	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration)
 	if err != nil {
 		message := "Vault failed to sign certificate"
@@ -109,3 +127,99 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 		CA:          caPem,
 	}, nil
 }
+
+// issue has Vault generate the private key of the certificate along with the
+// certificate itself. The name of the Secret in which the private key will be
+// stored is first recorded on the CertificateRequest, so that the certificate
+// is only issued once that name has been persisted.
+func (v *Vault) issue(ctx context.Context, client vaultinternal.Interface, cr *v1.CertificateRequest, duration time.Duration) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "issue")
+
+	keySecretName, ok := cr.Annotations[v1.CertificateRequestIssuerPrivateKeyAnnotationKey]
+	if !ok {
+		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, v1.CertificateRequestIssuerPrivateKeyAnnotationKey, privateKeySecretName(cr))
+		v.reporter.Pending(cr, nil, "IssuancePending", "Vault certificate is requested")
+		return nil, nil
+	}
+
+	certPem, caPem, keyPem, err := client.Issue(cr.Spec.Request, duration)
+	if err != nil {
+		message := "Vault failed to issue certificate"
+
+		v.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if err := v.storePrivateKey(ctx, cr, keySecretName, keyPem, certPem); err != nil {
+		message := "Failed to store the private key generated by vault"
+		v.reporter.Pending(cr, err, "PrivateKeyError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return &issuer.IssueResponse{
+		Certificate: certPem,
+		CA:          caPem,
+	}, nil
+}
+
+// privateKeySecretName returns the name of the Secret in which the private key
+// generated by Vault for the given CertificateRequest is stored.
+func privateKeySecretName(cr *v1.CertificateRequest) string {
+	return cr.Name + "-vault-key"
+}
+
+// storePrivateKey stores the private key generated by Vault in the named
+// Secret, which is owned by the CertificateRequest so that it is deleted along
+// with it. The private key must match the issued certificate. Errors never
+// include the private key itself.
+func (v *Vault) storePrivateKey(ctx context.Context, cr *v1.CertificateRequest, name string, keyPem, certPem []byte) error {
+	pk, err := pki.DecodePrivateKeyBytes(keyPem)
+	if err != nil {
+		return errors.New("the private key returned by vault could not be decoded")
+	}
+	cert, err := pki.DecodeX509CertificateBytes(certPem)
+	if err != nil {
+		return err
+	}
+	matches, err := pki.PublicKeyMatchesCertificate(pk.Public(), cert)
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return errors.New("the private key returned by vault does not match the issued certificate")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cr.Namespace,
+			Labels:          controllerpkg.AddOwnedByLabels(nil, v.ownedBy, cr),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cr, v1.SchemeGroupVersion.WithKind(v1.CertificateRequestKind))},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: keyPem,
+		},
+	}
+
+	_, err = v.kubeClient.CoreV1().Secrets(cr.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if k8sErrors.IsAlreadyExists(err) {
+		// The key was stored by a previous sync which then failed to update
+		// the CertificateRequest, so replace it with the key issued now.
+		existing, err := v.kubeClient.CoreV1().Secrets(cr.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existing = existing.DeepCopy()
+		existing.Data = secret.Data
+		_, err = v.kubeClient.CoreV1().Secrets(cr.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+
+	return err
+}
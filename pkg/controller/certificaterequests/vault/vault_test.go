@@ -169,6 +169,35 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	issueIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerVault(cmapi.VaultIssuer{
+			Auth: cmapi.VaultAuth{
+				TokenSecretRef: &cmmeta.SecretKeySelector{
+					Key: "my-token-key",
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "token-secret",
+					},
+				},
+			},
+			Mode: cmapi.VaultIssuerModeIssue,
+		}),
+	)
+
+	issueAnnotations := map[string]string{
+		cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey: "test-cr-vault-key",
+	}
+	issueCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestAnnotations(issueAnnotations),
+	)
+
+	rsaPEMKey := pki.EncodePKCS1PrivateKey(rsaSK)
+
+	otherSK, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPEMKey := pki.EncodePKCS1PrivateKey(otherSK)
+
 	tests := map[string]testT{
 		"a CertificateRequest without an approved condition should do nothing": {
 			certificateRequest: baseCRNotApproved.DeepCopy(),
@@ -498,6 +527,105 @@ func TestSign(t *testing.T) {
 			},
 			fakeVault: fakevault.New().WithSign(rsaPEMCert, rsaPEMCert, nil),
 		},
+		"an issuer in issue mode should first record the name of the private key Secret": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), issueIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Vault certificate is requested",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(issueCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Vault certificate is requested",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeVault: fakevault.New().WithIssue(rsaPEMCert, rsaPEMCert, rsaPEMKey, nil),
+		},
+		"an issuer in issue mode should store the private key generated by vault and return the certificate": {
+			certificateRequest: issueCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{issueCR.DeepCopy(), issueIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:            "test-cr-vault-key",
+								Namespace:       gen.DefaultTestNamespace,
+								OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(issueCR, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))},
+							},
+							Type: corev1.SecretTypeOpaque,
+							Data: map[string][]byte{
+								corev1.TLSPrivateKeyKey: rsaPEMKey,
+							},
+						},
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(issueCR,
+							gen.SetCertificateRequestCertificate(rsaPEMCert),
+							gen.SetCertificateRequestCA(rsaPEMCert),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeVault: fakevault.New().WithIssue(rsaPEMCert, rsaPEMCert, rsaPEMKey, nil),
+		},
+		"an issuer in issue mode should not store a private key which does not match the certificate": {
+			certificateRequest: issueCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{issueCR.DeepCopy(), issueIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal PrivateKeyError Failed to store the private key generated by vault: the private key returned by vault does not match the issued certificate",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(issueCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Failed to store the private key generated by vault: the private key returned by vault does not match the issued certificate",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeVault:   fakevault.New().WithIssue(rsaPEMCert, rsaPEMCert, otherPEMKey, nil),
+			expectedErr: true,
+		},
 	}
 
 	for name, test := range tests {
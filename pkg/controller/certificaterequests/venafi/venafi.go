@@ -19,10 +19,13 @@ package venafi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
@@ -50,6 +53,11 @@ type Venafi struct {
 	secretsLister corelisters.SecretLister
 	reporter      *crutil.Reporter
 	cmClient      clientset.Interface
+	kubeClient    kubernetes.Interface
+
+	// ownedBy identifies this cert-manager instance on the Secrets it
+	// creates to hold private keys generated by Venafi.
+	ownedBy string
 
 	clientBuilder venaficlient.VenafiClientBuilder
 
@@ -73,6 +81,8 @@ func NewVenafi(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		clientBuilder: venaficlient.New,
 		metrics:       ctx.Metrics,
 		cmClient:      ctx.CMClient,
+		kubeClient:    ctx.Client,
+		ownedBy:       ctx.OwnedBy,
 	}
 }
 
@@ -117,7 +127,12 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 
 	// check if the pickup ID annotation is there, if not set it up.
 	if pickupID == "" {
-		pickupID, err = client.RequestCertificate(cr.Spec.Request, duration, customFields)
+		serviceGenerated := issuerObj.GetSpec().Venafi.TPP != nil && issuerObj.GetSpec().Venafi.TPP.ServiceGeneratedCSR
+		if serviceGenerated {
+			pickupID, err = client.RequestServiceGeneratedCertificate(cr.Spec.Request, duration, customFields)
+		} else {
+			pickupID, err = client.RequestCertificate(cr.Spec.Request, duration, customFields)
+		}
 		// Check some known error types
 		if err != nil {
 			switch err.(type) {
@@ -141,11 +156,22 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		v.reporter.Pending(cr, err, "IssuancePending", "Venafi certificate is requested")
 
 		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, pickupID)
+		if serviceGenerated {
+			// Record where the private key generated by Venafi will be
+			// stored, so that it is used in place of the key of the CSR.
+			metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey, privateKeySecretName(cr))
+		}
 
 		return nil, nil
 	}
 
-	certPem, err := client.RetrieveCertificate(pickupID, cr.Spec.Request, duration, customFields)
+	var certPem, keyPem []byte
+	keySecretName, serviceGenerated := cr.ObjectMeta.Annotations[cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey]
+	if serviceGenerated {
+		certPem, keyPem, err = client.RetrieveServiceGeneratedCertificate(pickupID, cr.Spec.Request, duration, customFields)
+	} else {
+		certPem, err = client.RetrieveCertificate(pickupID, cr.Spec.Request, duration, customFields)
+	}
 	if err != nil {
 		switch err.(type) {
 		case endpoint.ErrCertificatePending, endpoint.ErrRetrieveCertificateTimeout:
@@ -175,8 +201,73 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		return nil, err
 	}
 
+	if serviceGenerated {
+		if err := v.storePrivateKey(ctx, cr, keySecretName, keyPem, bundle.ChainPEM); err != nil {
+			message := "Failed to store the private key generated by venafi"
+			v.reporter.Pending(cr, err, "PrivateKeyError", message)
+			log.Error(err, message)
+			return nil, err
+		}
+	}
+
 	return &issuerpkg.IssueResponse{
 		Certificate: bundle.ChainPEM,
 		CA:          bundle.CAPEM,
 	}, nil
 }
+
+// privateKeySecretName returns the name of the Secret in which the private key
+// generated by Venafi for the given CertificateRequest is stored.
+func privateKeySecretName(cr *cmapi.CertificateRequest) string {
+	return cr.Name + "-venafi-key"
+}
+
+// storePrivateKey stores the private key generated by Venafi in the named
+// Secret, which is owned by the CertificateRequest so that it is deleted
+// along with it. The private key must match the issued certificate.
+func (v *Venafi) storePrivateKey(ctx context.Context, cr *cmapi.CertificateRequest, name string, keyPem, certPem []byte) error {
+	pk, err := utilpki.DecodePrivateKeyBytes(keyPem)
+	if err != nil {
+		return err
+	}
+	cert, err := utilpki.DecodeX509CertificateBytes(certPem)
+	if err != nil {
+		return err
+	}
+	matches, err := utilpki.PublicKeyMatchesCertificate(pk.Public(), cert)
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return errors.New("the private key returned by venafi does not match the issued certificate")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cr.Namespace,
+			Labels:          controllerpkg.AddOwnedByLabels(nil, v.ownedBy, cr),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cr, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: keyPem,
+		},
+	}
+
+	_, err = v.kubeClient.CoreV1().Secrets(cr.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if k8sErrors.IsAlreadyExists(err) {
+		// The key was stored by a previous sync which then failed to update
+		// the CertificateRequest, so replace it with the key retrieved now.
+		existing, err := v.kubeClient.CoreV1().Secrets(cr.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existing = existing.DeepCopy()
+		existing.Data = secret.Data
+		_, err = v.kubeClient.CoreV1().Secrets(cr.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+
+	return err
+}
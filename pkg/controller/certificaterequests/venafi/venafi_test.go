@@ -155,6 +155,17 @@ func TestSign(t *testing.T) {
 		}),
 	)
 
+	tppServiceGeneratedIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			TPP: &cmapi.VenafiTPP{
+				CredentialsRef: cmmeta.LocalObjectReference{
+					Name: tppSecret.Name,
+				},
+				ServiceGeneratedCSR: true,
+			},
+		}),
+	)
+
 	cloudIssuer := gen.IssuerFrom(baseIssuer,
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
 			Cloud: &cmapi.VenafiCloud{
@@ -197,6 +208,14 @@ func TestSign(t *testing.T) {
 		}),
 	)
 
+	tppServiceGeneratedCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Group: certmanager.GroupName,
+			Name:  tppServiceGeneratedIssuer.Name,
+			Kind:  tppServiceGeneratedIssuer.Kind,
+		}),
+	)
+
 	tppCRWithCustomFields := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/custom-fields": `[{"name": "cert-manager-test", "value": "test ok"}]`}))
 
 	tppCRWithInvalidCustomFields := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/custom-fields": `[{"name": cert-manager-test}]`}))
@@ -256,6 +275,43 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	testPKPEM, err := pki.EncodeECPrivateKey(testPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientReturnsServiceGeneratedCert := &internalvenafifake.Venafi{
+		RequestServiceGeneratedCertificateFn: func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
+			return "test", nil
+		},
+		RetrieveServiceGeneratedCertificateFn: func(string, []byte, time.Duration, []api.CustomField) ([]byte, []byte, error) {
+			return append(certPEM, rootPEM...), testPKPEM, nil
+		},
+	}
+
+	otherPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPKPEM, err := pki.EncodeECPrivateKey(otherPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientReturnsMismatchedServiceGeneratedKey := &internalvenafifake.Venafi{
+		RequestServiceGeneratedCertificateFn: func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
+			return "test", nil
+		},
+		RetrieveServiceGeneratedCertificateFn: func(string, []byte, time.Duration, []api.CustomField) ([]byte, []byte, error) {
+			return append(certPEM, rootPEM...), otherPKPEM, nil
+		},
+	}
+
+	serviceGeneratedAnnotations := map[string]string{
+		cmapi.VenafiPickupIDAnnotationKey:                     "test",
+		cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey: "test-cr-venafi-key",
+	}
+
 	clientReturnsCertIfCustomField := &internalvenafifake.Venafi{
 		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, fields []api.CustomField) (string, error) {
 			if len(fields) > 0 && fields[0].Name == "cert-manager-test" && fields[0].Value == "test ok" {
@@ -641,6 +697,114 @@ func TestSign(t *testing.T) {
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsCert,
 		},
+		"tpp: if the issuer uses service generated CSRs then store the private key returned by venafi": {
+			certificateRequest: tppServiceGeneratedCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppServiceGeneratedCR.DeepCopy(), tppServiceGeneratedIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested",
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppServiceGeneratedCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(serviceGeneratedAnnotations),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:            "test-cr-venafi-key",
+								Namespace:       gen.DefaultTestNamespace,
+								OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(tppServiceGeneratedCR, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))},
+							},
+							Type: corev1.SecretTypeOpaque,
+							Data: map[string][]byte{
+								corev1.TLSPrivateKeyKey: testPKPEM,
+							},
+						},
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppServiceGeneratedCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(rootPEM),
+							gen.AddCertificateRequestAnnotations(serviceGeneratedAnnotations),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsServiceGeneratedCert,
+		},
+		"tpp: if the private key returned by venafi does not match the certificate then mark pending and return error": {
+			certificateRequest: tppServiceGeneratedCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppServiceGeneratedCR.DeepCopy(), tppServiceGeneratedIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested",
+					"Normal PrivateKeyError Failed to store the private key generated by venafi: the private key returned by venafi does not match the issued certificate",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppServiceGeneratedCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(serviceGeneratedAnnotations),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppServiceGeneratedCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Failed to store the private key generated by venafi: the private key returned by venafi does not match the issued certificate",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(serviceGeneratedAnnotations),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsMismatchedServiceGeneratedKey,
+			expectedErr:      true,
+		},
 		"cloud: if sign returns cert then return cert and not failed": {
 			certificateRequest: cloudCR.DeepCopy(),
 			builder: &controllertest.Builder{
@@ -852,6 +1016,13 @@ func runTest(t *testing.T, test testT) {
 		err = controller.Sync(context.Background(), test.certificateRequest)
 	}
 
+	if err == nil && test.fakeClient != nil && test.fakeClient.RetrieveServiceGeneratedCertificateFn != nil && !test.skipSecondSignCall {
+		// simulating a 2nd sync to fetch the cert and the private key generated by venafi
+		metav1.SetMetaDataAnnotation(&test.certificateRequest.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, "test")
+		metav1.SetMetaDataAnnotation(&test.certificateRequest.ObjectMeta, cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey, "test-cr-venafi-key")
+		err = controller.Sync(context.Background(), test.certificateRequest)
+	}
+
 	if err != nil && !test.expectedErr {
 		t.Errorf("expected to not get an error, but got: %v", err)
 	}
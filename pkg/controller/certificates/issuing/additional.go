@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"context"
+	"crypto"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// additionalKeyPair is called once the certificate for the Certificate's
+// private key has been issued. If the Certificate has an additional private
+// key, it returns the CertificateRequest for the next revision which has been
+// issued for that key, together with the key stored in the next private key
+// Secret.
+// additionalKeyPair returns false if the Certificate has an additional private
+// key for which a certificate has not yet been issued, in which case the
+// issuance of both certificates waits for it. If the CertificateRequest for
+// the additional private key has failed or was denied, the issuance is failed.
+func (c *controller) additionalKeyPair(ctx context.Context, crt *cmapi.Certificate, nextPrivateKeySecret *corev1.Secret, reqs []*cmapi.CertificateRequest) (*cmapi.CertificateRequest, crypto.Signer, bool, error) {
+	acrt := certificates.AdditionalPrivateKeyCertificate(crt)
+	if acrt == nil {
+		return nil, nil, true, nil
+	}
+
+	log := logf.FromContext(ctx).WithValues("additional_private_key", true)
+
+	if len(reqs) != 1 {
+		// If multiple exist, then leave to requestmanager controller to clean
+		// up.
+		log.V(logf.DebugLevel).Info("Waiting for a single CertificateRequest for the additional private key")
+		return nil, nil, false, nil
+	}
+	req := reqs[0]
+	log = logf.WithRelatedResource(log, req)
+
+	apk, _, err := utilkube.ParseTLSKeyFromSecret(nextPrivateKeySecret, cmmeta.AdditionalTLSPrivateKeyKey)
+	if err != nil {
+		log.Error(err, "failed to parse next additional private key, waiting for keymanager controller")
+		return nil, nil, false, nil
+	}
+	pkViolations, err := certificates.PrivateKeyMatchesSpec(apk, acrt.Spec)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(pkViolations) > 0 {
+		log.V(logf.DebugLevel).Info("stored next additional private key does not match requirements on Certificate resource, waiting for keymanager controller", "violations", pkViolations)
+		return nil, nil, false, nil
+	}
+
+	requestViolations, err := certificates.RequestMatchesSpec(req, acrt.Spec)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(requestViolations) > 0 {
+		log.V(logf.DebugLevel).Info("CertificateRequest does not match Certificate, waiting for requestmanager controller")
+		return nil, nil, false, nil
+	}
+
+	certIssuingCond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	crReadyCond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	if crReadyCond == nil {
+		if apiutil.CertificateRequestIsDenied(req) {
+			return nil, nil, false, c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied))
+		}
+		if apiutil.CertificateRequestHasInvalidRequest(req) {
+			return nil, nil, false, c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest))
+		}
+		log.V(logf.DebugLevel).Info("CertificateRequest does not have Ready condition, waiting...")
+		return nil, nil, false, nil
+	}
+
+	if crReadyCond.Reason == cmapi.CertificateRequestReasonFailed {
+		// A CertificateRequest which failed during a previous issuance of the
+		// same revision is left to the requestmanager controller to replace.
+		if req.Status.FailureTime != nil && certIssuingCond != nil &&
+			req.Status.FailureTime.Before(certIssuingCond.LastTransitionTime) {
+			log.V(logf.InfoLevel).Info("Found a failed CertificateRequest from previous issuance, waiting for it to be deleted...")
+			return nil, nil, false, nil
+		}
+		return nil, nil, false, c.failIssueCertificate(ctx, log, crt, crReadyCond)
+	}
+
+	if crReadyCond.Reason != cmapi.CertificateRequestReasonIssued {
+		log.V(logf.DebugLevel).Info("CertificateRequest not in final state, waiting...", "reason", crReadyCond.Reason)
+		return nil, nil, false, nil
+	}
+
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	matches, err := utilpki.PublicKeyMatchesCSR(apk.Public(), csr)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if !matches {
+		log.V(logf.InfoLevel).Info("next additional private key does not match CSR public key, waiting for requestmanager controller")
+		return nil, nil, false, nil
+	}
+
+	return req, apk, true, nil
+}
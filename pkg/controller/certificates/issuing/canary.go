@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"bytes"
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	reasonCanaryIssued   = "CanaryIssued"
+	reasonCanaryPending  = "CanaryPending"
+	reasonCanaryPromoted = "CanaryPromoted"
+)
+
+// ensureCanary is called before a newly issued certificate is stored in the
+// Certificate's Secret. If the Certificate has a canary configured and is
+// being migrated to a different issuer than the one which issued the
+// certificate currently stored in the Secret, the candidate certificate is
+// stored in the canary shadow Secret instead.
+// ensureCanary returns true once the candidate has been verified and may be
+// promoted to the Certificate's Secret, or if no canary is required.
+func (c *controller) ensureCanary(ctx context.Context, crt *cmapi.Certificate, req *cmapi.CertificateRequest, data internal.SecretData) (bool, error) {
+	if crt.Spec.Canary == nil {
+		return true, nil
+	}
+
+	log := logf.FromContext(ctx).WithValues("canary_secret", crt.Spec.Canary.SecretName)
+
+	// Only a change of issuer is issued through the shadow Secret. The initial
+	// issuance, and renewals by the same issuer, are stored directly.
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return true, nil
+	}
+	if _, _, migrating := policies.SecretIssuerAnnotationsNotUpToDate(policies.Input{Certificate: crt, Secret: secret}); !migrating {
+		return true, nil
+	}
+
+	shadow, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.Canary.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	if err == nil &&
+		shadow.Annotations[cmapi.CanaryRequestAnnotationKey] == req.Name &&
+		bytes.Equal(shadow.Data[corev1.TLSCertKey], data.Certificate) {
+		if shadow.Annotations[cmapi.CanaryVerifiedAnnotationKey] != req.Name {
+			log.V(logf.DebugLevel).Info("waiting for candidate certificate to be verified")
+			return false, nil
+		}
+
+		log.V(logf.InfoLevel).Info("candidate certificate has been verified, promoting")
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonCanaryPromoted, "Candidate certificate in Secret %q has been verified and will be promoted", crt.Spec.Canary.SecretName)
+		return true, nil
+	}
+
+	// Store the candidate in the shadow Secret, annotated with the name of the
+	// CertificateRequest so that a verification of a previous candidate is
+	// not mistaken for a verification of this one.
+	shadowCrt := crt.DeepCopy()
+	shadowCrt.Spec.SecretName = crt.Spec.Canary.SecretName
+	if shadowCrt.Spec.SecretTemplate == nil {
+		shadowCrt.Spec.SecretTemplate = &cmapi.CertificateSecretTemplate{}
+	}
+	if shadowCrt.Spec.SecretTemplate.Annotations == nil {
+		shadowCrt.Spec.SecretTemplate.Annotations = make(map[string]string)
+	}
+	shadowCrt.Spec.SecretTemplate.Annotations[cmapi.CanaryRequestAnnotationKey] = req.Name
+
+	if err := c.secretsUpdateData(ctx, shadowCrt, data); err != nil {
+		return false, err
+	}
+
+	log.V(logf.InfoLevel).Info("stored candidate certificate in shadow Secret")
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonCanaryIssued, "Candidate certificate from the new issuer stored in Secret %q, waiting for it to be verified", crt.Spec.Canary.SecretName)
+
+	return false, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestEnsureCanary(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "new-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+	)
+	canaryCrt := gen.CertificateFrom(crt, func(crt *cmapi.Certificate) {
+		crt.Spec.Canary = &cmapi.CertificateCanary{SecretName: "output-canary"}
+	})
+	req := gen.CertificateRequest("test-1", gen.SetCertificateRequestNamespace("testns"))
+	data := internal.SecretData{PrivateKey: []byte("key"), Certificate: []byte("new-cert"), CA: []byte("ca")}
+
+	secret := func(name string, annotations map[string]string, cert string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name, Annotations: annotations},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte(cert)},
+		}
+	}
+	issuedBy := func(name string) map[string]string {
+		return map[string]string{
+			cmapi.IssuerNameAnnotationKey:  name,
+			cmapi.IssuerKindAnnotationKey:  "Issuer",
+			cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+		}
+	}
+
+	tests := map[string]struct {
+		certificate    *cmapi.Certificate
+		secrets        []runtime.Object
+		expPromote     bool
+		expShadowWrite bool
+		expEvents      []string
+	}{
+		"if no canary is configured, promote": {
+			certificate: crt,
+			secrets:     []runtime.Object{secret("output", issuedBy("old-issuer"), "old-cert")},
+			expPromote:  true,
+		},
+		"if the target Secret does not exist, promote": {
+			certificate: canaryCrt,
+			expPromote:  true,
+		},
+		"if the target Secret was issued by the same issuer, promote": {
+			certificate: canaryCrt,
+			secrets:     []runtime.Object{secret("output", issuedBy("new-issuer"), "old-cert")},
+			expPromote:  true,
+		},
+		"if the issuer has changed and no shadow Secret exists, store the candidate in the shadow Secret": {
+			certificate:    canaryCrt,
+			secrets:        []runtime.Object{secret("output", issuedBy("old-issuer"), "old-cert")},
+			expShadowWrite: true,
+			expEvents:      []string{`Normal CanaryIssued Candidate certificate from the new issuer stored in Secret "output-canary", waiting for it to be verified`},
+		},
+		"if the shadow Secret holds a previous candidate, store the new candidate in the shadow Secret": {
+			certificate: canaryCrt,
+			secrets: []runtime.Object{
+				secret("output", issuedBy("old-issuer"), "old-cert"),
+				secret("output-canary", map[string]string{
+					cmapi.CanaryRequestAnnotationKey:  "test-0",
+					cmapi.CanaryVerifiedAnnotationKey: "test-0",
+				}, "previous-cert"),
+			},
+			expShadowWrite: true,
+			expEvents:      []string{`Normal CanaryIssued Candidate certificate from the new issuer stored in Secret "output-canary", waiting for it to be verified`},
+		},
+		"if the candidate has not been verified, wait": {
+			certificate: canaryCrt,
+			secrets: []runtime.Object{
+				secret("output", issuedBy("old-issuer"), "old-cert"),
+				secret("output-canary", map[string]string{cmapi.CanaryRequestAnnotationKey: "test-1"}, "new-cert"),
+			},
+		},
+		"if the candidate has been verified, promote": {
+			certificate: canaryCrt,
+			secrets: []runtime.Object{
+				secret("output", issuedBy("old-issuer"), "old-cert"),
+				secret("output-canary", map[string]string{
+					cmapi.CanaryRequestAnnotationKey:  "test-1",
+					cmapi.CanaryVerifiedAnnotationKey: "test-1",
+				}, "new-cert"),
+			},
+			expPromote: true,
+			expEvents:  []string{`Normal CanaryPromoted Candidate certificate in Secret "output-canary" has been verified and will be promoted`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:              t,
+				KubeObjects:    test.secrets,
+				ExpectedEvents: test.expEvents,
+			}
+			builder.Init()
+
+			var shadowWrite bool
+			c := &controller{
+				secretLister: builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+				recorder:     builder.Recorder,
+				secretsUpdateData: func(_ context.Context, crt *cmapi.Certificate, secretData internal.SecretData) error {
+					shadowWrite = true
+					assert.Equal(t, "output-canary", crt.Spec.SecretName)
+					assert.Equal(t, "test-1", crt.Spec.SecretTemplate.Annotations[cmapi.CanaryRequestAnnotationKey])
+					assert.Equal(t, data, secretData)
+					return nil
+				},
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			promote, err := c.ensureCanary(context.Background(), test.certificate, req, data)
+			require.NoError(t, err)
+			assert.Equal(t, test.expPromote, promote)
+			assert.Equal(t, test.expShadowWrite, shadowWrite)
+			builder.CheckAndFinish(err)
+		})
+	}
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +36,7 @@ import (
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -47,6 +51,11 @@ type SecretsManager struct {
 	secretClient coreclient.SecretsGetter
 	secretLister corelisters.SecretLister
 
+	// configMapLister is used to read ConfigMaps referenced as additional
+	// trusted CAs. It is nil if the AdditionalTrustedCAs feature gate is
+	// disabled.
+	configMapLister corelisters.ConfigMapLister
+
 	// fieldManager is the manager name used for the Apply operations on Secrets.
 	fieldManager string
 
@@ -55,11 +64,25 @@ type SecretsManager struct {
 	// Secret resource will be automatically deleted.
 	// This option is disabled by default.
 	enableSecretOwnerReferences bool
+
+	// ownedBy identifies this cert-manager instance. If set, Secret
+	// resources are labelled with it, and existing Secret resources which
+	// are not labelled with it are not updated.
+	ownedBy string
 }
 
 // SecretData is a structure wrapping private key, Certificate and CA data
 type SecretData struct {
 	PrivateKey, Certificate, CA []byte
+
+	// CrossSignedCertificate is the certificate chain issued by the
+	// cross-signing CA of the issuer, if any.
+	CrossSignedCertificate []byte
+
+	// AdditionalPrivateKey and AdditionalCertificate are the additional
+	// private key of the Certificate and the certificate issued for it, if
+	// any.
+	AdditionalPrivateKey, AdditionalCertificate []byte
 }
 
 // NewSecretsManager returns a new SecretsManager. Setting
@@ -68,14 +91,18 @@ type SecretData struct {
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
 	secretLister corelisters.SecretLister,
+	configMapLister corelisters.ConfigMapLister,
 	fieldManager string,
 	enableSecretOwnerReferences bool,
+	ownedBy string,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
 		secretLister:                secretLister,
+		configMapLister:             configMapLister,
 		fieldManager:                fieldManager,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
+		ownedBy:                     ownedBy,
 	}
 }
 
@@ -92,6 +119,15 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	log := logf.FromContext(ctx).WithName("secrets_manager")
 	log = logf.WithResource(log, secret)
 
+	if crt.Spec.Chain != nil {
+		trimmed, err := certificates.TrimCertificateChain(data.Certificate, data.CA, int(crt.Spec.Chain.MaxDepth))
+		if err != nil {
+			log.Error(err, "failed to trim certificate chain, storing the full chain")
+		} else {
+			data.Certificate = trimmed
+		}
+	}
+
 	if err := s.setValues(crt, secret, data); err != nil {
 		return err
 	}
@@ -144,11 +180,27 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		}
 	}
 
+	// Add the additional trusted CAs bundle if feature enabled.
+	if utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalTrustedCAs) && len(crt.Spec.AdditionalTrustedCAs) > 0 {
+		bundle, err := s.TrustBundle(crt, data.CA)
+		if err != nil {
+			return fmt.Errorf("failed to build trust bundle for Secret: %w", err)
+		}
+		secret.Data[cmmeta.TrustBundleKey] = bundle
+	}
+
 	secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
 	secret.Data[corev1.TLSCertKey] = data.Certificate
 	if len(data.CA) > 0 {
 		secret.Data[cmmeta.TLSCAKey] = data.CA
 	}
+	if len(data.CrossSignedCertificate) > 0 {
+		secret.Data[cmmeta.CrossSignedCertKey] = data.CrossSignedCertificate
+	}
+	if len(data.AdditionalPrivateKey) > 0 && len(data.AdditionalCertificate) > 0 {
+		secret.Data[cmmeta.AdditionalTLSPrivateKeyKey] = data.AdditionalPrivateKey
+		secret.Data[cmmeta.AdditionalTLSCertKey] = data.AdditionalCertificate
+	}
 
 	var certificate *x509.Certificate
 	if len(data.Certificate) > 0 {
@@ -173,6 +225,7 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 			secret.Annotations[k] = v
 		}
 	}
+	secret.Labels = controllerpkg.AddOwnedByLabels(secret.Labels, s.ownedBy, crt)
 
 	return nil
 }
@@ -201,6 +254,11 @@ func (s *SecretsManager) getCertificateSecret(ctx context.Context, crt *cmapi.Ce
 		return nil, err
 	}
 
+	if !controllerpkg.IsOwnedBy(existingSecret, s.ownedBy) {
+		return nil, fmt.Errorf("refusing to update Secret %s/%s which is not labelled %s=%s",
+			existingSecret.Namespace, existingSecret.Name, cmapi.OwnedByLabelKey, s.ownedBy)
+	}
+
 	// Only copy Secret Type to not take ownership of annotations or labels on
 	// Apply.
 	return &corev1.Secret{
@@ -296,3 +354,68 @@ func setAdditionalOutputFormats(crt *cmapi.Certificate, secret *corev1.Secret, d
 
 	return nil
 }
+
+// TrustBundle returns the PEM encoded bundle which should be stored at the
+// `trust.pem` key of the Certificate's Secret. The bundle contains the given
+// issuing CA followed by the certificates read from each of the Certificate's
+// additional trusted CA sources, in the order they are listed.
+func (s *SecretsManager) TrustBundle(crt *cmapi.Certificate, ca []byte) ([]byte, error) {
+	var bundle bytes.Buffer
+	if len(ca) > 0 {
+		bundle.Write(ca)
+		if !bytes.HasSuffix(ca, []byte("\n")) {
+			bundle.WriteByte('\n')
+		}
+	}
+
+	for i, ref := range crt.Spec.AdditionalTrustedCAs {
+		pemData, err := s.additionalTrustedCAData(crt.Namespace, ref)
+		if err != nil {
+			return nil, fmt.Errorf("additionalTrustedCAs[%d]: %w", i, err)
+		}
+		certs, err := utilpki.DecodeX509CertificateChainBytes(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("additionalTrustedCAs[%d]: %w", i, err)
+		}
+		// Root CAs are self-signed, so utilpki.EncodeX509Chain cannot be
+		// used here as it omits them.
+		for _, cert := range certs {
+			if err := pem.Encode(&bundle, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+				return nil, fmt.Errorf("additionalTrustedCAs[%d]: %w", i, err)
+			}
+		}
+	}
+
+	return bundle.Bytes(), nil
+}
+
+// additionalTrustedCAData returns the raw data referenced by the given
+// additional trusted CA source in the given namespace.
+func (s *SecretsManager) additionalTrustedCAData(namespace string, ref cmapi.CertificateAdditionalTrustedCA) ([]byte, error) {
+	switch {
+	case ref.Secret != nil:
+		secret, err := s.secretLister.Secrets(namespace).Get(ref.Secret.Name)
+		if err != nil {
+			return nil, fmt.Errorf("fetching trusted CA Secret: %w", err)
+		}
+		if len(secret.Data[ref.Secret.Key]) == 0 {
+			return nil, fmt.Errorf("trusted CA Secret %q contains no data for key %q", ref.Secret.Name, ref.Secret.Key)
+		}
+		return secret.Data[ref.Secret.Key], nil
+
+	case ref.ConfigMap != nil:
+		if s.configMapLister == nil {
+			return nil, errors.New("ConfigMap trusted CA sources require the AdditionalTrustedCAs feature gate")
+		}
+		cm, err := s.configMapLister.ConfigMaps(namespace).Get(ref.ConfigMap.Name)
+		if err != nil {
+			return nil, fmt.Errorf("fetching trusted CA ConfigMap: %w", err)
+		}
+		if len(cm.Data[ref.ConfigMap.Key]) == 0 {
+			return nil, fmt.Errorf("trusted CA ConfigMap %q contains no data for key %q", ref.ConfigMap.Name, ref.ConfigMap.Key)
+		}
+		return []byte(cm.Data[ref.ConfigMap.Key]), nil
+	}
+
+	return nil, errors.New("one of secret or configMap must be specified")
+}
@@ -31,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
@@ -98,6 +99,7 @@ func Test_SecretsManager(t *testing.T) {
 
 	tests := map[string]struct {
 		certificateOptions controllerpkg.CertificateOptions
+		ownedBy            string
 		certificate        *cmapi.Certificate
 		existingSecret     *corev1.Secret
 
@@ -155,6 +157,45 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if the issuer returned a cross-signed certificate, it should be stored in the Secret": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"), CrossSignedCertificate: []byte("test-cross-signed")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, map[string][]byte{
+						corev1.TLSCertKey:         baseCertBundle.CertBytes,
+						corev1.TLSPrivateKeyKey:   []byte("test-key"),
+						cmmeta.TLSCAKey:           []byte("test-ca"),
+						cmmeta.CrossSignedCertKey: []byte("test-cross-signed"),
+					}, gotCnf.Data)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
+		"if the Certificate has an additional private key, it should be stored in the Secret with its certificate": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"), AdditionalPrivateKey: []byte("test-additional-key"), AdditionalCertificate: []byte("test-additional-cert")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, map[string][]byte{
+						corev1.TLSCertKey:                 baseCertBundle.CertBytes,
+						corev1.TLSPrivateKeyKey:           []byte("test-key"),
+						cmmeta.TLSCAKey:                   []byte("test-ca"),
+						cmmeta.AdditionalTLSPrivateKeyKey: []byte("test-additional-key"),
+						cmmeta.AdditionalTLSCertKey:       []byte("test-additional-cert"),
+					}, gotCnf.Data)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does not exist, create new Secret, with owner enabled": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertBundle.Certificate,
@@ -649,6 +690,56 @@ func Test_SecretsManager(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if owned-by is set and secret does not exist, create new Secret with the owned-by labels": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			ownedBy:            "instance-a",
+			certificate:        baseCertBundle.Certificate,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, map[string]string{cmapi.OwnedByLabelKey: "instance-a", cmapi.ControllerUIDLabelKey: "test-uid"}, gotCnf.Labels)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if owned-by is set, the secret template can not override the owned-by labels": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			ownedBy:            "instance-a",
+			certificate: gen.CertificateFrom(baseCertBundle.Certificate,
+				gen.SetCertificateSecretTemplate(nil, map[string]string{
+					cmapi.OwnedByLabelKey:       "instance-b",
+					cmapi.ControllerUIDLabelKey: "other-uid",
+				}),
+			),
+			existingSecret: nil,
+			secretData:     SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, map[string]string{cmapi.OwnedByLabelKey: "instance-a", cmapi.ControllerUIDLabelKey: "test-uid"}, gotCnf.Labels)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if owned-by is set and existing secret is not labelled, expect error and no apply": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			ownedBy:            "instance-a",
+			certificate:        baseCertBundle.Certificate,
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "output"},
+				Type:       corev1.SecretTypeTLS,
+			},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(context.Context, *applycorev1.SecretApplyConfiguration, metav1.ApplyOptions) (*corev1.Secret, error) {
+					t.Error("unexpected apply call")
+					return nil, nil
+				}
+			},
+			expectedErr: true,
+		},
 		"if apply errors, expect error response": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertWithSecretTemplate,
@@ -678,9 +769,10 @@ func Test_SecretsManager(t *testing.T) {
 			secretLister := testcorelisters.NewFakeSecretLister(mod)
 
 			testManager := NewSecretsManager(
-				secretClient, secretLister,
+				secretClient, secretLister, nil,
 				"cert-manager-test",
 				test.certificateOptions.EnableOwnerRef,
+				test.ownedBy,
 			)
 
 			err := testManager.UpdateData(context.Background(), test.certificate, test.secretData)
@@ -777,3 +869,91 @@ func Test_getCertificateSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_TrustBundle(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	caA := testcrypto.MustCreateCert(t, pk, gen.Certificate("ca-a", gen.SetCertificateCommonName("ca-a"), gen.SetCertificateIsCA(true)))
+	caB := testcrypto.MustCreateCert(t, pk, gen.Certificate("ca-b", gen.SetCertificateCommonName("ca-b"), gen.SetCertificateIsCA(true)))
+
+	secretRef := cmapi.CertificateAdditionalTrustedCA{
+		Secret: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-secret"}, Key: "ca.crt"},
+	}
+	configMapRef := cmapi.CertificateAdditionalTrustedCA{
+		ConfigMap: &cmapi.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-bundle"}, Key: "ca.pem"},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "ca-secret"},
+		Data:       map[string][]byte{"ca.crt": caA},
+	}
+	caConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "ca-bundle"},
+		Data:       map[string]string{"ca.pem": string(caB)},
+	}
+
+	tests := map[string]struct {
+		objects   []runtime.Object
+		cas       []cmapi.CertificateAdditionalTrustedCA
+		issuingCA []byte
+		expBundle []byte
+		expErr    bool
+	}{
+		"if no additional CAs are referenced, expect only the issuing CA": {
+			issuingCA: caA,
+			expBundle: caA,
+		},
+		"if the issuing CA has no trailing newline, expect one to be added": {
+			issuingCA: []byte("ca"),
+			expBundle: []byte("ca\n"),
+		},
+		"if a Secret and a ConfigMap are referenced, expect both appended in order": {
+			objects:   []runtime.Object{caSecret, caConfigMap},
+			cas:       []cmapi.CertificateAdditionalTrustedCA{configMapRef, secretRef},
+			issuingCA: caA,
+			expBundle: append(append(append([]byte{}, caA...), caB...), caA...),
+		},
+		"if a referenced Secret does not exist, expect error": {
+			objects: []runtime.Object{caConfigMap},
+			cas:     []cmapi.CertificateAdditionalTrustedCA{configMapRef, secretRef},
+			expErr:  true,
+		},
+		"if a referenced ConfigMap does not contain a certificate, expect error": {
+			objects: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "ca-bundle"},
+				Data:       map[string]string{"ca.pem": "not a certificate"},
+			}},
+			cas:    []cmapi.CertificateAdditionalTrustedCA{configMapRef},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:           t,
+				KubeObjects: test.objects,
+			}
+			builder.Init()
+
+			s := SecretsManager{
+				secretClient:    builder.Client.CoreV1(),
+				secretLister:    builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+				configMapLister: builder.KubeSharedInformerFactory.Core().V1().ConfigMaps().Lister(),
+				fieldManager:    "cert-manager-test",
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			crt := gen.Certificate("test", gen.SetCertificateNamespace("test-namespace"))
+			crt.Spec.AdditionalTrustedCAs = test.cas
+
+			bundle, err := s.TrustBundle(crt, test.issuingCA)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, string(test.expBundle), string(bundle))
+		})
+	}
+}
@@ -56,6 +56,15 @@ import (
 
 const (
 	ControllerName = "certificates-issuing"
+
+	// reasonSecretAccessDenied is used when the controller is not permitted
+	// to write the Certificate's Secret, for example when access to Secrets
+	// is granted per namespace and has not been granted in this namespace.
+	reasonSecretAccessDenied = "SecretAccessDenied"
+
+	// maxLastErrorLength is the maximum length of the Certificate's
+	// status.lastError, so that it stays readable as a printer column.
+	maxLastErrorLength = 256
 )
 
 type localTemporarySignerFn func(crt *cmapi.Certificate, pk []byte) ([]byte, error)
@@ -77,6 +86,10 @@ type controller struct {
 	// Certificate's secret.
 	secretsUpdateData func(context.Context, *cmapi.Certificate, internal.SecretData) error
 
+	// trustBundle is used to build the expected `trust.pem` data for a
+	// Certificate which has additional trusted CAs configured.
+	trustBundle func(*cmapi.Certificate, []byte) ([]byte, error)
+
 	// postIssuancePolicyChain is the policies chain to ensure that all Secret
 	// metadata and output formats are kept are present and correct.
 	postIssuancePolicyChain policies.Chain
@@ -88,6 +101,10 @@ type controller struct {
 
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
 }
 
 func NewController(
@@ -100,6 +117,7 @@ func NewController(
 	clock clock.Clock,
 	certificateControllerOptions controllerpkg.CertificateOptions,
 	fieldManager string,
+	ownedBy string,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 
 	// create a queue used to queue up items to be processed
@@ -125,6 +143,11 @@ func NewController(
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
 	})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Issuer reconciles on changes to the Secret named `spec.canary.secretName`
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateCanarySecretName)),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -134,9 +157,30 @@ func NewController(
 		certificateInformer.Informer().HasSynced,
 	}
 
+	// Only watch ConfigMaps if additional trusted CAs are enabled, so that
+	// the controller does not otherwise require access to ConfigMaps.
+	var configMapLister corelisters.ConfigMapLister
+	if utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalTrustedCAs) {
+		configMapsInformer := factory.Core().V1().ConfigMaps()
+		configMapsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			// Certificates are re-synced when a ConfigMap referenced in
+			// `spec.additionalTrustedCAs` changes, to update their `trust.pem`
+			WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+				predicate.ExtractResourceName(predicate.CertificateAdditionalTrustedCAConfigMapName)),
+		})
+		secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			// Certificates are re-synced when a Secret referenced in
+			// `spec.additionalTrustedCAs` changes, to update their `trust.pem`
+			WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+				predicate.ExtractResourceName(predicate.CertificateAdditionalTrustedCASecretName)),
+		})
+		mustSync = append(mustSync, configMapsInformer.Informer().HasSynced)
+		configMapLister = configMapsInformer.Lister()
+	}
+
 	secretsManager := internal.NewSecretsManager(
-		kubeClient.CoreV1(), secretsInformer.Lister(),
-		fieldManager, certificateControllerOptions.EnableOwnerRef,
+		kubeClient.CoreV1(), secretsInformer.Lister(), configMapLister,
+		fieldManager, certificateControllerOptions.EnableOwnerRef, ownedBy,
 	)
 
 	return &controller{
@@ -147,6 +191,7 @@ func NewController(
 		recorder:                 recorder,
 		clock:                    clock,
 		secretsUpdateData:        secretsManager.UpdateData,
+		trustBundle:              secretsManager.TrustBundle,
 		postIssuancePolicyChain: policies.NewSecretPostIssuancePolicyChain(
 			certificateControllerOptions.EnableOwnerRef,
 			fieldManager,
@@ -178,6 +223,17 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
+	if crt.Spec.ObserveOnly {
+		// The Secrets of observe-only Certificates are never modified.
+		log.V(logf.DebugLevel).Info("skipping observe-only certificate")
+		return nil
+	}
+
 	log = logf.WithResource(log, crt)
 	ctx = logf.NewContext(ctx, log)
 
@@ -238,13 +294,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		predicate.CertificateRequestRevision(nextRevision),
 		predicate.ResourceOwnedBy(crt),
 	)
-	if err != nil || len(reqs) != 1 {
-		// If error return.
-		// if no error but none exist do nothing.
-		// If no error but multiple exist, then leave to requestmanager controller
-		// to clean up.
+	if err != nil {
 		return err
 	}
+	reqs, additionalReqs := certificates.SplitAdditionalPrivateKeyRequests(reqs)
+	if len(reqs) != 1 {
+		// If none exist do nothing.
+		// If multiple exist, then leave to requestmanager controller to clean
+		// up.
+		return nil
+	}
 
 	req := reqs[0]
 	log = logf.WithResource(log, req)
@@ -325,7 +384,21 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	// If the CertificateRequest is valid and ready, verify its status and issue
 	// accordingly.
 	if crReadyCond.Reason == cmapi.CertificateRequestReasonIssued {
-		return c.issueCertificate(ctx, nextRevision, crt, req, pk)
+		// Some issuers generate the private key of the certificate themselves,
+		// in which case that key must be stored instead of the next private key.
+		if name, ok := req.Annotations[cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey]; ok {
+			pk, err = c.issuerPrivateKey(req, name)
+			if err != nil {
+				return err
+			}
+		}
+		// If the Certificate has an additional private key, the certificate
+		// issued for it is stored together with this one.
+		additionalReq, apk, ready, err := c.additionalKeyPair(ctx, crt, nextPrivateKeySecret, additionalReqs)
+		if err != nil || !ready {
+			return err
+		}
+		return c.issueCertificate(ctx, nextRevision, crt, req, pk, additionalReq, apk)
 	}
 
 	// Issue temporary certificate if needed. If a certificate was issued, then
@@ -341,9 +414,9 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 }
 
 // failIssueCertificate will mark the Issuing condition of this Certificate as
-// false, set the Certificate's last failure time and issuance attempts, and log
-// an appropriate event. The reason and message of the Issuing condition will be that of
-// the CertificateRequest condition passed.
+// false, set the Certificate's last failure time, last error and issuance
+// attempts, and log an appropriate event. The reason and message of the
+// Issuing condition will be that of the CertificateRequest condition passed.
 func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, condition *cmapi.CertificateRequestCondition) error {
 	nowTime := metav1.NewTime(c.clock.Now())
 	crt.Status.LastFailureTime = &nowTime
@@ -362,6 +435,7 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 		condition.Message)
 
 	crt = crt.DeepCopy()
+	crt.Status.LastError = lastError(reason, condition.Message)
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reason, message)
 
 	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
@@ -373,10 +447,52 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 	return nil
 }
 
+// lastError returns a compact description of a failed issuance for the
+// Certificate's status.lastError, truncated to maxLastErrorLength characters.
+func lastError(reason, message string) string {
+	lastError := message
+	if reason != "" {
+		lastError = reason + ": " + message
+	}
+	if r := []rune(lastError); len(r) > maxLastErrorLength {
+		lastError = string(r[:maxLastErrorLength-3]) + "..."
+	}
+	return lastError
+}
+
+// issuerPrivateKey returns the private key stored by the issuer of the given
+// CertificateRequest in the named Secret, which must match the issued
+// certificate.
+func (c *controller) issuerPrivateKey(req *cmapi.CertificateRequest, name string) (crypto.Signer, error) {
+	secret, err := c.secretLister.Secrets(req.Namespace).Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the private key generated by the issuer: %w", err)
+	}
+	pk, _, err := utilkube.ParseTLSKeyFromSecret(secret, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key generated by the issuer: %w", err)
+	}
+
+	cert, err := utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := utilpki.PublicKeyMatchesCertificate(pk.Public(), cert)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, fmt.Errorf("the private key in Secret %q does not match the issued certificate", name)
+	}
+
+	return pk, nil
+}
+
 // issueCertificate will ensure the public key of the CSR matches the signed
 // certificate, and then store the certificate, CA and private key into the
-// Secret in the appropriate format type.
-func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer) error {
+// Secret in the appropriate format type. If additionalReq is not nil, the
+// certificate issued for the additional private key apk is stored too.
+func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer, additionalReq *cmapi.CertificateRequest, apk crypto.Signer) error {
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
@@ -390,9 +506,46 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 		PrivateKey:  pkData,
 		Certificate: req.Status.Certificate,
 		CA:          req.Status.CA,
+
+		CrossSignedCertificate: req.Status.CrossSignedCertificate,
+	}
+	if additionalReq != nil {
+		apkData, err := utilpki.EncodePrivateKey(apk, crt.Spec.PrivateKey.Encoding)
+		if err != nil {
+			return err
+		}
+		secretData.AdditionalPrivateKey = apkData
+		secretData.AdditionalCertificate = additionalReq.Status.Certificate
+	}
+
+	// If the Certificate is being migrated to a new issuer using a canary,
+	// the candidate must be verified before it is stored in the Secret.
+	// Issuance stops until then, and is triggered again by the trigger
+	// controller once the candidate has been verified.
+	promote, err := c.ensureCanary(ctx, crt, req, secretData)
+	if err != nil {
+		return err
+	}
+	if !promote {
+		message := fmt.Sprintf("Waiting for the candidate certificate in Secret %q to be verified", crt.Spec.Canary.SecretName)
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reasonCanaryPending, message)
+		return c.updateOrApplyStatus(ctx, crt, false)
+	}
+
+	// Whether a new private key is being stored must be checked before the
+	// Secret is updated.
+	var privateKeyChanged bool
+	if crt.Spec.PrivateKey.RotationPolicy == cmapi.RotationPolicyScheduled {
+		privateKeyChanged, err = c.privateKeyChanged(crt, pk)
+		if err != nil {
+			return err
+		}
 	}
 
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
+		if apierrors.IsForbidden(err) {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonSecretAccessDenied, "Not permitted to write Secret %q, ensure cert-manager has been granted access to Secrets in this namespace: %v", crt.Spec.SecretName, err)
+		}
 		return err
 	}
 
@@ -407,8 +560,22 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	// Clear status.failedIssuanceAttempts (if set)
 	crt.Status.FailedIssuanceAttempts = nil
 
-	// Clear status.lastFailureTime (if set)
+	// Clear status.lastFailureTime and status.lastError (if set)
 	crt.Status.LastFailureTime = nil
+	crt.Status.LastError = ""
+
+	nowTime := metav1.NewTime(c.clock.Now())
+	crt.Status.LastSuccessTime = &nowTime
+
+	// Schedule the next rotation of the private key whenever a new one is
+	// stored, or if no rotation is scheduled in the future.
+	if crt.Spec.PrivateKey.RotationPolicy == cmapi.RotationPolicyScheduled {
+		if rotationTime := crt.Status.PrivateKeyRotationTime; privateKeyChanged || rotationTime == nil || !nowTime.Before(rotationTime) {
+			crt.Status.PrivateKeyRotationTime = certificates.NextPrivateKeyRotationTime(crt.Spec.PrivateKey, nowTime.Time)
+		}
+	} else {
+		crt.Status.PrivateKeyRotationTime = nil
+	}
 
 	if err := c.updateOrApplyStatus(ctx, crt, true); err != nil {
 		return err
@@ -421,6 +588,28 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 
 }
 
+// privateKeyChanged returns true if the Certificate's Secret does not already
+// store the private key pk.
+func (c *controller) privateKeyChanged(crt *cmapi.Certificate, pk crypto.Signer) (bool, error) {
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := utilpki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return true, nil
+	}
+	equal, err := utilpki.PublicKeysEqual(existing.Public(), pk.Public())
+	if err != nil {
+		return true, nil
+	}
+	return !equal, nil
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -446,9 +635,12 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
 			Status: cmapi.CertificateStatus{
-				Revision:        crt.Status.Revision,
-				LastFailureTime: crt.Status.LastFailureTime,
-				Conditions:      conditions,
+				Revision:               crt.Status.Revision,
+				LastFailureTime:        crt.Status.LastFailureTime,
+				LastSuccessTime:        crt.Status.LastSuccessTime,
+				LastError:              crt.Status.LastError,
+				PrivateKeyRotationTime: crt.Status.PrivateKeyRotationTime,
+				Conditions:             conditions,
 			},
 		})
 	} else {
@@ -476,7 +668,9 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.Clock,
 		ctx.CertificateOptions,
 		ctx.FieldManager,
+		ctx.OwnedBy,
 	)
+	ctrl.issuerClass = ctx.IssuerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -19,6 +19,7 @@ package issuing
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -86,6 +87,23 @@ func TestIssuingController(t *testing.T) {
 		}),
 	)
 
+	scheduledCert := gen.CertificateFrom(baseCert,
+		gen.SetCertificatePrivateKeyRotationSchedule(cmapi.PrivateKeyRotationSchedule{
+			Interval: &metav1.Duration{Duration: time.Hour * 24 * 90},
+		}),
+	)
+	scheduledBundle := testcrypto.MustCreateCryptoBundle(t, scheduledCert.DeepCopy(), fixedClock)
+	scheduledIssuingCert := gen.CertificateFrom(scheduledCert,
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	nextRotationTime := metav1.NewTime(fixedClockStart.Add(time.Hour * 24 * 90).UTC().Truncate(time.Second))
+	futureRotationTime := metav1.NewTime(fixedClockStart.Add(time.Hour * 24).UTC().Truncate(time.Second))
+
 	tests := map[string]testT{
 		"if certificate is not in Issuing state, then do nothing": {
 			certificate: exampleBundle.Certificate,
@@ -291,6 +309,7 @@ func TestIssuingController(t *testing.T) {
 								ObservedGeneration: 3,
 							}),
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateLastError("Failed: The certificate request failed because of reasons"),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
 						),
 					)),
@@ -343,6 +362,7 @@ func TestIssuingController(t *testing.T) {
 								ObservedGeneration: 3,
 							}),
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateLastError("Failed: The certificate request failed because of reasons"),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(5)),
 						),
 					)),
@@ -499,6 +519,61 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequest, and is ready with a private key generated by the issuer, store the issuer's private key": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey:         "2", // Current Certificate revision=1
+							cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey: "issuer-private-key",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "issuer-private-key",
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
 						),
 					)),
 				},
@@ -513,6 +588,42 @@ func TestIssuingController(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the private key generated by the issuer does not match the certificate, return error": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey:         "2", // Current Certificate revision=1
+							cmapi.CertificateRequestIssuerPrivateKeyAnnotationKey: "issuer-private-key",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "issuer-private-key",
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundleAlt.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{},
+				ExpectedEvents:  []string{},
+			},
+			expectedErr: true,
+		},
 
 		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
@@ -553,6 +664,7 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
 						),
 					)),
 				},
@@ -567,6 +679,118 @@ func TestIssuingController(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if certificate is in Issuing state with a scheduled private key rotation and a new private key is stored, schedule the next rotation": {
+			certificate: scheduledBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(scheduledIssuingCert,
+						gen.SetCertificatePrivateKeyRotationTime(futureRotationTime),
+					),
+					gen.CertificateRequestFrom(scheduledBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: scheduledBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: scheduledBundle.PrivateKeyBytes,
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: scheduledBundle.Certificate.Namespace,
+							Name:      "output",
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundleAlt.PrivateKeyBytes,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						scheduledBundle.Certificate.Namespace,
+						gen.CertificateFrom(scheduledBundle.Certificate,
+							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
+							gen.SetCertificatePrivateKeyRotationTime(nextRotationTime),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: scheduledBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  scheduledBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			expectedErr: false,
+		},
+		"if certificate is in Issuing state with a scheduled private key rotation and the private key is reused, keep the scheduled rotation": {
+			certificate: scheduledBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(scheduledIssuingCert,
+						gen.SetCertificatePrivateKeyRotationTime(futureRotationTime),
+					),
+					gen.CertificateRequestFrom(scheduledBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: scheduledBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: scheduledBundle.PrivateKeyBytes,
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: scheduledBundle.Certificate.Namespace,
+							Name:      "output",
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: scheduledBundle.PrivateKeyBytes,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						scheduledBundle.Certificate.Namespace,
+						gen.CertificateFrom(scheduledBundle.Certificate,
+							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
+							gen.SetCertificatePrivateKeyRotationTime(futureRotationTime),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: scheduledBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  scheduledBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			expectedErr: false,
+		},
 		"if certificate is in Issuing state, one ready CertificateRequest and has last failure time set from previous issuance, set the Issuing condition to true, remove last failure time and store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -606,6 +830,7 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
 						),
 					)),
 				},
@@ -660,6 +885,7 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
 						),
 					)),
 				},
@@ -937,6 +1163,7 @@ func TestIssuingController(t *testing.T) {
 								cmapi.IssueTemporaryCertificateAnnotation: "true",
 							}),
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateLastSuccessTime(metaFixedClockStart),
 						),
 					)),
 				},
@@ -1000,6 +1227,7 @@ func TestIssuingController(t *testing.T) {
 								ObservedGeneration: 3,
 							}),
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateLastError("Failed: The certificate request failed because of reasons"),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
 						),
 					)),
@@ -1052,6 +1280,7 @@ func TestIssuingController(t *testing.T) {
 								ObservedGeneration: 3,
 							}),
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateLastError("DeniedReason: The certificate request has been denied"),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
 						),
 					)),
@@ -1107,3 +1336,17 @@ func TestIssuingController(t *testing.T) {
 		})
 	}
 }
+
+func TestLastError(t *testing.T) {
+	if got := lastError("Failed", "the issuer is not ready"); got != "Failed: the issuer is not ready" {
+		t.Errorf("unexpected last error: %q", got)
+	}
+	if got := lastError("", "the issuer is not ready"); got != "the issuer is not ready" {
+		t.Errorf("unexpected last error: %q", got)
+	}
+
+	got := lastError("Failed", strings.Repeat("a", 2*maxLastErrorLength))
+	if len(got) != maxLastErrorLength || !strings.HasSuffix(got, "...") {
+		t.Errorf("expected the last error to be truncated to %d characters, got %q", maxLastErrorLength, got)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package issuing
 
 import (
+	"bytes"
 	"context"
 	"errors"
 
@@ -25,16 +26,18 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 // ensureSecretData ensures that the Certificate's Secret is up to date with
 // non-issuing condition related data.
-// Reconciles over the Certificate's SecretTemplate, and
-// AdditionalOutputFormats.
+// Reconciles over the Certificate's SecretTemplate, AdditionalOutputFormats
+// and AdditionalTrustedCAs.
 func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt *cmapi.Certificate) error {
 	// Retrieve the Secret which is associated with this Certificate.
 	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
@@ -69,6 +72,12 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 		PrivateKey:  secret.Data[corev1.TLSPrivateKeyKey],
 		Certificate: secret.Data[corev1.TLSCertKey],
 		CA:          secret.Data[cmmeta.TLSCAKey],
+
+		CrossSignedCertificate: secret.Data[cmmeta.CrossSignedCertKey],
+	}
+	if crt.Spec.AdditionalPrivateKey != nil {
+		data.AdditionalPrivateKey = secret.Data[cmmeta.AdditionalTLSPrivateKeyKey]
+		data.AdditionalCertificate = secret.Data[cmmeta.AdditionalTLSCertKey]
 	}
 
 	// Check whether the Certificate's Secret has correct output format and
@@ -96,6 +105,22 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 		}
 	}
 
+	// Check whether the trust bundle is up to date with the Certificate's
+	// additional trusted CA sources.
+	if utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalTrustedCAs) && len(crt.Spec.AdditionalTrustedCAs) > 0 {
+		bundle, err := c.trustBundle(crt, data.CA)
+		if err != nil {
+			// The trusted CA sources may not exist yet or contain invalid
+			// data. The Certificate will be re-synced when they change.
+			log.Error(err, "failed to build trust bundle")
+			return nil
+		}
+		if !bytes.Equal(bundle, secret.Data[cmmeta.TrustBundleKey]) {
+			log.Info("applying Secret data", "message", "Secret trust bundle is out of date")
+			return c.secretsUpdateData(ctx, crt, data)
+		}
+	}
+
 	// No Secret violations, nothing to do.
 
 	return nil
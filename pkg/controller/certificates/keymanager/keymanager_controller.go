@@ -27,6 +27,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
@@ -56,6 +58,7 @@ const (
 	reasonDecodeFailed        = "DecodeFailed"
 	reasonCannotRegenerateKey = "CannotRegenerateKey"
 	reasonDeleted             = "Deleted"
+	reasonSecretAccessDenied  = "SecretAccessDenied"
 )
 
 var (
@@ -68,11 +71,21 @@ type controller struct {
 	client            cmclient.Interface
 	coreClient        kubernetes.Interface
 	recorder          record.EventRecorder
+	clock             clock.Clock
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
+
+	// ownedBy identifies this cert-manager instance. If set, 'next private
+	// key' Secrets are labelled with it, and those which are not labelled
+	// with it are not deleted.
+	ownedBy string
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
 }
 
 func NewController(
@@ -82,6 +95,7 @@ func NewController(
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
 	recorder record.EventRecorder,
+	clock clock.Clock,
 	fieldManager string,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 	// create a queue used to queue up items to be processed
@@ -119,6 +133,7 @@ func NewController(
 		client:            client,
 		coreClient:        coreClient,
 		recorder:          recorder,
+		clock:             clock,
 		fieldManager:      fieldManager,
 	}, queue, mustSync
 }
@@ -154,8 +169,15 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	// Discover all 'owned' secrets that have the `next-private-key` label
-	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), isNextPrivateKeyLabelSelector, predicate.ResourceOwnedBy(crt))
+	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), isNextPrivateKeyLabelSelector, predicate.ResourceOwnedBy(crt), func(obj runtime.Object) bool {
+		return controllerpkg.IsOwnedBy(obj.(metav1.Object), c.ownedBy)
+	})
 	if err != nil {
 		return err
 	}
@@ -183,6 +205,8 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		case cmapi.RotationPolicyAlways:
 			log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because no existing Secret found")
 			return c.createAndSetNextPrivateKey(ctx, crt)
+		case cmapi.RotationPolicyScheduled:
+			return c.createNextPrivateKeyRotationPolicyScheduled(ctx, crt)
 		default:
 			log.V(logf.WarnLevel).Info("Certificate with unknown certificate.spec.privateKey.rotationPolicy value", "rotation_policy", rotationPolicy)
 			return nil
@@ -231,6 +255,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.deleteSecretResources(ctx, secrets)
 	}
 
+	if acrt := certificates.AdditionalPrivateKeyCertificate(crt); acrt != nil {
+		apk, err := pki.DecodePrivateKeyBytes(secret.Data[cmmeta.AdditionalTLSPrivateKeyKey])
+		if err != nil {
+			log.V(logf.DebugLevel).Info("Deleting existing private key secret as the additional private key is missing or cannot be decoded", "error", err.Error())
+			return c.deleteSecretResources(ctx, secrets)
+		}
+		violations, err := certificates.PrivateKeyMatchesSpec(apk, acrt.Spec)
+		if err != nil {
+			log.Error(err, "Internal error verifying if additional private key matches spec - please open an issue.")
+			return nil
+		}
+		if len(violations) > 0 {
+			log.V(logf.DebugLevel).Info("Regenerating additional private key due to change in fields", "violations", violations)
+			c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonDeleted, "Regenerating additional private key due to change in spec.additionalPrivateKey")
+			return c.deleteSecretResources(ctx, secrets)
+		}
+	}
+
 	return nil
 }
 
@@ -264,7 +306,12 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 		return nil
 	}
 
-	nextPkSecret, err := c.createNewPrivateKeySecret(ctx, crt, pk)
+	apk, err := nextAdditionalPrivateKey(crt, s.Data[cmmeta.AdditionalTLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	nextPkSecret, err := c.createNewPrivateKeySecret(ctx, crt, pk, apk)
 	if err != nil {
 		return err
 	}
@@ -274,13 +321,32 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 	return c.setNextPrivateKeySecretName(ctx, crt, &nextPkSecret.Name)
 }
 
+// createNextPrivateKeyRotationPolicyScheduled reuses the existing private key
+// as for the Never rotation policy, unless the scheduled rotation time of the
+// private key has passed.
+func (c *controller) createNextPrivateKeyRotationPolicyScheduled(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+	if rotationTime := crt.Status.PrivateKeyRotationTime; rotationTime != nil && !c.clock.Now().Before(rotationTime.Time) {
+		log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because the scheduled private key rotation time has passed", "rotation_time", rotationTime.Time)
+		return c.createAndSetNextPrivateKey(ctx, crt)
+	}
+	return c.createNextPrivateKeyRotationPolicyNever(ctx, crt)
+}
+
 func (c *controller) createAndSetNextPrivateKey(ctx context.Context, crt *cmapi.Certificate) error {
 	pk, err := pki.GeneratePrivateKeyForCertificate(crt)
 	if err != nil {
 		return err
 	}
+	apk, err := nextAdditionalPrivateKey(crt, nil)
+	if err != nil {
+		return err
+	}
 
-	s, err := c.createNewPrivateKeySecret(ctx, crt, pk)
+	s, err := c.createNewPrivateKeySecret(ctx, crt, pk, apk)
+	if apierrors.IsForbidden(err) {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonSecretAccessDenied, "Not permitted to create a temporary Secret to store the next private key, ensure cert-manager has been granted access to Secrets in this namespace: %v", err)
+	}
 	if err != nil {
 		return err
 	}
@@ -290,6 +356,27 @@ func (c *controller) createAndSetNextPrivateKey(ctx context.Context, crt *cmapi.
 	return c.setNextPrivateKeySecretName(ctx, crt, &s.Name)
 }
 
+// nextAdditionalPrivateKey returns the additional private key to be stored
+// alongside the next private key of the Certificate, or nil if the Certificate
+// has no additional private key. The existing key is reused if it matches
+// spec.additionalPrivateKey, otherwise a new key is generated.
+func nextAdditionalPrivateKey(crt *cmapi.Certificate, existing []byte) (crypto.Signer, error) {
+	acrt := certificates.AdditionalPrivateKeyCertificate(crt)
+	if acrt == nil {
+		return nil, nil
+	}
+
+	if len(existing) > 0 {
+		if apk, err := pki.DecodePrivateKeyBytes(existing); err == nil {
+			if violations, err := certificates.PrivateKeyMatchesSpec(apk, acrt.Spec); err == nil && len(violations) == 0 {
+				return apk, nil
+			}
+		}
+	}
+
+	return pki.GeneratePrivateKeyForCertificate(acrt)
+}
+
 // deleteSecretResources will delete the given secret resources
 func (c *controller) deleteSecretResources(ctx context.Context, secrets []*corev1.Secret) error {
 	log := logf.FromContext(ctx)
@@ -332,7 +419,7 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 	}
 }
 
-func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer) (*corev1.Secret, error) {
+func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.Certificate, pk, apk crypto.Signer) (*corev1.Secret, error) {
 	// if the 'nextPrivateKeySecretName' field is already set, use this as the
 	// name of the Secret resource.
 	name := ""
@@ -350,14 +437,21 @@ func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.C
 			Namespace:       crt.Namespace,
 			Name:            name,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
-			Labels: map[string]string{
+			Labels: controllerpkg.AddOwnedByLabels(map[string]string{
 				"cert-manager.io/next-private-key": "true",
-			},
+			}, c.ownedBy, crt),
 		},
 		Data: map[string][]byte{
 			corev1.TLSPrivateKeyKey: pkData,
 		},
 	}
+	if apk != nil {
+		apkData, err := pki.EncodePrivateKey(apk, cmapi.PKCS8)
+		if err != nil {
+			return nil, err
+		}
+		s.Data[cmmeta.AdditionalTLSPrivateKeyKey] = apkData
+	}
 	if s.Name == "" {
 		// TODO: handle certificate resources that have especially long names
 		s.GenerateName = crt.Name + "-"
@@ -385,8 +479,11 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
 		ctx.Recorder,
+		ctx.Clock,
 		ctx.FieldManager,
 	)
+	ctrl.ownedBy = ctx.OwnedBy
+	ctrl.issuerClass = ctx.IssuerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
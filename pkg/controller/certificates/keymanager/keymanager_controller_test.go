@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/kr/pretty"
 	corev1 "k8s.io/api/core/v1"
@@ -35,6 +36,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func mustGenerateRSA(t *testing.T, keySize int) []byte {
@@ -93,6 +95,32 @@ func TestProcessItem(t *testing.T) {
 			Data: data,
 		}
 	}
+	now := time.Now()
+	// scheduledCertificate returns a Certificate which is being issued and
+	// whose private key is scheduled to be rotated at rotationTime.
+	scheduledCertificate := func(rotationTime time.Time) *cmapi.Certificate {
+		return gen.Certificate("test",
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateSecretName("test-tls"),
+			gen.SetCertificatePrivateKeyRotationSchedule(cmapi.PrivateKeyRotationSchedule{
+				Interval: &metav1.Duration{Duration: time.Hour * 24 * 90},
+			}),
+			gen.SetCertificatePrivateKeyRotationTime(metav1.NewTime(rotationTime.Truncate(time.Second))),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionIssuing,
+				Status: cmmeta.ConditionTrue,
+			}),
+		)
+	}
+	nextPrivateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "testns",
+			GenerateName:    "test-",
+			Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true"},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
+		},
+		Data: map[string][]byte{"tls.key": nil},
+	}
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
 		// if not set, the 'namespace/name' of the 'Certificate' field will be used.
@@ -444,6 +472,56 @@ func TestProcessItem(t *testing.T) {
 				)),
 			},
 		},
+		"reuse the existing private key if its scheduled rotation time has not passed": {
+			certificate: scheduledCertificate(now.Add(time.Hour * 24)),
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+					Data:       map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)},
+				},
+			},
+			expectedEvents: []string{`Normal Reused Reusing private key stored in existing Secret resource "test-tls"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					gen.CertificateFrom(scheduledCertificate(now.Add(time.Hour*24)),
+						gen.SetCertificateNextPrivateKeySecretName("test-notrandom"),
+					),
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					nextPrivateKeySecret,
+				), relaxedSecretMatcher),
+			},
+		},
+		"generate a new private key if its scheduled rotation time has passed": {
+			certificate: scheduledCertificate(now.Add(-time.Hour)),
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+					Data:       map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)},
+				},
+			},
+			expectedEvents: []string{`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					gen.CertificateFrom(scheduledCertificate(now.Add(-time.Hour)),
+						gen.SetCertificateNextPrivateKeySecretName("test-notrandom"),
+					),
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					nextPrivateKeySecret,
+				), relaxedSecretMatcher),
+			},
+		},
 		"if an owned secret exists and contains data valid for the spec, do nothing'": {
 			certificate: &cmapi.Certificate{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
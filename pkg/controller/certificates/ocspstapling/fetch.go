@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspstapling
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// fetchTimeout is the time a request to an OCSP responder may take.
+	fetchTimeout = 10 * time.Second
+
+	// maxResponseSize is the largest OCSP response which is read from a
+	// responder.
+	maxResponseSize = 1 << 20
+)
+
+// certificatesForSecret returns the leaf certificate stored in a Secret and
+// the certificate of its issuer, which is either the second certificate of
+// the chain or the CA certificate.
+func certificatesForSecret(secret *corev1.Secret) (*x509.Certificate, *x509.Certificate, error) {
+	chain, err := pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	leaf := chain[0]
+	if len(chain) > 1 {
+		return leaf, chain[1], nil
+	}
+
+	if ca, err := pki.DecodeX509CertificateBytes(secret.Data[cmmeta.TLSCAKey]); err == nil && leaf.CheckSignatureFrom(ca) == nil {
+		return leaf, ca, nil
+	}
+	return nil, nil, errors.New("the certificate of the issuer of the certificate was not found in the Secret")
+}
+
+// fetchOCSPResponse requests the OCSP response for leaf from the OCSP
+// responders named in it, in order, and returns the DER encoded response of
+// the first responder which returns a valid response.
+func fetchOCSPResponse(ctx context.Context, leaf, issuer *x509.Certificate) ([]byte, error) {
+	if len(leaf.OCSPServer) == 0 {
+		return nil, errors.New("the certificate does not name an OCSP responder")
+	}
+
+	req, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OCSP request: %w", err)
+	}
+
+	var errs []error
+	for _, server := range leaf.OCSPServer {
+		der, err := requestOCSPResponse(ctx, server, req)
+		if err == nil {
+			_, err = ocsp.ParseResponseForCert(der, leaf, issuer)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", server, err))
+			continue
+		}
+		return der, nil
+	}
+	return nil, fmt.Errorf("error fetching OCSP response: %v", errs)
+}
+
+func requestOCSPResponse(ctx context.Context, server string, req []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspstapling
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestCertificatesForSecret(t *testing.T) {
+	testPKI := mustCreatePKI(t, "http://ocsp.example.com")
+	other := mustCreatePKI(t, "http://ocsp.example.com")
+
+	tests := map[string]struct {
+		data      map[string][]byte
+		expErr    bool
+		expIssuer []byte
+	}{
+		"the issuer is the second certificate of the chain": {
+			data:      map[string][]byte{corev1.TLSCertKey: append(append([]byte{}, testPKI.leafPEM...), testPKI.caPEM...)},
+			expIssuer: testPKI.ca.Raw,
+		},
+		"the issuer is the CA certificate": {
+			data:      map[string][]byte{corev1.TLSCertKey: testPKI.leafPEM, cmmeta.TLSCAKey: testPKI.caPEM},
+			expIssuer: testPKI.ca.Raw,
+		},
+		"a CA certificate which did not sign the certificate is not its issuer": {
+			data:   map[string][]byte{corev1.TLSCertKey: testPKI.leafPEM, cmmeta.TLSCAKey: other.caPEM},
+			expErr: true,
+		},
+		"no certificate": {
+			data:   map[string][]byte{cmmeta.TLSCAKey: testPKI.caPEM},
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			leaf, issuer, err := certificatesForSecret(&corev1.Secret{Data: test.data})
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testPKI.leaf.Raw, leaf.Raw)
+			assert.Equal(t, test.expIssuer, issuer.Raw)
+		})
+	}
+}
+
+func TestFetchOCSPResponse(t *testing.T) {
+	var testPKI testPKI
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+		assert.Equal(t, testPKI.leaf.SerialNumber, req.SerialNumber)
+		w.Write(testPKI.mustCreateResponse(t, time.Now(), time.Now().Add(time.Hour)))
+	}))
+	defer responder.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	// A failing responder is skipped.
+	testPKI = mustCreatePKI(t, failing.URL)
+	testPKI.leaf.OCSPServer = append(testPKI.leaf.OCSPServer, responder.URL)
+	der, err := fetchOCSPResponse(context.Background(), testPKI.leaf, testPKI.ca)
+	require.NoError(t, err)
+	resp, err := ocsp.ParseResponseForCert(der, testPKI.leaf, testPKI.ca)
+	require.NoError(t, err)
+	assert.Equal(t, ocsp.Good, resp.Status)
+
+	testPKI.leaf.OCSPServer = []string{failing.URL}
+	_, err = fetchOCSPResponse(context.Background(), testPKI.leaf, testPKI.ca)
+	assert.Error(t, err)
+
+	testPKI.leaf.OCSPServer = nil
+	_, err = fetchOCSPResponse(context.Background(), testPKI.leaf, testPKI.ca)
+	assert.EqualError(t, err, "the certificate does not name an OCSP responder")
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspstapling
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the OCSP stapling controller.
+	ControllerName = "certificates-ocsp-stapling"
+
+	reasonFetchFailed = "OCSPFetchFailed"
+
+	// retryPeriod is the time after which a failed OCSP request is retried,
+	// and the earliest time after which a response is fetched again.
+	retryPeriod = time.Minute
+
+	// defaultRefreshInterval is the time after which a response without a
+	// next update time is fetched again.
+	defaultRefreshInterval = time.Hour
+)
+
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	kubeClient        kubernetes.Interface
+	recorder          record.EventRecorder
+	clock             clock.Clock
+	queue             workqueue.RateLimitingInterface
+
+	// fetch returns the DER encoded OCSP response for the given certificate.
+	// Named here to make testing simpler.
+	fetch func(ctx context.Context, leaf, issuer *x509.Certificate) ([]byte, error)
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
+}
+
+// NewController returns a new OCSP stapling controller.
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		secretsInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretsInformer.Lister(),
+		kubeClient:        kubeClient,
+		recorder:          recorder,
+		clock:             clock,
+		queue:             queue,
+		fetch:             fetchOCSPResponse,
+	}, queue, mustSync
+}
+
+// ProcessItem stores the OCSP response for the certificate in the Secret of
+// a Certificate with `spec.ocspStapling` set. A stored response is kept until
+// it is due to be refreshed, or until it no longer matches the certificate
+// stored in the Secret.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
+	if crt.Spec.ObserveOnly {
+		// The Secrets of observe-only Certificates are never modified.
+		log.V(logf.DebugLevel).Info("skipping observe-only certificate")
+		return nil
+	}
+
+	log = logf.WithResource(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("secret not found", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if crt.Spec.OCSPStapling == nil {
+		// Remove the response of a stapling which is no longer configured.
+		if _, ok := secret.Data[cmmeta.OCSPResponseKey]; !ok {
+			return nil
+		}
+		secret = secret.DeepCopy()
+		delete(secret.Data, cmmeta.OCSPResponseKey)
+		_, err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	}
+
+	leaf, issuer, err := certificatesForSecret(secret)
+	if err != nil {
+		// The Secret is updated once a certificate has been issued, which
+		// will cause the Certificate to be re-synced.
+		log.V(logf.DebugLevel).Info("secret does not contain a certificate and its issuer", "error", err.Error())
+		return nil
+	}
+
+	if existing, err := ocsp.ParseResponseForCert(secret.Data[cmmeta.OCSPResponseKey], leaf, issuer); err == nil {
+		if wait := refreshTime(crt, existing).Sub(c.clock.Now()); wait > 0 {
+			c.queue.AddAfter(key, wait)
+			return nil
+		}
+	}
+
+	der, err := c.fetch(ctx, leaf, issuer)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonFetchFailed, "Failed to fetch OCSP response: %v", err)
+		c.queue.AddAfter(key, retryPeriod)
+		return nil
+	}
+	resp, err := ocsp.ParseResponseForCert(der, leaf, issuer)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(secret.Data[cmmeta.OCSPResponseKey], der) {
+		log.V(logf.InfoLevel).Info("storing OCSP response", "status", resp.Status, "this_update", resp.ThisUpdate, "next_update", resp.NextUpdate)
+		secret = secret.DeepCopy()
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[cmmeta.OCSPResponseKey] = der
+		if _, err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	// A responder may return a response which is already due to be
+	// refreshed, which is not fetched again before the retry period.
+	wait := refreshTime(crt, resp).Sub(c.clock.Now())
+	if wait < retryPeriod {
+		wait = retryPeriod
+	}
+	c.queue.AddAfter(key, wait)
+	return nil
+}
+
+// refreshTime returns the time at which the OCSP response resp for the
+// Certificate should be fetched again. This is once half of the response's
+// validity period has elapsed, or after the Certificate's refresh interval if
+// that is earlier.
+func refreshTime(crt *cmapi.Certificate, resp *ocsp.Response) time.Time {
+	refresh := resp.ThisUpdate.Add(defaultRefreshInterval)
+	if !resp.NextUpdate.IsZero() {
+		refresh = resp.ThisUpdate.Add(resp.NextUpdate.Sub(resp.ThisUpdate) / 2)
+	}
+
+	if interval := crt.Spec.OCSPStapling.RefreshInterval; interval != nil {
+		if t := resp.ThisUpdate.Add(interval.Duration); t.Before(refresh) {
+			refresh = t
+		}
+	}
+
+	return refresh
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+	)
+	ctrl.issuerClass = ctx.IssuerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspstapling
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// testPKI is a CA and a certificate signed by it.
+type testPKI struct {
+	ca    *x509.Certificate
+	caKey crypto.Signer
+	leaf  *x509.Certificate
+
+	caPEM, leafPEM []byte
+}
+
+// mustCreatePKI returns a CA and a certificate signed by it, which names
+// ocspServer as its OCSP responder.
+func mustCreatePKI(t *testing.T, ocspServer string) testPKI {
+	caKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl, err := pki.GenerateTemplate(gen.Certificate("ca", gen.SetCertificateCommonName("ca"), gen.SetCertificateIsCA(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPEM, ca, err := pki.SignCertificate(caTmpl, caTmpl, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTmpl, err := pki.GenerateTemplate(gen.Certificate("leaf", gen.SetCertificateDNSNames("example.com")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTmpl.OCSPServer = []string{ocspServer}
+	leafPEM, leaf, err := pki.SignCertificate(leafTmpl, ca, leafKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testPKI{ca: ca, caKey: caKey, leaf: leaf, caPEM: caPEM, leafPEM: leafPEM}
+}
+
+// mustCreateResponse returns a DER encoded OCSP response for the certificate.
+func (p testPKI) mustCreateResponse(t *testing.T, thisUpdate, nextUpdate time.Time) []byte {
+	der, err := ocsp.CreateResponse(p.ca, p.ca, ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: p.leaf.SerialNumber,
+		ThisUpdate:   thisUpdate,
+		NextUpdate:   nextUpdate,
+	}, p.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestProcessItem(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	testPKI := mustCreatePKI(t, "http://ocsp.example.com")
+
+	current := testPKI.mustCreateResponse(t, now.Add(-time.Hour), now.Add(7*24*time.Hour))
+	due := testPKI.mustCreateResponse(t, now.Add(-4*24*time.Hour), now.Add(2*24*time.Hour))
+	fetched := testPKI.mustCreateResponse(t, now, now.Add(7*24*time.Hour))
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-tls"),
+	)
+	stapling := gen.SetCertificateOCSPStapling(cmapi.CertificateOCSPStapling{})
+	secret := func(ocspResponse []byte) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+			Data: map[string][]byte{
+				corev1.TLSCertKey: testPKI.leafPEM,
+				cmmeta.TLSCAKey:   testPKI.caPEM,
+			},
+		}
+		if ocspResponse != nil {
+			s.Data[cmmeta.OCSPResponseKey] = ocspResponse
+		}
+		return s
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		secret      *corev1.Secret
+		// fetched is the response returned by the responder, if it is not
+		// the current response.
+		fetched  []byte
+		fetchErr error
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"do nothing if OCSP stapling is not configured": {
+			certificate: baseCrt,
+			secret:      secret(nil),
+		},
+		"remove the OCSP response if OCSP stapling is no longer configured": {
+			certificate: baseCrt,
+			secret:      secret(current),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", secret(nil))),
+			},
+		},
+		"do nothing if the Secret does not exist": {
+			certificate: gen.CertificateFrom(baseCrt, stapling),
+		},
+		"store the OCSP response in the Secret": {
+			certificate: gen.CertificateFrom(baseCrt, stapling),
+			secret:      secret(nil),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", secret(fetched))),
+			},
+		},
+		"do nothing if the stored OCSP response is not due to be refreshed": {
+			certificate: gen.CertificateFrom(baseCrt, stapling),
+			secret:      secret(current),
+		},
+		"refresh the OCSP response once half of its validity period has elapsed": {
+			certificate: gen.CertificateFrom(baseCrt, stapling),
+			secret:      secret(due),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", secret(fetched))),
+			},
+		},
+		"do not update the Secret if the refreshed OCSP response has not changed": {
+			certificate: gen.CertificateFrom(baseCrt, stapling),
+			secret:      secret(due),
+			fetched:     due,
+		},
+		"refresh the OCSP response once the refresh interval has elapsed": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateOCSPStapling(cmapi.CertificateOCSPStapling{
+				RefreshInterval: &metav1.Duration{Duration: 30 * time.Minute},
+			})),
+			secret: secret(current),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", secret(fetched))),
+			},
+		},
+		"replace an OCSP response which is not for the stored certificate": {
+			certificate: gen.CertificateFrom(baseCrt, stapling),
+			secret:      secret(mustCreatePKI(t, "http://ocsp.example.com").mustCreateResponse(t, now, now.Add(7*24*time.Hour))),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", secret(fetched))),
+			},
+		},
+		"fire an event if the OCSP response cannot be fetched": {
+			certificate:    gen.CertificateFrom(baseCrt, stapling),
+			secret:         secret(nil),
+			fetchErr:       errors.New("connection refused"),
+			expectedEvents: []string{"Warning OCSPFetchFailed Failed to fetch OCSP response: connection refused"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var kubeObjects []runtime.Object
+			if test.secret != nil {
+				kubeObjects = append(kubeObjects, test.secret)
+			}
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				KubeObjects:        kubeObjects,
+				CertManagerObjects: []runtime.Object{test.certificate},
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.fetch = func(context.Context, *x509.Certificate, *x509.Certificate) ([]byte, error) {
+				if test.fetched != nil {
+					return test.fetched, test.fetchErr
+				}
+				return fetched, test.fetchErr
+			}
+			builder.Start()
+			defer builder.Stop()
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err := builder.AllEventsCalled(); err != nil {
+				builder.T.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
@@ -56,13 +57,17 @@ const (
 )
 
 type controller struct {
-	// the policies to use to define readiness - named here to make testing simpler
+	// the policies to use to define readiness, of Certificates and of
+	// observe-only Certificates - named here to make testing simpler
 	policyChain              policies.Chain
+	observerPolicyChain      policies.Chain
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             corelisters.SecretLister
 	client                   cmclient.Interface
 	gatherer                 *policies.Gatherer
+	clock                    clock.Clock
+	queue                    workqueue.RateLimitingInterface
 	// policyEvaluator builds Ready condition of a Certificate based on policy evaluation
 	policyEvaluator policyEvaluatorFunc
 	// renewalTimeCalculator calculates renewal time of a certificate
@@ -72,6 +77,10 @@ type controller struct {
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
 }
 
 // readyConditionFunc is custom function type that builds certificate's Ready condition
@@ -83,7 +92,9 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	clock clock.Clock,
 	chain policies.Chain,
+	observerChain policies.Chain,
 	renewalTimeCalculator certificates.RenewalTimeFunc,
 	policyEvaluator policyEvaluatorFunc,
 	fieldManager string,
@@ -119,10 +130,13 @@ func NewController(
 
 	return &controller{
 		policyChain:              chain,
+		observerPolicyChain:      observerChain,
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
 		client:                   client,
+		clock:                    clock,
+		queue:                    queue,
 		gatherer: &policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
@@ -135,7 +149,8 @@ func NewController(
 
 // ProcessItem is a worker function that will be called when a new key
 // corresponding to a Certificate to be re-synced is pulled from the workqueue.
-// ProcessItem will update the Ready condition of a Certificate.
+// ProcessItem will update the Ready condition of a Certificate, and its
+// ExpiryWarning condition if its renewal is disabled or it is observe-only.
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx).WithValues("key", key)
 
@@ -155,12 +170,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	input, err := c.gatherer.DataForCertificate(ctx, crt)
 	if err != nil {
 		return err
 	}
 
-	condition := c.policyEvaluator(c.policyChain, input)
+	chain := c.policyChain
+	if crt.Spec.ObserveOnly {
+		chain = c.observerPolicyChain
+	}
+	// Observe-only Certificates are never renewed by cert-manager.
+	renewalDisabled := crt.Spec.RenewalDisabled || crt.Spec.ObserveOnly
+
+	condition := c.policyEvaluator(chain, input)
 	oldCrt := crt
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, condition.Type, condition.Status, condition.Reason, condition.Message)
@@ -173,6 +200,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 			crt.Status.NotAfter = nil
 			crt.Status.NotBefore = nil
 			crt.Status.RenewalTime = nil
+			crt.Status.Observed = nil
 			break
 		}
 
@@ -180,17 +208,37 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		renewBeforeHint := crt.Spec.RenewBefore
 		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, x509cert.NotAfter, renewBeforeHint)
+		renewalTime = certificates.RenewalTimeInWindows(renewalTime, x509cert.NotAfter, crt.Spec.RenewalWindows)
+		if renewalDisabled {
+			// No renewal is scheduled for a certificate which is not
+			// renewed automatically. Instead, a warning is raised as it
+			// nears expiry.
+			renewalTime = nil
+			expiryWarning, next := internalcertificates.ExpiryWarningCondition(c.clock.Now(), x509cert.NotAfter)
+			apiutil.SetCertificateCondition(crt, crt.Generation, expiryWarning.Type, expiryWarning.Status, expiryWarning.Reason, expiryWarning.Message)
+			if !next.IsZero() {
+				c.queue.AddAfter(key, next.Sub(c.clock.Now()))
+			}
+		}
 
 		//update Certificate's Status
 		crt.Status.NotBefore = &notBefore
 		crt.Status.NotAfter = &notAfter
 		crt.Status.RenewalTime = renewalTime
+		crt.Status.Observed = nil
+		if crt.Spec.ObserveOnly {
+			crt.Status.Observed = internalcertificates.Observation(x509cert)
+		}
 
 	default:
 		// clear status fields if the secret does not have any data
 		crt.Status.NotAfter = nil
 		crt.Status.NotBefore = nil
 		crt.Status.RenewalTime = nil
+		crt.Status.Observed = nil
+	}
+	if !renewalDisabled || crt.Status.NotAfter == nil {
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionExpiryWarning)
 	}
 	if !apiequality.Semantic.DeepEqual(oldCrt.Status, crt.Status) {
 		log.V(logf.DebugLevel).Info("updating status fields", "notAfter",
@@ -207,8 +255,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+		for _, conditionType := range []cmapi.CertificateConditionType{cmapi.CertificateConditionReady, cmapi.CertificateConditionExpiryWarning} {
+			if cond := apiutil.GetCertificateCondition(crt, conditionType); cond != nil {
+				conditions = append(conditions, *cond)
+			}
 		}
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
@@ -216,6 +266,7 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 				NotAfter:    crt.Status.NotAfter,
 				NotBefore:   crt.Status.NotBefore,
 				RenewalTime: crt.Status.RenewalTime,
+				Observed:    crt.Status.Observed,
 				Conditions:  conditions,
 			},
 		})
@@ -258,11 +309,14 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Clock,
 		policies.NewReadinessPolicyChain(ctx.Clock),
+		policies.NewObserverReadinessPolicyChain(ctx.Clock),
 		certificates.RenewalTime,
 		BuildReadyConditionFromChain,
 		ctx.FieldManager,
 	)
+	ctrl.issuerClass = ctx.IssuerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -26,12 +26,15 @@ import (
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -101,6 +104,14 @@ func TestProcessItem(t *testing.T) {
 		// renewalTime will be the updated Certificate's status.renewalTime
 		renewalTime *metav1.Time
 
+		// expiryWarning is the ExpiryWarning condition expected to be set
+		// with the update, if any
+		expiryWarning *cmapi.CertificateCondition
+
+		// whether the details of the X509 cert are expected to be set as
+		// the updated Certificate's status.observed
+		observed bool
+
 		wantsErr bool
 	}{
 		"do nothing if an empty 'key' is used": {},
@@ -140,6 +151,74 @@ func TestProcessItem(t *testing.T) {
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
 		},
+		"set the ExpiryWarning condition and no renewal time for a Certificate with renewal disabled": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert, gen.SetCertificateRenewalDisabled(true)),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 24 * 10).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			expiryWarning: &cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionExpiryWarning,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             "ExpiresWithin14Days",
+				Message:            "Certificate expires on " + now.Add(time.Hour*24*10).Truncate(time.Second).Format(time.RFC1123) + " and is not renewed automatically as renewal is disabled",
+				LastTransitionTime: &metaNow,
+			},
+		},
+		"set the observed details and the ExpiryWarning condition for an observe-only Certificate": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert, gen.SetCertificateObserveOnly(true)),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 24 * 60).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			expiryWarning: &cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionExpiryWarning,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             internalcertificates.NotExpiringReason,
+				Message:            "Certificate does not expire within 30 days",
+				LastTransitionTime: &metaNow,
+			},
+			observed: true,
+		},
+		"remove the ExpiryWarning condition from a Certificate whose renewal is no longer disabled": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert: gen.CertificateFrom(cert,
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               cmapi.CertificateConditionReady,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             ReadyReason,
+					Message:            "ready message",
+					LastTransitionTime: &metaNow,
+				}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionExpiryWarning,
+					Status: cmmeta.ConditionTrue,
+					Reason: "ExpiresWithin7Days",
+				}),
+			),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+		},
 		"update status for a Certificate whose spec.secretName secret does not exist": {
 			condition: cmapi.CertificateCondition{
 				Type:               cmapi.CertificateConditionReady,
@@ -249,11 +328,12 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.cert)
 			}
 
+			var x509Bytes []byte
 			if test.secretShouldExist {
 				mods := make([]gen.SecretModifier, 0)
 				// If the test scenario needs a secret with a valid X509 cert.
 				if test.notBefore != nil && test.notAfter != nil {
-					x509Bytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
+					x509Bytes = testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
 					mods = append(mods,
 						gen.SetSecretData(map[string][]byte{
 							"tls.crt": x509Bytes,
@@ -284,11 +364,22 @@ func TestProcessItem(t *testing.T) {
 			if test.certShouldUpdate {
 				c := gen.CertificateFrom(test.cert,
 					gen.SetCertificateStatusCondition(test.condition))
+				apiutil.RemoveCertificateCondition(c, cmapi.CertificateConditionExpiryWarning)
+				if test.expiryWarning != nil {
+					c.Status.Conditions = append(c.Status.Conditions, *test.expiryWarning)
+				}
 
 				// gen package functions don't accept pointers- we need to test setting these values to nil in some scenarios.
 				c.Status.NotAfter = test.notAfter
 				c.Status.NotBefore = test.notBefore
 				c.Status.RenewalTime = test.renewalTime
+				if test.observed {
+					x509cert, err := pki.DecodeX509CertificateBytes(x509Bytes)
+					if err != nil {
+						t.Fatal(err)
+					}
+					c.Status.Observed = internalcertificates.Observation(x509cert)
+				}
 
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -74,6 +74,10 @@ type controller struct {
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Create or Apply API calls.
 	fieldManager string
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
 }
 
 func NewController(
@@ -147,6 +151,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -200,7 +209,41 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, pk.Public(), requests...)
+	requests, additionalRequests := certificates.SplitAdditionalPrivateKeyRequests(requests)
+	if err := c.ensureRequest(ctx, crt, pk, requests, nextRevision, nextPrivateKeySecret.Name, false); err != nil {
+		return err
+	}
+
+	acrt := certificates.AdditionalPrivateKeyCertificate(crt)
+	if acrt == nil {
+		// Delete any CertificateRequests left behind by an additional private
+		// key which has since been removed from the Certificate.
+		for _, req := range additionalRequests {
+			logf.WithRelatedResource(log, req).V(logf.DebugLevel).Info("Deleting CertificateRequest as the Certificate no longer has an additional private key")
+			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	apk, err := pki.DecodePrivateKeyBytes(nextPrivateKeySecret.Data[cmmeta.AdditionalTLSPrivateKeyKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("Next private key secret does not contain a valid additional private key, waiting for keymanager before processing certificate")
+		return nil
+	}
+
+	return c.ensureRequest(ctx, acrt, apk, additionalRequests, nextRevision, nextPrivateKeySecret.Name, true)
+}
+
+// ensureRequest ensures that a single CertificateRequest for the given private
+// key and the next revision exists and is up to date with the Certificate's
+// spec. For the additional private key, the Certificate given must be the one
+// returned by certificates.AdditionalPrivateKeyCertificate.
+func (c *controller) ensureRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, requests []*cmapi.CertificateRequest, nextRevision int, nextPrivateKeySecretName string, additional bool) error {
+	log := logf.FromContext(ctx)
+
+	requests, err := c.deleteRequestsNotMatchingSpec(ctx, crt, pk.Public(), requests...)
 	if err != nil {
 		return err
 	}
@@ -214,7 +257,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		// TODO: we should handle this case better, but for now do nothing to
 		//  avoid getting into loops where we keep creating multiple requests
 		//  and deleting them again.
-		log.V(logf.ErrorLevel).Info("Multiple matching CertificateRequest resources exist, delete one of them. This is likely an error and should be reported on the issue tracker!")
+		log.V(logf.ErrorLevel).Info("Multiple matching CertificateRequest resources exist, delete one of them. This is likely an error and should be reported on the issue tracker!", "additional_private_key", additional)
 		return nil
 	}
 
@@ -224,7 +267,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name)
+	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecretName, additional)
 }
 
 func (c *controller) deleteCurrentFailedRequests(ctx context.Context, crt *cmapi.Certificate, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
@@ -355,7 +398,7 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 	return remaining, nil
 }
 
-func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) error {
+func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string, additional bool) error {
 	log := logf.FromContext(ctx)
 	x509CSR, err := pki.GenerateCSR(crt)
 	if err != nil {
@@ -377,6 +420,9 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = strconv.Itoa(nextRevision)
 	annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	if additional {
+		annotations[cmapi.CertificateRequestAdditionalPrivateKeyAnnotationKey] = "true"
+	}
 
 	cr := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -440,6 +486,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CertificateOptions,
 		ctx.FieldManager,
 	)
+	ctrl.issuerClass = ctx.IssuerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
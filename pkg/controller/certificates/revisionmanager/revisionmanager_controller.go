@@ -51,6 +51,10 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	client                   cmclient.Interface
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
 }
 
 type revision struct {
@@ -110,6 +114,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	log = logf.WithResource(log, crt)
 
 	// If RevisionHistoryLimit is nil, don't attempt to garbage collect old
@@ -134,8 +143,12 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	// Fetch and delete all CertificateRequests that need to be deleted
+	// The CertificateRequests for the additional private key are garbage
+	// collected separately, so that the same revisions of both are kept.
 	limit := int(*crt.Spec.RevisionHistoryLimit)
-	toDelete := certificateRequestsToDelete(log, limit, requests)
+	requests, additionalRequests := certificates.SplitAdditionalPrivateKeyRequests(requests)
+	toDelete := append(certificateRequestsToDelete(log, limit, requests),
+		certificateRequestsToDelete(log, limit, additionalRequests)...)
 
 	for _, req := range toDelete {
 		logf.WithRelatedResourceName(log, req.Name, req.Namespace, cmapi.CertificateRequestKind).
@@ -206,6 +219,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	log := logf.FromContext(ctx.RootContext, ControllerName)
 
 	ctrl, queue, mustSync := NewController(log, ctx.CMClient, ctx.SharedInformerFactory)
+	ctrl.issuerClass = ctx.IssuerClass
 	c.controller = ctrl
 
 	return queue, mustSync, nil
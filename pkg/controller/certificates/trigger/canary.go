@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// canaryPending returns true if the candidate certificate of the next
+// revision of the Certificate has been stored in its canary shadow Secret and
+// is waiting to be verified. The issuing controller sets the Issuing condition
+// to False while the candidate is being verified, and issuance must only be
+// triggered again once it has been verified, so that it can be promoted.
+func (c *controller) canaryPending(crt *cmapi.Certificate, input policies.Input) (bool, error) {
+	if crt.Spec.Canary == nil || input.NextRevisionRequest == nil {
+		return false, nil
+	}
+
+	shadow, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.Canary.SecretName)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	request := shadow.Annotations[cmapi.CanaryRequestAnnotationKey]
+	return request == input.NextRevisionRequest.Name && shadow.Annotations[cmapi.CanaryVerifiedAnnotationKey] != request, nil
+}
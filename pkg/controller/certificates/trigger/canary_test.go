@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_controller_canaryPending(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("output"),
+	)
+	canaryCrt := gen.CertificateFrom(crt, func(crt *cmapi.Certificate) {
+		crt.Spec.Canary = &cmapi.CertificateCanary{SecretName: "output-canary"}
+	})
+	nextReq := gen.CertificateRequest("test-2", gen.SetCertificateRequestNamespace("testns"))
+
+	shadow := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "output-canary", Annotations: annotations}}
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		nextReq     *cmapi.CertificateRequest
+		secrets     []runtime.Object
+		expPending  bool
+	}{
+		"if no canary is configured, should return false": {
+			certificate: crt,
+			nextReq:     nextReq,
+			secrets:     []runtime.Object{shadow(map[string]string{cmapi.CanaryRequestAnnotationKey: "test-2"})},
+		},
+		"if the shadow Secret does not exist, should return false": {
+			certificate: canaryCrt,
+			nextReq:     nextReq,
+		},
+		"if there is no request for the next revision, should return false": {
+			certificate: canaryCrt,
+			secrets:     []runtime.Object{shadow(map[string]string{cmapi.CanaryRequestAnnotationKey: "test-2"})},
+		},
+		"if the shadow Secret holds the candidate of a previous request, should return false": {
+			certificate: canaryCrt,
+			nextReq:     nextReq,
+			secrets:     []runtime.Object{shadow(map[string]string{cmapi.CanaryRequestAnnotationKey: "test-1"})},
+		},
+		"if the candidate of the next revision has been verified, should return false": {
+			certificate: canaryCrt,
+			nextReq:     nextReq,
+			secrets: []runtime.Object{shadow(map[string]string{
+				cmapi.CanaryRequestAnnotationKey:  "test-2",
+				cmapi.CanaryVerifiedAnnotationKey: "test-2",
+			})},
+		},
+		"if the candidate of the next revision has not been verified, should return true": {
+			certificate: canaryCrt,
+			nextReq:     nextReq,
+			secrets:     []runtime.Object{shadow(map[string]string{cmapi.CanaryRequestAnnotationKey: "test-2"})},
+			expPending:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{T: t, KubeObjects: test.secrets}
+			builder.Init()
+			c := &controller{secretLister: builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister()}
+			builder.Start()
+			defer builder.Stop()
+
+			pending, err := c.canaryPending(test.certificate, policies.Input{Certificate: test.certificate, NextRevisionRequest: test.nextReq})
+			require.NoError(t, err)
+			assert.Equal(t, test.expPending, pending)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+// watchIssuerSecrets registers an event handler which re-queues the
+// Certificates whose last issuance failed when a Secret read by their issuer
+// is created or its data changes, for example when an expired API token is
+// replaced. The failed issuance back-off is skipped for these Certificates
+// so that the fix is picked up immediately.
+func (c *controller) watchIssuerSecrets(log logr.Logger, ctx *controllerpkg.Context, queue workqueue.Interface) []cache.InformerSynced {
+	log = log.WithName("issuer-secrets")
+
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	c.issuerLister = issuerInformer.Lister()
+	mustSync := []cache.InformerSynced{issuerInformer.Informer().HasSynced}
+
+	// ClusterIssuers are only used if cert-manager is not scoped to a
+	// single namespace.
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+
+	// Secrets which already exist when the informer starts are also observed
+	// as added, but have not been created to fix a failing issuer.
+	startTime := c.clock.Now()
+	ctx.KubeSharedInformerFactory.Core().V1().Secrets().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if secret, ok := obj.(*corev1.Secret); ok && secret.CreationTimestamp.Time.After(startTime) {
+				c.issuerSecretChanged(log, queue, secret)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, oldOK := oldObj.(*corev1.Secret)
+			newSecret, newOK := newObj.(*corev1.Secret)
+			if oldOK && newOK && !reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+				c.issuerSecretChanged(log, queue, newSecret)
+			}
+		},
+	})
+
+	return mustSync
+}
+
+// issuerSecretChanged re-queues the failing Certificates of all Issuers and
+// ClusterIssuers which read the given Secret.
+func (c *controller) issuerSecretChanged(log logr.Logger, queue workqueue.Interface, secret *corev1.Secret) {
+	issuers, err := c.issuerLister.Issuers(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list Issuers")
+		return
+	}
+	for _, iss := range issuers {
+		if issuerReadsSecret(&iss.Spec, secret.Name) {
+			c.retriggerFailedCertificates(log, queue, c.certificateLister.Certificates(iss.Namespace), cmapi.IssuerKind, iss.Name)
+		}
+	}
+
+	if c.clusterIssuerLister == nil || secret.Namespace != c.clusterResourceNamespace {
+		return
+	}
+	clusterIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "failed to list ClusterIssuers")
+		return
+	}
+	for _, iss := range clusterIssuers {
+		if issuerReadsSecret(&iss.Spec, secret.Name) {
+			c.retriggerFailedCertificates(log, queue, c.certificateLister.Certificates(corev1.NamespaceAll), cmapi.ClusterIssuerKind, iss.Name)
+		}
+	}
+}
+
+func (c *controller) retriggerFailedCertificates(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateNamespaceLister, kind, name string) {
+	crts, err := certificates.ListCertificatesMatchingPredicates(lister, labels.Everything(),
+		predicate.CertificateIssuerRef(kind, name), predicate.CertificateFailedIssuance())
+	if err != nil {
+		log.Error(err, "failed to list Certificates")
+		return
+	}
+
+	now := c.clock.Now()
+	for _, crt := range crts {
+		key, err := controllerpkg.KeyFunc(crt)
+		if err != nil {
+			log.Error(err, "error determining 'key' for resource")
+			continue
+		}
+		c.issuerSecretUpdates.Store(key, now)
+		queue.Add(key)
+	}
+}
+
+// issuerSecretUpdatedSinceFailure returns true if a Secret read by the issuer
+// of the Certificate with the given key has changed since its last failed
+// issuance. The recorded change is forgotten once it has been checked.
+func (c *controller) issuerSecretUpdatedSinceFailure(key string, crt *cmapi.Certificate) bool {
+	updated, ok := c.issuerSecretUpdates.LoadAndDelete(key)
+	if !ok || crt.Status.LastFailureTime == nil {
+		return false
+	}
+	return updated.(time.Time).After(crt.Status.LastFailureTime.Time)
+}
+
+func issuerReadsSecret(spec *cmapi.IssuerSpec, secretName string) bool {
+	for _, name := range issuer.SecretNames(spec) {
+		if name == secretName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_controller_issuerSecretChanged(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	lastFailureTime := metav1.NewTime(fixedNow.Add(-time.Minute))
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	caIssuer := func(secretName string) gen.IssuerModifier {
+		return gen.SetIssuerCA(cmapi.CAIssuer{SecretName: secretName})
+	}
+	failingCertificate := func(name, namespace string, ref cmmeta.ObjectReference) *cmapi.Certificate {
+		return gen.Certificate(name, gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateIssuer(ref),
+			gen.SetCertificateLastFailureTime(lastFailureTime))
+	}
+
+	tests := map[string]struct {
+		secretNamespace string
+		secretName      string
+		expKeys         []string
+	}{
+		"failing Certificates of an Issuer reading the Secret are re-queued": {
+			secretNamespace: "testns",
+			secretName:      "issuer-secret",
+			expKeys:         []string{"testns/failing", "testns/failing-default-kind"},
+		},
+		"failing Certificates of a ClusterIssuer reading the Secret are re-queued": {
+			secretNamespace: "cert-manager",
+			secretName:      "cluster-issuer-secret",
+			expKeys:         []string{"otherns/failing-cluster", "testns/failing-cluster"},
+		},
+		"Secret of a ClusterIssuer outside the cluster resource namespace is ignored": {
+			secretNamespace: "testns",
+			secretName:      "cluster-issuer-secret",
+		},
+		"Secret which is not read by an issuer is ignored": {
+			secretNamespace: "testns",
+			secretName:      "other-secret",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:     t,
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					gen.Issuer("issuer", gen.SetIssuerNamespace("testns"), caIssuer("issuer-secret")),
+					gen.ClusterIssuer("cluster-issuer", caIssuer("cluster-issuer-secret")),
+					failingCertificate("failing", "testns", cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.IssuerKind}),
+					failingCertificate("failing-default-kind", "testns", cmmeta.ObjectReference{Name: "issuer"}),
+					failingCertificate("failing-other-issuer", "testns", cmmeta.ObjectReference{Name: "other", Kind: cmapi.IssuerKind}),
+					failingCertificate("failing-cluster", "testns", cmmeta.ObjectReference{Name: "cluster-issuer", Kind: cmapi.ClusterIssuerKind}),
+					failingCertificate("failing-cluster", "otherns", cmmeta.ObjectReference{Name: "cluster-issuer", Kind: cmapi.ClusterIssuerKind}),
+					gen.Certificate("issued", gen.SetCertificateNamespace("testns"),
+						gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.IssuerKind})),
+				},
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "cert-manager"
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			queue := workqueue.New()
+			defer queue.ShutDown()
+			w.controller.issuerSecretChanged(logtesting.NewTestLogger(t), queue,
+				gen.Secret(test.secretName, gen.SetSecretNamespace(test.secretNamespace)))
+
+			var gotKeys []string
+			for queue.Len() > 0 {
+				key, _ := queue.Get()
+				gotKeys = append(gotKeys, key.(string))
+				queue.Done(key)
+			}
+			sort.Strings(gotKeys)
+			assert.Equal(t, test.expKeys, gotKeys)
+
+			for _, key := range test.expKeys {
+				crt := gen.Certificate("", gen.SetCertificateLastFailureTime(lastFailureTime))
+				assert.True(t, w.controller.issuerSecretUpdatedSinceFailure(key, crt), "expected %s to skip the back-off", key)
+				assert.False(t, w.controller.issuerSecretUpdatedSinceFailure(key, crt), "expected %s to only skip the back-off once", key)
+			}
+		})
+	}
+}
+
+func Test_controller_issuerSecretUpdatedSinceFailure(t *testing.T) {
+	now := time.Now()
+	c := &controller{}
+	c.issuerSecretUpdates.Store("testns/cert", now)
+
+	crt := gen.Certificate("cert", gen.SetCertificateLastFailureTime(metav1.NewTime(now.Add(time.Minute))))
+	assert.False(t, c.issuerSecretUpdatedSinceFailure("testns/cert", crt),
+		"a Secret change before the last failure must not skip the back-off")
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+)
+
+const reasonPrivateKeyRotationScheduled = "PrivateKeyRotationScheduled"
+
+// scheduledPrivateKeyRotation returns the time at which the private key of a
+// Certificate with the Scheduled rotation policy is next rotated, and the time
+// from which the rotation is announced. It returns false if no rotation is
+// scheduled.
+func scheduledPrivateKeyRotation(crt *cmapi.Certificate) (rotationTime, noticeTime time.Time, ok bool) {
+	pk := crt.Spec.PrivateKey
+	if pk == nil || pk.RotationPolicy != cmapi.RotationPolicyScheduled || crt.Status.PrivateKeyRotationTime == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	rotationTime = crt.Status.PrivateKeyRotationTime.Time
+	return rotationTime, rotationTime.Add(-certificates.PrivateKeyRotationAdvanceNotice(pk.RotationSchedule)), true
+}
+
+// nextRecheckTime returns the time at which the Certificate should next be
+// checked: its renewal time, the scheduled rotation time of its private key,
+// or the time from which that rotation is announced, whichever comes first.
+// Times which have passed are ignored for the announcement only, since the
+// Certificate is re-issued if its renewal or rotation time has passed. It
+// returns the zero time if no check is scheduled.
+func (c *controller) nextRecheckTime(crt *cmapi.Certificate) time.Time {
+	var next time.Time
+	consider := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+
+	if crt.Status.RenewalTime != nil {
+		consider(crt.Status.RenewalTime.Time)
+	}
+	if rotationTime, noticeTime, ok := scheduledPrivateKeyRotation(crt); ok {
+		consider(rotationTime)
+		if noticeTime.After(c.clock.Now()) {
+			consider(noticeTime)
+		}
+	}
+	return next
+}
+
+// announcePrivateKeyRotation emits an event once the scheduled rotation of
+// the private key of a Certificate is within its advance notice, so that the
+// next key can be prepared for, e.g. by publishing a backup pin or a TLSA
+// record. The event is emitted once per scheduled rotation, unless the
+// controller restarts in the meantime.
+func (c *controller) announcePrivateKeyRotation(key string, crt *cmapi.Certificate) {
+	rotationTime, noticeTime, ok := scheduledPrivateKeyRotation(crt)
+	if !ok {
+		c.privateKeyRotationNotices.Delete(key)
+		return
+	}
+
+	now := c.clock.Now()
+	if now.Before(noticeTime) || !now.Before(rotationTime) {
+		return
+	}
+	if announced, ok := c.privateKeyRotationNotices.Load(key); ok && announced.(time.Time).Equal(rotationTime) {
+		return
+	}
+
+	c.privateKeyRotationNotices.Store(key, rotationTime)
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonPrivateKeyRotationScheduled,
+		"The private key stored in Secret %q will be rotated at %s", crt.Spec.SecretName, rotationTime.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func scheduledRotationCertificate(rotationTime time.Time, mods ...gen.CertificateModifier) *cmapi.Certificate {
+	crt := gen.Certificate("crt", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("crt-tls"),
+		gen.SetCertificatePrivateKeyRotationSchedule(cmapi.PrivateKeyRotationSchedule{
+			Interval:      &metav1.Duration{Duration: 90 * 24 * time.Hour},
+			AdvanceNotice: &metav1.Duration{Duration: 24 * time.Hour},
+		}),
+		gen.SetCertificatePrivateKeyRotationTime(metav1.NewTime(rotationTime)),
+	)
+	return gen.CertificateFrom(crt, mods...)
+}
+
+func Test_controller_nextRecheckTime(t *testing.T) {
+	fixedNow := time.Now().Truncate(time.Second)
+	c := &controller{clock: fakeclock.NewFakeClock(fixedNow)}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		expTime     time.Time
+	}{
+		"no renewal or rotation scheduled": {
+			certificate: gen.Certificate("crt"),
+		},
+		"renewal time only": {
+			certificate: gen.Certificate("crt", gen.SetCertificateRenewalTime(metav1.NewTime(fixedNow.Add(time.Hour)))),
+			expTime:     fixedNow.Add(time.Hour),
+		},
+		"rotation is announced before the renewal": {
+			certificate: scheduledRotationCertificate(fixedNow.Add(48*time.Hour),
+				gen.SetCertificateRenewalTime(metav1.NewTime(fixedNow.Add(72*time.Hour)))),
+			expTime: fixedNow.Add(24 * time.Hour),
+		},
+		"rotation is due once it has been announced": {
+			certificate: scheduledRotationCertificate(fixedNow.Add(time.Hour),
+				gen.SetCertificateRenewalTime(metav1.NewTime(fixedNow.Add(72*time.Hour)))),
+			expTime: fixedNow.Add(time.Hour),
+		},
+		"renewal before the rotation is announced": {
+			certificate: scheduledRotationCertificate(fixedNow.Add(72*time.Hour),
+				gen.SetCertificateRenewalTime(metav1.NewTime(fixedNow.Add(time.Hour)))),
+			expTime: fixedNow.Add(time.Hour),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, test.expTime.Equal(c.nextRecheckTime(test.certificate)))
+		})
+	}
+}
+
+func Test_controller_announcePrivateKeyRotation(t *testing.T) {
+	fixedNow := time.Now().Truncate(time.Second)
+	rotationTime := fixedNow.Add(time.Hour)
+
+	recorder := new(testpkg.FakeRecorder)
+	c := &controller{clock: fakeclock.NewFakeClock(fixedNow), recorder: recorder}
+
+	c.announcePrivateKeyRotation("testns/crt", scheduledRotationCertificate(fixedNow.Add(48*time.Hour)))
+	assert.Empty(t, recorder.Events, "a rotation outside of the advance notice must not be announced")
+
+	crt := scheduledRotationCertificate(rotationTime)
+	c.announcePrivateKeyRotation("testns/crt", crt)
+	c.announcePrivateKeyRotation("testns/crt", crt)
+	assert.Equal(t, []string{
+		"Normal PrivateKeyRotationScheduled The private key stored in Secret \"crt-tls\" will be rotated at " + rotationTime.UTC().Format(time.RFC3339),
+	}, recorder.Events, "a rotation must be announced once")
+
+	c.announcePrivateKeyRotation("testns/crt", scheduledRotationCertificate(fixedNow.Add(-time.Minute)))
+	assert.Len(t, recorder.Events, 1, "a rotation which is due must not be announced")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	"github.com/cert-manager/cert-manager/pkg/acme/ratelimit"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	reasonRenewalDelayed = "RenewalDelayed"
+
+	// rateLimitBudgetRecheckInterval is the longest a delayed renewal waits
+	// before the budget of its issuer is checked again.
+	rateLimitBudgetRecheckInterval = time.Hour
+)
+
+// shouldDelayRenewal returns true if the renewal of a Certificate should be
+// delayed because the rate limit budget of its ACME issuer for one of the
+// Certificate's registered domains is nearly exhausted, along with a message
+// and the delay after which the renewal should be checked again. Renewals are
+// only delayed until half of the time between the renewal time and the expiry
+// of the certificate has passed.
+func (c *controller) shouldDelayRenewal(crt *cmapi.Certificate, reason string) (bool, string, time.Duration) {
+	if reason != policies.Renewing || crt.Status.RenewalTime == nil || crt.Status.NotAfter == nil {
+		return false, "", 0
+	}
+
+	iss := c.issuerForCertificate(crt)
+	if iss == nil || iss.GetSpec().ACME == nil || iss.GetSpec().ACME.RateLimitBudget == nil {
+		return false, "", 0
+	}
+	threshold := iss.GetSpec().ACME.RateLimitBudget.RenewalDelayThreshold
+	if threshold <= 0 || iss.GetStatus().ACME == nil {
+		return false, "", 0
+	}
+	limit, ok := ratelimit.LimitForIssuer(iss.GetSpec().ACME)
+	if !ok {
+		return false, "", 0
+	}
+
+	renewalTime, notAfter := crt.Status.RenewalTime.Time, crt.Status.NotAfter.Time
+	deadline := renewalTime.Add(notAfter.Sub(renewalTime) / 2)
+	now := c.clock.Now()
+	if !now.Before(deadline) {
+		return false, "", 0
+	}
+
+	dnsNames := crt.Spec.DNSNames
+	if len(crt.Spec.CommonName) > 0 {
+		dnsNames = append(append([]string(nil), dnsNames...), crt.Spec.CommonName)
+	}
+	remaining, domain := ratelimit.Remaining(limit, iss.GetStatus().ACME.RateLimitBudgets, dnsNames)
+	if remaining > threshold {
+		return false, "", 0
+	}
+
+	delay := deadline.Sub(now)
+	if delay > rateLimitBudgetRecheckInterval {
+		delay = rateLimitBudgetRecheckInterval
+	}
+	message := fmt.Sprintf("Renewal is delayed as %d certificates remain in the rate limit budget of %s for %s. Renewal will proceed at the latest at %v",
+		remaining, apiutil.IssuerKind(crt.Spec.IssuerRef), domain, deadline)
+	return true, message, delay
+}
+
+// issuerForCertificate returns the issuer referenced by a Certificate, or nil
+// if it is not a cert-manager issuer or cannot be found.
+func (c *controller) issuerForCertificate(crt *cmapi.Certificate) cmapi.GenericIssuer {
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != cmapi.SchemeGroupVersion.Group {
+		return nil
+	}
+	switch apiutil.IssuerKind(crt.Spec.IssuerRef) {
+	case cmapi.IssuerKind:
+		if c.issuerLister == nil {
+			return nil
+		}
+		iss, err := c.issuerLister.Issuers(crt.Namespace).Get(crt.Spec.IssuerRef.Name)
+		if err != nil {
+			return nil
+		}
+		return iss
+	case cmapi.ClusterIssuerKind:
+		if c.clusterIssuerLister == nil {
+			return nil
+		}
+		iss, err := c.clusterIssuerLister.Get(crt.Spec.IssuerRef.Name)
+		if err != nil {
+			return nil
+		}
+		return iss
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_controller_shouldDelayRenewal(t *testing.T) {
+	fixedNow := time.Now().Truncate(time.Second)
+	fixedClock := fakeclock.NewFakeClock(fixedNow)
+
+	issuer := func(threshold int, remaining int) *cmapi.Issuer {
+		return gen.Issuer("issuer", gen.SetIssuerNamespace("testns"),
+			gen.SetIssuerACMEURL("https://acme.example.com/directory"),
+			gen.SetIssuerACMERateLimitBudget(cmacme.ACMERateLimitBudget{
+				CertificatesPerRegisteredDomain: 50,
+				RenewalDelayThreshold:           threshold,
+			}),
+			gen.SetIssuerACMERateLimitBudgets(cmacme.ACMERateLimitBudgetStatus{
+				RegisteredDomain: "example.com",
+				Issued:           50 - remaining,
+				Remaining:        remaining,
+			}))
+	}
+	certificate := func(renewalTime time.Time, dnsNames ...string) *cmapi.Certificate {
+		return gen.Certificate("crt", gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.IssuerKind}),
+			gen.SetCertificateDNSNames(dnsNames...),
+			gen.SetCertificateRenewalTime(metav1.NewTime(renewalTime)),
+			gen.SetCertificateNotAfter(metav1.NewTime(renewalTime.Add(10*time.Hour))))
+	}
+
+	tests := map[string]struct {
+		issuer      *cmapi.Issuer
+		certificate *cmapi.Certificate
+		reason      string
+		expDelay    bool
+		expAfter    time.Duration
+	}{
+		"renewal is delayed when the budget is at the threshold": {
+			issuer:      issuer(5, 5),
+			certificate: certificate(fixedNow.Add(-time.Hour), "www.example.com"),
+			reason:      policies.Renewing,
+			expDelay:    true,
+			expAfter:    time.Hour,
+		},
+		"renewal is rechecked at the deadline when it is sooner than the recheck interval": {
+			issuer:      issuer(5, 0),
+			certificate: certificate(fixedNow.Add(-270*time.Minute), "www.example.com"),
+			reason:      policies.Renewing,
+			expDelay:    true,
+			expAfter:    30 * time.Minute,
+		},
+		"renewal is not delayed once half of the time until expiry has passed": {
+			issuer:      issuer(5, 0),
+			certificate: certificate(fixedNow.Add(-5*time.Hour), "www.example.com"),
+			reason:      policies.Renewing,
+		},
+		"renewal is not delayed when the budget is above the threshold": {
+			issuer:      issuer(5, 6),
+			certificate: certificate(fixedNow.Add(-time.Hour), "www.example.com"),
+			reason:      policies.Renewing,
+		},
+		"renewal is not delayed for other registered domains": {
+			issuer:      issuer(5, 0),
+			certificate: certificate(fixedNow.Add(-time.Hour), "www.example.org"),
+			reason:      policies.Renewing,
+		},
+		"renewal is not delayed when delaying is disabled": {
+			issuer:      issuer(0, 0),
+			certificate: certificate(fixedNow.Add(-time.Hour), "www.example.com"),
+			reason:      policies.Renewing,
+		},
+		"re-issuance for other reasons is not delayed": {
+			issuer:      issuer(5, 0),
+			certificate: certificate(fixedNow.Add(-time.Hour), "www.example.com"),
+			reason:      policies.SecretMismatch,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.issuer},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			delay, _, after := w.controller.shouldDelayRenewal(test.certificate, test.reason)
+			assert.Equal(t, test.expDelay, delay)
+			assert.Equal(t, test.expAfter, after)
+		})
+	}
+}
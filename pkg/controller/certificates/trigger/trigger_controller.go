@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -53,6 +54,16 @@ import (
 
 const (
 	ControllerName = "certificates-trigger"
+
+	reasonSecretDeleted   = "SecretDeleted"
+	reasonSecretCorrupted = "SecretCorrupted"
+
+	// Reasons of the Issuing and IssuanceRequested conditions for issuances
+	// requested with the IssuanceRequestAnnotationKey annotation.
+	reasonIssuanceRequested = "IssuanceRequested"
+	reasonIssued            = "Issued"
+	reasonFailed            = "Failed"
+
 	// stopIncreaseBackoff is the number of issuance attempts after which the backoff period should stop to increase
 	stopIncreaseBackoff = 6 // 2 ^ (6 - 1) = 32 = maxDelay
 	// maxDelay is the maximum backoff period
@@ -72,6 +83,21 @@ type controller struct {
 	recorder                 record.EventRecorder
 	scheduledWorkQueue       scheduler.ScheduledWorkQueue
 
+	// The following are used to re-trigger failing Certificates when a
+	// Secret read by their issuer changes, see watchIssuerSecrets.
+	issuerLister             cmlisters.IssuerLister
+	clusterIssuerLister      cmlisters.ClusterIssuerLister
+	clusterResourceNamespace string
+	// issuerSecretUpdates records when a Secret read by the issuer of a
+	// failing Certificate last changed, keyed by the Certificate's key.
+	issuerSecretUpdates sync.Map
+	// privateKeyRotationNotices records the scheduled private key rotation
+	// time last announced for a Certificate, keyed by the Certificate's key.
+	privateKeyRotationNotices sync.Map
+	// secretLossNotices records the reason the Secret of a Certificate was
+	// last reported as deleted or corrupted, keyed by the Certificate's key.
+	secretLossNotices sync.Map
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -81,6 +107,10 @@ type controller struct {
 	clock              clock.Clock
 	shouldReissue      policies.Func
 	dataForCertificate func(context.Context, *cmapi.Certificate) (policies.Input, error)
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
 }
 
 func NewController(
@@ -113,6 +143,12 @@ func NewController(
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
 	})
+	// When a canary shadow Secret changes, enqueue the Certificate so that
+	// its issuance is triggered again once the candidate has been verified.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateCanarySecretName)),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -153,11 +189,22 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	crt, err := c.certificateLister.Certificates(namespace).Get(name)
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		c.secretLossNotices.Delete(key)
 		return nil
 	}
 	if err != nil {
 		return err
 	}
+
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+	if crt.Spec.ObserveOnly {
+		// Observe-only Certificates are never issued.
+		log.V(logf.DebugLevel).Info("skipping observe-only certificate")
+		return nil
+	}
 	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -166,13 +213,47 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionIssuanceRequested,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		// The requested issuance is no longer in progress.
+		return c.completeIssuanceRequest(ctx, crt)
+	}
+
+	if request := crt.Annotations[cmapi.IssuanceRequestAnnotationKey]; len(request) > 0 && request != crt.Status.LastIssuanceRequest {
+		// Explicitly requested issuances are neither subject to the
+		// re-issuance policies nor to the back-off after failures.
+		return c.triggerRequestedIssuance(ctx, crt, request)
+	}
+
 	input, err := c.dataForCertificate(ctx, crt)
 	if err != nil {
 		return err
 	}
 
+	// A deleted or corrupted Secret is likely to break the workloads using
+	// the certificate, so warn about it as soon as it is noticed, even if
+	// re-issuance is backing off.
+	c.warnIfSecretLost(key, crt, input)
+
+	c.announcePrivateKeyRotation(key, crt)
+
+	pending, err := c.canaryPending(crt, input)
+	if err != nil {
+		return err
+	}
+	if pending {
+		log.V(logf.DebugLevel).Info("Not triggering issuance while the candidate certificate in the canary Secret is waiting to be verified")
+		return nil
+	}
+
 	// Don't trigger issuance if we need to back off due to previous failures and Certificate's spec has not changed.
 	backoff, delay := shouldBackoffReissuingOnFailure(log, c.clock, input.Certificate, input.NextRevisionRequest)
+	if c.issuerSecretUpdatedSinceFailure(key, crt) && backoff {
+		log.V(logf.InfoLevel).Info("Not backing off from issuance as a Secret used by the issuer has changed since the last failed issuance")
+		backoff = false
+	}
 	if backoff {
 		nextIssuanceRetry := c.clock.Now().Add(delay)
 		message := fmt.Sprintf("Backing off from issuance due to previously failed issuance(s). Issuance will next be attempted at %v", nextIssuanceRetry)
@@ -181,10 +262,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	if crt.Status.RenewalTime != nil {
+	if recheckTime := c.nextRecheckTime(crt); !recheckTime.IsZero() {
 		// ensure a resync is scheduled in the future so that we re-check
-		// Certificate resources and trigger them near expiry time
-		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
+		// Certificate resources and trigger them near expiry time, or when
+		// their private key is due to be rotated or its rotation announced
+		c.scheduleRecheckOfCertificateIfRequired(log, key, recheckTime.Sub(c.clock.Now()))
 	}
 
 	reason, message, reissue := c.shouldReissue(input)
@@ -193,6 +275,27 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	// The certificate of the current revision is likely to fail its
+	// verification again if the Certificate is re-issued straight away, for
+	// example after it has been rolled back to a certificate which is due
+	// for renewal.
+	if backoffReason, backoffMessage, failed := policies.NewTriggerBackoffPolicyChain().Evaluate(input); failed {
+		cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionVerificationFailed)
+		if backoff, delay := backoffAfterFailure(log, c.clock, cond.LastTransitionTime, crt.Status.FailedIssuanceAttempts); backoff {
+			nextIssuanceRetry := c.clock.Now().Add(delay)
+			log.V(logf.InfoLevel).Info(fmt.Sprintf("%s. Backing off from re-issuance, which will next be attempted at %v", backoffMessage, nextIssuanceRetry), "reason", backoffReason)
+			c.scheduleRecheckOfCertificateIfRequired(log, key, delay)
+			return nil
+		}
+	}
+
+	if delay, message, after := c.shouldDelayRenewal(crt, reason); delay {
+		log.V(logf.InfoLevel).Info(message)
+		c.recorder.Event(crt, corev1.EventTypeNormal, reasonRenewalDelayed, message)
+		c.scheduleRecheckOfCertificateIfRequired(log, key, after)
+		return nil
+	}
+
 	// Although the below recorder.Event already logs the event, the log
 	// line is quite unreadable (very long). Since this information is very
 	// important for the user and the operator, we log the following
@@ -209,18 +312,93 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return nil
 }
 
+// triggerRequestedIssuance triggers the issuance requested by setting the
+// IssuanceRequestAnnotationKey annotation to the given value, and
+// acknowledges the request.
+func (c *controller) triggerRequestedIssuance(ctx context.Context, crt *cmapi.Certificate, request string) error {
+	log := logf.FromContext(ctx)
+	message := fmt.Sprintf("Issuance was requested with the %s annotation set to %q", cmapi.IssuanceRequestAnnotationKey, request)
+	log.V(logf.InfoLevel).Info("Certificate must be re-issued", "reason", reasonIssuanceRequested, "message", message)
+
+	crt = crt.DeepCopy()
+	crt.Status.LastIssuanceRequest = request
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, reasonIssuanceRequested, message)
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuanceRequested, cmmeta.ConditionTrue, reasonIssuanceRequested, message)
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
+
+	return nil
+}
+
+// completeIssuanceRequest sets the IssuanceRequested condition to False once
+// the requested issuance has completed, with a reason reporting whether it
+// succeeded. The issuing controller either removes the Issuing condition or
+// sets it to False with the reason "Issued" after a successful issuance, and
+// sets it to False with the reason of the failed CertificateRequest
+// otherwise.
+func (c *controller) completeIssuanceRequest(ctx context.Context, crt *cmapi.Certificate) error {
+	reason := reasonIssued
+	message := fmt.Sprintf("The issuance requested with the %s annotation set to %q has succeeded", cmapi.IssuanceRequestAnnotationKey, crt.Status.LastIssuanceRequest)
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil && cond.Reason != reasonIssued {
+		reason = reasonFailed
+		message = fmt.Sprintf("The issuance requested with the %s annotation set to %q has failed: %s", cmapi.IssuanceRequestAnnotationKey, crt.Status.LastIssuanceRequest, cond.Message)
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuanceRequested, cmmeta.ConditionFalse, reason, message)
+	return c.updateOrApplyStatus(ctx, crt)
+}
+
+// warnIfSecretLost emits a Warning event if the Secret of a Certificate which
+// has previously been issued has been deleted or its data corrupted. No event
+// is emitted for Certificates which have not yet been issued, since their
+// Secret is expected to be missing.
+// The event is only emitted when the state of the Secret changes, and not on
+// every sync while the Certificate is being re-issued.
+func (c *controller) warnIfSecretLost(key string, crt *cmapi.Certificate, input policies.Input) {
+	if crt.Status.Revision == nil {
+		c.secretLossNotices.Delete(key)
+		return
+	}
+
+	reason, _, lost := policies.NewSecretIntegrityPolicyChain().Evaluate(input)
+	if !lost {
+		c.secretLossNotices.Delete(key)
+		return
+	}
+	if previous, ok := c.secretLossNotices.Load(key); ok && previous.(string) == reason {
+		return
+	}
+	c.secretLossNotices.Store(key, reason)
+
+	if reason == policies.DoesNotExist {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonSecretDeleted,
+			"Secret %q containing the issued certificate has been deleted", crt.Spec.SecretName)
+		return
+	}
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonSecretCorrupted,
+		"Secret %q containing the issued certificate is corrupted (%s)", crt.Spec.SecretName, reason)
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
 func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+		for _, condType := range []cmapi.CertificateConditionType{cmapi.CertificateConditionIssuing, cmapi.CertificateConditionIssuanceRequested} {
+			if cond := apiutil.GetCertificateCondition(crt, condType); cond != nil {
+				conditions = append(conditions, *cond)
+			}
 		}
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status:     cmapi.CertificateStatus{Conditions: conditions},
+			Status: cmapi.CertificateStatus{
+				Conditions:          conditions,
+				LastIssuanceRequest: crt.Status.LastIssuanceRequest,
+			},
 		})
 	} else {
 		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
@@ -269,26 +447,38 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 		}
 	}
 
+	return backoffAfterFailure(log, c, crt.Status.LastFailureTime, crt.Status.FailedIssuanceAttempts)
+}
+
+// backoffAfterFailure returns true if an issuance needs to be delayed after
+// a failure at lastFailureTime and the remaining delay, using the back-off
+// periods of shouldBackoffReissuingOnFailure for the given number of failed
+// issuance attempts.
+func backoffAfterFailure(log logr.Logger, c clock.Clock, lastFailureTime *metav1.Time, failedIssuanceAttempts *int) (bool, time.Duration) {
+	if lastFailureTime == nil {
+		return false, 0
+	}
+
 	now := c.Now()
-	durationSinceFailure := now.Sub(crt.Status.LastFailureTime.Time)
+	durationSinceFailure := now.Sub(lastFailureTime.Time)
 
 	initialDelay := time.Hour
 	delay := initialDelay
-	failedIssuanceAttempts := 0
+	attempts := 0
 	// It is possible that crt.Status.LastFailureTime != nil &&
 	// crt.Status.FailedIssuanceAttempts == nil (in case of the Certificate having
 	// failed for an installation of cert-manager before the issuance
 	// attempts were introduced). In such case delay = initialDelay.
-	if crt.Status.FailedIssuanceAttempts != nil {
-		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts
-		delay = time.Hour * time.Duration(math.Pow(2, float64(failedIssuanceAttempts-1)))
+	if failedIssuanceAttempts != nil {
+		attempts = *failedIssuanceAttempts
+		delay = time.Hour * time.Duration(math.Pow(2, float64(attempts-1)))
 	}
 
 	// Ensure that maximum returned delay is 32 hours
 	// delay cannot be calculated for large issuance numbers, so we
 	// cannot reliably check if delay > maxDelay directly
 	// (see i.e the result of time.Duration(math.Pow(2, 99)))
-	if failedIssuanceAttempts > stopIncreaseBackoff {
+	if attempts > stopIncreaseBackoff {
 		delay = maxDelay
 	}
 
@@ -347,6 +537,8 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		policies.NewTriggerPolicyChain(ctx.Clock).Evaluate,
 		ctx.FieldManager,
 	)
+	ctrl.issuerClass = ctx.IssuerClass
+	mustSync = append(mustSync, ctrl.watchIssuerSecrets(log, ctx, queue)...)
 	c.controller = ctrl
 
 	return queue, mustSync, nil
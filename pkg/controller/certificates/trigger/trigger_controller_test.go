@@ -24,6 +24,7 @@ import (
 
 	logtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
@@ -46,6 +47,16 @@ func Test_controller_ProcessItem(t *testing.T) {
 		return testcrypto.MustCreateCryptoBundle(t, crt, fixedClock).CertificateRequest
 	}
 
+	// A Secret containing a valid certificate and private key, for test
+	// cases of issued Certificates whose Secret is not lost.
+	issuedBundle := testcrypto.MustCreateCryptoBundle(t, gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("example.com"),
+	), fixedClock)
+	issuedSecret := gen.Secret("secret-1", gen.SetSecretNamespace("testns"), gen.SetSecretData(map[string][]byte{
+		corev1.TLSPrivateKeyKey: issuedBundle.PrivateKeyBytes,
+		corev1.TLSCertKey:       issuedBundle.CertBytes,
+	}))
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem. If not set, the
 		// 'namespace/name' of the 'Certificate' field will be used. If neither
@@ -69,12 +80,21 @@ func Test_controller_ProcessItem(t *testing.T) {
 		// remainder is the message.
 		wantEvent string
 
+		// wantSecretLostEvent, if set, is the Warning 'event string' that is
+		// expected to be fired before wantEvent because the Secret of an
+		// issued Certificate has been deleted or corrupted.
+		wantSecretLostEvent string
+
 		// wantConditions is the expected set of conditions on the Certificate
 		// resource if an Update is made.
 		// If nil, no update is expected.
 		// If empty, an update to the empty set/nil is expected.
 		wantConditions []cmapi.CertificateCondition
 
+		// wantLastIssuanceRequest, if set, is the expected
+		// status.lastIssuanceRequest of the updated Certificate.
+		wantLastIssuanceRequest string
+
 		// wantErr is the expected error text returned by the controller, if any.
 		wantErr string
 	}{
@@ -95,6 +115,12 @@ func Test_controller_ProcessItem(t *testing.T) {
 				}),
 			),
 		},
+		"should do nothing if Certificate is observe-only": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateObserveOnly(true),
+			),
+		},
 		"should call shouldReissue with the correct cert, secret and current CR": {
 			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
 				gen.SetCertificateSecretName("secret-1"),
@@ -130,6 +156,7 @@ func Test_controller_ProcessItem(t *testing.T) {
 					return "", "", false
 				}
 			},
+			wantSecretLostEvent: `Warning SecretCorrupted Secret "secret-1" containing the issued certificate is corrupted (MissingData)`,
 		},
 		"should log error when dataForCertificate errors": {
 			existingCertificate:             gen.Certificate("cert-1", gen.SetCertificateNamespace("testns")),
@@ -159,11 +186,35 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should emit a Warning event and set Issuing=True if the Secret of an issued Certificate has been deleted": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateRevision(1),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return policies.SecretDoesNotExist
+			},
+			wantSecretLostEvent: `Warning SecretDeleted Secret "secret-1" containing the issued certificate has been deleted`,
+			wantEvent:           "Normal Issuing Issuing certificate as Secret does not exist",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "DoesNotExist",
+				Message:            "Issuing certificate as Secret does not exist",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
 		// The combinations of number of failed issuances and last
 		// failed issuance time that do or do not result in re-issuance
 		// are tested in Test_shouldBackoffReissuingOnFailure below
 		"should not set Issuing=True when issuance failed once 59 minutes ago": {
 			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
 				gen.SetCertificateUID("cert-1-uid"),
 				gen.SetCertificateRevision(1),
 				gen.SetCertificateDNSNames("example.com"),
@@ -179,9 +230,11 @@ func Test_controller_ProcessItem(t *testing.T) {
 				)),
 			},
 			wantShouldReissueCalled: false,
+			wantSecretLostEvent:     `Warning SecretDeleted Secret "secret-1" containing the issued certificate has been deleted`,
 		},
 		"should set Issuing=True when issuance failed once 59 minutes ago but cert and next CR are mismatched": {
 			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
 				gen.SetCertificateGeneration(42),
 				gen.SetCertificateUID("cert-1-uid"),
 				gen.SetCertificateRevision(1),
@@ -203,7 +256,8 @@ func Test_controller_ProcessItem(t *testing.T) {
 					return "ForceTriggered", "Re-issuance forced by unit test case", true
 				}
 			},
-			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantSecretLostEvent: `Warning SecretDeleted Secret "secret-1" containing the issued certificate has been deleted`,
+			wantEvent:           "Normal Issuing Re-issuance forced by unit test case",
 			wantConditions: []cmapi.CertificateCondition{{
 				Type:               "Issuing",
 				Status:             "True",
@@ -238,6 +292,181 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should not set Issuing=True when the certificate of the current revision failed verification 59 minutes ago and was rolled back": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateVerification(cmapi.CertificateVerification{
+					HTTPProbe:         &cmapi.CertificateHTTPProbe{URL: "https://example.com"},
+					RollbackOnFailure: true,
+				}),
+				gen.SetCertificateRevision(2),
+				gen.SetCertificateVerificationRevision(2),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "VerificationFailed",
+					Status:             "True",
+					Reason:             "Failed",
+					LastTransitionTime: &metav1.Time{Time: fixedNow.Add(-59 * time.Minute)},
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: issuedSecret},
+			wantShouldReissueCalled:      true,
+			// The certificate restored by the rollback is due for renewal.
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return policies.Renewing, "Renewing certificate as renewal was scheduled at 2020-11-20 16:05:00 +0000 UTC", true
+				}
+			},
+		},
+		"should set Issuing=True when the certificate of the current revision failed verification 61 minutes ago": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateVerification(cmapi.CertificateVerification{
+					HTTPProbe: &cmapi.CertificateHTTPProbe{URL: "https://example.com"},
+				}),
+				gen.SetCertificateRevision(2),
+				gen.SetCertificateVerificationRevision(2),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "VerificationFailed",
+					Status:             "True",
+					Reason:             "Failed",
+					LastTransitionTime: &metav1.Time{Time: fixedNow.Add(-61 * time.Minute)},
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: issuedSecret},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "ForceTriggered", "Re-issuance forced by unit test case", true
+				}
+			},
+			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantConditions: []cmapi.CertificateCondition{
+				{
+					Type:               "VerificationFailed",
+					Status:             "True",
+					Reason:             "Failed",
+					LastTransitionTime: &metav1.Time{Time: fixedNow.Add(-61 * time.Minute)},
+					ObservedGeneration: 42,
+				},
+				{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "ForceTriggered",
+					Message:            "Re-issuance forced by unit test case",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+			},
+		},
+		"should set Issuing=True and acknowledge an issuance request without calling shouldReissue or backing off": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.AddCertificateAnnotations(map[string]string{"cert-manager.io/issuance-request": "failover-2"}),
+				gen.SetCertificateLastIssuanceRequest("failover-1"),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-59*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			wantEvent: `Normal Issuing Issuance was requested with the cert-manager.io/issuance-request annotation set to "failover-2"`,
+			wantConditions: []cmapi.CertificateCondition{
+				{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "IssuanceRequested",
+					Message:            `Issuance was requested with the cert-manager.io/issuance-request annotation set to "failover-2"`,
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+				{
+					Type:               "IssuanceRequested",
+					Status:             "True",
+					Reason:             "IssuanceRequested",
+					Message:            `Issuance was requested with the cert-manager.io/issuance-request annotation set to "failover-2"`,
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+			},
+			wantLastIssuanceRequest: "failover-2",
+		},
+		"should not trigger issuance if the issuance request has already been acknowledged": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.AddCertificateAnnotations(map[string]string{"cert-manager.io/issuance-request": "failover-1"}),
+				gen.SetCertificateLastIssuanceRequest("failover-1"),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+		},
+		"should set IssuanceRequested=False with reason Issued once the requested issuance has succeeded": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.AddCertificateAnnotations(map[string]string{"cert-manager.io/issuance-request": "failover-1"}),
+				gen.SetCertificateLastIssuanceRequest("failover-1"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "IssuanceRequested",
+					Status:             "True",
+					Reason:             "IssuanceRequested",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "IssuanceRequested",
+				Status:             "False",
+				Reason:             "Issued",
+				Message:            `The issuance requested with the cert-manager.io/issuance-request annotation set to "failover-1" has succeeded`,
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should set IssuanceRequested=False with reason Failed once the requested issuance has failed": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.AddCertificateAnnotations(map[string]string{"cert-manager.io/issuance-request": "failover-1"}),
+				gen.SetCertificateLastIssuanceRequest("failover-1"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "False",
+					Reason:             "Failed",
+					Message:            "The certificate request has failed to complete and will be retried: denied",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "IssuanceRequested",
+					Status:             "True",
+					Reason:             "IssuanceRequested",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantConditions: []cmapi.CertificateCondition{
+				{
+					Type:               "Issuing",
+					Status:             "False",
+					Reason:             "Failed",
+					Message:            "The certificate request has failed to complete and will be retried: denied",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+				{
+					Type:               "IssuanceRequested",
+					Status:             "False",
+					Reason:             "Failed",
+					Message:            `The issuance requested with the cert-manager.io/issuance-request annotation set to "failover-1" has failed: The certificate request has failed to complete and will be retried: denied`,
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -283,6 +512,9 @@ func Test_controller_ProcessItem(t *testing.T) {
 				}
 				expectedCert := test.existingCertificate.DeepCopy()
 				expectedCert.Status.Conditions = test.wantConditions
+				if test.wantLastIssuanceRequest != "" {
+					expectedCert.Status.LastIssuanceRequest = test.wantLastIssuanceRequest
+				}
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
@@ -292,8 +524,11 @@ func Test_controller_ProcessItem(t *testing.T) {
 					)),
 				)
 			}
+			if test.wantSecretLostEvent != "" {
+				builder.ExpectedEvents = []string{test.wantSecretLostEvent}
+			}
 			if test.wantEvent != "" {
-				builder.ExpectedEvents = []string{test.wantEvent}
+				builder.ExpectedEvents = append(builder.ExpectedEvents, test.wantEvent)
 			}
 
 			builder.Start()
@@ -679,3 +914,22 @@ func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 
 	}
 }
+
+func Test_controller_warnIfSecretLost(t *testing.T) {
+	recorder := new(testpkg.FakeRecorder)
+	c := &controller{recorder: recorder}
+
+	crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("secret-1"),
+		gen.SetCertificateRevision(1),
+	)
+
+	c.warnIfSecretLost("testns/cert-1", crt, policies.Input{})
+	c.warnIfSecretLost("testns/cert-1", crt, policies.Input{})
+	assert.Equal(t, []string{
+		`Warning SecretDeleted Secret "secret-1" containing the issued certificate has been deleted`,
+	}, recorder.Events, "a deleted Secret must be reported once")
+
+	c.warnIfSecretLost("testns/cert-1", crt, policies.Input{Secret: gen.Secret("secret-1")})
+	assert.Len(t, recorder.Events, 2, "a change of the state of the Secret must be reported")
+}
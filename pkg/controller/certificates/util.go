@@ -34,6 +34,7 @@ import (
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/cron"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -57,6 +58,49 @@ func PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]
 	}
 }
 
+// AdditionalPrivateKeyCertificate returns a copy of the given Certificate in
+// which spec.privateKey is replaced by spec.additionalPrivateKey, so that the
+// additional private key, and the CertificateRequests for it, can be generated
+// and checked in the same way as those for the Certificate's private key.
+// It returns nil if the Certificate has no additional private key.
+func AdditionalPrivateKeyCertificate(crt *cmapi.Certificate) *cmapi.Certificate {
+	if crt.Spec.AdditionalPrivateKey == nil {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	privateKey := &cmapi.CertificatePrivateKey{}
+	if crt.Spec.PrivateKey != nil {
+		privateKey = crt.Spec.PrivateKey
+	}
+	privateKey.Algorithm = crt.Spec.AdditionalPrivateKey.Algorithm
+	privateKey.Size = crt.Spec.AdditionalPrivateKey.Size
+	crt.Spec.PrivateKey = privateKey
+	crt.Spec.AdditionalPrivateKey = nil
+	return crt
+}
+
+// IsAdditionalPrivateKeyRequest returns true if the given CertificateRequest
+// was created for the additional private key of a Certificate.
+func IsAdditionalPrivateKeyRequest(req *cmapi.CertificateRequest) bool {
+	return req.Annotations[cmapi.CertificateRequestAdditionalPrivateKeyAnnotationKey] == "true"
+}
+
+// SplitAdditionalPrivateKeyRequests splits the given CertificateRequests into
+// those for the Certificate's private key and those for its additional
+// private key.
+func SplitAdditionalPrivateKeyRequests(reqs []*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, []*cmapi.CertificateRequest) {
+	var requests, additionalRequests []*cmapi.CertificateRequest
+	for _, req := range reqs {
+		if IsAdditionalPrivateKeyRequest(req) {
+			additionalRequests = append(additionalRequests, req)
+		} else {
+			requests = append(requests, req)
+		}
+	}
+	return requests, additionalRequests
+}
+
 func rsaPrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
 	rsaPk, ok := pk.(*rsa.PrivateKey)
 	if !ok {
@@ -123,13 +167,15 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	if spec.Subject == nil {
 		spec.Subject = &cmapi.X509Subject{}
 	}
+	spec.CommonName, spec.DNSNames = normalizeNames(spec.CommonName, spec.DNSNames)
+	reqCommonName, reqDNSNames := normalizeNames(x509req.Subject.CommonName, x509req.DNSNames)
 
 	var violations []string
 	if spec.LiteralSubject == "" {
-		if x509req.Subject.CommonName != spec.CommonName {
+		if reqCommonName != spec.CommonName {
 			violations = append(violations, "spec.commonName")
 		}
-		if !util.EqualUnsorted(x509req.DNSNames, spec.DNSNames) {
+		if !util.EqualUnsorted(reqDNSNames, spec.DNSNames) {
 			violations = append(violations, "spec.dnsNames")
 		}
 		if !util.EqualUnsorted(pki.IPAddressesToString(x509req.IPAddresses), spec.IPAddresses) {
@@ -201,6 +247,22 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	return violations, nil
 }
 
+// normalizeNames returns the normalized form of the given DNS names and, if
+// it is also one of the DNS names, the common name. This is the form used when
+// building certificate requests, which means that names differing only in
+// e.g. their case or a trailing dot are not reported as violations. Names
+// which cannot be normalized are returned unchanged.
+func normalizeNames(commonName string, dnsNames []string) (string, []string) {
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: commonName, DNSNames: dnsNames}}
+	if normalized, err := pki.CommonNameForCertificate(crt); err == nil {
+		commonName = normalized
+	}
+	if normalized, err := pki.DNSNamesForCertificate(crt); err == nil {
+		dnsNames = normalized
+	}
+	return commonName, dnsNames
+}
+
 // SecretDataAltNamesMatchSpec will compare a Secret resource containing certificate
 // data to a CertificateSpec and return a list of 'violations' for any fields that
 // do not match their counterparts.
@@ -212,6 +274,11 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 		return nil, err
 	}
 
+	// It is safe to mutate top-level fields in `spec` as it is not a pointer
+	// meaning changes will not effect the caller.
+	spec.CommonName, spec.DNSNames = normalizeNames(spec.CommonName, spec.DNSNames)
+	certCommonName, certDNSNames := normalizeNames(x509cert.Subject.CommonName, x509cert.DNSNames)
+
 	var violations []string
 
 	// Perform a 'loose' check on the x509 certificate to determine if the
@@ -223,17 +290,17 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 	if spec.CommonName != "" {
 		expectedDNSNames.Insert(spec.CommonName)
 	}
-	allDNSNames := sets.NewString(x509cert.DNSNames...)
-	if x509cert.Subject.CommonName != "" {
-		allDNSNames.Insert(x509cert.Subject.CommonName)
+	allDNSNames := sets.NewString(certDNSNames...)
+	if certCommonName != "" {
+		allDNSNames.Insert(certCommonName)
 	}
 	if !allDNSNames.Equal(expectedDNSNames) {
 		// We know a mismatch occurred, so now determine which fields mismatched.
-		if (spec.CommonName != "" && !allDNSNames.Has(spec.CommonName)) || (x509cert.Subject.CommonName != "" && !expectedDNSNames.Has(x509cert.Subject.CommonName)) {
+		if (spec.CommonName != "" && !allDNSNames.Has(spec.CommonName)) || (certCommonName != "" && !expectedDNSNames.Has(certCommonName)) {
 			violations = append(violations, "spec.commonName")
 		}
 
-		if !allDNSNames.HasAll(spec.DNSNames...) || !expectedDNSNames.HasAll(x509cert.DNSNames...) {
+		if !allDNSNames.HasAll(spec.DNSNames...) || !expectedDNSNames.HasAll(certDNSNames...) {
 			violations = append(violations, "spec.dnsNames")
 		}
 	}
@@ -337,3 +404,110 @@ func RenewalTime(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Dura
 	rt := metav1.NewTime(notAfter.Add(-1 * renewBefore).Truncate(time.Second))
 	return &rt
 }
+
+// RenewalTimeInWindows defers the given renewal time of a certificate expiring
+// at notAfter to the next time any of the given renewal windows is open. The
+// renewal time is returned unchanged if a window is already open at that time
+// or if the certificate is due for urgent renewal by then. The deferred time
+// never passes the urgent renewal deadline. If windows is nil or any of its
+// schedules cannot be parsed, the renewal time is returned unchanged.
+func RenewalTimeInWindows(renewalTime *metav1.Time, notAfter time.Time, windows *cmapi.CertificateRenewalWindows) *metav1.Time {
+	if renewalTime == nil || windows == nil || len(windows.Windows) == 0 {
+		return renewalTime
+	}
+
+	base := renewalTime.Time
+
+	urgentRenewBefore := notAfter.Sub(base) / 2
+	if windows.UrgentRenewBefore != nil {
+		urgentRenewBefore = windows.UrgentRenewBefore.Duration
+	}
+	deadline := notAfter.Add(-urgentRenewBefore)
+	if !base.Before(deadline) {
+		return renewalTime
+	}
+
+	loc := time.UTC
+	if len(windows.TimeZone) > 0 {
+		l, err := time.LoadLocation(windows.TimeZone)
+		if err != nil {
+			return renewalTime
+		}
+		loc = l
+	}
+	base = base.In(loc)
+
+	var next time.Time
+	for _, w := range windows.Windows {
+		sched, err := cron.Parse(w.Schedule)
+		if err != nil {
+			return renewalTime
+		}
+		// The window is open if it was last opened less than its duration ago.
+		if opened := sched.Next(base.Add(-w.Duration.Duration)); !opened.IsZero() && !opened.After(base) {
+			return renewalTime
+		}
+		if opens := sched.Next(base); !opens.IsZero() && (next.IsZero() || opens.Before(next)) {
+			next = opens
+		}
+	}
+
+	if next.IsZero() || next.After(deadline) {
+		next = deadline
+	}
+
+	// Truncate for the same reason as in RenewalTime.
+	rt := metav1.NewTime(next.UTC().Truncate(time.Second))
+	return &rt
+}
+
+// DefaultPrivateKeyRotationAdvanceNotice is how long before a scheduled
+// rotation of the private key it is announced if the rotation schedule does
+// not specify otherwise.
+const DefaultPrivateKeyRotationAdvanceNotice = 7 * 24 * time.Hour
+
+// NextPrivateKeyRotationTime returns the time of the first scheduled rotation
+// of the private key after from. It returns nil if the private key does not
+// use the Scheduled rotation policy, or if its schedule cannot be parsed.
+func NextPrivateKeyRotationTime(pk *cmapi.CertificatePrivateKey, from time.Time) *metav1.Time {
+	if pk == nil || pk.RotationPolicy != cmapi.RotationPolicyScheduled || pk.RotationSchedule == nil {
+		return nil
+	}
+	rs := pk.RotationSchedule
+
+	var next time.Time
+	switch {
+	case rs.Interval != nil && rs.Interval.Duration > 0:
+		next = from.Add(rs.Interval.Duration)
+	case len(rs.Schedule) > 0:
+		loc := time.UTC
+		if len(rs.TimeZone) > 0 {
+			l, err := time.LoadLocation(rs.TimeZone)
+			if err != nil {
+				return nil
+			}
+			loc = l
+		}
+		sched, err := cron.Parse(rs.Schedule)
+		if err != nil {
+			return nil
+		}
+		next = sched.Next(from.In(loc))
+	}
+	if next.IsZero() {
+		return nil
+	}
+
+	// Truncate for the same reason as in RenewalTime.
+	rt := metav1.NewTime(next.UTC().Truncate(time.Second))
+	return &rt
+}
+
+// PrivateKeyRotationAdvanceNotice returns how long before a scheduled rotation
+// of the private key it should be announced.
+func PrivateKeyRotationAdvanceNotice(rs *cmapi.PrivateKeyRotationSchedule) time.Duration {
+	if rs == nil || rs.AdvanceNotice == nil {
+		return DefaultPrivateKeyRotationAdvanceNotice
+	}
+	return rs.AdvanceNotice.Duration
+}
@@ -141,6 +141,26 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 				DNSNames:   []string{"at", "least", "one"},
 			}),
 		},
+		"should match if internationalised names were issued in their ASCII form": {
+			spec: cmapi.CertificateSpec{
+				CommonName: "bücher.example.com",
+				DNSNames:   []string{"bücher.example.com", "*.bücher.example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				CommonName: "xn--bcher-kva.example.com",
+				DNSNames:   []string{"xn--bcher-kva.example.com", "*.xn--bcher-kva.example.com"},
+			}),
+		},
+		"should match if names were issued in their normalized form": {
+			spec: cmapi.CertificateSpec{
+				CommonName: "Example.COM.",
+				DNSNames:   []string{"Example.COM.", "www.example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				CommonName: "example.com",
+				DNSNames:   []string{"example.com", "www.example.com"},
+			}),
+		},
 		"should match if commonName is missing but is present in dnsNames": {
 			spec: cmapi.CertificateSpec{
 				CommonName: "cn",
@@ -361,3 +381,146 @@ func TestRenewalTime(t *testing.T) {
 		})
 	}
 }
+
+func TestRenewalTimeInWindows(t *testing.T) {
+	weekdayNights := []cmapi.CertificateRenewalWindow{
+		{Schedule: "0 22 * * MON-FRI", Duration: metav1.Duration{Duration: time.Hour * 8}},
+	}
+	wednesday := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	saturday := time.Date(2022, time.June, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		renewalTime         time.Time
+		notAfter            time.Time
+		windows             *cmapi.CertificateRenewalWindows
+		expectedRenewalTime time.Time
+	}{
+		"no windows configured": {
+			renewalTime:         wednesday,
+			notAfter:            wednesday.Add(time.Hour * 24 * 30),
+			expectedRenewalTime: wednesday,
+		},
+		"renewal time outside of any window is deferred to the next window": {
+			renewalTime:         wednesday,
+			notAfter:            wednesday.Add(time.Hour * 24 * 30),
+			windows:             &cmapi.CertificateRenewalWindows{Windows: weekdayNights},
+			expectedRenewalTime: time.Date(2022, time.June, 1, 22, 0, 0, 0, time.UTC),
+		},
+		"renewal time inside a window is unchanged": {
+			renewalTime:         wednesday.Add(time.Hour * 13),
+			notAfter:            wednesday.Add(time.Hour * 24 * 30),
+			windows:             &cmapi.CertificateRenewalWindows{Windows: weekdayNights},
+			expectedRenewalTime: wednesday.Add(time.Hour * 13),
+		},
+		"renewal time on a weekend is deferred to Monday": {
+			renewalTime:         saturday,
+			notAfter:            saturday.Add(time.Hour * 24 * 30),
+			windows:             &cmapi.CertificateRenewalWindows{Windows: weekdayNights},
+			expectedRenewalTime: time.Date(2022, time.June, 6, 22, 0, 0, 0, time.UTC),
+		},
+		"renewal is not deferred past the default urgent deadline": {
+			renewalTime:         saturday,
+			notAfter:            saturday.Add(time.Hour * 48),
+			windows:             &cmapi.CertificateRenewalWindows{Windows: weekdayNights},
+			expectedRenewalTime: saturday.Add(time.Hour * 24),
+		},
+		"renewal is not deferred if already past the urgent deadline": {
+			renewalTime: saturday,
+			notAfter:    saturday.Add(time.Hour * 48),
+			windows: &cmapi.CertificateRenewalWindows{
+				Windows:           weekdayNights,
+				UrgentRenewBefore: &metav1.Duration{Duration: time.Hour * 72},
+			},
+			expectedRenewalTime: saturday,
+		},
+		"schedules are evaluated in the configured time zone": {
+			renewalTime: wednesday,
+			notAfter:    wednesday.Add(time.Hour * 24 * 30),
+			windows: &cmapi.CertificateRenewalWindows{
+				Windows:  weekdayNights,
+				TimeZone: "Europe/London",
+			},
+			expectedRenewalTime: time.Date(2022, time.June, 1, 21, 0, 0, 0, time.UTC),
+		},
+		"invalid schedule leaves the renewal time unchanged": {
+			renewalTime: wednesday,
+			notAfter:    wednesday.Add(time.Hour * 24 * 30),
+			windows: &cmapi.CertificateRenewalWindows{
+				Windows: []cmapi.CertificateRenewalWindow{{Schedule: "0 22 * *", Duration: metav1.Duration{Duration: time.Hour}}},
+			},
+			expectedRenewalTime: wednesday,
+		},
+	}
+	for n, s := range tests {
+		t.Run(n, func(t *testing.T) {
+			renewalTime := RenewalTimeInWindows(&metav1.Time{Time: s.renewalTime}, s.notAfter, s.windows)
+			assert.True(t, s.expectedRenewalTime.Equal(renewalTime.Time), fmt.Sprintf("Expected renewal time: %v got: %v", s.expectedRenewalTime, renewalTime))
+		})
+	}
+}
+
+func TestNextPrivateKeyRotationTime(t *testing.T) {
+	from := time.Date(2022, time.June, 1, 10, 30, 15, 500, time.UTC)
+
+	tests := map[string]struct {
+		privateKey           *cmapi.CertificatePrivateKey
+		expectedRotationTime *time.Time
+	}{
+		"no private key configuration": {},
+		"rotation policy is not Scheduled": {
+			privateKey: &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyAlways},
+		},
+		"interval": {
+			privateKey: &cmapi.CertificatePrivateKey{
+				RotationPolicy: cmapi.RotationPolicyScheduled,
+				RotationSchedule: &cmapi.PrivateKeyRotationSchedule{
+					Interval: &metav1.Duration{Duration: time.Hour * 24 * 90},
+				},
+			},
+			expectedRotationTime: ptrTime(time.Date(2022, time.August, 30, 10, 30, 15, 0, time.UTC)),
+		},
+		"schedule": {
+			privateKey: &cmapi.CertificatePrivateKey{
+				RotationPolicy: cmapi.RotationPolicyScheduled,
+				RotationSchedule: &cmapi.PrivateKeyRotationSchedule{
+					Schedule: "0 9 1 */3 *",
+				},
+			},
+			expectedRotationTime: ptrTime(time.Date(2022, time.July, 1, 9, 0, 0, 0, time.UTC)),
+		},
+		"schedule in time zone": {
+			privateKey: &cmapi.CertificatePrivateKey{
+				RotationPolicy: cmapi.RotationPolicyScheduled,
+				RotationSchedule: &cmapi.PrivateKeyRotationSchedule{
+					Schedule: "0 9 1 */3 *",
+					TimeZone: "Europe/London",
+				},
+			},
+			expectedRotationTime: ptrTime(time.Date(2022, time.July, 1, 8, 0, 0, 0, time.UTC)),
+		},
+		"invalid schedule": {
+			privateKey: &cmapi.CertificatePrivateKey{
+				RotationPolicy: cmapi.RotationPolicyScheduled,
+				RotationSchedule: &cmapi.PrivateKeyRotationSchedule{
+					Schedule: "0 9 1 *",
+				},
+			},
+		},
+	}
+	for n, s := range tests {
+		t.Run(n, func(t *testing.T) {
+			rotationTime := NextPrivateKeyRotationTime(s.privateKey, from)
+			if s.expectedRotationTime == nil {
+				assert.Nil(t, rotationTime)
+				return
+			}
+			if assert.NotNil(t, rotationTime) {
+				assert.True(t, s.expectedRotationTime.Equal(rotationTime.Time), fmt.Sprintf("Expected rotation time: %v got: %v", s.expectedRotationTime, rotationTime))
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// jobMountPath is the path the Certificate's Secret is mounted at in the
+	// container of a verification Job.
+	jobMountPath = "/etc/cert-manager/certificate"
+
+	// probeTimeout is the time a probe may take to connect to its endpoint.
+	probeTimeout = 10 * time.Second
+)
+
+// ensureJob ensures that the Job verifying the certificate of the given
+// revision exists. It returns true once the Job has completed, along with an
+// error if it has failed.
+func (c *controller) ensureJob(ctx context.Context, crt *cmapi.Certificate, revision int) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	job, err := buildJob(crt, revision)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := c.kubeClient.BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		log.V(logf.InfoLevel).Info("creating verification job", "job", job.Name)
+		_, err = c.kubeClient.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if existing.Status.Succeeded > 0 {
+		return true, nil
+	}
+	for _, cond := range existing.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			return true, fmt.Errorf("verification job %q failed: %s", existing.Name, cond.Message)
+		}
+	}
+
+	return false, fmt.Errorf("verification job %q has not completed", existing.Name)
+}
+
+// buildJob returns the Job verifying the certificate of the given revision of
+// a Certificate. The Certificate's Secret is mounted read-only into the Job's
+// container.
+func buildJob(crt *cmapi.Certificate, revision int) (*batchv1.Job, error) {
+	name, err := apiutil.ComputeName(crt.Name+"-verify", fmt.Sprintf("%s/%d", crt.Name, revision))
+	if err != nil {
+		return nil, err
+	}
+
+	spec := crt.Spec.Verification.Job
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: crt.Namespace,
+			Annotations: map[string]string{
+				cmapi.CertificateNameKey:                      crt.Name,
+				cmapi.CertificateRequestRevisionAnnotationKey: strconv.Itoa(revision),
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: spec.ServiceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:    "verify",
+							Image:   spec.Image,
+							Command: spec.Command,
+							Args:    spec.Args,
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "certificate", MountPath: jobMountPath, ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "certificate",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: crt.Spec.SecretName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// probeServingCertificate connects to the TLS endpoint of rawURL and returns
+// an error unless the leaf certificate it presents is cert.
+func probeServingCertificate(ctx context.Context, rawURL string, cert *x509.Certificate) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	dialer := &tls.Dialer{Config: &tls.Config{
+		ServerName: u.Hostname(),
+		// The presented certificate is compared with the issued certificate
+		// rather than verified, as it may not be trusted by the controller.
+		InsecureSkipVerify: true,
+	}}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %v", u.Host, err)
+	}
+	defer conn.Close()
+
+	peers := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(peers) == 0 || !bytes.Equal(peers[0].Raw, cert.Raw) {
+		return fmt.Errorf("%s is not serving the issued certificate", u.Host)
+	}
+
+	return nil
+}
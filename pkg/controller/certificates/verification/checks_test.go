@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProbeServingCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// The response status is not checked.
+	assert.NoError(t, probeServingCertificate(context.Background(), server.URL+"/healthz", server.Certificate()))
+
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	other, err := pki.DecodeX509CertificateBytes(testcrypto.MustCreateCert(t, pk, gen.Certificate("test", gen.SetCertificateCommonName("example.com"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, probeServingCertificate(context.Background(), server.URL, other))
+
+	server.Close()
+	assert.Error(t, probeServingCertificate(context.Background(), server.URL, server.Certificate()))
+}
@@ -0,0 +1,450 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the certificate verification controller.
+	ControllerName = "certificates-verification"
+
+	reasonVerifying  = "Verifying"
+	reasonVerified   = "Verified"
+	reasonFailed     = "Failed"
+	reasonRolledBack = "RolledBack"
+
+	// defaultTimeout is the time the checks of a certificate may take to
+	// pass if `spec.verification.timeout` is not set.
+	defaultTimeout = 5 * time.Minute
+
+	// retryPeriod is the time after which the pending checks of a
+	// certificate are run again.
+	retryPeriod = 15 * time.Second
+
+	// verifiedSecretSuffix is appended to the name of a Certificate's Secret
+	// to name the Secret holding its most recently verified certificate.
+	verifiedSecretSuffix = "-verified"
+)
+
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	client            cmclient.Interface
+	kubeClient        kubernetes.Interface
+	recorder          record.EventRecorder
+	clock             clock.Clock
+	queue             workqueue.RateLimitingInterface
+
+	// probe checks that the endpoint of an HTTP probe is serving the given
+	// certificate. Named here to make testing simpler.
+	probe func(ctx context.Context, rawURL string, cert *x509.Certificate) error
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// issuerClass is the class of the Certificates processed by this
+	// controller.
+	issuerClass string
+}
+
+// NewController returns a new certificate verification controller.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	kubeClient kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	fieldManager string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		secretsInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretsInformer.Lister(),
+		client:            client,
+		kubeClient:        kubeClient,
+		recorder:          recorder,
+		clock:             clock,
+		queue:             queue,
+		probe:             probeServingCertificate,
+		fieldManager:      fieldManager,
+	}, queue, mustSync
+}
+
+// ProcessItem verifies the certificate of the current revision of a
+// Certificate with `spec.verification` set, and reports the result with the
+// `VerificationFailed` condition. The condition is set to Unknown once a new
+// revision has been issued, and its checks are retried until they pass, one
+// of them fails, or the verification times out.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !controllerpkg.IsClaimed(crt, c.issuerClass) {
+		log.V(logf.DebugLevel).Info("skipping certificate with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
+	log = logf.WithResource(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	if crt.Spec.Verification == nil {
+		// Clear the result of a verification which is no longer configured.
+		if crt.Status.VerificationRevision == nil && apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionVerificationFailed) == nil {
+			return nil
+		}
+		crt = crt.DeepCopy()
+		crt.Status.VerificationRevision = nil
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionVerificationFailed)
+		return c.updateOrApplyStatus(ctx, crt)
+	}
+
+	// Nothing has been issued yet.
+	if crt.Status.Revision == nil {
+		return nil
+	}
+	revision := *crt.Status.Revision
+
+	if crt.Status.VerificationRevision == nil || *crt.Status.VerificationRevision != revision {
+		log.V(logf.InfoLevel).Info("verifying newly issued certificate", "revision", revision)
+		crt = crt.DeepCopy()
+		crt.Status.VerificationRevision = &revision
+		// Remove the condition first, so that its last transition time marks
+		// the start of the verification of this revision even if the
+		// verification of the previous revision had not completed.
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionVerificationFailed)
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionVerificationFailed, cmmeta.ConditionUnknown,
+			reasonVerifying, fmt.Sprintf("Verifying the certificate of revision %d", revision))
+		// The Certificate will be re-synced once its status has been updated.
+		return c.updateOrApplyStatus(ctx, crt)
+	}
+
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionVerificationFailed)
+	if cond == nil || cond.Status != cmmeta.ConditionUnknown {
+		// The certificate of this revision has already been verified.
+		return nil
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("secret not found", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		// The readiness controller reports Secrets with invalid data, which
+		// will cause the Certificate to be re-issued.
+		log.V(logf.DebugLevel).Info("secret does not contain a valid certificate", "error", err.Error())
+		return nil
+	}
+
+	pending, checkErr := c.runChecks(ctx, crt, revision, cert)
+	switch {
+	case !pending && checkErr == nil:
+		return c.verified(ctx, crt, revision, secret)
+	case !pending:
+		return c.failed(ctx, crt, revision, secret, checkErr)
+	}
+
+	timeout := defaultTimeout
+	if crt.Spec.Verification.Timeout != nil {
+		timeout = crt.Spec.Verification.Timeout.Duration
+	}
+	if cond.LastTransitionTime != nil && !c.clock.Now().Before(cond.LastTransitionTime.Add(timeout)) {
+		if checkErr == nil {
+			return c.failed(ctx, crt, revision, secret, fmt.Errorf("checks did not pass within %s", timeout))
+		}
+		return c.failed(ctx, crt, revision, secret, fmt.Errorf("checks did not pass within %s: %v", timeout, checkErr))
+	}
+
+	if checkErr != nil {
+		log.V(logf.DebugLevel).Info("verification check has not passed yet", "error", checkErr.Error())
+	}
+	c.queue.AddAfter(key, retryPeriod)
+	return nil
+}
+
+// runChecks runs the checks of the Certificate's verification against cert.
+// It returns true if any of the checks may still pass, in which case a
+// returned error is the reason the check has not passed yet; otherwise a
+// returned error is the reason a check has failed.
+func (c *controller) runChecks(ctx context.Context, crt *cmapi.Certificate, revision int, cert *x509.Certificate) (bool, error) {
+	var pending bool
+	var pendingErr error
+
+	if crt.Spec.Verification.Job != nil {
+		done, err := c.ensureJob(ctx, crt, revision)
+		if done && err != nil {
+			return false, err
+		}
+		if !done {
+			pending, pendingErr = true, err
+		}
+	}
+
+	if probe := crt.Spec.Verification.HTTPProbe; probe != nil {
+		// The endpoint may not serve the certificate until the workload
+		// using it has reloaded the Secret, so a failed probe is retried
+		// until the verification times out.
+		if err := c.probe(ctx, probe.URL, cert); err != nil {
+			pending, pendingErr = true, err
+		}
+	}
+
+	return pending, pendingErr
+}
+
+// verified marks the certificate of revision as verified and, if the
+// Certificate rolls back on failure, stores it as the most recently verified
+// certificate.
+func (c *controller) verified(ctx context.Context, crt *cmapi.Certificate, revision int, secret *corev1.Secret) error {
+	if crt.Spec.Verification.RollbackOnFailure {
+		if err := c.storeVerifiedSecret(ctx, crt, secret); err != nil {
+			return err
+		}
+	}
+
+	message := fmt.Sprintf("The certificate of revision %d has been verified", revision)
+	c.recorder.Event(crt, corev1.EventTypeNormal, reasonVerified, message)
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionVerificationFailed, cmmeta.ConditionFalse, reasonVerified, message)
+	return c.updateOrApplyStatus(ctx, crt)
+}
+
+// failed marks the verification of the certificate of revision as failed
+// and, if the Certificate rolls back on failure, restores the most recently
+// verified certificate to the Certificate's Secret.
+func (c *controller) failed(ctx context.Context, crt *cmapi.Certificate, revision int, secret *corev1.Secret, checkErr error) error {
+	message := fmt.Sprintf("Verification of the certificate of revision %d failed: %v", revision, checkErr)
+	c.recorder.Event(crt, corev1.EventTypeWarning, reasonFailed, message)
+
+	if crt.Spec.Verification.RollbackOnFailure {
+		rolledBack, err := c.rollback(ctx, crt, secret)
+		if err != nil {
+			return err
+		}
+		if rolledBack {
+			message += "; the previously verified certificate has been restored"
+		} else {
+			message += "; there is no previously verified certificate to restore"
+		}
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionVerificationFailed, cmmeta.ConditionTrue, reasonFailed, message)
+	return c.updateOrApplyStatus(ctx, crt)
+}
+
+// storeVerifiedSecret copies the certificate and private key of the
+// Certificate's Secret to the Secret holding its most recently verified
+// certificate.
+func (c *controller) storeVerifiedSecret(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret) error {
+	name := crt.Spec.SecretName + verifiedSecretSuffix
+	data := verifiedData(secret)
+
+	existing, err := c.secretLister.Secrets(crt.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		_, err = c.kubeClient.CoreV1().Secrets(crt.Namespace).Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       crt.Namespace,
+				Annotations:     map[string]string{cmapi.CertificateNameKey: crt.Name},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: data,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	existing = existing.DeepCopy()
+	existing.Data = data
+	_, err = c.kubeClient.CoreV1().Secrets(crt.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// rollback restores the most recently verified certificate and private key
+// of the Certificate to its Secret. It returns false if there is no verified
+// certificate to restore.
+func (c *controller) rollback(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret) (bool, error) {
+	verified, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName + verifiedSecretSuffix)
+	if apierrors.IsNotFound(err) || (err == nil && len(verified.Data[corev1.TLSCertKey]) == 0) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	delete(secret.Data, cmmeta.TLSCAKey)
+	for k, v := range verifiedData(verified) {
+		secret.Data[k] = v
+	}
+	if _, err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return false, err
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRolledBack, "Restored the previously verified certificate to Secret %q", secret.Name)
+	return true, nil
+}
+
+// verifiedData returns the certificate, private key and CA of a Secret.
+func verifiedData(secret *corev1.Secret) map[string][]byte {
+	data := make(map[string][]byte)
+	for _, k := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, cmmeta.TLSCAKey} {
+		if v, ok := secret.Data[k]; ok {
+			data[k] = v
+		}
+	}
+	return data
+}
+
+// updateOrApplyStatus will update the controller status. If the
+// ServerSideApply feature is enabled, the managed fields will instead get
+// applied using the relevant Patch API call.
+func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		var conditions []cmapi.CertificateCondition
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionVerificationFailed); cond != nil {
+			conditions = []cmapi.CertificateCondition{*cond}
+		}
+		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+			Status: cmapi.CertificateStatus{
+				VerificationRevision: crt.Status.VerificationRevision,
+				Conditions:           conditions,
+			},
+		})
+	} else {
+		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.FieldManager,
+	)
+	ctrl.issuerClass = ctx.IssuerClass
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
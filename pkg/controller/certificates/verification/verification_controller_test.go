@@ -0,0 +1,270 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	metaNow := metav1.NewTime(now)
+	started := metav1.NewTime(now.Add(-time.Minute))
+	timedOut := metav1.NewTime(now.Add(-10 * time.Minute))
+
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCert(t, pk, gen.Certificate("test", gen.SetCertificateCommonName("example.com")))
+	verifiedPEM := testcrypto.MustCreateCert(t, pk, gen.Certificate("test", gen.SetCertificateCommonName("example.com")))
+
+	probe := cmapi.CertificateVerification{
+		HTTPProbe: &cmapi.CertificateHTTPProbe{URL: "https://example.com"},
+	}
+	probeWithRollback := cmapi.CertificateVerification{
+		HTTPProbe:         &cmapi.CertificateHTTPProbe{URL: "https://example.com"},
+		RollbackOnFailure: true,
+	}
+	job := cmapi.CertificateVerification{
+		Job: &cmapi.CertificateVerificationJob{Image: "curlimages/curl"},
+	}
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateRevision(2),
+	)
+	verifying := func(transitionTime metav1.Time) gen.CertificateModifier {
+		return func(crt *cmapi.Certificate) {
+			revision := 2
+			crt.Status.VerificationRevision = &revision
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionVerificationFailed,
+				Status:             cmmeta.ConditionUnknown,
+				Reason:             "Verifying",
+				Message:            "Verifying the certificate of revision 2",
+				LastTransitionTime: &transitionTime,
+			})(crt)
+		}
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: pk,
+		},
+	}
+	verifiedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls-verified"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       verifiedPEM,
+			corev1.TLSPrivateKeyKey: pk,
+		},
+	}
+
+	jobCrt := gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(job), verifying(started))
+	expectedJob, err := buildJob(jobCrt, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failedJob := expectedJob.DeepCopy()
+	failedJob.Status.Conditions = []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"},
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		kubeObjects []runtime.Object
+		probeErr    error
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"do nothing if verification is not configured": {
+			certificate: baseCrt,
+			kubeObjects: []runtime.Object{secret},
+		},
+		"clear the result of a verification which is no longer configured": {
+			certificate: gen.CertificateFrom(baseCrt, verifying(started)),
+			kubeObjects: []runtime.Object{secret},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt, func(crt *cmapi.Certificate) { crt.Status.Conditions = nil }))),
+			},
+		},
+		"do nothing if no certificate has been issued yet": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probe), func(crt *cmapi.Certificate) { crt.Status.Revision = nil }),
+		},
+		"start verifying a newly issued revision": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probe)),
+			kubeObjects: []runtime.Object{secret},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probe), verifying(metaNow)))),
+			},
+		},
+		"do nothing if the revision has already been verified": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probe), verifying(started),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionVerificationFailed,
+					Status: cmmeta.ConditionFalse,
+				})),
+			kubeObjects: []runtime.Object{secret},
+		},
+		"retry a probe which has not passed before the timeout": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probe), verifying(started)),
+			kubeObjects: []runtime.Object{secret},
+			probeErr:    errors.New("example.com is not serving the issued certificate"),
+		},
+		"mark the revision as verified and store it once the probe passes": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probeWithRollback), verifying(started)),
+			kubeObjects: []runtime.Object{secret},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "testns",
+						Name:        "test-tls-verified",
+						Annotations: map[string]string{cmapi.CertificateNameKey: "test"},
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(baseCrt,
+							cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))},
+					},
+					Type: corev1.SecretTypeTLS,
+					Data: secret.Data,
+				})),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probeWithRollback), verifying(started),
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionVerificationFailed,
+							Status:             cmmeta.ConditionFalse,
+							Reason:             "Verified",
+							Message:            "The certificate of revision 2 has been verified",
+							LastTransitionTime: &metaNow,
+						})))),
+			},
+			expectedEvents: []string{"Normal Verified The certificate of revision 2 has been verified"},
+		},
+		"fail the verification and roll back if the probe has not passed within the timeout": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probeWithRollback), verifying(timedOut)),
+			kubeObjects: []runtime.Object{secret, verifiedSecret},
+			probeErr:    errors.New("example.com is not serving the issued certificate"),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", &corev1.Secret{
+					ObjectMeta: secret.ObjectMeta,
+					Data:       verifiedSecret.Data,
+				})),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateVerification(probeWithRollback), verifying(timedOut),
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionVerificationFailed,
+							Status:             cmmeta.ConditionTrue,
+							Reason:             "Failed",
+							Message:            "Verification of the certificate of revision 2 failed: checks did not pass within 5m0s: example.com is not serving the issued certificate; the previously verified certificate has been restored",
+							LastTransitionTime: &metaNow,
+						})))),
+			},
+			expectedEvents: []string{
+				"Warning Failed Verification of the certificate of revision 2 failed: checks did not pass within 5m0s: example.com is not serving the issued certificate",
+				`Warning RolledBack Restored the previously verified certificate to Secret "test-tls"`,
+			},
+		},
+		"create the verification job": {
+			certificate: jobCrt,
+			kubeObjects: []runtime.Object{secret},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewGetAction(batchv1.SchemeGroupVersion.WithResource("jobs"), "testns", expectedJob.Name)),
+				testpkg.NewAction(coretesting.NewCreateAction(batchv1.SchemeGroupVersion.WithResource("jobs"), "testns", expectedJob)),
+			},
+		},
+		"fail the verification if the job has failed": {
+			certificate: jobCrt,
+			kubeObjects: []runtime.Object{secret, failedJob},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewGetAction(batchv1.SchemeGroupVersion.WithResource("jobs"), "testns", expectedJob.Name)),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(jobCrt,
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionVerificationFailed,
+							Status:             cmmeta.ConditionTrue,
+							Reason:             "Failed",
+							Message:            `Verification of the certificate of revision 2 failed: verification job "` + expectedJob.Name + `" failed: Job has reached the specified backoff limit`,
+							LastTransitionTime: &metaNow,
+						})))),
+			},
+			expectedEvents: []string{
+				`Warning Failed Verification of the certificate of revision 2 failed: verification job "` + expectedJob.Name + `" failed: Job has reached the specified backoff limit`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				KubeObjects:        test.kubeObjects,
+				CertManagerObjects: []runtime.Object{test.certificate},
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.probe = func(context.Context, string, *x509.Certificate) error {
+				return test.probeErr
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := builder.AllEventsCalled(); err != nil {
+				builder.T.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
@@ -68,6 +68,9 @@ type ACME struct {
 
 	// fieldManager is the manager name used for Create and Apply operations.
 	fieldManager string
+
+	// ownedBy identifies this cert-manager instance on the Orders it creates.
+	ownedBy string
 }
 
 func init() {
@@ -107,6 +110,7 @@ func NewACME(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 		recorder:                 ctx.Recorder,
 		copiedAnnotationPrefixes: ctx.CertificateOptions.CopiedAnnotationPrefixes,
 		fieldManager:             ctx.FieldManager,
+		ownedBy:                  ctx.OwnedBy,
 	}
 }
 
@@ -155,6 +159,7 @@ func (a *ACME) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningR
 		_, uerr := ctrlutil.UpdateOrApplyStatus(ctx, a.certClient, csr, certificatesv1.CertificateFailed, a.fieldManager)
 		return uerr
 	}
+	expectedOrder.Labels = controllerpkg.AddOwnedByLabels(expectedOrder.Labels, a.ownedBy, csr)
 
 	order, err := a.orderLister.Orders(expectedOrder.Namespace).Get(expectedOrder.Name)
 	if apierrors.IsNotFound(err) {
@@ -72,6 +72,10 @@ type Controller struct {
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
 
+	// issuerClass is the class of the issuers whose requests are processed
+	// by this controller.
+	issuerClass string
+
 	queue workqueue.RateLimitingInterface
 
 	// logger to be used by this controller
@@ -172,6 +176,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.recorder = ctx.Recorder
 	c.certClient = kubeClient.CertificatesV1().CertificateSigningRequests()
 	c.fieldManager = ctx.FieldManager
+	c.issuerClass = ctx.IssuerClass
 
 	// Construct the signer implementation with the built component context.
 	c.signer = c.signerConstructor(ctx)
@@ -31,6 +31,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -97,6 +98,13 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 	}
 
 	log = logf.WithRelatedResource(log, issuerObj)
+
+	// The issuer belongs to another cert-manager instance, ignore
+	if !controllerpkg.IsClaimed(issuerObj, c.issuerClass) {
+		dbg.Info("issuer class does not match this controller, ignoring", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	dbg.Info("ensuring issuer type matches this controller")
 
 	signerType, err := apiutil.NameForIssuer(issuerObj)
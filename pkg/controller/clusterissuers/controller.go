@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -61,6 +62,9 @@ type controller struct {
 
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
+
+	// issuerClass is the class of the issuers processed by this controller.
+	issuerClass string
 }
 
 // Register registers and constructs the controller using the provided context.
@@ -76,11 +80,15 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// obtain references to all the informers used by this controller
 	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
 	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	challengeInformer := ctx.SharedInformerFactory.Acme().V1().Challenges()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		clusterIssuerInformer.Informer().HasSynced,
 		secretInformer.Informer().HasSynced,
+		challengeInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
 	}
 
 	// set all the references to the listers for used by the Sync function
@@ -90,11 +98,25 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// register handler functions
 	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretDeleted})
+	// ACME issuers own the Challenges which solve the authorizations of their
+	// preAuthorizedDNSNames, and are re-synced as the Challenges progress.
+	challengeInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: controllerpkg.HandleOwnedResourceNamespacedFunc(c.log, c.queue, cmapi.SchemeGroupVersion.WithKind(cmapi.ClusterIssuerKind), func(_, name string) (interface{}, error) {
+			return c.clusterIssuerLister.Get(name)
+		}),
+	})
+
+	// ACME issuers record the rate limit budgets left after the certificates
+	// they have issued, and are re-synced as CertificateRequests are issued.
+	certificateRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.certificateRequestUpdated,
+	})
 
 	// instantiate additional helpers used by this controller
 	c.issuerFactory = issuer.NewFactory(ctx)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.issuerClass = ctx.IssuerClass
 	c.recorder = ctx.Recorder
 	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
 
@@ -149,6 +171,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !controllerpkg.IsClaimed(issuer, c.issuerClass) {
+		logf.WithResource(log, issuer).V(logf.DebugLevel).Info("skipping clusterissuer with a different class", "issuer_class", c.issuerClass)
+		return nil
+	}
+
 	ctx = logf.NewContext(ctx, logf.WithResource(log, issuer))
 	return c.Sync(ctx, issuer)
 }
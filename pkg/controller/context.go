@@ -20,19 +20,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/utils/clock"
@@ -41,8 +45,11 @@ import (
 	gwscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
+	"github.com/cert-manager/cert-manager/internal/controller/eventexport"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/secretaccess"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
@@ -77,12 +84,20 @@ type Context struct {
 	FieldManager string
 	// RESTConfig is the loaded Kubernetes apiserver rest client configuration
 	RESTConfig *rest.Config
+	// ExternalUserAgent is the User Agent that should be used for requests
+	// to services outside of the cluster, such as ACME servers and DNS
+	// providers.
+	ExternalUserAgent string
 	// Client is a Kubernetes clientset
 	Client kubernetes.Interface
 	// CMClient is a cert-manager clientset
 	CMClient clientset.Interface
 	// GWClient is a GatewayAPI clientset.
 	GWClient gwclient.Interface
+	// DynamicClient is a dynamic client used for resources whose types are
+	// not known to cert-manager, such as Istio VirtualServices and OpenShift
+	// Routes.
+	DynamicClient dynamic.Interface
 	// DiscoveryClient is a discovery interface. Usually set to Client.Discovery unless a fake client is in use.
 	DiscoveryClient discovery.DiscoveryInterface
 
@@ -113,6 +128,10 @@ type ContextOptions struct {
 	// and authenticate to the API server.
 	Kubeconfig string
 
+	// KubeContext is the optional name of the kubeconfig context to use. If
+	// unset, the current context of the kubeconfig is used.
+	KubeContext string
+
 	// Kubernetes API QPS is the value of the maximum QPS to the API server from
 	// clients.
 	KubernetesAPIQPS float32
@@ -131,11 +150,30 @@ type ContextOptions struct {
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
+	// OwnedBy identifies this cert-manager instance. If set, resources
+	// created by the controllers are labelled with it, and resources which
+	// are not labelled with it are not modified.
+	OwnedBy string
+
+	// EventExporter, if set, is sent the Events recorded by the controllers
+	// so that they can be forwarded to an external system.
+	EventExporter *eventexport.Exporter
+
+	// IssuerClass is the value of the cert-manager.io/class annotation of the
+	// Issuers, ClusterIssuers and Certificates which this cert-manager
+	// instance processes.
+	IssuerClass string
+
+	// IssuancePause reports whether issuance has been paused cluster wide,
+	// in which case no new ACME orders are submitted.
+	IssuancePause *IssuancePause
+
 	IssuerOptions
 	ACMEOptions
 	IngressShimOptions
 	CertificateOptions
 	SchedulerOptions
+	SecretAccessOptions
 }
 
 type IssuerOptions struct {
@@ -151,6 +189,11 @@ type IssuerOptions struct {
 	// IssuerAmbientCredentials controls whether an issuer should pick up ambient
 	// credentials, such as those from metadata services, to construct clients.
 	IssuerAmbientCredentials bool
+
+	// KubernetesCSRAllowedSignerNames is the list of Kubernetes signer names
+	// which KubernetesCSR issuers may request CertificateSigningRequests
+	// from. KubernetesCSR issuers are not ready if this is empty.
+	KubernetesCSRAllowedSignerNames []string
 }
 
 type ACMEOptions struct {
@@ -170,6 +213,18 @@ type ACMEOptions struct {
 	// HTTP01SolverResourceLimitsMemory defines the ACME pod's resource limits Memory size
 	HTTP01SolverResourceLimitsMemory resource.Quantity
 
+	// HTTP01SolverRunAsNonRoot sets runAsNonRoot on the ACME pod's security
+	// context.
+	HTTP01SolverRunAsNonRoot bool
+
+	// HTTP01SolverSeccompProfileType is the type of the seccomp profile set on
+	// the ACME pod's security context. No profile is set if empty.
+	HTTP01SolverSeccompProfileType corev1.SeccompProfileType
+
+	// HTTP01SolverReadOnlyRootFilesystem sets readOnlyRootFilesystem on the
+	// security context of the ACME pod's container.
+	HTTP01SolverReadOnlyRootFilesystem bool
+
 	// HTTP01SolverNameservers is a list of nameservers to use when performing self-checks
 	// for ACME HTTP01 validations.
 	HTTP01SolverNameservers []string
@@ -188,6 +243,32 @@ type ACMEOptions struct {
 
 	// DNS01CheckRetryPeriod is the time the controller should wait between checking if a ACME dns entry exists.
 	DNS01CheckRetryPeriod time.Duration
+
+	// UserAgentClusterID is an optional identifier for this cluster which is
+	// included in the User Agent sent to ACME servers and DNS providers.
+	UserAgentClusterID string
+
+	// RedactUserAgent controls whether version, platform and cluster
+	// information is removed from the User Agent sent to ACME servers and DNS
+	// providers.
+	RedactUserAgent bool
+
+	// HTTPClient configures the HTTP client used to communicate with ACME
+	// servers, unless overridden by an issuer's httpClient field.
+	HTTPClient accounts.HTTPClientOptions
+
+	// OrderDiagnosticsTTL is how long the diagnostics of failed Orders are
+	// retained for in a ConfigMap. If zero, no diagnostics are retained.
+	OrderDiagnosticsTTL time.Duration
+
+	// AuthorizationFetchParallelism is the maximum number of authorizations
+	// of a new Order which are fetched from the ACME server at the same time.
+	AuthorizationFetchParallelism int
+
+	// DirectoryMetaCache is used as a cache of the metadata advertised in
+	// the directories of ACME servers between various components of
+	// cert-manager. If nil, the metadata is cached by each component.
+	DirectoryMetaCache *acmecl.DirectoryMetaCache
 }
 
 // IngressShimOptions contain default Issuer GVK config for the certificate-shim controllers.
@@ -213,6 +294,32 @@ type SchedulerOptions struct {
 	// MaxConcurrentChallenges determines the maximum number of challenges that can be
 	// scheduled as 'processing' at once.
 	MaxConcurrentChallenges int
+
+	// Policy determines the order in which challenges are scheduled, either
+	// FIFO or ZoneFair.
+	Policy string
+
+	// Workers is the number of challenges that are processed concurrently,
+	// such as when presenting challenges or checking their propagation.
+	Workers int
+}
+
+// SecretAccessOptions configure the secret-access controller, which grants
+// the cert-manager controller access to Secrets on a per-namespace basis.
+type SecretAccessOptions struct {
+	// ClusterRoleName is the name of the ClusterRole which grants access to
+	// Secrets. It is bound in each selected namespace using a RoleBinding.
+	ClusterRoleName string
+
+	// ServiceAccountNamespace and ServiceAccountName identify the
+	// ServiceAccount that the ClusterRole is bound to.
+	ServiceAccountNamespace string
+	ServiceAccountName      string
+
+	// NamespaceSelector is a label selector used to select the namespaces in
+	// which the ClusterRole is bound. An empty selector selects all
+	// namespaces.
+	NamespaceSelector string
 }
 
 // ContextFactory is used for constructing new Contexts who's clients have been
@@ -235,7 +342,7 @@ type ContextFactory struct {
 // corresponding QPS and Burst buckets.
 func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactory, error) {
 	// Load the users Kubernetes config
-	restConfig, err := clientcmd.BuildConfigFromFlags(opts.APIServerHost, opts.Kubeconfig)
+	restConfig, err := buildRESTConfig(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error creating rest config: %w", err)
 	}
@@ -243,6 +350,10 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 	restConfig.QPS = opts.KubernetesAPIQPS
 	restConfig.Burst = opts.KubernetesAPIBurst
 
+	logf.FromContext(ctx).V(logf.InfoLevel).Info("connecting to the Kubernetes API server",
+		"host", restConfig.Host, "in_cluster", opts.InCluster(),
+		"qps", restConfig.QPS, "burst", restConfig.Burst)
+
 	// Construct a single RateLimiter used across all built Context's clients. A
 	// single rate limiter (with corresponding QPS and Burst buckets) are
 	// preserved for all Contexts.
@@ -262,6 +373,18 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 
 	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(clients.cmClient, resyncPeriod, informers.WithNamespace(opts.Namespace))
 	kubeSharedInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(clients.kubeClient, resyncPeriod, kubeinformers.WithNamespace(opts.Namespace))
+	if len(opts.SecretAccessOptions.ClusterRoleName) > 0 && len(opts.Namespace) == 0 {
+		// Access to Secrets is only granted in the namespaces selected by the
+		// secret-access controller, so Secrets cannot be listed cluster wide.
+		selector, err := labels.Parse(opts.SecretAccessOptions.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret access namespace selector %q: %w", opts.SecretAccessOptions.NamespaceSelector, err)
+		}
+		kubeSharedInformerFactory = secretaccess.NewInformerFactory(kubeSharedInformerFactory, clients.kubeClient, resyncPeriod, secretaccess.NamespaceSelection{
+			Selector:                 selector,
+			ClusterResourceNamespace: opts.IssuerOptions.ClusterResourceNamespace,
+		})
+	}
 	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(clients.gwClient, resyncPeriod, gwinformers.WithNamespace(opts.Namespace))
 
 	return &ContextFactory{
@@ -279,6 +402,37 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 	}, nil
 }
 
+// InCluster returns whether Contexts built using the options connect to the
+// API server using the in-cluster configuration of a Pod, i.e. no kubeconfig,
+// kubeconfig context or API server has been given and the process runs in a
+// Pod.
+func (o ContextOptions) InCluster() bool {
+	if o.Kubeconfig != "" || o.KubeContext != "" || o.APIServerHost != "" {
+		return false
+	}
+	return os.Getenv("KUBERNETES_SERVICE_HOST") != "" && os.Getenv("KUBERNETES_SERVICE_PORT") != ""
+}
+
+// buildRESTConfig loads the REST config used to connect to the API server.
+// Out of cluster, the kubeconfig is loaded from the given path or, if neither
+// a path nor an API server is given, from the KUBECONFIG environment variable
+// or the default location in the home directory.
+func buildRESTConfig(opts ContextOptions) (*rest.Config, error) {
+	if opts.InCluster() {
+		return rest.InClusterConfig()
+	}
+
+	loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: opts.Kubeconfig}
+	if opts.Kubeconfig == "" && opts.APIServerHost == "" {
+		loadingRules = clientcmd.NewDefaultClientConfigLoadingRules()
+	}
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: opts.KubeContext,
+		ClusterInfo:    clientcmdapi.Cluster{Server: opts.APIServerHost},
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+}
+
 // Build builds a new controller Context who's clients have a User Agent
 // derived from the optional component name.
 func (c *ContextFactory) Build(component ...string) (*Context, error) {
@@ -298,14 +452,19 @@ func (c *ContextFactory) Build(component ...string) (*Context, error) {
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartLogging(logf.WithInfof(c.log.V(logf.DebugLevel)).Infof)
 	eventBroadcaster.StartRecordingToSink(&clientv1.EventSinkImpl{Interface: clients.kubeClient.CoreV1().Events("")})
+	if c.ctx.EventExporter != nil {
+		eventBroadcaster.StartEventWatcher(c.ctx.EventExporter.Export)
+	}
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: util.PrefixFromUserAgent(restConfig.UserAgent)})
 
 	ctx := *c.ctx
 	ctx.FieldManager = util.PrefixFromUserAgent(restConfig.UserAgent)
 	ctx.RESTConfig = restConfig
+	ctx.ExternalUserAgent = util.ExternalUserAgent(restConfig.UserAgent, c.ctx.UserAgentClusterID, c.ctx.RedactUserAgent)
 	ctx.Client = clients.kubeClient
 	ctx.CMClient = clients.cmClient
 	ctx.GWClient = clients.gwClient
+	ctx.DynamicClient = clients.dynamicClient
 	ctx.DiscoveryClient = clients.kubeClient.Discovery()
 	ctx.Recorder = recorder
 
@@ -317,6 +476,7 @@ type contextClients struct {
 	kubeClient       kubernetes.Interface
 	cmClient         clientset.Interface
 	gwClient         gwclient.Interface
+	dynamicClient    dynamic.Interface
 	gatewayAvailable bool
 }
 
@@ -362,5 +522,11 @@ func buildClients(restConfig *rest.Config) (contextClients, error) {
 		return contextClients{}, fmt.Errorf("error creating kubernetes client: %w", err)
 	}
 
-	return contextClients{kubeClient, cmClient, gwClient, gatewayAvailable}, nil
+	// Create a dynamic client.
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return contextClients{}, fmt.Errorf("error creating dynamic client: %w", err)
+	}
+
+	return contextClients{kubeClient, cmClient, gwClient, dynamicClient, gatewayAvailable}, nil
 }
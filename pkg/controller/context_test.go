@@ -18,9 +18,12 @@ package controller
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_NewContextFactory(t *testing.T) {
@@ -40,3 +43,71 @@ func Test_NewContextFactory(t *testing.T) {
 	assert.NotNil(t, ctx1.RESTConfig.RateLimiter)
 	assert.Same(t, ctx1.RESTConfig.RateLimiter, ctx2.RESTConfig.RateLimiter)
 }
+
+func Test_buildRESTConfig(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+current-context: workload
+clusters:
+- name: workload
+  cluster:
+    server: https://workload.example.com
+- name: management
+  cluster:
+    server: https://management.example.com
+contexts:
+- name: workload
+  context:
+    cluster: workload
+    user: user
+- name: management
+  context:
+    cluster: management
+    user: user
+users:
+- name: user
+  user:
+    token: token
+`), 0600)
+	require.NoError(t, err)
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	tests := map[string]struct {
+		opts    ContextOptions
+		expHost string
+	}{
+		"the current context of the kubeconfig is used by default": {
+			opts:    ContextOptions{Kubeconfig: kubeconfig},
+			expHost: "https://workload.example.com",
+		},
+		"the given context is used": {
+			opts:    ContextOptions{Kubeconfig: kubeconfig, KubeContext: "management"},
+			expHost: "https://management.example.com",
+		},
+		"the kubeconfig is loaded from the environment if not given": {
+			opts:    ContextOptions{KubeContext: "management"},
+			expHost: "https://management.example.com",
+		},
+		"the API server host overrides the one of the kubeconfig": {
+			opts:    ContextOptions{Kubeconfig: kubeconfig, APIServerHost: "https://localhost:6443"},
+			expHost: "https://localhost:6443",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, test.opts.InCluster())
+			restConfig, err := buildRESTConfig(test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.expHost, restConfig.Host)
+			assert.Equal(t, "token", restConfig.BearerToken)
+		})
+	}
+
+	_, err = buildRESTConfig(ContextOptions{Kubeconfig: kubeconfig, KubeContext: "missing"})
+	assert.Error(t, err)
+}
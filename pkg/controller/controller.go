@@ -91,6 +91,10 @@ type controller struct {
 
 	// metrics is used to expose Prometheus, shared by all controllers
 	metrics *metrics.Metrics
+
+	// workers is the number of workers the controller is run with. If it is
+	// not higher than zero, the number passed to Run is used.
+	workers int
 }
 
 // Run starts the controller loop
@@ -105,6 +109,11 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) error {
 		return fmt.Errorf("error waiting for informer caches to sync")
 	}
 
+	if c.workers > 0 {
+		workers = c.workers
+	}
+
+	log.V(logf.DebugLevel).Info("starting workers", "count", workers)
 	var wg sync.WaitGroup
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
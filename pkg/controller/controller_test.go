@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestRunWorkers(t *testing.T) {
+	tests := map[string]struct {
+		runWorkers int
+		workers    int
+		expected   int
+	}{
+		"the number of workers passed to Run is used by default": {
+			runWorkers: 3,
+			expected:   3,
+		},
+		"the number of workers of the controller overrides the one passed to Run": {
+			runWorkers: 1,
+			workers:    4,
+			expected:   4,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Each item blocks its worker until as many items as expected
+			// workers are being processed concurrently.
+			var wg sync.WaitGroup
+			wg.Add(test.expected)
+			allStarted := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(allStarted)
+			}()
+
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			for i := 0; i < test.expected; i++ {
+				queue.Add(fmt.Sprintf("item-%d", i))
+			}
+
+			ctrl := NewController(context.Background(), "test", metrics.New(logf.Log, clock.RealClock{}), func(ctx context.Context, key string) error {
+				wg.Done()
+				<-allStarted
+				return nil
+			}, nil, nil, queue)
+			ctrl.(*controller).workers = test.workers
+
+			stopCh := make(chan struct{})
+			errCh := make(chan error)
+			go func() { errCh <- ctrl.Run(test.runWorkers, stopCh) }()
+
+			select {
+			case <-allStarted:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected %d items to be processed concurrently", test.expected)
+			}
+
+			close(stopCh)
+			if err := <-errCh; err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -41,3 +41,14 @@ func (o IssuerOptions) CanUseAmbientCredentials(iss cmapi.GenericIssuer) bool {
 	}
 	return false
 }
+
+// KubernetesCSRSignerAllowed returns whether KubernetesCSR issuers may request
+// certificates from the Kubernetes signer with the given name.
+func (o IssuerOptions) KubernetesCSRSignerAllowed(signerName string) bool {
+	for _, allowed := range o.KubernetesCSRAllowedSignerNames {
+		if allowed == signerName {
+			return true
+		}
+	}
+	return false
+}
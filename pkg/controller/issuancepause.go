@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync/atomic"
+)
+
+// IssuancePause reports whether issuance has been paused cluster wide, either
+// statically when the controller was started or at runtime.
+// A nil IssuancePause is never paused.
+type IssuancePause struct {
+	static bool
+	// runtime is 1 while issuance is paused at runtime.
+	runtime int32
+}
+
+// NewIssuancePause returns an IssuancePause which is always paused if paused
+// is true, and otherwise only while paused at runtime using SetPaused.
+func NewIssuancePause(paused bool) *IssuancePause {
+	return &IssuancePause{static: paused}
+}
+
+// Paused returns true if issuance is currently paused.
+func (p *IssuancePause) Paused() bool {
+	if p == nil {
+		return false
+	}
+	return p.static || atomic.LoadInt32(&p.runtime) == 1
+}
+
+// SetPaused pauses or resumes issuance at runtime. Issuance cannot be resumed
+// at runtime if it was paused when the controller was started.
+func (p *IssuancePause) SetPaused(paused bool) {
+	var v int32
+	if paused {
+		v = 1
+	}
+	atomic.StoreInt32(&p.runtime, v)
+}
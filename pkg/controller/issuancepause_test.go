@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+)
+
+func TestIssuancePause(t *testing.T) {
+	var nilPause *IssuancePause
+	if nilPause.Paused() {
+		t.Errorf("expected a nil IssuancePause to not be paused")
+	}
+
+	p := NewIssuancePause(false)
+	if p.Paused() {
+		t.Errorf("expected issuance to not be paused")
+	}
+	p.SetPaused(true)
+	if !p.Paused() {
+		t.Errorf("expected issuance to be paused at runtime")
+	}
+	p.SetPaused(false)
+	if p.Paused() {
+		t.Errorf("expected issuance to be resumed at runtime")
+	}
+
+	p = NewIssuancePause(true)
+	p.SetPaused(false)
+	if !p.Paused() {
+		t.Errorf("expected issuance paused by flag to not be resumed at runtime")
+	}
+}
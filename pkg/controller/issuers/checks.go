@@ -19,9 +19,13 @@ package issuers
 import (
 	"fmt"
 
-	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 )
 
 func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, error) {
@@ -38,57 +42,34 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 			continue
 		}
 
-		switch {
-		case iss.Spec.ACME != nil:
-			if iss.Spec.ACME.PrivateKey.Name == secret.Name {
+		for _, name := range issuer.SecretNames(&iss.Spec) {
+			if name == secret.Name {
 				affected = append(affected, iss)
-				continue
-			}
-			if iss.Spec.ACME.ExternalAccountBinding != nil {
-				if iss.Spec.ACME.ExternalAccountBinding.Key.Name == secret.Name {
-					affected = append(affected, iss)
-					continue
-				}
-			}
-		case iss.Spec.CA != nil:
-			if iss.Spec.CA.SecretName == secret.Name {
-				affected = append(affected, iss)
-				continue
-			}
-		case iss.Spec.Venafi != nil:
-			if iss.Spec.Venafi.TPP != nil {
-				if iss.Spec.Venafi.TPP.CredentialsRef.Name == secret.Name {
-					affected = append(affected, iss)
-					continue
-				}
-			}
-			if iss.Spec.Venafi.Cloud != nil {
-				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.Name {
-					affected = append(affected, iss)
-					continue
-				}
-			}
-		case iss.Spec.Vault != nil:
-			if iss.Spec.Vault.Auth.TokenSecretRef != nil {
-				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.Name {
-					affected = append(affected, iss)
-					continue
-				}
-			}
-			if iss.Spec.Vault.Auth.AppRole != nil {
-				if iss.Spec.Vault.Auth.AppRole.SecretRef.Name == secret.Name {
-					affected = append(affected, iss)
-					continue
-				}
-			}
-			if iss.Spec.Vault.Auth.Kubernetes != nil {
-				if iss.Spec.Vault.Auth.Kubernetes.SecretRef.Name == secret.Name {
-					affected = append(affected, iss)
-					continue
-				}
+				break
 			}
 		}
 	}
 
 	return affected, nil
 }
+
+// certificateRequestUpdated enqueues the ACME Issuer a CertificateRequest
+// references when the request is issued, so that the rate limit budgets in
+// the Issuer's status are updated.
+func (c *controller) certificateRequestUpdated(old, new interface{}) {
+	cr, issued := controllerpkg.CertificateRequestIssued(old, new)
+	if !issued || apiutil.IssuerKind(cr.Spec.IssuerRef) != v1.IssuerKind ||
+		(cr.Spec.IssuerRef.Group != "" && cr.Spec.IssuerRef.Group != v1.SchemeGroupVersion.Group) {
+		return
+	}
+	iss, err := c.issuerLister.Issuers(cr.Namespace).Get(cr.Spec.IssuerRef.Name)
+	if err != nil || iss.Spec.ACME == nil {
+		return
+	}
+	key, err := keyFunc(iss)
+	if err != nil {
+		c.log.Error(err, "error computing key for resource")
+		return
+	}
+	c.queue.Add(key)
+}
@@ -28,6 +28,7 @@ import (
 	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/globals"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
@@ -56,6 +57,12 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.Issuer) (err error) {
 		return err
 	}
 
+	if f, ok := i.(issuer.Finalizer); ok {
+		if done, err := c.syncFinalizer(ctx, issuerCopy, f); done || err != nil {
+			return err
+		}
+	}
+
 	err = i.Setup(ctx)
 	if err != nil {
 		s := messageErrorInitIssuer + err.Error()
@@ -67,6 +74,43 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.Issuer) (err error) {
 	return nil
 }
 
+// syncFinalizer ensures the Issuer has the issuer's finalizer only if it is
+// required, and finalizes the issuer once the Issuer has been deleted.
+// It returns true if the Issuer has been updated or is being deleted, in
+// which case the issuer must not be set up.
+func (c *controller) syncFinalizer(ctx context.Context, iss *cmapi.Issuer, f issuer.Finalizer) (bool, error) {
+	name, required := f.Finalizer()
+	has := false
+	var finalizers []string
+	for _, finalizer := range iss.Finalizers {
+		if finalizer == name {
+			has = true
+			continue
+		}
+		finalizers = append(finalizers, finalizer)
+	}
+
+	switch {
+	case iss.DeletionTimestamp != nil && has:
+		if required {
+			if err := f.Finalize(ctx); err != nil {
+				return true, err
+			}
+		}
+
+	case iss.DeletionTimestamp != nil, required == has:
+		return false, nil
+
+	case required:
+		finalizers = append(finalizers, name)
+	}
+
+	iss = iss.DeepCopy()
+	iss.Finalizers = finalizers
+	_, err := c.cmClient.CertmanagerV1().Issuers(iss.Namespace).Update(ctx, iss, metav1.UpdateOptions{})
+	return true, err
+}
+
 func (c *controller) updateIssuerStatus(ctx context.Context, old, new *cmapi.Issuer) error {
 	if apiequality.Semantic.DeepEqual(old.Status, new.Status) {
 		return nil
@@ -18,6 +18,7 @@ package issuers
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"runtime/debug"
 	"testing"
@@ -29,6 +30,7 @@ import (
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 )
 
@@ -95,6 +97,115 @@ func TestUpdateIssuerStatus(t *testing.T) {
 	assertDeepEqual(t, errorf, newStatus, issuer.Status)
 }
 
+type fakeFinalizer struct {
+	required    bool
+	finalizeErr error
+	finalized   bool
+}
+
+func (f *fakeFinalizer) Finalizer() (string, bool) {
+	return "example.com/finalizer", f.required
+}
+
+func (f *fakeFinalizer) Finalize(context.Context) error {
+	f.finalized = true
+	return f.finalizeErr
+}
+
+func TestSyncFinalizer(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+
+	tests := map[string]struct {
+		finalizers  []string
+		deleted     bool
+		required    bool
+		finalizeErr error
+
+		expDone       bool
+		expErr        bool
+		expFinalized  bool
+		expFinalizers []string
+	}{
+		"finalizer is added when required": {
+			finalizers:    []string{"other"},
+			required:      true,
+			expDone:       true,
+			expFinalizers: []string{"other", "example.com/finalizer"},
+		},
+		"finalizer is removed when no longer required": {
+			finalizers:    []string{"example.com/finalizer", "other"},
+			expDone:       true,
+			expFinalizers: []string{"other"},
+		},
+		"nothing is done when the finalizer is already present": {
+			finalizers: []string{"example.com/finalizer"},
+			required:   true,
+		},
+		"nothing is done when the finalizer is not required": {},
+		"issuer is finalized and the finalizer removed once deleted": {
+			finalizers:    []string{"example.com/finalizer", "other"},
+			deleted:       true,
+			required:      true,
+			expDone:       true,
+			expFinalized:  true,
+			expFinalizers: []string{"other"},
+		},
+		"finalizer is kept if the issuer cannot be finalized": {
+			finalizers:    []string{"example.com/finalizer"},
+			deleted:       true,
+			required:      true,
+			finalizeErr:   errors.New("test"),
+			expDone:       true,
+			expErr:        true,
+			expFinalized:  true,
+			expFinalizers: []string{"example.com/finalizer"},
+		},
+		"finalizer which is no longer required is removed without finalizing once deleted": {
+			finalizers:    []string{"example.com/finalizer"},
+			deleted:       true,
+			expDone:       true,
+			expFinalizers: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := &v1.Issuer{ObjectMeta: metav1.ObjectMeta{
+				Name:       "test",
+				Namespace:  "testns",
+				Finalizers: test.finalizers,
+			}}
+			if test.deleted {
+				iss.DeletionTimestamp = &deletionTimestamp
+			}
+			cmClient := cmfake.NewSimpleClientset(iss)
+			c := &controller{cmClient: cmClient}
+			f := &fakeFinalizer{required: test.required, finalizeErr: test.finalizeErr}
+
+			done, err := c.syncFinalizer(context.TODO(), iss, f)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if done != test.expDone {
+				t.Errorf("unexpected done, exp=%t got=%t", test.expDone, done)
+			}
+			if f.finalized != test.expFinalized {
+				t.Errorf("unexpected finalized, exp=%t got=%t", test.expFinalized, f.finalized)
+			}
+
+			got, err := cmClient.CertmanagerV1().Issuers("testns").Get(context.TODO(), "test", metav1.GetOptions{})
+			require.NoError(t, err)
+			expFinalizers := test.finalizers
+			if test.expDone && !test.expErr {
+				expFinalizers = test.expFinalizers
+			}
+			if !reflect.DeepEqual(got.Finalizers, expFinalizers) {
+				t.Errorf("unexpected finalizers, exp=%v got=%v", expFinalizers, got.Finalizers)
+			}
+		})
+	}
+}
+
 func assertIsUpdateAction(t *testing.T, f failfFunc, action clientgotesting.Action) clientgotesting.UpdateAction {
 	updateAction, ok := action.(clientgotesting.UpdateAction)
 	if !ok {
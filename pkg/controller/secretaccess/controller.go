@@ -0,0 +1,263 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretaccess
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/cert-manager/cert-manager/internal/controller/secretaccess"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the string used to refer to this controller
+	// when enabling or disabling it from command line flags.
+	ControllerName = "secret-access"
+
+	// managedByLabelKey and managedByLabelValue label the RoleBindings
+	// created by this controller. Only RoleBindings with this label are
+	// updated or deleted.
+	managedByLabelKey   = "app.kubernetes.io/managed-by"
+	managedByLabelValue = "cert-manager"
+)
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+// controller binds a ClusterRole granting access to Secrets to the
+// cert-manager controller's ServiceAccount in each selected namespace. This
+// allows the cert-manager controller to be deployed without cluster wide
+// write access to Secrets.
+// The controller is synced on Namespace events, and on events for the
+// RoleBindings it manages.
+type controller struct {
+	namespaceLister   corelisters.NamespaceLister
+	roleBindingLister rbaclisters.RoleBindingLister
+	client            kubernetes.Interface
+
+	clusterRoleName         string
+	serviceAccountNamespace string
+	serviceAccountName      string
+	selection               secretaccess.NamespaceSelection
+}
+
+func NewController(
+	log logr.Logger,
+	client kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	opts controllerpkg.SecretAccessOptions,
+	clusterResourceNamespace string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	selector, err := labels.Parse(opts.NamespaceSelector)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid namespace selector %q: %w", opts.NamespaceSelector, err)
+	}
+
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	namespaceInformer := factory.Core().V1().Namespaces()
+	roleBindingInformer := factory.Rbac().V1().RoleBindings()
+
+	namespaceInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// Re-sync the owning namespace whenever a managed RoleBinding is changed
+	// or deleted by something other than this controller.
+	roleBindingInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: func(obj interface{}) {
+			rb, ok := obj.(*rbacv1.RoleBinding)
+			if !ok {
+				log.Error(nil, "object is not a RoleBinding", "object", obj)
+				return
+			}
+			if rb.Name != opts.ClusterRoleName {
+				return
+			}
+			queue.Add(rb.Namespace)
+		},
+	})
+
+	// build a list of InformerSynced functions that will be returned by the
+	// Register method.  the controller will only begin processing items once all
+	// of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		namespaceInformer.Informer().HasSynced,
+		roleBindingInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		namespaceLister:         namespaceInformer.Lister(),
+		roleBindingLister:       roleBindingInformer.Lister(),
+		client:                  client,
+		clusterRoleName:         opts.ClusterRoleName,
+		serviceAccountNamespace: opts.ServiceAccountNamespace,
+		serviceAccountName:      opts.ServiceAccountName,
+		selection: secretaccess.NamespaceSelection{
+			Selector:                 selector,
+			ClusterResourceNamespace: clusterResourceNamespace,
+		},
+	}, queue, mustSync, nil
+}
+
+// ProcessItem ensures that the RoleBinding granting access to Secrets exists
+// in the namespace with the given name if it is selected, and does not exist
+// if it is not.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("namespace", key)
+	ctx = logf.NewContext(ctx, log)
+
+	ns, err := c.namespaceLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		// RoleBindings are removed along with the namespace.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ns.DeletionTimestamp != nil {
+		return nil
+	}
+
+	existing, err := c.roleBindingLister.RoleBindings(ns.Name).Get(c.clusterRoleName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if apierrors.IsNotFound(err) {
+		existing = nil
+	}
+
+	if existing != nil && !managed(existing) {
+		// A RoleBinding with the same name which was not created by this
+		// controller is never modified or deleted.
+		log.V(logf.WarnLevel).Info("RoleBinding is not managed by cert-manager, ignoring", "rolebinding", existing.Name)
+		return nil
+	}
+
+	if !c.selection.Selected(ns.Name, ns.Labels) {
+		if existing == nil {
+			return nil
+		}
+		log.V(logf.DebugLevel).Info("namespace is not selected, removing RoleBinding")
+		return c.deleteRoleBinding(ctx, existing)
+	}
+
+	desired := c.buildRoleBinding(ns.Name)
+	switch {
+	case existing == nil:
+		log.V(logf.DebugLevel).Info("creating RoleBinding granting access to Secrets")
+		_, err := c.client.RbacV1().RoleBindings(ns.Name).Create(ctx, desired, metav1.CreateOptions{})
+		return err
+
+	case !apiequality.Semantic.DeepEqual(existing.RoleRef, desired.RoleRef):
+		// The roleRef of a RoleBinding is immutable, so the RoleBinding must
+		// be deleted and will be re-created on the next sync.
+		log.V(logf.DebugLevel).Info("RoleBinding references the wrong role, re-creating")
+		return c.deleteRoleBinding(ctx, existing)
+
+	case !apiequality.Semantic.DeepEqual(existing.Subjects, desired.Subjects):
+		log.V(logf.DebugLevel).Info("updating RoleBinding subjects")
+		updated := existing.DeepCopy()
+		updated.Subjects = desired.Subjects
+		_, err := c.client.RbacV1().RoleBindings(ns.Name).Update(ctx, updated, metav1.UpdateOptions{})
+		return err
+	}
+
+	return nil
+}
+
+// managed returns true if the RoleBinding was created by this controller.
+func managed(rb *rbacv1.RoleBinding) bool {
+	return rb.Labels[managedByLabelKey] == managedByLabelValue
+}
+
+func (c *controller) deleteRoleBinding(ctx context.Context, rb *rbacv1.RoleBinding) error {
+	err := c.client.RbacV1().RoleBindings(rb.Namespace).Delete(ctx, rb.Name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *controller) buildRoleBinding(namespace string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.clusterRoleName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				managedByLabelKey: managedByLabelValue,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     c.clusterRoleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: c.serviceAccountNamespace,
+				Name:      c.serviceAccountName,
+			},
+		},
+	}
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync, err := NewController(
+		log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SecretAccessOptions,
+		ctx.IssuerOptions.ClusterResourceNamespace,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretaccess
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+const (
+	testClusterRole = "cert-manager-controller-secrets"
+	testSANamespace = "cert-manager"
+	testSAName      = "cert-manager"
+)
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func roleBinding(namespace, roleName, saName string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testClusterRole,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "cert-manager",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     roleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Namespace: testSANamespace, Name: saName},
+		},
+	}
+}
+
+func unmanaged(rb *rbacv1.RoleBinding) *rbacv1.RoleBinding {
+	rb.Labels = nil
+	return rb
+}
+
+func TestProcessItem(t *testing.T) {
+	rbResource := rbacv1.SchemeGroupVersion.WithResource("rolebindings")
+
+	tests := map[string]struct {
+		key             string
+		selector        string
+		objects         []runtime.Object
+		expectedActions []testpkg.Action
+	}{
+		"do nothing if the namespace does not exist": {
+			key: "missing",
+		},
+		"create a RoleBinding in a selected namespace": {
+			key:     "test",
+			objects: []runtime.Object{namespace("test", nil)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(rbResource, "test",
+					roleBinding("test", testClusterRole, testSAName))),
+			},
+		},
+		"do nothing if the RoleBinding is up to date": {
+			key: "test",
+			objects: []runtime.Object{
+				namespace("test", nil),
+				roleBinding("test", testClusterRole, testSAName),
+			},
+		},
+		"update the subjects of an existing RoleBinding": {
+			key: "test",
+			objects: []runtime.Object{
+				namespace("test", nil),
+				roleBinding("test", testClusterRole, "other"),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(rbResource, "test",
+					roleBinding("test", testClusterRole, testSAName))),
+			},
+		},
+		"delete a RoleBinding referencing the wrong role": {
+			key: "test",
+			objects: []runtime.Object{
+				namespace("test", nil),
+				roleBinding("test", "other", testSAName),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(rbResource, "test", testClusterRole)),
+			},
+		},
+		"delete the RoleBinding from a namespace which is no longer selected": {
+			key:      "test",
+			selector: "secrets=allowed",
+			objects: []runtime.Object{
+				namespace("test", nil),
+				roleBinding("test", testClusterRole, testSAName),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(rbResource, "test", testClusterRole)),
+			},
+		},
+		"do not delete a RoleBinding which is not managed by cert-manager": {
+			key:      "test",
+			selector: "secrets=allowed",
+			objects: []runtime.Object{
+				namespace("test", nil),
+				unmanaged(roleBinding("test", testClusterRole, testSAName)),
+			},
+		},
+		"do not modify a RoleBinding which is not managed by cert-manager": {
+			key: "test",
+			objects: []runtime.Object{
+				namespace("test", nil),
+				unmanaged(roleBinding("test", "other", "other")),
+			},
+		},
+		"always select the cluster resource namespace": {
+			key:      "kube-system",
+			selector: "secrets=allowed",
+			objects:  []runtime.Object{namespace("kube-system", nil)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(rbResource, "kube-system",
+					roleBinding("kube-system", testClusterRole, testSAName))),
+			},
+		},
+		"create a RoleBinding in a namespace matching the selector": {
+			key:      "test",
+			selector: "secrets=allowed",
+			objects:  []runtime.Object{namespace("test", map[string]string{"secrets": "allowed"})},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(rbResource, "test",
+					roleBinding("test", testClusterRole, testSAName))),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:               t,
+				KubeObjects:     test.objects,
+				ExpectedActions: test.expectedActions,
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "kube-system"
+			builder.Context.SecretAccessOptions = controllerpkg.SecretAccessOptions{
+				ClusterRoleName:         testClusterRole,
+				ServiceAccountNamespace: testSANamespace,
+				ServiceAccountName:      testSAName,
+				NamespaceSelector:       test.selector,
+			}
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), test.key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
@@ -27,7 +27,9 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
@@ -64,6 +66,7 @@ type Builder struct {
 	KubeObjects        []runtime.Object
 	CertManagerObjects []runtime.Object
 	GWObjects          []runtime.Object
+	DynamicObjects     []runtime.Object
 	ExpectedActions    []Action
 	ExpectedEvents     []string
 	StringGenerator    StringGenerator
@@ -99,6 +102,13 @@ func (b *Builder) generateNameReactor(action coretesting.Action) (handled bool,
 
 const informerResyncPeriod = time.Second
 
+// dynamicListKinds are the list kinds of the resources which are accessed
+// using the dynamic client.
+var dynamicListKinds = map[schema.GroupVersionResource]string{
+	{Group: "networking.istio.io", Version: "v1beta1", Resource: "virtualservices"}: "VirtualServiceList",
+	{Group: "route.openshift.io", Version: "v1", Resource: "routes"}:                "RouteList",
+}
+
 // Init will construct a new context for this builder and set default values
 // for any unset fields.
 func (b *Builder) Init() {
@@ -114,6 +124,7 @@ func (b *Builder) Init() {
 	b.Client = kubefake.NewSimpleClientset(b.KubeObjects...)
 	b.CMClient = cmfake.NewSimpleClientset(b.CertManagerObjects...)
 	b.GWClient = gwfake.NewSimpleClientset(b.GWObjects...)
+	b.DynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), dynamicListKinds, b.DynamicObjects...)
 	b.DiscoveryClient = discoveryfake.NewDiscovery().WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
 		if groupVersion == networkingv1.SchemeGroupVersion.String() {
 			return &metav1.APIResourceList{
@@ -141,6 +152,7 @@ func (b *Builder) Init() {
 	b.FakeKubeClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeCMClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeGWClient().PrependReactor("create", "*", b.generateNameReactor)
+	b.FakeDynamicClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.KubeSharedInformerFactory = kubeinformers.NewSharedInformerFactory(b.Client, informerResyncPeriod)
 	b.SharedInformerFactory = informers.NewSharedInformerFactory(b.CMClient, informerResyncPeriod)
 	b.GWShared = gwinformers.NewSharedInformerFactory(b.GWClient, informerResyncPeriod)
@@ -181,6 +193,10 @@ func (b *Builder) FakeGWClient() *gwfake.Clientset {
 	return b.Context.GWClient.(*gwfake.Clientset)
 }
 
+func (b *Builder) FakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	return b.Context.DynamicClient.(*dynamicfake.FakeDynamicClient)
+}
+
 func (b *Builder) FakeCMInformerFactory() informers.SharedInformerFactory {
 	return b.Context.SharedInformerFactory
 }
@@ -245,6 +261,7 @@ func (b *Builder) AllActionsExecuted() error {
 	firedActions := b.FakeCMClient().Actions()
 	firedActions = append(firedActions, b.FakeKubeClient().Actions()...)
 	firedActions = append(firedActions, b.FakeGWClient().Actions()...)
+	firedActions = append(firedActions, b.FakeDynamicClient().Actions()...)
 
 	var unexpectedActions []coretesting.Action
 	var errs []error
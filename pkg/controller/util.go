@@ -29,6 +29,9 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -199,3 +202,57 @@ func BuildAnnotationsToCopy(allAnnotations map[string]string, prefixes []string)
 	}
 	return filteredAnnotations
 }
+
+// IsOwnedBy returns true if the object may be modified by the cert-manager
+// instance identified by ownedBy, which is the case if ownedBy is empty or if
+// the object is labelled with the same value.
+func IsOwnedBy(obj metav1.Object, ownedBy string) bool {
+	if ownedBy == "" {
+		return true
+	}
+	return obj.GetLabels()[cmapi.OwnedByLabelKey] == ownedBy
+}
+
+// IsClaimed returns true if the object is assigned to the cert-manager
+// instance run with the given issuer class. Objects without the class
+// annotation are only claimed by instances without an issuer class.
+func IsClaimed(obj metav1.Object, issuerClass string) bool {
+	return obj.GetAnnotations()[cmapi.IssuerClassAnnotationKey] == issuerClass
+}
+
+// AddOwnedByLabels returns a copy of the given labels with the labels
+// identifying the cert-manager instance and the UID of the controlling
+// resource added. The labels are returned unmodified if ownedBy is empty.
+// It must be called after any user supplied labels have been merged, so that
+// they can not override the labels of the controller.
+func AddOwnedByLabels(labels map[string]string, ownedBy string, controller metav1.Object) map[string]string {
+	if ownedBy == "" {
+		return labels
+	}
+	out := make(map[string]string, len(labels)+2)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out[cmapi.OwnedByLabelKey] = ownedBy
+	out[cmapi.ControllerUIDLabelKey] = string(controller.GetUID())
+	return out
+}
+
+// CertificateRequestIssued returns the new CertificateRequest of an update
+// event, and true if the update marked it as issued.
+func CertificateRequestIssued(old, new interface{}) (*cmapi.CertificateRequest, bool) {
+	oldCR, ok := old.(*cmapi.CertificateRequest)
+	if !ok {
+		return nil, false
+	}
+	newCR, ok := new.(*cmapi.CertificateRequest)
+	if !ok {
+		return nil, false
+	}
+	return newCR, !isIssued(oldCR) && isIssued(newCR)
+}
+
+func isIssued(cr *cmapi.CertificateRequest) bool {
+	cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady)
+	return cond != nil && cond.Status == cmmeta.ConditionTrue && cond.Reason == cmapi.CertificateRequestReasonIssued
+}
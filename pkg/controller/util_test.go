@@ -19,6 +19,10 @@ package controller
 import (
 	"reflect"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 func TestBuildAnnotationsToCopy(t *testing.T) {
@@ -63,3 +67,129 @@ func TestBuildAnnotationsToCopy(t *testing.T) {
 		})
 	}
 }
+
+func TestIsOwnedBy(t *testing.T) {
+	tests := map[string]struct {
+		labels  map[string]string
+		ownedBy string
+		want    bool
+	}{
+		"any object is owned if owned-by is not set": {
+			labels:  map[string]string{cmapi.OwnedByLabelKey: "other"},
+			ownedBy: "",
+			want:    true,
+		},
+		"object with matching label is owned": {
+			labels:  map[string]string{cmapi.OwnedByLabelKey: "instance-a"},
+			ownedBy: "instance-a",
+			want:    true,
+		},
+		"object with different label is not owned": {
+			labels:  map[string]string{cmapi.OwnedByLabelKey: "instance-b"},
+			ownedBy: "instance-a",
+			want:    false,
+		},
+		"object without label is not owned": {
+			labels:  nil,
+			ownedBy: "instance-a",
+			want:    false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Labels: test.labels}
+			if got := IsOwnedBy(obj, test.ownedBy); got != test.want {
+				t.Errorf("IsOwnedBy() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsClaimed(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		issuerClass string
+		want        bool
+	}{
+		"object without class is claimed by instance without class": {
+			annotations: nil,
+			issuerClass: "",
+			want:        true,
+		},
+		"object without class is not claimed by instance with class": {
+			annotations: nil,
+			issuerClass: "tenant-a",
+			want:        false,
+		},
+		"object with class is not claimed by instance without class": {
+			annotations: map[string]string{cmapi.IssuerClassAnnotationKey: "tenant-a"},
+			issuerClass: "",
+			want:        false,
+		},
+		"object with matching class is claimed": {
+			annotations: map[string]string{cmapi.IssuerClassAnnotationKey: "tenant-a"},
+			issuerClass: "tenant-a",
+			want:        true,
+		},
+		"object with different class is not claimed": {
+			annotations: map[string]string{cmapi.IssuerClassAnnotationKey: "tenant-b"},
+			issuerClass: "tenant-a",
+			want:        false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: test.annotations}
+			if got := IsClaimed(obj, test.issuerClass); got != test.want {
+				t.Errorf("IsClaimed() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAddOwnedByLabels(t *testing.T) {
+	controller := &metav1.ObjectMeta{UID: "uid-a"}
+	tests := map[string]struct {
+		labels  map[string]string
+		ownedBy string
+		want    map[string]string
+	}{
+		"labels are not modified if owned-by is not set": {
+			labels:  map[string]string{"foo": "bar"},
+			ownedBy: "",
+			want:    map[string]string{"foo": "bar"},
+		},
+		"label is added to nil labels": {
+			labels:  nil,
+			ownedBy: "instance-a",
+			want:    map[string]string{cmapi.OwnedByLabelKey: "instance-a", cmapi.ControllerUIDLabelKey: "uid-a"},
+		},
+		"labels are added alongside existing labels": {
+			labels:  map[string]string{"foo": "bar"},
+			ownedBy: "instance-a",
+			want:    map[string]string{"foo": "bar", cmapi.OwnedByLabelKey: "instance-a", cmapi.ControllerUIDLabelKey: "uid-a"},
+		},
+		"labels override existing user supplied values": {
+			labels:  map[string]string{cmapi.OwnedByLabelKey: "instance-b", cmapi.ControllerUIDLabelKey: "uid-b"},
+			ownedBy: "instance-a",
+			want:    map[string]string{cmapi.OwnedByLabelKey: "instance-a", cmapi.ControllerUIDLabelKey: "uid-a"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var orig map[string]string
+			if test.labels != nil {
+				orig = make(map[string]string)
+				for k, v := range test.labels {
+					orig[k] = v
+				}
+			}
+			if got := AddOwnedByLabels(test.labels, test.ownedBy, controller); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("AddOwnedByLabels() = %+#v, want %+#v", got, test.want)
+			}
+			if !reflect.DeepEqual(test.labels, orig) {
+				t.Errorf("AddOwnedByLabels() modified the given labels: %+#v", test.labels)
+			}
+		})
+	}
+}
@@ -24,10 +24,15 @@ import (
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	"github.com/cert-manager/cert-manager/pkg/acme/client"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
@@ -43,6 +48,10 @@ type Acme struct {
 	secretsClient core.SecretsGetter
 	recorder      record.EventRecorder
 
+	// secretsLister is used to check that the Secrets holding the
+	// credentials of the DNS providers of DNS01 solvers exist.
+	secretsLister corelisters.SecretLister
+
 	// keyFromSecret returns a decoded account key from a Kubernetes secret.
 	// It can be stubbed in unit tests.
 	keyFromSecret keyFromSecretFunc
@@ -58,8 +67,27 @@ type Acme struct {
 	// metrics is used to create instrumented ACME clients
 	metrics *metrics.Metrics
 
+	// httpClientOptions configures the HTTP client of ACME clients, unless
+	// overridden by the issuer's httpClient field.
+	httpClientOptions accounts.HTTPClientOptions
+
+	// directoryMetaCache caches the metadata advertised in the directory of
+	// the ACME server, which is recorded in the issuer's status.
+	directoryMetaCache *client.DirectoryMetaCache
+
 	// userAgent is the string used as the UserAgent when making HTTP calls.
 	userAgent string
+
+	// cmClient and challengeLister are used to manage the Challenges which
+	// solve the authorizations of the issuer's preAuthorizedDNSNames.
+	cmClient        cmclient.Interface
+	challengeLister cmacmelisters.ChallengeLister
+
+	// certificateRequestLister is used to count the certificates recently
+	// issued by the issuer against the rate limits of the ACME server.
+	certificateRequestLister cmlisters.CertificateRequestLister
+
+	clock clock.Clock
 }
 
 // New returns a new ACME issuer interface for the given issuer.
@@ -75,11 +103,18 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		keyFromSecret:            newKeyFromSecret(secretsLister),
 		clientBuilder:            accounts.NewClient,
 		secretsClient:            ctx.Client.CoreV1(),
+		secretsLister:            secretsLister,
 		recorder:                 ctx.Recorder,
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
 		metrics:                  ctx.Metrics,
-		userAgent:                ctx.RESTConfig.UserAgent,
+		httpClientOptions:        ctx.ACMEOptions.HTTPClient,
+		directoryMetaCache:       ctx.ACMEOptions.DirectoryMetaCache,
+		userAgent:                ctx.ExternalUserAgent,
+		cmClient:                 ctx.CMClient,
+		challengeLister:          ctx.SharedInformerFactory.Acme().V1().Challenges().Lister(),
+		certificateRequestLister: ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
+		clock:                    ctx.Clock,
 	}
 
 	return a, nil
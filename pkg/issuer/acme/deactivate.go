@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+const (
+	errorAccountDeactivationFailed = "ErrDeactivateACMEAccount"
+
+	successAccountDeactivated = "ACMEAccountDeactivated"
+
+	messageAccountDeactivationFailed = "Failed to deactivate ACME account: "
+	messageAccountDeactivated        = "The ACME account was deactivated with the ACME server"
+
+	accountDoesNotExistProblemType = "urn:ietf:params:acme:error:accountDoesNotExist"
+)
+
+var _ issuer.Finalizer = &Acme{}
+
+// Finalizer returns the finalizer used to deactivate the ACME account when
+// the issuer is deleted, which is only required if the issuer has
+// deactivateAccountOnDeletion set.
+func (a *Acme) Finalizer() (string, bool) {
+	return cmacme.ACMEAccountDeactivationFinalizer, a.issuer.GetSpec().ACME.DeactivateAccountOnDeletion
+}
+
+// Finalize deactivates the issuer's ACME account with the ACME server.
+// The issuer's Ready condition is set to False if the account could not be
+// deactivated and the deactivation is retried. If the account private key is
+// missing or invalid the account can never be deactivated, in which case a
+// Warning event is recorded and the issuer is removed.
+func (a *Acme) Finalize(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	if a.issuer.GetStatus().ACMEStatus().URI == "" {
+		log.V(logf.DebugLevel).Info("skipping deactivating ACME account as no account has been registered")
+		return nil
+	}
+
+	ns := a.issuer.GetObjectMeta().Namespace
+	if ns == "" {
+		ns = a.clusterResourceNamespace
+	}
+
+	privateKeySelector := acme.PrivateKeySelector(a.issuer.GetSpec().ACME.PrivateKey)
+	pk, err := a.keyFromSecret(ctx, ns, privateKeySelector.Name, privateKeySelector.Key)
+	switch {
+	case apierrors.IsNotFound(err), errors.IsInvalidData(err):
+		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountDeactivationFailed,
+			messageAccountDeactivationFailed+err.Error())
+		return nil
+
+	case err != nil:
+		a.setDeactivationFailedCondition(messageAccountDeactivationFailed + err.Error())
+		return err
+	}
+	rsaPk, ok := pk.(*rsa.PrivateKey)
+	if !ok {
+		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountDeactivationFailed,
+			messageAccountDeactivationFailed+fmt.Sprintf(messageTemplateNotRSA, privateKeySelector.Name))
+		return nil
+	}
+
+	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify, a.httpClientOptions.ForIssuer(a.issuer.GetSpec().ACME).WithTLS(a.issuer.GetSpec().TLS))
+	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+
+	// An account which cannot be found has already been deactivated, or
+	// was never registered with the current private key.
+	err = cl.DeactivateReg(ctx)
+	acmeErr, isACMEErr := err.(*acmeapi.Error)
+	switch {
+	case err == acmeapi.ErrNoAccount, isACMEErr && acmeErr.ProblemType == accountDoesNotExistProblemType:
+		log.V(logf.InfoLevel).Info("ACME account does not exist, assuming it has already been deactivated")
+
+	case err != nil:
+		msg := messageAccountDeactivationFailed + err.Error()
+		log.Error(err, "failed to deactivate ACME account")
+		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountDeactivationFailed, msg)
+		a.setDeactivationFailedCondition(msg)
+		return err
+
+	default:
+		log.V(logf.InfoLevel).Info("deactivated ACME account")
+		a.recorder.Event(a.issuer, corev1.EventTypeNormal, successAccountDeactivated, messageAccountDeactivated)
+	}
+
+	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
+
+	return nil
+}
+
+func (a *Acme) setDeactivationFailedCondition(msg string) {
+	apiutil.SetIssuerCondition(a.issuer,
+		a.issuer.GetGeneration(),
+		v1.IssuerConditionReady,
+		cmmeta.ConditionFalse,
+		errorAccountDeactivationFailed,
+		msg)
+}
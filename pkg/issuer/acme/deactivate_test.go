@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+func TestAcme_Finalize(t *testing.T) {
+	rsaPrivKey := mustGenerateRSAKey(t)
+	registeredIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerACMEURL(acmev2Prod),
+		gen.SetIssuerACMEAccountURL("https://acme-v02.api.letsencrypt.org/acme/acct/1"))
+
+	tests := map[string]struct {
+		issuer        cmapi.GenericIssuer
+		kfsKey        crypto.Signer
+		kfsErr        error
+		deactivateErr error
+
+		expDeactivateCalled bool
+		expRemoveClient     bool
+		expFailedCondition  bool
+		expEvents           []string
+		expErr              bool
+	}{
+		"issuer without a registered account is not deactivated": {
+			issuer: gen.Issuer("test-issuer", gen.SetIssuerACMEURL(acmev2Prod)),
+		},
+		"account is deactivated": {
+			issuer:              registeredIssuer,
+			kfsKey:              rsaPrivKey,
+			expDeactivateCalled: true,
+			expRemoveClient:     true,
+			expEvents:           []string{fmt.Sprintf("Normal %s %s", successAccountDeactivated, messageAccountDeactivated)},
+		},
+		"account which no longer exists is assumed to be deactivated": {
+			issuer:              registeredIssuer,
+			kfsKey:              rsaPrivKey,
+			deactivateErr:       acmeapi.ErrNoAccount,
+			expDeactivateCalled: true,
+			expRemoveClient:     true,
+		},
+		"failure to deactivate the account is retried": {
+			issuer:              registeredIssuer,
+			kfsKey:              rsaPrivKey,
+			deactivateErr:       &acmeapi.Error{StatusCode: 500, Detail: "test"},
+			expDeactivateCalled: true,
+			expFailedCondition:  true,
+			expEvents:           []string{fmt.Sprintf("Warning %s %s500 : test", errorAccountDeactivationFailed, messageAccountDeactivationFailed)},
+			expErr:              true,
+		},
+		"missing account private key does not block deletion": {
+			issuer:    registeredIssuer,
+			kfsErr:    apierrors.NewNotFound(corev1.Resource("secrets"), "test"),
+			expEvents: []string{fmt.Sprintf("Warning %s %ssecrets %q not found", errorAccountDeactivationFailed, messageAccountDeactivationFailed, "test")},
+		},
+		"failure to get the account private key is retried": {
+			issuer:             registeredIssuer,
+			kfsErr:             fmt.Errorf("test"),
+			expFailedCondition: true,
+			expErr:             true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			deactivateCalled := false
+			cl := acmecl.FakeACME{
+				FakeDeactivateReg: func(context.Context) error {
+					deactivateCalled = true
+					return test.deactivateErr
+				},
+			}
+
+			removeClientCalled := false
+			kfsCalled := false
+			recorder := new(controllertest.FakeRecorder)
+			a := Acme{
+				issuer:        test.issuer.(*cmapi.Issuer).DeepCopy(),
+				keyFromSecret: keyFromSecretMockBuilder(&kfsCalled, test.kfsKey, test.kfsErr),
+				clientBuilder: clientBuilderMock(&cl),
+				accountRegistry: &fakeregistry.FakeRegistry{
+					RemoveClientFunc: func(string) {
+						removeClientCalled = true
+					},
+				},
+				recorder: recorder,
+			}
+
+			err := a.Finalize(context.Background())
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if deactivateCalled != test.expDeactivateCalled {
+				t.Errorf("unexpected DeactivateReg call, exp=%t got=%t", test.expDeactivateCalled, deactivateCalled)
+			}
+			if removeClientCalled != test.expRemoveClient {
+				t.Errorf("unexpected RemoveClient call, exp=%t got=%t", test.expRemoveClient, removeClientCalled)
+			}
+			hasFailedCondition := apiutil.IssuerHasCondition(a.issuer, cmapi.IssuerCondition{
+				Type:   cmapi.IssuerConditionReady,
+				Status: cmmeta.ConditionFalse,
+				Reason: errorAccountDeactivationFailed,
+			})
+			if hasFailedCondition != test.expFailedCondition {
+				t.Errorf("unexpected Ready condition, exp failed=%t got=%v", test.expFailedCondition, a.issuer.GetStatus().Conditions)
+			}
+			if !util.EqualSorted(test.expEvents, recorder.Events) {
+				t.Errorf("unexpected events, exp=%v got=%v", test.expEvents, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestAcme_Finalizer(t *testing.T) {
+	a := Acme{issuer: gen.Issuer("test-issuer", gen.SetIssuerACMEURL(acmev2Prod))}
+	if name, required := a.Finalizer(); name != cmacme.ACMEAccountDeactivationFinalizer || required {
+		t.Errorf("unexpected finalizer for issuer without deactivateAccountOnDeletion: %q, %t", name, required)
+	}
+
+	a.issuer.GetSpec().ACME.DeactivateAccountOnDeletion = true
+	if _, required := a.Finalizer(); !required {
+		t.Errorf("expected finalizer to be required for issuer with deactivateAccountOnDeletion")
+	}
+}
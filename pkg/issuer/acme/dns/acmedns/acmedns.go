@@ -75,6 +75,46 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	return fmt.Errorf("account credentials not found for domain %s", domain)
 }
 
+// Account returns the credentials of the account used for the domain.
+func (c *DNSProvider) Account(domain string) (goacmedns.Account, bool) {
+	account, exists := c.accounts[domain]
+	return account, exists
+}
+
+// SetAccount sets the credentials of the account used for the domain.
+func (c *DNSProvider) SetAccount(domain string, account goacmedns.Account) {
+	if c.accounts == nil {
+		c.accounts = make(map[string]goacmedns.Account)
+	}
+	c.accounts[domain] = account
+}
+
+// RegisterAccount registers a new account with the acme-dns server, and uses
+// it for the domain.
+func (c *DNSProvider) RegisterAccount(domain string) (goacmedns.Account, error) {
+	account, err := c.client.RegisterAccount(nil)
+	if err != nil {
+		return goacmedns.Account{}, err
+	}
+	c.SetAccount(domain, account)
+	return account, nil
+}
+
+// AddAccount adds the credentials of the account used for the domain to the
+// accounts stored as JSON in accountJSON, in the format read by
+// NewDNSProviderHostBytes, and returns the resulting JSON. An empty
+// accountJSON holds no accounts.
+func AddAccount(accountJSON []byte, domain string, account goacmedns.Account) ([]byte, error) {
+	accounts := make(map[string]goacmedns.Account)
+	if len(accountJSON) > 0 {
+		if err := json.Unmarshal(accountJSON, &accounts); err != nil {
+			return nil, fmt.Errorf("Error unmarshalling accountJSON: %s", err)
+		}
+	}
+	accounts[domain] = account
+	return json.Marshal(accounts)
+}
+
 // CleanUp removes the record matching the specified parameters. It is not
 // implemented for the ACME-DNS provider.
 func (c *DNSProvider) CleanUp(_, _, _ string) error {
@@ -17,10 +17,13 @@ limitations under the License.
 package acmedns
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/cpu/goacmedns"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,6 +69,54 @@ func TestNoValidJson(t *testing.T) {
 	assert.Error(t, err, "Expected error constructing DNSProvider from invalid JSON")
 }
 
+func TestAddAccount(t *testing.T) {
+	account := goacmedns.Account{FullDomain: "fooldom", SubDomain: "subdoom", Username: "usernom", Password: "secret"}
+
+	accountJSON, err := AddAccount(nil, "domain", account)
+	assert.NoError(t, err, "Expected no error adding an account to empty JSON")
+
+	accountJSON, err = AddAccount(accountJSON, "other-domain", goacmedns.Account{FullDomain: "otherdom"})
+	assert.NoError(t, err, "Expected no error adding an account to existing JSON")
+
+	provider, err := NewDNSProviderHostBytes("http://localhost/", accountJSON, util.RecursiveNameservers)
+	assert.NoError(t, err, "Expected no error constructing DNSProvider")
+	got, ok := provider.Account("domain")
+	assert.True(t, ok)
+	assert.Equal(t, account, got)
+	got, ok = provider.Account("other-domain")
+	assert.True(t, ok)
+	assert.Equal(t, "otherdom", got.FullDomain)
+
+	_, err = AddAccount([]byte("b00m"), "domain", account)
+	assert.Error(t, err, "Expected error adding an account to invalid JSON")
+}
+
+func TestRegisterAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/register" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"fulldomain":"d420c923-bbd7-4056-ab64-c3ca54c9b3cf.auth.example.org","subdomain":"d420c923-bbd7-4056-ab64-c3ca54c9b3cf","username":"c36f50e8-4632-44f0-83fe-e070fef28a10","password":"htB9mR9DYgcu9bX_afHF62erXaH2TS7bg9KW3F7Z","allowfrom":[]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewDNSProviderHostBytes(server.URL, []byte("{}"), util.RecursiveNameservers)
+	assert.NoError(t, err, "Expected no error constructing DNSProvider")
+
+	_, ok := provider.Account("example.com")
+	assert.False(t, ok)
+
+	account, err := provider.RegisterAccount("example.com")
+	assert.NoError(t, err, "Expected no error registering an account")
+	assert.Equal(t, "d420c923-bbd7-4056-ab64-c3ca54c9b3cf.auth.example.org", account.FullDomain)
+
+	got, ok := provider.Account("example.com")
+	assert.True(t, ok)
+	assert.Equal(t, account, got)
+}
+
 func TestLiveAcmeDnsPresent(t *testing.T) {
 	if !acmednsLiveTest {
 		t.Skip("skipping live test")
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cpu/goacmedns"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// ensureAcmeDNSAccount ensures that the acme-dns provider has an account for
+// the domain of the challenge. If the account Secret has none and autoRegister
+// is enabled, a new account is registered with the acme-dns server and its
+// credentials are added to the Secret. The CNAME target of the account is
+// reported in the status of the challenge.
+func (s *Solver) ensureAcmeDNSAccount(ctx context.Context, issuer v1.GenericIssuer, cfg *cmacme.ACMEIssuerDNS01ProviderAcmeDNS, provider *acmedns.DNSProvider, ch *cmacme.Challenge) error {
+	domain := ch.Spec.DNSName
+	account, ok := provider.Account(domain)
+	if !ok {
+		if !cfg.AutoRegister {
+			// Present reports the missing account.
+			return nil
+		}
+
+		var err error
+		account, err = s.registerAcmeDNSAccount(ctx, s.ResourceNamespace(issuer), cfg, provider, domain)
+		if err != nil {
+			return err
+		}
+	}
+
+	ch.Status.CNAMETarget = account.FullDomain
+	return nil
+}
+
+// registerAcmeDNSAccount registers a new account for the domain with the
+// acme-dns server and adds its credentials to the account Secret, unless
+// another challenge for the domain has done so since the provider was created.
+func (s *Solver) registerAcmeDNSAccount(ctx context.Context, namespace string, cfg *cmacme.ACMEIssuerDNS01ProviderAcmeDNS, provider *acmedns.DNSProvider, domain string) (goacmedns.Account, error) {
+	log := logf.FromContext(ctx)
+	ref := cfg.AccountSecret
+
+	s.acmeDNSAccountsLock.Lock()
+	defer s.acmeDNSAccountsLock.Unlock()
+
+	// The provider was created from the lister's copy of the Secret, which
+	// may not contain an account registered by a recent call.
+	secret, err := s.Client.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return goacmedns.Account{}, fmt.Errorf("error getting acmedns accounts secret: %w", err)
+	}
+	if data := secret.Data[ref.Key]; len(data) > 0 {
+		current, err := acmedns.NewDNSProviderHostBytes(cfg.Host, data, nil)
+		if err != nil {
+			return goacmedns.Account{}, fmt.Errorf("error reading acmedns accounts secret: %w", err)
+		}
+		if account, ok := current.Account(domain); ok {
+			provider.SetAccount(domain, account)
+			return account, nil
+		}
+	}
+
+	account, err := provider.RegisterAccount(domain)
+	if err != nil {
+		return goacmedns.Account{}, fmt.Errorf("error registering acmedns account for domain %s: %w", domain, err)
+	}
+	log.V(logf.InfoLevel).Info("registered acme-dns account", "cname_target", account.FullDomain)
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := s.Client.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		data, err := acmedns.AddAccount(secret.Data[ref.Key], domain, account)
+		if err != nil {
+			return err
+		}
+		secret = secret.DeepCopy()
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[ref.Key] = data
+		_, err = s.Client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return goacmedns.Account{}, fmt.Errorf("error storing acmedns account for domain %s in secret: %w", domain, err)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestEnsureAcmeDNSAccount(t *testing.T) {
+	var registrations int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/register" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&registrations, 1)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"fulldomain":"d420c923.auth.example.org","subdomain":"d420c923","username":"c36f50e8","password":"htB9mR9D","allowfrom":[]}`))
+	}))
+	defer server.Close()
+
+	issuer := gen.Issuer(defaultTestIssuerName, gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+	newChallenge := func() *cmacme.Challenge {
+		return gen.Challenge("test-challenge", gen.SetChallengeDNSName("example.com"))
+	}
+	newProvider := func() *acmedns.DNSProvider {
+		p, err := acmedns.NewDNSProviderHostBytes(server.URL, []byte("{}"), nil)
+		require.NoError(t, err)
+		return p
+	}
+	cfg := func(autoRegister bool) *cmacme.ACMEIssuerDNS01ProviderAcmeDNS {
+		return &cmacme.ACMEIssuerDNS01ProviderAcmeDNS{
+			Host:          server.URL,
+			AccountSecret: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "acme-dns"}, Key: "acmedns.json"},
+			AutoRegister:  autoRegister,
+		}
+	}
+
+	b := &test.Builder{
+		T: t,
+		KubeObjects: []runtime.Object{
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: issuer.Namespace, Name: "acme-dns"}},
+		},
+	}
+	s := buildFakeSolver(b, newFakeDNSProviders().constructors)
+	defer b.Stop()
+
+	ch := newChallenge()
+	assert.NoError(t, s.ensureAcmeDNSAccount(context.Background(), issuer, cfg(false), newProvider(), ch))
+	assert.Empty(t, ch.Status.CNAMETarget, "no account should be registered without autoRegister")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&registrations))
+
+	provider := newProvider()
+	ch = newChallenge()
+	assert.NoError(t, s.ensureAcmeDNSAccount(context.Background(), issuer, cfg(true), provider, ch))
+	assert.Equal(t, "d420c923.auth.example.org", ch.Status.CNAMETarget)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&registrations))
+	_, ok := provider.Account("example.com")
+	assert.True(t, ok, "the registered account should be used by the provider")
+
+	secret, err := b.Client.CoreV1().Secrets(issuer.Namespace).Get(context.Background(), "acme-dns", metav1.GetOptions{})
+	require.NoError(t, err)
+	stored, err := acmedns.NewDNSProviderHostBytes(server.URL, secret.Data["acmedns.json"], nil)
+	require.NoError(t, err)
+	account, ok := stored.Account("example.com")
+	assert.True(t, ok, "the registered account should be stored in the Secret")
+	assert.Equal(t, "d420c923.auth.example.org", account.FullDomain)
+
+	// A provider created from a stale copy of the Secret reuses the account
+	// stored in the Secret.
+	ch = newChallenge()
+	assert.NoError(t, s.ensureAcmeDNSAccount(context.Background(), issuer, cfg(true), newProvider(), ch))
+	assert.Equal(t, "d420c923.auth.example.org", ch.Status.CNAMETarget)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&registrations))
+}
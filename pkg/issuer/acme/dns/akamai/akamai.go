@@ -16,16 +16,14 @@ limitations under the License.
 
 // Package akamai implements a DNS provider for solving the DNS-01
 // challenge using Akamai Edge DNS.
-// See https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html
+// See https://techdocs.akamai.com/edge-dns/reference/edge-dns-api
 package akamai
 
 import (
 	"fmt"
 	"strings"
 
-	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
-
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
@@ -33,124 +31,117 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
-// OpenEdgegridDNSService enables mocking and required functions
-type OpenEdgegridDNSService interface {
-	GetRecord(zone string, name string, recordType string) (*dns.RecordBody, error)
-	RecordSave(rec *dns.RecordBody, zone string) error
-	RecordUpdate(rec *dns.RecordBody, zone string) error
-	RecordDelete(rec *dns.RecordBody, zone string) error
-}
-
-// OpenDNSConfig contains akamai's config to create authorization header.
-type OpenDNSConfig struct {
-	config edgegrid.Config
-}
-
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
-	dns01Nameservers       []string
-	serviceConsumerDomain  string
-	dnsclient              OpenEdgegridDNSService
-	TTL                    int
-	findHostedDomainByFqdn func(string, []string) (string, error)
-	isNotFound             func(error) bool
-	log                    logr.Logger
+	serviceConsumerDomain string
+	client                EdgeDNSClient
+	TTL                   int
+	zoneMap               map[string]string
+	log                   logr.Logger
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Akamai.
-func NewDNSProvider(serviceConsumerDomain, clientToken, clientSecret, accessToken string, dns01Nameservers []string) (*DNSProvider, error) {
-
-	// required Aka OpenEdgegrid creds + non empty dnsservers list
-	if serviceConsumerDomain == "" || clientToken == "" || clientSecret == "" || accessToken == "" || len(dns01Nameservers) < 1 {
+// NewDNSProvider returns a DNSProvider instance configured for Akamai,
+// which authenticates with the Edge DNS API of the given service consumer
+// domain using EdgeGrid credentials and identifies itself with userAgent.
+func NewDNSProvider(serviceConsumerDomain, clientToken, clientSecret, accessToken, userAgent string) (*DNSProvider, error) {
+	if serviceConsumerDomain == "" || clientToken == "" || clientSecret == "" || accessToken == "" {
 		return nil, fmt.Errorf("edgedns: Provider creation failed. Missing required arguments.")
 	}
 
-	dnsp := &DNSProvider{
-		dns01Nameservers:       dns01Nameservers,
-		serviceConsumerDomain:  serviceConsumerDomain,
-		dnsclient:              &OpenDNSConfig{},
-		findHostedDomainByFqdn: findHostedDomainByFqdn,
-		isNotFound:             isNotFound,
-		log:                    logf.Log.WithName("akamai-dns"),
-		TTL:                    300,
-	}
-	dnsp.dnsclient.(*OpenDNSConfig).config = edgegrid.Config{
-		Host:         serviceConsumerDomain,
-		ClientToken:  clientToken,
-		ClientSecret: clientSecret,
-		AccessToken:  accessToken,
-		MaxBody:      131072,
-	}
+	return &DNSProvider{
+		serviceConsumerDomain: serviceConsumerDomain,
+		client: &edgeDNSClient{config: edgegrid.Config{
+			Host:         serviceConsumerDomain,
+			ClientToken:  clientToken,
+			ClientSecret: clientSecret,
+			AccessToken:  accessToken,
+			MaxBody:      131072,
+		}, userAgent: userAgent},
+		log: logf.Log.WithName("akamai-dns"),
+		TTL: 300,
+	}, nil
+}
 
-	dns.Init(dnsp.dnsclient.(*OpenDNSConfig).config)
+// SetTTL sets the time to live, in seconds, of the TXT records created by
+// the provider.
+func (a *DNSProvider) SetTTL(ttl int) {
+	a.TTL = ttl
+}
 
-	return dnsp, nil
+// SetZoneMap sets the zones configured for domains, which override the zone
+// looked up in the Edge DNS account.
+func (a *DNSProvider) SetZoneMap(zoneMap map[string]string) {
+	a.zoneMap = zoneMap
 }
 
-func findHostedDomainByFqdn(fqdn string, ns []string) (string, error) {
-	zone, err := util.FindZoneByFqdn(fqdn, ns)
-	if err != nil {
-		return "", err
+// hostedDomain returns the zone configured for fqdn in the zone map, or
+// otherwise the most specific zone of the Edge DNS account that fqdn is part
+// of.
+func (a *DNSProvider) hostedDomain(fqdn string) (string, error) {
+	if zone, ok := util.LookupZoneMap(fqdn, a.zoneMap); ok {
+		return util.UnFqdn(zone), nil
+	}
+
+	// Try each parent domain of fqdn in turn, rather than relying on SOA
+	// records which may not reflect the zones of the account, for example
+	// for internal zones delegated to Edge DNS.
+	for zone := util.UnFqdn(fqdn); strings.Contains(zone, "."); zone = zone[strings.Index(zone, ".")+1:] {
+		err := a.client.GetZone(zone)
+		if err == nil {
+			return zone, nil
+		}
+		if !isNotFound(err) {
+			return "", errors.Wrapf(err, "failed to retrieve zone %q", zone)
+		}
 	}
 
-	return util.UnFqdn(zone), nil
+	return "", fmt.Errorf("no zone of the Edge DNS account contains %q", fqdn)
 }
 
 // Present creates/updates a TXT record to fulfill the dns-01 challenge.
 func (a *DNSProvider) Present(domain, fqdn, value string) error {
+	a.log.V(logf.DebugLevel).Info("entering Present", "domain", domain, "fqdn", fqdn)
 
-	logf.V(logf.DebugLevel).Infof("entering Present. domain: %s, fqdn: %s, value: %s", domain, fqdn, value)
-
-	hostedDomain, err := a.findHostedDomainByFqdn(fqdn, a.dns01Nameservers)
+	hostedDomain, err := a.hostedDomain(fqdn)
 	if err != nil {
 		return errors.Wrapf(err, "edgedns: failed to determine hosted domain for %q", fqdn)
 	}
-	hostedDomain = util.UnFqdn(hostedDomain)
-	logf.V(logf.DebugLevel).Infof("hostedDomain: %s", hostedDomain)
 
 	recordName, err := makeTxtRecordName(fqdn, hostedDomain)
 	if err != nil {
 		return errors.Wrapf(err, "edgedns: failed to create TXT record name")
 	}
-	logf.V(logf.DebugLevel).Infof("recordName: %s", recordName)
+	a.log.V(logf.DebugLevel).Info("determined TXT record", "zone", hostedDomain, "name", recordName)
 
-	record, err := a.dnsclient.GetRecord(hostedDomain, recordName, "TXT")
-	if err != nil && !a.isNotFound(err) {
+	recordSet, err := a.client.GetRecordSet(hostedDomain, recordName, "TXT")
+	if err != nil && !isNotFound(err) {
 		return errors.Wrapf(err, "edgedns: failed to retrieve TXT record")
 	}
 
-	if err == nil && record == nil {
-		return fmt.Errorf("edgedns: unknown error")
-	}
-
-	if record != nil {
-		logf.V(logf.InfoLevel).Infof("edgedns: TXT record already exists. Updating target")
-
-		if containsValue(record.Target, value) {
-			// have a record and have entry already
+	if err == nil {
+		if containsValue(recordSet.Rdata, value) {
 			return nil
 		}
 
-		record.Target = append(record.Target, `"`+value+`"`)
-		record.TTL = a.TTL
+		// The record set may hold the values of other challenges for the
+		// same name, such as those of a wildcard and apex domain.
+		a.log.V(logf.InfoLevel).Info("edgedns: TXT record already exists, adding value", "name", recordName)
+		recordSet.Rdata = append(recordSet.Rdata, quote(value))
+		recordSet.TTL = a.TTL
 
-		err = a.dnsclient.RecordUpdate(record, hostedDomain)
-		if err != nil {
+		if err := a.client.UpdateRecordSet(hostedDomain, recordSet); err != nil {
 			return errors.Wrapf(err, "edgedns: failed to update TXT record")
 		}
-
 		return nil
 	}
 
-	record = &dns.RecordBody{
-		Name:       recordName,
-		RecordType: "TXT",
-		TTL:        a.TTL,
-		Target:     []string{`"` + value + `"`},
+	recordSet = &RecordSet{
+		Name:  recordName,
+		Type:  "TXT",
+		TTL:   a.TTL,
+		Rdata: []string{quote(value)},
 	}
-
-	err = a.dnsclient.RecordSave(record, hostedDomain)
-	if err != nil {
+	if err := a.client.CreateRecordSet(hostedDomain, recordSet); err != nil {
 		return errors.Wrapf(err, "edgedns: failed to create TXT record")
 	}
 
@@ -159,65 +150,50 @@ func (a *DNSProvider) Present(domain, fqdn, value string) error {
 
 // CleanUp removes/updates the TXT record matching the specified parameters.
 func (a *DNSProvider) CleanUp(domain, fqdn, value string) error {
+	a.log.V(logf.DebugLevel).Info("entering CleanUp", "domain", domain, "fqdn", fqdn)
 
-	logf.V(logf.DebugLevel).Infof("entering CleanUp. domain: %s, fqdn: %s, value: %s", domain, fqdn, value)
-
-	hostedDomain, err := a.findHostedDomainByFqdn(fqdn, a.dns01Nameservers)
+	hostedDomain, err := a.hostedDomain(fqdn)
 	if err != nil {
 		return errors.Wrapf(err, "edgedns: failed to determine hosted domain for %q", fqdn)
 	}
-	hostedDomain = util.UnFqdn(hostedDomain)
-	logf.V(logf.DebugLevel).Infof("hostedDomain: %s", hostedDomain)
 
 	recordName, err := makeTxtRecordName(fqdn, hostedDomain)
 	if err != nil {
 		return errors.Wrapf(err, "edgedns: failed to create TXT record name")
 	}
-	logf.V(logf.DebugLevel).Infof("recordName: %s", recordName)
+	a.log.V(logf.DebugLevel).Info("determined TXT record", "zone", hostedDomain, "name", recordName)
 
-	existingRec, err := a.dnsclient.GetRecord(hostedDomain, recordName, "TXT")
+	recordSet, err := a.client.GetRecordSet(hostedDomain, recordName, "TXT")
 	if err != nil {
-		if a.isNotFound(err) {
+		if isNotFound(err) {
 			return nil
 		}
 		return errors.Wrapf(err, "edgedns: failed to retrieve TXT record")
 	}
 
-	if existingRec == nil {
-		return fmt.Errorf("edgedns: unknown failure")
-	}
-
-	if len(existingRec.Target) == 0 {
-		return fmt.Errorf("edgedns: TXT record is invalid")
-	}
-
-	if !containsValue(existingRec.Target, value) {
+	if !containsValue(recordSet.Rdata, value) {
 		return nil
 	}
 
-	var newRData []string
-	for _, val := range existingRec.Target {
-		tval := strings.Trim(val, `"`)
-		if tval == value {
+	var rdata []string
+	for _, val := range recordSet.Rdata {
+		if strings.Trim(val, `"`) == value {
 			continue
 		}
-		newRData = append(newRData, val)
+		rdata = append(rdata, val)
 	}
 
-	if len(newRData) > 0 {
-		existingRec.Target = newRData
-		logf.V(logf.DebugLevel).Infof("updating Akamai TXT record: %s, data: %s", existingRec.Name, newRData)
-		err = a.dnsclient.RecordUpdate(existingRec, hostedDomain)
-		if err != nil {
+	if len(rdata) > 0 {
+		recordSet.Rdata = rdata
+		a.log.V(logf.DebugLevel).Info("updating TXT record", "name", recordSet.Name, "rdata", rdata)
+		if err := a.client.UpdateRecordSet(hostedDomain, recordSet); err != nil {
 			return errors.Wrapf(err, "edgedns: TXT record update failed")
 		}
-
 		return nil
 	}
 
-	logf.V(logf.DebugLevel).Infof("deleting Akamai TXT record %s", existingRec.Name)
-	err = a.dnsclient.RecordDelete(existingRec, hostedDomain)
-	if err != nil {
+	a.log.V(logf.DebugLevel).Info("deleting TXT record", "name", recordSet.Name)
+	if err := a.client.DeleteRecordSet(hostedDomain, recordSet); err != nil {
 		return errors.Wrapf(err, "edgedns: TXT record delete failed")
 	}
 
@@ -234,53 +210,17 @@ func containsValue(values []string, value string) bool {
 	return false
 }
 
-func isNotFound(err error) bool {
-
-	if err == nil {
-		return false
-	}
-
-	_, ok := err.(*dns.RecordError)
-	if ok {
-		return true
-	}
-
-	return false
+// quote returns value as a quoted TXT record string, as required by the Edge
+// DNS API.
+func quote(value string) string {
+	return `"` + value + `"`
 }
 
 func makeTxtRecordName(fqdn, hostedDomain string) (string, error) {
-
 	recName := util.UnFqdn(fqdn)
-	if !strings.HasSuffix(recName, hostedDomain) {
+	if recName != hostedDomain && !strings.HasSuffix(recName, "."+hostedDomain) {
 		return "", errors.Errorf("fqdn %q is not part of %q", fqdn, hostedDomain)
 	}
 
 	return recName, nil
 }
-
-// GetRecord gets a single Recordset as RecordBody. Sets Akamai OPEN Edgegrid API
-// global variable.
-func (o OpenDNSConfig) GetRecord(zone string, name string, recordType string) (*dns.RecordBody, error) {
-
-	dns.Config = o.config
-
-	return dns.GetRecord(zone, name, recordType)
-}
-
-// RecordSave is a function that saves the given zone in the given RecordBody.
-func (o OpenDNSConfig) RecordSave(rec *dns.RecordBody, zone string) error {
-
-	return rec.Save(zone)
-}
-
-// RecordUpdate is a function that updates the given zone in the given RecordBody.
-func (o OpenDNSConfig) RecordUpdate(rec *dns.RecordBody, zone string) error {
-
-	return rec.Update(zone)
-}
-
-// RecordDelete is a function that deletes the given zone in the given RecordBody.
-func (o OpenDNSConfig) RecordDelete(rec *dns.RecordBody, zone string) error {
-
-	return rec.Delete(zone)
-}
@@ -17,394 +17,298 @@ limitations under the License.
 package akamai
 
 import (
-	"testing"
-
 	"fmt"
-	"reflect"
-
-	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"testing"
 
-	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/stretchr/testify/assert"
 )
 
-func testRecordBodyData() *dns.RecordBody {
+// fakeEdgeDNSClient is an in-memory EdgeDNSClient.
+type fakeEdgeDNSClient struct {
+	// zones holds the record sets of each zone of the account, by name.
+	zones map[string]map[string]*RecordSet
+	// errs holds the error returned by each method, by name.
+	errs map[string]error
+}
 
-	return &dns.RecordBody{
-		Name:       "_acme-challenge.test.example.com",
-		RecordType: "TXT",
-		Target:     []string{`"` + "dns01-key" + `"`},
-		TTL:        300,
+func newFakeEdgeDNSClient(zones ...string) *fakeEdgeDNSClient {
+	c := &fakeEdgeDNSClient{zones: map[string]map[string]*RecordSet{}, errs: map[string]error{}}
+	for _, zone := range zones {
+		c.zones[zone] = map[string]*RecordSet{}
 	}
+	return c
 }
 
-func testRecordBodyDataExist() *dns.RecordBody {
-
-	return &dns.RecordBody{
-		Name:       "_acme-challenge.test.example.com",
-		RecordType: "TXT",
-		Target:     []string{`"` + "dns01-key" + `"`, `"` + "dns01-key-stub" + `"`},
-		TTL:        300,
+func (c *fakeEdgeDNSClient) GetZone(zone string) error {
+	if err := c.errs["GetZone"]; err != nil {
+		return err
 	}
+	if _, ok := c.zones[zone]; !ok {
+		return fmt.Errorf("%w: zone %q", errNotFound, zone)
+	}
+	return nil
 }
 
-// OpenEdggrid DNS Stub
-type StubOpenDNSConfig struct {
-	FuncOutput map[string]interface{}
-	FuncErrors map[string]error
+func (c *fakeEdgeDNSClient) GetRecordSet(zone, name, recordType string) (*RecordSet, error) {
+	if err := c.errs["GetRecordSet"]; err != nil {
+		return nil, err
+	}
+	recordSet, ok := c.zones[zone][name+"/"+recordType]
+	if !ok {
+		return nil, fmt.Errorf("%w: record set %q", errNotFound, name)
+	}
+	copied := *recordSet
+	copied.Rdata = append([]string(nil), recordSet.Rdata...)
+	return &copied, nil
 }
 
-func findStubHostedDomainByFqdn(fqdn string, ns []string) (string, error) {
-
-	return "test.example.com", nil
+func (c *fakeEdgeDNSClient) CreateRecordSet(zone string, recordSet *RecordSet) error {
+	return c.save("CreateRecordSet", zone, recordSet)
+}
 
+func (c *fakeEdgeDNSClient) UpdateRecordSet(zone string, recordSet *RecordSet) error {
+	return c.save("UpdateRecordSet", zone, recordSet)
 }
 
-func stubIsNotFoundTrue(err error) bool {
+func (c *fakeEdgeDNSClient) DeleteRecordSet(zone string, recordSet *RecordSet) error {
+	if err := c.errs["DeleteRecordSet"]; err != nil {
+		return err
+	}
+	delete(c.zones[zone], recordSet.Name+"/"+recordSet.Type)
+	return nil
+}
 
-	return true
+func (c *fakeEdgeDNSClient) save(method, zone string, recordSet *RecordSet) error {
+	if err := c.errs[method]; err != nil {
+		return err
+	}
+	c.zones[zone][recordSet.Name+"/"+recordSet.Type] = recordSet
+	return nil
 }
 
-func stubIsNotFoundFalse(err error) bool {
+func newTestProvider(t *testing.T, client EdgeDNSClient) *DNSProvider {
+	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", "")
+	assert.NoError(t, err)
+	akamai.client = client
+	return akamai
+}
 
-	return false
+func txtRecordSet(rdata ...string) *RecordSet {
+	return &RecordSet{
+		Name:  "_acme-challenge.test.example.com",
+		Type:  "TXT",
+		TTL:   300,
+		Rdata: rdata,
+	}
 }
 
 // TestNewDNSProvider performs sanity check on provider init
 func TestNewDNSProvider(t *testing.T) {
-
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
+	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", "")
 	assert.NoError(t, err)
-	// samplee couple important fields
-	assert.Equal(t, akamai.serviceConsumerDomain, "akamai.example.com")
-	assert.Equal(t, fmt.Sprintf("%T", akamai.dnsclient), "*akamai.OpenDNSConfig")
+	assert.Equal(t, "akamai.example.com", akamai.serviceConsumerDomain)
+	assert.IsType(t, &edgeDNSClient{}, akamai.client)
+	assert.Equal(t, "akamai.example.com", akamai.client.(*edgeDNSClient).config.Host)
+
+	_, err = NewDNSProvider("akamai.example.com", "token", "", "access-token", "")
+	assert.Error(t, err)
+}
+
+func TestHostedDomain(t *testing.T) {
+	tests := map[string]struct {
+		zones        []string
+		zoneMap      map[string]string
+		getZoneErr   error
+		expectedZone string
+		expectedErr  bool
+	}{
+		"the most specific zone of the account is used": {
+			zones:        []string{"example.com", "test.example.com"},
+			expectedZone: "test.example.com",
+		},
+		"a parent zone of the account is used": {
+			zones:        []string{"example.com"},
+			expectedZone: "example.com",
+		},
+		"the zone map takes precedence over the zones of the account": {
+			zones:        []string{"test.example.com"},
+			zoneMap:      map[string]string{"test.example.com": "example.com."},
+			expectedZone: "example.com",
+		},
+		"an error is returned if no zone of the account contains the name": {
+			zones:       []string{"example.org"},
+			expectedErr: true,
+		},
+		"an error retrieving a zone is returned": {
+			zones:       []string{"example.com"},
+			getZoneErr:  fmt.Errorf("API Error: 403 Forbidden"),
+			expectedErr: true,
+		},
+	}
 
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeEdgeDNSClient(test.zones...)
+			client.errs["GetZone"] = test.getZoneErr
+			akamai := newTestProvider(t, client)
+			akamai.SetZoneMap(test.zoneMap)
+
+			zone, err := akamai.hostedDomain("_acme-challenge.test.example.com.")
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedZone, zone)
+		})
+	}
 }
 
 // TestPresentBasicFlow tests basic flow, e.g. no record exists.
 func TestPresentBasicFlow(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundTrue
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = nil
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["RecordSave"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
+	client := newFakeEdgeDNSClient("test.example.com")
+	client.errs["UpdateRecordSet"] = fmt.Errorf("Update not expected")
+	akamai := newTestProvider(t, client)
+	akamai.SetTTL(60)
 
 	assert.NoError(t, akamai.Present("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
 
+	expected := txtRecordSet(`"dns01-key"`)
+	expected.TTL = 60
+	assert.Equal(t, expected, client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"])
 }
 
-// TestPresentExists tests flow with existing record.
+// TestPresentExists tests that the value is added to an existing record, so
+// that multiple challenges for the same name can be presented at once.
 func TestPresentExists(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["RecordUpdate"] = testRecordBodyDataExist()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
+	client := newFakeEdgeDNSClient("test.example.com")
+	client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"] = txtRecordSet(`"dns01-key"`)
+	client.errs["CreateRecordSet"] = fmt.Errorf("Create not expected")
+	akamai := newTestProvider(t, client)
 
 	assert.NoError(t, akamai.Present("test.example.com", "_acme-challenge.test.example.com.", "dns01-key-stub"))
 
+	assert.Equal(t, txtRecordSet(`"dns01-key"`, `"dns01-key-stub"`), client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"])
 }
 
-// TestPresentValueExists tests flow with existing record.
+// TestPresentValueExists tests that an existing value is not added again.
 func TestPresentValueExists(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
+	client := newFakeEdgeDNSClient("test.example.com")
+	client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"] = txtRecordSet(`"dns01-key"`)
+	client.errs["CreateRecordSet"] = fmt.Errorf("Create not expected")
+	client.errs["UpdateRecordSet"] = fmt.Errorf("Update not expected")
+	akamai := newTestProvider(t, client)
 
 	assert.NoError(t, akamai.Present("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
-
-}
-
-func TestPresentFailGetRecord(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = nil
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["GetRecord"] = fmt.Errorf("Failed Get Test")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
-
-	assert.Error(t, akamai.Present("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
-
-}
-
-func TestPresentFailSaveRecord(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundTrue
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = nil
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save fail")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
-
-	assert.Error(t, akamai.Present("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
-
 }
 
-func TestPresentFailUpdateRecord(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["RecordUpdate"] = testRecordBodyDataExist()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update failed")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
-
-	assert.Error(t, akamai.Present("test.example.com", "_acme-challenge.test.example.com.", "dns01-key-stub"))
+func TestPresentFailures(t *testing.T) {
+	tests := map[string]struct {
+		existing *RecordSet
+		method   string
+	}{
+		"retrieving the record fails": {
+			method: "GetRecordSet",
+		},
+		"creating the record fails": {
+			method: "CreateRecordSet",
+		},
+		"updating the record fails": {
+			existing: txtRecordSet(`"dns01-key"`),
+			method:   "UpdateRecordSet",
+		},
+	}
 
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeEdgeDNSClient("test.example.com")
+			if test.existing != nil {
+				client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"] = test.existing
+			}
+			client.errs[test.method] = fmt.Errorf("%s failed", test.method)
+			akamai := newTestProvider(t, client)
+
+			assert.Error(t, akamai.Present("test.example.com", "_acme-challenge.test.example.com.", "dns01-key-stub"))
+		})
+	}
 }
 
-// TestCleanUpBasicFlow tests flow with existing record.
+// TestCleanUpBasicFlow tests that the record is deleted once its last value
+// is removed.
 func TestCleanUpBasicFlow(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["RecordDelete"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
+	client := newFakeEdgeDNSClient("test.example.com")
+	client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"] = txtRecordSet(`"dns01-key"`)
+	client.errs["UpdateRecordSet"] = fmt.Errorf("Update not expected")
+	akamai := newTestProvider(t, client)
 
 	assert.NoError(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
 
+	assert.Empty(t, client.zones["test.example.com"])
 }
 
-// TestPresentExists tests flow with existing record.
+// TestCleanUpExists tests that only the value of the challenge is removed
+// from a record holding multiple values.
 func TestCleanUpExists(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["RecordUpdate"] = testRecordBodyDataExist()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
+	client := newFakeEdgeDNSClient("test.example.com")
+	client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"] = txtRecordSet(`"dns01-key"`, `"dns01-key-stub"`)
+	client.errs["DeleteRecordSet"] = fmt.Errorf("Delete not expected")
+	akamai := newTestProvider(t, client)
 
-	assert.NoError(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key-stub"))
+	assert.NoError(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
 
+	assert.Equal(t, txtRecordSet(`"dns01-key-stub"`), client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"])
 }
 
-// TestCleanUpExistsNoValue tests flow with existing record.
+// TestCleanUpExistsNoValue tests that a record without the value of the
+// challenge is left as is.
 func TestCleanUpExistsNoValue(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
-
-	assert.NoError(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key-stub"))
+	client := newFakeEdgeDNSClient("test.example.com")
+	client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"] = txtRecordSet(`"dns01-key-stub"`)
+	client.errs["UpdateRecordSet"] = fmt.Errorf("Update not expected")
+	client.errs["DeleteRecordSet"] = fmt.Errorf("Delete not expected")
+	akamai := newTestProvider(t, client)
 
+	assert.NoError(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
 }
 
-// TestCleanUpNoRecord tests flow with no existing record.
+// TestCleanUpNoRecord tests that there is nothing to clean up if the record
+// does not exist.
 func TestCleanUpNoRecord(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundTrue // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = nil
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
-
-	assert.NoError(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01"))
-
-}
-
-func TestCleanUpFailGetRecord(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = nil
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["GetRecord"] = fmt.Errorf("Failed Get Record")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
-
-	assert.Error(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
-
-}
-
-func TestCleanUpFailUpdateRecord(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyDataExist()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["RecordUpdate"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update failed")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete not expected")
-
-	assert.Error(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key-stub"))
-
-}
-
-func TestCleanUpFailDeleteRecord(t *testing.T) {
-	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
-	assert.NoError(t, err)
-
-	akamai.findHostedDomainByFqdn = findStubHostedDomainByFqdn
-	akamai.isNotFound = stubIsNotFoundFalse // ignored for this flow ...
-	akamai.dnsclient = &StubOpenDNSConfig{FuncOutput: map[string]interface{}{}, FuncErrors: map[string]error{}}
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["GetRecord"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncOutput["RecordDelete"] = testRecordBodyData()
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordSave"] = fmt.Errorf("Save not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordUpdate"] = fmt.Errorf("Update not expected")
-	akamai.dnsclient.(*StubOpenDNSConfig).FuncErrors["RecordDelete"] = fmt.Errorf("Delete failed")
-
-	assert.Error(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
-
-}
-
-// Stub Get Record
-func (o StubOpenDNSConfig) GetRecord(zone string, name string, recordType string) (*dns.RecordBody, error) {
-
-	var rec *dns.RecordBody
-
-	err, ok := o.FuncErrors["GetRecord"]
-	if ok {
-		return nil, err
-	}
-
-	exp, ok := o.FuncOutput["GetRecord"]
-	if ok {
-		if exp == nil {
-			return nil, fmt.Errorf("GetRecord: Unexpected nil")
-		}
-		rec = exp.(*dns.RecordBody)
-		// comare passed with expected
-		if name != rec.Name {
-			return nil, fmt.Errorf("GetRecord: expected/actual Name don't match")
-		}
-		if recordType != rec.RecordType {
-			return nil, fmt.Errorf("GetRecord: expected/actual Record Type don't match")
-		}
-	}
-
-	return rec, nil
-
-}
-
-func (o StubOpenDNSConfig) RecordSave(rec *dns.RecordBody, zone string) error {
-
-	exp, ok := o.FuncOutput["RecordSave"]
-	if ok {
-		// comare passed with expected
-		if rec.Name != exp.(*dns.RecordBody).Name {
-			return fmt.Errorf("RecordSave: expected/actual Name don't match")
-		}
-		if rec.RecordType != exp.(*dns.RecordBody).RecordType {
-			return fmt.Errorf("RecordSave: expected/actual Record Type don't match")
-		}
-		if !reflect.DeepEqual(rec.Target, exp.(*dns.RecordBody).Target) {
-			return fmt.Errorf("RecordSave: expected/actual Target don't match")
-		}
-		if rec.TTL != exp.(*dns.RecordBody).TTL {
-			return fmt.Errorf("RecordSave: expected/actual TTL don't match")
-		}
-	}
-	err, ok := o.FuncErrors["RecordSave"]
-	if ok {
-		return err
-	}
-
-	return nil
+	client := newFakeEdgeDNSClient("test.example.com")
+	client.errs["UpdateRecordSet"] = fmt.Errorf("Update not expected")
+	client.errs["DeleteRecordSet"] = fmt.Errorf("Delete not expected")
+	akamai := newTestProvider(t, client)
 
+	assert.NoError(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
 }
 
-func (o StubOpenDNSConfig) RecordUpdate(rec *dns.RecordBody, zone string) error {
-
-	exp, ok := o.FuncOutput["RecordUpdate"]
-	if ok {
-		// comare passed with expected
-		if rec.Name != exp.(*dns.RecordBody).Name {
-			return fmt.Errorf("RecordUpdate: expected/actual Name don't match")
-		}
-		if rec.RecordType != exp.(*dns.RecordBody).RecordType {
-			return fmt.Errorf("RecordUpdate: expected/actual Record Type don't match")
-		}
-		if !reflect.DeepEqual(rec.Target, exp.(*dns.RecordBody).Target) {
-			return fmt.Errorf("RecordUpdate: expected/actual Target don't match")
-		}
-		if rec.TTL != exp.(*dns.RecordBody).TTL {
-			return fmt.Errorf("RecordUpdate: expected/actual TTL don't match")
-		}
-	}
-	err, ok := o.FuncErrors["RecordUpdate"]
-	if ok {
-		return err
+func TestCleanUpFailures(t *testing.T) {
+	tests := map[string]struct {
+		existing *RecordSet
+		method   string
+	}{
+		"retrieving the record fails": {
+			existing: txtRecordSet(`"dns01-key"`),
+			method:   "GetRecordSet",
+		},
+		"updating the record fails": {
+			existing: txtRecordSet(`"dns01-key"`, `"dns01-key-stub"`),
+			method:   "UpdateRecordSet",
+		},
+		"deleting the record fails": {
+			existing: txtRecordSet(`"dns01-key"`),
+			method:   "DeleteRecordSet",
+		},
 	}
 
-	return nil
-}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeEdgeDNSClient("test.example.com")
+			client.zones["test.example.com"]["_acme-challenge.test.example.com/TXT"] = test.existing
+			client.errs[test.method] = fmt.Errorf("%s failed", test.method)
+			akamai := newTestProvider(t, client)
 
-func (o StubOpenDNSConfig) RecordDelete(rec *dns.RecordBody, zone string) error {
-
-	exp, ok := o.FuncOutput["RecordDelete"]
-	if ok {
-		// comare passed with expected
-		if rec.Name != exp.(*dns.RecordBody).Name {
-			return fmt.Errorf("RecordDelete: expected/actual Name don't match")
-		}
-		if rec.RecordType != exp.(*dns.RecordBody).RecordType {
-			return fmt.Errorf("RecordDelete: expected/actual Record Type don't match")
-		}
-		if !reflect.DeepEqual(rec.Target, exp.(*dns.RecordBody).Target) {
-			return fmt.Errorf("RecordDelete: expected/actual Target don't match")
-		}
-		if rec.TTL != exp.(*dns.RecordBody).TTL {
-			return fmt.Errorf("RecordDelete: expected/actual TTL don't match")
-		}
-	}
-	err, ok := o.FuncErrors["RecordDelete"]
-	if ok {
-		return err
+			assert.Error(t, akamai.CleanUp("test.example.com", "_acme-challenge.test.example.com.", "dns01-key"))
+		})
 	}
-
-	return nil
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	client "github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// EdgeDNSClient manages the record sets of the zones of an Edge DNS account.
+// Errors for zones or record sets which do not exist satisfy isNotFound.
+type EdgeDNSClient interface {
+	GetZone(zone string) error
+	GetRecordSet(zone, name, recordType string) (*RecordSet, error)
+	CreateRecordSet(zone string, recordSet *RecordSet) error
+	UpdateRecordSet(zone string, recordSet *RecordSet) error
+	DeleteRecordSet(zone string, recordSet *RecordSet) error
+}
+
+// RecordSet is a set of records of the same name and type in an Edge DNS
+// zone.
+type RecordSet struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	TTL   int      `json:"ttl"`
+	Rdata []string `json:"rdata"`
+}
+
+// errNotFound is returned by EdgeDNSClient implementations for zones and
+// record sets which do not exist.
+var errNotFound = errors.New("not found")
+
+func isNotFound(err error) bool {
+	return errors.Is(err, errNotFound)
+}
+
+// edgeDNSClient implements EdgeDNSClient using the record sets API of Edge
+// DNS. Each client signs its requests with its own EdgeGrid credentials,
+// so that providers for different accounts can be used concurrently.
+type edgeDNSClient struct {
+	config    edgegrid.Config
+	userAgent string
+}
+
+func (c *edgeDNSClient) GetZone(zone string) error {
+	return c.do(http.MethodGet, zonePath(zone), nil, nil)
+}
+
+func (c *edgeDNSClient) GetRecordSet(zone, name, recordType string) (*RecordSet, error) {
+	var recordSet RecordSet
+	if err := c.do(http.MethodGet, recordSetPath(zone, name, recordType), nil, &recordSet); err != nil {
+		return nil, err
+	}
+	return &recordSet, nil
+}
+
+func (c *edgeDNSClient) CreateRecordSet(zone string, recordSet *RecordSet) error {
+	return c.do(http.MethodPost, recordSetPath(zone, recordSet.Name, recordSet.Type), recordSet, nil)
+}
+
+func (c *edgeDNSClient) UpdateRecordSet(zone string, recordSet *RecordSet) error {
+	return c.do(http.MethodPut, recordSetPath(zone, recordSet.Name, recordSet.Type), recordSet, nil)
+}
+
+func (c *edgeDNSClient) DeleteRecordSet(zone string, recordSet *RecordSet) error {
+	return c.do(http.MethodDelete, recordSetPath(zone, recordSet.Name, recordSet.Type), nil, nil)
+}
+
+// do sends a request signed with the client's credentials to the Edge DNS
+// API, and decodes the response into out if it is not nil.
+func (c *edgeDNSClient) do(method, path string, body, out interface{}) error {
+	req, err := client.NewJSONRequest(c.config, method, path, body)
+	if err != nil {
+		return err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := client.Do(c.config, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if client.IsError(resp) {
+		apiErr := client.NewAPIError(resp)
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %v", errNotFound, apiErr)
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	return client.BodyJSON(resp, out)
+}
+
+func zonePath(zone string) string {
+	return fmt.Sprintf("/config-dns/v2/zones/%s", url.PathEscape(zone))
+}
+
+func recordSetPath(zone, name, recordType string) string {
+	return fmt.Sprintf("%s/names/%s/types/%s", zonePath(zone), url.PathEscape(name), url.PathEscape(recordType))
+}
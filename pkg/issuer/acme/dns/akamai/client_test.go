@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package akamai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	client "github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdgeDNSClient(t *testing.T) {
+	var requests []string
+	var created RecordSet
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "EG1-HMAC-SHA256 client_token=token;access_token=access-token;") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "cert-manager/test" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /config-dns/v2/zones/example.com":
+			w.Write([]byte(`{"zone":"example.com","type":"primary"}`))
+		case "GET /config-dns/v2/zones/example.com/names/_acme-challenge.example.com/types/TXT":
+			w.Write([]byte(`{"name":"_acme-challenge.example.com","type":"TXT","ttl":300,"rdata":["\"value\""]}`))
+		case "POST /config-dns/v2/zones/example.com/names/_acme-challenge.test.example.com/types/TXT":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"https://problems.luna.akamaiapis.net/authoritative-dns/notFound","title":"Not Found","status":404}`))
+		}
+	}))
+	defer server.Close()
+
+	httpClient := client.Client
+	client.Client = server.Client()
+	defer func() { client.Client = httpClient }()
+
+	c := &edgeDNSClient{config: edgegrid.Config{
+		Host:         server.URL,
+		ClientToken:  "token",
+		ClientSecret: "secret",
+		AccessToken:  "access-token",
+		MaxBody:      131072,
+	}, userAgent: "cert-manager/test"}
+
+	assert.NoError(t, c.GetZone("example.com"))
+
+	err := c.GetZone("test.example.com")
+	assert.True(t, isNotFound(err), "expected a not found error, got %v", err)
+
+	recordSet, err := c.GetRecordSet("example.com", "_acme-challenge.example.com", "TXT")
+	assert.NoError(t, err)
+	assert.Equal(t, &RecordSet{Name: "_acme-challenge.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"value"`}}, recordSet)
+
+	_, err = c.GetRecordSet("example.com", "_acme-challenge.test.example.com", "TXT")
+	assert.True(t, isNotFound(err), "expected a not found error, got %v", err)
+
+	newRecordSet := &RecordSet{Name: "_acme-challenge.test.example.com", Type: "TXT", TTL: 60, Rdata: []string{`"new-value"`}}
+	assert.NoError(t, c.CreateRecordSet("example.com", newRecordSet))
+	assert.Equal(t, *newRecordSet, created)
+
+	assert.Equal(t, []string{
+		"GET /config-dns/v2/zones/example.com",
+		"GET /config-dns/v2/zones/test.example.com",
+		"GET /config-dns/v2/zones/example.com/names/_acme-challenge.example.com/types/TXT",
+		"GET /config-dns/v2/zones/example.com/names/_acme-challenge.test.example.com/types/TXT",
+		"POST /config-dns/v2/zones/example.com/names/_acme-challenge.test.example.com/types/TXT",
+	}, requests)
+}
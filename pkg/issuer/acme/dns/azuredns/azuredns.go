@@ -13,6 +13,9 @@ package azuredns
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -28,26 +31,35 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// The environment variables injected into pods by the Azure Workload Identity
+// webhook.
+const (
+	azureClientIDEnv           = "AZURE_CLIENT_ID"
+	azureTenantIDEnv           = "AZURE_TENANT_ID"
+	azureFederatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+	azureAuthorityHostEnv      = "AZURE_AUTHORITY_HOST"
+)
+
 // DNSProvider implements the util.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers  []string
 	recordClient      dns.RecordSetsClient
 	zoneClient        dns.ZonesClient
+	token             *adal.ServicePrincipalToken
 	resourceGroupName string
 	zoneName          string
+	zoneMap           map[string]string
+	ttl               int
 	log               logr.Logger
 }
 
 // NewDNSProviderCredentials returns a DNSProvider instance configured for the Azure
-// DNS service using static credentials from its parameters
-func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*DNSProvider, error) {
-	env := azure.PublicCloud
-	if environment != "" {
-		var err error
-		env, err = azure.EnvironmentFromName(environment)
-		if err != nil {
-			return nil, err
-		}
+// DNS service using static credentials from its parameters. Requests to the
+// Azure DNS API identify themselves with userAgent.
+func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity, userAgent string) (*DNSProvider, error) {
+	env, err := azureEnvironment(environment)
+	if err != nil {
+		return nil, err
 	}
 
 	spt, err := getAuthorization(env, clientID, clientSecret, subscriptionID, tenantID, ambient, managedIdentity)
@@ -61,16 +73,39 @@ func NewDNSProviderCredentials(environment, clientID, clientSecret, subscription
 	zc := dns.NewZonesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
 	zc.Authorizer = autorest.NewBearerAuthorizer(spt)
 
+	if userAgent != "" {
+		if err := rc.AddToUserAgent(userAgent); err != nil {
+			return nil, err
+		}
+		if err := zc.AddToUserAgent(userAgent); err != nil {
+			return nil, err
+		}
+	}
+
 	return &DNSProvider{
 		dns01Nameservers:  dns01Nameservers,
 		recordClient:      rc,
 		zoneClient:        zc,
+		token:             spt,
 		resourceGroupName: resourceGroupName,
 		zoneName:          zoneName,
+		ttl:               60,
 		log:               logf.Log.WithName("azure-dns"),
 	}, nil
 }
 
+// azureEnvironment returns the endpoints of the named Azure environment,
+// defaulting to the public cloud.
+func azureEnvironment(name string) (azure.Environment, error) {
+	switch cmacme.AzureDNSEnvironment(name) {
+	case "":
+		return azure.PublicCloud, nil
+	case cmacme.AzureUSGovernment:
+		return azure.USGovernmentCloud, nil
+	}
+	return azure.EnvironmentFromName(name)
+}
+
 func getAuthorization(env azure.Environment, clientID, clientSecret, subscriptionID, tenantID string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*adal.ServicePrincipalToken, error) {
 	if clientID != "" {
 		logf.Log.V(logf.InfoLevel).Info("azuredns authenticating with clientID and secret key")
@@ -84,11 +119,19 @@ func getAuthorization(env azure.Environment, clientID, clientSecret, subscriptio
 		}
 		return spt, nil
 	}
-	logf.Log.V(logf.InfoLevel).Info("No ClientID found:  authenticating azuredns with managed identity (MSI)")
 	if !ambient {
 		return nil, fmt.Errorf("ClientID is not set but neither `--cluster-issuer-ambient-credentials` nor `--issuer-ambient-credentials` are set. These are necessary to enable Azure Managed Identities")
 	}
 
+	// Azure Workload Identity projects a federated service account token
+	// into the pod and exposes its location through the environment.
+	if tokenFile := os.Getenv(azureFederatedTokenFileEnv); tokenFile != "" {
+		logf.Log.V(logf.InfoLevel).Info("No ClientID found:  authenticating azuredns with workload identity (federated token)")
+		return getFederatedAuthorization(env, tenantID, tokenFile, managedIdentity)
+	}
+
+	logf.Log.V(logf.InfoLevel).Info("No ClientID found:  authenticating azuredns with managed identity (MSI)")
+
 	opt := adal.ManagedIdentityOptions{}
 
 	if managedIdentity != nil {
@@ -103,9 +146,97 @@ func getAuthorization(env azure.Environment, clientID, clientSecret, subscriptio
 	return spt, nil
 }
 
+// getFederatedAuthorization returns a token that is obtained by exchanging the
+// federated service account token found in tokenFile. The client ID of the
+// managed identity, or application, defaults to the one injected by the
+// workload identity webhook, as does the tenant ID.
+func getFederatedAuthorization(env azure.Environment, tenantID, tokenFile string, managedIdentity *cmacme.AzureManagedIdentity) (*adal.ServicePrincipalToken, error) {
+	if managedIdentity != nil && managedIdentity.ResourceID != "" {
+		return nil, fmt.Errorf("managed identity resourceID is not supported with workload identity, use clientID instead")
+	}
+
+	clientID := os.Getenv(azureClientIDEnv)
+	if managedIdentity != nil && managedIdentity.ClientID != "" {
+		clientID = managedIdentity.ClientID
+	}
+	if clientID == "" {
+		return nil, fmt.Errorf("workload identity requires either managedIdentity.clientID or the %s environment variable to be set", azureClientIDEnv)
+	}
+
+	if tenantID == "" {
+		tenantID = os.Getenv(azureTenantIDEnv)
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("workload identity requires either tenantID or the %s environment variable to be set", azureTenantIDEnv)
+	}
+
+	authorityHost := env.ActiveDirectoryEndpoint
+	if host := os.Getenv(azureAuthorityHostEnv); host != "" {
+		authorityHost = host
+	}
+	if !strings.HasSuffix(authorityHost, "/") {
+		authorityHost += "/"
+	}
+
+	oauthConfig, err := adal.NewOAuthConfig(authorityHost, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	spt, err := adal.NewServicePrincipalTokenWithSecret(*oauthConfig, clientID, env.ResourceManagerEndpoint, &federatedTokenSecret{tokenFile: tokenFile})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the workload identity token: %v", err)
+	}
+	return spt, nil
+}
+
+// federatedTokenSecret authenticates token requests with a federated token
+// read from a file. The file is read again on every refresh, as the token
+// projected by the kubelet is rotated before it expires.
+type federatedTokenSecret struct {
+	tokenFile string
+}
+
+// SetAuthenticationValues implements adal.ServicePrincipalSecret
+func (s *federatedTokenSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, v *url.Values) error {
+	token, err := os.ReadFile(s.tokenFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the federated token: %v", err)
+	}
+	v.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	v.Set("client_assertion", strings.TrimSpace(string(token)))
+	return nil
+}
+
+// SetTTL sets the time to live, in seconds, of the TXT records created by
+// the provider.
+func (c *DNSProvider) SetTTL(ttl int) {
+	c.ttl = ttl
+}
+
+// SetZoneMap sets the zones configured for domains, which override the zone
+// detected using SOA records if no hosted zone name is configured.
+func (c *DNSProvider) SetZoneMap(zoneMap map[string]string) {
+	c.zoneMap = zoneMap
+}
+
+// SetTransport sets the transport of the HTTP clients used to call the Azure
+// DNS API and to acquire tokens for it.
+func (c *DNSProvider) SetTransport(transport http.RoundTripper) {
+	client := &http.Client{Transport: transport}
+	c.recordClient.Sender = client
+	c.zoneClient.Sender = client
+	c.token.SetSender(client)
+}
+
 // Present creates a TXT record using the specified parameters
 func (c *DNSProvider) Present(domain, fqdn, value string) error {
-	return c.createRecord(fqdn, value, 60)
+	return c.createRecord(fqdn, []string{value}, c.ttl)
+}
+
+// PresentValues replaces the TXT record set for fqdn with a record for each
+// of the given values.
+func (c *DNSProvider) PresentValues(domain, fqdn string, values []string) error {
+	return c.createRecord(fqdn, values, c.ttl)
 }
 
 // CleanUp removes the TXT record matching the specified parameters
@@ -129,13 +260,15 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return nil
 }
 
-func (c *DNSProvider) createRecord(fqdn, value string, ttl int) error {
+func (c *DNSProvider) createRecord(fqdn string, values []string, ttl int) error {
+	txtRecords := make([]dns.TxtRecord, 0, len(values))
+	for _, value := range values {
+		txtRecords = append(txtRecords, dns.TxtRecord{Value: &[]string{value}})
+	}
 	rparams := &dns.RecordSet{
 		RecordSetProperties: &dns.RecordSetProperties{
-			TTL: to.Int64Ptr(int64(ttl)),
-			TxtRecords: &[]dns.TxtRecord{
-				{Value: &[]string{value}},
-			},
+			TTL:        to.Int64Ptr(int64(ttl)),
+			TxtRecords: &txtRecords,
 		},
 	}
 
@@ -164,7 +297,7 @@ func (c *DNSProvider) getHostedZoneName(fqdn string) (string, error) {
 	if c.zoneName != "" {
 		return c.zoneName, nil
 	}
-	z, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	z, err := util.FindZoneByFqdnWithZoneMap(fqdn, c.zoneMap, c.dns01Nameservers)
 	if err != nil {
 		return "", err
 	}
@@ -9,13 +9,25 @@ this directory.
 package azuredns
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2017-10-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
-	"github.com/stretchr/testify/assert"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
 var (
@@ -46,7 +58,7 @@ func TestLiveAzureDnsPresent(t *testing.T) {
 	if !azureLiveTest {
 		t.Skip("skipping live test")
 	}
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, "")
 	assert.NoError(t, err)
 
 	err = provider.Present(azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -60,7 +72,7 @@ func TestLiveAzureDnsCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 5)
 
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, "")
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -68,12 +80,104 @@ func TestLiveAzureDnsCleanUp(t *testing.T) {
 }
 
 func TestInvalidAzureDns(t *testing.T) {
-	validEnv := []string{"", "AzurePublicCloud", "AzureChinaCloud", "AzureGermanCloud", "AzureUSGovernmentCloud"}
+	validEnv := []string{"", "AzurePublicCloud", "AzureChinaCloud", "AzureGermanCloud", "AzureUSGovernmentCloud", "AzureUSGovernment"}
 	for _, env := range validEnv {
-		_, err := NewDNSProviderCredentials(env, "cid", "secret", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+		_, err := NewDNSProviderCredentials(env, "cid", "secret", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, "")
 		assert.NoError(t, err)
 	}
 
-	_, err := NewDNSProviderCredentials("invalid env", "cid", "secret", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	_, err := NewDNSProviderCredentials("invalid env", "cid", "secret", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, "")
+	assert.Error(t, err)
+}
+
+func TestAzureEnvironmentEndpoints(t *testing.T) {
+	tests := map[string]struct {
+		arm, aad string
+	}{
+		"":                       {arm: "https://management.azure.com/", aad: "login.microsoftonline.com"},
+		"AzureChinaCloud":        {arm: "https://management.chinacloudapi.cn/", aad: "login.chinacloudapi.cn"},
+		"AzureUSGovernmentCloud": {arm: "https://management.usgovcloudapi.net/", aad: "login.microsoftonline.us"},
+		"AzureUSGovernment":      {arm: "https://management.usgovcloudapi.net/", aad: "login.microsoftonline.us"},
+	}
+	for env, test := range tests {
+		t.Run(env, func(t *testing.T) {
+			p, err := NewDNSProviderCredentials(env, "cid", "secret", "sub", "tenant", "", "", util.RecursiveNameservers, false, nil, "")
+			require.NoError(t, err)
+			assert.Equal(t, test.arm, p.recordClient.BaseURI)
+			assert.Equal(t, test.arm, p.zoneClient.BaseURI)
+
+			data, err := p.token.MarshalJSON()
+			require.NoError(t, err)
+			var token struct {
+				OAuth adal.OAuthConfig `json:"oauth"`
+			}
+			require.NoError(t, json.Unmarshal(data, &token))
+			assert.Equal(t, test.aad, token.OAuth.TokenEndpoint.Host)
+		})
+	}
+}
+
+func TestWorkloadIdentityAuthorization(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("federated-token\n"), 0600))
+
+	var requests int
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/tenant/oauth2/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client", r.PostForm.Get("client_id"))
+		assert.Equal(t, "urn:ietf:params:oauth:client-assertion-type:jwt-bearer", r.PostForm.Get("client_assertion_type"))
+		assert.Equal(t, "federated-token", r.PostForm.Get("client_assertion"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"access-token","expires_in":"3600","expires_on":"%d","token_type":"Bearer"}`, time.Now().Add(time.Hour).Unix())
+	}))
+	defer srv.Close()
+
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", tokenFile)
+	t.Setenv("AZURE_AUTHORITY_HOST", srv.URL)
+	t.Setenv("AZURE_CLIENT_ID", "webhook-client")
+	t.Setenv("AZURE_TENANT_ID", "tenant")
+
+	// Workload identity is only used with ambient credentials.
+	_, err := getAuthorization(azure.PublicCloud, "", "", "", "", false, nil)
 	assert.Error(t, err)
+
+	_, err = getAuthorization(azure.PublicCloud, "", "", "", "", true, &v1.AzureManagedIdentity{ResourceID: "resource"})
+	assert.Error(t, err)
+
+	spt, err := getAuthorization(azure.PublicCloud, "", "", "", "", true, &v1.AzureManagedIdentity{ClientID: "client"})
+	require.NoError(t, err)
+	spt.SetSender(srv.Client())
+	require.NoError(t, spt.Refresh())
+	assert.Equal(t, "access-token", spt.OAuthToken())
+	assert.Equal(t, 1, requests)
+}
+
+func TestPresentSetsTTL(t *testing.T) {
+	var ttl *int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var recordSet dns.RecordSet
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&recordSet))
+		ttl = recordSet.TTL
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	rc := dns.NewRecordSetsClientWithBaseURI(srv.URL, "sub")
+	rc.Authorizer = autorest.NullAuthorizer{}
+	provider := &DNSProvider{
+		recordClient:      rc,
+		resourceGroupName: "rg",
+		zoneName:          "example.com",
+		ttl:               60,
+		log:               logf.Log.WithName("azure-dns"),
+	}
+	provider.SetTTL(300)
+
+	require.NoError(t, provider.Present("test.example.com", "_acme-challenge.test.example.com.", "123d=="))
+	require.NotNil(t, ttl)
+	assert.Equal(t, int64(300), *ttl)
 }
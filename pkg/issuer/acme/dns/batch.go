@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// This file implements batching of the changes to the TXT records of the
+// DNS-01 challenges of an Order, such as the challenges for `example.com`
+// and `*.example.com` or for the many DNS names of a certificate. Challenges
+// are presented and cleaned up concurrently, so changes made using the same
+// solver within a short window are grouped and passed at once to providers
+// with batch APIs, which then use a single API call per zone. As changes are
+// made to single values, these solvers take care of challenges sharing a TXT
+// record name without the help of sharedChallengeValues.
+
+// recordBatchWindow is how long changes are collected before a batch is
+// sent to the provider.
+const recordBatchWindow = time.Second
+
+// batchSolver is implemented by solvers which can change the TXT records of
+// several challenges using a single call to the API of their provider.
+type batchSolver interface {
+	// PresentRecords creates the given TXT records. TXT records for the same
+	// names with other values are left untouched.
+	PresentRecords(records []util.TXTRecord) error
+	// CleanUpRecords deletes the given TXT records. TXT records for the same
+	// names with other values are left untouched.
+	CleanUpRecords(records []util.TXTRecord) error
+}
+
+var (
+	_ batchSolver = &cloudflare.DNSProvider{}
+	_ batchSolver = &route53.DNSProvider{}
+)
+
+// recordBatcher groups the changes to TXT records made by concurrent calls
+// using the same key into batches.
+type recordBatcher struct {
+	window time.Duration
+
+	lock    sync.Mutex
+	pending map[string]*recordBatch
+}
+
+// recordBatch is a batch of TXT records to be presented or cleaned up.
+type recordBatch struct {
+	slv     batchSolver
+	cleanUp bool
+	records []util.TXTRecord
+
+	// done is closed once the batch has been sent, after err is set.
+	done chan struct{}
+	err  error
+}
+
+func newRecordBatcher(window time.Duration) *recordBatcher {
+	return &recordBatcher{
+		window:  window,
+		pending: make(map[string]*recordBatch),
+	}
+}
+
+// change adds the record to the pending batch for key, starting a new batch
+// sent using slv if there is none, and waits for the batch to be sent. The
+// error returned is the error of the whole batch.
+func (b *recordBatcher) change(key string, slv batchSolver, cleanUp bool, record util.TXTRecord) error {
+	b.lock.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &recordBatch{slv: slv, cleanUp: cleanUp, done: make(chan struct{})}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() { b.send(key, batch) })
+	}
+	if !containsRecord(batch.records, record) {
+		batch.records = append(batch.records, record)
+	}
+	b.lock.Unlock()
+
+	<-batch.done
+	return batch.err
+}
+
+func (b *recordBatcher) send(key string, batch *recordBatch) {
+	b.lock.Lock()
+	delete(b.pending, key)
+	b.lock.Unlock()
+
+	if batch.cleanUp {
+		batch.err = batch.slv.CleanUpRecords(batch.records)
+	} else {
+		batch.err = batch.slv.PresentRecords(batch.records)
+	}
+	close(batch.done)
+}
+
+func containsRecord(records []util.TXTRecord, record util.TXTRecord) bool {
+	for _, r := range records {
+		if r == record {
+			return true
+		}
+	}
+	return false
+}
+
+// recordBatchKey returns the key grouping the changes to the TXT record of
+// the challenge with those of the other challenges of the same Order using
+// the same solver, or false if the challenge is not part of an Order.
+func recordBatchKey(ch *cmacme.Challenge, cleanUp bool) (string, bool) {
+	orderRef := metav1.GetControllerOf(ch)
+	if orderRef == nil || orderRef.Kind != cmacme.OrderKind {
+		return "", false
+	}
+	solver, err := json.Marshal(ch.Spec.Solver)
+	if err != nil {
+		return "", false
+	}
+	action := "present"
+	if cleanUp {
+		action = "cleanup"
+	}
+	return string(orderRef.UID) + "/" + action + "/" + string(solver), true
+}
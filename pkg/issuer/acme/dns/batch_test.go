@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+type fakeBatchSolver struct {
+	lock     sync.Mutex
+	presents [][]util.TXTRecord
+	cleanUps [][]util.TXTRecord
+}
+
+func (f *fakeBatchSolver) PresentRecords(records []util.TXTRecord) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.presents = append(f.presents, records)
+	return nil
+}
+
+func (f *fakeBatchSolver) CleanUpRecords(records []util.TXTRecord) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.cleanUps = append(f.cleanUps, records)
+	return nil
+}
+
+func TestRecordBatcher(t *testing.T) {
+	b := newRecordBatcher(500 * time.Millisecond)
+	slv := &fakeBatchSolver{}
+
+	apex := util.TXTRecord{Domain: "example.com", FQDN: "_acme-challenge.example.com.", Value: "key-apex"}
+	wildcard := util.TXTRecord{Domain: "*.example.com", FQDN: "_acme-challenge.example.com.", Value: "key-wildcard"}
+	www := util.TXTRecord{Domain: "www.example.com", FQDN: "_acme-challenge.www.example.com.", Value: "key-www"}
+
+	var wg sync.WaitGroup
+	change := func(key string, cleanUp bool, record util.TXTRecord) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, b.change(key, slv, cleanUp, record))
+		}()
+	}
+	// concurrent changes with the same key are sent in a single batch, and
+	// duplicate records are only sent once
+	change("order/present", false, apex)
+	change("order/present", false, wildcard)
+	change("order/present", false, wildcard)
+	change("other-order/present", false, www)
+	change("order/cleanup", true, apex)
+	wg.Wait()
+
+	assert.Len(t, slv.presents, 2)
+	assert.Contains(t, slv.presents, []util.TXTRecord{www})
+	for _, records := range slv.presents {
+		if len(records) != 1 {
+			assert.ElementsMatch(t, []util.TXTRecord{apex, wildcard}, records)
+		}
+	}
+	assert.Equal(t, [][]util.TXTRecord{{apex}}, slv.cleanUps)
+
+	// a change made once a batch has been sent starts a new batch
+	assert.NoError(t, b.change("order/present", slv, false, www))
+	assert.Len(t, slv.presents, 3)
+}
+
+func TestRecordBatchKey(t *testing.T) {
+	apex := groupChallenge("apex", "example.com", "key-apex", cmacme.Pending)
+	wildcard := groupChallenge("wildcard", "example.com", "key-wildcard", cmacme.Pending)
+	otherOrder := groupChallenge("other-order", "example.com", "key-other-order", cmacme.Pending)
+	otherOrder.OwnerReferences[0].UID = "other-order-uid"
+	otherSolver := groupChallenge("other-solver", "example.com", "key-other-solver", cmacme.Pending)
+	otherSolver.Spec.Solver.DNS01 = &cmacme.ACMEChallengeSolverDNS01{AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{}}
+	noOrder := groupChallenge("no-order", "example.com", "key-no-order", cmacme.Pending)
+	noOrder.OwnerReferences = nil
+
+	key := func(ch *cmacme.Challenge, cleanUp bool) string {
+		key, ok := recordBatchKey(ch, cleanUp)
+		assert.True(t, ok)
+		return key
+	}
+	assert.Equal(t, key(apex, false), key(wildcard, false))
+	assert.NotEqual(t, key(apex, false), key(apex, true))
+	assert.NotEqual(t, key(apex, false), key(otherOrder, false))
+	assert.NotEqual(t, key(apex, false), key(otherSolver, false))
+
+	_, ok := recordBatchKey(noOrder, false)
+	assert.False(t, ok)
+}
@@ -22,6 +22,7 @@ import (
 
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/impersonate"
 	"google.golang.org/api/option"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
@@ -30,76 +31,59 @@ import (
 // DNSProvider is an implementation of the DNSProvider interface.
 type DNSProvider struct {
 	hostedZoneName   string
+	zoneMap          map[string]string
 	dns01Nameservers []string
 	project          string
 	client           *dns.Service
+	ttl              int
 	log              logr.Logger
 }
 
 // NewDNSProvider returns a new DNSProvider Instance with configuration
-func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*DNSProvider, error) {
-	// project is a required field
-	if project == "" {
-		return nil, fmt.Errorf("Google Cloud project name missing")
-	}
+func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName, impersonateServiceAccount, userAgent string) (*DNSProvider, error) {
 	// if the service account bytes are not provided, we will attempt to instantiate
 	// with 'ambient credentials' (if they are allowed/enabled)
 	if len(saBytes) == 0 {
 		if !ambient {
 			return nil, fmt.Errorf("unable to construct clouddns provider: empty credentials; perhaps you meant to enable ambient credentials?")
 		}
-		return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName, impersonateServiceAccount, userAgent)
 	}
 	// if service account data is provided, we instantiate using that
-	if len(saBytes) != 0 {
-		return NewDNSProviderServiceAccountBytes(project, saBytes, dns01Nameservers, hostedZoneName)
-	}
-	return nil, fmt.Errorf("missing Google Cloud DNS provider credentials")
+	return NewDNSProviderServiceAccountBytes(project, saBytes, dns01Nameservers, hostedZoneName, impersonateServiceAccount, userAgent)
 }
 
 // NewDNSProviderEnvironment returns a DNSProvider instance configured for Google Cloud
 // DNS. Project name must be passed in the environment variable: GCE_PROJECT.
 // A Service Account file can be passed in the environment variable:
 // GCE_SERVICE_ACCOUNT_FILE
-func NewDNSProviderEnvironment(dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderEnvironment(dns01Nameservers []string, hostedZoneName, userAgent string) (*DNSProvider, error) {
 	project := os.Getenv("GCE_PROJECT")
-	if saFile, ok := os.LookupEnv("GCE_SERVICE_ACCOUNT_FILE"); ok {
-		return NewDNSProviderServiceAccount(project, saFile, dns01Nameservers, hostedZoneName)
-	}
-	return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName)
-}
-
-// NewDNSProviderCredentials uses the supplied credentials to return a
-// DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderCredentials(project string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
+	if saFile, ok := os.LookupEnv("GCE_SERVICE_ACCOUNT_FILE"); ok {
+		return NewDNSProviderServiceAccount(project, saFile, dns01Nameservers, hostedZoneName, userAgent)
+	}
+	return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName, "", userAgent)
+}
 
+// NewDNSProviderCredentials uses the ambient credentials, such as those of
+// GKE Workload Identity, to return a DNSProvider instance configured for
+// Google Cloud DNS. If project is empty, the project of the credentials is
+// used.
+func NewDNSProviderCredentials(project string, dns01Nameservers []string, hostedZoneName, impersonateServiceAccount, userAgent string) (*DNSProvider, error) {
 	ctx := context.Background()
-	client, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
+	creds, err := google.FindDefaultCredentials(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to get Google Cloud client: %v", err)
 	}
-	svc, err := dns.NewService(ctx, option.WithHTTPClient(client))
-	if err != nil {
-		return nil, fmt.Errorf("Unable to create Google Cloud DNS service: %v", err)
-	}
-	return &DNSProvider{
-		project:          project,
-		client:           svc,
-		dns01Nameservers: dns01Nameservers,
-		hostedZoneName:   hostedZoneName,
-		log:              logf.Log.WithName("clouddns"),
-	}, nil
+	return newDNSProvider(ctx, project, creds, dns01Nameservers, hostedZoneName, impersonateServiceAccount, userAgent)
 }
 
 // NewDNSProviderServiceAccount uses the supplied service account JSON file to
 // return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
-	if project == "" {
-		return nil, fmt.Errorf("Google Cloud project name missing")
-	}
+func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameservers []string, hostedZoneName, userAgent string) (*DNSProvider, error) {
 	if saFile == "" {
 		return nil, fmt.Errorf("Google Cloud Service Account file missing")
 	}
@@ -108,28 +92,52 @@ func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameserver
 	if err != nil {
 		return nil, fmt.Errorf("Unable to read Service Account file: %v", err)
 	}
-	return NewDNSProviderServiceAccountBytes(project, dat, dns01Nameservers, hostedZoneName)
+	return NewDNSProviderServiceAccountBytes(project, dat, dns01Nameservers, hostedZoneName, "", userAgent)
 }
 
 // NewDNSProviderServiceAccountBytes uses the supplied service account JSON
 // file data to return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
-	if project == "" {
-		return nil, fmt.Errorf("Google Cloud project name missing")
-	}
+// If project is empty, the project of the service account is used.
+func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Nameservers []string, hostedZoneName, impersonateServiceAccount, userAgent string) (*DNSProvider, error) {
 	if len(saBytes) == 0 {
 		return nil, fmt.Errorf("Google Cloud Service Account data missing")
 	}
 
-	conf, err := google.JWTConfigFromJSON(saBytes, dns.NdevClouddnsReadwriteScope)
+	ctx := context.Background()
+	creds, err := google.CredentialsFromJSON(ctx, saBytes, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to acquire config: %v", err)
 	}
+	return newDNSProvider(ctx, project, creds, dns01Nameservers, hostedZoneName, impersonateServiceAccount, userAgent)
+}
 
-	ctx := context.Background()
-	client := conf.Client(ctx)
+// newDNSProvider returns a DNSProvider instance managing the zones of the
+// given project with creds, impersonating impersonateServiceAccount if set,
+// which identifies itself to the Cloud DNS API with userAgent.
+// The project of creds is used if project is empty, which allows the zones to
+// be hosted in another project than the one of the credentials.
+func newDNSProvider(ctx context.Context, project string, creds *google.Credentials, dns01Nameservers []string, hostedZoneName, impersonateServiceAccount, userAgent string) (*DNSProvider, error) {
+	if project == "" {
+		project = creds.ProjectID
+	}
+	// project is a required field
+	if project == "" {
+		return nil, fmt.Errorf("Google Cloud project name missing")
+	}
+
+	tokenSource := creds.TokenSource
+	if impersonateServiceAccount != "" {
+		var err error
+		tokenSource, err = impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: impersonateServiceAccount,
+			Scopes:          []string{dns.NdevClouddnsReadwriteScope},
+		}, option.WithTokenSource(creds.TokenSource))
+		if err != nil {
+			return nil, fmt.Errorf("Unable to impersonate Google Cloud service account %q: %v", impersonateServiceAccount, err)
+		}
+	}
 
-	svc, err := dns.NewService(ctx, option.WithHTTPClient(client))
+	svc, err := dns.NewService(ctx, option.WithTokenSource(tokenSource), option.WithUserAgent(userAgent))
 	if err != nil {
 		return nil, fmt.Errorf("Unable to create Google Cloud DNS service: %v", err)
 	}
@@ -138,12 +146,31 @@ func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Name
 		client:           svc,
 		dns01Nameservers: dns01Nameservers,
 		hostedZoneName:   hostedZoneName,
+		ttl:              60,
 		log:              logf.Log.WithName("clouddns"),
 	}, nil
 }
 
+// SetTTL sets the time to live, in seconds, of the TXT records created by
+// the provider.
+func (c *DNSProvider) SetTTL(ttl int) {
+	c.ttl = ttl
+}
+
+// SetZoneMap sets the zones configured for domains, which override the zone
+// detected using SOA records if no hosted zone name is configured.
+func (c *DNSProvider) SetZoneMap(zoneMap map[string]string) {
+	c.zoneMap = zoneMap
+}
+
 // Present creates a TXT record to fulfil the dns-01 challenge.
 func (c *DNSProvider) Present(domain, fqdn, value string) error {
+	return c.PresentValues(domain, fqdn, []string{value})
+}
+
+// PresentValues replaces the TXT record set for fqdn with a record set
+// containing each of the given values.
+func (c *DNSProvider) PresentValues(domain, fqdn string, values []string) error {
 	zone, err := c.getHostedZone(fqdn)
 	if err != nil {
 		return err
@@ -151,8 +178,8 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 
 	rec := &dns.ResourceRecordSet{
 		Name:    fqdn,
-		Rrdatas: []string{value},
-		Ttl:     int64(60),
+		Rrdatas: values,
+		Ttl:     int64(c.ttl),
 		Type:    "TXT",
 	}
 	change := &dns.Change{
@@ -217,7 +244,7 @@ func (c *DNSProvider) getHostedZone(domain string) (string, error) {
 		return c.hostedZoneName, nil
 	}
 
-	authZone, err := util.FindZoneByFqdn(util.ToFqdn(domain), c.dns01Nameservers)
+	authZone, err := util.FindZoneByFqdnWithZoneMap(util.ToFqdn(domain), c.zoneMap, c.dns01Nameservers)
 	if err != nil {
 		return "", err
 	}
@@ -10,15 +10,26 @@ package clouddns
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -45,7 +56,7 @@ func TestNewDNSProviderValid(t *testing.T) {
 		t.Skip("skipping live test (requires credentials)")
 	}
 	os.Setenv("GCE_PROJECT", "")
-	_, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, "")
+	_, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, "", "", "")
 	assert.NoError(t, err)
 	restoreGCloudEnv()
 }
@@ -55,14 +66,14 @@ func TestNewDNSProviderValidEnv(t *testing.T) {
 		t.Skip("skipping live test (requires credentials)")
 	}
 	os.Setenv("GCE_PROJECT", "my-project")
-	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, "")
+	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, "", "")
 	assert.NoError(t, err)
 	restoreGCloudEnv()
 }
 
 func TestNewDNSProviderMissingCredErr(t *testing.T) {
 	os.Setenv("GCE_PROJECT", "")
-	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, "")
+	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, "", "")
 	assert.EqualError(t, err, "Google Cloud project name missing")
 	restoreGCloudEnv()
 }
@@ -72,7 +83,7 @@ func TestLiveGoogleCloudPresent(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "", "", "")
 	assert.NoError(t, err)
 
 	err = provider.Present(gcloudDomain, "_acme-challenge."+gcloudDomain+".", "123d==")
@@ -84,7 +95,7 @@ func TestLiveGoogleCloudPresentMultiple(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "", "", "")
 	assert.NoError(t, err)
 
 	// Check that we're able to create multiple entries
@@ -101,7 +112,7 @@ func TestLiveGoogleCloudCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 1)
 
-	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "", "", "")
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(gcloudDomain, "_acme-challenge."+gcloudDomain+".", "123d==")
@@ -113,7 +124,7 @@ func TestDNSProvider_getHostedZone(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	testProvider, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, "test-zone")
+	testProvider, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, "test-zone", "", "")
 	assert.NoError(t, err)
 
 	type args struct {
@@ -148,3 +159,98 @@ func TestDNSProvider_getHostedZone(t *testing.T) {
 		})
 	}
 }
+
+func serviceAccountJSON(t *testing.T, project string) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     project,
+		"private_key_id": "key-id",
+		"private_key":    string(keyPEM),
+		"client_email":   "dns01-solver@" + project + ".iam.gserviceaccount.com",
+		"token_uri":      "https://oauth2.googleapis.com/token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestNewDNSProviderServiceAccountBytesProject(t *testing.T) {
+	tests := map[string]struct {
+		project                   string
+		credentialsProject        string
+		impersonateServiceAccount string
+		expectedProject           string
+		expectedErr               string
+	}{
+		"project of the credentials is used if no project is set": {
+			credentialsProject: "credentials-project",
+			expectedProject:    "credentials-project",
+		},
+		"zone can be hosted in another project than the credentials": {
+			project:            "zone-project",
+			credentialsProject: "credentials-project",
+			expectedProject:    "zone-project",
+		},
+		"service account can be impersonated": {
+			project:                   "zone-project",
+			credentialsProject:        "credentials-project",
+			impersonateServiceAccount: "dns01-solver@zone-project.iam.gserviceaccount.com",
+			expectedProject:           "zone-project",
+		},
+		"project is required if the credentials have none": {
+			expectedErr: "Google Cloud project name missing",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider, err := NewDNSProviderServiceAccountBytes(test.project, serviceAccountJSON(t, test.credentialsProject), util.RecursiveNameservers, "", test.impersonateServiceAccount, "")
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedProject, provider.project)
+		})
+	}
+}
+
+func TestPresentSetsTTL(t *testing.T) {
+	var additions []*dns.ResourceRecordSet
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"rrsets": []}`)
+		case http.MethodPost:
+			var change dns.Change
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+			additions = change.Additions
+			fmt.Fprint(w, `{"id": "1", "status": "done"}`)
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := dns.NewService(context.Background(), option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+	require.NoError(t, err)
+	provider := &DNSProvider{
+		hostedZoneName: "test-zone",
+		project:        "my-project",
+		client:         client,
+		ttl:            60,
+		log:            logf.Log.WithName("clouddns"),
+	}
+	provider.SetTTL(300)
+
+	require.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "123d=="))
+	require.Len(t, additions, 1)
+	assert.Equal(t, int64(300), additions[0].Ttl)
+}
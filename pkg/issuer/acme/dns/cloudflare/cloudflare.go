@@ -39,6 +39,13 @@ type DNSProvider struct {
 	authEmail        string
 	authKey          string
 	authToken        string
+	ttl              int
+	zoneIDs          map[string]string
+	zoneMap          map[string]string
+
+	// transport is the transport of the HTTP client used to call the
+	// Cloudflare API, or nil to use the default transport.
+	transport http.RoundTripper
 
 	userAgent string
 }
@@ -86,11 +93,41 @@ func NewDNSProviderCredentials(email, key, token string, dns01Nameservers []stri
 		authEmail:        email,
 		authKey:          key,
 		authToken:        token,
+		ttl:              120,
 		dns01Nameservers: dns01Nameservers,
 		userAgent:        userAgent,
 	}, nil
 }
 
+// SetTTL sets the time to live, in seconds, of the TXT records created by
+// the provider.
+func (c *DNSProvider) SetTTL(ttl int) {
+	c.ttl = ttl
+}
+
+// SetZoneMap sets the zones configured for domains. The Cloudflare zone of a
+// domain in the zone map is looked up by the configured zone's name, rather
+// than by searching for the nearest zone.
+func (c *DNSProvider) SetZoneMap(zoneMap map[string]string) {
+	c.zoneMap = zoneMap
+}
+
+// SetZoneIDs sets the IDs of the Cloudflare zones managing DNS zones, which
+// are used instead of looking up the Cloudflare zone of domains part of the
+// DNS zones.
+func (c *DNSProvider) SetZoneIDs(zoneIDs map[string]string) {
+	c.zoneIDs = make(map[string]string, len(zoneIDs))
+	for zone, id := range zoneIDs {
+		c.zoneIDs[util.ToFqdn(strings.ToLower(zone))] = id
+	}
+}
+
+// SetTransport sets the transport of the HTTP client used to call the
+// Cloudflare API.
+func (c *DNSProvider) SetTransport(transport http.RoundTripper) {
+	c.transport = transport
+}
+
 // FindNearestZoneForFQDN will try to traverse the official Cloudflare API to find the nearest valid Zone.
 // It's a replacement for /pkg/issuer/acme/dns/util/wait.go#FindZoneByFqdn
 //
@@ -167,7 +204,7 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 		Type:    "TXT",
 		Name:    util.UnFqdn(fqdn),
 		Content: value,
-		TTL:     120,
+		TTL:     c.ttl,
 	}
 
 	body, err := json.Marshal(rec)
@@ -183,6 +220,64 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	return nil
 }
 
+// PresentValues ensures the TXT records for fqdn are exactly a record for each
+// of the given values, deleting records with other values and creating the
+// missing ones.
+func (c *DNSProvider) PresentValues(domain, fqdn string, values []string) error {
+	zoneID, err := c.getHostedZoneID(fqdn)
+	if err != nil {
+		return err
+	}
+
+	records, err := c.findTxtRecords(fqdn)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(values))
+	for _, value := range values {
+		wanted[value] = true
+	}
+	existing := make(map[string]bool, len(records))
+	for _, record := range records {
+		if wanted[record.Content] && !existing[record.Content] {
+			existing[record.Content] = true
+			continue
+		}
+
+		_, err = c.makeRequest("DELETE", fmt.Sprintf("/zones/%s/dns_records/%s", record.ZoneID, record.ID), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, value := range values {
+		if existing[value] {
+			continue
+		}
+
+		rec := cloudFlareRecord{
+			Type:    "TXT",
+			Name:    util.UnFqdn(fqdn),
+			Content: value,
+			TTL:     c.ttl,
+		}
+
+		body, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.makeRequest("POST", fmt.Sprintf("/zones/%s/dns_records", zoneID), bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		existing[value] = true
+	}
+
+	return nil
+}
+
 // CleanUp removes the TXT record matching the specified parameters
 func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	record, err := c.findTxtRecord(fqdn)
@@ -202,7 +297,109 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return nil
 }
 
+// PresentRecords creates the given TXT records which do not exist yet, using
+// a single batch request for the records of each zone. Existing TXT records
+// for the same names are kept.
+func (c *DNSProvider) PresentRecords(records []util.TXTRecord) error {
+	return c.changeRecords(records, false)
+}
+
+// CleanUpRecords deletes the given TXT records, using a single batch request
+// for the records of each zone. TXT records for the same names with other
+// values are kept.
+func (c *DNSProvider) CleanUpRecords(records []util.TXTRecord) error {
+	return c.changeRecords(records, true)
+}
+
+func (c *DNSProvider) changeRecords(records []util.TXTRecord, cleanUp bool) error {
+	zoneIDs := make(map[string]string)
+	existing := make(map[string][]cloudFlareRecord)
+	batches := make(map[string]*cloudFlareBatch)
+	var batchZoneIDs []string
+	for _, record := range records {
+		zoneID, ok := zoneIDs[record.FQDN]
+		if !ok {
+			var err error
+			zoneID, err = c.getHostedZoneID(record.FQDN)
+			if err != nil {
+				return err
+			}
+			zoneIDs[record.FQDN] = zoneID
+			existing[record.FQDN], err = c.findTxtRecordsInZone(zoneID, record.FQDN)
+			if err != nil {
+				return err
+			}
+		}
+
+		batch, ok := batches[zoneID]
+		if !ok {
+			batch = &cloudFlareBatch{}
+			batches[zoneID] = batch
+			batchZoneIDs = append(batchZoneIDs, zoneID)
+		}
+
+		if cleanUp {
+			for _, rec := range existing[record.FQDN] {
+				if rec.Content == record.Value {
+					batch.Deletes = append(batch.Deletes, cloudFlareRecordID{ID: rec.ID})
+				}
+			}
+			continue
+		}
+
+		found := false
+		for _, rec := range existing[record.FQDN] {
+			if rec.Content == record.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			rec := cloudFlareRecord{
+				Type:    "TXT",
+				Name:    util.UnFqdn(record.FQDN),
+				Content: record.Value,
+				TTL:     c.ttl,
+			}
+			batch.Posts = append(batch.Posts, rec)
+			existing[record.FQDN] = append(existing[record.FQDN], rec)
+		}
+	}
+
+	for _, zoneID := range batchZoneIDs {
+		batch := batches[zoneID]
+		if len(batch.Deletes) == 0 && len(batch.Posts) == 0 {
+			continue
+		}
+
+		body, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.makeRequest("POST", fmt.Sprintf("/zones/%s/dns_records/batch", zoneID), bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
+	if len(c.zoneIDs) > 0 {
+		zones := make([]string, 0, len(c.zoneIDs))
+		for zone := range c.zoneIDs {
+			zones = append(zones, zone)
+		}
+		if zone, err := util.FindBestMatch(strings.ToLower(fqdn), zones...); err == nil {
+			return c.zoneIDs[zone], nil
+		}
+	}
+
+	if zone, ok := util.LookupZoneMap(fqdn, c.zoneMap); ok {
+		fqdn = zone
+	}
 	hostedZone, err := FindNearestZoneForFQDN(c, fqdn)
 	if err != nil {
 		return "", err
@@ -213,11 +410,28 @@ func (c *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 var errNoExistingRecord = errors.New("No existing record found")
 
 func (c *DNSProvider) findTxtRecord(fqdn string) (*cloudFlareRecord, error) {
+	records, err := c.findTxtRecords(fqdn)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errNoExistingRecord
+	}
+	return &records[0], nil
+}
+
+// findTxtRecords returns all the TXT records named fqdn.
+func (c *DNSProvider) findTxtRecords(fqdn string) ([]cloudFlareRecord, error) {
 	zoneID, err := c.getHostedZoneID(fqdn)
 	if err != nil {
 		return nil, err
 	}
 
+	return c.findTxtRecordsInZone(zoneID, fqdn)
+}
+
+// findTxtRecordsInZone returns all the TXT records named fqdn in the zone.
+func (c *DNSProvider) findTxtRecordsInZone(zoneID, fqdn string) ([]cloudFlareRecord, error) {
 	result, err := c.makeRequest(
 		"GET",
 		fmt.Sprintf("/zones/%s/dns_records?per_page=100&type=TXT&name=%s", zoneID, util.UnFqdn(fqdn)),
@@ -233,13 +447,14 @@ func (c *DNSProvider) findTxtRecord(fqdn string) (*cloudFlareRecord, error) {
 		return nil, err
 	}
 
+	var matching []cloudFlareRecord
 	for _, rec := range records {
 		if rec.Name == util.UnFqdn(fqdn) {
-			return &rec, nil
+			matching = append(matching, rec)
 		}
 	}
 
-	return nil, errNoExistingRecord
+	return matching, nil
 }
 
 func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawMessage, error) {
@@ -262,18 +477,19 @@ func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 		return nil, err
 	}
 
-	if c.authEmail != "" {
-		req.Header.Set("X-Auth-Email", c.authEmail)
-	}
+	// API tokens identify the account on their own, and the email of the
+	// account is only used together with the global API key.
 	if c.authToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.authToken)
 	} else {
+		req.Header.Set("X-Auth-Email", c.authEmail)
 		req.Header.Set("X-Auth-Key", c.authKey)
 	}
 	req.Header.Set("User-Agent", c.userAgent)
 
 	client := http.Client{
-		Timeout: 30 * time.Second,
+		Transport: c.transport,
+		Timeout:   30 * time.Second,
 	}
 	resp, err := client.Do(req)
 	if err != nil {
@@ -315,6 +531,18 @@ type cloudFlareRecord struct {
 	ZoneID  string `json:"zone_id,omitempty"`
 }
 
+// cloudFlareBatch represents the changes of a CloudFlare DNS records batch
+// request, which are applied in a single transaction
+type cloudFlareBatch struct {
+	Deletes []cloudFlareRecordID `json:"deletes,omitempty"`
+	Posts   []cloudFlareRecord   `json:"posts,omitempty"`
+}
+
+// cloudFlareRecordID identifies a CloudFlare DNS record
+type cloudFlareRecordID struct {
+	ID string `json:"id"`
+}
+
 // following functions are copy-pasted from go's internal
 // http server
 func validHeaderFieldValue(v string) bool {
@@ -9,15 +9,19 @@ this directory.
 package cloudflare
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
@@ -156,3 +160,148 @@ func TestCloudFlareCleanUp(t *testing.T) {
 	err = provider.CleanUp(cflareDomain, "_acme-challenge."+cflareDomain+".", "123d==")
 	assert.NoError(t, err)
 }
+
+func TestCloudFlarePresentValues(t *testing.T) {
+	if !cflareLiveTest {
+		t.Skip("skipping live test")
+	}
+
+	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, util.RecursiveNameservers, "cert-manager-test")
+	assert.NoError(t, err)
+
+	fqdn := "_acme-challenge." + cflareDomain + "."
+	err = provider.PresentValues(cflareDomain, fqdn, []string{"123d==", "456d=="})
+	assert.NoError(t, err)
+
+	records, err := provider.findTxtRecords(fqdn)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	err = provider.PresentValues(cflareDomain, fqdn, []string{"456d=="})
+	assert.NoError(t, err)
+
+	records, err = provider.findTxtRecords(fqdn)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	err = provider.CleanUp(cflareDomain, fqdn, "456d==")
+	assert.NoError(t, err)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCloudFlareChangeRecordsInBatches(t *testing.T) {
+	var batches []cloudFlareBatch
+	provider, err := NewDNSProviderCredentials("", "", "token", util.RecursiveNameservers, "cert-manager-test")
+	require.NoError(t, err)
+	provider.SetTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var result interface{} = []interface{}{}
+		switch path := strings.TrimPrefix(req.URL.Path, "/client/v4"); {
+		case path == "/zones":
+			if req.URL.Query().Get("name") == "example.com" {
+				result = []DNSZone{{ID: "zone-1", Name: "example.com"}}
+			}
+		case path == "/zones/zone-1/dns_records" && req.Method == http.MethodGet:
+			if req.URL.Query().Get("name") == "_acme-challenge.example.com" {
+				result = []cloudFlareRecord{{ID: "record-1", Name: "_acme-challenge.example.com", Type: "TXT", Content: "value-1", ZoneID: "zone-1"}}
+			}
+		case path == "/zones/zone-1/dns_records/batch" && req.Method == http.MethodPost:
+			var batch cloudFlareBatch
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&batch))
+			batches = append(batches, batch)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+		body, err := json.Marshal(map[string]interface{}{"success": true, "result": result})
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+	}))
+
+	records := []util.TXTRecord{
+		{Domain: "example.com", FQDN: "_acme-challenge.example.com.", Value: "value-1"},
+		{Domain: "*.example.com", FQDN: "_acme-challenge.example.com.", Value: "value-2"},
+		{Domain: "www.example.com", FQDN: "_acme-challenge.www.example.com.", Value: "value-3"},
+	}
+
+	// Only the records which do not exist yet are created, using a single
+	// request.
+	require.NoError(t, provider.PresentRecords(records))
+	assert.Equal(t, []cloudFlareBatch{{
+		Posts: []cloudFlareRecord{
+			{Name: "_acme-challenge.example.com", Type: "TXT", Content: "value-2", TTL: 120},
+			{Name: "_acme-challenge.www.example.com", Type: "TXT", Content: "value-3", TTL: 120},
+		},
+	}}, batches)
+
+	batches = nil
+	require.NoError(t, provider.CleanUpRecords(records[:1]))
+	assert.Equal(t, []cloudFlareBatch{{
+		Deletes: []cloudFlareRecordID{{ID: "record-1"}},
+	}}, batches)
+}
+
+func TestCloudFlareZoneIDs(t *testing.T) {
+	var requests []string
+	provider, err := NewDNSProviderCredentials("", "", "token", util.RecursiveNameservers, "cert-manager-test")
+	require.NoError(t, err)
+	provider.SetZoneIDs(map[string]string{"Example.com": "zone-1", "internal.example.com.": "zone-2"})
+	provider.SetTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+strings.TrimPrefix(req.URL.Path, "/client/v4"))
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		assert.Empty(t, req.Header.Get("X-Auth-Email"))
+
+		var result interface{} = []interface{}{}
+		if req.URL.Path == "/client/v4/zones" && req.URL.Query().Get("name") == "example.org" {
+			result = []DNSZone{{ID: "zone-3", Name: "example.org"}}
+		}
+		body, err := json.Marshal(map[string]interface{}{"success": true, "result": result})
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+	}))
+
+	// Zones with a configured ID are not looked up, which API tokens scoped
+	// to a zone are not permitted to do.
+	for fqdn, expected := range map[string]string{
+		"_acme-challenge.example.com.":              "zone-1",
+		"_acme-challenge.www.internal.example.com.": "zone-2",
+		"_acme-challenge.example.org.":              "zone-3",
+	} {
+		zoneID, err := provider.getHostedZoneID(fqdn)
+		require.NoError(t, err)
+		assert.Equal(t, expected, zoneID, fqdn)
+	}
+	assert.Equal(t, []string{"GET /zones", "GET /zones"}, requests)
+}
+
+func TestCloudFlarePresentSetsTTL(t *testing.T) {
+	var created []cloudFlareRecord
+	provider, err := NewDNSProviderCredentials("", "", "token", util.RecursiveNameservers, "cert-manager-test")
+	require.NoError(t, err)
+	provider.SetTTL(300)
+	provider.SetZoneIDs(map[string]string{"example.com": "zone-1"})
+	provider.SetTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var result interface{} = []interface{}{}
+		switch path := strings.TrimPrefix(req.URL.Path, "/client/v4"); {
+		case path == "/zones/zone-1/dns_records" && req.Method == http.MethodGet:
+		case path == "/zones/zone-1/dns_records" && req.Method == http.MethodPost:
+			var record cloudFlareRecord
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&record))
+			created = append(created, record)
+			result = record
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+		body, err := json.Marshal(map[string]interface{}{"success": true, "result": result})
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+	}))
+
+	require.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "value-1"))
+	assert.Equal(t, []cloudFlareRecord{
+		{Name: "_acme-challenge.example.com", Type: "TXT", Content: "value-1", TTL: 300},
+	}, created)
+}
@@ -21,6 +21,7 @@ package digitalocean
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
@@ -34,18 +35,24 @@ import (
 type DNSProvider struct {
 	dns01Nameservers []string
 	client           *godo.Client
+	ttl              int
+	zoneMap          map[string]string
+
+	// oauthTransport is the transport of the client, which authenticates
+	// requests sent through its base transport.
+	oauthTransport *oauth2.Transport
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for digitalocean.
 // The access token must be passed in the environment variable DIGITALOCEAN_TOKEN
-func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProvider(dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
 	token := os.Getenv("DIGITALOCEAN_TOKEN")
-	return NewDNSProviderCredentials(token, dns01Nameservers)
+	return NewDNSProviderCredentials(token, dns01Nameservers, userAgent)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
 // DNSProvider instance configured for digitalocean.
-func NewDNSProviderCredentials(token string, dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProviderCredentials(token string, dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
 	if token == "" {
 		return nil, fmt.Errorf("DigitalOcean token missing")
 	}
@@ -55,16 +62,41 @@ func NewDNSProviderCredentials(token string, dns01Nameservers []string) (*DNSPro
 		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
 	)
 
+	client, err := godo.New(c, godo.SetUserAgent(userAgent))
+	if err != nil {
+		return nil, err
+	}
+
 	return &DNSProvider{
 		dns01Nameservers: dns01Nameservers,
-		client:           godo.NewClient(c),
+		client:           client,
+		ttl:              60,
+		oauthTransport:   c.Transport.(*oauth2.Transport),
 	}, nil
 }
 
+// SetTTL sets the time to live, in seconds, of the TXT records created by
+// the provider.
+func (c *DNSProvider) SetTTL(ttl int) {
+	c.ttl = ttl
+}
+
+// SetZoneMap sets the zones configured for domains, which override the zone
+// detected using SOA records.
+func (c *DNSProvider) SetZoneMap(zoneMap map[string]string) {
+	c.zoneMap = zoneMap
+}
+
+// SetTransport sets the transport through which authenticated requests to
+// the DigitalOcean API are sent.
+func (c *DNSProvider) SetTransport(transport http.RoundTripper) {
+	c.oauthTransport.Base = transport
+}
+
 // Present creates a TXT record to fulfil the dns-01 challenge
 func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	// if DigitalOcean does not have this zone then we will find out later
-	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	zoneName, err := util.FindZoneByFqdnWithZoneMap(fqdn, c.zoneMap, c.dns01Nameservers)
 	if err != nil {
 		return err
 	}
@@ -86,7 +118,7 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 		Type: "TXT",
 		Name: fqdn,
 		Data: value,
-		TTL:  60,
+		TTL:  c.ttl,
 	}
 
 	_, _, err = c.client.Domains.CreateRecord(
@@ -104,7 +136,7 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 
 // CleanUp removes the TXT record matching the specified parameters
 func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
-	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	zoneName, err := util.FindZoneByFqdnWithZoneMap(fqdn, c.zoneMap, c.dns01Nameservers)
 	if err != nil {
 		return err
 	}
@@ -127,7 +159,7 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 
 func (c *DNSProvider) findTxtRecord(fqdn string) ([]godo.DomainRecord, error) {
 
-	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	zoneName, err := util.FindZoneByFqdnWithZoneMap(fqdn, c.zoneMap, c.dns01Nameservers)
 	if err != nil {
 		return nil, err
 	}
@@ -17,12 +17,19 @@ limitations under the License.
 package digitalocean
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
 	"os"
 	"testing"
 	"time"
 
-	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/digitalocean/godo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
 
 var (
@@ -45,21 +52,21 @@ func restoreEnv() {
 
 func TestNewDNSProviderValid(t *testing.T) {
 	os.Setenv("DIGITALOCEAN_TOKEN", "")
-	_, err := NewDNSProviderCredentials("123", util.RecursiveNameservers)
+	_, err := NewDNSProviderCredentials("123", util.RecursiveNameservers, "")
 	assert.NoError(t, err)
 	restoreEnv()
 }
 
 func TestNewDNSProviderValidEnv(t *testing.T) {
 	os.Setenv("DIGITALOCEAN_TOKEN", "123")
-	_, err := NewDNSProvider(util.RecursiveNameservers)
+	_, err := NewDNSProvider(util.RecursiveNameservers, "")
 	assert.NoError(t, err)
 	restoreEnv()
 }
 
 func TestNewDNSProviderMissingCredErr(t *testing.T) {
 	os.Setenv("DIGITALOCEAN_TOKEN", "")
-	_, err := NewDNSProvider(util.RecursiveNameservers)
+	_, err := NewDNSProvider(util.RecursiveNameservers, "")
 	assert.EqualError(t, err, "DigitalOcean token missing")
 	restoreEnv()
 }
@@ -69,7 +76,7 @@ func TestDigitalOceanPresent(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(doToken, util.RecursiveNameservers)
+	provider, err := NewDNSProviderCredentials(doToken, util.RecursiveNameservers, "")
 	assert.NoError(t, err)
 
 	err = provider.Present(doDomain, "_acme-challenge."+doDomain+".", "123d==")
@@ -83,7 +90,7 @@ func TestDigitalOceanCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 2)
 
-	provider, err := NewDNSProviderCredentials(doToken, util.RecursiveNameservers)
+	provider, err := NewDNSProviderCredentials(doToken, util.RecursiveNameservers, "")
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(doDomain, "_acme-challenge."+doDomain+".", "123d==")
@@ -93,3 +100,39 @@ func TestDigitalOceanCleanUp(t *testing.T) {
 func TestDigitalOceanSolveForProvider(t *testing.T) {
 
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestDigitalOceanPresentSetsTTL(t *testing.T) {
+	var created []godo.DomainRecordEditRequest
+	provider, err := NewDNSProviderCredentials("token", util.RecursiveNameservers, "cert-manager-test")
+	require.NoError(t, err)
+	provider.SetTTL(300)
+	provider.SetZoneMap(map[string]string{"example.com": "example.com"})
+	provider.SetTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var result interface{}
+		switch {
+		case req.URL.Path == "/v2/domains/example.com/records" && req.Method == http.MethodGet:
+			result = map[string]interface{}{"domain_records": []godo.DomainRecord{}}
+		case req.URL.Path == "/v2/domains/example.com/records" && req.Method == http.MethodPost:
+			var record godo.DomainRecordEditRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&record))
+			created = append(created, record)
+			result = map[string]interface{}{"domain_record": godo.DomainRecord{}}
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+		body, err := json.Marshal(result)
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"application/json"}}, Body: io.NopCloser(bytes.NewReader(body))}, nil
+	}))
+
+	require.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "value-1"))
+	assert.Equal(t, []godo.DomainRecordEditRequest{
+		{Type: "TXT", Name: "_acme-challenge.example.com.", Data: "value-1", TTL: 300},
+	}, created)
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
-	"time"
+	"sync"
 
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -32,6 +34,7 @@ import (
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/akamai"
@@ -44,6 +47,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	webhookslv "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/webhook"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/tlsconfig"
 )
 
 // solver is the old solver type interface.
@@ -53,16 +57,43 @@ type solver interface {
 	CleanUp(domain, fqdn, value string) error
 }
 
+// ttlSolver is implemented by solvers which support configuring the TTL of
+// the TXT records they create.
+type ttlSolver interface {
+	SetTTL(ttl int)
+}
+
+// zoneMapSolver is implemented by solvers which support overriding the
+// detection of the zone of a domain using the solver's zone map.
+type zoneMapSolver interface {
+	SetZoneMap(zoneMap map[string]string)
+}
+
+// transportSolver is implemented by solvers which support configuring the
+// transport of the HTTP client they use to call the API of their provider.
+type transportSolver interface {
+	SetTransport(transport http.RoundTripper)
+}
+
+var (
+	_ transportSolver = &azuredns.DNSProvider{}
+	_ transportSolver = &cloudflare.DNSProvider{}
+	_ transportSolver = &digitalocean.DNSProvider{}
+	_ transportSolver = &route53.DNSProvider{}
+)
+
 // dnsProviderConstructors defines how each provider may be constructed.
 // It is useful for mocking out a given provider since an alternate set of
 // constructors may be set.
+// Providers which call an HTTP API are given the external User Agent, except
+// for acme-dns whose client library always sends its own User Agent.
 type dnsProviderConstructors struct {
-	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error)
+	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName, impersonateServiceAccount, userAgent string) (*clouddns.DNSProvider, error)
 	cloudFlare   func(email, apikey, apiToken string, dns01Nameservers []string, userAgent string) (*cloudflare.DNSProvider, error)
-	route53      func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error)
-	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error)
+	route53      func(accessKey, secretKey, hostedZoneID, region string, roles []route53.AssumeRole, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error)
+	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity, userAgent string) (*azuredns.DNSProvider, error)
 	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
-	digitalOcean func(token string, dns01Nameservers []string) (*digitalocean.DNSProvider, error)
+	digitalOcean func(token string, dns01Nameservers []string, userAgent string) (*digitalocean.DNSProvider, error)
 }
 
 // Solver is a solver for the acme dns01 challenge.
@@ -71,8 +102,18 @@ type dnsProviderConstructors struct {
 type Solver struct {
 	*controller.Context
 	secretLister            corev1listers.SecretLister
+	challengeLister         cmacmelisters.ChallengeLister
 	dnsProviderConstructors dnsProviderConstructors
 	webhookSolvers          map[string]webhook.Solver
+
+	// recordBatcher groups the changes to the TXT records of the challenges
+	// of an Order for solvers implementing batchSolver. Changes are not
+	// batched if nil.
+	recordBatcher *recordBatcher
+
+	// acmeDNSAccountsLock serialises the registration of acme-dns accounts,
+	// so that challenges for the same domain share a single account.
+	acmeDNSAccountsLock sync.Mutex
 }
 
 // Present performs the work to configure DNS to resolve a DNS01 challenge.
@@ -94,11 +135,37 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	if adns, ok := slv.(*acmedns.DNSProvider); ok && adns != nil {
+		if err := s.ensureAcmeDNSAccount(ctx, issuer, providerConfig.AcmeDNS, adns, ch); err != nil {
+			return err
+		}
+	}
+
+	fqdn, err := s.challengeFQDN(providerConfig, ch)
 	if err != nil {
 		return err
 	}
 
+	if bs, ok := slv.(batchSolver); ok && s.recordBatcher != nil {
+		if key, ok := recordBatchKey(ch, false); ok {
+			log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain along with the other challenges of its order")
+			return s.recordBatcher.change(key, bs, false, util.TXTRecord{Domain: ch.Spec.DNSName, FQDN: fqdn, Value: ch.Spec.Key})
+		}
+	}
+
+	if mvs, ok := slv.(multiValueSolver); ok {
+		shared, err := s.sharedChallengeValues(ch)
+		if err != nil {
+			return err
+		}
+		if len(shared) > 0 {
+			values := append([]string{ch.Spec.Key}, shared...)
+			sort.Strings(values)
+			log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain along with challenges sharing its record", "values", len(values))
+			return mvs.PresentValues(ch.Spec.DNSName, fqdn, values)
+		}
+	}
+
 	log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain")
 
 	return slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key)
@@ -108,15 +175,16 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	log := logf.WithResource(logf.FromContext(ctx, "Check"), ch).WithValues("domain", ch.Spec.DNSName)
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, false, s.DNS01Nameservers...)
+	nameservers := s.recursiveNameservers(ch)
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, false, nameservers...)
 	if err != nil {
 		return err
 	}
 
-	log.V(logf.DebugLevel).Info("checking DNS propagation", "nameservers", s.Context.DNS01Nameservers)
+	checkAuthoritative := s.checkAuthoritative(issuer)
+	log.V(logf.DebugLevel).Info("checking DNS propagation", "nameservers", nameservers, "authoritative", checkAuthoritative)
 
-	ok, err := util.PreCheckDNS(fqdn, ch.Spec.Key, s.Context.DNS01Nameservers,
-		s.Context.DNS01CheckAuthoritative)
+	ok, err := util.PreCheckDNS(fqdn, ch.Spec.Key, nameservers, checkAuthoritative)
 	if err != nil {
 		return err
 	}
@@ -124,14 +192,47 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 		return fmt.Errorf("DNS record for %q not yet propagated", ch.Spec.DNSName)
 	}
 
-	ttl := 60
-	log.V(logf.DebugLevel).Info("waiting DNS record TTL to allow the DNS01 record to propagate for domain", "ttl", ttl, "fqdn", fqdn)
-	time.Sleep(time.Second * time.Duration(ttl))
 	log.V(logf.DebugLevel).Info("ACME DNS01 validation record propagated", "fqdn", fqdn)
 
 	return nil
 }
 
+// recursiveNameservers returns the nameservers used to follow CNAMEs and to
+// check the propagation of the DNS records for the ACME challenge. Nameservers configured on the
+// challenge's solver take precedence over the ones configured on the
+// controller.
+func (s *Solver) recursiveNameservers(ch *cmacme.Challenge) []string {
+	if ch.Spec.Solver.DNS01 != nil && len(ch.Spec.Solver.DNS01.RecursiveNameservers) > 0 {
+		return ch.Spec.Solver.DNS01.RecursiveNameservers
+	}
+	return s.DNS01Nameservers
+}
+
+// challengeFQDN returns the FQDN of the TXT record solving the ACME challenge.
+// If the solver's CNAMEStrategy is Follow, the CNAMEs of the
+// _acme-challenge record are followed using the solver's recursive
+// nameservers so that the record is presented in the zone validation has
+// been delegated to.
+func (s *Solver) challengeFQDN(config *cmacme.ACMEChallengeSolverDNS01, ch *cmacme.Challenge) (string, error) {
+	return util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(config.CNAMEStrategy), s.recursiveNameservers(ch)...)
+}
+
+// checkAuthoritative returns true if the propagation of the DNS records for
+// ACME challenges of the issuer is checked by querying the authoritative
+// nameservers of the zone. The issuer's configuration takes precedence over
+// the controller's.
+func (s *Solver) checkAuthoritative(issuer v1.GenericIssuer) bool {
+	if acme := issuer.GetSpec().ACME; acme != nil && acme.DNS01SelfCheck != nil {
+		switch acme.DNS01SelfCheck.Nameservers {
+		case cmacme.DNS01SelfCheckNameserversAuthoritative:
+			return true
+		case cmacme.DNS01SelfCheckNameserversRecursive:
+			return false
+		}
+	}
+	return s.DNS01CheckAuthoritative
+}
+
 // CleanUp removes DNS records which are no longer needed after
 // certificate issuance.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
@@ -152,11 +253,31 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(providerConfig, ch)
 	if err != nil {
 		return err
 	}
 
+	if bs, ok := slv.(batchSolver); ok && s.recordBatcher != nil {
+		if key, ok := recordBatchKey(ch, true); ok {
+			log.V(logf.DebugLevel).Info("cleaning up DNS01 challenge along with the other challenges of its order")
+			return s.recordBatcher.change(key, bs, true, util.TXTRecord{Domain: ch.Spec.DNSName, FQDN: fqdn, Value: ch.Spec.Key})
+		}
+	}
+
+	if mvs, ok := slv.(multiValueSolver); ok {
+		shared, err := s.sharedChallengeValues(ch)
+		if err != nil {
+			return err
+		}
+		if len(shared) > 0 {
+			// other challenges still need the record, so only remove the
+			// value of this challenge from it
+			log.V(logf.DebugLevel).Info("removing DNS01 challenge value from record still used by other challenges", "values", len(shared))
+			return mvs.PresentValues(ch.Spec.DNSName, fqdn, shared)
+		}
+	}
+
 	return slv.CleanUp(ch.Spec.DNSName, fqdn, ch.Spec.Key)
 }
 
@@ -211,7 +332,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			string(clientToken),
 			string(clientSecret),
 			string(accessToken),
-			s.DNS01Nameservers)
+			s.ExternalUserAgent)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "error instantiating akamai challenge solver")
 		}
@@ -236,7 +357,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		// attempt to construct the cloud dns provider
-		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName)
+		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName, providerConfig.CloudDNS.ImpersonateServiceAccount, s.ExternalUserAgent)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
 		}
@@ -273,10 +394,14 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		email := providerConfig.Cloudflare.Email
-		impl, err = s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, s.DNS01Nameservers, s.RESTConfig.UserAgent)
+		cf, err := s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, s.DNS01Nameservers, s.ExternalUserAgent)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
 		}
+		if len(providerConfig.Cloudflare.ZoneIDs) > 0 {
+			cf.SetZoneIDs(providerConfig.Cloudflare.ZoneIDs)
+		}
+		impl = cf
 	case providerConfig.DigitalOcean != nil:
 		dbg.Info("preparing to create DigitalOcean provider")
 		apiTokenSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.DigitalOcean.Token.Name)
@@ -286,7 +411,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 
 		apiToken := string(apiTokenSecret.Data[providerConfig.DigitalOcean.Token.Key])
 
-		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), s.DNS01Nameservers)
+		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), s.DNS01Nameservers, s.ExternalUserAgent)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
 		}
@@ -344,19 +469,39 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			secretAccessKey = string(secretAccessKeyBytes)
 		}
 
-		impl, err = s.dnsProviderConstructors.route53(
+		var roles []route53.AssumeRole
+		if providerConfig.Route53.Role != "" {
+			roles = append(roles, route53.AssumeRole{
+				ARN:         providerConfig.Route53.Role,
+				ExternalID:  providerConfig.Route53.ExternalID,
+				SessionTags: providerConfig.Route53.SessionTags,
+			})
+		}
+		for _, r := range providerConfig.Route53.RoleChain {
+			roles = append(roles, route53.AssumeRole{
+				ARN:         r.Role,
+				ExternalID:  r.ExternalID,
+				SessionTags: r.SessionTags,
+			})
+		}
+
+		r53, err := s.dnsProviderConstructors.route53(
 			secretAccessKeyID,
 			strings.TrimSpace(secretAccessKey),
 			providerConfig.Route53.HostedZoneID,
 			providerConfig.Route53.Region,
-			providerConfig.Route53.Role,
+			roles,
 			canUseAmbientCredentials,
 			s.DNS01Nameservers,
-			s.RESTConfig.UserAgent,
+			s.ExternalUserAgent,
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err)
 		}
+		if len(providerConfig.Route53.HostedZoneIDs) > 0 {
+			r53.SetHostedZoneIDs(providerConfig.Route53.HostedZoneIDs)
+		}
+		impl = r53
 	case providerConfig.AzureDNS != nil:
 		dbg.Info("preparing to create AzureDNS provider")
 		secret := ""
@@ -385,6 +530,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			s.DNS01Nameservers,
 			canUseAmbientCredentials,
 			providerConfig.AzureDNS.ManagedIdentity,
+			s.ExternalUserAgent,
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating azuredns challenge solver: %s", err)
@@ -397,9 +543,13 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		accountSecretBytes, ok := accountSecret.Data[providerConfig.AcmeDNS.AccountSecret.Key]
-		if !ok {
+		if !ok && !providerConfig.AcmeDNS.AutoRegister {
 			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", providerConfig.AcmeDNS.AccountSecret.Key)
 		}
+		// The Secret holds no accounts until the first one is registered.
+		if len(accountSecretBytes) == 0 && providerConfig.AcmeDNS.AutoRegister {
+			accountSecretBytes = []byte("{}")
+		}
 
 		impl, err = s.dnsProviderConstructors.acmeDNS(
 			providerConfig.AcmeDNS.Host,
@@ -413,6 +563,34 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		return nil, providerConfig, fmt.Errorf("no dns provider config specified for challenge")
 	}
 
+	if len(providerConfig.ZoneMap) > 0 {
+		if z, ok := impl.(zoneMapSolver); ok {
+			z.SetZoneMap(providerConfig.ZoneMap)
+		} else {
+			dbg.Info("DNS provider does not support configuring the zone map, detecting the zone using SOA records")
+		}
+	}
+
+	if providerConfig.TTL != nil {
+		if t, ok := impl.(ttlSolver); ok {
+			t.SetTTL(*providerConfig.TTL)
+		} else {
+			dbg.Info("DNS provider does not support configuring the record TTL, using the provider default", "ttl", *providerConfig.TTL)
+		}
+	}
+
+	if issuer.GetSpec().TLS != nil {
+		if t, ok := impl.(transportSolver); ok {
+			transport, err := tlsconfig.Transport(issuer.GetSpec().TLS)
+			if err != nil {
+				return nil, providerConfig, fmt.Errorf("error configuring the TLS connections of the DNS provider: %w", err)
+			}
+			t.SetTransport(transport)
+		} else {
+			dbg.Info("DNS provider does not support configuring TLS, using the default TLS configuration")
+		}
+	}
+
 	return impl, providerConfig, nil
 }
 
@@ -427,12 +605,12 @@ func (s *Solver) prepareChallengeRequest(issuer v1.GenericIssuer, ch *cmacme.Cha
 		return nil, nil, err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(dns01Config.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(dns01Config, ch)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	zone, err := util.FindZoneByFqdn(fqdn, s.DNS01Nameservers)
+	zone, err := util.FindZoneByFqdnWithZoneMap(fqdn, dns01Config.ZoneMap, s.recursiveNameservers(ch))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -509,8 +687,9 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 	}
 
 	return &Solver{
-		Context:      ctx,
-		secretLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		Context:         ctx,
+		secretLister:    ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		challengeLister: ctx.SharedInformerFactory.Acme().V1().Challenges().Lister(),
 		dnsProviderConstructors: dnsProviderConstructors{
 			clouddns.NewDNSProvider,
 			cloudflare.NewDNSProviderCredentials,
@@ -520,6 +699,7 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 			digitalocean.NewDNSProviderCredentials,
 		},
 		webhookSolvers: initialized,
+		recordBatcher:  newRecordBatcher(recordBatchWindow),
 	}, nil
 }
 
@@ -18,9 +18,11 @@ package dns
 
 import (
 	"context"
+	"net"
 	"reflect"
 	"testing"
 
+	"github.com/miekg/dns"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,6 +35,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
 
@@ -395,7 +398,7 @@ func TestRoute53TrimCreds(t *testing.T) {
 	expectedR53Call := []fakeDNSProviderCall{
 		{
 			name: "route53",
-			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", "", false, util.RecursiveNameservers},
+			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", []route53.AssumeRole(nil), false, util.RecursiveNameservers},
 		},
 	}
 
@@ -453,7 +456,7 @@ func TestRoute53SecretAccessKey(t *testing.T) {
 	expectedR53Call := []fakeDNSProviderCall{
 		{
 			name: "route53",
-			args: []interface{}{"AWSACCESSKEYID", "AKIENDINNEWLINE", "", "us-west-2", "", false, util.RecursiveNameservers},
+			args: []interface{}{"AWSACCESSKEYID", "AKIENDINNEWLINE", "", "us-west-2", []route53.AssumeRole(nil), false, util.RecursiveNameservers},
 		},
 	}
 
@@ -501,7 +504,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", []route53.AssumeRole(nil), true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -534,7 +537,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", []route53.AssumeRole(nil), false, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -598,7 +601,7 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-role", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", []route53.AssumeRole{{ARN: "my-role"}}, true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -632,7 +635,49 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-other-role", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", []route53.AssumeRole{{ARN: "my-other-role"}}, false, util.RecursiveNameservers},
+				},
+			},
+		},
+		{
+			solverFixture{
+				Builder: &test.Builder{
+					Context: &controller.Context{
+						RESTConfig: new(rest.Config),
+						ContextOptions: controller.ContextOptions{
+							IssuerOptions: controller.IssuerOptions{
+								IssuerAmbientCredentials: true,
+							},
+						},
+					},
+				},
+				Issuer:       newIssuer("test", "default"),
+				dnsProviders: newFakeDNSProviders(),
+				Challenge: &cmacme.Challenge{
+					Spec: cmacme.ChallengeSpec{
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: &cmacme.ACMEChallengeSolverDNS01{
+								Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+									Region:      "us-west-2",
+									Role:        "my-role",
+									ExternalID:  "my-external-id",
+									SessionTags: map[string]string{"team": "platform"},
+									RoleChain: []cmacme.Route53AssumeRole{
+										{Role: "my-zone-role", ExternalID: "my-other-external-id"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			result{
+				expectedCall: &fakeDNSProviderCall{
+					name: "route53",
+					args: []interface{}{"", "", "", "us-west-2", []route53.AssumeRole{
+						{ARN: "my-role", ExternalID: "my-external-id", SessionTags: map[string]string{"team": "platform"}},
+						{ARN: "my-zone-role", ExternalID: "my-other-external-id"},
+					}, true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -655,3 +700,177 @@ func TestRoute53AssumeRole(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckRecursiveNameservers(t *testing.T) {
+	defaultNameservers := []string{"8.8.8.8:53"}
+	tests := map[string]struct {
+		solverNameservers []string
+		expected          []string
+	}{
+		"the nameservers of the controller are used by default": {
+			expected: defaultNameservers,
+		},
+		"the nameservers of the solver override the ones of the controller": {
+			solverNameservers: []string{"10.0.0.53:53"},
+			expected:          []string{"10.0.0.53:53"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var nameservers []string
+			preCheckDNS := util.PreCheckDNS
+			defer func() { util.PreCheckDNS = preCheckDNS }()
+			util.PreCheckDNS = func(fqdn, value string, ns []string, useAuthoritative bool) (bool, error) {
+				nameservers = ns
+				return true, nil
+			}
+
+			s := &Solver{Context: &controller.Context{ContextOptions: controller.ContextOptions{ACMEOptions: controller.ACMEOptions{DNS01Nameservers: defaultNameservers}}}}
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Solver: cmacme.ACMEChallengeSolver{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							TTL:                  new(int),
+							RecursiveNameservers: test.solverNameservers,
+						},
+					},
+				},
+			}
+			if err := s.Check(context.Background(), newIssuer("test", "default"), ch); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(test.expected, nameservers) {
+				t.Errorf("expected nameservers %v, got %v", test.expected, nameservers)
+			}
+		})
+	}
+}
+
+func TestCheckAuthoritative(t *testing.T) {
+	tests := map[string]struct {
+		controllerAuthoritative bool
+		selfCheck               *cmacme.ACMEDNS01SelfCheck
+		expected                bool
+	}{
+		"the mode of the controller is used by default": {
+			controllerAuthoritative: true,
+			expected:                true,
+		},
+		"the mode of the controller is used if the issuer does not set the nameservers": {
+			controllerAuthoritative: true,
+			selfCheck:               &cmacme.ACMEDNS01SelfCheck{},
+			expected:                true,
+		},
+		"the issuer may require the authoritative nameservers to be queried": {
+			selfCheck: &cmacme.ACMEDNS01SelfCheck{Nameservers: cmacme.DNS01SelfCheckNameserversAuthoritative},
+			expected:  true,
+		},
+		"the issuer may require only recursive nameservers to be queried": {
+			controllerAuthoritative: true,
+			selfCheck:               &cmacme.ACMEDNS01SelfCheck{Nameservers: cmacme.DNS01SelfCheckNameserversRecursive},
+			expected:                false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var authoritative bool
+			preCheckDNS := util.PreCheckDNS
+			defer func() { util.PreCheckDNS = preCheckDNS }()
+			util.PreCheckDNS = func(fqdn, value string, ns []string, useAuthoritative bool) (bool, error) {
+				authoritative = useAuthoritative
+				return true, nil
+			}
+
+			s := &Solver{Context: &controller.Context{ContextOptions: controller.ContextOptions{ACMEOptions: controller.ACMEOptions{DNS01CheckAuthoritative: test.controllerAuthoritative}}}}
+			issuer := newIssuer("test", "default")
+			issuer.Spec.ACME.DNS01SelfCheck = test.selfCheck
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Solver: cmacme.ACMEChallengeSolver{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{TTL: new(int)},
+					},
+				},
+			}
+			if err := s.Check(context.Background(), issuer, ch); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if authoritative != test.expected {
+				t.Errorf("expected authoritative check to be %t, got %t", test.expected, authoritative)
+			}
+		})
+	}
+}
+
+func TestChallengeFQDN(t *testing.T) {
+	// serve a CNAME delegating the validation of example.com to another zone
+	mux := dns.NewServeMux()
+	mux.HandleFunc(".", func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		for _, q := range r.Question {
+			if q.Name == "_acme-challenge.example.com." && q.Qtype == dns.TypeCNAME {
+				rr, err := dns.NewRR("_acme-challenge.example.com. 60 IN CNAME _acme-challenge.delegated.example.net.")
+				if err != nil {
+					t.Error(err)
+				}
+				m.Answer = append(m.Answer, rr)
+			}
+		}
+		if err := w.WriteMsg(m); err != nil {
+			t.Error(err)
+		}
+	})
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: pc, Handler: mux, NotifyStartedFunc: func() { close(started) }}
+	go func() {
+		if err := server.ActivateAndServe(); err != nil {
+			t.Error(err)
+		}
+	}()
+	defer server.Shutdown()
+	<-started
+
+	tests := map[string]struct {
+		strategy cmacme.CNAMEStrategy
+		expected string
+	}{
+		"CNAMEs are not followed by default": {
+			expected: "_acme-challenge.example.com.",
+		},
+		"CNAMEs are followed using the nameservers of the solver": {
+			strategy: cmacme.FollowStrategy,
+			expected: "_acme-challenge.delegated.example.net.",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &Solver{Context: &controller.Context{ContextOptions: controller.ContextOptions{ACMEOptions: controller.ACMEOptions{DNS01Nameservers: []string{"127.0.0.1:1"}}}}}
+			config := &cmacme.ACMEChallengeSolverDNS01{
+				CNAMEStrategy:        test.strategy,
+				RecursiveNameservers: []string{pc.LocalAddr().String()},
+			}
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Solver:  cmacme.ACMEChallengeSolver{DNS01: config},
+				},
+			}
+			fqdn, err := s.challengeFQDN(config, ch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fqdn != test.expected {
+				t.Errorf("expected fqdn %q, got %q", test.expected, fqdn)
+			}
+		})
+	}
+}
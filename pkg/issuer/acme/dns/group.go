@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"sort"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/azuredns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
+)
+
+// This file implements cooperative solving of DNS-01 challenges which share
+// a TXT record name, such as the challenges for `example.com` and
+// `*.example.com` of the same Order. Providers which replace the whole TXT
+// record set on each change implement multiValueSolver, and are given the
+// values of all such challenges so that presenting or cleaning up one
+// challenge does not remove the record of another.
+
+// multiValueSolver is implemented by solvers which replace all the TXT
+// records for a name when presenting a challenge.
+type multiValueSolver interface {
+	// PresentValues ensures the TXT records for fqdn are exactly the given
+	// values.
+	PresentValues(domain, fqdn string, values []string) error
+}
+
+var (
+	_ multiValueSolver = &azuredns.DNSProvider{}
+	_ multiValueSolver = &clouddns.DNSProvider{}
+	_ multiValueSolver = &cloudflare.DNSProvider{}
+)
+
+// sharedChallengeValues returns the keys of the other DNS-01 challenges of the
+// same Order and solver as the given challenge, which are for the same DNS
+// name and are not yet in a final state. The keys are sorted and do not
+// include the key of the given challenge.
+func (s *Solver) sharedChallengeValues(ch *cmacme.Challenge) ([]string, error) {
+	if s.challengeLister == nil {
+		return nil, nil
+	}
+	orderRef := metav1.GetControllerOf(ch)
+	if orderRef == nil || orderRef.Kind != cmacme.OrderKind {
+		return nil, nil
+	}
+
+	chs, err := s.challengeLister.Challenges(ch.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{ch.Spec.Key: true}
+	var values []string
+	for _, other := range chs {
+		if other.UID == ch.UID ||
+			other.DeletionTimestamp != nil ||
+			other.Spec.Type != cmacme.ACMEChallengeTypeDNS01 ||
+			other.Spec.DNSName != ch.Spec.DNSName ||
+			acme.IsFinalState(other.Status.State) {
+			continue
+		}
+		if ref := metav1.GetControllerOf(other); ref == nil || ref.UID != orderRef.UID {
+			continue
+		}
+		if !apiequality.Semantic.DeepEqual(other.Spec.Solver, ch.Spec.Solver) {
+			continue
+		}
+		if seen[other.Spec.Key] {
+			continue
+		}
+		seen[other.Spec.Key] = true
+		values = append(values, other.Spec.Key)
+	}
+	sort.Strings(values)
+
+	return values, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func groupChallenge(name, dnsName, key string, state cmacme.State) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID(name),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: cmacme.SchemeGroupVersion.String(),
+				Kind:       cmacme.OrderKind,
+				Name:       "test-order",
+				UID:        "test-order-uid",
+				Controller: func(b bool) *bool { return &b }(true),
+			}},
+		},
+		Spec: cmacme.ChallengeSpec{
+			Type:    cmacme.ACMEChallengeTypeDNS01,
+			DNSName: dnsName,
+			Key:     key,
+			Solver: cmacme.ACMEChallengeSolver{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{},
+				},
+			},
+		},
+		Status: cmacme.ChallengeStatus{State: state},
+	}
+}
+
+func TestSharedChallengeValues(t *testing.T) {
+	apex := groupChallenge("apex", "example.com", "key-apex", cmacme.Pending)
+	wildcard := groupChallenge("wildcard", "example.com", "key-wildcard", cmacme.Pending)
+	wildcard.Spec.Wildcard = true
+	// challenges for other names, of other orders, with other solvers or
+	// which have already been solved do not share the record
+	otherName := groupChallenge("other-name", "www.example.com", "key-other-name", cmacme.Pending)
+	otherOrder := groupChallenge("other-order", "example.com", "key-other-order", cmacme.Pending)
+	otherOrder.OwnerReferences[0].UID = "other-order-uid"
+	otherSolver := groupChallenge("other-solver", "example.com", "key-other-solver", cmacme.Pending)
+	otherSolver.Spec.Solver.DNS01 = &cmacme.ACMEChallengeSolverDNS01{AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{}}
+	valid := groupChallenge("valid", "example.com", "key-valid", cmacme.Valid)
+
+	b := &test.Builder{
+		T:                  t,
+		CertManagerObjects: []runtime.Object{apex, wildcard, otherName, otherOrder, otherSolver, valid},
+	}
+	s := buildFakeSolver(b, newFakeDNSProviders().constructors)
+	defer b.Stop()
+
+	tests := map[string]struct {
+		challenge *cmacme.Challenge
+		expected  []string
+	}{
+		"apex challenge shares the record of the wildcard challenge": {
+			challenge: apex,
+			expected:  []string{"key-wildcard"},
+		},
+		"wildcard challenge shares the record of the apex challenge": {
+			challenge: wildcard,
+			expected:  []string{"key-apex"},
+		},
+		"challenge for another name does not share a record": {
+			challenge: otherName,
+			expected:  nil,
+		},
+		"solved challenge still sees the challenges it shares the record with": {
+			challenge: valid,
+			expected:  []string{"key-apex", "key-wildcard"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := s.sharedChallengeValues(test.challenge)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(test.expected, values) {
+				t.Errorf("expected values %v, got %v", test.expected, values)
+			}
+		})
+	}
+}
@@ -75,3 +75,11 @@ var ChangeResourceRecordSets403Response = `<?xml version="1.0"?>
   </Error>
   <RequestId>SOMEREQUESTID</RequestId>
 </ErrorResponse>`
+
+var ChangeResourceRecordSetsInvalidChangeBatchResponse = `<?xml version="1.0"?>
+<InvalidChangeBatch xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+  <Messages>
+    <Message>Tried to delete resource record set [name='_acme-challenge.example.com.', type='TXT', set-identifier='"value-1"'] but it was not found</Message>
+  </Messages>
+  <RequestId>SOMEREQUESTID</RequestId>
+</InvalidChangeBatch>`
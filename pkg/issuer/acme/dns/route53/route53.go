@@ -12,6 +12,8 @@ package route53
 
 import (
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -39,20 +41,57 @@ type DNSProvider struct {
 	dns01Nameservers []string
 	client           *route53.Route53
 	hostedZoneID     string
+	hostedZoneIDs    map[string]string
+	zoneMap          map[string]string
+	ttl              int
 	log              logr.Logger
 
 	userAgent string
 }
 
+// AssumeRole is a role assumed to obtain the credentials used to call
+// Route 53.
+type AssumeRole struct {
+	// ARN is the ARN of the role.
+	ARN string
+	// ExternalID is passed when assuming the role, if set.
+	ExternalID string
+	// SessionTags are passed as session tags when assuming the role.
+	SessionTags map[string]string
+}
+
+func (r AssumeRole) input() *sts.AssumeRoleInput {
+	input := &sts.AssumeRoleInput{
+		RoleArn:         aws.String(r.ARN),
+		RoleSessionName: aws.String("cert-manager"),
+	}
+	if r.ExternalID != "" {
+		input.ExternalId = aws.String(r.ExternalID)
+	}
+	// Sort the tags so that the requests are the same for the same
+	// configuration.
+	keys := make([]string, 0, len(r.SessionTags))
+	for k := range r.SessionTags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		input.Tags = append(input.Tags, &sts.Tag{Key: aws.String(k), Value: aws.String(r.SessionTags[k])})
+	}
+	return input
+}
+
 type sessionProvider struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	Ambient         bool
 	Region          string
-	Role            string
-	StsProvider     func(*session.Session) stsiface.STSAPI
-	log             logr.Logger
-	userAgent       string
+	// Roles are assumed in turn, each using the credentials of the
+	// previously assumed role.
+	Roles       []AssumeRole
+	StsProvider func(*session.Session) stsiface.STSAPI
+	log         logr.Logger
+	userAgent   string
 }
 
 func (d *sessionProvider) GetSession() (*session.Session, error) {
@@ -89,15 +128,12 @@ func (d *sessionProvider) GetSession() (*session.Session, error) {
 		return nil, fmt.Errorf("unable to create aws session: %s", err)
 	}
 
-	if d.Role != "" {
-		d.log.V(logf.DebugLevel).WithValues("role", d.Role).Info("assuming role")
+	for _, role := range d.Roles {
+		d.log.V(logf.DebugLevel).WithValues("role", role.ARN).Info("assuming role")
 		stsSvc := d.StsProvider(sess)
-		result, err := stsSvc.AssumeRole(&sts.AssumeRoleInput{
-			RoleArn:         aws.String(d.Role),
-			RoleSessionName: aws.String("cert-manager"),
-		})
+		result, err := stsSvc.AssumeRole(role.input())
 		if err != nil {
-			return nil, fmt.Errorf("unable to assume role: %s", err)
+			return nil, fmt.Errorf("unable to assume role %q: %s", role.ARN, err)
 		}
 
 		creds := credentials.Value{
@@ -124,13 +160,13 @@ func (d *sessionProvider) GetSession() (*session.Session, error) {
 	return sess, nil
 }
 
-func newSessionProvider(accessKeyID, secretAccessKey, region, role string, ambient bool, userAgent string) (*sessionProvider, error) {
+func newSessionProvider(accessKeyID, secretAccessKey, region string, roles []AssumeRole, ambient bool, userAgent string) (*sessionProvider, error) {
 	return &sessionProvider{
 		AccessKeyID:     accessKeyID,
 		SecretAccessKey: secretAccessKey,
 		Ambient:         ambient,
 		Region:          region,
-		Role:            role,
+		Roles:           roles,
 		StsProvider:     defaultSTSProvider,
 		log:             logf.Log.WithName("route53-session-provider"),
 		userAgent:       userAgent,
@@ -144,12 +180,15 @@ func defaultSTSProvider(sess *session.Session) stsiface.STSAPI {
 // NewDNSProvider returns a DNSProvider instance configured for the AWS
 // Route 53 service using static credentials from its parameters or, if they're
 // unset and the 'ambient' option is set, credentials from the environment.
-func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role string,
+// The given roles are assumed in turn before calling Route 53, each using the
+// credentials of the previously assumed role.
+func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region string,
+	roles []AssumeRole,
 	ambient bool,
 	dns01Nameservers []string,
 	userAgent string,
 ) (*DNSProvider, error) {
-	provider, err := newSessionProvider(accessKeyID, secretAccessKey, region, role, ambient, userAgent)
+	provider, err := newSessionProvider(accessKeyID, secretAccessKey, region, roles, ambient, userAgent)
 	if err != nil {
 		return nil, err
 	}
@@ -164,22 +203,93 @@ func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role str
 	return &DNSProvider{
 		client:           client,
 		hostedZoneID:     hostedZoneID,
+		ttl:              route53TTL,
 		dns01Nameservers: dns01Nameservers,
 		log:              logf.Log.WithName("route53"),
 		userAgent:        userAgent,
 	}, nil
 }
 
+// SetTTL sets the time to live, in seconds, of the TXT records created by
+// the provider.
+func (r *DNSProvider) SetTTL(ttl int) {
+	r.ttl = ttl
+}
+
+// SetZoneMap sets the zones configured for domains, which override the zone
+// detected using SOA records if no hosted zone ID is configured.
+func (r *DNSProvider) SetZoneMap(zoneMap map[string]string) {
+	r.zoneMap = zoneMap
+}
+
+// SetHostedZoneIDs sets the IDs of the hosted zones managing DNS zones, which
+// are used instead of looking up the hosted zone of domains part of the DNS
+// zones.
+func (r *DNSProvider) SetHostedZoneIDs(hostedZoneIDs map[string]string) {
+	r.hostedZoneIDs = make(map[string]string, len(hostedZoneIDs))
+	for zone, id := range hostedZoneIDs {
+		r.hostedZoneIDs[util.ToFqdn(strings.ToLower(zone))] = id
+	}
+}
+
+// SetTransport sets the transport of the HTTP client used to call the Route53
+// API. Requests made to STS to assume a role when the provider is
+// constructed use the default transport.
+func (r *DNSProvider) SetTransport(transport http.RoundTripper) {
+	r.client.Config.HTTPClient = &http.Client{Transport: transport}
+}
+
+// SetEndpoint sets the endpoint of the Route 53 API the provider sends
+// requests to, e.g. to use an emulator such as localstack.
+func (r *DNSProvider) SetEndpoint(endpoint string) {
+	r.client.Endpoint = endpoint
+}
+
 // Present creates a TXT record using the specified parameters
 func (r *DNSProvider) Present(domain, fqdn, value string) error {
 	value = `"` + value + `"`
-	return r.changeRecord(route53.ChangeActionUpsert, fqdn, value, route53TTL)
+	return r.changeRecord(route53.ChangeActionUpsert, fqdn, value, r.ttl)
 }
 
 // CleanUp removes the TXT record matching the specified parameters
 func (r *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	value = `"` + value + `"`
-	return r.changeRecord(route53.ChangeActionDelete, fqdn, value, route53TTL)
+	return r.changeRecord(route53.ChangeActionDelete, fqdn, value, r.ttl)
+}
+
+// PresentRecords creates the given TXT records, using a single change batch
+// for the records of each hosted zone.
+func (r *DNSProvider) PresentRecords(records []util.TXTRecord) error {
+	return r.changeRecords(route53.ChangeActionUpsert, records)
+}
+
+// CleanUpRecords removes the given TXT records, using a single change batch
+// for the records of each hosted zone.
+func (r *DNSProvider) CleanUpRecords(records []util.TXTRecord) error {
+	return r.changeRecords(route53.ChangeActionDelete, records)
+}
+
+func (r *DNSProvider) changeRecords(action string, records []util.TXTRecord) error {
+	var hostedZoneIDs []string
+	recordSets := make(map[string][]*route53.ResourceRecordSet)
+	for _, record := range records {
+		hostedZoneID, err := r.getHostedZoneID(record.FQDN)
+		if err != nil {
+			return fmt.Errorf("failed to determine Route 53 hosted zone ID: %v", err)
+		}
+		if _, ok := recordSets[hostedZoneID]; !ok {
+			hostedZoneIDs = append(hostedZoneIDs, hostedZoneID)
+		}
+		value := `"` + record.Value + `"`
+		recordSets[hostedZoneID] = append(recordSets[hostedZoneID], newTXTRecordSet(record.FQDN, value, r.ttl))
+	}
+
+	for _, hostedZoneID := range hostedZoneIDs {
+		if err := r.changeRecordSets(hostedZoneID, action, recordSets[hostedZoneID]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
@@ -188,17 +298,24 @@ func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 		return fmt.Errorf("failed to determine Route 53 hosted zone ID: %v", err)
 	}
 
-	recordSet := newTXTRecordSet(fqdn, value, ttl)
+	return r.changeRecordSets(hostedZoneID, action, []*route53.ResourceRecordSet{newTXTRecordSet(fqdn, value, ttl)})
+}
+
+// changeRecordSets applies the action to the record sets of the hosted zone
+// using a single change batch, and waits for the change to be in sync.
+func (r *DNSProvider) changeRecordSets(hostedZoneID, action string, recordSets []*route53.ResourceRecordSet) error {
+	changes := make([]*route53.Change, 0, len(recordSets))
+	for _, recordSet := range recordSets {
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(action),
+			ResourceRecordSet: recordSet,
+		})
+	}
 	reqParams := &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneID),
 		ChangeBatch: &route53.ChangeBatch{
 			Comment: aws.String("Managed by cert-manager"),
-			Changes: []*route53.Change{
-				{
-					Action:            &action,
-					ResourceRecordSet: recordSet,
-				},
-			},
+			Changes: changes,
 		},
 	}
 
@@ -206,6 +323,16 @@ func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 	if err != nil {
 		if awserr, ok := err.(awserr.Error); ok {
 			if action == route53.ChangeActionDelete && awserr.Code() == route53.ErrCodeInvalidChangeBatch {
+				if len(recordSets) > 1 {
+					// A single record set which was already deleted fails
+					// the whole batch, so delete them one at a time.
+					for _, recordSet := range recordSets {
+						if err := r.changeRecordSets(hostedZoneID, action, []*route53.ResourceRecordSet{recordSet}); err != nil {
+							return err
+						}
+					}
+					return nil
+				}
 				r.log.V(logf.DebugLevel).WithValues("error", err).Info("ignoring InvalidChangeBatch error")
 				// If we try to delete something and get a 'InvalidChangeBatch' that
 				// means it's already deleted, no need to consider it an error.
@@ -238,7 +365,18 @@ func (r *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		return r.hostedZoneID, nil
 	}
 
-	authZone, err := util.FindZoneByFqdn(fqdn, r.dns01Nameservers)
+	if len(r.hostedZoneIDs) > 0 {
+		zones := make([]string, 0, len(r.hostedZoneIDs))
+		for zone := range r.hostedZoneIDs {
+			zones = append(zones, zone)
+		}
+		if zone, err := util.FindBestMatch(strings.ToLower(fqdn), zones...); err == nil {
+			return strings.TrimPrefix(r.hostedZoneIDs[zone], "/hostedzone/"), nil
+		}
+		r.log.V(logf.DebugLevel).WithValues("fqdn", fqdn).Info("domain is not part of any configured DNS zone, looking up its hosted zone")
+	}
+
+	authZone, err := util.FindZoneByFqdnWithZoneMap(fqdn, r.zoneMap, r.dns01Nameservers)
 	if err != nil {
 		return "", fmt.Errorf("error finding zone from fqdn: %v", err)
 	}
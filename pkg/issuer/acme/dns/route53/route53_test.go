@@ -9,8 +9,10 @@ this directory.
 package route53
 
 import (
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
@@ -70,7 +72,7 @@ func TestAmbientCredentialsFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	provider, err := NewDNSProvider("", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider("", "", "", "", nil, true, util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	_, err = provider.client.Config.Credentials.Get()
@@ -84,7 +86,7 @@ func TestNoCredentialsFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	_, err := NewDNSProvider("", "", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
+	_, err := NewDNSProvider("", "", "", "", nil, false, util.RecursiveNameservers, "cert-manager-test")
 	assert.Error(t, err, "Expected error constructing DNSProvider with no credentials and not ambient")
 }
 
@@ -92,7 +94,7 @@ func TestAmbientRegionFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	provider, err := NewDNSProvider("", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider("", "", "", "", nil, true, util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	assert.Equal(t, "us-east-1", *provider.client.Config.Region, "Expected Region to be set from environment")
@@ -102,7 +104,7 @@ func TestNoRegionFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	provider, err := NewDNSProvider("marx", "swordfish", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider("marx", "swordfish", "", "", nil, false, util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	assert.Equal(t, "", *provider.client.Config.Region, "Expected Region to not be set from environment")
@@ -149,6 +151,127 @@ func TestRoute53Present(t *testing.T) {
 	assert.Equal(t, `failed to change Route 53 record set: AccessDenied: User: arn:aws:iam::0123456789:user/test-cert-manager is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/OPQRSTU`, err.Error())
 }
 
+func TestRoute53PresentHostedZoneIDs(t *testing.T) {
+	// Looking up hosted zones is not mocked, so that it fails if the hosted
+	// zone of a domain is not taken from the configured hosted zone IDs.
+	mockResponses := MockResponseMap{
+		"/2013-04-01/hostedzone/HIJKLMN/rrset/": MockResponse{StatusCode: 200, Body: ChangeResourceRecordSetsResponse},
+		"/2013-04-01/change/123456":             MockResponse{StatusCode: 200, Body: GetChangeResponse},
+		"/2013-04-01/hostedzone/OPQRSTU/rrset/": MockResponse{StatusCode: 403, Body: ChangeResourceRecordSets403Response},
+	}
+
+	ts := newMockServer(t, mockResponses)
+	defer ts.Close()
+
+	provider, err := makeRoute53Provider(ts)
+	require.NoError(t, err, "Expected to make a Route 53 provider without error")
+	provider.SetHostedZoneIDs(map[string]string{
+		"example.com":      "OPQRSTU",
+		"Foo.example.com.": "/hostedzone/HIJKLMN",
+	})
+
+	keyAuth := "123456d=="
+
+	// The hosted zone of the longest matching DNS zone is used.
+	err = provider.Present("foo.example.com", "_acme-challenge.foo.example.com.", keyAuth)
+	assert.NoError(t, err, "Expected Present to return no error")
+
+	err = provider.Present("bar.example.com", "_acme-challenge.bar.example.com.", keyAuth)
+	require.Error(t, err, "Expected Present to return an error")
+	assert.Contains(t, err.Error(), "hostedzone/OPQRSTU")
+}
+
+func TestRoute53PresentSetsTTL(t *testing.T) {
+	var ttls []int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Path == "/2013-04-01/change/123456" {
+			_, _ = w.Write([]byte(GetChangeResponse))
+			return
+		}
+		var input struct {
+			TTLs []int64 `xml:"ChangeBatch>Changes>Change>ResourceRecordSet>TTL"`
+		}
+		require.NoError(t, xml.NewDecoder(r.Body).Decode(&input))
+		ttls = append(ttls, input.TTLs...)
+		_, _ = w.Write([]byte(ChangeResourceRecordSetsResponse))
+	}))
+	defer ts.Close()
+
+	provider, err := makeRoute53Provider(ts)
+	require.NoError(t, err)
+	provider.SetHostedZoneIDs(map[string]string{"example.com": "ABCDEFG"})
+	provider.SetTTL(300)
+
+	require.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "123456d=="))
+	assert.Equal(t, []int64{300}, ttls)
+}
+
+func TestRoute53ChangeRecordsInBatches(t *testing.T) {
+	var requests []string
+	changes := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Path == "/2013-04-01/change/123456" {
+			_, _ = w.Write([]byte(GetChangeResponse))
+			return
+		}
+		var input struct {
+			Changes []struct {
+				Action string `xml:"Action"`
+			} `xml:"ChangeBatch>Changes>Change"`
+		}
+		require.NoError(t, xml.NewDecoder(r.Body).Decode(&input))
+		changes[r.URL.Path] += len(input.Changes)
+		// Deleting several record sets fails if any of them was already
+		// deleted.
+		if input.Changes[0].Action == route53.ChangeActionDelete && len(input.Changes) > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(ChangeResourceRecordSetsInvalidChangeBatchResponse))
+			return
+		}
+		_, _ = w.Write([]byte(ChangeResourceRecordSetsResponse))
+	}))
+	defer ts.Close()
+
+	provider, err := makeRoute53Provider(ts)
+	require.NoError(t, err)
+	provider.SetHostedZoneIDs(map[string]string{
+		"example.com": "ABCDEFG",
+		"example.org": "HIJKLMN",
+	})
+
+	records := []util.TXTRecord{
+		{Domain: "example.com", FQDN: "_acme-challenge.example.com.", Value: "value-1"},
+		{Domain: "*.example.com", FQDN: "_acme-challenge.example.com.", Value: "value-2"},
+		{Domain: "www.example.com", FQDN: "_acme-challenge.www.example.com.", Value: "value-3"},
+		{Domain: "example.org", FQDN: "_acme-challenge.example.org.", Value: "value-4"},
+	}
+
+	require.NoError(t, provider.PresentRecords(records))
+	assert.Equal(t, []string{
+		"/2013-04-01/hostedzone/ABCDEFG/rrset/",
+		"/2013-04-01/change/123456",
+		"/2013-04-01/hostedzone/HIJKLMN/rrset/",
+		"/2013-04-01/change/123456",
+	}, requests)
+	assert.Equal(t, map[string]int{
+		"/2013-04-01/hostedzone/ABCDEFG/rrset/": 3,
+		"/2013-04-01/hostedzone/HIJKLMN/rrset/": 1,
+	}, changes)
+
+	requests = nil
+	require.NoError(t, provider.CleanUpRecords(records[:2]))
+	assert.Equal(t, []string{
+		"/2013-04-01/hostedzone/ABCDEFG/rrset/",
+		"/2013-04-01/hostedzone/ABCDEFG/rrset/",
+		"/2013-04-01/change/123456",
+		"/2013-04-01/hostedzone/ABCDEFG/rrset/",
+		"/2013-04-01/change/123456",
+	}, requests)
+}
+
 func TestAssumeRole(t *testing.T) {
 	creds := &sts.Credentials{
 		AccessKeyId:     aws.String("foo"),
@@ -242,9 +365,13 @@ func TestAssumeRole(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
+			var roles []AssumeRole
+			if c.role != "" {
+				roles = []AssumeRole{{ARN: c.role}}
+			}
 			provider, err := makeMockSessionProvider(func(sess *session.Session) stsiface.STSAPI {
 				return c.mockSTS
-			}, c.key, c.secret, c.region, c.role, c.ambient)
+			}, c.key, c.secret, c.region, roles, c.ambient)
 			assert.NoError(t, err)
 			sess, err := provider.GetSession()
 			if c.expErr {
@@ -260,6 +387,65 @@ func TestAssumeRole(t *testing.T) {
 	}
 }
 
+func TestAssumeRoleChain(t *testing.T) {
+	// Each role is assumed using the credentials of the previous one.
+	var usedKeys []string
+	var inputs []*sts.AssumeRoleInput
+	mock := &mockSTS{
+		AssumeRoleFn: func(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+			inputs = append(inputs, input)
+			n := fmt.Sprint(len(inputs))
+			return &sts.AssumeRoleOutput{
+				Credentials: &sts.Credentials{
+					AccessKeyId:     aws.String("key-" + n),
+					SecretAccessKey: aws.String("secret-" + n),
+					SessionToken:    aws.String("token-" + n),
+				},
+			}, nil
+		},
+	}
+	provider, err := makeMockSessionProvider(func(sess *session.Session) stsiface.STSAPI {
+		creds, err := sess.Config.Credentials.Get()
+		require.NoError(t, err)
+		usedKeys = append(usedKeys, creds.AccessKeyID)
+		return mock
+	}, "key", "secret", "eu-central-1", []AssumeRole{
+		{
+			ARN:         "arn:aws:iam::111111111111:role/intermediate",
+			ExternalID:  "external-id",
+			SessionTags: map[string]string{"team": "platform", "cluster": "prod"},
+		},
+		{
+			ARN: "arn:aws:iam::222222222222:role/dns",
+		},
+	}, false)
+	require.NoError(t, err)
+
+	sess, err := provider.GetSession()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"key", "key-1"}, usedKeys)
+	require.Len(t, inputs, 2)
+	assert.Equal(t, &sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::111111111111:role/intermediate"),
+		RoleSessionName: aws.String("cert-manager"),
+		ExternalId:      aws.String("external-id"),
+		Tags: []*sts.Tag{
+			{Key: aws.String("cluster"), Value: aws.String("prod")},
+			{Key: aws.String("team"), Value: aws.String("platform")},
+		},
+	}, inputs[0])
+	assert.Equal(t, &sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::222222222222:role/dns"),
+		RoleSessionName: aws.String("cert-manager"),
+	}, inputs[1])
+
+	sessCreds, err := sess.Config.Credentials.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", sessCreds.AccessKeyID)
+	assert.Equal(t, "token-2", sessCreds.SessionToken)
+}
+
 type mockSTS struct {
 	*sts.STS
 	AssumeRoleFn func(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error)
@@ -275,13 +461,13 @@ func (m *mockSTS) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput,
 	return nil, nil
 }
 
-func makeMockSessionProvider(defaultSTSProvider func(sess *session.Session) stsiface.STSAPI, accessKeyID, secretAccessKey, region, role string, ambient bool) (*sessionProvider, error) {
+func makeMockSessionProvider(defaultSTSProvider func(sess *session.Session) stsiface.STSAPI, accessKeyID, secretAccessKey, region string, roles []AssumeRole, ambient bool) (*sessionProvider, error) {
 	return &sessionProvider{
 		AccessKeyID:     accessKeyID,
 		SecretAccessKey: secretAccessKey,
 		Ambient:         ambient,
 		Region:          region,
-		Role:            role,
+		Roles:           roles,
 		StsProvider:     defaultSTSProvider,
 		log:             logf.Log.WithName("route53-session"),
 	}, nil
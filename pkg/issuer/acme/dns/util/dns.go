@@ -12,17 +12,24 @@ import (
 	"fmt"
 
 	"github.com/miekg/dns"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // DNS01LookupFQDN returns a DNS name which will be updated to solve the dns-01
-// challenge
+// challenge. The domain is normalized first, so e.g. internationalised domain
+// names are converted to their ASCII form.
 // TODO: move this into the pkg/acme package
 func DNS01LookupFQDN(domain string, followCNAME bool, nameservers ...string) (string, error) {
+	domain, err := pki.NormalizeDNSName(domain)
+	if err != nil {
+		return "", err
+	}
+
 	fqdn := fmt.Sprintf("_acme-challenge.%s.", domain)
 
 	// Check if the domain has CNAME then return that
 	if followCNAME {
-		var err error
 		fqdn, err = followCNAMEs(fqdn, nameservers)
 		if err != nil {
 			return "", err
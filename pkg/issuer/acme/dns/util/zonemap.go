@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+)
+
+// LookupZoneMap returns the zone configured in zoneMap for the given fqdn.
+// The keys of zoneMap are domains which match themselves and their
+// subdomains, and the longest matching key is used. The zone is returned as
+// a fully qualified domain name, as returned by FindZoneByFqdn.
+func LookupZoneMap(fqdn string, zoneMap map[string]string) (string, bool) {
+	name := strings.ToLower(ToFqdn(fqdn))
+
+	var match, zone string
+	for domain, z := range zoneMap {
+		domain = strings.ToLower(ToFqdn(domain))
+		if name != domain && !strings.HasSuffix(name, "."+domain) {
+			continue
+		}
+		if len(domain) > len(match) {
+			match, zone = domain, z
+		}
+	}
+	if match == "" {
+		return "", false
+	}
+
+	return strings.ToLower(ToFqdn(zone)), true
+}
+
+// FindZoneByFqdnWithZoneMap returns the zone configured in zoneMap for the
+// given fqdn, or determines it using FindZoneByFqdn if none is configured.
+func FindZoneByFqdnWithZoneMap(fqdn string, zoneMap map[string]string, nameservers []string) (string, error) {
+	if zone, ok := LookupZoneMap(fqdn, zoneMap); ok {
+		return zone, nil
+	}
+	return FindZoneByFqdn(fqdn, nameservers)
+}
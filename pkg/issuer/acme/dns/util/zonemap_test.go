@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import "testing"
+
+func TestLookupZoneMap(t *testing.T) {
+	zoneMap := map[string]string{
+		"internal.corp.example.com":     "corp.example.com",
+		"dev.internal.corp.example.com": "dev.internal.corp.example.com.",
+		"Other.Example.org":             "Example.org",
+	}
+
+	tests := map[string]struct {
+		fqdn    string
+		zoneMap map[string]string
+		expZone string
+		expOK   bool
+	}{
+		"a domain matches itself": {
+			fqdn:    "internal.corp.example.com.",
+			zoneMap: zoneMap,
+			expZone: "corp.example.com.",
+			expOK:   true,
+		},
+		"a domain matches its subdomains": {
+			fqdn:    "_acme-challenge.www.internal.corp.example.com.",
+			zoneMap: zoneMap,
+			expZone: "corp.example.com.",
+			expOK:   true,
+		},
+		"the longest matching domain is used": {
+			fqdn:    "_acme-challenge.dev.internal.corp.example.com.",
+			zoneMap: zoneMap,
+			expZone: "dev.internal.corp.example.com.",
+			expOK:   true,
+		},
+		"domains are matched case insensitively": {
+			fqdn:    "_acme-challenge.other.example.ORG",
+			zoneMap: zoneMap,
+			expZone: "example.org.",
+			expOK:   true,
+		},
+		"a domain does not match a domain with the same suffix": {
+			fqdn:    "_acme-challenge.notinternal.corp.example.com.",
+			zoneMap: zoneMap,
+		},
+		"no domain matches": {
+			fqdn:    "_acme-challenge.example.com.",
+			zoneMap: zoneMap,
+		},
+		"empty zone map": {
+			fqdn: "_acme-challenge.example.com.",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			zone, ok := LookupZoneMap(test.fqdn, test.zoneMap)
+			if zone != test.expZone || ok != test.expOK {
+				t.Errorf("unexpected result, exp=(%q, %t) got=(%q, %t)", test.expZone, test.expOK, zone, ok)
+			}
+		})
+	}
+}
+
+func TestFindZoneByFqdnWithZoneMap(t *testing.T) {
+	// The zone map is used without querying any nameservers
+	zone, err := FindZoneByFqdnWithZoneMap("_acme-challenge.internal.corp.example.com.",
+		map[string]string{"internal.corp.example.com": "corp.example.com"}, []string{"127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if zone != "corp.example.com." {
+		t.Errorf("unexpected zone, exp=%q got=%q", "corp.example.com.", zone)
+	}
+}
@@ -0,0 +1,517 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/adler32"
+	"path"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// This file implements consolidated solving of HTTP-01 challenges, which is
+// enabled by the HTTP01ConsolidatedSolving feature gate.
+// The HTTP-01 challenges of an Order which use the same Ingress solver form a
+// solver group. All challenges in a solver group are served by a single
+// solver Pod, Service and Ingress, which are owned by the Order. This avoids
+// creating, and mutating an Ingress, for each domain of an Order.
+// The challenges of a group are written to a ConfigMap which is mounted into
+// the solver Pod, so that challenges can join or leave the group without the
+// Pod being replaced.
+
+const (
+	// groupChallengesKey is the key of the group ConfigMap holding the
+	// challenges served by the group, one per line.
+	groupChallengesKey = "challenges"
+	// groupChallengesVolume is the name of the solver Pod volume the group
+	// ConfigMap is mounted as.
+	groupChallengesVolume = "challenges"
+	// groupChallengesMountPath is the directory the group ConfigMap is
+	// mounted at in the solver Pod.
+	groupChallengesMountPath = "/var/run/acmesolver"
+)
+
+// solverGroup is a set of challenges which are served by the same solver.
+type solverGroup struct {
+	// id identifies the Order and solver of the group.
+	id string
+	// owner is the controller reference to the Order owning the challenges.
+	owner metav1.OwnerReference
+	// challenges in the group which still need to be served, sorted by name.
+	challenges []*cmacme.Challenge
+}
+
+// consolidatedOrderRef returns the reference to the Order owning the given
+// challenge if the challenge should be solved as part of a solver group.
+func (s *Solver) consolidatedOrderRef(ch *cmacme.Challenge) *metav1.OwnerReference {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.HTTP01ConsolidatedSolving) || s.challengeLister == nil {
+		return nil
+	}
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.Ingress == nil {
+		return nil
+	}
+	ref := metav1.GetControllerOf(ch)
+	if ref == nil || ref.Kind != cmacme.OrderKind || ref.APIVersion != cmacme.SchemeGroupVersion.String() {
+		return nil
+	}
+	return ref
+}
+
+// solverGroupForChallenge returns the solver group of the given challenge.
+// The group always contains the given challenge, and any other HTTP-01
+// challenges of the same Order and solver which are not yet in a final state.
+func (s *Solver) solverGroupForChallenge(ch *cmacme.Challenge, orderRef *metav1.OwnerReference) (*solverGroup, error) {
+	solverJSON, err := json.Marshal(ch.Spec.Solver)
+	if err != nil {
+		return nil, err
+	}
+
+	chs, err := s.challengeLister.Challenges(ch.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	group := &solverGroup{
+		id:         fmt.Sprintf("%d", adler32.Checksum(append([]byte(orderRef.UID), solverJSON...))),
+		owner:      *orderRef,
+		challenges: []*cmacme.Challenge{ch},
+	}
+	for _, other := range chs {
+		if other.Name == ch.Name ||
+			other.DeletionTimestamp != nil ||
+			other.Spec.Type != cmacme.ACMEChallengeTypeHTTP01 ||
+			acme.IsFinalState(other.Status.State) {
+			continue
+		}
+		if ref := metav1.GetControllerOf(other); ref == nil || ref.UID != orderRef.UID {
+			continue
+		}
+		if !apiequality.Semantic.DeepEqual(other.Spec.Solver, ch.Spec.Solver) {
+			continue
+		}
+		group.challenges = append(group.challenges, other)
+	}
+	sort.Slice(group.challenges, func(i, j int) bool {
+		return group.challenges[i].Name < group.challenges[j].Name
+	})
+
+	return group, nil
+}
+
+func (g *solverGroup) labels() map[string]string {
+	return map[string]string{
+		cmacme.SolverIdentificationLabelKey: "true",
+		cmacme.SolverGroupLabelKey:          g.id,
+	}
+}
+
+// owns returns true if the given object is controlled by the Order of the
+// solver group.
+func (g *solverGroup) owns(obj metav1.Object) bool {
+	ref := metav1.GetControllerOf(obj)
+	return ref != nil && ref.UID == g.owner.UID
+}
+
+// setGroupMeta replaces the per-challenge labels and owner of a resource
+// built for a single challenge with those of the solver group.
+func (g *solverGroup) setGroupMeta(obj metav1.Object) {
+	l := obj.GetLabels()
+	delete(l, cmacme.DomainLabelKey)
+	delete(l, cmacme.TokenLabelKey)
+	for k, v := range g.labels() {
+		l[k] = v
+	}
+	if _, ok := l[cmapi.ControllerUIDLabelKey]; ok {
+		l[cmapi.ControllerUIDLabelKey] = string(g.owner.UID)
+	}
+	obj.SetLabels(l)
+	obj.SetOwnerReferences([]metav1.OwnerReference{g.owner})
+}
+
+// challengesData returns the contents of the group ConfigMap, listing the
+// challenges of the group in the form read by the acmesolver.
+func (g *solverGroup) challengesData() map[string]string {
+	lines := make([]string, 0, len(g.challenges))
+	for _, member := range g.challenges {
+		lines = append(lines, solver.FormatChallenge(solver.Challenge{
+			Domain: member.Spec.DNSName,
+			Token:  member.Spec.Token,
+			Key:    member.Spec.Key,
+		}))
+	}
+	return map[string]string{groupChallengesKey: strings.Join(lines, "\n") + "\n"}
+}
+
+// podMountsConfigMap returns true if the given solver pod mounts the
+// ConfigMap with the given name.
+func podMountsConfigMap(pod *corev1.Pod, name string) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.ConfigMap != nil && v.ConfigMap.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// presentSolverGroup ensures the ConfigMap, Pod, Service and Ingress of the
+// solver group of the given challenge exist and serve the challenge.
+func (s *Solver) presentSolverGroup(ctx context.Context, ch *cmacme.Challenge, orderRef *metav1.OwnerReference) error {
+	g, err := s.solverGroupForChallenge(ch, orderRef)
+	if err != nil {
+		return err
+	}
+	log := logf.FromContext(ctx).WithValues("solver_group", g.id, "solver_group_size", len(g.challenges))
+	ctx = logf.NewContext(ctx, log)
+
+	var podErr error
+	cm, err := s.ensureGroupConfigMap(ctx, ch, g)
+	if err != nil {
+		podErr = err
+	} else {
+		_, podErr = s.ensureGroupPod(ctx, ch, g, cm.Name)
+	}
+	svc, svcErr := s.ensureGroupService(ctx, ch, g)
+	if svcErr != nil {
+		return utilerrors.NewAggregate([]error{podErr, svcErr})
+	}
+	_, ingressErr := s.ensureGroupIngress(ctx, ch, g, svc.Name)
+	return utilerrors.NewAggregate([]error{podErr, ingressErr})
+}
+
+// ensureGroupConfigMap ensures the ConfigMap of the solver group lists all
+// challenges of the group. The kubelet propagates changes to the ConfigMap to
+// the solver Pod, from which the acmesolver reads the challenges it serves.
+func (s *Solver) ensureGroupConfigMap(ctx context.Context, ch *cmacme.Challenge, g *solverGroup) (*corev1.ConfigMap, error) {
+	log := logf.FromContext(ctx).WithName("ensureGroupConfigMap")
+
+	configMaps, err := s.listGroupConfigMaps(g, ch.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(configMaps) > 1 {
+		log.V(logf.InfoLevel).Info("multiple solver group configmaps found. cleaning up all existing configmaps.")
+		if err := s.deleteGroupConfigMaps(ctx, configMaps); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing solver group configmaps found and cleaned up. retrying challenge sync")
+	}
+
+	data := g.challengesData()
+	if len(configMaps) == 1 {
+		cm := configMaps[0]
+		if apiequality.Semantic.DeepEqual(cm.Data, data) {
+			logf.WithRelatedResource(log, cm).V(logf.DebugLevel).Info("found existing HTTP01 solver group configmap")
+			return cm, nil
+		}
+		cm = cm.DeepCopy()
+		cm.Data = data
+		logf.WithRelatedResource(log, cm).V(logf.InfoLevel).Info("updating challenges of HTTP01 solver group configmap")
+		return s.Client.CoreV1().ConfigMaps(cm.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "cm-acme-http-solver-",
+			Namespace:    ch.Namespace,
+			Labels:       g.labels(),
+		},
+		Data: data,
+	}
+	g.setGroupMeta(cm)
+
+	log.V(logf.InfoLevel).Info("creating HTTP01 solver group configmap")
+	return s.Client.CoreV1().ConfigMaps(ch.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+}
+
+// ensureGroupPod ensures a single solver pod mounting the group ConfigMap
+// with the given name exists. The pod is not replaced when challenges join or
+// leave the group.
+func (s *Solver) ensureGroupPod(ctx context.Context, ch *cmacme.Challenge, g *solverGroup, configMapName string) (*corev1.Pod, error) {
+	log := logf.FromContext(ctx).WithName("ensureGroupPod")
+
+	pods, err := s.listGroupPods(g, ch.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(pods) == 1 && podMountsConfigMap(pods[0], configMapName):
+		logf.WithRelatedResource(log, pods[0]).V(logf.DebugLevel).Info("found existing HTTP01 solver group pod")
+		return pods[0], nil
+	case len(pods) > 1:
+		log.V(logf.InfoLevel).Info("multiple solver group pods found. cleaning up all existing pods.")
+		if err := s.deleteGroupPods(ctx, pods); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing solver group pods found and cleaned up. retrying challenge sync")
+	case len(pods) == 1:
+		// The group ConfigMap was recreated, so the pod must be replaced to
+		// mount the new ConfigMap.
+		log.V(logf.InfoLevel).Info("existing solver group pod does not mount the solver group configmap, replacing it")
+		if err := s.deleteGroupPods(ctx, pods); err != nil {
+			return nil, err
+		}
+	}
+
+	log.V(logf.InfoLevel).Info("creating HTTP01 solver group pod")
+	return s.Client.CoreV1().Pods(ch.Namespace).Create(ctx, s.buildGroupPod(ch, g, configMapName), metav1.CreateOptions{})
+}
+
+// buildGroupPod builds a solver pod serving the challenges listed in the
+// group ConfigMap with the given name.
+func (s *Solver) buildGroupPod(ch *cmacme.Challenge, g *solverGroup, configMapName string) *corev1.Pod {
+	pod := s.buildPod(ch)
+	g.setGroupMeta(pod)
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: groupChallengesVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	})
+	container := &pod.Spec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      groupChallengesVolume,
+		MountPath: groupChallengesMountPath,
+		ReadOnly:  true,
+	})
+	container.Args = append(solverServerArgs(ch),
+		"--challenges-file="+path.Join(groupChallengesMountPath, groupChallengesKey))
+
+	return pod
+}
+
+func (s *Solver) ensureGroupService(ctx context.Context, ch *cmacme.Challenge, g *solverGroup) (*corev1.Service, error) {
+	log := logf.FromContext(ctx).WithName("ensureGroupService")
+
+	services, err := s.listGroupServices(g, ch.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(services) == 1 {
+		logf.WithRelatedResource(log, services[0]).V(logf.DebugLevel).Info("found existing HTTP01 solver group service")
+		return services[0], nil
+	}
+	if len(services) > 1 {
+		log.V(logf.InfoLevel).Info("multiple solver group services found. cleaning up all existing services.")
+		if err := s.deleteGroupServices(ctx, services); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing solver group services found and cleaned up. retrying challenge sync")
+	}
+
+	svc, err := buildService(ch)
+	if err != nil {
+		return nil, err
+	}
+	g.setGroupMeta(svc)
+	svc.Spec.Selector = g.labels()
+
+	log.V(logf.InfoLevel).Info("creating HTTP01 solver group service")
+	return s.Client.CoreV1().Services(ch.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+}
+
+func (s *Solver) ensureGroupIngress(ctx context.Context, ch *cmacme.Challenge, g *solverGroup, svcName string) (*networkingv1.Ingress, error) {
+	log := logf.FromContext(ctx).WithName("ensureGroupIngress")
+
+	if name := ch.Spec.Solver.HTTP01.Ingress.Name; name != "" {
+		log.V(logf.DebugLevel).Info("adding solver group paths to existing ingress resource", "ingress", name)
+		return s.addChallengePathsToIngress(ctx, ch.Namespace, name, g.challenges, svcName)
+	}
+
+	ingresses, err := s.listGroupIngresses(g, ch.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(ingresses) > 1 {
+		log.V(logf.InfoLevel).Info("multiple solver group ingresses found. cleaning up all existing ingresses.")
+		if err := s.deleteGroupIngresses(ctx, ingresses); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing solver group ingresses found and cleaned up. retrying challenge sync")
+	}
+
+	if len(ingresses) == 1 {
+		ing := ingresses[0].DeepCopy()
+		if !addGroupPaths(ing, g, svcName) {
+			logf.WithRelatedResource(log, ing).V(logf.DebugLevel).Info("found existing HTTP01 solver group ingress")
+			return ing, nil
+		}
+		logf.WithRelatedResource(log, ing).V(logf.InfoLevel).Info("adding solver group paths to HTTP01 solver group ingress")
+		return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+	}
+
+	ing, err := buildIngressResource(ch, svcName)
+	if err != nil {
+		return nil, err
+	}
+	ing = s.mergeIngressObjectMetaWithIngressResourceTemplate(ing, ch.Spec.Solver.HTTP01.Ingress.IngressTemplate)
+	g.setGroupMeta(ing)
+	ing.Spec.Rules = nil
+	addGroupPaths(ing, g, svcName)
+
+	log.V(logf.InfoLevel).Info("creating HTTP01 solver group ingress")
+	return s.Client.NetworkingV1().Ingresses(ch.Namespace).Create(ctx, ing, metav1.CreateOptions{})
+}
+
+// addGroupPaths adds the paths for all challenges of the group to the given
+// ingress, and returns true if the ingress was changed.
+func addGroupPaths(ing *networkingv1.Ingress, g *solverGroup, svcName string) bool {
+	changed := false
+	for _, member := range g.challenges {
+		if addIngressPath(ing, ingressHost(member), ingressPath(member.Spec.Token, svcName)) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// cleanupSolverGroup deletes the ConfigMap, Pod, Service and Ingress of the
+// solver group
+// of the given challenge, if no other challenge in the group still needs
+// them. Paths added to an existing Ingress are removed by cleanupIngresses.
+func (s *Solver) cleanupSolverGroup(ctx context.Context, ch *cmacme.Challenge, orderRef *metav1.OwnerReference) error {
+	g, err := s.solverGroupForChallenge(ch, orderRef)
+	if err != nil {
+		return err
+	}
+	log := logf.FromContext(ctx, "cleanupSolverGroup").WithValues("solver_group", g.id)
+
+	if len(g.challenges) > 1 {
+		log.V(logf.DebugLevel).Info("not cleaning up solver group resources as they are still used by other challenges", "solver_group_size", len(g.challenges))
+		return nil
+	}
+
+	var errs []error
+	configMaps, err := s.listGroupConfigMaps(g, ch.Namespace)
+	errs = append(errs, err, s.deleteGroupConfigMaps(ctx, configMaps))
+	pods, err := s.listGroupPods(g, ch.Namespace)
+	errs = append(errs, err, s.deleteGroupPods(ctx, pods))
+	services, err := s.listGroupServices(g, ch.Namespace)
+	errs = append(errs, err, s.deleteGroupServices(ctx, services))
+	ingresses, err := s.listGroupIngresses(g, ch.Namespace)
+	errs = append(errs, err, s.deleteGroupIngresses(ctx, ingresses))
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s *Solver) listGroupConfigMaps(g *solverGroup, namespace string) ([]*corev1.ConfigMap, error) {
+	configMaps, err := s.configMapLister.ConfigMaps(namespace).List(labels.SelectorFromSet(g.labels()))
+	if err != nil {
+		return nil, err
+	}
+	var owned []*corev1.ConfigMap
+	for _, cm := range configMaps {
+		if g.owns(cm) {
+			owned = append(owned, cm)
+		}
+	}
+	return owned, nil
+}
+
+func (s *Solver) listGroupPods(g *solverGroup, namespace string) ([]*corev1.Pod, error) {
+	pods, err := s.podLister.Pods(namespace).List(labels.SelectorFromSet(g.labels()))
+	if err != nil {
+		return nil, err
+	}
+	var owned []*corev1.Pod
+	for _, pod := range pods {
+		if g.owns(pod) {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+func (s *Solver) listGroupServices(g *solverGroup, namespace string) ([]*corev1.Service, error) {
+	services, err := s.serviceLister.Services(namespace).List(labels.SelectorFromSet(g.labels()))
+	if err != nil {
+		return nil, err
+	}
+	var owned []*corev1.Service
+	for _, svc := range services {
+		if g.owns(svc) {
+			owned = append(owned, svc)
+		}
+	}
+	return owned, nil
+}
+
+func (s *Solver) listGroupIngresses(g *solverGroup, namespace string) ([]*networkingv1.Ingress, error) {
+	ingresses, err := s.ingressLister.Ingresses(namespace).List(labels.SelectorFromSet(g.labels()))
+	if err != nil {
+		return nil, err
+	}
+	var owned []*networkingv1.Ingress
+	for _, ing := range ingresses {
+		if g.owns(ing) {
+			owned = append(owned, ing)
+		}
+	}
+	return owned, nil
+}
+
+func (s *Solver) deleteGroupConfigMaps(ctx context.Context, configMaps []*corev1.ConfigMap) error {
+	var errs []error
+	for _, cm := range configMaps {
+		errs = append(errs, s.Client.CoreV1().ConfigMaps(cm.Namespace).Delete(ctx, cm.Name, metav1.DeleteOptions{}))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s *Solver) deleteGroupPods(ctx context.Context, pods []*corev1.Pod) error {
+	var errs []error
+	for _, pod := range pods {
+		errs = append(errs, s.Client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s *Solver) deleteGroupServices(ctx context.Context, services []*corev1.Service) error {
+	var errs []error
+	for _, svc := range services {
+		errs = append(errs, s.Client.CoreV1().Services(svc.Namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{}))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s *Solver) deleteGroupIngresses(ctx context.Context, ingresses []*networkingv1.Ingress) error {
+	var errs []error
+	for _, ing := range ingresses {
+		errs = append(errs, s.Client.NetworkingV1().Ingresses(ing.Namespace).Delete(ctx, ing.Name, metav1.DeleteOptions{}))
+	}
+	return utilerrors.NewAggregate(errs)
+}
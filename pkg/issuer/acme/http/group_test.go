@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/test"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+func groupChallenge(name, dnsName, token string, state cmacme.State) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultTestNamespace,
+			UID:       types.UID(name),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         cmacme.SchemeGroupVersion.String(),
+				Kind:               cmacme.OrderKind,
+				Name:               "test-order",
+				UID:                "test-order-uid",
+				Controller:         func(b bool) *bool { return &b }(true),
+				BlockOwnerDeletion: func(b bool) *bool { return &b }(true),
+			}},
+		},
+		Spec: cmacme.ChallengeSpec{
+			Type:    cmacme.ACMEChallengeTypeHTTP01,
+			DNSName: dnsName,
+			Token:   token,
+			Key:     token + "-key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+		Status: cmacme.ChallengeStatus{State: state},
+	}
+}
+
+func TestSolverGroup(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.HTTP01ConsolidatedSolving, true)()
+
+	ctx := context.Background()
+	chA := groupChallenge("a", "a.example.com", "token-a", cmacme.Pending)
+	chB := groupChallenge("b", "b.example.com", "token-b", cmacme.Pending)
+	// challenges of other orders, or which have already been solved, are
+	// not part of the group
+	chOtherOrder := groupChallenge("c", "c.example.com", "token-c", cmacme.Pending)
+	chOtherOrder.OwnerReferences[0].UID = "other-order-uid"
+	chValid := groupChallenge("d", "d.example.com", "token-d", cmacme.Valid)
+
+	b := &test.Builder{
+		T:                  t,
+		CertManagerObjects: []runtime.Object{chA, chB, chOtherOrder, chValid},
+	}
+	s, err := buildFakeSolver(b)
+	require.NoError(t, err)
+	defer b.Stop()
+
+	kube := b.FakeKubeClient()
+
+	// Presenting the first challenge creates a pod, service and ingress
+	// serving both challenges of the group.
+	require.NoError(t, s.Present(ctx, nil, chA))
+	b.Sync()
+
+	pods, err := kube.CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	assert.Equal(t, []string{
+		"--listen-port=8089",
+		"--challenges-file=/var/run/acmesolver/challenges",
+	}, pods.Items[0].Spec.Containers[0].Args)
+	assert.Equal(t, types.UID("test-order-uid"), metav1.GetControllerOf(&pods.Items[0]).UID)
+
+	configMaps, err := kube.CoreV1().ConfigMaps(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, configMaps.Items, 1)
+	assert.Equal(t, "a.example.com,token-a,token-a-key\nb.example.com,token-b,token-b-key\n", configMaps.Items[0].Data[groupChallengesKey])
+	assert.True(t, podMountsConfigMap(&pods.Items[0], configMaps.Items[0].Name))
+
+	services, err := kube.CoreV1().Services(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, services.Items, 1)
+	assert.Equal(t, pods.Items[0].Labels[cmacme.SolverGroupLabelKey], services.Items[0].Spec.Selector[cmacme.SolverGroupLabelKey])
+
+	ingresses, err := kube.NetworkingV1().Ingresses(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ingresses.Items, 1)
+	require.Len(t, ingresses.Items[0].Spec.Rules, 2)
+	for i, ch := range []*cmacme.Challenge{chA, chB} {
+		rule := ingresses.Items[0].Spec.Rules[i]
+		assert.Equal(t, ch.Spec.DNSName, rule.Host)
+		assert.Equal(t, ingressPath(ch.Spec.Token, services.Items[0].Name), rule.HTTP.Paths[0])
+	}
+
+	// Presenting the second challenge re-uses the group's resources without
+	// modifying them.
+	actionsBefore := len(kube.Actions())
+	require.NoError(t, s.Present(ctx, nil, chB))
+	assert.Len(t, kube.Actions(), actionsBefore)
+
+	// Cleaning up a challenge while another challenge in the group still
+	// needs to be solved does not delete the group's resources.
+	chAValid := chA.DeepCopy()
+	chAValid.Status.State = cmacme.Valid
+	_, err = b.FakeCMClient().AcmeV1().Challenges(defaultTestNamespace).Update(ctx, chAValid, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	b.Sync()
+	require.NoError(t, s.CleanUp(ctx, nil, chAValid))
+	b.Sync()
+	pods, err = kube.CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+
+	// Cleaning up the last challenge in the group deletes its resources.
+	require.NoError(t, s.CleanUp(ctx, nil, chB))
+	b.Sync()
+	pods, err = kube.CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items)
+	services, err = kube.CoreV1().Services(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, services.Items)
+	ingresses, err = kube.NetworkingV1().Ingresses(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ingresses.Items)
+	configMaps, err = kube.CoreV1().ConfigMaps(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, configMaps.Items)
+}
+
+func TestSolverGroupKeepsPodForNewChallenge(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.HTTP01ConsolidatedSolving, true)()
+
+	ctx := context.Background()
+	chA := groupChallenge("a", "a.example.com", "token-a", cmacme.Pending)
+	chB := groupChallenge("b", "b.example.com", "token-b", cmacme.Pending)
+
+	b := &test.Builder{
+		T:                  t,
+		CertManagerObjects: []runtime.Object{chA},
+	}
+	s, err := buildFakeSolver(b)
+	require.NoError(t, err)
+	defer b.Stop()
+
+	kube := b.FakeKubeClient()
+
+	require.NoError(t, s.Present(ctx, nil, chA))
+	b.Sync()
+
+	pods, err := kube.CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	podName := pods.Items[0].Name
+
+	// A challenge which was not known when the group's pod was created is
+	// added to the group's configmap, and served by the existing pod.
+	_, err = b.FakeCMClient().AcmeV1().Challenges(defaultTestNamespace).Create(ctx, chB, metav1.CreateOptions{})
+	require.NoError(t, err)
+	b.Sync()
+	require.NoError(t, s.Present(ctx, nil, chB))
+	b.Sync()
+
+	pods, err = kube.CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	assert.Equal(t, podName, pods.Items[0].Name)
+	for _, action := range kube.Actions() {
+		assert.False(t, action.Matches("delete", "pods"), "solver group pod must not be deleted")
+	}
+
+	configMaps, err := kube.CoreV1().ConfigMaps(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, configMaps.Items, 1)
+	assert.Equal(t, "a.example.com,token-a,token-a-key\nb.example.com,token-b,token-b-key\n", configMaps.Items[0].Data[groupChallengesKey])
+
+	ingresses, err := kube.NetworkingV1().Ingresses(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ingresses.Items, 1)
+	assert.Len(t, ingresses.Items[0].Spec.Rules, 2)
+}
@@ -19,12 +19,15 @@ package http
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +39,7 @@ import (
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -47,18 +51,30 @@ const (
 	HTTP01Timeout = time.Minute * 15
 	// acmeSolverListenPort is the port acmesolver should listen on
 	acmeSolverListenPort = 8089
+	// acmeSolverHostPort is the port on the node which is mapped to the
+	// acmesolver by the host port solver
+	acmeSolverHostPort = 80
+	// acmeSolverShutdownTimeout is the time acmesolver allows for in-flight
+	// requests to complete when shutting down, after draining.
+	acmeSolverShutdownTimeout = 5 * time.Second
 
 	loggerName = "http01"
 )
 
 var (
 	challengeGvk = cmacme.SchemeGroupVersion.WithKind("Challenge")
+
+	// errCloudflareProxied is wrapped by errors returned from a failed
+	// reachability test when the response was served by the Cloudflare proxy.
+	errCloudflareProxied = errors.New("response was served by the Cloudflare proxy")
 )
 
 // Solver is an implementation of the acme http-01 challenge solver protocol
 type Solver struct {
 	*controller.Context
 
+	challengeLister cmacmelisters.ChallengeLister
+	configMapLister corev1listers.ConfigMapLister
 	podLister       corev1listers.PodLister
 	serviceLister   corev1listers.ServiceLister
 	ingressLister   networkingv1listers.IngressLister
@@ -68,12 +84,48 @@ type Solver struct {
 	requiredPasses   int
 }
 
-type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error
+// reachabilityTest checks that key is served at url.
+type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string, opts reachabilityOptions) error
+
+// reachabilityOptions customises how a reachability test connects to the
+// challenge URL.
+type reachabilityOptions struct {
+	// dialAddress, if not empty, is the address connections are made to
+	// instead of the host in the URL.
+	dialAddress string
+
+	// proxyURL, if not empty, is the URL of the proxy requests are sent
+	// through instead of the proxy configured in the environment.
+	proxyURL string
+
+	// host, if not empty, overrides the Host header and TLS server name of
+	// the request.
+	host string
+
+	// ipFamily is the IP family connections are made over. If empty, IPv6
+	// addresses are preferred over IPv4 addresses.
+	ipFamily cmacme.HTTP01SelfCheckIPFamily
+}
+
+// reachabilityOptionsForChallenge returns the reachability options
+// configured by the self check of the challenge's HTTP01 solver.
+func reachabilityOptionsForChallenge(ch *cmacme.Challenge) reachabilityOptions {
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.SelfCheck == nil {
+		return reachabilityOptions{}
+	}
+	return reachabilityOptions{
+		proxyURL: ch.Spec.Solver.HTTP01.SelfCheck.ProxyURL,
+		host:     ch.Spec.Solver.HTTP01.SelfCheck.Host,
+		ipFamily: ch.Spec.Solver.HTTP01.SelfCheck.IPFamily,
+	}
+}
 
 // NewSolver returns a new ACME HTTP01 solver for the given *controller.Context.
 func NewSolver(ctx *controller.Context) (*Solver, error) {
 	return &Solver{
 		Context:          ctx,
+		challengeLister:  ctx.SharedInformerFactory.Acme().V1().Challenges().Lister(),
+		configMapLister:  ctx.KubeSharedInformerFactory.Core().V1().ConfigMaps().Lister(),
 		podLister:        ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
 		serviceLister:    ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
 		ingressLister:    ctx.KubeSharedInformerFactory.Networking().V1().Ingresses().Lister(),
@@ -98,7 +150,13 @@ func getServiceType(ch *cmacme.Challenge) (corev1.ServiceType, error) {
 	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.GatewayHTTPRoute != nil {
 		return ch.Spec.Solver.HTTP01.GatewayHTTPRoute.ServiceType, nil
 	}
-	return "", fmt.Errorf("neither HTTP01 Ingress nor Gateway solvers were found")
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.IstioVirtualService != nil {
+		return ch.Spec.Solver.HTTP01.IstioVirtualService.ServiceType, nil
+	}
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.OpenShiftRoute != nil {
+		return ch.Spec.Solver.HTTP01.OpenShiftRoute.ServiceType, nil
+	}
+	return "", fmt.Errorf("neither HTTP01 Ingress, Gateway, Istio VirtualService nor OpenShift Route solvers were found")
 }
 
 // Present will realise the resources required to solve the given HTTP01
@@ -108,12 +166,21 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.FromContext(ctx).WithName(loggerName)
 	ctx = logf.NewContext(ctx, log)
 
+	if orderRef := s.consolidatedOrderRef(ch); orderRef != nil {
+		return s.presentSolverGroup(ctx, ch, orderRef)
+	}
+
 	_, podErr := s.ensurePod(ctx, ch)
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.HostPort != nil {
+		// The solver pod is reached directly on the node, so neither a
+		// Service nor an Ingress is required.
+		return podErr
+	}
 	svc, svcErr := s.ensureService(ctx, ch)
 	if svcErr != nil {
 		return utilerrors.NewAggregate([]error{podErr, svcErr})
 	}
-	var ingressErr, gatewayErr error
+	var ingressErr, gatewayErr, virtualServiceErr, routeErr error
 	if ch.Spec.Solver.HTTP01 != nil {
 		if ch.Spec.Solver.HTTP01.Ingress != nil {
 			_, ingressErr = s.ensureIngress(ctx, ch, svc.Name)
@@ -123,6 +190,14 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 			_, gatewayErr = s.ensureGatewayHTTPRoute(ctx, ch, svc.Name)
 			return utilerrors.NewAggregate([]error{podErr, svcErr, gatewayErr})
 		}
+		if ch.Spec.Solver.HTTP01.IstioVirtualService != nil {
+			_, virtualServiceErr = s.ensureIstioVirtualService(ctx, ch, svc.Name)
+			return utilerrors.NewAggregate([]error{podErr, svcErr, virtualServiceErr})
+		}
+		if ch.Spec.Solver.HTTP01.OpenShiftRoute != nil {
+			_, routeErr = s.ensureOpenShiftRoute(ctx, ch, svc.Name)
+			return utilerrors.NewAggregate([]error{podErr, svcErr, routeErr})
+		}
 	}
 	return utilerrors.NewAggregate(
 		[]error{
@@ -130,7 +205,9 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 			svcErr,
 			ingressErr,
 			gatewayErr,
-			fmt.Errorf("couldn't Present challenge %s/%s: no Ingress nor Gateway HTTP01 solvers were specified", ch.Namespace, ch.Name),
+			virtualServiceErr,
+			routeErr,
+			fmt.Errorf("couldn't Present challenge %s/%s: no Ingress, Gateway, Istio VirtualService, OpenShift Route nor host port HTTP01 solvers were specified", ch.Namespace, ch.Name),
 		},
 	)
 }
@@ -153,6 +230,11 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 		}
 	}
 
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.SelfCheck != nil && ch.Spec.Solver.HTTP01.SelfCheck.Disabled {
+		log.V(logf.InfoLevel).Info("self check is disabled for this solver, skipping")
+		return nil
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, HTTP01Timeout)
 	defer cancel()
 	url := s.buildChallengeUrl(ch)
@@ -161,7 +243,10 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 
 	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
 	for i := 0; i < s.requiredPasses; i++ {
-		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.RESTConfig.UserAgent)
+		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.ExternalUserAgent, reachabilityOptionsForChallenge(ch))
+		if errors.Is(err, errCloudflareProxied) && ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.ProxiedSelfCheck != nil {
+			return s.checkProxied(ctx, ch, url, ch.Spec.Solver.HTTP01.ProxiedSelfCheck)
+		}
 		if err != nil {
 			return err
 		}
@@ -174,6 +259,53 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 	return nil
 }
 
+// checkProxied performs the self check for a DNS name which is proxied by
+// Cloudflare, using the configured strategy.
+func (s *Solver) checkProxied(ctx context.Context, ch *cmacme.Challenge, url *url.URL, cfg *cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck) error {
+	log := logf.FromContext(ctx).WithValues("strategy", cfg.Strategy)
+
+	switch cfg.Strategy {
+	case cmacme.HTTP01ProxiedSelfCheckStrategyTrustCA:
+		log.V(logf.InfoLevel).Info("DNS name is proxied by Cloudflare, skipping self check")
+		return nil
+
+	case cmacme.HTTP01ProxiedSelfCheckStrategyService:
+		if cfg.Service == nil {
+			return fmt.Errorf("no ingress controller service configured for proxied self check")
+		}
+		svc, err := s.serviceLister.Services(cfg.Service.Namespace).Get(cfg.Service.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get ingress controller service %s/%s: %w", cfg.Service.Namespace, cfg.Service.Name, err)
+		}
+		if len(svc.Spec.ClusterIP) == 0 || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+			return fmt.Errorf("ingress controller service %s/%s has no cluster IP", svc.Namespace, svc.Name)
+		}
+		port := cfg.Service.Port
+		if port == 0 {
+			port = 80
+		}
+		addr := net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(int(port)))
+
+		log = log.WithValues("address", addr)
+		ctx = logf.NewContext(ctx, log)
+		log.V(logf.DebugLevel).Info("DNS name is proxied by Cloudflare, running self check against the ingress controller service")
+		for i := 0; i < s.requiredPasses; i++ {
+			opts := reachabilityOptionsForChallenge(ch)
+			opts.dialAddress = addr
+			if err := s.testReachability(ctx, url, ch.Spec.Key, nil, s.Context.ExternalUserAgent, opts); err != nil {
+				return err
+			}
+			log.V(logf.DebugLevel).Info("reachability test passed, re-checking in 2s time")
+			time.Sleep(time.Second * 2)
+		}
+
+		log.V(logf.DebugLevel).Info("self check succeeded")
+		return nil
+	}
+
+	return fmt.Errorf("unknown proxied self check strategy %q", cfg.Strategy)
+}
+
 // CleanUp will ensure the created service, ingress and pod are clean/deleted of any
 // cert-manager created data.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
@@ -181,6 +313,15 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	errs = append(errs, s.cleanupPods(ctx, ch))
 	errs = append(errs, s.cleanupServices(ctx, ch))
 	errs = append(errs, s.cleanupIngresses(ctx, ch))
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.IstioVirtualService != nil {
+		errs = append(errs, s.cleanupIstioVirtualServices(ctx, ch))
+	}
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.OpenShiftRoute != nil {
+		errs = append(errs, s.cleanupOpenShiftRoutes(ctx, ch))
+	}
+	if orderRef := s.consolidatedOrderRef(ch); orderRef != nil {
+		errs = append(errs, s.cleanupSolverGroup(ctx, ch, orderRef))
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -198,9 +339,64 @@ func (s *Solver) buildChallengeUrl(ch *cmacme.Challenge) *url.URL {
 	return url
 }
 
+// dialContextForIPFamily returns a function which resolves both the A and
+// AAAA records of the host being dialled, and connects to its addresses in
+// the order preferred for the given IP family. Like Let's Encrypt, IPv6
+// addresses are tried before IPv4 addresses unless a family is given.
+func dialContextForIPFamily(dialer *net.Dialer, family cmacme.HTTP01SelfCheckIPFamily) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		resolver := dialer.Resolver
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		addrs, err := resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		addrs = orderAddrsForIPFamily(addrs, family)
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no %s addresses found for %q", family, host)
+		}
+
+		var errs []error
+		for _, a := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(a.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			errs = append(errs, err)
+		}
+		return nil, utilerrors.NewAggregate(errs)
+	}
+}
+
+// orderAddrsForIPFamily returns the addresses of the given IP family, or all
+// addresses with IPv6 addresses first if no single family is given.
+func orderAddrsForIPFamily(addrs []net.IPAddr, family cmacme.HTTP01SelfCheckIPFamily) []net.IPAddr {
+	var ipv4, ipv6 []net.IPAddr
+	for _, a := range addrs {
+		if a.IP.To4() != nil {
+			ipv4 = append(ipv4, a)
+		} else {
+			ipv6 = append(ipv6, a)
+		}
+	}
+	switch family {
+	case cmacme.HTTP01SelfCheckIPFamilyIPv4:
+		return ipv4
+	case cmacme.HTTP01SelfCheckIPFamilyIPv6:
+		return ipv6
+	}
+	return append(ipv6, ipv4...)
+}
+
 // testReachability will attempt to connect to the 'domain' with 'path' and
 // check if the returned body equals 'key'
-func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string, opts reachabilityOptions) error {
 	log := logf.FromContext(ctx)
 	log.V(logf.DebugLevel).Info("performing HTTP01 reachability check")
 
@@ -209,6 +405,9 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 		return err
 	}
 	req.Header.Set("User-Agent", userAgent)
+	if len(opts.host) > 0 {
+		req.Host = opts.host
+	}
 
 	// The ACME spec says that a verifier should try on http port 80 first, but to follow any
 	// redirects which may be returned. Let's Encrypt, in practice, follows redirects for HTTP
@@ -255,30 +454,49 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 			// > this challenge is intended to bootstrap valid certificates, it may encounter
 			// > self-signed or expired certificates along the way).
 			InsecureSkipVerify: true,
+			ServerName:         opts.host,
 		},
 	}
 
-	if len(dnsServers) != 0 {
-		transport.DialContext = func(ctx context.Context, network, addr string) (conn net.Conn, err error) {
+	if len(opts.proxyURL) > 0 {
+		proxy, err := parseProxyURL(opts.proxyURL)
+		if err != nil {
+			return err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	switch {
+	case len(opts.dialAddress) != 0:
+		// connect directly to the given address, for example to bypass a
+		// proxy in front of the ingress controller.
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{
+				Timeout: 3 * time.Second,
+			}
+			return d.DialContext(ctx, network, opts.dialAddress)
+		}
+	default:
+		dialer := &net.Dialer{
+			Timeout: 3 * time.Second,
+		}
+		if len(dnsServers) != 0 {
 			// we need to increment a counter to iterate through the dns servers as the dialer will not
 			// return an error if the dns server is not responding.
-			counter := 0
-			dialer := &net.Dialer{
-				Timeout: 3 * time.Second,
-				Resolver: &net.Resolver{
-					PreferGo: true,
-					Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-						d := net.Dialer{
-							Timeout: 3 * time.Second,
-						}
-						s := dnsServers[counter%len(dnsServers)]
-						counter++
-						return d.DialContext(ctx, network, s)
-					},
+			var counter uint32
+			dialer.Resolver = &net.Resolver{
+				PreferGo: true,
+				Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+					d := net.Dialer{
+						Timeout: 3 * time.Second,
+					}
+					s := dnsServers[int(atomic.AddUint32(&counter, 1)-1)%len(dnsServers)]
+					return d.DialContext(ctx, network, s)
 				},
 			}
-			return dialer.DialContext(ctx, network, addr)
 		}
+		transport.DialContext = dialContextForIPFamily(dialer, opts.ipFamily)
 	}
 	client := &http.Client{
 		Transport: transport,
@@ -291,9 +509,18 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 		return fmt.Errorf("failed to perform self check GET request '%s': %v", url, err)
 	}
 
+	// wrapErr marks errors as being caused by the Cloudflare proxy so that an
+	// alternative self check strategy can be used.
+	wrapErr := func(err error) error {
+		if isCloudflareResponse(response) {
+			return fmt.Errorf("%w: %v", errCloudflareProxied, err)
+		}
+		return err
+	}
+
 	if response.StatusCode != http.StatusOK {
 		log.V(logf.DebugLevel).Info("received HTTP status code was not StatusOK (200)", "code", response.StatusCode)
-		return fmt.Errorf("wrong status code '%d', expected '%d'", response.StatusCode, http.StatusOK)
+		return wrapErr(fmt.Errorf("wrong status code '%d', expected '%d'", response.StatusCode, http.StatusOK))
 	}
 
 	defer response.Body.Close()
@@ -312,10 +539,25 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 			keyToPrint = strings.TrimSpace(keyToPrint[:24]) + "... (truncated)"
 		}
 		log.V(logf.DebugLevel).Info("key returned by server did not match expected", "actual", keyToPrint, "expected", key)
-		return fmt.Errorf("did not get expected response when querying endpoint, expected %q but got: %s", key, keyToPrint)
+		return wrapErr(fmt.Errorf("did not get expected response when querying endpoint, expected %q but got: %s", key, keyToPrint))
 	}
 
 	log.V(logf.DebugLevel).Info("reachability test succeeded")
 
 	return nil
 }
+
+// parseProxyURL parses the URL of the proxy the self check is sent through.
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid self check proxy URL %q: %v", proxyURL, err)
+	}
+	return u, nil
+}
+
+// isCloudflareResponse returns true if the response was served by the
+// Cloudflare proxy.
+func isCloudflareResponse(response *http.Response) bool {
+	return len(response.Header.Get("CF-RAY")) > 0 || strings.EqualFold(response.Header.Get("Server"), "cloudflare")
+}
@@ -18,15 +18,23 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strings"
 	"sync/atomic"
 	"testing"
 
 	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
@@ -35,9 +43,9 @@ import (
 // countReachabilityTestCalls is a wrapper function that allows us to count the number
 // of calls to a reachabilityTest.
 func countReachabilityTestCalls(counter *int, t reachabilityTest) reachabilityTest {
-	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string, opts reachabilityOptions) error {
 		*counter++
-		return t(ctx, url, key, dnsServers, userAgent)
+		return t(ctx, url, key, dnsServers, userAgent, opts)
 	}
 }
 
@@ -47,22 +55,53 @@ func TestCheck(t *testing.T) {
 		reachabilityTest reachabilityTest
 		challenge        *cmacme.Challenge
 		expectedErr      bool
+		// expectedPasses overrides the number of expected reachability tests
+		expectedPasses int
 	}
 	tests := []testT{
 		{
 			name: "should pass",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
+			reachabilityTest: func(context.Context, *url.URL, string, []string, string, reachabilityOptions) error {
 				return nil
 			},
 			expectedErr: false,
 		},
 		{
 			name: "should error",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
+			reachabilityTest: func(context.Context, *url.URL, string, []string, string, reachabilityOptions) error {
 				return fmt.Errorf("failed")
 			},
 			expectedErr: true,
 		},
+		{
+			name: "should error if proxied and no proxied self check is configured",
+			reachabilityTest: func(context.Context, *url.URL, string, []string, string, reachabilityOptions) error {
+				return fmt.Errorf("%w: failed", errCloudflareProxied)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "should pass if proxied and the CA is trusted",
+			reachabilityTest: func(_ context.Context, _ *url.URL, _ string, _ []string, _ string, opts reachabilityOptions) error {
+				if len(opts.dialAddress) > 0 {
+					return fmt.Errorf("unexpected check against %s", opts.dialAddress)
+				}
+				return fmt.Errorf("%w: failed", errCloudflareProxied)
+			},
+			challenge: &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							ProxiedSelfCheck: &cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck{
+								Strategy: cmacme.HTTP01ProxiedSelfCheckStrategyTrustCA,
+							},
+						},
+					},
+				},
+			},
+			expectedErr:    false,
+			expectedPasses: 1,
+		},
 	}
 
 	for i := range tests {
@@ -88,6 +127,9 @@ func TestCheck(t *testing.T) {
 				t.Errorf("Expected error from Check, but got none")
 				return
 			}
+			if test.expectedPasses > 0 {
+				requiredCallsForPass = test.expectedPasses
+			}
 			if !test.expectedErr && calls != requiredCallsForPass {
 				t.Errorf("Expected Wait to verify reachability test passes %d times, but only checked %d", requiredCallsForPass, calls)
 				return
@@ -176,7 +218,7 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 
 	for _, tt := range tests {
 		atomic.StoreInt32(&dnsServerCalled, 0)
-		err = testReachability(context.Background(), u, key, tt.dnsServers, "cert-manager-test")
+		err = testReachability(context.Background(), u, key, tt.dnsServers, "cert-manager-test", reachabilityOptions{})
 		switch {
 		case err == nil:
 			t.Errorf("Expected error for testReachability, but got none")
@@ -190,3 +232,283 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 		}
 	}
 }
+
+func TestReachabilityCloudflareProxied(t *testing.T) {
+	const key = "expected-key"
+
+	tests := map[string]struct {
+		headers     map[string]string
+		body        string
+		expProxied  bool
+		expectedErr bool
+	}{
+		"correct key served through the proxy": {
+			headers: map[string]string{"CF-RAY": "abc-LHR"},
+			body:    key,
+		},
+		"wrong response served through the proxy": {
+			headers:     map[string]string{"Server": "cloudflare"},
+			body:        "edge error page",
+			expProxied:  true,
+			expectedErr: true,
+		},
+		"wrong response not served through the proxy": {
+			body:        "some other page",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range test.headers {
+					w.Header().Set(k, v)
+				}
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			u, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = testReachability(context.Background(), u, key, nil, "cert-manager-test", reachabilityOptions{})
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if proxied := errors.Is(err, errCloudflareProxied); proxied != test.expProxied {
+				t.Errorf("expected proxied=%v, got error: %v", test.expProxied, err)
+			}
+		})
+	}
+}
+
+func TestReachabilityDialAddress(t *testing.T) {
+	const key = "expected-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(key))
+	}))
+	defer server.Close()
+
+	u := &url.URL{Scheme: "http", Host: "example.com", Path: "/.well-known/acme-challenge/token"}
+	if err := testReachability(context.Background(), u, key, nil, "cert-manager-test", reachabilityOptions{dialAddress: server.Listener.Addr().String()}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckProxiedService(t *testing.T) {
+	ingressService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress-nginx", Namespace: "ingress"},
+		Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.10"},
+	}
+	headlessService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "headless", Namespace: "ingress"},
+		Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+	}
+
+	tests := map[string]struct {
+		service         *cmacme.ACMEChallengeSolverHTTP01ServiceReference
+		expDialAddress  string
+		expectedErr     bool
+		reachabilityErr error
+	}{
+		"should check against the service port": {
+			service:        &cmacme.ACMEChallengeSolverHTTP01ServiceReference{Name: "ingress-nginx", Namespace: "ingress", Port: 8080},
+			expDialAddress: "10.0.0.10:8080",
+		},
+		"should default to port 80": {
+			service:        &cmacme.ACMEChallengeSolverHTTP01ServiceReference{Name: "ingress-nginx", Namespace: "ingress"},
+			expDialAddress: "10.0.0.10:80",
+		},
+		"should error if the check against the service fails": {
+			service:         &cmacme.ACMEChallengeSolverHTTP01ServiceReference{Name: "ingress-nginx", Namespace: "ingress"},
+			expDialAddress:  "10.0.0.10:80",
+			reachabilityErr: fmt.Errorf("failed"),
+			expectedErr:     true,
+		},
+		"should error if no service is configured": {
+			expectedErr: true,
+		},
+		"should error if the service does not exist": {
+			service:     &cmacme.ACMEChallengeSolverHTTP01ServiceReference{Name: "missing", Namespace: "ingress"},
+			expectedErr: true,
+		},
+		"should error if the service has no cluster IP": {
+			service:     &cmacme.ACMEChallengeSolverHTTP01ServiceReference{Name: "headless", Namespace: "ingress"},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, svc := range []*corev1.Service{ingressService, headlessService} {
+				if err := indexer.Add(svc); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var dialAddresses []string
+			s := Solver{
+				Context:       &controller.Context{RESTConfig: new(rest.Config)},
+				serviceLister: corev1listers.NewServiceLister(indexer),
+				testReachability: func(_ context.Context, _ *url.URL, _ string, _ []string, _ string, opts reachabilityOptions) error {
+					dialAddresses = append(dialAddresses, opts.dialAddress)
+					return test.reachabilityErr
+				},
+				requiredPasses: 1,
+			}
+
+			cfg := &cmacme.ACMEChallengeSolverHTTP01ProxiedSelfCheck{
+				Strategy: cmacme.HTTP01ProxiedSelfCheckStrategyService,
+				Service:  test.service,
+			}
+			u := &url.URL{Scheme: "http", Host: "example.com", Path: "/.well-known/acme-challenge/token"}
+			err := s.checkProxied(context.Background(), &cmacme.Challenge{}, u, cfg)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var expDialAddresses []string
+			if len(test.expDialAddress) > 0 {
+				expDialAddresses = []string{test.expDialAddress}
+			}
+			if !reflect.DeepEqual(dialAddresses, expDialAddresses) {
+				t.Errorf("expected self checks against %v, got %v", expDialAddresses, dialAddresses)
+			}
+		})
+	}
+}
+
+func TestCheckSelfCheckDisabled(t *testing.T) {
+	calls := 0
+	s := Solver{
+		Context: &controller.Context{RESTConfig: new(rest.Config)},
+		testReachability: countReachabilityTestCalls(&calls, func(context.Context, *url.URL, string, []string, string, reachabilityOptions) error {
+			return fmt.Errorf("failed")
+		}),
+		requiredPasses: 2,
+	}
+
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					SelfCheck: &cmacme.ACMEChallengeSolverHTTP01SelfCheck{Disabled: true},
+				},
+			},
+		},
+	}
+	if err := s.Check(context.Background(), nil, ch); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no reachability tests, got %d", calls)
+	}
+}
+
+func TestReachabilityProxy(t *testing.T) {
+	const key = "expected-key"
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(key))
+	}))
+	defer proxy.Close()
+
+	u := &url.URL{Scheme: "http", Host: "example.com", Path: "/.well-known/acme-challenge/token"}
+	if err := testReachability(context.Background(), u, key, nil, "cert-manager-test", reachabilityOptions{proxyURL: proxy.URL}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReachabilityHost(t *testing.T) {
+	const key = "expected-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "ingress.example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(key))
+	}))
+	defer server.Close()
+
+	u := &url.URL{Scheme: "http", Host: "example.com", Path: "/.well-known/acme-challenge/token"}
+	opts := reachabilityOptions{dialAddress: server.Listener.Addr().String(), host: "ingress.example.com"}
+	if err := testReachability(context.Background(), u, key, nil, "cert-manager-test", opts); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	opts.host = ""
+	if err := testReachability(context.Background(), u, key, nil, "cert-manager-test", opts); err == nil {
+		t.Errorf("expected an error without the Host header override")
+	}
+}
+
+func TestOrderAddrsForIPFamily(t *testing.T) {
+	ipv4 := net.IPAddr{IP: net.ParseIP("192.0.2.1")}
+	ipv6 := net.IPAddr{IP: net.ParseIP("2001:db8::1")}
+	addrs := []net.IPAddr{ipv4, ipv6}
+
+	tests := map[string]struct {
+		family   cmacme.HTTP01SelfCheckIPFamily
+		expected []net.IPAddr
+	}{
+		"unset prefers IPv6": {
+			expected: []net.IPAddr{ipv6, ipv4},
+		},
+		"DualStack prefers IPv6": {
+			family:   cmacme.HTTP01SelfCheckIPFamilyDualStack,
+			expected: []net.IPAddr{ipv6, ipv4},
+		},
+		"IPv4 only returns IPv4 addresses": {
+			family:   cmacme.HTTP01SelfCheckIPFamilyIPv4,
+			expected: []net.IPAddr{ipv4},
+		},
+		"IPv6 only returns IPv6 addresses": {
+			family:   cmacme.HTTP01SelfCheckIPFamilyIPv6,
+			expected: []net.IPAddr{ipv6},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := orderAddrsForIPFamily(addrs, test.family)
+			if fmt.Sprint(got) != fmt.Sprint(test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestReachabilityIPFamily(t *testing.T) {
+	const key = "expected-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(key))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL + "/.well-known/acme-challenge/token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testReachability(context.Background(), u, key, nil, "cert-manager-test", reachabilityOptions{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := testReachability(context.Background(), u, key, nil, "cert-manager-test", reachabilityOptions{ipFamily: cmacme.HTTP01SelfCheckIPFamilyIPv4}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := testReachability(context.Background(), u, key, nil, "cert-manager-test", reachabilityOptions{ipFamily: cmacme.HTTP01SelfCheckIPFamilyIPv6}); err == nil {
+		t.Errorf("expected an error connecting to an IPv4 address over IPv6")
+	}
+}
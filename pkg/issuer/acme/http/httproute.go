@@ -95,6 +95,7 @@ func (s *Solver) createGatewayHTTPRoute(ctx context.Context, ch *cmacme.Challeng
 			labels[k] = v
 		}
 	}
+	labels = withOwnerLabels(labels, ch)
 	httpRoute := &gwapi.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName:    "cm-acme-http-solver",
@@ -121,6 +122,7 @@ func (s *Solver) checkAndUpdateGatewayHTTPRoute(ctx context.Context, ch *cmacme.
 			expectedLabels[k] = v
 		}
 	}
+	expectedLabels = withOwnerLabels(expectedLabels, ch)
 	actualLabels := ch.Labels
 	if reflect.DeepEqual(expectedSpec, actualSpec) && reflect.DeepEqual(expectedLabels, actualLabels) {
 		return httpRoute, nil
@@ -148,13 +150,17 @@ func (s *Solver) checkAndUpdateGatewayHTTPRoute(ctx context.Context, ch *cmacme.
 }
 
 func generateHTTPRouteSpec(ch *cmacme.Challenge, svcName string) gwapi.HTTPRouteSpec {
+	// IP addresses cannot be used as hostnames, so the route for a challenge
+	// for an IP address identifier matches requests for any hostname.
+	var hostnames []gwapi.Hostname
+	if host := ingressHost(ch); host != "" {
+		hostnames = []gwapi.Hostname{gwapi.Hostname(host)}
+	}
 	return gwapi.HTTPRouteSpec{
 		CommonRouteSpec: gwapi.CommonRouteSpec{
 			ParentRefs: ch.Spec.Solver.HTTP01.GatewayHTTPRoute.ParentRefs,
 		},
-		Hostnames: []gwapi.Hostname{
-			gwapi.Hostname(ch.Spec.DNSName),
-		},
+		Hostnames: hostnames,
 		Rules: []gwapi.HTTPRouteRule{
 			{
 				Matches: []gwapi.HTTPRouteMatch{
@@ -137,6 +137,7 @@ func (s *Solver) createIngress(ctx context.Context, ch *cmacme.Challenge, svcNam
 		ch.Spec.Solver.HTTP01.Ingress != nil {
 		ing = s.mergeIngressObjectMetaWithIngressResourceTemplate(ing, ch.Spec.Solver.HTTP01.Ingress.IngressTemplate)
 	}
+	ing.Labels = withOwnerLabels(ing.Labels, ch)
 
 	return s.Client.NetworkingV1().Ingresses(ch.Namespace).Create(ctx, ing, metav1.CreateOptions{})
 }
@@ -165,11 +166,7 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*networkingv1.I
 
 	ingPathToAdd := ingressPath(ch.Spec.Token, svcName)
 
-	httpHost := ch.Spec.DNSName
-	// if we need to verify ownership of an IP the challenge should propagate on all hosts
-	if net.ParseIP(httpHost) != nil {
-		httpHost = ""
-	}
+	httpHost := ingressHost(ch)
 	return &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName:    "cm-acme-http-solver-",
@@ -232,48 +229,83 @@ func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Chall
 	if err != nil {
 		return nil, err
 	}
-	ingressName := httpDomainCfg.Name
 
-	ing, err := s.ingressLister.Ingresses(ch.Namespace).Get(ingressName)
+	return s.addChallengePathsToIngress(ctx, ch.Namespace, httpDomainCfg.Name, []*cmacme.Challenge{ch}, svcName)
+}
+
+// addChallengePathsToIngress adds the paths for the given challenges to the
+// existing ingress with the given name, updating it at most once.
+func (s *Solver) addChallengePathsToIngress(ctx context.Context, namespace, ingressName string, chs []*cmacme.Challenge, svcName string) (*networkingv1.Ingress, error) {
+	ing, err := s.ingressLister.Ingresses(namespace).Get(ingressName)
 	if err != nil {
 		return nil, err
 	}
+	ing = ing.DeepCopy()
 
-	ingPathToAdd := ingressPath(ch.Spec.Token, svcName)
+	changed := false
+	for _, ch := range chs {
+		if addIngressPath(ing, ingressHost(ch), ingressPath(ch.Spec.Token, svcName)) {
+			changed = true
+		}
+	}
+	if !changed {
+		// ingress resource is already up to date
+		return ing, nil
+	}
+
+	return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+}
+
+// addIngressPath adds the given path to the rule for the given host on the
+// ingress, creating the rule if it does not exist. It returns false if the
+// ingress already contained the path.
+// ingressHost returns the host of the ingress rule used to solve the given
+// challenge. IP addresses cannot be used as the host of an ingress rule, so
+// challenges for IP address identifiers are solved on all hosts.
+func ingressHost(ch *cmacme.Challenge) string {
+	if ch.Spec.IdentifierType == cmacme.ACMEIdentifierTypeIP || net.ParseIP(ch.Spec.DNSName) != nil {
+		return ""
+	}
+	return ch.Spec.DNSName
+}
+
+func addIngressPath(ing *networkingv1.Ingress, host string, ingPathToAdd networkingv1.HTTPIngressPath) bool {
 	// check for an existing Rule for the given domain on the ingress resource
-	for _, rule := range ing.Spec.Rules {
-		if rule.Host == ch.Spec.DNSName {
-			if rule.HTTP == nil {
-				rule.HTTP = &networkingv1.HTTPIngressRuleValue{}
-			}
-			for i, p := range rule.HTTP.Paths {
-				// if an existing path exists on this rule for the challenge path,
-				// we overwrite it else we'll confuse ingress controllers
-				if p.Path == ingPathToAdd.Path {
-					// ingress resource is already up to date
-					if p.Backend.Service.Name == ingPathToAdd.Backend.Service.Name &&
-						p.Backend.Service.Port == ingPathToAdd.Backend.Service.Port {
-						return ing, nil
-					}
-					rule.HTTP.Paths[i] = ingPathToAdd
-					return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+	for i := range ing.Spec.Rules {
+		rule := &ing.Spec.Rules[i]
+		if rule.Host != host {
+			continue
+		}
+		if rule.HTTP == nil {
+			rule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+		}
+		for j, p := range rule.HTTP.Paths {
+			// if an existing path exists on this rule for the challenge path,
+			// we overwrite it else we'll confuse ingress controllers
+			if p.Path == ingPathToAdd.Path {
+				if p.Backend.Service != nil &&
+					p.Backend.Service.Name == ingPathToAdd.Backend.Service.Name &&
+					p.Backend.Service.Port == ingPathToAdd.Backend.Service.Port {
+					return false
 				}
+				rule.HTTP.Paths[j] = ingPathToAdd
+				return true
 			}
-			rule.HTTP.Paths = append([]networkingv1.HTTPIngressPath{ingPathToAdd}, rule.HTTP.Paths...)
-			return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
 		}
+		rule.HTTP.Paths = append([]networkingv1.HTTPIngressPath{ingPathToAdd}, rule.HTTP.Paths...)
+		return true
 	}
 
 	// if one doesn't exist, create a new IngressRule
 	ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
-		Host: ch.Spec.DNSName,
+		Host: host,
 		IngressRuleValue: networkingv1.IngressRuleValue{
 			HTTP: &networkingv1.HTTPIngressRuleValue{
 				Paths: []networkingv1.HTTPIngressPath{ingPathToAdd},
 			},
 		},
 	})
-	return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+	return true
 }
 
 // cleanupIngresses will remove the rules added by cert-manager to an existing
@@ -331,7 +363,7 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 	var ingRules []networkingv1.IngressRule
 	for _, rule := range ing.Spec.Rules {
 		// always retain rules that are not for the same DNSName
-		if rule.Host != ch.Spec.DNSName {
+		if rule.Host != ingressHost(ch) {
 			ingRules = append(ingRules, rule)
 			continue
 		}
@@ -298,6 +298,86 @@ func TestCleanupIngresses(t *testing.T) {
 				}
 			},
 		},
+		"should clean up an ingress with a single challenge path inserted for an IP address": {
+			Builder: &test.Builder{
+				KubeObjects: []runtime.Object{
+					&networkingv1.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "testingress",
+							Namespace: defaultTestNamespace,
+						},
+						Spec: networkingv1.IngressSpec{
+							DefaultBackend: &networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "",
+									Port: networkingv1.ServiceBackendPort{
+										Number: 8080,
+									},
+								},
+							},
+							Rules: []networkingv1.IngressRule{
+								{
+									Host: "",
+									IngressRuleValue: networkingv1.IngressRuleValue{
+										HTTP: &networkingv1.HTTPIngressRuleValue{
+											Paths: []networkingv1.HTTPIngressPath{
+												{
+													Path: "/.well-known/acme-challenge/abcd",
+													Backend: networkingv1.IngressBackend{
+														Service: &networkingv1.IngressServiceBackend{
+															Name: "solversvc",
+															Port: networkingv1.ServiceBackendPort{
+																Number: 8081,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Challenge: &cmacme.Challenge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testchal",
+					Namespace: defaultTestNamespace,
+				},
+				Spec: cmacme.ChallengeSpec{
+					DNSName:        "10.0.0.1",
+					IdentifierType: cmacme.ACMEIdentifierTypeIP,
+					Token:          "abcd",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+								Name: "testingress",
+							},
+						},
+					},
+				},
+			},
+			PreFn: func(t *testing.T, s *solverFixture) {
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				expectedIng := s.KubeObjects[0].(*networkingv1.Ingress).DeepCopy()
+				expectedIng.Spec.Rules = nil
+
+				actualIng, err := s.Builder.FakeKubeClient().NetworkingV1().Ingresses(s.Challenge.Namespace).Get(context.TODO(), expectedIng.Name, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) {
+					t.Errorf("expected ingress resource %q to not be deleted, but it was deleted", expectedIng.Name)
+				}
+				if err != nil {
+					t.Errorf("error getting ingress resource: %v", err)
+				}
+
+				if !reflect.DeepEqual(expectedIng, actualIng) {
+					t.Errorf("expected did not match actual: %v", diff.ObjectDiff(expectedIng, actualIng))
+				}
+			},
+		},
 		"should clean up an ingress with a single challenge path inserted without removing second HTTP rule": {
 			Builder: &test.Builder{
 				KubeObjects: []runtime.Object{
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// Istio's types are not imported, so VirtualServices are managed as
+// unstructured resources using the dynamic client.
+var (
+	virtualServiceGVR = schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1beta1", Resource: "virtualservices"}
+	virtualServiceGVK = virtualServiceGVR.GroupVersion().WithKind("VirtualService")
+)
+
+// ensureIstioVirtualService ensures that the VirtualService needed to solve a challenge exists.
+func (s *Solver) ensureIstioVirtualService(ctx context.Context, ch *cmacme.Challenge, svcName string) (*unstructured.Unstructured, error) {
+	if ch == nil {
+		return nil, fmt.Errorf("ensureIstioVirtualService received nil *acme.Challenge")
+	}
+	log := logf.FromContext(ctx).WithName("ensureIstioVirtualService")
+
+	virtualService, err := s.getIstioVirtualService(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	if virtualService == nil {
+		log.Info("creating VirtualService for challenge", "name", ch.Name, "namespace", ch.Namespace)
+		return s.createIstioVirtualService(ctx, ch, svcName)
+	}
+
+	log.Info("Found existing VirtualService for challenge", "name", ch.Name, "namespace", ch.Namespace)
+
+	return s.checkAndUpdateIstioVirtualService(ctx, ch, svcName, virtualService)
+}
+
+func (s *Solver) getIstioVirtualService(ctx context.Context, ch *cmacme.Challenge) (*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx).WithName("getIstioVirtualService")
+	virtualServices, err := s.DynamicClient.Resource(virtualServiceGVR).Namespace(ch.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(podLabels(ch)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(virtualServices.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &virtualServices.Items[0], nil
+	default:
+		// It should not be possible for multiple VirtualServices for this challenge to exist
+		// If we find this, try to delete them.
+		for _, virtualService := range virtualServices.Items[1:] {
+			log.Info("Deleting extra VirtualService", "name", virtualService.GetName(), "namespace", virtualService.GetNamespace())
+			err := s.DynamicClient.Resource(virtualServiceGVR).Namespace(virtualService.GetNamespace()).Delete(ctx, virtualService.GetName(), metav1.DeleteOptions{})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, fmt.Errorf("multiple VirtualServices found")
+	}
+}
+
+func (s *Solver) createIstioVirtualService(ctx context.Context, ch *cmacme.Challenge, svcName string) (*unstructured.Unstructured, error) {
+	virtualService := &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(virtualServiceGVK)
+	virtualService.SetGenerateName("cm-acme-http-solver-")
+	virtualService.SetNamespace(ch.Namespace)
+	virtualService.SetLabels(istioVirtualServiceLabels(ch))
+	virtualService.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)})
+	if err := unstructured.SetNestedField(virtualService.Object, generateVirtualServiceSpec(ch, svcName), "spec"); err != nil {
+		return nil, err
+	}
+	return s.DynamicClient.Resource(virtualServiceGVR).Namespace(ch.Namespace).Create(ctx, virtualService, metav1.CreateOptions{})
+}
+
+func (s *Solver) checkAndUpdateIstioVirtualService(ctx context.Context, ch *cmacme.Challenge, svcName string, virtualService *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx, "checkAndUpdateIstioVirtualService")
+	expectedSpec := generateVirtualServiceSpec(ch, svcName)
+	actualSpec, _, _ := unstructured.NestedMap(virtualService.Object, "spec")
+	expectedLabels := istioVirtualServiceLabels(ch)
+	if reflect.DeepEqual(expectedSpec, actualSpec) && reflect.DeepEqual(expectedLabels, virtualService.GetLabels()) {
+		return virtualService, nil
+	}
+	log.Info("VirtualService is out of date, updating", "name", virtualService.GetName(), "namespace", virtualService.GetNamespace())
+	var ret *unstructured.Unstructured
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		oldVirtualService, err := s.DynamicClient.Resource(virtualServiceGVR).Namespace(virtualService.GetNamespace()).Get(ctx, virtualService.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		newVirtualService := oldVirtualService.DeepCopy()
+		if err := unstructured.SetNestedField(newVirtualService.Object, expectedSpec, "spec"); err != nil {
+			return err
+		}
+		newVirtualService.SetLabels(expectedLabels)
+		ret, err = s.DynamicClient.Resource(virtualServiceGVR).Namespace(newVirtualService.GetNamespace()).Update(ctx, newVirtualService, metav1.UpdateOptions{})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func istioVirtualServiceLabels(ch *cmacme.Challenge) map[string]string {
+	labels := podLabels(ch)
+	for k, v := range ch.Spec.Solver.HTTP01.IstioVirtualService.Labels {
+		labels[k] = v
+	}
+	return withOwnerLabels(labels, ch)
+}
+
+// generateVirtualServiceSpec returns the spec of a VirtualService which
+// routes requests for the challenge's token to the solver Service. It only
+// contains the types produced by JSON decoding, so that it can be compared
+// with the spec of an existing VirtualService.
+func generateVirtualServiceSpec(ch *cmacme.Challenge, svcName string) map[string]interface{} {
+	// IP addresses cannot be used as hosts, so the VirtualService for a
+	// challenge for an IP address identifier matches requests for any host.
+	host := ingressHost(ch)
+	if host == "" {
+		host = "*"
+	}
+	var gateways []interface{}
+	for _, gateway := range ch.Spec.Solver.HTTP01.IstioVirtualService.Gateways {
+		gateways = append(gateways, gateway)
+	}
+	return map[string]interface{}{
+		"hosts":    []interface{}{host},
+		"gateways": gateways,
+		"http": []interface{}{
+			map[string]interface{}{
+				"match": []interface{}{
+					map[string]interface{}{
+						"uri": map[string]interface{}{
+							"exact": fmt.Sprintf("/.well-known/acme-challenge/%s", ch.Spec.Token),
+						},
+					},
+				},
+				"route": []interface{}{
+					map[string]interface{}{
+						"destination": map[string]interface{}{
+							"host": svcName,
+							"port": map[string]interface{}{
+								"number": int64(acmeSolverListenPort),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cleanupIstioVirtualServices deletes the VirtualServices created for the challenge.
+func (s *Solver) cleanupIstioVirtualServices(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupIstioVirtualServices")
+	virtualServices, err := s.DynamicClient.Resource(virtualServiceGVR).Namespace(ch.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(podLabels(ch)).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, virtualService := range virtualServices.Items {
+		log.V(logf.DebugLevel).Info("deleting VirtualService", "name", virtualService.GetName(), "namespace", virtualService.GetNamespace())
+		err := s.DynamicClient.Resource(virtualServiceGVR).Namespace(virtualService.GetNamespace()).Delete(ctx, virtualService.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
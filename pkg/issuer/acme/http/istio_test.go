@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func istioChallenge(dnsName string) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: dnsName,
+			Token:   "token",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					IstioVirtualService: &cmacme.ACMEChallengeSolverHTTP01IstioVirtualService{
+						Gateways: []string{"istio-system/public"},
+						Labels:   map[string]string{"foo": "bar"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEnsureIstioVirtualService(t *testing.T) {
+	tests := map[string]solverFixture{
+		"should create a VirtualService for the challenge": {
+			Challenge: istioChallenge("example.com"),
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				virtualService := args[0].(*unstructured.Unstructured)
+				if virtualService.GetLabels()["foo"] != "bar" {
+					t.Errorf("expected the custom labels to be set, got %v", virtualService.GetLabels())
+				}
+				hosts, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts")
+				if len(hosts) != 1 || hosts[0] != "example.com" {
+					t.Errorf("expected hosts to be [example.com], got %v", hosts)
+				}
+				gateways, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "gateways")
+				if len(gateways) != 1 || gateways[0] != "istio-system/public" {
+					t.Errorf("expected gateways to be [istio-system/public], got %v", gateways)
+				}
+				route, _, _ := unstructured.NestedSlice(virtualService.Object, "spec", "http")
+				expectedRoute := generateVirtualServiceSpec(s.Challenge, "fakeservice")["http"]
+				if !reflect.DeepEqual(expectedRoute, route) {
+					t.Errorf("expected http routes %v, got %v", expectedRoute, route)
+				}
+			},
+		},
+		"should match any host for an IP address": {
+			Challenge: istioChallenge("10.0.0.1"),
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				virtualService := args[0].(*unstructured.Unstructured)
+				hosts, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts")
+				if len(hosts) != 1 || hosts[0] != "*" {
+					t.Errorf("expected hosts to be [*], got %v", hosts)
+				}
+			},
+		},
+		"should update an existing VirtualService which is out of date": {
+			Challenge: istioChallenge("example.com"),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				ch := s.Challenge.DeepCopy()
+				ch.Spec.Solver.HTTP01.IstioVirtualService.Gateways = []string{"old"}
+				if _, err := s.Solver.createIstioVirtualService(context.TODO(), ch, "fakeservice"); err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				virtualService := args[0].(*unstructured.Unstructured)
+				gateways, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "gateways")
+				if len(gateways) != 1 || gateways[0] != "istio-system/public" {
+					t.Errorf("expected gateways to be updated to [istio-system/public], got %v", gateways)
+				}
+				list, err := s.DynamicClient.Resource(virtualServiceGVR).Namespace(defaultTestNamespace).List(context.TODO(), metav1.ListOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(list.Items) != 1 {
+					t.Errorf("expected one VirtualService to exist, got %d", len(list.Items))
+				}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Setup(t)
+			resp, err := test.Solver.ensureIstioVirtualService(context.TODO(), test.Challenge, "fakeservice")
+			if err != nil && !test.Err {
+				t.Errorf("Expected function to not error, but got: %v", err)
+			}
+			if err == nil && test.Err {
+				t.Errorf("Expected function to get an error, but got: %v", err)
+			}
+			test.Finish(t, resp)
+		})
+	}
+}
+
+func TestCleanupIstioVirtualServices(t *testing.T) {
+	s := solverFixture{
+		Challenge: istioChallenge("example.com"),
+		PreFn: func(t *testing.T, s *solverFixture) {
+			if _, err := s.Solver.createIstioVirtualService(context.TODO(), s.Challenge, "fakeservice"); err != nil {
+				t.Errorf("error preparing test: %v", err)
+			}
+		},
+		CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+			list, err := s.DynamicClient.Resource(virtualServiceGVR).Namespace(defaultTestNamespace).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Items) != 0 {
+				t.Errorf("expected all VirtualServices to be deleted, got %d", len(list.Items))
+			}
+		},
+	}
+	s.Setup(t)
+	if err := s.Solver.cleanupIstioVirtualServices(context.TODO(), s.Challenge); err != nil {
+		t.Errorf("Expected function to not error, but got: %v", err)
+	}
+	s.Finish(t)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// OpenShift's types are not imported, so Routes are managed as unstructured
+// resources using the dynamic client.
+var (
+	routeGVR = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+	routeGVK = routeGVR.GroupVersion().WithKind("Route")
+)
+
+// ensureOpenShiftRoute ensures that the Route needed to solve a challenge exists.
+func (s *Solver) ensureOpenShiftRoute(ctx context.Context, ch *cmacme.Challenge, svcName string) (*unstructured.Unstructured, error) {
+	if ch == nil {
+		return nil, fmt.Errorf("ensureOpenShiftRoute received nil *acme.Challenge")
+	}
+	if ingressHost(ch) == "" {
+		return nil, fmt.Errorf("OpenShift Routes cannot be used to solve challenges for IP addresses")
+	}
+	log := logf.FromContext(ctx).WithName("ensureOpenShiftRoute")
+
+	route, err := s.getOpenShiftRoute(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	if route == nil {
+		log.Info("creating Route for challenge", "name", ch.Name, "namespace", ch.Namespace)
+		return s.createOpenShiftRoute(ctx, ch, svcName)
+	}
+
+	log.Info("Found existing Route for challenge", "name", ch.Name, "namespace", ch.Namespace)
+
+	return s.checkAndUpdateOpenShiftRoute(ctx, ch, svcName, route)
+}
+
+func (s *Solver) getOpenShiftRoute(ctx context.Context, ch *cmacme.Challenge) (*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx).WithName("getOpenShiftRoute")
+	routes, err := s.DynamicClient.Resource(routeGVR).Namespace(ch.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(podLabels(ch)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(routes.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &routes.Items[0], nil
+	default:
+		// It should not be possible for multiple Routes for this challenge to exist
+		// If we find this, try to delete them.
+		for _, route := range routes.Items[1:] {
+			log.Info("Deleting extra Route", "name", route.GetName(), "namespace", route.GetNamespace())
+			err := s.DynamicClient.Resource(routeGVR).Namespace(route.GetNamespace()).Delete(ctx, route.GetName(), metav1.DeleteOptions{})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, fmt.Errorf("multiple Routes found")
+	}
+}
+
+func (s *Solver) createOpenShiftRoute(ctx context.Context, ch *cmacme.Challenge, svcName string) (*unstructured.Unstructured, error) {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	route.SetGenerateName("cm-acme-http-solver-")
+	route.SetNamespace(ch.Namespace)
+	route.SetLabels(openShiftRouteLabels(ch))
+	route.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)})
+	if err := unstructured.SetNestedField(route.Object, generateOpenShiftRouteSpec(ch, svcName), "spec"); err != nil {
+		return nil, err
+	}
+	return s.DynamicClient.Resource(routeGVR).Namespace(ch.Namespace).Create(ctx, route, metav1.CreateOptions{})
+}
+
+func (s *Solver) checkAndUpdateOpenShiftRoute(ctx context.Context, ch *cmacme.Challenge, svcName string, route *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx, "checkAndUpdateOpenShiftRoute")
+	expectedSpec := generateOpenShiftRouteSpec(ch, svcName)
+	actualSpec, _, _ := unstructured.NestedMap(route.Object, "spec")
+	expectedLabels := openShiftRouteLabels(ch)
+	if reflect.DeepEqual(expectedSpec, actualSpec) && reflect.DeepEqual(expectedLabels, route.GetLabels()) {
+		return route, nil
+	}
+	log.Info("Route is out of date, updating", "name", route.GetName(), "namespace", route.GetNamespace())
+	var ret *unstructured.Unstructured
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		oldRoute, err := s.DynamicClient.Resource(routeGVR).Namespace(route.GetNamespace()).Get(ctx, route.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		newRoute := oldRoute.DeepCopy()
+		if err := unstructured.SetNestedField(newRoute.Object, expectedSpec, "spec"); err != nil {
+			return err
+		}
+		newRoute.SetLabels(expectedLabels)
+		ret, err = s.DynamicClient.Resource(routeGVR).Namespace(newRoute.GetNamespace()).Update(ctx, newRoute, metav1.UpdateOptions{})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func openShiftRouteLabels(ch *cmacme.Challenge) map[string]string {
+	labels := podLabels(ch)
+	for k, v := range ch.Spec.Solver.HTTP01.OpenShiftRoute.Labels {
+		labels[k] = v
+	}
+	return withOwnerLabels(labels, ch)
+}
+
+// generateOpenShiftRouteSpec returns the spec of a Route which routes requests
+// for the challenge's token to the solver Service. It only contains the types
+// produced by JSON decoding, so that it can be compared with the spec of an
+// existing Route. Fields which are defaulted by the API server are set to
+// their defaults, so that an unchanged Route is not updated.
+func generateOpenShiftRouteSpec(ch *cmacme.Challenge, svcName string) map[string]interface{} {
+	return map[string]interface{}{
+		"host": ch.Spec.DNSName,
+		"path": fmt.Sprintf("/.well-known/acme-challenge/%s", ch.Spec.Token),
+		"to": map[string]interface{}{
+			"kind":   "Service",
+			"name":   svcName,
+			"weight": int64(100),
+		},
+		"port": map[string]interface{}{
+			"targetPort": int64(solverListenPort(ch)),
+		},
+		"wildcardPolicy": "None",
+	}
+}
+
+// cleanupOpenShiftRoutes deletes the Routes created for the challenge.
+func (s *Solver) cleanupOpenShiftRoutes(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupOpenShiftRoutes")
+	routes, err := s.DynamicClient.Resource(routeGVR).Namespace(ch.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(podLabels(ch)).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, route := range routes.Items {
+		log.V(logf.DebugLevel).Info("deleting Route", "name", route.GetName(), "namespace", route.GetNamespace())
+		err := s.DynamicClient.Resource(routeGVR).Namespace(route.GetNamespace()).Delete(ctx, route.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
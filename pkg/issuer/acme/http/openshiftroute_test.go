@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func openShiftRouteChallenge(dnsName string) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: dnsName,
+			Token:   "token",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					OpenShiftRoute: &cmacme.ACMEChallengeSolverHTTP01OpenShiftRoute{
+						Labels: map[string]string{"router": "public"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEnsureOpenShiftRoute(t *testing.T) {
+	tests := map[string]solverFixture{
+		"should create a Route for the challenge": {
+			Challenge: openShiftRouteChallenge("example.com"),
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				route := args[0].(*unstructured.Unstructured)
+				if route.GetLabels()["router"] != "public" {
+					t.Errorf("expected the custom labels to be set, got %v", route.GetLabels())
+				}
+				spec, _, _ := unstructured.NestedMap(route.Object, "spec")
+				expectedSpec := map[string]interface{}{
+					"host": "example.com",
+					"path": "/.well-known/acme-challenge/token",
+					"to": map[string]interface{}{
+						"kind":   "Service",
+						"name":   "fakeservice",
+						"weight": int64(100),
+					},
+					"port": map[string]interface{}{
+						"targetPort": int64(acmeSolverListenPort),
+					},
+					"wildcardPolicy": "None",
+				}
+				if !reflect.DeepEqual(expectedSpec, spec) {
+					t.Errorf("expected spec %v, got %v", expectedSpec, spec)
+				}
+			},
+		},
+		"should fail for an IP address": {
+			Challenge: openShiftRouteChallenge("10.0.0.1"),
+			Err:       true,
+		},
+		"should update an existing Route which is out of date": {
+			Challenge: openShiftRouteChallenge("example.com"),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				if _, err := s.Solver.createOpenShiftRoute(context.TODO(), s.Challenge, "oldservice"); err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				route := args[0].(*unstructured.Unstructured)
+				name, _, _ := unstructured.NestedString(route.Object, "spec", "to", "name")
+				if name != "fakeservice" {
+					t.Errorf("expected the Route to be updated to route to fakeservice, got %q", name)
+				}
+				list, err := s.DynamicClient.Resource(routeGVR).Namespace(defaultTestNamespace).List(context.TODO(), metav1.ListOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(list.Items) != 1 {
+					t.Errorf("expected one Route to exist, got %d", len(list.Items))
+				}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Setup(t)
+			resp, err := test.Solver.ensureOpenShiftRoute(context.TODO(), test.Challenge, "fakeservice")
+			if err != nil && !test.Err {
+				t.Errorf("Expected function to not error, but got: %v", err)
+			}
+			if err == nil && test.Err {
+				t.Errorf("Expected function to get an error, but got: %v", err)
+			}
+			test.Finish(t, resp)
+		})
+	}
+}
+
+func TestCleanupOpenShiftRoutes(t *testing.T) {
+	s := solverFixture{
+		Challenge: openShiftRouteChallenge("example.com"),
+		PreFn: func(t *testing.T, s *solverFixture) {
+			if _, err := s.Solver.createOpenShiftRoute(context.TODO(), s.Challenge, "fakeservice"); err != nil {
+				t.Errorf("error preparing test: %v", err)
+			}
+		},
+		CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+			list, err := s.DynamicClient.Resource(routeGVR).Namespace(defaultTestNamespace).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Items) != 0 {
+				t.Errorf("expected all Routes to be deleted, got %d", len(list.Items))
+			}
+		},
+	}
+	s.Setup(t)
+	if err := s.Solver.cleanupOpenShiftRoutes(context.TODO(), s.Challenge); err != nil {
+		t.Errorf("Expected function to not error, but got: %v", err)
+	}
+	s.Finish(t)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"hash/adler32"
+	"math"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,8 @@ import (
 	"k8s.io/utils/pointer"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -36,7 +39,7 @@ func podLabels(ch *cmacme.Challenge) map[string]string {
 	domainHash := fmt.Sprintf("%d", adler32.Checksum([]byte(ch.Spec.DNSName)))
 	tokenHash := fmt.Sprintf("%d", adler32.Checksum([]byte(ch.Spec.Token)))
 	solverIdent := "true"
-	return map[string]string{
+	lbls := map[string]string{
 		// TODO: we need to support domains longer than 63 characters
 		// this value should probably be hashed, and then the full plain text
 		// value stored as an annotation to make it easier for users to read
@@ -45,6 +48,21 @@ func podLabels(ch *cmacme.Challenge) map[string]string {
 		cmacme.TokenLabelKey:                tokenHash,
 		cmacme.SolverIdentificationLabelKey: solverIdent,
 	}
+	// Challenges are only synced by the cert-manager instance they are
+	// labelled as owned by, so the label is propagated to the solver
+	// resources. As the labels are also used to look up existing solver
+	// resources, those of other instances are never modified.
+	if ownedBy, ok := ch.Labels[cmapi.OwnedByLabelKey]; ok {
+		lbls[cmapi.OwnedByLabelKey] = ownedBy
+	}
+	return lbls
+}
+
+// withOwnerLabels adds the labels identifying the cert-manager instance and
+// the Challenge which own a solver resource. They are added after any labels
+// from the solver's templates, so that those can not override them.
+func withOwnerLabels(lbls map[string]string, ch *cmacme.Challenge) map[string]string {
+	return controllerpkg.AddOwnedByLabels(lbls, ch.Labels[cmapi.OwnedByLabelKey], ch)
 }
 
 func (s *Solver) ensurePod(ctx context.Context, ch *cmacme.Challenge) (*corev1.Pod, error) {
@@ -139,18 +157,69 @@ func (s *Solver) createPod(ctx context.Context, ch *cmacme.Challenge) (*corev1.P
 		metav1.CreateOptions{})
 }
 
+// solverServer returns the configuration of the solver's HTTP server, or nil
+// if the defaults are used.
+func solverServer(ch *cmacme.Challenge) *cmacme.ACMEChallengeSolverHTTP01Server {
+	if ch.Spec.Solver.HTTP01 == nil {
+		return nil
+	}
+	return ch.Spec.Solver.HTTP01.Server
+}
+
+// solverListenPort returns the port the solver pod for the challenge listens
+// on.
+func solverListenPort(ch *cmacme.Challenge) int32 {
+	if server := solverServer(ch); server != nil && server.ListenPort != 0 {
+		return server.ListenPort
+	}
+	return acmeSolverListenPort
+}
+
+// solverServerArgs returns the acmesolver arguments configuring its HTTP
+// server.
+func solverServerArgs(ch *cmacme.Challenge) []string {
+	args := []string{fmt.Sprintf("--listen-port=%d", solverListenPort(ch))}
+
+	server := solverServer(ch)
+	if server == nil {
+		return args
+	}
+	if server.ReadTimeout != nil {
+		args = append(args, fmt.Sprintf("--read-timeout=%s", server.ReadTimeout.Duration))
+	}
+	if server.ReadHeaderTimeout != nil {
+		args = append(args, fmt.Sprintf("--read-header-timeout=%s", server.ReadHeaderTimeout.Duration))
+	}
+	if server.ShutdownDrainPeriod != nil {
+		args = append(args, fmt.Sprintf("--shutdown-drain-period=%s", server.ShutdownDrainPeriod.Duration))
+	}
+	return args
+}
+
 // buildPod will build a challenge solving pod for the given certificate,
 // domain, token and key. It will not create it in the API server
 func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 	pod := s.buildDefaultPod(ch)
 
+	// Allow the solver to drain and shut down before it is killed.
+	if server := solverServer(ch); server != nil && server.ShutdownDrainPeriod != nil {
+		grace := int64(math.Ceil((server.ShutdownDrainPeriod.Duration + acmeSolverShutdownTimeout).Seconds()))
+		pod.Spec.TerminationGracePeriodSeconds = &grace
+	}
+
 	// Override defaults if they have changed in the pod template.
 	if ch.Spec.Solver.HTTP01 != nil {
 		if ch.Spec.Solver.HTTP01.Ingress != nil {
 			pod = s.mergePodObjectMetaWithPodTemplate(pod,
 				ch.Spec.Solver.HTTP01.Ingress.PodTemplate)
 		}
+		if ch.Spec.Solver.HTTP01.HostPort != nil {
+			pod.Spec.Containers[0].Ports[0].HostPort = acmeSolverHostPort
+			pod = s.mergePodObjectMetaWithPodTemplate(pod,
+				ch.Spec.Solver.HTTP01.HostPort.PodTemplate)
+		}
 	}
+	pod.Labels = withOwnerLabels(pod.Labels, ch)
 
 	return pod
 }
@@ -178,13 +247,8 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 			NodeSelector: map[string]string{
 				"kubernetes.io/os": "linux",
 			},
-			RestartPolicy: corev1.RestartPolicyOnFailure,
-			SecurityContext: &corev1.PodSecurityContext{
-				RunAsNonRoot: pointer.BoolPtr(true),
-				SeccompProfile: &corev1.SeccompProfile{
-					Type: corev1.SeccompProfileTypeRuntimeDefault,
-				},
-			},
+			RestartPolicy:   corev1.RestartPolicyOnFailure,
+			SecurityContext: s.solverPodSecurityContext(),
 			Containers: []corev1.Container{
 				{
 					Name: "acmesolver",
@@ -192,12 +256,11 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 					Image:           s.Context.HTTP01SolverImage,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					// TODO: replace this with some kind of cmdline generator
-					Args: []string{
-						fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+					Args: append(solverServerArgs(ch),
 						fmt.Sprintf("--domain=%s", ch.Spec.DNSName),
 						fmt.Sprintf("--token=%s", ch.Spec.Token),
 						fmt.Sprintf("--key=%s", ch.Spec.Key),
-					},
+					),
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
 							corev1.ResourceCPU:    s.ACMEOptions.HTTP01SolverResourceRequestCPU,
@@ -211,21 +274,47 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 					Ports: []corev1.ContainerPort{
 						{
 							Name:          "http",
-							ContainerPort: acmeSolverListenPort,
-						},
-					},
-					SecurityContext: &corev1.SecurityContext{
-						AllowPrivilegeEscalation: pointer.BoolPtr(false),
-						Capabilities: &corev1.Capabilities{
-							Drop: []corev1.Capability{"ALL"},
+							ContainerPort: solverListenPort(ch),
 						},
 					},
+					SecurityContext: s.solverContainerSecurityContext(),
 				},
 			},
 		},
 	}
 }
 
+// solverPodSecurityContext returns the security context of solver pods as
+// configured on the controller.
+func (s *Solver) solverPodSecurityContext() *corev1.PodSecurityContext {
+	sc := &corev1.PodSecurityContext{}
+	if s.ACMEOptions.HTTP01SolverRunAsNonRoot {
+		sc.RunAsNonRoot = pointer.BoolPtr(true)
+	}
+	if len(s.ACMEOptions.HTTP01SolverSeccompProfileType) > 0 {
+		sc.SeccompProfile = &corev1.SeccompProfile{
+			Type: s.ACMEOptions.HTTP01SolverSeccompProfileType,
+		}
+	}
+	return sc
+}
+
+// solverContainerSecurityContext returns the security context of the
+// acmesolver container. Privilege escalation is never allowed and all
+// capabilities are always dropped.
+func (s *Solver) solverContainerSecurityContext() *corev1.SecurityContext {
+	sc := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: pointer.BoolPtr(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+	if s.ACMEOptions.HTTP01SolverReadOnlyRootFilesystem {
+		sc.ReadOnlyRootFilesystem = pointer.BoolPtr(true)
+	}
+	return sc
+}
+
 // Merge object meta from the pod template. Fall back to default values.
 func (s *Solver) mergePodObjectMetaWithPodTemplate(pod *corev1.Pod, podTempl *cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate) *corev1.Pod {
 	if podTempl == nil {
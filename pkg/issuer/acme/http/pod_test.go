@@ -20,13 +20,20 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
 )
 
 func TestEnsurePod(t *testing.T) {
@@ -413,3 +420,150 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildPodServerConfig(t *testing.T) {
+	s := &Solver{Context: &controller.Context{}}
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+					Server: &cmacme.ACMEChallengeSolverHTTP01Server{
+						ListenPort:          8080,
+						ReadTimeout:         &metav1.Duration{Duration: 10 * time.Second},
+						ReadHeaderTimeout:   &metav1.Duration{Duration: 2 * time.Second},
+						ShutdownDrainPeriod: &metav1.Duration{Duration: 30 * time.Second},
+					},
+				},
+			},
+		},
+	}
+
+	pod := s.buildPod(ch)
+	assert.Equal(t, []string{
+		"--listen-port=8080",
+		"--read-timeout=10s",
+		"--read-header-timeout=2s",
+		"--shutdown-drain-period=30s",
+		"--domain=example.com",
+		"--token=token",
+		"--key=key",
+	}, pod.Spec.Containers[0].Args)
+	assert.Equal(t, int32(8080), pod.Spec.Containers[0].Ports[0].ContainerPort)
+	assert.Equal(t, int64(35), *pod.Spec.TerminationGracePeriodSeconds)
+
+	svc, err := buildService(ch)
+	require.NoError(t, err)
+	assert.Equal(t, int32(acmeSolverListenPort), svc.Spec.Ports[0].Port)
+	assert.Equal(t, 8080, svc.Spec.Ports[0].TargetPort.IntValue())
+
+	// Without any configuration the defaults are used.
+	ch.Spec.Solver.HTTP01.Server = nil
+	pod = s.buildPod(ch)
+	assert.Equal(t, "--listen-port=8089", pod.Spec.Containers[0].Args[0])
+	assert.Nil(t, pod.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestBuildPodHostPort(t *testing.T) {
+	s := &Solver{Context: &controller.Context{}}
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					HostPort: &cmacme.ACMEChallengeSolverHTTP01HostPort{
+						PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+							Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+								NodeSelector: map[string]string{"node-role.kubernetes.io/edge": ""},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pod := s.buildPod(ch)
+	assert.Equal(t, int32(acmeSolverListenPort), pod.Spec.Containers[0].Ports[0].ContainerPort)
+	assert.Equal(t, int32(acmeSolverHostPort), pod.Spec.Containers[0].Ports[0].HostPort)
+	assert.False(t, pod.Spec.HostNetwork)
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", "node-role.kubernetes.io/edge": ""}, pod.Spec.NodeSelector)
+}
+
+func TestBuildPodOwnerLabels(t *testing.T) {
+	s := &Solver{Context: &controller.Context{}}
+	ch := &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:    "challenge-uid",
+			Labels: map[string]string{cmapi.OwnedByLabelKey: "instance-a"},
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+						PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+							ACMEChallengeSolverHTTP01IngressPodObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressPodObjectMeta{
+								Labels: map[string]string{
+									"foo":                       "bar",
+									cmapi.OwnedByLabelKey:       "instance-b",
+									cmapi.ControllerUIDLabelKey: "other-uid",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pod := s.buildPod(ch)
+	assert.Equal(t, "bar", pod.Labels["foo"])
+	assert.Equal(t, "instance-a", pod.Labels[cmapi.OwnedByLabelKey])
+	assert.Equal(t, "challenge-uid", pod.Labels[cmapi.ControllerUIDLabelKey])
+}
+
+func TestBuildPodSecurityContext(t *testing.T) {
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}
+
+	s := &Solver{Context: &controller.Context{}}
+	s.ACMEOptions = controller.ACMEOptions{
+		HTTP01SolverRunAsNonRoot:           true,
+		HTTP01SolverSeccompProfileType:     corev1.SeccompProfileTypeRuntimeDefault,
+		HTTP01SolverReadOnlyRootFilesystem: true,
+	}
+	pod := s.buildPod(ch)
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot:   pointer.BoolPtr(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, pod.Spec.SecurityContext)
+	assert.Equal(t, &corev1.SecurityContext{
+		AllowPrivilegeEscalation: pointer.BoolPtr(false),
+		ReadOnlyRootFilesystem:   pointer.BoolPtr(true),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}, pod.Spec.Containers[0].SecurityContext)
+
+	// Options which are not set are left unset on the pod.
+	s = &Solver{Context: &controller.Context{}}
+	pod = s.buildPod(ch)
+	assert.Equal(t, &corev1.PodSecurityContext{}, pod.Spec.SecurityContext)
+	assert.Nil(t, pod.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+}
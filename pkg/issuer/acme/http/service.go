@@ -105,7 +105,7 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "cm-acme-http-solver-",
 			Namespace:    ch.Namespace,
-			Labels:       podLabels,
+			Labels:       withOwnerLabels(podLabels, ch),
 			Annotations: map[string]string{
 				"auth.istio.io/8089": "NONE",
 			},
@@ -117,7 +117,7 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 				{
 					Name:       "http",
 					Port:       acmeSolverListenPort,
-					TargetPort: intstr.FromInt(acmeSolverListenPort),
+					TargetPort: intstr.FromInt(int(solverListenPort(ch))),
 				},
 			},
 			Selector: podLabels,
@@ -133,9 +133,43 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 		service.Spec.Type = serviceType
 	}
 
+	// Override the defaults if they have changed in the service template.
+	if ch.Spec.Solver.HTTP01 != nil &&
+		ch.Spec.Solver.HTTP01.Ingress != nil {
+		service = mergeServiceObjectMetaWithServiceTemplate(service, ch.Spec.Solver.HTTP01.Ingress.ServiceTemplate)
+	}
+
 	return service, nil
 }
 
+// Merge object meta from the service template. Fall back to default values.
+func mergeServiceObjectMetaWithServiceTemplate(service *corev1.Service, serviceTempl *cmacme.ACMEChallengeSolverHTTP01ServiceTemplate) *corev1.Service {
+	if serviceTempl == nil {
+		return service
+	}
+
+	// The default labels are shared with the selector, which must not be
+	// changed by the template.
+	labels := make(map[string]string, len(service.Labels)+len(serviceTempl.Labels))
+	for k, v := range service.Labels {
+		labels[k] = v
+	}
+	for k, v := range serviceTempl.Labels {
+		labels[k] = v
+	}
+	service.Labels = labels
+
+	if service.Annotations == nil {
+		service.Annotations = make(map[string]string)
+	}
+
+	for k, v := range serviceTempl.Annotations {
+		service.Annotations[k] = v
+	}
+
+	return service
+}
+
 func (s *Solver) cleanupServices(ctx context.Context, ch *cmacme.Challenge) error {
 	log := logf.FromContext(ctx, "cleanupPods")
 
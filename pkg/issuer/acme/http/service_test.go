@@ -491,3 +491,52 @@ func TestGetServicesForChallenge(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildServiceWithServiceTemplate(t *testing.T) {
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+						ServiceTemplate: &cmacme.ACMEChallengeSolverHTTP01ServiceTemplate{
+							ACMEChallengeSolverHTTP01ServiceObjectMeta: cmacme.ACMEChallengeSolverHTTP01ServiceObjectMeta{
+								Labels: map[string]string{
+									"cost-center": "platform",
+								},
+								Annotations: map[string]string{
+									"auth.istio.io/8089":                        "MUTUAL_TLS",
+									"external-dns.alpha.kubernetes.io/hostname": "",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	svc, err := buildService(ch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedLabels := podLabels(ch)
+	if !reflect.DeepEqual(svc.Spec.Selector, expectedLabels) {
+		t.Errorf("expected the selector not to be changed by the template, got %v", svc.Spec.Selector)
+	}
+
+	expectedLabels["cost-center"] = "platform"
+	if !reflect.DeepEqual(svc.Labels, expectedLabels) {
+		t.Errorf("unexpected labels\nexp=%v\ngot=%v", expectedLabels, svc.Labels)
+	}
+
+	expectedAnnotations := map[string]string{
+		"auth.istio.io/8089":                        "MUTUAL_TLS",
+		"external-dns.alpha.kubernetes.io/hostname": "",
+	}
+	if !reflect.DeepEqual(svc.Annotations, expectedAnnotations) {
+		t.Errorf("unexpected annotations\nexp=%v\ngot=%v", expectedAnnotations, svc.Annotations)
+	}
+}
@@ -19,8 +19,10 @@ package solver
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"path"
 	"strings"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 )
@@ -32,16 +34,93 @@ type HTTP01Solver struct {
 	Token  string
 	Key    string
 
+	// Challenges are additional challenges served by the solver, allowing a
+	// single solver to serve the challenges for several domains.
+	Challenges []Challenge
+
+	// ChallengesFile, if set, is a file listing further challenges served by
+	// the solver, one per line. It is read on each request so that challenges
+	// can be added or removed while the solver is running.
+	ChallengesFile string
+
 	http.Server
+
+	// draining is set once the solver has started to drain, after which
+	// health checks fail while challenges continue to be served.
+	draining int32
+}
+
+// Challenge is an HTTP-01 challenge served by the solver.
+type Challenge struct {
+	Domain string
+	Token  string
+	Key    string
+}
+
+// ParseChallenge parses a challenge in the form 'domain,token,key'.
+func ParseChallenge(s string) (Challenge, error) {
+	parts := strings.SplitN(s, ",", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return Challenge{}, fmt.Errorf("invalid challenge %q, expected 'domain,token,key'", s)
+	}
+	return Challenge{Domain: parts[0], Token: parts[1], Key: parts[2]}, nil
+}
+
+// FormatChallenge formats a challenge in the form parsed by ParseChallenge.
+func FormatChallenge(ch Challenge) string {
+	return fmt.Sprintf("%s,%s,%s", ch.Domain, ch.Token, ch.Key)
+}
+
+// ReadChallengesFile reads the challenges listed in the given file, one per
+// line. Empty lines are ignored.
+func ReadChallengesFile(name string) ([]Challenge, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var challenges []Challenge
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		ch, err := ParseChallenge(line)
+		if err != nil {
+			return nil, err
+		}
+		challenges = append(challenges, ch)
+	}
+	return challenges, nil
+}
+
+// challenges returns the challenges configured on the solver, excluding
+// those listed in ChallengesFile.
+func (h *HTTP01Solver) challenges() []Challenge {
+	if h.Token == "" {
+		return h.Challenges
+	}
+	return append([]Challenge{{Domain: h.Domain, Token: h.Token, Key: h.Key}}, h.Challenges...)
+}
+
+// Drain causes health checks to fail so that traffic is moved away from the
+// solver, while it continues to serve challenges until it is shut down.
+func (h *HTTP01Solver) Drain() {
+	atomic.StoreInt32(&h.draining, 1)
 }
 
 func (h *HTTP01Solver) Listen(log logr.Logger) error {
-	log.Info("starting listener",
-		"expected_domain", h.Domain,
-		"expected_token", h.Token,
-		"expected_key", h.Key,
-		"listen_port", h.ListenPort,
-	)
+	challenges := h.challenges()
+	for _, ch := range challenges {
+		log.Info("starting listener",
+			"expected_domain", ch.Domain,
+			"expected_token", ch.Token,
+			"expected_key", ch.Key,
+			"listen_port", h.ListenPort,
+		)
+	}
+	if h.ChallengesFile != "" {
+		log.Info("serving challenges listed in file", "challenges_file", h.ChallengesFile, "listen_port", h.ListenPort)
+	}
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// extract vars from the request
@@ -56,6 +135,12 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 			"token", token,
 		)
 		if r.URL.EscapedPath() == "/" || r.URL.EscapedPath() == "/healthz" {
+			if atomic.LoadInt32(&h.draining) == 1 {
+				log.Info("responding unavailable to health check while draining")
+				w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
 			log.Info("responding OK to health check")
 			w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 			w.WriteHeader(http.StatusOK)
@@ -69,31 +154,36 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 			return
 		}
 
-		log.Info("comparing host", "expected_host", h.Domain)
-		if h.Domain != host {
-			log.Info("invalid host", "expected_host", h.Domain)
-			http.NotFound(w, r)
-			return
+		served := challenges
+		if h.ChallengesFile != "" {
+			fromFile, err := ReadChallengesFile(h.ChallengesFile)
+			if err != nil {
+				log.Error(err, "failed to read challenges file", "challenges_file", h.ChallengesFile)
+			}
+			served = append(append([]Challenge{}, challenges...), fromFile...)
 		}
 
-		log.Info("comparing token", "expected_token", h.Token)
-		if h.Token != token {
-			// if nothing else, we return a 404 here
-			log.Info("invalid token", "expected_token", h.Token)
-			http.NotFound(w, r)
+		for _, ch := range served {
+			log.Info("comparing host and token", "expected_host", ch.Domain, "expected_token", ch.Token)
+			if ch.Domain != host || ch.Token != token {
+				continue
+			}
+
+			log.Info("got successful challenge request, writing key")
+			w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, ch.Key)
 			return
 		}
 
-		log.Info("got successful challenge request, writing key")
-		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprint(w, h.Key)
+		// if nothing else, we return a 404 here
+		log.Info("invalid host or token")
+		http.NotFound(w, r)
 	})
 
-	h.Server = http.Server{
-		Addr:    fmt.Sprintf(":%d", h.ListenPort),
-		Handler: handler,
-	}
+	// Timeouts may already have been configured on the server.
+	h.Server.Addr = fmt.Sprintf(":%d", h.ListenPort)
+	h.Server.Handler = handler
 
 	return h.Server.ListenAndServe()
 }
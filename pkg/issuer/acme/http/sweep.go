@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// SweepOrphaned deletes the solver Pods, Services, Ingresses and HTTPRoutes
+// which are no longer needed by the Challenge, or Order, controlling them.
+// These are normally deleted when a Challenge is cleaned up, but can be left
+// behind if cert-manager exits part way through cleaning up, or if a
+// duplicate was created while the cache of solver resources was stale.
+// Resources which are not controlled by a Challenge or Order are ignored, as
+// are those of other cert-manager instances.
+func (s *Solver) SweepOrphaned(ctx context.Context) error {
+	log := logf.FromContext(ctx, "sweepOrphaned")
+
+	set := labels.Set{cmacme.SolverIdentificationLabelKey: "true"}
+	if s.OwnedBy != "" {
+		set[cmapi.OwnedByLabelKey] = s.OwnedBy
+	}
+	selector := set.AsSelector()
+
+	var errs []error
+	sweep := func(obj metav1.Object, resource string, del func() error) {
+		if !s.orphaned(obj) {
+			return
+		}
+		log.Info("deleting orphaned solver resource", "resource", resource, "namespace", obj.GetNamespace(), "name", obj.GetName())
+		if err := del(); err != nil && !k8sErrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	pods, err := s.podLister.List(selector)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		sweep(pod, "pods", func() error {
+			return s.Client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		})
+	}
+
+	services, err := s.serviceLister.List(selector)
+	if err != nil {
+		return err
+	}
+	for _, svc := range services {
+		sweep(svc, "services", func() error {
+			return s.Client.CoreV1().Services(svc.Namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{})
+		})
+	}
+
+	ingresses, err := s.ingressLister.List(selector)
+	if err != nil {
+		return err
+	}
+	for _, ing := range ingresses {
+		sweep(ing, "ingresses", func() error {
+			return s.Client.NetworkingV1().Ingresses(ing.Namespace).Delete(ctx, ing.Name, metav1.DeleteOptions{})
+		})
+	}
+
+	if s.GatewaySolverEnabled {
+		httpRoutes, err := s.httpRouteLister.List(selector)
+		if err != nil {
+			return err
+		}
+		for _, route := range httpRoutes {
+			sweep(route, "httproutes", func() error {
+				return s.GWClient.GatewayV1alpha2().HTTPRoutes(route.Namespace).Delete(ctx, route.Name, metav1.DeleteOptions{})
+			})
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// orphaned returns true if the solver resource is controlled by a Challenge
+// which no longer exists, or which is in a final state and no longer
+// presented. Resources shared by the challenges of an Order are orphaned
+// once none of the HTTP01 challenges of the Order still need them.
+func (s *Solver) orphaned(obj metav1.Object) bool {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil {
+		return false
+	}
+
+	switch ref.Kind {
+	case cmacme.ChallengeKind:
+		ch, err := s.challengeLister.Challenges(obj.GetNamespace()).Get(ref.Name)
+		if k8sErrors.IsNotFound(err) {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+		return ch.UID != ref.UID || !challengeNeedsSolver(ch)
+
+	case cmacme.OrderKind:
+		challenges, err := s.challengeLister.Challenges(obj.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			return false
+		}
+		for _, ch := range challenges {
+			chRef := metav1.GetControllerOf(ch)
+			if chRef == nil || chRef.UID != ref.UID || ch.Spec.Type != cmacme.ACMEChallengeTypeHTTP01 {
+				continue
+			}
+			if challengeNeedsSolver(ch) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// challengeNeedsSolver returns true if the solver resources of the challenge
+// may still be serving, or may be about to serve, its key.
+func challengeNeedsSolver(ch *cmacme.Challenge) bool {
+	return ch.Status.Presented || !acme.IsFinalState(ch.Status.State)
+}
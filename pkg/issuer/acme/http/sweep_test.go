@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func solverPodOwnedBy(name string, owner metav1.Object, kind string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultTestNamespace,
+			Labels: map[string]string{
+				cmacme.SolverIdentificationLabelKey: "true",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(owner, cmacme.SchemeGroupVersion.WithKind(kind)),
+			},
+		},
+	}
+}
+
+func TestSweepOrphaned(t *testing.T) {
+	ctx := context.Background()
+
+	chPending := groupChallenge("pending", "a.example.com", "token-a", cmacme.Pending)
+	chValid := groupChallenge("valid", "b.example.com", "token-b", cmacme.Valid)
+	chValidPresented := groupChallenge("valid-presented", "c.example.com", "token-c", cmacme.Valid)
+	chValidPresented.Status.Presented = true
+	chDeleted := groupChallenge("deleted", "d.example.com", "token-d", cmacme.Pending)
+
+	order := &metav1.ObjectMeta{Name: "test-order", UID: "test-order-uid"}
+	doneOrder := &metav1.ObjectMeta{Name: "done-order", UID: "done-order-uid"}
+	chDoneOrder := groupChallenge("done-order-challenge", "e.example.com", "token-e", cmacme.Valid)
+	chDoneOrder.OwnerReferences[0].Name = doneOrder.Name
+	chDoneOrder.OwnerReferences[0].UID = doneOrder.UID
+
+	notSolverPod := solverPodOwnedBy("not-a-solver", chDeleted, cmacme.ChallengeKind)
+	notSolverPod.Labels = nil
+
+	b := &test.Builder{
+		T:                  t,
+		CertManagerObjects: []runtime.Object{chPending, chValid, chValidPresented, chDoneOrder},
+		KubeObjects: []runtime.Object{
+			solverPodOwnedBy("pending", chPending, cmacme.ChallengeKind),
+			solverPodOwnedBy("valid", chValid, cmacme.ChallengeKind),
+			solverPodOwnedBy("valid-presented", chValidPresented, cmacme.ChallengeKind),
+			solverPodOwnedBy("deleted", chDeleted, cmacme.ChallengeKind),
+			solverPodOwnedBy("order", order, cmacme.OrderKind),
+			solverPodOwnedBy("done-order", doneOrder, cmacme.OrderKind),
+			notSolverPod,
+		},
+	}
+	s, err := buildFakeSolver(b)
+	require.NoError(t, err)
+	defer b.Stop()
+
+	require.NoError(t, s.SweepOrphaned(ctx))
+
+	pods, err := b.FakeKubeClient().CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	var remaining []string
+	for _, pod := range pods.Items {
+		remaining = append(remaining, pod.Name)
+	}
+	sort.Strings(remaining)
+	assert.Equal(t, []string{"not-a-solver", "order", "pending", "valid-presented"}, remaining)
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/client"
+	"github.com/cert-manager/cert-manager/pkg/acme/solverselection"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+// preAuthorize requests authorizations for the issuer's
+// preAuthorizedDNSNames and ensures that a Challenge resource exists for each
+// pending authorization. The Challenges are owned by the issuer so that it is
+// re-synced as they progress, and are deleted once no longer required.
+// Failed authorizations are not requested again until the DNS name is
+// removed from and re-added to the list.
+func (a *Acme) preAuthorize(ctx context.Context, ns string) error {
+	log := logf.FromContext(ctx, "preAuthorize")
+	status := a.issuer.GetStatus().ACMEStatus()
+	dnsNames := sets.NewString(a.issuer.GetSpec().ACME.PreAuthorizedDNSNames...)
+	if dnsNames.Len() == 0 && len(status.PreAuthorizations) == 0 {
+		return nil
+	}
+
+	cl, err := a.accountRegistry.GetClient(string(a.issuer.GetUID()))
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]cmacme.ACMEPreAuthorization)
+	for _, preAuthz := range status.PreAuthorizations {
+		existing[preAuthz.DNSName] = preAuthz
+	}
+
+	var errs []error
+	var preAuthzs []cmacme.ACMEPreAuthorization
+	var required []*cmacme.Challenge
+	for _, dnsName := range dnsNames.List() {
+		preAuthz, ok := existing[dnsName]
+		if !ok {
+			preAuthz = cmacme.ACMEPreAuthorization{DNSName: dnsName}
+		}
+		ch, err := a.syncPreAuthorization(ctx, cl, ns, &preAuthz)
+		if err != nil {
+			log.Error(err, "failed to sync pre-authorization", "dnsName", dnsName)
+			errs = append(errs, err)
+		}
+		if ch != nil {
+			required = append(required, ch)
+		}
+		preAuthzs = append(preAuthzs, preAuthz)
+	}
+	status.PreAuthorizations = preAuthzs
+
+	if err := a.syncPreAuthorizationChallenges(ctx, ns, required); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// syncPreAuthorization updates the given pre-authorization, requesting a new
+// authorization if none has been requested yet or if the previous one has
+// expired. It returns the Challenge needed to solve the authorization if it
+// is pending.
+func (a *Acme) syncPreAuthorization(ctx context.Context, cl client.Interface, ns string, preAuthz *cmacme.ACMEPreAuthorization) (*cmacme.Challenge, error) {
+	expired := preAuthz.Expires != nil && !a.clock.Now().Before(preAuthz.Expires.Time)
+
+	var authz *acmeapi.Authorization
+	var err error
+	switch {
+	case preAuthz.State == "" || (preAuthz.State == cmacme.Valid && expired):
+		authz, err = cl.Authorize(ctx, preAuthz.DNSName)
+		if preAuthorizationFailed(err) {
+			preAuthz.URL = ""
+			preAuthz.State = cmacme.Errored
+			preAuthz.Reason = fmt.Sprintf("Failed to request authorization: %v", err)
+			return nil, nil
+		}
+
+	case preAuthz.State == cmacme.Pending:
+		authz, err = cl.GetAuthorization(ctx, preAuthz.URL)
+		if preAuthorizationFailed(err) {
+			preAuthz.State = cmacme.Errored
+			preAuthz.Reason = fmt.Sprintf("Failed to retrieve authorization: %v", err)
+			return nil, nil
+		}
+
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	preAuthz.URL = authz.URI
+	preAuthz.State = cmacme.State(authz.Status)
+	preAuthz.Reason = ""
+	preAuthz.Expires = nil
+	if !authz.Expires.IsZero() {
+		preAuthz.Expires = &metav1.Time{Time: authz.Expires}
+	}
+	if preAuthz.State != cmacme.Pending {
+		return nil, nil
+	}
+
+	ch, err := a.buildPreAuthorizationChallenge(ctx, cl, ns, authz)
+	if err != nil {
+		preAuthz.State = cmacme.Errored
+		preAuthz.Reason = fmt.Sprintf("Failed to determine a challenge solver: %v", err)
+		return nil, nil
+	}
+
+	return ch, nil
+}
+
+// preAuthorizationFailed returns true if the given error cannot be fixed by
+// retrying the request.
+func preAuthorizationFailed(err error) bool {
+	var acmeErr *acmeapi.Error
+	return errors.Is(err, client.ErrPreAuthorizationNotSupported) ||
+		(errors.As(err, &acmeErr) && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500)
+}
+
+// buildPreAuthorizationChallenge builds the Challenge resource for the given
+// pending authorization, using the most specific of the issuer's solvers.
+func (a *Acme) buildPreAuthorizationChallenge(ctx context.Context, cl client.Interface, ns string, authz *acmeapi.Authorization) (*cmacme.Challenge, error) {
+	offered := make(map[cmacme.ACMEChallengeType]*acmeapi.Challenge)
+	var types []cmacme.ACMEChallengeType
+	for _, ch := range authz.Challenges {
+		var t cmacme.ACMEChallengeType
+		switch ch.Type {
+		case "http-01":
+			t = cmacme.ACMEChallengeTypeHTTP01
+		case "dns-01":
+			t = cmacme.ACMEChallengeTypeDNS01
+		default:
+			continue
+		}
+		if _, ok := offered[t]; !ok {
+			offered[t] = ch
+			types = append(types, t)
+		}
+	}
+
+	selection, err := solverselection.Select(ctx, a.issuer.GetSpec().ACME.Solvers, solverselection.Request{
+		DNSName:        authz.Identifier.Value,
+		Wildcard:       authz.Wildcard,
+		ChallengeTypes: types,
+	})
+	if err != nil {
+		return nil, err
+	}
+	selected := offered[selection.Type]
+
+	var key string
+	if selection.Type == cmacme.ACMEChallengeTypeHTTP01 {
+		key, err = cl.HTTP01ChallengeResponse(selected.Token)
+	} else {
+		key, err = cl.DNS01ChallengeRecord(selected.Token)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kind := v1.IssuerKind
+	if _, ok := a.issuer.(*v1.ClusterIssuer); ok {
+		kind = v1.ClusterIssuerKind
+	}
+	spec := cmacme.ChallengeSpec{
+		AuthorizationURL: authz.URI,
+		Type:             selection.Type,
+		URL:              selected.URI,
+		DNSName:          authz.Identifier.Value,
+		Token:            selected.Token,
+		Key:              key,
+		Solver:           selection.Solver,
+		Wildcard:         authz.Wildcard,
+		IssuerRef: cmmeta.ObjectReference{
+			Name:  a.issuer.GetName(),
+			Kind:  kind,
+			Group: v1.SchemeGroupVersion.Group,
+		},
+	}
+	name, err := apiutil.ComputeName(a.issuer.GetName(), spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       ns,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(a.issuer, v1.SchemeGroupVersion.WithKind(kind))},
+		},
+		Spec: spec,
+	}, nil
+}
+
+// syncPreAuthorizationChallenges creates the required Challenges which do not
+// exist yet, and deletes the Challenges owned by the issuer which are no
+// longer required.
+func (a *Acme) syncPreAuthorizationChallenges(ctx context.Context, ns string, required []*cmacme.Challenge) error {
+	names := sets.NewString()
+	for _, ch := range required {
+		names.Insert(ch.Name)
+		_, err := a.challengeLister.Challenges(ns).Get(ch.Name)
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err = a.cmClient.AcmeV1().Challenges(ns).Create(ctx, ch, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	chs, err := a.challengeLister.Challenges(ns).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, ch := range chs {
+		if !metav1.IsControlledBy(ch, a.issuer) || names.Has(ch.Name) || ch.DeletionTimestamp != nil {
+			continue
+		}
+		err := a.cmClient.AcmeV1().Challenges(ns).Delete(ctx, ch.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
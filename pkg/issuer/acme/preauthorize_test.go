@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
+)
+
+func TestAcme_preAuthorize(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	expires := metav1.NewTime(now.Add(time.Hour))
+
+	baseIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerACMEURL(acmev2Prod),
+		gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}}}),
+	)
+	baseIssuer.UID = "test-uid"
+
+	pendingAuthz := &acmeapi.Authorization{
+		URI:        "https://acme.example.com/authz/1",
+		Status:     acmeapi.StatusPending,
+		Expires:    expires.Time,
+		Identifier: acmeapi.AuthzID{Type: "dns", Value: "example.com"},
+		Challenges: []*acmeapi.Challenge{
+			{Type: "dns-01", URI: "https://acme.example.com/chall/1", Token: "dns-token"},
+			{Type: "http-01", URI: "https://acme.example.com/chall/2", Token: "http-token"},
+		},
+	}
+	validAuthz := &acmeapi.Authorization{
+		URI:        "https://acme.example.com/authz/1",
+		Status:     acmeapi.StatusValid,
+		Expires:    expires.Time,
+		Identifier: acmeapi.AuthzID{Type: "dns", Value: "example.com"},
+	}
+	pending := cmacme.ACMEPreAuthorization{
+		DNSName: "example.com",
+		URL:     "https://acme.example.com/authz/1",
+		State:   cmacme.Pending,
+		Expires: &expires,
+	}
+
+	// ownedChallenge is a Challenge owned by the issuer which is not required
+	// by any pending pre-authorization.
+	ownedChallenge := gen.Challenge("test-issuer-leftover")
+	ownedChallenge.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(baseIssuer, cmapi.SchemeGroupVersion.WithKind(cmapi.IssuerKind))}
+
+	tests := map[string]struct {
+		issuer     *cmapi.Issuer
+		challenges []runtime.Object
+		authorize  func(ctx context.Context, domain string) (*acmeapi.Authorization, error)
+		getAuthz   func(ctx context.Context, url string) (*acmeapi.Authorization, error)
+
+		expPreAuthzs []cmacme.ACMEPreAuthorization
+		expActions   []string
+		expErr       bool
+	}{
+		"issuer without pre-authorized DNS names does nothing": {
+			issuer: baseIssuer,
+		},
+		"authorization is requested and a Challenge is created": {
+			issuer: gen.IssuerFrom(baseIssuer, gen.SetIssuerACMEPreAuthorizedDNSNames("example.com")),
+			authorize: func(_ context.Context, domain string) (*acmeapi.Authorization, error) {
+				if domain != "example.com" {
+					t.Errorf("unexpected domain %q", domain)
+				}
+				return pendingAuthz, nil
+			},
+			expPreAuthzs: []cmacme.ACMEPreAuthorization{pending},
+			expActions:   []string{"create"},
+		},
+		"authorization which is already valid does not need a Challenge": {
+			issuer: gen.IssuerFrom(baseIssuer, gen.SetIssuerACMEPreAuthorizedDNSNames("example.com")),
+			authorize: func(context.Context, string) (*acmeapi.Authorization, error) {
+				return validAuthz, nil
+			},
+			expPreAuthzs: []cmacme.ACMEPreAuthorization{{DNSName: "example.com", URL: validAuthz.URI, State: cmacme.Valid, Expires: &expires}},
+		},
+		"ACME server without pre-authorization support marks the authorization as errored": {
+			issuer: gen.IssuerFrom(baseIssuer, gen.SetIssuerACMEPreAuthorizedDNSNames("example.com")),
+			authorize: func(context.Context, string) (*acmeapi.Authorization, error) {
+				return nil, acmecl.ErrPreAuthorizationNotSupported
+			},
+			expPreAuthzs: []cmacme.ACMEPreAuthorization{{
+				DNSName: "example.com",
+				State:   cmacme.Errored,
+				Reason:  "Failed to request authorization: " + acmecl.ErrPreAuthorizationNotSupported.Error(),
+			}},
+		},
+		"transient failure to request an authorization is retried": {
+			issuer: gen.IssuerFrom(baseIssuer, gen.SetIssuerACMEPreAuthorizedDNSNames("example.com")),
+			authorize: func(context.Context, string) (*acmeapi.Authorization, error) {
+				return nil, &acmeapi.Error{StatusCode: 500}
+			},
+			expPreAuthzs: []cmacme.ACMEPreAuthorization{{DNSName: "example.com"}},
+			expErr:       true,
+		},
+		"authorization which became valid has its Challenge deleted": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEPreAuthorizedDNSNames("example.com"),
+				gen.SetIssuerACMEPreAuthorizations(pending)),
+			challenges: []runtime.Object{ownedChallenge},
+			getAuthz: func(_ context.Context, url string) (*acmeapi.Authorization, error) {
+				if url != pending.URL {
+					t.Errorf("unexpected authorization URL %q", url)
+				}
+				return validAuthz, nil
+			},
+			expPreAuthzs: []cmacme.ACMEPreAuthorization{{DNSName: "example.com", URL: validAuthz.URI, State: cmacme.Valid, Expires: &expires}},
+			expActions:   []string{"delete"},
+		},
+		"removed DNS name is forgotten and its Challenge is deleted": {
+			issuer:     gen.IssuerFrom(baseIssuer, gen.SetIssuerACMEPreAuthorizations(pending)),
+			challenges: []runtime.Object{ownedChallenge, gen.Challenge("not-owned")},
+			expActions: []string{"delete"},
+		},
+		"expired authorization is requested again": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEPreAuthorizedDNSNames("example.com"),
+				gen.SetIssuerACMEPreAuthorizations(cmacme.ACMEPreAuthorization{
+					DNSName: "example.com",
+					URL:     "https://acme.example.com/authz/0",
+					State:   cmacme.Valid,
+					Expires: &metav1.Time{Time: now.Add(-time.Minute)},
+				})),
+			authorize: func(context.Context, string) (*acmeapi.Authorization, error) {
+				return pendingAuthz, nil
+			},
+			expPreAuthzs: []cmacme.ACMEPreAuthorization{pending},
+			expActions:   []string{"create"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cl := &acmecl.FakeACME{
+				FakeAuthorize:        test.authorize,
+				FakeGetAuthorization: test.getAuthz,
+				FakeHTTP01ChallengeResponse: func(token string) (string, error) {
+					return token + ".key", nil
+				},
+			}
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, obj := range test.challenges {
+				if err := indexer.Add(obj); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cmClient := cmfake.NewSimpleClientset(test.challenges...)
+
+			a := Acme{
+				issuer: test.issuer.DeepCopy(),
+				accountRegistry: &fakeregistry.FakeRegistry{
+					GetClientFunc: func(string) (acmecl.Interface, error) {
+						return cl, nil
+					},
+				},
+				cmClient:        cmClient,
+				challengeLister: cmacmelisters.NewChallengeLister(indexer),
+				clock:           fakeclock.NewFakeClock(now),
+			}
+
+			err := a.preAuthorize(context.Background(), gen.DefaultTestNamespace)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+
+			var preAuthzs []cmacme.ACMEPreAuthorization
+			if status := a.issuer.GetStatus().ACME; status != nil {
+				preAuthzs = status.PreAuthorizations
+			}
+			if !reflect.DeepEqual(preAuthzs, test.expPreAuthzs) {
+				t.Errorf("unexpected pre-authorizations, exp=%+v got=%+v", test.expPreAuthzs, preAuthzs)
+			}
+
+			var actions []string
+			for _, action := range cmClient.Actions() {
+				actions = append(actions, action.GetVerb())
+				if create, ok := action.(coretesting.CreateAction); ok {
+					ch := create.GetObject().(*cmacme.Challenge)
+					if ch.Spec.Type != cmacme.ACMEChallengeTypeHTTP01 || ch.Spec.Key != "http-token.key" || ch.Spec.AuthorizationURL != pendingAuthz.URI {
+						t.Errorf("unexpected Challenge spec: %+v", ch.Spec)
+					}
+					if !metav1.IsControlledBy(ch, a.issuer) {
+						t.Errorf("expected Challenge to be owned by the issuer")
+					}
+				}
+			}
+			if !reflect.DeepEqual(actions, test.expActions) {
+				t.Errorf("unexpected actions, exp=%v got=%v", test.expActions, actions)
+			}
+		})
+	}
+}
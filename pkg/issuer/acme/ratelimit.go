@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/ratelimit"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// updateRateLimitBudgets records the rate limit budget of each registered
+// domain the issuer has recently issued certificates for in the issuer's
+// status and metrics. The certificates issued are those of the
+// CertificateRequests of the issuer which are ready, so certificates whose
+// CertificateRequest has been deleted, or which were issued by other issuers
+// or ACME clients, are not counted.
+func (a *Acme) updateRateLimitBudgets(ctx context.Context) {
+	if a.certificateRequestLister == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+
+	status := a.issuer.GetStatus().ACMEStatus()
+	kind, namespace := v1.IssuerKind, a.issuer.GetObjectMeta().Namespace
+	if _, ok := a.issuer.(*v1.ClusterIssuer); ok {
+		kind, namespace = v1.ClusterIssuerKind, corev1.NamespaceAll
+	}
+
+	limit, ok := ratelimit.LimitForIssuer(a.issuer.GetSpec().ACME)
+	if !ok {
+		status.RateLimitBudgets = nil
+		a.updateRateLimitBudgetMetrics(kind, nil)
+		return
+	}
+
+	crs, err := a.certificateRequestLister.CertificateRequests(namespace).List(labels.Everything())
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to list CertificateRequests", "error", err)
+		return
+	}
+
+	var issuances []ratelimit.Issuance
+	for _, cr := range crs {
+		if cr.Spec.IssuerRef.Name != a.issuer.GetObjectMeta().Name ||
+			apiutil.IssuerKind(cr.Spec.IssuerRef) != kind ||
+			(cr.Spec.IssuerRef.Group != "" && cr.Spec.IssuerRef.Group != v1.SchemeGroupVersion.Group) {
+			continue
+		}
+		ready := apiutil.GetCertificateRequestCondition(cr, v1.CertificateRequestConditionReady)
+		if ready == nil || ready.Status != cmmeta.ConditionTrue || ready.Reason != v1.CertificateRequestReasonIssued || ready.LastTransitionTime == nil {
+			continue
+		}
+		csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+		if err != nil {
+			continue
+		}
+		dnsNames := csr.DNSNames
+		if len(csr.Subject.CommonName) > 0 {
+			dnsNames = append(dnsNames, csr.Subject.CommonName)
+		}
+		issuances = append(issuances, ratelimit.Issuance{
+			Time:     ready.LastTransitionTime.Time,
+			DNSNames: dnsNames,
+		})
+	}
+
+	status.RateLimitBudgets = ratelimit.Budgets(limit, issuances, a.clock.Now())
+	a.updateRateLimitBudgetMetrics(kind, status.RateLimitBudgets)
+}
+
+func (a *Acme) updateRateLimitBudgetMetrics(kind string, budgets []cmacme.ACMERateLimitBudgetStatus) {
+	if a.metrics == nil {
+		return
+	}
+	a.metrics.UpdateACMERateLimitBudgets(kind, a.issuer.GetObjectMeta().Namespace, a.issuer.GetObjectMeta().Name, budgets)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/x509"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestAcme_updateRateLimitBudgets(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	issuedRequest := func(name, namespace, issuerName string, issuedAt time.Time, dnsNames ...string) *cmapi.CertificateRequest {
+		csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames(dnsNames...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		issued := metav1.NewTime(issuedAt)
+		return gen.CertificateRequest(name,
+			gen.SetCertificateRequestNamespace(namespace),
+			gen.SetCertificateRequestCSR(csr),
+			gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: issuerName, Kind: cmapi.IssuerKind}),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             cmapi.CertificateRequestReasonIssued,
+				LastTransitionTime: &issued,
+			}))
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, cr := range []*cmapi.CertificateRequest{
+		issuedRequest("recent", "testns", "test-issuer", now.Add(-time.Hour), "a.example.com", "b.example.com", "example.org"),
+		issuedRequest("other-recent", "testns", "test-issuer", now.Add(-2*time.Hour), "c.example.com"),
+		issuedRequest("expired", "testns", "test-issuer", now.Add(-8*24*time.Hour), "d.example.com"),
+		issuedRequest("other-issuer", "testns", "other-issuer", now.Add(-time.Hour), "e.example.com"),
+		issuedRequest("other-namespace", "otherns", "test-issuer", now.Add(-time.Hour), "f.example.com"),
+		gen.CertificateRequest("pending", gen.SetCertificateRequestNamespace("testns"),
+			gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.IssuerKind})),
+	} {
+		if err := indexer.Add(cr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issuer := gen.Issuer("test-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerACMEURL(acmev2Prod),
+	)
+	a := &Acme{
+		issuer:                   issuer,
+		certificateRequestLister: cmlisters.NewCertificateRequestLister(indexer),
+		clock:                    fakeclock.NewFakeClock(now),
+	}
+	a.updateRateLimitBudgets(context.Background())
+
+	expected := []cmacme.ACMERateLimitBudgetStatus{
+		{RegisteredDomain: "example.com", Issued: 2, Remaining: 48},
+		{RegisteredDomain: "example.org", Issued: 1, Remaining: 49},
+	}
+	if got := issuer.Status.ACMEStatus().RateLimitBudgets; !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected budgets %+v, got %+v", expected, got)
+	}
+}
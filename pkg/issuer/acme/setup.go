@@ -21,10 +21,10 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
-	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,30 +33,39 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/acme/client"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 const (
 	errorAccountRegistrationFailed = "ErrRegisterACMEAccount"
 	errorAccountVerificationFailed = "ErrVerifyACMEAccount"
 	errorAccountUpdateFailed       = "ErrUpdateACMEAccount"
+	errorAccountKeyRotationFailed  = "ErrRotateACMEAccountKey"
 	errorInvalidConfig             = "InvalidConfig"
 	errorInvalidURL                = "InvalidURL"
 
 	successAccountRegistered = "ACMEAccountRegistered"
 	successAccountVerified   = "ACMEAccountVerified"
+	successAccountKeyRotated = "ACMEAccountKeyRotated"
 
 	messageAccountRegistrationFailed     = "Failed to register ACME account: "
 	messageAccountVerificationFailed     = "Failed to verify ACME account: "
 	messageAccountUpdateFailed           = "Failed to update ACME account:"
+	messageAccountKeyRotationFailed      = "Failed to rotate ACME account private key: "
+	messageAccountKeyRotated             = "The private key of the ACME account was rotated"
 	messageAccountRegistered             = "The ACME account was registered with the ACME server"
 	messageAccountVerified               = "The ACME account was verified with the ACME server"
 	messageNoSecretKeyGenerationDisabled = "the ACME issuer config has 'disableAccountKeyGeneration' set to true, but the secret was not found: "
+	messageNoExistingAccount             = "no ACME account is registered for the private key, and onlyReturnExistingAccount is set"
 	messageInvalidPrivateKey             = "Account private key is invalid: "
+	messageSolverCredentialsFailed       = "Failed to load the credentials of a DNS01 solver: "
 
 	messageTemplateUpdateToV2              = "Your ACME server URL is set to a v1 endpoint (%s). You should update the spec.acme.server field to %q"
 	messageTemplateNotRSA                  = "ACME private key in %q is not of type RSA"
@@ -155,7 +164,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// We could therefore move the removing of the client up to the start of
 	// this function.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
-	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify)
+	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify, a.httpClientOptions.ForIssuer(a.issuer.GetSpec().ACME).WithTLS(a.issuer.GetSpec().TLS))
 	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
 	// TODO: perform a complex check to determine whether we need to verify
@@ -186,22 +195,72 @@ func (a *Acme) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	a.updateDirectoryMeta(ctx, httpClient)
+	a.updateRateLimitBudgets(ctx)
+
+	// Rotate the account's private key if requested by the annotation. This
+	// is only possible for an account which has already been registered with
+	// the ACME server, new accounts are registered with the current key.
+	rotation, rotationRequested := a.issuer.GetObjectMeta().Annotations[cmacme.AccountKeyRotationAnnotationKey]
+	if rotationRequested &&
+		rotation != a.issuer.GetStatus().ACMEStatus().AccountKeyRotation &&
+		rawAccountURL != "" &&
+		parsedAccountURL.Host == parsedServerURL.Host {
+		log.V(logf.InfoLevel).Info("rotating ACME account private key", "rotation", rotation)
+		newPk, err := a.rotateAccountKey(ctx, cl, httpClient, privateKeySelector, ns)
+		if err != nil {
+			reason = errorAccountKeyRotationFailed
+			msg = messageAccountKeyRotationFailed + err.Error()
+			log.Error(err, "failed to rotate ACME account private key")
+			a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountKeyRotationFailed, msg)
+			return err
+		}
+
+		rsaPk = newPk
+		cl = a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+		a.issuer.GetStatus().ACMEStatus().AccountKeyRotation = rotation
+		a.issuer.GetStatus().ACMEStatus().LastPrivateKeyHash = accounts.PrivateKeyHash(rsaPk)
+		a.recorder.Event(a.issuer, corev1.EventTypeNormal, successAccountKeyRotated, messageAccountKeyRotated)
+	}
+
+	// The account URI in the status belongs to a different private key if
+	// the key has been changed since the account was registered.
+	privateKeyHash := accounts.PrivateKeyHash(rsaPk)
+	lastPrivateKeyHash := a.issuer.GetStatus().ACMEStatus().LastPrivateKeyHash
+	if lastPrivateKeyHash != "" && lastPrivateKeyHash != privateKeyHash && a.issuer.GetStatus().ACMEStatus().URI != "" {
+		log.V(logf.InfoLevel).Info("ACME private key has changed since the ACME account was " +
+			"registered. Re-checking ACME account registration")
+		a.issuer.GetStatus().ACMEStatus().URI = ""
+	}
+
 	hasReadyCondition := apiutil.IssuerHasCondition(a.issuer, v1.IssuerCondition{
 		Type:   v1.IssuerConditionReady,
 		Status: cmmeta.ConditionTrue,
 	})
 
+	// The account is known to be registered for the current private key if
+	// the key's hash was recorded when it was last verified, unless the
+	// ACME server has rejected the account since.
+	accountKnown := hasReadyCondition ||
+		(lastPrivateKeyHash == privateKeyHash && !accountVerificationFailed(a.issuer))
+
 	// If the Host components of the server URL and the account URL match,
 	// and the cached email matches the registered email, then
 	// we skip re-checking the account status to save excess calls to the
 	// ACME api.
-	if hasReadyCondition &&
+	if accountKnown &&
 		a.issuer.GetStatus().ACMEStatus().URI != "" &&
 		parsedAccountURL.Host == parsedServerURL.Host &&
 		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == a.issuer.GetSpec().ACME.Email {
 		log.V(logf.InfoLevel).Info("skipping re-verifying ACME account as cached registration " +
 			"details look sufficient")
 
+		// Clients for an account whose private key hash has been recorded
+		// can use its URI as their key ID without looking it up again.
+		if lastPrivateKeyHash == privateKeyHash {
+			a.accountRegistry.SetAccountURL(a.issuer.GetSpec().ACME.Server, rsaPk, a.issuer.GetStatus().ACMEStatus().URI)
+		}
+
 		// Updating issuer's Ready condition here will ensure that observed
 		// generation gets bumped correctly if this re-sync was triggered by a
 		// spec change. Last transition time on the condition will not be modified.
@@ -212,7 +271,13 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 		// ensure the cached client in the account registry is up to date
 		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
-		return nil
+
+		if err := a.checkSolverCredentials(ns); err != nil {
+			status = cmmeta.ConditionFalse
+			reason, msg = solverCredentialsReason(err), messageSolverCredentialsFailed+err.Error()
+			return ignoreNotFound(err)
+		}
+		return a.preAuthorize(ctx, ns)
 	}
 
 	if parsedAccountURL.Host != parsedServerURL.Host {
@@ -258,6 +323,14 @@ func (a *Acme) Setup(ctx context.Context) error {
 		msg = messageAccountRegistrationFailed + err.Error()
 		log.Error(err, "failed to register an ACME account")
 
+		// Retrying will not help if there is no existing account to adopt,
+		// as a new account is never registered.
+		if err == acmeapi.ErrNoAccount {
+			msg = messageAccountRegistrationFailed + messageNoExistingAccount
+			a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountRegistrationFailed, msg)
+			return nil
+		}
+
 		acmeErr, ok := err.(*acmeapi.Error)
 		// If this is not an ACME error, we will simply return it and retry later
 		if !ok {
@@ -312,12 +385,70 @@ func (a *Acme) Setup(ctx context.Context) error {
 	msg = messageAccountRegistered
 	a.issuer.GetStatus().ACMEStatus().URI = account.URI
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
+	a.issuer.GetStatus().ACMEStatus().LastPrivateKeyHash = privateKeyHash
+	a.accountRegistry.SetAccountURL(a.issuer.GetSpec().ACME.Server, rsaPk, account.URI)
 	// ensure the cached client in the account registry is up to date
 	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
+	if err := a.checkSolverCredentials(ns); err != nil {
+		status = cmmeta.ConditionFalse
+		reason, msg = solverCredentialsReason(err), messageSolverCredentialsFailed+err.Error()
+		return ignoreNotFound(err)
+	}
+	return a.preAuthorize(ctx, ns)
+}
+
+// checkSolverCredentials returns an error if a Secret holding the
+// credentials of the DNS provider of one of the issuer's DNS01 solvers cannot
+// be loaded.
+func (a *Acme) checkSolverCredentials(ns string) error {
+	for _, solver := range a.issuer.GetSpec().ACME.Solvers {
+		for _, ref := range issuer.DNS01SecretRefs(solver.DNS01) {
+			if ref == nil || ref.Name == "" {
+				continue
+			}
+			if _, err := a.secretsLister.Secrets(ns).Get(ref.Name); err != nil {
+				return fmt.Errorf("failed to load secret %q: %w", ns+"/"+ref.Name, err)
+			}
+		}
+	}
 	return nil
 }
 
+// solverCredentialsReason returns the reason for the Ready condition of an
+// issuer whose DNS01 solver credentials could not be loaded.
+func solverCredentialsReason(err error) string {
+	if reason := errors.CredentialsErrorReason(err); reason != "" {
+		return reason
+	}
+	return errorInvalidConfig
+}
+
+// ignoreNotFound returns nil if err is a NotFound error. The issuer is
+// re-synced when a Secret it references is created.
+func ignoreNotFound(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// accountVerificationFailed returns true if the issuer is not ready because
+// its ACME account could not be verified, registered or updated, in which
+// case the account must be verified again with the ACME server.
+func accountVerificationFailed(iss v1.GenericIssuer) bool {
+	for _, cond := range iss.GetStatus().Conditions {
+		if cond.Type != v1.IssuerConditionReady || cond.Status == cmmeta.ConditionTrue {
+			continue
+		}
+		switch cond.Reason {
+		case errorAccountVerificationFailed, errorAccountRegistrationFailed, errorAccountUpdateFailed:
+			return true
+		}
+	}
+	return false
+}
+
 func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, specEmail string) (*acmeapi.Account, string, error) {
 	log := logf.FromContext(ctx)
 
@@ -353,7 +484,13 @@ func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.
 // account with the clients private key already exists, it will attempt to look
 // up and verify the corresponding account, and will return that. If this fails
 // due to a not found error it will register a new account with the given key.
+// If the issuer only uses existing accounts, the account is looked up without
+// registering a new one, and acmeapi.ErrNoAccount is returned if none exists.
 func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAccount *acmeapi.ExternalAccountBinding) (*acmeapi.Account, error) {
+	if a.issuer.GetSpec().ACME.OnlyReturnExistingAccount {
+		return cl.GetReg(ctx, "")
+	}
+
 	emailurl := []string(nil)
 	if a.issuer.GetSpec().ACME.Email != "" {
 		emailurl = []string{fmt.Sprintf("mailto:%s", strings.ToLower(a.issuer.GetSpec().ACME.Email))}
@@ -436,6 +573,74 @@ func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKey
 	return accountPrivKey, err
 }
 
+// pendingAccountKeySuffix is appended to the key of the account private key
+// Secret's data under which a new private key is stored while it is being
+// rolled over with the ACME server. Storing it first means the new key is not
+// lost if the Secret cannot be updated once the ACME server has accepted it.
+const pendingAccountKeySuffix = ".next"
+
+// rotateAccountKey replaces the private key of the registered ACME account
+// with a newly generated one using the ACME server's keyChange endpoint, and
+// replaces the key in the account private key Secret. All updates of the
+// Secret are made with its resource version, so that they fail rather than
+// overwrite concurrent changes.
+func (a *Acme) rotateAccountKey(ctx context.Context, cl client.Interface, httpClient *http.Client, sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
+	log := logf.FromContext(ctx)
+	secretsClient := a.secretsClient.Secrets(ns)
+	pendingKey := sel.Key + pendingAccountKeySuffix
+
+	secret, err := secretsClient.Get(ctx, sel.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse a new key left behind by a previous attempt, as the ACME server
+	// may already have accepted it.
+	var newPk *rsa.PrivateKey
+	if data, ok := secret.Data[pendingKey]; ok {
+		pk, err := pki.DecodePrivateKeyBytes(data)
+		if err != nil {
+			return nil, errors.NewInvalidData("failed to decode pending ACME account private key %q: %v", pendingKey, err)
+		}
+		if newPk, ok = pk.(*rsa.PrivateKey); !ok {
+			return nil, errors.NewInvalidData("pending ACME account private key %q is not of type RSA", pendingKey)
+		}
+	} else {
+		newPk, err = pki.GenerateRSAPrivateKey(pki.MinRSAKeySize)
+		if err != nil {
+			return nil, err
+		}
+		secret = secret.DeepCopy()
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[pendingKey] = pki.EncodePKCS1PrivateKey(newPk)
+		if secret, err = secretsClient.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cl.AccountKeyRollover(ctx, newPk); err != nil {
+		// The key may have been rolled over by a previous attempt whose
+		// response was lost, in which case the new key now identifies the
+		// account.
+		acc, getErr := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, newPk, a.userAgent).GetReg(ctx, "")
+		if getErr != nil || acc.URI != a.issuer.GetStatus().ACMEStatus().URI {
+			return nil, err
+		}
+		log.V(logf.DebugLevel).Info("ACME account private key has already been rolled over")
+	}
+
+	secret = secret.DeepCopy()
+	secret.Data[sel.Key] = pki.EncodePKCS1PrivateKey(newPk)
+	delete(secret.Data, pendingKey)
+	if _, err := secretsClient.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, err
+	}
+
+	return newPk, nil
+}
+
 var (
 	acmev1Staging = "https://acme-staging.api.letsencrypt.org/directory"
 	acmev1Prod    = "https://acme-v01.api.letsencrypt.org/directory"
@@ -450,3 +655,21 @@ var acmev1ToV2Mappings = map[string]string{
 	fmt.Sprintf("%s/", acmev1Prod):    acmev2Prod,
 	fmt.Sprintf("%s/", acmev1Staging): acmev2Staging,
 }
+
+// updateDirectoryMeta records the CAA identities and terms of service
+// advertised in the directory of the ACME server in the issuer's status.
+// Failing to fetch the directory does not fail the setup of the issuer, as
+// these status fields are informational.
+func (a *Acme) updateDirectoryMeta(ctx context.Context, httpClient *http.Client) {
+	if a.directoryMetaCache == nil {
+		return
+	}
+	meta, err := a.directoryMetaCache.Get(ctx, httpClient, a.issuer.GetSpec().ACME.Server)
+	if err != nil {
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("failed to fetch the metadata of the ACME directory", "error", err)
+		return
+	}
+	status := a.issuer.GetStatus().ACMEStatus()
+	status.CAAIdentities = meta.CAAIdentities
+	status.TermsOfService = meta.TermsOfService
+}
@@ -22,15 +22,18 @@ import (
 	"crypto/rsa"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
 	"time"
 
-	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -46,6 +49,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/coreclients"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	acmeapi "github.com/cert-manager/cert-manager/third_party/forked/acme"
 )
 
 func TestAcme_Setup(t *testing.T) {
@@ -94,6 +98,17 @@ func TestAcme_Setup(t *testing.T) {
 		eabSecret = gen.Secret(someString,
 			gen.SetSecretData(map[string][]byte{"key": []byte("ZEdWemRBbz0K")}))
 
+		// dns01Solver reads the credentials of its DNS provider from the
+		// 'cloudflare' secret.
+		dns01Solver = cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{
+			Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+				APIToken: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloudflare"},
+					Key:                  "api-token",
+				},
+			},
+		}}
+
 		// 'dGVzdAo=\n' is 'ZEdWemRBbz0K' decoded + a newline.
 		// This is the decoded EAB key that we send to the ACME server.
 		// TODO: could the newline cause any issues?
@@ -130,8 +145,14 @@ func TestAcme_Setup(t *testing.T) {
 		eabSecret       *corev1.Secret
 		eabSecretGetErr error
 
+		// Secrets returned by the secrets lister, used to check the
+		// credentials of DNS01 solvers.
+		solverSecrets []*corev1.Secret
+
 		// expected ACME account passed to cl.Register
 		expectedRegisteredAcc *acmeapi.Account
+		// expected account URL recorded in the accounts registry
+		expectedAccountURL string
 		// expected issuer conditions after Setup has been called.
 		expectedConditions []cmapi.IssuerCondition
 		expectedEvents     []string
@@ -263,6 +284,94 @@ func TestAcme_Setup(t *testing.T) {
 			removeClientShouldBeCalled: true,
 			addClientShouldBeCalled:    true,
 		},
+		"ACME Issuer is ready, but the credentials secret of a DNS01 solver does not exist": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{dns01Solver}),
+				gen.AddIssuerCondition(
+					*gen.IssuerConditionFrom(readyTrueCondition,
+						gen.SetIssuerConditionStatus(cmmeta.ConditionTrue)))),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionFalse),
+					gen.SetIssuerConditionMessage(messageSolverCredentialsFailed+`failed to load secret "test-ns/cloudflare": secret "cloudflare" not found`),
+					gen.SetIssuerConditionReason(cmapi.IssuerReasonSecretNotFound)),
+			},
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+		},
+		"ACME Issuer is ready, and the credentials secret of a DNS01 solver exists": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{dns01Solver}),
+				gen.AddIssuerCondition(
+					*gen.IssuerConditionFrom(readyTrueCondition,
+						gen.SetIssuerConditionStatus(cmmeta.ConditionTrue)))),
+			solverSecrets: []*corev1.Secret{
+				gen.Secret("cloudflare", gen.SetSecretNamespace("test-ns")),
+			},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionMessage(messageAccountRegistered),
+					gen.SetIssuerConditionReason(successAccountRegistered)),
+			},
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+		},
+		"ACME Issuer is not ready, but the account was registered for the current private key": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEmail(someEmail),
+				gen.SetIssuerACMELastRegisteredEmail(someEmail),
+				gen.SetIssuerACMELastPrivateKeyHash(accounts.PrivateKeyHash(rsaPrivKey.(*rsa.PrivateKey))),
+				gen.AddIssuerCondition(
+					*gen.IssuerConditionFrom(readyFalseCondition,
+						gen.SetIssuerConditionReason(errorInvalidURL)))),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition),
+			},
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			expectedAccountURL:         acmev2Prod,
+		},
+		"ACME account was registered for the current private key, but its verification failed": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEmail(someEmail),
+				gen.SetIssuerACMELastRegisteredEmail(someEmail),
+				gen.SetIssuerACMELastPrivateKeyHash(accounts.PrivateKeyHash(rsaPrivKey.(*rsa.PrivateKey))),
+				gen.AddIssuerCondition(
+					*gen.IssuerConditionFrom(readyFalseCondition,
+						gen.SetIssuerConditionReason(errorAccountRegistrationFailed)))),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition),
+			},
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			expectedRegisteredAcc:      &acmeapi.Account{Contact: []string{someEmailURL}},
+		},
+		"ACME Issuer is ready, but the account was registered for a different private key": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEmail(someEmail),
+				gen.SetIssuerACMELastRegisteredEmail(someEmail),
+				gen.SetIssuerACMELastPrivateKeyHash("other"),
+				gen.AddIssuerCondition(*gen.IssuerConditionFrom(readyTrueCondition))),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition),
+			},
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			expectedRegisteredAcc:      &acmeapi.Account{Contact: []string{someEmailURL}},
+		},
 		"EAB for issuer specified, but the corresponding secret is not found": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEEAB(someString, someString)),
@@ -343,6 +452,34 @@ func TestAcme_Setup(t *testing.T) {
 			},
 			wantsErr: true,
 		},
+		"ACME account is only looked up if only existing accounts are used": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEOnlyReturnExistingAccount(true)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			getRegAcc:                  &acmeapi.Account{URI: "https://acme.example.com/acct/1"},
+			expectedAccountURL:         "https://acme.example.com/acct/1",
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionReason(successAccountRegistered),
+					gen.SetIssuerConditionMessage(messageAccountRegistered)),
+			},
+		},
+		"ACME account does not exist and only existing accounts are used": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEOnlyReturnExistingAccount(true)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			getRegErr:                  acmeapi.ErrNoAccount,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+messageNoExistingAccount)),
+			},
+			expectedEvents: []string{fmt.Sprintf("Warning %s %s%s", errorAccountRegistrationFailed, messageAccountRegistrationFailed, messageNoExistingAccount)},
+		},
 		"ACME account with EAB registered successfully": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEEAB(someString, someString)),
@@ -407,6 +544,7 @@ func TestAcme_Setup(t *testing.T) {
 			// Mock ACME accounts registry.
 			removeClientWasCalled := false
 			addClientWasCalled := false
+			gotAccountURL := ""
 			ar := &fakeregistry.FakeRegistry{
 				RemoveClientFunc: func(string) {
 					removeClientWasCalled = true
@@ -414,6 +552,9 @@ func TestAcme_Setup(t *testing.T) {
 				AddClientFunc: func(string, cmacme.ACMEIssuer, *rsa.PrivateKey, string) {
 					addClientWasCalled = true
 				},
+				SetAccountURLFunc: func(_ string, _ *rsa.PrivateKey, accountURL string) {
+					gotAccountURL = accountURL
+				},
 			}
 
 			// Mock ACME client.
@@ -428,11 +569,20 @@ func TestAcme_Setup(t *testing.T) {
 				},
 			}
 
+			// Secrets lister used to check the credentials of DNS01 solvers.
+			secretsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, secret := range test.solverSecrets {
+				if err := secretsIndexer.Add(secret); err != nil {
+					t.Fatal(err)
+				}
+			}
+
 			// Mock events recorder.
 			recorder := new(controllertest.FakeRecorder)
 			a := Acme{
 				issuer:          test.issuer,
 				secretsClient:   secretsClient,
+				secretsLister:   corelisters.NewSecretLister(secretsIndexer),
 				accountRegistry: ar,
 				keyFromSecret:   kfs,
 				clientBuilder:   clientBuilderMock(&cl),
@@ -463,6 +613,12 @@ func TestAcme_Setup(t *testing.T) {
 					addClientWasCalled)
 			}
 
+			// Verify that the expected account URL was recorded.
+			if gotAccountURL != test.expectedAccountURL {
+				t.Errorf("Expected account URL %q to be recorded in the accounts registry, got %q",
+					test.expectedAccountURL, gotAccountURL)
+			}
+
 			// Verify that the expected account value was passed when the
 			// account was registered.
 			if !reflect.DeepEqual(gotAcc, test.expectedRegisteredAcc) {
@@ -489,6 +645,115 @@ func TestAcme_Setup(t *testing.T) {
 	}
 }
 
+func TestAcme_rotateAccountKey(t *testing.T) {
+	const accountURI = "https://acme.example.com/acct/1"
+	oldKey := mustGenerateRSAKey(t).(*rsa.PrivateKey)
+	pendingKey := mustGenerateRSAKey(t).(*rsa.PrivateKey)
+	sel := cmmeta.SecretKeySelector{
+		LocalObjectReference: cmmeta.LocalObjectReference{Name: "account-key"},
+		Key:                  corev1.TLSPrivateKeyKey,
+	}
+
+	tests := map[string]struct {
+		secretData  map[string][]byte
+		rolloverErr error
+		getRegAcc   *acmeapi.Account
+		getRegErr   error
+
+		expPendingKey bool
+		expErr        bool
+	}{
+		"a new key is generated and replaces the old key": {
+			secretData: map[string][]byte{corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(oldKey)},
+		},
+		"a pending key from a previous attempt is reused": {
+			secretData: map[string][]byte{
+				corev1.TLSPrivateKeyKey:           pki.EncodePKCS1PrivateKey(oldKey),
+				corev1.TLSPrivateKeyKey + ".next": pki.EncodePKCS1PrivateKey(pendingKey),
+			},
+		},
+		"a key which has already been rolled over replaces the old key": {
+			secretData: map[string][]byte{
+				corev1.TLSPrivateKeyKey:           pki.EncodePKCS1PrivateKey(oldKey),
+				corev1.TLSPrivateKeyKey + ".next": pki.EncodePKCS1PrivateKey(pendingKey),
+			},
+			rolloverErr: &acmeapi.Error{StatusCode: 401},
+			getRegAcc:   &acmeapi.Account{URI: accountURI},
+		},
+		"the pending key is kept if the rollover fails": {
+			secretData:    map[string][]byte{corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(oldKey)},
+			rolloverErr:   &acmeapi.Error{StatusCode: 500},
+			getRegErr:     acmeapi.ErrNoAccount,
+			expPendingKey: true,
+			expErr:        true,
+		},
+		"an invalid pending key fails the rotation": {
+			secretData: map[string][]byte{
+				corev1.TLSPrivateKeyKey:           pki.EncodePKCS1PrivateKey(oldKey),
+				corev1.TLSPrivateKeyKey + ".next": []byte("invalid"),
+			},
+			expPendingKey: true,
+			expErr:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset(gen.Secret(sel.Name,
+				gen.SetSecretNamespace(gen.DefaultTestNamespace),
+				gen.SetSecretData(test.secretData)))
+
+			var rolledOverKey crypto.Signer
+			cl := acmecl.FakeACME{
+				FakeAccountKeyRollover: func(_ context.Context, newKey crypto.Signer) error {
+					rolledOverKey = newKey
+					return test.rolloverErr
+				},
+				FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+					return test.getRegAcc, test.getRegErr
+				},
+			}
+
+			a := Acme{
+				issuer: gen.Issuer("test-issuer",
+					gen.SetIssuerACMEURL(acmev2Prod),
+					gen.SetIssuerACMEAccountURL(accountURI)),
+				secretsClient: kubeClient.CoreV1(),
+				clientBuilder: clientBuilderMock(&cl),
+			}
+
+			newKey, err := a.rotateAccountKey(context.Background(), &cl, nil, sel, gen.DefaultTestNamespace)
+			if test.expErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+
+			secret, err := kubeClient.CoreV1().Secrets(gen.DefaultTestNamespace).Get(context.Background(), sel.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := secret.Data[sel.Key+".next"]; ok != test.expPendingKey {
+				t.Errorf("unexpected pending key in Secret, exp=%t got=%t", test.expPendingKey, ok)
+			}
+			if test.expErr {
+				if !reflect.DeepEqual(secret.Data[sel.Key], pki.EncodePKCS1PrivateKey(oldKey)) {
+					t.Errorf("expected the old key to be kept in the Secret")
+				}
+				return
+			}
+
+			if newKey != rolledOverKey {
+				t.Errorf("expected the returned key to be the key which was rolled over")
+			}
+			if _, ok := test.secretData[sel.Key+".next"]; ok && !newKey.Equal(pendingKey) {
+				t.Errorf("expected the pending key to be rolled over")
+			}
+			if !reflect.DeepEqual(secret.Data[sel.Key], pki.EncodePKCS1PrivateKey(newKey)) {
+				t.Errorf("expected the new key to replace the old key in the Secret")
+			}
+		})
+	}
+}
+
 // keyFromSecretMockBuilder returns a mock implementation of keyFromSecretFunc.
 func keyFromSecretMockBuilder(wasCalled *bool, key crypto.Signer, err error) keyFromSecretFunc {
 	return func(context.Context, string, string, string) (crypto.Signer, error) {
@@ -525,3 +790,33 @@ func mustGenerateRSAKey(t *testing.T) crypto.Signer {
 	}
 	return key
 }
+
+func TestAcme_updateDirectoryMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"meta": {"caaIdentities": ["acme.example.com"], "termsOfService": "https://acme.example.com/tos"}}`))
+	}))
+	defer server.Close()
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerACMEURL(server.URL))
+	a := &Acme{
+		issuer:             issuer,
+		directoryMetaCache: acmecl.NewDirectoryMetaCache(time.Hour),
+	}
+	a.updateDirectoryMeta(context.Background(), server.Client())
+
+	status := issuer.GetStatus().ACMEStatus()
+	if !reflect.DeepEqual(status.CAAIdentities, []string{"acme.example.com"}) {
+		t.Errorf("unexpected CAA identities %v", status.CAAIdentities)
+	}
+	if status.TermsOfService != "https://acme.example.com/tos" {
+		t.Errorf("unexpected terms of service %q", status.TermsOfService)
+	}
+
+	// A failure to fetch the directory leaves the status unchanged.
+	a.issuer = gen.IssuerFrom(issuer, gen.SetIssuerACMEURL(server.URL+"/missing"))
+	server.Close()
+	a.updateDirectoryMeta(context.Background(), server.Client())
+	if a.issuer.GetStatus().ACMEStatus().TermsOfService != "https://acme.example.com/tos" {
+		t.Errorf("expected the terms of service to be retained")
+	}
+}
@@ -17,15 +17,19 @@ limitations under the License.
 package ca
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 const (
@@ -43,22 +47,36 @@ const (
 func (c *CA) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx, "setup")
 
-	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
-	if err != nil {
-		log.Error(err, "error getting signing CA TLS certificate")
-		s := messageErrorGetKeyPair + err.Error()
-		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
-		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
-		return err
-	}
-
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
-	if err != nil {
-		log.Error(err, "error getting signing CA private key")
-		s := messageErrorGetKeyPair + err.Error()
-		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
-		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
-		return err
+	var cert *x509.Certificate
+	if pkcs12 := c.issuer.GetSpec().CA.PKCS12; pkcs12 != nil {
+		certs, _, err := kube.SecretPKCS12KeyPair(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName, pkcs12.PasswordSecretRef)
+		if err != nil {
+			log.Error(err, "error getting signing CA PKCS#12 keypair")
+			s := messageErrorGetKeyPair + err.Error()
+			c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
+			apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
+			return err
+		}
+		cert = certs[0]
+	} else {
+		var err error
+		cert, err = kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+		if err != nil {
+			log.Error(err, "error getting signing CA TLS certificate")
+			s := messageErrorGetKeyPair + err.Error()
+			c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
+			apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
+			return err
+		}
+
+		_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+		if err != nil {
+			log.Error(err, "error getting signing CA private key")
+			s := messageErrorGetKeyPair + err.Error()
+			c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
+			apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
+			return err
+		}
 	}
 
 	log = logf.WithRelatedResourceName(log, c.issuer.GetSpec().CA.SecretName, c.resourceNamespace, "Secret")
@@ -71,9 +89,69 @@ func (c *CA) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	if err := c.recordSigningCA(ctx); err != nil {
+		log.Error(err, "error recording signing CA certificate")
+		s := messageErrorGetKeyPair + err.Error()
+		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorInvalidKeyPair, s)
+		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorInvalidKeyPair, s)
+		return err
+	}
+
 	log.V(logf.DebugLevel).Info("signing CA verified")
 	c.Recorder.Event(c.issuer, corev1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
 	apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successKeyPairVerified, messageKeyPairVerified)
 
 	return nil
 }
+
+// recordSigningCA records the CA certificate of the signing keypair in the
+// status of the Issuer if a rotation overlap is configured. When the keypair
+// has been rotated, the CA certificate used before is kept so that it can be
+// included in the `ca.crt` of certificates issued during the overlap.
+func (c *CA) recordSigningCA(ctx context.Context) error {
+	status := c.issuer.GetStatus()
+	if c.issuer.GetSpec().CA.RotationOverlap == nil {
+		status.CA = nil
+		return nil
+	}
+
+	caCerts, _, err := kube.SecretCAKeyPair(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA)
+	if err != nil {
+		return err
+	}
+	caPEM, err := signingCAPEM(caCerts)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case status.CA == nil || len(status.CA.Certificate) == 0:
+		status.CA = &v1.CAIssuerStatus{Certificate: caPEM}
+	case !bytes.Equal(status.CA.Certificate, caPEM):
+		logf.FromContext(ctx).V(logf.InfoLevel).Info("signing CA certificate has been rotated")
+		now := metav1.NewTime(c.Clock.Now())
+		status.CA = &v1.CAIssuerStatus{
+			Certificate:         caPEM,
+			PreviousCertificate: status.CA.Certificate,
+			LastRotationTime:    &now,
+		}
+	}
+
+	return nil
+}
+
+// signingCAPEM returns the PEM encoded CA certificate included in the `ca.crt`
+// of certificates signed using the given CA certificate chain, i.e. the
+// certificate at the top of the chain.
+func signingCAPEM(caCerts []*x509.Certificate) ([]byte, error) {
+	bundle, err := pki.ParseSingleCertificateChain(caCerts)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle.CAPEM) == 0 {
+		// A single certificate which is not self-signed is the top of the
+		// chain.
+		return bundle.ChainPEM, nil
+	}
+	return bundle.CAPEM, nil
+}
@@ -27,6 +27,21 @@ type Interface interface {
 	Setup(ctx context.Context) error
 }
 
+// Finalizer is implemented by issuers which need to clean up external
+// resources, such as accounts registered with a remote server, before their
+// Issuer or ClusterIssuer resource is removed.
+type Finalizer interface {
+	// Finalizer returns the name of the issuer's finalizer, and whether the
+	// issuer's current configuration requires it to be present on the issuer
+	// resource.
+	Finalizer() (name string, required bool)
+
+	// Finalize cleans up the issuer's external resources once the issuer
+	// resource has been deleted. The finalizer is removed from the resource
+	// once Finalize has returned without an error.
+	Finalize(ctx context.Context) error
+}
+
 type IssueResponse struct {
 	// Certificate is the certificate resource that should be stored in the
 	// target secret.
@@ -44,4 +59,8 @@ type IssueResponse struct {
 	// This field should only be set if the private key field is set, similar
 	// to the Certificate field.
 	CA []byte
+
+	// CrossSignedCertificate is the certificate chain resulting from
+	// additionally signing the request with a cross-signing CA keypair.
+	CrossSignedCertificate []byte
 }
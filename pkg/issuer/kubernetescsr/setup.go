@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetescsr
+
+import (
+	"context"
+	"fmt"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	successReady = "IsReady"
+
+	errorSignerNotAllowed = "SignerNotAllowed"
+)
+
+// Setup marks the issuer as Ready if its signer is allowed by the controller.
+// Whether the configured signer exists can not be determined through the
+// CertificateSigningRequest API, so any problems with the signer are reported
+// on the CertificateRequests instead.
+func (k *KubernetesCSR) Setup(ctx context.Context) error {
+	signerName := k.issuer.GetSpec().KubernetesCSR.SignerName
+	if !k.IssuerOptions.KubernetesCSRSignerAllowed(signerName) {
+		apiutil.SetIssuerCondition(k.issuer, k.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorSignerNotAllowed,
+			fmt.Sprintf("Signer %q is not allowed by the cert-manager controller", signerName))
+		return nil
+	}
+
+	apiutil.SetIssuerCondition(k.issuer, k.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successReady, "")
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// SecretNames returns the names of the Secrets from which an issuer with the
+// given spec reads its keys and credentials, including the credentials of its
+// ACME DNS01 solvers. The Secrets of an Issuer are in its own namespace, and
+// those of a ClusterIssuer in the cluster resource namespace.
+func SecretNames(spec *cmapi.IssuerSpec) []string {
+	var names []string
+	add := func(refs ...*cmmeta.SecretKeySelector) {
+		for _, ref := range refs {
+			if ref != nil && ref.Name != "" {
+				names = append(names, ref.Name)
+			}
+		}
+	}
+
+	switch {
+	case spec.ACME != nil:
+		add(&spec.ACME.PrivateKey)
+		if spec.ACME.ExternalAccountBinding != nil {
+			add(&spec.ACME.ExternalAccountBinding.Key)
+		}
+		for _, solver := range spec.ACME.Solvers {
+			add(DNS01SecretRefs(solver.DNS01)...)
+		}
+
+	case spec.CA != nil:
+		names = append(names, spec.CA.SecretName)
+		if spec.CA.PKCS12 != nil {
+			add(&spec.CA.PKCS12.PasswordSecretRef)
+		}
+
+	case spec.Venafi != nil:
+		if spec.Venafi.TPP != nil {
+			names = append(names, spec.Venafi.TPP.CredentialsRef.Name)
+		}
+		if spec.Venafi.Cloud != nil {
+			add(&spec.Venafi.Cloud.APITokenSecretRef)
+		}
+
+	case spec.Vault != nil:
+		add(spec.Vault.Auth.TokenSecretRef)
+		if spec.Vault.Auth.AppRole != nil {
+			add(&spec.Vault.Auth.AppRole.SecretRef)
+		}
+		if spec.Vault.Auth.Kubernetes != nil {
+			add(&spec.Vault.Auth.Kubernetes.SecretRef)
+		}
+	}
+
+	return names
+}
+
+// DNS01SecretRefs returns references to the Secrets holding the credentials
+// of the DNS provider of a DNS01 solver. References to optional Secrets which
+// are not set may be nil or have an empty name.
+func DNS01SecretRefs(dns01 *cmacme.ACMEChallengeSolverDNS01) []*cmmeta.SecretKeySelector {
+	switch {
+	case dns01 == nil:
+		return nil
+	case dns01.Akamai != nil:
+		return []*cmmeta.SecretKeySelector{&dns01.Akamai.ClientToken, &dns01.Akamai.ClientSecret, &dns01.Akamai.AccessToken}
+	case dns01.CloudDNS != nil:
+		return []*cmmeta.SecretKeySelector{dns01.CloudDNS.ServiceAccount}
+	case dns01.Cloudflare != nil:
+		return []*cmmeta.SecretKeySelector{dns01.Cloudflare.APIKey, dns01.Cloudflare.APIToken}
+	case dns01.Route53 != nil:
+		return []*cmmeta.SecretKeySelector{dns01.Route53.SecretAccessKeyID, &dns01.Route53.SecretAccessKey}
+	case dns01.AzureDNS != nil:
+		return []*cmmeta.SecretKeySelector{dns01.AzureDNS.ClientSecret}
+	case dns01.DigitalOcean != nil:
+		return []*cmmeta.SecretKeySelector{&dns01.DigitalOcean.Token}
+	case dns01.AcmeDNS != nil:
+		return []*cmmeta.SecretKeySelector{&dns01.AcmeDNS.AccountSecret}
+	case dns01.RFC2136 != nil:
+		return []*cmmeta.SecretKeySelector{&dns01.RFC2136.TSIGSecret}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"reflect"
+	"testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestSecretNames(t *testing.T) {
+	ref := func(name string) cmmeta.SecretKeySelector {
+		return cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}}
+	}
+	refPtr := func(name string) *cmmeta.SecretKeySelector {
+		r := ref(name)
+		return &r
+	}
+
+	tests := map[string]struct {
+		spec     cmapi.IssuerSpec
+		expNames []string
+	}{
+		"ACME issuer with an external account binding and DNS01 solvers": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{
+				PrivateKey:             ref("account-key"),
+				ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{Key: ref("eab")},
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+					{DNS01: &cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{APIToken: refPtr("cloudflare")}}},
+					{DNS01: &cmacme.ACMEChallengeSolverDNS01{Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{SecretAccessKey: ref("route53")}}},
+				},
+			}}},
+			expNames: []string{"account-key", "eab", "cloudflare", "route53"},
+		},
+		"CA issuer with a PKCS#12 keypair": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{
+				SecretName: "ca",
+				PKCS12:     &cmapi.CAPKCS12Keypair{PasswordSecretRef: ref("ca-password")},
+			}}},
+			expNames: []string{"ca", "ca-password"},
+		},
+		"Vault issuer using AppRole authentication": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Vault: &cmapi.VaultIssuer{
+				Auth: cmapi.VaultAuth{AppRole: &cmapi.VaultAppRole{SecretRef: ref("approle")}},
+			}}},
+			expNames: []string{"approle"},
+		},
+		"Venafi Cloud issuer": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Venafi: &cmapi.VenafiIssuer{
+				Cloud: &cmapi.VenafiCloud{APITokenSecretRef: ref("api-token")},
+			}}},
+			expNames: []string{"api-token"},
+		},
+		"SelfSigned issuer": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{SelfSigned: &cmapi.SelfSignedIssuer{}}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := SecretNames(&test.spec); !reflect.DeepEqual(got, test.expNames) {
+				t.Errorf("unexpected Secret names, exp=%v got=%v", test.expNames, got)
+			}
+		})
+	}
+}
@@ -25,11 +25,13 @@ import (
 )
 
 type Venafi struct {
-	PingFn                  func() error
-	RequestCertificateFn    func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error)
-	RetrieveCertificateFn   func(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, error)
-	ReadZoneConfigurationFn func() (*endpoint.ZoneConfiguration, error)
-	VerifyCredentialsFn     func() error
+	PingFn                                func() error
+	RequestCertificateFn                  func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error)
+	RetrieveCertificateFn                 func(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, error)
+	RequestServiceGeneratedCertificateFn  func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error)
+	RetrieveServiceGeneratedCertificateFn func(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, []byte, error)
+	ReadZoneConfigurationFn               func() (*endpoint.ZoneConfiguration, error)
+	VerifyCredentialsFn                   func() error
 }
 
 func (v *Venafi) Ping() error {
@@ -44,6 +46,14 @@ func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration ti
 	return v.RetrieveCertificateFn(pickupID, csrPEM, duration, customFields)
 }
 
+func (v *Venafi) RequestServiceGeneratedCertificate(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
+	return v.RequestServiceGeneratedCertificateFn(csrPEM, duration, customFields)
+}
+
+func (v *Venafi) RetrieveServiceGeneratedCertificate(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, []byte, error) {
+	return v.RetrieveServiceGeneratedCertificateFn(pickupID, csrPEM, duration, customFields)
+}
+
 func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
 	return v.ReadZoneConfigurationFn()
 }
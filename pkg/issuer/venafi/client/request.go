@@ -17,7 +17,11 @@ limitations under the License.
 package client
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"strings"
@@ -45,7 +49,7 @@ var ErrorMissingSubject = errors.New("Certificate requests submitted to Venafi i
 // Upon the template being successfully defaulted and validated, the CSR will be sent, as is.
 // It will return a pickup ID which can be used with RetrieveCertificate to get the certificate
 func (v *Venafi) RequestCertificate(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
-	vreq, err := v.buildVReq(csrPEM, duration, customFields)
+	vreq, err := v.buildVReq(csrPEM, duration, customFields, false)
 	if err != nil {
 		return "", err
 	}
@@ -53,7 +57,7 @@ func (v *Venafi) RequestCertificate(csrPEM []byte, duration time.Duration, custo
 }
 
 func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, error) {
-	vreq, err := v.buildVReq(csrPEM, duration, customFields)
+	vreq, err := v.buildVReq(csrPEM, duration, customFields, false)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +78,56 @@ func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration ti
 	return []byte(chain), nil
 }
 
-func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (*certificate.Request, error) {
+// RequestServiceGeneratedCertificate is equivalent to RequestCertificate, but
+// requests that Venafi generates the private key and CSR of the certificate.
+// The CSR is only used for its subject, subject alternative names and key
+// type; the CSR itself is not sent to Venafi.
+func (v *Venafi) RequestServiceGeneratedCertificate(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
+	vreq, err := v.buildVReq(csrPEM, duration, customFields, true)
+	if err != nil {
+		return "", err
+	}
+	return v.vcertClient.RequestCertificate(vreq)
+}
+
+// RetrieveServiceGeneratedCertificate retrieves a certificate requested using
+// RequestServiceGeneratedCertificate, along with the PEM encoded private key
+// that was generated by Venafi.
+func (v *Venafi) RetrieveServiceGeneratedCertificate(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, []byte, error) {
+	vreq, err := v.buildVReq(csrPEM, duration, customFields, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Venafi only returns private keys encrypted with a password, so use a
+	// random one which is only used to decrypt the response.
+	password, err := randomKeyPassword()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vreq.PickupID = pickupID
+	vreq.Timeout = time.Second * 60
+	vreq.FetchPrivateKey = true
+	vreq.KeyPassword = password
+
+	pemCollection, err := v.vcertClient.RetrieveCertificate(vreq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := decryptPrivateKeyPEM([]byte(pemCollection.PrivateKey), []byte(password))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cs := append([]string{pemCollection.Certificate}, pemCollection.Chain...)
+	chain := strings.Join(cs, "\n")
+
+	return []byte(chain), keyPEM, nil
+}
+
+func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields []api.CustomField, serviceGenerated bool) (*certificate.Request, error) {
 	// Retrieve a copy of the Venafi zone.
 	// This contains default values and policy control info that we can apply
 	// and check against locally.
@@ -119,6 +172,16 @@ func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields [
 	}
 	vreq.FriendlyName = friendlyName
 
+	if serviceGenerated {
+		// Have Venafi generate a key of the same type as the one in the CSR,
+		// so that it satisfies the requirements of the Certificate.
+		vreq.CsrOrigin = certificate.ServiceGeneratedCSR
+		if pub, ok := tmpl.PublicKey.(*ecdsa.PublicKey); ok {
+			_ = vreq.KeyCurve.Set(pub.Curve.Params().Name)
+		}
+		return vreq, nil
+	}
+
 	// Set options on the request
 	vreq.CsrOrigin = certificate.UserProvidedCSR
 
@@ -130,6 +193,38 @@ func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields [
 	return vreq, nil
 }
 
+func randomKeyPassword() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate private key password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decryptPrivateKeyPEM decrypts the first PEM block of the given private key
+// returned by Venafi and returns it PEM encoded without encryption.
+func decryptPrivateKeyPEM(keyPEM, password []byte) ([]byte, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("Venafi did not return a private key for the certificate")
+	}
+
+	//nolint:staticcheck // Venafi encrypts private keys using legacy PEM encryption.
+	if x509.IsEncryptedPEMBlock(block) {
+		der, err := x509.DecryptPEMBlock(block, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the private key returned by Venafi: %w", err)
+		}
+		block = &pem.Block{Type: block.Type, Bytes: der}
+	}
+
+	if _, err := pki.DecodePrivateKeyBytes(pem.EncodeToMemory(block)); err != nil {
+		return nil, fmt.Errorf("failed to parse the private key returned by Venafi: %w", err)
+	}
+
+	return pem.EncodeToMemory(block), nil
+}
+
 func convertCustomFieldsToVcert(customFields []api.CustomField) ([]certificate.CustomField, error) {
 	var out []certificate.CustomField
 	if len(customFields) > 0 {
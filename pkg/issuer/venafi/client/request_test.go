@@ -305,3 +305,61 @@ func TestVenafi_RetrieveCertificate(t *testing.T) {
 		})
 	}
 }
+
+func TestDecryptPrivateKeyPEM(t *testing.T) {
+	pk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodeECPrivateKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	//nolint:staticcheck // Venafi encrypts private keys using legacy PEM encryption.
+	encrypted, err := x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte("password"), x509.PEMCipherAES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedPEM := pem.EncodeToMemory(encrypted)
+
+	tests := map[string]struct {
+		keyPEM    []byte
+		password  []byte
+		expKeyPEM []byte
+		expErr    bool
+	}{
+		"an encrypted private key is decrypted": {
+			keyPEM:    encryptedPEM,
+			password:  []byte("password"),
+			expKeyPEM: keyPEM,
+		},
+		"an unencrypted private key is returned unchanged": {
+			keyPEM:    keyPEM,
+			password:  []byte("password"),
+			expKeyPEM: keyPEM,
+		},
+		"an encrypted private key with the wrong password errors": {
+			keyPEM:   encryptedPEM,
+			password: []byte("wrong"),
+			expErr:   true,
+		},
+		"a missing private key errors": {
+			keyPEM:   nil,
+			password: []byte("password"),
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := decryptPrivateKeyPEM(test.keyPEM, test.password)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if string(got) != string(test.expKeyPEM) {
+				t.Errorf("unexpected private key, exp=%q got=%q", test.expKeyPEM, got)
+			}
+		})
+	}
+}
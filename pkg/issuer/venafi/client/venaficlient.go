@@ -17,7 +17,9 @@ limitations under the License.
 package client
 
 import (
+	"crypto/x509"
 	"fmt"
+	"net/http"
 	"time"
 
 	vcert "github.com/Venafi/vcert/v4"
@@ -31,6 +33,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/tlsconfig"
 )
 
 const (
@@ -48,6 +51,8 @@ type VenafiClientBuilder func(namespace string, secretsLister corelisters.Secret
 type Interface interface {
 	RequestCertificate(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error)
 	RetrieveCertificate(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, error)
+	RequestServiceGeneratedCertificate(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error)
+	RetrieveServiceGeneratedCertificate(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, []byte, error)
 	Ping() error
 	ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error)
 	SetClient(endpoint.Connector)
@@ -136,6 +141,10 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 		password := string(tppSecret.Data[tppPasswordKey])
 		accessToken := string(tppSecret.Data[tppAccessTokenKey])
 		caBundle := string(tpp.CABundle)
+		client, err := httpClientForIssuer(iss.GetSpec().TLS, tpp.CABundle)
+		if err != nil {
+			return nil, err
+		}
 
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeTPP,
@@ -149,6 +158,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 				Password:    password,
 				AccessToken: accessToken,
 			},
+			Client: client,
 			// this is needed for local development when tunneling to the TPP server
 			//Client: &http.Client{
 			//	Transport: &http.Transport{
@@ -170,6 +180,10 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			k = cloud.APITokenSecretRef.Key
 		}
 		apiKey := string(cloudSecret.Data[k])
+		client, err := httpClientForIssuer(iss.GetSpec().TLS, nil)
+		if err != nil {
+			return nil, err
+		}
 
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeCloud,
@@ -180,6 +194,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			Credentials: &endpoint.Authentication{
 				APIKey: apiKey,
 			},
+			Client: client,
 		}, nil
 	}
 	// API validation in webhook and in the ClusterIssuer and Issuer controller
@@ -187,6 +202,29 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 	return nil, fmt.Errorf("neither Venafi Cloud or TPP configuration found")
 }
 
+// httpClientForIssuer returns the HTTP client used to connect to Venafi with
+// the TLS configuration of an issuer, or nil to use the default client of
+// vcert if the issuer has no TLS configuration. vcert ignores the connection
+// trust of its config when a client is set, so the CA bundle is added to the
+// root CAs of the client.
+func httpClientForIssuer(issuerTLS *cmapi.IssuerTLSConfig, caBundle []byte) (*http.Client, error) {
+	if issuerTLS == nil {
+		return nil, nil
+	}
+	transport, err := tlsconfig.Transport(issuerTLS)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring Venafi TLS: %w", err)
+	}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("error loading Venafi TPP CA bundle")
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
 func (v *Venafi) Ping() error {
 	return v.vcertClient.Ping()
 }
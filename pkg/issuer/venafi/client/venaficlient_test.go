@@ -17,7 +17,9 @@ limitations under the License.
 package client
 
 import (
+	"crypto/tls"
 	"errors"
+	"net/http"
 	"testing"
 
 	vcert "github.com/Venafi/vcert/v4"
@@ -178,6 +180,40 @@ func TestConfigForIssuerT(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if TPP with TLS configuration, should use a client with the TLS configuration": {
+			iss: gen.IssuerFrom(tppIssuer,
+				gen.SetIssuerTLS(cmapi.IssuerTLSConfig{MinVersion: "VersionTLS13"}),
+			),
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppUsernameKey: []byte(username),
+					tppPasswordKey: []byte(password),
+				},
+			}, nil),
+			CheckFn: func(t *testing.T, cnf *vcert.Config) {
+				if cnf.Client == nil {
+					t.Fatalf("expected an HTTP client to be set")
+				}
+				if v := cnf.Client.Transport.(*http.Transport).TLSClientConfig.MinVersion; v != tls.VersionTLS13 {
+					t.Errorf("got unexpected TLS minimum version: %x", v)
+				}
+				checkZone(t, zone, cnf)
+			},
+			expectedErr: false,
+		},
+		"if TPP with invalid TLS configuration, should error": {
+			iss: gen.IssuerFrom(tppIssuer,
+				gen.SetIssuerTLS(cmapi.IssuerTLSConfig{MinVersion: "VersionTLS14"}),
+			),
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppUsernameKey: []byte(username),
+					tppPasswordKey: []byte(password),
+				},
+			}, nil),
+			CheckFn:     checkNoConfigReturned,
+			expectedErr: true,
+		},
 		"if TPP and Cloud, should chose TPP": {
 			iss: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
@@ -26,6 +26,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 )
 
 func (v *Venafi) Setup(ctx context.Context) (err error) {
@@ -33,23 +34,27 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 		if err != nil {
 			errorMessage := "Failed to setup Venafi issuer"
 			v.log.Error(err, errorMessage)
-			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "ErrorSetup", fmt.Sprintf("%s: %v", errorMessage, err))
+			reason := cmerrors.CredentialsErrorReason(err)
+			if reason == "" {
+				reason = "ErrorSetup"
+			}
+			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, reason, fmt.Sprintf("%s: %v", errorMessage, err))
 			err = fmt.Errorf("%s: %v", errorMessage, err)
 		}
 	}()
 
 	client, err := v.clientBuilder(v.resourceNamespace, v.secretsLister, v.issuer, v.Metrics, v.log)
 	if err != nil {
-		return fmt.Errorf("error building client: %v", err)
+		return fmt.Errorf("error building client: %w", err)
 	}
 	err = client.Ping()
 	if err != nil {
-		return fmt.Errorf("error pinging Venafi API: %v", err)
+		return cmerrors.NewCredentialsError(cmapi.IssuerReasonEndpointUnreachable, fmt.Errorf("error pinging Venafi API: %v", err))
 	}
 
 	err = client.VerifyCredentials()
 	if err != nil {
-		return fmt.Errorf("client.VerifyCredentials: %v", err)
+		return cmerrors.NewCredentialsError(cmapi.IssuerReasonInvalidCredentials, fmt.Errorf("client.VerifyCredentials: %v", err))
 	}
 
 	// If it does not already have a 'ready' condition, we'll also log an event
@@ -27,6 +27,8 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -46,6 +48,11 @@ func TestSetup(t *testing.T) {
 		return nil, errors.New("this is an error")
 	}
 
+	secretNotFoundClientBuilder := func(string, corelisters.SecretLister,
+		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger) (client.Interface, error) {
+		return nil, apierrors.NewNotFound(corev1.Resource("secrets"), "venafi-credentials")
+	}
+
 	failingPingClient := func(string, corelisters.SecretLister,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
@@ -98,12 +105,23 @@ func TestSetup(t *testing.T) {
 			},
 		},
 
+		"if the credentials secret does not exist then should error": {
+			clientBuilder: secretNotFoundClientBuilder,
+			expectedErr:   true,
+			iss:           baseIssuer.DeepCopy(),
+			expectedCondition: &cmapi.IssuerCondition{
+				Reason:  "SecretNotFound",
+				Message: `Failed to setup Venafi issuer: error building client: secrets "venafi-credentials" not found`,
+				Status:  "False",
+			},
+		},
+
 		"if ping fails then should error": {
 			clientBuilder: failingPingClient,
 			iss:           baseIssuer.DeepCopy(),
 			expectedErr:   true,
 			expectedCondition: &cmapi.IssuerCondition{
-				Reason:  "ErrorSetup",
+				Reason:  "EndpointUnreachable",
 				Message: "Failed to setup Venafi issuer: error pinging Venafi API: this is a ping error",
 				Status:  "False",
 			},
@@ -141,7 +159,7 @@ func TestSetup(t *testing.T) {
 			iss:           baseIssuer.DeepCopy(),
 			expectedErr:   true,
 			expectedCondition: &cmapi.IssuerCondition{
-				Reason:  "ErrorSetup",
+				Reason:  "InvalidCredentials",
 				Message: "Failed to setup Venafi issuer: client.VerifyCredentials: 401 Unauthorized",
 				Status:  "False",
 			},
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+)
+
+// levelOverrides holds a map[string]int of logger names to the verbosity
+// that loggers with that name should log at. The map is replaced as a whole
+// by SetLevels and must not be modified once stored.
+var levelOverrides atomic.Value
+
+// SetLevels replaces the verbosity overrides of named loggers, for example
+// the loggers of individual controllers. A logger whose name, or the name of
+// one of its parents, is a key in levels logs at the given verbosity instead
+// of the global -v level. If more than one name matches, the most specific
+// name is used. Passing an empty map restores the global verbosity for all
+// loggers.
+//
+// Only loggers derived from Log are affected. Messages logged using V, which
+// is not associated with a named logger, always use the global verbosity.
+func SetLevels(levels map[string]int) {
+	copied := make(map[string]int, len(levels))
+	for name, level := range levels {
+		copied[name] = level
+	}
+	levelOverrides.Store(copied)
+}
+
+func loadLevels() map[string]int {
+	levels, _ := levelOverrides.Load().(map[string]int)
+	return levels
+}
+
+// namedLevelSink wraps a logr.LogSink and overrides its verbosity based on
+// the names of the logger, as configured using SetLevels.
+type namedLevelSink struct {
+	sink  logr.LogSink
+	names []string
+}
+
+var _ logr.CallDepthLogSink = &namedLevelSink{}
+
+func newNamedLevelSink(sink logr.LogSink) logr.LogSink {
+	// Account for the additional stack frame of namedLevelSink so that the
+	// delegate reports the correct caller.
+	if cd, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = cd.WithCallDepth(1)
+	}
+	return &namedLevelSink{sink: sink}
+}
+
+// level returns the verbosity override for this logger, if any.
+func (s *namedLevelSink) level() (int, bool) {
+	levels := loadLevels()
+	if len(levels) == 0 {
+		return 0, false
+	}
+	for i := len(s.names) - 1; i >= 0; i-- {
+		if level, ok := levels[s.names[i]]; ok {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// Init is a no-op since the delegate sink has already been initialised.
+func (s *namedLevelSink) Init(logr.RuntimeInfo) {}
+
+func (s *namedLevelSink) Enabled(level int) bool {
+	if override, ok := s.level(); ok {
+		return level <= override
+	}
+	return s.sink.Enabled(level)
+}
+
+func (s *namedLevelSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if _, ok := s.level(); ok {
+		// Enabled has already been checked against the override, so make
+		// sure the delegate does not filter the message using the global
+		// verbosity again.
+		level = 0
+	}
+	s.sink.Info(level, msg, keysAndValues...)
+}
+
+func (s *namedLevelSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.sink.Error(err, msg, keysAndValues...)
+}
+
+func (s *namedLevelSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &namedLevelSink{sink: s.sink.WithValues(keysAndValues...), names: s.names}
+}
+
+func (s *namedLevelSink) WithName(name string) logr.LogSink {
+	names := make([]string, len(s.names), len(s.names)+1)
+	copy(names, s.names)
+	return &namedLevelSink{sink: s.sink.WithName(name), names: append(names, name)}
+}
+
+func (s *namedLevelSink) WithCallDepth(depth int) logr.LogSink {
+	sink := s.sink
+	if cd, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = cd.WithCallDepth(depth)
+	}
+	return &namedLevelSink{sink: sink, names: s.names}
+}
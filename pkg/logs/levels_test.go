@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+func TestNamedLevelSink(t *testing.T) {
+	tests := map[string]struct {
+		levels    map[string]int
+		names     []string
+		level     int
+		expLogged bool
+	}{
+		"without overrides the global verbosity is used": {
+			names:     []string{"controller", "orders"},
+			level:     2,
+			expLogged: false,
+		},
+		"an override for the logger's name raises the verbosity": {
+			levels:    map[string]int{"orders": 4},
+			names:     []string{"controller", "orders"},
+			level:     4,
+			expLogged: true,
+		},
+		"an override for the logger's name lowers the verbosity": {
+			levels:    map[string]int{"orders": 0},
+			names:     []string{"controller", "orders"},
+			level:     1,
+			expLogged: false,
+		},
+		"an override for a parent's name applies to child loggers": {
+			levels:    map[string]int{"orders": 4},
+			names:     []string{"controller", "orders", "sync"},
+			level:     4,
+			expLogged: true,
+		},
+		"the most specific name is used": {
+			levels:    map[string]int{"controller": 0, "orders": 4},
+			names:     []string{"controller", "orders"},
+			level:     4,
+			expLogged: true,
+		},
+		"overrides for other names are ignored": {
+			levels:    map[string]int{"challenges": 4},
+			names:     []string{"controller", "orders"},
+			level:     4,
+			expLogged: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			SetLevels(test.levels)
+			defer SetLevels(nil)
+
+			var logged bool
+			delegate := funcr.New(func(string, string) { logged = true }, funcr.Options{Verbosity: 1})
+			log := logr.New(newNamedLevelSink(delegate.GetSink()))
+			for _, n := range test.names {
+				log = log.WithName(n)
+			}
+
+			log.V(test.level).Info("test")
+			if logged != test.expLogged {
+				t.Errorf("expected logged=%t but got %t", test.expLogged, logged)
+			}
+		})
+	}
+}
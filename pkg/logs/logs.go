@@ -35,7 +35,7 @@ import (
 )
 
 var (
-	Log = klogr.New().WithName("cert-manager")
+	Log = logr.New(newNamedLevelSink(klogr.New().GetSink())).WithName("cert-manager")
 )
 
 const (
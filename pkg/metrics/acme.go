@@ -17,7 +17,12 @@ limitations under the License.
 package metrics
 
 import (
+	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 )
 
 // ObserveACMERequestDuration increases bucket counters for that ACME client duration.
@@ -29,3 +34,58 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// IncrementChallengeCleanUpAbandonedCount increases the counter of ACME
+// challenges whose presented records were left behind.
+func (m *Metrics) IncrementChallengeCleanUpAbandonedCount(challengeType string) {
+	m.challengeCleanUpAbandonedCount.WithLabelValues(challengeType).Inc()
+}
+
+// rateLimitBudgetDomains records the registered domains of the rate limit
+// budget series of each issuer.
+type rateLimitBudgetDomains struct {
+	lock    sync.Mutex
+	domains map[[3]string][]string
+}
+
+func newRateLimitBudgetDomains() *rateLimitBudgetDomains {
+	return &rateLimitBudgetDomains{domains: make(map[[3]string][]string)}
+}
+
+// UpdateACMERateLimitBudgets sets the remaining rate limit budget of each
+// registered domain of an issuer, and deletes the series of registered
+// domains which are no longer in the issuer's budgets.
+func (m *Metrics) UpdateACMERateLimitBudgets(kind, namespace, name string, budgets []cmacme.ACMERateLimitBudgetStatus) {
+	key := [3]string{kind, namespace, name}
+
+	m.rateLimitBudgetDomains.lock.Lock()
+	defer m.rateLimitBudgetDomains.lock.Unlock()
+
+	current := make(map[string]struct{}, len(budgets))
+	var domains []string
+	for _, budget := range budgets {
+		current[budget.RegisteredDomain] = struct{}{}
+		domains = append(domains, budget.RegisteredDomain)
+		m.acmeRateLimitBudgetRemaining.With(rateLimitBudgetLabels(kind, namespace, name, budget.RegisteredDomain)).Set(float64(budget.Remaining))
+	}
+	for _, domain := range m.rateLimitBudgetDomains.domains[key] {
+		if _, ok := current[domain]; !ok {
+			m.acmeRateLimitBudgetRemaining.Delete(rateLimitBudgetLabels(kind, namespace, name, domain))
+		}
+	}
+
+	if len(domains) == 0 {
+		delete(m.rateLimitBudgetDomains.domains, key)
+		return
+	}
+	m.rateLimitBudgetDomains.domains[key] = domains
+}
+
+func rateLimitBudgetLabels(kind, namespace, name, domain string) prometheus.Labels {
+	return prometheus.Labels{
+		"name":              name,
+		"namespace":         namespace,
+		"kind":              kind,
+		"registered_domain": domain,
+	}
+}
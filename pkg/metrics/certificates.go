@@ -18,17 +18,21 @@ package metrics
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
-// UpdateCertificate will update the given Certificate's metrics for its expiry, renewal, and status
-// condition.
+// UpdateCertificate will update the given Certificate's metrics for its expiry, renewal, status
+// condition, and expiry warning.
 func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate) {
 	key, err := cache.MetaNamespaceKeyFunc(crt)
 	if err != nil {
@@ -40,6 +44,9 @@ func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate)
 	m.updateCertificateStatus(key, crt)
 	m.updateCertificateExpiry(ctx, key, crt)
 	m.updateCertificateRenewalTime(crt)
+	m.updateCertificateExpiryWarning(crt)
+	m.updateCertificateLastIssuanceTime(crt)
+	m.certificateSummaries.update(key, crt)
 }
 
 // updateCertificateExpiry updates the expiry time of a certificate
@@ -69,6 +76,62 @@ func (m *Metrics) updateCertificateRenewalTime(crt *cmapi.Certificate) {
 
 }
 
+// updateCertificateLastIssuanceTime updates the time of the most recent
+// successful and failed issuance of a certificate
+func (m *Metrics) updateCertificateLastIssuanceTime(crt *cmapi.Certificate) {
+	for result, t := range map[string]*metav1.Time{
+		"success": crt.Status.LastSuccessTime,
+		"failure": crt.Status.LastFailureTime,
+	} {
+		value := 0.0
+		if t != nil {
+			value = float64(t.Unix())
+		}
+
+		m.certificateLastIssuanceTimeSeconds.With(prometheus.Labels{
+			"name":      crt.Name,
+			"namespace": crt.Namespace,
+			"result":    result,
+		}).Set(value)
+	}
+}
+
+// updateCertificateExpiryWarning updates whether a certificate with renewal
+// disabled, or an observe-only certificate, expires within each of the expiry
+// warning thresholds, as reported by its ExpiryWarning condition.
+func (m *Metrics) updateCertificateExpiryWarning(crt *cmapi.Certificate) {
+	if !crt.Spec.RenewalDisabled && !crt.Spec.ObserveOnly {
+		m.removeCertificateExpiryWarning(crt.Name, crt.Namespace)
+		return
+	}
+
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExpiryWarning)
+	// The thresholds are ordered from the shortest to the longest, so a
+	// certificate expiring within one threshold expires within all of the
+	// following ones.
+	within := false
+	for _, threshold := range internalcertificates.ExpiryWarningThresholds {
+		if cond != nil && cond.Status == cmmeta.ConditionTrue && cond.Reason == threshold.Reason {
+			within = true
+		}
+		value := 0.0
+		if within {
+			value = 1.0
+		}
+		m.certificateExpiryWarning.With(prometheus.Labels{
+			"name":           crt.Name,
+			"namespace":      crt.Namespace,
+			"threshold_days": strconv.Itoa(threshold.Days),
+		}).Set(value)
+	}
+}
+
+func (m *Metrics) removeCertificateExpiryWarning(name, namespace string) {
+	for _, threshold := range internalcertificates.ExpiryWarningThresholds {
+		m.certificateExpiryWarning.DeleteLabelValues(name, namespace, strconv.Itoa(threshold.Days))
+	}
+}
+
 // updateCertificateStatus will update the metric for that Certificate
 func (m *Metrics) updateCertificateStatus(key string, crt *cmapi.Certificate) {
 	for _, c := range crt.Status.Conditions {
@@ -109,7 +172,12 @@ func (m *Metrics) RemoveCertificate(key string) {
 
 	m.certificateExpiryTimeSeconds.DeleteLabelValues(name, namespace)
 	m.certificateRenewalTimeSeconds.DeleteLabelValues(name, namespace)
+	m.removeCertificateExpiryWarning(name, namespace)
+	for _, result := range []string{"success", "failure"} {
+		m.certificateLastIssuanceTimeSeconds.DeleteLabelValues(name, namespace, result)
+	}
 	for _, condition := range readyConditionStatuses {
 		m.certificateReadyStatus.DeleteLabelValues(name, namespace, string(condition))
 	}
+	m.certificateSummaries.remove(key)
 }
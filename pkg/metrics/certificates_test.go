@@ -196,6 +196,74 @@ func TestCertificateMetrics(t *testing.T) {
 	}
 }
 
+const expiryWarningMetadata = `
+	# HELP certmanager_certificate_expiry_warning Whether a certificate with renewal disabled expires within the threshold_days number of days.
+	# TYPE certmanager_certificate_expiry_warning gauge
+`
+
+func TestCertificateExpiryWarningMetrics(t *testing.T) {
+	tests := map[string]struct {
+		crt      *cmapi.Certificate
+		expected string
+	}{
+		"certificate with renewal enabled has no expiry warning": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateNamespace("test-ns"),
+			),
+		},
+		"certificate with renewal disabled which does not expire soon": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateNamespace("test-ns"),
+				gen.SetCertificateRenewalDisabled(true),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionExpiryWarning,
+					Status: cmmeta.ConditionFalse,
+					Reason: "NotExpiring",
+				}),
+			),
+			expected: `
+	certmanager_certificate_expiry_warning{name="test-certificate",namespace="test-ns",threshold_days="14"} 0
+	certmanager_certificate_expiry_warning{name="test-certificate",namespace="test-ns",threshold_days="30"} 0
+	certmanager_certificate_expiry_warning{name="test-certificate",namespace="test-ns",threshold_days="7"} 0
+`,
+		},
+		"certificate with renewal disabled which expires within 14 days": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateNamespace("test-ns"),
+				gen.SetCertificateRenewalDisabled(true),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionExpiryWarning,
+					Status: cmmeta.ConditionTrue,
+					Reason: "ExpiresWithin14Days",
+				}),
+			),
+			expected: `
+	certmanager_certificate_expiry_warning{name="test-certificate",namespace="test-ns",threshold_days="14"} 1
+	certmanager_certificate_expiry_warning{name="test-certificate",namespace="test-ns",threshold_days="30"} 1
+	certmanager_certificate_expiry_warning{name="test-certificate",namespace="test-ns",threshold_days="7"} 0
+`,
+		},
+	}
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+			m.UpdateCertificate(context.TODO(), test.crt)
+
+			if err := testutil.CollectAndCompare(m.certificateExpiryWarning,
+				strings.NewReader(expiryWarningMetadata+test.expected),
+				"certmanager_certificate_expiry_warning",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+
+			m.RemoveCertificate("test-ns/test-certificate")
+			if count := testutil.CollectAndCount(m.certificateExpiryWarning); count != 0 {
+				t.Errorf("expected the metrics to be removed, got %d", count)
+			}
+		})
+	}
+}
+
 func TestCertificateCache(t *testing.T) {
 	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
 
@@ -324,3 +392,55 @@ func TestCertificateCache(t *testing.T) {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
+
+const lastIssuanceMetadata = `
+	# HELP certmanager_certificate_last_issuance_timestamp_seconds The time of the most recent successful or failed issuance of the certificate, as given by the result label. Expressed as a Unix Epoch Time.
+	# TYPE certmanager_certificate_last_issuance_timestamp_seconds gauge
+`
+
+func TestCertificateLastIssuanceMetrics(t *testing.T) {
+	tests := map[string]struct {
+		crt      *cmapi.Certificate
+		expected string
+	}{
+		"certificate which has not been issued yet": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateNamespace("test-ns"),
+			),
+			expected: `
+	certmanager_certificate_last_issuance_timestamp_seconds{name="test-certificate",namespace="test-ns",result="failure"} 0
+	certmanager_certificate_last_issuance_timestamp_seconds{name="test-certificate",namespace="test-ns",result="success"} 0
+`,
+		},
+		"certificate which was issued and has failed to renew since": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateNamespace("test-ns"),
+				gen.SetCertificateLastSuccessTime(metav1.Unix(2208988804, 0)),
+				gen.SetCertificateLastFailureTime(metav1.Unix(2208988904, 0)),
+				gen.SetCertificateLastError("Failed: the issuer is not ready"),
+			),
+			expected: `
+	certmanager_certificate_last_issuance_timestamp_seconds{name="test-certificate",namespace="test-ns",result="failure"} 2.208988904e+09
+	certmanager_certificate_last_issuance_timestamp_seconds{name="test-certificate",namespace="test-ns",result="success"} 2.208988804e+09
+`,
+		},
+	}
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+			m.UpdateCertificate(context.TODO(), test.crt)
+
+			if err := testutil.CollectAndCompare(m.certificateLastIssuanceTimeSeconds,
+				strings.NewReader(lastIssuanceMetadata+test.expected),
+				"certmanager_certificate_last_issuance_timestamp_seconds",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+
+			m.RemoveCertificate("test-ns/test-certificate")
+			if count := testutil.CollectAndCount(m.certificateLastIssuanceTimeSeconds); count != 0 {
+				t.Errorf("expected the metrics to be removed, got %d", count)
+			}
+		})
+	}
+}
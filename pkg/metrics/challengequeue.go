@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ChallengeQueuePath is the path on the metrics server that the challenge
+// scheduler's queue is served on, if enabled.
+const ChallengeQueuePath = "/challenges/queue"
+
+// QueuedChallenge describes a Challenge which is waiting to be scheduled for
+// processing.
+type QueuedChallenge struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	DNSName   string `json:"dnsName"`
+	Type      string `json:"type"`
+
+	// Position is the position of the Challenge in the queue, starting at 1.
+	Position int `json:"position"`
+
+	// Reason and Message describe why the Challenge has not been scheduled
+	// yet.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+
+	// QueuedSince is the creation time of the Challenge.
+	QueuedSince time.Time `json:"queuedSince"`
+}
+
+// ChallengeQueue is the response body of the challenge queue endpoint.
+type ChallengeQueue struct {
+	// Items are the queued Challenges, in the order in which they will be
+	// scheduled.
+	Items []QueuedChallenge `json:"items"`
+}
+
+// challengeQueue holds the most recent queue reported by the challenge
+// scheduler.
+type challengeQueue struct {
+	lock  sync.RWMutex
+	items []QueuedChallenge
+}
+
+// SetupChallengeQueue enables serving the challenge scheduler's queue on
+// ChallengeQueuePath. It must be called before NewServer.
+func (m *Metrics) SetupChallengeQueue() {
+	m.challengeQueue = &challengeQueue{}
+}
+
+// UpdateChallengeQueue replaces the served challenge queue with the given
+// items. It does nothing unless SetupChallengeQueue has been called.
+func (m *Metrics) UpdateChallengeQueue(items []QueuedChallenge) {
+	if m.challengeQueue == nil {
+		return
+	}
+
+	m.challengeQueue.lock.Lock()
+	defer m.challengeQueue.lock.Unlock()
+	m.challengeQueue.items = items
+}
+
+// ServeHTTP writes the current ChallengeQueue as JSON.
+func (q *challengeQueue) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	q.lock.RLock()
+	queue := ChallengeQueue{Items: q.items}
+	q.lock.RUnlock()
+	if queue.Items == nil {
+		queue.Items = []QueuedChallenge{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(queue); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestChallengeQueue(t *testing.T) {
+	queuedSince := time.Unix(1000, 0).UTC()
+	items := []QueuedChallenge{
+		{
+			Namespace:   "ns-1",
+			Name:        "ch-1",
+			DNSName:     "example.com",
+			Type:        "HTTP-01",
+			Position:    1,
+			Reason:      "MaxConcurrentChallenges",
+			Message:     "Waiting for a Challenge to complete",
+			QueuedSince: queuedSince,
+		},
+	}
+
+	tests := map[string]struct {
+		setup    bool
+		update   []QueuedChallenge
+		expCode  int
+		expected []QueuedChallenge
+	}{
+		"endpoint is not served unless set up": {
+			update:  items,
+			expCode: http.StatusNotFound,
+		},
+		"empty queue should return an empty list": {
+			setup:    true,
+			expCode:  http.StatusOK,
+			expected: []QueuedChallenge{},
+		},
+		"queued challenges should be returned": {
+			setup:    true,
+			update:   items,
+			expCode:  http.StatusOK,
+			expected: items,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+			if test.setup {
+				m.SetupChallengeQueue()
+			}
+			m.UpdateChallengeQueue(test.update)
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ln.Close()
+			server := m.NewServer(ln)
+
+			req := httptest.NewRequest(http.MethodGet, ChallengeQueuePath, nil)
+			rec := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expCode, rec.Code)
+			if test.expCode != http.StatusOK {
+				return
+			}
+
+			var queue ChallengeQueue
+			if err := json.Unmarshal(rec.Body.Bytes(), &queue); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expected, queue.Items)
+		})
+	}
+}
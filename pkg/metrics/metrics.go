@@ -19,10 +19,20 @@ limitations under the License.
 // certificate_expiration_timestamp_seconds{name, namespace}
 // certificate_renewal_timestamp_seconds{name, namespace}
 // certificate_ready_status{name, namespace, condition}
+// certificate_last_issuance_timestamp_seconds{name, namespace, result}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// acme_rate_limit_budget_remaining{name, namespace, kind, registered_domain}
+//
+// If resource state metrics are enabled, the following are also exposed:
+// certificate_state{name, namespace, issuer_name, issuer_kind, issuer_group, ready, issuing}
+// acme_order_state{name, namespace, issuer_name, issuer_kind, issuer_group, state}
+// acme_challenge_state{name, namespace, issuer_name, issuer_kind, issuer_group, type, dns_name, state, processing, self_check}
+//
+// An aggregated per-namespace summary of Certificates is also served as JSON
+// on the metrics server at /certificates/summary.
 package metrics
 
 import (
@@ -57,11 +67,30 @@ type Metrics struct {
 	certificateExpiryTimeSeconds       *prometheus.GaugeVec
 	certificateRenewalTimeSeconds      *prometheus.GaugeVec
 	certificateReadyStatus             *prometheus.GaugeVec
+	certificateExpiryWarning           *prometheus.GaugeVec
+	certificateLastIssuanceTimeSeconds *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
+	challengeCleanUpAbandonedCount     *prometheus.CounterVec
+	acmeRateLimitBudgetRemaining       *prometheus.GaugeVec
+
+	// rateLimitBudgetDomains are the registered domains the remaining rate
+	// limit budget of each issuer was last recorded for, so that series of
+	// domains no longer tracked can be deleted.
+	rateLimitBudgetDomains *rateLimitBudgetDomains
+
+	certificateSummaries *certificateSummaries
+
+	// resourceStateCollector is optional and only registered if
+	// SetupResourceStateCollector has been called.
+	resourceStateCollector *resourceStateCollector
+
+	// challengeQueue is optional and only served if SetupChallengeQueue has
+	// been called.
+	challengeQueue *challengeQueue
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -127,6 +156,24 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"name", "namespace", "condition"},
 		)
 
+		certificateExpiryWarning = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_expiry_warning",
+				Help:      "Whether a certificate with renewal disabled expires within the threshold_days number of days.",
+			},
+			[]string{"name", "namespace", "threshold_days"},
+		)
+
+		certificateLastIssuanceTimeSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_last_issuance_timestamp_seconds",
+				Help:      "The time of the most recent successful or failed issuance of the certificate, as given by the result label. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"name", "namespace", "result"},
+		)
+
 		// acmeClientRequestCount is a Prometheus summary to collect the number of
 		// requests made to each endpoint with the ACME client.
 		acmeClientRequestCount = prometheus.NewCounterVec(
@@ -184,6 +231,24 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		challengeCleanUpAbandonedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_challenge_cleanup_abandoned_count",
+				Help:      "The number of ACME challenges whose presented records were not cleaned up and must be deleted manually.",
+			},
+			[]string{"type"},
+		)
+
+		acmeRateLimitBudgetRemaining = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "acme_rate_limit_budget_remaining",
+				Help:      "The number of certificates an ACME issuer can issue for a registered domain before reaching the rate limit of the ACME server.",
+			},
+			[]string{"name", "namespace", "kind", "registered_domain"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -196,11 +261,18 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
+		certificateExpiryWarning:           certificateExpiryWarning,
+		certificateLastIssuanceTimeSeconds: certificateLastIssuanceTimeSeconds,
 		acmeClientRequestCount:             acmeClientRequestCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
+		challengeCleanUpAbandonedCount:     challengeCleanUpAbandonedCount,
+		acmeRateLimitBudgetRemaining:       acmeRateLimitBudgetRemaining,
+		rateLimitBudgetDomains:             newRateLimitBudgetDomains(),
+
+		certificateSummaries: newCertificateSummaries(c),
 	}
 
 	return m
@@ -213,14 +285,25 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
 	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
 	m.registry.MustRegister(m.certificateReadyStatus)
+	m.registry.MustRegister(m.certificateExpiryWarning)
+	m.registry.MustRegister(m.certificateLastIssuanceTimeSeconds)
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
 	m.registry.MustRegister(m.controllerSyncCallCount)
 	m.registry.MustRegister(m.controllerSyncErrorCount)
+	m.registry.MustRegister(m.challengeCleanUpAbandonedCount)
+	m.registry.MustRegister(m.acmeRateLimitBudgetRemaining)
+	if m.resourceStateCollector != nil {
+		m.registry.MustRegister(m.resourceStateCollector)
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	mux.Handle(CertificateSummaryPath, m.certificateSummaries)
+	if m.challengeQueue != nil {
+		mux.Handle(ChallengeQueuePath, m.challengeQueue)
+	}
 
 	server := &http.Server{
 		Addr:           ln.Addr().String(),
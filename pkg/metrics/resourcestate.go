@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	acmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+)
+
+// resourceStateCollector is a prometheus.Collector which exposes one time
+// series per Certificate, Order and Challenge describing the current state of
+// that resource, in the style of kube-state-metrics. The state is read from
+// the shared informer caches at scrape time so no additional bookkeeping is
+// needed in the controllers.
+type resourceStateCollector struct {
+	log logr.Logger
+
+	certificateLister cmlisters.CertificateLister
+	orderLister       acmelisters.OrderLister
+	challengeLister   acmelisters.ChallengeLister
+
+	certificateState *prometheus.Desc
+	orderState       *prometheus.Desc
+	challengeState   *prometheus.Desc
+}
+
+func newResourceStateCollector(log logr.Logger, certificateLister cmlisters.CertificateLister, orderLister acmelisters.OrderLister, challengeLister acmelisters.ChallengeLister) *resourceStateCollector {
+	return &resourceStateCollector{
+		log:               log.WithName("resource-state"),
+		certificateLister: certificateLister,
+		orderLister:       orderLister,
+		challengeLister:   challengeLister,
+
+		certificateState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "certificate_state"),
+			"The current state of the certificate. Always 1, the state is given in the labels.",
+			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group", "ready", "issuing"},
+			nil,
+		),
+		orderState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "acme_order_state"),
+			"The current state of the ACME order. Always 1, the state is given in the labels.",
+			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group", "state"},
+			nil,
+		),
+		challengeState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "acme_challenge_state"),
+			"The current state of the ACME challenge. Always 1, the state is given in the labels.",
+			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group", "type", "dns_name", "state", "processing", "self_check"},
+			nil,
+		),
+	}
+}
+
+// SetupResourceStateCollector enables exporting per-resource state metrics for
+// Certificates, Orders and Challenges using the given listers. It must be
+// called before NewServer.
+func (m *Metrics) SetupResourceStateCollector(certificateLister cmlisters.CertificateLister, orderLister acmelisters.OrderLister, challengeLister acmelisters.ChallengeLister) {
+	m.resourceStateCollector = newResourceStateCollector(m.log, certificateLister, orderLister, challengeLister)
+}
+
+// Describe implements prometheus.Collector.
+func (c *resourceStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.certificateState
+	ch <- c.orderState
+	ch <- c.challengeState
+}
+
+// Collect implements prometheus.Collector.
+func (c *resourceStateCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collectCertificates(ch)
+	c.collectOrders(ch)
+	c.collectChallenges(ch)
+}
+
+func (c *resourceStateCollector) collectCertificates(ch chan<- prometheus.Metric) {
+	crts, err := c.certificateLister.List(labels.Everything())
+	if err != nil {
+		c.log.Error(err, "failed to list certificates")
+		return
+	}
+
+	for _, crt := range crts {
+		ch <- prometheus.MustNewConstMetric(c.certificateState, prometheus.GaugeValue, 1,
+			crt.Name,
+			crt.Namespace,
+			crt.Spec.IssuerRef.Name,
+			crt.Spec.IssuerRef.Kind,
+			crt.Spec.IssuerRef.Group,
+			string(certificateConditionStatus(crt, cmapi.CertificateConditionReady)),
+			string(certificateConditionStatus(crt, cmapi.CertificateConditionIssuing)),
+		)
+	}
+}
+
+func (c *resourceStateCollector) collectOrders(ch chan<- prometheus.Metric) {
+	orders, err := c.orderLister.List(labels.Everything())
+	if err != nil {
+		c.log.Error(err, "failed to list orders")
+		return
+	}
+
+	for _, order := range orders {
+		ch <- prometheus.MustNewConstMetric(c.orderState, prometheus.GaugeValue, 1,
+			order.Name,
+			order.Namespace,
+			order.Spec.IssuerRef.Name,
+			order.Spec.IssuerRef.Kind,
+			order.Spec.IssuerRef.Group,
+			stateLabel(order.Status.State),
+		)
+	}
+}
+
+func (c *resourceStateCollector) collectChallenges(ch chan<- prometheus.Metric) {
+	challenges, err := c.challengeLister.List(labels.Everything())
+	if err != nil {
+		c.log.Error(err, "failed to list challenges")
+		return
+	}
+
+	for _, challenge := range challenges {
+		ch <- prometheus.MustNewConstMetric(c.challengeState, prometheus.GaugeValue, 1,
+			challenge.Name,
+			challenge.Namespace,
+			challenge.Spec.IssuerRef.Name,
+			challenge.Spec.IssuerRef.Kind,
+			challenge.Spec.IssuerRef.Group,
+			string(challenge.Spec.Type),
+			challenge.Spec.DNSName,
+			stateLabel(challenge.Status.State),
+			strconv.FormatBool(challenge.Status.Processing),
+			selfCheckLabel(challenge),
+		)
+	}
+}
+
+// certificateConditionStatus returns the status of the condition with the
+// given type, or Unknown if the condition is not present.
+func certificateConditionStatus(crt *cmapi.Certificate, conditionType cmapi.CertificateConditionType) cmmeta.ConditionStatus {
+	for _, c := range crt.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status
+		}
+	}
+	return cmmeta.ConditionUnknown
+}
+
+// selfCheckLabel returns the label value for the SelfCheckSucceeded condition
+// of a challenge, which tells challenges waiting for their values to
+// propagate apart from those whose self check has failed.
+func selfCheckLabel(ch *cmacme.Challenge) string {
+	for _, c := range ch.Status.Conditions {
+		if c.Type != cmacme.ChallengeConditionSelfCheckSucceeded {
+			continue
+		}
+		switch {
+		case c.Status == cmmeta.ConditionTrue:
+			return "succeeded"
+		case c.Reason == cmacme.ChallengeReasonSelfCheckTimeout:
+			return "failed"
+		default:
+			return "awaiting_propagation"
+		}
+	}
+	return "unknown"
+}
+
+// stateLabel returns the label value for an ACME resource state. The Unknown
+// state is the empty string, so is given an explicit value to avoid empty
+// label values.
+func stateLabel(state cmacme.State) string {
+	if state == cmacme.Unknown {
+		return "unknown"
+	}
+	return string(state)
+}
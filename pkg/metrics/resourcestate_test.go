@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	acmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const resourceStateMetadata = `
+	# HELP certmanager_acme_challenge_state The current state of the ACME challenge. Always 1, the state is given in the labels.
+	# TYPE certmanager_acme_challenge_state gauge
+	# HELP certmanager_acme_order_state The current state of the ACME order. Always 1, the state is given in the labels.
+	# TYPE certmanager_acme_order_state gauge
+	# HELP certmanager_certificate_state The current state of the certificate. Always 1, the state is given in the labels.
+	# TYPE certmanager_certificate_state gauge
+`
+
+func TestResourceStateCollector(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer", Group: "cert-manager.io"}
+
+	tests := map[string]struct {
+		crts       []*cmapi.Certificate
+		orders     []*cmacme.Order
+		challenges []*cmacme.Challenge
+		expected   string
+	}{
+		"no resources should expose no series": {},
+		"one series should be exposed per resource": {
+			crts: []*cmapi.Certificate{
+				gen.Certificate("test-crt",
+					gen.SetCertificateNamespace("test-ns"),
+					gen.SetCertificateIssuer(issuerRef),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+						Type:   cmapi.CertificateConditionReady,
+						Status: cmmeta.ConditionTrue,
+					}),
+				),
+			},
+			orders: []*cmacme.Order{
+				gen.Order("test-order",
+					gen.SetOrderNamespace("test-ns"),
+					gen.SetOrderIssuer(issuerRef),
+					gen.SetOrderState(cmacme.Pending),
+				),
+			},
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("test-challenge",
+					gen.SetChallengeNamespace("test-ns"),
+					gen.SetChallengeIssuer(issuerRef),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengeDNSName("example.com"),
+					gen.SetChallengeProcessing(true),
+				),
+			},
+			expected: `
+	certmanager_acme_challenge_state{dns_name="example.com",issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",name="test-challenge",namespace="test-ns",processing="true",self_check="unknown",state="unknown",type="HTTP-01"} 1
+	certmanager_acme_order_state{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",name="test-order",namespace="test-ns",state="pending"} 1
+	certmanager_certificate_state{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",issuing="Unknown",name="test-crt",namespace="test-ns",ready="True"} 1
+`,
+		},
+		"challenges should be labelled with the outcome of their self check": {
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("awaiting",
+					gen.SetChallengeNamespace("test-ns"),
+					gen.SetChallengeIssuer(issuerRef),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					gen.SetChallengeDNSName("example.com"),
+					gen.SetChallengeStatusCondition(cmacme.ChallengeCondition{
+						Type:   cmacme.ChallengeConditionSelfCheckSucceeded,
+						Status: cmmeta.ConditionFalse,
+						Reason: cmacme.ChallengeReasonAwaitingPropagation,
+					}),
+				),
+				gen.Challenge("failed",
+					gen.SetChallengeNamespace("test-ns"),
+					gen.SetChallengeIssuer(issuerRef),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					gen.SetChallengeDNSName("example.com"),
+					gen.SetChallengeState(cmacme.Errored),
+					gen.SetChallengeStatusCondition(cmacme.ChallengeCondition{
+						Type:   cmacme.ChallengeConditionSelfCheckSucceeded,
+						Status: cmmeta.ConditionFalse,
+						Reason: cmacme.ChallengeReasonSelfCheckTimeout,
+					}),
+				),
+				gen.Challenge("succeeded",
+					gen.SetChallengeNamespace("test-ns"),
+					gen.SetChallengeIssuer(issuerRef),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					gen.SetChallengeDNSName("example.com"),
+					gen.SetChallengeStatusCondition(cmacme.ChallengeCondition{
+						Type:   cmacme.ChallengeConditionSelfCheckSucceeded,
+						Status: cmmeta.ConditionTrue,
+					}),
+				),
+			},
+			expected: `
+	certmanager_acme_challenge_state{dns_name="example.com",issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",name="awaiting",namespace="test-ns",processing="false",self_check="awaiting_propagation",state="unknown",type="DNS-01"} 1
+	certmanager_acme_challenge_state{dns_name="example.com",issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",name="failed",namespace="test-ns",processing="false",self_check="failed",state="errored",type="DNS-01"} 1
+	certmanager_acme_challenge_state{dns_name="example.com",issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",name="succeeded",namespace="test-ns",processing="false",self_check="succeeded",state="unknown",type="DNS-01"} 1
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := func() cache.Indexer {
+				return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			}
+			crtIndexer, orderIndexer, challengeIndexer := indexer(), indexer(), indexer()
+			for _, crt := range test.crts {
+				crtIndexer.Add(crt)
+			}
+			for _, order := range test.orders {
+				orderIndexer.Add(order)
+			}
+			for _, challenge := range test.challenges {
+				challengeIndexer.Add(challenge)
+			}
+
+			m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+			m.SetupResourceStateCollector(
+				cmlisters.NewCertificateLister(crtIndexer),
+				acmelisters.NewOrderLister(orderIndexer),
+				acmelisters.NewChallengeLister(challengeIndexer),
+			)
+
+			expected := ""
+			if len(test.expected) > 0 {
+				expected = resourceStateMetadata + test.expected
+			}
+			if err := testutil.CollectAndCompare(m.resourceStateCollector,
+				strings.NewReader(expected),
+				"certmanager_certificate_state",
+				"certmanager_acme_order_state",
+				"certmanager_acme_challenge_state",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// CertificateSummaryPath is the path on the metrics server that the
+	// aggregated per-namespace CertificateSummary is served on.
+	CertificateSummaryPath = "/certificates/summary"
+
+	// certificateSummaryNamespaceParam is the optional query parameter used to
+	// limit the summary response to a single namespace.
+	certificateSummaryNamespaceParam = "namespace"
+)
+
+// CertificateSummary is an aggregated view of the Certificates in a single
+// namespace. It is intended for dashboards which need an overview of
+// certificate health at scale without listing and parsing every Certificate
+// resource.
+type CertificateSummary struct {
+	// Namespace is the namespace the counts were aggregated over.
+	Namespace string `json:"namespace"`
+
+	// Total is the number of Certificates in the namespace.
+	Total int `json:"total"`
+
+	// Ready is the number of Certificates with a Ready condition of True.
+	Ready int `json:"ready"`
+
+	// NotReady is the number of Certificates with a Ready condition of False.
+	NotReady int `json:"notReady"`
+
+	// Unknown is the number of Certificates with either no Ready condition or
+	// a Ready condition of Unknown.
+	Unknown int `json:"unknown"`
+
+	// Expiring is the number of Certificates whose renewal time has passed.
+	Expiring int `json:"expiring"`
+}
+
+// CertificateSummaryList is the response body of the summary endpoint.
+type CertificateSummaryList struct {
+	Items []CertificateSummary `json:"items"`
+}
+
+// certificateSnapshot is the minimal state retained per Certificate in order
+// to build summaries.
+type certificateSnapshot struct {
+	namespace   string
+	ready       cmmeta.ConditionStatus
+	renewalTime *time.Time
+}
+
+// certificateSummaries keeps track of the state of every Certificate known to
+// the metrics controller so that per-namespace summaries can be computed
+// cheaply on request.
+type certificateSummaries struct {
+	clock clock.Clock
+
+	lock         sync.RWMutex
+	certificates map[string]certificateSnapshot
+}
+
+func newCertificateSummaries(c clock.Clock) *certificateSummaries {
+	return &certificateSummaries{
+		clock:        c,
+		certificates: make(map[string]certificateSnapshot),
+	}
+}
+
+// update stores the current state of the given Certificate against its key.
+func (s *certificateSummaries) update(key string, crt *cmapi.Certificate) {
+	snapshot := certificateSnapshot{
+		namespace: crt.Namespace,
+		ready:     cmmeta.ConditionUnknown,
+	}
+	for _, c := range crt.Status.Conditions {
+		if c.Type == cmapi.CertificateConditionReady {
+			snapshot.ready = c.Status
+			break
+		}
+	}
+	if crt.Status.RenewalTime != nil {
+		renewalTime := crt.Status.RenewalTime.Time
+		snapshot.renewalTime = &renewalTime
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.certificates[key] = snapshot
+}
+
+// remove stops tracking the Certificate with the given key.
+func (s *certificateSummaries) remove(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.certificates, key)
+}
+
+// summarise returns a CertificateSummary for each namespace that contains at
+// least one Certificate, sorted by namespace. If namespace is not empty, only
+// the summary for that namespace is returned.
+func (s *certificateSummaries) summarise(namespace string) []CertificateSummary {
+	now := s.clock.Now()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	byNamespace := make(map[string]*CertificateSummary)
+	for _, snapshot := range s.certificates {
+		if len(namespace) > 0 && snapshot.namespace != namespace {
+			continue
+		}
+
+		summary, ok := byNamespace[snapshot.namespace]
+		if !ok {
+			summary = &CertificateSummary{Namespace: snapshot.namespace}
+			byNamespace[snapshot.namespace] = summary
+		}
+
+		summary.Total++
+		switch snapshot.ready {
+		case cmmeta.ConditionTrue:
+			summary.Ready++
+		case cmmeta.ConditionFalse:
+			summary.NotReady++
+		default:
+			summary.Unknown++
+		}
+		if snapshot.renewalTime != nil && !now.Before(*snapshot.renewalTime) {
+			summary.Expiring++
+		}
+	}
+
+	summaries := make([]CertificateSummary, 0, len(byNamespace))
+	for _, summary := range byNamespace {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Namespace < summaries[j].Namespace
+	})
+
+	return summaries
+}
+
+// ServeHTTP writes the current CertificateSummaryList as JSON.
+func (s *certificateSummaries) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	list := CertificateSummaryList{
+		Items: s.summarise(r.URL.Query().Get(certificateSummaryNamespaceParam)),
+	}
+
+	writeJSON(w, list)
+}
+
+// writeJSON writes v as a JSON response. v is encoded before anything is
+// written, so that an encoding error can still be reported as such.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
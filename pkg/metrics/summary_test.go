@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCertificateSummary(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	past := metav1.NewTime(fixedClock.Now().Add(-time.Hour))
+	future := metav1.NewTime(fixedClock.Now().Add(time.Hour))
+
+	ready := func(status cmmeta.ConditionStatus) gen.CertificateModifier {
+		return gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: status,
+		})
+	}
+
+	tests := map[string]struct {
+		crts      []*cmapi.Certificate
+		removed   []string
+		namespace string
+		expected  []CertificateSummary
+	}{
+		"no certificates should return an empty list": {
+			expected: []CertificateSummary{},
+		},
+		"certificates should be aggregated per namespace": {
+			crts: []*cmapi.Certificate{
+				gen.Certificate("a", gen.SetCertificateNamespace("ns-1"), ready(cmmeta.ConditionTrue), gen.SetCertificateRenewalTime(future)),
+				gen.Certificate("b", gen.SetCertificateNamespace("ns-1"), ready(cmmeta.ConditionTrue), gen.SetCertificateRenewalTime(past)),
+				gen.Certificate("c", gen.SetCertificateNamespace("ns-1"), ready(cmmeta.ConditionFalse)),
+				gen.Certificate("d", gen.SetCertificateNamespace("ns-2")),
+			},
+			expected: []CertificateSummary{
+				{Namespace: "ns-1", Total: 3, Ready: 2, NotReady: 1, Expiring: 1},
+				{Namespace: "ns-2", Total: 1, Unknown: 1},
+			},
+		},
+		"removed certificates should no longer be counted": {
+			crts: []*cmapi.Certificate{
+				gen.Certificate("a", gen.SetCertificateNamespace("ns-1"), ready(cmmeta.ConditionTrue)),
+				gen.Certificate("b", gen.SetCertificateNamespace("ns-2"), ready(cmmeta.ConditionTrue)),
+			},
+			removed: []string{"ns-2/b"},
+			expected: []CertificateSummary{
+				{Namespace: "ns-1", Total: 1, Ready: 1},
+			},
+		},
+		"namespace parameter should filter the summary": {
+			crts: []*cmapi.Certificate{
+				gen.Certificate("a", gen.SetCertificateNamespace("ns-1"), ready(cmmeta.ConditionTrue)),
+				gen.Certificate("b", gen.SetCertificateNamespace("ns-2"), ready(cmmeta.ConditionFalse)),
+			},
+			namespace: "ns-2",
+			expected: []CertificateSummary{
+				{Namespace: "ns-2", Total: 1, NotReady: 1},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := New(logtesting.NewTestLogger(t), fixedClock)
+			for _, crt := range test.crts {
+				m.UpdateCertificate(context.TODO(), crt)
+			}
+			for _, key := range test.removed {
+				m.RemoveCertificate(key)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, CertificateSummaryPath, nil)
+			if len(test.namespace) > 0 {
+				q := req.URL.Query()
+				q.Set(certificateSummaryNamespaceParam, test.namespace)
+				req.URL.RawQuery = q.Encode()
+			}
+			rec := httptest.NewRecorder()
+			m.certificateSummaries.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var list CertificateSummaryList
+			if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expected, list.Items)
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeJSON(rec, map[string]interface{}{"invalid": make(chan int)})
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Empty(t, rec.Body.String(), "a partially encoded body must not be written")
+
+	rec = httptest.NewRecorder()
+	writeJSON(rec, map[string]string{"key": "value"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"key":"value"}`, rec.Body.String())
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		wantErr bool
+	}{
+		"every minute":             {spec: "* * * * *"},
+		"lists, ranges and steps":  {spec: "0,30 9-17/2 1-15 */3 1-5"},
+		"names":                    {spec: "0 22 * JAN-jun MON-FRI"},
+		"sunday as 7":              {spec: "0 0 * * 7"},
+		"too few fields":           {spec: "0 22 * *", wantErr: true},
+		"out of range minute":      {spec: "60 * * * *", wantErr: true},
+		"out of range day":         {spec: "0 0 0 * *", wantErr: true},
+		"reversed range":           {spec: "0 17-9 * * *", wantErr: true},
+		"zero step":                {spec: "*/0 * * * *", wantErr: true},
+		"unknown name":             {spec: "0 0 * * MONDAY", wantErr: true},
+		"name in the wrong field":  {spec: "0 0 * MON *", wantErr: true},
+		"non numeric single value": {spec: "a * * * *", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(test.spec)
+			if (err != nil) != test.wantErr {
+				t.Errorf("unexpected error for %q: wantErr=%t got=%v", test.spec, test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	base := time.Date(2022, time.March, 4, 10, 30, 15, 0, time.UTC) // a Friday
+
+	tests := map[string]struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		"every minute is strictly after": {
+			spec: "* * * * *",
+			from: time.Date(2022, time.March, 4, 10, 30, 0, 0, time.UTC),
+			want: time.Date(2022, time.March, 4, 10, 31, 0, 0, time.UTC),
+		},
+		"later the same day": {
+			spec: "0 22 * * *",
+			from: base,
+			want: time.Date(2022, time.March, 4, 22, 0, 0, 0, time.UTC),
+		},
+		"next weekday skips the weekend": {
+			spec: "0 9 * * MON-FRI",
+			from: base,
+			want: time.Date(2022, time.March, 7, 9, 0, 0, 0, time.UTC),
+		},
+		"next month": {
+			spec: "15 3 1 * *",
+			from: base,
+			want: time.Date(2022, time.April, 1, 3, 15, 0, 0, time.UTC),
+		},
+		"next year": {
+			spec: "0 0 1 JAN *",
+			from: base,
+			want: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week if both are restricted": {
+			spec: "0 0 10 * SUN",
+			from: base,
+			want: time.Date(2022, time.March, 6, 0, 0, 0, 0, time.UTC),
+		},
+		"steps": {
+			spec: "*/20 * * * *",
+			from: base,
+			want: time.Date(2022, time.March, 4, 10, 40, 0, 0, time.UTC),
+		},
+		"leap day": {
+			spec: "0 0 29 FEB *",
+			from: base,
+			want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			spec: "0 0 30 FEB *",
+			from: base,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := Parse(test.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.Next(test.from); !got.Equal(test.want) {
+				t.Errorf("unexpected next activation of %q after %s: want=%s got=%s", test.spec, test.from, test.want, got)
+			}
+		})
+	}
+}
+
+func TestNextInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	s, err := Parse("0 22 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Next(time.Date(2022, time.March, 4, 10, 0, 0, 0, time.UTC).In(loc))
+	want := time.Date(2022, time.March, 4, 20, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("unexpected next activation: want=%s got=%s", want, got)
+	}
+}
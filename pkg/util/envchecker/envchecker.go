@@ -0,0 +1,260 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envchecker checks that the environment cert-manager runs in meets
+// the prerequisites of its issuers: that the ACME directories, recursive
+// nameservers and DNS provider APIs they use are reachable.
+package envchecker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+const (
+	// KindACMEDirectory is the kind of the check of an ACME directory.
+	KindACMEDirectory = "ACME directory"
+	// KindNameserver is the kind of the check of a recursive nameserver.
+	KindNameserver = "Recursive nameserver"
+	// KindDNSAPI is the kind of the check of a DNS provider API.
+	KindDNSAPI = "DNS provider API"
+)
+
+// Targets are the endpoints whose reachability is checked.
+type Targets struct {
+	// ACMEDirectories are the URLs of ACME server directories.
+	ACMEDirectories []string
+	// Nameservers are recursive nameservers in the form host:port.
+	Nameservers []string
+	// DNSAPIs are the base URLs of DNS provider APIs.
+	DNSAPIs []string
+}
+
+// azureManagementEndpoints are the resource manager endpoints of the Azure
+// environments.
+var azureManagementEndpoints = map[cmacme.AzureDNSEnvironment]string{
+	cmacme.AzurePublicCloud:       "https://management.azure.com",
+	cmacme.AzureChinaCloud:        "https://management.chinacloudapi.cn",
+	cmacme.AzureGermanCloud:       "https://management.microsoftazure.de",
+	cmacme.AzureUSGovernmentCloud: "https://management.usgovcloudapi.net",
+	cmacme.AzureUSGovernment:      "https://management.usgovcloudapi.net",
+}
+
+// TargetsForIssuers returns the ACME directories and DNS provider APIs used
+// by the given issuers. Nameservers are not set as they are configured on
+// the controller rather than on issuers.
+func TargetsForIssuers(issuers []cmapi.GenericIssuer) Targets {
+	directories := map[string]struct{}{}
+	apis := map[string]struct{}{}
+	for _, issuer := range issuers {
+		acme := issuer.GetSpec().ACME
+		if acme == nil {
+			continue
+		}
+		directories[acme.Server] = struct{}{}
+		for _, solver := range acme.Solvers {
+			if solver.DNS01 == nil {
+				continue
+			}
+			if api := dnsAPIForProvider(solver.DNS01); len(api) > 0 {
+				apis[api] = struct{}{}
+			}
+		}
+	}
+	return Targets{
+		ACMEDirectories: sortedKeys(directories),
+		DNSAPIs:         sortedKeys(apis),
+	}
+}
+
+// dnsAPIForProvider returns the base URL of the API used by a DNS01
+// provider, or an empty string if the provider does not use a public API.
+func dnsAPIForProvider(p *cmacme.ACMEChallengeSolverDNS01) string {
+	switch {
+	case p.Akamai != nil:
+		return "https://" + p.Akamai.ServiceConsumerDomain
+	case p.CloudDNS != nil:
+		return "https://dns.googleapis.com"
+	case p.Cloudflare != nil:
+		return "https://api.cloudflare.com"
+	case p.Route53 != nil:
+		return "https://route53.amazonaws.com"
+	case p.AzureDNS != nil:
+		if endpoint, ok := azureManagementEndpoints[p.AzureDNS.Environment]; ok {
+			return endpoint
+		}
+		return azureManagementEndpoints[cmacme.AzurePublicCloud]
+	case p.DigitalOcean != nil:
+		return "https://api.digitalocean.com"
+	case p.AcmeDNS != nil:
+		return p.AcmeDNS.Host
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Result is the outcome of checking a single endpoint.
+type Result struct {
+	// Kind is the kind of endpoint checked, e.g. "ACME directory".
+	Kind string
+	// Target is the endpoint checked.
+	Target string
+	// Err is the reason the check failed, or nil if it succeeded.
+	Err error
+}
+
+// Report is the outcome of checking all endpoints.
+type Report []Result
+
+// Failed returns true if any of the checks failed.
+func (r Report) Failed() bool {
+	for _, result := range r {
+		if result.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes a line per check to w.
+func (r Report) Print(w io.Writer) {
+	for _, result := range r {
+		if result.Err != nil {
+			fmt.Fprintf(w, "[FAIL] %s %s: %v\n", result.Kind, result.Target, result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "[OK]   %s %s\n", result.Kind, result.Target)
+	}
+}
+
+// Checker checks the reachability of endpoints.
+type Checker struct {
+	client   *http.Client
+	dnsQuery func(fqdn string, rtype uint16, nameservers []string, recursive bool) (*dns.Msg, error)
+}
+
+// New returns a Checker whose HTTP requests time out after timeout.
+func New(timeout time.Duration) *Checker {
+	return &Checker{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		dnsQuery: dnsutil.DNSQuery,
+	}
+}
+
+// Check checks all the given targets concurrently, and returns the results
+// in the order of the targets.
+func (c *Checker) Check(ctx context.Context, targets Targets) Report {
+	var report Report
+	var checks []func() error
+	add := func(kind, target string, check func(context.Context, string) error) {
+		report = append(report, Result{Kind: kind, Target: target})
+		checks = append(checks, func() error { return check(ctx, target) })
+	}
+	for _, directory := range targets.ACMEDirectories {
+		add(KindACMEDirectory, directory, c.checkACMEDirectory)
+	}
+	for _, nameserver := range targets.Nameservers {
+		add(KindNameserver, nameserver, c.checkNameserver)
+	}
+	for _, api := range targets.DNSAPIs {
+		add(KindDNSAPI, api, c.checkDNSAPI)
+	}
+
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			report[i].Err = checks[i]()
+		}(i)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// checkACMEDirectory checks that the URL serves an ACME directory.
+func (c *Checker) checkACMEDirectory(ctx context.Context, url string) error {
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var directory struct {
+		NewNonce string `json:"newNonce"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&directory); err != nil || len(directory.NewNonce) == 0 {
+		return fmt.Errorf("response is not an ACME directory")
+	}
+	return nil
+}
+
+// checkNameserver checks that the nameserver answers recursive queries.
+func (c *Checker) checkNameserver(_ context.Context, nameserver string) error {
+	msg, err := c.dnsQuery(".", dns.TypeNS, []string{nameserver}, true)
+	if err != nil {
+		return err
+	}
+	if msg.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("query for the root zone failed with %s", dns.RcodeToString[msg.Rcode])
+	}
+	return nil
+}
+
+// checkDNSAPI checks that the API can be connected to. Any HTTP response is
+// accepted, as requests are not authenticated.
+func (c *Checker) checkDNSAPI(ctx context.Context, url string) error {
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *Checker) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envchecker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func acmeIssuer(server string, solvers ...cmacme.ACMEChallengeSolver) cmapi.GenericIssuer {
+	return &cmapi.Issuer{
+		Spec: cmapi.IssuerSpec{
+			IssuerConfig: cmapi.IssuerConfig{
+				ACME: &cmacme.ACMEIssuer{
+					Server:  server,
+					Solvers: solvers,
+				},
+			},
+		},
+	}
+}
+
+func TestTargetsForIssuers(t *testing.T) {
+	issuers := []cmapi.GenericIssuer{
+		acmeIssuer("https://acme-v02.api.letsencrypt.org/directory",
+			cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{}}},
+			cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+		),
+		acmeIssuer("https://acme-staging-v02.api.letsencrypt.org/directory",
+			cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{Environment: cmacme.AzureChinaCloud}}},
+			cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{}}},
+		),
+		acmeIssuer("https://acme-v02.api.letsencrypt.org/directory",
+			cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{}}},
+		),
+		&cmapi.ClusterIssuer{Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{SelfSigned: &cmapi.SelfSignedIssuer{}}}},
+	}
+
+	expected := Targets{
+		ACMEDirectories: []string{
+			"https://acme-staging-v02.api.letsencrypt.org/directory",
+			"https://acme-v02.api.letsencrypt.org/directory",
+		},
+		DNSAPIs: []string{
+			"https://management.chinacloudapi.cn",
+			"https://route53.amazonaws.com",
+		},
+	}
+	if got := TargetsForIssuers(issuers); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"newNonce": "https://acme.example.com/new-nonce"}`))
+	})
+	mux.HandleFunc("/not-a-directory", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html></html>`))
+	})
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	checker := New(time.Second)
+	checker.dnsQuery = func(fqdn string, rtype uint16, nameservers []string, recursive bool) (*dns.Msg, error) {
+		switch nameservers[0] {
+		case "192.0.2.1:53":
+			return &dns.Msg{MsgHdr: dns.MsgHdr{Rcode: dns.RcodeSuccess}}, nil
+		case "192.0.2.2:53":
+			return &dns.Msg{MsgHdr: dns.MsgHdr{Rcode: dns.RcodeRefused}}, nil
+		}
+		return nil, errors.New("i/o timeout")
+	}
+
+	report := checker.Check(context.Background(), Targets{
+		ACMEDirectories: []string{server.URL + "/directory", server.URL + "/not-a-directory"},
+		Nameservers:     []string{"192.0.2.1:53", "192.0.2.2:53", "192.0.2.3:53"},
+		DNSAPIs:         []string{server.URL + "/api", "http://127.0.0.1:0"},
+	})
+
+	expectedFailed := []bool{false, true, false, true, true, false, true}
+	if len(report) != len(expectedFailed) {
+		t.Fatalf("expected %d results, got %d", len(expectedFailed), len(report))
+	}
+	for i, result := range report {
+		if (result.Err != nil) != expectedFailed[i] {
+			t.Errorf("unexpected result of the check of %s %s: %v", result.Kind, result.Target, result.Err)
+		}
+	}
+	if !report.Failed() {
+		t.Errorf("expected the report to have failed")
+	}
+
+	var buf bytes.Buffer
+	report.Print(&buf)
+	if !strings.Contains(buf.String(), "[FAIL] Recursive nameserver 192.0.2.2:53: query for the root zone failed with REFUSED") {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+}
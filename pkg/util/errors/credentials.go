@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"errors"
+	"net"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+type credentialsError struct {
+	reason string
+	error
+}
+
+func (e *credentialsError) Unwrap() error {
+	return e.error
+}
+
+// NewCredentialsError wraps err, which was caused by a problem with the
+// credentials used to connect to an external service, with the Issuer
+// condition reason describing the problem, such as
+// cmapi.IssuerReasonInvalidCredentials.
+func NewCredentialsError(reason string, err error) error {
+	return &credentialsError{reason: reason, error: err}
+}
+
+// CredentialsErrorReason returns the Issuer condition reason describing the
+// credentials problem that caused err, or an empty string if err was not
+// caused by a known credentials problem.
+// Errors created with NewCredentialsError return their reason. Otherwise, a
+// Kubernetes NotFound error is assumed to be caused by a missing Secret, a
+// Forbidden error by missing permissions, and network errors by an
+// unreachable endpoint.
+func CredentialsErrorReason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var credsErr *credentialsError
+	if errors.As(err, &credsErr) {
+		return credsErr.reason
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return cmapi.IssuerReasonSecretNotFound
+	case apierrors.IsForbidden(err):
+		return cmapi.IssuerReasonPermissionDenied
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, syscall.ECONNREFUSED) {
+		return cmapi.IssuerReasonEndpointUnreachable
+	}
+
+	return ""
+}
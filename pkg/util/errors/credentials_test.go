@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"syscall"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestCredentialsErrorReason(t *testing.T) {
+	secrets := schema.GroupResource{Resource: "secrets"}
+
+	tests := map[string]struct {
+		err    error
+		reason string
+	}{
+		"nil error": {
+			err:    nil,
+			reason: "",
+		},
+		"unknown error": {
+			err:    errors.New("some error"),
+			reason: "",
+		},
+		"credentials error": {
+			err:    NewCredentialsError(cmapi.IssuerReasonInvalidCredentials, errors.New("bad token")),
+			reason: cmapi.IssuerReasonInvalidCredentials,
+		},
+		"wrapped credentials error": {
+			err:    fmt.Errorf("setup: %w", NewCredentialsError(cmapi.IssuerReasonPermissionDenied, errors.New("denied"))),
+			reason: cmapi.IssuerReasonPermissionDenied,
+		},
+		"credentials error takes precedence over the wrapped error": {
+			err:    NewCredentialsError(cmapi.IssuerReasonEndpointUnreachable, apierrors.NewNotFound(secrets, "creds")),
+			reason: cmapi.IssuerReasonEndpointUnreachable,
+		},
+		"wrapped not found error": {
+			err:    fmt.Errorf("failed to load secret: %w", apierrors.NewNotFound(secrets, "creds")),
+			reason: cmapi.IssuerReasonSecretNotFound,
+		},
+		"forbidden error": {
+			err:    apierrors.NewForbidden(secrets, "creds", errors.New("denied")),
+			reason: cmapi.IssuerReasonPermissionDenied,
+		},
+		"url error": {
+			err:    &url.Error{Op: "Get", URL: "https://vault.example.com", Err: errors.New("no such host")},
+			reason: cmapi.IssuerReasonEndpointUnreachable,
+		},
+		"connection refused": {
+			err:    fmt.Errorf("dial: %w", syscall.ECONNREFUSED),
+			reason: cmapi.IssuerReasonEndpointUnreachable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if reason := CredentialsErrorReason(test.err); reason != test.reason {
+				t.Errorf("expected reason %q, got %q", test.reason, reason)
+			}
+		})
+	}
+}
@@ -23,12 +23,18 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"software.sslmate.com/src/go-pkcs12"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
+// PKCS12SecretKey is the key of the entry in a Secret which holds a PKCS#12
+// bundle.
+const PKCS12SecretKey = "keystore.p12"
+
 // SecretTLSKeyRef will decode a PKCS1/SEC1 (in effect, a RSA or ECDSA) private key stored in a
 // secret with 'name' in 'namespace'. It will read the private key data from the secret
 // entry with name 'keyName'.
@@ -114,6 +120,53 @@ func SecretTLSKeyPairAndCA(ctx context.Context, secretLister corelisters.SecretL
 	return append(certs, ca), key, nil
 }
 
+// SecretCAKeyPair returns the X.509 certificate chain and private key used by
+// the given CA issuer to sign certificates, which are read from the issuer's
+// Secret in either the PEM or the PKCS#12 format.
+func SecretCAKeyPair(ctx context.Context, secretLister corelisters.SecretLister, namespace string, ca *cmapi.CAIssuer) ([]*x509.Certificate, crypto.Signer, error) {
+	if ca.PKCS12 != nil {
+		return SecretPKCS12KeyPair(ctx, secretLister, namespace, ca.SecretName, ca.PKCS12.PasswordSecretRef)
+	}
+	return SecretTLSKeyPairAndCA(ctx, secretLister, namespace, ca.SecretName)
+}
+
+// SecretPKCS12KeyPair returns the X.509 certificate chain and private key
+// stored in the password-protected PKCS#12 bundle in the 'keystore.p12' entry
+// of the Secret with 'name' in 'namespace'. The password is read from the
+// Secret referenced by 'passwordRef', also in 'namespace'. Any CA certificates
+// in the bundle are added to the end of the certificate chain.
+func SecretPKCS12KeyPair(ctx context.Context, secretLister corelisters.SecretLister, namespace, name string, passwordRef cmmeta.SecretKeySelector) ([]*x509.Certificate, crypto.Signer, error) {
+	secret, err := secretLister.Secrets(namespace).Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bundle, ok := secret.Data[PKCS12SecretKey]
+	if !ok {
+		return nil, nil, errors.NewInvalidData("no PKCS#12 data for %q in secret '%s/%s'", PKCS12SecretKey, namespace, name)
+	}
+
+	passwordSecret, err := secretLister.Secrets(namespace).Get(passwordRef.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	password, ok := passwordSecret.Data[passwordRef.Key]
+	if !ok {
+		return nil, nil, errors.NewInvalidData("no PKCS#12 password data for %q in secret '%s/%s'", passwordRef.Key, namespace, passwordRef.Name)
+	}
+
+	pk, cert, cas, err := pkcs12.DecodeChain(bundle, string(password))
+	if err != nil {
+		return nil, nil, errors.NewInvalidData("failed to decode PKCS#12 bundle in secret '%s/%s': %v", namespace, name, err)
+	}
+	key, ok := pk.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.NewInvalidData("unsupported private key type %T in PKCS#12 bundle in secret '%s/%s'", pk, namespace, name)
+	}
+
+	return append([]*x509.Certificate{cert}, cas...), key, nil
+}
+
 func SecretTLSKeyPair(ctx context.Context, secretLister corelisters.SecretLister, namespace, name string) ([]*x509.Certificate, crypto.Signer, error) {
 	secret, err := secretLister.Secrets(namespace).Get(name)
 	if err != nil {
@@ -59,13 +59,49 @@ func URIsForCertificate(crt *v1.Certificate) ([]*url.URL, error) {
 	return uris, nil
 }
 
+// DNSNamesForCertificate returns the DNS names of the Certificate in their
+// normalized form, see NormalizeDNSNames.
 func DNSNamesForCertificate(crt *v1.Certificate) ([]string, error) {
 	_, err := URLsFromStrings(crt.Spec.DNSNames)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse DNSNames: %s", err)
 	}
 
-	return crt.Spec.DNSNames, nil
+	dnsNames, err := NormalizeDNSNames(crt.Spec.DNSNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DNSNames: %s", err)
+	}
+
+	return dnsNames, nil
+}
+
+// CommonNameForCertificate returns the common name of the Certificate. If the
+// common name is also one of the Certificate's DNS names, it is returned in
+// its normalized form so that it matches the corresponding subject
+// alternative name.
+func CommonNameForCertificate(crt *v1.Certificate) (string, error) {
+	commonName, err := extractCommonName(crt.Spec)
+	if err != nil {
+		return "", err
+	}
+
+	normalizedCommonName, err := NormalizeDNSName(commonName)
+	if err != nil {
+		// Not a domain name, so leave it as is.
+		return commonName, nil
+	}
+
+	dnsNames, err := DNSNamesForCertificate(crt)
+	if err != nil {
+		return "", err
+	}
+	for _, dnsName := range dnsNames {
+		if dnsName == normalizedCommonName {
+			return normalizedCommonName, nil
+		}
+	}
+
+	return commonName, nil
 }
 
 func URLsFromStrings(urlStrs []string) ([]*url.URL, error) {
@@ -180,7 +216,7 @@ func BuildCertManagerKeyUsages(ku x509.KeyUsage, eku []x509.ExtKeyUsage) []v1.Ke
 // The CSR will not be signed, and should be passed to either EncodeCSR or
 // to the x509.CreateCertificateRequest function.
 func GenerateCSR(crt *v1.Certificate) (*x509.CertificateRequest, error) {
-	commonName, err := extractCommonName(crt.Spec)
+	commonName, err := CommonNameForCertificate(crt)
 	if err != nil {
 		return nil, err
 	}
@@ -303,12 +339,15 @@ func buildKeyUsagesExtensionsForCertificate(crt *v1.Certificate) ([]pkix.Extensi
 // generated by GenerateCSR.
 // The PublicKey field must be populated by the caller.
 func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
-	commonName, err := extractCommonName(crt.Spec)
+	commonName, err := CommonNameForCertificate(crt)
 	if err != nil {
 		return nil, err
 	}
 
-	dnsNames := crt.Spec.DNSNames
+	dnsNames, err := DNSNamesForCertificate(crt)
+	if err != nil {
+		return nil, err
+	}
 	ipAddresses := IPAddressesForCertificate(crt)
 	organization := OrganizationForCertificate(crt)
 	subject := SubjectForCertificate(crt)
@@ -150,10 +150,37 @@ func TestCommonNameForCertificate(t *testing.T) {
 			crtDNSNames: []string{"dnsname1", "dnsname2"},
 			expectedCN:  "",
 		},
+		{
+			name:        "certificate with an internationalised common name equal to a dns name",
+			crtCN:       "bücher.example.com",
+			crtDNSNames: []string{"bücher.example.com"},
+			expectedCN:  "xn--bcher-kva.example.com",
+		},
+		{
+			name:        "certificate with an internationalised common name equal to a punycode dns name",
+			crtCN:       "bücher.example.com",
+			crtDNSNames: []string{"xn--bcher-kva.example.com"},
+			expectedCN:  "xn--bcher-kva.example.com",
+		},
+		{
+			name:        "certificate with a common name equal to a dns name in a different case",
+			crtCN:       "Example.COM",
+			crtDNSNames: []string{"example.com"},
+			expectedCN:  "example.com",
+		},
+		{
+			name:        "certificate with an internationalised common name which is not a dns name",
+			crtCN:       "Müller",
+			crtDNSNames: []string{"dnsname"},
+			expectedCN:  "Müller",
+		},
 	}
 	testFn := func(test testT) func(*testing.T) {
 		return func(t *testing.T) {
-			actualCN := buildCertificate(test.crtCN, test.crtDNSNames...).Spec.CommonName
+			actualCN, err := CommonNameForCertificate(buildCertificate(test.crtCN, test.crtDNSNames...))
+			if err != nil {
+				t.Fatal(err)
+			}
 			if actualCN != test.expectedCN {
 				t.Errorf("expected %q but got %q", test.expectedCN, actualCN)
 				return
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// DNSNameToASCII converts an internationalised domain name into its ASCII
+// (A-label, or punycode) form, e.g. 'bücher.example' becomes
+// 'xn--bcher-kva.example'. This is the form that must be used in X.509
+// certificates, ACME orders and DNS records.
+// A leading wildcard label is preserved. Names which only contain ASCII
+// characters are returned unchanged.
+func DNSNameToASCII(name string) (string, error) {
+	if isASCII(name) {
+		return name, nil
+	}
+
+	prefix := ""
+	if strings.HasPrefix(name, "*.") {
+		prefix, name = "*.", strings.TrimPrefix(name, "*.")
+	}
+
+	ascii, err := idna.Lookup.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalised domain name %q: %w", name, err)
+	}
+
+	return prefix + ascii, nil
+}
+
+// NormalizeDNSName returns the canonical form of a DNS name, which is the
+// form used when comparing names and when adding them to certificate
+// requests, ACME orders and DNS records. The name is converted to its ASCII
+// form, lower cased and any trailing dot is removed, so that for example
+// 'Example.COM.' and 'example.com' are treated as the same name.
+func NormalizeDNSName(name string) (string, error) {
+	ascii, err := DNSNameToASCII(name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(strings.TrimSuffix(ascii, ".")), nil
+}
+
+// NormalizeDNSNames normalizes each of the given names using NormalizeDNSName
+// and removes any names which are duplicates once normalized. The order of
+// the remaining names is preserved.
+func NormalizeDNSNames(names []string) ([]string, error) {
+	if names == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		n, err := NormalizeDNSName(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		normalized = append(normalized, n)
+	}
+
+	return normalized, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDNSNameToASCII(t *testing.T) {
+	tests := map[string]struct {
+		name   string
+		exp    string
+		expErr bool
+	}{
+		"ascii names are unchanged": {
+			name: "Example.com",
+			exp:  "Example.com",
+		},
+		"punycode names are unchanged": {
+			name: "xn--bcher-kva.example.com",
+			exp:  "xn--bcher-kva.example.com",
+		},
+		"internationalised names are converted": {
+			name: "bücher.example.com",
+			exp:  "xn--bcher-kva.example.com",
+		},
+		"internationalised names are lower cased": {
+			name: "Bücher.example.com",
+			exp:  "xn--bcher-kva.example.com",
+		},
+		"wildcards are preserved": {
+			name: "*.bücher.example.com",
+			exp:  "*.xn--bcher-kva.example.com",
+		},
+		"invalid internationalised names return an error": {
+			name:   "-bücher.example.com",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := DNSNameToASCII(test.name)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if got != test.exp {
+				t.Errorf("unexpected name, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeDNSNames(t *testing.T) {
+	tests := map[string]struct {
+		names  []string
+		exp    []string
+		expErr bool
+	}{
+		"nil names": {
+			names: nil,
+			exp:   nil,
+		},
+		"names are lower cased": {
+			names: []string{"Example.COM", "*.Example.com"},
+			exp:   []string{"example.com", "*.example.com"},
+		},
+		"trailing dots are removed": {
+			names: []string{"example.com."},
+			exp:   []string{"example.com"},
+		},
+		"internationalised names are converted": {
+			names: []string{"Bücher.example.com."},
+			exp:   []string{"xn--bcher-kva.example.com"},
+		},
+		"names which are duplicates once normalized are removed": {
+			names: []string{"example.com", "foo.example.com", "Example.COM.", "bücher.example.com", "xn--bcher-kva.example.com"},
+			exp:   []string{"example.com", "foo.example.com", "xn--bcher-kva.example.com"},
+		},
+		"invalid internationalised names return an error": {
+			names:  []string{"example.com", "-bücher.example.com"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeDNSNames(test.names)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected names, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
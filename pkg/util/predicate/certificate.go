@@ -19,6 +19,7 @@ package predicate
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
@@ -44,3 +45,71 @@ func CertificateNextPrivateKeySecretName(name string) Func {
 		return *crt.Status.NextPrivateKeySecretName == name
 	}
 }
+
+// CertificateCanarySecretName returns a predicate that used to filter
+// Certificates to only those with the given 'spec.canary.secretName'.
+func CertificateCanarySecretName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		return crt.Spec.Canary != nil && crt.Spec.Canary.SecretName == name
+	}
+}
+
+// CertificateAdditionalTrustedCASecretName returns a predicate that used to
+// filter Certificates to only those which reference a Secret with the given
+// name in 'spec.additionalTrustedCAs'.
+func CertificateAdditionalTrustedCASecretName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		for _, ca := range crt.Spec.AdditionalTrustedCAs {
+			if ca.Secret != nil && ca.Secret.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// CertificateAdditionalTrustedCAConfigMapName returns a predicate that used
+// to filter Certificates to only those which reference a ConfigMap with the
+// given name in 'spec.additionalTrustedCAs'.
+func CertificateAdditionalTrustedCAConfigMapName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		for _, ca := range crt.Spec.AdditionalTrustedCAs {
+			if ca.ConfigMap != nil && ca.ConfigMap.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// CertificateIssuerRef returns a predicate that used to filter Certificates
+// to only those which reference the cert-manager.io issuer of the given kind
+// and name in 'spec.issuerRef'. An empty kind in 'spec.issuerRef' refers to an
+// Issuer.
+func CertificateIssuerRef(kind, name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != certmanager.GroupName {
+			return false
+		}
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = cmapi.IssuerKind
+		}
+		return refKind == kind && ref.Name == name
+	}
+}
+
+// CertificateFailedIssuance returns a predicate that used to filter
+// Certificates to only those whose most recent issuance failed, as recorded
+// in 'status.lastFailureTime'.
+func CertificateFailedIssuance() Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		return crt.Status.LastFailureTime != nil
+	}
+}
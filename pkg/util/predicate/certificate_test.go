@@ -22,6 +22,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 func TestCertificateSecretName(t *testing.T) {
@@ -92,3 +93,127 @@ func TestCertificateNextPrivateKeySecretName(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateAdditionalTrustedCAName(t *testing.T) {
+	cert := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			AdditionalTrustedCAs: []cmapi.CertificateAdditionalTrustedCA{
+				{Secret: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-secret"}, Key: "ca.crt"}},
+				{ConfigMap: &cmapi.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-bundle"}, Key: "ca.pem"}},
+			},
+		},
+	}
+	tests := map[string]struct {
+		predicate Func
+		expected  bool
+	}{
+		"returns true if a referenced secret name matches": {
+			predicate: CertificateAdditionalTrustedCASecretName("ca-secret"),
+			expected:  true,
+		},
+		"returns false if the name only matches a referenced configmap": {
+			predicate: CertificateAdditionalTrustedCASecretName("ca-bundle"),
+			expected:  false,
+		},
+		"returns true if a referenced configmap name matches": {
+			predicate: CertificateAdditionalTrustedCAConfigMapName("ca-bundle"),
+			expected:  true,
+		},
+		"returns false if no referenced configmap name matches": {
+			predicate: CertificateAdditionalTrustedCAConfigMapName("ca-secret"),
+			expected:  false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.predicate(cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestCertificateCanarySecretName(t *testing.T) {
+	tests := map[string]struct {
+		secretName string
+		cert       *cmapi.Certificate
+		expected   bool
+	}{
+		"returns true if canary secret name matches": {
+			secretName: "abc",
+			cert:       &cmapi.Certificate{Spec: cmapi.CertificateSpec{Canary: &cmapi.CertificateCanary{SecretName: "abc"}}},
+			expected:   true,
+		},
+		"returns false if canary secret name does not match": {
+			secretName: "abc",
+			cert:       &cmapi.Certificate{Spec: cmapi.CertificateSpec{Canary: &cmapi.CertificateCanary{SecretName: "abcd"}}},
+			expected:   false,
+		},
+		"returns false if canary is not configured": {
+			secretName: "abc",
+			cert:       &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "abc"}},
+			expected:   false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateCanarySecretName(test.secretName)(test.cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestCertificateIssuerRef(t *testing.T) {
+	tests := map[string]struct {
+		ref      cmmeta.ObjectReference
+		kind     string
+		name     string
+		expected bool
+	}{
+		"returns true if the Issuer matches": {
+			ref:      cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.IssuerKind},
+			kind:     cmapi.IssuerKind,
+			name:     "issuer",
+			expected: true,
+		},
+		"returns true for an Issuer if the kind is not set": {
+			ref:      cmmeta.ObjectReference{Name: "issuer"},
+			kind:     cmapi.IssuerKind,
+			name:     "issuer",
+			expected: true,
+		},
+		"returns true if the ClusterIssuer matches": {
+			ref:      cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"},
+			kind:     cmapi.ClusterIssuerKind,
+			name:     "issuer",
+			expected: true,
+		},
+		"returns false if the kind differs": {
+			ref:  cmmeta.ObjectReference{Name: "issuer"},
+			kind: cmapi.ClusterIssuerKind,
+			name: "issuer",
+		},
+		"returns false if the name differs": {
+			ref:  cmmeta.ObjectReference{Name: "other", Kind: cmapi.IssuerKind},
+			kind: cmapi.IssuerKind,
+			name: "issuer",
+		},
+		"returns false for an external issuer": {
+			ref:  cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.IssuerKind, Group: "example.com"},
+			kind: cmapi.IssuerKind,
+			name: "issuer",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cert := &cmapi.Certificate{Spec: cmapi.CertificateSpec{IssuerRef: test.ref}}
+			got := CertificateIssuerRef(test.kind, test.name)(cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
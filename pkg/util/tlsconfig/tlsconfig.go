@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tlsconfig applies the TLS configuration of issuers to the clients
+// they use to communicate with ACME servers, Vault, Venafi and DNS provider
+// APIs.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	cliflag "k8s.io/component-base/cli/flag"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Apply sets the minimum version and cipher suites of config to those set in
+// the TLS configuration of an issuer. Fields which are not set in issuerTLS,
+// or all fields if it is nil, are left unchanged.
+func Apply(config *tls.Config, issuerTLS *cmapi.IssuerTLSConfig) error {
+	if issuerTLS == nil {
+		return nil
+	}
+	if len(issuerTLS.MinVersion) > 0 {
+		minVersion, err := cliflag.TLSVersion(issuerTLS.MinVersion)
+		if err != nil {
+			return fmt.Errorf("invalid TLS minimum version: %w", err)
+		}
+		config.MinVersion = minVersion
+	}
+	if len(issuerTLS.CipherSuites) > 0 {
+		cipherSuites, err := cliflag.TLSCipherSuites(issuerTLS.CipherSuites)
+		if err != nil {
+			return fmt.Errorf("invalid TLS cipher suites: %w", err)
+		}
+		config.CipherSuites = cipherSuites
+	}
+	return nil
+}
+
+// Transport returns a copy of http.DefaultTransport which makes connections
+// with the TLS configuration of an issuer.
+func Transport(issuerTLS *cmapi.IssuerTLSConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if err := Apply(transport.TLSClientConfig, issuerTLS); err != nil {
+		return nil, err
+	}
+	return transport, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"reflect"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestApply(t *testing.T) {
+	tests := map[string]struct {
+		issuerTLS        *cmapi.IssuerTLSConfig
+		wantMinVersion   uint16
+		wantCipherSuites []uint16
+		wantErr          bool
+	}{
+		"a nil configuration leaves the config unchanged": {
+			wantMinVersion: tls.VersionTLS11,
+		},
+		"minimum version and cipher suites are set": {
+			issuerTLS: &cmapi.IssuerTLSConfig{
+				MinVersion:   "VersionTLS13",
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			},
+			wantMinVersion:   tls.VersionTLS13,
+			wantCipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+		},
+		"unset fields leave the config unchanged": {
+			issuerTLS:      &cmapi.IssuerTLSConfig{},
+			wantMinVersion: tls.VersionTLS11,
+		},
+		"unknown minimum version is an error": {
+			issuerTLS: &cmapi.IssuerTLSConfig{MinVersion: "VersionTLS14"},
+			wantErr:   true,
+		},
+		"unknown cipher suite is an error": {
+			issuerTLS: &cmapi.IssuerTLSConfig{CipherSuites: []string{"TLS_NOT_A_CIPHER"}},
+			wantErr:   true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := &tls.Config{MinVersion: tls.VersionTLS11}
+			err := Apply(config, test.issuerTLS)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.wantErr {
+				return
+			}
+			if config.MinVersion != test.wantMinVersion {
+				t.Errorf("expected minimum version %x, got %x", test.wantMinVersion, config.MinVersion)
+			}
+			if !reflect.DeepEqual(test.wantCipherSuites, config.CipherSuites) {
+				t.Errorf("expected cipher suites %v, got %v", test.wantCipherSuites, config.CipherSuites)
+			}
+		})
+	}
+}
@@ -37,6 +37,22 @@ func RestConfigWithUserAgent(restConfig *rest.Config, component ...string) *rest
 	return restConfig
 }
 
+// ExternalUserAgent returns the User Agent to use for requests made to
+// services outside of the Kubernetes cluster, such as ACME servers and DNS
+// provider APIs. If clusterID is not empty it is appended to the given User
+// Agent so that the operators of those services can correlate requests from
+// the same cluster. If redact is true, only the product name is returned so
+// that no version, platform or cluster information is disclosed.
+func ExternalUserAgent(userAgent, clusterID string, redact bool) string {
+	if redact {
+		return "cert-manager"
+	}
+	if len(clusterID) > 0 {
+		return fmt.Sprintf("%s cluster/%s", userAgent, clusterID)
+	}
+	return userAgent
+}
+
 // PrefixFromUserAgent takes the characters preceding the first /, quote
 // unprintable character and then trim what's beyond the FieldManagerMaxLength
 // limit.
@@ -59,6 +59,35 @@ func Test_RestConfigWithUserAgent(t *testing.T) {
 	}
 }
 
+func Test_ExternalUserAgent(t *testing.T) {
+	const userAgent = "cert-manager-controller/v1.9.0 (linux/amd64) cert-manager/test-commit"
+
+	tests := map[string]struct {
+		clusterID    string
+		redact       bool
+		expUserAgent string
+	}{
+		"if no cluster id given, expect the user agent unchanged": {
+			expUserAgent: userAgent,
+		},
+		"if a cluster id is given, expect it to be appended to the user agent": {
+			clusterID:    "prod-eu-1",
+			expUserAgent: userAgent + " cluster/prod-eu-1",
+		},
+		"if redacted, expect only the product name": {
+			clusterID:    "prod-eu-1",
+			redact:       true,
+			expUserAgent: "cert-manager",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expUserAgent, ExternalUserAgent(userAgent, test.clusterID, test.redact))
+		})
+	}
+}
+
 // Adapted from
 // https://github.com/kubernetes/apiserver/blob/cecf3a2e57ffdfa8f3b36db4ee0c44e59ad656e9/pkg/endpoints/handlers/create_test.go#L24
 func Test_PrefixFromUserAgent(t *testing.T) {
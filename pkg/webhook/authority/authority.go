@@ -41,6 +41,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -52,6 +53,14 @@ import (
 // and provides methods to obtain signed leaf certificates.
 // The private key and certificate will be automatically generated, and when
 // nearing expiry, the private key and root certificate will be rotated.
+//
+// Rotation is staged so that clients trusting the CA bundle stored in the
+// Secret (ca.crt), e.g. through caBundle fields injected by the cainjector, do
+// not see certificates they cannot verify:
+//  1. the new CA is generated and added to the CA bundle,
+//  2. after PropagationDelay, leaf certificates are signed by the new CA,
+//  3. after LeafDuration, when no leaf certificate signed by the previous CA
+//     can still be valid, the previous CA is removed from the CA bundle.
 type DynamicAuthority struct {
 	// Namespace and Name of the Secret resource used to store the authority.
 	SecretNamespace, SecretName string
@@ -70,9 +79,17 @@ type DynamicAuthority struct {
 	// Defaults to 7d.
 	LeafDuration time.Duration
 
+	// The amount of time a new CA certificate is present in the CA bundle
+	// before it is used to sign leaf certificates, which gives consumers of
+	// the CA bundle time to start trusting it.
+	// Defaults to 10m.
+	PropagationDelay time.Duration
+
 	// Logger to write messages to.
 	log logr.Logger
 
+	clock clock.Clock
+
 	lister corelisters.SecretNamespaceLister
 	client coreclientset.SecretInterface
 
@@ -105,6 +122,12 @@ func (d *DynamicAuthority) Run(ctx context.Context) error {
 	if d.LeafDuration == 0 {
 		d.LeafDuration = time.Hour * 24 * 7 // 7d
 	}
+	if d.PropagationDelay == 0 {
+		d.PropagationDelay = time.Minute * 10
+	}
+	if d.clock == nil {
+		d.clock = clock.RealClock{}
+	}
 
 	cl, err := kubernetes.NewForConfig(d.RESTConfig)
 	if err != nil {
@@ -246,6 +269,10 @@ func (d *DynamicAuthority) ensureCA(ctx context.Context) error {
 	if d.caRequiresRegeneration(s) {
 		return d.regenerateCA(ctx, s.DeepCopy())
 	}
+	if updated, err := d.rotateCA(ctx, s.DeepCopy()); err != nil || updated {
+		// the informer triggers another call once the update is observed
+		return err
+	}
 	d.notifyWatches(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
 	return nil
 }
@@ -276,7 +303,8 @@ func (d *DynamicAuthority) notifyWatches(newCertData, newPrivateKeyData []byte)
 }
 
 // caRequiresRegeneration will check data in a Secret resource and return true
-// if the CA needs to be regenerated for any reason.
+// if the CA needs to be regenerated immediately, i.e. without staging the
+// rotation, because the stored CA cannot be used for signing.
 func (d *DynamicAuthority) caRequiresRegeneration(s *corev1.Secret) bool {
 	if s.Data == nil {
 		return true
@@ -288,72 +316,140 @@ func (d *DynamicAuthority) caRequiresRegeneration(s *corev1.Secret) bool {
 		d.log.V(logf.InfoLevel).Info("Missing data in CA secret. Regenerating")
 		return true
 	}
-	// ensure that the ca.crt and tls.crt keys are equal
-	if !bytes.Equal(caData, certData) {
+	// ensure that the ca.crt bundle contains the tls.crt certificate
+	if !bytes.Contains(caData, certData) {
 		return true
 	}
-	cert, err := tls.X509KeyPair(certData, pkData)
+	x509Cert, err := parseCA(certData, pkData)
 	if err != nil {
 		d.log.Error(err, "Failed to parse data in CA secret. Regenerating")
 		return true
 	}
-
-	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
-	if err != nil {
-		d.log.Error(err, "internal error parsing x509 certificate")
-		return true
-	}
-	if !x509Cert.IsCA {
-		d.log.V(logf.InfoLevel).Info("Stored certificate is not marked as a CA. Regenerating...")
-		return true
-	}
-	// renew the root CA when the current one is 2/3 of the way through its life
-	if x509Cert.NotAfter.Sub(time.Now()) < (d.CADuration / 3) {
-		d.log.V(logf.InfoLevel).Info("Root CA certificate is nearing expiry. Regenerating...")
+	if !x509Cert.NotAfter.After(d.clock.Now()) {
+		d.log.V(logf.InfoLevel).Info("Root CA certificate has expired. Regenerating...")
 		return true
 	}
 	return false
 }
 
-var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+// rotateCA performs the next step of a staged rotation of the CA stored in
+// the Secret, if any is due. It returns whether the Secret has been updated.
+func (d *DynamicAuthority) rotateCA(ctx context.Context, s *corev1.Secret) (bool, error) {
+	now := d.clock.Now()
+	if s.Annotations == nil {
+		s.Annotations = make(map[string]string)
+	}
+	certData := s.Data[corev1.TLSCertKey]
+	nextCertData, nextPKData := s.Data[nextCertKey], s.Data[nextPrivateKeyKey]
+
+	if len(nextCertData) > 0 || len(nextPKData) > 0 {
+		if _, err := parseCA(nextCertData, nextPKData); err != nil {
+			d.log.Error(err, "Failed to parse the next CA in CA secret. Discarding it")
+			delete(s.Data, nextCertKey)
+			delete(s.Data, nextPrivateKeyKey)
+			delete(s.Annotations, nextCAPromoteAfterAnnotation)
+			s.Data[cmmeta.TLSCAKey] = certData
+			return true, d.updateSecret(ctx, s)
+		}
+		if promoteAfter, err := time.Parse(time.RFC3339, s.Annotations[nextCAPromoteAfterAnnotation]); err == nil && now.Before(promoteAfter) {
+			return false, nil
+		}
 
-// regenerateCA will regenerate and store a new CA.
-// If the provided Secret is nil, a new secret resource will be Created.
-// Otherwise, the provided resource will be modified and Updated.
-func (d *DynamicAuthority) regenerateCA(ctx context.Context, s *corev1.Secret) error {
-	d.log.V(logf.DebugLevel).Info("Generating new root CA")
-	pk, err := pki.GenerateECPrivateKey(384)
-	if err != nil {
-		return err
+		d.log.V(logf.InfoLevel).Info("Signing leaf certificates using the new root CA")
+		s.Data[corev1.TLSCertKey] = nextCertData
+		s.Data[corev1.TLSPrivateKeyKey] = nextPKData
+		s.Data[cmmeta.TLSCAKey] = concat(nextCertData, certData)
+		delete(s.Data, nextCertKey)
+		delete(s.Data, nextPrivateKeyKey)
+		delete(s.Annotations, nextCAPromoteAfterAnnotation)
+		s.Annotations[previousCARemoveAfterAnnotation] = now.Add(d.LeafDuration).UTC().Format(time.RFC3339)
+		return true, d.updateSecret(ctx, s)
 	}
-	pkBytes, err := pki.EncodePrivateKey(pk, cmapi.PKCS8)
+
+	if removeAfter, ok := s.Annotations[previousCARemoveAfterAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, removeAfter); err == nil && now.Before(t) {
+			return false, nil
+		}
+
+		d.log.V(logf.InfoLevel).Info("Removing the previous root CA from the CA bundle")
+		s.Data[cmmeta.TLSCAKey] = certData
+		delete(s.Annotations, previousCARemoveAfterAnnotation)
+		return true, d.updateSecret(ctx, s)
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certData)
 	if err != nil {
-		return err
+		return false, err
+	}
+	// start rotating the root CA when the current one is 2/3 of the way
+	// through its life
+	if cert.NotAfter.Sub(now) >= d.CADuration/3 {
+		return false, nil
 	}
 
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	d.log.V(logf.InfoLevel).Info("Root CA certificate is nearing expiry. Adding a new root CA to the CA bundle...")
+	nextCertData, nextPKData, err = d.generateCA()
 	if err != nil {
-		return err
+		return false, err
 	}
-	cert := &x509.Certificate{
-		Version:               2,
-		BasicConstraintsValid: true,
-		SerialNumber:          serialNumber,
-		PublicKeyAlgorithm:    x509.ECDSA,
-		Subject: pkix.Name{
-			CommonName: "cert-manager-webhook-ca",
-		},
-		IsCA:      true,
-		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(d.CADuration),
-		KeyUsage:  x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+	s.Data[nextCertKey] = nextCertData
+	s.Data[nextPrivateKeyKey] = nextPKData
+	s.Data[cmmeta.TLSCAKey] = concat(certData, nextCertData)
+	s.Annotations[nextCAPromoteAfterAnnotation] = now.Add(d.PropagationDelay).UTC().Format(time.RFC3339)
+	return true, d.updateSecret(ctx, s)
+}
+
+func (d *DynamicAuthority) updateSecret(ctx context.Context, s *corev1.Secret) error {
+	_, err := d.client.Update(ctx, s, metav1.UpdateOptions{})
+	return err
+}
+
+// parseCA parses and verifies a PEM-encoded CA certificate and private key.
+func parseCA(certData, pkData []byte) (*x509.Certificate, error) {
+	// tls.X509KeyPair performs a number of verification checks against the
+	// keypair.
+	cert, err := tls.X509KeyPair(certData, pkData)
+	if err != nil {
+		return nil, err
 	}
-	// self sign the root CA
-	_, cert, err = pki.SignCertificate(cert, cert, pk.Public(), pk)
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
 	if err != nil {
-		return err
+		return nil, err
 	}
-	certBytes, err := pki.EncodeX509(cert)
+	if !x509Cert.IsCA {
+		return nil, fmt.Errorf("certificate is not marked as a CA")
+	}
+	return x509Cert, nil
+}
+
+func concat(certs ...[]byte) []byte {
+	return bytes.Join(certs, nil)
+}
+
+var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+
+const (
+	// nextCertKey and nextPrivateKeyKey hold a new CA which has been added to
+	// the CA bundle but is not used to sign leaf certificates yet.
+	nextCertKey       = "next.crt"
+	nextPrivateKeyKey = "next.key"
+
+	// nextCAPromoteAfterAnnotation is the time after which the next CA is used
+	// to sign leaf certificates.
+	nextCAPromoteAfterAnnotation = "cert-manager.io/next-ca-promote-after"
+
+	// previousCARemoveAfterAnnotation is the time after which the previous CA
+	// is removed from the CA bundle.
+	previousCARemoveAfterAnnotation = "cert-manager.io/previous-ca-remove-after"
+)
+
+// regenerateCA will regenerate and store a new CA, replacing any CA stored
+// in the Secret without staging the rotation.
+// If the provided Secret is nil, a new secret resource will be Created.
+// Otherwise, the provided resource will be modified and Updated.
+func (d *DynamicAuthority) regenerateCA(ctx context.Context, s *corev1.Secret) error {
+	d.log.V(logf.DebugLevel).Info("Generating new root CA")
+	certBytes, pkBytes, err := d.generateCA()
 	if err != nil {
 		return err
 	}
@@ -382,13 +478,58 @@ func (d *DynamicAuthority) regenerateCA(ctx context.Context, s *corev1.Secret) e
 	s.Data[corev1.TLSCertKey] = certBytes
 	s.Data[corev1.TLSPrivateKeyKey] = pkBytes
 	s.Data[cmmeta.TLSCAKey] = certBytes
-	if _, err := d.client.Update(ctx, s, metav1.UpdateOptions{}); err != nil {
+	delete(s.Data, nextCertKey)
+	delete(s.Data, nextPrivateKeyKey)
+	delete(s.Annotations, nextCAPromoteAfterAnnotation)
+	delete(s.Annotations, previousCARemoveAfterAnnotation)
+	if err := d.updateSecret(ctx, s); err != nil {
 		return err
 	}
 	d.log.V(logf.DebugLevel).Info("Generated new root CA")
 	return nil
 }
 
+// generateCA generates a new self signed CA and returns its PEM-encoded
+// certificate and private key.
+func (d *DynamicAuthority) generateCA() ([]byte, []byte, error) {
+	pk, err := pki.GenerateECPrivateKey(384)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkBytes, err := pki.EncodePrivateKey(pk, cmapi.PKCS8)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert := &x509.Certificate{
+		Version:               2,
+		BasicConstraintsValid: true,
+		SerialNumber:          serialNumber,
+		PublicKeyAlgorithm:    x509.ECDSA,
+		Subject: pkix.Name{
+			CommonName: "cert-manager-webhook-ca",
+		},
+		IsCA:      true,
+		NotBefore: d.clock.Now(),
+		NotAfter:  d.clock.Now().Add(d.CADuration),
+		KeyUsage:  x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+	}
+	// self sign the root CA
+	_, cert, err = pki.SignCertificate(cert, cert, pk.Public(), pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	certBytes, err := pki.EncodeX509(cert)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certBytes, pkBytes, nil
+}
+
 func (d *DynamicAuthority) handleAdd(obj interface{}) {
 	ctx := context.Background()
 	if err := d.ensureCA(ctx); err != nil {
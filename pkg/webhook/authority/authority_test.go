@@ -17,3 +17,116 @@ limitations under the License.
 package authority
 
 // Integration tests for the authority can be found in `test/integration/webhook/dynamic_authority_test.go`.
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+// Ensure that the rotation of the CA is staged, so that the new CA is
+// trusted before it signs leaf certificates and the previous CA is trusted
+// until leaf certificates it signed have expired.
+func TestDynamicAuthority_StagedRotation(t *testing.T) {
+	ctx := context.Background()
+	clock := fakeclock.NewFakeClock(time.Now())
+	cl := fake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	d := &DynamicAuthority{
+		SecretNamespace:  "cert-manager",
+		SecretName:       "ca",
+		CADuration:       time.Hour * 24 * 30,
+		LeafDuration:     time.Hour,
+		PropagationDelay: time.Minute * 10,
+		log:              logr.Discard(),
+		clock:            clock,
+		lister:           corelisters.NewSecretLister(indexer).Secrets("cert-manager"),
+		client:           cl.CoreV1().Secrets("cert-manager"),
+	}
+
+	// ensure runs ensureCA with the informer cache in sync with the API
+	// server, and returns the stored Secret.
+	ensure := func() *corev1.Secret {
+		t.Helper()
+		if s, err := d.client.Get(ctx, d.SecretName, metav1.GetOptions{}); err == nil {
+			if err := indexer.Update(s); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := d.ensureCA(ctx); err != nil {
+			t.Fatal(err)
+		}
+		s, err := d.client.Get(ctx, d.SecretName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	s := ensure()
+	first := s.Data[corev1.TLSCertKey]
+	if !bytes.Equal(s.Data[cmmeta.TLSCAKey], first) {
+		t.Fatal("expected the CA bundle of a new CA to only contain the CA")
+	}
+
+	// Nothing happens before the CA is 2/3 of the way through its life.
+	clock.Step(d.CADuration / 2)
+	if s := ensure(); !bytes.Equal(s.Data[cmmeta.TLSCAKey], first) || len(s.Data[nextCertKey]) > 0 {
+		t.Fatal("expected the CA not to be rotated")
+	}
+
+	// The new CA is added to the CA bundle first.
+	clock.Step(d.CADuration / 4)
+	s = ensure()
+	next := s.Data[nextCertKey]
+	if len(next) == 0 {
+		t.Fatal("expected a new CA to be generated")
+	}
+	if !bytes.Equal(s.Data[corev1.TLSCertKey], first) {
+		t.Fatal("expected leaf certificates to be signed by the current CA until the new CA has propagated")
+	}
+	if !bytes.Equal(s.Data[cmmeta.TLSCAKey], append(append([]byte{}, first...), next...)) {
+		t.Fatal("expected the CA bundle to contain the current and the new CA")
+	}
+	if s := ensure(); !bytes.Equal(s.Data[corev1.TLSCertKey], first) {
+		t.Fatal("expected the new CA not to be used before the propagation delay")
+	}
+
+	// The new CA is used to sign leaf certificates after the propagation
+	// delay, and the previous CA remains trusted.
+	clock.Step(d.PropagationDelay)
+	s = ensure()
+	if !bytes.Equal(s.Data[corev1.TLSCertKey], next) || len(s.Data[nextCertKey]) > 0 {
+		t.Fatal("expected the new CA to be used to sign leaf certificates")
+	}
+	if !bytes.Equal(s.Data[cmmeta.TLSCAKey], append(append([]byte{}, next...), first...)) {
+		t.Fatal("expected the CA bundle to contain the new and the previous CA")
+	}
+	ensure()
+	if !bytes.Equal(d.currentCertData, next) {
+		t.Fatal("expected the new CA to be loaded")
+	}
+
+	// The previous CA is removed once leaf certificates it signed have
+	// expired.
+	clock.Step(d.LeafDuration)
+	s = ensure()
+	if !bytes.Equal(s.Data[cmmeta.TLSCAKey], next) {
+		t.Fatal("expected the previous CA to be removed from the CA bundle")
+	}
+	if _, ok := s.Annotations[previousCARemoveAfterAnnotation]; ok {
+		t.Fatal("expected the rotation annotations to be removed")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// IssuerConformance configures the issuer conformance suite to run against
+// an existing Issuer or ClusterIssuer, such as one managed by a user or by
+// an external issuer.
+type IssuerConformance struct {
+	// IssuerName is the name of the issuer to test. If not set, the suite is
+	// only run against the issuers provisioned by the e2e tests.
+	IssuerName string
+	// IssuerKind is the kind of the issuer to test.
+	IssuerKind string
+	// IssuerGroup is the API group of the issuer to test.
+	IssuerGroup string
+	// Namespace is the namespace Certificates are created in. It must be set
+	// to the namespace of the issuer when testing a namespaced issuer.
+	Namespace string
+	// DomainSuffix is the domain the DNS names of the Certificates are
+	// generated under.
+	DomainSuffix string
+	// CasesDir is a directory of YAML test cases to run instead of the test
+	// cases that are built into the suite.
+	CasesDir string
+	// UnsupportedFeatures is a comma separated list of features that the
+	// issuer does not support.
+	UnsupportedFeatures string
+	// RevokeCommand is a command that revokes the PEM encoded certificate
+	// given on its standard input. Test cases that revoke certificates are
+	// skipped if not set.
+	RevokeCommand string
+}
+
+func (c *IssuerConformance) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.IssuerName, "suite.conformance-issuer-name", "", ""+
+		"The name of an existing issuer to run the issuer conformance suite against. If not specified, the suite only runs against issuers provisioned by the tests")
+	fs.StringVar(&c.IssuerKind, "suite.conformance-issuer-kind", "ClusterIssuer", ""+
+		"The kind of the issuer to run the issuer conformance suite against")
+	fs.StringVar(&c.IssuerGroup, "suite.conformance-issuer-group", "cert-manager.io", ""+
+		"The API group of the issuer to run the issuer conformance suite against")
+	fs.StringVar(&c.Namespace, "suite.conformance-namespace", "", ""+
+		"The namespace to create Certificates in. Required if the issuer is namespaced, in which case it must be the namespace of the issuer")
+	fs.StringVar(&c.DomainSuffix, "suite.conformance-domain-suffix", "example.com", ""+
+		"The domain that DNS names requested by the issuer conformance suite are generated under")
+	fs.StringVar(&c.CasesDir, "suite.conformance-cases-dir", "", ""+
+		"A directory of YAML test cases to run instead of the test cases built into the issuer conformance suite")
+	fs.StringVar(&c.UnsupportedFeatures, "suite.conformance-unsupported-features", "", ""+
+		"A comma separated list of features not supported by the issuer, e.g. IPAddresses,URISANs. Test cases that require them are skipped")
+	fs.StringVar(&c.RevokeCommand, "suite.conformance-revoke-command", "", ""+
+		"A shell command that revokes the PEM encoded certificate given on its standard input. If not specified, revocation test cases are skipped")
+}
+
+func (c *IssuerConformance) Validate() []error {
+	if c.IssuerName == "" {
+		return nil
+	}
+	var errs []error
+	if c.IssuerKind == "" {
+		errs = append(errs, fmt.Errorf("--suite.conformance-issuer-kind must be specified"))
+	}
+	if c.IssuerKind == "Issuer" && c.Namespace == "" {
+		errs = append(errs, fmt.Errorf("--suite.conformance-namespace must be specified when testing an Issuer"))
+	}
+	if c.DomainSuffix == "" {
+		errs = append(errs, fmt.Errorf("--suite.conformance-domain-suffix must be specified"))
+	}
+	return errs
+}
+
+// UnsupportedFeatureList returns the features listed in UnsupportedFeatures.
+func (c *IssuerConformance) UnsupportedFeatureList() []string {
+	var features []string
+	for _, f := range strings.Split(c.UnsupportedFeatures, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			features = append(features, f)
+		}
+	}
+	return features
+}
@@ -23,7 +23,8 @@ import (
 )
 
 type Suite struct {
-	ACME ACME
+	ACME              ACME
+	IssuerConformance IssuerConformance
 }
 
 type ACME struct {
@@ -38,11 +39,13 @@ type Cloudflare struct {
 
 func (f *Suite) AddFlags(fs *flag.FlagSet) {
 	f.ACME.AddFlags(fs)
+	f.IssuerConformance.AddFlags(fs)
 }
 
 func (c *Suite) Validate() []error {
 	var errs []error
 	errs = append(errs, c.ACME.Validate()...)
+	errs = append(errs, c.IssuerConformance.Validate()...)
 	return errs
 }
 
@@ -162,4 +162,10 @@ const (
 	// a certificate containing an arbitrary Subject in the CSR, without
 	// imposing requirements on form or structure.
 	LiteralSubjectFeature Feature = "LiteralCertificateSubject"
+
+	// RevocationFeature denotes whether certificates signed by the target
+	// issuer can be revoked. cert-manager has no revocation API of its own,
+	// so this is only supported when the suite is given a way to revoke
+	// certificates with the issuer.
+	RevocationFeature Feature = "Revocation"
 )
@@ -29,5 +29,7 @@ import (
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificatesigningrequests/selfsigned"
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificatesigningrequests/vault"
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/certificatesigningrequests/venafi"
+	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/issuers/configured"
+	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/issuers/selfsigned"
 	_ "github.com/cert-manager/cert-manager/test/e2e/suite/conformance/rbac"
 )
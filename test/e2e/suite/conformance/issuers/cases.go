@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/e2e/framework/helper/featureset"
+)
+
+// DomainPlaceholder is replaced in the Certificate of a test case by a random
+// subdomain of the domain suffix of the suite. Every occurrence within a test
+// case is replaced by the same subdomain.
+const DomainPlaceholder = "$(DOMAIN)"
+
+// Step is an action performed on the Certificate of a test case.
+type Step string
+
+const (
+	// StepIssue creates the Certificate and waits for it to be issued.
+	StepIssue Step = "issue"
+	// StepRenew forces the renewal of the Certificate and waits for a new
+	// certificate to be issued.
+	StepRenew Step = "renew"
+	// StepRevoke revokes the current certificate with the issuer.
+	StepRevoke Step = "revoke"
+)
+
+// TestCase is a declarative conformance test case.
+type TestCase struct {
+	// Name describes the test case. It must be unique within a suite.
+	Name string `json:"name"`
+
+	// Features lists the features the issuer must support for the test case
+	// to be run, e.g. IPAddresses or Wildcards.
+	Features []featureset.Feature `json:"features,omitempty"`
+
+	// Certificate is the spec of the Certificate requested by the test case.
+	// The secretName and issuerRef fields are set by the suite.
+	Certificate cmapi.CertificateSpec `json:"certificate"`
+
+	// Steps are performed in order. The first step must be issue.
+	Steps []Step `json:"steps"`
+}
+
+// requiredFeatures returns the features required to run the test case,
+// including those implied by its steps.
+func (tc TestCase) requiredFeatures() []featureset.Feature {
+	features := append([]featureset.Feature(nil), tc.Features...)
+	for _, step := range tc.Steps {
+		if step == StepRevoke {
+			features = append(features, featureset.RevocationFeature)
+			break
+		}
+	}
+	return features
+}
+
+// certificateSpec returns the Certificate spec of the test case with the
+// domain placeholder replaced by domain.
+func (tc TestCase) certificateSpec(domain string) (cmapi.CertificateSpec, error) {
+	var spec cmapi.CertificateSpec
+	data, err := json.Marshal(tc.Certificate)
+	if err != nil {
+		return spec, err
+	}
+	data = []byte(strings.ReplaceAll(string(data), DomainPlaceholder, domain))
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return spec, err
+	}
+	return spec, nil
+}
+
+func (tc TestCase) validate() error {
+	if tc.Name == "" {
+		return fmt.Errorf("name must be set")
+	}
+	if len(tc.Steps) == 0 || tc.Steps[0] != StepIssue {
+		return fmt.Errorf("%q: the first step must be %q", tc.Name, StepIssue)
+	}
+	for _, step := range tc.Steps[1:] {
+		switch step {
+		case StepRenew, StepRevoke:
+		case StepIssue:
+			return fmt.Errorf("%q: the certificate can only be issued once, use %q to issue it again", tc.Name, StepRenew)
+		default:
+			return fmt.Errorf("%q: unknown step %q, must be one of %q, %q or %q", tc.Name, step, StepIssue, StepRenew, StepRevoke)
+		}
+	}
+	if tc.Certificate.SecretName != "" || tc.Certificate.IssuerRef.Name != "" {
+		return fmt.Errorf("%q: certificate.secretName and certificate.issuerRef are set by the suite and must not be set", tc.Name)
+	}
+	return nil
+}
+
+//go:embed cases/*.yaml
+var defaultCases embed.FS
+
+// DefaultTestCases returns the test cases built into the suite.
+func DefaultTestCases() ([]TestCase, error) {
+	sub, err := fs.Sub(defaultCases, "cases")
+	if err != nil {
+		return nil, err
+	}
+	return LoadTestCases(sub)
+}
+
+// LoadTestCasesFromDir loads the test cases of every YAML file in dir.
+func LoadTestCasesFromDir(dir string) ([]TestCase, error) {
+	return LoadTestCases(os.DirFS(dir))
+}
+
+// LoadTestCases loads the test cases of every YAML file at the root of fsys,
+// in lexical order of the file names. Every file holds a list of test cases.
+func LoadTestCases(fsys fs.FS) ([]TestCase, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if ext := path.Ext(e.Name()); !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+
+	var cases []TestCase
+	names := make(map[string]string)
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var fileCases []TestCase
+		if err := yaml.UnmarshalStrict(data, &fileCases); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for _, tc := range fileCases {
+			if err := tc.validate(); err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+			if other, ok := names[tc.Name]; ok {
+				return nil, fmt.Errorf("%s: test case %q is already defined in %s", file, tc.Name, other)
+			}
+			names[tc.Name] = file
+			cases = append(cases, tc)
+		}
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("no test cases found")
+	}
+	return cases, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/test/e2e/framework/helper/featureset"
+)
+
+func TestDefaultTestCases(t *testing.T) {
+	cases, err := DefaultTestCases()
+	require.NoError(t, err)
+	assert.NotEmpty(t, cases)
+}
+
+func TestLoadTestCases(t *testing.T) {
+	tests := map[string]struct {
+		files   map[string]string
+		want    []string
+		wantErr string
+	}{
+		"test cases are loaded in the order of the files": {
+			files: map[string]string{
+				"b.yaml":    "- name: b\n  steps: [issue]\n",
+				"a.yml":     "- name: a1\n  steps: [issue]\n- name: a2\n  steps: [issue, renew, revoke]\n",
+				"README.md": "not a test case",
+			},
+			want: []string{"a1", "a2", "b"},
+		},
+		"unknown fields are rejected": {
+			files:   map[string]string{"a.yaml": "- name: a\n  step: [issue]\n"},
+			wantErr: `unknown field "step"`,
+		},
+		"the first step must be issue": {
+			files:   map[string]string{"a.yaml": "- name: a\n  steps: [renew]\n"},
+			wantErr: `a.yaml: "a": the first step must be "issue"`,
+		},
+		"unknown steps are rejected": {
+			files:   map[string]string{"a.yaml": "- name: a\n  steps: [issue, delete]\n"},
+			wantErr: `unknown step "delete"`,
+		},
+		"the issuer is set by the suite": {
+			files:   map[string]string{"a.yaml": "- name: a\n  certificate:\n    issuerRef:\n      name: ca\n  steps: [issue]\n"},
+			wantErr: "must not be set",
+		},
+		"names must be unique": {
+			files: map[string]string{
+				"a.yaml": "- name: a\n  steps: [issue]\n",
+				"b.yaml": "- name: a\n  steps: [issue]\n",
+			},
+			wantErr: `b.yaml: test case "a" is already defined in a.yaml`,
+		},
+		"at least one test case is required": {
+			files:   map[string]string{},
+			wantErr: "no test cases found",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fsys := make(fstest.MapFS)
+			for file, data := range test.files {
+				fsys[file] = &fstest.MapFile{Data: []byte(data)}
+			}
+			cases, err := LoadTestCases(fsys)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, tc := range cases {
+				names = append(names, tc.Name)
+			}
+			assert.Equal(t, test.want, names)
+		})
+	}
+}
+
+func TestTestCase(t *testing.T) {
+	tc := TestCase{
+		Features: []featureset.Feature{featureset.WildcardsFeature},
+		Steps:    []Step{StepIssue, StepRevoke},
+	}
+	tc.Certificate.CommonName = DomainPlaceholder
+	tc.Certificate.DNSNames = []string{DomainPlaceholder, "*." + DomainPlaceholder}
+
+	assert.Equal(t, []featureset.Feature{featureset.WildcardsFeature, featureset.RevocationFeature}, tc.requiredFeatures())
+
+	spec, err := tc.certificateSpec("abcde.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "abcde.example.com", spec.CommonName)
+	assert.Equal(t, []string{"abcde.example.com", "*.abcde.example.com"}, spec.DNSNames)
+	// The test case itself is left untouched.
+	assert.Equal(t, DomainPlaceholder, tc.Certificate.CommonName)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package configured runs the issuer conformance suite against an existing
+// issuer configured with the --suite.conformance-* flags, so that users can
+// validate their own issuers, e.g.
+//
+//	make/e2e.sh --ginkgo.focus '\[Conformance\] Issuers with issuer type Configured' \
+//	  --suite.conformance-issuer-name=letsencrypt-staging \
+//	  --suite.conformance-domain-suffix=example.com
+package configured
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/e2e/framework"
+	"github.com/cert-manager/cert-manager/test/e2e/framework/helper/featureset"
+	"github.com/cert-manager/cert-manager/test/e2e/suite/conformance/issuers"
+)
+
+var _ = framework.ConformanceDescribe("Issuers", func() {
+	cfg := framework.DefaultConfig.Suite.IssuerConformance
+	if cfg.IssuerName == "" {
+		return
+	}
+
+	suite := &issuers.Suite{
+		Name: fmt.Sprintf("Configured %s %s", cfg.IssuerKind, cfg.IssuerName),
+		CreateIssuerFunc: func(*framework.Framework) cmmeta.ObjectReference {
+			return cmmeta.ObjectReference{
+				Group: cfg.IssuerGroup,
+				Kind:  cfg.IssuerKind,
+				Name:  cfg.IssuerName,
+			}
+		},
+		Namespace:           cfg.Namespace,
+		DomainSuffix:        cfg.DomainSuffix,
+		UnsupportedFeatures: featureset.NewFeatureSet(),
+	}
+	for _, f := range cfg.UnsupportedFeatureList() {
+		suite.UnsupportedFeatures.Add(featureset.Feature(f))
+	}
+	if cfg.CasesDir != "" {
+		cases, err := issuers.LoadTestCasesFromDir(cfg.CasesDir)
+		if err != nil {
+			framework.Failf("Failed to load the issuer conformance test cases: %v", err)
+		}
+		suite.TestCases = cases
+	}
+	if cfg.RevokeCommand != "" {
+		suite.RevokeFunc = revokeWithCommand(cfg.RevokeCommand)
+	}
+	suite.Define()
+})
+
+// revokeWithCommand returns a RevokeFunc that runs command with the PEM
+// encoded certificate on its standard input.
+func revokeWithCommand(command string) issuers.RevokeFunc {
+	return func(_ *framework.Framework, _ *cmapi.Certificate, cert *x509.Certificate) error {
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Stdin = strings.NewReader(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to run the revoke command: %w: %s", err, out)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuers implements a declarative conformance test suite that runs
+// test cases described in YAML against any Issuer or ClusterIssuer.
+package issuers
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/e2e/framework"
+	"github.com/cert-manager/cert-manager/test/e2e/framework/helper/featureset"
+)
+
+// RevokeFunc revokes cert, the certificate currently stored in the Secret of
+// crt, with the issuer of crt.
+type RevokeFunc func(f *framework.Framework, crt *cmapi.Certificate, cert *x509.Certificate) error
+
+// Suite defines a reusable conformance test suite that runs declarative test
+// cases against any Issuer implementation.
+type Suite struct {
+	// Name is the name of the issuer being tested, e.g. SelfSigned, CA, ACME
+	// This field must be provided.
+	Name string
+
+	// CreateIssuerFunc is a function that provisions a new issuer resource and
+	// returns an ObjectReference to that Issuer that will be used as the
+	// IssuerRef on Certificate resources that this suite creates.
+	// This field must be provided.
+	CreateIssuerFunc func(*framework.Framework) cmmeta.ObjectReference
+
+	// DeleteIssuerFunc is a function that is run after the test has completed
+	// in order to clean up resources created for a test (e.g. the resources
+	// created in CreateIssuerFunc).
+	// This function will be run regardless whether the test passes or fails.
+	// If not specified, this function will be skipped.
+	DeleteIssuerFunc func(*framework.Framework, cmmeta.ObjectReference)
+
+	// Namespace is the namespace Certificates are created in. If not set,
+	// the namespace created for each test is used.
+	// This must be set to the namespace of the issuer when testing an
+	// existing namespaced issuer.
+	Namespace string
+
+	// DomainSuffix is the domain under which the subdomains that replace
+	// DomainPlaceholder in test cases are generated.
+	// If not set, this will be defaulted to example.com.
+	DomainSuffix string
+
+	// TestCases are the test cases to run.
+	// If not set, the test cases built into the suite are run.
+	TestCases []TestCase
+
+	// UnsupportedFeatures is a list of features that are not supported by this
+	// invocation of the test suite. Test cases that require any of them are
+	// skipped.
+	UnsupportedFeatures featureset.FeatureSet
+
+	// RevokeFunc is used by test cases that revoke certificates.
+	// If not set, the Revocation feature is treated as unsupported.
+	RevokeFunc RevokeFunc
+
+	// completed is used internally to track whether complete() has been called
+	completed bool
+}
+
+// complete will validate configuration and set default values.
+func (s *Suite) complete() error {
+	if s.completed {
+		return nil
+	}
+
+	if s.Name == "" {
+		return fmt.Errorf("Name must be set")
+	}
+
+	if s.CreateIssuerFunc == nil {
+		return fmt.Errorf("CreateIssuerFunc must be set")
+	}
+
+	if s.DomainSuffix == "" {
+		s.DomainSuffix = "example.com"
+	}
+
+	if s.TestCases == nil {
+		cases, err := DefaultTestCases()
+		if err != nil {
+			return fmt.Errorf("failed to load the default test cases: %w", err)
+		}
+		s.TestCases = cases
+	}
+
+	if s.UnsupportedFeatures == nil {
+		s.UnsupportedFeatures = make(featureset.FeatureSet)
+	}
+	if s.RevokeFunc == nil {
+		s.UnsupportedFeatures = s.UnsupportedFeatures.Copy().Add(featureset.RevocationFeature)
+	}
+
+	s.completed = true
+	return nil
+}
+
+// checkFeatures is a helper function that is used to ensure that the features
+// required for a given test case are supported by the suite.
+// It will return 'true' if all features are supported and the test should run,
+// or return 'false' if any required feature is not supported.
+func (s *Suite) checkFeatures(fs ...featureset.Feature) bool {
+	for _, f := range fs {
+		if s.UnsupportedFeatures.Contains(f) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/e2e/framework"
+	"github.com/cert-manager/cert-manager/test/e2e/framework/helper/validation"
+	e2eutil "github.com/cert-manager/cert-manager/test/e2e/util"
+)
+
+// Define defines a conformance test for each test case of the suite that only
+// requires features supported by the issuer.
+func (s *Suite) Define() {
+	Describe("with issuer type "+s.Name, func() {
+		f := framework.NewDefaultFramework("issuers")
+
+		if err := s.complete(); err != nil {
+			framework.Failf("Invalid issuer conformance suite %q: %v", s.Name, err)
+		}
+
+		for _, tc := range s.TestCases {
+			tc := tc
+			if !s.checkFeatures(tc.requiredFeatures()...) {
+				continue
+			}
+			It("should "+tc.Name, func() {
+				By("Creating an issuer resource")
+				issuerRef := s.CreateIssuerFunc(f)
+				defer func() {
+					if s.DeleteIssuerFunc != nil {
+						By("Cleaning up the issuer resource")
+						s.DeleteIssuerFunc(f, issuerRef)
+					}
+				}()
+				s.run(f, tc, issuerRef)
+			})
+		}
+	})
+}
+
+// run performs the steps of a test case.
+func (s *Suite) run(f *framework.Framework, tc TestCase, issuerRef cmmeta.ObjectReference) {
+	ctx := context.Background()
+
+	namespace := s.Namespace
+	if namespace == "" {
+		namespace = f.Namespace.Name
+	}
+
+	spec, err := tc.certificateSpec(e2eutil.RandomSubdomain(s.DomainSuffix))
+	Expect(err).NotTo(HaveOccurred())
+	name := "conformance-" + util.RandStringRunes(5)
+	spec.SecretName = name + "-tls"
+	spec.IssuerRef = issuerRef
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+
+	defer func() {
+		By("Cleaning up the Certificate")
+		err := f.CertManagerClientSet.CertmanagerV1().Certificates(namespace).Delete(ctx, crt.Name, metav1.DeleteOptions{})
+		Expect(crtclient.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		err = f.KubeClientSet.CoreV1().Secrets(namespace).Delete(ctx, crt.Spec.SecretName, metav1.DeleteOptions{})
+		Expect(crtclient.IgnoreNotFound(err)).NotTo(HaveOccurred())
+	}()
+
+	var current *x509.Certificate
+	for _, step := range tc.Steps {
+		switch step {
+		case StepIssue:
+			By("Creating a Certificate")
+			crt, err = f.CertManagerClientSet.CertmanagerV1().Certificates(namespace).Create(ctx, crt, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			crt, current = s.waitForIssuance(f, crt)
+
+		case StepRenew:
+			By("Renewing the Certificate")
+			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				crt, err = f.CertManagerClientSet.CertmanagerV1().Certificates(namespace).Get(ctx, crt.Name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "e2e-testing", "Renewing for the issuer conformance suite")
+				crt, err = f.CertManagerClientSet.CertmanagerV1().Certificates(namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+				return err
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			previous := current
+			crt, current = s.waitForIssuance(f, crt)
+			Expect(current.SerialNumber).NotTo(Equal(previous.SerialNumber), "expected a new certificate to be issued")
+			if pk := crt.Spec.PrivateKey; pk != nil && pk.RotationPolicy == cmapi.RotationPolicyNever {
+				Expect(current.PublicKey).To(Equal(previous.PublicKey), "expected the private key to be reused")
+			}
+
+		case StepRevoke:
+			By("Revoking the certificate")
+			Expect(s.RevokeFunc(f, crt, current)).To(Succeed())
+
+			s.expectRevoked(f, crt, current)
+		}
+	}
+}
+
+// waitForIssuance waits for the Certificate to be issued, validates it and
+// returns the issued certificate.
+func (s *Suite) waitForIssuance(f *framework.Framework, crt *cmapi.Certificate) (*cmapi.Certificate, *x509.Certificate) {
+	By("Waiting for the Certificate to be issued...")
+	crt, err := f.Helper().WaitForCertificateReadyAndDoneIssuing(crt, time.Minute*8)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("Validating the issued Certificate...")
+	err = f.Helper().ValidateCertificate(crt, validation.CertificateSetForUnsupportedFeatureSet(s.UnsupportedFeatures)...)
+	Expect(err).NotTo(HaveOccurred())
+
+	secret, err := f.KubeClientSet.CoreV1().Secrets(crt.Namespace).Get(context.Background(), crt.Spec.SecretName, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	Expect(err).NotTo(HaveOccurred())
+
+	return crt, cert
+}
+
+// expectRevoked checks with the OCSP responder of the certificate, if it has
+// one, that the certificate has been revoked.
+func (s *Suite) expectRevoked(f *framework.Framework, crt *cmapi.Certificate, cert *x509.Certificate) {
+	if len(cert.OCSPServer) == 0 {
+		By("Skipping the revocation check as the certificate has no OCSP server")
+		return
+	}
+
+	secret, err := f.KubeClientSet.CoreV1().Secrets(crt.Namespace).Get(context.Background(), crt.Spec.SecretName, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	issuer, err := issuerOf(secret)
+	Expect(err).NotTo(HaveOccurred())
+
+	By("Checking the revocation status of the certificate with its OCSP server")
+	Eventually(func() (int, error) {
+		return ocspStatus(cert.OCSPServer[0], cert, issuer)
+	}, time.Minute*2, time.Second*5).Should(Equal(ocsp.Revoked))
+}
+
+// issuerOf returns the certificate of the issuer of the certificate stored in
+// the Secret, which is the second certificate of the chain, or the CA.
+func issuerOf(secret *corev1.Secret) (*x509.Certificate, error) {
+	chain, err := pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) > 1 {
+		return chain[1], nil
+	}
+	if ca := secret.Data[cmmeta.TLSCAKey]; len(ca) > 0 {
+		return pki.DecodeX509CertificateBytes(ca)
+	}
+	return nil, fmt.Errorf("the secret contains neither a certificate chain nor a CA certificate")
+}
+
+func ocspStatus(server string, cert, issuer *x509.Certificate) (int, error) {
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.Post(server, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code from OCSP server: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	res, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return 0, err
+	}
+	return res.Status, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/test/e2e/framework"
+)
+
+var _ = framework.CertManagerDescribe("ACME Issuer RBAC", func() {
+	f := framework.NewDefaultFramework("acme-issuer-rbac")
+
+	// Automatically registered acme-dns accounts are stored in the Secret
+	// referenced by the DNS01 solver, in the namespace of the Issuer. The
+	// controller is either granted this cluster wide, or in each namespace by
+	// the secret-access controller when namespaced Secret access is enabled,
+	// so the RoleBinding may take a moment to be created.
+	It("should allow the controller to update Secrets in the Issuer's namespace", func() {
+		certManager := f.Config.Addons.CertManager
+		user := "system:serviceaccount:" + certManager.ClusterResourceNamespace + ":" + certManager.ServiceAccountName
+
+		By("Submitting a subject access review for the cert-manager controller")
+		Eventually(func() (bool, error) {
+			sar, err := f.KubeClientSet.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User: user,
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: f.Namespace.Name,
+						Verb:      "update",
+						Resource:  "secrets",
+					},
+				},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return false, err
+			}
+			return sar.Status.Allowed, nil
+		}).WithTimeout(time.Minute).WithPolling(time.Second).Should(BeTrue())
+	})
+})
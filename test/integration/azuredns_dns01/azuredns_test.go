@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package azuredns runs the Azure DNS provider against an in-process mock of
+// the Azure Resource Manager DNS API, using a local DNS server for zone
+// detection.
+package azuredns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/azuredns"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	testserver "github.com/cert-manager/cert-manager/test/acme/dns/server"
+)
+
+const (
+	testSubscription  = "subscription"
+	testTenant        = "tenant"
+	testResourceGroup = "resource-group"
+)
+
+// armServer is a minimal mock of the Azure Active Directory token endpoint
+// and the Azure Resource Manager DNS API.
+type armServer struct {
+	lock    sync.Mutex
+	zones   map[string]bool
+	records map[string][]string
+}
+
+func (a *armServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if r.URL.Path == "/"+testTenant+"/oauth2/token" {
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "token",
+			"expires_in":   "3600",
+			"expires_on":   fmt.Sprint(time.Now().Add(time.Hour).Unix()),
+			"not_before":   fmt.Sprint(time.Now().Unix()),
+			"token_type":   "Bearer",
+		})
+		return
+	}
+
+	prefix := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/dnsZones/", testSubscription, testResourceGroup)
+	if r.Header.Get("Authorization") != "Bearer token" || !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The remaining path is either '<zone>' or '<zone>/TXT/<name>'.
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if !a.zones[parts[0]] {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]string{"name": parts[0]})
+	case len(parts) == 3 && parts[1] == "TXT" && r.Method == http.MethodPut:
+		var body struct {
+			Properties struct {
+				TXTRecords []struct {
+					Value []string `json:"value"`
+				} `json:"TXTRecords"`
+			} `json:"properties"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var values []string
+		for _, rec := range body.Properties.TXTRecords {
+			values = append(values, rec.Value...)
+		}
+		a.records[parts[2]+"."+parts[0]] = values
+		json.NewEncoder(w).Encode(body)
+	case len(parts) == 3 && parts[1] == "TXT" && r.Method == http.MethodDelete:
+		delete(a.records, parts[2]+"."+parts[0])
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (a *armServer) record(name string) []string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.records[name]
+}
+
+// newProvider returns an Azure DNS provider using the mock API server and
+// the given nameserver for zone detection.
+func newProvider(t *testing.T, api *httptest.Server, nameserver string) *azuredns.DNSProvider {
+	env, err := json.Marshal(map[string]string{
+		"name":                      "AzureStackCloud",
+		"resourceManagerEndpoint":   api.URL,
+		"activeDirectoryEndpoint":   api.URL + "/",
+		"serviceManagementEndpoint": api.URL + "/",
+	})
+	require.NoError(t, err)
+
+	envFile := filepath.Join(t.TempDir(), "environment.json")
+	require.NoError(t, os.WriteFile(envFile, env, 0600))
+	t.Setenv("AZURE_ENVIRONMENT_FILEPATH", envFile)
+
+	provider, err := azuredns.NewDNSProviderCredentials("AzureStackCloud", "client-id", "client-secret", testSubscription, testTenant, testResourceGroup, "", []string{nameserver}, false, nil, "")
+	require.NoError(t, err)
+	return provider
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	ctx := logf.NewContext(context.TODO(), logtesting.NewTestLogger(t), t.Name())
+
+	// The nested zone is listed first so that the DNS server answers with
+	// the most specific zone, as an authoritative nameserver would.
+	server := &testserver.BasicServer{
+		Zones: []string{"sub.example.com.", "example.com.", "unknown.com."},
+	}
+	require.NoError(t, server.Run(ctx))
+	defer func() {
+		require.NoError(t, server.Shutdown())
+	}()
+
+	arm := &armServer{
+		zones:   map[string]bool{"example.com": true, "sub.example.com": true},
+		records: map[string][]string{},
+	}
+	api := httptest.NewServer(arm)
+	defer api.Close()
+
+	tests := map[string]struct {
+		fqdn       string
+		recordName string
+		expErr     bool
+	}{
+		"a name in the parent zone is created in the parent zone": {
+			fqdn:       "_acme-challenge.www.example.com.",
+			recordName: "_acme-challenge.www.example.com",
+		},
+		"a name in a delegated zone is created in the delegated zone": {
+			fqdn:       "_acme-challenge.www.sub.example.com.",
+			recordName: "_acme-challenge.www.sub.example.com",
+		},
+		"a name in a zone which does not exist in Azure DNS fails": {
+			fqdn:   "_acme-challenge.www.unknown.com.",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider := newProvider(t, api, server.ListenAddr())
+
+			err := provider.Present("", test.fqdn, "value")
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"value"}, arm.record(test.recordName))
+
+			require.NoError(t, provider.CleanUp("", test.fqdn, "value"))
+			assert.Empty(t, arm.record(test.recordName))
+
+			// Cleaning up a record which has already been removed succeeds.
+			assert.NoError(t, provider.CleanUp("", test.fqdn, "value"))
+		})
+	}
+}
@@ -72,7 +72,7 @@ func TestIssuingController(t *testing.T) {
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
 		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, "cert-manage-certificates-issuing-test")
+		controllerOptions, "cert-manage-certificates-issuing-test", "")
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -278,7 +278,7 @@ func TestIssuingController_PKCS8_PrivateKey(t *testing.T) {
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
 		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, "cert-manage-certificates-issuing-test")
+		controllerOptions, "cert-manage-certificates-issuing-test", "")
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -493,7 +493,7 @@ func Test_IssuingController_SecretTemplate(t *testing.T) {
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
 		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, "cert-manage-certificates-issuing-test")
+		controllerOptions, "cert-manage-certificates-issuing-test", "")
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -726,7 +726,7 @@ func Test_IssuingController_AdditionalOutputFormats(t *testing.T) {
 		EnableOwnerRef: true,
 	}
 
-	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{}, controllerOptions, "cert-manager-issuing-test")
+	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{}, controllerOptions, "cert-manager-issuing-test", "")
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -951,7 +951,7 @@ func Test_IssuingController_OwnerRefernece(t *testing.T) {
 	}
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmClient,
 		factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, fieldManager,
+		controllerOptions, fieldManager, "",
 	)
 	c := controllerpkg.NewController(ctx, fieldManager, metrics.New(logf.Log, clock.RealClock{}), ctrl.ProcessItem, mustSync, nil, queue)
 	stopControllerNoOwnerRef := framework.StartInformersAndController(t, factory, cmFactory, c)
@@ -1038,7 +1038,7 @@ func Test_IssuingController_OwnerRefernece(t *testing.T) {
 	controllerOptions.EnableOwnerRef = true
 	ctrl, queue, mustSync = issuing.NewController(logf.Log, kubeClient, cmClient,
 		factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, fieldManager,
+		controllerOptions, fieldManager, "",
 	)
 	c = controllerpkg.NewController(ctx, fieldManager, metrics.New(logf.Log, clock.RealClock{}), ctrl.ProcessItem, mustSync, nil, queue)
 	stopControllerOwnerRef := framework.StartInformersAndController(t, factory, cmFactory, c)
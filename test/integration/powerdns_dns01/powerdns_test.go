@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package powerdns runs the RFC2136 provider against a local PowerDNS
+// authoritative server. The tests are skipped unless PDNS_NAMESERVER is set to
+// the address of the server, e.g. by running 'make dns01-provider-test'.
+//
+// The server must serve the zone named by PDNS_ZONE (default 'example.com.')
+// and accept dynamic updates for it, signed with the TSIG key given by
+// PDNS_TSIG_KEY_NAME and PDNS_TSIG_SECRET if they are set.
+package powerdns
+
+import (
+	"os"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/rfc2136"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// txtRecords returns the values of the TXT records named fqdn served by the
+// nameserver.
+func txtRecords(t *testing.T, nameserver, fqdn string) []string {
+	in, err := util.DNSQuery(fqdn, dns.TypeTXT, []string{nameserver}, false)
+	require.NoError(t, err)
+
+	var values []string
+	for _, rr := range in.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			values = append(values, txt.Txt...)
+		}
+	}
+	return values
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	nameserver := os.Getenv("PDNS_NAMESERVER")
+	if nameserver == "" {
+		t.Skip("PDNS_NAMESERVER is not set")
+	}
+	zone := os.Getenv("PDNS_ZONE")
+	if zone == "" {
+		zone = "example.com."
+	}
+	zone = util.ToFqdn(zone)
+
+	provider, err := rfc2136.NewDNSProviderCredentials(nameserver, os.Getenv("PDNS_TSIG_ALGORITHM"), os.Getenv("PDNS_TSIG_KEY_NAME"), os.Getenv("PDNS_TSIG_SECRET"))
+	require.NoError(t, err)
+
+	fqdn := "_acme-challenge.www." + zone
+
+	// The zone is detected using the SOA records served by PowerDNS.
+	detected, err := util.FindZoneByFqdn(fqdn, []string{nameserver})
+	require.NoError(t, err)
+	require.Equal(t, zone, detected)
+
+	require.NoError(t, provider.Present("", fqdn, detected, "first"))
+	require.NoError(t, provider.Present("", fqdn, detected, "second"))
+	assert.ElementsMatch(t, []string{"first", "second"}, txtRecords(t, nameserver, fqdn))
+
+	// Only the record with the given value is removed.
+	require.NoError(t, provider.CleanUp("", fqdn, detected, "first"))
+	assert.Equal(t, []string{"second"}, txtRecords(t, nameserver, fqdn))
+
+	require.NoError(t, provider.CleanUp("", fqdn, detected, "second"))
+	assert.Empty(t, txtRecords(t, nameserver, fqdn))
+
+	// Cleaning up a record which has already been removed succeeds.
+	assert.NoError(t, provider.CleanUp("", fqdn, detected, "second"))
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package route53 runs the Route 53 provider against a Route 53 emulator
+// such as localstack. The tests are skipped unless ROUTE53_ENDPOINT is set
+// to the URL of the emulator, e.g. by running 'make dns01-provider-test'.
+package route53
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awsroute53 "github.com/aws/aws-sdk-go/service/route53"
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	testserver "github.com/cert-manager/cert-manager/test/acme/dns/server"
+)
+
+const (
+	testAccessKeyID     = "test"
+	testSecretAccessKey = "test"
+	testRegion          = "us-east-1"
+)
+
+// createHostedZone creates a hosted zone in the emulator, which is deleted
+// when the test completes, and returns its ID.
+func createHostedZone(t *testing.T, client *awsroute53.Route53, name string) string {
+	resp, err := client.CreateHostedZone(&awsroute53.CreateHostedZoneInput{
+		Name:            aws.String(name),
+		CallerReference: aws.String(fmt.Sprintf("%s-%d", name, time.Now().UnixNano())),
+	})
+	require.NoError(t, err)
+
+	id := *resp.HostedZone.Id
+	t.Cleanup(func() {
+		if _, err := client.DeleteHostedZone(&awsroute53.DeleteHostedZoneInput{Id: aws.String(id)}); err != nil {
+			t.Logf("failed to delete hosted zone %s: %v", name, err)
+		}
+	})
+	return id
+}
+
+// txtRecords returns the values of the TXT records named fqdn in the hosted
+// zone with the given ID.
+func txtRecords(t *testing.T, client *awsroute53.Route53, zoneID, fqdn string) []string {
+	resp, err := client.ListResourceRecordSets(&awsroute53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+	})
+	require.NoError(t, err)
+
+	var values []string
+	for _, rrs := range resp.ResourceRecordSets {
+		if *rrs.Type != awsroute53.RRTypeTxt || *rrs.Name != fqdn {
+			continue
+		}
+		for _, rr := range rrs.ResourceRecords {
+			values = append(values, *rr.Value)
+		}
+	}
+	return values
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	endpoint := os.Getenv("ROUTE53_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("ROUTE53_ENDPOINT is not set")
+	}
+	ctx := logf.NewContext(context.TODO(), logtesting.NewTestLogger(t), t.Name())
+
+	sess, err := session.NewSession(aws.NewConfig().
+		WithEndpoint(endpoint).
+		WithRegion(testRegion).
+		WithCredentials(credentials.NewStaticCredentials(testAccessKeyID, testSecretAccessKey, "")))
+	require.NoError(t, err)
+	client := awsroute53.New(sess)
+
+	// Zones are unique to each run so that hosted zones left behind by a
+	// previous run do not interfere with zone detection.
+	parent := fmt.Sprintf("run-%d.example.com.", time.Now().UnixNano())
+	child := "sub." + parent
+	zoneIDs := map[string]string{
+		parent: createHostedZone(t, client, parent),
+		child:  createHostedZone(t, client, child),
+	}
+
+	// The nested zone is listed first so that the DNS server answers with
+	// the most specific zone, as an authoritative nameserver would.
+	server := &testserver.BasicServer{
+		Zones: []string{child, parent},
+	}
+	require.NoError(t, server.Run(ctx))
+	defer func() {
+		require.NoError(t, server.Shutdown())
+	}()
+
+	tests := map[string]struct {
+		fqdn string
+		zone string
+	}{
+		"a name in the parent zone is created in the parent zone": {
+			fqdn: "_acme-challenge.www." + parent,
+			zone: parent,
+		},
+		"a name in a delegated zone is created in the delegated zone": {
+			fqdn: "_acme-challenge.www." + child,
+			zone: child,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider, err := route53.NewDNSProvider(testAccessKeyID, testSecretAccessKey, "", testRegion, nil, false, []string{server.ListenAddr()}, "cert-manager-test")
+			require.NoError(t, err)
+			provider.SetEndpoint(endpoint)
+
+			require.NoError(t, provider.Present("", test.fqdn, "value"))
+			assert.Equal(t, []string{`"value"`}, txtRecords(t, client, zoneIDs[test.zone], test.fqdn))
+			for zone, id := range zoneIDs {
+				if zone != test.zone {
+					assert.Empty(t, txtRecords(t, client, id, test.fqdn), "record created in zone %s", zone)
+				}
+			}
+
+			require.NoError(t, provider.CleanUp("", test.fqdn, "value"))
+			assert.Empty(t, txtRecords(t, client, zoneIDs[test.zone], test.fqdn))
+
+			// Cleaning up a record which has already been removed succeeds.
+			assert.NoError(t, provider.CleanUp("", test.fqdn, "value"))
+		})
+	}
+}
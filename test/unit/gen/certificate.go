@@ -110,6 +110,15 @@ func SetCertificateKeyEncoding(keyEncoding v1.PrivateKeyEncoding) CertificateMod
 	}
 }
 
+// SetCertificatePrivateKeyRotationSchedule sets the Scheduled rotation policy
+// with the given schedule on the Certificate's private key.
+func SetCertificatePrivateKeyRotationSchedule(schedule v1.PrivateKeyRotationSchedule) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.PrivateKey.RotationPolicy = v1.RotationPolicyScheduled
+		crt.Spec.PrivateKey.RotationSchedule = &schedule
+	}
+}
+
 func SetCertificateSecretName(secretName string) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.SecretName = secretName
@@ -165,12 +174,31 @@ func SetCertificateLastFailureTime(p metav1.Time) CertificateModifier {
 		crt.Status.LastFailureTime = &p
 	}
 }
+
+func SetCertificateLastSuccessTime(p metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.LastSuccessTime = &p
+	}
+}
+
+func SetCertificateLastError(lastError string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.LastError = lastError
+	}
+}
+
 func SetCertificateIssuanceAttempts(ia *int) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Status.FailedIssuanceAttempts = ia
 	}
 }
 
+func SetCertificateLastIssuanceRequest(request string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.LastIssuanceRequest = request
+	}
+}
+
 func SetCertificateNotAfter(p metav1.Time) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Status.NotAfter = &p
@@ -189,6 +217,12 @@ func SetCertificateRenewalTime(p metav1.Time) CertificateModifier {
 	}
 }
 
+func SetCertificatePrivateKeyRotationTime(p metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.PrivateKeyRotationTime = &p
+	}
+}
+
 func SetCertificateOrganization(orgs ...string) CertificateModifier {
 	return func(ch *v1.Certificate) {
 		ch.Spec.Subject.Organizations = orgs
@@ -278,3 +312,33 @@ func SetCertificateAdditionalOutputFormats(additionalOutputFormats ...v1.Certifi
 		crt.Spec.AdditionalOutputFormats = additionalOutputFormats
 	}
 }
+
+func SetCertificateVerification(verification v1.CertificateVerification) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.Verification = &verification
+	}
+}
+
+func SetCertificateVerificationRevision(revision int) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.VerificationRevision = &revision
+	}
+}
+
+func SetCertificateRenewalDisabled(renewalDisabled bool) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.RenewalDisabled = renewalDisabled
+	}
+}
+
+func SetCertificateObserveOnly(observeOnly bool) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.ObserveOnly = observeOnly
+	}
+}
+
+func SetCertificateOCSPStapling(ocspStapling v1.CertificateOCSPStapling) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.OCSPStapling = &ocspStapling
+	}
+}
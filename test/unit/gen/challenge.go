@@ -91,12 +91,24 @@ func SetChallengeState(s cmacme.State) ChallengeModifier {
 	}
 }
 
+func SetChallengeStep(s cmacme.ChallengeStep) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Status.Step = s
+	}
+}
+
 func SetChallengeReason(s string) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Status.Reason = s
 	}
 }
 
+func SetChallengeProblem(p *cmacme.ACMEProblem) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Status.Problem = p
+	}
+}
+
 func SetChallengeURL(s string) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Spec.URL = s
@@ -109,6 +121,30 @@ func SetChallengeProcessing(b bool) ChallengeModifier {
 	}
 }
 
+func SetChallengeStatusCondition(c cmacme.ChallengeCondition) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		for i, existingC := range ch.Status.Conditions {
+			if existingC.Type == c.Type {
+				ch.Status.Conditions[i] = c
+				return
+			}
+		}
+		ch.Status.Conditions = append(ch.Status.Conditions, c)
+	}
+}
+
+func SetChallengeFailedCleanUpAttempts(n int) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Status.FailedCleanUpAttempts = n
+	}
+}
+
+func SetChallengeAnnotations(annotations map[string]string) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Annotations = annotations
+	}
+}
+
 func SetChallengeFinalizers(finalizers []string) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Finalizers = finalizers
@@ -126,3 +162,21 @@ func ResetChallengeStatus() ChallengeModifier {
 		ch.Status = cmacme.ChallengeStatus{}
 	}
 }
+
+func SetChallengeOwnerReference(ref metav1.OwnerReference) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.OwnerReferences = []metav1.OwnerReference{ref}
+	}
+}
+
+func SetChallengePresentedTime(t metav1.Time) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Status.PresentedTime = &t
+	}
+}
+
+func SetChallengeSolver(s cmacme.ACMEChallengeSolver) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Spec.Solver = s
+	}
+}
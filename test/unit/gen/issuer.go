@@ -167,6 +167,16 @@ func SetIssuerACMEDisableAccountKeyGeneration(disabled bool) IssuerModifier {
 	}
 }
 
+func SetIssuerACMEOnlyReturnExistingAccount(enabled bool) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.ACME == nil {
+			spec.ACME = &cmacme.ACMEIssuer{}
+		}
+		spec.ACME.OnlyReturnExistingAccount = enabled
+	}
+}
+
 func SetIssuerACMEEAB(keyID, secretName string) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		spec := iss.GetSpec()
@@ -226,6 +236,62 @@ func SetIssuerACMELastRegisteredEmail(email string) IssuerModifier {
 	}
 }
 
+func SetIssuerACMELastPrivateKeyHash(hash string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		status := iss.GetStatus()
+		if status.ACME == nil {
+			status.ACME = &cmacme.ACMEIssuerStatus{}
+		}
+		status.ACME.LastPrivateKeyHash = hash
+	}
+}
+
+func SetIssuerACMEPreAuthorizedDNSNames(dnsNames ...string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.ACME == nil {
+			spec.ACME = &cmacme.ACMEIssuer{}
+		}
+		spec.ACME.PreAuthorizedDNSNames = dnsNames
+	}
+}
+
+func SetIssuerACMEPreAuthorizations(preAuthzs ...cmacme.ACMEPreAuthorization) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		status := iss.GetStatus()
+		if status.ACME == nil {
+			status.ACME = &cmacme.ACMEIssuerStatus{}
+		}
+		status.ACME.PreAuthorizations = preAuthzs
+	}
+}
+
+func SetIssuerACMERateLimitBudget(budget cmacme.ACMERateLimitBudget) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.ACME == nil {
+			spec.ACME = &cmacme.ACMEIssuer{}
+		}
+		spec.ACME.RateLimitBudget = &budget
+	}
+}
+
+func SetIssuerACMERateLimitBudgets(budgets ...cmacme.ACMERateLimitBudgetStatus) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		status := iss.GetStatus()
+		if status.ACME == nil {
+			status.ACME = &cmacme.ACMEIssuerStatus{}
+		}
+		status.ACME.RateLimitBudgets = budgets
+	}
+}
+
+func SetIssuerTLS(t v1.IssuerTLSConfig) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().TLS = &t
+	}
+}
+
 func SetIssuerCA(a v1.CAIssuer) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetSpec().CA = &a
@@ -334,6 +400,12 @@ func SetIssuerSelfSigned(a v1.SelfSignedIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerKubernetesCSR(a v1.KubernetesCSRIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().KubernetesCSR = &a
+	}
+}
+
 func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetSpec().Venafi = &a
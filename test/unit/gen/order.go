@@ -20,6 +20,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -129,3 +130,9 @@ func SetOrderOwnerReference(ref metav1.OwnerReference) OrderModifier {
 		order.OwnerReferences = []metav1.OwnerReference{ref}
 	}
 }
+
+func SetOrderUID(uid types.UID) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.UID = uid
+	}
+}